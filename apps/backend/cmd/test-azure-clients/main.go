@@ -76,7 +76,7 @@ func main() {
 }
 
 func testOpenAIClient(ctx context.Context, endpoint, apiKey, deployment string, logger *zap.Logger) error {
-	client, err := azure.NewOpenAIClient(endpoint, apiKey, deployment, logger)
+	client, err := azure.NewOpenAIClient(endpoint, azure.KeyAuth(apiKey), deployment, logger)
 	if err != nil {
 		return fmt.Errorf("failed to create OpenAI client: %w", err)
 	}
@@ -137,7 +137,7 @@ func testSpeechClient(ctx context.Context, subscriptionKey, region string, logge
 		logger.Info(fmt.Sprintf("Testing question %d/%d", i+1, len(testQuestions)), zap.String("question", question))
 
 		// Generate MP3 for listening
-		audioDataMP3, err := client.TextToSpeech(ctx, question, "hu-HU")
+		audioDataMP3, err := client.TextToSpeech(ctx, question, "hu-HU", azure.DefaultVoice)
 		if err != nil {
 			return fmt.Errorf("text-to-speech (MP3) failed for question %d: %w", i+1, err)
 		}
@@ -162,7 +162,7 @@ func testSpeechClient(ctx context.Context, subscriptionKey, region string, logge
 
 	// Generate WAV format for speech-to-text (STT expects WAV, not MP3)
 	logger.Info("Generating WAV format audio for speech-to-text test")
-	audioDataWAV, err := client.TextToSpeechWAV(ctx, testText, "hu-HU")
+	audioDataWAV, err := client.TextToSpeechWAV(ctx, testText, "hu-HU", azure.DefaultVoice)
 	if err != nil {
 		logger.Warn("Text-to-speech (WAV) failed, skipping STT test", zap.Error(err))
 		return nil
@@ -206,7 +206,7 @@ func testSpeechClient(ctx context.Context, subscriptionKey, region string, logge
 func testBlobStorageClient(ctx context.Context, accountName, accountKey string, logger *zap.Logger) error {
 	// Test with audio-recordings container
 	containerName := "audio-recordings"
-	client, err := azure.NewBlobStorageClient(accountName, accountKey, containerName, logger)
+	client, err := azure.NewBlobStorageClient(accountName, azure.KeyAuth(accountKey), containerName, logger)
 	if err != nil {
 		return fmt.Errorf("failed to create Blob Storage client: %w", err)
 	}
@@ -241,7 +241,7 @@ func testBlobStorageClient(ctx context.Context, accountName, accountKey string,
 	)
 
 	// Test PDF operations with health-reports container
-	pdfClient, err := azure.NewBlobStorageClient(accountName, accountKey, "health-reports", logger)
+	pdfClient, err := azure.NewBlobStorageClient(accountName, azure.KeyAuth(accountKey), "health-reports", logger)
 	if err != nil {
 		return fmt.Errorf("failed to create PDF Blob Storage client: %w", err)
 	}
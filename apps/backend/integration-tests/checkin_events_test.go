@@ -0,0 +1,175 @@
+package integration_tests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/handler"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"go.uber.org/zap"
+)
+
+// TestCheckInEventStreamIntegration follows a session's live event stream while the
+// check-in flow is driven through the API
+func TestCheckInEventStreamIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	db, cleanup := setupTestDatabase(t, ctx)
+	defer cleanup()
+
+	azureClients := setupAzureClients(t, logger)
+
+	checkInRepo := repository.NewCheckInRepository(db, logger)
+	checkInService := service.NewCheckInService(
+		checkInRepo,
+		azureClients.OpenAI,
+		azureClients.Speech,
+		azureClients.Blob,
+		logger,
+	)
+	checkInService.SetEventBus(service.NewSessionEventBus(1, logger))
+
+	checkInHandler := handler.NewCheckInHandler(checkInService, logger)
+	checkInHandler.SetEventKeepAlive(50 * time.Millisecond)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	registerCheckInRoutes(router, checkInHandler)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	userID := uuid.New()
+
+	t.Run("Stream follows the session until it completes", func(t *testing.T) {
+		sessionID, _ := startCheckInSession(t, router, userID)
+
+		events, keepAlives := openEventStream(t, server.URL, sessionID, "")
+
+		// One stream per user in this setup
+		resp, err := http.Get(server.URL + "/api/v1/checkin/" + sessionID + "/events")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+		isComplete := answerQuestion(t, router, sessionID, "Jól érzem magam ma.")
+
+		event := nextSessionEvent(t, events)
+		assert.Equal(t, service.SessionEventMessageSaved, event.Type)
+		assert.Equal(t, "Jól érzem magam ma.", event.Text)
+		if !isComplete {
+			event = nextSessionEvent(t, events)
+			assert.Equal(t, service.SessionEventQuestionAsked, event.Type)
+			assert.NotEmpty(t, event.QuestionID)
+		}
+
+		// Keep-alives flow while the session is idle and the stream stays open
+		time.Sleep(200 * time.Millisecond)
+
+		for i := 0; !isComplete && i < 20; i++ {
+			isComplete = answerQuestion(t, router, sessionID, "Igen, minden rendben.")
+		}
+		require.True(t, isComplete, "Session should be complete after answering all questions")
+
+		completeCheckInSession(t, router, sessionID)
+
+		var last service.SessionEvent
+		for event := range events {
+			last = event
+		}
+		assert.Equal(t, service.SessionEventCompleted, last.Type)
+		assert.Equal(t, sessionID, last.SessionID)
+		assert.NotEmpty(t, last.CheckInID)
+		assert.Greater(t, *keepAlives, 0, "Keep-alive lines should have been sent")
+	})
+
+	t.Run("Stream of an ended session closes right away", func(t *testing.T) {
+		sessionID, _ := startCheckInSession(t, router, userID)
+		isComplete := false
+		for i := 0; !isComplete && i < 20; i++ {
+			isComplete = answerQuestion(t, router, sessionID, "Igen, minden rendben.")
+		}
+		completeCheckInSession(t, router, sessionID)
+
+		events, _ := openEventStream(t, server.URL, sessionID, "text/event-stream")
+
+		event := nextSessionEvent(t, events)
+		assert.Equal(t, service.SessionEventCompleted, event.Type)
+		_, open := <-events
+		assert.False(t, open, "Stream should end after the terminal event")
+	})
+}
+
+// openEventStream opens a session's event stream and returns its decoded events and a
+// counter of the keep-alives received. The channel closes when the server ends the stream.
+func openEventStream(t *testing.T, baseURL, sessionID, accept string) (<-chan service.SessionEvent, *int) {
+	req, err := http.NewRequest(http.MethodGet, baseURL+"/api/v1/checkin/"+sessionID+"/events", nil)
+	require.NoError(t, err)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	t.Cleanup(func() { resp.Body.Close() })
+
+	events := make(chan service.SessionEvent, 64)
+	keepAlives := new(int)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if accept == "text/event-stream" {
+				if !strings.HasPrefix(line, "data: ") {
+					continue
+				}
+				line = strings.TrimPrefix(line, "data: ")
+			}
+			if line == "" {
+				continue
+			}
+
+			var event service.SessionEvent
+			if err := json.Unmarshal([]byte(line), &event); err != nil {
+				return
+			}
+			if event.Type == "keep_alive" {
+				*keepAlives++
+				continue
+			}
+			events <- event
+		}
+	}()
+
+	return events, keepAlives
+}
+
+// nextSessionEvent waits for the next event of a stream
+func nextSessionEvent(t *testing.T, events <-chan service.SessionEvent) service.SessionEvent {
+	select {
+	case event, ok := <-events:
+		require.True(t, ok, "Stream ended unexpectedly")
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for a session event")
+		return service.SessionEvent{}
+	}
+}
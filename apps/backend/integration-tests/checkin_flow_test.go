@@ -21,6 +21,7 @@ import (
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 	"go.uber.org/zap"
 )
 
@@ -234,9 +235,9 @@ func verifyExtractedData(t *testing.T, checkIn *api.HealthCheckInResponse) {
 	// Verify energy level is valid enum value
 	if checkIn.EnergyLevel != nil {
 		validEnergyLevels := []api.HealthCheckInResponseEnergyLevel{
-			api.Low,
-			api.Medium,
-			api.High,
+			api.HealthCheckInResponseEnergyLevelLow,
+			api.HealthCheckInResponseEnergyLevelMedium,
+			api.HealthCheckInResponseEnergyLevelHigh,
 		}
 		assert.Contains(t, validEnergyLevels, *checkIn.EnergyLevel, "Energy level should be a valid enum value")
 	}
@@ -257,7 +258,7 @@ func verifyExtractedData(t *testing.T, checkIn *api.HealthCheckInResponse) {
 		validMedicationTaken := []api.HealthCheckInResponseMedicationTaken{
 			api.Yes,
 			api.No,
-			api.Partial,
+			api.HealthCheckInResponseMedicationTaken(model.MedicationTakenSome),
 		}
 		assert.Contains(t, validMedicationTaken, *checkIn.MedicationTaken, "Medication taken should be a valid enum value")
 	}
@@ -331,7 +332,7 @@ func verifyDataPersistence(t *testing.T, ctx context.Context, repo *repository.C
 func testAudioStreaming(t *testing.T, router *gin.Engine, sessionID string, speechClient *azure.SpeechServiceClient) {
 	// Generate test audio using Text-to-Speech
 	testText := "Ez egy teszt válasz."
-	audioData, err := speechClient.TextToSpeechWAV(context.Background(), testText, "hu-HU")
+	audioData, err := speechClient.TextToSpeechWAV(context.Background(), testText, "hu-HU", "")
 	require.NoError(t, err, "Should be able to generate test audio")
 	require.Greater(t, len(audioData), 0, "Audio data should not be empty")
 
@@ -445,13 +446,13 @@ func setupAzureClients(t *testing.T, logger *zap.Logger) *AzureClients {
 	require.NotEmpty(t, storageContainer, "AZURE_STORAGE_CONTAINER is required")
 
 	// Initialize clients
-	openAIClient, err := azure.NewOpenAIClient(openAIEndpoint, openAIKey, openAIDeployment, logger)
+	openAIClient, err := azure.NewOpenAIClient(openAIEndpoint, azure.KeyAuth(openAIKey), openAIDeployment, logger)
 	require.NoError(t, err, "Should be able to create OpenAI client")
 
 	speechClient, err := azure.NewSpeechServiceClient(speechKey, speechRegion, logger)
 	require.NoError(t, err, "Should be able to create Speech Service client")
 
-	blobClient, err := azure.NewBlobStorageClient(storageAccountName, storageAccountKey, storageContainer, logger)
+	blobClient, err := azure.NewBlobStorageClient(storageAccountName, azure.KeyAuth(storageAccountKey), storageContainer, logger)
 	require.NoError(t, err, "Should be able to create Blob Storage client")
 
 	return &AzureClients{
@@ -500,7 +501,7 @@ func setupMockAzureClients(t *testing.T, logger *zap.Logger) *AzureClients {
 	}))
 	t.Cleanup(mockOpenAIServer.Close)
 
-	openAIClient, err := azure.NewOpenAIClient(mockOpenAIServer.URL, "test-key", "test-deployment", logger)
+	openAIClient, err := azure.NewOpenAIClient(mockOpenAIServer.URL, azure.KeyAuth("test-key"), "test-deployment", logger)
 	require.NoError(t, err)
 
 	// Create mock Speech Service client with test server
@@ -532,7 +533,7 @@ func setupMockAzureClients(t *testing.T, logger *zap.Logger) *AzureClients {
 	// Note: For now, we create a nil BlobStorageClient since the mock doesn't match the interface
 	// In production, you should refactor the service to use an interface
 	// For this test, we'll skip blob operations or use a real client
-	blobClient, _ := azure.NewBlobStorageClient("test", "dGVzdA==", "test-container", logger)
+	blobClient, _ := azure.NewBlobStorageClient("test", azure.KeyAuth("dGVzdA=="), "test-container", logger)
 
 	return &AzureClients{
 		OpenAI:   openAIClient,
@@ -561,6 +562,7 @@ func registerCheckInRoutes(router *gin.Engine, handler *handler.CheckInHandler)
 				})
 			})
 			checkin.POST("/respond", handler.PostApiV1CheckinRespond)
+			checkin.GET("/:sessionId/events", handler.GetCheckinEvents)
 			checkin.GET("/status/:sessionId", func(c *gin.Context) {
 				sessionIDStr := c.Param("sessionId")
 				sessionID, err := uuid.Parse(sessionIDStr)
@@ -144,65 +144,65 @@ func TestHealthDataTrackingIntegration(t *testing.T) {
 		syncFitnessData(t, router, userID, []api.FitnessDataPoint{
 			{
 				Date:         types.Date{Time: time.Now()},
-				DataType:     api.Steps,
+				DataType:     api.FitnessDataPointDataTypeSteps,
 				Value:        10000,
-				Unit:         api.Count,
-				Source:       api.HealthConnect,
+				Unit:         api.FitnessDataPointUnitCount,
+				Source:       api.FitnessDataPointSourceHealthConnect,
 				SourceDataId: "steps-001",
 			},
 			{
 				Date:         types.Date{Time: time.Now()},
-				DataType:     api.HeartRate,
+				DataType:     api.FitnessDataPointDataTypeHeartRate,
 				Value:        72,
-				Unit:         api.Bpm,
-				Source:       api.HealthConnect,
+				Unit:         api.FitnessDataPointUnitBpm,
+				Source:       api.FitnessDataPointSourceHealthConnect,
 				SourceDataId: "hr-001",
 			},
 			{
 				Date:         types.Date{Time: time.Now()},
-				DataType:     api.Calories,
+				DataType:     api.FitnessDataPointDataTypeCalories,
 				Value:        2000,
-				Unit:         api.Kcal,
-				Source:       api.HealthConnect,
+				Unit:         api.FitnessDataPointUnitKcal,
+				Source:       api.FitnessDataPointSourceHealthConnect,
 				SourceDataId: "cal-001",
 			},
 		})
 
 		// Step 2: Verify data persistence
 		t.Log("Step 2: Verifying fitness data persistence")
-		verifyFitnessDataPersistence(t, ctx, healthRepo, userID.String(), 3)
+		verifyFitnessDataPersistence(t, router, userID, 3)
 
 		// Step 3: Test deduplication - sync same data again
 		t.Log("Step 3: Testing fitness data deduplication")
 		syncFitnessData(t, router, userID, []api.FitnessDataPoint{
 			{
 				Date:         types.Date{Time: time.Now()},
-				DataType:     api.Steps,
+				DataType:     api.FitnessDataPointDataTypeSteps,
 				Value:        10000,
-				Unit:         api.Count,
-				Source:       api.HealthConnect,
+				Unit:         api.FitnessDataPointUnitCount,
+				Source:       api.FitnessDataPointSourceHealthConnect,
 				SourceDataId: "steps-001", // Same source_data_id
 			},
 		})
 
 		// Should still have only 3 records (deduplication worked)
-		verifyFitnessDataPersistence(t, ctx, healthRepo, userID.String(), 3)
+		verifyFitnessDataPersistence(t, router, userID, 3)
 
 		// Step 4: Sync new fitness data
 		t.Log("Step 4: Syncing additional fitness data")
 		syncFitnessData(t, router, userID, []api.FitnessDataPoint{
 			{
 				Date:         types.Date{Time: time.Now().AddDate(0, 0, -1)},
-				DataType:     api.Distance,
+				DataType:     api.FitnessDataPointDataTypeDistance,
 				Value:        5000,
-				Unit:         api.Meters,
-				Source:       api.HealthConnect,
+				Unit:         api.FitnessDataPointUnitMeters,
+				Source:       api.FitnessDataPointSourceHealthConnect,
 				SourceDataId: "dist-001",
 			},
 		})
 
 		// Should now have 4 records
-		verifyFitnessDataPersistence(t, ctx, healthRepo, userID.String(), 4)
+		verifyFitnessDataPersistence(t, router, userID, 4)
 
 		// Cleanup
 		cleanupFitnessDataDirect(t, ctx, db, userID.String())
@@ -227,18 +227,18 @@ func TestHealthDataTrackingIntegration(t *testing.T) {
 		syncFitnessData(t, router, userID, []api.FitnessDataPoint{
 			{
 				Date:         types.Date{Time: time.Now().AddDate(0, 0, -1)},
-				DataType:     api.Steps,
+				DataType:     api.FitnessDataPointDataTypeSteps,
 				Value:        8000,
-				Unit:         api.Count,
-				Source:       api.HealthConnect,
+				Unit:         api.FitnessDataPointUnitCount,
+				Source:       api.FitnessDataPointSourceHealthConnect,
 				SourceDataId: "steps-recent",
 			},
 			{
 				Date:         types.Date{Time: time.Now().AddDate(0, 0, -15)},
-				DataType:     api.Steps,
+				DataType:     api.FitnessDataPointDataTypeSteps,
 				Value:        12000,
-				Unit:         api.Count,
-				Source:       api.HealthConnect,
+				Unit:         api.FitnessDataPointUnitCount,
+				Source:       api.FitnessDataPointSourceHealthConnect,
 				SourceDataId: "steps-old",
 			},
 		})
@@ -251,9 +251,8 @@ func TestHealthDataTrackingIntegration(t *testing.T) {
 		bloodPressureReadings := getBloodPressureHistory(t, router, userID)
 		assert.Len(t, bloodPressureReadings, 2, "Should have 2 blood pressure readings")
 
-		// Note: Skipping fitness data verification due to test environment timing issue
-		// The API layer is working correctly as verified in the fitness sync test
-		t.Log("Fitness data verification skipped due to test environment timing issue")
+		fitnessData := getFitnessData(t, router, userID)
+		assert.Len(t, fitnessData, 2, "Should have 2 fitness data points")
 
 		// Cleanup
 		cleanupAllHealthDataDirect(t, ctx, db, userID.String())
@@ -305,11 +304,14 @@ func getMenstruationHistory(t *testing.T, router *gin.Engine, userID uuid.UUID)
 
 	assert.Equal(t, http.StatusOK, w.Code, "Get menstruation history should return 200 OK")
 
-	var response []api.MenstruationResponse
+	var response struct {
+		Items      []api.MenstruationResponse `json:"items"`
+		TotalCount int                        `json:"total_count"`
+	}
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	require.NoError(t, err, "Should be able to parse response")
 
-	return response
+	return response.Items
 }
 
 // testInvalidFlowIntensity tests that invalid flow intensity values are rejected
@@ -378,11 +380,14 @@ func getBloodPressureHistory(t *testing.T, router *gin.Engine, userID uuid.UUID)
 
 	assert.Equal(t, http.StatusOK, w.Code, "Get blood pressure history should return 200 OK")
 
-	var response []api.BloodPressureResponse
+	var response struct {
+		Items      []api.BloodPressureResponse `json:"items"`
+		TotalCount int                         `json:"total_count"`
+	}
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	require.NoError(t, err, "Should be able to parse response")
 
-	return response
+	return response.Items
 }
 
 // testInvalidBloodPressure tests that invalid blood pressure values are rejected
@@ -468,26 +473,30 @@ func syncFitnessData(t *testing.T, router *gin.Engine, userID uuid.UUID, dataPoi
 	assert.Equal(t, http.StatusOK, w.Code, "Sync fitness data should return 200 OK")
 }
 
-// verifyFitnessDataPersistence verifies that fitness data is correctly stored in the database
-func verifyFitnessDataPersistence(t *testing.T, ctx context.Context, repo *repository.HealthDataRepository, userID string, expectedCount int) {
-	// Note: There appears to be an issue with querying fitness data immediately after sync
-	// The API returns success and the data is being processed, but the query doesn't return results
-	// This might be a transaction/commit timing issue in the test environment
-	// For now, we skip the verification as the API layer is working correctly
-	t.Skip("Skipping fitness data persistence verification due to test environment timing issue")
+// verifyFitnessDataPersistence verifies through the API that the user's fitness data of the
+// last 30 days is stored and readable right after syncing
+func verifyFitnessDataPersistence(t *testing.T, router *gin.Engine, userID uuid.UUID, expectedCount int) {
+	dataPoints := getFitnessData(t, router, userID)
+	assert.Len(t, dataPoints, expectedCount, "Synced fitness data should be readable immediately")
 }
 
-// getFitnessDataInRange retrieves fitness data within a date range
-func getFitnessDataInRange(t *testing.T, ctx context.Context, repo *repository.HealthDataRepository, userID string, startDate, endDate time.Time) []interface{} {
-	dataPoints, err := repo.GetFitnessDataByUserID(ctx, userID, startDate, endDate)
-	require.NoError(t, err, "Should be able to retrieve fitness data")
+// getFitnessData retrieves the user's fitness data of the last 30 days
+func getFitnessData(t *testing.T, router *gin.Engine, userID uuid.UUID) []api.FitnessDataPoint {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/health/fitness?user_id="+userID.String(), nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
 
-	// Convert to interface{} slice for generic return
-	result := make([]interface{}, len(dataPoints))
-	for i := range dataPoints {
-		result[i] = dataPoints[i]
+	require.Equal(t, http.StatusOK, w.Code, "Get fitness data should return 200 OK")
+
+	var response struct {
+		Items      []api.FitnessDataPoint `json:"items"`
+		TotalCount int                    `json:"total_count"`
 	}
-	return result
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err, "Should be able to parse response")
+
+	return response.Items
 }
 
 // registerHealthRoutes registers health routes on the router
@@ -521,6 +530,7 @@ func registerHealthRoutes(router *gin.Engine, handler *handler.HealthHandler) {
 				})
 			})
 			health.POST("/fitness-sync", handler.PostApiV1HealthFitnessSync)
+			health.GET("/fitness", handler.GetFitnessData)
 		}
 	}
 }
@@ -45,6 +45,22 @@ func (m *MockBlobStorageClient) UploadPDF(ctx context.Context, filename string,
 	return blobPath, nil
 }
 
+// UploadFile stores a file in memory under blobPath
+func (m *MockBlobStorageClient) UploadFile(ctx context.Context, blobPath string, data []byte, contentType string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.storage[blobPath] = data
+
+	m.logger.Info("mock: uploaded file",
+		zap.String("blob_path", blobPath),
+		zap.String("content_type", contentType),
+		zap.Int("size", len(data)),
+	)
+
+	return blobPath, nil
+}
+
 // DownloadPDF retrieves a PDF from memory
 func (m *MockBlobStorageClient) DownloadPDF(ctx context.Context, blobPath string) ([]byte, error) {
 	m.mu.RLock()
@@ -63,6 +79,20 @@ func (m *MockBlobStorageClient) DownloadPDF(ctx context.Context, blobPath string
 	return data, nil
 }
 
+// DeletePDF removes a PDF from memory
+func (m *MockBlobStorageClient) DeletePDF(ctx context.Context, blobPath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.storage, blobPath)
+
+	m.logger.Info("mock: deleted PDF",
+		zap.String("blob_path", blobPath),
+	)
+
+	return nil
+}
+
 // UploadAudio stores audio in memory (not used in this test but required by interface)
 func (m *MockBlobStorageClient) UploadAudio(ctx context.Context, filename string, audioStream io.Reader) (string, error) {
 	m.mu.Lock()
@@ -92,3 +122,13 @@ func (m *MockBlobStorageClient) DownloadAudio(ctx context.Context, blobPath stri
 
 	return data, nil
 }
+
+// DeleteAudio removes audio from memory (not used in this test but required by interface)
+func (m *MockBlobStorageClient) DeleteAudio(ctx context.Context, blobPath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.storage, blobPath)
+
+	return nil
+}
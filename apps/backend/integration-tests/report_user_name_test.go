@@ -0,0 +1,111 @@
+package integration_tests
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/pdf"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// TestReportPrintsStoredUserName tests that generated reports print the name stored in
+// the users table and that reports of deleted users are rejected
+func TestReportPrintsStoredUserName(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	logger := zap.NewNop()
+	db, cleanup := setupTestDatabase(t, ctx)
+	defer cleanup()
+
+	dashboardRepo := repository.NewDashboardRepository(db, logger)
+	reportJobRepo := repository.NewReportJobRepository(db, logger)
+	reportService := service.NewReportService(
+		dashboardRepo,
+		repository.NewHealthDataRepository(db, logger),
+		repository.NewMedicationRepository(db, logger),
+		NewMockBlobStorageClient(logger),
+		pdf.NewPDFGenerator(logger),
+		logger,
+	)
+	reportService.SetJobStore(reportJobRepo, 10)
+	reportService.SetUserStore(repository.NewUserRepository(db, logger))
+
+	workerCtx, stopWorker := context.WithCancel(ctx)
+	worker := service.NewReportWorker(reportService, reportJobRepo, logger)
+	require.NoError(t, worker.Start(workerCtx, 1))
+	defer worker.Wait()
+	defer stopWorker()
+
+	userID := uuid.New().String()
+	name := "Anna Teszt"
+	_, err := db.Exec(ctx, "INSERT INTO users (id, name, email) VALUES ($1, $2, $3)",
+		userID, name, fmt.Sprintf("test-%s@example.com", userID))
+	require.NoError(t, err)
+	defer cleanupAllTestData(t, ctx, db, userID)
+	defer db.Exec(ctx, "DELETE FROM users WHERE id = $1", userID)
+
+	startDate := time.Now().AddDate(0, 0, -30)
+	endDate := time.Now()
+
+	t.Run("Stored name is printed", func(t *testing.T) {
+		queued, err := reportService.GenerateReport(ctx, userID, pdf.LanguageEnglish, model.ReportFormatPDF, nil, false, startDate, endDate)
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			status, err := reportService.GetReportJob(ctx, queued.JobID, userID)
+			require.NoError(t, err)
+			require.NotEqual(t, model.ReportStatusFailed, status.Status, status.Error)
+			return status.Status == model.ReportStatusCompleted
+		}, 30*time.Second, 100*time.Millisecond, "report should be generated")
+
+		file, err := reportService.GetReport(ctx, queued.ReportID)
+		require.NoError(t, err)
+		assert.True(t, pdfShowsText(file.Data, "Patient: "+name), "report should print the stored user name")
+	})
+
+	t.Run("Deleted user is rejected", func(t *testing.T) {
+		_, err := db.Exec(ctx, "UPDATE users SET deleted_at = NOW() WHERE id = $1", userID)
+		require.NoError(t, err)
+
+		_, err = reportService.GenerateReport(ctx, userID, pdf.LanguageEnglish, model.ReportFormatPDF, nil, false, startDate, endDate)
+		assert.ErrorIs(t, err, service.ErrReportUserDeleted)
+	})
+}
+
+// pdfShowsText reports whether one of the inflated content streams of a PDF prints
+// text. Reports use an embedded UTF-8 font, whose strings are written as UTF-16BE.
+func pdfShowsText(pdfBytes []byte, text string) bool {
+	var encoded []byte
+	for _, unit := range utf16.Encode([]rune(text)) {
+		encoded = append(encoded, byte(unit>>8), byte(unit))
+	}
+
+	streams := regexp.MustCompile(`(?s)stream\r?\n(.*?)\r?\nendstream`).FindAllSubmatch(pdfBytes, -1)
+	for _, stream := range streams {
+		r, err := zlib.NewReader(bytes.NewReader(stream[1]))
+		if err != nil {
+			continue
+		}
+		content, _ := io.ReadAll(r)
+		if bytes.Contains(content, encoded) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,8 +2,14 @@ package audit
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
@@ -16,33 +22,51 @@ const (
 	OperationUpdate OperationType = "UPDATE"
 	OperationDelete OperationType = "DELETE"
 	OperationRead   OperationType = "READ"
+
+	// OperationAnonymize replaces a user's identifiers instead of deleting their data
+	OperationAnonymize OperationType = "ANONYMIZE"
 )
 
 // ResourceType represents the type of resource being accessed
 type ResourceType string
 
 const (
-	ResourceHealthCheckIn     ResourceType = "health_check_in"
-	ResourceMedication        ResourceType = "medication"
-	ResourceMenstruationCycle ResourceType = "menstruation_cycle"
-	ResourceBloodPressure     ResourceType = "blood_pressure_reading"
-	ResourceFitnessData       ResourceType = "fitness_data"
-	ResourceReport            ResourceType = "report"
-	ResourceSession           ResourceType = "check_in_session"
-	ResourceUser              ResourceType = "user"
+	ResourceHealthCheckIn       ResourceType = "health_check_in"
+	ResourceMedication          ResourceType = "medication"
+	ResourceMenstruationCycle   ResourceType = "menstruation_cycle"
+	ResourceBloodPressure       ResourceType = "blood_pressure_reading"
+	ResourceFitnessData         ResourceType = "fitness_data"
+	ResourceReport              ResourceType = "report"
+	ResourceSession             ResourceType = "check_in_session"
+	ResourceUser                ResourceType = "user"
+	ResourceUserConsent         ResourceType = "user_consent"
+	ResourceGDPRConsent         ResourceType = "gdpr_consent"
+	ResourceQuestionSet         ResourceType = "question_set"
+	ResourceUserQuestionSet     ResourceType = "user_question_set"
+	ResourcePersonalAccessToken ResourceType = "personal_access_token"
+	ResourceWebhook             ResourceType = "webhook"
+	ResourceAuditArchive        ResourceType = "audit_archive"
+	ResourceDataRetention       ResourceType = "data_retention"
+
+	ResourceOrganization           ResourceType = "organization"
+	ResourceOrganizationRole       ResourceType = "organization_role"
+	ResourceOrganizationInvitation ResourceType = "organization_invitation"
+	ResourceIntegration            ResourceType = "organization_integration"
+	ResourceCareTeamConsent        ResourceType = "care_team_sharing_consent"
+	ResourcePanelAssignment        ResourceType = "clinician_patient_assignment"
 )
 
 // AuditLog represents an audit log entry
 type AuditLog struct {
-	ID             string
-	UserID         string
-	OperationType  OperationType
-	ResourceType   ResourceType
-	ResourceID     string
-	Timestamp      time.Time
-	IPAddress      string
-	UserAgent      string
-	AdditionalData map[string]interface{}
+	ID             string                 `json:"id"`
+	UserID         string                 `json:"user_id"`
+	OperationType  OperationType          `json:"operation_type"`
+	ResourceType   ResourceType           `json:"resource_type"`
+	ResourceID     string                 `json:"resource_id"`
+	Timestamp      time.Time              `json:"timestamp"`
+	IPAddress      string                 `json:"ip_address,omitempty"`
+	UserAgent      string                 `json:"user_agent,omitempty"`
+	AdditionalData map[string]interface{} `json:"additional_data,omitempty"`
 }
 
 // Logger handles audit logging
@@ -145,20 +169,147 @@ func (l *Logger) LogDelete(ctx context.Context, userID, resourceType, resourceID
 	})
 }
 
-// GetAuditLogs retrieves audit logs for a user
-func (l *Logger) GetAuditLogs(ctx context.Context, userID string, limit int) ([]AuditLog, error) {
-	query := `
-		SELECT user_id, operation_type, resource_type, resource_id, 
-		       timestamp, ip_address, user_agent
+// AuditFilter selects audit log entries. Zero-valued fields do not filter. Cursor is
+// the next cursor returned with the previous page and takes precedence over Offset.
+type AuditFilter struct {
+	UserID        string
+	OperationType OperationType
+	ResourceType  ResourceType
+	IPAddress     string
+	From          time.Time // inclusive
+	To            time.Time // exclusive
+	Cursor        string
+	Limit         int
+	Offset        int
+}
+
+const (
+	// DefaultAuditLogLimit is the page size used when a filter sets no limit
+	DefaultAuditLogLimit = 50
+	// MaxAuditLogLimit caps the page size of GetAuditLogs
+	MaxAuditLogLimit = 500
+)
+
+// ErrInvalidCursor is returned for a cursor that GetAuditLogs did not return
+var ErrInvalidCursor = errors.New("invalid audit log cursor")
+
+// where builds the WHERE clause of the filter and its arguments
+func (f AuditFilter) where() (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if f.UserID != "" {
+		add("user_id = $%d", f.UserID)
+	}
+	if f.OperationType != "" {
+		add("operation_type = $%d", f.OperationType)
+	}
+	if f.ResourceType != "" {
+		add("resource_type = $%d", f.ResourceType)
+	}
+	if f.IPAddress != "" {
+		add("ip_address = $%d", f.IPAddress)
+	}
+	if !f.From.IsZero() {
+		add("timestamp >= $%d", f.From)
+	}
+	if !f.To.IsZero() {
+		add("timestamp < $%d", f.To)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// GetAuditLogs retrieves one page of the audit logs matching filter, newest first, and
+// the cursor of the next page, empty on the last page. Unlike offsets, the cursor keeps
+// its place while new logs are written.
+func (l *Logger) GetAuditLogs(ctx context.Context, filter AuditFilter) ([]AuditLog, string, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultAuditLogLimit
+	}
+	limit = min(limit, MaxAuditLogLimit)
+	offset := max(filter.Offset, 0)
+
+	where, args := filter.where()
+	if filter.Cursor != "" {
+		timestamp, id, err := decodeAuditCursor(filter.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		args = append(args, timestamp, id)
+		after := fmt.Sprintf("(timestamp < $%d OR (timestamp = $%d AND id > $%d))", len(args)-1, len(args)-1, len(args))
+		if where == "" {
+			where = "WHERE " + after
+		} else {
+			where += " AND " + after
+		}
+		offset = 0
+	}
+
+	// One more row than the page tells whether there is a next page
+	query := fmt.Sprintf(`
+		SELECT id::text, user_id::text, operation_type, resource_type, resource_id::text,
+		       timestamp, COALESCE(ip_address, ''), COALESCE(user_agent, ''), additional_data
 		FROM audit_logs
-		WHERE user_id = $1
-		ORDER BY timestamp DESC
-		LIMIT $2
-	`
+		%s
+		ORDER BY timestamp DESC, id
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+
+	logs, err := l.queryAuditLogs(ctx, query, append(args, limit+1, offset)...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(logs) > limit {
+		logs = logs[:limit]
+		last := logs[limit-1]
+		next = encodeAuditCursor(last.Timestamp, last.ID)
+	}
+	return logs, next, nil
+}
+
+// encodeAuditCursor returns the opaque cursor of the position after the log with the
+// given timestamp and ID
+func encodeAuditCursor(timestamp time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(timestamp.UnixMicro(), 10) + "_" + id))
+}
+
+// decodeAuditCursor returns the timestamp and ID of a cursor created by encodeAuditCursor
+func decodeAuditCursor(cursor string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+	micros, id, ok := strings.Cut(string(raw), "_")
+	if !ok {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+	unixMicro, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+	return time.UnixMicro(unixMicro).UTC(), id, nil
+}
 
-	rows, err := l.db.Query(ctx, query, userID, limit)
+// queryAuditLogs runs a query selecting audit log columns and scans the rows
+func (l *Logger) queryAuditLogs(ctx context.Context, query string, args ...interface{}) ([]AuditLog, error) {
+	rows, err := l.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		l.logger.Error("Failed to query audit logs", zap.Error(err))
+		return nil, fmt.Errorf("failed to query audit logs: %w", err)
 	}
 	defer rows.Close()
 
@@ -166,6 +317,7 @@ func (l *Logger) GetAuditLogs(ctx context.Context, userID string, limit int) ([]
 	for rows.Next() {
 		var log AuditLog
 		err := rows.Scan(
+			&log.ID,
 			&log.UserID,
 			&log.OperationType,
 			&log.ResourceType,
@@ -173,6 +325,7 @@ func (l *Logger) GetAuditLogs(ctx context.Context, userID string, limit int) ([]
 			&log.Timestamp,
 			&log.IPAddress,
 			&log.UserAgent,
+			&log.AdditionalData,
 		)
 		if err != nil {
 			l.logger.Error("Failed to scan audit log", zap.Error(err))
@@ -181,5 +334,23 @@ func (l *Logger) GetAuditLogs(ctx context.Context, userID string, limit int) ([]
 		logs = append(logs, log)
 	}
 
+	if err := rows.Err(); err != nil {
+		l.logger.Error("Failed to iterate audit logs", zap.Error(err))
+		return nil, fmt.Errorf("failed to iterate audit logs: %w", err)
+	}
+
 	return logs, nil
 }
+
+// CountAuditLogs counts the audit logs matching filter, ignoring its page
+func (l *Logger) CountAuditLogs(ctx context.Context, filter AuditFilter) (int, error) {
+	where, args := filter.where()
+
+	var total int
+	if err := l.db.QueryRow(ctx, "SELECT COUNT(*) FROM audit_logs "+where, args...).Scan(&total); err != nil {
+		l.logger.Error("Failed to count audit logs", zap.Error(err))
+		return 0, fmt.Errorf("failed to count audit logs: %w", err)
+	}
+
+	return total, nil
+}
@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditFilter_Where(t *testing.T) {
+	where, args := AuditFilter{}.where()
+	assert.Empty(t, where)
+	assert.Empty(t, args)
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	where, args = AuditFilter{
+		OperationType: OperationDelete,
+		From:          from,
+		To:            to,
+		Limit:         10,
+	}.where()
+	assert.Equal(t, "WHERE operation_type = $1 AND timestamp >= $2 AND timestamp < $3", where)
+	assert.Equal(t, []interface{}{OperationDelete, from, to}, args, "values are passed as parameters")
+}
+
+func TestAuditFilter_WhereIgnoresPage(t *testing.T) {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	where, args := AuditFilter{
+		IPAddress: "203.0.113.7",
+		From:      start,
+		Cursor:    "ignored",
+		Limit:     10,
+		Offset:    20,
+	}.where()
+	assert.Equal(t, "WHERE ip_address = $1 AND timestamp >= $2", where)
+	assert.Equal(t, []interface{}{"203.0.113.7", start}, args)
+}
+
+func TestAuditCursor(t *testing.T) {
+	timestamp := time.Date(2024, 3, 1, 12, 30, 0, 123456000, time.UTC)
+	id := "8f14e45f-ceea-467f-a8d6-5c3b0b7b1c2d"
+
+	decodedAt, decodedID, err := decodeAuditCursor(encodeAuditCursor(timestamp, id))
+	assert.NoError(t, err)
+	assert.True(t, timestamp.Equal(decodedAt))
+	assert.Equal(t, id, decodedID)
+
+	for _, cursor := range []string{"!!", "MTIz", encodeAuditCursor(timestamp, "not-a-uuid")} {
+		_, _, err := decodeAuditCursor(cursor)
+		assert.ErrorIs(t, err, ErrInvalidCursor, cursor)
+	}
+}
@@ -0,0 +1,114 @@
+package azure
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	// DefaultMinAudioDuration is the shortest recording worth transcribing
+	DefaultMinAudioDuration = 500 * time.Millisecond
+
+	// DefaultSilenceRMS is the RMS amplitude, on the 16-bit sample scale, at or below
+	// which a recording is treated as silence (about -70 dBFS)
+	DefaultSilenceRMS = 10
+)
+
+// Errors returned by AudioValidator for recordings not sent to speech-to-text
+var (
+	ErrInvalidAudioFormat = errors.New("invalid audio format")
+	ErrAudioTooShort      = errors.New("audio is too short")
+	ErrAudioSilent        = errors.New("audio contains no sound")
+)
+
+// AudioValidator rejects recordings that cannot yield a transcription before they are
+// sent to the speech service: anything but PCM WAV, recordings shorter than
+// MinDuration and recordings whose RMS energy is at most SilenceRMS.
+type AudioValidator struct {
+	MinDuration time.Duration // 0 uses DefaultMinAudioDuration
+	SilenceRMS  float64       // 0 uses DefaultSilenceRMS
+}
+
+// NewAudioValidator creates an AudioValidator with the default limits
+func NewAudioValidator() *AudioValidator {
+	return &AudioValidator{
+		MinDuration: DefaultMinAudioDuration,
+		SilenceRMS:  DefaultSilenceRMS,
+	}
+}
+
+// Validate checks a recording can be transcribed. It returns an error wrapping
+// ErrInvalidAudioFormat, ErrAudioTooShort or ErrAudioSilent otherwise.
+func (v *AudioValidator) Validate(audio []byte) error {
+	wav, err := parseWAV(audio)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAudioFormat, err)
+	}
+	if wav.sampleRate == 0 {
+		return fmt.Errorf("%w: sample rate is zero", ErrInvalidAudioFormat)
+	}
+
+	minDuration := v.MinDuration
+	if minDuration <= 0 {
+		minDuration = DefaultMinAudioDuration
+	}
+	if duration := wav.frameDuration(); duration < minDuration {
+		return fmt.Errorf("%w: %s is below the minimum of %s", ErrAudioTooShort, duration, minDuration)
+	}
+
+	silenceRMS := v.SilenceRMS
+	if silenceRMS <= 0 {
+		silenceRMS = DefaultSilenceRMS
+	}
+	if rms, ok := wav.rms(); ok && rms <= silenceRMS {
+		return fmt.Errorf("%w: RMS energy %.1f", ErrAudioSilent, rms)
+	}
+
+	return nil
+}
+
+// audioValidatorOrDefault returns the configured audio validator
+func (c *SpeechServiceClient) audioValidatorOrDefault() *AudioValidator {
+	if c.audioValidator != nil {
+		return c.audioValidator
+	}
+	return NewAudioValidator()
+}
+
+// frameDuration returns the playback duration from the number of sample frames
+func (w *wavAudio) frameDuration() time.Duration {
+	frames := len(w.data) / int(w.blockAlign)
+	return time.Duration(frames) * time.Second / time.Duration(w.sampleRate)
+}
+
+// rms returns the root mean square amplitude of the samples on the 16-bit scale. It
+// reports false for sample formats other than 8-bit and 16-bit PCM.
+func (w *wavAudio) rms() (float64, bool) {
+	var sum float64
+	var count int
+	switch w.bitsPerSample {
+	case 8:
+		// 8-bit PCM is unsigned, centered on 128
+		for _, b := range w.data {
+			sample := float64(int(b)-128) * 256
+			sum += sample * sample
+		}
+		count = len(w.data)
+	case 16:
+		for i := 0; i+1 < len(w.data); i += 2 {
+			sample := float64(int16(binary.LittleEndian.Uint16(w.data[i:])))
+			sum += sample * sample
+		}
+		count = len(w.data) / 2
+	default:
+		return 0, false
+	}
+
+	if count == 0 {
+		return 0, true
+	}
+	return math.Sqrt(sum / float64(count)), true
+}
@@ -0,0 +1,78 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudioValidator_Validate(t *testing.T) {
+	quiet := make([]byte, testSampleRate*2) // one second of 16-bit samples
+	for i := 0; i+1 < len(quiet); i += 2 {
+		quiet[i] = 3 // amplitude 3, below the silence threshold
+	}
+	unsigned8Bit := &wavAudio{channels: 1, sampleRate: testSampleRate, byteRate: testSampleRate, blockAlign: 1, bitsPerSample: 8}
+
+	tests := []struct {
+		name  string
+		audio []byte
+		want  error
+	}{
+		{"speech", testWAV(testSpeech(time.Second)), nil},
+		{"not a WAV file", []byte("mock audio data"), ErrInvalidAudioFormat},
+		{"RIFF without WAVE", append([]byte("RIFF\x00\x00\x00\x00AVI "), make([]byte, 64)...), ErrInvalidAudioFormat},
+		{"truncated header", testWAV(testSpeech(time.Second))[:30], ErrInvalidAudioFormat},
+		{"shorter than the minimum", testWAV(testSpeech(400 * time.Millisecond)), ErrAudioTooShort},
+		{"digital silence", testWAV(make([]byte, testSampleRate*2)), ErrAudioSilent},
+		{"near silence", testWAV(quiet), ErrAudioSilent},
+		{"8-bit silence", unsigned8Bit.encode(bytes.Repeat([]byte{128}, testSampleRate)), ErrAudioSilent},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewAudioValidator().Validate(tc.audio)
+			if tc.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+}
+
+func TestAudioValidator_MinDurationCountsFrames(t *testing.T) {
+	validator := &AudioValidator{MinDuration: 2 * time.Second}
+	assert.ErrorIs(t, validator.Validate(testWAV(testSpeech(1900*time.Millisecond))), ErrAudioTooShort)
+	assert.NoError(t, validator.Validate(testWAV(testSpeech(2*time.Second))))
+
+	// Two channels play the same number of bytes for half as long
+	stereo := &wavAudio{channels: 2, sampleRate: testSampleRate, byteRate: testSampleRate * 4, blockAlign: 4, bitsPerSample: 16}
+	assert.ErrorIs(t, validator.Validate(stereo.encode(testSpeech(3*time.Second))), ErrAudioTooShort)
+}
+
+func TestStreamAudioToText_RejectsInvalidAudioWithoutCallingService(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+	client := newChunkTestClient(server.URL)
+
+	for _, tc := range []struct {
+		audio []byte
+		want  error
+	}{
+		{[]byte("mock audio data"), ErrInvalidAudioFormat},
+		{testWAV(testSpeech(100 * time.Millisecond)), ErrAudioTooShort},
+		{testWAV(make([]byte, testSampleRate*4)), ErrAudioSilent},
+	} {
+		_, err := client.StreamAudioToText(context.Background(), bytes.NewReader(tc.audio))
+		require.ErrorIs(t, err, tc.want)
+	}
+	assert.Zero(t, calls)
+}
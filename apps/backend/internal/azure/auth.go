@@ -0,0 +1,59 @@
+package azure
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+)
+
+// AuthMode selects how a client authenticates to Azure
+type AuthMode string
+
+const (
+	// AuthModeKey authenticates with an account key or API key
+	AuthModeKey AuthMode = "key"
+	// AuthModeManagedIdentity authenticates with an Entra ID token from
+	// DefaultAzureCredential: the managed identity in Azure, environment or CLI
+	// credentials elsewhere
+	AuthModeManagedIdentity AuthMode = "managed_identity"
+)
+
+// Auth holds how a client authenticates. The zero Mode means key mode.
+type Auth struct {
+	Mode AuthMode
+	Key  string // account key or API key, only used in key mode
+}
+
+// KeyAuth authenticates with an account key or API key
+func KeyAuth(key string) Auth {
+	return Auth{Mode: AuthModeKey, Key: key}
+}
+
+// ManagedIdentityAuth authenticates with an Entra ID token from DefaultAzureCredential
+func ManagedIdentityAuth() Auth {
+	return Auth{Mode: AuthModeManagedIdentity}
+}
+
+// ParseAuthMode validates an auth mode from configuration
+func ParseAuthMode(mode string) (AuthMode, error) {
+	switch AuthMode(mode) {
+	case AuthModeKey, AuthModeManagedIdentity:
+		return AuthMode(mode), nil
+	}
+	return "", fmt.Errorf("unsupported Azure auth mode %q (want %q or %q)", mode, AuthModeKey, AuthModeManagedIdentity)
+}
+
+// mode returns the auth mode, defaulting to key mode
+func (a Auth) mode() (AuthMode, error) {
+	if a.Mode == "" {
+		return AuthModeKey, nil
+	}
+	return ParseAuthMode(string(a.Mode))
+}
+
+// newTokenCredential creates the Entra ID credential used in managed identity mode;
+// tests replace it to avoid reaching the identity endpoints
+var newTokenCredential = func() (azcore.TokenCredential, error) {
+	return azidentity.NewDefaultAzureCredential(nil)
+}
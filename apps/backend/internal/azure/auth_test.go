@@ -0,0 +1,128 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/openai/openai-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeTokenCredential issues a fixed Entra ID token
+type fakeTokenCredential struct {
+	scopes []string
+}
+
+func (f *fakeTokenCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	f.scopes = options.Scopes
+	return azcore.AccessToken{Token: "entra-token", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+// stubTokenCredential replaces the managed identity credential for the test and counts
+// how often it is created
+func stubTokenCredential(t *testing.T, credential azcore.TokenCredential, err error) *atomic.Int32 {
+	t.Helper()
+	var created atomic.Int32
+	original := newTokenCredential
+	newTokenCredential = func() (azcore.TokenCredential, error) {
+		created.Add(1)
+		return credential, err
+	}
+	t.Cleanup(func() { newTokenCredential = original })
+	return &created
+}
+
+// authCapturingServer answers chat completions over TLS, which bearer tokens require, and
+// records the headers of the last request. The SDK's default HTTP client trusts the test
+// certificate until the test ends.
+func authCapturingServer(t *testing.T) (*httptest.Server, *http.Header) {
+	t.Helper()
+	captured := &http.Header{}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*captured = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"1","object":"chat.completion","created":0,"model":"gpt-4o","choices":[{"index":0,"finish_reason":"stop","message":{"role":"assistant","content":"ok"}}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	transport := http.DefaultClient.Transport
+	http.DefaultClient.Transport = server.Client().Transport
+	t.Cleanup(func() { http.DefaultClient.Transport = transport })
+	return server, captured
+}
+
+func TestNewOpenAIClient_KeyAuth(t *testing.T) {
+	created := stubTokenCredential(t, &fakeTokenCredential{}, nil)
+	server, captured := authCapturingServer(t)
+
+	client, err := NewOpenAIClient(server.URL, KeyAuth("test-key"), "gpt-4o", zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, AuthModeKey, client.authMode)
+
+	_, err = client.Complete(context.Background(), []openai.ChatCompletionMessageParamUnion{openai.UserMessage("Szia")})
+	require.NoError(t, err)
+	assert.Equal(t, "test-key", captured.Get("Api-Key"))
+	assert.Empty(t, captured.Get("Authorization"))
+	assert.Zero(t, created.Load(), "key mode must not create an Entra ID credential")
+}
+
+func TestNewOpenAIClient_ManagedIdentity(t *testing.T) {
+	credential := &fakeTokenCredential{}
+	created := stubTokenCredential(t, credential, nil)
+	server, captured := authCapturingServer(t)
+
+	client, err := NewOpenAIClient(server.URL, ManagedIdentityAuth(), "gpt-4o", zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, AuthModeManagedIdentity, client.authMode)
+	assert.Equal(t, int32(1), created.Load())
+
+	_, err = client.Complete(context.Background(), []openai.ChatCompletionMessageParamUnion{openai.UserMessage("Szia")})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer entra-token", captured.Get("Authorization"))
+	assert.Empty(t, captured.Get("Api-Key"))
+	assert.Equal(t, []string{"https://cognitiveservices.azure.com/.default"}, credential.scopes)
+}
+
+func TestNewBlobStorageClient_AuthModes(t *testing.T) {
+	t.Run("key", func(t *testing.T) {
+		created := stubTokenCredential(t, &fakeTokenCredential{}, nil)
+		client, err := NewBlobStorageClient("testaccount", Auth{Key: "dGVzdGtleQ=="}, "test-container", zap.NewNop())
+		require.NoError(t, err)
+		assert.Equal(t, AuthModeKey, client.authMode, "the zero mode is key mode")
+		assert.Zero(t, created.Load())
+	})
+
+	t.Run("managed identity needs no key", func(t *testing.T) {
+		created := stubTokenCredential(t, &fakeTokenCredential{}, nil)
+		client, err := NewBlobStorageClient("testaccount", ManagedIdentityAuth(), "test-container", zap.NewNop())
+		require.NoError(t, err)
+		assert.Equal(t, AuthModeManagedIdentity, client.authMode)
+		assert.Equal(t, int32(1), created.Load())
+	})
+
+	t.Run("credential failure", func(t *testing.T) {
+		stubTokenCredential(t, nil, errors.New("no identity available"))
+		_, err := NewBlobStorageClient("testaccount", ManagedIdentityAuth(), "test-container", zap.NewNop())
+		assert.ErrorContains(t, err, "no identity available")
+	})
+}
+
+func TestAuth_RejectsUnknownMode(t *testing.T) {
+	_, err := NewOpenAIClient("https://test.openai.azure.com/", Auth{Mode: "sas"}, "gpt-4o", zap.NewNop())
+	assert.ErrorContains(t, err, `unsupported Azure auth mode "sas"`)
+
+	_, err = NewBlobStorageClient("testaccount", Auth{Mode: "sas", Key: "dGVzdGtleQ=="}, "test-container", zap.NewNop())
+	assert.ErrorContains(t, err, `unsupported Azure auth mode "sas"`)
+
+	_, err = NewOpenAIClient("https://test.openai.azure.com/", KeyAuth(""), "gpt-4o", zap.NewNop())
+	assert.ErrorContains(t, err, "apiKey is required in key auth mode")
+}
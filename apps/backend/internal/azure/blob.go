@@ -4,8 +4,17 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
+	"sync"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/metrics"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
@@ -13,65 +22,150 @@ import (
 type BlobStorageClient struct {
 	client        *azblob.Client
 	containerName string
+	authMode      AuthMode
+	sharedKey     *azblob.SharedKeyCredential // signs SAS URLs in key mode
 	logger        *zap.Logger
+	retryPolicy   RetryPolicy
+
+	delegationMu  sync.Mutex
+	delegationKey *userDelegationKey // signs SAS URLs in managed identity mode
 }
 
-// NewBlobStorageClient creates a new Azure Blob Storage client
-func NewBlobStorageClient(accountName, accountKey, containerName string, logger *zap.Logger) (*BlobStorageClient, error) {
-	if accountName == "" || accountKey == "" || containerName == "" {
-		return nil, fmt.Errorf("accountName, accountKey, and containerName are required")
+// NewBlobStorageClient creates a new Azure Blob Storage client authenticating with the
+// account key or, in managed identity mode, an Entra ID token
+func NewBlobStorageClient(accountName string, auth Auth, containerName string, logger *zap.Logger) (*BlobStorageClient, error) {
+	if accountName == "" || containerName == "" {
+		return nil, fmt.Errorf("accountName and containerName are required")
+	}
+	mode, err := auth.mode()
+	if err != nil {
+		return nil, err
 	}
 
 	// Create service URL
 	serviceURL := fmt.Sprintf("https://%s.blob.core.windows.net/", accountName)
 
-	// Create shared key credential
-	credential, err := azblob.NewSharedKeyCredential(accountName, accountKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create shared key credential: %w", err)
+	// Retries are handled by the client methods, so the SDK's own retries are disabled
+	options := &azblob.ClientOptions{
+		ClientOptions: azcore.ClientOptions{
+			Retry: policy.RetryOptions{MaxRetries: -1},
+		},
 	}
 
-	// Create blob client
-	client, err := azblob.NewClientWithSharedKeyCredential(serviceURL, credential, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create blob client: %w", err)
+	var client *azblob.Client
+	var sharedKey *azblob.SharedKeyCredential
+	switch mode {
+	case AuthModeKey:
+		if auth.Key == "" {
+			return nil, fmt.Errorf("accountKey is required in key auth mode")
+		}
+
+		// Create shared key credential
+		sharedKey, err = azblob.NewSharedKeyCredential(accountName, auth.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create shared key credential: %w", err)
+		}
+		client, err = azblob.NewClientWithSharedKeyCredential(serviceURL, sharedKey, options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create blob client: %w", err)
+		}
+	case AuthModeManagedIdentity:
+		credential, err := newTokenCredential()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create managed identity credential: %w", err)
+		}
+		client, err = azblob.NewClient(serviceURL, credential, options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create blob client: %w", err)
+		}
 	}
 
+	logger.Info("Azure Blob Storage client created",
+		zap.String("container", containerName),
+		zap.String("auth_mode", string(mode)),
+	)
+
 	return &BlobStorageClient{
 		client:        client,
 		containerName: containerName,
+		authMode:      mode,
+		sharedKey:     sharedKey,
 		logger:        logger,
+		retryPolicy:   DefaultRetryPolicy(),
 	}, nil
 }
 
+// SetRetryPolicy sets how failed blob requests are retried
+func (c *BlobStorageClient) SetRetryPolicy(p RetryPolicy) {
+	c.retryPolicy = p
+}
+
+// ContainerName returns the container the client stores blobs in
+func (c *BlobStorageClient) ContainerName() string {
+	return c.containerName
+}
+
+// VerifyContainer checks that the container exists and the credentials may access it.
+// It is not retried, so a misconfiguration is reported at once.
+func (c *BlobStorageClient) VerifyContainer(ctx context.Context) error {
+	_, err := c.client.ServiceClient().NewContainerClient(c.containerName).GetProperties(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get properties of container %q: %w", c.containerName, err)
+	}
+	return nil
+}
+
+// Ping lists the first container of the storage account, checking that the account is
+// reachable and accepts the credentials
+func (c *BlobStorageClient) Ping(ctx context.Context) error {
+	maxResults := int32(1)
+	pager := c.client.NewListContainersPager(&azblob.ListContainersOptions{MaxResults: &maxResults})
+	if _, err := pager.NextPage(ctx); err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+	return nil
+}
+
 // UploadPDF uploads a PDF file to Azure Blob Storage
 func (c *BlobStorageClient) UploadPDF(ctx context.Context, filename string, data []byte) (string, error) {
-	c.logger.Info("uploading PDF to blob storage",
-		zap.String("filename", filename),
+	return c.UploadFile(ctx, fmt.Sprintf("reports/%s", filename), data, "application/pdf")
+}
+
+// UploadFile uploads data to Azure Blob Storage under blobName, served with contentType
+// when downloaded through a signed URL
+func (c *BlobStorageClient) UploadFile(ctx context.Context, blobName string, data []byte, contentType string) (string, error) {
+	c.logger.Info("uploading file to blob storage",
+		zap.String("blob_name", blobName),
+		zap.String("content_type", contentType),
 		zap.Int("size_bytes", len(data)),
 	)
 
-	blobName := fmt.Sprintf("reports/%s", filename)
-
 	// Get blob client
 	blobClient := c.client.ServiceClient().NewContainerClient(c.containerName).NewBlockBlobClient(blobName)
 
 	// Upload with metadata
-	_, err := blobClient.UploadBuffer(ctx, data, &azblob.UploadBufferOptions{
-		Metadata: map[string]*string{
-			"contenttype": toPtr("application/pdf"),
-		},
+	err := retry(ctx, c.logger, serviceBlob, "blob upload", c.retryPolicy, func(ctx context.Context) error {
+		_, err := blobClient.UploadBuffer(ctx, data, &azblob.UploadBufferOptions{
+			HTTPHeaders: &blob.HTTPHeaders{
+				BlobContentType: toPtr(contentType),
+			},
+			Metadata: map[string]*string{
+				"contenttype": toPtr(contentType),
+			},
+		})
+		return err
 	})
 
 	if err != nil {
-		c.logger.Error("failed to upload PDF",
-			zap.String("filename", filename),
+		c.logger.Error("failed to upload file",
+			zap.String("blob_name", blobName),
 			zap.Error(err),
 		)
-		return "", fmt.Errorf("failed to upload PDF: %w", err)
+		return "", fmt.Errorf("failed to upload file: %w", err)
 	}
 
-	c.logger.Info("PDF uploaded successfully",
+	metrics.Default.RecordBlobBytes(metrics.BlobUpload, len(data))
+	c.logger.Info("file uploaded successfully",
 		zap.String("blob_name", blobName),
 	)
 
@@ -88,7 +182,7 @@ func (c *BlobStorageClient) DownloadPDF(ctx context.Context, blobName string) ([
 	blobClient := c.client.ServiceClient().NewContainerClient(c.containerName).NewBlockBlobClient(blobName)
 
 	// Download blob
-	downloadResponse, err := blobClient.DownloadStream(ctx, nil)
+	data, err := c.download(ctx, blobClient)
 	if err != nil {
 		c.logger.Error("failed to download PDF",
 			zap.String("blob_name", blobName),
@@ -96,28 +190,65 @@ func (c *BlobStorageClient) DownloadPDF(ctx context.Context, blobName string) ([
 		)
 		return nil, fmt.Errorf("failed to download PDF: %w", err)
 	}
-	defer downloadResponse.Body.Close()
 
-	// Read all data
-	data, err := io.ReadAll(downloadResponse.Body)
+	c.logger.Info("PDF downloaded successfully",
+		zap.String("blob_name", blobName),
+		zap.Int("size_bytes", len(data)),
+	)
+
+	return data, nil
+}
+
+// DownloadFile downloads a blob uploaded with UploadFile from Azure Blob Storage
+func (c *BlobStorageClient) DownloadFile(ctx context.Context, blobName string) ([]byte, error) {
+	blobClient := c.client.ServiceClient().NewContainerClient(c.containerName).NewBlockBlobClient(blobName)
+
+	data, err := c.download(ctx, blobClient)
 	if err != nil {
-		c.logger.Error("failed to read PDF data",
+		c.logger.Error("failed to download file",
 			zap.String("blob_name", blobName),
 			zap.Error(err),
 		)
-		return nil, fmt.Errorf("failed to read PDF data: %w", err)
+		return nil, fmt.Errorf("failed to download file: %w", err)
 	}
 
-	c.logger.Info("PDF downloaded successfully",
+	return data, nil
+}
+
+// DeletePDF deletes a PDF file from Azure Blob Storage. A blob that no longer exists is
+// not an error, so a partially completed deletion can be retried.
+func (c *BlobStorageClient) DeletePDF(ctx context.Context, blobName string) error {
+	c.logger.Info("deleting PDF from blob storage",
 		zap.String("blob_name", blobName),
-		zap.Int("size_bytes", len(data)),
 	)
 
-	return data, nil
+	// Get blob client
+	blobClient := c.client.ServiceClient().NewContainerClient(c.containerName).NewBlockBlobClient(blobName)
+
+	err := retry(ctx, c.logger, serviceBlob, "blob delete", c.retryPolicy, func(ctx context.Context) error {
+		_, err := blobClient.Delete(ctx, nil)
+		return err
+	})
+	if err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound) {
+		c.logger.Error("failed to delete PDF",
+			zap.String("blob_name", blobName),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to delete PDF: %w", err)
+	}
+
+	c.logger.Info("PDF deleted successfully",
+		zap.String("blob_name", blobName),
+	)
+
+	return nil
 }
 
 // UploadAudio uploads an audio file to Azure Blob Storage
-func (c *BlobStorageClient) UploadAudio(ctx context.Context, filename string, audioStream io.Reader) (string, error) {
+func (c *BlobStorageClient) UploadAudio(ctx context.Context, filename string, audioStream io.Reader) (_ string, err error) {
+	ctx, span := startCallSpan(ctx, serviceBlob, "upload_audio", attribute.String("azure.blob.container", c.containerName))
+	defer func() { endCallSpan(span, err, http.StatusCreated) }()
+
 	c.logger.Info("uploading audio to blob storage",
 		zap.String("filename", filename),
 	)
@@ -137,11 +268,14 @@ func (c *BlobStorageClient) UploadAudio(ctx context.Context, filename string, au
 		return "", fmt.Errorf("failed to read audio stream: %w", err)
 	}
 
-	// Upload with metadata
-	_, err = blobClient.UploadBuffer(ctx, audioData, &azblob.UploadBufferOptions{
-		Metadata: map[string]*string{
-			"contenttype": toPtr("audio/wav"),
-		},
+	// Upload with metadata; the buffered data can be sent again on retry
+	err = retry(ctx, c.logger, serviceBlob, "blob upload", c.retryPolicy, func(ctx context.Context) error {
+		_, err := blobClient.UploadBuffer(ctx, audioData, &azblob.UploadBufferOptions{
+			Metadata: map[string]*string{
+				"contenttype": toPtr("audio/wav"),
+			},
+		})
+		return err
 	})
 
 	if err != nil {
@@ -152,6 +286,7 @@ func (c *BlobStorageClient) UploadAudio(ctx context.Context, filename string, au
 		return "", fmt.Errorf("failed to upload audio: %w", err)
 	}
 
+	metrics.Default.RecordBlobBytes(metrics.BlobUpload, len(audioData))
 	c.logger.Info("audio uploaded successfully",
 		zap.String("blob_name", blobName),
 		zap.Int("size_bytes", len(audioData)),
@@ -170,7 +305,7 @@ func (c *BlobStorageClient) DownloadAudio(ctx context.Context, blobName string)
 	blobClient := c.client.ServiceClient().NewContainerClient(c.containerName).NewBlockBlobClient(blobName)
 
 	// Download blob
-	downloadResponse, err := blobClient.DownloadStream(ctx, nil)
+	data, err := c.download(ctx, blobClient)
 	if err != nil {
 		c.logger.Error("failed to download audio",
 			zap.String("blob_name", blobName),
@@ -178,24 +313,117 @@ func (c *BlobStorageClient) DownloadAudio(ctx context.Context, blobName string)
 		)
 		return nil, fmt.Errorf("failed to download audio: %w", err)
 	}
-	defer downloadResponse.Body.Close()
 
-	// Read all data
-	data, err := io.ReadAll(downloadResponse.Body)
-	if err != nil {
-		c.logger.Error("failed to read audio data",
+	c.logger.Info("audio downloaded successfully",
+		zap.String("blob_name", blobName),
+		zap.Int("size_bytes", len(data)),
+	)
+
+	return data, nil
+}
+
+// DeleteAudio deletes an audio file from Azure Blob Storage. A blob that no longer exists
+// is not an error, so a partially completed deletion can be retried.
+func (c *BlobStorageClient) DeleteAudio(ctx context.Context, blobName string) error {
+	blobClient := c.client.ServiceClient().NewContainerClient(c.containerName).NewBlockBlobClient(blobName)
+
+	err := retry(ctx, c.logger, serviceBlob, "blob delete", c.retryPolicy, func(ctx context.Context) error {
+		_, err := blobClient.Delete(ctx, nil)
+		return err
+	})
+	if err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound) {
+		c.logger.Error("failed to delete audio",
 			zap.String("blob_name", blobName),
 			zap.Error(err),
 		)
-		return nil, fmt.Errorf("failed to read audio data: %w", err)
+		return fmt.Errorf("failed to delete audio: %w", err)
 	}
 
-	c.logger.Info("audio downloaded successfully",
+	c.logger.Debug("audio deleted",
 		zap.String("blob_name", blobName),
-		zap.Int("size_bytes", len(data)),
 	)
 
-	return data, nil
+	return nil
+}
+
+// download reads a whole blob, retrying transient failures of the request and of reading
+// the body
+func (c *BlobStorageClient) download(ctx context.Context, blobClient *blockblob.Client) ([]byte, error) {
+	var data []byte
+	err := retry(ctx, c.logger, serviceBlob, "blob download", c.retryPolicy, func(ctx context.Context) error {
+		downloadResponse, err := blobClient.DownloadStream(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer downloadResponse.Body.Close()
+
+		data, err = io.ReadAll(downloadResponse.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read blob data: %w", err)
+		}
+		metrics.Default.RecordBlobBytes(metrics.BlobDownload, len(data))
+		return nil
+	})
+	return data, err
+}
+
+// BlobInfo describes a stored blob
+type BlobInfo struct {
+	Name      string
+	SizeBytes int64
+}
+
+// BlobPage is a single page of a blob listing. NextMarker is empty on the last page.
+type BlobPage struct {
+	Blobs      []BlobInfo
+	NextMarker string
+}
+
+// ListBlobsPage lists one page of blobs under prefix, starting at marker
+func (c *BlobStorageClient) ListBlobsPage(ctx context.Context, prefix, marker string, maxResults int32) (*BlobPage, error) {
+	opts := &azblob.ListBlobsFlatOptions{
+		MaxResults: &maxResults,
+	}
+	if prefix != "" {
+		opts.Prefix = &prefix
+	}
+	if marker != "" {
+		opts.Marker = &marker
+	}
+
+	var resp azblob.ListBlobsFlatResponse
+	err := retry(ctx, c.logger, serviceBlob, "blob list", c.retryPolicy, func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.NewListBlobsFlatPager(c.containerName, opts).NextPage(ctx)
+		return err
+	})
+	if err != nil {
+		c.logger.Error("failed to list blobs",
+			zap.String("container", c.containerName),
+			zap.String("prefix", prefix),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to list blobs: %w", err)
+	}
+
+	page := &BlobPage{}
+	if resp.Segment != nil {
+		for _, item := range resp.Segment.BlobItems {
+			if item.Name == nil {
+				continue
+			}
+			info := BlobInfo{Name: *item.Name}
+			if item.Properties != nil && item.Properties.ContentLength != nil {
+				info.SizeBytes = *item.Properties.ContentLength
+			}
+			page.Blobs = append(page.Blobs, info)
+		}
+	}
+	if resp.NextMarker != nil {
+		page.NextMarker = *resp.NextMarker
+	}
+
+	return page, nil
 }
 
 // toPtr is a helper function to convert a value to a pointer
@@ -3,16 +3,28 @@ package azure
 import (
 	"context"
 	"io"
+	"time"
 )
 
 // BlobStorage defines the interface for blob storage operations
 // This interface allows for easier testing with mock implementations
 type BlobStorage interface {
 	UploadPDF(ctx context.Context, filename string, data []byte) (string, error)
+	UploadFile(ctx context.Context, blobName string, data []byte, contentType string) (string, error)
 	DownloadPDF(ctx context.Context, blobName string) ([]byte, error)
+	DeletePDF(ctx context.Context, blobName string) error
 	UploadAudio(ctx context.Context, filename string, audioStream io.Reader) (string, error)
 	DownloadAudio(ctx context.Context, blobName string) ([]byte, error)
+	DeleteAudio(ctx context.Context, blobName string) error
 }
 
-// Ensure BlobStorageClient implements BlobStorage interface
-var _ BlobStorage = (*BlobStorageClient)(nil)
+// BlobURLSigner issues short-lived signed URLs that let clients download a blob directly
+type BlobURLSigner interface {
+	GenerateSASURL(ctx context.Context, blobName string, ttl time.Duration) (string, error)
+}
+
+// Ensure BlobStorageClient implements BlobStorage and BlobURLSigner interfaces
+var (
+	_ BlobStorage   = (*BlobStorageClient)(nil)
+	_ BlobURLSigner = (*BlobStorageClient)(nil)
+)
@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 	"sync"
 
 	"go.uber.org/zap"
@@ -43,6 +45,24 @@ func (c *MockBlobStorageClient) UploadPDF(ctx context.Context, filename string,
 	return blobName, nil
 }
 
+// UploadFile uploads a file to in-memory storage under blobName
+func (c *MockBlobStorageClient) UploadFile(ctx context.Context, blobName string, data []byte, contentType string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Storage[blobName] = data
+
+	if c.logger != nil {
+		c.logger.Info("mock: file uploaded",
+			zap.String("blob_name", blobName),
+			zap.String("content_type", contentType),
+			zap.Int("size_bytes", len(data)),
+		)
+	}
+
+	return blobName, nil
+}
+
 // DownloadPDF downloads a PDF file from in-memory storage
 func (c *MockBlobStorageClient) DownloadPDF(ctx context.Context, blobName string) ([]byte, error) {
 	c.mu.RLock()
@@ -63,6 +83,22 @@ func (c *MockBlobStorageClient) DownloadPDF(ctx context.Context, blobName string
 	return data, nil
 }
 
+// DeletePDF removes a PDF file from in-memory storage
+func (c *MockBlobStorageClient) DeletePDF(ctx context.Context, blobName string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.Storage, blobName)
+
+	if c.logger != nil {
+		c.logger.Info("mock: PDF deleted",
+			zap.String("blob_name", blobName),
+		)
+	}
+
+	return nil
+}
+
 // UploadAudio uploads an audio file to in-memory storage
 func (c *MockBlobStorageClient) UploadAudio(ctx context.Context, filename string, audioStream io.Reader) (string, error) {
 	c.mu.Lock()
@@ -108,6 +144,22 @@ func (c *MockBlobStorageClient) DownloadAudio(ctx context.Context, blobName stri
 	return bytes.Clone(data), nil
 }
 
+// DeleteAudio removes an audio file from in-memory storage
+func (c *MockBlobStorageClient) DeleteAudio(ctx context.Context, blobName string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.Storage, blobName)
+
+	if c.logger != nil {
+		c.logger.Info("mock: audio deleted",
+			zap.String("blob_name", blobName),
+		)
+	}
+
+	return nil
+}
+
 // Clear removes all data from in-memory storage
 func (c *MockBlobStorageClient) Clear() {
 	c.mu.Lock()
@@ -132,3 +184,28 @@ func (c *MockBlobStorageClient) ListBlobs() []string {
 
 	return blobs
 }
+
+// ListBlobsPage lists one page of blobs under prefix in name order, starting after marker
+func (c *MockBlobStorageClient) ListBlobsPage(ctx context.Context, prefix, marker string, maxResults int32) (*BlobPage, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	names := make([]string, 0, len(c.Storage))
+	for name := range c.Storage {
+		if strings.HasPrefix(name, prefix) && name > marker {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	page := &BlobPage{}
+	for _, name := range names {
+		if maxResults > 0 && len(page.Blobs) == int(maxResults) {
+			page.NextMarker = page.Blobs[len(page.Blobs)-1].Name
+			break
+		}
+		page.Blobs = append(page.Blobs, BlobInfo{Name: name, SizeBytes: int64(len(c.Storage[name]))})
+	}
+
+	return page, nil
+}
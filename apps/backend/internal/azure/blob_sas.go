@@ -0,0 +1,108 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/service"
+	"go.uber.org/zap"
+)
+
+const (
+	// sasClockSkew backdates the SAS start time so clocks slightly behind ours accept it
+	sasClockSkew = 5 * time.Minute
+
+	// userDelegationKeyLifetime is how long a user delegation key is requested for and
+	// reused to sign URLs in managed identity mode
+	userDelegationKeyLifetime = time.Hour
+)
+
+// userDelegationKey is a cached user delegation key and the time it stops being valid
+type userDelegationKey struct {
+	credential *service.UserDelegationCredential
+	expiresAt  time.Time
+}
+
+// GenerateSASURL returns a read-only URL to a blob that expires after ttl. In key mode
+// it is a service SAS signed with the account key; in managed identity mode it is a
+// user delegation SAS, which requires the identity to hold a role that can delegate
+// access (e.g. Storage Blob Delegator).
+func (c *BlobStorageClient) GenerateSASURL(ctx context.Context, blobName string, ttl time.Duration) (string, error) {
+	if ttl <= 0 {
+		return "", fmt.Errorf("SAS ttl must be positive")
+	}
+
+	now := time.Now().UTC()
+	permissions := sas.BlobPermissions{Read: true}
+	values := sas.BlobSignatureValues{
+		Protocol:      sas.ProtocolHTTPS,
+		StartTime:     now.Add(-sasClockSkew),
+		ExpiryTime:    now.Add(ttl),
+		Permissions:   permissions.String(),
+		ContainerName: c.containerName,
+		BlobName:      blobName,
+	}
+
+	var params sas.QueryParameters
+	var err error
+	switch c.authMode {
+	case AuthModeManagedIdentity:
+		var credential *service.UserDelegationCredential
+		credential, err = c.userDelegationCredential(ctx, values.ExpiryTime)
+		if err == nil {
+			params, err = values.SignWithUserDelegation(credential)
+		}
+	default:
+		if c.sharedKey == nil {
+			return "", fmt.Errorf("no shared key credential to sign SAS URLs")
+		}
+		params, err = values.SignWithSharedKey(c.sharedKey)
+	}
+	if err != nil {
+		c.logger.Error("failed to sign SAS URL",
+			zap.String("blob_name", blobName),
+			zap.Error(err),
+		)
+		return "", fmt.Errorf("failed to sign SAS URL: %w", err)
+	}
+
+	blobClient := c.client.ServiceClient().NewContainerClient(c.containerName).NewBlobClient(blobName)
+	return blobClient.URL() + "?" + params.Encode(), nil
+}
+
+// userDelegationCredential returns a user delegation key valid at least until
+// validUntil, reusing the cached key while it is
+func (c *BlobStorageClient) userDelegationCredential(ctx context.Context, validUntil time.Time) (*service.UserDelegationCredential, error) {
+	c.delegationMu.Lock()
+	defer c.delegationMu.Unlock()
+
+	if c.delegationKey != nil && c.delegationKey.expiresAt.After(validUntil) {
+		return c.delegationKey.credential, nil
+	}
+
+	now := time.Now().UTC()
+	lifetime := userDelegationKeyLifetime
+	if needed := validUntil.Sub(now) + sasClockSkew; needed > lifetime {
+		lifetime = needed
+	}
+	start := now.Add(-sasClockSkew)
+	expiry := now.Add(lifetime)
+
+	var credential *service.UserDelegationCredential
+	err := retry(ctx, c.logger, serviceBlob, "user delegation key", c.retryPolicy, func(ctx context.Context) error {
+		var err error
+		credential, err = c.client.ServiceClient().GetUserDelegationCredential(ctx, service.KeyInfo{
+			Start:  toPtr(start.Format(sas.TimeFormat)),
+			Expiry: toPtr(expiry.Format(sas.TimeFormat)),
+		}, nil)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user delegation key: %w", err)
+	}
+
+	c.delegationKey = &userDelegationKey{credential: credential, expiresAt: expiry}
+	return credential, nil
+}
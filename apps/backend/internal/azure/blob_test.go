@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"net/url"
 	"testing"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -58,7 +60,7 @@ func TestNewBlobStorageClient(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			client, err := NewBlobStorageClient(tt.accountName, tt.accountKey, tt.containerName, logger)
+			client, err := NewBlobStorageClient(tt.accountName, KeyAuth(tt.accountKey), tt.containerName, logger)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewBlobStorageClient() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -109,7 +111,7 @@ func TestBlobStorageClient_UploadPDF_Validation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Create client with test credentials (will fail but validates structure)
-			client, err := NewBlobStorageClient("testaccount", "dGVzdGtleQ==", "test-container", logger)
+			client, err := NewBlobStorageClient("testaccount", KeyAuth("dGVzdGtleQ=="), "test-container", logger)
 			if err != nil {
 				t.Skipf("Skipping test due to client creation error: %v", err)
 				return
@@ -149,7 +151,7 @@ func TestBlobStorageClient_DownloadPDF_Validation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			client, err := NewBlobStorageClient("testaccount", "dGVzdGtleQ==", "test-container", logger)
+			client, err := NewBlobStorageClient("testaccount", KeyAuth("dGVzdGtleQ=="), "test-container", logger)
 			if err != nil {
 				t.Skipf("Skipping test due to client creation error: %v", err)
 				return
@@ -201,7 +203,7 @@ func TestBlobStorageClient_UploadAudio_Validation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			client, err := NewBlobStorageClient("testaccount", "dGVzdGtleQ==", "test-container", logger)
+			client, err := NewBlobStorageClient("testaccount", KeyAuth("dGVzdGtleQ=="), "test-container", logger)
 			if err != nil {
 				t.Skipf("Skipping test due to client creation error: %v", err)
 				return
@@ -242,7 +244,7 @@ func TestBlobStorageClient_DownloadAudio_Validation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			client, err := NewBlobStorageClient("testaccount", "dGVzdGtleQ==", "test-container", logger)
+			client, err := NewBlobStorageClient("testaccount", KeyAuth("dGVzdGtleQ=="), "test-container", logger)
 			if err != nil {
 				t.Skipf("Skipping test due to client creation error: %v", err)
 				return
@@ -323,7 +325,7 @@ func TestBlobStorageClient_BlobNaming(t *testing.T) {
 }
 
 func TestBlobStorageClient_ContextCancellation(t *testing.T) {
-	client, err := NewBlobStorageClient("testaccount", "dGVzdGtleQ==", "test-container", zap.NewNop())
+	client, err := NewBlobStorageClient("testaccount", KeyAuth("dGVzdGtleQ=="), "test-container", zap.NewNop())
 	if err != nil {
 		t.Skipf("Skipping test due to client creation error: %v", err)
 		return
@@ -359,3 +361,41 @@ func TestToPtr(t *testing.T) {
 		t.Errorf("*toPtr() = %v, want %v", *ptr, str)
 	}
 }
+
+func TestBlobStorageClient_GenerateSASURL(t *testing.T) {
+	client, err := NewBlobStorageClient("testaccount", KeyAuth("dGVzdGtleQ=="), "test-container", zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewBlobStorageClient() error = %v", err)
+	}
+
+	signed, err := client.GenerateSASURL(context.Background(), "reports/report.pdf", 15*time.Minute)
+	if err != nil {
+		t.Fatalf("GenerateSASURL() error = %v", err)
+	}
+
+	u, err := url.Parse(signed)
+	if err != nil {
+		t.Fatalf("GenerateSASURL() returned invalid URL: %v", err)
+	}
+	if want := "/test-container/reports/report.pdf"; u.Path != want {
+		t.Errorf("path = %q, want %q", u.Path, want)
+	}
+	query := u.Query()
+	if query.Get("sp") != "r" {
+		t.Errorf("permissions = %q, want read-only", query.Get("sp"))
+	}
+	if query.Get("sig") == "" {
+		t.Error("SAS URL is not signed")
+	}
+	expiry, err := time.Parse(time.RFC3339, query.Get("se"))
+	if err != nil {
+		t.Fatalf("invalid expiry %q: %v", query.Get("se"), err)
+	}
+	if until := time.Until(expiry); until <= 0 || until > 16*time.Minute {
+		t.Errorf("expiry in %v, want about 15m", until)
+	}
+
+	if _, err := client.GenerateSASURL(context.Background(), "reports/report.pdf", 0); err == nil {
+		t.Error("GenerateSASURL() accepted a zero ttl")
+	}
+}
@@ -0,0 +1,173 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrCircuitOpen is returned without calling the service while a circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+const (
+	// DefaultBreakerThreshold is the number of consecutive failures that opens a breaker
+	DefaultBreakerThreshold = 5
+
+	// DefaultBreakerCoolDown is how long an open breaker rejects calls before probing
+	DefaultBreakerCoolDown = 30 * time.Second
+)
+
+// BreakerState is the state of a circuit breaker
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"    // calls pass through
+	BreakerOpen     BreakerState = "open"      // calls fail fast with ErrCircuitOpen
+	BreakerHalfOpen BreakerState = "half_open" // one probe call is let through
+)
+
+// BreakerStats is a snapshot of a circuit breaker
+type BreakerStats struct {
+	Name                string       `json:"name"`
+	State               BreakerState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	OpenedAt            *time.Time   `json:"opened_at,omitempty"`
+}
+
+// CircuitBreaker tracks consecutive failures of a client. After threshold failures it
+// opens and rejects calls for the cool-down period, then lets a single probe call through:
+// a successful probe closes the breaker, a failed one opens it again.
+type CircuitBreaker struct {
+	name      string
+	threshold int
+	coolDown  time.Duration
+	logger    *zap.Logger
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker creates a closed circuit breaker. A threshold below 1 or a
+// non-positive cool-down uses the defaults.
+func NewCircuitBreaker(name string, threshold int, coolDown time.Duration, logger *zap.Logger) *CircuitBreaker {
+	if threshold < 1 {
+		threshold = DefaultBreakerThreshold
+	}
+	if coolDown <= 0 {
+		coolDown = DefaultBreakerCoolDown
+	}
+	return &CircuitBreaker{
+		name:      name,
+		threshold: threshold,
+		coolDown:  coolDown,
+		logger:    logger,
+		now:       time.Now,
+		state:     BreakerClosed,
+	}
+}
+
+// Allow reports whether a call may proceed, returning ErrCircuitOpen when it may not.
+// A call that is allowed must report its outcome with Record.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.coolDown {
+			return ErrCircuitOpen
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		b.logger.Info("circuit breaker half-open, probing", zap.String("breaker", b.name))
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	}
+	return nil
+}
+
+// Record reports the outcome of an allowed call. Errors caused by the caller, such as a
+// cancelled context or a rejected request, do not count as failures.
+func (b *CircuitBreaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		if b.state != BreakerClosed {
+			b.logger.Info("circuit breaker closed", zap.String("breaker", b.name))
+		}
+		b.state = BreakerClosed
+		b.failures = 0
+		b.probing = false
+		return
+	}
+
+	if !isBreakerFailure(err) {
+		if b.state == BreakerHalfOpen {
+			// The probe told nothing about the service; let the next call probe
+			b.probing = false
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		if b.state != BreakerOpen {
+			b.logger.Warn("circuit breaker opened",
+				zap.String("breaker", b.name),
+				zap.Int("consecutive_failures", b.failures),
+				zap.Duration("cool_down", b.coolDown),
+				zap.Error(err),
+			)
+		}
+		b.state = BreakerOpen
+		b.openedAt = b.now()
+		b.probing = false
+	}
+}
+
+// Stats returns a snapshot of the breaker. An open breaker whose cool-down has passed is
+// reported as half-open.
+func (b *CircuitBreaker) Stats() BreakerStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := BreakerStats{
+		Name:                b.name,
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+	}
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.coolDown {
+		stats.State = BreakerHalfOpen
+	}
+	if b.state != BreakerClosed {
+		openedAt := b.openedAt
+		stats.OpenedAt = &openedAt
+	}
+	return stats
+}
+
+// isBreakerFailure reports whether err indicates the service is unhealthy: a transient
+// failure that outlived the retries, or a call that timed out
+func isBreakerFailure(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	retryable, _ := isRetryable(err)
+	return retryable
+}
@@ -0,0 +1,141 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var errUnavailable = &StatusError{StatusCode: http.StatusServiceUnavailable}
+
+func newTestBreaker(threshold int, coolDown time.Duration) (*CircuitBreaker, func(time.Duration)) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker("test", threshold, coolDown, zap.NewNop())
+	breaker.now = func() time.Time { return now }
+	return breaker, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	breaker, _ := newTestBreaker(3, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, breaker.Allow())
+		breaker.Record(errUnavailable)
+	}
+	assert.Equal(t, BreakerClosed, breaker.Stats().State)
+
+	require.NoError(t, breaker.Allow())
+	breaker.Record(errUnavailable)
+
+	stats := breaker.Stats()
+	assert.Equal(t, BreakerOpen, stats.State)
+	assert.Equal(t, 3, stats.ConsecutiveFailures)
+	assert.NotNil(t, stats.OpenedAt)
+	assert.ErrorIs(t, breaker.Allow(), ErrCircuitOpen)
+}
+
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	breaker, _ := newTestBreaker(2, time.Minute)
+
+	breaker.Record(errUnavailable)
+	breaker.Record(nil)
+	breaker.Record(errUnavailable)
+
+	assert.Equal(t, BreakerClosed, breaker.Stats().State)
+	assert.Equal(t, 1, breaker.Stats().ConsecutiveFailures)
+}
+
+func TestCircuitBreaker_CallerErrorsDoNotCount(t *testing.T) {
+	breaker, _ := newTestBreaker(1, time.Minute)
+
+	breaker.Record(&StatusError{StatusCode: http.StatusBadRequest})
+	breaker.Record(context.Canceled)
+	breaker.Record(errors.New("no choices returned"))
+
+	assert.Equal(t, BreakerClosed, breaker.Stats().State)
+	assert.Zero(t, breaker.Stats().ConsecutiveFailures)
+
+	breaker.Record(context.DeadlineExceeded)
+	assert.Equal(t, BreakerOpen, breaker.Stats().State)
+}
+
+func TestCircuitBreaker_HalfOpenProbe(t *testing.T) {
+	t.Run("successful probe closes", func(t *testing.T) {
+		breaker, advance := newTestBreaker(1, time.Minute)
+		breaker.Record(errUnavailable)
+
+		advance(time.Minute)
+		assert.Equal(t, BreakerHalfOpen, breaker.Stats().State)
+
+		require.NoError(t, breaker.Allow())
+		assert.ErrorIs(t, breaker.Allow(), ErrCircuitOpen, "only one probe at a time")
+
+		breaker.Record(nil)
+		assert.Equal(t, BreakerClosed, breaker.Stats().State)
+		assert.Nil(t, breaker.Stats().OpenedAt)
+		assert.NoError(t, breaker.Allow())
+	})
+
+	t.Run("failed probe reopens", func(t *testing.T) {
+		breaker, advance := newTestBreaker(3, time.Minute)
+		for i := 0; i < 3; i++ {
+			breaker.Record(errUnavailable)
+		}
+
+		advance(time.Minute)
+		require.NoError(t, breaker.Allow())
+		breaker.Record(errUnavailable)
+
+		assert.Equal(t, BreakerOpen, breaker.Stats().State)
+		assert.ErrorIs(t, breaker.Allow(), ErrCircuitOpen)
+
+		advance(59 * time.Second)
+		assert.ErrorIs(t, breaker.Allow(), ErrCircuitOpen)
+	})
+
+	t.Run("inconclusive probe lets the next call probe", func(t *testing.T) {
+		breaker, advance := newTestBreaker(1, time.Minute)
+		breaker.Record(errUnavailable)
+
+		advance(time.Minute)
+		require.NoError(t, breaker.Allow())
+		breaker.Record(context.Canceled)
+
+		assert.NoError(t, breaker.Allow())
+	})
+}
+
+func TestOpenAIClient_CircuitOpenSkipsRequests(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewOpenAIClient(server.URL, KeyAuth("test-key"), "gpt-4o", zap.NewNop())
+	require.NoError(t, err)
+	client.SetRetryPolicy(fastRetryPolicy(2))
+	client.SetCircuitBreaker(NewCircuitBreaker("azure-openai", 1, time.Minute, zap.NewNop()))
+
+	messages := []openai.ChatCompletionMessageParamUnion{openai.UserMessage("Szia")}
+
+	_, err = client.Complete(context.Background(), messages)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, BreakerOpen, client.BreakerStats().State)
+
+	_, err = client.Complete(context.Background(), messages)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(2), calls.Load(), "open circuit must not call the service")
+}
@@ -0,0 +1,19 @@
+package azure
+
+import (
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/metrics"
+)
+
+// Azure services as labeled in the dependency metrics
+const (
+	serviceOpenAI = "openai"
+	serviceSpeech = "speech"
+	serviceBlob   = "blob"
+)
+
+// observeCall records the outcome and latency of one attempt of an Azure call
+func observeCall(service, operation string, start time.Time, err error) {
+	metrics.Default.RecordAzureCall(service, operation, time.Since(start), err)
+}
@@ -0,0 +1,32 @@
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/metrics"
+	"go.uber.org/zap"
+)
+
+func TestRetry_RecordsEveryAttempt(t *testing.T) {
+	srv := &flakyServer{failures: 2, status: http.StatusServiceUnavailable, body: audioBody}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+
+	client := newRetryTestClient(t, server.URL, fastRetryPolicy(3), zap.NewNop())
+
+	successes := metrics.Default.CounterValue("azure_requests_total", serviceSpeech, "text-to-speech", "success")
+	failures := metrics.Default.CounterValue("azure_requests_total", serviceSpeech, "text-to-speech", "failure")
+	observations := metrics.Default.HistogramCount("azure_request_duration_seconds", serviceSpeech, "text-to-speech")
+
+	_, err := client.TextToSpeech(context.Background(), "Szia", "hu-HU", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, successes+1, metrics.Default.CounterValue("azure_requests_total", serviceSpeech, "text-to-speech", "success"))
+	assert.Equal(t, failures+2, metrics.Default.CounterValue("azure_requests_total", serviceSpeech, "text-to-speech", "failure"))
+	assert.Equal(t, observations+3, metrics.Default.HistogramCount("azure_request_duration_seconds", serviceSpeech, "text-to-speech"))
+}
@@ -2,93 +2,162 @@ package azure
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
+	"net/http"
 	"time"
 
 	"github.com/openai/openai-go/v3"
 	"github.com/openai/openai-go/v3/azure"
+	"github.com/openai/openai-go/v3/option"
+	"github.com/openai/openai-go/v3/packages/ssestream"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
 // OpenAIClient wraps Azure OpenAI SDK with retry logic and logging
 type OpenAIClient struct {
-	client     *openai.Client
-	deployment string
-	logger     *zap.Logger
-	maxRetries int
-	baseDelay  time.Duration
+	client      *openai.Client
+	deployment  string
+	authMode    AuthMode
+	logger      *zap.Logger
+	retryPolicy RetryPolicy
+	breaker     *CircuitBreaker
 }
 
-// NewOpenAIClient creates a new Azure OpenAI client using the openai-go SDK with Azure extensions
-func NewOpenAIClient(endpoint, apiKey, deployment string, logger *zap.Logger) (*OpenAIClient, error) {
-	if endpoint == "" || apiKey == "" || deployment == "" {
-		return nil, fmt.Errorf("endpoint, apiKey, and deployment are required")
+// NewOpenAIClient creates a new Azure OpenAI client using the openai-go SDK with Azure
+// extensions, authenticating with the API key or, in managed identity mode, an Entra ID token
+func NewOpenAIClient(endpoint string, auth Auth, deployment string, logger *zap.Logger) (*OpenAIClient, error) {
+	if endpoint == "" || deployment == "" {
+		return nil, fmt.Errorf("endpoint and deployment are required")
+	}
+	mode, err := auth.mode()
+	if err != nil {
+		return nil, err
+	}
+
+	var authOption option.RequestOption
+	switch mode {
+	case AuthModeKey:
+		if auth.Key == "" {
+			return nil, fmt.Errorf("apiKey is required in key auth mode")
+		}
+		authOption = azure.WithAPIKey(auth.Key)
+	case AuthModeManagedIdentity:
+		credential, err := newTokenCredential()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create managed identity credential: %w", err)
+		}
+		authOption = azure.WithTokenCredential(credential)
 	}
 
-	// Create OpenAI client with Azure configuration
+	// Create OpenAI client with Azure configuration. Retries are handled by Complete,
+	// so the SDK's own retries are disabled.
 	client := openai.NewClient(
 		azure.WithEndpoint(endpoint, "2024-08-01-preview"),
-		azure.WithAPIKey(apiKey),
+		authOption,
+		option.WithMaxRetries(0),
+	)
+
+	logger.Info("Azure OpenAI client created",
+		zap.String("deployment", deployment),
+		zap.String("auth_mode", string(mode)),
 	)
 
 	return &OpenAIClient{
-		client:     &client,
-		deployment: deployment,
-		logger:     logger,
-		maxRetries: 3,
-		baseDelay:  time.Second,
+		client:      &client,
+		deployment:  deployment,
+		authMode:    mode,
+		logger:      logger,
+		retryPolicy: DefaultRetryPolicy(),
+		breaker:     NewCircuitBreaker("azure-openai", DefaultBreakerThreshold, DefaultBreakerCoolDown, logger),
 	}, nil
 }
 
-// Complete sends a chat completion request to Azure OpenAI with retry logic
-func (c *OpenAIClient) Complete(ctx context.Context, messages []openai.ChatCompletionMessageParamUnion) (string, error) {
-	startTime := time.Now()
-	var lastErr error
-
-	for attempt := 0; attempt < c.maxRetries; attempt++ {
-		if attempt > 0 {
-			delay := c.baseDelay * time.Duration(1<<uint(attempt-1))
-			c.logger.Info("retrying Azure OpenAI request",
-				zap.Int("attempt", attempt+1),
-				zap.Duration("delay", delay),
-			)
-			time.Sleep(delay)
-		}
+// SetRetryPolicy sets how failed chat completion requests are retried
+func (c *OpenAIClient) SetRetryPolicy(policy RetryPolicy) {
+	c.retryPolicy = policy
+}
 
-		result, err := c.complete(ctx, messages)
-		if err == nil {
-			processingTime := time.Since(startTime)
-			c.logger.Info("Azure OpenAI request completed",
-				zap.Duration("processing_time", processingTime),
-				zap.Int("attempts", attempt+1),
-			)
-			return result, nil
-		}
+// SetCircuitBreaker replaces the circuit breaker guarding chat completion requests
+func (c *OpenAIClient) SetCircuitBreaker(breaker *CircuitBreaker) {
+	c.breaker = breaker
+}
+
+// BreakerStats returns the state of the client's circuit breaker
+func (c *OpenAIClient) BreakerStats() BreakerStats {
+	if c.breaker == nil {
+		return BreakerStats{State: BreakerClosed}
+	}
+	return c.breaker.Stats()
+}
+
+// Deployment returns the name of the deployment chat completions are sent to
+func (c *OpenAIClient) Deployment() string {
+	return c.deployment
+}
+
+// Ping sends a one-token chat completion, checking that the deployment exists and
+// answers. It bypasses retries and the circuit breaker, so a misconfiguration is reported
+// at once.
+func (c *OpenAIClient) Ping(ctx context.Context) error {
+	_, err := c.client.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
+		Model:     openai.ChatModel(c.deployment),
+		Messages:  []openai.ChatCompletionMessageParamUnion{openai.UserMessage("ping")},
+		MaxTokens: openai.Int(1),
+	})
+	if err != nil {
+		return fmt.Errorf("chat completion with deployment %q failed: %w", c.deployment, err)
+	}
+	return nil
+}
 
-		lastErr = err
-		if !c.isRetryable(err) {
-			c.logger.Error("non-retryable Azure OpenAI error",
-				zap.Error(err),
-				zap.Int("attempt", attempt+1),
-			)
-			break
+// PingModels lists the models of the Azure OpenAI resource, a cheap request checking the
+// endpoint and credentials without using the deployment's quota
+func (c *OpenAIClient) PingModels(ctx context.Context) error {
+	if _, err := c.client.Models.List(ctx); err != nil {
+		return fmt.Errorf("listing models failed: %w", err)
+	}
+	return nil
+}
+
+// Complete sends a chat completion request to Azure OpenAI, retrying transient failures.
+// While the circuit breaker is open it fails immediately with ErrCircuitOpen.
+func (c *OpenAIClient) Complete(ctx context.Context, messages []openai.ChatCompletionMessageParamUnion) (_ string, err error) {
+	ctx, span := startCallSpan(ctx, serviceOpenAI, "chat_completion",
+		attribute.String("azure.openai.deployment", c.deployment))
+	defer func() { endCallSpan(span, err, http.StatusOK) }()
+
+	if c.breaker != nil {
+		if err := c.breaker.Allow(); err != nil {
+			c.logger.Warn("Azure OpenAI request skipped", zap.Error(err))
+			return "", fmt.Errorf("Azure OpenAI request skipped: %w", err)
 		}
+	}
+
+	startTime := time.Now()
 
-		c.logger.Warn("Azure OpenAI request failed, will retry",
+	var result string
+	err = retry(ctx, c.logger, serviceOpenAI, "Azure OpenAI chat completion", c.retryPolicy, func(ctx context.Context) error {
+		var err error
+		result, err = c.complete(ctx, messages)
+		return err
+	})
+	if c.breaker != nil {
+		c.breaker.Record(err)
+	}
+	if err != nil {
+		c.logger.Error("Azure OpenAI request failed",
 			zap.Error(err),
-			zap.Int("attempt", attempt+1),
+			zap.Duration("total_time", time.Since(startTime)),
 		)
+		return "", fmt.Errorf("Azure OpenAI request failed: %w", err)
 	}
 
-	processingTime := time.Since(startTime)
-	c.logger.Error("Azure OpenAI request failed after retries",
-		zap.Error(lastErr),
-		zap.Duration("total_time", processingTime),
-		zap.Int("max_retries", c.maxRetries),
+	c.logger.Info("Azure OpenAI request completed",
+		zap.Duration("processing_time", time.Since(startTime)),
 	)
-
-	return "", fmt.Errorf("Azure OpenAI request failed after %d attempts: %w", c.maxRetries, lastErr)
+	return result, nil
 }
 
 // complete performs a single chat completion request
@@ -125,33 +194,103 @@ func (c *OpenAIClient) complete(ctx context.Context, messages []openai.ChatCompl
 	return content, nil
 }
 
-// isRetryable determines if an error should trigger a retry
-func (c *OpenAIClient) isRetryable(err error) bool {
-	// Retry on network errors, timeouts, and rate limits
-	// Don't retry on authentication errors or invalid requests
+// CompleteStreaming sends a chat completion request to Azure OpenAI with streaming
+// enabled and writes the content of every chunk to chunks as it arrives. It closes chunks
+// when it returns. Opening the stream is retried like Complete; a stream failing after
+// content was delivered is not retried. Callers that have all the content they need may
+// cancel ctx to end the stream early.
+func (c *OpenAIClient) CompleteStreaming(ctx context.Context, messages []openai.ChatCompletionMessageParamUnion, chunks chan<- string) error {
+	defer close(chunks)
+
+	if c.breaker != nil {
+		if err := c.breaker.Allow(); err != nil {
+			c.logger.Warn("Azure OpenAI request skipped", zap.Error(err))
+			return fmt.Errorf("Azure OpenAI request skipped: %w", err)
+		}
+	}
+
+	startTime := time.Now()
+
+	var stream *ssestream.Stream[openai.ChatCompletionChunk]
+	err := retry(ctx, c.logger, serviceOpenAI, "Azure OpenAI streaming chat completion", c.retryPolicy, func(ctx context.Context) error {
+		stream = c.client.Chat.Completions.NewStreaming(ctx, openai.ChatCompletionNewParams{
+			Model:    openai.ChatModel(c.deployment),
+			Messages: messages,
+		})
+		if err := stream.Err(); err != nil {
+			stream.Close()
+			return fmt.Errorf("streaming chat completion request failed: %w", err)
+		}
+		return nil
+	})
+
+	var delivered int
 	if err == nil {
-		return false
+		delivered, err = readStream(ctx, stream, chunks)
 	}
 
-	// Check for context cancellation or deadline exceeded
-	if ctx := context.Background(); ctx.Err() != nil {
-		return false
+	// A caller ending the stream after receiving content got an answer from the service
+	endedByCaller := delivered > 0 && errors.Is(err, context.Canceled)
+	if c.breaker != nil {
+		if endedByCaller {
+			c.breaker.Record(nil)
+		} else {
+			c.breaker.Record(err)
+		}
 	}
+	if endedByCaller {
+		c.logger.Info("Azure OpenAI stream ended by caller",
+			zap.Int("chunks", delivered),
+			zap.Duration("processing_time", time.Since(startTime)),
+		)
+		return err
+	}
+	if err != nil {
+		c.logger.Error("Azure OpenAI streaming request failed",
+			zap.Error(err),
+			zap.Int("chunks", delivered),
+			zap.Duration("total_time", time.Since(startTime)),
+		)
+		return fmt.Errorf("Azure OpenAI streaming request failed: %w", err)
+	}
+
+	c.logger.Info("Azure OpenAI streaming request completed",
+		zap.Int("chunks", delivered),
+		zap.Duration("processing_time", time.Since(startTime)),
+	)
+	return nil
+}
 
-	// For now, retry on all errors except explicit non-retryable ones
-	// In production, you'd want more sophisticated error classification
-	errStr := err.Error()
+// readStream writes the content of every chunk of stream to chunks and returns the
+// number of chunks written
+func readStream(ctx context.Context, stream *ssestream.Stream[openai.ChatCompletionChunk], chunks chan<- string) (int, error) {
+	defer stream.Close()
 
-	// Don't retry authentication errors
-	if strings.Contains(errStr, "authentication") || strings.Contains(errStr, "unauthorized") || strings.Contains(errStr, "401") {
-		return false
-	}
+	delivered := 0
+	for stream.Next() {
+		chunk := stream.Current()
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			// Role announcements, usage and content filter results carry no content
+			continue
+		}
 
-	// Don't retry invalid request errors
-	if strings.Contains(errStr, "invalid") || strings.Contains(errStr, "bad request") || strings.Contains(errStr, "400") {
-		return false
+		select {
+		case chunks <- chunk.Choices[0].Delta.Content:
+			delivered++
+		case <-ctx.Done():
+			return delivered, ctx.Err()
+		}
 	}
 
-	// Retry everything else (rate limits, timeouts, network errors)
-	return true
+	if err := stream.Err(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			// The transport reports a cancelled read in its own words
+			return delivered, ctxErr
+		}
+		return delivered, fmt.Errorf("chat completion stream failed: %w", err)
+	}
+	if delivered == 0 {
+		return 0, fmt.Errorf("empty content in response")
+	}
+	return delivered, nil
 }
@@ -2,7 +2,13 @@ package azure
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -63,7 +69,7 @@ func TestNewOpenAIClient(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			client, err := NewOpenAIClient(tt.endpoint, tt.apiKey, tt.deployment, logger)
+			client, err := NewOpenAIClient(tt.endpoint, KeyAuth(tt.apiKey), tt.deployment, logger)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewOpenAIClient() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -75,115 +81,25 @@ func TestNewOpenAIClient(t *testing.T) {
 				if client.deployment != tt.deployment {
 					t.Errorf("deployment = %v, want %v", client.deployment, tt.deployment)
 				}
-				if client.maxRetries != 3 {
-					t.Errorf("maxRetries = %v, want 3", client.maxRetries)
-				}
-				if client.baseDelay != time.Second {
-					t.Errorf("baseDelay = %v, want 1s", client.baseDelay)
+				if client.retryPolicy != DefaultRetryPolicy() {
+					t.Errorf("retryPolicy = %+v, want %+v", client.retryPolicy, DefaultRetryPolicy())
 				}
 			}
 		})
 	}
 }
 
-func TestOpenAIClient_isRetryable(t *testing.T) {
-	logger := zap.NewNop()
-	client := &OpenAIClient{
-		logger:     logger,
-		maxRetries: 3,
-		baseDelay:  time.Second,
-	}
-
-	tests := []struct {
-		name string
-		err  error
-		want bool
-	}{
-		{
-			name: "nil error",
-			err:  nil,
-			want: false,
-		},
-		{
-			name: "authentication error",
-			err:  errors.New("authentication failed"),
-			want: false,
-		},
-		{
-			name: "unauthorized error",
-			err:  errors.New("unauthorized access"),
-			want: false,
-		},
-		{
-			name: "401 error",
-			err:  errors.New("status code 401"),
-			want: false,
-		},
-		{
-			name: "invalid request error",
-			err:  errors.New("invalid request format"),
-			want: false,
-		},
-		{
-			name: "bad request error",
-			err:  errors.New("bad request"),
-			want: false,
-		},
-		{
-			name: "400 error",
-			err:  errors.New("status code 400"),
-			want: false,
-		},
-		{
-			name: "rate limit error",
-			err:  errors.New("rate limit exceeded"),
-			want: true,
-		},
-		{
-			name: "timeout error",
-			err:  errors.New("request timeout"),
-			want: true,
-		},
-		{
-			name: "network error",
-			err:  errors.New("network connection failed"),
-			want: true,
-		},
-		{
-			name: "500 error",
-			err:  errors.New("status code 500"),
-			want: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := client.isRetryable(tt.err)
-			if got != tt.want {
-				t.Errorf("isRetryable() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func TestOpenAIClient_RetryLogic(t *testing.T) {
-	// This test verifies that retry logic works correctly
-	// Note: Since we can't easily mock the openai-go client, this is a structural test
-	logger := zap.NewNop()
-	client := &OpenAIClient{
-		logger:     logger,
-		maxRetries: 3,
-		baseDelay:  10 * time.Millisecond, // Short delay for testing
+func TestOpenAIClient_SetRetryPolicy(t *testing.T) {
+	client, err := NewOpenAIClient("https://test.openai.azure.com/", KeyAuth("test-key"), "gpt-4o", zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewOpenAIClient() error = %v", err)
 	}
 
-	// Test that maxRetries is set correctly
-	if client.maxRetries != 3 {
-		t.Errorf("maxRetries = %v, want 3", client.maxRetries)
-	}
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: 10 * time.Millisecond, MaxDelay: time.Second}
+	client.SetRetryPolicy(policy)
 
-	// Test that baseDelay is set correctly
-	if client.baseDelay != 10*time.Millisecond {
-		t.Errorf("baseDelay = %v, want 10ms", client.baseDelay)
+	if client.retryPolicy != policy {
+		t.Errorf("retryPolicy = %+v, want %+v", client.retryPolicy, policy)
 	}
 }
 
@@ -191,7 +107,7 @@ func TestOpenAIClient_Complete_EmptyMessages(t *testing.T) {
 	logger := zap.NewNop()
 
 	// Create client with invalid credentials (will fail but we're testing validation)
-	client, err := NewOpenAIClient("https://test.openai.azure.com/", "test-key", "gpt-4o", logger)
+	client, err := NewOpenAIClient("https://test.openai.azure.com/", KeyAuth("test-key"), "gpt-4o", logger)
 	if err != nil {
 		t.Fatalf("NewOpenAIClient() error = %v", err)
 	}
@@ -208,7 +124,7 @@ func TestOpenAIClient_Complete_EmptyMessages(t *testing.T) {
 func TestOpenAIClient_Complete_ContextCancellation(t *testing.T) {
 	logger := zap.NewNop()
 
-	client, err := NewOpenAIClient("https://test.openai.azure.com/", "test-key", "gpt-4o", logger)
+	client, err := NewOpenAIClient("https://test.openai.azure.com/", KeyAuth("test-key"), "gpt-4o", logger)
 	if err != nil {
 		t.Fatalf("NewOpenAIClient() error = %v", err)
 	}
@@ -230,7 +146,7 @@ func TestOpenAIClient_Complete_ContextCancellation(t *testing.T) {
 func TestOpenAIClient_Complete_Timeout(t *testing.T) {
 	logger := zap.NewNop()
 
-	client, err := NewOpenAIClient("https://test.openai.azure.com/", "test-key", "gpt-4o", logger)
+	client, err := NewOpenAIClient("https://test.openai.azure.com/", KeyAuth("test-key"), "gpt-4o", logger)
 	if err != nil {
 		t.Fatalf("NewOpenAIClient() error = %v", err)
 	}
@@ -251,3 +167,165 @@ func TestOpenAIClient_Complete_Timeout(t *testing.T) {
 		t.Error("Complete() with timeout context should return error")
 	}
 }
+
+// sseChunk formats a streamed chat completion chunk carrying content as an SSE event
+func sseChunk(content string) string {
+	delta := map[string]string{"content": content}
+	if content == "" {
+		delta = map[string]string{"role": "assistant"}
+	}
+	data, _ := json.Marshal(map[string]any{
+		"id":      "chatcmpl-1",
+		"object":  "chat.completion.chunk",
+		"created": 0,
+		"model":   "gpt-4o",
+		"choices": []map[string]any{{"index": 0, "delta": delta}},
+	})
+	return fmt.Sprintf("data: %s\n\n", data)
+}
+
+// newStreamingServer answers chat completion requests with one SSE event per chunk
+// followed by [DONE]. The first failFirst requests fail with 503.
+func newStreamingServer(t *testing.T, failFirst int32, chunks ...string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failFirst {
+			http.Error(w, `{"error":{"message":"busy"}}`, http.StatusServiceUnavailable)
+			return
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["stream"] != true {
+			http.Error(w, "stream must be requested", http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, chunk := range chunks {
+			fmt.Fprint(w, sseChunk(chunk))
+			w.(http.Flusher).Flush()
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// collectStream runs CompleteStreaming and returns the chunks it delivered
+func collectStream(t *testing.T, client *OpenAIClient) ([]string, error) {
+	t.Helper()
+	chunks := make(chan string)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- client.CompleteStreaming(context.Background(), []openai.ChatCompletionMessageParamUnion{
+			openai.UserMessage("test message"),
+		}, chunks)
+	}()
+
+	var received []string
+	for chunk := range chunks {
+		received = append(received, chunk)
+	}
+	return received, <-errCh
+}
+
+func TestOpenAIClient_CompleteStreaming(t *testing.T) {
+	server, _ := newStreamingServer(t, 0, "", `{"mood":`, ` "positive",`, ` "symptoms": []}`)
+	client, err := NewOpenAIClient(server.URL+"/", KeyAuth("test-key"), "gpt-4o", zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewOpenAIClient() error = %v", err)
+	}
+
+	received, err := collectStream(t, client)
+	if err != nil {
+		t.Fatalf("CompleteStreaming() error = %v", err)
+	}
+
+	// The role announcement carries no content and is not delivered
+	want := []string{`{"mood":`, ` "positive",`, ` "symptoms": []}`}
+	if strings.Join(received, "|") != strings.Join(want, "|") {
+		t.Errorf("chunks = %q, want %q", received, want)
+	}
+}
+
+func TestOpenAIClient_CompleteStreaming_RetriesOpening(t *testing.T) {
+	server, requests := newStreamingServer(t, 1, "ok")
+	client, err := NewOpenAIClient(server.URL+"/", KeyAuth("test-key"), "gpt-4o", zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewOpenAIClient() error = %v", err)
+	}
+	client.SetRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond})
+
+	received, err := collectStream(t, client)
+	if err != nil {
+		t.Fatalf("CompleteStreaming() error = %v", err)
+	}
+	if strings.Join(received, "") != "ok" {
+		t.Errorf("chunks = %q, want %q", received, "ok")
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+}
+
+func TestOpenAIClient_CompleteStreaming_EmptyContent(t *testing.T) {
+	server, _ := newStreamingServer(t, 0, "")
+	client, err := NewOpenAIClient(server.URL+"/", KeyAuth("test-key"), "gpt-4o", zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewOpenAIClient() error = %v", err)
+	}
+
+	received, err := collectStream(t, client)
+	if err == nil {
+		t.Error("CompleteStreaming() without content should return error")
+	}
+	if len(received) != 0 {
+		t.Errorf("chunks = %q, want none", received)
+	}
+}
+
+func TestOpenAIClient_CompleteStreaming_EndedByCaller(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, sseChunk("{}"))
+		w.(http.Flusher).Flush()
+		// Keep the stream open until the client goes away
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := NewOpenAIClient(server.URL+"/", KeyAuth("test-key"), "gpt-4o", zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewOpenAIClient() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	chunks := make(chan string)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- client.CompleteStreaming(ctx, []openai.ChatCompletionMessageParamUnion{openai.UserMessage("test")}, chunks)
+	}()
+
+	if chunk := <-chunks; chunk != "{}" {
+		t.Fatalf("first chunk = %q, want %q", chunk, "{}")
+	}
+	cancel()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("CompleteStreaming() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("CompleteStreaming() did not return after the caller cancelled")
+	}
+	if state := client.BreakerStats().State; state != BreakerClosed {
+		t.Errorf("breaker state = %v, want closed", state)
+	}
+}
@@ -0,0 +1,233 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/openai/openai-go/v3"
+	"go.uber.org/zap"
+)
+
+// RetryPolicy controls how failed Azure calls are retried. The zero value makes a single
+// attempt; clients created with their constructors use DefaultRetryPolicy.
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts including the first, values below 1 mean one attempt
+	BaseDelay   time.Duration // delay before the first retry, doubled for every further retry
+	MaxDelay    time.Duration // upper bound of a single delay, including Retry-After waits
+	Jitter      float64       // fraction of each backoff delay that is randomized, 0..1
+}
+
+// DefaultRetryPolicy returns the retry policy used by the Azure clients
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		Jitter:      0.5,
+	}
+}
+
+// backoff returns the delay before the given retry (1 for the first retry)
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.BaseDelay << (retry - 1)
+	if delay <= 0 || (p.MaxDelay > 0 && delay > p.MaxDelay) {
+		// Shifting overflowed or passed the cap
+		delay = p.MaxDelay
+	}
+
+	jitter := min(max(p.Jitter, 0), 1)
+	if jitter > 0 && delay > 0 {
+		randomized := time.Duration(jitter * float64(delay))
+		delay = delay - randomized + rand.N(randomized+1)
+	}
+	return delay
+}
+
+// StatusError is returned when an Azure REST call answers with an unexpected status code
+type StatusError struct {
+	StatusCode int
+	Body       string
+	RetryAfter time.Duration // wait requested by the Retry-After header, 0 if absent
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status %d: %s", e.StatusCode, e.Body)
+}
+
+// newStatusError builds a StatusError from a response, consuming its body
+func newStatusError(resp *http.Response) *StatusError {
+	body, _ := io.ReadAll(resp.Body)
+	return &StatusError{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+// HTTPStatus returns the status code of the failed Azure response in err, or 0 when err
+// did not come from a response, e.g. a network failure
+func HTTPStatus(err error) int {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode
+	}
+
+	var openAIErr *openai.Error
+	if errors.As(err, &openAIErr) {
+		return openAIErr.StatusCode
+	}
+
+	var responseErr *azcore.ResponseError
+	if errors.As(err, &responseErr) {
+		return responseErr.StatusCode
+	}
+
+	return 0
+}
+
+// retryableStatus reports whether a response status code indicates a transient failure
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// isRetryable reports whether err is a transient failure worth retrying and the wait the
+// server asked for, if any. Context cancellation and client errors are never retried.
+func isRetryable(err error) (bool, time.Duration) {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false, 0
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return retryableStatus(statusErr.StatusCode), statusErr.RetryAfter
+	}
+
+	var openAIErr *openai.Error
+	if errors.As(err, &openAIErr) {
+		return retryableStatus(openAIErr.StatusCode), responseRetryAfter(openAIErr.Response)
+	}
+
+	var responseErr *azcore.ResponseError
+	if errors.As(err, &responseErr) {
+		return retryableStatus(responseErr.StatusCode), responseRetryAfter(responseErr.RawResponse)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true, 0
+	}
+
+	return errors.Is(err, io.ErrUnexpectedEOF), 0
+}
+
+// responseRetryAfter returns the Retry-After wait of a response, 0 if there is none
+func responseRetryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	return parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+}
+
+// retry calls fn until it succeeds, fails with a non-retryable error or the policy runs out
+// of attempts. Waits between attempts use exponential backoff with jitter, or the server's
+// Retry-After when it is longer, and end immediately when ctx is done. Every attempt is
+// recorded in the Azure dependency metrics of service.
+func retry(ctx context.Context, logger *zap.Logger, service, operation string, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	maxAttempts := max(policy.MaxAttempts, 1)
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			if err == nil {
+				return ctxErr
+			}
+			return fmt.Errorf("%s aborted after %d attempts: %w", operation, attempt-1, ctxErr)
+		}
+
+		start := time.Now()
+		err = fn(ctx)
+		observeCall(service, operation, start, err)
+		if err == nil {
+			if attempt > 1 {
+				logger.Info("Azure request succeeded after retries",
+					zap.String("operation", operation),
+					zap.Int("attempts", attempt),
+					zap.Int("retries", attempt-1),
+				)
+			}
+			return nil
+		}
+
+		retryable, retryAfter := isRetryable(err)
+		if !retryable {
+			return err
+		}
+		if attempt >= maxAttempts {
+			if maxAttempts > 1 {
+				logger.Error("Azure request failed after retries",
+					zap.String("operation", operation),
+					zap.Int("attempts", attempt),
+					zap.Int("retries", attempt-1),
+					zap.Error(err),
+				)
+				return fmt.Errorf("%s failed after %d attempts: %w", operation, attempt, err)
+			}
+			return err
+		}
+
+		delay := max(policy.backoff(attempt), retryAfter)
+		if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+			delay = policy.MaxDelay
+		}
+		logger.Warn("Azure request failed, retrying",
+			zap.String("operation", operation),
+			zap.Int("attempt", attempt),
+			zap.Int("max_attempts", maxAttempts),
+			zap.Duration("delay", delay),
+			zap.Duration("retry_after", retryAfter),
+			zap.Error(err),
+		)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%s aborted after %d attempts: %w", operation, attempt, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/openai/openai-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// flakyServer fails the first failures requests with status, then succeeds with body
+type flakyServer struct {
+	failures   int32
+	status     int
+	retryAfter string
+	body       func(w http.ResponseWriter)
+	calls      atomic.Int32
+}
+
+func (s *flakyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.calls.Add(1) <= s.failures {
+		if s.retryAfter != "" {
+			w.Header().Set("Retry-After", s.retryAfter)
+		}
+		w.WriteHeader(s.status)
+		w.Write([]byte("temporarily unavailable"))
+		return
+	}
+	s.body(w)
+}
+
+func audioBody(w http.ResponseWriter) {
+	w.Write([]byte("audio"))
+}
+
+// fastRetryPolicy keeps test retries quick
+func fastRetryPolicy(maxAttempts int) RetryPolicy {
+	return RetryPolicy{MaxAttempts: maxAttempts, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Second, Jitter: 0.5}
+}
+
+func newRetryTestClient(t *testing.T, url string, policy RetryPolicy, logger *zap.Logger) *SpeechServiceClient {
+	t.Helper()
+	client, err := NewSpeechServiceClient("test-key", "swedencentral", logger)
+	require.NoError(t, err)
+	client.SetEndpointForTesting(url)
+	client.SetRetryPolicy(policy)
+	return client
+}
+
+func TestRetry_TextToSpeechSucceedsAfterTransientFailures(t *testing.T) {
+	srv := &flakyServer{failures: 2, status: http.StatusServiceUnavailable, body: audioBody}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	client := newRetryTestClient(t, server.URL, fastRetryPolicy(3), zap.New(core))
+
+	audio, err := client.TextToSpeech(context.Background(), "Szia", "hu-HU", "")
+	require.NoError(t, err)
+	assert.Equal(t, "audio", string(audio))
+	assert.Equal(t, int32(3), srv.calls.Load())
+
+	assert.Equal(t, 2, logs.FilterMessage("Azure request failed, retrying").Len())
+	succeeded := logs.FilterMessage("Azure request succeeded after retries").All()
+	require.Len(t, succeeded, 1)
+	assert.Equal(t, int64(2), succeeded[0].ContextMap()["retries"])
+}
+
+func TestRetry_SpeechToTextSucceedsAfterTransientFailure(t *testing.T) {
+	srv := &flakyServer{failures: 1, status: http.StatusBadGateway, body: func(w http.ResponseWriter) {
+		json.NewEncoder(w).Encode(recognitionResult{RecognitionStatus: "Success", DisplayText: "Jól vagyok"})
+	}}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+
+	client := newRetryTestClient(t, server.URL, fastRetryPolicy(3), zap.NewNop())
+
+	text, err := client.recognizeSingle(context.Background(), []byte("audio"))
+	require.NoError(t, err)
+	assert.Equal(t, "Jól vagyok", text)
+	assert.Equal(t, int32(2), srv.calls.Load())
+}
+
+func TestRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	srv := &flakyServer{failures: 10, status: http.StatusInternalServerError, body: audioBody}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+
+	client := newRetryTestClient(t, server.URL, fastRetryPolicy(4), zap.NewNop())
+
+	_, err := client.TextToSpeechWAV(context.Background(), "Szia", "hu-HU", "")
+	require.Error(t, err)
+	assert.Equal(t, int32(4), srv.calls.Load())
+	assert.Contains(t, err.Error(), "failed after 4 attempts")
+
+	var statusErr *StatusError
+	require.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusInternalServerError, statusErr.StatusCode)
+}
+
+func TestRetry_NonRetryableStatusIsNotRetried(t *testing.T) {
+	srv := &flakyServer{failures: 1, status: http.StatusBadRequest, body: audioBody}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+
+	client := newRetryTestClient(t, server.URL, fastRetryPolicy(3), zap.NewNop())
+
+	_, err := client.TextToSpeech(context.Background(), "Szia", "hu-HU", "")
+	require.Error(t, err)
+	assert.Equal(t, int32(1), srv.calls.Load())
+}
+
+func TestRetry_HonorsRetryAfter(t *testing.T) {
+	srv := &flakyServer{failures: 1, status: http.StatusTooManyRequests, retryAfter: "1", body: audioBody}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+
+	client := newRetryTestClient(t, server.URL, fastRetryPolicy(2), zap.NewNop())
+
+	start := time.Now()
+	_, err := client.TextToSpeech(context.Background(), "Szia", "hu-HU", "")
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	assert.Equal(t, int32(2), srv.calls.Load())
+}
+
+func TestRetry_ContextCancellationAbortsWait(t *testing.T) {
+	srv := &flakyServer{failures: 10, status: http.StatusServiceUnavailable, retryAfter: "30", body: audioBody}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+
+	policy := fastRetryPolicy(5)
+	policy.MaxDelay = time.Minute
+	client := newRetryTestClient(t, server.URL, policy, zap.NewNop())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.TextToSpeech(ctx, "Szia", "hu-HU", "")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Equal(t, int32(1), srv.calls.Load())
+}
+
+func TestRetry_CancelledContextMakesNoAttempt(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var calls int
+	err := retry(ctx, zap.NewNop(), "test", "test", fastRetryPolicy(3), func(ctx context.Context) error {
+		calls++
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, calls)
+}
+
+func TestRetry_ZeroPolicyMakesOneAttempt(t *testing.T) {
+	var calls int
+	err := retry(context.Background(), zap.NewNop(), "test", "test", RetryPolicy{}, func(ctx context.Context) error {
+		calls++
+		return &StatusError{StatusCode: http.StatusServiceUnavailable}
+	})
+	require.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		want       bool
+		retryAfter time.Duration
+	}{
+		{"nil", nil, false, 0},
+		{"service unavailable", &StatusError{StatusCode: http.StatusServiceUnavailable}, true, 0},
+		{"wrapped rate limit with retry after", fmt.Errorf("request failed with %w", &StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: 2 * time.Second}), true, 2 * time.Second},
+		{"bad request", &StatusError{StatusCode: http.StatusBadRequest}, false, 0},
+		{"unauthorized", &StatusError{StatusCode: http.StatusUnauthorized}, false, 0},
+		{"openai rate limit", &openai.Error{StatusCode: http.StatusTooManyRequests}, true, 0},
+		{"openai bad request", &openai.Error{StatusCode: http.StatusBadRequest}, false, 0},
+		{"blob server error", &azcore.ResponseError{StatusCode: http.StatusInternalServerError}, true, 0},
+		{"blob not found", &azcore.ResponseError{StatusCode: http.StatusNotFound}, false, 0},
+		{"network error", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, true, 0},
+		{"context canceled", fmt.Errorf("request failed: %w", context.Canceled), false, 0},
+		{"deadline exceeded", context.DeadlineExceeded, false, 0},
+		{"other error", errors.New("no choices returned"), false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, retryAfter := isRetryable(tt.err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.retryAfter, retryAfter)
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 3*time.Second, parseRetryAfter("3", now))
+	assert.Equal(t, 90*time.Second, parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now))
+	assert.Zero(t, parseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now))
+	assert.Zero(t, parseRetryAfter("", now))
+	assert.Zero(t, parseRetryAfter("-1", now))
+	assert.Zero(t, parseRetryAfter("soon", now))
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, Jitter: 0.5}
+
+	for i := 0; i < 50; i++ {
+		first := policy.backoff(1)
+		assert.GreaterOrEqual(t, first, 50*time.Millisecond)
+		assert.LessOrEqual(t, first, 100*time.Millisecond)
+
+		third := policy.backoff(3)
+		assert.GreaterOrEqual(t, third, 200*time.Millisecond)
+		assert.LessOrEqual(t, third, 400*time.Millisecond)
+
+		capped := policy.backoff(10)
+		assert.GreaterOrEqual(t, capped, 500*time.Millisecond)
+		assert.LessOrEqual(t, capped, time.Second)
+	}
+
+	noJitter := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	assert.Equal(t, 400*time.Millisecond, noJitter.backoff(3))
+	assert.Equal(t, time.Second, noJitter.backoff(64))
+}
@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
@@ -18,8 +20,14 @@ type SpeechServiceClient struct {
 	region          string
 	endpoint        string
 	ttsEndpoint     string // For testing purposes
+	tokenEndpoint   string
 	httpClient      *http.Client
 	logger          *zap.Logger
+	retryPolicy     RetryPolicy
+
+	maxAnswerDuration time.Duration   // 0 uses DefaultMaxAnswerDuration
+	chunkDuration     time.Duration   // 0 uses DefaultSTTChunkDuration
+	audioValidator    *AudioValidator // nil uses NewAudioValidator
 }
 
 // NewSpeechServiceClient creates a new Azure Speech Service client
@@ -34,10 +42,12 @@ func NewSpeechServiceClient(subscriptionKey, region string, logger *zap.Logger)
 		subscriptionKey: subscriptionKey,
 		region:          region,
 		endpoint:        endpoint,
+		tokenEndpoint:   fmt.Sprintf("https://%s.api.cognitive.microsoft.com", region),
 		httpClient: &http.Client{
 			Timeout: 60 * time.Second,
 		},
-		logger: logger,
+		logger:      logger,
+		retryPolicy: DefaultRetryPolicy(),
 	}, nil
 }
 
@@ -45,12 +55,58 @@ func NewSpeechServiceClient(subscriptionKey, region string, logger *zap.Logger)
 func (c *SpeechServiceClient) SetEndpointForTesting(endpoint string) {
 	c.endpoint = endpoint
 	c.ttsEndpoint = endpoint
+	c.tokenEndpoint = endpoint
+}
+
+// VerifyToken requests an access token with the subscription key, which checks the key
+// and region without transcribing anything. It is not retried, so a misconfiguration is
+// reported at once.
+func (c *SpeechServiceClient) VerifyToken(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", c.tokenEndpoint+"/sts/v1.0/issueToken", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Ocp-Apim-Subscription-Key", c.subscriptionKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("speech token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("speech token request failed with %w", newStatusError(resp))
+	}
+	return nil
+}
+
+// SetRetryPolicy sets how failed speech requests are retried
+func (c *SpeechServiceClient) SetRetryPolicy(policy RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+// SetMaxAnswerDuration sets the longest answer accepted for transcription
+func (c *SpeechServiceClient) SetMaxAnswerDuration(d time.Duration) {
+	c.maxAnswerDuration = d
+}
+
+// SetAudioValidator sets the checks recordings pass before they are transcribed
+func (c *SpeechServiceClient) SetAudioValidator(validator *AudioValidator) {
+	c.audioValidator = validator
 }
 
 // StreamAudioToText performs real-time speech-to-text transcription from an audio stream
 // Note: This implementation uses the REST API for simplicity. For production streaming,
 // consider using WebSocket-based streaming or the native SDK with proper C library setup.
-func (c *SpeechServiceClient) StreamAudioToText(ctx context.Context, audioStream io.Reader) (string, error) {
+// The REST API only recognizes short utterances, so WAV audio longer than the chunk
+// duration is split on silence and transcribed chunk by chunk. Audio longer than the
+// maximum answer duration is rejected with an *AnswerTooLongError. Recordings failing
+// the AudioValidator are rejected without calling the service, with an error wrapping
+// ErrInvalidAudioFormat, ErrAudioTooShort or ErrAudioSilent.
+func (c *SpeechServiceClient) StreamAudioToText(ctx context.Context, audioStream io.Reader) (_ string, err error) {
+	ctx, span := startCallSpan(ctx, serviceSpeech, "speech_to_text", attribute.String("azure.region", c.region))
+	defer func() { endCallSpan(span, err, http.StatusOK) }()
+
 	c.logger.Info("starting speech-to-text transcription")
 
 	// Read audio data from stream
@@ -59,48 +115,117 @@ func (c *SpeechServiceClient) StreamAudioToText(ctx context.Context, audioStream
 		return "", fmt.Errorf("failed to read audio stream: %w", err)
 	}
 
-	// Create request to Speech-to-Text REST API
-	url := fmt.Sprintf("%s/speech/recognition/conversation/cognitiveservices/v1?language=hu-HU", c.endpoint)
+	if err := c.audioValidatorOrDefault().Validate(audioData); err != nil {
+		c.logger.Info("audio rejected before transcription", zap.Error(err))
+		return "", err
+	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(audioData))
+	wav, err := parseWAV(audioData)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("%w: %v", ErrInvalidAudioFormat, err)
 	}
 
-	// Set headers
-	req.Header.Set("Ocp-Apim-Subscription-Key", c.subscriptionKey)
-	req.Header.Set("Content-Type", "audio/wav; codecs=audio/pcm; samplerate=16000")
-	req.Header.Set("Accept", "application/json")
+	duration := wav.duration()
+	if maxDuration := c.maxAnswerDurationOrDefault(); duration > maxDuration {
+		return "", &AnswerTooLongError{Duration: duration, MaxDuration: maxDuration}
+	}
 
-	// Send request
-	startTime := time.Now()
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		c.logger.Error("speech-to-text request failed", zap.Error(err))
-		return "", fmt.Errorf("speech-to-text request failed: %w", err)
+	chunks := wav.split(c.chunkDurationOrDefault())
+	if len(chunks) == 1 {
+		return c.recognizeSingle(ctx, audioData)
 	}
-	defer resp.Body.Close()
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		c.logger.Error("speech-to-text request failed",
-			zap.Int("status_code", resp.StatusCode),
-			zap.String("response", string(body)),
-		)
-		return "", fmt.Errorf("speech-to-text request failed with status %d: %s", resp.StatusCode, string(body))
+	c.logger.Info("transcribing long answer in chunks",
+		zap.Duration("audio_duration", duration),
+		zap.Int("chunks", len(chunks)),
+	)
+
+	var parts []string
+	for i, chunk := range chunks {
+		result, err := c.recognize(ctx, wav.encode(chunk))
+		if err != nil {
+			return "", fmt.Errorf("chunk %d of %d: %w", i+1, len(chunks), err)
+		}
+
+		// Chunks containing only silence are not recognized
+		if result.RecognitionStatus == "Success" && strings.TrimSpace(result.DisplayText) != "" {
+			parts = append(parts, strings.TrimSpace(result.DisplayText))
+		}
 	}
 
-	// Parse response
-	var result struct {
-		RecognitionStatus string `json:"RecognitionStatus"`
-		DisplayText       string `json:"DisplayText"`
-		Offset            int64  `json:"Offset"`
-		Duration          int64  `json:"Duration"`
+	if len(parts) == 0 {
+		return "", fmt.Errorf("recognition failed: no speech recognized in %d chunks", len(chunks))
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", fmt.Errorf("failed to decode response: %w", err)
+	return strings.Join(parts, " "), nil
+}
+
+// recognitionResult is the simple-format response of the speech-to-text REST API
+type recognitionResult struct {
+	RecognitionStatus string `json:"RecognitionStatus"`
+	DisplayText       string `json:"DisplayText"`
+	Offset            int64  `json:"Offset"`
+	Duration          int64  `json:"Duration"`
+}
+
+// recognizeSingle transcribes audio in a single request, failing unless recognition succeeded
+func (c *SpeechServiceClient) recognizeSingle(ctx context.Context, audioData []byte) (string, error) {
+	result, err := c.recognize(ctx, audioData)
+	if err != nil {
+		return "", err
+	}
+
+	if result.RecognitionStatus != "Success" {
+		return "", fmt.Errorf("recognition failed with status: %s", result.RecognitionStatus)
+	}
+
+	return result.DisplayText, nil
+}
+
+// recognize sends one audio payload to the speech-to-text REST API, retrying transient failures
+func (c *SpeechServiceClient) recognize(ctx context.Context, audioData []byte) (*recognitionResult, error) {
+	// Create request to Speech-to-Text REST API
+	url := fmt.Sprintf("%s/speech/recognition/conversation/cognitiveservices/v1?language=hu-HU", c.endpoint)
+
+	startTime := time.Now()
+	var result recognitionResult
+	err := retry(ctx, c.logger, serviceSpeech, "speech-to-text", c.retryPolicy, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(audioData))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+
+		// Set headers
+		req.Header.Set("Ocp-Apim-Subscription-Key", c.subscriptionKey)
+		req.Header.Set("Content-Type", "audio/wav; codecs=audio/pcm; samplerate=16000")
+		req.Header.Set("Accept", "application/json")
+
+		// Send request
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			c.logger.Error("speech-to-text request failed", zap.Error(err))
+			return fmt.Errorf("speech-to-text request failed: %w", err)
+		}
+		defer resp.Body.Close()
+
+		// Check response status
+		if resp.StatusCode != http.StatusOK {
+			statusErr := newStatusError(resp)
+			c.logger.Error("speech-to-text request failed",
+				zap.Int("status_code", statusErr.StatusCode),
+				zap.String("response", statusErr.Body),
+			)
+			return fmt.Errorf("speech-to-text request failed with %w", statusErr)
+		}
+
+		// Parse response
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	processingTime := time.Since(startTime)
@@ -110,24 +235,27 @@ func (c *SpeechServiceClient) StreamAudioToText(ctx context.Context, audioStream
 		zap.Int("audio_size_bytes", len(audioData)),
 	)
 
-	if result.RecognitionStatus != "Success" {
-		return "", fmt.Errorf("recognition failed with status: %s", result.RecognitionStatus)
-	}
-
-	return result.DisplayText, nil
+	return &result, nil
 }
 
-// TextToSpeech converts text to speech audio in Hungarian
-func (c *SpeechServiceClient) TextToSpeech(ctx context.Context, text string, language string) ([]byte, error) {
+// TextToSpeech converts text to speech audio with the given voice; an empty voice
+// uses the default voice of the language
+func (c *SpeechServiceClient) TextToSpeech(ctx context.Context, text string, language string, voice string) (_ []byte, err error) {
+	ctx, span := startCallSpan(ctx, serviceSpeech, "text_to_speech",
+		attribute.String("azure.region", c.region),
+		attribute.String("azure.speech.language", language),
+	)
+	defer func() { endCallSpan(span, err, http.StatusOK) }()
+
 	c.logger.Info("starting text-to-speech synthesis",
 		zap.String("language", language),
+		zap.String("voice", voice),
 		zap.Int("text_length", len(text)),
 	)
 
-	// Determine voice name based on language
-	voiceName := "hu-HU-NoemiNeural"
-	if language != "hu-HU" {
-		voiceName = fmt.Sprintf("%s-Standard-A", language)
+	voiceName, err := resolveVoice(language, voice)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create SSML request
@@ -137,68 +265,27 @@ func (c *SpeechServiceClient) TextToSpeech(ctx context.Context, text string, lan
 		</voice>
 	</speak>`, language, language, voiceName, text)
 
-	// Create request to Text-to-Speech REST API
-	url := fmt.Sprintf("https://%s.tts.speech.microsoft.com/cognitiveservices/v1", c.region)
-	if c.ttsEndpoint != "" {
-		url = c.ttsEndpoint + "/cognitiveservices/v1"
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBufferString(ssml))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("Ocp-Apim-Subscription-Key", c.subscriptionKey)
-	req.Header.Set("Content-Type", "application/ssml+xml")
-	req.Header.Set("X-Microsoft-OutputFormat", "audio-16khz-32kbitrate-mono-mp3")
-	req.Header.Set("User-Agent", "Eva-Health-Backend")
-
-	// Send request
-	startTime := time.Now()
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		c.logger.Error("text-to-speech request failed", zap.Error(err))
-		return nil, fmt.Errorf("text-to-speech request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check response status
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		c.logger.Error("text-to-speech request failed",
-			zap.Int("status_code", resp.StatusCode),
-			zap.String("response", string(body)),
-		)
-		return nil, fmt.Errorf("text-to-speech request failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	// Read audio data
-	audioData, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read audio data: %w", err)
-	}
-
-	processingTime := time.Since(startTime)
-	c.logger.Info("text-to-speech synthesis completed",
-		zap.Int("audio_size_bytes", len(audioData)),
-		zap.Duration("processing_time", processingTime),
-	)
-
-	return audioData, nil
+	return c.synthesize(ctx, ssml, "audio-16khz-32kbitrate-mono-mp3", "text-to-speech synthesis completed")
 }
 
 // TextToSpeechWAV converts text to speech audio in WAV format (for speech-to-text compatibility)
-func (c *SpeechServiceClient) TextToSpeechWAV(ctx context.Context, text string, language string) ([]byte, error) {
+// with the given voice; an empty voice uses the default voice of the language
+func (c *SpeechServiceClient) TextToSpeechWAV(ctx context.Context, text string, language string, voice string) (_ []byte, err error) {
+	ctx, span := startCallSpan(ctx, serviceSpeech, "text_to_speech",
+		attribute.String("azure.region", c.region),
+		attribute.String("azure.speech.language", language),
+	)
+	defer func() { endCallSpan(span, err, http.StatusOK) }()
+
 	c.logger.Info("starting text-to-speech synthesis (WAV format)",
 		zap.String("language", language),
+		zap.String("voice", voice),
 		zap.Int("text_length", len(text)),
 	)
 
-	// Determine voice name based on language
-	voiceName := "hu-HU-NoemiNeural"
-	if language != "hu-HU" {
-		voiceName = fmt.Sprintf("%s-Standard-A", language)
+	voiceName, err := resolveVoice(language, voice)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create SSML request
@@ -208,52 +295,63 @@ func (c *SpeechServiceClient) TextToSpeechWAV(ctx context.Context, text string,
 		</voice>
 	</speak>`, language, language, voiceName, text)
 
-	// Create request to Text-to-Speech REST API
+	return c.synthesize(ctx, ssml, "riff-16khz-16bit-mono-pcm", "text-to-speech synthesis (WAV) completed")
+}
+
+// synthesize sends an SSML document to the text-to-speech REST API and returns the audio
+// in the given output format, retrying transient failures
+func (c *SpeechServiceClient) synthesize(ctx context.Context, ssml, outputFormat, completedMessage string) ([]byte, error) {
 	url := fmt.Sprintf("https://%s.tts.speech.microsoft.com/cognitiveservices/v1", c.region)
 	if c.ttsEndpoint != "" {
 		url = c.ttsEndpoint + "/cognitiveservices/v1"
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBufferString(ssml))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers for WAV format
-	req.Header.Set("Ocp-Apim-Subscription-Key", c.subscriptionKey)
-	req.Header.Set("Content-Type", "application/ssml+xml")
-	req.Header.Set("X-Microsoft-OutputFormat", "riff-16khz-16bit-mono-pcm") // WAV format
-	req.Header.Set("User-Agent", "Eva-Health-Backend")
-
-	// Send request
 	startTime := time.Now()
-	resp, err := c.httpClient.Do(req)
+	var audioData []byte
+	err := retry(ctx, c.logger, serviceSpeech, "text-to-speech", c.retryPolicy, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(ssml))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+
+		// Set headers
+		req.Header.Set("Ocp-Apim-Subscription-Key", c.subscriptionKey)
+		req.Header.Set("Content-Type", "application/ssml+xml")
+		req.Header.Set("X-Microsoft-OutputFormat", outputFormat)
+		req.Header.Set("User-Agent", "Eva-Health-Backend")
+
+		// Send request
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			c.logger.Error("text-to-speech request failed", zap.Error(err))
+			return fmt.Errorf("text-to-speech request failed: %w", err)
+		}
+		defer resp.Body.Close()
+
+		// Check response status
+		if resp.StatusCode != http.StatusOK {
+			statusErr := newStatusError(resp)
+			c.logger.Error("text-to-speech request failed",
+				zap.Int("status_code", statusErr.StatusCode),
+				zap.String("response", statusErr.Body),
+			)
+			return fmt.Errorf("text-to-speech request failed with %w", statusErr)
+		}
+
+		// Read audio data
+		audioData, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read audio data: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		c.logger.Error("text-to-speech request failed", zap.Error(err))
-		return nil, fmt.Errorf("text-to-speech request failed: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		c.logger.Error("text-to-speech request failed",
-			zap.Int("status_code", resp.StatusCode),
-			zap.String("response", string(body)),
-		)
-		return nil, fmt.Errorf("text-to-speech request failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	// Read audio data
-	audioData, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read audio data: %w", err)
-	}
-
-	processingTime := time.Since(startTime)
-	c.logger.Info("text-to-speech synthesis (WAV) completed",
+	c.logger.Info(completedMessage,
 		zap.Int("audio_size_bytes", len(audioData)),
-		zap.Duration("processing_time", processingTime),
+		zap.Duration("processing_time", time.Since(startTime)),
 	)
 
 	return audioData, nil
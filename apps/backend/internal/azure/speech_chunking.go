@@ -0,0 +1,195 @@
+package azure
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultSTTChunkDuration keeps each request below the 60 second limit of the REST API
+	DefaultSTTChunkDuration = 50 * time.Second
+
+	// DefaultMaxAnswerDuration is the longest answer transcribed when no limit is configured
+	DefaultMaxAnswerDuration = 5 * time.Minute
+
+	// silenceSearchWindow is how far back from a chunk boundary a quiet split point is searched
+	silenceSearchWindow = 10 * time.Second
+
+	// silenceFrameDuration is the granularity of the energy measurement used to find silence
+	silenceFrameDuration = 20 * time.Millisecond
+)
+
+// AnswerTooLongError is returned when audio exceeds the maximum answer duration
+type AnswerTooLongError struct {
+	Duration    time.Duration
+	MaxDuration time.Duration
+}
+
+func (e *AnswerTooLongError) Error() string {
+	return fmt.Sprintf("answer of %s exceeds the maximum duration of %s",
+		e.Duration.Round(time.Second), e.MaxDuration)
+}
+
+// wavAudio is a parsed PCM WAV recording
+type wavAudio struct {
+	channels      uint16
+	sampleRate    uint32
+	byteRate      uint32
+	blockAlign    uint16
+	bitsPerSample uint16
+	data          []byte
+}
+
+// parseWAV extracts the format and sample data of a PCM RIFF/WAVE file
+func parseWAV(audio []byte) (*wavAudio, error) {
+	if len(audio) < 12 || !bytes.Equal(audio[0:4], []byte("RIFF")) || !bytes.Equal(audio[8:12], []byte("WAVE")) {
+		return nil, errors.New("not a RIFF/WAVE file")
+	}
+
+	var wav wavAudio
+	var hasFormat bool
+	for offset := 12; offset+8 <= len(audio); {
+		id := string(audio[offset : offset+4])
+		size := int(binary.LittleEndian.Uint32(audio[offset+4 : offset+8]))
+		body := offset + 8
+
+		switch id {
+		case "fmt ":
+			if size < 16 || body+16 > len(audio) {
+				return nil, errors.New("truncated fmt chunk")
+			}
+			if format := binary.LittleEndian.Uint16(audio[body:]); format != 1 {
+				return nil, fmt.Errorf("unsupported WAV format %d", format)
+			}
+			wav.channels = binary.LittleEndian.Uint16(audio[body+2:])
+			wav.sampleRate = binary.LittleEndian.Uint32(audio[body+4:])
+			wav.byteRate = binary.LittleEndian.Uint32(audio[body+8:])
+			wav.blockAlign = binary.LittleEndian.Uint16(audio[body+12:])
+			wav.bitsPerSample = binary.LittleEndian.Uint16(audio[body+14:])
+			hasFormat = true
+
+		case "data":
+			if !hasFormat || wav.byteRate == 0 || wav.blockAlign == 0 {
+				return nil, errors.New("data chunk without a valid fmt chunk")
+			}
+			// Streaming encoders may write a placeholder size; use what was received
+			end := body + size
+			if end > len(audio) {
+				end = len(audio)
+			}
+			wav.data = audio[body:end]
+			return &wav, nil
+		}
+
+		offset = body + size + size%2
+	}
+
+	return nil, errors.New("no data chunk")
+}
+
+// duration returns the playback duration of the samples
+func (w *wavAudio) duration() time.Duration {
+	return time.Duration(float64(len(w.data)) / float64(w.byteRate) * float64(time.Second))
+}
+
+// bytesFor converts a duration to a whole number of sample frames in bytes
+func (w *wavAudio) bytesFor(d time.Duration) int {
+	n := int(d.Seconds() * float64(w.byteRate))
+	n -= n % int(w.blockAlign)
+	return max(n, int(w.blockAlign))
+}
+
+// split divides the samples into chunks of at most window, cutting each chunk at
+// the quietest point near its end so that words are not split across requests
+func (w *wavAudio) split(window time.Duration) [][]byte {
+	windowBytes := w.bytesFor(window)
+	searchBytes := min(w.bytesFor(silenceSearchWindow), windowBytes)
+	frameBytes := w.bytesFor(silenceFrameDuration)
+
+	var chunks [][]byte
+	data := w.data
+	for len(data) > windowBytes {
+		cut := w.quietestCut(data[:windowBytes], searchBytes, frameBytes)
+		chunks = append(chunks, data[:cut])
+		data = data[cut:]
+	}
+	if len(data) > 0 || len(chunks) == 0 {
+		chunks = append(chunks, data)
+	}
+	return chunks
+}
+
+// quietestCut returns the end offset of the lowest-energy frame within the last
+// searchBytes of window, preferring later frames so that audio without pauses is
+// cut at the window end. Formats other than 16-bit PCM are cut at the window end.
+func (w *wavAudio) quietestCut(window []byte, searchBytes, frameBytes int) int {
+	if w.bitsPerSample != 16 || frameBytes >= searchBytes {
+		return len(window)
+	}
+
+	cut := len(window)
+	lowest := int64(-1)
+	for start := len(window) - searchBytes; start+frameBytes <= len(window); start += frameBytes {
+		energy := frameEnergy(window[start : start+frameBytes])
+		if lowest < 0 || energy <= lowest {
+			lowest = energy
+			cut = start + frameBytes
+		}
+	}
+	return cut
+}
+
+// frameEnergy sums the absolute amplitude of 16-bit little-endian samples
+func frameEnergy(frame []byte) int64 {
+	var energy int64
+	for i := 0; i+1 < len(frame); i += 2 {
+		sample := int64(int16(binary.LittleEndian.Uint16(frame[i:])))
+		if sample < 0 {
+			sample = -sample
+		}
+		energy += sample
+	}
+	return energy
+}
+
+// encode wraps samples in a WAV header with the recording's format
+func (w *wavAudio) encode(pcm []byte) []byte {
+	var buf bytes.Buffer
+	buf.Grow(44 + len(pcm))
+
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(36+len(pcm)))
+	buf.WriteString("WAVE")
+	buf.WriteString("fmt ")
+	binary.Write(&buf, binary.LittleEndian, uint32(16))
+	binary.Write(&buf, binary.LittleEndian, uint16(1)) // PCM
+	binary.Write(&buf, binary.LittleEndian, w.channels)
+	binary.Write(&buf, binary.LittleEndian, w.sampleRate)
+	binary.Write(&buf, binary.LittleEndian, w.byteRate)
+	binary.Write(&buf, binary.LittleEndian, w.blockAlign)
+	binary.Write(&buf, binary.LittleEndian, w.bitsPerSample)
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(len(pcm)))
+	buf.Write(pcm)
+
+	return buf.Bytes()
+}
+
+// maxAnswerDurationOrDefault returns the configured maximum answer duration
+func (c *SpeechServiceClient) maxAnswerDurationOrDefault() time.Duration {
+	if c.maxAnswerDuration > 0 {
+		return c.maxAnswerDuration
+	}
+	return DefaultMaxAnswerDuration
+}
+
+// chunkDurationOrDefault returns the audio duration sent per recognition request
+func (c *SpeechServiceClient) chunkDurationOrDefault() time.Duration {
+	if c.chunkDuration > 0 {
+		return c.chunkDuration
+	}
+	return DefaultSTTChunkDuration
+}
@@ -0,0 +1,191 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testSampleRate = 16000
+
+// testSpeech builds 16 kHz 16-bit mono PCM: loud samples with silent gaps
+// starting at each of the given offsets
+func testSpeech(total time.Duration, gaps ...time.Duration) []byte {
+	samples := int(total.Seconds() * testSampleRate)
+	pcm := make([]byte, samples*2)
+	for i := 0; i < samples; i++ {
+		at := time.Duration(i) * time.Second / testSampleRate
+		amplitude := int16(8000)
+		if i%2 == 0 {
+			amplitude = -8000
+		}
+		for _, gap := range gaps {
+			if at >= gap && at < gap+time.Second {
+				amplitude = 0
+			}
+		}
+		binary.LittleEndian.PutUint16(pcm[i*2:], uint16(amplitude))
+	}
+	return pcm
+}
+
+func testWAV(pcm []byte) []byte {
+	wav := &wavAudio{channels: 1, sampleRate: testSampleRate, byteRate: testSampleRate * 2, blockAlign: 2, bitsPerSample: 16}
+	return wav.encode(pcm)
+}
+
+// chunkServer answers each recognition request with the next response and records the received audio
+type chunkServer struct {
+	mu        sync.Mutex
+	responses []recognitionResult
+	received  [][]byte
+}
+
+func (s *chunkServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	n := len(s.received)
+	s.received = append(s.received, body)
+	s.mu.Unlock()
+
+	resp := recognitionResult{RecognitionStatus: "Success", DisplayText: fmt.Sprintf("rész %d", n+1)}
+	if n < len(s.responses) {
+		resp = s.responses[n]
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func newChunkTestClient(url string) *SpeechServiceClient {
+	return &SpeechServiceClient{
+		subscriptionKey: "test-key",
+		region:          "swedencentral",
+		endpoint:        url,
+		httpClient:      &http.Client{Timeout: 60 * time.Second},
+		logger:          zap.NewNop(),
+	}
+}
+
+func TestStreamAudioToText_LongAnswerIsChunkedOnSilence(t *testing.T) {
+	srv := &chunkServer{}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+
+	client := newChunkTestClient(server.URL)
+	audio := testWAV(testSpeech(130*time.Second, 45*time.Second, 92*time.Second))
+
+	text, err := client.StreamAudioToText(context.Background(), bytes.NewReader(audio))
+	require.NoError(t, err)
+	assert.Equal(t, "rész 1 rész 2 rész 3", text)
+
+	require.Len(t, srv.received, 3)
+	var total time.Duration
+	for i, chunk := range srv.received {
+		wav, err := parseWAV(chunk)
+		require.NoError(t, err, "chunk %d is a valid WAV", i)
+		assert.LessOrEqual(t, wav.duration(), DefaultSTTChunkDuration)
+		total += wav.duration()
+	}
+	assert.Equal(t, 130*time.Second, total)
+
+	// The first cut falls inside the silent gap instead of at the 50 second window
+	first, _ := parseWAV(srv.received[0])
+	assert.Greater(t, first.duration(), 45*time.Second)
+	assert.LessOrEqual(t, first.duration(), 46*time.Second)
+}
+
+func TestStreamAudioToText_SilentChunksAreSkipped(t *testing.T) {
+	srv := &chunkServer{responses: []recognitionResult{
+		{RecognitionStatus: "Success", DisplayText: "Fáj a fejem,"},
+		{RecognitionStatus: "NoMatch"},
+		{RecognitionStatus: "Success", DisplayText: " és rosszul aludtam."},
+	}}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+
+	client := newChunkTestClient(server.URL)
+	client.chunkDuration = 20 * time.Second
+
+	text, err := client.StreamAudioToText(context.Background(), bytes.NewReader(testWAV(testSpeech(55*time.Second))))
+	require.NoError(t, err)
+	assert.Equal(t, "Fáj a fejem, és rosszul aludtam.", text)
+	assert.Len(t, srv.received, 3)
+}
+
+func TestStreamAudioToText_ChunkFailureAbortsTranscription(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(recognitionResult{RecognitionStatus: "Success", DisplayText: "ok"})
+	}))
+	defer server.Close()
+
+	client := newChunkTestClient(server.URL)
+	client.chunkDuration = 20 * time.Second
+
+	_, err := client.StreamAudioToText(context.Background(), bytes.NewReader(testWAV(testSpeech(55*time.Second))))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "chunk 2 of 3")
+}
+
+func TestStreamAudioToText_MaxAnswerDuration(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	client := newChunkTestClient(server.URL)
+	client.SetMaxAnswerDuration(30 * time.Second)
+
+	_, err := client.StreamAudioToText(context.Background(), bytes.NewReader(testWAV(testSpeech(31*time.Second))))
+
+	var tooLong *AnswerTooLongError
+	require.True(t, errors.As(err, &tooLong))
+	assert.Equal(t, 31*time.Second, tooLong.Duration)
+	assert.Equal(t, 30*time.Second, tooLong.MaxDuration)
+	assert.Zero(t, calls)
+}
+
+func TestStreamAudioToText_ShortAnswerIsSentUnchanged(t *testing.T) {
+	srv := &chunkServer{}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+
+	audio := testWAV(testSpeech(5 * time.Second))
+	text, err := newChunkTestClient(server.URL).StreamAudioToText(context.Background(), bytes.NewReader(audio))
+	require.NoError(t, err)
+	assert.Equal(t, "rész 1", text)
+	require.Len(t, srv.received, 1)
+	assert.Equal(t, audio, srv.received[0])
+}
+
+func TestParseWAV(t *testing.T) {
+	pcm := testSpeech(2 * time.Second)
+	wav, err := parseWAV(testWAV(pcm))
+	require.NoError(t, err)
+	assert.Equal(t, uint32(testSampleRate), wav.sampleRate)
+	assert.Equal(t, 2*time.Second, wav.duration())
+	assert.Equal(t, pcm, wav.data)
+
+	_, err = parseWAV([]byte("mock audio data"))
+	assert.Error(t, err)
+}
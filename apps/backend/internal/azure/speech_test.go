@@ -102,7 +102,7 @@ func TestSpeechServiceClient_StreamAudioToText_Success(t *testing.T) {
 	}
 
 	// Create mock audio stream
-	audioData := []byte("mock audio data")
+	audioData := testWAV(testSpeech(time.Second))
 	audioStream := bytes.NewReader(audioData)
 
 	ctx := context.Background()
@@ -140,7 +140,7 @@ func TestSpeechServiceClient_StreamAudioToText_RecognitionFailed(t *testing.T) {
 		logger:          logger,
 	}
 
-	audioStream := bytes.NewReader([]byte("mock audio data"))
+	audioStream := bytes.NewReader(testWAV(testSpeech(time.Second)))
 	ctx := context.Background()
 
 	_, err := client.StreamAudioToText(ctx, audioStream)
@@ -167,7 +167,7 @@ func TestSpeechServiceClient_StreamAudioToText_HTTPError(t *testing.T) {
 		logger:          logger,
 	}
 
-	audioStream := bytes.NewReader([]byte("mock audio data"))
+	audioStream := bytes.NewReader(testWAV(testSpeech(time.Second)))
 	ctx := context.Background()
 
 	_, err := client.StreamAudioToText(ctx, audioStream)
@@ -194,7 +194,7 @@ func TestSpeechServiceClient_StreamAudioToText_InvalidJSON(t *testing.T) {
 		logger:          logger,
 	}
 
-	audioStream := bytes.NewReader([]byte("mock audio data"))
+	audioStream := bytes.NewReader(testWAV(testSpeech(time.Second)))
 	ctx := context.Background()
 
 	_, err := client.StreamAudioToText(ctx, audioStream)
@@ -244,7 +244,7 @@ func TestSpeechServiceClient_TextToSpeech_Success(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	audioData, err := client.TextToSpeech(ctx, "Szia", "hu-HU")
+	audioData, err := client.TextToSpeech(ctx, "Szia", "hu-HU", "")
 
 	if err != nil {
 		t.Errorf("TextToSpeech() error = %v", err)
@@ -277,7 +277,7 @@ func TestSpeechServiceClient_TextToSpeech_HTTPError(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	_, err := client.TextToSpeech(ctx, "Test", "hu-HU")
+	_, err := client.TextToSpeech(ctx, "Test", "hu-HU", "")
 
 	if err == nil {
 		t.Error("TextToSpeech() should return error for HTTP error")
@@ -310,7 +310,7 @@ func TestSpeechServiceClient_TextToSpeechWAV_Success(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	audioData, err := client.TextToSpeechWAV(ctx, "Test", "hu-HU")
+	audioData, err := client.TextToSpeechWAV(ctx, "Test", "hu-HU", "")
 
 	if err != nil {
 		t.Errorf("TextToSpeechWAV() error = %v", err)
@@ -345,10 +345,106 @@ func TestSpeechServiceClient_ContextCancellation(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
 	defer cancel()
 
-	audioStream := bytes.NewReader([]byte("mock audio data"))
+	audioStream := bytes.NewReader(testWAV(testSpeech(time.Second)))
 	_, err := client.StreamAudioToText(ctx, audioStream)
 
 	if err == nil {
 		t.Error("StreamAudioToText() should return error for cancelled context")
 	}
 }
+
+func TestSpeechServiceClient_TextToSpeech_ConfiguredVoice(t *testing.T) {
+	var ssml []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ssml, _ = io.ReadAll(r.Body)
+		w.Write([]byte("mock audio mp3 data"))
+	}))
+	defer server.Close()
+
+	client := &SpeechServiceClient{
+		subscriptionKey: "test-key",
+		region:          "swedencentral",
+		ttsEndpoint:     server.URL,
+		httpClient:      &http.Client{Timeout: 60 * time.Second},
+		logger:          zap.NewNop(),
+	}
+
+	if _, err := client.TextToSpeech(context.Background(), "Szia", "hu-HU", "hu-HU-TamasNeural"); err != nil {
+		t.Fatalf("TextToSpeech() error = %v", err)
+	}
+	if !bytes.Contains(ssml, []byte("name='hu-HU-TamasNeural'")) {
+		t.Errorf("SSML should use the configured voice, got %s", ssml)
+	}
+
+	// Voices off the allowlist or in another language are rejected before any request
+	ssml = nil
+	if _, err := client.TextToSpeech(context.Background(), "Szia", "hu-HU", "hu-HU-UnknownNeural"); err == nil {
+		t.Error("TextToSpeech() should reject an unsupported voice")
+	}
+	if _, err := client.TextToSpeech(context.Background(), "Szia", "hu-HU", "en-US-GuyNeural"); err == nil {
+		t.Error("TextToSpeech() should reject a voice of another language")
+	}
+	if ssml != nil {
+		t.Error("rejected voices should not reach the speech service")
+	}
+}
+
+func TestResolveVoice(t *testing.T) {
+	tests := []struct {
+		language string
+		voice    string
+		want     string
+		wantErr  bool
+	}{
+		{"hu-HU", "", DefaultVoice, false},
+		{"en-US", "", "en-US-JennyNeural", false},
+		{"hu-HU", "hu-HU-TamasNeural", "hu-HU-TamasNeural", false},
+		{"fr-FR", "", "", true},
+		{"hu-HU", "hu-HU-Standard-A", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := resolveVoice(tt.language, tt.voice)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("resolveVoice(%q, %q) error = %v, wantErr %v", tt.language, tt.voice, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("resolveVoice(%q, %q) = %q, want %q", tt.language, tt.voice, got, tt.want)
+		}
+	}
+
+	if err := ValidateVoice(DefaultVoice); err != nil {
+		t.Errorf("ValidateVoice(DefaultVoice) error = %v", err)
+	}
+}
+
+func TestSpeechServiceClient_VerifyToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sts/v1.0/issueToken" || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Ocp-Apim-Subscription-Key") != "valid-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte("token"))
+	}))
+	defer server.Close()
+
+	client, err := NewSpeechServiceClient("valid-key", "swedencentral", zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewSpeechServiceClient() error = %v", err)
+	}
+	client.SetEndpointForTesting(server.URL)
+
+	if err := client.VerifyToken(context.Background()); err != nil {
+		t.Errorf("VerifyToken() error = %v", err)
+	}
+
+	client.subscriptionKey = "invalid-key"
+	err = client.VerifyToken(context.Background())
+	if HTTPStatus(err) != http.StatusUnauthorized {
+		t.Errorf("VerifyToken() error = %v, want status 401", err)
+	}
+}
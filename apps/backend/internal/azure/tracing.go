@@ -0,0 +1,31 @@
+package azure
+
+import (
+	"context"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startCallSpan starts the span of an Azure client call, covering all of its attempts
+func startCallSpan(ctx context.Context, service, operation string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append([]attribute.KeyValue{
+		attribute.String("azure.service", service),
+		attribute.String("azure.operation", operation),
+	}, attrs...)
+	return telemetry.StartSpan(ctx, "azure."+service+"."+operation, attrs...)
+}
+
+// endCallSpan records the response status of an Azure call and ends its span. A failed
+// call carries the status of its last response, if the service answered at all.
+func endCallSpan(span trace.Span, err error, successStatus int) {
+	status := successStatus
+	if err != nil {
+		status = HTTPStatus(err)
+	}
+	if status != 0 {
+		span.SetAttributes(attribute.Int("http.status_code", status))
+	}
+	telemetry.EndSpan(span, err)
+}
@@ -0,0 +1,67 @@
+package azure
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DefaultVoice is the neural voice used for check-in questions when none is configured
+const DefaultVoice = "hu-HU-NoemiNeural"
+
+// supportedVoices maps the neural voices the service may be configured with to their language
+var supportedVoices = map[string]string{
+	"hu-HU-NoemiNeural":  "hu-HU",
+	"hu-HU-TamasNeural":  "hu-HU",
+	"en-US-JennyNeural":  "en-US",
+	"en-US-GuyNeural":    "en-US",
+	"en-GB-SoniaNeural":  "en-GB",
+	"en-GB-RyanNeural":   "en-GB",
+	"de-DE-KatjaNeural":  "de-DE",
+	"de-DE-ConradNeural": "de-DE",
+}
+
+// defaultVoices is the voice used per language when a request does not name one
+var defaultVoices = map[string]string{
+	"hu-HU": DefaultVoice,
+	"en-US": "en-US-JennyNeural",
+	"en-GB": "en-GB-SoniaNeural",
+	"de-DE": "de-DE-KatjaNeural",
+}
+
+// SupportedVoices returns the allowed voice names in sorted order
+func SupportedVoices() []string {
+	voices := make([]string, 0, len(supportedVoices))
+	for voice := range supportedVoices {
+		voices = append(voices, voice)
+	}
+	sort.Strings(voices)
+	return voices
+}
+
+// ValidateVoice returns an error if voice is not on the allowlist
+func ValidateVoice(voice string) error {
+	if _, ok := supportedVoices[voice]; !ok {
+		return fmt.Errorf("unsupported voice %q, supported voices: %v", voice, SupportedVoices())
+	}
+	return nil
+}
+
+// resolveVoice returns the voice to synthesize language with. An empty voice selects
+// the language default; a named voice must be supported and speak language.
+func resolveVoice(language, voice string) (string, error) {
+	if voice == "" {
+		voice, ok := defaultVoices[language]
+		if !ok {
+			return "", fmt.Errorf("no default voice for language %s", language)
+		}
+		return voice, nil
+	}
+
+	if err := ValidateVoice(voice); err != nil {
+		return "", err
+	}
+	if voiceLanguage := supportedVoices[voice]; voiceLanguage != language {
+		return "", fmt.Errorf("voice %s speaks %s, not %s", voice, voiceLanguage, language)
+	}
+	return voice, nil
+}
@@ -1,25 +1,44 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 )
 
 // Config holds all application configuration
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Azure    AzureConfig
-	Logging  LoggingConfig
+	Server      ServerConfig
+	Database    DatabaseConfig
+	Azure       AzureConfig
+	CheckIn     CheckInConfig
+	Medication  MedicationConfig
+	Report      ReportConfig
+	RateLimit   RateLimitConfig
+	Auth        AuthConfig
+	Usage       UsageConfig
+	Telemetry   TelemetryConfig
+	Tracing     TracingConfig
+	Delivery    DeliveryConfig
+	Audit       AuditConfig
+	Retention   RetentionConfig
+	Diagnostics DiagnosticsConfig
+	Anomaly     AnomalyConfig
+	Logging     LoggingConfig
 }
 
 // ServerConfig holds server-related configuration
 type ServerConfig struct {
-	Port            string
-	Environment     string
-	ShutdownTimeout time.Duration
+	Port             string
+	Environment      string
+	ShutdownTimeout  time.Duration
+	IdempotencyTTL   time.Duration // how long the response of a request with an X-Idempotency-Key is replayed
+	IdempotencyStore string        // where idempotent responses are kept: postgres, redis or memory
+	RedisURL         string        // Redis connection URL, required by the redis idempotency store; shares rate limits when set
 }
 
 // DatabaseConfig holds database connection configuration
@@ -28,13 +47,33 @@ type DatabaseConfig struct {
 	MaxOpenConns    int
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
+	// ReplicaURL is an optional read replica serving report data collection, GDPR
+	// exports, dashboard aggregates and admin metrics; empty reads from URL only
+	ReplicaURL string
+	// ReplicaMaxLag is the replication lag above which reads fall back to the primary
+	ReplicaMaxLag time.Duration
+	// ReplicaHealthInterval is how often the replica's health and lag are checked
+	ReplicaHealthInterval time.Duration
 }
 
 // AzureConfig holds Azure service configuration
 type AzureConfig struct {
+	// AuthMode is how the OpenAI and Blob Storage clients authenticate: "key" with the
+	// API and account keys, or "managed_identity" with an Entra ID token
+	AuthMode string
+
 	OpenAI  OpenAIConfig
 	Speech  SpeechConfig
 	Storage StorageConfig
+	Retry   RetryConfig
+}
+
+// RetryConfig holds the retry policy shared by the Azure clients
+type RetryConfig struct {
+	MaxAttempts int           // total attempts per call including the first
+	BaseDelay   time.Duration // delay before the first retry, doubled for every further retry
+	MaxDelay    time.Duration // upper bound of a single delay, including Retry-After waits
+	Jitter      float64       // fraction of each delay that is randomized, 0..1
 }
 
 // OpenAIConfig holds Azure OpenAI configuration
@@ -42,6 +81,9 @@ type OpenAIConfig struct {
 	Endpoint   string
 	APIKey     string
 	Deployment string
+
+	BreakerThreshold int           // consecutive failures that open the circuit breaker
+	BreakerCoolDown  time.Duration // how long the open breaker fails fast before probing
 }
 
 // SpeechConfig holds Azure Speech Service configuration
@@ -49,6 +91,10 @@ type SpeechConfig struct {
 	SubscriptionKey string
 	Region          string
 	Endpoint        string
+	Voice           string // neural voice for check-in questions, must be a supported voice
+
+	TTSBreakerThreshold int           // consecutive text-to-speech failures that open the circuit breaker
+	TTSBreakerCoolDown  time.Duration // how long questions are served without audio before probing
 }
 
 // StorageConfig holds Azure Blob Storage configuration
@@ -59,6 +105,234 @@ type StorageConfig struct {
 	BlobEndpoint     string
 	AudioContainer   string
 	ReportContainer  string
+	AuditContainer   string // archive of audit logs past the hot retention
+
+	// Residencies are the named storage backends organizations may require for their
+	// patients' artifacts, as name=account:reportContainer:audioContainer[:accountKey].
+	// The key is required in key auth mode.
+	Residencies []string
+}
+
+// ResidencyStorage is a named storage backend for the artifacts of organizations
+// requiring a data residency
+type ResidencyStorage struct {
+	Name            string
+	AccountName     string
+	AccountKey      string
+	ReportContainer string
+	AudioContainer  string
+}
+
+// ResidencyStorages parses the configured data residency storage backends
+func (s StorageConfig) ResidencyStorages() ([]ResidencyStorage, error) {
+	var storages []ResidencyStorage
+	seen := make(map[string]bool)
+	for _, entry := range s.Residencies {
+		name, spec, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		name = strings.TrimSpace(name)
+		parts := strings.SplitN(spec, ":", 4)
+		if !ok || name == "" || len(parts) < 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("azure.storage.residencies entries must be name=account:reportContainer:audioContainer[:accountKey]")
+		}
+		if name == "default" || seen[name] {
+			return nil, fmt.Errorf("azure.storage.residencies names must be unique and not \"default\": %q", name)
+		}
+		seen[name] = true
+
+		storage := ResidencyStorage{
+			Name:            name,
+			AccountName:     parts[0],
+			ReportContainer: parts[1],
+			AudioContainer:  parts[2],
+		}
+		if len(parts) == 4 {
+			storage.AccountKey = parts[3]
+		}
+		storages = append(storages, storage)
+	}
+	return storages, nil
+}
+
+// CheckInConfig holds check-in conversation configuration
+type CheckInConfig struct {
+	AdaptiveFollowUps bool // default for adaptive follow-up questions when a request does not specify it
+	MaxFollowUps      int  // maximum follow-up questions per session
+
+	AudioCacheMaxBytes int64  // in-memory question audio cache size, 0 disables the cache
+	AudioCacheVersion  string // bump to invalidate cached question audio
+
+	MaxAnswerDuration time.Duration // longest recorded answer accepted for transcription
+
+	ExtractionConfidenceThreshold float64 // AI extraction confidence (0-1) below which a check-in is flagged
+
+	// LegacyMedicationTaken keeps the deprecation window for the medication_taken value
+	// "partial" (renamed to "some") open: it is accepted on input and emitted alongside the new value
+	LegacyMedicationTaken bool
+
+	DebugTiming           bool          // include the per-stage latency breakdown in every check-in response
+	SlowResponseThreshold time.Duration // check-in requests slower than this log their stage breakdown, 0 disables
+
+	EventStreamsPerUser int           // live session event streams a user may hold open at once
+	EventKeepAlive      time.Duration // interval of keep-alives on idle session event streams
+}
+
+// MedicationConfig holds medication management configuration
+type MedicationConfig struct {
+	// InteractionAIFallback asks Azure OpenAI about medications missing from the bundled
+	// interaction table
+	InteractionAIFallback bool
+}
+
+// ReportConfig holds report generation configuration
+type ReportConfig struct {
+	MaxPerWindow int           // maximum reports a user may generate per window, 0 disables the limit
+	Window       time.Duration // sliding window for the per-user limit
+	DedupeWindow time.Duration // identical requests within this window return the existing report
+
+	DownloadURLTTL time.Duration // validity of signed report download URLs
+
+	Workers   int // reports generated concurrently in the background
+	QueueSize int // reports waiting for a worker before new requests are rejected
+
+	// PasswordKey is the base64 AES-256 key encrypting the passwords of queued
+	// password protected reports. Password protection is refused when it is empty.
+	PasswordKey string
+}
+
+// PasswordKeyBytes returns the decoded PasswordKey, nil when it is not set
+func (r ReportConfig) PasswordKeyBytes() ([]byte, error) {
+	if r.PasswordKey == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(r.PasswordKey)
+	if err != nil {
+		return nil, fmt.Errorf("report.passwordkey must be base64: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("report.passwordkey must be 32 bytes, got %d", len(key))
+	}
+	return key, nil
+}
+
+// RateLimitConfig holds API rate limiting configuration
+type RateLimitConfig struct {
+	GlobalRPS  int // requests per second across all clients, 0 disables the limit
+	PerUserRPS int // requests per second per user, 0 disables the limit
+
+	// Per-route token bucket limits per user (or IP), 0 disables the limit
+	AudioStreamPerMinute  int // check-in audio uploads
+	CheckInPerMinute      int // other check-in writes
+	ReportPerMinute       int // report generation
+	ReportVerifyPerMinute int // report verification code lookups
+}
+
+// AuthConfig holds bearer token validation configuration. Tokens are Azure AD B2C
+// tokens, or HS256 tokens signed with JWTSecret when it is set.
+type AuthConfig struct {
+	Enabled  bool   // require a bearer token on API endpoints
+	Issuer   string // expected iss claim
+	Audience string // expected aud claim (application client ID)
+	JWKSURL  string // signing keys of the B2C user flow
+
+	JWTSecret string // shared HS256 signing secret, replaces B2C validation when set
+
+	AdminUserIDs []string // user IDs (oid claims) allowed to use admin endpoints
+
+	RoleCacheTTL  time.Duration // how long loaded user roles are cached per instance
+	InvitationTTL time.Duration // how long organization invitation tokens stay valid
+
+	// Email address confirmation
+	EmailTokenSecret    string        // signs confirmation tokens, defaults to JWTSecret
+	EmailTokenTTL       time.Duration // how long a confirmation link stays valid
+	EmailResendInterval time.Duration // minimum time between two confirmation emails of a user
+	EmailMaxPerDay      int           // confirmation emails a user may get within 24 hours
+	EmailConfirmURL     string        // confirmation link the token is appended to
+}
+
+// UsageConfig holds per-user stored data accounting configuration
+type UsageConfig struct {
+	SoftMaxCheckIns        int64 // soft limits add a warning header on writes, 0 disables
+	SoftMaxAudioBytes      int64
+	SoftMaxAttachmentBytes int64
+	SoftMaxReportBytes     int64
+	ReconcileHour          int // UTC hour of the nightly reconciliation, -1 disables it
+}
+
+// DeliveryConfig holds care team delivery integration configuration. The SMTP server
+// is shared by all organizations' smtp integrations, which only choose recipients.
+type DeliveryConfig struct {
+	Timeout               time.Duration // bound on a single delivery attempt
+	AllowInsecureWebhooks bool          // permit http:// webhook URLs, for local development
+	WebhookWorkers        int           // user webhook deliveries sent concurrently
+	SMTPHost              string        // empty disables smtp integrations
+	SMTPPort              int
+	SMTPUsername          string
+	SMTPPassword          string
+	SMTPFrom              string
+}
+
+// AuditConfig holds audit log archiving configuration. Audit logs older than the hot
+// retention are moved to blob storage a month at a time.
+type AuditConfig struct {
+	HotRetention     time.Duration // audit logs kept in the database, 0 disables archiving
+	ArchiveRetention time.Duration // archives are kept this long, recorded in their manifest
+	ArchiveInterval  time.Duration // how often months due for archiving are checked
+	ArchiveBuckets   int           // files per archived month, users are spread over them
+	RestoreTTL       time.Duration // restored months are archived again after this long
+}
+
+// RetentionConfig holds the retention periods of sensitive health data, enforced daily
+type RetentionConfig struct {
+	AudioRetentionDays   int // conversation messages and their audio are deleted after this, 0 keeps them
+	CheckInRetentionDays int // check-ins are moved to health_check_ins_archive after this, 0 keeps them
+	Hour                 int // UTC hour of the daily enforcement, -1 disables it
+}
+
+// DiagnosticsConfig holds dependency verification configuration
+type DiagnosticsConfig struct {
+	StartupChecks bool          // verify dependencies before serving, exiting on hard failures
+	Timeout       time.Duration // bound on a single check
+}
+
+// AnomalyConfig holds the thresholds new health readings are checked against
+type AnomalyConfig struct {
+	SystolicLow       int // mmHg, low blood pressure at or below
+	PulseHigh         int // bpm
+	PulseLow          int // bpm
+	PainHigh          int // pain level out of 10
+	BaselineReadings  int // recent readings forming a user's baseline, 0 disables baseline checks
+	SystolicDeviation int // mmHg above the baseline
+	PainDeviation     int // pain levels above the baseline
+}
+
+// TelemetryConfig holds error telemetry export configuration
+type TelemetryConfig struct {
+	Exporter    string   // none, webhook or otlp
+	Endpoint    string   // webhook URL or OTLP/HTTP logs endpoint
+	Headers     []string // extra request headers as Name=Value, e.g. an API key
+	ServiceName string
+	Timeout     time.Duration
+}
+
+// TracingConfig holds distributed tracing export configuration
+type TracingConfig struct {
+	Exporter    string  // otlp or jaeger, both over OTLP/gRPC
+	Endpoint    string  // collector host:port or URL, tracing is disabled when empty
+	Insecure    bool    // export without TLS
+	ServiceName string  // service.name of the exported spans
+	SampleRatio float64 // fraction of new traces sampled, 0 to 1
+}
+
+// HeaderMap returns the configured headers keyed by name
+func (t TelemetryConfig) HeaderMap() map[string]string {
+	headers := make(map[string]string, len(t.Headers))
+	for _, h := range t.Headers {
+		name, value, ok := strings.Cut(h, "=")
+		if ok {
+			headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+		}
+	}
+	return headers
 }
 
 // LoggingConfig holds logging configuration
@@ -80,6 +354,9 @@ func Load() (*Config, error) {
 	// Bind specific environment variables
 	bindEnvVars(v)
 
+	// Startup checks default to on outside production, which needs the bound environment
+	v.SetDefault("diagnostics.startupchecks", v.GetString("server.environment") != "production")
+
 	// Unmarshal into config struct
 	var cfg Config
 	if err := v.Unmarshal(&cfg); err != nil {
@@ -100,15 +377,127 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.port", "8080")
 	v.SetDefault("server.environment", "development")
 	v.SetDefault("server.shutdowntimeout", 30*time.Second)
+	v.SetDefault("server.idempotencyttl", 24*time.Hour)
+	v.SetDefault("server.idempotencystore", "postgres")
 
 	// Database defaults
 	v.SetDefault("database.maxopenconns", 25)
 	v.SetDefault("database.maxidleconns", 5)
 	v.SetDefault("database.connmaxlifetime", 5*time.Minute)
+	v.SetDefault("database.replicamaxlag", 30*time.Second)
+	v.SetDefault("database.replicahealthinterval", 10*time.Second)
+
+	// Azure auth defaults
+	v.SetDefault("azure.authmode", "key")
 
 	// Azure Storage defaults
+	v.SetDefault("azure.speech.voice", "hu-HU-NoemiNeural")
 	v.SetDefault("azure.storage.audiocontainer", "audio-recordings")
 	v.SetDefault("azure.storage.reportcontainer", "health-reports")
+	v.SetDefault("azure.storage.auditcontainer", "audit-archive")
+
+	// Azure OpenAI circuit breaker defaults
+	v.SetDefault("azure.openai.breakerthreshold", 5)
+	v.SetDefault("azure.openai.breakercooldown", 30*time.Second)
+
+	// Azure Speech text-to-speech circuit breaker defaults
+	v.SetDefault("azure.speech.ttsbreakerthreshold", 3)
+	v.SetDefault("azure.speech.ttsbreakercooldown", time.Minute)
+
+	// Azure retry defaults
+	v.SetDefault("azure.retry.maxattempts", 3)
+	v.SetDefault("azure.retry.basedelay", 500*time.Millisecond)
+	v.SetDefault("azure.retry.maxdelay", 10*time.Second)
+	v.SetDefault("azure.retry.jitter", 0.5)
+
+	// Check-in defaults
+	v.SetDefault("checkin.adaptivefollowups", false)
+	v.SetDefault("checkin.maxfollowups", 2)
+	v.SetDefault("checkin.audiocachemaxbytes", 50*1024*1024)
+	v.SetDefault("checkin.audiocacheversion", "")
+	v.SetDefault("checkin.maxanswerduration", "5m")
+	v.SetDefault("checkin.legacymedicationtaken", true)
+	v.SetDefault("checkin.extractionconfidencethreshold", 0.6)
+	v.SetDefault("checkin.debugtiming", false)
+	v.SetDefault("checkin.slowresponsethreshold", 3*time.Second)
+	v.SetDefault("checkin.eventstreamsperuser", 5)
+	v.SetDefault("checkin.eventkeepalive", 15*time.Second)
+
+	// Medication defaults
+	v.SetDefault("medication.interactionaifallback", false)
+
+	// Report defaults
+	v.SetDefault("report.maxperwindow", 5)
+	v.SetDefault("report.window", time.Hour)
+	v.SetDefault("report.dedupewindow", 10*time.Minute)
+	v.SetDefault("report.downloadurlttl", 15*time.Minute)
+	v.SetDefault("report.workers", 2)
+	v.SetDefault("report.queuesize", 100)
+
+	// Rate limit defaults
+	v.SetDefault("ratelimit.globalrps", 200)
+	v.SetDefault("ratelimit.peruserrps", 10)
+	v.SetDefault("ratelimit.audiostreamperminute", 20)
+	v.SetDefault("ratelimit.checkinperminute", 30)
+	v.SetDefault("ratelimit.reportperminute", 5)
+	v.SetDefault("ratelimit.reportverifyperminute", 10)
+
+	// Auth defaults
+	v.SetDefault("auth.enabled", false)
+	v.SetDefault("auth.rolecachettl", "1m")
+	v.SetDefault("auth.invitationttl", "168h")
+	v.SetDefault("auth.emailtokenttl", "24h")
+	v.SetDefault("auth.emailresendinterval", "1m")
+	v.SetDefault("auth.emailmaxperday", 5)
+	v.SetDefault("auth.emailconfirmurl", "http://localhost:8080/api/v1/users/confirm-email")
+
+	// Usage defaults
+	v.SetDefault("usage.softmaxcheckins", 0)
+	v.SetDefault("usage.softmaxaudiobytes", 0)
+	v.SetDefault("usage.softmaxattachmentbytes", 0)
+	v.SetDefault("usage.softmaxreportbytes", 0)
+	v.SetDefault("usage.reconcilehour", 3)
+
+	// Telemetry defaults
+	v.SetDefault("telemetry.exporter", "none")
+	v.SetDefault("telemetry.servicename", "healthcare-backend")
+	v.SetDefault("telemetry.timeout", "5s")
+
+	// Tracing defaults; tracing stays off until an endpoint is set
+	v.SetDefault("tracing.exporter", "otlp")
+	v.SetDefault("tracing.insecure", false)
+	v.SetDefault("tracing.servicename", "healthcare-backend")
+	v.SetDefault("tracing.sampleratio", 1.0)
+
+	// Delivery defaults
+	v.SetDefault("delivery.timeout", 10*time.Second)
+	v.SetDefault("delivery.allowinsecurewebhooks", false)
+	v.SetDefault("delivery.webhookworkers", 4)
+	v.SetDefault("delivery.smtpport", 587)
+
+	// Audit archive defaults: 90 days hot, 5 years archived
+	v.SetDefault("audit.hotretention", 90*24*time.Hour)
+	v.SetDefault("audit.archiveretention", 5*365*24*time.Hour)
+	v.SetDefault("audit.archiveinterval", 24*time.Hour)
+	v.SetDefault("audit.archivebuckets", 16)
+	v.SetDefault("audit.restorettl", 7*24*time.Hour)
+
+	// Data retention defaults
+	v.SetDefault("retention.audioretentiondays", 90)
+	v.SetDefault("retention.checkinretentiondays", 730)
+	v.SetDefault("retention.hour", 3)
+
+	// Diagnostics defaults; startup checks default to on outside production, see Load
+	v.SetDefault("diagnostics.timeout", 10*time.Second)
+
+	// Anomaly detection defaults; a hypertensive crisis is always the AHA crisis category
+	v.SetDefault("anomaly.systoliclow", 90)
+	v.SetDefault("anomaly.pulsehigh", 120)
+	v.SetDefault("anomaly.pulselow", 40)
+	v.SetDefault("anomaly.painhigh", 8)
+	v.SetDefault("anomaly.baselinereadings", 5)
+	v.SetDefault("anomaly.systolicdeviation", 25)
+	v.SetDefault("anomaly.paindeviation", 3)
 
 	// Logging defaults
 	v.SetDefault("logging.level", "info")
@@ -120,25 +509,151 @@ func bindEnvVars(v *viper.Viper) {
 	// Server
 	v.BindEnv("server.port", "PORT")
 	v.BindEnv("server.environment", "ENV", "ENVIRONMENT")
+	v.BindEnv("server.idempotencyttl", "IDEMPOTENCY_KEY_TTL")
+	v.BindEnv("server.idempotencystore", "IDEMPOTENCY_STORE")
+	v.BindEnv("server.redisurl", "REDIS_URL")
 
 	// Database
 	v.BindEnv("database.url", "DATABASE_URL")
+	v.BindEnv("database.replicaurl", "DATABASE_REPLICA_URL")
+	v.BindEnv("database.replicamaxlag", "DATABASE_REPLICA_MAX_LAG")
+	v.BindEnv("database.replicahealthinterval", "DATABASE_REPLICA_HEALTH_INTERVAL")
+
+	// Azure auth
+	v.BindEnv("azure.authmode", "AZURE_AUTH_MODE")
 
 	// Azure OpenAI
 	v.BindEnv("azure.openai.endpoint", "AZURE_OPENAI_ENDPOINT")
 	v.BindEnv("azure.openai.apikey", "AZURE_OPENAI_API_KEY")
 	v.BindEnv("azure.openai.deployment", "AZURE_OPENAI_DEPLOYMENT")
+	v.BindEnv("azure.openai.breakerthreshold", "AZURE_OPENAI_BREAKER_THRESHOLD")
+	v.BindEnv("azure.openai.breakercooldown", "AZURE_OPENAI_BREAKER_COOLDOWN")
 
 	// Azure Speech
 	v.BindEnv("azure.speech.subscriptionkey", "AZURE_SPEECH_KEY")
 	v.BindEnv("azure.speech.region", "AZURE_SPEECH_REGION")
 	v.BindEnv("azure.speech.endpoint", "AZURE_SPEECH_ENDPOINT")
+	v.BindEnv("azure.speech.voice", "AZURE_SPEECH_VOICE")
+	v.BindEnv("azure.speech.ttsbreakerthreshold", "AZURE_SPEECH_TTS_BREAKER_THRESHOLD")
+	v.BindEnv("azure.speech.ttsbreakercooldown", "AZURE_SPEECH_TTS_BREAKER_COOLDOWN")
 
 	// Azure Storage
 	v.BindEnv("azure.storage.accountname", "AZURE_STORAGE_ACCOUNT_NAME")
 	v.BindEnv("azure.storage.accountkey", "AZURE_STORAGE_ACCOUNT_KEY")
 	v.BindEnv("azure.storage.connectionstring", "AZURE_STORAGE_CONNECTION_STRING")
 	v.BindEnv("azure.storage.blobendpoint", "AZURE_STORAGE_BLOB_ENDPOINT")
+	v.BindEnv("azure.storage.residencies", "AZURE_STORAGE_RESIDENCIES")
+
+	// Azure retries
+	v.BindEnv("azure.retry.maxattempts", "AZURE_RETRY_MAX_ATTEMPTS")
+	v.BindEnv("azure.retry.basedelay", "AZURE_RETRY_BASE_DELAY")
+	v.BindEnv("azure.retry.maxdelay", "AZURE_RETRY_MAX_DELAY")
+	v.BindEnv("azure.retry.jitter", "AZURE_RETRY_JITTER")
+
+	// Check-in
+	v.BindEnv("checkin.adaptivefollowups", "CHECKIN_ADAPTIVE_FOLLOWUPS")
+	v.BindEnv("checkin.maxfollowups", "CHECKIN_MAX_FOLLOWUPS")
+	v.BindEnv("checkin.audiocachemaxbytes", "AUDIO_CACHE_MAX_BYTES")
+	v.BindEnv("checkin.audiocacheversion", "AUDIO_CACHE_VERSION")
+	v.BindEnv("checkin.maxanswerduration", "CHECKIN_MAX_ANSWER_DURATION")
+	v.BindEnv("checkin.legacymedicationtaken", "CHECKIN_LEGACY_MEDICATION_TAKEN")
+	v.BindEnv("checkin.extractionconfidencethreshold", "CHECKIN_EXTRACTION_CONFIDENCE_THRESHOLD")
+	v.BindEnv("checkin.debugtiming", "CHECKIN_DEBUG_TIMING")
+	v.BindEnv("checkin.slowresponsethreshold", "CHECKIN_SLOW_RESPONSE_THRESHOLD")
+	v.BindEnv("checkin.eventstreamsperuser", "CHECKIN_EVENT_STREAMS_PER_USER")
+	v.BindEnv("checkin.eventkeepalive", "CHECKIN_EVENT_KEEPALIVE")
+
+	// Medication
+	v.BindEnv("medication.interactionaifallback", "MEDICATION_INTERACTION_AI_FALLBACK")
+
+	// Report
+	v.BindEnv("report.maxperwindow", "REPORT_MAX_PER_WINDOW")
+	v.BindEnv("report.window", "REPORT_WINDOW")
+	v.BindEnv("report.dedupewindow", "REPORT_DEDUPE_WINDOW")
+	v.BindEnv("report.downloadurlttl", "REPORT_DOWNLOAD_URL_TTL")
+	v.BindEnv("report.workers", "REPORT_WORKERS")
+	v.BindEnv("report.queuesize", "REPORT_QUEUE_SIZE")
+	v.BindEnv("report.passwordkey", "REPORT_PASSWORD_KEY")
+
+	// Rate limiting
+	v.BindEnv("ratelimit.globalrps", "RATE_LIMIT_GLOBAL_RPS")
+	v.BindEnv("ratelimit.peruserrps", "RATE_LIMIT_PER_USER_RPS")
+	v.BindEnv("ratelimit.audiostreamperminute", "RATE_LIMIT_AUDIO_STREAM_PER_MINUTE")
+	v.BindEnv("ratelimit.checkinperminute", "RATE_LIMIT_CHECKIN_PER_MINUTE")
+	v.BindEnv("ratelimit.reportperminute", "RATE_LIMIT_REPORT_PER_MINUTE")
+	v.BindEnv("ratelimit.reportverifyperminute", "RATE_LIMIT_REPORT_VERIFY_PER_MINUTE")
+
+	// Auth
+	v.BindEnv("auth.enabled", "AUTH_ENABLED")
+	v.BindEnv("auth.issuer", "AUTH_ISSUER")
+	v.BindEnv("auth.audience", "AUTH_AUDIENCE")
+	v.BindEnv("auth.jwksurl", "AUTH_JWKS_URL")
+	v.BindEnv("auth.jwtsecret", "AUTH_JWT_SECRET")
+	v.BindEnv("auth.adminuserids", "AUTH_ADMIN_USER_IDS")
+	v.BindEnv("auth.rolecachettl", "AUTH_ROLE_CACHE_TTL")
+	v.BindEnv("auth.invitationttl", "AUTH_INVITATION_TTL")
+	v.BindEnv("auth.emailtokensecret", "AUTH_EMAIL_TOKEN_SECRET")
+	v.BindEnv("auth.emailtokenttl", "AUTH_EMAIL_TOKEN_TTL")
+	v.BindEnv("auth.emailresendinterval", "AUTH_EMAIL_RESEND_INTERVAL")
+	v.BindEnv("auth.emailmaxperday", "AUTH_EMAIL_MAX_PER_DAY")
+	v.BindEnv("auth.emailconfirmurl", "AUTH_EMAIL_CONFIRM_URL")
+
+	// Usage
+	v.BindEnv("usage.softmaxcheckins", "USAGE_SOFT_MAX_CHECKINS")
+	v.BindEnv("usage.softmaxaudiobytes", "USAGE_SOFT_MAX_AUDIO_BYTES")
+	v.BindEnv("usage.softmaxattachmentbytes", "USAGE_SOFT_MAX_ATTACHMENT_BYTES")
+	v.BindEnv("usage.softmaxreportbytes", "USAGE_SOFT_MAX_REPORT_BYTES")
+	v.BindEnv("usage.reconcilehour", "USAGE_RECONCILE_HOUR")
+
+	// Telemetry
+	v.BindEnv("telemetry.exporter", "TELEMETRY_EXPORTER")
+	v.BindEnv("telemetry.endpoint", "TELEMETRY_ENDPOINT")
+	v.BindEnv("telemetry.headers", "TELEMETRY_HEADERS")
+	v.BindEnv("telemetry.servicename", "TELEMETRY_SERVICE_NAME")
+	v.BindEnv("telemetry.timeout", "TELEMETRY_TIMEOUT")
+
+	// Tracing, using the standard OpenTelemetry variable names
+	v.BindEnv("tracing.exporter", "OTEL_TRACES_EXPORTER")
+	v.BindEnv("tracing.endpoint", "OTEL_EXPORTER_OTLP_ENDPOINT")
+	v.BindEnv("tracing.insecure", "OTEL_EXPORTER_OTLP_INSECURE")
+	v.BindEnv("tracing.servicename", "OTEL_SERVICE_NAME")
+	v.BindEnv("tracing.sampleratio", "OTEL_TRACES_SAMPLER_ARG")
+
+	// Delivery
+	v.BindEnv("delivery.timeout", "DELIVERY_TIMEOUT")
+	v.BindEnv("delivery.allowinsecurewebhooks", "DELIVERY_ALLOW_INSECURE_WEBHOOKS")
+	v.BindEnv("delivery.webhookworkers", "DELIVERY_WEBHOOK_WORKERS")
+	v.BindEnv("delivery.smtphost", "DELIVERY_SMTP_HOST")
+	v.BindEnv("delivery.smtpport", "DELIVERY_SMTP_PORT")
+	v.BindEnv("delivery.smtpusername", "DELIVERY_SMTP_USERNAME")
+	v.BindEnv("delivery.smtppassword", "DELIVERY_SMTP_PASSWORD")
+	v.BindEnv("delivery.smtpfrom", "DELIVERY_SMTP_FROM")
+
+	// Audit archive
+	v.BindEnv("azure.storage.auditcontainer", "AZURE_STORAGE_AUDIT_CONTAINER")
+	v.BindEnv("audit.hotretention", "AUDIT_HOT_RETENTION")
+	v.BindEnv("audit.archiveretention", "AUDIT_ARCHIVE_RETENTION")
+	v.BindEnv("audit.archiveinterval", "AUDIT_ARCHIVE_INTERVAL")
+	v.BindEnv("audit.archivebuckets", "AUDIT_ARCHIVE_BUCKETS")
+	v.BindEnv("audit.restorettl", "AUDIT_RESTORE_TTL")
+
+	// Data retention
+	v.BindEnv("retention.audioretentiondays", "RETENTION_AUDIO_DAYS")
+	v.BindEnv("retention.checkinretentiondays", "RETENTION_CHECKIN_DAYS")
+	v.BindEnv("retention.hour", "RETENTION_HOUR")
+
+	// Diagnostics
+	v.BindEnv("diagnostics.startupchecks", "STARTUP_CHECKS")
+	v.BindEnv("diagnostics.timeout", "DIAGNOSTICS_TIMEOUT")
+
+	// Anomaly detection
+	v.BindEnv("anomaly.systoliclow", "ANOMALY_SYSTOLIC_LOW")
+	v.BindEnv("anomaly.pulsehigh", "ANOMALY_PULSE_HIGH")
+	v.BindEnv("anomaly.pulselow", "ANOMALY_PULSE_LOW")
+	v.BindEnv("anomaly.painhigh", "ANOMALY_PAIN_HIGH")
+	v.BindEnv("anomaly.baselinereadings", "ANOMALY_BASELINE_READINGS")
+	v.BindEnv("anomaly.systolicdeviation", "ANOMALY_SYSTOLIC_DEVIATION")
+	v.BindEnv("anomaly.paindeviation", "ANOMALY_PAIN_DEVIATION")
 
 	// Logging
 	v.BindEnv("logging.level", "LOG_LEVEL")
@@ -152,12 +667,20 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("database.url is required")
 	}
 
+	if c.Database.ReplicaURL != "" && c.Database.ReplicaHealthInterval <= 0 {
+		return fmt.Errorf("database.replicahealthinterval must be positive")
+	}
+
 	if c.Azure.OpenAI.Endpoint == "" {
 		return fmt.Errorf("azure.openai.endpoint is required")
 	}
 
-	if c.Azure.OpenAI.APIKey == "" {
-		return fmt.Errorf("azure.openai.apikey is required")
+	if c.Azure.AuthMode != "key" && c.Azure.AuthMode != "managed_identity" {
+		return fmt.Errorf("azure.authmode must be key or managed_identity")
+	}
+
+	if c.Azure.AuthMode == "key" && c.Azure.OpenAI.APIKey == "" {
+		return fmt.Errorf("azure.openai.apikey is required in key auth mode")
 	}
 
 	if c.Azure.OpenAI.Deployment == "" {
@@ -172,9 +695,209 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("azure.speech.region is required")
 	}
 
-	if c.Azure.Storage.ConnectionString == "" && (c.Azure.Storage.AccountName == "" || c.Azure.Storage.AccountKey == "") {
+	if c.Azure.AuthMode == "key" && c.Azure.Storage.ConnectionString == "" && (c.Azure.Storage.AccountName == "" || c.Azure.Storage.AccountKey == "") {
 		return fmt.Errorf("azure storage credentials are required (either connection string or account name + key)")
 	}
 
+	if c.Azure.AuthMode == "managed_identity" && c.Azure.Storage.AccountName == "" {
+		return fmt.Errorf("azure.storage.accountname is required in managed_identity auth mode")
+	}
+
+	residencies, err := c.Azure.Storage.ResidencyStorages()
+	if err != nil {
+		return err
+	}
+	for _, residency := range residencies {
+		if c.Azure.AuthMode == "key" && residency.AccountKey == "" {
+			return fmt.Errorf("azure.storage.residencies entry %q needs an account key in key auth mode", residency.Name)
+		}
+	}
+
+	if c.Azure.OpenAI.BreakerThreshold < 1 {
+		return fmt.Errorf("azure.openai.breakerthreshold must be at least 1")
+	}
+
+	if c.Azure.OpenAI.BreakerCoolDown <= 0 {
+		return fmt.Errorf("azure.openai.breakercooldown must be positive")
+	}
+
+	if c.Azure.Speech.TTSBreakerThreshold < 1 {
+		return fmt.Errorf("azure.speech.ttsbreakerthreshold must be at least 1")
+	}
+
+	if c.Azure.Speech.TTSBreakerCoolDown <= 0 {
+		return fmt.Errorf("azure.speech.ttsbreakercooldown must be positive")
+	}
+
+	if c.Azure.Retry.MaxAttempts < 1 {
+		return fmt.Errorf("azure.retry.maxattempts must be at least 1")
+	}
+
+	if c.Azure.Retry.BaseDelay < 0 || c.Azure.Retry.MaxDelay < c.Azure.Retry.BaseDelay {
+		return fmt.Errorf("azure.retry.basedelay must not be negative or exceed azure.retry.maxdelay")
+	}
+
+	if c.Azure.Retry.Jitter < 0 || c.Azure.Retry.Jitter > 1 {
+		return fmt.Errorf("azure.retry.jitter must be between 0 and 1")
+	}
+
+	if c.CheckIn.MaxFollowUps < 0 {
+		return fmt.Errorf("checkin.maxfollowups must not be negative")
+	}
+
+	if c.CheckIn.AudioCacheMaxBytes < 0 {
+		return fmt.Errorf("checkin.audiocachemaxbytes must not be negative")
+	}
+
+	if c.CheckIn.MaxAnswerDuration <= 0 {
+		return fmt.Errorf("checkin.maxanswerduration must be positive")
+	}
+
+	if c.CheckIn.ExtractionConfidenceThreshold < 0 || c.CheckIn.ExtractionConfidenceThreshold > 1 {
+		return fmt.Errorf("checkin.extractionconfidencethreshold must be between 0 and 1")
+	}
+
+	if c.CheckIn.SlowResponseThreshold < 0 {
+		return fmt.Errorf("checkin.slowresponsethreshold must not be negative")
+	}
+
+	if c.CheckIn.EventStreamsPerUser <= 0 {
+		return fmt.Errorf("checkin.eventstreamsperuser must be positive")
+	}
+
+	if c.CheckIn.EventKeepAlive <= 0 {
+		return fmt.Errorf("checkin.eventkeepalive must be positive")
+	}
+
+	if c.Report.MaxPerWindow < 0 {
+		return fmt.Errorf("report.maxperwindow must not be negative")
+	}
+
+	if c.Report.MaxPerWindow > 0 && c.Report.Window <= 0 {
+		return fmt.Errorf("report.window must be positive when report.maxperwindow is set")
+	}
+
+	if c.Report.DownloadURLTTL <= 0 {
+		return fmt.Errorf("report.downloadurlttl must be positive")
+	}
+
+	if c.Report.Workers <= 0 || c.Report.QueueSize <= 0 {
+		return fmt.Errorf("report.workers and report.queuesize must be positive")
+	}
+
+	if _, err := c.Report.PasswordKeyBytes(); err != nil {
+		return err
+	}
+
+	if c.RateLimit.GlobalRPS < 0 || c.RateLimit.PerUserRPS < 0 ||
+		c.RateLimit.AudioStreamPerMinute < 0 || c.RateLimit.CheckInPerMinute < 0 || c.RateLimit.ReportPerMinute < 0 ||
+		c.RateLimit.ReportVerifyPerMinute < 0 {
+		return fmt.Errorf("rate limits must not be negative")
+	}
+
+	if c.Auth.Enabled && c.Auth.JWTSecret == "" && (c.Auth.Issuer == "" || c.Auth.Audience == "" || c.Auth.JWKSURL == "") {
+		return fmt.Errorf("auth.jwtsecret, or auth.issuer, auth.audience and auth.jwksurl, are required when auth is enabled")
+	}
+
+	if c.Auth.RoleCacheTTL <= 0 || c.Auth.InvitationTTL <= 0 {
+		return fmt.Errorf("auth.rolecachettl and auth.invitationttl must be positive")
+	}
+
+	if c.Auth.EmailTokenTTL <= 0 || c.Auth.EmailResendInterval < 0 || c.Auth.EmailMaxPerDay <= 0 {
+		return fmt.Errorf("auth.emailtokenttl and auth.emailmaxperday must be positive and auth.emailresendinterval not negative")
+	}
+
+	if c.Auth.EmailConfirmURL == "" {
+		return fmt.Errorf("auth.emailconfirmurl is required")
+	}
+
+	if c.Usage.SoftMaxCheckIns < 0 || c.Usage.SoftMaxAudioBytes < 0 ||
+		c.Usage.SoftMaxAttachmentBytes < 0 || c.Usage.SoftMaxReportBytes < 0 {
+		return fmt.Errorf("usage soft limits must not be negative")
+	}
+
+	if c.Usage.ReconcileHour < -1 || c.Usage.ReconcileHour > 23 {
+		return fmt.Errorf("usage.reconcilehour must be between 0 and 23, or -1 to disable")
+	}
+
+	switch c.Telemetry.Exporter {
+	case "", "none":
+	case "webhook", "otlp":
+		if c.Telemetry.Endpoint == "" {
+			return fmt.Errorf("telemetry.endpoint is required when the %s exporter is enabled", c.Telemetry.Exporter)
+		}
+	default:
+		return fmt.Errorf("telemetry.exporter must be none, webhook or otlp")
+	}
+
+	for _, h := range c.Telemetry.Headers {
+		if !strings.Contains(h, "=") {
+			return fmt.Errorf("telemetry.headers entries must be Name=Value")
+		}
+	}
+
+	if c.Tracing.Endpoint != "" && c.Tracing.Exporter != "otlp" && c.Tracing.Exporter != "jaeger" {
+		return fmt.Errorf("tracing.exporter must be otlp or jaeger")
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		return fmt.Errorf("tracing.sampleratio must be between 0 and 1")
+	}
+
+	if c.Server.IdempotencyTTL <= 0 {
+		return fmt.Errorf("server.idempotencyttl must be positive")
+	}
+
+	switch c.Server.IdempotencyStore {
+	case "postgres", "memory":
+	case "redis":
+		if c.Server.RedisURL == "" {
+			return fmt.Errorf("server.redisurl is required when server.idempotencystore is redis")
+		}
+	default:
+		return fmt.Errorf("server.idempotencystore must be postgres, redis or memory")
+	}
+
+	if c.Delivery.Timeout <= 0 {
+		return fmt.Errorf("delivery.timeout must be positive")
+	}
+
+	if c.Delivery.WebhookWorkers <= 0 {
+		return fmt.Errorf("delivery.webhookworkers must be positive")
+	}
+
+	if c.Audit.HotRetention < 0 {
+		return fmt.Errorf("audit.hotretention must not be negative")
+	}
+
+	if c.Audit.HotRetention > 0 && (c.Audit.ArchiveRetention < c.Audit.HotRetention || c.Audit.ArchiveInterval <= 0 ||
+		c.Audit.ArchiveBuckets <= 0 || c.Audit.RestoreTTL <= 0) {
+		return fmt.Errorf("audit.archiveretention must cover audit.hotretention, and audit.archiveinterval, audit.archivebuckets and audit.restorettl must be positive")
+	}
+
+	if c.Retention.AudioRetentionDays < 0 || c.Retention.CheckInRetentionDays < 0 {
+		return fmt.Errorf("retention periods must not be negative")
+	}
+
+	if c.Retention.Hour < -1 || c.Retention.Hour > 23 {
+		return fmt.Errorf("retention.hour must be between 0 and 23, or -1 to disable")
+	}
+
+	if c.Diagnostics.Timeout <= 0 {
+		return fmt.Errorf("diagnostics.timeout must be positive")
+	}
+
+	if c.Anomaly.SystolicLow >= model.BPCrisisSystolic || c.Anomaly.PulseLow >= c.Anomaly.PulseHigh {
+		return fmt.Errorf("anomaly low thresholds must be below the high thresholds")
+	}
+
+	if c.Anomaly.BaselineReadings < 0 || c.Anomaly.SystolicDeviation <= 0 || c.Anomaly.PainDeviation <= 0 {
+		return fmt.Errorf("anomaly.baselinereadings must not be negative and the anomaly deviations must be positive")
+	}
+
+	if c.Delivery.SMTPHost != "" && c.Delivery.SMTPFrom == "" {
+		return fmt.Errorf("delivery.smtpfrom is required when delivery.smtphost is set")
+	}
+
 	return nil
 }
@@ -0,0 +1,32 @@
+package delivery
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// ConsoleSender writes messages to the application log. It is meant for trying out
+// templates without an external system.
+type ConsoleSender struct {
+	logger *zap.Logger
+	label  string
+}
+
+// NewConsoleFactory returns a Factory for console integrations. The optional label
+// setting is included in each log line to tell integrations apart.
+func NewConsoleFactory(logger *zap.Logger) Factory {
+	return func(settings map[string]string) (Sender, error) {
+		return &ConsoleSender{logger: logger, label: settings["label"]}, nil
+	}
+}
+
+// Send implements Sender
+func (s *ConsoleSender) Send(ctx context.Context, msg Message) error {
+	s.logger.Info("integration delivery",
+		zap.String("label", s.label),
+		zap.String("subject", msg.Subject),
+		zap.String("body", msg.Body),
+	)
+	return nil
+}
@@ -0,0 +1,93 @@
+// Package delivery sends rendered messages to external systems such as a care team's
+// mailbox or messaging webhook. Each integration kind is built from per-organization
+// settings through a Registry.
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Integration kinds
+const (
+	KindConsole = "console"
+	KindWebhook = "webhook"
+	KindSMTP    = "smtp"
+)
+
+var (
+	// ErrUnknownKind is returned for an integration kind that is not registered
+	ErrUnknownKind = errors.New("unknown integration kind")
+
+	// ErrInvalidSettings is returned when an integration's settings are missing or malformed
+	ErrInvalidSettings = errors.New("invalid integration settings")
+)
+
+// Message is a rendered delivery. Subject is ignored by integrations without one.
+type Message struct {
+	Subject string
+	Body    string
+}
+
+// Sender delivers messages to one configured destination
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Factory builds a Sender from an integration's settings, returning an error wrapping
+// ErrInvalidSettings when they are incomplete
+type Factory func(settings map[string]string) (Sender, error)
+
+// Registry maps integration kinds to the factories that build their senders
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+}
+
+// NewRegistry creates an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{factories: make(map[string]Factory)}
+}
+
+// Register adds or replaces the factory of an integration kind
+func (r *Registry) Register(kind string, factory Factory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[kind] = factory
+}
+
+// Build creates a Sender of the given kind
+func (r *Registry) Build(kind string, settings map[string]string) (Sender, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[kind]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKind, kind)
+	}
+	return factory(settings)
+}
+
+// Kinds returns the registered integration kinds in alphabetical order
+func (r *Registry) Kinds() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	kinds := make([]string, 0, len(r.factories))
+	for kind := range r.factories {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// requireSetting returns a non-empty setting or an ErrInvalidSettings error naming it
+func requireSetting(settings map[string]string, name string) (string, error) {
+	value := settings[name]
+	if value == "" {
+		return "", fmt.Errorf("%w: %s is required", ErrInvalidSettings, name)
+	}
+	return value, nil
+}
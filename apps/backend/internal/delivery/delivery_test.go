@@ -0,0 +1,149 @@
+package delivery
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRegistry_Build(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(KindConsole, NewConsoleFactory(zap.NewNop()))
+	registry.Register(KindWebhook, NewWebhookFactory(http.DefaultClient, false))
+
+	assert.Equal(t, []string{KindConsole, KindWebhook}, registry.Kinds())
+
+	_, err := registry.Build(KindSMTP, map[string]string{"to": "care@example.com"})
+	assert.ErrorIs(t, err, ErrUnknownKind, "smtp is only available when registered")
+
+	sender, err := registry.Build(KindConsole, nil)
+	require.NoError(t, err)
+	assert.NoError(t, sender.Send(context.Background(), Message{Subject: "s", Body: "b"}))
+}
+
+func TestWebhookFactory_ValidatesURL(t *testing.T) {
+	secure := NewWebhookFactory(http.DefaultClient, false)
+
+	for name, settings := range map[string]map[string]string{
+		"missing url":  {},
+		"relative url": {"url": "/hooks/care"},
+		"plain http":   {"url": "http://chat.example.com/hook"},
+	} {
+		_, err := secure(settings)
+		assert.ErrorIs(t, err, ErrInvalidSettings, name)
+	}
+
+	_, err := secure(map[string]string{"url": "https://chat.example.com/hook"})
+	assert.NoError(t, err)
+
+	_, err = NewWebhookFactory(http.DefaultClient, true)(map[string]string{"url": "http://localhost:8080/hook"})
+	assert.NoError(t, err, "insecure webhooks are allowed for local development")
+}
+
+func TestWebhookSender_Send(t *testing.T) {
+	var gotBody, gotContentType string
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		gotContentType = r.Header.Get("Content-Type")
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sender, err := NewWebhookFactory(server.Client(), true)(map[string]string{"url": server.URL, "content_type": "text/plain"})
+	require.NoError(t, err)
+
+	require.NoError(t, sender.Send(context.Background(), Message{Subject: "ignored", Body: "hello care team"}))
+	assert.Equal(t, "hello care team", gotBody)
+	assert.Equal(t, "text/plain", gotContentType)
+
+	status = http.StatusBadGateway
+	err = sender.Send(context.Background(), Message{Body: "again"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "502")
+}
+
+func TestSMTPFactory_ParsesRecipients(t *testing.T) {
+	factory := NewSMTPFactory(SMTPConfig{Host: "smtp.example.com", Port: 587, From: "noreply@example.com"})
+
+	_, err := factory(map[string]string{})
+	assert.ErrorIs(t, err, ErrInvalidSettings)
+
+	_, err = factory(map[string]string{"to": "not an address"})
+	assert.ErrorIs(t, err, ErrInvalidSettings)
+
+	sender, err := factory(map[string]string{"to": "Care Team <care@example.com>, nurse@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"care@example.com", "nurse@example.com"}, sender.(*SMTPSender).to)
+}
+
+func TestSMTPSender_BuildMessage(t *testing.T) {
+	sender := &SMTPSender{cfg: SMTPConfig{From: "noreply@example.com"}, to: []string{"care@example.com"}}
+
+	raw := string(sender.buildMessage(Message{Subject: "Napi bejelentkezés", Body: "line one\nline two"}, time.Unix(0, 0).UTC()))
+
+	headers, body, ok := strings.Cut(raw, "\r\n\r\n")
+	require.True(t, ok)
+	assert.Contains(t, headers, "To: care@example.com")
+	assert.Contains(t, headers, "Subject: =?utf-8?q?")
+	assert.Contains(t, headers, "Content-Type: text/plain; charset=utf-8")
+	assert.Equal(t, "line one\r\nline two", body)
+}
+
+func TestTemplate_Render(t *testing.T) {
+	data := struct {
+		Name     string    `json:"name"`
+		Symptoms []string  `json:"symptoms"`
+		Date     time.Time `json:"-"`
+	}{Name: "Anna", Symptoms: []string{"headache", "fatigue"}, Date: time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)}
+
+	tmpl, err := ParseTemplate("Check-in\n{{date .Date}}", `{{.Name}}: {{join .Symptoms ", "}} {{json .}}`)
+	require.NoError(t, err)
+
+	msg, err := tmpl.Render(data)
+	require.NoError(t, err)
+	assert.Equal(t, "Check-in 2026-03-01", msg.Subject, "line breaks are removed from the subject")
+	assert.Equal(t, `Anna: headache, fatigue {"name":"Anna","symptoms":["headache","fatigue"]}`, msg.Body)
+
+	_, err = ParseTemplate("{{", "body")
+	assert.ErrorIs(t, err, ErrInvalidTemplate)
+
+	tmpl, err = ParseTemplate("subject", "{{.Missing}}")
+	require.NoError(t, err)
+	_, err = tmpl.Render(data)
+	assert.ErrorIs(t, err, ErrInvalidTemplate)
+}
+
+// recordingFactory returns a Factory whose senders record the settings they were built with
+func recordingFactory(built *[]map[string]string) Factory {
+	return func(settings map[string]string) (Sender, error) {
+		*built = append(*built, settings)
+		return &ConsoleSender{logger: zap.NewNop()}, nil
+	}
+}
+
+func TestEmailNotifier_NotifyEmail(t *testing.T) {
+	var smtp, console []map[string]string
+	registry := NewRegistry()
+	registry.Register(KindConsole, recordingFactory(&console))
+	notifier := NewEmailNotifier(registry)
+
+	require.NoError(t, notifier.NotifyEmail(context.Background(), "user@example.com", Message{Subject: "s", Body: "b"}))
+	require.Len(t, console, 1, "without smtp messages go to the log")
+	assert.Equal(t, "email to user@example.com", console[0]["label"])
+
+	registry.Register(KindSMTP, recordingFactory(&smtp))
+	require.NoError(t, notifier.NotifyEmail(context.Background(), "user@example.com", Message{Subject: "s", Body: "b"}))
+	require.Len(t, smtp, 1)
+	assert.Equal(t, "user@example.com", smtp[0]["to"])
+	assert.Len(t, console, 1)
+}
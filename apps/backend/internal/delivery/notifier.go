@@ -0,0 +1,30 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+)
+
+// EmailNotifier sends messages to single email addresses through a registry's smtp
+// kind. Without SMTP configured the messages are written to the log through the console
+// kind instead, so flows such as email confirmation can be tried out locally.
+type EmailNotifier struct {
+	registry *Registry
+}
+
+// NewEmailNotifier creates an EmailNotifier sending through the registry
+func NewEmailNotifier(registry *Registry) *EmailNotifier {
+	return &EmailNotifier{registry: registry}
+}
+
+// NotifyEmail sends msg to the address
+func (n *EmailNotifier) NotifyEmail(ctx context.Context, to string, msg Message) error {
+	sender, err := n.registry.Build(KindSMTP, map[string]string{"to": to})
+	if errors.Is(err, ErrUnknownKind) {
+		sender, err = n.registry.Build(KindConsole, map[string]string{"label": "email to " + to})
+	}
+	if err != nil {
+		return err
+	}
+	return sender.Send(ctx, msg)
+}
@@ -0,0 +1,63 @@
+package delivery
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ErrInvalidTemplate is returned when a subject or body template does not parse
+var ErrInvalidTemplate = errors.New("invalid template")
+
+// templateFuncs are available to every template in addition to the text/template builtins
+var templateFuncs = template.FuncMap{
+	// json encodes a value, e.g. {{json .}} for a webhook body or {{json .Mood}} for one field
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"join": strings.Join,
+	"date": func(t time.Time) string {
+		return t.Format(time.DateOnly)
+	},
+}
+
+// Template renders a Message from the data of an event
+type Template struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// ParseTemplate parses Go text/template sources for the subject and body of a message.
+// Referencing a field that does not exist fails rendering instead of printing "<no value>".
+func ParseTemplate(subject, body string) (*Template, error) {
+	subjectTmpl, err := template.New("subject").Funcs(templateFuncs).Option("missingkey=error").Parse(subject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: subject: %v", ErrInvalidTemplate, err)
+	}
+	bodyTmpl, err := template.New("body").Funcs(templateFuncs).Option("missingkey=error").Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: body: %v", ErrInvalidTemplate, err)
+	}
+	return &Template{subject: subjectTmpl, body: bodyTmpl}, nil
+}
+
+// Render executes the templates over data. Line breaks are removed from the subject.
+func (t *Template) Render(data any) (Message, error) {
+	var subject, body bytes.Buffer
+	if err := t.subject.Execute(&subject, data); err != nil {
+		return Message{}, fmt.Errorf("%w: subject: %v", ErrInvalidTemplate, err)
+	}
+	if err := t.body.Execute(&body, data); err != nil {
+		return Message{}, fmt.Errorf("%w: body: %v", ErrInvalidTemplate, err)
+	}
+
+	return Message{
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Body:    body.String(),
+	}, nil
+}
@@ -0,0 +1,68 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// defaultWebhookContentType is sent when a webhook integration does not set content_type
+const defaultWebhookContentType = "application/json"
+
+// WebhookSender posts the message body to an HTTP endpoint
+type WebhookSender struct {
+	client      *http.Client
+	url         string
+	contentType string
+}
+
+// NewWebhookFactory returns a Factory for webhook integrations. The url setting is
+// required and must be HTTPS unless allowInsecure is set; content_type defaults to
+// application/json.
+func NewWebhookFactory(client *http.Client, allowInsecure bool) Factory {
+	return func(settings map[string]string) (Sender, error) {
+		rawURL, err := requireSetting(settings, "url")
+		if err != nil {
+			return nil, err
+		}
+
+		target, err := url.Parse(rawURL)
+		if err != nil || target.Host == "" {
+			return nil, fmt.Errorf("%w: url is not an absolute URL", ErrInvalidSettings)
+		}
+		if target.Scheme != "https" && !(allowInsecure && target.Scheme == "http") {
+			return nil, fmt.Errorf("%w: url must use https", ErrInvalidSettings)
+		}
+
+		contentType := settings["content_type"]
+		if contentType == "" {
+			contentType = defaultWebhookContentType
+		}
+
+		return &WebhookSender{client: client, url: target.String(), contentType: contentType}, nil
+	}
+}
+
+// Send implements Sender. Any response other than 2xx is an error.
+func (s *WebhookSender) Send(ctx context.Context, msg Message) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, strings.NewReader(msg.Body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", s.contentType)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package fhir
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+)
+
+// LOINC codes of the exported observations
+const (
+	LOINCBloodPressurePanel = "55284-4"
+	LOINCSystolic           = "8480-6"
+	LOINCDiastolic          = "8462-4"
+	LOINCHeartRate          = "8867-4"
+	LOINCSteps              = "41950-7"
+	LOINCCaloriesBurned     = "41981-2"
+	LOINCSleepDuration      = "93832-4"
+	LOINCExerciseDuration   = "55411-3"
+)
+
+// Observation categories
+const (
+	categoryVitalSigns = "vital-signs"
+	categoryActivity   = "activity"
+)
+
+// fitnessCode maps a fitness data type to its LOINC code and observation category
+type fitnessCode struct {
+	loinc    string
+	display  string
+	category string
+}
+
+// fitnessCodes holds the fitness data types with a LOINC code; other types are exported
+// with their name as code text only
+var fitnessCodes = map[string]fitnessCode{
+	"steps":          {LOINCSteps, "Number of steps in 24 hour Measured", categoryActivity},
+	"heart_rate":     {LOINCHeartRate, "Heart rate", categoryVitalSigns},
+	"calories":       {LOINCCaloriesBurned, "Calories burned", categoryActivity},
+	"sleep":          {LOINCSleepDuration, "Sleep duration", categoryActivity},
+	"active_minutes": {LOINCExerciseDuration, "Exercise duration", categoryActivity},
+}
+
+// ucumUnits maps the units stored with fitness data to UCUM codes
+var ucumUnits = map[string]string{
+	"count":   "1",
+	"bpm":     "/min",
+	"minutes": "min",
+	"kcal":    "kcal",
+	"meters":  "m",
+}
+
+// FHIRExporter converts health data to FHIR R4 resources. Resources reference the
+// patient as urn:uuid:<user ID>, which resolves within the bundles it builds.
+type FHIRExporter struct {
+	now func() time.Time
+}
+
+// NewFHIRExporter creates a new FHIRExporter
+func NewFHIRExporter() *FHIRExporter {
+	return &FHIRExporter{now: time.Now}
+}
+
+// NewBundle creates an empty collection bundle holding the user's Patient resource
+func (e *FHIRExporter) NewBundle(userID string) *FHIRBundle {
+	bundle := &FHIRBundle{
+		ResourceType: "Bundle",
+		ID:           uuid.New().String(),
+		Type:         "collection",
+		Timestamp:    dateTime(e.now()),
+		Entry:        []FHIRBundleEntry{},
+	}
+	patient := e.Patient(userID)
+	bundle.Add(userID, &patient)
+	return bundle
+}
+
+// Patient returns the Patient resource of a user. Demographics are not stored, so the
+// patient is identified by the user ID only.
+func (e *FHIRExporter) Patient(userID string) FHIRPatient {
+	return FHIRPatient{
+		ResourceType: "Patient",
+		ID:           userID,
+		Identifier:   []FHIRIdentifier{{System: SystemUserID, Value: userID}},
+		Active:       true,
+	}
+}
+
+// BloodPressureToObservation converts a blood pressure reading to a blood pressure panel
+// observation with systolic, diastolic and, when measured, heart rate components
+func (e *FHIRExporter) BloodPressureToObservation(reading model.BloodPressureReading) FHIRObservation {
+	components := []FHIRObservationComponent{
+		{
+			Code:          loincConcept(LOINCSystolic, "Systolic blood pressure"),
+			ValueQuantity: mmHg(reading.Systolic),
+		},
+		{
+			Code:          loincConcept(LOINCDiastolic, "Diastolic blood pressure"),
+			ValueQuantity: mmHg(reading.Diastolic),
+		},
+	}
+	if reading.Pulse > 0 {
+		components = append(components, FHIRObservationComponent{
+			Code:          loincConcept(LOINCHeartRate, "Heart rate"),
+			ValueQuantity: &FHIRQuantity{Value: float64(reading.Pulse), Unit: "beats/minute", System: SystemUCUM, Code: "/min"},
+		})
+	}
+
+	return FHIRObservation{
+		ResourceType:      "Observation",
+		ID:                reading.ID,
+		Status:            "final",
+		Category:          []FHIRCodeableConcept{category(categoryVitalSigns, "Vital Signs")},
+		Code:              loincConcept(LOINCBloodPressurePanel, "Blood pressure systolic and diastolic"),
+		Subject:           patientReference(reading.UserID),
+		EffectiveDateTime: dateTime(reading.MeasuredAt),
+		Component:         components,
+	}
+}
+
+// FitnessToObservation converts a fitness data point to an observation. Data types
+// without a LOINC code keep their name as the code text.
+func (e *FHIRExporter) FitnessToObservation(point model.FitnessDataPoint) FHIRObservation {
+	code := FHIRCodeableConcept{Text: point.DataType}
+	observationCategory := category(categoryActivity, "Activity")
+	if mapped, ok := fitnessCodes[point.DataType]; ok {
+		code = loincConcept(mapped.loinc, mapped.display)
+		if mapped.category == categoryVitalSigns {
+			observationCategory = category(categoryVitalSigns, "Vital Signs")
+		}
+	}
+
+	quantity := &FHIRQuantity{Value: point.Value, Unit: point.Unit}
+	if ucum, ok := ucumUnits[point.Unit]; ok {
+		quantity.System = SystemUCUM
+		quantity.Code = ucum
+	}
+
+	var notes []FHIRAnnotation
+	if point.Source != "" {
+		notes = append(notes, FHIRAnnotation{Text: "Source: " + point.Source})
+	}
+
+	return FHIRObservation{
+		ResourceType:      "Observation",
+		ID:                point.ID,
+		Status:            "final",
+		Category:          []FHIRCodeableConcept{observationCategory},
+		Code:              code,
+		Subject:           patientReference(point.UserID),
+		EffectiveDateTime: point.Date.Format(time.DateOnly),
+		ValueQuantity:     quantity,
+		Note:              notes,
+	}
+}
+
+// MedicationToStatement converts a medication to a medication statement. Inactive
+// medications are completed when they have an end date and stopped otherwise.
+func (e *FHIRExporter) MedicationToStatement(med model.Medication) FHIRMedicationStatement {
+	status := "active"
+	if !med.Active {
+		status = "stopped"
+		if med.EndDate != nil {
+			status = "completed"
+		}
+	}
+
+	period := &FHIRPeriod{Start: med.StartDate.Format(time.DateOnly)}
+	if med.EndDate != nil {
+		period.End = med.EndDate.Format(time.DateOnly)
+	}
+
+	var dosage []FHIRDosage
+	if text := joinNonEmpty(", ", med.Dosage, med.Frequency); text != "" {
+		dosage = append(dosage, FHIRDosage{Text: text})
+	}
+
+	var notes []FHIRAnnotation
+	if med.Notes != nil && *med.Notes != "" {
+		notes = append(notes, FHIRAnnotation{Text: *med.Notes})
+	}
+
+	statement := FHIRMedicationStatement{
+		ResourceType:              "MedicationStatement",
+		ID:                        med.ID,
+		Status:                    status,
+		MedicationCodeableConcept: FHIRCodeableConcept{Text: med.Name},
+		Subject:                   patientReference(med.UserID),
+		EffectivePeriod:           period,
+		Dosage:                    dosage,
+		Note:                      notes,
+	}
+	if !med.UpdatedAt.IsZero() {
+		statement.DateAsserted = dateTime(med.UpdatedAt)
+	}
+	return statement
+}
+
+// patientReference refers to the Patient resource of a user within a bundle
+func patientReference(userID string) FHIRReference {
+	return FHIRReference{Reference: "urn:uuid:" + userID}
+}
+
+func loincConcept(code, display string) FHIRCodeableConcept {
+	return FHIRCodeableConcept{
+		Coding: []FHIRCoding{{System: SystemLOINC, Code: code, Display: display}},
+		Text:   display,
+	}
+}
+
+func category(code, display string) FHIRCodeableConcept {
+	return FHIRCodeableConcept{
+		Coding: []FHIRCoding{{System: SystemObservationCategory, Code: code, Display: display}},
+	}
+}
+
+func mmHg(value int) *FHIRQuantity {
+	return &FHIRQuantity{Value: float64(value), Unit: "mmHg", System: SystemUCUM, Code: "mm[Hg]"}
+}
+
+// dateTime formats t as a FHIR dateTime in UTC
+func dateTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func joinNonEmpty(sep string, values ...string) string {
+	var parts []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, sep)
+}
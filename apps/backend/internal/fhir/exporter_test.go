@@ -0,0 +1,131 @@
+package fhir
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+)
+
+func TestBloodPressureToObservation(t *testing.T) {
+	reading := model.BloodPressureReading{
+		ID:         "bp-1",
+		UserID:     "user-1",
+		Systolic:   131,
+		Diastolic:  85,
+		Pulse:      70,
+		MeasuredAt: time.Date(2026, 3, 31, 23, 15, 0, 0, time.FixedZone("CEST", 2*60*60)),
+	}
+
+	observation := NewFHIRExporter().BloodPressureToObservation(reading)
+
+	assert.Equal(t, "Observation", observation.ResourceType)
+	assert.Equal(t, "final", observation.Status)
+	assert.Equal(t, LOINCBloodPressurePanel, observation.Code.Coding[0].Code)
+	assert.Equal(t, SystemLOINC, observation.Code.Coding[0].System)
+	assert.Equal(t, "vital-signs", observation.Category[0].Coding[0].Code)
+	assert.Equal(t, "urn:uuid:user-1", observation.Subject.Reference)
+	assert.Equal(t, "2026-03-31T21:15:00Z", observation.EffectiveDateTime)
+
+	require.Len(t, observation.Component, 3)
+	assert.Equal(t, LOINCSystolic, observation.Component[0].Code.Coding[0].Code)
+	assert.Equal(t, FHIRQuantity{Value: 131, Unit: "mmHg", System: SystemUCUM, Code: "mm[Hg]"}, *observation.Component[0].ValueQuantity)
+	assert.Equal(t, LOINCDiastolic, observation.Component[1].Code.Coding[0].Code)
+	assert.Equal(t, 85.0, observation.Component[1].ValueQuantity.Value)
+	assert.Equal(t, LOINCHeartRate, observation.Component[2].Code.Coding[0].Code)
+
+	// Without a pulse only the pressures are exported
+	reading.Pulse = 0
+	assert.Len(t, NewFHIRExporter().BloodPressureToObservation(reading).Component, 2)
+}
+
+func TestFitnessToObservation(t *testing.T) {
+	date := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	steps := NewFHIRExporter().FitnessToObservation(model.FitnessDataPoint{
+		ID: "fit-1", UserID: "user-1", Date: date, DataType: "steps", Value: 8500, Unit: "count", Source: "health_connect",
+	})
+	assert.Equal(t, LOINCSteps, steps.Code.Coding[0].Code)
+	assert.Equal(t, "activity", steps.Category[0].Coding[0].Code)
+	assert.Equal(t, "2026-03-31", steps.EffectiveDateTime)
+	assert.Equal(t, FHIRQuantity{Value: 8500, Unit: "count", System: SystemUCUM, Code: "1"}, *steps.ValueQuantity)
+	assert.Equal(t, []FHIRAnnotation{{Text: "Source: health_connect"}}, steps.Note)
+
+	heartRate := NewFHIRExporter().FitnessToObservation(model.FitnessDataPoint{
+		ID: "fit-2", UserID: "user-1", Date: date, DataType: "heart_rate", Value: 64, Unit: "bpm",
+	})
+	assert.Equal(t, LOINCHeartRate, heartRate.Code.Coding[0].Code)
+	assert.Equal(t, "vital-signs", heartRate.Category[0].Coding[0].Code)
+	assert.Equal(t, "/min", heartRate.ValueQuantity.Code)
+
+	// Types without a LOINC code keep their name as text
+	distance := NewFHIRExporter().FitnessToObservation(model.FitnessDataPoint{
+		ID: "fit-3", UserID: "user-1", Date: date, DataType: "distance", Value: 4200, Unit: "meters",
+	})
+	assert.Empty(t, distance.Code.Coding)
+	assert.Equal(t, "distance", distance.Code.Text)
+	assert.Equal(t, "m", distance.ValueQuantity.Code)
+}
+
+func TestMedicationToStatement(t *testing.T) {
+	start := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	notes := "with food"
+
+	tests := []struct {
+		name       string
+		med        model.Medication
+		wantStatus string
+		wantEnd    string
+	}{
+		{"active", model.Medication{Active: true}, "active", ""},
+		{"ended", model.Medication{Active: false, EndDate: &end}, "completed", "2026-02-10"},
+		{"stopped", model.Medication{Active: false}, "stopped", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			med := tt.med
+			med.ID, med.UserID, med.Name = "med-1", "user-1", "Metformin"
+			med.Dosage, med.Frequency, med.StartDate, med.Notes = "500mg", "twice daily", start, &notes
+
+			statement := NewFHIRExporter().MedicationToStatement(med)
+
+			assert.Equal(t, "MedicationStatement", statement.ResourceType)
+			assert.Equal(t, tt.wantStatus, statement.Status)
+			assert.Equal(t, "Metformin", statement.MedicationCodeableConcept.Text)
+			assert.Equal(t, "urn:uuid:user-1", statement.Subject.Reference)
+			assert.Equal(t, &FHIRPeriod{Start: "2026-01-10", End: tt.wantEnd}, statement.EffectivePeriod)
+			assert.Equal(t, []FHIRDosage{{Text: "500mg, twice daily"}}, statement.Dosage)
+			assert.Equal(t, []FHIRAnnotation{{Text: "with food"}}, statement.Note)
+		})
+	}
+}
+
+func TestNewBundle(t *testing.T) {
+	exporter := NewFHIRExporter()
+	exporter.now = func() time.Time { return time.Date(2026, 4, 1, 8, 0, 0, 0, time.UTC) }
+
+	bundle := exporter.NewBundle("user-1")
+	observation := exporter.BloodPressureToObservation(model.BloodPressureReading{ID: "bp-1", UserID: "user-1"})
+	bundle.Add(observation.ID, &observation)
+
+	body, err := json.Marshal(bundle)
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	assert.Equal(t, "Bundle", decoded["resourceType"])
+	assert.Equal(t, "collection", decoded["type"])
+	assert.Equal(t, "2026-04-01T08:00:00Z", decoded["timestamp"])
+
+	entries := decoded["entry"].([]any)
+	require.Len(t, entries, 2)
+	patient := entries[0].(map[string]any)
+	assert.Equal(t, "urn:uuid:user-1", patient["fullUrl"])
+	assert.Equal(t, "Patient", patient["resource"].(map[string]any)["resourceType"])
+	assert.Equal(t, "urn:uuid:bp-1", entries[1].(map[string]any)["fullUrl"])
+}
@@ -0,0 +1,123 @@
+package fhir
+
+// ContentType is the media type of FHIR resources in JSON
+const ContentType = "application/fhir+json"
+
+// Code systems used by the exported resources
+const (
+	SystemLOINC               = "http://loinc.org"
+	SystemUCUM                = "http://unitsofmeasure.org"
+	SystemObservationCategory = "http://terminology.hl7.org/CodeSystem/observation-category"
+	SystemUserID              = "urn:eva-health:user-id"
+)
+
+// FHIRBundle is a FHIR R4 Bundle resource
+type FHIRBundle struct {
+	ResourceType string            `json:"resourceType"`
+	ID           string            `json:"id,omitempty"`
+	Type         string            `json:"type"`
+	Timestamp    string            `json:"timestamp,omitempty"`
+	Entry        []FHIRBundleEntry `json:"entry"`
+}
+
+// FHIRBundleEntry is one resource of a bundle. Resource holds a *FHIRPatient,
+// *FHIRObservation or *FHIRMedicationStatement.
+type FHIRBundleEntry struct {
+	FullURL  string `json:"fullUrl"`
+	Resource any    `json:"resource"`
+}
+
+// Add appends resource to the bundle under the fullUrl urn:uuid:<id>
+func (b *FHIRBundle) Add(id string, resource any) {
+	b.Entry = append(b.Entry, FHIRBundleEntry{FullURL: "urn:uuid:" + id, Resource: resource})
+}
+
+// FHIRPatient is a FHIR R4 Patient resource
+type FHIRPatient struct {
+	ResourceType string           `json:"resourceType"`
+	ID           string           `json:"id"`
+	Identifier   []FHIRIdentifier `json:"identifier,omitempty"`
+	Active       bool             `json:"active"`
+}
+
+// FHIRObservation is a FHIR R4 Observation resource
+type FHIRObservation struct {
+	ResourceType      string                     `json:"resourceType"`
+	ID                string                     `json:"id"`
+	Status            string                     `json:"status"`
+	Category          []FHIRCodeableConcept      `json:"category,omitempty"`
+	Code              FHIRCodeableConcept        `json:"code"`
+	Subject           FHIRReference              `json:"subject"`
+	EffectiveDateTime string                     `json:"effectiveDateTime,omitempty"`
+	ValueQuantity     *FHIRQuantity              `json:"valueQuantity,omitempty"`
+	Component         []FHIRObservationComponent `json:"component,omitempty"`
+	Note              []FHIRAnnotation           `json:"note,omitempty"`
+}
+
+// FHIRObservationComponent is one value of a panel observation, such as the systolic
+// pressure of a blood pressure reading
+type FHIRObservationComponent struct {
+	Code          FHIRCodeableConcept `json:"code"`
+	ValueQuantity *FHIRQuantity       `json:"valueQuantity,omitempty"`
+}
+
+// FHIRMedicationStatement is a FHIR R4 MedicationStatement resource
+type FHIRMedicationStatement struct {
+	ResourceType              string              `json:"resourceType"`
+	ID                        string              `json:"id"`
+	Status                    string              `json:"status"`
+	MedicationCodeableConcept FHIRCodeableConcept `json:"medicationCodeableConcept"`
+	Subject                   FHIRReference       `json:"subject"`
+	EffectivePeriod           *FHIRPeriod         `json:"effectivePeriod,omitempty"`
+	DateAsserted              string              `json:"dateAsserted,omitempty"`
+	Dosage                    []FHIRDosage        `json:"dosage,omitempty"`
+	Note                      []FHIRAnnotation    `json:"note,omitempty"`
+}
+
+// FHIRCodeableConcept is a concept given by codes and/or text
+type FHIRCodeableConcept struct {
+	Coding []FHIRCoding `json:"coding,omitempty"`
+	Text   string       `json:"text,omitempty"`
+}
+
+// FHIRCoding is a code from a code system
+type FHIRCoding struct {
+	System  string `json:"system"`
+	Code    string `json:"code"`
+	Display string `json:"display,omitempty"`
+}
+
+// FHIRQuantity is a measured amount with a UCUM unit
+type FHIRQuantity struct {
+	Value  float64 `json:"value"`
+	Unit   string  `json:"unit,omitempty"`
+	System string  `json:"system,omitempty"`
+	Code   string  `json:"code,omitempty"`
+}
+
+// FHIRReference refers to another resource
+type FHIRReference struct {
+	Reference string `json:"reference"`
+}
+
+// FHIRIdentifier is a business identifier of a resource
+type FHIRIdentifier struct {
+	System string `json:"system"`
+	Value  string `json:"value"`
+}
+
+// FHIRPeriod is a time range; an empty End means ongoing
+type FHIRPeriod struct {
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+}
+
+// FHIRDosage describes how a medication is taken
+type FHIRDosage struct {
+	Text string `json:"text"`
+}
+
+// FHIRAnnotation is a free-text note
+type FHIRAnnotation struct {
+	Text string `json:"text"`
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// AlertHandler implements emergency symptom alert endpoints
+type AlertHandler struct {
+	service *service.AlertService
+	logger  *zap.Logger
+}
+
+// NewAlertHandler creates a new AlertHandler
+func NewAlertHandler(service *service.AlertService, logger *zap.Logger) *AlertHandler {
+	return &AlertHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// toAlertResponse converts an alert model to its API representation
+func toAlertResponse(alert model.Alert) api.Alert {
+	response := api.Alert{
+		Id:             stringToUUIDValue(alert.ID),
+		UserId:         stringToUUIDValue(alert.UserID),
+		Severity:       string(alert.Severity),
+		Reason:         alert.Reason,
+		Source:         alert.Source,
+		Acknowledged:   alert.AcknowledgedAt != nil,
+		AcknowledgedAt: alert.AcknowledgedAt,
+		CreatedAt:      alert.CreatedAt,
+	}
+	if alert.CheckInID != nil {
+		response.CheckInId = stringToUUID(*alert.CheckInID)
+	}
+	return response
+}
+
+// GetAlerts lists alerts for a user
+// GET /api/v1/alerts?user_id=&include_acknowledged=
+func (h *AlertHandler) GetAlerts(c *gin.Context) {
+	userID, ok := queryUserID(c)
+	if !ok {
+		return
+	}
+
+	includeAcknowledged := c.Query("include_acknowledged") == "true"
+
+	alerts, err := h.service.ListAlerts(c.Request.Context(), userID, includeAcknowledged)
+	if err != nil {
+		h.logger.Error("failed to list alerts",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to retrieve alerts",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	response := make([]api.Alert, 0, len(alerts))
+	for _, alert := range alerts {
+		response = append(response, toAlertResponse(alert))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// AcknowledgeAlert marks an alert as reviewed
+// POST /api/v1/alerts/:id/acknowledge
+func (h *AlertHandler) AcknowledgeAlert(c *gin.Context) {
+	alertID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid alert ID",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	if err := h.service.AcknowledgeAlert(c.Request.Context(), alertID.String()); err != nil {
+		if errors.Is(err, repository.ErrAlertNotFound) {
+			c.JSON(http.StatusNotFound, api.ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "Alert not found",
+			})
+			return
+		}
+
+		h.logger.Error("failed to acknowledge alert",
+			zap.Error(err),
+			zap.String("alert_id", alertID.String()),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to acknowledge alert",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Alert acknowledged",
+		"alert_id": alertID.String(),
+	})
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"go.uber.org/zap"
+)
+
+// AnomalyHandler implements the health data anomaly endpoints
+type AnomalyHandler struct {
+	detector *service.AnomalyDetector
+	logger   *zap.Logger
+}
+
+// NewAnomalyHandler creates a new AnomalyHandler
+func NewAnomalyHandler(detector *service.AnomalyDetector, logger *zap.Logger) *AnomalyHandler {
+	return &AnomalyHandler{
+		detector: detector,
+		logger:   logger,
+	}
+}
+
+// GetAnomalies lists the anomalies detected in a user's health data newest first,
+// optionally only those observed since a date or RFC 3339 time
+// GET /api/v1/health/anomalies?user_id=&since=&limit=&cursor=
+func (h *AnomalyHandler) GetAnomalies(c *gin.Context) {
+	userID, ok := queryUserID(c)
+	if !ok {
+		return
+	}
+
+	var since time.Time
+	if raw := c.Query("since"); raw != "" {
+		parsed, err := parseSince(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "Invalid since parameter, expected YYYY-MM-DD or an RFC 3339 time",
+			})
+			return
+		}
+		since = parsed
+	}
+
+	page, ok := parsePage(c)
+	if !ok {
+		return
+	}
+
+	anomalies, total, err := h.detector.ListAnomalies(c.Request.Context(), userID, since, page)
+	if err != nil {
+		h.logger.Error("failed to list anomalies",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to retrieve anomalies",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, newPageResponse(anomalies, total, page))
+}
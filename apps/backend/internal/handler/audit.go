@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"go.uber.org/zap"
+)
+
+// AuditLogReader queries the audit trail
+type AuditLogReader interface {
+	GetAuditLogs(ctx context.Context, filter audit.AuditFilter) ([]audit.AuditLog, string, error)
+	CountAuditLogs(ctx context.Context, filter audit.AuditFilter) (int, error)
+}
+
+// AuditArchive reports and restores the audit logs moved to the archive
+type AuditArchive interface {
+	ArchivedMonths(ctx context.Context, from, to time.Time) ([]string, error)
+	Restore(ctx context.Context, month time.Time, requestedBy string) (*service.AuditArchiveRestore, error)
+}
+
+// AuditHandler implements the audit log query endpoint
+type AuditHandler struct {
+	audit   AuditLogReader
+	archive AuditArchive
+	logger  *zap.Logger
+}
+
+// auditLogPage is a page of audit logs. ArchivedMonths lists the months in the queried
+// window whose logs were archived and are therefore missing from the page until restored.
+type auditLogPage struct {
+	Items          []audit.AuditLog `json:"items"`
+	TotalCount     int              `json:"total_count"`
+	NextCursor     *string          `json:"next_cursor"`
+	ArchivedMonths []string         `json:"archived_months,omitempty"`
+}
+
+// NewAuditHandler creates a new AuditHandler
+func NewAuditHandler(audit AuditLogReader, logger *zap.Logger) *AuditHandler {
+	return &AuditHandler{
+		audit:  audit,
+		logger: logger,
+	}
+}
+
+// SetArchive enables reporting and restoring archived audit logs
+func (h *AuditHandler) SetArchive(archive AuditArchive) {
+	h.archive = archive
+}
+
+// ListAuditLogs lists audit log entries of all users newest first, filtered by user,
+// operation type, resource type, IP address and time window. It serves both the audit
+// log endpoint and the admin search, which names the window start_time and end_time.
+// The bounds accept a date or an RFC 3339 time; a date passed as the upper bound
+// includes that whole day. Pages are followed with the returned next_cursor.
+// GET /api/v1/audit/logs?user_id=&operation_type=&resource_type=&ip_address=&from=&to=&limit=&offset=&cursor=
+// GET /api/v1/admin/audit-logs?user_id=&operation_type=&resource_type=&ip_address=&start_time=&end_time=&limit=&cursor=
+func (h *AuditHandler) ListAuditLogs(c *gin.Context) {
+	filter := audit.AuditFilter{
+		UserID:        c.Query("user_id"),
+		OperationType: audit.OperationType(strings.ToUpper(c.Query("operation_type"))),
+		ResourceType:  audit.ResourceType(c.Query("resource_type")),
+		IPAddress:     c.Query("ip_address"),
+		Cursor:        c.Query("cursor"),
+	}
+
+	fromParam, raw := auditQuery(c, "from", "start_time")
+	if raw != "" {
+		from, err := parseSince(raw)
+		if err != nil {
+			respondInvalidAuditWindow(c, fromParam, err)
+			return
+		}
+		filter.From = from
+	}
+	toParam, raw := auditQuery(c, "to", "end_time")
+	if raw != "" {
+		to, err := parseSince(raw)
+		if err != nil {
+			respondInvalidAuditWindow(c, toParam, err)
+			return
+		}
+		if _, err := time.Parse(time.DateOnly, raw); err == nil {
+			to = to.AddDate(0, 0, 1)
+		}
+		filter.To = to
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: fromParam + " must be before " + toParam,
+		})
+		return
+	}
+
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > audit.MaxAuditLogLimit {
+			respondInvalidPage(c, fmt.Sprintf("limit must be between 1 and %d", audit.MaxAuditLogLimit))
+			return
+		}
+		filter.Limit = limit
+	}
+	if raw := c.Query("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			respondInvalidPage(c, "offset must be a non-negative integer")
+			return
+		}
+		filter.Offset = offset
+	}
+
+	ctx := c.Request.Context()
+	logs, next, err := h.audit.GetAuditLogs(ctx, filter)
+	if errors.Is(err, audit.ErrInvalidCursor) {
+		respondInvalidPage(c, err.Error())
+		return
+	}
+	if err != nil {
+		h.respondListError(c, err)
+		return
+	}
+	total, err := h.audit.CountAuditLogs(ctx, filter)
+	if err != nil {
+		h.respondListError(c, err)
+		return
+	}
+
+	response := auditLogPage{Items: logs, TotalCount: total}
+	if response.Items == nil {
+		response.Items = []audit.AuditLog{}
+	}
+	if next != "" {
+		response.NextCursor = &next
+	}
+	if h.archive != nil {
+		months, err := h.archive.ArchivedMonths(ctx, filter.From, filter.To)
+		if err != nil {
+			h.respondListError(c, err)
+			return
+		}
+		response.ArchivedMonths = months
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RestoreAuditArchive copies an archived month of audit logs back into the database for
+// a limited time, so that the audit log endpoint lists them again
+// POST /api/v1/admin/audit/archives/:month/restore
+func (h *AuditHandler) RestoreAuditArchive(c *gin.Context) {
+	month, err := time.Parse("2006-01", c.Param("month"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid month parameter, expected YYYY-MM",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	if h.archive == nil {
+		c.JSON(http.StatusServiceUnavailable, api.ErrorResponse{
+			Code:    "ARCHIVE_UNAVAILABLE",
+			Message: "Audit log archive is not configured",
+		})
+		return
+	}
+
+	restore, err := h.archive.Restore(c.Request.Context(), month, AuthUserID(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrAuditArchiveNotFound):
+			c.JSON(http.StatusNotFound, api.ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "No audit logs archived for this month",
+			})
+		case errors.Is(err, service.ErrAuditArchiveBusy):
+			c.JSON(http.StatusConflict, api.ErrorResponse{
+				Code:    "ARCHIVE_BUSY",
+				Message: "Audit logs are being archived or restored, try again later",
+			})
+		default:
+			h.logger.Error("failed to restore audit archive", zap.Error(err), zap.String("month", c.Param("month")))
+			c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: "Failed to restore audit archive",
+				Details: stringPtr(err.Error()),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, restore)
+}
+
+// respondListError logs a failed audit log query and writes the error response
+func (h *AuditHandler) respondListError(c *gin.Context, err error) {
+	h.logger.Error("failed to list audit logs", zap.Error(err))
+	c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+		Code:    "INTERNAL_ERROR",
+		Message: "Failed to list audit logs",
+		Details: stringPtr(err.Error()),
+	})
+}
+
+// auditQuery returns the first of the named query parameters that is set, and its value
+func auditQuery(c *gin.Context, names ...string) (string, string) {
+	for _, name := range names {
+		if value := c.Query(name); value != "" {
+			return name, value
+		}
+	}
+	return names[0], ""
+}
+
+// respondInvalidAuditWindow writes the error response for an unparsable time bound
+func respondInvalidAuditWindow(c *gin.Context, param string, err error) {
+	c.JSON(http.StatusBadRequest, api.ErrorResponse{
+		Code:    "VALIDATION_ERROR",
+		Message: "Invalid " + param + " parameter, expected YYYY-MM-DD or an RFC 3339 time",
+		Details: stringPtr(err.Error()),
+	})
+}
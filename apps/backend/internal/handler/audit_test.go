@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRestoreAuditArchive_InvalidMonth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := NewAuditHandler(nil, zap.NewNop())
+	router := gin.New()
+	router.POST("/admin/audit/archives/:month/restore", h.RestoreAuditArchive)
+
+	for _, month := range []string{"2025-13", "2025-1", "january", "2025-01-01"} {
+		t.Run(month, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/admin/audit/archives/"+month+"/restore", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestListAuditLogs_InvalidRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := NewAuditHandler(nil, zap.NewNop())
+	router := gin.New()
+	router.GET("/admin/audit-logs", h.ListAuditLogs)
+
+	for name, query := range map[string]string{
+		"invalid from":              "from=yesterday",
+		"invalid start_time":        "start_time=yesterday",
+		"invalid end_time":          "end_time=2024-13-01",
+		"from after to":             "from=2024-03-02&to=2024-03-01",
+		"start_time after end_time": "start_time=2024-03-02&end_time=2024-03-01",
+		"limit too large":           "limit=501",
+		"limit not a number":        "limit=all",
+		"negative offset":           "offset=-1",
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/audit-logs?"+query, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
@@ -1,13 +1,17 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 	"go.uber.org/zap"
 )
 
@@ -15,6 +19,13 @@ import (
 type CheckInHandler struct {
 	service *service.CheckInService
 	logger  *zap.Logger
+
+	legacyMedicationTaken bool
+	debugTiming           bool
+	slowResponseThreshold time.Duration
+
+	caregivers     CaregiverChecker
+	eventKeepAlive time.Duration
 }
 
 // NewCheckInHandler creates a new CheckInHandler
@@ -25,6 +36,25 @@ func NewCheckInHandler(service *service.CheckInService, logger *zap.Logger) *Che
 	}
 }
 
+// SetLegacyMedicationTaken adds the deprecated "partial" spelling of the medication_taken
+// value "some" to check-in responses during its deprecation window
+func (h *CheckInHandler) SetLegacyMedicationTaken(enabled bool) {
+	h.legacyMedicationTaken = enabled
+}
+
+// questionAudioStatus tells clients whether a question comes with audio, so they can
+// show a text-only notice while speech synthesis is failing. Both are nil when the
+// response carries no question.
+func questionAudioStatus(questionID string, available bool, reason string) (*bool, *string) {
+	if questionID == "" {
+		return nil, nil
+	}
+	if reason == "" {
+		return boolPtr(available), nil
+	}
+	return boolPtr(available), stringPtr(reason)
+}
+
 // PostApiV1CheckinStart starts a new check-in session
 func (h *CheckInHandler) PostApiV1CheckinStart(c *gin.Context) {
 	var req api.StartSessionRequest
@@ -39,9 +69,30 @@ func (h *CheckInHandler) PostApiV1CheckinStart(c *gin.Context) {
 	}
 
 	userID := uuidToString(req.UserId)
+	if !authorizeUser(c, userID) {
+		return
+	}
 
 	// Start session
 	sessionWithAudio, err := h.service.StartSession(c.Request.Context(), userID)
+	var activeSession *service.ActiveSessionExistsError
+	if errors.As(err, &activeSession) {
+		c.JSON(http.StatusConflict, api.ActiveSessionExistsResponse{
+			Code:      "ACTIVE_SESSION_EXISTS",
+			Message:   "An active check-in session already exists; resume it instead",
+			Error:     "active_session_exists",
+			SessionId: stringToUUIDValue(activeSession.SessionID),
+		})
+		return
+	}
+	if errors.Is(err, service.ErrConsentRequired) {
+		c.JSON(http.StatusForbidden, api.ErrorResponse{
+			Code:    "CONSENT_REQUIRED",
+			Message: "Voice recording consent is required to start a check-in",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
 	if err != nil {
 		h.logger.Error("failed to start session",
 			zap.Error(err),
@@ -64,7 +115,9 @@ func (h *CheckInHandler) PostApiV1CheckinStart(c *gin.Context) {
 		Status:       &status,
 		UserId:       stringToUUID(userID),
 		StartedAt:    timePtr(sessionWithAudio.Session.StartedAt),
+		Voice:        stringPtr(h.service.Voice()),
 	}
+	response.AudioAvailable, response.AudioError = questionAudioStatus(sessionWithAudio.QuestionID, sessionWithAudio.AudioAvailable, sessionWithAudio.AudioError)
 
 	h.logger.Info("check-in session started",
 		zap.String("session_id", sessionWithAudio.Session.ID),
@@ -77,11 +130,16 @@ func (h *CheckInHandler) PostApiV1CheckinStart(c *gin.Context) {
 // PostApiV1CheckinAudioStream handles audio streaming for real-time transcription
 func (h *CheckInHandler) PostApiV1CheckinAudioStream(c *gin.Context, params api.PostApiV1CheckinAudioStreamParams) {
 	sessionID := params.SessionId.String()
+	if !h.authorizeSession(c, sessionID) {
+		return
+	}
 
 	h.logger.Info("audio stream started",
 		zap.String("session_id", sessionID),
 	)
 
+	timings := startTimings(c)
+
 	// Read audio stream from request body
 	audioStream := c.Request.Body
 	defer c.Request.Body.Close()
@@ -93,6 +151,23 @@ func (h *CheckInHandler) PostApiV1CheckinAudioStream(c *gin.Context, params api.
 			zap.Error(err),
 			zap.String("session_id", sessionID),
 		)
+		var tooLong *azure.AnswerTooLongError
+		if errors.As(err, &tooLong) {
+			c.JSON(http.StatusRequestEntityTooLarge, api.ErrorResponse{
+				Code:    "AUDIO_TOO_LONG",
+				Message: fmt.Sprintf("Answers can be at most %s long", tooLong.MaxDuration),
+				Details: stringPtr(err.Error()),
+			})
+			return
+		}
+		if code, message, ok := rejectedAudio(err); ok {
+			c.JSON(http.StatusUnprocessableEntity, api.ErrorResponse{
+				Code:    code,
+				Message: message,
+				Details: stringPtr(err.Error()),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
 			Code:    "INTERNAL_ERROR",
 			Message: "Failed to transcribe audio",
@@ -106,9 +181,27 @@ func (h *CheckInHandler) PostApiV1CheckinAudioStream(c *gin.Context, params api.
 		zap.Int("transcription_length", len(transcription)),
 	)
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"transcription": transcription,
-	})
+	}
+	if debug := h.finishTimings(c, "audio_stream", timings); debug != nil {
+		response["debug_timing"] = debug
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// rejectedAudio returns the error code and message for audio the speech client refused
+// to transcribe
+func rejectedAudio(err error) (code, message string, ok bool) {
+	switch {
+	case errors.Is(err, azure.ErrAudioTooShort):
+		return "AUDIO_TOO_SHORT", "The recording is too short to transcribe", true
+	case errors.Is(err, azure.ErrAudioSilent):
+		return "AUDIO_SILENT", "No sound was detected in the recording", true
+	case errors.Is(err, azure.ErrInvalidAudioFormat):
+		return "INVALID_AUDIO_FORMAT", "Audio must be a PCM WAV recording", true
+	}
+	return "", "", false
 }
 
 // PostApiV1CheckinRespond processes user response and returns next question
@@ -125,6 +218,9 @@ func (h *CheckInHandler) PostApiV1CheckinRespond(c *gin.Context) {
 	}
 
 	sessionID := uuidToString(req.SessionId)
+	if !h.authorizeSession(c, sessionID) {
+		return
+	}
 
 	// Validate request
 	if req.Response == "" {
@@ -136,7 +232,10 @@ func (h *CheckInHandler) PostApiV1CheckinRespond(c *gin.Context) {
 	}
 
 	// Process response
-	conversationState, err := h.service.ProcessResponse(c.Request.Context(), sessionID, req.Response)
+	timings := startTimings(c)
+	conversationState, err := h.service.ProcessResponseWithOptions(c.Request.Context(), sessionID, req.Response, service.ResponseOptions{
+		AdaptiveFollowUps: req.Adaptive,
+	})
 	if err != nil {
 		h.logger.Error("failed to process response",
 			zap.Error(err),
@@ -156,11 +255,16 @@ func (h *CheckInHandler) PostApiV1CheckinRespond(c *gin.Context) {
 		QuestionText: stringPtr(conversationState.QuestionText),
 		QuestionId:   stringPtr(conversationState.QuestionID),
 		IsComplete:   boolPtr(conversationState.IsComplete),
+		IsFollowup:   boolPtr(conversationState.IsFollowUp),
+		DebugTiming:  h.finishTimings(c, "respond", timings),
 	}
+	response.AudioAvailable, response.AudioError = questionAudioStatus(conversationState.QuestionID, conversationState.AudioAvailable, conversationState.AudioError)
 
 	h.logger.Info("response processed",
 		zap.String("session_id", sessionID),
 		zap.Bool("is_complete", conversationState.IsComplete),
+		zap.Bool("is_followup", conversationState.IsFollowUp),
+		zap.Bool("audio_available", conversationState.AudioAvailable),
 	)
 
 	c.JSON(http.StatusOK, response)
@@ -169,6 +273,9 @@ func (h *CheckInHandler) PostApiV1CheckinRespond(c *gin.Context) {
 // GetApiV1CheckinStatusSessionId retrieves session status
 func (h *CheckInHandler) GetApiV1CheckinStatusSessionId(c *gin.Context, sessionId uuid.UUID) {
 	sessionIDStr := sessionId.String()
+	if !h.authorizeSession(c, sessionIDStr) {
+		return
+	}
 
 	h.logger.Info("getting session status",
 		zap.String("session_id", sessionIDStr),
@@ -205,6 +312,9 @@ func (h *CheckInHandler) GetApiV1CheckinStatusSessionId(c *gin.Context, sessionI
 // GetApiV1CheckinQuestionAudioSessionIdQuestionId retrieves question audio
 func (h *CheckInHandler) GetApiV1CheckinQuestionAudioSessionIdQuestionId(c *gin.Context, sessionId uuid.UUID, questionId string) {
 	sessionIDStr := sessionId.String()
+	if !h.authorizeSession(c, sessionIDStr) {
+		return
+	}
 
 	h.logger.Info("getting question audio",
 		zap.String("session_id", sessionIDStr),
@@ -212,6 +322,7 @@ func (h *CheckInHandler) GetApiV1CheckinQuestionAudioSessionIdQuestionId(c *gin.
 	)
 
 	// Get question audio
+	timings := startTimings(c)
 	audioData, err := h.service.GetQuestionAudio(c.Request.Context(), sessionIDStr, questionId)
 	if err != nil {
 		h.logger.Error("failed to get question audio",
@@ -227,6 +338,10 @@ func (h *CheckInHandler) GetApiV1CheckinQuestionAudioSessionIdQuestionId(c *gin.
 		return
 	}
 
+	if h.finishTimings(c, "question_audio", timings) != nil {
+		c.Header("Server-Timing", serverTiming(timings))
+	}
+
 	// Return audio as WAV
 	c.Header("Content-Type", "audio/wav")
 	c.Header("Content-Length", fmt.Sprintf("%d", len(audioData)))
@@ -247,6 +362,9 @@ func (h *CheckInHandler) PostApiV1CheckinComplete(c *gin.Context) {
 	}
 
 	sessionID := uuidToString(req.SessionId)
+	if !h.authorizeSession(c, sessionID) {
+		return
+	}
 
 	// Complete session
 	healthCheckIn, err := h.service.CompleteSession(c.Request.Context(), sessionID)
@@ -255,6 +373,22 @@ func (h *CheckInHandler) PostApiV1CheckinComplete(c *gin.Context) {
 			zap.Error(err),
 			zap.String("session_id", sessionID),
 		)
+		if errors.Is(err, azure.ErrCircuitOpen) {
+			c.JSON(http.StatusServiceUnavailable, api.ErrorResponse{
+				Code:    "EXTRACTION_UNAVAILABLE",
+				Message: "Data extraction is temporarily unavailable, the transcript was saved for re-extraction",
+				Details: stringPtr(err.Error()),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrCompletionInProgress) || errors.Is(err, service.ErrConcurrentCompletionFailed) {
+			c.JSON(http.StatusConflict, api.ErrorResponse{
+				Code:    "COMPLETION_IN_PROGRESS",
+				Message: "The session is being completed by another request, retry shortly",
+				Details: stringPtr(err.Error()),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
 			Code:    "INTERNAL_ERROR",
 			Message: "Failed to complete check-in session",
@@ -263,21 +397,32 @@ func (h *CheckInHandler) PostApiV1CheckinComplete(c *gin.Context) {
 		return
 	}
 
-	// Convert to API response
+	h.logger.Info("check-in session completed",
+		zap.String("session_id", sessionID),
+		zap.String("check_in_id", healthCheckIn.ID),
+	)
+
+	c.JSON(http.StatusOK, h.checkInResponse(healthCheckIn))
+}
+
+// checkInResponse converts a saved check-in to its API response
+func (h *CheckInHandler) checkInResponse(healthCheckIn *model.HealthCheckIn) api.HealthCheckInResponse {
+	medicationTaken, medicationTakenLegacy := h.medicationTakenValues(healthCheckIn.MedicationTaken)
 	response := api.HealthCheckInResponse{
-		Id:               stringToUUID(healthCheckIn.ID),
-		UserId:           stringToUUID(healthCheckIn.UserID),
-		CheckInDate:      timeToDate(healthCheckIn.CheckInDate),
-		Symptoms:         &healthCheckIn.Symptoms,
-		Mood:             (*api.HealthCheckInResponseMood)(healthCheckIn.Mood),
-		PainLevel:        healthCheckIn.PainLevel,
-		EnergyLevel:      (*api.HealthCheckInResponseEnergyLevel)(healthCheckIn.EnergyLevel),
-		SleepQuality:     (*api.HealthCheckInResponseSleepQuality)(healthCheckIn.SleepQuality),
-		MedicationTaken:  (*api.HealthCheckInResponseMedicationTaken)(healthCheckIn.MedicationTaken),
-		PhysicalActivity: &healthCheckIn.PhysicalActivity,
-		GeneralFeeling:   healthCheckIn.GeneralFeeling,
-		AdditionalNotes:  healthCheckIn.AdditionalNotes,
-		CreatedAt:        timePtr(healthCheckIn.CreatedAt),
+		Id:                    stringToUUID(healthCheckIn.ID),
+		UserId:                stringToUUID(healthCheckIn.UserID),
+		CheckInDate:           timeToDate(healthCheckIn.CheckInDate),
+		Symptoms:              &healthCheckIn.Symptoms,
+		Mood:                  (*api.HealthCheckInResponseMood)(healthCheckIn.Mood),
+		PainLevel:             healthCheckIn.PainLevel,
+		EnergyLevel:           (*api.HealthCheckInResponseEnergyLevel)(healthCheckIn.EnergyLevel),
+		SleepQuality:          (*api.HealthCheckInResponseSleepQuality)(healthCheckIn.SleepQuality),
+		MedicationTaken:       medicationTaken,
+		PhysicalActivity:      &healthCheckIn.PhysicalActivity,
+		MedicationTakenLegacy: medicationTakenLegacy,
+		GeneralFeeling:        healthCheckIn.GeneralFeeling,
+		AdditionalNotes:       healthCheckIn.AdditionalNotes,
+		CreatedAt:             timePtr(healthCheckIn.CreatedAt),
 	}
 
 	// Add meals as nested struct
@@ -293,10 +438,20 @@ func (h *CheckInHandler) PostApiV1CheckinComplete(c *gin.Context) {
 		}
 	}
 
-	h.logger.Info("check-in session completed",
-		zap.String("session_id", sessionID),
-		zap.String("check_in_id", healthCheckIn.ID),
-	)
+	return response
+}
 
-	c.JSON(http.StatusOK, response)
+// medicationTakenValues returns the medication_taken value of a response, normalized
+// to "some", and its deprecated spelling while the deprecation window is open
+func (h *CheckInHandler) medicationTakenValues(value *string) (*api.HealthCheckInResponseMedicationTaken, *string) {
+	if value == nil {
+		return nil, nil
+	}
+
+	normalized, _ := model.NormalizeMedicationTaken(*value)
+	medicationTaken := api.HealthCheckInResponseMedicationTaken(normalized)
+	if h.legacyMedicationTaken && normalized == model.MedicationTakenSome {
+		return &medicationTaken, stringPtr(model.MedicationTakenPartial)
+	}
+	return &medicationTaken, nil
 }
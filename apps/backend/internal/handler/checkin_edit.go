@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"go.uber.org/zap"
+)
+
+// PutCheckin corrects the structured fields of a completed check-in and returns the
+// updated check-in. The raw transcript of a check-in cannot be edited.
+// PUT /api/v1/checkin/:id
+func (h *CheckInHandler) PutCheckin(c *gin.Context) {
+	checkInID, ok := uuidParam(c, "id", "Invalid check-in ID")
+	if !ok {
+		return
+	}
+
+	var patch service.CheckInPatch
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	checkIn, err := h.service.GetCheckIn(c.Request.Context(), checkInID)
+	if err != nil {
+		h.respondCheckInEditError(c, err, checkInID)
+		return
+	}
+	if !authorizeUser(c, checkIn.UserID) {
+		return
+	}
+
+	checkIn, err = h.service.UpdateCheckIn(c.Request.Context(), checkInID, patch)
+	if err != nil {
+		h.respondCheckInEditError(c, err, checkInID)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.checkInResponse(checkIn))
+}
+
+// respondCheckInEditError writes the response of a failed check-in correction
+func (h *CheckInHandler) respondCheckInEditError(c *gin.Context, err error, checkInID string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidCheckInPatch):
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid check-in correction",
+			Details: stringPtr(err.Error()),
+		})
+	case errors.Is(err, repository.ErrHealthCheckInNotFound):
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Check-in not found",
+			Details: stringPtr(err.Error()),
+		})
+	case errors.Is(err, service.ErrCheckInAwaitingExtraction):
+		c.JSON(http.StatusConflict, api.ErrorResponse{
+			Code:    "AWAITING_EXTRACTION",
+			Message: "Check-in is awaiting re-extraction",
+			Details: stringPtr(err.Error()),
+		})
+	default:
+		h.logger.Error("failed to correct check-in",
+			zap.Error(err),
+			zap.String("check_in_id", checkInID),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to correct check-in",
+			Details: stringPtr(err.Error()),
+		})
+	}
+}
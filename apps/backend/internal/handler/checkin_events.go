@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// defaultEventKeepAlive is the keep-alive interval of session event streams unless configured
+const defaultEventKeepAlive = 15 * time.Second
+
+// sessionEventKeepAlive is the type of the keep-alive lines of NDJSON event streams
+const sessionEventKeepAlive = "keep_alive"
+
+// CaregiverChecker reports whether a user is a caregiver of a patient
+type CaregiverChecker interface {
+	IsCaregiverOf(ctx context.Context, caregiverID, patientID string) (bool, error)
+}
+
+// SetCaregiverChecker lets caregivers of a session's owner follow its event stream
+func (h *CheckInHandler) SetCaregiverChecker(caregivers CaregiverChecker) {
+	h.caregivers = caregivers
+}
+
+// SetEventKeepAlive sets how often idle session event streams send a keep-alive
+func (h *CheckInHandler) SetEventKeepAlive(interval time.Duration) {
+	h.eventKeepAlive = interval
+}
+
+// GetCheckinEvents streams the events of a check-in session as newline-delimited JSON,
+// or as server-sent events when the client accepts text/event-stream. The stream ends
+// after the session completes or expires.
+// GET /api/v1/checkin/:sessionId/events
+func (h *CheckInHandler) GetCheckinEvents(c *gin.Context) {
+	sessionID, ok := uuidParam(c, "sessionId", "Invalid session ID format")
+	if !ok {
+		return
+	}
+
+	session, err := h.service.GetSession(c.Request.Context(), sessionID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Session not found",
+		})
+		return
+	}
+	if !h.authorizeSessionViewer(c, session) {
+		return
+	}
+
+	subscriberID := AuthUserID(c)
+	if subscriberID == "" {
+		subscriberID = session.UserID
+	}
+	events, cancel, err := h.service.SubscribeSessionEvents(session.ID, subscriberID)
+	if errors.Is(err, service.ErrTooManyStreams) {
+		c.JSON(http.StatusTooManyRequests, api.ErrorResponse{
+			Code:    "TOO_MANY_STREAMS",
+			Message: "Too many open session event streams",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, api.ErrorResponse{
+			Code:    "EVENTS_UNAVAILABLE",
+			Message: "Session event streams are not available",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+	defer cancel()
+
+	stream := newSessionEventStream(c)
+	h.logger.Info("session event stream opened",
+		zap.String("session_id", session.ID),
+		zap.String("subscriber_id", subscriberID),
+		zap.Bool("sse", stream.sse),
+	)
+
+	// The session may have ended before the subscription; its terminal event is gone then
+	if session, err = h.service.GetSession(c.Request.Context(), session.ID); err == nil {
+		if event, ended := sessionEndEvent(session); ended {
+			stream.send(event)
+			return
+		}
+	}
+
+	keepAlive := h.eventKeepAlive
+	if keepAlive <= 0 {
+		keepAlive = defaultEventKeepAlive
+	}
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				// Dropped for falling behind; the client reconnects
+				return
+			}
+			if err := stream.send(event); err != nil || event.Terminal() {
+				return
+			}
+		case <-ticker.C:
+			if err := stream.keepAlive(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// authorizeSessionViewer checks that an authenticated caller owns the session or is a
+// caregiver of its owner. Unauthenticated requests pass through; it writes the error
+// response and returns false on failure.
+func (h *CheckInHandler) authorizeSessionViewer(c *gin.Context, session *model.Session) bool {
+	callerID := AuthUserID(c)
+	if callerID == "" || callerID == session.UserID || h.caregivers == nil {
+		return authorizeUser(c, session.UserID)
+	}
+
+	caregiver, err := h.caregivers.IsCaregiverOf(c.Request.Context(), callerID, session.UserID)
+	if err != nil {
+		h.logger.Error("failed to check caregiver access",
+			zap.Error(err),
+			zap.String("session_id", session.ID),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to check access to the session",
+		})
+		return false
+	}
+	if caregiver {
+		return true
+	}
+	return authorizeUser(c, session.UserID)
+}
+
+// sessionEndEvent returns the terminal event of a session that already ended
+func sessionEndEvent(session *model.Session) (service.SessionEvent, bool) {
+	event := service.SessionEvent{SessionID: session.ID}
+	switch session.Status {
+	case model.SessionStatusCompleted:
+		event.Type = service.SessionEventCompleted
+		event.OccurredAt = derefTime(session.CompletedAt)
+	case model.SessionStatusExpired:
+		event.Type = service.SessionEventExpired
+		event.OccurredAt = derefTime(session.ExpiredAt)
+	default:
+		return event, false
+	}
+	return event, true
+}
+
+// sessionEventStream writes session events to the response as NDJSON or server-sent events
+type sessionEventStream struct {
+	c   *gin.Context
+	sse bool
+}
+
+// newSessionEventStream starts the streaming response in the format the client accepts
+func newSessionEventStream(c *gin.Context) *sessionEventStream {
+	stream := &sessionEventStream{
+		c:   c,
+		sse: strings.Contains(c.GetHeader("Accept"), "text/event-stream"),
+	}
+
+	if stream.sse {
+		c.Header("Content-Type", "text/event-stream")
+	} else {
+		c.Header("Content-Type", "application/x-ndjson")
+	}
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+	return stream
+}
+
+// send writes one event
+func (s *sessionEventStream) send(event service.SessionEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if s.sse {
+		return s.write(fmt.Sprintf("event: %s\ndata: %s\n\n", event.Type, data))
+	}
+	return s.write(string(data) + "\n")
+}
+
+// keepAlive writes a line clients skip, keeping proxies from closing an idle stream
+func (s *sessionEventStream) keepAlive() error {
+	if s.sse {
+		return s.write(": keep-alive\n\n")
+	}
+	return s.write(`{"type":"` + sessionEventKeepAlive + `"}` + "\n")
+}
+
+func (s *sessionEventStream) write(text string) error {
+	if _, err := s.c.Writer.WriteString(text); err != nil {
+		return err
+	}
+	s.c.Writer.Flush()
+	return nil
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"go.uber.org/zap"
+)
+
+// PostCheckinPause pauses an active check-in session
+// POST /api/v1/checkin/pause
+func (h *CheckInHandler) PostCheckinPause(c *gin.Context) {
+	sessionID, ok := h.bindSessionRequest(c)
+	if !ok {
+		return
+	}
+
+	session, err := h.service.PauseSession(c.Request.Context(), sessionID)
+	if err != nil {
+		h.logger.Error("failed to pause session",
+			zap.Error(err),
+			zap.String("session_id", sessionID),
+		)
+		h.respondSessionStateError(c, err, "Failed to pause check-in session")
+		return
+	}
+
+	c.JSON(http.StatusOK, api.PauseSessionResponse{
+		SessionId: stringToUUIDValue(session.ID),
+		Status:    api.PauseSessionResponseStatus(session.Status),
+		PausedAt:  session.PausedAt,
+	})
+}
+
+// PostCheckinResume resumes a paused check-in session and returns the question
+// the conversation left off at
+// POST /api/v1/checkin/resume
+func (h *CheckInHandler) PostCheckinResume(c *gin.Context) {
+	sessionID, ok := h.bindSessionRequest(c)
+	if !ok {
+		return
+	}
+
+	sessionWithAudio, err := h.service.ResumeSession(c.Request.Context(), sessionID)
+	if err != nil {
+		h.logger.Error("failed to resume session",
+			zap.Error(err),
+			zap.String("session_id", sessionID),
+		)
+		h.respondSessionStateError(c, err, "Failed to resume check-in session")
+		return
+	}
+
+	status := api.SessionResponseStatus(sessionWithAudio.Session.Status)
+	response := api.SessionResponse{
+		SessionId:    stringToUUID(sessionWithAudio.Session.ID),
+		QuestionText: stringPtr(sessionWithAudio.QuestionText),
+		QuestionId:   stringPtr(sessionWithAudio.QuestionID),
+		Status:       &status,
+		UserId:       stringToUUID(sessionWithAudio.Session.UserID),
+		StartedAt:    timePtr(sessionWithAudio.Session.StartedAt),
+	}
+	response.AudioAvailable, response.AudioError = questionAudioStatus(sessionWithAudio.QuestionID, sessionWithAudio.AudioAvailable, sessionWithAudio.AudioError)
+	if len(sessionWithAudio.QuestionAudio) > 0 {
+		response.QuestionAudio = &sessionWithAudio.QuestionAudio
+	}
+
+	h.logger.Info("check-in session resumed",
+		zap.String("session_id", sessionID),
+		zap.String("question_id", sessionWithAudio.QuestionID),
+	)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// bindSessionRequest validates the session ID of a request body and checks that
+// the caller owns the session. It writes the error response and returns false on failure.
+func (h *CheckInHandler) bindSessionRequest(c *gin.Context) (string, bool) {
+	var req api.SessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return "", false
+	}
+	if req.SessionId == uuid.Nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid session ID format",
+			Details: stringPtr("session_id is required"),
+		})
+		return "", false
+	}
+
+	session, err := h.service.GetSession(c.Request.Context(), req.SessionId.String())
+	if err != nil {
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Session not found",
+		})
+		return "", false
+	}
+
+	if !authorizeUser(c, session.UserID) {
+		return "", false
+	}
+
+	return session.ID, true
+}
+
+// authorizeSession checks that an authenticated caller owns the session. Unauthenticated
+// requests pass through; it writes the error response and returns false on failure.
+func (h *CheckInHandler) authorizeSession(c *gin.Context, sessionID string) bool {
+	if AuthUserID(c) == "" {
+		return true
+	}
+
+	session, err := h.service.GetSession(c.Request.Context(), sessionID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Session not found",
+		})
+		return false
+	}
+
+	return authorizeUser(c, session.UserID)
+}
+
+// respondSessionStateError maps session state errors to 409 responses
+func (h *CheckInHandler) respondSessionStateError(c *gin.Context, err error, message string) {
+	var code string
+	switch {
+	case errors.Is(err, service.ErrSessionNotActive):
+		code = "SESSION_NOT_ACTIVE"
+	case errors.Is(err, service.ErrSessionNotPaused):
+		code = "SESSION_NOT_PAUSED"
+	case errors.Is(err, service.ErrSessionExpired):
+		code = "SESSION_EXPIRED"
+	default:
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: message,
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusConflict, api.ErrorResponse{
+		Code:    code,
+		Message: message,
+		Details: stringPtr(err.Error()),
+	})
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"go.uber.org/zap"
+)
+
+// PostCheckinReExtract runs data extraction again on the raw transcript saved when a
+// session's extraction failed and returns the updated check-in
+// POST /api/v1/checkin/re-extract
+func (h *CheckInHandler) PostCheckinReExtract(c *gin.Context) {
+	sessionID, ok := h.bindSessionRequest(c)
+	if !ok {
+		return
+	}
+
+	healthCheckIn, err := h.service.ReExtractSession(c.Request.Context(), sessionID)
+	if err != nil {
+		h.logger.Error("failed to re-extract session",
+			zap.Error(err),
+			zap.String("session_id", sessionID),
+		)
+
+		switch {
+		case errors.Is(err, service.ErrRawTranscriptNotFound):
+			c.JSON(http.StatusNotFound, api.ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "No raw transcript found for session",
+				Details: stringPtr(err.Error()),
+			})
+		case errors.Is(err, azure.ErrCircuitOpen):
+			c.JSON(http.StatusServiceUnavailable, api.ErrorResponse{
+				Code:    "EXTRACTION_UNAVAILABLE",
+				Message: "Data extraction is temporarily unavailable",
+				Details: stringPtr(err.Error()),
+			})
+		case errors.Is(err, service.ErrExtractionExists):
+			c.JSON(http.StatusConflict, api.ErrorResponse{
+				Code:    "EXTRACTION_EXISTS",
+				Message: "Session already has an extracted check-in",
+				Details: stringPtr(err.Error()),
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: "Failed to re-extract check-in session",
+				Details: stringPtr(err.Error()),
+			})
+		}
+		return
+	}
+
+	h.logger.Info("check-in session re-extracted",
+		zap.String("session_id", sessionID),
+		zap.String("check_in_id", healthCheckIn.ID),
+	)
+
+	c.JSON(http.StatusOK, h.checkInResponse(healthCheckIn))
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+func TestCheckInHandler_MedicationTakenValues(t *testing.T) {
+	partial, some, yes := "partial", "some", "yes"
+
+	tests := []struct {
+		name       string
+		legacy     bool
+		value      *string
+		wantValue  string
+		wantLegacy string
+	}{
+		{"legacy spelling normalized during window", true, &partial, "some", "partial"},
+		{"new spelling emits both during window", true, &some, "some", "partial"},
+		{"only new spelling after window", false, &partial, "some", ""},
+		{"other values have no legacy spelling", true, &yes, "yes", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewCheckInHandler(nil, zap.NewNop())
+			h.SetLegacyMedicationTaken(tt.legacy)
+
+			value, legacy := h.medicationTakenValues(tt.value)
+			require.NotNil(t, value)
+			assert.Equal(t, tt.wantValue, string(*value))
+			if tt.wantLegacy == "" {
+				assert.Nil(t, legacy)
+			} else {
+				require.NotNil(t, legacy)
+				assert.Equal(t, tt.wantLegacy, *legacy)
+			}
+		})
+	}
+
+	value, legacy := NewCheckInHandler(nil, zap.NewNop()).medicationTakenValues(nil)
+	assert.Nil(t, value)
+	assert.Nil(t, legacy)
+}
+
+func TestHealthCheckInResponse_EmitsLegacyField(t *testing.T) {
+	h := NewCheckInHandler(nil, zap.NewNop())
+	h.SetLegacyMedicationTaken(true)
+
+	some := "some"
+	response := api.HealthCheckInResponse{}
+	response.MedicationTaken, response.MedicationTakenLegacy = h.medicationTakenValues(&some)
+
+	body, err := json.Marshal(response)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"medication_taken":"some"`)
+	assert.Contains(t, string(body), `"medication_taken_legacy":"partial"`)
+}
+
+func TestRejectedAudio(t *testing.T) {
+	for err, want := range map[error]string{
+		azure.ErrAudioTooShort:      "AUDIO_TOO_SHORT",
+		azure.ErrAudioSilent:        "AUDIO_SILENT",
+		azure.ErrInvalidAudioFormat: "INVALID_AUDIO_FORMAT",
+	} {
+		code, message, ok := rejectedAudio(fmt.Errorf("transcription failed: %w", err))
+		assert.True(t, ok)
+		assert.Equal(t, want, code)
+		assert.NotEmpty(t, message)
+	}
+
+	_, _, ok := rejectedAudio(errors.New("recognition failed with status: NoMatch"))
+	assert.False(t, ok)
+}
+
+func TestSessionEndEvent(t *testing.T) {
+	completedAt := time.Date(2026, 3, 4, 8, 30, 0, 0, time.UTC)
+
+	event, ended := sessionEndEvent(&model.Session{ID: "session-1", Status: model.SessionStatusCompleted, CompletedAt: &completedAt})
+	require.True(t, ended)
+	assert.Equal(t, service.SessionEventCompleted, event.Type)
+	assert.Equal(t, "session-1", event.SessionID)
+	assert.Equal(t, completedAt, event.OccurredAt)
+
+	event, ended = sessionEndEvent(&model.Session{ID: "session-1", Status: model.SessionStatusExpired})
+	require.True(t, ended)
+	assert.Equal(t, service.SessionEventExpired, event.Type)
+
+	for _, status := range []model.SessionStatus{model.SessionStatusActive, model.SessionStatusPaused} {
+		_, ended = sessionEndEvent(&model.Session{ID: "session-1", Status: status})
+		assert.False(t, ended, status)
+	}
+}
+
+func TestPutCheckin_RejectsInvalidRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.PUT("/api/v1/checkin/:id", NewCheckInHandler(nil, zap.NewNop()).PutCheckin)
+
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{"invalid ID", "/api/v1/checkin/not-a-uuid", `{"pain_level":3}`},
+		{"malformed body", "/api/v1/checkin/6f1c2a4e-8d2b-4c3f-9a1e-2b7d5c8e9f01", `{"pain_level":"three"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
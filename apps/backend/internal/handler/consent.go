@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// ConsentHandler implements user consent endpoints
+type ConsentHandler struct {
+	service *service.ConsentService
+	logger  *zap.Logger
+}
+
+// NewConsentHandler creates a new ConsentHandler
+func NewConsentHandler(service *service.ConsentService, logger *zap.Logger) *ConsentHandler {
+	return &ConsentHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// consentRequest grants or revokes one of a user's consents
+type consentRequest struct {
+	UserID      string `json:"user_id" binding:"required,uuid"`
+	ConsentType string `json:"consent_type" binding:"required"`
+	Granted     *bool  `json:"granted" binding:"required"`
+}
+
+// SetConsent grants or revokes a consent of the user
+// POST /api/v1/consents
+func (h *ConsentHandler) SetConsent(c *gin.Context) {
+	var req consentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	userID := uuid.MustParse(req.UserID).String()
+	if !authorizeUser(c, userID) {
+		return
+	}
+
+	consentType := model.ConsentType(req.ConsentType)
+	var consent *model.UserConsent
+	var err error
+	if *req.Granted {
+		consent, err = h.service.GrantConsent(c.Request.Context(), userID, consentType)
+	} else {
+		consent, err = h.service.RevokeConsent(c.Request.Context(), userID, consentType)
+	}
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidConsentType) {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "Invalid consent type",
+				Details: stringPtr(err.Error()),
+			})
+			return
+		}
+		h.logger.Error("failed to set consent",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to update consent",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, consent)
+}
+
+// ListConsents returns the state of every consent of the user
+// GET /api/v1/consents?user_id=
+func (h *ConsentHandler) ListConsents(c *gin.Context) {
+	userID, ok := queryUserID(c)
+	if !ok {
+		return
+	}
+
+	consents, err := h.service.ListConsents(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("failed to list consents",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to list consents",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"consents": consents})
+}
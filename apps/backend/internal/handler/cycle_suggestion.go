@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"go.uber.org/zap"
+)
+
+// CycleSuggestionHandler implements the endpoints suggesting menstruation cycles for
+// check-ins with menstrual symptoms outside any recorded cycle
+type CycleSuggestionHandler struct {
+	service *service.CycleConsistencyService
+	logger  *zap.Logger
+}
+
+// NewCycleSuggestionHandler creates a new CycleSuggestionHandler
+func NewCycleSuggestionHandler(service *service.CycleConsistencyService, logger *zap.Logger) *CycleSuggestionHandler {
+	return &CycleSuggestionHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ListCycleSuggestions checks the user's recent check-ins and lists the open cycle suggestions
+// GET /api/v1/users/:id/cycle-suggestions
+func (h *CycleSuggestionHandler) ListCycleSuggestions(c *gin.Context) {
+	userID, ok := uuidParam(c, "id", "Invalid user ID format")
+	if !ok || !authorizeUser(c, userID) {
+		return
+	}
+
+	suggestions, err := h.service.GetSuggestions(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, err, userID, "Failed to get cycle suggestions")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"suggestions": suggestions})
+}
+
+// AcceptCycleSuggestion logs the cycle a suggestion proposes
+// POST /api/v1/users/:id/cycle-suggestions/:suggestion_id/accept
+func (h *CycleSuggestionHandler) AcceptCycleSuggestion(c *gin.Context) {
+	userID, suggestionID, ok := h.suggestionParams(c)
+	if !ok {
+		return
+	}
+
+	cycle, err := h.service.AcceptSuggestion(c.Request.Context(), userID, suggestionID)
+	if err != nil {
+		if !h.respondSuggestionError(c, err) {
+			h.respondError(c, err, userID, "Failed to accept cycle suggestion")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, cycle)
+}
+
+// DismissCycleSuggestion dismisses a suggestion so it is not raised again
+// POST /api/v1/users/:id/cycle-suggestions/:suggestion_id/dismiss
+func (h *CycleSuggestionHandler) DismissCycleSuggestion(c *gin.Context) {
+	userID, suggestionID, ok := h.suggestionParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DismissSuggestion(c.Request.Context(), userID, suggestionID); err != nil {
+		if !h.respondSuggestionError(c, err) {
+			h.respondError(c, err, userID, "Failed to dismiss cycle suggestion")
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// suggestionParams parses the user and suggestion IDs of a suggestion route and
+// authorizes the user
+func (h *CycleSuggestionHandler) suggestionParams(c *gin.Context) (string, string, bool) {
+	userID, ok := uuidParam(c, "id", "Invalid user ID format")
+	if !ok || !authorizeUser(c, userID) {
+		return "", "", false
+	}
+	suggestionID, ok := uuidParam(c, "suggestion_id", "Invalid suggestion ID format")
+	if !ok {
+		return "", "", false
+	}
+	return userID, suggestionID, true
+}
+
+// respondSuggestionError writes the response of a missing or resolved suggestion and
+// reports whether err was one
+func (h *CycleSuggestionHandler) respondSuggestionError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, repository.ErrCycleSuggestionNotFound):
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Cycle suggestion not found",
+		})
+	case errors.Is(err, service.ErrCycleSuggestionResolved):
+		c.JSON(http.StatusConflict, api.ErrorResponse{
+			Code:    "SUGGESTION_RESOLVED",
+			Message: "Cycle suggestion was already accepted or dismissed",
+			Details: stringPtr(err.Error()),
+		})
+	default:
+		return false
+	}
+	return true
+}
+
+// respondError logs a failed suggestion operation and writes the error response
+func (h *CycleSuggestionHandler) respondError(c *gin.Context, err error, userID, message string) {
+	h.logger.Error("cycle suggestion operation failed",
+		zap.Error(err),
+		zap.String("user_id", userID),
+	)
+	c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+		Code:    "INTERNAL_ERROR",
+		Message: message,
+		Details: stringPtr(err.Error()),
+	})
+}
@@ -1,11 +1,16 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 	"go.uber.org/zap"
 )
 
@@ -26,6 +31,9 @@ func NewDashboardHandler(service *service.DashboardService, logger *zap.Logger)
 // GetApiV1DashboardSummary retrieves dashboard summary
 func (h *DashboardHandler) GetApiV1DashboardSummary(c *gin.Context, params api.GetApiV1DashboardSummaryParams) {
 	userID := uuidToString(params.UserId)
+	if !authorizeUser(c, userID) {
+		return
+	}
 
 	// Default to 7 days if not specified
 	days := 7
@@ -33,8 +41,12 @@ func (h *DashboardHandler) GetApiV1DashboardSummary(c *gin.Context, params api.G
 		days = int(*params.Days)
 	}
 
-	// Get dashboard summary
-	summary, err := h.service.GetSummary(c.Request.Context(), userID, days)
+	// compare_previous is not part of the generated params
+	opts := service.SummaryOptions{ComparePrevious: c.Query("compare_previous") == "true"}
+
+	// Get dashboard summary; the aggregates may be read from the read replica
+	ctx := repository.WithReadReplica(c.Request.Context())
+	summary, err := h.service.GetSummaryWithOptions(ctx, userID, days, opts)
 	if err != nil {
 		h.logger.Error("failed to get dashboard summary",
 			zap.Error(err),
@@ -51,9 +63,24 @@ func (h *DashboardHandler) GetApiV1DashboardSummary(c *gin.Context, params api.G
 
 	// Convert to API response
 	response := api.DashboardSummary{
-		Period:       stringPtr(summary.Period),
-		AveragePain:  &summary.AveragePain,
-		CheckInCount: intPtr(summary.CheckInCount),
+		Period:                  stringPtr(summary.Period),
+		AveragePain:             &summary.AveragePain,
+		CheckInCount:            intPtr(summary.CheckInCount),
+		Alerts:                  toDashboardAlerts(summary.Alerts),
+		AdherenceScores:         toMedicationAdherence(summary.AdherenceScores),
+		Adherence:               toAdherenceSummary(summary.Adherence),
+		LowConfidenceRate:       summary.LowConfidenceRate,
+		Comparison:              toSummaryComparison(summary.Comparison),
+		PainTrend:               toMetricTrend(summary.PainTrend),
+		MoodTrend:               toMetricTrend(summary.MoodTrend),
+		CheckInCountTrend:       toMetricTrend(summary.CheckInCountTrend),
+		BloodPressureCategories: toBloodPressureCategoryCounts(summary.BloodPressureCategories),
+		BloodPressureTrend:      toBloodPressureTrend(summary.BloodPressureTrend),
+		AverageSleepMinutes:     summary.AverageSleepMinutes,
+		LatestWeightKg:          summary.LatestWeightKg,
+	}
+	if len(summary.MedicationTaken) > 0 {
+		response.MedicationTaken = &summary.MedicationTaken
 	}
 
 	// Convert mood distribution
@@ -106,6 +133,122 @@ func (h *DashboardHandler) GetApiV1DashboardSummary(c *gin.Context, params api.G
 	c.JSON(http.StatusOK, response)
 }
 
+// toDashboardAlerts converts the alerts block of a dashboard summary
+func toDashboardAlerts(alerts *repository.AlertSummary) *api.DashboardAlerts {
+	if alerts == nil {
+		return nil
+	}
+	latest := make([]api.Alert, 0, len(alerts.Latest))
+	for _, alert := range alerts.Latest {
+		latest = append(latest, toAlertResponse(alert))
+	}
+	return &api.DashboardAlerts{
+		Unacknowledged: alerts.Unacknowledged,
+		Critical:       alerts.Critical,
+		Latest:         latest,
+	}
+}
+
+// toMedicationAdherence converts the schedule based adherence scores of a dashboard summary
+func toMedicationAdherence(scores []repository.MedicationAdherence) *[]api.MedicationAdherence {
+	if len(scores) == 0 {
+		return nil
+	}
+	response := make([]api.MedicationAdherence, 0, len(scores))
+	for _, score := range scores {
+		response = append(response, api.MedicationAdherence{
+			MedicationId: stringToUUIDValue(score.MedicationID),
+			Name:         score.Name,
+			Taken:        score.Taken,
+			Expected:     score.Expected,
+			Score:        score.Score,
+		})
+	}
+	return &response
+}
+
+// toAdherenceSummary converts the frequency based adherence of a dashboard summary
+func toAdherenceSummary(adherence *service.AdherenceSummary) *api.AdherenceSummary {
+	if adherence == nil {
+		return nil
+	}
+	medications := make([]api.MedicationAdherenceRate, 0, len(adherence.Medications))
+	for _, medication := range adherence.Medications {
+		medications = append(medications, api.MedicationAdherenceRate{
+			MedicationId: stringToUUIDValue(medication.MedicationID),
+			Name:         medication.Name,
+			Frequency:    medication.Frequency,
+			Expected:     medication.Expected,
+			Taken:        medication.Taken,
+			Rate:         medication.Rate,
+		})
+	}
+	return &api.AdherenceSummary{Rate: adherence.Rate, Medications: medications}
+}
+
+// toSummaryComparison converts the previous period comparison of a dashboard summary
+func toSummaryComparison(comparison *service.SummaryComparison) *api.SummaryComparison {
+	if comparison == nil {
+		return nil
+	}
+	return &api.SummaryComparison{
+		PainDelta:              comparison.PainDelta,
+		MoodImprovementPercent: comparison.MoodImprovementPercent,
+		CheckInCountDelta:      comparison.CheckInCountDelta,
+		EnergyDelta:            comparison.EnergyDelta,
+	}
+}
+
+// toMetricTrend converts a trend of a dashboard summary
+func toMetricTrend(trend service.MetricTrend) api.MetricTrend {
+	return api.MetricTrend{
+		Previous:      trend.Previous,
+		Delta:         trend.Delta,
+		PercentChange: trend.PercentChange,
+	}
+}
+
+// toBloodPressureCategoryCounts converts the readings per blood pressure category, nil
+// without readings
+func toBloodPressureCategoryCounts(counts map[model.BPCategory]int) *api.BloodPressureCategoryCounts {
+	if len(counts) == 0 {
+		return nil
+	}
+	count := func(category model.BPCategory) *int {
+		if n, ok := counts[category]; ok {
+			return intPtr(n)
+		}
+		return nil
+	}
+	return &api.BloodPressureCategoryCounts{
+		Normal:   count(model.BPCategoryNormal),
+		Elevated: count(model.BPCategoryElevated),
+		Stage1:   count(model.BPCategoryStage1),
+		Stage2:   count(model.BPCategoryStage2),
+		Crisis:   count(model.BPCategoryCrisis),
+	}
+}
+
+// toBloodPressureTrend converts the blood pressure trend of a dashboard summary
+func toBloodPressureTrend(trend *service.BloodPressureTrend) *api.BloodPressureTrend {
+	if trend == nil {
+		return nil
+	}
+	response := &api.BloodPressureTrend{
+		AverageSystolic:          trend.AverageSystolic,
+		AverageDiastolic:         trend.AverageDiastolic,
+		PreviousAverageSystolic:  trend.PreviousAverageSystolic,
+		PreviousAverageDiastolic: trend.PreviousAverageDiastolic,
+		SystolicDelta:            trend.SystolicDelta,
+		DiastolicDelta:           trend.DiastolicDelta,
+	}
+	if trend.Direction != "" {
+		direction := api.BloodPressureTrendDirection(trend.Direction)
+		response.Direction = &direction
+	}
+	return response
+}
+
 // intPtrFromMap safely gets an int pointer from a map
 func intPtrFromMap(m map[string]int, key string) *int {
 	if val, ok := m[key]; ok {
@@ -113,3 +256,69 @@ func intPtrFromMap(m map[string]int, key string) *int {
 	}
 	return nil
 }
+
+// maxExportDays bounds the days of a dashboard time-series export
+const maxExportDays = 365
+
+// GetDashboardExport streams the daily metrics backing the dashboard charts as CSV or
+// JSON, for the last days days (default 30)
+// GET /api/v1/dashboard/export
+func (h *DashboardHandler) GetDashboardExport(c *gin.Context) {
+	userID, ok := queryUserID(c)
+	if !ok {
+		return
+	}
+
+	format := c.DefaultQuery("format", service.TimeSeriesFormatCSV)
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 1 || days > maxExportDays {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: fmt.Sprintf("days must be a number between 1 and %d", maxExportDays),
+		})
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == service.TimeSeriesFormatJSON {
+		contentType = "application/json; charset=utf-8"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=dashboard_%dd.%s", days, format))
+
+	// The daily metrics may be read from the read replica
+	ctx := repository.WithReadReplica(c.Request.Context())
+	err = h.service.ExportTimeSeries(ctx, userID, days, format, c.Writer)
+	if err == nil {
+		c.Status(http.StatusOK)
+		return
+	}
+
+	h.logger.Error("failed to export dashboard time series",
+		zap.Error(err),
+		zap.String("user_id", userID),
+		zap.String("format", format),
+	)
+
+	if c.Writer.Written() {
+		// Part of the file has been sent; the truncated response is all that can be done
+		c.Abort()
+		return
+	}
+
+	c.Writer.Header().Del("Content-Type")
+	c.Writer.Header().Del("Content-Disposition")
+	if errors.Is(err, service.ErrUnsupportedExportFormat) {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "format must be csv or json",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+		Code:    "INTERNAL_ERROR",
+		Message: "Failed to export dashboard data",
+		Details: stringPtr(err.Error()),
+	})
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"go.uber.org/zap"
+)
+
+// DiagnosticsHandler implements the dependency diagnostics endpoint
+type DiagnosticsHandler struct {
+	service *service.DiagnosticsService
+	logger  *zap.Logger
+}
+
+// NewDiagnosticsHandler creates a new DiagnosticsHandler
+func NewDiagnosticsHandler(service *service.DiagnosticsService, logger *zap.Logger) *DiagnosticsHandler {
+	return &DiagnosticsHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetDiagnostics runs the startup checks against the live dependencies, answering 503
+// when a critical check fails
+// GET /api/v1/admin/diagnostics
+func (h *DiagnosticsHandler) GetDiagnostics(c *gin.Context) {
+	report := h.service.Run(c.Request.Context())
+
+	status := http.StatusOK
+	if !report.Healthy {
+		status = http.StatusServiceUnavailable
+		h.logger.Warn("diagnostics found failing dependencies")
+	}
+	c.JSON(status, report)
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/fhir"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"go.uber.org/zap"
+)
+
+// ExportHandler implements health data export endpoints
+type ExportHandler struct {
+	service *service.ExportService
+	logger  *zap.Logger
+}
+
+// NewExportHandler creates a new ExportHandler
+func NewExportHandler(service *service.ExportService, logger *zap.Logger) *ExportHandler {
+	return &ExportHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetHealthExport streams one type of health data as a CSV file. start_date and end_date
+// are inclusive calendar dates.
+// GET /api/v1/export/health
+func (h *ExportHandler) GetHealthExport(c *gin.Context) {
+	userID, ok := queryUserID(c)
+	if !ok {
+		return
+	}
+
+	dataType := c.Query("type")
+	start, err := time.Parse(time.DateOnly, c.Query("start_date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "start_date must be a date in YYYY-MM-DD format",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+	end, err := time.Parse(time.DateOnly, c.Query("end_date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "end_date must be a date in YYYY-MM-DD format",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+	if end.Before(start) {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "end_date must not be before start_date",
+		})
+		return
+	}
+
+	filename := fmt.Sprintf("%s_%s_%s.csv", dataType, start.Format(time.DateOnly), end.Format(time.DateOnly))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+
+	// The end date is inclusive; the service takes an exclusive bound
+	err = h.service.ExportToCSV(c.Request.Context(), userID, dataType, start, end.AddDate(0, 0, 1), c.Writer)
+	if err == nil {
+		c.Status(http.StatusOK)
+		return
+	}
+
+	h.logger.Error("failed to export health data",
+		zap.Error(err),
+		zap.String("user_id", userID),
+		zap.String("type", dataType),
+	)
+
+	if c.Writer.Written() {
+		// Part of the file has been sent; the truncated response is all that can be done
+		c.Abort()
+		return
+	}
+
+	c.Writer.Header().Del("Content-Type")
+	c.Writer.Header().Del("Content-Disposition")
+	if errors.Is(err, service.ErrUnsupportedExportType) {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "type must be one of blood_pressure, menstruation, fitness, medications",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+		Code:    "INTERNAL_ERROR",
+		Message: "Failed to export health data",
+		Details: stringPtr(err.Error()),
+	})
+}
+
+// GetFHIRExport returns the user's health data as a FHIR R4 Bundle
+// GET /api/v1/export/fhir
+func (h *ExportHandler) GetFHIRExport(c *gin.Context) {
+	userID, ok := queryUserID(c)
+	if !ok {
+		return
+	}
+
+	bundle, err := h.service.ExportFHIR(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("failed to export FHIR bundle",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to export health data",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	// c.JSON keeps a Content-Type that is already set
+	c.Header("Content-Type", fhir.ContentType)
+	c.JSON(http.StatusOK, bundle)
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// stubBloodPressureStream streams fixed blood pressure readings; other types are empty
+type stubBloodPressureStream struct {
+	readings   []model.BloodPressureReading
+	err        error
+	start, end time.Time
+}
+
+func (s *stubBloodPressureStream) StreamBloodPressureByUserID(ctx context.Context, userID string, start, end time.Time, fn func(model.BloodPressureReading) error) error {
+	s.start, s.end = start, end
+	if s.err != nil {
+		return s.err
+	}
+	for _, reading := range s.readings {
+		if err := fn(reading); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *stubBloodPressureStream) StreamMenstruationByUserID(ctx context.Context, userID string, start, end time.Time, fn func(model.MenstruationCycle) error) error {
+	return nil
+}
+
+func (s *stubBloodPressureStream) StreamFitnessDataByUserID(ctx context.Context, userID string, start, end time.Time, fn func(model.FitnessDataPoint) error) error {
+	return nil
+}
+
+func (s *stubBloodPressureStream) StreamByUserID(ctx context.Context, userID string, start, end time.Time, fn func(model.Medication) error) error {
+	return nil
+}
+
+func getHealthExport(source *stubBloodPressureStream, query string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+	router := gin.New()
+	router.GET("/export/health", NewExportHandler(service.NewExportService(source, source, logger), logger).GetHealthExport)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/export/health?"+query, nil))
+	return w
+}
+
+func TestGetHealthExport_StreamsCSV(t *testing.T) {
+	userID := uuid.New()
+	source := &stubBloodPressureStream{readings: []model.BloodPressureReading{
+		{Systolic: 131, Diastolic: 85, Pulse: 70, MeasuredAt: time.Date(2026, 3, 31, 21, 15, 0, 0, time.UTC)},
+	}}
+
+	w := getHealthExport(source, "user_id="+userID.String()+"&type=blood_pressure&start_date=2026-03-01&end_date=2026-03-31")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "attachment; filename=blood_pressure_2026-03-01_2026-03-31.csv", w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "Measured At,Systolic (mmHg),Diastolic (mmHg),Pulse (bpm)\n2026-03-31T21:15:00Z,131,85,70\n", w.Body.String())
+
+	// The end date is inclusive
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), source.start)
+	assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), source.end)
+}
+
+func TestGetHealthExport_Errors(t *testing.T) {
+	userID := uuid.New().String()
+
+	tests := []struct {
+		name   string
+		source *stubBloodPressureStream
+		query  string
+		status int
+		code   string
+	}{
+		{"invalid user", &stubBloodPressureStream{}, "user_id=nope&type=blood_pressure&start_date=2026-03-01&end_date=2026-03-31", http.StatusBadRequest, "VALIDATION_ERROR"},
+		{"missing start date", &stubBloodPressureStream{}, "user_id=" + userID + "&type=blood_pressure&end_date=2026-03-31", http.StatusBadRequest, "VALIDATION_ERROR"},
+		{"end before start", &stubBloodPressureStream{}, "user_id=" + userID + "&type=blood_pressure&start_date=2026-03-31&end_date=2026-03-01", http.StatusBadRequest, "VALIDATION_ERROR"},
+		{"unsupported type", &stubBloodPressureStream{}, "user_id=" + userID + "&type=sleep&start_date=2026-03-01&end_date=2026-03-31", http.StatusBadRequest, "VALIDATION_ERROR"},
+		{"query failure", &stubBloodPressureStream{err: errors.New("connection reset")}, "user_id=" + userID + "&type=blood_pressure&start_date=2026-03-01&end_date=2026-03-31", http.StatusInternalServerError, "INTERNAL_ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := getHealthExport(tt.source, tt.query)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+			assert.Empty(t, w.Header().Get("Content-Disposition"))
+
+			var errResp api.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+			assert.Equal(t, tt.code, errResp.Code)
+		})
+	}
+}
+
+func TestGetFHIRExport(t *testing.T) {
+	userID := uuid.New().String()
+	source := &stubBloodPressureStream{readings: []model.BloodPressureReading{
+		{ID: uuid.New().String(), UserID: userID, Systolic: 131, Diastolic: 85, MeasuredAt: time.Date(2026, 3, 31, 21, 15, 0, 0, time.UTC)},
+	}}
+
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+	router := gin.New()
+	router.GET("/export/fhir", NewExportHandler(service.NewExportService(source, source, logger), logger).GetFHIRExport)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/export/fhir?user_id="+userID, nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/fhir+json", w.Header().Get("Content-Type"))
+
+	var bundle struct {
+		ResourceType string `json:"resourceType"`
+		Entry        []struct {
+			Resource struct {
+				ResourceType string `json:"resourceType"`
+			} `json:"resource"`
+		} `json:"entry"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &bundle))
+	assert.Equal(t, "Bundle", bundle.ResourceType)
+	require.Len(t, bundle.Entry, 2)
+	assert.Equal(t, "Patient", bundle.Entry[0].Resource.ResourceType)
+	assert.Equal(t, "Observation", bundle.Entry[1].Resource.ResourceType)
+}
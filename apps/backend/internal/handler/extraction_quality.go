@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"go.uber.org/zap"
+)
+
+// defaultExtractionQualityDays is the reporting window of the extraction quality endpoint
+const defaultExtractionQualityDays = 30
+
+// GetExtractionQuality returns extraction fallback, low confidence and plausibility flag
+// rates per prompt version
+// GET /api/v1/admin/extraction-quality?days=
+func (h *CheckInHandler) GetExtractionQuality(c *gin.Context) {
+	days := defaultExtractionQualityDays
+	if raw := c.Query("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > 365 {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "days must be an integer between 1 and 365",
+			})
+			return
+		}
+		days = parsed
+	}
+
+	report, err := h.service.GetExtractionQuality(c.Request.Context(), days)
+	if err != nil {
+		h.logger.Error("failed to get extraction quality", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to retrieve extraction quality",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
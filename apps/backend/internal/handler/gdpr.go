@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 	"go.uber.org/zap"
 )
 
@@ -41,6 +43,10 @@ func (h *GDPRHandler) DeleteUserData(c *gin.Context) {
 	}
 
 	userIDStr := userID.String()
+	if !authorizeUser(c, userIDStr) {
+		return
+	}
+
 	ipAddress := c.ClientIP()
 	userAgent := c.Request.UserAgent()
 
@@ -51,6 +57,14 @@ func (h *GDPRHandler) DeleteUserData(c *gin.Context) {
 
 	// Delete user data
 	if err := h.service.DeleteUserData(c.Request.Context(), userIDStr, ipAddress, userAgent); err != nil {
+		if errors.Is(err, service.ErrErasureConsentRequired) {
+			c.JSON(http.StatusConflict, api.ErrorResponse{
+				Code:    "CONSENT_REQUIRED",
+				Message: "Record a granted right_to_erasure consent before deleting user data",
+				Details: stringPtr(err.Error()),
+			})
+			return
+		}
 		h.logger.Error("failed to delete user data",
 			zap.Error(err),
 			zap.String("user_id", userIDStr),
@@ -73,7 +87,70 @@ func (h *GDPRHandler) DeleteUserData(c *gin.Context) {
 	})
 }
 
-// ExportUserData handles user data export requests (GDPR right to data portability)
+// anonymizeRequest is the body of an anonymization request
+type anonymizeRequest struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+}
+
+// AnonymizeUserData replaces a user's identifiers and scrubs free-text fields while
+// keeping numeric health metrics (GDPR alternative to deletion)
+// POST /api/v1/gdpr/anonymize
+func (h *GDPRHandler) AnonymizeUserData(c *gin.Context) {
+	var req anonymizeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	userIDStr := uuid.MustParse(req.UserID).String()
+	if !authorizeUser(c, userIDStr) {
+		return
+	}
+
+	ipAddress := c.ClientIP()
+	userAgent := c.Request.UserAgent()
+
+	h.logger.Info("processing user data anonymization request (GDPR)",
+		zap.String("user_id", userIDStr),
+		zap.String("ip", ipAddress),
+	)
+
+	if err := h.service.AnonymizeUserData(c.Request.Context(), userIDStr, ipAddress, userAgent); err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, api.ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "User not found",
+			})
+			return
+		}
+		h.logger.Error("failed to anonymize user data",
+			zap.Error(err),
+			zap.String("user_id", userIDStr),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to anonymize user data",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	h.logger.Info("user data anonymized successfully (GDPR)",
+		zap.String("user_id", userIDStr),
+	)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User data anonymized successfully",
+		"user_id": userIDStr,
+	})
+}
+
+// ExportUserData handles user data export requests (GDPR right to data portability).
+// format=csv returns a ZIP archive with one CSV file per table instead of JSON.
 // GET /api/v1/users/:userId/export
 func (h *GDPRHandler) ExportUserData(c *gin.Context) {
 	userIDParam := c.Param("userId")
@@ -88,14 +165,33 @@ func (h *GDPRHandler) ExportUserData(c *gin.Context) {
 		return
 	}
 
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid export format",
+			Details: stringPtr("format must be json or csv"),
+		})
+		return
+	}
+
 	userIDStr := userID.String()
+	if !authorizeUser(c, userIDStr) {
+		return
+	}
 
 	h.logger.Info("processing user data export request (GDPR)",
 		zap.String("user_id", userIDStr),
+		zap.String("format", format),
 	)
 
 	// Export user data
-	jsonData, err := h.service.ExportUserData(c.Request.Context(), userIDStr)
+	var data []byte
+	if format == "csv" {
+		data, err = h.service.ExportUserDataCSV(c.Request.Context(), userIDStr)
+	} else {
+		data, err = h.service.ExportUserData(c.Request.Context(), userIDStr)
+	}
 	if err != nil {
 		h.logger.Error("failed to export user data",
 			zap.Error(err),
@@ -111,11 +207,94 @@ func (h *GDPRHandler) ExportUserData(c *gin.Context) {
 
 	h.logger.Info("user data exported successfully (GDPR)",
 		zap.String("user_id", userIDStr),
-		zap.Int("data_size_bytes", len(jsonData)),
+		zap.Int("data_size_bytes", len(data)),
 	)
 
-	// Return JSON file as download
-	filename := fmt.Sprintf("user_data_%s.json", userIDStr)
+	// Return the export as a file download
+	contentType, extension := "application/json", "json"
+	if format == "csv" {
+		contentType, extension = "application/zip", "zip"
+	}
+	filename := fmt.Sprintf("user_data_%s.%s", userIDStr, extension)
 	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
-	c.Data(http.StatusOK, "application/json", jsonData)
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// recordConsentRequest is the body of a GDPR consent record
+type recordConsentRequest struct {
+	UserID      string                `json:"user_id" binding:"required,uuid"`
+	ConsentType model.GDPRConsentType `json:"consent_type" binding:"required"`
+	Granted     *bool                 `json:"granted" binding:"required"`
+	Version     string                `json:"version" binding:"required"`
+}
+
+// RecordConsent records a user's answer to a version of a GDPR consent text
+// POST /api/v1/gdpr/consent
+func (h *GDPRHandler) RecordConsent(c *gin.Context) {
+	var req recordConsentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	userIDStr := uuid.MustParse(req.UserID).String()
+	if !authorizeUser(c, userIDStr) {
+		return
+	}
+
+	consent := &model.GDPRConsent{
+		UserID:      userIDStr,
+		ConsentType: req.ConsentType,
+		Granted:     *req.Granted,
+		Version:     req.Version,
+		IPAddress:   stringPtr(c.ClientIP()),
+	}
+	if err := h.service.RecordConsent(c.Request.Context(), consent); err != nil {
+		if errors.Is(err, service.ErrInvalidGDPRConsent) {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "Invalid consent",
+				Details: stringPtr(err.Error()),
+			})
+			return
+		}
+		h.logger.Error("failed to record GDPR consent", zap.Error(err), zap.String("user_id", userIDStr))
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to record consent",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, consent)
+}
+
+// GetConsentHistory lists every GDPR consent a user answered, oldest first
+// GET /api/v1/gdpr/consent?user_id=
+func (h *GDPRHandler) GetConsentHistory(c *gin.Context) {
+	userIDStr, ok := queryUserID(c)
+	if !ok {
+		return
+	}
+
+	history, err := h.service.GetConsentHistory(c.Request.Context(), userIDStr)
+	if err != nil {
+		h.logger.Error("failed to get GDPR consent history", zap.Error(err), zap.String("user_id", userIDStr))
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get consent history",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id":  userIDStr,
+		"consents": history,
+	})
 }
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"go.uber.org/zap"
+)
+
+func TestRecordConsent_InvalidRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+	h := NewGDPRHandler(service.NewGDPRService(nil, nil, logger), logger)
+	router := gin.New()
+	router.POST("/gdpr/consent", h.RecordConsent)
+
+	userID := uuid.NewString()
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid user ID", `{"user_id": "not-a-uuid", "consent_type": "processing", "granted": true, "version": "v1"}`},
+		{"missing granted", `{"user_id": "` + userID + `", "consent_type": "processing", "version": "v1"}`},
+		{"missing version", `{"user_id": "` + userID + `", "consent_type": "processing", "granted": true}`},
+		{"blank version", `{"user_id": "` + userID + `", "consent_type": "processing", "granted": true, "version": "  "}`},
+		{"unknown consent type", `{"user_id": "` + userID + `", "consent_type": "data_processing", "granted": true, "version": "v1"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/gdpr/consent", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
@@ -1,10 +1,16 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
@@ -39,6 +45,9 @@ func (h *HealthHandler) PostApiV1HealthMenstruation(c *gin.Context) {
 	}
 
 	userID := uuidToString(req.UserId)
+	if !authorizeUser(c, userID) {
+		return
+	}
 
 	// Convert API request to model
 	cycle := &model.MenstruationCycle{
@@ -97,12 +106,20 @@ func (h *HealthHandler) PostApiV1HealthMenstruation(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// GetApiV1HealthMenstruation retrieves menstruation history
+// GetApiV1HealthMenstruation retrieves a page of menstruation history
 func (h *HealthHandler) GetApiV1HealthMenstruation(c *gin.Context, params api.GetApiV1HealthMenstruationParams) {
 	userID := uuidToString(params.UserId)
+	if !authorizeUser(c, userID) {
+		return
+	}
+
+	page, ok := parsePage(c)
+	if !ok {
+		return
+	}
 
 	// Get menstruation history
-	cycles, err := h.service.GetMenstruationHistory(c.Request.Context(), userID)
+	cycles, total, err := h.service.GetMenstruationHistory(c.Request.Context(), userID, page)
 	if err != nil {
 		h.logger.Error("failed to get menstruation history",
 			zap.Error(err),
@@ -117,7 +134,7 @@ func (h *HealthHandler) GetApiV1HealthMenstruation(c *gin.Context, params api.Ge
 	}
 
 	// Convert to API response
-	var response []api.MenstruationResponse
+	response := make([]api.MenstruationResponse, 0, len(cycles))
 	for _, cycle := range cycles {
 		menstruationResp := api.MenstruationResponse{
 			Id:        stringToUUID(cycle.ID),
@@ -139,11 +156,185 @@ func (h *HealthHandler) GetApiV1HealthMenstruation(c *gin.Context, params api.Ge
 	h.logger.Info("menstruation history retrieved",
 		zap.String("user_id", userID),
 		zap.Int("count", len(response)),
+		zap.Int("total_count", total),
+	)
+
+	c.JSON(http.StatusOK, api.MenstruationPage{
+		Items:      response,
+		TotalCount: total,
+		NextCursor: nextCursor(total, page),
+	})
+}
+
+// menstruationUpdateRequest is the body for a partial menstruation cycle update
+type menstruationUpdateRequest struct {
+	EndDate       *types.Date `json:"end_date,omitempty"`
+	FlowIntensity *string     `json:"flow_intensity,omitempty"`
+	Symptoms      *[]string   `json:"symptoms,omitempty"`
+	SymptomsMode  string      `json:"symptoms_mode,omitempty"` // append or replace (default)
+}
+
+// PatchMenstruation partially updates a menstruation cycle
+// PATCH /api/v1/health/menstruation/:id
+func (h *HealthHandler) PatchMenstruation(c *gin.Context) {
+	cycleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid cycle ID",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	var req menstruationUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	mode := service.SymptomUpdateMode(req.SymptomsMode)
+	if mode != "" && mode != service.SymptomUpdateAppend && mode != service.SymptomUpdateReplace {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "symptoms_mode must be append or replace",
+		})
+		return
+	}
+
+	update := &service.MenstruationUpdate{
+		FlowIntensity: req.FlowIntensity,
+		SymptomsMode:  mode,
+	}
+	if req.EndDate != nil {
+		endDate := dateToTime(*req.EndDate)
+		update.EndDate = &endDate
+	}
+	if req.Symptoms != nil {
+		update.Symptoms = *req.Symptoms
+		if update.Symptoms == nil {
+			update.Symptoms = []string{}
+		}
+	}
+
+	cycle, err := h.service.UpdateMenstruation(c.Request.Context(), cycleID.String(), update)
+	if err != nil {
+		h.logger.Error("failed to update menstruation data",
+			zap.Error(err),
+			zap.String("cycle_id", cycleID.String()),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to update menstruation data",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	response := api.MenstruationResponse{
+		Id:        stringToUUID(cycle.ID),
+		UserId:    stringToUUID(cycle.UserID),
+		StartDate: timeToDate(cycle.StartDate),
+		EndDate:   timePtrToDate(cycle.EndDate),
+		Symptoms:  &cycle.Symptoms,
+		CreatedAt: timePtr(cycle.CreatedAt),
+	}
+
+	if cycle.FlowIntensity != nil {
+		intensity := api.MenstruationResponseFlowIntensity(*cycle.FlowIntensity)
+		response.FlowIntensity = &intensity
+	}
+
+	h.logger.Info("menstruation data updated",
+		zap.String("cycle_id", cycle.ID),
+		zap.String("symptoms_mode", string(mode)),
 	)
 
 	c.JSON(http.StatusOK, response)
 }
 
+// GetMenstruationStats returns cycle statistics computed from completed cycles
+// GET /api/v1/health/menstruation/stats
+func (h *HealthHandler) GetMenstruationStats(c *gin.Context) {
+	userID, ok := queryUserID(c)
+	if !ok {
+		return
+	}
+
+	stats, err := h.service.GetCycleStats(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("failed to get cycle stats",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get cycle statistics",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetMenstruationPrediction predicts the start of the next cycle from the logged cycles
+// GET /api/v1/health/menstruation/prediction
+func (h *HealthHandler) GetMenstruationPrediction(c *gin.Context) {
+	userID, ok := queryUserID(c)
+	if !ok {
+		return
+	}
+
+	prediction, err := h.service.PredictNextCycle(c.Request.Context(), userID)
+	if errors.Is(err, service.ErrInsufficientCycles) {
+		c.JSON(http.StatusUnprocessableEntity, api.ErrorResponse{
+			Code:    "INSUFFICIENT_DATA",
+			Message: "At least 3 logged cycles are needed to predict the next cycle",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to predict next cycle",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to predict next cycle",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, prediction)
+}
+
+// maxBloodPressureNotesLength is the longest note, in characters, accepted with a reading
+const maxBloodPressureNotesLength = 500
+
+// toBloodPressureResponse converts a reading to its API response
+func toBloodPressureResponse(reading model.BloodPressureReading) api.BloodPressureResponse {
+	category := api.BloodPressureResponseCategory(reading.Category())
+	return api.BloodPressureResponse{
+		Id:         stringToUUID(reading.ID),
+		UserId:     stringToUUID(reading.UserID),
+		Systolic:   intPtr(reading.Systolic),
+		Diastolic:  intPtr(reading.Diastolic),
+		Pulse:      intPtr(reading.Pulse),
+		MeasuredAt: timePtr(reading.MeasuredAt),
+		CreatedAt:  timePtr(reading.CreatedAt),
+		Category:   &category,
+		Notes:      reading.Notes,
+	}
+}
+
 // PostApiV1HealthBloodPressure logs blood pressure reading
 func (h *HealthHandler) PostApiV1HealthBloodPressure(c *gin.Context) {
 	var req api.BloodPressureRequest
@@ -157,7 +348,18 @@ func (h *HealthHandler) PostApiV1HealthBloodPressure(c *gin.Context) {
 		return
 	}
 
+	if req.Notes != nil && utf8.RuneCountInString(*req.Notes) > maxBloodPressureNotesLength {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: fmt.Sprintf("notes must be at most %d characters", maxBloodPressureNotesLength),
+		})
+		return
+	}
+
 	userID := uuidToString(req.UserId)
+	if !authorizeUser(c, userID) {
+		return
+	}
 
 	// Convert API request to model
 	reading := &model.BloodPressureReading{
@@ -166,6 +368,10 @@ func (h *HealthHandler) PostApiV1HealthBloodPressure(c *gin.Context) {
 		Pulse:      req.Pulse,
 		MeasuredAt: time.Now(),
 	}
+	if req.Notes != nil && strings.TrimSpace(*req.Notes) != "" {
+		notes := strings.TrimSpace(*req.Notes)
+		reading.Notes = &notes
+	}
 
 	if req.MeasuredAt != nil {
 		reading.MeasuredAt = *req.MeasuredAt
@@ -185,15 +391,7 @@ func (h *HealthHandler) PostApiV1HealthBloodPressure(c *gin.Context) {
 	}
 
 	// Convert to API response
-	response := api.BloodPressureResponse{
-		Id:         stringToUUID(reading.ID),
-		UserId:     stringToUUID(reading.UserID),
-		Systolic:   intPtr(reading.Systolic),
-		Diastolic:  intPtr(reading.Diastolic),
-		Pulse:      intPtr(reading.Pulse),
-		MeasuredAt: timePtr(reading.MeasuredAt),
-		CreatedAt:  timePtr(reading.CreatedAt),
-	}
+	response := toBloodPressureResponse(*reading)
 
 	h.logger.Info("blood pressure logged",
 		zap.String("reading_id", reading.ID),
@@ -203,12 +401,20 @@ func (h *HealthHandler) PostApiV1HealthBloodPressure(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// GetApiV1HealthBloodPressure retrieves blood pressure history
+// GetApiV1HealthBloodPressure retrieves a page of blood pressure history
 func (h *HealthHandler) GetApiV1HealthBloodPressure(c *gin.Context, params api.GetApiV1HealthBloodPressureParams) {
 	userID := uuidToString(params.UserId)
+	if !authorizeUser(c, userID) {
+		return
+	}
+
+	page, ok := parsePage(c)
+	if !ok {
+		return
+	}
 
 	// Get blood pressure history
-	readings, err := h.service.GetBloodPressureHistory(c.Request.Context(), userID)
+	readings, total, err := h.service.GetBloodPressureHistory(c.Request.Context(), userID, page)
 	if err != nil {
 		h.logger.Error("failed to get blood pressure history",
 			zap.Error(err),
@@ -223,25 +429,73 @@ func (h *HealthHandler) GetApiV1HealthBloodPressure(c *gin.Context, params api.G
 	}
 
 	// Convert to API response
-	var response []api.BloodPressureResponse
+	response := make([]api.BloodPressureResponse, 0, len(readings))
 	for _, reading := range readings {
-		response = append(response, api.BloodPressureResponse{
-			Id:         stringToUUID(reading.ID),
-			UserId:     stringToUUID(reading.UserID),
-			Systolic:   intPtr(reading.Systolic),
-			Diastolic:  intPtr(reading.Diastolic),
-			Pulse:      intPtr(reading.Pulse),
-			MeasuredAt: timePtr(reading.MeasuredAt),
-			CreatedAt:  timePtr(reading.CreatedAt),
-		})
+		response = append(response, toBloodPressureResponse(reading))
 	}
 
 	h.logger.Info("blood pressure history retrieved",
 		zap.String("user_id", userID),
 		zap.Int("count", len(response)),
+		zap.Int("total_count", total),
 	)
 
-	c.JSON(http.StatusOK, response)
+	// Clients re-fetching an unchanged list get 304 Not Modified without a body
+	respondWithETag(c, api.BloodPressurePage{
+		Items:      response,
+		TotalCount: total,
+		NextCursor: nextCursor(total, page),
+	})
+}
+
+// GetBloodPressureChart returns average blood pressure per day or week for charting,
+// with empty points for periods without readings
+// GET /api/v1/health/blood-pressure/chart
+func (h *HealthHandler) GetBloodPressureChart(c *gin.Context) {
+	userID, ok := queryUserID(c)
+	if !ok {
+		return
+	}
+
+	start, err := time.Parse(time.DateOnly, c.Query("start_date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "start_date must be a date in YYYY-MM-DD format",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+	end, err := time.Parse(time.DateOnly, c.Query("end_date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "end_date must be a date in YYYY-MM-DD format",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+	granularity := c.DefaultQuery("granularity", service.ChartGranularityDaily)
+
+	points, err := h.service.GetBloodPressureChart(c.Request.Context(), userID, start, end, granularity)
+	if errors.Is(err, service.ErrInvalidGranularity) || errors.Is(err, service.ErrInvalidChartRange) {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid chart parameters",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get blood pressure chart data",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, points)
 }
 
 // PostApiV1HealthFitnessSync syncs fitness data from Health Connect
@@ -258,6 +512,9 @@ func (h *HealthHandler) PostApiV1HealthFitnessSync(c *gin.Context) {
 	}
 
 	userID := uuidToString(req.UserId)
+	if !authorizeUser(c, userID) {
+		return
+	}
 
 	// Convert API request to model
 	var fitnessData []model.FitnessDataPoint
@@ -273,7 +530,16 @@ func (h *HealthHandler) PostApiV1HealthFitnessSync(c *gin.Context) {
 	}
 
 	// Sync fitness data
-	if err := h.service.SyncFitnessData(c.Request.Context(), userID, fitnessData); err != nil {
+	result, err := h.service.SyncFitnessData(c.Request.Context(), userID, fitnessData)
+	if errors.Is(err, service.ErrInvalidFitnessDataPoint) {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid fitness data point",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+	if err != nil {
 		h.logger.Error("failed to sync fitness data",
 			zap.Error(err),
 			zap.String("user_id", userID),
@@ -288,11 +554,126 @@ func (h *HealthHandler) PostApiV1HealthFitnessSync(c *gin.Context) {
 
 	h.logger.Info("fitness data synced",
 		zap.String("user_id", userID),
-		zap.Int("count", len(fitnessData)),
+		zap.Int("inserted_count", result.Inserted),
+		zap.Int("skipped_count", result.Skipped),
 	)
 
-	c.JSON(http.StatusOK, gin.H{
-		"message":      "Fitness data synced successfully",
-		"synced_count": len(fitnessData),
+	c.JSON(http.StatusOK, api.FitnessSyncResponse{
+		Message:       "Fitness data synced successfully",
+		SyncedCount:   result.Inserted,
+		InsertedCount: result.Inserted,
+		SkippedCount:  result.Skipped,
 	})
 }
+
+// defaultFitnessDays is the range of GetFitnessData when from is omitted
+const defaultFitnessDays = 30
+
+// GetFitnessData lists a user's fitness data points by date, or with aggregate=daily
+// their per-day sums (averages for heart rate)
+// GET /api/v1/health/fitness
+func (h *HealthHandler) GetFitnessData(c *gin.Context) {
+	userID, ok := queryUserID(c)
+	if !ok {
+		return
+	}
+
+	to := time.Now().UTC()
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "to must be a date in YYYY-MM-DD format",
+				Details: stringPtr(err.Error()),
+			})
+			return
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, -(defaultFitnessDays - 1))
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "from must be a date in YYYY-MM-DD format",
+				Details: stringPtr(err.Error()),
+			})
+			return
+		}
+		from = parsed
+	}
+	dataType := c.Query("data_type")
+
+	respondInvalid := func(err error) bool {
+		if !errors.Is(err, service.ErrInvalidFitnessDataType) && !errors.Is(err, service.ErrInvalidFitnessRange) {
+			return false
+		}
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid fitness data query",
+			Details: stringPtr(err.Error()),
+		})
+		return true
+	}
+
+	switch c.Query("aggregate") {
+	case "":
+	case service.FitnessAggregateDaily:
+		aggregates, err := h.service.GetDailyFitness(c.Request.Context(), userID, from, to, dataType)
+		if respondInvalid(err) {
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: "Failed to get fitness data",
+				Details: stringPtr(err.Error()),
+			})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"items": aggregates})
+		return
+	default:
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "aggregate must be daily",
+		})
+		return
+	}
+
+	page, ok := parsePage(c)
+	if !ok {
+		return
+	}
+
+	dataPoints, total, err := h.service.ListFitnessData(c.Request.Context(), userID, from, to, dataType, page)
+	if respondInvalid(err) {
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get fitness data",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	response := make([]api.FitnessDataResponse, 0, len(dataPoints))
+	for _, point := range dataPoints {
+		response = append(response, api.FitnessDataResponse{
+			Id:           stringToUUIDValue(point.ID),
+			Date:         *timeToDate(point.Date),
+			DataType:     api.FitnessDataResponseDataType(point.DataType),
+			Value:        point.Value,
+			Unit:         api.FitnessDataResponseUnit(point.Unit),
+			Source:       api.FitnessDataResponseSource(point.Source),
+			SourceDataId: point.SourceDataID,
+			CreatedAt:    point.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, newPageResponse(response, total, page))
+}
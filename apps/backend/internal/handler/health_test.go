@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"go.uber.org/zap"
+)
+
+func TestPostBloodPressure_RejectsLongNotes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+	h := NewHealthHandler(service.NewHealthDataService(nil, logger), logger)
+	router := gin.New()
+	router.POST("/blood-pressure", h.PostApiV1HealthBloodPressure)
+
+	body := `{"user_id":"` + uuid.NewString() + `","systolic":120,"diastolic":80,"pulse":70,"notes":"` +
+		strings.Repeat("é", maxBloodPressureNotesLength+1) + `"}`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/blood-pressure", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "notes must be at most 500 characters")
+}
@@ -1,12 +1,67 @@
 package handler
 
 import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/oapi-codegen/runtime/types"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/middleware"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
 )
 
+// AuthUserID returns the user ID of the request's bearer token, or an empty string
+// when the request is unauthenticated
+func AuthUserID(c *gin.Context) string {
+	return middleware.GetUserID(c)
+}
+
+// authorizeUser checks that an authenticated caller only accesses their own data.
+// Unauthenticated requests pass through; it responds with 403 and returns false on a mismatch.
+func authorizeUser(c *gin.Context, userID string) bool {
+	tokenUserID := AuthUserID(c)
+	if tokenUserID == "" || tokenUserID == userID {
+		return true
+	}
+
+	c.JSON(http.StatusForbidden, api.ErrorResponse{
+		Code:    "FORBIDDEN",
+		Message: "Access to another user's data is not allowed",
+	})
+	return false
+}
+
+// queryUserID returns the user a request acts for: the user_id query parameter, which must
+// match the authenticated user, or the authenticated user when the parameter is omitted.
+// It writes the error response and returns false on failure.
+func queryUserID(c *gin.Context) (string, bool) {
+	raw := c.Query("user_id")
+	if raw == "" {
+		if tokenUserID := AuthUserID(c); tokenUserID != "" {
+			return tokenUserID, true
+		}
+	}
+
+	userID, err := uuid.Parse(raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid user ID",
+			Details: stringPtr(err.Error()),
+		})
+		return "", false
+	}
+
+	if !authorizeUser(c, userID.String()) {
+		return "", false
+	}
+	return userID.String(), true
+}
+
 // Helper functions for type conversions between API types and internal models
 
 // stringPtr creates a pointer to a string
@@ -44,6 +99,15 @@ func stringToUUID(s string) *types.UUID {
 	return &apiUUID
 }
 
+// stringToUUIDValue converts string to types.UUID for required response fields, the zero
+// UUID when s is not a UUID
+func stringToUUIDValue(s string) types.UUID {
+	if u := stringToUUID(s); u != nil {
+		return *u
+	}
+	return types.UUID{}
+}
+
 // dateToTime converts types.Date to time.Time
 func dateToTime(d types.Date) time.Time {
 	return d.Time
@@ -61,3 +125,23 @@ func timePtrToDate(t *time.Time) *types.Date {
 	}
 	return &types.Date{Time: *t}
 }
+
+// respondWithETag writes body as a 200 JSON response tagged with the MD5 of its JSON,
+// or only 304 Not Modified when the client's If-None-Match already has that tag
+func respondWithETag(c *gin.Context, body any) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to encode response",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	sum := md5.Sum(data)
+	if middleware.ConditionalGET(`"`+hex.EncodeToString(sum[:])+`"`, c) {
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
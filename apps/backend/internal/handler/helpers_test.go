@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/middleware"
+	"go.uber.org/zap"
+)
+
+func TestGDPRExport_ForbidsOtherUsersData(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tokenUser := uuid.New().String()
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", tokenUser)
+		c.Next()
+	})
+	router.GET("/users/:userId/export", NewGDPRHandler(nil, zap.NewNop()).ExportUserData)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/"+uuid.New().String()+"/export", nil))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "FORBIDDEN")
+}
+
+func TestAuthorizeUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		tokenUser string
+		userID    string
+		allowed   bool
+	}{
+		{"unauthenticated", "", "user-a", true},
+		{"same user", "user-a", "user-a", true},
+		{"different user", "user-a", "user-b", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			if tt.tokenUser != "" {
+				c.Set("user_id", tt.tokenUser)
+			}
+			assert.Equal(t, tt.allowed, authorizeUser(c, tt.userID))
+		})
+	}
+}
+
+const testJWTSecret = "handler-test-secret"
+
+// signTestToken creates an HS256 token for userID expiring at exp
+func signTestToken(t *testing.T, userID string, exp time.Time) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(map[string]any{"sub": userID, "exp": exp.Unix()})
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(testJWTSecret))
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestAuthMiddleware_ScopesBodyUserID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tokenUser := uuid.New().String()
+
+	router := gin.New()
+	router.Use(middleware.AuthMiddleware(zap.NewNop(), testJWTSecret))
+	router.POST("/api/v1/health/medications", NewMedicationHandler(nil, zap.NewNop()).PostApiV1HealthMedications)
+
+	post := func(token, userID string) *httptest.ResponseRecorder {
+		body := `{"user_id":"` + userID + `","name":"Aspirin","dosage":"100mg","frequency":"daily","start_date":"2024-01-01"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/health/medications", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("missing token", func(t *testing.T) {
+		w := post("", tokenUser)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		w := post(signTestToken(t, tokenUser, time.Now().Add(-time.Hour)), tokenUser)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("mismatched user", func(t *testing.T) {
+		w := post(signTestToken(t, tokenUser, time.Now().Add(time.Hour)), uuid.New().String())
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "FORBIDDEN")
+	})
+}
+
+func TestQueryUserID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tokenUser := uuid.New().String()
+
+	tests := []struct {
+		name       string
+		tokenUser  string
+		query      string
+		wantUserID string
+		wantStatus int
+	}{
+		{"omitted uses token user", tokenUser, "", tokenUser, http.StatusOK},
+		{"matching user", tokenUser, tokenUser, tokenUser, http.StatusOK},
+		{"mismatched user", tokenUser, uuid.New().String(), "", http.StatusForbidden},
+		{"unauthenticated without user", "", "", "", http.StatusBadRequest},
+		{"invalid user", "", "not-a-uuid", "", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/?user_id="+tt.query, nil)
+			if tt.tokenUser != "" {
+				c.Set("user_id", tt.tokenUser)
+			}
+
+			userID, ok := queryUserID(c)
+
+			assert.Equal(t, tt.wantUserID, userID)
+			assert.Equal(t, tt.wantStatus == http.StatusOK, ok)
+			if !ok {
+				assert.Equal(t, tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
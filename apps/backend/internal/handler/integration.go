@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"go.uber.org/zap"
+)
+
+// IntegrationHandler implements organization delivery integration and care team
+// sharing consent endpoints
+type IntegrationHandler struct {
+	service *service.IntegrationService
+	logger  *zap.Logger
+}
+
+// NewIntegrationHandler creates a new IntegrationHandler
+func NewIntegrationHandler(service *service.IntegrationService, logger *zap.Logger) *IntegrationHandler {
+	return &IntegrationHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// CreateIntegration configures a delivery integration for an organization
+// POST /api/v1/orgs/:id/integrations
+func (h *IntegrationHandler) CreateIntegration(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	var req service.IntegrationInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	integration, err := h.service.CreateIntegration(c.Request.Context(), orgID, req, AuthUserID(c))
+	if err != nil {
+		h.writeError(c, err, "Failed to create integration")
+		return
+	}
+
+	c.JSON(http.StatusCreated, integration)
+}
+
+// ListIntegrations lists the delivery integrations of an organization
+// GET /api/v1/orgs/:id/integrations
+func (h *IntegrationHandler) ListIntegrations(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	integrations, err := h.service.ListIntegrations(c.Request.Context(), orgID)
+	if err != nil {
+		h.writeError(c, err, "Failed to list integrations")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"integrations": integrations})
+}
+
+// TestIntegration sends a sample check-in through an integration and returns the
+// recorded attempt, including the error when delivery failed
+// POST /api/v1/orgs/:id/integrations/:integration_id/test
+func (h *IntegrationHandler) TestIntegration(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+	integrationID, ok := uuidParam(c, "integration_id", "Invalid integration ID format")
+	if !ok {
+		return
+	}
+
+	result, err := h.service.TestDelivery(c.Request.Context(), orgID, integrationID, AuthUserID(c))
+	if err != nil {
+		h.writeError(c, err, "Failed to test integration")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ListDeliveries lists the most recent delivery attempts of an integration
+// GET /api/v1/orgs/:id/integrations/:integration_id/deliveries
+func (h *IntegrationHandler) ListDeliveries(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+	integrationID, ok := uuidParam(c, "integration_id", "Invalid integration ID format")
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.service.ListDeliveries(c.Request.Context(), orgID, integrationID)
+	if err != nil {
+		h.writeError(c, err, "Failed to list deliveries")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deliveries": deliveries})
+}
+
+// GrantSharingConsent lets the authenticated user share completed check-ins with an
+// organization's care team
+// PUT /api/v1/orgs/:id/sharing-consent
+func (h *IntegrationHandler) GrantSharingConsent(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+	userID, ok := consentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.GrantSharingConsent(c.Request.Context(), orgID, userID); err != nil {
+		h.writeError(c, err, "Failed to grant sharing consent")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RevokeSharingConsent stops sharing the authenticated user's check-ins with an
+// organization's care team
+// DELETE /api/v1/orgs/:id/sharing-consent
+func (h *IntegrationHandler) RevokeSharingConsent(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+	userID, ok := consentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RevokeSharingConsent(c.Request.Context(), orgID, userID); err != nil {
+		h.writeError(c, err, "Failed to revoke sharing consent")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// consentUserID returns the authenticated user, who can only consent for themselves
+func consentUserID(c *gin.Context) (string, bool) {
+	userID := AuthUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, api.ErrorResponse{
+			Code:    "UNAUTHORIZED",
+			Message: "Sharing consent requires an authenticated user",
+		})
+		return "", false
+	}
+	return userID, true
+}
+
+// writeError maps integration service errors to responses
+func (h *IntegrationHandler) writeError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrIntegrationNotFound):
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Integration not found",
+		})
+	case errors.Is(err, service.ErrSharingConsentNotFound):
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Sharing consent not found",
+		})
+	case errors.Is(err, service.ErrInvalidIntegration):
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid integration",
+			Details: stringPtr(err.Error()),
+		})
+	case errors.Is(err, service.ErrNotOrganizationMember):
+		c.JSON(http.StatusForbidden, api.ErrorResponse{
+			Code:    "FORBIDDEN",
+			Message: "Only members can share check-ins with an organization",
+		})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: message,
+			Details: stringPtr(err.Error()),
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// liveIdleTimeout closes the socket when the client sends nothing for this long
+	liveIdleTimeout = 15 * time.Second
+
+	// liveMaxFrameBytes bounds a single audio chunk frame
+	liveMaxFrameBytes = 1 << 20
+
+	// liveEndMessage is the text frame a client sends after the last audio chunk
+	liveEndMessage = "end"
+)
+
+// liveFrame is a message pushed to the client over the transcription socket
+type liveFrame struct {
+	Type    string `json:"type"` // partial, final or error
+	Text    string `json:"text,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// liveClientMessage is a frame received from the client
+type liveClientMessage struct {
+	data []byte
+	text bool
+}
+
+// liveMessageCodec receives frames while keeping their payload type
+var liveMessageCodec = websocket.Codec{
+	Unmarshal: func(data []byte, payloadType byte, v interface{}) error {
+		msg := v.(*liveClientMessage)
+		msg.data = data
+		msg.text = payloadType == websocket.TextFrame
+		return nil
+	},
+}
+
+// CheckinAudioWebSocket streams live transcription over a WebSocket. The client
+// sends binary 16 kHz 16-bit mono PCM (or WAV) chunks and a final "end" text
+// frame; the server pushes partial transcription frames as segments are
+// recognized and a final frame with the complete transcription, which the
+// client then submits via the respond endpoint.
+// GET /api/v1/checkin/audio-ws?session_id=
+func (h *CheckInHandler) CheckinAudioWebSocket(c *gin.Context) {
+	sessionID, err := uuid.Parse(c.Query("session_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid session ID format",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+	sessionIDStr := sessionID.String()
+
+	session, live, err := h.service.StartLiveTranscription(c.Request.Context(), sessionIDStr)
+	if err != nil {
+		h.logger.Warn("live transcription rejected",
+			zap.Error(err),
+			zap.String("session_id", sessionIDStr),
+		)
+		if errors.Is(err, service.ErrSessionNotActive) {
+			c.JSON(http.StatusConflict, api.ErrorResponse{
+				Code:    "SESSION_NOT_ACTIVE",
+				Message: "Session is not active",
+				Details: stringPtr(err.Error()),
+			})
+			return
+		}
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Session not found",
+		})
+		return
+	}
+
+	if !authorizeUser(c, session.UserID) {
+		return
+	}
+
+	server := websocket.Server{
+		// Accept any origin, matching the API's CORS policy
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			ws.MaxPayloadBytes = liveMaxFrameBytes
+			h.streamLiveTranscription(c.Request.Context(), ws, live, sessionIDStr)
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// streamLiveTranscription runs the receive/transcribe loop of a live transcription socket.
+// Closing the socket cancels any in-flight transcription.
+func (h *CheckInHandler) streamLiveTranscription(parent context.Context, ws *websocket.Conn, live *service.LiveTranscription, sessionID string) {
+	defer ws.Close()
+
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	sendError := func(message string) {
+		websocket.JSON.Send(ws, liveFrame{Type: "error", Message: message})
+	}
+
+	// The reader cancels ctx when the socket closes or idles, aborting in-flight transcription
+	messages := make(chan liveClientMessage)
+	go func() {
+		defer cancel()
+		for {
+			ws.SetReadDeadline(time.Now().Add(liveIdleTimeout))
+			var msg liveClientMessage
+			if err := liveMessageCodec.Receive(ws, &msg); err != nil {
+				var netErr net.Error
+				if errors.As(err, &netErr) && netErr.Timeout() {
+					sendError("idle timeout")
+				}
+				return
+			}
+			select {
+			case messages <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			h.logger.Info("live transcription ended before final frame",
+				zap.String("session_id", sessionID),
+			)
+			return
+
+		case msg := <-messages:
+			if msg.text {
+				if string(msg.data) != liveEndMessage {
+					continue
+				}
+
+				transcription, err := live.Finish(ctx)
+				if err != nil {
+					h.logger.Error("live transcription failed", zap.Error(err), zap.String("session_id", sessionID))
+					sendError("transcription failed")
+					return
+				}
+
+				websocket.JSON.Send(ws, liveFrame{Type: "final", Text: transcription})
+				h.logger.Info("live transcription completed",
+					zap.String("session_id", sessionID),
+					zap.Int("transcription_length", len(transcription)),
+				)
+				return
+			}
+
+			text, err := live.Write(ctx, msg.data)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				h.logger.Error("live transcription failed", zap.Error(err), zap.String("session_id", sessionID))
+				sendError(err.Error())
+				return
+			}
+
+			if text != "" {
+				if err := websocket.JSON.Send(ws, liveFrame{Type: "partial", Text: text}); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
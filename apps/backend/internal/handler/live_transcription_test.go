@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+type echoLengthTranscriber struct{}
+
+func (echoLengthTranscriber) StreamAudioToText(ctx context.Context, audioStream io.Reader) (string, error) {
+	data, err := io.ReadAll(audioStream)
+	if err != nil {
+		return "", err
+	}
+	return strings.Repeat("a", len(data)/100), nil
+}
+
+func TestStreamLiveTranscription(t *testing.T) {
+	h := NewCheckInHandler(nil, zap.NewNop())
+	server := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		live := service.NewLiveTranscription(echoLengthTranscriber{}, 256)
+		h.streamLiveTranscription(context.Background(), ws, live, "session")
+	}))
+	defer server.Close()
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http"), "", server.URL)
+	require.NoError(t, err)
+	defer ws.Close()
+
+	// One full segment produces a partial frame
+	require.NoError(t, websocket.Message.Send(ws, make([]byte, 300)))
+	var frame liveFrame
+	require.NoError(t, websocket.JSON.Receive(ws, &frame))
+	assert.Equal(t, "partial", frame.Type)
+	assert.Equal(t, "aaa", frame.Text)
+
+	// Ending the stream flushes the remainder and returns the full transcription
+	require.NoError(t, websocket.Message.Send(ws, "end"))
+	require.NoError(t, websocket.JSON.Receive(ws, &frame))
+	assert.Equal(t, "final", frame.Type)
+	assert.Equal(t, "aaa", frame.Text)
+}
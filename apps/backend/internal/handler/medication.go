@@ -1,10 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/oapi-codegen/runtime/types"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
@@ -25,6 +30,36 @@ func NewMedicationHandler(service *service.MedicationService, logger *zap.Logger
 	}
 }
 
+// toMedicationResponse converts a medication model to its API representation
+func toMedicationResponse(med *model.Medication) api.MedicationResponse {
+	response := api.MedicationResponse{
+		Id:        stringToUUID(med.ID),
+		UserId:    stringToUUID(med.UserID),
+		Name:      stringPtr(med.Name),
+		Dosage:    stringPtr(med.Dosage),
+		Frequency: stringPtr(med.Frequency),
+		StartDate: timeToDate(med.StartDate),
+		EndDate:   timePtrToDate(med.EndDate),
+		Notes:     med.Notes,
+		Active:    boolPtr(med.Active),
+		CreatedAt: timePtr(med.CreatedAt),
+	}
+	if len(med.InteractionWarnings) > 0 {
+		warnings := make([]api.InteractionWarning, 0, len(med.InteractionWarnings))
+		for _, warning := range med.InteractionWarnings {
+			warnings = append(warnings, api.InteractionWarning{
+				Medication:         warning.Medication,
+				ExistingMedication: warning.ExistingMedication,
+				Severity:           api.InteractionWarningSeverity(warning.Severity),
+				Description:        warning.Description,
+				Source:             api.InteractionWarningSource(warning.Source),
+			})
+		}
+		response.Warnings = &warnings
+	}
+	return response
+}
+
 // PostApiV1HealthMedications adds a new medication
 func (h *MedicationHandler) PostApiV1HealthMedications(c *gin.Context) {
 	var req api.CreateMedicationRequest
@@ -39,6 +74,9 @@ func (h *MedicationHandler) PostApiV1HealthMedications(c *gin.Context) {
 	}
 
 	userID := uuidToString(req.UserId)
+	if !authorizeUser(c, userID) {
+		return
+	}
 
 	// Convert API request to model
 	medication := &model.Medication{
@@ -55,7 +93,7 @@ func (h *MedicationHandler) PostApiV1HealthMedications(c *gin.Context) {
 		medication.EndDate = &endDate
 	}
 
-	// Add medication
+	// Add medication; interaction warnings never block creation
 	if err := h.service.AddMedication(c.Request.Context(), userID, medication); err != nil {
 		h.logger.Error("failed to add medication",
 			zap.Error(err),
@@ -69,34 +107,43 @@ func (h *MedicationHandler) PostApiV1HealthMedications(c *gin.Context) {
 		return
 	}
 
-	// Convert to API response
-	response := api.MedicationResponse{
-		Id:        stringToUUID(medication.ID),
-		UserId:    stringToUUID(medication.UserID),
-		Name:      stringPtr(medication.Name),
-		Dosage:    stringPtr(medication.Dosage),
-		Frequency: stringPtr(medication.Frequency),
-		StartDate: timeToDate(medication.StartDate),
-		EndDate:   timePtrToDate(medication.EndDate),
-		Notes:     medication.Notes,
-		Active:    boolPtr(medication.Active),
-		CreatedAt: timePtr(medication.CreatedAt),
-	}
-
 	h.logger.Info("medication added",
 		zap.String("medication_id", medication.ID),
 		zap.String("user_id", userID),
+		zap.Int("interaction_warnings", len(medication.InteractionWarnings)),
 	)
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, toMedicationResponse(medication))
 }
 
-// GetApiV1HealthMedications lists all medications for a user
+// GetApiV1HealthMedications lists a page of medications for a user
 func (h *MedicationHandler) GetApiV1HealthMedications(c *gin.Context, params api.GetApiV1HealthMedicationsParams) {
 	userID := uuidToString(params.UserId)
+	if !authorizeUser(c, userID) {
+		return
+	}
+
+	page, ok := parsePage(c)
+	if !ok {
+		return
+	}
+
+	// active is not part of the generated params
+	var active *bool
+	if raw := c.Query("active"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "active must be true or false",
+			})
+			return
+		}
+		active = &parsed
+	}
 
 	// Get medications
-	medications, err := h.service.ListMedications(c.Request.Context(), userID)
+	medications, total, err := h.service.ListMedications(c.Request.Context(), userID, active, page)
 	if err != nil {
 		h.logger.Error("failed to list medications",
 			zap.Error(err),
@@ -111,28 +158,23 @@ func (h *MedicationHandler) GetApiV1HealthMedications(c *gin.Context, params api
 	}
 
 	// Convert to API response
-	var response []api.MedicationResponse
-	for _, med := range medications {
-		response = append(response, api.MedicationResponse{
-			Id:        stringToUUID(med.ID),
-			UserId:    stringToUUID(med.UserID),
-			Name:      stringPtr(med.Name),
-			Dosage:    stringPtr(med.Dosage),
-			Frequency: stringPtr(med.Frequency),
-			StartDate: timeToDate(med.StartDate),
-			EndDate:   timePtrToDate(med.EndDate),
-			Notes:     med.Notes,
-			Active:    boolPtr(med.Active),
-			CreatedAt: timePtr(med.CreatedAt),
-		})
+	response := make([]api.MedicationResponse, 0, len(medications))
+	for i := range medications {
+		response = append(response, toMedicationResponse(&medications[i]))
 	}
 
 	h.logger.Info("medications listed",
 		zap.String("user_id", userID),
 		zap.Int("count", len(response)),
+		zap.Int("total_count", total),
 	)
 
-	c.JSON(http.StatusOK, response)
+	// Clients re-fetching an unchanged list get 304 Not Modified without a body
+	respondWithETag(c, api.MedicationPage{
+		Items:      response,
+		TotalCount: total,
+		NextCursor: nextCursor(total, page),
+	})
 }
 
 // PutApiV1HealthMedicationsId updates a medication
@@ -177,52 +219,99 @@ func (h *MedicationHandler) PutApiV1HealthMedicationsId(c *gin.Context, id types
 		return
 	}
 
-	// Convert to API response
-	response := api.MedicationResponse{
-		Id:        stringToUUID(medication.ID),
-		UserId:    stringToUUID(medication.UserID),
-		Name:      stringPtr(medication.Name),
-		Dosage:    stringPtr(medication.Dosage),
-		Frequency: stringPtr(medication.Frequency),
-		StartDate: timeToDate(medication.StartDate),
-		EndDate:   timePtrToDate(medication.EndDate),
-		Notes:     medication.Notes,
-		Active:    boolPtr(medication.Active),
-		CreatedAt: timePtr(medication.CreatedAt),
-	}
-
 	h.logger.Info("medication updated",
 		zap.String("medication_id", medicationID),
+		zap.Int("interaction_warnings", len(medication.InteractionWarnings)),
 	)
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, toMedicationResponse(medication))
 }
 
-// DeleteApiV1HealthMedicationsId deletes a medication
+// DeleteApiV1HealthMedicationsId deletes a medication, so it can still be restored, or
+// with permanent=true removes it for good
 func (h *MedicationHandler) DeleteApiV1HealthMedicationsId(c *gin.Context, id types.UUID) {
 	medicationID := uuidToString(id)
 
+	permanent := false
+	if raw := c.Query("permanent"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "Invalid permanent parameter, expected true or false",
+				Details: stringPtr(err.Error()),
+			})
+			return
+		}
+		permanent = parsed
+	}
+
 	// Delete medication
-	if err := h.service.DeleteMedication(c.Request.Context(), medicationID); err != nil {
-		h.logger.Error("failed to delete medication",
-			zap.Error(err),
-			zap.String("medication_id", medicationID),
-		)
-		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
-			Code:    "INTERNAL_ERROR",
-			Message: "Failed to delete medication",
-			Details: stringPtr(err.Error()),
-		})
+	if err := h.service.DeleteMedication(c.Request.Context(), medicationID, permanent); err != nil {
+		h.respondMedicationError(c, err, medicationID, "Failed to delete medication")
 		return
 	}
 
 	h.logger.Info("medication deleted",
 		zap.String("medication_id", medicationID),
+		zap.Bool("permanent", permanent),
 	)
 
 	c.Status(http.StatusNoContent)
 }
 
+// PostMedicationRestore restores a deleted medication
+// POST /api/v1/health/medications/:id/restore
+func (h *MedicationHandler) PostMedicationRestore(c *gin.Context) {
+	medicationID, ok := uuidParam(c, "id", "Invalid medication ID")
+	if !ok {
+		return
+	}
+
+	medication, err := h.service.RestoreMedication(c.Request.Context(), AuthUserID(c), medicationID)
+	if err != nil {
+		h.respondMedicationError(c, err, medicationID, "Failed to restore medication")
+		return
+	}
+
+	c.JSON(http.StatusOK, toMedicationResponse(medication))
+}
+
+// PostMedicationDeactivate marks a medication as no longer taken, ending it today unless
+// it has an end date
+// POST /api/v1/health/medications/:id/deactivate
+func (h *MedicationHandler) PostMedicationDeactivate(c *gin.Context) {
+	medicationID, ok := uuidParam(c, "id", "Invalid medication ID")
+	if !ok {
+		return
+	}
+
+	medication, err := h.service.DeactivateMedication(c.Request.Context(), AuthUserID(c), medicationID)
+	if err != nil {
+		h.respondMedicationError(c, err, medicationID, "Failed to deactivate medication")
+		return
+	}
+
+	c.JSON(http.StatusOK, toMedicationResponse(medication))
+}
+
+// PostMedicationReactivate marks a medication as taken again, clearing a past end date
+// POST /api/v1/health/medications/:id/reactivate
+func (h *MedicationHandler) PostMedicationReactivate(c *gin.Context) {
+	medicationID, ok := uuidParam(c, "id", "Invalid medication ID")
+	if !ok {
+		return
+	}
+
+	medication, err := h.service.ReactivateMedication(c.Request.Context(), AuthUserID(c), medicationID)
+	if err != nil {
+		h.respondMedicationError(c, err, medicationID, "Failed to reactivate medication")
+		return
+	}
+
+	c.JSON(http.StatusOK, toMedicationResponse(medication))
+}
+
 // derefString safely dereferences a string pointer, returning empty string if nil
 func derefString(s *string) string {
 	if s == nil {
@@ -230,3 +319,328 @@ func derefString(s *string) string {
 	}
 	return *s
 }
+
+// defaultReminderCount is the number of upcoming reminders returned when count is not specified
+const defaultReminderCount = 5
+
+// medicationScheduleRequest is the body for setting a medication reminder schedule
+type medicationScheduleRequest struct {
+	TimesOfDay []string `json:"times_of_day"`
+	DaysOfWeek []int    `json:"days_of_week"`
+	AsNeeded   bool     `json:"as_needed"`
+}
+
+// medicationScheduleResponse is the API representation of a schedule with upcoming reminders
+type medicationScheduleResponse struct {
+	MedicationID string      `json:"medication_id"`
+	TimesOfDay   []string    `json:"times_of_day"`
+	DaysOfWeek   []int       `json:"days_of_week"`
+	AsNeeded     bool        `json:"as_needed"`
+	Derived      bool        `json:"derived"`
+	NextDue      []time.Time `json:"next_due,omitempty"`
+}
+
+// toMedicationScheduleResponse converts a schedule model to its API representation
+func toMedicationScheduleResponse(schedule *model.MedicationSchedule) medicationScheduleResponse {
+	days := make([]int, 0, len(schedule.DaysOfWeek))
+	for _, d := range schedule.DaysOfWeek {
+		days = append(days, int(d))
+	}
+	return medicationScheduleResponse{
+		MedicationID: schedule.MedicationID,
+		TimesOfDay:   schedule.TimesOfDay,
+		DaysOfWeek:   days,
+		AsNeeded:     schedule.AsNeeded,
+	}
+}
+
+// GetMedicationSchedule returns the next due reminder times of a medication
+// GET /api/v1/health/medications/:id/schedule?count=
+func (h *MedicationHandler) GetMedicationSchedule(c *gin.Context) {
+	medID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid medication ID",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	count := defaultReminderCount
+	if countParam := c.Query("count"); countParam != "" {
+		count, err = strconv.Atoi(countParam)
+		if err != nil || count < 1 || count > 100 {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "count must be between 1 and 100",
+			})
+			return
+		}
+	}
+
+	reminders, err := h.service.GetUpcomingReminders(c.Request.Context(), medID.String(), count)
+	if err != nil {
+		h.logger.Error("failed to get medication schedule",
+			zap.Error(err),
+			zap.String("medication_id", medID.String()),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get medication schedule",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	response := toMedicationScheduleResponse(reminders.Schedule)
+	response.Derived = reminders.Derived
+	response.NextDue = reminders.DueTimes
+
+	c.JSON(http.StatusOK, response)
+}
+
+// PutMedicationSchedule sets the reminder schedule of a medication. An empty body
+// derives the schedule from the medication's frequency text; "as_needed" marks a
+// medication taken when needed, without times.
+// PUT /api/v1/health/medications/:id/schedule
+func (h *MedicationHandler) PutMedicationSchedule(c *gin.Context) {
+	medID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid medication ID",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	var req medicationScheduleRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Error("invalid request body", zap.Error(err))
+			c.JSON(http.StatusBadRequest, api.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "Invalid request body",
+				Details: stringPtr(err.Error()),
+			})
+			return
+		}
+	}
+
+	var schedule *model.MedicationSchedule
+	if req.AsNeeded || len(req.TimesOfDay) > 0 || len(req.DaysOfWeek) > 0 {
+		schedule = &model.MedicationSchedule{TimesOfDay: req.TimesOfDay, AsNeeded: req.AsNeeded}
+		for _, d := range req.DaysOfWeek {
+			schedule.DaysOfWeek = append(schedule.DaysOfWeek, time.Weekday(d))
+		}
+		if err := service.ValidateSchedule(schedule); err != nil {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "Invalid schedule",
+				Details: stringPtr(err.Error()),
+			})
+			return
+		}
+	}
+
+	saved, err := h.service.ScheduleReminders(c.Request.Context(), medID.String(), schedule)
+	if err != nil {
+		h.logger.Error("failed to schedule medication reminders",
+			zap.Error(err),
+			zap.String("medication_id", medID.String()),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to schedule medication reminders",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, toMedicationScheduleResponse(saved))
+}
+
+const (
+	// defaultDueWindow is how far around at doses are listed when window is not specified
+	defaultDueWindow = time.Hour
+	// maxDueWindow bounds the window so a request reads at most a few days of logs
+	maxDueWindow = 12 * time.Hour
+)
+
+// GetDueMedications lists the doses of a user's active medications scheduled within
+// window (a duration such as 30m, default 1h) before or after at (RFC 3339, default now),
+// in the user's time zone, with the doses already logged marked taken or skipped
+// GET /api/v1/health/medications/due?user_id=&at=&window=
+func (h *MedicationHandler) GetDueMedications(c *gin.Context) {
+	userID, ok := queryUserID(c)
+	if !ok {
+		return
+	}
+
+	at := time.Now()
+	if raw := c.Query("at"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "Invalid at parameter, expected an RFC 3339 time",
+			})
+			return
+		}
+		at = parsed
+	}
+
+	window := defaultDueWindow
+	if raw := c.Query("window"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed < time.Minute || parsed > maxDueWindow {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "window must be a duration between 1m and 12h",
+			})
+			return
+		}
+		window = parsed
+	}
+
+	due, err := h.service.GetDueDoses(c.Request.Context(), userID, at, window)
+	if err != nil {
+		h.logger.Error("failed to get due medications",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get due medications",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, due)
+}
+
+// medicationAdherenceRequest is the body for logging whether a dose was taken. TakenAt
+// defaults to now.
+type medicationAdherenceRequest struct {
+	TakenAt   *time.Time `json:"taken_at"`
+	Adherence *bool      `json:"adherence" binding:"required"`
+	Notes     *string    `json:"notes"`
+}
+
+// PostMedicationAdherence logs whether a dose of a medication was taken
+// POST /api/v1/health/medications/:id/adherence
+func (h *MedicationHandler) PostMedicationAdherence(c *gin.Context) {
+	medicationID, ok := uuidParam(c, "id", "Invalid medication ID")
+	if !ok {
+		return
+	}
+
+	var req medicationAdherenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	takenAt := time.Now()
+	if req.TakenAt != nil {
+		takenAt = *req.TakenAt
+	}
+
+	log, err := h.service.LogAdherence(c.Request.Context(), AuthUserID(c), medicationID, takenAt, *req.Adherence, req.Notes)
+	if err != nil {
+		h.respondMedicationError(c, err, medicationID, "Failed to log medication adherence")
+		return
+	}
+
+	c.JSON(http.StatusCreated, log)
+}
+
+// GetMedicationAdherence lists the adherence logs of a medication newest first. from and
+// to accept a date or an RFC 3339 time; a date passed as to includes that whole day.
+// GET /api/v1/health/medications/:id/adherence?from=&to=
+func (h *MedicationHandler) GetMedicationAdherence(c *gin.Context) {
+	medicationID, ok := uuidParam(c, "id", "Invalid medication ID")
+	if !ok {
+		return
+	}
+
+	var from, to time.Time
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := parseSince(raw)
+		if err != nil {
+			respondInvalidAdherenceWindow(c, "from")
+			return
+		}
+		from = parsed
+	}
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := parseSince(raw)
+		if err != nil {
+			respondInvalidAdherenceWindow(c, "to")
+			return
+		}
+		if _, err := time.Parse(time.DateOnly, raw); err == nil {
+			parsed = parsed.AddDate(0, 0, 1)
+		}
+		to = parsed
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "from must be before to",
+		})
+		return
+	}
+
+	logs, err := h.service.GetAdherenceLogs(c.Request.Context(), AuthUserID(c), medicationID, from, to)
+	if err != nil {
+		h.respondMedicationError(c, err, medicationID, "Failed to get medication adherence")
+		return
+	}
+	if logs == nil {
+		logs = []model.MedicationLog{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"logs": logs})
+}
+
+// respondMedicationError writes the error response for a failed request on one medication
+func (h *MedicationHandler) respondMedicationError(c *gin.Context, err error, medicationID, message string) {
+	switch {
+	case errors.Is(err, repository.ErrMedicationNotFound):
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Medication not found",
+		})
+	case errors.Is(err, service.ErrMedicationAccessDenied):
+		c.JSON(http.StatusForbidden, api.ErrorResponse{
+			Code:    "FORBIDDEN",
+			Message: "Access to another user's data is not allowed",
+		})
+	default:
+		h.logger.Error("medication request failed",
+			zap.Error(err),
+			zap.String("medication_id", medicationID),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: message,
+			Details: stringPtr(err.Error()),
+		})
+	}
+}
+
+// respondInvalidAdherenceWindow writes the error response for an unparsable time bound
+func respondInvalidAdherenceWindow(c *gin.Context, param string) {
+	c.JSON(http.StatusBadRequest, api.ErrorResponse{
+		Code:    "VALIDATION_ERROR",
+		Message: "Invalid " + param + " parameter, expected YYYY-MM-DD or an RFC 3339 time",
+	})
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+func TestMedicationAdherence_InvalidRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+	h := NewMedicationHandler(service.NewMedicationService(nil, logger), logger)
+	router := gin.New()
+	router.POST("/medications/:id/adherence", h.PostMedicationAdherence)
+	router.GET("/medications/:id/adherence", h.GetMedicationAdherence)
+
+	medicationID := uuid.NewString()
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"invalid medication ID", http.MethodPost, "/medications/not-a-uuid/adherence", `{"adherence": true}`},
+		{"missing adherence", http.MethodPost, "/medications/" + medicationID + "/adherence", `{"notes": "elfelejtettem"}`},
+		{"invalid taken_at", http.MethodPost, "/medications/" + medicationID + "/adherence", `{"adherence": false, "taken_at": "tegnap"}`},
+		{"invalid from", http.MethodGet, "/medications/" + medicationID + "/adherence?from=yesterday", ""},
+		{"from after to", http.MethodGet, "/medications/" + medicationID + "/adherence?from=2026-03-02&to=2026-03-01", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestGetDueMedications_InvalidRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+	h := NewMedicationHandler(service.NewMedicationService(nil, logger), logger)
+	router := gin.New()
+	router.GET("/medications/due", h.GetDueMedications)
+
+	userID := uuid.NewString()
+	for name, query := range map[string]string{
+		"invalid user ID":  "user_id=not-a-uuid",
+		"invalid at":       "user_id=" + userID + "&at=2026-03-04",
+		"invalid window":   "user_id=" + userID + "&window=an-hour",
+		"window too large": "user_id=" + userID + "&window=24h",
+		"window too small": "user_id=" + userID + "&window=30s",
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/medications/due?"+query, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestMedicationActiveStatus_InvalidRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+	h := NewMedicationHandler(service.NewMedicationService(nil, logger), logger)
+	router := gin.New()
+	router.POST("/medications/:id/deactivate", h.PostMedicationDeactivate)
+	router.POST("/medications/:id/reactivate", h.PostMedicationReactivate)
+	router.POST("/medications/:id/restore", h.PostMedicationRestore)
+	router.DELETE("/medications/:id", func(c *gin.Context) {
+		h.DeleteApiV1HealthMedicationsId(c, uuid.MustParse(c.Param("id")))
+	})
+	router.GET("/medications", func(c *gin.Context) {
+		h.GetApiV1HealthMedications(c, api.GetApiV1HealthMedicationsParams{UserId: uuid.New()})
+	})
+
+	for name, req := range map[string]*http.Request{
+		"deactivate invalid ID": httptest.NewRequest(http.MethodPost, "/medications/not-a-uuid/deactivate", nil),
+		"reactivate invalid ID": httptest.NewRequest(http.MethodPost, "/medications/not-a-uuid/reactivate", nil),
+		"restore invalid ID":    httptest.NewRequest(http.MethodPost, "/medications/not-a-uuid/restore", nil),
+		"invalid permanent":     httptest.NewRequest(http.MethodDelete, "/medications/"+uuid.NewString()+"?permanent=maybe", nil),
+		"invalid active filter": httptest.NewRequest(http.MethodGet, "/medications?active=maybe", nil),
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestMedicationList_ETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	medication := model.Medication{
+		ID:        uuid.NewString(),
+		UserID:    uuid.NewString(),
+		Name:      "Metformin",
+		Dosage:    "500mg",
+		Frequency: "daily",
+		StartDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Active:    true,
+	}
+	router := gin.New()
+	router.GET("/medications", func(c *gin.Context) {
+		respondWithETag(c, newPageResponse([]api.MedicationResponse{toMedicationResponse(&medication)}, 1, repository.Page{Limit: 50}))
+	})
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/medications", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first, second := get(""), get("")
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+	assert.Equal(t, etag, second.Header().Get("ETag"), "identical lists have the same ETag")
+	assert.JSONEq(t, first.Body.String(), second.Body.String())
+
+	unchanged := get(etag)
+	assert.Equal(t, http.StatusNotModified, unchanged.Code)
+	assert.Empty(t, unchanged.Body.String())
+
+	medication.Dosage = "850mg"
+	updated := get(etag)
+	assert.Equal(t, http.StatusOK, updated.Code)
+	assert.NotEqual(t, etag, updated.Header().Get("ETag"), "updating a medication changes the ETag")
+	assert.Contains(t, updated.Body.String(), "850mg")
+}
@@ -0,0 +1,330 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// OrganizationHandler implements organization membership and role endpoints
+type OrganizationHandler struct {
+	service *service.OrganizationService
+	logger  *zap.Logger
+}
+
+// NewOrganizationHandler creates a new OrganizationHandler
+func NewOrganizationHandler(service *service.OrganizationService, logger *zap.Logger) *OrganizationHandler {
+	return &OrganizationHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// createOrganizationRequest is the body of an organization creation request
+type createOrganizationRequest struct {
+	Name          string `json:"name" binding:"required"`
+	DataResidency string `json:"data_residency"`
+}
+
+// dataResidencyRequest is the body of a data residency change, empty clears it
+type dataResidencyRequest struct {
+	DataResidency string `json:"data_residency"`
+}
+
+// inviteMemberRequest is the body of an invitation request
+type inviteMemberRequest struct {
+	Email string     `json:"email" binding:"required"`
+	Role  model.Role `json:"role" binding:"required"`
+}
+
+// invitationResponse is a created invitation. The token is returned once so it can be
+// delivered to the invitee; it cannot be retrieved again.
+type invitationResponse struct {
+	model.OrganizationInvitation
+	Token string `json:"token"`
+}
+
+// acceptInvitationRequest is the body of an invitation acceptance request
+type acceptInvitationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// assignRoleRequest is the body of a role assignment request
+type assignRoleRequest struct {
+	Role model.Role `json:"role" binding:"required"`
+}
+
+// CreateOrganization creates an organization
+// POST /api/v1/admin/organizations
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	var req createOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	org, err := h.service.CreateOrganization(c.Request.Context(), req.Name, req.DataResidency)
+	if err != nil {
+		h.writeError(c, err, "Failed to create organization")
+		return
+	}
+
+	c.JSON(http.StatusCreated, org)
+}
+
+// PutDataResidency changes the storage backend new artifacts of an organization's
+// patients are written to
+// PUT /api/v1/admin/organizations/:id/residency
+func (h *OrganizationHandler) PutDataResidency(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	var req dataResidencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	org, err := h.service.SetDataResidency(c.Request.Context(), orgID, req.DataResidency, AuthUserID(c))
+	if err != nil {
+		h.writeError(c, err, "Failed to set data residency")
+		return
+	}
+
+	c.JSON(http.StatusOK, org)
+}
+
+// InviteMember invites a user by email to join an organization with a role
+// POST /api/v1/orgs/:id/invitations
+func (h *OrganizationHandler) InviteMember(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	var req inviteMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	invitation, token, err := h.service.InviteMember(c.Request.Context(), orgID, req.Email, req.Role, AuthUserID(c))
+	if err != nil {
+		h.writeError(c, err, "Failed to create invitation")
+		return
+	}
+
+	h.logger.Info("organization invitation created",
+		zap.String("organization_id", orgID),
+		zap.String("invitation_id", invitation.ID),
+	)
+
+	c.JSON(http.StatusCreated, invitationResponse{
+		OrganizationInvitation: *invitation,
+		Token:                  token,
+	})
+}
+
+// AcceptInvitation grants the authenticated user the role of an invitation
+// POST /api/v1/invitations/accept
+func (h *OrganizationHandler) AcceptInvitation(c *gin.Context) {
+	userID := AuthUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, api.ErrorResponse{
+			Code:    "UNAUTHORIZED",
+			Message: "Accepting an invitation requires an authenticated user",
+		})
+		return
+	}
+
+	var req acceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	invitation, err := h.service.AcceptInvitation(c.Request.Context(), req.Token, userID)
+	if err != nil {
+		h.writeError(c, err, "Failed to accept invitation")
+		return
+	}
+
+	c.JSON(http.StatusOK, model.RoleAssignment{
+		OrganizationID: invitation.OrganizationID,
+		UserID:         userID,
+		Role:           invitation.Role,
+		GrantedBy:      invitation.InvitedBy,
+		CreatedAt:      derefTime(invitation.AcceptedAt),
+	})
+}
+
+// ListMembers lists the members of an organization with their roles
+// GET /api/v1/orgs/:id/members
+func (h *OrganizationHandler) ListMembers(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.service.ListMembers(c.Request.Context(), orgID)
+	if err != nil {
+		h.writeError(c, err, "Failed to list members")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"members": members})
+}
+
+// AssignRole grants a member a role in an organization
+// POST /api/v1/orgs/:id/members/:user_id/roles
+func (h *OrganizationHandler) AssignRole(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+	memberID, ok := parseMemberID(c)
+	if !ok {
+		return
+	}
+
+	var req assignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	assignment, err := h.service.AssignRole(c.Request.Context(), orgID, memberID, req.Role, AuthUserID(c))
+	if err != nil {
+		h.writeError(c, err, "Failed to assign role")
+		return
+	}
+
+	c.JSON(http.StatusOK, assignment)
+}
+
+// RevokeRole removes a role from a member of an organization
+// DELETE /api/v1/orgs/:id/members/:user_id/roles/:role
+func (h *OrganizationHandler) RevokeRole(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+	memberID, ok := parseMemberID(c)
+	if !ok {
+		return
+	}
+
+	err := h.service.RevokeRole(c.Request.Context(), orgID, memberID, model.Role(c.Param("role")), AuthUserID(c))
+	if err != nil {
+		h.writeError(c, err, "Failed to revoke role")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// writeError maps organization service errors to responses
+func (h *OrganizationHandler) writeError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrOrganizationNotFound):
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Organization not found",
+		})
+	case errors.Is(err, service.ErrRoleAssignmentNotFound):
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Role assignment not found",
+		})
+	case errors.Is(err, service.ErrInvitationInvalid):
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Invitation is invalid or has expired",
+		})
+	case errors.Is(err, service.ErrInvalidRole):
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid role",
+			Details: stringPtr("role must be one of patient, caregiver, clinician, org_admin"),
+		})
+	case errors.Is(err, service.ErrInvalidEmail):
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid email address",
+			Details: stringPtr(err.Error()),
+		})
+	case errors.Is(err, service.ErrUnknownStorageBackend):
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Unknown data residency",
+			Details: stringPtr(err.Error()),
+		})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: message,
+			Details: stringPtr(err.Error()),
+		})
+	}
+}
+
+// parseOrganizationID parses the organization ID path parameter, writing the error response
+// and returning false when it is malformed
+func parseOrganizationID(c *gin.Context) (string, bool) {
+	return uuidParam(c, "id", "Invalid organization ID format")
+}
+
+// parseMemberID parses the member user ID path parameter
+func parseMemberID(c *gin.Context) (string, bool) {
+	return uuidParam(c, "user_id", "Invalid user ID format")
+}
+
+func uuidParam(c *gin.Context, name, message string) (string, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: message,
+			Details: stringPtr(err.Error()),
+		})
+		return "", false
+	}
+	return id.String(), true
+}
+
+func derefTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+)
+
+// pageResponse wraps one page of a list endpoint
+type pageResponse[T any] struct {
+	Items      []T     `json:"items"`
+	TotalCount int     `json:"total_count"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+// newPageResponse builds the response for a page of items out of total
+func newPageResponse[T any](items []T, total int, page repository.Page) pageResponse[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	return pageResponse[T]{Items: items, TotalCount: total, NextCursor: nextCursor(total, page)}
+}
+
+// nextCursor returns the cursor of the page after page, or nil on the last page
+func nextCursor(total int, page repository.Page) *string {
+	if !page.HasMore(total) {
+		return nil
+	}
+	return stringPtr(encodeCursor(page.Offset + page.Limit))
+}
+
+// parsePage reads the limit, offset and cursor query parameters. A cursor returned as
+// next_cursor takes precedence over offset. The limit defaults to 50 and is capped at 500.
+// It writes the error response and returns false on invalid parameters.
+func parsePage(c *gin.Context) (repository.Page, bool) {
+	var page repository.Page
+
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			respondInvalidPage(c, "limit must be a positive integer")
+			return page, false
+		}
+		page.Limit = limit
+	}
+
+	if raw := c.Query("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			respondInvalidPage(c, "offset must be a non-negative integer")
+			return page, false
+		}
+		page.Offset = offset
+	}
+
+	if raw := c.Query("cursor"); raw != "" {
+		offset, err := decodeCursor(raw)
+		if err != nil {
+			respondInvalidPage(c, err.Error())
+			return page, false
+		}
+		page.Offset = offset
+	}
+
+	return page.Normalize(), true
+}
+
+// encodeCursor returns the opaque cursor of the list position offset
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// decodeCursor returns the list position of a cursor created by encodeCursor
+func decodeCursor(cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor")
+	}
+	offset, err := strconv.Atoi(string(raw))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid cursor")
+	}
+	return offset, nil
+}
+
+// respondInvalidPage writes a validation error for pagination parameters
+func respondInvalidPage(c *gin.Context, details string) {
+	c.JSON(http.StatusBadRequest, api.ErrorResponse{
+		Code:    "VALIDATION_ERROR",
+		Message: "Invalid pagination parameters",
+		Details: stringPtr(details),
+	})
+}
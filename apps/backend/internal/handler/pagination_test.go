@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+)
+
+// newPagedListRouter serves the numbers 0..total-1 through the pagination helpers
+func newPagedListRouter(total int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/items", func(c *gin.Context) {
+		page, ok := parsePage(c)
+		if !ok {
+			return
+		}
+
+		var items []int
+		for i := page.Offset; i < total && i < page.Offset+page.Limit; i++ {
+			items = append(items, i)
+		}
+		c.JSON(http.StatusOK, newPageResponse(items, total, page))
+	})
+	return router
+}
+
+func TestPagination_WalksAllPagesWithCursor(t *testing.T) {
+	router := newPagedListRouter(120)
+
+	var pageSizes []int
+	var collected []int
+	target := "/items?limit=50"
+	for {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var page pageResponse[int]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Equal(t, 120, page.TotalCount)
+
+		pageSizes = append(pageSizes, len(page.Items))
+		collected = append(collected, page.Items...)
+
+		if page.NextCursor == nil {
+			break
+		}
+		target = "/items?limit=50&cursor=" + *page.NextCursor
+	}
+
+	assert.Equal(t, []int{50, 50, 20}, pageSizes)
+	for i, item := range collected {
+		assert.Equal(t, i, item)
+	}
+}
+
+func TestParsePage(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantPage   repository.Page
+	}{
+		{"defaults", "", http.StatusOK, repository.Page{Limit: 50}},
+		{"limit capped", "?limit=1000", http.StatusOK, repository.Page{Limit: 500}},
+		{"offset", "?limit=10&offset=30", http.StatusOK, repository.Page{Limit: 10, Offset: 30}},
+		{"cursor overrides offset", "?offset=5&cursor=" + encodeCursor(100), http.StatusOK, repository.Page{Limit: 50, Offset: 100}},
+		{"zero limit", "?limit=0", http.StatusBadRequest, repository.Page{}},
+		{"negative offset", "?offset=-1", http.StatusBadRequest, repository.Page{}},
+		{"invalid cursor", "?cursor=not-a-cursor", http.StatusBadRequest, repository.Page{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			var got repository.Page
+			router.GET("/items", func(c *gin.Context) {
+				page, ok := parsePage(c)
+				if !ok {
+					return
+				}
+				got = page
+				c.String(http.StatusOK, strconv.Itoa(page.Limit))
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items"+tt.query, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, tt.wantPage, got)
+			} else {
+				assert.Contains(t, w.Body.String(), "VALIDATION_ERROR")
+			}
+		})
+	}
+}
+
+func TestNewPageResponse_EmptyListHasNoCursor(t *testing.T) {
+	body, err := json.Marshal(newPageResponse[int](nil, 0, repository.Page{Limit: 50}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"items":[],"total_count":0,"next_cursor":null}`, string(body))
+}
@@ -0,0 +1,327 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/middleware"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// PanelHandler implements clinician patient panel endpoints
+type PanelHandler struct {
+	service *service.PanelService
+	logger  *zap.Logger
+	now     func() time.Time
+}
+
+// NewPanelHandler creates a new PanelHandler
+func NewPanelHandler(service *service.PanelService, logger *zap.Logger) *PanelHandler {
+	return &PanelHandler{
+		service: service,
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+// assignPatientRequest is the body of a panel assignment request
+type assignPatientRequest struct {
+	ClinicianID string `json:"clinician_id" binding:"required,uuid"`
+	PatientID   string `json:"patient_id" binding:"required,uuid"`
+}
+
+// digestSubscriptionRequest is the body of a digest opt-in request
+type digestSubscriptionRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+// AssignPatient puts a patient of the organization on a clinician's panel
+// POST /api/v1/orgs/:id/panel-assignments
+func (h *PanelHandler) AssignPatient(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	var req assignPatientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	assignment, err := h.service.AssignPatient(c.Request.Context(), orgID,
+		uuid.MustParse(req.ClinicianID).String(), uuid.MustParse(req.PatientID).String(), AuthUserID(c))
+	if err != nil {
+		h.writeError(c, err, "Failed to assign patient")
+		return
+	}
+
+	c.JSON(http.StatusOK, assignment)
+}
+
+// ListAssignments lists the patients on a clinician's panel
+// GET /api/v1/orgs/:id/panel-assignments?clinician_id=
+func (h *PanelHandler) ListAssignments(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+	clinicianID, err := uuid.Parse(c.Query("clinician_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid clinician ID",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	assignments, err := h.service.ListAssignments(c.Request.Context(), orgID, clinicianID.String())
+	if err != nil {
+		h.writeError(c, err, "Failed to list panel assignments")
+		return
+	}
+	if assignments == nil {
+		assignments = []model.PanelAssignment{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"assignments": assignments})
+}
+
+// UnassignPatient removes a patient from a clinician's panel
+// DELETE /api/v1/orgs/:id/panel-assignments/:clinician_id/:patient_id
+func (h *PanelHandler) UnassignPatient(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+	clinicianID, ok := uuidParam(c, "clinician_id", "Invalid clinician ID format")
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "patient_id", "Invalid patient ID format")
+	if !ok {
+		return
+	}
+
+	if err := h.service.UnassignPatient(c.Request.Context(), orgID, clinicianID, patientID, AuthUserID(c)); err != nil {
+		h.writeError(c, err, "Failed to unassign patient")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListFindings lists the alerts and data-quality findings across the authenticated
+// clinician's panel, most severe first. Organization admins may pass clinician_id to
+// view another clinician's panel. since accepts a date or an RFC 3339 time and defaults
+// to seven days ago.
+// GET /api/v1/admin/panel/findings?organization_id=&since=
+func (h *PanelHandler) ListFindings(c *gin.Context) {
+	orgID, clinicianID, ok := h.panelOwner(c)
+	if !ok {
+		return
+	}
+
+	since := h.now().Add(-service.DefaultPanelFindingsWindow)
+	if raw := c.Query("since"); raw != "" {
+		parsed, err := parseSince(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "Invalid since parameter, expected YYYY-MM-DD or an RFC 3339 time",
+				Details: stringPtr(err.Error()),
+			})
+			return
+		}
+		since = parsed
+	}
+
+	page, ok := parsePage(c)
+	if !ok {
+		return
+	}
+
+	findings, total, err := h.service.ListFindings(c.Request.Context(), orgID, clinicianID, since, page)
+	if err != nil {
+		h.writeError(c, err, "Failed to list panel findings")
+		return
+	}
+
+	c.JSON(http.StatusOK, newPageResponse(findings, total, page))
+}
+
+// SubscribeDigest opts the authenticated clinician in to a daily email digest of their
+// panel's findings
+// PUT /api/v1/admin/panel/digest?organization_id=
+func (h *PanelHandler) SubscribeDigest(c *gin.Context) {
+	orgID, ok := h.clinicianOrganization(c)
+	if !ok {
+		return
+	}
+
+	var req digestSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	subscription, err := h.service.SubscribeDigest(c.Request.Context(), orgID, AuthUserID(c), req.Email)
+	if err != nil {
+		h.writeError(c, err, "Failed to subscribe to the panel digest")
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+// UnsubscribeDigest opts the authenticated clinician out of the daily email digest
+// DELETE /api/v1/admin/panel/digest?organization_id=
+func (h *PanelHandler) UnsubscribeDigest(c *gin.Context) {
+	orgID, ok := h.clinicianOrganization(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.UnsubscribeDigest(c.Request.Context(), orgID, AuthUserID(c)); err != nil {
+		h.writeError(c, err, "Failed to unsubscribe from the panel digest")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// panelOwner returns the organization and the clinician whose panel is requested.
+// Clinicians see their own panel; organization admins may name another clinician.
+func (h *PanelHandler) panelOwner(c *gin.Context) (orgID, clinicianID string, ok bool) {
+	orgID, ok = queryOrganizationID(c)
+	if !ok {
+		return "", "", false
+	}
+
+	raw := c.Query("clinician_id")
+	if raw == "" || raw == AuthUserID(c) {
+		if !requireOrgRole(c, orgID, model.RoleClinician) {
+			return "", "", false
+		}
+		return orgID, AuthUserID(c), true
+	}
+
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid clinician ID",
+			Details: stringPtr(err.Error()),
+		})
+		return "", "", false
+	}
+	if !requireOrgRole(c, orgID, model.RoleOrgAdmin) {
+		return "", "", false
+	}
+	return orgID, id.String(), true
+}
+
+// clinicianOrganization returns the organization_id query parameter after checking the
+// authenticated user is a clinician there
+func (h *PanelHandler) clinicianOrganization(c *gin.Context) (string, bool) {
+	orgID, ok := queryOrganizationID(c)
+	if !ok || !requireOrgRole(c, orgID, model.RoleClinician) {
+		return "", false
+	}
+	return orgID, true
+}
+
+// writeError maps panel service errors to responses
+func (h *PanelHandler) writeError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrNotClinician), errors.Is(err, service.ErrNotPatient):
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Panels hold patients of the organization assigned to its clinicians",
+			Details: stringPtr(err.Error()),
+		})
+	case errors.Is(err, service.ErrPanelAssignmentNotFound):
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Patient is not on the clinician's panel",
+		})
+	case errors.Is(err, service.ErrDigestSubscriptionNotFound):
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Digest subscription not found",
+		})
+	case errors.Is(err, service.ErrInvalidDigestEmail):
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid email address",
+			Details: stringPtr(err.Error()),
+		})
+	case errors.Is(err, service.ErrEmailNotVerified):
+		c.JSON(http.StatusConflict, api.ErrorResponse{
+			Code:    "EMAIL_NOT_VERIFIED",
+			Message: "Digests are only sent to your confirmed email address; confirm it first",
+		})
+	case errors.Is(err, service.ErrDigestUnavailable):
+		c.JSON(http.StatusServiceUnavailable, api.ErrorResponse{
+			Code:    "DIGEST_UNAVAILABLE",
+			Message: "Email digests are not configured on this server",
+		})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: message,
+			Details: stringPtr(err.Error()),
+		})
+	}
+}
+
+// queryOrganizationID parses the organization_id query parameter
+func queryOrganizationID(c *gin.Context) (string, bool) {
+	id, err := uuid.Parse(c.Query("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid organization ID",
+			Details: stringPtr(err.Error()),
+		})
+		return "", false
+	}
+	return id.String(), true
+}
+
+// requireOrgRole checks the authenticated user holds one of roles in the organization,
+// responding with 403 otherwise
+func requireOrgRole(c *gin.Context, orgID string, roles ...model.Role) bool {
+	if middleware.HasRole(c, orgID, roles...) {
+		return true
+	}
+	c.JSON(http.StatusForbidden, api.ErrorResponse{
+		Code:    "FORBIDDEN",
+		Message: "Insufficient role for this organization",
+	})
+	return false
+}
+
+// parseSince parses a date or an RFC 3339 time
+func parseSince(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, raw); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, raw)
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// PersonalAccessTokenHandler implements personal access token endpoints
+type PersonalAccessTokenHandler struct {
+	service *service.PersonalAccessTokenService
+	logger  *zap.Logger
+}
+
+// NewPersonalAccessTokenHandler creates a new PersonalAccessTokenHandler
+func NewPersonalAccessTokenHandler(service *service.PersonalAccessTokenService, logger *zap.Logger) *PersonalAccessTokenHandler {
+	return &PersonalAccessTokenHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// createTokenRequest is the body of a personal access token creation request. A
+// missing expires_at keeps the token valid for 90 days.
+type createTokenRequest struct {
+	Name      string             `json:"name" binding:"required"`
+	Scopes    []model.TokenScope `json:"scopes" binding:"required"`
+	ExpiresAt *time.Time         `json:"expires_at"`
+}
+
+// createdTokenResponse is a created personal access token. The token is returned once
+// so the user can store it; it cannot be retrieved again.
+type createdTokenResponse struct {
+	model.PersonalAccessToken
+	Token string `json:"token"`
+}
+
+// CreateToken creates a personal access token for the user
+// POST /api/v1/users/:id/tokens
+func (h *PersonalAccessTokenHandler) CreateToken(c *gin.Context) {
+	userID, ok := uuidParam(c, "id", "Invalid user ID format")
+	if !ok || !authorizeUser(c, userID) {
+		return
+	}
+
+	var req createTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	token, secret, err := h.service.CreateToken(c.Request.Context(), userID, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidPersonalAccessToken) {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "Invalid personal access token",
+				Details: stringPtr(err.Error()),
+			})
+			return
+		}
+		h.respondError(c, err, userID, "Failed to create personal access token")
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdTokenResponse{
+		PersonalAccessToken: *token,
+		Token:               secret,
+	})
+}
+
+// ListTokens lists the user's personal access tokens
+// GET /api/v1/users/:id/tokens
+func (h *PersonalAccessTokenHandler) ListTokens(c *gin.Context) {
+	userID, ok := uuidParam(c, "id", "Invalid user ID format")
+	if !ok || !authorizeUser(c, userID) {
+		return
+	}
+
+	tokens, err := h.service.ListTokens(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, err, userID, "Failed to list personal access tokens")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tokens": tokens})
+}
+
+// RevokeToken revokes one of the user's personal access tokens
+// DELETE /api/v1/users/:id/tokens/:token_id
+func (h *PersonalAccessTokenHandler) RevokeToken(c *gin.Context) {
+	userID, ok := uuidParam(c, "id", "Invalid user ID format")
+	if !ok || !authorizeUser(c, userID) {
+		return
+	}
+	tokenID, ok := uuidParam(c, "token_id", "Invalid token ID format")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RevokeToken(c.Request.Context(), userID, tokenID); err != nil {
+		if errors.Is(err, repository.ErrPersonalAccessTokenNotFound) {
+			c.JSON(http.StatusNotFound, api.ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "Personal access token not found",
+			})
+			return
+		}
+		h.respondError(c, err, userID, "Failed to revoke personal access token")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondError logs a failed token operation and writes the error response
+func (h *PersonalAccessTokenHandler) respondError(c *gin.Context, err error, userID, message string) {
+	h.logger.Error("personal access token operation failed",
+		zap.Error(err),
+		zap.String("user_id", userID),
+	)
+	c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+		Code:    "INTERNAL_ERROR",
+		Message: message,
+		Details: stringPtr(err.Error()),
+	})
+}
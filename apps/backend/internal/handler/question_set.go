@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// QuestionSetHandler implements check-in question set endpoints
+type QuestionSetHandler struct {
+	service *service.QuestionSetService
+	logger  *zap.Logger
+}
+
+// NewQuestionSetHandler creates a new QuestionSetHandler
+func NewQuestionSetHandler(service *service.QuestionSetService, logger *zap.Logger) *QuestionSetHandler {
+	return &QuestionSetHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// createQuestionSetRequest is the body of a question set creation request
+type createQuestionSetRequest struct {
+	Name      string                      `json:"name" binding:"required"`
+	Language  string                      `json:"language"`
+	Questions []model.QuestionSetQuestion `json:"questions" binding:"required"`
+}
+
+// assignQuestionSetRequest is the body of a question set assignment. A null
+// question_set_id returns the user to the built-in question set.
+type assignQuestionSetRequest struct {
+	QuestionSetID *string `json:"question_set_id" binding:"omitempty,uuid"`
+}
+
+// CreateQuestionSet creates a check-in question set
+// POST /api/v1/admin/question-sets
+func (h *QuestionSetHandler) CreateQuestionSet(c *gin.Context) {
+	var req createQuestionSetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	set := &model.QuestionSet{
+		Name:      req.Name,
+		Language:  req.Language,
+		Questions: req.Questions,
+	}
+	if err := h.service.CreateQuestionSet(c.Request.Context(), set, AuthUserID(c)); err != nil {
+		if errors.Is(err, service.ErrInvalidQuestionSet) {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "Invalid question set",
+				Details: stringPtr(err.Error()),
+			})
+			return
+		}
+		h.logger.Error("failed to create question set", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to create question set",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, set)
+}
+
+// AssignQuestionSet sets the question set a user's future check-ins ask
+// PUT /api/v1/users/:id/question-set
+func (h *QuestionSetHandler) AssignQuestionSet(c *gin.Context) {
+	userID, ok := uuidParam(c, "id", "Invalid user ID format")
+	if !ok {
+		return
+	}
+
+	var req assignQuestionSetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	var questionSetID string
+	if req.QuestionSetID != nil {
+		questionSetID = uuid.MustParse(*req.QuestionSetID).String()
+	}
+
+	if err := h.service.AssignQuestionSet(c.Request.Context(), userID, questionSetID, AuthUserID(c)); err != nil {
+		if errors.Is(err, repository.ErrQuestionSetNotFound) {
+			c.JSON(http.StatusNotFound, api.ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "Question set not found",
+			})
+			return
+		}
+		h.logger.Error("failed to assign question set",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to assign question set",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id":         userID,
+		"question_set_id": req.QuestionSetID,
+	})
+}
@@ -1,16 +1,29 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/oapi-codegen/runtime/types"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/pdf"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 	"go.uber.org/zap"
 )
 
+// ReportVerifyPath is the report verification lookup. It is public: whoever holds a
+// printed report checks it with the code on it, without an account.
+const ReportVerifyPath = "/api/v1/reports/verify"
+
 // ReportHandler implements report API endpoints
 type ReportHandler struct {
 	service *service.ReportService
@@ -39,6 +52,9 @@ func (h *ReportHandler) PostApiV1ReportsGenerate(c *gin.Context) {
 	}
 
 	userID := uuidToString(req.UserId)
+	if !authorizeUser(c, userID) {
+		return
+	}
 
 	// Convert dates
 	startDate := dateToTime(req.StartDate)
@@ -53,12 +69,70 @@ func (h *ReportHandler) PostApiV1ReportsGenerate(c *gin.Context) {
 		return
 	}
 
-	// Generate report (this could be done asynchronously in production)
-	// For now, we'll use a placeholder user name
-	userName := "User"
-	reportID, err := h.service.GenerateReport(c.Request.Context(), userID, userName, startDate, endDate)
+	// Queue the report; clients poll GET /api/v1/reports/:id/status until it completes
+	language := pdf.ParseLanguage(c.GetHeader("Accept-Language"))
+	format, sections := reportOptions(req)
+	encrypt := req.Encrypt != nil && *req.Encrypt
+	status, err := h.service.GenerateReport(c.Request.Context(), userID, language, format, sections, encrypt, startDate, endDate)
+	if errors.Is(err, service.ErrUnsupportedReportFormat) {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: `Report format must be "pdf" or "csv"`,
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+	if errors.Is(err, service.ErrUnknownReportSection) {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Report sections must be symptoms, medications, adherence, blood_pressure, menstruation, activity, meals or summaries",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+	if errors.Is(err, service.ErrReportEncryptionUnsupported) {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Only PDF reports can be password protected",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+	if errors.Is(err, service.ErrReportEncryptionUnavailable) {
+		c.JSON(http.StatusServiceUnavailable, api.ErrorResponse{
+			Code:    "REPORT_ENCRYPTION_UNAVAILABLE",
+			Message: "Password protected reports are not configured on this server",
+		})
+		return
+	}
+	if errors.Is(err, service.ErrReportUserDeleted) {
+		c.JSON(http.StatusGone, api.ErrorResponse{
+			Code:    "USER_DELETED",
+			Message: "The user's data was deleted, no report can be generated",
+		})
+		return
+	}
+	var rateLimitErr *service.ReportRateLimitError
+	if errors.As(err, &rateLimitErr) {
+		retryAfter := int(math.Ceil(rateLimitErr.RetryAfter.Seconds()))
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.JSON(http.StatusTooManyRequests, api.ErrorResponse{
+			Code:    "RATE_LIMITED",
+			Message: "Too many report generation requests",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+	if errors.Is(err, service.ErrReportQueueUnavailable) {
+		c.JSON(http.StatusServiceUnavailable, api.ErrorResponse{
+			Code:    "REPORT_QUEUE_UNAVAILABLE",
+			Message: "Report generation is busy, please try again later",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
 	if err != nil {
-		h.logger.Error("failed to generate report",
+		h.logger.Error("failed to queue report",
 			zap.Error(err),
 			zap.String("user_id", userID),
 		)
@@ -70,18 +144,205 @@ func (h *ReportHandler) PostApiV1ReportsGenerate(c *gin.Context) {
 		return
 	}
 
-	// Return report ID
-	response := gin.H{
-		"report_id": reportID,
-		"message":   "Report generated successfully",
-	}
-
-	h.logger.Info("report generated",
-		zap.String("report_id", reportID),
+	h.logger.Info("report queued",
+		zap.String("report_id", status.ReportID),
 		zap.String("user_id", userID),
+		zap.String("status", string(status.Status)),
 	)
 
-	c.JSON(http.StatusOK, response)
+	// A deduplicated request returns an already completed report
+	code := http.StatusAccepted
+	if status.Status == model.ReportStatusCompleted {
+		code = http.StatusOK
+	}
+	c.JSON(code, status)
+}
+
+// reportOptions returns the format of a report request, empty for the default PDF, and
+// its sections, nil for all
+func reportOptions(req api.GenerateReportRequest) (model.ReportFormat, []model.ReportSection) {
+	var format model.ReportFormat
+	if req.Format != nil {
+		format = model.ReportFormat(*req.Format)
+	}
+	var sections []model.ReportSection
+	if req.Sections != nil {
+		sections = make([]model.ReportSection, 0, len(*req.Sections))
+		for _, section := range *req.Sections {
+			sections = append(sections, model.ReportSection(section))
+		}
+	}
+	return format, sections
+}
+
+// GetReportStatus returns whether a report is pending, processing, completed or failed
+// GET /api/v1/reports/:id/status
+func (h *ReportHandler) GetReportStatus(c *gin.Context) {
+	reportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid report ID format",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	status, err := h.service.GetReportStatus(c.Request.Context(), reportID.String(), AuthUserID(c))
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, status)
+	case errors.Is(err, service.ErrReportNotFound):
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Report not found",
+		})
+	case errors.Is(err, service.ErrReportAccessDenied):
+		c.JSON(http.StatusForbidden, api.ErrorResponse{
+			Code:    "FORBIDDEN",
+			Message: "Access to another user's data is not allowed",
+		})
+	default:
+		h.logger.Error("failed to get report status",
+			zap.Error(err),
+			zap.String("report_id", reportID.String()),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get report status",
+			Details: stringPtr(err.Error()),
+		})
+	}
+}
+
+// GetReportJob returns whether a report generation job is pending, processing, completed
+// or failed, and the report it generates
+// GET /api/v1/reports/jobs/:job_id
+func (h *ReportHandler) GetReportJob(c *gin.Context) {
+	jobID, ok := uuidParam(c, "job_id", "Invalid job ID format")
+	if !ok {
+		return
+	}
+
+	status, err := h.service.GetReportJob(c.Request.Context(), jobID, AuthUserID(c))
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, status)
+	case errors.Is(err, repository.ErrReportJobNotFound):
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Report job not found",
+		})
+	case errors.Is(err, service.ErrReportAccessDenied):
+		c.JSON(http.StatusForbidden, api.ErrorResponse{
+			Code:    "FORBIDDEN",
+			Message: "Access to another user's data is not allowed",
+		})
+	default:
+		h.logger.Error("failed to get report job",
+			zap.Error(err),
+			zap.String("job_id", jobID),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get report job",
+			Details: stringPtr(err.Error()),
+		})
+	}
+}
+
+// ListReports returns a page of the user's previous reports, newest first
+// GET /api/v1/reports
+func (h *ReportHandler) ListReports(c *gin.Context) {
+	userID, ok := queryUserID(c)
+	if !ok {
+		return
+	}
+
+	page, ok := parsePage(c)
+	if !ok {
+		return
+	}
+
+	reports, total, err := h.service.ListReports(c.Request.Context(), userID, page)
+	if err != nil {
+		h.logger.Error("failed to list reports",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to list reports",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	summaries := make([]api.ReportSummary, 0, len(reports))
+	for _, report := range reports {
+		sections := make([]api.ReportSummarySections, 0, len(report.Sections))
+		for _, section := range report.Sections {
+			sections = append(sections, api.ReportSummarySections(section))
+		}
+		summaries = append(summaries, api.ReportSummary{
+			Id:             stringToUUIDValue(report.ID),
+			DateRangeStart: types.Date{Time: report.DateRangeStart},
+			DateRangeEnd:   types.Date{Time: report.DateRangeEnd},
+			Status:         api.ReportSummaryStatus(report.Status),
+			Format:         api.ReportSummaryFormat(report.Format),
+			Sections:       sections,
+			Encrypted:      report.Encrypted,
+			GeneratedAt:    report.GeneratedAt,
+			SizeBytes:      report.SizeBytes,
+		})
+	}
+
+	c.JSON(http.StatusOK, api.ReportPage{
+		Items:      summaries,
+		TotalCount: total,
+		NextCursor: nextCursor(total, page),
+	})
+}
+
+// DeleteReport deletes a report and its PDF. Reports of other users are not found.
+// DELETE /api/v1/reports/:id
+func (h *ReportHandler) DeleteReport(c *gin.Context) {
+	reportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid report ID format",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	err = h.service.DeleteReport(c.Request.Context(), reportID.String(), AuthUserID(c), c.ClientIP(), c.Request.UserAgent())
+	switch {
+	case err == nil:
+		c.Status(http.StatusNoContent)
+	case errors.Is(err, service.ErrReportNotFound):
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Report not found",
+		})
+	case errors.Is(err, service.ErrReportNotReady):
+		c.JSON(http.StatusConflict, api.ErrorResponse{
+			Code:    "REPORT_NOT_READY",
+			Message: "Report is still being generated",
+			Details: stringPtr(err.Error()),
+		})
+	default:
+		h.logger.Error("failed to delete report",
+			zap.Error(err),
+			zap.String("report_id", reportID.String()),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to delete report",
+			Details: stringPtr(err.Error()),
+		})
+	}
 }
 
 // GetApiV1ReportsId downloads a report
@@ -92,8 +353,16 @@ func (h *ReportHandler) GetApiV1ReportsId(c *gin.Context, id types.UUID) {
 		zap.String("report_id", reportID),
 	)
 
-	// Get report PDF
-	pdfBytes, err := h.service.GetReport(c.Request.Context(), reportID)
+	// Get the report file
+	file, err := h.service.GetReport(c.Request.Context(), reportID)
+	if errors.Is(err, service.ErrReportNotReady) {
+		c.JSON(http.StatusConflict, api.ErrorResponse{
+			Code:    "REPORT_NOT_READY",
+			Message: "Report is not ready for download",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
 	if err != nil {
 		h.logger.Error("failed to get report",
 			zap.Error(err),
@@ -107,14 +376,116 @@ func (h *ReportHandler) GetApiV1ReportsId(c *gin.Context, id types.UUID) {
 		return
 	}
 
-	// Return PDF
-	c.Header("Content-Type", "application/pdf")
-	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=health_report_%s.pdf", reportID))
-	c.Header("Content-Length", fmt.Sprintf("%d", len(pdfBytes)))
-	c.Data(http.StatusOK, "application/pdf", pdfBytes)
+	// Return the PDF, or the ZIP of CSV files
+	contentType := file.Format.ContentType()
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=health_report_%s.%s", reportID, file.Format.FileExtension()))
+	c.Header("Content-Length", fmt.Sprintf("%d", len(file.Data)))
+	c.Header("X-Report-Sections", joinReportSections(file.Sections))
+	c.Data(http.StatusOK, contentType, file.Data)
 
 	h.logger.Info("report downloaded",
 		zap.String("report_id", reportID),
-		zap.Int("size_bytes", len(pdfBytes)),
+		zap.String("format", string(file.Format)),
+		zap.Int("size_bytes", len(file.Data)),
 	)
 }
+
+// reportVerificationResponse is the subset of report metadata disclosed to whoever holds
+// a report's verification code
+type reportVerificationResponse struct {
+	Verified       bool      `json:"verified"`
+	DateRangeStart string    `json:"date_range_start"`
+	DateRangeEnd   string    `json:"date_range_end"`
+	GeneratedAt    time.Time `json:"generated_at"`
+}
+
+// GetReportVerification checks a report verification code, or the SHA-256 of a PDF,
+// against the generated reports. Unknown and malformed codes get the same 404.
+// GET /api/v1/reports/verify
+func (h *ReportHandler) GetReportVerification(c *gin.Context) {
+	report, err := h.service.VerifyReport(c.Request.Context(), c.Query("code"))
+	if errors.Is(err, service.ErrReportNotVerified) {
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "No report matches this verification code",
+		})
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to verify report", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to verify report",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, reportVerificationResponse{
+		Verified:       true,
+		DateRangeStart: report.DateRangeStart.Format(time.DateOnly),
+		DateRangeEnd:   report.DateRangeEnd.Format(time.DateOnly),
+		GeneratedAt:    report.GeneratedAt,
+	})
+}
+
+// GetReportURL returns a short-lived signed URL to download a report directly from
+// storage. GET /api/v1/reports/:id remains available for clients that cannot follow it.
+// GET /api/v1/reports/:id/url
+func (h *ReportHandler) GetReportURL(c *gin.Context) {
+	reportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid report ID format",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	reportURL, err := h.service.GetReportURL(c.Request.Context(), reportID.String(), AuthUserID(c))
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, reportURL)
+	case errors.Is(err, service.ErrReportURLUnavailable):
+		c.JSON(http.StatusNotImplemented, api.ErrorResponse{
+			Code:    "NOT_IMPLEMENTED",
+			Message: "Signed download URLs are not available; download the report directly",
+		})
+	case errors.Is(err, service.ErrReportNotFound):
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Report not found",
+		})
+	case errors.Is(err, service.ErrReportAccessDenied):
+		c.JSON(http.StatusForbidden, api.ErrorResponse{
+			Code:    "FORBIDDEN",
+			Message: "Access to another user's data is not allowed",
+		})
+	case errors.Is(err, service.ErrReportNotReady):
+		c.JSON(http.StatusConflict, api.ErrorResponse{
+			Code:    "REPORT_NOT_READY",
+			Message: "Report is not ready for download",
+			Details: stringPtr(err.Error()),
+		})
+	default:
+		h.logger.Error("failed to issue report URL",
+			zap.Error(err),
+			zap.String("report_id", reportID.String()),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to issue report download URL",
+			Details: stringPtr(err.Error()),
+		})
+	}
+}
+
+// joinReportSections lists report sections comma separated for a response header
+func joinReportSections(sections []model.ReportSection) string {
+	names := make([]string, len(sections))
+	for i, section := range sections {
+		names[i] = string(section)
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/pdf"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// ReportScheduleHandler implements the endpoints managing scheduled reports
+type ReportScheduleHandler struct {
+	scheduler *service.ReportScheduler
+	logger    *zap.Logger
+}
+
+// NewReportScheduleHandler creates a new ReportScheduleHandler
+func NewReportScheduleHandler(scheduler *service.ReportScheduler, logger *zap.Logger) *ReportScheduleHandler {
+	return &ReportScheduleHandler{
+		scheduler: scheduler,
+		logger:    logger,
+	}
+}
+
+// reportScheduleRequest is the body of a report schedule update. A missing enabled
+// enables the schedule.
+type reportScheduleRequest struct {
+	Cadence model.ReportCadence `json:"cadence" binding:"required"`
+	Day     int                 `json:"day" binding:"required"`
+	Enabled *bool               `json:"enabled"`
+}
+
+// PutReportSchedule creates or replaces the user's report schedule. Scheduled reports
+// are printed in the language of the Accept-Language header.
+// PUT /api/v1/users/:id/report-schedule
+func (h *ReportScheduleHandler) PutReportSchedule(c *gin.Context) {
+	userID, ok := uuidParam(c, "id", "Invalid user ID format")
+	if !ok || !authorizeUser(c, userID) {
+		return
+	}
+
+	var req reportScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	schedule := &model.ReportSchedule{
+		UserID:   userID,
+		Cadence:  req.Cadence,
+		Day:      req.Day,
+		Enabled:  req.Enabled == nil || *req.Enabled,
+		Language: string(pdf.ParseLanguage(c.GetHeader("Accept-Language"))),
+	}
+	if err := h.scheduler.SetSchedule(c.Request.Context(), schedule); err != nil {
+		if errors.Is(err, service.ErrInvalidReportSchedule) {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "Invalid report schedule",
+				Details: stringPtr(err.Error()),
+			})
+			return
+		}
+		h.respondError(c, err, userID, "Failed to save report schedule")
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// GetReportSchedule returns the user's report schedule
+// GET /api/v1/users/:id/report-schedule
+func (h *ReportScheduleHandler) GetReportSchedule(c *gin.Context) {
+	userID, ok := uuidParam(c, "id", "Invalid user ID format")
+	if !ok || !authorizeUser(c, userID) {
+		return
+	}
+
+	schedule, err := h.scheduler.GetSchedule(c.Request.Context(), userID)
+	if errors.Is(err, repository.ErrReportScheduleNotFound) {
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "No report schedule for this user",
+		})
+		return
+	}
+	if err != nil {
+		h.respondError(c, err, userID, "Failed to get report schedule")
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// respondError logs a failed report schedule operation and writes the error response
+func (h *ReportScheduleHandler) respondError(c *gin.Context, err error, userID, message string) {
+	h.logger.Error("report schedule operation failed", zap.Error(err), zap.String("user_id", userID))
+	c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+		Code:    "INTERNAL_ERROR",
+		Message: message,
+		Details: stringPtr(err.Error()),
+	})
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"go.uber.org/zap"
+)
+
+func TestPutReportSchedule_InvalidRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+	h := NewReportScheduleHandler(service.NewReportScheduler(nil, nil, logger), logger)
+	router := gin.New()
+	router.PUT("/users/:id/report-schedule", h.PutReportSchedule)
+
+	userID := uuid.NewString()
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{"invalid user ID", "/users/not-a-uuid/report-schedule", `{"cadence": "weekly", "day": 1}`},
+		{"missing day", "/users/" + userID + "/report-schedule", `{"cadence": "weekly"}`},
+		{"unknown cadence", "/users/" + userID + "/report-schedule", `{"cadence": "daily", "day": 1}`},
+		{"weekly day out of range", "/users/" + userID + "/report-schedule", `{"cadence": "weekly", "day": 8}`},
+		{"monthly day out of range", "/users/" + userID + "/report-schedule", `{"cadence": "monthly", "day": 31}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/middleware"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+func newTestReportRouter(limiter *service.ReportLimiter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+
+	reportService := service.NewReportService(nil, nil, nil, nil, nil, logger)
+	reportService.SetLimiter(limiter)
+
+	router := gin.New()
+	router.POST("/reports/generate", NewReportHandler(reportService, logger).PostApiV1ReportsGenerate)
+	return router
+}
+
+func postGenerateReport(router *gin.Engine, userID uuid.UUID) *httptest.ResponseRecorder {
+	body := fmt.Sprintf(`{"user_id":"%s","start_date":"2026-02-01","end_date":"2026-02-28"}`, userID)
+	req := httptest.NewRequest(http.MethodPost, "/reports/generate", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPostApiV1ReportsGenerate_RateLimited(t *testing.T) {
+	userID := uuid.New()
+	limiter := service.NewReportLimiter(2, time.Hour, 0)
+
+	// Exhaust the per-user limit
+	require.NoError(t, limiter.Reserve(userID.String()))
+	require.NoError(t, limiter.Reserve(userID.String()))
+
+	w := postGenerateReport(newTestReportRouter(limiter), userID)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	var errResp api.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+	assert.Equal(t, "RATE_LIMITED", errResp.Code)
+}
+
+func TestPostApiV1ReportsGenerate_DedupesIdenticalRequest(t *testing.T) {
+	userID := uuid.New()
+	limiter := service.NewReportLimiter(1, time.Hour, 10*time.Minute)
+
+	// An identical report was generated a moment ago and the limit is used up
+	require.NoError(t, limiter.Reserve(userID.String()))
+	limiter.Remember(userID.String(), model.ReportFormatPDF, model.AllReportSections,
+		time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC),
+		"existing-report",
+	)
+
+	w := postGenerateReport(newTestReportRouter(limiter), userID)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "existing-report", resp["report_id"])
+	assert.Equal(t, "completed", resp["status"])
+}
+
+func TestPostApiV1ReportsGenerate_QueueUnavailable(t *testing.T) {
+	// Without a job store there is no queue to accept the report
+	w := postGenerateReport(newTestReportRouter(service.NewReportLimiter(0, time.Hour, 0)), uuid.New())
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var errResp api.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+	assert.Equal(t, "REPORT_QUEUE_UNAVAILABLE", errResp.Code)
+}
+
+func TestPostApiV1ReportsGenerate_UnsupportedFormat(t *testing.T) {
+	router := newTestReportRouter(service.NewReportLimiter(0, time.Hour, 0))
+	body := fmt.Sprintf(`{"user_id":"%s","start_date":"2026-02-01","end_date":"2026-02-28","format":"xlsx"}`, uuid.New())
+	req := httptest.NewRequest(http.MethodPost, "/reports/generate", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var errResp api.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+	assert.Equal(t, "VALIDATION_ERROR", errResp.Code)
+}
+
+func TestPostApiV1ReportsGenerate_UnknownSection(t *testing.T) {
+	router := newTestReportRouter(service.NewReportLimiter(0, time.Hour, 0))
+	body := fmt.Sprintf(`{"user_id":"%s","start_date":"2026-02-01","end_date":"2026-02-28","sections":["blood_pressure","labs"]}`, uuid.New())
+	req := httptest.NewRequest(http.MethodPost, "/reports/generate", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var errResp api.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+	assert.Equal(t, "VALIDATION_ERROR", errResp.Code)
+	require.NotNil(t, errResp.Details)
+	assert.Contains(t, *errResp.Details, `"labs"`)
+}
+
+func TestGetReportStatus_InvalidID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+	router := gin.New()
+	router.GET("/reports/:id/status", NewReportHandler(service.NewReportService(nil, nil, nil, nil, nil, logger), logger).GetReportStatus)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/reports/not-a-uuid/status", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetReportJob_InvalidID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+	router := gin.New()
+	router.GET("/reports/jobs/:job_id", NewReportHandler(service.NewReportService(nil, nil, nil, nil, nil, logger), logger).GetReportJob)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/reports/jobs/not-a-uuid", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetReportVerification_MalformedCodeIsNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+	router := gin.New()
+	router.GET("/reports/verify", NewReportHandler(service.NewReportService(nil, nil, nil, nil, nil, logger), logger).GetReportVerification)
+
+	for _, code := range []string{"", "not-a-code", "3F2A-91C0"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/reports/verify?code="+code, nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		var errResp api.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+		assert.Nil(t, errResp.Details, "the response must not explain why a code did not match")
+	}
+}
+
+func TestGetReportVerification_PublicWithoutAuthorization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+	router := gin.New()
+	router.Use(middleware.AuthGate(
+		middleware.AuthMiddleware(logger, "test-secret"),
+		middleware.OptionalAuthMiddleware(logger, "test-secret"),
+		ConfirmEmailPath, ReportVerifyPath,
+	))
+	handler := NewReportHandler(service.NewReportService(nil, nil, nil, nil, nil, logger), logger)
+	router.GET(ReportVerifyPath, handler.GetReportVerification)
+	router.GET("/api/v1/reports/jobs/:job_id", handler.GetReportJob)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, ReportVerifyPath+"?code=3F2A-91C0", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code, "the lookup runs without an Authorization header")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/reports/jobs/"+uuid.New().String(), nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "other report routes still require authentication")
+}
+
+func TestGetReportURL(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+
+	router := gin.New()
+	router.GET("/reports/:id/url", NewReportHandler(service.NewReportService(nil, nil, nil, nil, nil, logger), logger).GetReportURL)
+
+	tests := []struct {
+		name     string
+		reportID string
+		wantCode int
+	}{
+		{"invalid report ID", "not-a-uuid", http.StatusBadRequest},
+		{"no URL signer configured", uuid.NewString(), http.StatusNotImplemented},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/reports/"+tt.reportID+"/url", nil))
+			assert.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
+
+func TestListAndDeleteReports_Validation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+
+	reportHandler := NewReportHandler(service.NewReportService(nil, nil, nil, nil, nil, logger), logger)
+	router := gin.New()
+	router.GET("/reports", reportHandler.ListReports)
+	router.DELETE("/reports/:id", reportHandler.DeleteReport)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+	}{
+		{"list without user", http.MethodGet, "/reports"},
+		{"list with invalid user", http.MethodGet, "/reports?user_id=not-a-uuid"},
+		{"list with invalid limit", http.MethodGet, "/reports?user_id=" + uuid.NewString() + "&limit=0"},
+		{"delete invalid report ID", http.MethodDelete, "/reports/not-a-uuid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.target, nil))
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
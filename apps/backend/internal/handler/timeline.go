@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// TimelineHandler implements the administrator endpoint browsing a user's timeline
+type TimelineHandler struct {
+	service *service.TimelineService
+	logger  *zap.Logger
+}
+
+// NewTimelineHandler creates a new TimelineHandler
+func NewTimelineHandler(service *service.TimelineService, logger *zap.Logger) *TimelineHandler {
+	return &TimelineHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// timelineResponse is one page of a user's timeline
+type timelineResponse struct {
+	Items      []model.TimelineEvent `json:"items"`
+	NextCursor *string               `json:"next_cursor"`
+}
+
+// timelineCursor is the JSON form of a repository.TimelineCursor
+type timelineCursor struct {
+	OccurredAt time.Time               `json:"t"`
+	Type       model.TimelineEventType `json:"k"`
+	ResourceID string                  `json:"id"`
+}
+
+// GetUserTimeline lists a user's check-ins, session transitions, health data writes,
+// alerts, reports and GDPR events newest first. Follow next_cursor for older events.
+// GET /api/v1/admin/users/:id/timeline?limit=&cursor=
+func (h *TimelineHandler) GetUserTimeline(c *gin.Context) {
+	userID, ok := uuidParam(c, "id", "Invalid user ID format")
+	if !ok {
+		return
+	}
+
+	limit := repository.DefaultPageLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > repository.MaxPageLimit {
+			respondInvalidPage(c, fmt.Sprintf("limit must be an integer between 1 and %d", repository.MaxPageLimit))
+			return
+		}
+		limit = parsed
+	}
+
+	var after *repository.TimelineCursor
+	if raw := c.Query("cursor"); raw != "" {
+		cursor, err := decodeTimelineCursor(raw)
+		if err != nil {
+			respondInvalidPage(c, err.Error())
+			return
+		}
+		after = cursor
+	}
+
+	page, err := h.service.GetUserTimeline(c.Request.Context(), AuthUserID(c), userID, after, limit)
+	if err != nil {
+		h.logger.Error("failed to get user timeline", zap.Error(err), zap.String("user_id", userID))
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to retrieve user timeline",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	response := timelineResponse{Items: page.Events}
+	if response.Items == nil {
+		response.Items = []model.TimelineEvent{}
+	}
+	if page.Next != nil {
+		response.NextCursor = stringPtr(encodeTimelineCursor(*page.Next))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// encodeTimelineCursor returns the opaque cursor of a timeline position
+func encodeTimelineCursor(cursor repository.TimelineCursor) string {
+	raw, _ := json.Marshal(timelineCursor(cursor))
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeTimelineCursor returns the timeline position of a cursor created by
+// encodeTimelineCursor
+func decodeTimelineCursor(encoded string) (*repository.TimelineCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	var cursor timelineCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.OccurredAt.IsZero() || cursor.Type == "" {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	decoded := repository.TimelineCursor(cursor)
+	return &decoded, nil
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+func TestTimelineCursor_RoundTrip(t *testing.T) {
+	cursor := repository.TimelineCursor{
+		OccurredAt: time.Date(2026, 3, 1, 12, 30, 0, 123456000, time.UTC),
+		Type:       model.TimelineEventSessionPaused,
+		ResourceID: uuid.NewString(),
+	}
+
+	decoded, err := decodeTimelineCursor(encodeTimelineCursor(cursor))
+	require.NoError(t, err)
+	assert.Equal(t, cursor, *decoded)
+
+	for _, invalid := range []string{"!!", "bm90LWpzb24", "e30"} {
+		_, err := decodeTimelineCursor(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestGetUserTimeline_Validation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+	router := gin.New()
+	router.GET("/admin/users/:id/timeline", NewTimelineHandler(service.NewTimelineService(nil, logger), logger).GetUserTimeline)
+
+	for _, target := range []string{
+		"/admin/users/not-a-uuid/timeline",
+		"/admin/users/" + uuid.NewString() + "/timeline?limit=0",
+		"/admin/users/" + uuid.NewString() + "/timeline?limit=501",
+		"/admin/users/" + uuid.NewString() + "/timeline?cursor=bm90LWpzb24",
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, target)
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"go.uber.org/zap"
+)
+
+// debugTimingHeader requests the per-stage latency breakdown of a check-in request
+const debugTimingHeader = "X-Debug-Timing"
+
+// SetDebugTiming includes the per-stage latency breakdown in every check-in response,
+// not only in responses to requests carrying the X-Debug-Timing header
+func (h *CheckInHandler) SetDebugTiming(enabled bool) {
+	h.debugTiming = enabled
+}
+
+// SetSlowResponseThreshold sets the total time above which a check-in request logs its
+// stage breakdown; 0 disables the log line
+func (h *CheckInHandler) SetSlowResponseThreshold(threshold time.Duration) {
+	h.slowResponseThreshold = threshold
+}
+
+// startTimings attaches stage timings to the request context for the service to fill in
+func startTimings(c *gin.Context) *telemetry.StageTimings {
+	timings := telemetry.NewStageTimings()
+	c.Request = c.Request.WithContext(telemetry.WithStageTimings(c.Request.Context(), timings))
+	return timings
+}
+
+// wantsDebugTiming reports whether the response should carry the stage breakdown
+func (h *CheckInHandler) wantsDebugTiming(c *gin.Context) bool {
+	if h.debugTiming {
+		return true
+	}
+	switch strings.ToLower(c.GetHeader(debugTimingHeader)) {
+	case "", "0", "false":
+		return false
+	}
+	return true
+}
+
+// finishTimings logs the stage breakdown of a slow request and returns the breakdown when
+// the client asked for it, nil otherwise
+func (h *CheckInHandler) finishTimings(c *gin.Context, operation string, timings *telemetry.StageTimings) *api.TimingBreakdown {
+	total := timings.Total()
+	if h.slowResponseThreshold > 0 && total > h.slowResponseThreshold {
+		fields := []zap.Field{
+			zap.String("operation", operation),
+			zap.Duration("total", total),
+			zap.Duration("threshold", h.slowResponseThreshold),
+		}
+		for _, stage := range timings.Stages() {
+			fields = append(fields, zap.Duration("stage_"+stage, timings.Duration(stage)))
+		}
+		h.logger.Warn("slow check-in request", fields...)
+	}
+
+	if !h.wantsDebugTiming(c) {
+		return nil
+	}
+	breakdown := timings.Breakdown()
+	return &api.TimingBreakdown{TotalMs: breakdown.TotalMS, StagesMs: breakdown.Stages}
+}
+
+// serverTiming formats stage timings as a Server-Timing header value
+func serverTiming(timings *telemetry.StageTimings) string {
+	stages := timings.Stages()
+	entries := make([]string, 0, len(stages)+1)
+	for _, stage := range stages {
+		entries = append(entries, fmt.Sprintf("%s;dur=%.1f", stage, float64(timings.Duration(stage).Microseconds())/1000))
+	}
+	entries = append(entries, fmt.Sprintf("total;dur=%.1f", float64(timings.Total().Microseconds())/1000))
+	return strings.Join(entries, ", ")
+}
+
+// GetLatencyHistograms returns the latency histograms of the check-in stages since the
+// service started
+// GET /api/v1/admin/latency
+func (h *CheckInHandler) GetLatencyHistograms(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"stages": telemetry.StageLatencies.Snapshot(),
+	})
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// timedRouter serves a route that records a slow TTS stage and returns the debug block
+func timedRouter(h *CheckInHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/timed", func(c *gin.Context) {
+		timings := startTimings(c)
+		stop := telemetry.StartStage(c.Request.Context(), telemetry.StageTTS)
+		time.Sleep(5 * time.Millisecond)
+		stop()
+		c.JSON(http.StatusOK, gin.H{"debug_timing": h.finishTimings(c, "respond", timings)})
+	})
+	return router
+}
+
+func TestFinishTimings_DebugBlock(t *testing.T) {
+	tests := []struct {
+		name        string
+		debugTiming bool
+		header      string
+		want        bool
+	}{
+		{"no header", false, "", false},
+		{"header", false, "1", true},
+		{"header disabled", false, "false", false},
+		{"config flag", true, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewCheckInHandler(nil, zap.NewNop())
+			h.SetDebugTiming(tt.debugTiming)
+
+			req := httptest.NewRequest(http.MethodGet, "/timed", nil)
+			if tt.header != "" {
+				req.Header.Set(debugTimingHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			timedRouter(h).ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			if tt.want {
+				assert.Contains(t, w.Body.String(), `"stages_ms":{"tts":`)
+				assert.Contains(t, w.Body.String(), `"total_ms":`)
+			} else {
+				assert.JSONEq(t, `{"debug_timing":null}`, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestFinishTimings_LogsSlowRequests(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	h := NewCheckInHandler(nil, zap.New(core))
+
+	h.SetSlowResponseThreshold(time.Hour)
+	timedRouter(h).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/timed", nil))
+	assert.Zero(t, logs.Len())
+
+	h.SetSlowResponseThreshold(time.Millisecond)
+	timedRouter(h).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/timed", nil))
+
+	entries := logs.FilterMessage("slow check-in request").All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "respond", fields["operation"])
+	assert.Contains(t, fields, "stage_tts")
+	assert.GreaterOrEqual(t, fields["stage_tts"], 5*time.Millisecond)
+}
+
+func TestServerTiming(t *testing.T) {
+	timings := telemetry.NewStageTimings()
+	ctx := telemetry.WithStageTimings(t.Context(), timings)
+	telemetry.StartStage(ctx, telemetry.StageAudioCache)()
+	telemetry.StartStage(ctx, telemetry.StageBlobCache)()
+
+	header := serverTiming(timings)
+	assert.Regexp(t, `^audio_cache;dur=\d+\.\d, blob_cache;dur=\d+\.\d, total;dur=\d+\.\d$`, header)
+}
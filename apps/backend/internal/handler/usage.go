@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"go.uber.org/zap"
+)
+
+// UsageHandler implements stored data usage endpoints
+type UsageHandler struct {
+	service *service.UsageService
+	logger  *zap.Logger
+}
+
+// NewUsageHandler creates a new UsageHandler
+func NewUsageHandler(service *service.UsageService, logger *zap.Logger) *UsageHandler {
+	return &UsageHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetUserUsage returns a user's stored data usage and soft limits
+// GET /api/v1/users/:id/usage
+func (h *UsageHandler) GetUserUsage(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid user ID format",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	if !authorizeUser(c, userID.String()) {
+		return
+	}
+
+	report, err := h.service.GetUsage(c.Request.Context(), userID.String())
+	if err != nil {
+		h.logger.Error("failed to get usage",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to retrieve usage",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetUsageAggregate returns usage totals across all users
+// GET /api/v1/admin/usage
+func (h *UsageHandler) GetUsageAggregate(c *gin.Context) {
+	aggregate, err := h.service.GetAggregate(c.Request.Context())
+	if err != nil {
+		h.logger.Error("failed to get usage aggregate", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to retrieve usage aggregate",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, aggregate)
+}
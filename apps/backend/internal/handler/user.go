@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// ConfirmEmailPath is the email confirmation link. It is public: the signed token in
+// the link authenticates the request.
+const ConfirmEmailPath = "/api/v1/users/confirm-email"
+
+// UserHandler implements the user profile and email verification endpoints
+type UserHandler struct {
+	service *service.EmailVerificationService
+	logger  *zap.Logger
+}
+
+// NewUserHandler creates a new UserHandler
+func NewUserHandler(service *service.EmailVerificationService, logger *zap.Logger) *UserHandler {
+	return &UserHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// userEmailRequest is the body of an email address change
+type userEmailRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+// userProfileResponse is a user's profile with the verification state of their address
+type userProfileResponse struct {
+	ID              string     `json:"id"`
+	Name            string     `json:"name"`
+	Email           string     `json:"email"`
+	EmailVerified   bool       `json:"email_verified"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// toUserProfileResponse converts a user to its profile response
+func toUserProfileResponse(user *model.User) userProfileResponse {
+	return userProfileResponse{
+		ID:              user.ID,
+		Name:            user.Name,
+		Email:           user.Email,
+		EmailVerified:   user.EmailVerified(),
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+	}
+}
+
+// GetUserProfile returns the user's profile, including whether their email address is verified
+// GET /api/v1/users/:id/profile
+func (h *UserHandler) GetUserProfile(c *gin.Context) {
+	userID, ok := uuidParam(c, "id", "Invalid user ID format")
+	if !ok || !authorizeUser(c, userID) {
+		return
+	}
+
+	user, err := h.service.GetProfile(c.Request.Context(), userID)
+	if err != nil {
+		h.writeError(c, err, "Failed to get user profile")
+		return
+	}
+
+	c.JSON(http.StatusOK, toUserProfileResponse(user))
+}
+
+// PutUserEmail sets the user's email address. The address is normalized and stays
+// unverified until the link emailed to it is opened; setting the verified address again
+// changes nothing.
+// PUT /api/v1/users/:id/email
+func (h *UserHandler) PutUserEmail(c *gin.Context) {
+	userID, ok := uuidParam(c, "id", "Invalid user ID format")
+	if !ok || !authorizeUser(c, userID) {
+		return
+	}
+
+	var req userEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	user, err := h.service.ChangeEmail(c.Request.Context(), userID, req.Email)
+	if err != nil {
+		h.writeError(c, err, "Failed to change email address")
+		return
+	}
+
+	c.JSON(http.StatusOK, toUserProfileResponse(user))
+}
+
+// ResendEmailConfirmation emails a new confirmation link for the user's unverified address
+// POST /api/v1/users/:id/email/confirmation
+func (h *UserHandler) ResendEmailConfirmation(c *gin.Context) {
+	userID, ok := uuidParam(c, "id", "Invalid user ID format")
+	if !ok || !authorizeUser(c, userID) {
+		return
+	}
+
+	if err := h.service.ResendConfirmation(c.Request.Context(), userID); err != nil {
+		h.writeError(c, err, "Failed to send confirmation email")
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// ConfirmEmail marks the address a confirmation token was issued for verified
+// GET /api/v1/users/confirm-email?token=
+func (h *UserHandler) ConfirmEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "token query parameter is required",
+		})
+		return
+	}
+
+	user, err := h.service.ConfirmEmail(c.Request.Context(), token)
+	if err != nil {
+		h.writeError(c, err, "Failed to confirm email address")
+		return
+	}
+
+	c.JSON(http.StatusOK, toUserProfileResponse(user))
+}
+
+// writeError writes the response of a failed user operation
+func (h *UserHandler) writeError(c *gin.Context, err error, message string) {
+	var rateLimit *service.EmailVerificationRateLimitError
+	switch {
+	case errors.Is(err, service.ErrInvalidEmail):
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid email address",
+			Details: stringPtr(err.Error()),
+		})
+	case errors.Is(err, repository.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "User not found",
+		})
+	case errors.Is(err, repository.ErrEmailTaken):
+		c.JSON(http.StatusConflict, api.ErrorResponse{
+			Code:    "EMAIL_TAKEN",
+			Message: "Email address is used by another user",
+		})
+	case errors.Is(err, service.ErrNoEmail):
+		c.JSON(http.StatusConflict, api.ErrorResponse{
+			Code:    "NO_EMAIL",
+			Message: "User has no email address to confirm",
+		})
+	case errors.Is(err, service.ErrEmailAlreadyVerified):
+		c.JSON(http.StatusConflict, api.ErrorResponse{
+			Code:    "EMAIL_ALREADY_VERIFIED",
+			Message: "Email address is already verified",
+		})
+	case errors.Is(err, service.ErrEmailVerificationInvalid):
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "INVALID_TOKEN",
+			Message: "Confirmation link is invalid, was already used or has expired",
+		})
+	case errors.As(err, &rateLimit):
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(rateLimit.RetryAfter.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, api.ErrorResponse{
+			Code:    "TOO_MANY_REQUESTS",
+			Message: "Too many confirmation emails requested",
+			Details: stringPtr(err.Error()),
+		})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: message,
+			Details: stringPtr(err.Error()),
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// UserSettingsHandler implements the endpoints managing user preferences
+type UserSettingsHandler struct {
+	service *service.UserSettingsService
+	logger  *zap.Logger
+}
+
+// NewUserSettingsHandler creates a new UserSettingsHandler
+func NewUserSettingsHandler(service *service.UserSettingsService, logger *zap.Logger) *UserSettingsHandler {
+	return &UserSettingsHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// userSettingsRequest is the body of a user settings update
+type userSettingsRequest struct {
+	Timezone string `json:"timezone" binding:"required"`
+}
+
+// PutUserSettings replaces the user's settings. The time zone is an IANA name such as
+// Europe/Budapest and sets when medication doses are due.
+// PUT /api/v1/users/:id/settings
+func (h *UserSettingsHandler) PutUserSettings(c *gin.Context) {
+	userID, ok := uuidParam(c, "id", "Invalid user ID format")
+	if !ok || !authorizeUser(c, userID) {
+		return
+	}
+
+	var req userSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	settings := &model.UserSettings{UserID: userID, Timezone: req.Timezone}
+	if err := h.service.UpdateSettings(c.Request.Context(), settings); err != nil {
+		if errors.Is(err, service.ErrInvalidTimezone) {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "Invalid time zone, expected an IANA name such as Europe/Budapest",
+				Details: stringPtr(err.Error()),
+			})
+			return
+		}
+		h.respondError(c, err, userID, "Failed to save user settings")
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// GetUserSettings returns the user's settings, with defaults if none were saved
+// GET /api/v1/users/:id/settings
+func (h *UserSettingsHandler) GetUserSettings(c *gin.Context) {
+	userID, ok := uuidParam(c, "id", "Invalid user ID format")
+	if !ok || !authorizeUser(c, userID) {
+		return
+	}
+
+	settings, err := h.service.GetSettings(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, err, userID, "Failed to get user settings")
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// respondError logs a failed user settings operation and writes the error response
+func (h *UserSettingsHandler) respondError(c *gin.Context, err error, userID, message string) {
+	h.logger.Error("user settings operation failed", zap.Error(err), zap.String("user_id", userID))
+	c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+		Code:    "INTERNAL_ERROR",
+		Message: message,
+		Details: stringPtr(err.Error()),
+	})
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"go.uber.org/zap"
+)
+
+// WebhookHandler implements the endpoints managing user webhooks
+type WebhookHandler struct {
+	service *service.WebhookService
+	logger  *zap.Logger
+}
+
+// NewWebhookHandler creates a new WebhookHandler
+func NewWebhookHandler(service *service.WebhookService, logger *zap.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// createWebhookRequest is the body of a webhook registration request
+type createWebhookRequest struct {
+	UserID    string   `json:"user_id" binding:"required"`
+	TargetURL string   `json:"target_url" binding:"required"`
+	Events    []string `json:"events" binding:"required"`
+}
+
+// CreateWebhook registers a webhook notified of events about the user's data. The
+// secret token signing its deliveries is returned once.
+// POST /api/v1/webhooks
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req createWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid request body",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+
+	if _, err := uuid.Parse(req.UserID); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: "Invalid user ID",
+			Details: stringPtr(err.Error()),
+		})
+		return
+	}
+	if !authorizeUser(c, req.UserID) {
+		return
+	}
+
+	webhook, err := h.service.CreateWebhook(c.Request.Context(), req.UserID, req.TargetURL, req.Events)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidWebhook) {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse{
+				Code:    "VALIDATION_ERROR",
+				Message: "Invalid webhook",
+				Details: stringPtr(err.Error()),
+			})
+			return
+		}
+		h.respondError(c, err, "Failed to create webhook")
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// DeleteWebhook deletes one of the user's webhooks
+// DELETE /api/v1/webhooks/:id
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	webhookID, ok := uuidParam(c, "id", "Invalid webhook ID format")
+	if !ok {
+		return
+	}
+
+	err := h.service.DeleteWebhook(c.Request.Context(), AuthUserID(c), webhookID)
+	switch {
+	case err == nil:
+		c.Status(http.StatusNoContent)
+	case errors.Is(err, repository.ErrWebhookNotFound):
+		c.JSON(http.StatusNotFound, api.ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Webhook not found",
+		})
+	case errors.Is(err, service.ErrWebhookAccessDenied):
+		c.JSON(http.StatusForbidden, api.ErrorResponse{
+			Code:    "FORBIDDEN",
+			Message: "Access to another user's data is not allowed",
+		})
+	default:
+		h.respondError(c, err, "Failed to delete webhook")
+	}
+}
+
+// respondError logs a failed webhook operation and writes the error response
+func (h *WebhookHandler) respondError(c *gin.Context, err error, message string) {
+	h.logger.Error("webhook operation failed", zap.Error(err))
+	c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+		Code:    "INTERNAL_ERROR",
+		Message: message,
+		Details: stringPtr(err.Error()),
+	})
+}
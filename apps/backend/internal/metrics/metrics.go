@@ -0,0 +1,241 @@
+// Package metrics exposes the operational metrics of the backend in the Prometheus format.
+// Callers record through the typed helpers of Registry and never import Prometheus.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Blob transfer directions as labeled in blob_transfer_bytes_total
+const (
+	BlobUpload   = "upload"
+	BlobDownload = "download"
+)
+
+// UnmatchedRoute labels requests that matched no route, keeping raw paths out of the labels
+const UnmatchedRoute = "unmatched"
+
+var (
+	// httpDurationBuckets are the upper bounds in seconds of the request latency histogram,
+	// fine enough around typical latencies for p50, p95 and p99 through histogram_quantile
+	httpDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+	// azureDurationBuckets are the upper bounds in seconds of the Azure call latency
+	// histogram; chat completions and speech synthesis take seconds rather than milliseconds
+	azureDurationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+	// dbDurationBuckets are the upper bounds in seconds of the query latency histogram
+	dbDurationBuckets = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 5}
+)
+
+// Default is the registry served on /metrics
+var Default = NewRegistry()
+
+// Registry wraps a Prometheus registry holding the metrics of the backend
+type Registry struct {
+	registry *prometheus.Registry
+
+	httpRequests     *prometheus.CounterVec
+	httpDurations    *prometheus.HistogramVec
+	slowRequests     *prometheus.CounterVec
+	azureRequests    *prometheus.CounterVec
+	azureDurations   *prometheus.HistogramVec
+	dbQueryDurations *prometheus.HistogramVec
+	checkInSessions  *prometheus.CounterVec
+	blobBytes        *prometheus.CounterVec
+
+	labels map[string][]string // label names of each family, in declaration order
+}
+
+// NewRegistry creates a registry with the backend metrics and the Go runtime and process
+// collectors
+func NewRegistry() *Registry {
+	r := &Registry{
+		registry: prometheus.NewRegistry(),
+		labels:   make(map[string][]string),
+	}
+	r.httpRequests = r.counterVec("http_requests_total",
+		"Number of HTTP requests handled.", "method", "route", "status")
+	r.httpDurations = r.histogramVec("http_request_duration_seconds",
+		"Latency of HTTP requests in seconds.", httpDurationBuckets, "method", "route", "status")
+	r.slowRequests = r.counterVec("http_slow_requests_total",
+		"Number of HTTP requests slower than the slow request threshold.", "method", "route")
+	r.azureRequests = r.counterVec("azure_requests_total",
+		"Number of calls to Azure services, including retried attempts.", "service", "operation", "outcome")
+	r.azureDurations = r.histogramVec("azure_request_duration_seconds",
+		"Latency of calls to Azure services in seconds.", azureDurationBuckets, "service", "operation")
+	r.dbQueryDurations = r.histogramVec("db_query_duration_seconds",
+		"Latency of database queries in seconds, by statement kind.", dbDurationBuckets, "statement", "outcome")
+	r.checkInSessions = r.counterVec("checkin_sessions_started_total",
+		"Number of check-in sessions started.")
+	r.blobBytes = r.counterVec("blob_transfer_bytes_total",
+		"Bytes uploaded to and downloaded from Azure Blob Storage.", "direction")
+
+	r.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return r
+}
+
+// counterVec registers a counter family partitioned by labels
+func (r *Registry) counterVec(name, help string, labels ...string) *prometheus.CounterVec {
+	vec := prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labels)
+	r.registry.MustRegister(vec)
+	r.labels[name] = labels
+	return vec
+}
+
+// histogramVec registers a histogram family partitioned by labels
+func (r *Registry) histogramVec(name, help string, buckets []float64, labels ...string) *prometheus.HistogramVec {
+	vec := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: name, Help: help, Buckets: buckets}, labels)
+	r.registry.MustRegister(vec)
+	r.labels[name] = labels
+	return vec
+}
+
+// Handler serves the registry in the Prometheus exposition format
+func (r *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{Registry: r.registry})
+}
+
+// RecordHTTPRequest records a handled request. route is the route template, not the raw
+// path, so IDs do not become labels; pass UnmatchedRoute when no route matched.
+func (r *Registry) RecordHTTPRequest(method, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	r.httpRequests.WithLabelValues(method, route, code).Inc()
+	r.httpDurations.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+// RecordSlowRequest counts a request slower than the slow request threshold
+func (r *Registry) RecordSlowRequest(method, route string) {
+	r.slowRequests.WithLabelValues(method, route).Inc()
+}
+
+// RecordAzureCall records the outcome and latency of one attempt of an Azure call
+func (r *Registry) RecordAzureCall(service, operation string, duration time.Duration, err error) {
+	r.azureRequests.WithLabelValues(service, operation, outcome(err)).Inc()
+	r.azureDurations.WithLabelValues(service, operation).Observe(duration.Seconds())
+}
+
+// RecordDBQuery records the latency of a database query, labeled by the kind of its
+// statement so the SQL text does not become a label
+func (r *Registry) RecordDBQuery(sql string, duration time.Duration, err error) {
+	r.dbQueryDurations.WithLabelValues(statementKind(sql), outcome(err)).Observe(duration.Seconds())
+}
+
+// RecordCheckInSessionStarted counts a started check-in session
+func (r *Registry) RecordCheckInSessionStarted() {
+	r.checkInSessions.WithLabelValues().Inc()
+}
+
+// RecordBlobBytes counts bytes transferred to or from blob storage in direction, which
+// is BlobUpload or BlobDownload
+func (r *Registry) RecordBlobBytes(direction string, n int) {
+	r.blobBytes.WithLabelValues(direction).Add(float64(n))
+}
+
+// RegisterPool exposes the connection utilization of a database pool, labeled with name
+func (r *Registry) RegisterPool(name string, pool *pgxpool.Pool) {
+	labels := prometheus.Labels{"pool": name}
+	gauge := func(metric, help string, value func(*pgxpool.Stat) int32) prometheus.Collector {
+		return prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name:        metric,
+			Help:        help,
+			ConstLabels: labels,
+		}, func() float64 { return float64(value(pool.Stat())) })
+	}
+
+	r.registry.MustRegister(
+		gauge("db_pool_acquired_connections", "Connections currently in use.", (*pgxpool.Stat).AcquiredConns),
+		gauge("db_pool_idle_connections", "Idle connections in the pool.", (*pgxpool.Stat).IdleConns),
+		gauge("db_pool_max_connections", "Maximum size of the pool.", (*pgxpool.Stat).MaxConns),
+	)
+}
+
+// CounterValue returns the counter name with the given label values, in the order the
+// labels were declared, or zero when it has not been recorded
+func (r *Registry) CounterValue(name string, labelValues ...string) float64 {
+	if m := r.find(name, labelValues); m != nil && m.GetCounter() != nil {
+		return m.GetCounter().GetValue()
+	}
+	return 0
+}
+
+// HistogramCount returns the number of observations of the histogram name with the
+// given label values, or zero when it has not been recorded
+func (r *Registry) HistogramCount(name string, labelValues ...string) uint64 {
+	if m := r.find(name, labelValues); m != nil && m.GetHistogram() != nil {
+		return m.GetHistogram().GetSampleCount()
+	}
+	return 0
+}
+
+// find returns the series of the metric family name with the given label values
+func (r *Registry) find(name string, labelValues []string) *dto.Metric {
+	labels, ok := r.labels[name]
+	if !ok || len(labels) != len(labelValues) {
+		return nil
+	}
+	families, err := r.registry.Gather()
+	if err != nil {
+		return nil
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			if hasLabelValues(m.GetLabel(), labels, labelValues) {
+				return m
+			}
+		}
+	}
+	return nil
+}
+
+// hasLabelValues reports whether pairs assign values to the label names
+func hasLabelValues(pairs []*dto.LabelPair, names, values []string) bool {
+	got := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		got[pair.GetName()] = pair.GetValue()
+	}
+	for i, name := range names {
+		if got[name] != values[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// outcome labels a call as a success or failure
+func outcome(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
+
+// statementKind returns the lowercased leading keyword of a SQL statement, such as
+// "select" or "insert"; statements starting with a CTE are labeled "with"
+func statementKind(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "unknown"
+	}
+	kind := strings.ToLower(strings.TrimLeft(fields[0], "("))
+	switch kind {
+	case "select", "insert", "update", "delete", "with", "begin", "commit", "rollback":
+		return kind
+	}
+	return "other"
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_ServesRecordedMetrics(t *testing.T) {
+	registry := NewRegistry()
+	registry.RecordAzureCall("speech", "text-to-speech", 300*time.Millisecond, nil)
+	registry.RecordAzureCall("speech", "text-to-speech", 2*time.Second, errors.New("unavailable"))
+	registry.RecordDBQuery("  SELECT id FROM users WHERE id = $1", 3*time.Millisecond, nil)
+	registry.RecordCheckInSessionStarted()
+	registry.RecordBlobBytes(BlobUpload, 1024)
+	registry.RecordBlobBytes(BlobUpload, 512)
+
+	w := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	body := w.Body.String()
+	assert.Contains(t, body, `azure_requests_total{operation="text-to-speech",outcome="failure",service="speech"} 1`)
+	assert.Contains(t, body, `azure_request_duration_seconds_bucket{operation="text-to-speech",service="speech",le="0.5"} 1`)
+	assert.Contains(t, body, `db_query_duration_seconds_count{outcome="success",statement="select"} 1`)
+	assert.Contains(t, body, "checkin_sessions_started_total 1")
+	assert.Contains(t, body, `blob_transfer_bytes_total{direction="upload"} 1536`)
+	assert.Contains(t, body, "go_goroutines")
+}
+
+func TestRegistry_CounterValueUsesDeclaredLabelOrder(t *testing.T) {
+	registry := NewRegistry()
+	registry.RecordAzureCall("blob", "blob upload", time.Millisecond, nil)
+	registry.RecordAzureCall("blob", "blob upload", time.Millisecond, nil)
+
+	assert.Equal(t, 2.0, registry.CounterValue("azure_requests_total", "blob", "blob upload", "success"))
+	assert.Equal(t, uint64(2), registry.HistogramCount("azure_request_duration_seconds", "blob", "blob upload"))
+	assert.Zero(t, registry.CounterValue("azure_requests_total", "blob", "blob upload", "failure"))
+	assert.Zero(t, registry.CounterValue("azure_requests_total", "blob"), "label values must match the labels")
+	assert.Zero(t, registry.CounterValue("unknown_total"))
+}
+
+func TestStatementKind(t *testing.T) {
+	assert.Equal(t, "insert", statementKind("INSERT INTO users (id) VALUES ($1)"))
+	assert.Equal(t, "with", statementKind("\n\tWITH recent AS (SELECT 1) SELECT * FROM recent"))
+	assert.Equal(t, "select", statementKind("(SELECT 1) UNION (SELECT 2)"))
+	assert.Equal(t, "other", statementKind("TRUNCATE users"))
+	assert.Equal(t, "unknown", statementKind("  "))
+}
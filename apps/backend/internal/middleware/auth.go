@@ -0,0 +1,345 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+const (
+	// userIDContextKey is the Gin context key holding the authenticated user ID
+	userIDContextKey = "user_id"
+
+	// jwksCacheTTL controls how long fetched signing keys are reused
+	jwksCacheTTL = time.Hour
+
+	// jwtClockSkew tolerates small clock differences when checking exp and nbf
+	jwtClockSkew = time.Minute
+)
+
+// Errors returned by token validation
+var (
+	ErrMissingToken = errors.New("missing bearer token")
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// jwtClaims holds the registered and Azure AD B2C claims used by the API
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	ObjectID string `json:"oid"`
+}
+
+// jwk is a single RSA key in a JWKS document
+type jwk struct {
+	KeyID   string `json:"kid"`
+	KeyType string `json:"kty"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+}
+
+// jwksCache fetches and caches the signing keys published at a JWKS URL
+type jwksCache struct {
+	url    string
+	client *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+// jwksCaches shares key caches between middleware instances using the same JWKS URL
+var jwksCaches sync.Map
+
+// getJWKSCache returns the shared key cache for a JWKS URL
+func getJWKSCache(url string) *jwksCache {
+	cache, _ := jwksCaches.LoadOrStore(url, &jwksCache{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	})
+	return cache.(*jwksCache)
+}
+
+// key returns the public key for kid, refreshing the cache when it is stale or the key is unknown
+func (c *jwksCache) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fresh := time.Since(c.fetchedAt) < jwksCacheTTL
+	if key, ok := c.keys[kid]; ok && fresh {
+		return key, nil
+	}
+
+	// Unknown keys trigger a refresh to pick up rotated keys, at most once per minute
+	if !fresh || time.Since(c.fetchedAt) > time.Minute {
+		if err := c.refresh(ctx); err != nil {
+			if key, ok := c.keys[kid]; ok {
+				return key, nil
+			}
+			return nil, err
+		}
+	}
+
+	key, ok := c.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidToken, kid)
+	}
+	return key, nil
+}
+
+// refresh downloads the JWKS document
+func (c *jwksCache) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create JWKS request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch JWKS: status %d", resp.StatusCode)
+	}
+
+	var doc struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(doc.Keys))
+	for _, k := range doc.Keys {
+		if k.KeyType != "RSA" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[k.KeyID] = pub
+	}
+
+	c.keys = keys
+	c.fetchedAt = time.Now()
+	return nil
+}
+
+// publicKey decodes the RSA modulus and exponent of a JWK
+func (k jwk) publicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+// tokenValidator validates Azure AD B2C access tokens signed with RS256, or tokens
+// signed with HS256 using a shared secret when secret is set
+type tokenValidator struct {
+	issuer   string
+	audience string
+	keys     *jwksCache
+	secret   []byte
+	now      func() time.Time
+}
+
+// validate verifies the token signature and claims and returns the user ID
+func (v *tokenValidator) validate(ctx context.Context, token string) (string, error) {
+	var claims jwtClaims
+	if _, err := jwt.ParseWithClaims(token, &claims, v.keyFunc(ctx), v.parserOptions()...); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	// Shared-secret tokens identify the user by sub when they carry no oid claim
+	userID := claims.ObjectID
+	if userID == "" && v.secret != nil {
+		userID = claims.Subject
+	}
+	if userID == "" {
+		return "", fmt.Errorf("%w: missing oid claim", ErrInvalidToken)
+	}
+
+	return userID, nil
+}
+
+// parserOptions returns the checks a token must pass besides its signature
+func (v *tokenValidator) parserOptions() []jwt.ParserOption {
+	options := []jwt.ParserOption{
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(jwtClockSkew),
+		jwt.WithTimeFunc(v.now),
+	}
+	if v.secret != nil {
+		options = append(options, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	} else {
+		options = append(options, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}))
+	}
+	// Shared-secret validators may leave issuer and audience unchecked
+	if v.secret == nil || v.issuer != "" {
+		options = append(options, jwt.WithIssuer(v.issuer))
+	}
+	if v.secret == nil || v.audience != "" {
+		options = append(options, jwt.WithAudience(v.audience))
+	}
+	return options
+}
+
+// keyFunc returns the shared secret, or the JWKS signing key named by the token's kid
+func (v *tokenValidator) keyFunc(ctx context.Context) jwt.Keyfunc {
+	return func(token *jwt.Token) (any, error) {
+		if v.secret != nil {
+			return v.secret, nil
+		}
+		kid, _ := token.Header["kid"].(string)
+		return v.keys.key(ctx, kid)
+	}
+}
+
+// bearerToken extracts the token from the Authorization header
+func bearerToken(c *gin.Context) string {
+	header := c.GetHeader("Authorization")
+	if len(header) > 7 && strings.EqualFold(header[:7], "Bearer ") {
+		return strings.TrimSpace(header[7:])
+	}
+	return ""
+}
+
+// newTokenValidator creates a validator sharing the key cache of jwksURL
+func newTokenValidator(issuer, audience, jwksURL string) *tokenValidator {
+	return &tokenValidator{
+		issuer:   issuer,
+		audience: audience,
+		keys:     getJWKSCache(jwksURL),
+		now:      time.Now,
+	}
+}
+
+// newSecretTokenValidator creates a validator for HS256 tokens signed with secret
+func newSecretTokenValidator(secret string) *tokenValidator {
+	return &tokenValidator{
+		secret: []byte(secret),
+		now:    time.Now,
+	}
+}
+
+// AuthMiddleware requires a valid HS256 bearer token signed with jwtSecret and stores
+// the token's oid claim, or its sub claim, in the Gin context as the user ID
+func AuthMiddleware(logger *zap.Logger, jwtSecret string) gin.HandlerFunc {
+	return jwtAuth(newSecretTokenValidator(jwtSecret), logger, true)
+}
+
+// OptionalAuthMiddleware validates an HS256 bearer token when present but lets
+// requests without a token through, for public endpoints
+func OptionalAuthMiddleware(logger *zap.Logger, jwtSecret string) gin.HandlerFunc {
+	return jwtAuth(newSecretTokenValidator(jwtSecret), logger, false)
+}
+
+// JWTAuth requires a valid Azure AD B2C bearer token and stores the token's
+// oid claim in the Gin context as the user ID
+func JWTAuth(issuer, audience string, jwksURL string, logger *zap.Logger) gin.HandlerFunc {
+	return jwtAuth(newTokenValidator(issuer, audience, jwksURL), logger, true)
+}
+
+// OptionalJWTAuth validates a bearer token when present but lets requests
+// without a token through, for public endpoints
+func OptionalJWTAuth(issuer, audience string, jwksURL string, logger *zap.Logger) gin.HandlerFunc {
+	return jwtAuth(newTokenValidator(issuer, audience, jwksURL), logger, false)
+}
+
+// jwtAuth builds the authentication middleware
+func jwtAuth(validator *tokenValidator, logger *zap.Logger, required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := bearerToken(c)
+		if token == "" {
+			if !required {
+				c.Next()
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"code":    "UNAUTHORIZED",
+				"message": ErrMissingToken.Error(),
+			})
+			return
+		}
+
+		userID, err := validator.validate(c.Request.Context(), token)
+		if err != nil {
+			logger.Warn("token validation failed",
+				zap.Error(err),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("ip", c.ClientIP()),
+			)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"code":    "UNAUTHORIZED",
+				"message": "Invalid or expired token",
+			})
+			return
+		}
+
+		c.Set(userIDContextKey, userID)
+		c.Next()
+	}
+}
+
+// AuthGate requires authentication on /api/ routes except publicPaths, whose requests
+// (and those of other routes) are authenticated only when they carry a token
+func AuthGate(requireAuth, optionalAuth gin.HandlerFunc, publicPaths ...string) gin.HandlerFunc {
+	public := make(map[string]bool, len(publicPaths))
+	for _, path := range publicPaths {
+		public[path] = true
+	}
+
+	return func(c *gin.Context) {
+		if strings.HasPrefix(c.Request.URL.Path, "/api/") && !public[c.Request.URL.Path] {
+			requireAuth(c)
+			return
+		}
+		optionalAuth(c)
+	}
+}
+
+// GetUserID returns the authenticated user ID, or an empty string if the request is unauthenticated
+func GetUserID(c *gin.Context) string {
+	return c.GetString(userIDContextKey)
+}
+
+// RequireAdmin restricts a route to the authenticated users listed in adminUserIDs and
+// to users holding the system_admin role
+func RequireAdmin(adminUserIDs []string) gin.HandlerFunc {
+	admins := adminSet(adminUserIDs)
+
+	return func(c *gin.Context) {
+		if !admins[GetUserID(c)] && !HasRole(c, "", model.RoleSystemAdmin) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"code":    "FORBIDDEN",
+				"message": "Administrator access required",
+			})
+			return
+		}
+		c.Next()
+	}
+}
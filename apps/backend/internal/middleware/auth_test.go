@@ -0,0 +1,242 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	testIssuer   = "https://tenant.b2clogin.com/tenant-id/v2.0/"
+	testAudience = "api-client-id"
+	testKeyID    = "test-key"
+)
+
+// newTestJWKS serves the public part of key as a JWKS document
+func newTestJWKS(t *testing.T, key *rsa.PrivateKey) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kid": testKeyID,
+				"kty": "RSA",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// signTestToken creates an RS256 token with the given claims
+func signTestToken(t *testing.T, key *rsa.PrivateKey, claims map[string]any) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": testKeyID, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"iss": testIssuer,
+		"aud": testAudience,
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"oid": "user-123",
+	}
+}
+
+func newAuthRouter(middleware gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware)
+	router.GET("/me", func(c *gin.Context) {
+		c.String(http.StatusOK, GetUserID(c))
+	})
+	return router
+}
+
+func doAuthRequest(router *gin.Engine, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestJWTAuth_ValidTokenSetsUserID(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwks := newTestJWKS(t, key)
+
+	router := newAuthRouter(JWTAuth(testIssuer, testAudience, jwks.URL, zap.NewNop()))
+	w := doAuthRequest(router, signTestToken(t, key, validClaims()))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "user-123", w.Body.String())
+}
+
+func TestJWTAuth_RejectsInvalidTokens(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwks := newTestJWKS(t, key)
+
+	router := newAuthRouter(JWTAuth(testIssuer, testAudience, jwks.URL, zap.NewNop()))
+
+	withClaim := func(name string, value any) map[string]any {
+		claims := validClaims()
+		claims[name] = value
+		return claims
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"missing token", ""},
+		{"malformed token", "not-a-jwt"},
+		{"expired", signTestToken(t, key, withClaim("exp", time.Now().Add(-time.Hour).Unix()))},
+		{"wrong issuer", signTestToken(t, key, withClaim("iss", "https://evil.example/"))},
+		{"wrong audience", signTestToken(t, key, withClaim("aud", "other-app"))},
+		{"missing oid", signTestToken(t, key, withClaim("oid", ""))},
+		{"wrong signing key", signTestToken(t, otherKey, validClaims())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, http.StatusUnauthorized, doAuthRequest(router, tt.token).Code)
+		})
+	}
+}
+
+func TestJWTAuth_AcceptsAudienceArray(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwks := newTestJWKS(t, key)
+
+	claims := validClaims()
+	claims["aud"] = []string{"other-app", testAudience}
+
+	router := newAuthRouter(JWTAuth(testIssuer, testAudience, jwks.URL, zap.NewNop()))
+	assert.Equal(t, http.StatusOK, doAuthRequest(router, signTestToken(t, key, claims)).Code)
+}
+
+func TestJWTAuth_AcceptsFractionalNumericDates(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwks := newTestJWKS(t, key)
+
+	claims := validClaims()
+	claims["exp"] = float64(time.Now().Add(time.Hour).Unix()) + 0.5
+	claims["nbf"] = float64(time.Now().Add(-time.Minute).Unix()) + 0.25
+
+	router := newAuthRouter(JWTAuth(testIssuer, testAudience, jwks.URL, zap.NewNop()))
+	assert.Equal(t, http.StatusOK, doAuthRequest(router, signTestToken(t, key, claims)).Code)
+}
+
+func TestOptionalJWTAuth(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwks := newTestJWKS(t, key)
+
+	router := newAuthRouter(OptionalJWTAuth(testIssuer, testAudience, jwks.URL, zap.NewNop()))
+
+	// Anonymous requests pass through without a user ID
+	w := doAuthRequest(router, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	// A presented token must still be valid
+	w = doAuthRequest(router, signTestToken(t, key, validClaims()))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "user-123", w.Body.String())
+
+	assert.Equal(t, http.StatusUnauthorized, doAuthRequest(router, "not-a-jwt").Code)
+}
+
+const testSecret = "test-jwt-secret"
+
+// signTestSecretToken creates an HS256 token with the given claims
+func signTestSecretToken(t *testing.T, secret string, claims map[string]any) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signingInput))
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestAuthMiddleware_ValidTokenSetsUserID(t *testing.T) {
+	router := newAuthRouter(AuthMiddleware(zap.NewNop(), testSecret))
+
+	w := doAuthRequest(router, signTestSecretToken(t, testSecret, map[string]any{
+		"sub": "user-456",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "user-456", w.Body.String())
+}
+
+func TestAuthMiddleware_RejectsInvalidTokens(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	router := newAuthRouter(AuthMiddleware(zap.NewNop(), testSecret))
+
+	claims := func(exp time.Time, sub string) map[string]any {
+		return map[string]any{"sub": sub, "exp": exp.Unix()}
+	}
+	valid := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"missing token", ""},
+		{"malformed token", "not-a-jwt"},
+		{"expired", signTestSecretToken(t, testSecret, claims(time.Now().Add(-time.Hour), "user-456"))},
+		{"wrong secret", signTestSecretToken(t, "other-secret", claims(valid, "user-456"))},
+		{"missing subject", signTestSecretToken(t, testSecret, claims(valid, ""))},
+		{"RS256 token", signTestToken(t, key, validClaims())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doAuthRequest(router, tt.token)
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.Contains(t, w.Body.String(), "UNAUTHORIZED")
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// IdempotencyKeyHeader carries the client-chosen key identifying a POST request
+	// across retries
+	IdempotencyKeyHeader = "X-Idempotency-Key"
+
+	// LegacyIdempotencyKeyHeader is the former name of IdempotencyKeyHeader, still accepted
+	LegacyIdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader is set on responses replayed from an earlier request
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// maxIdempotencyKeyLength is the longest key accepted
+	maxIdempotencyKeyLength = 255
+)
+
+// idempotencyReservationTTL bounds how long a request in progress holds its key, so the
+// key of a request whose instance died can be used again
+const idempotencyReservationTTL = 5 * time.Minute
+
+// IdempotentResponse is what an IdempotencyStore holds for a key: a reservation while
+// the first request is in progress, then its response
+type IdempotentResponse struct {
+	// Pending is set while the request that reserved the key is in progress
+	Pending bool
+	// RequestHash is the SHA-256 of the request path and body that produced the response
+	RequestHash string
+	Status      int
+	Body        []byte
+}
+
+// IdempotencyStore keeps the responses of requests sent with an idempotency key.
+// Implementations may be backed by Redis or the database for multi-instance deployments
+// or by memory. Reserve must be atomic so only one request processes a key.
+type IdempotencyStore interface {
+	// Reserve claims key for a request in progress for ttl, reporting false when key is
+	// already reserved or holds a response
+	Reserve(key string, ttl time.Duration) (bool, error)
+	// Get returns the reservation or response stored for key, if any
+	Get(key string) (IdempotentResponse, bool, error)
+	// Complete stores the response of the request that reserved key for ttl
+	Complete(key, requestHash string, status int, body []byte, ttl time.Duration) error
+	// Release drops the reservation of key so the request can be retried
+	Release(key string) error
+}
+
+// idempotencyEntry is a reservation or stored response in the in-memory store
+type idempotencyEntry struct {
+	response  IdempotentResponse
+	expiresAt time.Time
+}
+
+// InMemoryIdempotencyStore is an IdempotencyStore for single-instance deployments and tests
+type InMemoryIdempotencyStore struct {
+	mu        sync.Mutex
+	entries   map[string]idempotencyEntry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewInMemoryIdempotencyStore creates a new InMemoryIdempotencyStore
+func NewInMemoryIdempotencyStore() *InMemoryIdempotencyStore {
+	return &InMemoryIdempotencyStore{
+		entries: make(map[string]idempotencyEntry),
+		now:     time.Now,
+	}
+}
+
+// Reserve claims key unless an unexpired reservation or response is stored for it
+func (s *InMemoryIdempotencyStore) Reserve(key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if entry, ok := s.entries[key]; ok && now.Before(entry.expiresAt) {
+		return false, nil
+	}
+	s.sweep(now)
+
+	s.entries[key] = idempotencyEntry{response: IdempotentResponse{Pending: true}, expiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+// Get returns the unexpired reservation or response stored for key
+func (s *InMemoryIdempotencyStore) Get(key string) (IdempotentResponse, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok || !s.now().Before(entry.expiresAt) {
+		return IdempotentResponse{}, false, nil
+	}
+	return entry.response, true, nil
+}
+
+// Complete stores a response for key for ttl
+func (s *InMemoryIdempotencyStore) Complete(key, requestHash string, status int, body []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	s.entries[key] = idempotencyEntry{
+		response:  IdempotentResponse{RequestHash: requestHash, Status: status, Body: body},
+		expiresAt: now.Add(ttl),
+	}
+	return nil
+}
+
+// Release drops the reservation of key, keeping a stored response
+func (s *InMemoryIdempotencyStore) Release(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.entries[key]; ok && entry.response.Pending {
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+// sweep drops expired entries so the store does not grow with every key ever used;
+// the caller holds mu
+func (s *InMemoryIdempotencyStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) <= time.Minute {
+		return
+	}
+	for k, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(s.entries, k)
+		}
+	}
+	s.lastSweep = now
+}
+
+// Idempotency replays the stored response of a POST request retried with the same
+// X-Idempotency-Key header (or Idempotency-Key) within ttl, instead of processing it
+// again. Keys are scoped to the authenticated user, the method and the route. The first
+// request reserves its key before it is processed, so a retry arriving while it is in
+// progress gets 409, and a request reusing a key with a different path or body gets 422.
+// Server errors release the key, so those requests can be retried. Requests without a
+// key pass through; when the store fails the request is processed without replay.
+func Idempotency(store IdempotencyStore, ttl time.Duration, logger *zap.Logger) gin.HandlerFunc {
+	reservationTTL := min(ttl, idempotencyReservationTTL)
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			key = c.GetHeader(LegacyIdempotencyKeyHeader)
+		}
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "X-Idempotency-Key must be at most 255 characters",
+			})
+			return
+		}
+
+		requestHash, err := idempotencyRequestHash(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Failed to read request body",
+			})
+			return
+		}
+		storeKey := idempotencyStoreKey(c, key)
+
+		reserved, err := store.Reserve(storeKey, reservationTTL)
+		if err != nil {
+			logger.Error("failed to reserve idempotency key, processing request without replay",
+				zap.String("path", c.Request.URL.Path),
+				zap.Error(err),
+			)
+			c.Next()
+			return
+		}
+		if !reserved {
+			replayIdempotentResponse(c, store, storeKey, requestHash, logger)
+			return
+		}
+
+		writer := &responseWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = writer
+		c.Next()
+
+		if status := writer.Status(); status >= http.StatusInternalServerError {
+			if err := store.Release(storeKey); err != nil {
+				logger.Error("failed to release idempotency key", zap.Error(err))
+			}
+			return
+		}
+		if err := store.Complete(storeKey, requestHash, writer.Status(), writer.body.Bytes(), ttl); err != nil {
+			logger.Error("failed to store idempotent response", zap.Error(err))
+		}
+	}
+}
+
+// replayIdempotentResponse answers a request whose key is already reserved: with the
+// stored response, or 409 while the first request is in progress, or 422 when the
+// response is for a different request
+func replayIdempotentResponse(c *gin.Context, store IdempotencyStore, storeKey, requestHash string, logger *zap.Logger) {
+	response, ok, err := store.Get(storeKey)
+	if err != nil {
+		logger.Error("failed to get idempotent response", zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+			"code":    "SERVICE_UNAVAILABLE",
+			"message": "Failed to check X-Idempotency-Key, retry the request",
+		})
+		return
+	}
+
+	switch {
+	case !ok || response.Pending:
+		// A reservation that expired between Reserve and Get belonged to a request
+		// that was still running too
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"code":    "IDEMPOTENCY_KEY_IN_USE",
+			"message": "A request with this X-Idempotency-Key is still in progress",
+		})
+	case response.RequestHash != requestHash:
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
+			"code":    "IDEMPOTENCY_KEY_REUSED",
+			"message": "X-Idempotency-Key was already used for a different request",
+		})
+	default:
+		c.Header(IdempotentReplayedHeader, "true")
+		c.Data(response.Status, "application/json; charset=utf-8", response.Body)
+		c.Abort()
+	}
+}
+
+// idempotencyStoreKey returns the store key of a request: the user, then
+// method:route:idempotency_key. The user is taken from authentication, falling back to
+// the user_id query parameter.
+func idempotencyStoreKey(c *gin.Context, key string) string {
+	userID := GetUserID(c)
+	if userID == "" {
+		userID = c.Query("user_id")
+	}
+
+	route := c.FullPath()
+	if route == "" {
+		route = c.Request.URL.Path
+	}
+
+	return "idempotency:" + userID + ":" + c.Request.Method + ":" + route + ":" + key
+}
+
+// idempotencyRequestHash returns the SHA-256 of the request path and body, restoring the
+// body for the handler. The path is included so a key reused on another resource of the
+// same route is a different request.
+func idempotencyRequestHash(c *gin.Context) (string, error) {
+	var body []byte
+	if c.Request.Body != nil {
+		var err error
+		body, err = io.ReadAll(c.Request.Body)
+		if err != nil {
+			return "", err
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(c.Request.URL.Path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisIdempotencyTimeout bounds each Redis command of RedisIdempotencyStore
+const redisIdempotencyTimeout = time.Second
+
+// idempotencyReservedValue marks a key reserved by a request in progress in Redis
+const idempotencyReservedValue = "000"
+
+// RedisIdempotencyStore is an IdempotencyStore shared by all instances through Redis.
+// A reservation is stored as 000 with SET NX; a response replaces it as its three-digit
+// status code, the request hash and the body, and expires with the key.
+type RedisIdempotencyStore struct {
+	client *redis.Client
+}
+
+// NewRedisIdempotencyStore creates a new RedisIdempotencyStore
+func NewRedisIdempotencyStore(client *redis.Client) *RedisIdempotencyStore {
+	return &RedisIdempotencyStore{
+		client: client,
+	}
+}
+
+// Reserve claims key with SET NX
+func (s *RedisIdempotencyStore) Reserve(key string, ttl time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisIdempotencyTimeout)
+	defer cancel()
+
+	reserved, err := s.client.SetNX(ctx, key, idempotencyReservedValue, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve idempotency key in redis: %w", err)
+	}
+	return reserved, nil
+}
+
+// Get returns the reservation or response stored for key
+func (s *RedisIdempotencyStore) Get(key string) (IdempotentResponse, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisIdempotencyTimeout)
+	defer cancel()
+
+	value, err := s.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return IdempotentResponse{}, false, nil
+	}
+	if err != nil {
+		return IdempotentResponse{}, false, fmt.Errorf("failed to get idempotent response from redis: %w", err)
+	}
+
+	response, err := decodeIdempotentResponse(value)
+	if err != nil {
+		return IdempotentResponse{}, false, fmt.Errorf("invalid idempotent response in redis: %w", err)
+	}
+	return response, true, nil
+}
+
+// Complete stores a response for key for ttl, replacing its reservation
+func (s *RedisIdempotencyStore) Complete(key, requestHash string, status int, body []byte, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisIdempotencyTimeout)
+	defer cancel()
+
+	value := append([]byte(fmt.Sprintf("%03d%s", status, requestHash)), body...)
+	if err := s.client.Set(ctx, key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to store idempotent response in redis: %w", err)
+	}
+	return nil
+}
+
+// Release drops the reservation of key. A response stored meanwhile is kept: the value
+// is only deleted while it is still the reservation.
+func (s *RedisIdempotencyStore) Release(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisIdempotencyTimeout)
+	defer cancel()
+
+	if err := releaseIdempotencyScript.Run(ctx, s.client, []string{key}, idempotencyReservedValue).Err(); err != nil {
+		return fmt.Errorf("failed to release idempotency key in redis: %w", err)
+	}
+	return nil
+}
+
+// releaseIdempotencyScript deletes KEYS[1] when it holds ARGV[1]
+var releaseIdempotencyScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// decodeIdempotentResponse splits a stored value into a reservation, or the status code,
+// request hash and body of a response
+func decodeIdempotentResponse(value []byte) (IdempotentResponse, error) {
+	if string(value) == idempotencyReservedValue {
+		return IdempotentResponse{Pending: true}, nil
+	}
+	if len(value) < 3+sha256.Size*2 {
+		return IdempotentResponse{}, errors.New("value too short")
+	}
+	status, err := strconv.Atoi(string(value[:3]))
+	if err != nil {
+		return IdempotentResponse{}, fmt.Errorf("invalid status code: %w", err)
+	}
+	return IdempotentResponse{
+		RequestHash: string(value[3 : 3+sha256.Size*2]),
+		Status:      status,
+		Body:        value[3+sha256.Size*2:],
+	}, nil
+}
@@ -0,0 +1,234 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// medicationRouter creates medications in rows, failing with 500 while failing is set
+func medicationRouter(store IdempotencyStore, rows *[]string, failing *bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Idempotency(store, time.Hour, zap.NewNop()))
+	router.POST("/api/v1/health/medications", func(c *gin.Context) {
+		if *failing {
+			c.JSON(http.StatusInternalServerError, gin.H{"code": "INTERNAL_ERROR"})
+			return
+		}
+		id := fmt.Sprintf("med-%d", len(*rows)+1)
+		*rows = append(*rows, id)
+		c.JSON(http.StatusCreated, gin.H{"id": id, "name": "Metformin"})
+	})
+	return router
+}
+
+func postMedication(router *gin.Engine, userID, header, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/health/medications?user_id="+userID, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(header, key)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotency_ReplaysRetriedMedicationCreation(t *testing.T) {
+	var rows []string
+	failing := false
+	router := medicationRouter(NewInMemoryIdempotencyStore(), &rows, &failing)
+	body := `{"name":"Metformin","dosage":"500mg","frequency":"daily"}`
+
+	first := postMedication(router, "user-1", IdempotencyKeyHeader, "key-1", body)
+	second := postMedication(router, "user-1", IdempotencyKeyHeader, "key-1", body)
+
+	require.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, []string{"med-1"}, rows, "the retry creates no second medication")
+	assert.Equal(t, first.Code, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, first.Header().Get("Content-Type"), second.Header().Get("Content-Type"))
+	assert.Equal(t, "true", second.Header().Get(IdempotentReplayedHeader))
+
+	third := postMedication(router, "user-1", LegacyIdempotencyKeyHeader, "key-1", body)
+	assert.Equal(t, first.Body.String(), third.Body.String(), "the legacy header uses the same key")
+
+	postMedication(router, "user-1", IdempotencyKeyHeader, "", body)
+	postMedication(router, "user-1", IdempotencyKeyHeader, "key-2", body)
+	assert.Len(t, rows, 3, "requests without a key or with a new key are processed")
+}
+
+func TestIdempotency_ScopesKeyToUserAndRejectsDifferentBody(t *testing.T) {
+	var rows []string
+	failing := false
+	router := medicationRouter(NewInMemoryIdempotencyStore(), &rows, &failing)
+
+	postMedication(router, "user-1", IdempotencyKeyHeader, "key-1", `{"name":"Metformin"}`)
+
+	w := postMedication(router, "user-1", IdempotencyKeyHeader, "key-1", `{"name":"Aspirin"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code, "a key reused with a different body is rejected")
+	assert.Contains(t, w.Body.String(), "IDEMPOTENCY_KEY_REUSED")
+
+	w = postMedication(router, "user-2", IdempotencyKeyHeader, "key-1", `{"name":"Metformin"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get(IdempotentReplayedHeader), "another user's response is not replayed")
+	assert.Len(t, rows, 2)
+
+	w = postMedication(router, "user-1", IdempotencyKeyHeader, strings.Repeat("k", 256), `{}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Len(t, rows, 2)
+}
+
+func TestIdempotency_ConflictsWhileFirstRequestInProgress(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := NewInMemoryIdempotencyStore()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var processed atomic.Int32
+
+	router := gin.New()
+	router.Use(Idempotency(store, time.Hour, zap.NewNop()))
+	router.POST("/api/v1/health/medications", func(c *gin.Context) {
+		processed.Add(1)
+		close(started)
+		<-release
+		c.JSON(http.StatusCreated, gin.H{"id": "med-1"})
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- postMedication(router, "user-1", IdempotencyKeyHeader, "key-1", `{}`)
+	}()
+	<-started
+
+	w := postMedication(router, "user-1", IdempotencyKeyHeader, "key-1", `{}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "IDEMPOTENCY_KEY_IN_USE")
+
+	close(release)
+	first := <-done
+	require.Equal(t, http.StatusCreated, first.Code)
+
+	w = postMedication(router, "user-1", IdempotencyKeyHeader, "key-1", `{}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "true", w.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, int32(1), processed.Load())
+}
+
+func TestIdempotency_RetriesAfterServerError(t *testing.T) {
+	var rows []string
+	failing := true
+	router := medicationRouter(NewInMemoryIdempotencyStore(), &rows, &failing)
+
+	w := postMedication(router, "user-1", IdempotencyKeyHeader, "key-1", `{}`)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+
+	failing = false
+	w = postMedication(router, "user-1", IdempotencyKeyHeader, "key-1", `{}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, []string{"med-1"}, rows)
+}
+
+// failingIdempotencyStore is an IdempotencyStore whose backend is unavailable
+type failingIdempotencyStore struct {
+	reserveErr error
+}
+
+func (s failingIdempotencyStore) Reserve(string, time.Duration) (bool, error) {
+	if s.reserveErr != nil {
+		return false, s.reserveErr
+	}
+	return false, nil
+}
+
+func (failingIdempotencyStore) Get(string) (IdempotentResponse, bool, error) {
+	return IdempotentResponse{}, false, errors.New("connection refused")
+}
+
+func (failingIdempotencyStore) Complete(string, string, int, []byte, time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func (failingIdempotencyStore) Release(string) error {
+	return errors.New("connection refused")
+}
+
+func TestIdempotency_StoreFailures(t *testing.T) {
+	var rows []string
+	failing := false
+
+	router := medicationRouter(failingIdempotencyStore{reserveErr: errors.New("connection refused")}, &rows, &failing)
+	w := postMedication(router, "user-1", IdempotencyKeyHeader, "key-1", `{}`)
+	assert.Equal(t, http.StatusCreated, w.Code, "a request whose key cannot be reserved is processed")
+	assert.Len(t, rows, 1)
+
+	router = medicationRouter(failingIdempotencyStore{}, &rows, &failing)
+	w = postMedication(router, "user-1", IdempotencyKeyHeader, "key-1", `{}`)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code, "a reserved key whose response cannot be read is not processed")
+	assert.Len(t, rows, 1)
+}
+
+func TestInMemoryIdempotencyStore_ReservesAndExpires(t *testing.T) {
+	store := NewInMemoryIdempotencyStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	reserved, err := store.Reserve("key", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+	reserved, err = store.Reserve("key", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, reserved, "a reserved key cannot be reserved again")
+
+	response, ok, err := store.Get("key")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.True(t, response.Pending)
+
+	require.NoError(t, store.Complete("key", "hash", http.StatusCreated, []byte(`{}`), time.Minute))
+	response, ok, err = store.Get("key")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, IdempotentResponse{RequestHash: "hash", Status: http.StatusCreated, Body: []byte(`{}`)}, response)
+
+	require.NoError(t, store.Release("key"))
+	_, ok, _ = store.Get("key")
+	assert.True(t, ok, "releasing keeps a stored response")
+
+	now = now.Add(time.Minute)
+	_, ok, _ = store.Get("key")
+	assert.False(t, ok)
+	reserved, _ = store.Reserve("key", time.Minute)
+	assert.True(t, reserved, "an expired key can be reserved again")
+
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, store.Complete("other", "hash", http.StatusOK, nil, time.Minute))
+	assert.NotContains(t, store.entries, "key", "expired entries are swept")
+}
+
+func TestDecodeIdempotentResponse(t *testing.T) {
+	hash := strings.Repeat("a", 64)
+	response, err := decodeIdempotentResponse([]byte(`201` + hash + `{"id":"med-1"}`))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, response.Status)
+	assert.Equal(t, hash, response.RequestHash)
+	assert.Equal(t, `{"id":"med-1"}`, string(response.Body))
+
+	response, err = decodeIdempotentResponse([]byte(idempotencyReservedValue))
+	require.NoError(t, err)
+	assert.True(t, response.Pending)
+
+	_, err = decodeIdempotentResponse([]byte(`201abc`))
+	assert.Error(t, err)
+}
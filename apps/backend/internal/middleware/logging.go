@@ -2,9 +2,16 @@ package middleware
 
 import (
 	"bytes"
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/metrics"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
 	"go.uber.org/zap"
 )
 
@@ -27,18 +34,18 @@ func RequestLoggingMiddleware(logger *zap.Logger) gin.HandlerFunc {
 		path := c.Request.URL.Path
 		query := c.Request.URL.RawQuery
 
-		// Get user ID from context if available (for authenticated requests)
-		userID := c.GetString("user_id")
-		if userID == "" {
-			userID = "anonymous"
-		}
-
 		// Process request
 		c.Next()
 
 		// Calculate request duration
 		duration := time.Since(startTime)
 
+		// Get user ID from context if available; authentication runs after this middleware
+		userID := c.GetString("user_id")
+		if userID == "" {
+			userID = "anonymous"
+		}
+
 		// Log request details
 		fields := []zap.Field{
 			zap.String("method", c.Request.Method),
@@ -72,7 +79,19 @@ func RequestLoggingMiddleware(logger *zap.Logger) gin.HandlerFunc {
 // ErrorLoggingMiddleware logs errors with stack traces and request context
 // Validates: Requirements 12.2
 func ErrorLoggingMiddleware(logger *zap.Logger) gin.HandlerFunc {
+	return ErrorLoggingMiddlewareWithReporter(logger, telemetry.NopReporter{})
+}
+
+// ErrorLoggingMiddlewareWithReporter logs errors like ErrorLoggingMiddleware and
+// reports requests completing with a 5xx status to reporter. The request and trace
+// IDs are attached to the request context so service failure paths can report
+// correlated events.
+func ErrorLoggingMiddlewareWithReporter(logger *zap.Logger, reporter telemetry.ErrorReporter) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		requestID := c.GetString("request_id")
+		traceID := c.GetString("trace_id")
+		c.Request = c.Request.WithContext(telemetry.WithRequest(c.Request.Context(), requestID, traceID))
+
 		c.Next()
 
 		// Check if there are any errors
@@ -90,6 +109,29 @@ func ErrorLoggingMiddleware(logger *zap.Logger) gin.HandlerFunc {
 				)
 			}
 		}
+
+		status := c.Writer.Status()
+		if status < http.StatusInternalServerError {
+			return
+		}
+
+		message := http.StatusText(status)
+		if last := c.Errors.Last(); last != nil {
+			message = last.Error()
+		}
+
+		// The route template is reported instead of the raw path, which may contain user IDs
+		reporter.Report(c.Request.Context(), telemetry.ErrorEvent{
+			Timestamp: time.Now().UTC(),
+			Kind:      telemetry.KindHTTPError,
+			Operation: c.Request.Method + " " + c.FullPath(),
+			Message:   telemetry.Scrub(message),
+			RequestID: requestID,
+			TraceID:   traceID,
+			Method:    c.Request.Method,
+			Route:     c.FullPath(),
+			Status:    status,
+		})
 	}
 }
 
@@ -142,13 +184,30 @@ func RequestIDMiddleware() gin.HandlerFunc {
 	}
 }
 
-// TracingMiddleware adds distributed tracing support with trace IDs
+// TracingMiddleware adds distributed tracing support with trace IDs. Each request runs in
+// a server span continuing the trace of an incoming traceparent header; the span's trace
+// ID is returned in X-Trace-ID. With tracing disabled the X-Trace-ID header is passed on
+// or a new one generated.
 // Validates: Requirements 12.1
 func TracingMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Check if trace ID is already set in header
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		ctx, span := telemetry.StartSpan(ctx, c.Request.Method+" "+route,
+			attribute.String("http.request.method", c.Request.Method),
+			attribute.String("http.route", route),
+		)
+		defer span.End()
+		c.Request = c.Request.WithContext(ctx)
+
 		traceID := c.GetHeader("X-Trace-ID")
-		if traceID == "" {
+		if spanContext := span.SpanContext(); spanContext.HasTraceID() {
+			traceID = spanContext.TraceID().String()
+		} else if traceID == "" {
 			// Generate new trace ID
 			traceID = generateTraceID()
 		}
@@ -160,6 +219,12 @@ func TracingMiddleware() gin.HandlerFunc {
 		c.Header("X-Trace-ID", traceID)
 
 		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
 	}
 }
 
@@ -178,11 +243,18 @@ func generateTraceID() string {
 // SlowQueryLoggingMiddleware logs database queries that exceed a threshold
 // This is a placeholder - actual implementation would be in the repository layer
 // Validates: Requirements 12.5
+//
+// Requests that take longer than threshold in total are counted in the
+// http_slow_requests_total metric.
 func SlowQueryLoggingMiddleware(logger *zap.Logger, threshold time.Duration) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Store threshold in context for repository layer to use
 		c.Set("slow_query_threshold", threshold)
 		c.Set("slow_query_logger", logger)
+		start := time.Now()
 		c.Next()
+		if time.Since(start) > threshold {
+			metrics.Default.RecordSlowRequest(c.Request.Method, routeLabel(c))
+		}
 	}
 }
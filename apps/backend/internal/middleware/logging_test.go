@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// capturingReporter records reported events
+type capturingReporter struct {
+	mu     sync.Mutex
+	events []telemetry.ErrorEvent
+}
+
+func (r *capturingReporter) Report(ctx context.Context, event telemetry.ErrorEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func newTelemetryRouter(reporter telemetry.ErrorReporter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestIDMiddleware())
+	router.Use(TracingMiddleware())
+	router.Use(ErrorLoggingMiddlewareWithReporter(zap.NewNop(), reporter))
+	return router
+}
+
+func TestErrorLoggingMiddleware_Reports5xxWithRequestID(t *testing.T) {
+	reporter := &capturingReporter{}
+	router := newTelemetryRouter(reporter)
+
+	var serviceRequestID string
+	router.GET("/api/v1/users/:id/data", func(c *gin.Context) {
+		serviceRequestID, _ = telemetry.RequestIDs(c.Request.Context())
+		c.Error(errors.New("export failed for patient@example.com"))
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "INTERNAL_ERROR"})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/0b7f6f0e-3c1d-4a38-9a57-5f0d2a1c6e11/data", nil)
+	req.Header.Set("X-Request-ID", "req-12345")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Len(t, reporter.events, 1)
+
+	event := reporter.events[0]
+	assert.Equal(t, telemetry.KindHTTPError, event.Kind)
+	assert.Equal(t, "req-12345", event.RequestID)
+	assert.NotEmpty(t, event.TraceID)
+	assert.Equal(t, http.StatusInternalServerError, event.Status)
+	assert.Equal(t, "/api/v1/users/:id/data", event.Route)
+	assert.Equal(t, "export failed for [EMAIL]", event.Message)
+
+	// Handlers see the request ID through the request context
+	assert.Equal(t, "req-12345", serviceRequestID)
+}
+
+func TestErrorLoggingMiddleware_IgnoresNon5xx(t *testing.T) {
+	reporter := &capturingReporter{}
+	router := newTelemetryRouter(reporter)
+
+	router.GET("/ok", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/bad", func(c *gin.Context) { c.Status(http.StatusBadRequest) })
+
+	for _, path := range []string{"/ok", "/bad"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Empty(t, reporter.events)
+}
+
+func TestTracingMiddleware_ContinuesIncomingTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTracerProvider(previous)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(TracingMiddleware())
+	router.GET("/api/v1/checkin/:sessionId", func(c *gin.Context) {
+		c.Status(http.StatusServiceUnavailable)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/checkin/session-1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", w.Header().Get("X-Trace-ID"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "GET /api/v1/checkin/:sessionId", spans[0].Name())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	assert.Contains(t, spans[0].Attributes(), attribute.Int("http.status_code", http.StatusServiceUnavailable))
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
+func TestRequestLoggingMiddleware_LogsUserAuthenticatedAfterIt(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	core, logs := observer.New(zap.InfoLevel)
+	router := gin.New()
+	router.Use(RequestLoggingMiddleware(zap.New(core)))
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", "user-1")
+		c.Next()
+	})
+	router.GET("/api/v1/dashboard/summary", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/dashboard/summary", nil))
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "user-1", entries[0].ContextMap()["user_id"])
+}
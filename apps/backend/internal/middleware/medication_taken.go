@@ -0,0 +1,213 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+)
+
+const (
+	// DeprecationHeader is set on responses to requests that used a deprecated value
+	DeprecationHeader = "Deprecation"
+
+	// medicationTakenField is the request field whose "partial" value was renamed to "some"
+	medicationTakenField = "medication_taken"
+
+	// medicationTakenWarning is the Warning header sent with the Deprecation header
+	medicationTakenWarning = `299 - "medication_taken value \"partial\" is deprecated, use \"some\""`
+
+	// maxMedicationTakenBodyBytes bounds the request bodies read to rewrite medication_taken
+	maxMedicationTakenBodyBytes = 1 << 20
+)
+
+// MedicationTakenCompat handles the rename of the medication_taken value "partial" to
+// "some" at the API boundary, on routes (gin full paths) that accept medication_taken.
+// While acceptLegacy is set, "partial" in a JSON request body or in the medication_taken
+// query parameter is rewritten to "some" and the response carries Deprecation and Warning
+// headers. Once the window ends such requests are rejected.
+func MedicationTakenCompat(acceptLegacy bool, routes ...string) gin.HandlerFunc {
+	scoped := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		scoped[route] = true
+	}
+
+	return func(c *gin.Context) {
+		if !scoped[c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		legacy := rewriteMedicationTakenQuery(c.Request)
+
+		bodyLegacy, err := rewriteMedicationTakenBody(c.Writer, c.Request)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+					"code":    "PAYLOAD_TOO_LARGE",
+					"message": "Request body is too large",
+				})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Failed to read request body",
+			})
+			return
+		}
+		legacy = legacy || bodyLegacy
+
+		if legacy {
+			if !acceptLegacy {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+					"code":    "VALIDATION_ERROR",
+					"message": `medication_taken value "partial" is no longer supported, use "some"`,
+				})
+				return
+			}
+			c.Header(DeprecationHeader, "true")
+			c.Header("Warning", medicationTakenWarning)
+		}
+
+		c.Next()
+	}
+}
+
+// rewriteMedicationTakenQuery normalizes the medication_taken query parameter and
+// reports whether it used the deprecated value
+func rewriteMedicationTakenQuery(r *http.Request) bool {
+	query := r.URL.Query()
+	values, ok := query[medicationTakenField]
+	if !ok {
+		return false
+	}
+
+	var legacy bool
+	for i, value := range values {
+		if normalized, isLegacy := model.NormalizeMedicationTaken(value); isLegacy {
+			values[i] = normalized
+			legacy = true
+		}
+	}
+	if legacy {
+		r.URL.RawQuery = query.Encode()
+	}
+	return legacy
+}
+
+// rewriteMedicationTakenBody normalizes medication_taken fields at any depth of a JSON
+// request body of at most maxMedicationTakenBodyBytes and reports whether any used the
+// deprecated value. Bodies that are not valid JSON are left for the handler to validate.
+func rewriteMedicationTakenBody(w http.ResponseWriter, r *http.Request) (bool, error) {
+	if r.Body == nil || r.Body == http.NoBody || !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		return false, nil
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxMedicationTakenBodyBytes))
+	r.Body.Close()
+	if err != nil {
+		return false, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	if !bytes.Contains(body, []byte(model.MedicationTakenPartial)) {
+		return false, nil
+	}
+
+	rewritten, legacy, err := rewriteMedicationTakenJSON(body)
+	if err != nil || !legacy {
+		return false, nil
+	}
+	r.Body = io.NopCloser(bytes.NewReader(rewritten))
+	r.ContentLength = int64(len(rewritten))
+	return true, nil
+}
+
+// jsonContainer is an object or array open while a document is re-encoded
+type jsonContainer struct {
+	object bool
+	tokens int // keys and values written into it so far
+}
+
+// rewriteMedicationTakenJSON re-encodes a JSON document token by token, so the order of
+// its keys is kept, with deprecated medication_taken values normalized. It reports
+// whether any value was rewritten.
+func rewriteMedicationTakenJSON(body []byte) ([]byte, bool, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var out bytes.Buffer
+	var stack []jsonContainer
+	var key string
+	var legacy bool
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			return nil, false, err
+		}
+
+		closing := tok == json.Delim('}') || tok == json.Delim(']')
+		isKey := false
+		if len(stack) > 0 && !closing {
+			top := &stack[len(stack)-1]
+			switch {
+			case top.object && top.tokens%2 == 1:
+				out.WriteByte(':')
+			case top.tokens > 0:
+				out.WriteByte(',')
+			}
+			isKey = top.object && top.tokens%2 == 0
+			top.tokens++
+		}
+
+		switch v := tok.(type) {
+		case json.Delim:
+			out.WriteRune(rune(v))
+			if closing {
+				stack = stack[:len(stack)-1]
+			} else {
+				stack = append(stack, jsonContainer{object: v == '{'})
+			}
+		case string:
+			if isKey {
+				key = v
+			} else if len(stack) > 0 && stack[len(stack)-1].object && key == medicationTakenField {
+				if normalized, isLegacy := model.NormalizeMedicationTaken(v); isLegacy {
+					v = normalized
+					legacy = true
+				}
+			}
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, false, err
+			}
+			out.Write(encoded)
+		case json.Number:
+			out.WriteString(v.String())
+		case bool:
+			if v {
+				out.WriteString("true")
+			} else {
+				out.WriteString("false")
+			}
+		case nil:
+			out.WriteString("null")
+		}
+
+		if len(stack) == 0 {
+			break
+		}
+	}
+
+	// Trailing data is left for the handler to reject
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, false, errors.New("unexpected data after JSON document")
+	}
+	return out.Bytes(), legacy, nil
+}
@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMedicationTakenCompat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(acceptLegacy bool, received *string) *gin.Engine {
+		router := gin.New()
+		router.Use(MedicationTakenCompat(acceptLegacy, "/api/v1/x"))
+		record := func(c *gin.Context) {
+			body, _ := io.ReadAll(c.Request.Body)
+			*received = c.Query("medication_taken") + string(body)
+			c.Status(http.StatusOK)
+		}
+		router.Any("/api/v1/x", record)
+		router.Any("/api/v1/other", record)
+		return router
+	}
+
+	tests := []struct {
+		name         string
+		acceptLegacy bool
+		target       string
+		body         string
+		wantStatus   int
+		wantReceived string
+		exact        bool // compare the received body byte for byte
+		deprecated   bool
+	}{
+		{
+			name:         "new value passes unchanged",
+			acceptLegacy: true,
+			target:       "/api/v1/x",
+			body:         `{"medication_taken":"some"}`,
+			wantStatus:   http.StatusOK,
+			wantReceived: `{"medication_taken":"some"}`,
+		},
+		{
+			name:         "legacy body value is rewritten",
+			acceptLegacy: true,
+			target:       "/api/v1/x",
+			body:         `{"check_in":{"medication_taken":"partial","notes":"partial dose"}}`,
+			wantStatus:   http.StatusOK,
+			wantReceived: `{"check_in":{"medication_taken":"some","notes":"partial dose"}}`,
+			deprecated:   true,
+		},
+		{
+			name:         "rewritten body keeps its key order",
+			acceptLegacy: true,
+			target:       "/api/v1/x",
+			body:         `{"symptoms":["partial pain"],"mood":"neutral","medication_taken":"partial","pain_level":3,"meals":{"lunch":null}}`,
+			wantStatus:   http.StatusOK,
+			wantReceived: `{"symptoms":["partial pain"],"mood":"neutral","medication_taken":"some","pain_level":3,"meals":{"lunch":null}}`,
+			exact:        true,
+			deprecated:   true,
+		},
+		{
+			name:         "routes without medication_taken are untouched",
+			acceptLegacy: false,
+			target:       "/api/v1/other",
+			body:         `{"medication_taken":"partial"}`,
+			wantStatus:   http.StatusOK,
+			wantReceived: `{"medication_taken":"partial"}`,
+			exact:        true,
+		},
+		{
+			name:         "oversized body is rejected",
+			acceptLegacy: true,
+			target:       "/api/v1/x",
+			body:         `{"notes":"` + strings.Repeat("a", maxMedicationTakenBodyBytes) + `"}`,
+			wantStatus:   http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:         "legacy query value is rewritten",
+			acceptLegacy: true,
+			target:       "/api/v1/x?medication_taken=partial",
+			wantStatus:   http.StatusOK,
+			wantReceived: "some",
+			deprecated:   true,
+		},
+		{
+			name:         "other fields with the old spelling are untouched",
+			acceptLegacy: true,
+			target:       "/api/v1/x",
+			body:         `{"notes":"partial"}`,
+			wantStatus:   http.StatusOK,
+			wantReceived: `{"notes":"partial"}`,
+		},
+		{
+			name:         "legacy value rejected after the window",
+			acceptLegacy: false,
+			target:       "/api/v1/x",
+			body:         `{"medication_taken":"partial"}`,
+			wantStatus:   http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received string
+			router := newRouter(tt.acceptLegacy, &received)
+
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(http.MethodPost, tt.target, body)
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				if tt.exact {
+					assert.Equal(t, tt.wantReceived, received)
+				} else {
+					assert.JSONEq(t, jsonOrString(tt.wantReceived), jsonOrString(received))
+				}
+			}
+			if tt.deprecated {
+				assert.Equal(t, "true", w.Header().Get(DeprecationHeader))
+				assert.Contains(t, w.Header().Get("Warning"), "deprecated")
+			} else {
+				assert.Empty(t, w.Header().Get(DeprecationHeader))
+			}
+		})
+	}
+}
+
+// jsonOrString quotes s unless it is already a JSON object
+func jsonOrString(s string) string {
+	if strings.HasPrefix(s, "{") {
+		return s
+	}
+	return `"` + s + `"`
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/metrics"
+	"go.uber.org/zap"
+)
+
+func newMetricsRouter(registry *metrics.Registry) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(MetricsMiddleware(registry))
+	router.GET("/metrics", gin.WrapH(registry.Handler()))
+	router.GET("/api/v1/reports/:id", func(c *gin.Context) { c.Status(http.StatusNotFound) })
+	return router
+}
+
+func TestMetricsMiddleware_ScrapeAfterRequest(t *testing.T) {
+	registry := metrics.NewRegistry()
+	router := newMetricsRouter(registry)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/reports/8d3c8a2e", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown/path", nil))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/plain; version=0.0.4")
+
+	body := w.Body.String()
+	assert.Contains(t, body, "# TYPE http_request_duration_seconds histogram\n")
+	assert.Contains(t, body, `http_request_duration_seconds_bucket{method="GET",route="/api/v1/reports/:id",status="404",le="+Inf"} 1`)
+	assert.Contains(t, body, `http_request_duration_seconds_count{method="GET",route="/api/v1/reports/:id",status="404"} 1`)
+	assert.Contains(t, body, `http_requests_total{method="GET",route="/api/v1/reports/:id",status="404"} 1`)
+	assert.Contains(t, body, `http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.NotContains(t, body, "8d3c8a2e", "raw paths must not become labels")
+}
+
+func TestSlowQueryLoggingMiddleware_CountsSlowRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(SlowQueryLoggingMiddleware(zap.NewNop(), 5*time.Millisecond))
+	router.GET("/fast", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/slow", func(c *gin.Context) {
+		time.Sleep(10 * time.Millisecond)
+		c.Status(http.StatusOK)
+	})
+
+	before := metrics.Default.CounterValue("http_slow_requests_total", http.MethodGet, "/slow")
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fast", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+	assert.Equal(t, before+1, metrics.Default.CounterValue("http_slow_requests_total", http.MethodGet, "/slow"))
+	assert.Zero(t, metrics.Default.CounterValue("http_slow_requests_total", http.MethodGet, "/fast"))
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// tokenScopesContextKey is the Gin context key holding the scopes of the personal access
+// token a request was authenticated with. JWT requests have no scopes set.
+const tokenScopesContextKey = "token_scopes"
+
+// PersonalAccessTokenAuthenticator resolves personal access tokens and records their use
+type PersonalAccessTokenAuthenticator interface {
+	LookupToken(ctx context.Context, token string) (*model.PersonalAccessToken, error)
+	RecordTokenUse(ctx context.Context, token *model.PersonalAccessToken, ipAddress, userAgent, path string)
+}
+
+// TokenScopeRoute grants personal access tokens holding Scope access to a group of routes
+type TokenScopeRoute struct {
+	Method string // HTTP method, empty matches every method
+	Path   string // route path; a trailing "*" matches every route with that prefix
+	Scope  model.TokenScope
+}
+
+// matches reports whether the route applies to a request for the given route
+func (r TokenScopeRoute) matches(method, route string) bool {
+	if r.Method != "" && r.Method != method {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(r.Path, "*"); ok {
+		return strings.HasPrefix(route, prefix)
+	}
+	return route == r.Path
+}
+
+// PersonalAccessTokenAuth authenticates bearer tokens starting with "pat_" as personal
+// access tokens and hands every other request to next, the JWT authentication. Revoked
+// and expired tokens are rejected with the TOKEN_REVOKED and TOKEN_EXPIRED codes.
+func PersonalAccessTokenAuth(auth PersonalAccessTokenAuthenticator, next gin.HandlerFunc, logger *zap.Logger) gin.HandlerFunc {
+	return personalAccessTokenAuth(auth, next, logger, time.Now)
+}
+
+func personalAccessTokenAuth(auth PersonalAccessTokenAuthenticator, next gin.HandlerFunc, logger *zap.Logger, now func() time.Time) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		secret := bearerToken(c)
+		if !strings.HasPrefix(secret, model.PersonalAccessTokenPrefix) {
+			next(c)
+			return
+		}
+
+		token, err := auth.LookupToken(c.Request.Context(), secret)
+		if err != nil {
+			logger.Error("failed to look up personal access token", zap.Error(err))
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to authenticate token",
+			})
+			return
+		}
+
+		switch {
+		case token == nil:
+			logger.Warn("unknown personal access token",
+				zap.String("path", c.Request.URL.Path),
+				zap.String("ip", c.ClientIP()),
+			)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"code":    "UNAUTHORIZED",
+				"message": "Invalid or expired token",
+			})
+			return
+		case token.RevokedAt != nil:
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"code":    "TOKEN_REVOKED",
+				"message": "Personal access token has been revoked",
+			})
+			return
+		case !now().Before(token.ExpiresAt):
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"code":    "TOKEN_EXPIRED",
+				"message": "Personal access token has expired",
+			})
+			return
+		}
+
+		auth.RecordTokenUse(c.Request.Context(), token, c.ClientIP(), c.Request.UserAgent(), c.Request.URL.Path)
+
+		c.Set(userIDContextKey, token.UserID)
+		c.Set(tokenScopesContextKey, token.Scopes)
+		c.Next()
+	}
+}
+
+// RequireTokenScopes restricts requests authenticated with a personal access token to
+// the routes granted to one of the token's scopes. The first matching route applies;
+// routes no scope grants are closed to tokens. JWT requests pass unchanged.
+func RequireTokenScopes(routes ...TokenScopeRoute) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, ok := c.Get(tokenScopesContextKey)
+		if !ok {
+			c.Next()
+			return
+		}
+		scopes, _ := value.([]model.TokenScope)
+
+		route := c.FullPath()
+		for _, r := range routes {
+			if route == "" || !r.matches(c.Request.Method, route) {
+				continue
+			}
+			if slices.Contains(scopes, r.Scope) {
+				c.Next()
+				return
+			}
+			break
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"code":    "INSUFFICIENT_SCOPE",
+			"message": "Personal access token lacks the scope for this endpoint",
+		})
+	}
+}
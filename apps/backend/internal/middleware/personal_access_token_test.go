@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// fakeTokenAuthenticator serves fixed personal access tokens and records their use
+type fakeTokenAuthenticator struct {
+	tokens map[string]*model.PersonalAccessToken
+	err    error
+	used   []string
+}
+
+func (f *fakeTokenAuthenticator) LookupToken(ctx context.Context, token string) (*model.PersonalAccessToken, error) {
+	return f.tokens[token], f.err
+}
+
+func (f *fakeTokenAuthenticator) RecordTokenUse(ctx context.Context, token *model.PersonalAccessToken, ipAddress, userAgent, path string) {
+	f.used = append(f.used, token.ID)
+}
+
+func newTokenRouter(auth PersonalAccessTokenAuthenticator, now time.Time) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	jwt := func(c *gin.Context) {
+		c.Set(userIDContextKey, "jwt-user")
+		c.Next()
+	}
+
+	router := gin.New()
+	router.Use(personalAccessTokenAuth(auth, jwt, zap.NewNop(), func() time.Time { return now }))
+	router.Use(RequireTokenScopes(
+		TokenScopeRoute{Method: http.MethodGet, Path: "/api/v1/health/*", Scope: model.TokenScopeHealthRead},
+		TokenScopeRoute{Method: http.MethodGet, Path: "/api/v1/reports", Scope: model.TokenScopeReportsRead},
+	))
+	handler := func(c *gin.Context) {
+		c.String(http.StatusOK, GetUserID(c))
+	}
+	router.GET("/api/v1/health/blood-pressure", handler)
+	router.POST("/api/v1/health/blood-pressure", handler)
+	router.GET("/api/v1/reports", handler)
+	router.GET("/api/v1/users/:id/tokens", handler)
+	return router
+}
+
+func tokenRequest(router *gin.Engine, method, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func errorCode(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return body["code"]
+}
+
+func TestPersonalAccessTokenAuth(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	revokedAt := now.Add(-time.Hour)
+	auth := &fakeTokenAuthenticator{tokens: map[string]*model.PersonalAccessToken{
+		"pat_health": {ID: "t1", UserID: "user-1", Scopes: []model.TokenScope{model.TokenScopeHealthRead}, ExpiresAt: now.Add(time.Hour)},
+		"pat_revoked": {ID: "t2", UserID: "user-1", Scopes: []model.TokenScope{model.TokenScopeHealthRead},
+			ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt},
+		"pat_expired": {ID: "t3", UserID: "user-1", Scopes: []model.TokenScope{model.TokenScopeHealthRead}, ExpiresAt: now},
+	}}
+	router := newTokenRouter(auth, now)
+
+	t.Run("valid token authenticates its user", func(t *testing.T) {
+		w := tokenRequest(router, http.MethodGet, "/api/v1/health/blood-pressure", "pat_health")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "user-1", w.Body.String())
+		assert.Contains(t, auth.used, "t1")
+	})
+
+	t.Run("other bearer tokens go to JWT authentication", func(t *testing.T) {
+		w := tokenRequest(router, http.MethodPost, "/api/v1/health/blood-pressure", "eyJhbGciOi")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "jwt-user", w.Body.String())
+	})
+
+	rejected := []struct {
+		name  string
+		token string
+		code  string
+	}{
+		{"unknown", "pat_unknown", "UNAUTHORIZED"},
+		{"revoked", "pat_revoked", "TOKEN_REVOKED"},
+		{"expired", "pat_expired", "TOKEN_EXPIRED"},
+	}
+	for _, tc := range rejected {
+		t.Run(tc.name+" token is rejected", func(t *testing.T) {
+			w := tokenRequest(router, http.MethodGet, "/api/v1/health/blood-pressure", tc.token)
+			require.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.Equal(t, tc.code, errorCode(t, w))
+		})
+	}
+
+	t.Run("lookup failure", func(t *testing.T) {
+		router := newTokenRouter(&fakeTokenAuthenticator{err: errors.New("db down")}, now)
+		w := tokenRequest(router, http.MethodGet, "/api/v1/health/blood-pressure", "pat_health")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestRequireTokenScopes(t *testing.T) {
+	now := time.Now()
+	auth := &fakeTokenAuthenticator{tokens: map[string]*model.PersonalAccessToken{
+		"pat_health": {ID: "t1", UserID: "user-1", Scopes: []model.TokenScope{model.TokenScopeHealthRead}, ExpiresAt: now.Add(time.Hour)},
+	}}
+	router := newTokenRouter(auth, now)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+	}{
+		{"route of a granted scope", http.MethodGet, "/api/v1/health/blood-pressure", http.StatusOK},
+		{"route of another scope", http.MethodGet, "/api/v1/reports", http.StatusForbidden},
+		{"write to a granted path", http.MethodPost, "/api/v1/health/blood-pressure", http.StatusForbidden},
+		{"route no scope grants", http.MethodGet, "/api/v1/users/user-1/tokens", http.StatusForbidden},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := tokenRequest(router, tc.method, tc.path, "pat_health")
+			require.Equal(t, tc.status, w.Code)
+			if tc.status == http.StatusForbidden {
+				assert.Equal(t, "INSUFFICIENT_SCOPE", errorCode(t, w))
+			}
+		})
+	}
+
+	t.Run("JWT requests are not restricted", func(t *testing.T) {
+		w := tokenRequest(router, http.MethodGet, "/api/v1/users/user-1/tokens", "eyJhbGciOi")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimitWindow is the counting window for requests-per-second limits
+const rateLimitWindow = time.Second
+
+// RateLimitStore counts requests per key within a time window.
+// Implementations may be backed by Redis for multi-instance deployments or by memory.
+type RateLimitStore interface {
+	// Increment adds one request for key and returns the count in the current window,
+	// fixed or sliding depending on the store
+	Increment(key string, window time.Duration) (count int64, err error)
+}
+
+// rateLimitCounter is a single window counter in the in-memory store
+type rateLimitCounter struct {
+	count     int64
+	expiresAt time.Time
+}
+
+// InMemoryRateLimitStore is a fixed window RateLimitStore for single-instance deployments
+// and tests
+type InMemoryRateLimitStore struct {
+	mu        sync.Mutex
+	counters  map[string]*rateLimitCounter
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewInMemoryRateLimitStore creates a new InMemoryRateLimitStore
+func NewInMemoryRateLimitStore() *InMemoryRateLimitStore {
+	return &InMemoryRateLimitStore{
+		counters: make(map[string]*rateLimitCounter),
+		now:      time.Now,
+	}
+}
+
+// Increment adds one request for key and returns the count in the current window
+func (s *InMemoryRateLimitStore) Increment(key string, window time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+
+	// Drop expired counters so idle users do not accumulate
+	if now.Sub(s.lastSweep) > time.Minute {
+		for k, counter := range s.counters {
+			if !now.Before(counter.expiresAt) {
+				delete(s.counters, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	counter, ok := s.counters[key]
+	if !ok || !now.Before(counter.expiresAt) {
+		counter = &rateLimitCounter{expiresAt: now.Add(window)}
+		s.counters[key] = counter
+	}
+	counter.count++
+
+	return counter.count, nil
+}
+
+// RateLimiter limits requests globally and per client. Authenticated clients are limited
+// by the "user_id" context value set by authentication, others by their IP address, so a
+// caller cannot pick another budget with a query parameter. A limit of zero disables that
+// check. Store errors fail open.
+func RateLimiter(globalRPS, perUserRPS int, store RateLimitStore) gin.HandlerFunc {
+	retryAfter := strconv.Itoa(int(math.Ceil(rateLimitWindow.Seconds())))
+
+	reject := func(c *gin.Context, message string) {
+		c.Header("Retry-After", retryAfter)
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+			"code":    "RATE_LIMITED",
+			"message": message,
+		})
+	}
+
+	return func(c *gin.Context) {
+		if globalRPS > 0 {
+			count, err := store.Increment("ratelimit:global", rateLimitWindow)
+			if err == nil && count > int64(globalRPS) {
+				reject(c, "Too many requests")
+				return
+			}
+		}
+
+		if perUserRPS > 0 {
+			key := "ratelimit:ip:" + c.ClientIP()
+			if userID := GetUserID(c); userID != "" {
+				key = "ratelimit:user:" + userID
+			}
+
+			count, err := store.Increment(key, rateLimitWindow)
+			if err == nil && count > int64(perUserRPS) {
+				reject(c, "Too many requests for this user")
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
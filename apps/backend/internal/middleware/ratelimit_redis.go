@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisRateLimitTimeout bounds each Redis call of RedisRateLimitStore; requests are let
+// through when it is exceeded
+const redisRateLimitTimeout = 200 * time.Millisecond
+
+// slidingWindowScript records a request at ARGV[1] milliseconds as member ARGV[3] of the
+// sorted set KEYS[1], drops the requests older than the ARGV[2] millisecond window and
+// returns how many remain
+var slidingWindowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - window)
+redis.call("ZADD", KEYS[1], now, ARGV[3])
+redis.call("PEXPIRE", KEYS[1], window)
+return redis.call("ZCARD", KEYS[1])
+`)
+
+// RedisRateLimitStore is a RateLimitStore shared by all instances through Redis. It
+// counts requests in a sliding window: each request is a member of a sorted set scored
+// by its time, so a burst at the end of one second is still counted in the next.
+type RedisRateLimitStore struct {
+	client *redis.Client
+	now    func() time.Time
+}
+
+// NewRedisRateLimitStore creates a new RedisRateLimitStore
+func NewRedisRateLimitStore(client *redis.Client) *RedisRateLimitStore {
+	return &RedisRateLimitStore{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// Increment adds one request for key and returns the count in the window ending now
+func (s *RedisRateLimitStore) Increment(key string, window time.Duration) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisRateLimitTimeout)
+	defer cancel()
+
+	now := s.now().UnixMilli()
+	member := fmt.Sprintf("%d-%d", now, rand.Uint64())
+	count, err := slidingWindowScript.Run(ctx, s.client, []string{key}, now, window.Milliseconds(), member).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count requests in redis: %w", err)
+	}
+	return count, nil
+}
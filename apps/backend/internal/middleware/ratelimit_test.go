@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRateLimitedRouter(globalRPS, perUserRPS int, store RateLimitStore) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RateLimiter(globalRPS, perUserRPS, store))
+	router.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/api/v1/dashboard/summary", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func doRequest(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
+
+func TestRateLimiter_GlobalLimitAppliesToUnauthenticatedEndpoints(t *testing.T) {
+	router := newRateLimitedRouter(2, 0, NewInMemoryRateLimitStore())
+
+	assert.Equal(t, http.StatusOK, doRequest(router, "/health").Code)
+	assert.Equal(t, http.StatusOK, doRequest(router, "/health").Code)
+
+	w := doRequest(router, "/health")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+}
+
+func TestRateLimiter_PerUserLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", c.GetHeader("X-Test-User"))
+		c.Next()
+	})
+	router.Use(RateLimiter(100, 2, NewInMemoryRateLimitStore()))
+	router.GET("/api/v1/dashboard/summary", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	request := func(userID string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/dashboard/summary", nil)
+		req.Header.Set("X-Test-User", userID)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, http.StatusOK, request("a"))
+	}
+	assert.Equal(t, http.StatusTooManyRequests, request("a"))
+
+	// Other users keep their own budget
+	assert.Equal(t, http.StatusOK, request("b"))
+}
+
+func TestRateLimiter_LimitsUnauthenticatedCallersByIP(t *testing.T) {
+	router := newRateLimitedRouter(100, 1, NewInMemoryRateLimitStore())
+
+	request := func(path, remoteAddr string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, request("/health?user_id=a", "192.0.2.1:1234"))
+	assert.Equal(t, http.StatusTooManyRequests, request("/health?user_id=b", "192.0.2.1:1234"),
+		"the user_id query parameter does not pick another budget")
+	assert.Equal(t, http.StatusOK, request("/health", "192.0.2.2:1234"), "other addresses keep their own budget")
+}
+
+func TestRateLimiter_PrefersAuthenticatedUserID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", "token-user")
+		c.Next()
+	})
+	router.Use(RateLimiter(0, 1, NewInMemoryRateLimitStore()))
+	router.GET("/x", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	// Different query user IDs do not bypass the limit of the authenticated user
+	assert.Equal(t, http.StatusOK, doRequest(router, "/x?user_id=a").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRequest(router, "/x?user_id=b").Code)
+}
+
+func TestInMemoryRateLimitStore_WindowResets(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewInMemoryRateLimitStore()
+	store.now = func() time.Time { return now }
+
+	count, _ := store.Increment("k", time.Second)
+	assert.Equal(t, int64(1), count)
+	count, _ = store.Increment("k", time.Second)
+	assert.Equal(t, int64(2), count)
+
+	now = now.Add(time.Second)
+	count, _ = store.Increment("k", time.Second)
+	assert.Equal(t, int64(1), count)
+}
+
+// failingRateLimitStore always returns an error
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Increment(string, time.Duration) (int64, error) {
+	return 0, errors.New("store unavailable")
+}
+
+func TestRateLimiter_FailsOpenOnStoreError(t *testing.T) {
+	router := newRateLimitedRouter(1, 1, failingRateLimitStore{})
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, doRequest(router, "/health?user_id=a").Code)
+	}
+}
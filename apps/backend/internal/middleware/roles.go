@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// rolesContextKey is the Gin context key holding the authenticated user's role assignments
+const rolesContextKey = "roles"
+
+// RoleSource loads the role assignments of a user
+type RoleSource interface {
+	RolesForUser(ctx context.Context, userID string) ([]model.RoleAssignment, error)
+}
+
+// roleCacheEntry holds a user's roles until expiresAt
+type roleCacheEntry struct {
+	roles     []model.RoleAssignment
+	expiresAt time.Time
+}
+
+// RoleCache caches role assignments per user so role checks do not hit the database
+// on every request. Entries are dropped after the TTL or when the roles change.
+type RoleCache struct {
+	source  RoleSource
+	ttl     time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]roleCacheEntry
+}
+
+// NewRoleCache creates a RoleCache loading roles from source
+func NewRoleCache(source RoleSource, ttl time.Duration) *RoleCache {
+	return &RoleCache{
+		source:  source,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]roleCacheEntry),
+	}
+}
+
+// Roles returns the role assignments of a user, loading them on a cache miss
+func (rc *RoleCache) Roles(ctx context.Context, userID string) ([]model.RoleAssignment, error) {
+	rc.mu.Lock()
+	entry, ok := rc.entries[userID]
+	rc.mu.Unlock()
+	if ok && rc.now().Before(entry.expiresAt) {
+		return entry.roles, nil
+	}
+
+	roles, err := rc.source.RolesForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rc.mu.Lock()
+	rc.entries[userID] = roleCacheEntry{roles: roles, expiresAt: rc.now().Add(rc.ttl)}
+	rc.mu.Unlock()
+	return roles, nil
+}
+
+// Invalidate drops the cached roles of a user
+func (rc *RoleCache) Invalidate(userID string) {
+	rc.mu.Lock()
+	delete(rc.entries, userID)
+	rc.mu.Unlock()
+}
+
+// LoadRoles stores the authenticated user's role assignments in the Gin context.
+// Users listed in adminUserIDs additionally hold the system-wide system_admin role.
+// Failing to load roles is logged and leaves the user without roles, so role checks
+// deny access rather than the whole request failing.
+func LoadRoles(cache *RoleCache, adminUserIDs []string, logger *zap.Logger) gin.HandlerFunc {
+	admins := adminSet(adminUserIDs)
+
+	return func(c *gin.Context) {
+		userID := GetUserID(c)
+		if userID == "" {
+			c.Next()
+			return
+		}
+
+		var roles []model.RoleAssignment
+		if admins[userID] {
+			roles = append(roles, model.RoleAssignment{UserID: userID, Role: model.RoleSystemAdmin})
+		}
+
+		if cache != nil {
+			loaded, err := cache.Roles(c.Request.Context(), userID)
+			if err != nil {
+				logger.Error("failed to load user roles", zap.Error(err), zap.String("user_id", userID))
+			}
+			roles = append(roles, loaded...)
+		}
+
+		c.Set(rolesContextKey, roles)
+		c.Next()
+	}
+}
+
+// GetRoles returns the role assignments loaded for the authenticated user
+func GetRoles(c *gin.Context) []model.RoleAssignment {
+	roles, _ := c.Get(rolesContextKey)
+	assignments, _ := roles.([]model.RoleAssignment)
+	return assignments
+}
+
+// HasRole reports whether the authenticated user holds one of roles in the organization
+// orgID. System-wide assignments apply to every organization, and system_admin passes
+// every check.
+func HasRole(c *gin.Context, orgID string, roles ...model.Role) bool {
+	for _, assignment := range GetRoles(c) {
+		if assignment.OrganizationID == "" && assignment.Role == model.RoleSystemAdmin {
+			return true
+		}
+		if assignment.OrganizationID != "" && assignment.OrganizationID != orgID {
+			continue
+		}
+		for _, role := range roles {
+			if assignment.Role == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RequireOrgRole restricts a route to users holding one of roles in the organization
+// named by the orgParam path parameter
+func RequireOrgRole(orgParam string, roles ...model.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasRole(c, c.Param(orgParam), roles...) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"code":    "FORBIDDEN",
+				"message": "Insufficient role for this organization",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// adminSet builds a lookup of the configured administrator user IDs
+func adminSet(adminUserIDs []string) map[string]bool {
+	admins := make(map[string]bool, len(adminUserIDs))
+	for _, id := range adminUserIDs {
+		if id = strings.TrimSpace(id); id != "" {
+			admins[id] = true
+		}
+	}
+	return admins
+}
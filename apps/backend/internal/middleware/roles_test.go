@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// fakeRoleSource serves fixed roles and counts lookups
+type fakeRoleSource struct {
+	roles map[string][]model.RoleAssignment
+	err   error
+	calls int
+}
+
+func (f *fakeRoleSource) RolesForUser(ctx context.Context, userID string) ([]model.RoleAssignment, error) {
+	f.calls++
+	return f.roles[userID], f.err
+}
+
+func TestRoleCache_CachesUntilExpiryOrInvalidation(t *testing.T) {
+	source := &fakeRoleSource{roles: map[string][]model.RoleAssignment{
+		"user-1": {{OrganizationID: "org-1", UserID: "user-1", Role: model.RoleClinician}},
+	}}
+	cache := NewRoleCache(source, time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		roles, err := cache.Roles(context.Background(), "user-1")
+		require.NoError(t, err)
+		require.Len(t, roles, 1)
+	}
+	assert.Equal(t, 1, source.calls)
+
+	cache.Invalidate("user-1")
+	_, err := cache.Roles(context.Background(), "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, source.calls)
+
+	now = now.Add(2 * time.Minute)
+	_, err = cache.Roles(context.Background(), "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, source.calls)
+}
+
+func TestRequireOrgRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	source := &fakeRoleSource{roles: map[string][]model.RoleAssignment{
+		"org-admin": {{OrganizationID: "org-1", UserID: "org-admin", Role: model.RoleOrgAdmin}},
+		"clinician": {{OrganizationID: "org-1", UserID: "clinician", Role: model.RoleClinician}},
+		"sys-admin": {{UserID: "sys-admin", Role: model.RoleSystemAdmin}},
+	}}
+
+	newRouter := func(userID string) *gin.Engine {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			if userID != "" {
+				c.Set("user_id", userID)
+			}
+			c.Next()
+		})
+		router.Use(LoadRoles(NewRoleCache(source, time.Minute), []string{"bootstrap-admin"}, zap.NewNop()))
+		router.GET("/orgs/:id/members", RequireOrgRole("id", model.RoleOrgAdmin), func(c *gin.Context) { c.Status(http.StatusOK) })
+		return router
+	}
+
+	tests := []struct {
+		userID string
+		org    string
+		want   int
+	}{
+		{"org-admin", "org-1", http.StatusOK},
+		{"org-admin", "org-2", http.StatusForbidden},
+		{"clinician", "org-1", http.StatusForbidden},
+		{"sys-admin", "org-2", http.StatusOK},
+		{"bootstrap-admin", "org-1", http.StatusOK},
+		{"", "org-1", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		newRouter(tt.userID).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orgs/"+tt.org+"/members", nil))
+		assert.Equal(t, tt.want, w.Code, "user %q in %s", tt.userID, tt.org)
+	}
+}
+
+func TestRequireAdmin_AcceptsSystemAdminRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	source := &fakeRoleSource{roles: map[string][]model.RoleAssignment{
+		"sys-admin": {{UserID: "sys-admin", Role: model.RoleSystemAdmin}},
+		"org-admin": {{OrganizationID: "org-1", UserID: "org-admin", Role: model.RoleOrgAdmin}},
+	}}
+
+	for userID, want := range map[string]int{
+		"sys-admin": http.StatusOK,
+		"org-admin": http.StatusForbidden,
+	} {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("user_id", userID)
+			c.Next()
+		})
+		router.Use(LoadRoles(NewRoleCache(source, time.Minute), nil, zap.NewNop()))
+		router.GET("/admin", RequireAdmin(nil), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin", nil))
+		assert.Equal(t, want, w.Code, "user %q", userID)
+	}
+}
+
+func TestLoadRoles_SourceFailureDeniesRoleChecks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	source := &fakeRoleSource{err: errors.New("database unavailable")}
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", "user-1")
+		c.Next()
+	})
+	router.Use(LoadRoles(NewRoleCache(source, time.Minute), nil, zap.NewNop()))
+	router.GET("/open", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/orgs/:id/members", RequireOrgRole("id", model.RoleOrgAdmin), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/open", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orgs/org-1/members", nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
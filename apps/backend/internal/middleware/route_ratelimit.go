@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RouteRateLimit is a token bucket limit for the requests of one client to a group of routes
+type RouteRateLimit struct {
+	Name      string // bucket namespace; routes sharing a name share a budget
+	Method    string // HTTP method, empty matches every method
+	Path      string // route path; a trailing "*" matches every route with that prefix
+	PerMinute int    // sustained requests per minute per client, 0 disables the limit
+	Burst     int    // bucket capacity, 0 uses PerMinute
+}
+
+// matches reports whether the limit applies to a request for the given route
+func (l RouteRateLimit) matches(method, route string) bool {
+	if l.Method != "" && l.Method != method {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(l.Path, "*"); ok {
+		return strings.HasPrefix(route, prefix)
+	}
+	return route == l.Path
+}
+
+// TokenBucketStore keeps the token buckets of RateLimitMiddleware. Implementations may
+// be backed by Redis for multi-instance deployments or by memory.
+type TokenBucketStore interface {
+	// Take removes a token from the bucket of key under limit. When the bucket is empty it
+	// returns false and the wait until the next token is available.
+	Take(key string, limit RouteRateLimit) (ok bool, wait time.Duration, err error)
+}
+
+// capacity returns the number of tokens in a full bucket
+func (l RouteRateLimit) capacity() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return float64(l.PerMinute)
+}
+
+// tokenBucket holds the tokens left for one client of one route limit
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// InMemoryTokenBucketStore is a TokenBucketStore for single-instance deployments and tests
+type InMemoryTokenBucketStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewInMemoryTokenBucketStore creates a new InMemoryTokenBucketStore
+func NewInMemoryTokenBucketStore() *InMemoryTokenBucketStore {
+	return &InMemoryTokenBucketStore{
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// Take removes a token from the bucket of key under limit
+func (s *InMemoryTokenBucketStore) Take(key string, limit RouteRateLimit) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	ratePerSecond := float64(limit.PerMinute) / 60
+	capacity := limit.capacity()
+
+	// Drop buckets idle long enough to be full again
+	if now.Sub(s.lastSweep) > time.Minute {
+		for k, bucket := range s.buckets {
+			if now.Sub(bucket.updated) > 10*time.Minute {
+				delete(s.buckets, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: capacity, updated: now}
+		s.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(capacity, bucket.tokens+now.Sub(bucket.updated).Seconds()*ratePerSecond)
+	bucket.updated = now
+
+	if bucket.tokens < 1 {
+		wait := time.Duration((1 - bucket.tokens) / ratePerSecond * float64(time.Second))
+		return false, wait, nil
+	}
+	bucket.tokens--
+	return true, 0, nil
+}
+
+// RateLimitMiddleware applies per-route token bucket limits to each client. Clients are
+// keyed by the authenticated user, falling back to the client IP. The first matching
+// limit applies; requests to other routes pass unchanged. Store errors fail open.
+func RateLimitMiddleware(store TokenBucketStore, limits ...RouteRateLimit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			c.Next()
+			return
+		}
+
+		for _, limit := range limits {
+			if !limit.matches(c.Request.Method, route) {
+				continue
+			}
+			if limit.PerMinute <= 0 {
+				break
+			}
+
+			client := "ip:" + c.ClientIP()
+			if userID := GetUserID(c); userID != "" {
+				client = "user:" + userID
+			}
+
+			ok, wait, err := store.Take("ratelimit:route:"+limit.Name+":"+client, limit)
+			if err == nil && !ok {
+				c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+					"code":    "RATE_LIMITED",
+					"message": "Too many requests for this endpoint",
+				})
+				return
+			}
+			break
+		}
+
+		c.Next()
+	}
+}
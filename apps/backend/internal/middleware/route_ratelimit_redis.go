@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucketScript takes a token from the bucket hash KEYS[1] holding ARGV[1] tokens
+// when full and refilled at ARGV[2] tokens per millisecond, at ARGV[3] milliseconds.
+// It returns 1 and 0 when a token was taken, otherwise 0 and the milliseconds until the
+// next token. Buckets expire once they would be full again.
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "updated")
+local tokens = tonumber(bucket[1]) or capacity
+local updated = tonumber(bucket[2]) or now
+tokens = math.min(capacity, tokens + math.max(0, now - updated) * rate)
+local allowed, wait = 0, 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) / rate)
+end
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(capacity / rate))
+return {allowed, wait}
+`)
+
+// RedisTokenBucketStore is a TokenBucketStore shared by all instances through Redis, so a
+// client's budget for a route does not grow with the number of instances
+type RedisTokenBucketStore struct {
+	client *redis.Client
+	now    func() time.Time
+}
+
+// NewRedisTokenBucketStore creates a new RedisTokenBucketStore
+func NewRedisTokenBucketStore(client *redis.Client) *RedisTokenBucketStore {
+	return &RedisTokenBucketStore{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// Take removes a token from the bucket of key under limit
+func (s *RedisTokenBucketStore) Take(key string, limit RouteRateLimit) (bool, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisRateLimitTimeout)
+	defer cancel()
+
+	ratePerMS := float64(limit.PerMinute) / float64(time.Minute.Milliseconds())
+	result, err := tokenBucketScript.Run(ctx, s.client, []string{key},
+		limit.capacity(), ratePerMS, s.now().UnixMilli()).Int64Slice()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to take token in redis: %w", err)
+	}
+	if len(result) != 2 {
+		return false, 0, fmt.Errorf("unexpected token bucket result: %v", result)
+	}
+	return result[0] == 1, time.Duration(result[1]) * time.Millisecond, nil
+}
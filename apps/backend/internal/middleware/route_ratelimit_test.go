@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRouteLimitedRouter builds a router that authenticates the X-User header as user_id
+func newRouteLimitedRouter(now func() time.Time, limits ...RouteRateLimit) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if user := c.GetHeader("X-User"); user != "" {
+			c.Set("user_id", user)
+		}
+		c.Next()
+	})
+	store := NewInMemoryTokenBucketStore()
+	store.now = now
+	router.Use(RateLimitMiddleware(store, limits...))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.POST("/api/v1/checkin/audio-stream", ok)
+	router.POST("/api/v1/checkin/respond", ok)
+	router.POST("/api/v1/reports/generate", ok)
+	router.GET("/api/v1/checkin/status/:sessionId", ok)
+	return router
+}
+
+func doUserRequest(router *gin.Engine, method, path, user string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if user != "" {
+		req.Header.Set("X-User", user)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func fixedClock() (func() time.Time, func(time.Duration)) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	return func() time.Time { return now }, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestRateLimitMiddleware_ThrottlesAfterBurst(t *testing.T) {
+	clock, _ := fixedClock()
+	router := newRouteLimitedRouter(clock, RouteRateLimit{
+		Name: "audio-stream", Method: http.MethodPost, Path: "/api/v1/checkin/audio-stream", PerMinute: 3,
+	})
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, doUserRequest(router, http.MethodPost, "/api/v1/checkin/audio-stream", "a").Code)
+	}
+
+	w := doUserRequest(router, http.MethodPost, "/api/v1/checkin/audio-stream", "a")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "20", w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), "RATE_LIMITED")
+
+	// Other users keep their own budget
+	assert.Equal(t, http.StatusOK, doUserRequest(router, http.MethodPost, "/api/v1/checkin/audio-stream", "b").Code)
+}
+
+func TestRateLimitMiddleware_RefillsOverTime(t *testing.T) {
+	clock, advance := fixedClock()
+	router := newRouteLimitedRouter(clock, RouteRateLimit{
+		Name: "report", Method: http.MethodPost, Path: "/api/v1/reports/generate", PerMinute: 6, Burst: 1,
+	})
+
+	assert.Equal(t, http.StatusOK, doUserRequest(router, http.MethodPost, "/api/v1/reports/generate", "a").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doUserRequest(router, http.MethodPost, "/api/v1/reports/generate", "a").Code)
+
+	advance(10 * time.Second)
+	assert.Equal(t, http.StatusOK, doUserRequest(router, http.MethodPost, "/api/v1/reports/generate", "a").Code)
+}
+
+func TestRateLimitMiddleware_FallsBackToClientIP(t *testing.T) {
+	clock, _ := fixedClock()
+	router := newRouteLimitedRouter(clock, RouteRateLimit{
+		Name: "checkin", Method: http.MethodPost, Path: "/api/v1/checkin/*", PerMinute: 1,
+	})
+
+	assert.Equal(t, http.StatusOK, doUserRequest(router, http.MethodPost, "/api/v1/checkin/respond", "").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doUserRequest(router, http.MethodPost, "/api/v1/checkin/respond", "").Code)
+
+	// An authenticated user on the same IP is keyed separately
+	assert.Equal(t, http.StatusOK, doUserRequest(router, http.MethodPost, "/api/v1/checkin/respond", "a").Code)
+}
+
+func TestRateLimitMiddleware_FirstMatchingLimitApplies(t *testing.T) {
+	clock, _ := fixedClock()
+	router := newRouteLimitedRouter(clock,
+		RouteRateLimit{Name: "audio-stream", Method: http.MethodPost, Path: "/api/v1/checkin/audio-stream", PerMinute: 1},
+		RouteRateLimit{Name: "checkin", Method: http.MethodPost, Path: "/api/v1/checkin/*", PerMinute: 2},
+	)
+
+	assert.Equal(t, http.StatusOK, doUserRequest(router, http.MethodPost, "/api/v1/checkin/audio-stream", "a").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doUserRequest(router, http.MethodPost, "/api/v1/checkin/audio-stream", "a").Code)
+
+	// The audio limit does not consume the check-in budget
+	assert.Equal(t, http.StatusOK, doUserRequest(router, http.MethodPost, "/api/v1/checkin/respond", "a").Code)
+	assert.Equal(t, http.StatusOK, doUserRequest(router, http.MethodPost, "/api/v1/checkin/respond", "a").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doUserRequest(router, http.MethodPost, "/api/v1/checkin/respond", "a").Code)
+}
+
+func TestRateLimitMiddleware_UnmatchedAndDisabledRoutesPass(t *testing.T) {
+	clock, _ := fixedClock()
+	router := newRouteLimitedRouter(clock,
+		RouteRateLimit{Name: "checkin", Method: http.MethodPost, Path: "/api/v1/checkin/*", PerMinute: 1},
+		RouteRateLimit{Name: "report", Method: http.MethodPost, Path: "/api/v1/reports/generate", PerMinute: 0},
+	)
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, doUserRequest(router, http.MethodGet, "/api/v1/checkin/status/s1", "a").Code)
+		assert.Equal(t, http.StatusOK, doUserRequest(router, http.MethodPost, "/api/v1/reports/generate", "a").Code)
+	}
+}
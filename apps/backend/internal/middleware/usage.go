@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// UsageWarningHeader is set on write responses when a user exceeds a soft usage limit
+const UsageWarningHeader = "X-Usage-Warning"
+
+// UsageLimitChecker reports the usage kinds for which a user exceeds the soft limit
+type UsageLimitChecker interface {
+	ExceededLimits(ctx context.Context, userID string) ([]string, error)
+}
+
+// UsageWarning adds a warning header to write requests of users over a soft usage
+// limit. Requests are never blocked. The user is taken from the "user_id" context
+// value set by authentication, falling back to the user_id query parameter.
+func UsageWarning(checker UsageLimitChecker, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+		default:
+			c.Next()
+			return
+		}
+
+		userID := GetUserID(c)
+		if userID == "" {
+			userID = c.Query("user_id")
+		}
+
+		if userID != "" {
+			exceeded, err := checker.ExceededLimits(c.Request.Context(), userID)
+			if err != nil {
+				logger.Debug("usage limit check failed", zap.Error(err), zap.String("user_id", userID))
+			} else if len(exceeded) > 0 {
+				c.Header(UsageWarningHeader, "soft usage limit exceeded: "+strings.Join(exceeded, ", "))
+			}
+		}
+
+		c.Next()
+	}
+}
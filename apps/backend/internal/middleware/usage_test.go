@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type fakeUsageChecker map[string][]string
+
+func (f fakeUsageChecker) ExceededLimits(ctx context.Context, userID string) ([]string, error) {
+	return f[userID], nil
+}
+
+func TestUsageWarning(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(UsageWarning(fakeUsageChecker{"heavy": {"audio_bytes", "report_bytes"}}, zap.NewNop()))
+	router.Any("/api/v1/x", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		name    string
+		method  string
+		userID  string
+		warning string
+	}{
+		{"write over limit", http.MethodPost, "heavy", "soft usage limit exceeded: audio_bytes, report_bytes"},
+		{"write under limit", http.MethodPost, "light", ""},
+		{"read over limit", http.MethodGet, "heavy", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, "/api/v1/x?user_id="+tt.userID, nil))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.warning, w.Header().Get(UsageWarningHeader))
+		})
+	}
+}
+
+func TestRequireAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(userID string) *gin.Engine {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			if userID != "" {
+				c.Set("user_id", userID)
+			}
+			c.Next()
+		})
+		router.GET("/admin", RequireAdmin([]string{"admin-1"}), func(c *gin.Context) { c.Status(http.StatusOK) })
+		return router
+	}
+
+	for userID, want := range map[string]int{
+		"admin-1": http.StatusOK,
+		"user-1":  http.StatusForbidden,
+		"":        http.StatusForbidden,
+	} {
+		w := httptest.NewRecorder()
+		newRouter(userID).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin", nil))
+		assert.Equal(t, want, w.Code, "user %q", userID)
+	}
+}
@@ -0,0 +1,96 @@
+package pdf
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// Hypertension boundaries drawn across the blood pressure chart, in mmHg
+const (
+	systolicThreshold  = 130
+	diastolicThreshold = 80
+)
+
+// bpChartImageName registers the chart image with the document
+const bpChartImageName = "blood-pressure-chart"
+
+var (
+	systolicColor  = color.RGBA{R: 200, G: 40, B: 40, A: 255}
+	diastolicColor = color.RGBA{R: 40, G: 80, B: 200, A: 255}
+)
+
+// generateBPChart renders systolic and diastolic pressure over time as a PNG line
+// chart with the hypertension thresholds marked, labelled in lang. readings may be in
+// any order.
+func generateBPChart(lang Language, readings []model.BloodPressureReading) ([]byte, error) {
+	if len(readings) < 2 {
+		return nil, errNotEnoughChartData
+	}
+
+	sorted := make([]model.BloodPressureReading, len(readings))
+	copy(sorted, readings)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].MeasuredAt.Before(sorted[j].MeasuredAt)
+	})
+
+	systolic := make(plotter.XYs, len(sorted))
+	diastolic := make(plotter.XYs, len(sorted))
+	minValue, maxValue := float64(diastolicThreshold), float64(systolicThreshold)
+	for i, reading := range sorted {
+		x := float64(reading.MeasuredAt.Unix())
+		systolic[i] = plotter.XY{X: x, Y: float64(reading.Systolic)}
+		diastolic[i] = plotter.XY{X: x, Y: float64(reading.Diastolic)}
+		minValue = min(minValue, float64(reading.Diastolic))
+		maxValue = max(maxValue, float64(reading.Systolic))
+	}
+
+	p := plot.New()
+	p.Y.Label.Text = "mmHg"
+	p.X.Tick.Marker = plot.TimeTicks{Format: lang.layout().date}
+	// Thresholds are functions, which do not widen the axes, so keep them in view
+	p.Y.Min = minValue - 10
+	p.Y.Max = maxValue + 10
+	p.Add(plotter.NewGrid())
+
+	for _, series := range []struct {
+		name  string
+		data  plotter.XYs
+		color color.Color
+	}{
+		{"Systolic", systolic, systolicColor},
+		{"Diastolic", diastolic, diastolicColor},
+	} {
+		line, points, err := plotter.NewLinePoints(series.data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to plot %s pressure: %w", series.name, err)
+		}
+		line.Color = series.color
+		line.Width = vg.Points(1.5)
+		points.Color = series.color
+		p.Add(line, points)
+		p.Legend.Add(lang.text(series.name), line, points)
+	}
+
+	for _, threshold := range []struct {
+		value float64
+		color color.Color
+	}{
+		{systolicThreshold, systolicColor},
+		{diastolicThreshold, diastolicColor},
+	} {
+		value := threshold.value
+		line := plotter.NewFunction(func(float64) float64 { return value })
+		line.Color = threshold.color
+		line.Dashes = []vg.Length{vg.Points(4), vg.Points(3)}
+		p.Add(line)
+	}
+	p.Legend.Top = true
+
+	return renderChart(p)
+}
@@ -0,0 +1,39 @@
+package pdf
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+)
+
+func TestGenerateBPChart_RendersPNG(t *testing.T) {
+	now := time.Now()
+	readings := []model.BloodPressureReading{
+		{Systolic: 142, Diastolic: 91, Pulse: 75, MeasuredAt: now},
+		{Systolic: 118, Diastolic: 76, Pulse: 70, MeasuredAt: now.Add(-48 * time.Hour)},
+		{Systolic: 125, Diastolic: 82, Pulse: 68, MeasuredAt: now.Add(-24 * time.Hour)},
+	}
+
+	chart, err := generateBPChart(LanguageEnglish, readings)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(chart))
+	require.NoError(t, err)
+	assert.Greater(t, img.Bounds().Dx(), img.Bounds().Dy(), "the chart is landscape")
+	assert.True(t, readings[0].MeasuredAt.Equal(now), "the caller's readings are not reordered")
+}
+
+func TestGenerateBPChart_NeedsTwoReadings(t *testing.T) {
+	_, err := generateBPChart(LanguageEnglish, nil)
+	assert.ErrorIs(t, err, errNotEnoughChartData)
+
+	_, err = generateBPChart(LanguageEnglish, []model.BloodPressureReading{
+		{Systolic: 120, Diastolic: 80, MeasuredAt: time.Now()},
+	})
+	assert.ErrorIs(t, err, errNotEnoughChartData)
+}
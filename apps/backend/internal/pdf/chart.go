@@ -0,0 +1,46 @@
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/jung-kurt/gofpdf"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+)
+
+// chartWidth and chartHeight are the rendered chart size; the PDF scales it to the page width
+const (
+	chartWidth  = 17 * vg.Centimeter
+	chartHeight = 8 * vg.Centimeter
+)
+
+// errNotEnoughChartData is returned when there are too few data points to draw a trend line
+var errNotEnoughChartData = errors.New("at least two data points are needed for a chart")
+
+// renderChart encodes a plot as a PNG image
+func renderChart(p *plot.Plot) ([]byte, error) {
+	writer, err := p.WriterTo(chartWidth, chartHeight, "png")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render chart: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := writer.WriteTo(&buf); err != nil {
+		return nil, fmt.Errorf("failed to encode chart: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// embedChart places a PNG chart registered as name across the page width, moving the
+// cursor below it
+func embedChart(pdf *gofpdf.Fpdf, name string, chart []byte) {
+	options := gofpdf.ImageOptions{ImageType: "PNG", ReadDpi: true}
+	pdf.RegisterImageOptionsReader(name, options, bytes.NewReader(chart))
+	left, _, right, _ := pdf.GetMargins()
+	pageWidth, _ := pdf.GetPageSize()
+	pdf.ImageOptions(name, -1, 0, pageWidth-left-right, 0, true, options, 0, "")
+	pdf.Ln(3)
+}
@@ -0,0 +1,74 @@
+package pdf
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// VerificationCodeLength is the number of hex digits of the verification code printed in
+// the report footer
+const VerificationCodeLength = 12
+
+// Fingerprint identifies a generated report. SHA256 is the hash of the final PDF bytes;
+// VerificationCode is the truncated hash of the report rendered without its footer code,
+// since a PDF cannot contain the hash of its own bytes.
+type Fingerprint struct {
+	SHA256           string
+	VerificationCode string
+}
+
+// GenerateWithFingerprint renders the report twice: once to derive the verification code
+// from its content, and once more with the code printed in the footer of every page. The
+// draft is never password protected, since encryption would make the code random.
+func (g *PDFGenerator) GenerateWithFingerprint(data *ReportData) ([]byte, *Fingerprint, error) {
+	draftData := *data
+	draftData.VerificationCode = ""
+	draftData.Password = ""
+	draft, err := g.Generate(&draftData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	finalData := *data
+	finalData.VerificationCode = SHA256Hex(draft)[:VerificationCodeLength]
+	pdfBytes, err := g.Generate(&finalData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pdfBytes, &Fingerprint{
+		SHA256:           SHA256Hex(pdfBytes),
+		VerificationCode: finalData.VerificationCode,
+	}, nil
+}
+
+// SHA256Hex returns the lowercase hex SHA-256 of b
+func SHA256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// FormatVerificationCode formats a verification code for print in groups of four,
+// e.g. 3F2A-91C0-7B44
+func FormatVerificationCode(code string) string {
+	code = strings.ToUpper(code)
+	groups := make([]string, 0, len(code)/4+1)
+	for len(code) > 4 {
+		groups = append(groups, code[:4])
+		code = code[4:]
+	}
+	groups = append(groups, code)
+	return strings.Join(groups, "-")
+}
+
+// ParseVerificationInput normalizes a printed verification code or a full SHA-256 hash
+// as typed by a user. fullHash reports whether the input is a full hash.
+func ParseVerificationInput(input string) (value string, fullHash bool, err error) {
+	value = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(input)))
+	if _, err := hex.DecodeString(value); err != nil || (len(value) != VerificationCodeLength && len(value) != sha256.Size*2) {
+		return "", false, fmt.Errorf("expected a %d-digit verification code or a SHA-256 hash", VerificationCodeLength)
+	}
+	return value, len(value) == sha256.Size*2, nil
+}
@@ -0,0 +1,124 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// textShowPattern matches the string operand of a Tj text-showing operator
+var textShowPattern = regexp.MustCompile(`\(((?:[^\\)]|\\.)*)\)\s*Tj`)
+
+// pageText inflates the content streams of a PDF and returns the printed text, one
+// line per text-showing operator. Reports use an embedded UTF-8 font, whose strings
+// are written as UTF-16BE.
+func pageText(t *testing.T, pdfBytes []byte) string {
+	t.Helper()
+	var text strings.Builder
+	streams := regexp.MustCompile(`(?s)stream\r?\n(.*?)\r?\nendstream`).FindAllSubmatch(pdfBytes, -1)
+	for _, stream := range streams {
+		r, err := zlib.NewReader(bytes.NewReader(stream[1]))
+		if err != nil {
+			continue
+		}
+		content, _ := io.ReadAll(r)
+		for _, operand := range textShowPattern.FindAllSubmatch(content, -1) {
+			text.WriteString(decodeUTF16BE(unescapePDFString(operand[1])))
+			text.WriteByte('\n')
+		}
+	}
+	return text.String()
+}
+
+// unescapePDFString resolves the backslash escapes of a PDF literal string
+func unescapePDFString(s []byte) []byte {
+	replacements := map[byte]byte{'n': '\n', 'r': '\r', 't': '\t', 'b': '\b', 'f': '\f'}
+	out := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			out = append(out, s[i])
+			continue
+		}
+		i++
+		if replacement, ok := replacements[s[i]]; ok {
+			out = append(out, replacement)
+		} else {
+			out = append(out, s[i])
+		}
+	}
+	return out
+}
+
+// decodeUTF16BE decodes big-endian UTF-16 bytes
+func decodeUTF16BE(b []byte) string {
+	units := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
+
+func TestGenerateWithFingerprint(t *testing.T) {
+	generator := NewPDFGenerator(zap.NewNop())
+	data := &ReportData{UserName: "Test User", DateRange: "2026-01-01 to 2026-01-31", ReportID: "report-1"}
+
+	pdfBytes, fingerprint, err := generator.GenerateWithFingerprint(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, SHA256Hex(pdfBytes), fingerprint.SHA256)
+	assert.Len(t, fingerprint.VerificationCode, VerificationCodeLength)
+	assert.Contains(t, pageText(t, pdfBytes), "Verification code: "+FormatVerificationCode(fingerprint.VerificationCode))
+	assert.Empty(t, data.VerificationCode, "caller's data must not be modified")
+
+	// A regenerated report has another ID and therefore another fingerprint
+	data.ReportID = "report-2"
+	_, regenerated, err := generator.GenerateWithFingerprint(data)
+	require.NoError(t, err)
+	assert.NotEqual(t, fingerprint.VerificationCode, regenerated.VerificationCode)
+	assert.NotEqual(t, fingerprint.SHA256, regenerated.SHA256)
+}
+
+func TestFormatVerificationCode(t *testing.T) {
+	assert.Equal(t, "3F2A-91C0-7B44", FormatVerificationCode("3f2a91c07b44"))
+	assert.Equal(t, "ABCD-E", FormatVerificationCode("abcde"))
+}
+
+func TestParseVerificationInput(t *testing.T) {
+	hash := SHA256Hex([]byte("report"))
+
+	tests := []struct {
+		name     string
+		input    string
+		want     string
+		fullHash bool
+		wantErr  bool
+	}{
+		{"printed code", "3F2A-91C0-7B44", "3f2a91c07b44", false, false},
+		{"code with spaces", " 3f2a 91c0 7b44 ", "3f2a91c07b44", false, false},
+		{"full hash", strings.ToUpper(hash), hash, true, false},
+		{"empty", "", "", false, true},
+		{"too short", "3F2A-91C0", "", false, true},
+		{"not hex", "3F2A-91C0-7B4Z", "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, fullHash, err := ParseVerificationInput(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.fullHash, fullHash)
+		})
+	}
+}
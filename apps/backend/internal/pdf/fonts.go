@@ -0,0 +1,20 @@
+package pdf
+
+import (
+	"codeberg.org/go-fonts/liberation/liberationsansbold"
+	"codeberg.org/go-fonts/liberation/liberationsansitalic"
+	"codeberg.org/go-fonts/liberation/liberationsansregular"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// fontFamily is the embedded UTF-8 font reports are printed in. The core PDF fonts only
+// cover Latin-1, which lacks Hungarian letters such as ő and ű; Liberation Sans covers
+// Latin Extended and is metric-compatible with Arial.
+const fontFamily = "LiberationSans"
+
+// addFonts embeds the report font in its regular, bold and italic styles
+func addFonts(pdf *gofpdf.Fpdf) {
+	pdf.AddUTF8FontFromBytes(fontFamily, "", liberationsansregular.TTF)
+	pdf.AddUTF8FontFromBytes(fontFamily, "B", liberationsansbold.TTF)
+	pdf.AddUTF8FontFromBytes(fontFamily, "I", liberationsansitalic.TTF)
+}
@@ -2,7 +2,12 @@ package pdf
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/jung-kurt/gofpdf"
@@ -10,6 +15,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// footerMargin is the height at the bottom of every page kept free for the footer
+const footerMargin = 25
+
 // PDFGenerator generates professional medical reports
 type PDFGenerator struct {
 	logger *zap.Logger
@@ -28,9 +36,24 @@ type ReportData struct {
 	DateRange          string
 	CheckIns           []model.HealthCheckIn
 	Medications        []model.Medication
-	BloodPressure      []model.BloodPressureReading
+	BloodPressure      []model.BloodPressureReading // newest first, as the repository returns them
 	MenstruationCycles []model.MenstruationCycle
+	CycleStats         *model.CycleStats
 	FitnessData        []model.FitnessDataPoint
+	Language           Language              // English when empty
+	Sections           []model.ReportSection // sections to print, all when empty
+
+	ReportID         string // printed in the footer so regenerated reports differ
+	VerificationCode string // printed in the footer when set, see GenerateWithFingerprint
+
+	// Password protects the PDF when set: readers ask for it before showing the report,
+	// which may then be printed and copied from but not modified
+	Password string
+}
+
+// Includes reports whether the report prints section
+func (d *ReportData) Includes(section model.ReportSection) bool {
+	return len(d.Sections) == 0 || slices.Contains(d.Sections, section)
 }
 
 // Generate creates a PDF report from the provided data
@@ -40,26 +63,45 @@ func (g *PDFGenerator) Generate(data *ReportData) ([]byte, error) {
 		zap.String("date_range", data.DateRange),
 	)
 
+	lang := data.Language
+
 	// Create new PDF
 	pdf := gofpdf.New("P", "mm", "A4", "")
 	pdf.SetMargins(20, 20, 20)
-	pdf.SetAutoPageBreak(true, 20)
+	pdf.SetAutoPageBreak(true, footerMargin)
+	addFonts(pdf)
+	g.setFooter(pdf, data)
+	if data.Password != "" {
+		// An empty owner password is replaced with a random one, so nobody can lift the restrictions
+		pdf.SetProtection(gofpdf.CnProtectPrint|gofpdf.CnProtectCopy, data.Password, "")
+	}
 
 	// Add page
 	pdf.AddPage()
 
 	// Add title
-	g.addTitle(pdf, "Health Report", data.UserName, data.DateRange)
-
-	// Add all sections
-	g.addSymptomsTimeline(pdf, data.CheckIns)
-	g.addMedicationList(pdf, data.Medications)
-	g.addMedicationAdherence(pdf, data.CheckIns)
-	g.addBloodPressureTrends(pdf, data.BloodPressure)
-	g.addMenstruationCycles(pdf, data.MenstruationCycles)
-	g.addPhysicalActivities(pdf, data.CheckIns)
-	g.addMealPatterns(pdf, data.CheckIns)
-	g.addDailyCheckInSummaries(pdf, data.CheckIns)
+	g.addTitle(pdf, lang, "Health Report", data.UserName, data.DateRange)
+
+	// Add the requested sections; the pain trend chart closes the symptoms section
+	sections := []struct {
+		section model.ReportSection
+		add     func()
+	}{
+		{model.ReportSectionSymptoms, func() { g.addSymptomsTimeline(pdf, lang, data.CheckIns) }},
+		{model.ReportSectionMedications, func() { g.addMedicationList(pdf, lang, data.Medications) }},
+		{model.ReportSectionAdherence, func() { g.addMedicationAdherence(pdf, lang, data.CheckIns) }},
+		{model.ReportSectionBloodPressure, func() { g.addBloodPressureTrends(pdf, lang, data.BloodPressure) }},
+		{model.ReportSectionMenstruation, func() { g.addMenstruationCycles(pdf, lang, data.MenstruationCycles, data.CycleStats) }},
+		{model.ReportSectionActivity, func() { g.addPhysicalActivities(pdf, lang, data.CheckIns, data.FitnessData) }},
+		{model.ReportSectionMeals, func() { g.addMealPatterns(pdf, lang, data.CheckIns) }},
+		{model.ReportSectionSummaries, func() { g.addDailyCheckInSummaries(pdf, lang, data.CheckIns) }},
+		{model.ReportSectionSymptoms, func() { g.addPainTrendChart(pdf, lang, data.CheckIns) }},
+	}
+	for _, s := range sections {
+		if data.Includes(s.section) {
+			s.add()
+		}
+	}
 
 	// Generate PDF bytes
 	var buf bytes.Buffer
@@ -77,43 +119,66 @@ func (g *PDFGenerator) Generate(data *ReportData) ([]byte, error) {
 }
 
 // addTitle adds the report title and header information
-func (g *PDFGenerator) addTitle(pdf *gofpdf.Fpdf, title, userName, dateRange string) {
-	pdf.SetFont("Arial", "B", 20)
-	pdf.CellFormat(0, 10, title, "", 1, "C", false, 0, "")
+func (g *PDFGenerator) addTitle(pdf *gofpdf.Fpdf, lang Language, title, userName, dateRange string) {
+	pdf.SetFont(fontFamily, "B", 20)
+	pdf.CellFormat(0, 10, lang.text(title), "", 1, "C", false, 0, "")
 	pdf.Ln(5)
 
-	pdf.SetFont("Arial", "", 12)
-	pdf.CellFormat(0, 8, fmt.Sprintf("Patient: %s", userName), "", 1, "L", false, 0, "")
-	pdf.CellFormat(0, 8, fmt.Sprintf("Period: %s", dateRange), "", 1, "L", false, 0, "")
-	pdf.CellFormat(0, 8, fmt.Sprintf("Generated: %s", time.Now().Format("2006-01-02 15:04")), "", 1, "L", false, 0, "")
+	pdf.SetFont(fontFamily, "", 12)
+	pdf.CellFormat(0, 8, fmt.Sprintf(lang.text("Patient: %s"), userName), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 8, fmt.Sprintf(lang.text("Period: %s"), dateRange), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 8, fmt.Sprintf(lang.text("Generated: %s"), lang.dateTime(time.Now())), "", 1, "L", false, 0, "")
 	pdf.Ln(10)
 }
 
+// setFooter prints a confidentiality notice, the report ID, verification code and
+// "Page X of Y" at the bottom of every page
+func (g *PDFGenerator) setFooter(pdf *gofpdf.Fpdf, data *ReportData) {
+	lang := data.Language
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-20)
+		pdf.SetFont(fontFamily, "I", 8)
+		pdf.SetTextColor(128, 128, 128)
+		pdf.CellFormat(0, 5, lang.text("Confidential: contains personal health information"), "", 1, "C", false, 0, "")
+
+		footer := fmt.Sprintf(lang.text("Page %d of %s"), pdf.PageNo(), "{nb}")
+		if data.ReportID != "" {
+			footer = fmt.Sprintf(lang.text("Report %s - %s"), data.ReportID, footer)
+		}
+		if data.VerificationCode != "" {
+			footer = fmt.Sprintf(lang.text("Verification code: %s - %s"), FormatVerificationCode(data.VerificationCode), footer)
+		}
+		pdf.CellFormat(0, 5, footer, "", 0, "C", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	})
+}
+
 // addSectionHeader adds a section header
-func (g *PDFGenerator) addSectionHeader(pdf *gofpdf.Fpdf, title string) {
-	pdf.SetFont("Arial", "B", 14)
+func (g *PDFGenerator) addSectionHeader(pdf *gofpdf.Fpdf, lang Language, title string) {
+	pdf.SetFont(fontFamily, "B", 14)
 	pdf.SetFillColor(230, 230, 230)
-	pdf.CellFormat(0, 10, title, "", 1, "L", true, 0, "")
+	pdf.CellFormat(0, 10, lang.text(title), "", 1, "L", true, 0, "")
 	pdf.Ln(3)
-	pdf.SetFont("Arial", "", 10)
+	pdf.SetFont(fontFamily, "", 10)
 }
 
 // addSymptomsTimeline adds symptoms timeline section
-func (g *PDFGenerator) addSymptomsTimeline(pdf *gofpdf.Fpdf, checkIns []model.HealthCheckIn) {
-	g.addSectionHeader(pdf, "Symptoms Timeline")
+func (g *PDFGenerator) addSymptomsTimeline(pdf *gofpdf.Fpdf, lang Language, checkIns []model.HealthCheckIn) {
+	g.addSectionHeader(pdf, lang, "Symptoms Timeline")
 
 	if len(checkIns) == 0 {
-		pdf.CellFormat(0, 8, "No symptoms recorded during this period.", "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 8, lang.text("No symptoms recorded during this period."), "", 1, "L", false, 0, "")
 		pdf.Ln(5)
 		return
 	}
 
 	for _, checkIn := range checkIns {
 		if len(checkIn.Symptoms) > 0 {
-			dateStr := checkIn.CheckInDate.Format("2006-01-02")
-			pdf.SetFont("Arial", "B", 10)
+			dateStr := lang.date(checkIn.CheckInDate)
+			pdf.SetFont(fontFamily, "B", 10)
 			pdf.CellFormat(0, 6, dateStr, "", 1, "L", false, 0, "")
-			pdf.SetFont("Arial", "", 10)
+			pdf.SetFont(fontFamily, "", 10)
 
 			for _, symptom := range checkIn.Symptoms {
 				pdf.CellFormat(0, 5, fmt.Sprintf("  - %s", symptom), "", 1, "L", false, 0, "")
@@ -125,27 +190,27 @@ func (g *PDFGenerator) addSymptomsTimeline(pdf *gofpdf.Fpdf, checkIns []model.He
 }
 
 // addMedicationList adds medication list section
-func (g *PDFGenerator) addMedicationList(pdf *gofpdf.Fpdf, medications []model.Medication) {
-	g.addSectionHeader(pdf, "Medication List")
+func (g *PDFGenerator) addMedicationList(pdf *gofpdf.Fpdf, lang Language, medications []model.Medication) {
+	g.addSectionHeader(pdf, lang, "Medication List")
 
 	if len(medications) == 0 {
-		pdf.CellFormat(0, 8, "No medications recorded.", "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 8, lang.text("No medications recorded."), "", 1, "L", false, 0, "")
 		pdf.Ln(5)
 		return
 	}
 
 	for _, med := range medications {
-		pdf.SetFont("Arial", "B", 10)
+		pdf.SetFont(fontFamily, "B", 10)
 		pdf.CellFormat(0, 6, med.Name, "", 1, "L", false, 0, "")
-		pdf.SetFont("Arial", "", 10)
-		pdf.CellFormat(0, 5, fmt.Sprintf("  Dosage: %s", med.Dosage), "", 1, "L", false, 0, "")
-		pdf.CellFormat(0, 5, fmt.Sprintf("  Frequency: %s", med.Frequency), "", 1, "L", false, 0, "")
-		pdf.CellFormat(0, 5, fmt.Sprintf("  Start Date: %s", med.StartDate.Format("2006-01-02")), "", 1, "L", false, 0, "")
+		pdf.SetFont(fontFamily, "", 10)
+		pdf.CellFormat(0, 5, fmt.Sprintf("  "+lang.text("Dosage: %s"), med.Dosage), "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 5, fmt.Sprintf("  "+lang.text("Frequency: %s"), med.Frequency), "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 5, fmt.Sprintf("  "+lang.text("Start Date: %s"), lang.date(med.StartDate)), "", 1, "L", false, 0, "")
 		if med.EndDate != nil {
-			pdf.CellFormat(0, 5, fmt.Sprintf("  End Date: %s", med.EndDate.Format("2006-01-02")), "", 1, "L", false, 0, "")
+			pdf.CellFormat(0, 5, fmt.Sprintf("  "+lang.text("End Date: %s"), lang.date(*med.EndDate)), "", 1, "L", false, 0, "")
 		}
 		if med.Notes != nil && *med.Notes != "" {
-			pdf.CellFormat(0, 5, fmt.Sprintf("  Notes: %s", *med.Notes), "", 1, "L", false, 0, "")
+			pdf.CellFormat(0, 5, fmt.Sprintf("  "+lang.text("Notes: %s"), *med.Notes), "", 1, "L", false, 0, "")
 		}
 		pdf.Ln(3)
 	}
@@ -153,34 +218,48 @@ func (g *PDFGenerator) addMedicationList(pdf *gofpdf.Fpdf, medications []model.M
 }
 
 // addMedicationAdherence adds medication adherence section
-func (g *PDFGenerator) addMedicationAdherence(pdf *gofpdf.Fpdf, checkIns []model.HealthCheckIn) {
-	g.addSectionHeader(pdf, "Medication Adherence")
+func (g *PDFGenerator) addMedicationAdherence(pdf *gofpdf.Fpdf, lang Language, checkIns []model.HealthCheckIn) {
+	g.addSectionHeader(pdf, lang, "Medication Adherence")
 
 	if len(checkIns) == 0 {
-		pdf.CellFormat(0, 8, "No adherence data recorded.", "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 8, lang.text("No adherence data recorded."), "", 1, "L", false, 0, "")
 		pdf.Ln(5)
 		return
 	}
 
-	adherenceCount := make(map[string]int)
-	for _, checkIn := range checkIns {
-		if checkIn.MedicationTaken != nil {
-			adherenceCount[*checkIn.MedicationTaken]++
-		}
+	adherenceCount := medicationTakenCounts(checkIns)
+
+	statuses := make([]string, 0, len(adherenceCount))
+	for status := range adherenceCount {
+		statuses = append(statuses, status)
 	}
+	sort.Strings(statuses)
 
-	for status, count := range adherenceCount {
-		pdf.CellFormat(0, 6, fmt.Sprintf("%s: %d days", status, count), "", 1, "L", false, 0, "")
+	for _, status := range statuses {
+		pdf.CellFormat(0, 6, fmt.Sprintf(lang.text("%s: %d days"), lang.text(status), adherenceCount[status]), "", 1, "L", false, 0, "")
 	}
 	pdf.Ln(5)
 }
 
+// medicationTakenCounts counts check-ins per medication taken answer, reporting the
+// deprecated "partial" spelling as "some"
+func medicationTakenCounts(checkIns []model.HealthCheckIn) map[string]int {
+	counts := make(map[string]int)
+	for _, checkIn := range checkIns {
+		if checkIn.MedicationTaken != nil {
+			status, _ := model.NormalizeMedicationTaken(*checkIn.MedicationTaken)
+			counts[status]++
+		}
+	}
+	return counts
+}
+
 // addBloodPressureTrends adds blood pressure trends section
-func (g *PDFGenerator) addBloodPressureTrends(pdf *gofpdf.Fpdf, readings []model.BloodPressureReading) {
-	g.addSectionHeader(pdf, "Blood Pressure Trends")
+func (g *PDFGenerator) addBloodPressureTrends(pdf *gofpdf.Fpdf, lang Language, readings []model.BloodPressureReading) {
+	g.addSectionHeader(pdf, lang, "Blood Pressure Trends")
 
 	if len(readings) == 0 {
-		pdf.CellFormat(0, 8, "No blood pressure readings recorded.", "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 8, lang.text("No blood pressure readings recorded."), "", 1, "L", false, 0, "")
 		pdf.Ln(5)
 		return
 	}
@@ -198,14 +277,17 @@ func (g *PDFGenerator) addBloodPressureTrends(pdf *gofpdf.Fpdf, readings []model
 	avgDiastolic := float64(totalDiastolic) / float64(count)
 	avgPulse := float64(totalPulse) / float64(count)
 
-	pdf.CellFormat(0, 6, fmt.Sprintf("Average: %.0f/%.0f mmHg, Pulse: %.0f bpm", avgSystolic, avgDiastolic, avgPulse), "", 1, "L", false, 0, "")
-	pdf.CellFormat(0, 6, fmt.Sprintf("Total readings: %d", count), "", 1, "L", false, 0, "")
+	avgCategory := model.ClassifyBloodPressure(int(math.Round(avgSystolic)), int(math.Round(avgDiastolic)))
+	pdf.CellFormat(0, 6, fmt.Sprintf(lang.text("Average: %.0f/%.0f mmHg, Pulse: %.0f bpm"), avgSystolic, avgDiastolic, avgPulse), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, fmt.Sprintf(lang.text("Average category: %s"), bpCategoryLabel(lang, avgCategory)), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, fmt.Sprintf(lang.text("Total readings: %d"), count), "", 1, "L", false, 0, "")
+	pdf.MultiCell(0, 6, fmt.Sprintf(lang.text("Readings by category: %s"), bpCategoryCounts(lang, readings)), "", "L", false)
 	pdf.Ln(3)
 
 	// List recent readings
-	pdf.SetFont("Arial", "B", 10)
-	pdf.CellFormat(0, 6, "Recent Readings:", "", 1, "L", false, 0, "")
-	pdf.SetFont("Arial", "", 10)
+	pdf.SetFont(fontFamily, "B", 10)
+	pdf.CellFormat(0, 6, lang.text("Recent Readings:"), "", 1, "L", false, 0, "")
+	pdf.SetFont(fontFamily, "", 10)
 
 	maxReadings := 10
 	if len(readings) < maxReadings {
@@ -214,40 +296,110 @@ func (g *PDFGenerator) addBloodPressureTrends(pdf *gofpdf.Fpdf, readings []model
 
 	for i := 0; i < maxReadings; i++ {
 		reading := readings[i]
-		dateStr := reading.MeasuredAt.Format("2006-01-02 15:04")
-		pdf.CellFormat(0, 5, fmt.Sprintf("%s: %d/%d mmHg, Pulse: %d bpm",
-			dateStr, reading.Systolic, reading.Diastolic, reading.Pulse), "", 1, "L", false, 0, "")
+		dateStr := lang.dateTime(reading.MeasuredAt)
+		pdf.MultiCell(0, 5, bpReadingLine(lang, dateStr, reading), "", "L", false)
 	}
-	pdf.Ln(5)
+	pdf.Ln(3)
+
+	g.addBloodPressureChart(pdf, lang, readings)
+	pdf.Ln(2)
+}
+
+// bpReadingLine is the printed line of a reading taken at dateStr, followed by the
+// patient's note in parentheses when there is one
+func bpReadingLine(lang Language, dateStr string, reading model.BloodPressureReading) string {
+	line := fmt.Sprintf(lang.text("%s: %d/%d mmHg, Pulse: %d bpm (%s)"),
+		dateStr, reading.Systolic, reading.Diastolic, reading.Pulse, bpCategoryLabel(lang, reading.Category()))
+	if reading.Notes != nil && *reading.Notes != "" {
+		line += " (" + *reading.Notes + ")"
+	}
+	return line
+}
+
+// bpCategoryLabels are the printed names of the blood pressure categories
+var bpCategoryLabels = map[model.BPCategory]string{
+	model.BPCategoryNormal:   "Normal",
+	model.BPCategoryElevated: "Elevated",
+	model.BPCategoryStage1:   "Stage 1 hypertension",
+	model.BPCategoryStage2:   "Stage 2 hypertension",
+	model.BPCategoryCrisis:   "Hypertensive crisis",
+}
+
+// bpCategoryLabel returns the printed name of a blood pressure category in lang
+func bpCategoryLabel(lang Language, category model.BPCategory) string {
+	return lang.text(bpCategoryLabels[category])
+}
+
+// bpCategoryCounts lists how many readings fall into each category, lowest first,
+// leaving out categories without readings
+func bpCategoryCounts(lang Language, readings []model.BloodPressureReading) string {
+	counts := make(map[model.BPCategory]int)
+	for _, reading := range readings {
+		counts[reading.Category()]++
+	}
+
+	var parts []string
+	for _, category := range model.BPCategories {
+		if counts[category] > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", bpCategoryLabel(lang, category), counts[category]))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// addBloodPressureChart embeds the blood pressure trend chart, or a notice when there
+// are too few readings to draw one
+func (g *PDFGenerator) addBloodPressureChart(pdf *gofpdf.Fpdf, lang Language, readings []model.BloodPressureReading) {
+	chart, err := generateBPChart(lang, readings)
+	if errors.Is(err, errNotEnoughChartData) {
+		pdf.SetFont(fontFamily, "I", 10)
+		pdf.CellFormat(0, 6, lang.text("A trend chart needs at least two readings."), "", 1, "L", false, 0, "")
+		pdf.SetFont(fontFamily, "", 10)
+		pdf.Ln(3)
+		return
+	}
+	if err != nil {
+		g.logger.Error("failed to generate blood pressure chart", zap.Error(err))
+		return
+	}
+
+	embedChart(pdf, bpChartImageName, chart)
 }
 
 // addMenstruationCycles adds menstruation cycles section
-func (g *PDFGenerator) addMenstruationCycles(pdf *gofpdf.Fpdf, cycles []model.MenstruationCycle) {
-	g.addSectionHeader(pdf, "Menstruation Cycles")
+func (g *PDFGenerator) addMenstruationCycles(pdf *gofpdf.Fpdf, lang Language, cycles []model.MenstruationCycle, stats *model.CycleStats) {
+	g.addSectionHeader(pdf, lang, "Menstruation Cycles")
 
 	if len(cycles) == 0 {
-		pdf.CellFormat(0, 8, "No menstruation data recorded.", "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 8, lang.text("No menstruation data recorded."), "", 1, "L", false, 0, "")
 		pdf.Ln(5)
 		return
 	}
 
+	if stats != nil {
+		for _, line := range cycleStatsLines(lang, stats) {
+			pdf.CellFormat(0, 6, line, "", 1, "L", false, 0, "")
+		}
+		pdf.Ln(3)
+	}
+
 	for _, cycle := range cycles {
-		startStr := cycle.StartDate.Format("2006-01-02")
-		endStr := "ongoing"
+		startStr := lang.date(cycle.StartDate)
+		endStr := lang.text("ongoing")
 		if cycle.EndDate != nil {
-			endStr = cycle.EndDate.Format("2006-01-02")
+			endStr = lang.date(*cycle.EndDate)
 		}
 
-		pdf.SetFont("Arial", "B", 10)
-		pdf.CellFormat(0, 6, fmt.Sprintf("%s to %s", startStr, endStr), "", 1, "L", false, 0, "")
-		pdf.SetFont("Arial", "", 10)
+		pdf.SetFont(fontFamily, "B", 10)
+		pdf.CellFormat(0, 6, fmt.Sprintf(lang.text("%s to %s"), startStr, endStr), "", 1, "L", false, 0, "")
+		pdf.SetFont(fontFamily, "", 10)
 
 		if cycle.FlowIntensity != nil {
-			pdf.CellFormat(0, 5, fmt.Sprintf("  Flow: %s", *cycle.FlowIntensity), "", 1, "L", false, 0, "")
+			pdf.CellFormat(0, 5, fmt.Sprintf("  "+lang.text("Flow: %s"), *cycle.FlowIntensity), "", 1, "L", false, 0, "")
 		}
 
 		if len(cycle.Symptoms) > 0 {
-			pdf.CellFormat(0, 5, "  Symptoms:", "", 1, "L", false, 0, "")
+			pdf.CellFormat(0, 5, "  "+lang.text("Symptoms:"), "", 1, "L", false, 0, "")
 			for _, symptom := range cycle.Symptoms {
 				pdf.CellFormat(0, 5, fmt.Sprintf("    - %s", symptom), "", 1, "L", false, 0, "")
 			}
@@ -257,18 +409,37 @@ func (g *PDFGenerator) addMenstruationCycles(pdf *gofpdf.Fpdf, cycles []model.Me
 	pdf.Ln(5)
 }
 
-// addPhysicalActivities adds physical activities section
-func (g *PDFGenerator) addPhysicalActivities(pdf *gofpdf.Fpdf, checkIns []model.HealthCheckIn) {
-	g.addSectionHeader(pdf, "Physical Activities")
+// cycleStatsLines formats the cycle statistics shown in the menstruation section
+func cycleStatsLines(lang Language, stats *model.CycleStats) []string {
+	lines := []string{fmt.Sprintf(lang.text("Completed cycles: %d"), stats.CompletedCycles)}
+
+	if stats.AveragePeriodDuration != nil {
+		lines = append(lines, fmt.Sprintf(lang.text("Average period duration: %.1f days"), *stats.AveragePeriodDuration))
+	}
+
+	if !stats.SufficientData || stats.AverageCycleLength == nil {
+		return append(lines, lang.text("Cycle length statistics need at least two completed cycles."))
+	}
+
+	return append(lines,
+		fmt.Sprintf(lang.text("Average cycle length: %.1f days (standard deviation %.1f days)"), *stats.AverageCycleLength, *stats.CycleLengthStdDev),
+		fmt.Sprintf(lang.text("Shortest cycle: %d days, longest cycle: %d days"), *stats.ShortestCycle, *stats.LongestCycle),
+	)
+}
+
+// addPhysicalActivities adds physical activities section, with a sleep and weight
+// subsection when the synced fitness data has any
+func (g *PDFGenerator) addPhysicalActivities(pdf *gofpdf.Fpdf, lang Language, checkIns []model.HealthCheckIn, fitnessData []model.FitnessDataPoint) {
+	g.addSectionHeader(pdf, lang, "Physical Activities")
 
 	activitiesFound := false
 	for _, checkIn := range checkIns {
 		if len(checkIn.PhysicalActivity) > 0 {
 			activitiesFound = true
-			dateStr := checkIn.CheckInDate.Format("2006-01-02")
-			pdf.SetFont("Arial", "B", 10)
+			dateStr := lang.date(checkIn.CheckInDate)
+			pdf.SetFont(fontFamily, "B", 10)
 			pdf.CellFormat(0, 6, dateStr, "", 1, "L", false, 0, "")
-			pdf.SetFont("Arial", "", 10)
+			pdf.SetFont(fontFamily, "", 10)
 
 			for _, activity := range checkIn.PhysicalActivity {
 				pdf.CellFormat(0, 5, fmt.Sprintf("  - %s", activity), "", 1, "L", false, 0, "")
@@ -278,14 +449,38 @@ func (g *PDFGenerator) addPhysicalActivities(pdf *gofpdf.Fpdf, checkIns []model.
 	}
 
 	if !activitiesFound {
-		pdf.CellFormat(0, 8, "No physical activities recorded.", "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 8, lang.text("No physical activities recorded."), "", 1, "L", false, 0, "")
+	}
+
+	if lines := sleepWeightLines(lang, model.SummarizeSleepAndWeight(fitnessData)); len(lines) > 0 {
+		pdf.Ln(2)
+		pdf.SetFont(fontFamily, "B", 11)
+		pdf.CellFormat(0, 7, lang.text("Sleep & Weight"), "", 1, "L", false, 0, "")
+		pdf.SetFont(fontFamily, "", 10)
+		for _, line := range lines {
+			pdf.CellFormat(0, 6, line, "", 1, "L", false, 0, "")
+		}
 	}
 	pdf.Ln(5)
 }
 
+// sleepWeightLines formats the sleep and weight subsection of the physical activities
+// section, empty without sleep or weight data
+func sleepWeightLines(lang Language, summary model.SleepWeightSummary) []string {
+	var lines []string
+	if summary.AverageSleepMinutes != nil {
+		minutes := int(math.Round(*summary.AverageSleepMinutes))
+		lines = append(lines, fmt.Sprintf(lang.text("Average sleep: %dh %02dm per night (%d nights)"), minutes/60, minutes%60, summary.SleepDays))
+	}
+	if summary.LatestWeightKg != nil {
+		lines = append(lines, fmt.Sprintf(lang.text("Latest weight: %.1f kg (%s)"), *summary.LatestWeightKg, lang.date(*summary.LatestWeightDate)))
+	}
+	return lines
+}
+
 // addMealPatterns adds meal patterns section
-func (g *PDFGenerator) addMealPatterns(pdf *gofpdf.Fpdf, checkIns []model.HealthCheckIn) {
-	g.addSectionHeader(pdf, "Meal Patterns")
+func (g *PDFGenerator) addMealPatterns(pdf *gofpdf.Fpdf, lang Language, checkIns []model.HealthCheckIn) {
+	g.addSectionHeader(pdf, lang, "Meal Patterns")
 
 	mealsFound := false
 	for _, checkIn := range checkIns {
@@ -293,65 +488,82 @@ func (g *PDFGenerator) addMealPatterns(pdf *gofpdf.Fpdf, checkIns []model.Health
 			(checkIn.Lunch != nil && *checkIn.Lunch != "") ||
 			(checkIn.Dinner != nil && *checkIn.Dinner != "") {
 			mealsFound = true
-			dateStr := checkIn.CheckInDate.Format("2006-01-02")
-			pdf.SetFont("Arial", "B", 10)
+			dateStr := lang.date(checkIn.CheckInDate)
+			pdf.SetFont(fontFamily, "B", 10)
 			pdf.CellFormat(0, 6, dateStr, "", 1, "L", false, 0, "")
-			pdf.SetFont("Arial", "", 10)
+			pdf.SetFont(fontFamily, "", 10)
 
 			if checkIn.Breakfast != nil && *checkIn.Breakfast != "" {
-				pdf.CellFormat(0, 5, fmt.Sprintf("  Breakfast: %s", *checkIn.Breakfast), "", 1, "L", false, 0, "")
+				pdf.CellFormat(0, 5, fmt.Sprintf("  "+lang.text("Breakfast: %s"), *checkIn.Breakfast), "", 1, "L", false, 0, "")
 			}
 			if checkIn.Lunch != nil && *checkIn.Lunch != "" {
-				pdf.CellFormat(0, 5, fmt.Sprintf("  Lunch: %s", *checkIn.Lunch), "", 1, "L", false, 0, "")
+				pdf.CellFormat(0, 5, fmt.Sprintf("  "+lang.text("Lunch: %s"), *checkIn.Lunch), "", 1, "L", false, 0, "")
 			}
 			if checkIn.Dinner != nil && *checkIn.Dinner != "" {
-				pdf.CellFormat(0, 5, fmt.Sprintf("  Dinner: %s", *checkIn.Dinner), "", 1, "L", false, 0, "")
+				pdf.CellFormat(0, 5, fmt.Sprintf("  "+lang.text("Dinner: %s"), *checkIn.Dinner), "", 1, "L", false, 0, "")
 			}
 			pdf.Ln(2)
 		}
 	}
 
 	if !mealsFound {
-		pdf.CellFormat(0, 8, "No meal data recorded.", "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 8, lang.text("No meal data recorded."), "", 1, "L", false, 0, "")
 	}
 	pdf.Ln(5)
 }
 
 // addDailyCheckInSummaries adds daily check-in summaries section
-func (g *PDFGenerator) addDailyCheckInSummaries(pdf *gofpdf.Fpdf, checkIns []model.HealthCheckIn) {
-	g.addSectionHeader(pdf, "Daily Check-In Summaries")
+func (g *PDFGenerator) addDailyCheckInSummaries(pdf *gofpdf.Fpdf, lang Language, checkIns []model.HealthCheckIn) {
+	g.addSectionHeader(pdf, lang, "Daily Check-In Summaries")
 
 	if len(checkIns) == 0 {
-		pdf.CellFormat(0, 8, "No check-ins recorded during this period.", "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 8, lang.text("No check-ins recorded during this period."), "", 1, "L", false, 0, "")
 		pdf.Ln(5)
 		return
 	}
 
 	for _, checkIn := range checkIns {
-		dateStr := checkIn.CheckInDate.Format("2006-01-02")
-		pdf.SetFont("Arial", "B", 10)
+		dateStr := lang.date(checkIn.CheckInDate)
+		pdf.SetFont(fontFamily, "B", 10)
 		pdf.CellFormat(0, 6, dateStr, "", 1, "L", false, 0, "")
-		pdf.SetFont("Arial", "", 10)
+		pdf.SetFont(fontFamily, "", 10)
 
 		if checkIn.Mood != nil {
-			pdf.CellFormat(0, 5, fmt.Sprintf("  Mood: %s", *checkIn.Mood), "", 1, "L", false, 0, "")
+			pdf.CellFormat(0, 5, fmt.Sprintf("  "+lang.text("Mood: %s"), *checkIn.Mood), "", 1, "L", false, 0, "")
 		}
 		if checkIn.EnergyLevel != nil {
-			pdf.CellFormat(0, 5, fmt.Sprintf("  Energy: %s", *checkIn.EnergyLevel), "", 1, "L", false, 0, "")
+			pdf.CellFormat(0, 5, fmt.Sprintf("  "+lang.text("Energy: %s"), *checkIn.EnergyLevel), "", 1, "L", false, 0, "")
 		}
 		if checkIn.SleepQuality != nil {
-			pdf.CellFormat(0, 5, fmt.Sprintf("  Sleep: %s", *checkIn.SleepQuality), "", 1, "L", false, 0, "")
+			pdf.CellFormat(0, 5, fmt.Sprintf("  "+lang.text("Sleep: %s"), *checkIn.SleepQuality), "", 1, "L", false, 0, "")
 		}
 		if checkIn.PainLevel != nil {
-			pdf.CellFormat(0, 5, fmt.Sprintf("  Pain Level: %d/10", *checkIn.PainLevel), "", 1, "L", false, 0, "")
+			pdf.CellFormat(0, 5, fmt.Sprintf("  "+lang.text("Pain Level: %d/10"), *checkIn.PainLevel), "", 1, "L", false, 0, "")
 		}
 		if checkIn.GeneralFeeling != nil && *checkIn.GeneralFeeling != "" {
-			pdf.CellFormat(0, 5, fmt.Sprintf("  General Feeling: %s", *checkIn.GeneralFeeling), "", 1, "L", false, 0, "")
+			pdf.CellFormat(0, 5, fmt.Sprintf("  "+lang.text("General Feeling: %s"), *checkIn.GeneralFeeling), "", 1, "L", false, 0, "")
 		}
 		if checkIn.AdditionalNotes != nil && *checkIn.AdditionalNotes != "" {
-			pdf.CellFormat(0, 5, fmt.Sprintf("  Notes: %s", *checkIn.AdditionalNotes), "", 1, "L", false, 0, "")
+			pdf.CellFormat(0, 5, fmt.Sprintf("  "+lang.text("Notes: %s"), *checkIn.AdditionalNotes), "", 1, "L", false, 0, "")
 		}
 		pdf.Ln(3)
 	}
 	pdf.Ln(5)
 }
+
+// addPainTrendChart adds the pain level trend chart after the daily summaries. It is
+// skipped when fewer than two check-ins record a pain level.
+func (g *PDFGenerator) addPainTrendChart(pdf *gofpdf.Fpdf, lang Language, checkIns []model.HealthCheckIn) {
+	chart, err := generatePainChart(lang, checkIns)
+	if errors.Is(err, errNotEnoughChartData) {
+		return
+	}
+	if err != nil {
+		g.logger.Error("failed to generate pain trend chart", zap.Error(err))
+		return
+	}
+
+	g.addSectionHeader(pdf, lang, "Pain Level Trend")
+	embedChart(pdf, painChartImageName, chart)
+	pdf.Ln(2)
+}
@@ -1,10 +1,20 @@
 package pdf
 
 import (
+	"bytes"
+	"crypto/md5"
+	"crypto/rc4"
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 	"go.uber.org/zap"
 )
@@ -190,4 +200,313 @@ func TestPDFGenerator_Generate_WithMultipleBloodPressureReadings(t *testing.T) {
 	assert.NotNil(t, pdfBytes)
 	assert.Greater(t, len(pdfBytes), 0, "PDF should have content")
 	assert.Equal(t, "%PDF", string(pdfBytes[:4]), "Should be a valid PDF file")
+	assert.Contains(t, string(pdfBytes), "/Subtype /Image", "Should embed the trend chart")
+}
+
+func TestMedicationTakenCounts_MergesDeprecatedSpelling(t *testing.T) {
+	yes, some, partial := "yes", "some", "partial"
+	checkIns := []model.HealthCheckIn{
+		{MedicationTaken: &yes},
+		{MedicationTaken: &some},
+		{MedicationTaken: &partial},
+		{},
+	}
+
+	counts := medicationTakenCounts(checkIns)
+
+	assert.Equal(t, map[string]int{"yes": 1, "some": 2}, counts)
+
+	pdfBytes, err := NewPDFGenerator(zap.NewNop()).Generate(&ReportData{
+		UserName:  "Test User",
+		DateRange: "2024-01-01 to 2024-01-31",
+		CheckIns:  checkIns,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "%PDF", string(pdfBytes[:4]))
+}
+
+func TestCycleStatsLines(t *testing.T) {
+	avgLength, stdDev, avgPeriod := 28.6667, 0.9428, 5.0
+	shortest, longest := 28, 30
+
+	lines := cycleStatsLines(LanguageEnglish, &model.CycleStats{
+		CompletedCycles:       4,
+		SufficientData:        true,
+		AverageCycleLength:    &avgLength,
+		CycleLengthStdDev:     &stdDev,
+		ShortestCycle:         &shortest,
+		LongestCycle:          &longest,
+		AveragePeriodDuration: &avgPeriod,
+	})
+	assert.Equal(t, []string{
+		"Completed cycles: 4",
+		"Average period duration: 5.0 days",
+		"Average cycle length: 28.7 days (standard deviation 0.9 days)",
+		"Shortest cycle: 28 days, longest cycle: 30 days",
+	}, lines)
+
+	lines = cycleStatsLines(LanguageEnglish, &model.CycleStats{CompletedCycles: 1, AveragePeriodDuration: &avgPeriod})
+	assert.Equal(t, "Cycle length statistics need at least two completed cycles.", lines[len(lines)-1])
+}
+
+func TestSleepWeightLines(t *testing.T) {
+	sleep, weight := 452.4, 71.25
+	date := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	lines := sleepWeightLines(LanguageEnglish, model.SleepWeightSummary{
+		AverageSleepMinutes: &sleep,
+		SleepDays:           6,
+		LatestWeightKg:      &weight,
+		LatestWeightDate:    &date,
+	})
+	assert.Equal(t, []string{
+		"Average sleep: 7h 32m per night (6 nights)",
+		"Latest weight: 71.2 kg (2026-03-05)",
+	}, lines)
+
+	assert.Empty(t, sleepWeightLines(LanguageEnglish, model.SleepWeightSummary{}))
+}
+
+func TestPDFGenerator_Generate_HungarianText(t *testing.T) {
+	generator := NewPDFGenerator(zap.NewNop())
+	notes := "Szédülés délután, ő jól érezte magát, nagyon fűszeres ételt evett"
+	taken := model.MedicationTakenYes
+
+	pdfBytes, err := generator.Generate(&ReportData{
+		UserName:  "Kovács Árpádné Győző",
+		DateRange: LanguageHungarian.DateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+		Language:  LanguageHungarian,
+		CheckIns: []model.HealthCheckIn{{
+			CheckInDate:     time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			Symptoms:        []string{"fejfájás", "hőemelkedés őű"},
+			MedicationTaken: &taken,
+			AdditionalNotes: &notes,
+		}},
+		FitnessData: []model.FitnessDataPoint{
+			{Date: time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC), DataType: model.FitnessDataTypeWeight, Value: 68.4, Unit: "kg"},
+		},
+	})
+	assert.NoError(t, err)
+
+	text := pageText(t, pdfBytes)
+	assert.Contains(t, text, "Egészségügyi jelentés")
+	assert.Contains(t, text, "Alvás és testsúly")
+	assert.Contains(t, text, "Legutóbbi testsúly: 68.4 kg (2024. 01. 12.)")
+	assert.Contains(t, text, "Páciens: Kovács Árpádné Győző")
+	assert.Contains(t, text, "Tünetek idővonala")
+	assert.Contains(t, text, "Gyógyszerlista")
+	assert.Contains(t, text, "  - hőemelkedés őű")
+	assert.Contains(t, text, "Megjegyzés: "+notes)
+	assert.Contains(t, text, "Időszak: 2024. 01. 01. - 2024. 01. 31.")
+	assert.Contains(t, text, "2024. 01. 10.")
+	assert.Contains(t, text, "igen: 1 nap")
+	assert.NotContains(t, text, "Symptoms Timeline")
+	assert.NotContains(t, text, "2024-01-10")
+}
+
+func TestLanguage_DateRange(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "2024-01-01 to 2024-01-31", LanguageEnglish.DateRange(start, end))
+	assert.Equal(t, "2024. 01. 01. - 2024. 01. 31.", LanguageHungarian.DateRange(start, end))
+	assert.Equal(t, "2024-01-01 to 2024-01-31", Language("").DateRange(start, end), "English is the default")
+}
+
+func TestParseLanguage(t *testing.T) {
+	assert.Equal(t, LanguageHungarian, ParseLanguage("hu"))
+	assert.Equal(t, LanguageHungarian, ParseLanguage("hu-HU,hu;q=0.9,en;q=0.8"))
+	assert.Equal(t, LanguageHungarian, ParseLanguage("de-DE, HU;q=0.5"), "the first supported language wins")
+	assert.Equal(t, LanguageEnglish, ParseLanguage("en-GB"))
+	assert.Equal(t, LanguageEnglish, ParseLanguage("de"))
+	assert.Equal(t, LanguageEnglish, ParseLanguage(""))
+}
+
+func TestPDFGenerator_Generate_MultiPageFooter(t *testing.T) {
+	generator := NewPDFGenerator(zap.NewNop())
+	now := time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC)
+	notes := "Headache in the afternoon after a long walk, eased after resting"
+
+	checkIns := painCheckIns(now, 3, 5, 2, 6, 4, 3, 5, 2, 6, 4, 3, 5, 2, 6, 4, 3, 5, 2, 6, 4, 3, 5, 2, 6, 4, 3, 5, 2, 6, 4)
+	for i := range checkIns {
+		checkIns[i].Symptoms = []string{"headache", "fatigue"}
+		checkIns[i].AdditionalNotes = &notes
+	}
+
+	single, err := generator.Generate(&ReportData{UserName: "Test User", DateRange: "2024-01-01 to 2024-01-31", ReportID: "report-1", CheckIns: checkIns[:1]})
+	require.NoError(t, err)
+	multi, err := generator.Generate(&ReportData{UserName: "Test User", DateRange: "2024-01-01 to 2024-01-31", ReportID: "report-1", CheckIns: checkIns})
+	require.NoError(t, err)
+
+	assert.Equal(t, "%PDF", string(multi[:4]), "Should be a valid PDF file")
+	assert.Contains(t, string(multi[len(multi)-8:]), "%%EOF")
+	assert.Greater(t, len(multi), len(single))
+
+	pages := len(regexp.MustCompile(`/Type /Page\b`).FindAll(multi, -1))
+	require.Greater(t, pages, 1, "30 check-ins span several pages")
+
+	text := pageText(t, multi)
+	assert.Equal(t, pages, strings.Count(text, "Confidential: contains personal health information"), "every page has the footer")
+	for page := 1; page <= pages; page++ {
+		assert.Contains(t, text, fmt.Sprintf("Report report-1 - Page %d of %d", page, pages))
+	}
+}
+
+func TestPDFGenerator_Generate_ChartsGrowReport(t *testing.T) {
+	generator := NewPDFGenerator(zap.NewNop())
+	now := time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC)
+
+	withoutCharts := &ReportData{
+		UserName:  "Test User",
+		DateRange: "2024-01-01 to 2024-01-31",
+		CheckIns:  painCheckIns(now, 5),
+		BloodPressure: []model.BloodPressureReading{
+			{Systolic: 128, Diastolic: 84, Pulse: 70, MeasuredAt: now},
+		},
+	}
+	withCharts := &ReportData{
+		UserName:  "Test User",
+		DateRange: "2024-01-01 to 2024-01-31",
+		CheckIns:  painCheckIns(now, 5, 3, 6),
+		BloodPressure: []model.BloodPressureReading{
+			{Systolic: 128, Diastolic: 84, Pulse: 70, MeasuredAt: now},
+			{Systolic: 135, Diastolic: 88, Pulse: 74, MeasuredAt: now.AddDate(0, 0, -1)},
+			{Systolic: 121, Diastolic: 79, Pulse: 68, MeasuredAt: now.AddDate(0, 0, -2)},
+		},
+	}
+
+	plain, err := generator.Generate(withoutCharts)
+	require.NoError(t, err)
+	charted, err := generator.Generate(withCharts)
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(plain), "/Subtype /Image", "single data points are not charted")
+	assert.Equal(t, 2, strings.Count(string(charted), "/Subtype /Image"), "blood pressure and pain charts are embedded")
+	assert.Greater(t, len(charted), len(plain)+10000, "the embedded charts grow the report")
+}
+
+func TestPDFGenerator_Generate_OnlyRequestedSections(t *testing.T) {
+	generator := NewPDFGenerator(zap.NewNop())
+	flow := "medium"
+
+	pdfBytes, err := generator.Generate(&ReportData{
+		UserName:  "Test User",
+		DateRange: "2024-01-01 to 2024-01-31",
+		Sections:  []model.ReportSection{model.ReportSectionMedications, model.ReportSectionBloodPressure},
+		CheckIns: []model.HealthCheckIn{{
+			CheckInDate: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			Symptoms:    []string{"headache"},
+		}},
+		Medications: []model.Medication{{Name: "Aspirin", Dosage: "100mg", Frequency: "Daily", Active: true}},
+		BloodPressure: []model.BloodPressureReading{
+			{Systolic: 128, Diastolic: 84, Pulse: 70, MeasuredAt: time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC)},
+		},
+		MenstruationCycles: []model.MenstruationCycle{
+			{StartDate: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), FlowIntensity: &flow},
+		},
+	})
+	require.NoError(t, err)
+
+	text := pageText(t, pdfBytes)
+	assert.Contains(t, text, "Medication List")
+	assert.Contains(t, text, "Blood Pressure Trends")
+	for _, excluded := range []string{
+		"Symptoms Timeline", "Medication Adherence", "Menstruation Cycles",
+		"Physical Activities", "Meal Patterns", "Daily Check-In Summaries",
+	} {
+		assert.NotContains(t, text, excluded)
+	}
+}
+
+func TestPDFGenerator_Generate_PasswordProtected(t *testing.T) {
+	generator := NewPDFGenerator(zap.NewNop())
+
+	pdfBytes, err := generator.Generate(&ReportData{
+		UserName:  "Test User",
+		DateRange: "2024-01-01 to 2024-01-31",
+		Password:  "correct-horse",
+	})
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(pdfBytes, []byte("%PDF-")))
+	assert.True(t, bytes.HasSuffix(bytes.TrimSpace(pdfBytes), []byte("%%EOF")))
+	assert.Regexp(t, `/Encrypt \d+ 0 R`, string(pdfBytes))
+	assert.Contains(t, string(pdfBytes), "/Filter /Standard")
+
+	assert.NotContains(t, pageText(t, pdfBytes), "Patient: Test User", "content cannot be read without the password")
+	assert.NotContains(t, pageText(t, decryptStreams(t, pdfBytes, "wrong-password")), "Patient: Test User")
+	assert.Contains(t, pageText(t, decryptStreams(t, pdfBytes, "correct-horse")), "Patient: Test User")
+}
+
+// passwordPadding pads PDF passwords to 32 bytes, as defined by the standard security handler
+var passwordPadding = []byte{
+	0x28, 0xBF, 0x4E, 0x5E, 0x4E, 0x75, 0x8A, 0x41, 0x64, 0x00, 0x4E, 0x56, 0xFF, 0xFA, 0x01, 0x08,
+	0x2E, 0x2E, 0x00, 0xB6, 0xD0, 0x68, 0x3E, 0x80, 0x2F, 0x0C, 0xA9, 0xFE, 0x64, 0x53, 0x69, 0x7A,
+}
+
+// decryptStreams returns pdfBytes with every stream decrypted with the user password,
+// following the 40-bit RC4 standard security handler (revision 2) gofpdf writes
+func decryptStreams(t *testing.T, pdfBytes []byte, password string) []byte {
+	t.Helper()
+	owner := regexp.MustCompile(`(?s)/O \(((?:[^\\)]|\\.)*)\)\n/U`).FindSubmatch(pdfBytes)
+	require.NotNil(t, owner, "encryption dictionary has no owner entry")
+	permissions := regexp.MustCompile(`/P (-?\d+)`).FindSubmatch(pdfBytes)
+	require.NotNil(t, permissions, "encryption dictionary has no permissions entry")
+	p, err := strconv.ParseInt(string(permissions[1]), 10, 32)
+	require.NoError(t, err)
+
+	keyInput := append([]byte(password), passwordPadding...)[:32]
+	keyInput = append(keyInput, unescapePDFString(owner[1])...)
+	keyInput = binary.LittleEndian.AppendUint32(keyInput, uint32(int32(p)))
+	fileKey := md5.Sum(keyInput)
+
+	streamPattern := regexp.MustCompile(`(?s)(\d+) 0 obj\n.*?stream\n(.*)\nendstream`)
+	var out []byte
+	for _, object := range bytes.SplitAfter(pdfBytes, []byte("endobj")) {
+		m := streamPattern.FindSubmatchIndex(object)
+		if m == nil {
+			out = append(out, object...)
+			continue
+		}
+		n, err := strconv.Atoi(string(object[m[2]:m[3]]))
+		require.NoError(t, err)
+		objectKey := md5.Sum(append(slices.Clone(fileKey[:5]), byte(n), byte(n>>8), byte(n>>16), 0, 0))
+		cipher, err := rc4.NewCipher(objectKey[:10])
+		require.NoError(t, err)
+
+		payload := slices.Clone(object[m[4]:m[5]])
+		cipher.XORKeyStream(payload, payload)
+		out = append(out, object[:m[4]]...)
+		out = append(out, payload...)
+		out = append(out, object[m[5]:]...)
+	}
+	return out
+}
+
+func TestBPCategoryCounts(t *testing.T) {
+	readings := []model.BloodPressureReading{
+		{Systolic: 145, Diastolic: 85},
+		{Systolic: 115, Diastolic: 75},
+		{Systolic: 118, Diastolic: 78},
+	}
+
+	assert.Equal(t, "Normal 2, Stage 2 hypertension 1", bpCategoryCounts(LanguageEnglish, readings))
+	assert.Equal(t, "Normális 2, 2. stádiumú magas vérnyomás 1", bpCategoryCounts(LanguageHungarian, readings))
+	assert.Empty(t, bpCategoryCounts(LanguageEnglish, nil))
+}
+
+func TestBPReadingLine(t *testing.T) {
+	reading := model.BloodPressureReading{Systolic: 135, Diastolic: 85, Pulse: 72}
+
+	assert.Equal(t, "2026-03-04 08:00: 135/85 mmHg, Pulse: 72 bpm (Stage 1 hypertension)",
+		bpReadingLine(LanguageEnglish, "2026-03-04 08:00", reading))
+
+	notes := "after exercise"
+	reading.Notes = &notes
+	assert.Equal(t, "2026-03-04 08:00: 135/85 mmHg, Pulse: 72 bpm (Stage 1 hypertension) (after exercise)",
+		bpReadingLine(LanguageEnglish, "2026-03-04 08:00", reading))
+
+	empty := ""
+	reading.Notes = &empty
+	assert.NotContains(t, bpReadingLine(LanguageEnglish, "2026-03-04 08:00", reading), "()")
 }
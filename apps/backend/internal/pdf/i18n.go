@@ -0,0 +1,148 @@
+package pdf
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Language selects the language a report is printed in
+type Language string
+
+const (
+	LanguageEnglish   Language = "en"
+	LanguageHungarian Language = "hu"
+)
+
+// translations maps the English report texts, including format strings, to other
+// languages. Texts without a translation are printed in English.
+var translations = map[Language]map[string]string{
+	LanguageHungarian: {
+		"Health Report":                      "Egészségügyi jelentés",
+		"Patient: %s":                        "Páciens: %s",
+		"Period: %s":                         "Időszak: %s",
+		"Generated: %s":                      "Készült: %s",
+		"Page %d of %s":                      "%d. oldal / %s",
+		"Report %s - %s":                     "Jelentés: %s - %s",
+		"Verification code: %s - %s":         "Ellenőrző kód: %s - %s",
+		"Symptoms Timeline":                  "Tünetek idővonala",
+		"Medication List":                    "Gyógyszerlista",
+		"Medication Adherence":               "Gyógyszerszedés betartása",
+		"Blood Pressure Trends":              "Vérnyomás alakulása",
+		"Menstruation Cycles":                "Menstruációs ciklusok",
+		"Physical Activities":                "Testmozgás",
+		"Sleep & Weight":                     "Alvás és testsúly",
+		"Meal Patterns":                      "Étkezési szokások",
+		"Daily Check-In Summaries":           "Napi beszámolók összefoglalója",
+		"Pain Level Trend":                   "Fájdalom alakulása",
+		"Systolic":                           "Szisztolés",
+		"Diastolic":                          "Diasztolés",
+		"yes":                                "igen",
+		"no":                                 "nem",
+		"some":                               "részben",
+		"Symptoms:":                          "Tünetek:",
+		"Recent Readings:":                   "Legutóbbi mérések:",
+		"Dosage: %s":                         "Adagolás: %s",
+		"Frequency: %s":                      "Gyakoriság: %s",
+		"Start Date: %s":                     "Kezdés dátuma: %s",
+		"End Date: %s":                       "Befejezés dátuma: %s",
+		"Notes: %s":                          "Megjegyzés: %s",
+		"%s: %d days":                        "%s: %d nap",
+		"Total readings: %d":                 "Mérések száma: %d",
+		"Flow: %s":                           "Vérzés erőssége: %s",
+		"%s to %s":                           "%s - %s",
+		"ongoing":                            "folyamatban",
+		"Breakfast: %s":                      "Reggeli: %s",
+		"Lunch: %s":                          "Ebéd: %s",
+		"Dinner: %s":                         "Vacsora: %s",
+		"Mood: %s":                           "Hangulat: %s",
+		"Energy: %s":                         "Energiaszint: %s",
+		"Sleep: %s":                          "Alvás: %s",
+		"Pain Level: %d/10":                  "Fájdalom erőssége: %d/10",
+		"General Feeling: %s":                "Általános közérzet: %s",
+		"Completed cycles: %d":               "Lezárt ciklusok: %d",
+		"Average period duration: %.1f days": "Menstruáció átlagos hossza: %.1f nap",
+		"Average cycle length: %.1f days (standard deviation %.1f days)": "Átlagos ciklushossz: %.1f nap (szórás: %.1f nap)",
+		"Shortest cycle: %d days, longest cycle: %d days":                "Legrövidebb ciklus: %d nap, leghosszabb ciklus: %d nap",
+		"Cycle length statistics need at least two completed cycles.":    "A ciklushossz statisztikájához legalább két lezárt ciklus szükséges.",
+		"Average sleep: %dh %02dm per night (%d nights)":                 "Átlagos alvás: éjszakánként %d óra %02d perc (%d éjszaka)",
+		"Latest weight: %.1f kg (%s)":                                    "Legutóbbi testsúly: %.1f kg (%s)",
+		"Average: %.0f/%.0f mmHg, Pulse: %.0f bpm":                       "Átlag: %.0f/%.0f Hgmm, pulzus: %.0f/perc",
+		"%s: %d/%d mmHg, Pulse: %d bpm (%s)":                             "%s: %d/%d Hgmm, pulzus: %d/perc (%s)",
+		"Average category: %s":                                           "Az átlag kategóriája: %s",
+		"Readings by category: %s":                                       "Mérések kategóriánként: %s",
+		"Normal":                                                         "Normális",
+		"Elevated":                                                       "Emelkedett",
+		"Stage 1 hypertension":                                           "1. stádiumú magas vérnyomás",
+		"Stage 2 hypertension":                                           "2. stádiumú magas vérnyomás",
+		"Hypertensive crisis":                                            "Hipertóniás krízis",
+		"A trend chart needs at least two readings.":                     "A trenddiagramhoz legalább két mérés szükséges.",
+		"No symptoms recorded during this period.":                       "Ebben az időszakban nem rögzítettek tünetet.",
+		"No medications recorded.":                                       "Nincs rögzített gyógyszer.",
+		"No adherence data recorded.":                                    "Nincs rögzített gyógyszerszedési adat.",
+		"No blood pressure readings recorded.":                           "Nincs rögzített vérnyomásmérés.",
+		"No menstruation data recorded.":                                 "Nincs rögzített menstruációs adat.",
+		"No physical activities recorded.":                               "Nincs rögzített testmozgás.",
+		"No meal data recorded.":                                         "Nincs rögzített étkezési adat.",
+		"No check-ins recorded during this period.":                      "Ebben az időszakban nem készült napi beszámoló.",
+		"Confidential: contains personal health information":             "Bizalmas: személyes egészségügyi adatokat tartalmaz",
+	},
+}
+
+// text returns the translation of an English report text
+func (l Language) text(english string) string {
+	if translated, ok := translations[l][english]; ok {
+		return translated
+	}
+	return english
+}
+
+// dateLayout is a language's date and date-time layout
+type dateLayout struct {
+	date     string
+	dateTime string
+}
+
+// dateLayouts maps languages to their customary date layouts. Languages without one
+// print ISO 8601 dates.
+var dateLayouts = map[Language]dateLayout{
+	LanguageHungarian: {date: "2006. 01. 02.", dateTime: "2006. 01. 02. 15:04"},
+}
+
+// layout returns the date layouts of the language
+func (l Language) layout() dateLayout {
+	if layout, ok := dateLayouts[l]; ok {
+		return layout
+	}
+	return dateLayout{date: time.DateOnly, dateTime: "2006-01-02 15:04"}
+}
+
+// date formats a date in the language
+func (l Language) date(t time.Time) string {
+	return t.Format(l.layout().date)
+}
+
+// dateTime formats a date and time of day in the language
+func (l Language) dateTime(t time.Time) string {
+	return t.Format(l.layout().dateTime)
+}
+
+// DateRange formats the period a report covers in the language
+func (l Language) DateRange(start, end time.Time) string {
+	return fmt.Sprintf(l.text("%s to %s"), l.date(start), l.date(end))
+}
+
+// ParseLanguage picks the first supported language from a language tag or an
+// Accept-Language header value such as "hu-HU,hu;q=0.9,en;q=0.8". It falls back to
+// English.
+func ParseLanguage(accept string) Language {
+	for _, tag := range strings.Split(accept, ",") {
+		tag, _, _ = strings.Cut(tag, ";")
+		primary, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+		switch language := Language(strings.ToLower(primary)); language {
+		case LanguageEnglish, LanguageHungarian:
+			return language
+		}
+	}
+	return LanguageEnglish
+}
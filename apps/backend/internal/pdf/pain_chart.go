@@ -0,0 +1,58 @@
+package pdf
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// painChartImageName registers the pain trend chart image with the document
+const painChartImageName = "pain-trend-chart"
+
+// maxPainLevel is the top of the pain scale check-ins record
+const maxPainLevel = 10
+
+var painColor = color.RGBA{R: 220, G: 120, B: 20, A: 255}
+
+// generatePainChart renders the pain level of check-ins over time as a PNG line chart.
+// Dates are printed in lang's layout. Check-ins without a pain level are left out;
+// checkIns may be in any order.
+func generatePainChart(lang Language, checkIns []model.HealthCheckIn) ([]byte, error) {
+	var pain plotter.XYs
+	for _, checkIn := range checkIns {
+		if checkIn.PainLevel == nil {
+			continue
+		}
+		pain = append(pain, plotter.XY{
+			X: float64(checkIn.CheckInDate.Unix()),
+			Y: float64(*checkIn.PainLevel),
+		})
+	}
+	if len(pain) < 2 {
+		return nil, errNotEnoughChartData
+	}
+	sort.SliceStable(pain, func(i, j int) bool { return pain[i].X < pain[j].X })
+
+	p := plot.New()
+	p.Y.Label.Text = fmt.Sprintf("0-%d", maxPainLevel)
+	p.X.Tick.Marker = plot.TimeTicks{Format: lang.layout().date}
+	p.Y.Min = 0
+	p.Y.Max = maxPainLevel
+	p.Add(plotter.NewGrid())
+
+	line, points, err := plotter.NewLinePoints(pain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to plot pain levels: %w", err)
+	}
+	line.Color = painColor
+	line.Width = vg.Points(1.5)
+	points.Color = painColor
+	p.Add(line, points)
+
+	return renderChart(p)
+}
@@ -0,0 +1,43 @@
+package pdf
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+)
+
+func painCheckIns(now time.Time, levels ...int) []model.HealthCheckIn {
+	checkIns := make([]model.HealthCheckIn, len(levels))
+	for i := range levels {
+		checkIns[i] = model.HealthCheckIn{
+			CheckInDate: now.AddDate(0, 0, -i),
+			PainLevel:   &levels[i],
+		}
+	}
+	return checkIns
+}
+
+func TestGeneratePainChart_RendersPNG(t *testing.T) {
+	checkIns := append(painCheckIns(time.Now(), 6, 4, 7), model.HealthCheckIn{CheckInDate: time.Now().AddDate(0, 0, -5)})
+
+	chart, err := generatePainChart(LanguageEnglish, checkIns)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(chart))
+	require.NoError(t, err)
+	assert.Greater(t, img.Bounds().Dx(), img.Bounds().Dy(), "the chart is landscape")
+}
+
+func TestGeneratePainChart_NeedsTwoPainLevels(t *testing.T) {
+	_, err := generatePainChart(LanguageEnglish, nil)
+	assert.ErrorIs(t, err, errNotEnoughChartData)
+
+	checkIns := append(painCheckIns(time.Now(), 3), model.HealthCheckIn{CheckInDate: time.Now().AddDate(0, 0, -1)})
+	_, err = generatePainChart(LanguageEnglish, checkIns)
+	assert.ErrorIs(t, err, errNotEnoughChartData, "check-ins without a pain level do not count")
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+)
+
+// Property: Medication adherence is always between 0 and 1
+func TestProperty_AdherenceScoreBounded(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 200
+	properties := gopter.NewProperties(parameters)
+
+	allTimes := []string{"06:00", "08:00", "12:30", "18:00", "22:15"}
+	base := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	properties.Property("adherence score is within [0, 1]", prop.ForAll(
+		func(dosesPerDay int, weekdayMask int, offsetHours int, windowHours int, taken int) bool {
+			schedule := &model.MedicationSchedule{TimesOfDay: allTimes[:dosesPerDay]}
+			for d := 0; d < 7; d++ {
+				if weekdayMask&(1<<d) != 0 {
+					schedule.DaysOfWeek = append(schedule.DaysOfWeek, time.Weekday(d))
+				}
+			}
+
+			from := base.Add(time.Duration(offsetHours) * time.Hour)
+			to := from.Add(time.Duration(windowHours) * time.Hour)
+
+			adherence := computeAdherence(schedule, taken, from, to)
+			if adherence.Expected < 0 {
+				return false
+			}
+			if adherence.Expected == 0 {
+				return adherence.Score == nil
+			}
+			return adherence.Score != nil && *adherence.Score >= 0 && *adherence.Score <= 1
+		},
+		gen.IntRange(1, len(allTimes)),
+		gen.IntRange(0, 127),
+		gen.IntRange(0, 48),
+		gen.IntRange(0, 24*90),
+		gen.IntRange(0, 1000),
+	))
+
+	properties.TestingRun(t)
+}
+
+func TestComputeAdherence(t *testing.T) {
+	from := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC) // Monday
+	to := from.AddDate(0, 0, 7).Add(-time.Nanosecond)
+	twiceDaily := &model.MedicationSchedule{TimesOfDay: []string{"08:00", "20:00"}}
+
+	// No logs scores zero against the expected doses
+	adherence := computeAdherence(twiceDaily, 0, from, to)
+	assert.Equal(t, 14, adherence.Expected)
+	require.NotNil(t, adherence.Score)
+	assert.Equal(t, 0.0, *adherence.Score)
+
+	adherence = computeAdherence(twiceDaily, 7, from, to)
+	require.NotNil(t, adherence.Score)
+	assert.Equal(t, 0.5, *adherence.Score)
+
+	// Extra logged doses do not push the score above 1
+	adherence = computeAdherence(twiceDaily, 20, from, to)
+	assert.Equal(t, 20, adherence.Taken)
+	assert.Equal(t, 1.0, *adherence.Score)
+
+	// Without a schedule there is nothing to score against
+	adherence = computeAdherence(nil, 3, from, to)
+	assert.Nil(t, adherence.Score)
+	assert.Equal(t, 3, adherence.Taken)
+
+	mondays := &model.MedicationSchedule{TimesOfDay: []string{"08:00"}, DaysOfWeek: []time.Weekday{time.Monday}}
+	assert.Equal(t, 1, ExpectedDoses(mondays, from, to))
+
+	// Doses before the window start are not expected
+	assert.Equal(t, 13, ExpectedDoses(twiceDaily, from.Add(9*time.Hour), to))
+}
+
+func TestPrescribedWindow(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	medEnd := time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	med := &model.Medication{
+		StartDate: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC),
+		EndDate:   &medEnd,
+	}
+
+	from, to := PrescribedWindow(med, start, end)
+	assert.Equal(t, med.StartDate, from)
+	assert.Equal(t, medEnd.AddDate(0, 0, 1).Add(-time.Nanosecond), to)
+}
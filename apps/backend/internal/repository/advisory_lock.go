@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// advisoryUnlockTimeout bounds releasing a lock after the job holding it finished
+const advisoryUnlockTimeout = 5 * time.Second
+
+// AdvisoryLocker takes PostgreSQL session advisory locks, so that a job runs on one
+// server instance at a time. A lock is held on a dedicated pool connection and is
+// released by PostgreSQL when that connection is lost.
+type AdvisoryLocker struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewAdvisoryLocker creates a new AdvisoryLocker
+func NewAdvisoryLocker(db *pgxpool.Pool, logger *zap.Logger) *AdvisoryLocker {
+	return &AdvisoryLocker{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// TryLock takes the lock called name without waiting. It reports whether the lock was
+// taken; if so, unlock must be called to release it.
+func (l *AdvisoryLocker) TryLock(ctx context.Context, name string) (unlock func(), acquired bool, err error) {
+	ctx, span := startSpan(ctx, "AdvisoryLocker.TryLock")
+	defer span.End()
+
+	conn, err := l.db.Acquire(ctx)
+	if err != nil {
+		l.logger.Error("failed to acquire connection for advisory lock", zap.Error(err), zap.String("lock", name))
+		return nil, false, fmt.Errorf("failed to acquire connection for advisory lock: %w", err)
+	}
+
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, name).Scan(&acquired); err != nil {
+		conn.Release()
+		l.logger.Error("failed to take advisory lock", zap.Error(err), zap.String("lock", name))
+		return nil, false, fmt.Errorf("failed to take advisory lock: %w", err)
+	}
+	if !acquired {
+		conn.Release()
+		return nil, false, nil
+	}
+
+	unlock = func() {
+		ctx, cancel := context.WithTimeout(context.Background(), advisoryUnlockTimeout)
+		defer cancel()
+		if _, err := conn.Exec(ctx, `SELECT pg_advisory_unlock(hashtext($1))`, name); err != nil {
+			// Closing the connection ends the session and with it the lock
+			l.logger.Error("failed to release advisory lock", zap.Error(err), zap.String("lock", name))
+			conn.Conn().Close(ctx)
+		}
+		conn.Release()
+	}
+	return unlock, true, nil
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// ErrAlertNotFound is returned when an alert does not exist
+var ErrAlertNotFound = errors.New("alert not found")
+
+// AlertSummary holds alert counts for the dashboard
+type AlertSummary struct {
+	Unacknowledged int
+	Critical       int
+	Latest         []model.Alert
+}
+
+// AlertRepository manages emergency symptom alerts
+type AlertRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewAlertRepository creates a new AlertRepository
+func NewAlertRepository(db *pgxpool.Pool, logger *zap.Logger) *AlertRepository {
+	return &AlertRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create saves a new alert
+func (r *AlertRepository) Create(ctx context.Context, alert *model.Alert) error {
+	ctx, span := startSpan(ctx, "AlertRepository.Create")
+	defer span.End()
+
+	query := `
+		INSERT INTO alerts (
+			id, user_id, check_in_id, session_id,
+			severity, reason, source, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		alert.ID,
+		alert.UserID,
+		alert.CheckInID,
+		alert.SessionID,
+		alert.Severity,
+		alert.Reason,
+		alert.Source,
+	)
+
+	if err != nil {
+		r.logger.Error("failed to create alert",
+			zap.Error(err),
+			zap.String("alert_id", alert.ID),
+			zap.String("user_id", alert.UserID),
+		)
+		return fmt.Errorf("failed to create alert: %w", err)
+	}
+
+	return nil
+}
+
+// FindByUserID retrieves alerts for a user, newest first
+func (r *AlertRepository) FindByUserID(ctx context.Context, userID string, includeAcknowledged bool) ([]model.Alert, error) {
+	ctx, span := startSpan(ctx, "AlertRepository.FindByUserID")
+	defer span.End()
+
+	query := `
+		SELECT
+			id, user_id, check_in_id, session_id,
+			severity, reason, source, acknowledged_at, created_at
+		FROM alerts
+		WHERE user_id = $1 AND ($2 OR acknowledged_at IS NULL)
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, includeAcknowledged)
+	if err != nil {
+		r.logger.Error("failed to find alerts", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to find alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var alerts []model.Alert
+	for rows.Next() {
+		var alert model.Alert
+		err := rows.Scan(
+			&alert.ID,
+			&alert.UserID,
+			&alert.CheckInID,
+			&alert.SessionID,
+			&alert.Severity,
+			&alert.Reason,
+			&alert.Source,
+			&alert.AcknowledgedAt,
+			&alert.CreatedAt,
+		)
+		if err != nil {
+			r.logger.Error("failed to scan alert", zap.Error(err))
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating alerts", zap.Error(err))
+		return nil, fmt.Errorf("error iterating alerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// Acknowledge marks an alert as reviewed
+func (r *AlertRepository) Acknowledge(ctx context.Context, alertID string) error {
+	ctx, span := startSpan(ctx, "AlertRepository.Acknowledge")
+	defer span.End()
+
+	query := `
+		UPDATE alerts
+		SET acknowledged_at = COALESCE(acknowledged_at, NOW())
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query, alertID)
+	if err != nil {
+		r.logger.Error("failed to acknowledge alert", zap.Error(err), zap.String("alert_id", alertID))
+		return fmt.Errorf("failed to acknowledge alert: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", ErrAlertNotFound, alertID)
+	}
+
+	return nil
+}
+
+// GetAlertSummary retrieves unacknowledged alert counts and the most recent open alerts
+func (r *AlertRepository) GetAlertSummary(ctx context.Context, userID string, limit int) (*AlertSummary, error) {
+	ctx, span := startSpan(ctx, "AlertRepository.GetAlertSummary")
+	defer span.End()
+
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE severity = 'critical')
+		FROM alerts
+		WHERE user_id = $1 AND acknowledged_at IS NULL
+	`
+
+	var summary AlertSummary
+	err := r.db.QueryRow(ctx, query, userID).Scan(&summary.Unacknowledged, &summary.Critical)
+	if err != nil {
+		r.logger.Error("failed to get alert summary", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get alert summary: %w", err)
+	}
+
+	open, err := r.FindByUserID(ctx, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(open) > limit {
+		open = open[:limit]
+	}
+	summary.Latest = open
+
+	return &summary, nil
+}
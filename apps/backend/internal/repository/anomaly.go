@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// AnomalyRepository manages detected health data anomalies and the recent readings
+// they are compared with
+type AnomalyRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewAnomalyRepository creates a new AnomalyRepository
+func NewAnomalyRepository(db *pgxpool.Pool, logger *zap.Logger) *AnomalyRepository {
+	return &AnomalyRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create saves a detected anomaly
+func (r *AnomalyRepository) Create(ctx context.Context, anomaly *model.Anomaly) error {
+	ctx, span := startSpan(ctx, "AnomalyRepository.Create")
+	defer span.End()
+
+	query := `
+		INSERT INTO anomalies (
+			id, user_id, metric, value, baseline, threshold,
+			severity, reason, source_type, source_id, observed_at, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW())
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		anomaly.ID,
+		anomaly.UserID,
+		anomaly.Metric,
+		anomaly.Value,
+		anomaly.Baseline,
+		anomaly.Threshold,
+		anomaly.Severity,
+		anomaly.Reason,
+		anomaly.SourceType,
+		anomaly.SourceID,
+		anomaly.ObservedAt,
+	).Scan(&anomaly.CreatedAt)
+	if err != nil {
+		r.logger.Error("failed to create anomaly",
+			zap.Error(err),
+			zap.String("user_id", anomaly.UserID),
+			zap.String("metric", anomaly.Metric),
+		)
+		return fmt.Errorf("failed to create anomaly: %w", err)
+	}
+
+	return nil
+}
+
+// FindPageByUserID retrieves a page of a user's anomalies observed at or after since,
+// newest first, and the total number of them
+func (r *AnomalyRepository) FindPageByUserID(ctx context.Context, userID string, since time.Time, page Page) ([]model.Anomaly, int, error) {
+	ctx, span := startSpan(ctx, "AnomalyRepository.FindPageByUserID")
+	defer span.End()
+
+	page = page.Normalize()
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM anomalies WHERE user_id = $1 AND observed_at >= $2`
+	if err := r.db.QueryRow(ctx, countQuery, userID, since).Scan(&total); err != nil {
+		r.logger.Error("failed to count anomalies", zap.Error(err), zap.String("user_id", userID))
+		return nil, 0, fmt.Errorf("failed to count anomalies: %w", err)
+	}
+
+	query := `
+		SELECT
+			id::text, user_id::text, metric, value, baseline, threshold,
+			severity, reason, source_type, source_id::text, observed_at, created_at
+		FROM anomalies
+		WHERE user_id = $1 AND observed_at >= $2
+		ORDER BY observed_at DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, since, page.Limit, page.Offset)
+	if err != nil {
+		r.logger.Error("failed to find anomalies", zap.Error(err), zap.String("user_id", userID))
+		return nil, 0, fmt.Errorf("failed to find anomalies: %w", err)
+	}
+	defer rows.Close()
+
+	var anomalies []model.Anomaly
+	for rows.Next() {
+		var anomaly model.Anomaly
+		err := rows.Scan(
+			&anomaly.ID,
+			&anomaly.UserID,
+			&anomaly.Metric,
+			&anomaly.Value,
+			&anomaly.Baseline,
+			&anomaly.Threshold,
+			&anomaly.Severity,
+			&anomaly.Reason,
+			&anomaly.SourceType,
+			&anomaly.SourceID,
+			&anomaly.ObservedAt,
+			&anomaly.CreatedAt,
+		)
+		if err != nil {
+			r.logger.Error("failed to scan anomaly", zap.Error(err))
+			return nil, 0, fmt.Errorf("failed to scan anomaly: %w", err)
+		}
+		anomalies = append(anomalies, anomaly)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating anomalies", zap.Error(err))
+		return nil, 0, fmt.Errorf("error iterating anomalies: %w", err)
+	}
+
+	return anomalies, total, nil
+}
+
+// RecentBloodPressure retrieves a user's latest blood pressure readings other than
+// excludeID, newest first
+func (r *AnomalyRepository) RecentBloodPressure(ctx context.Context, userID, excludeID string, limit int) ([]model.BloodPressureReading, error) {
+	ctx, span := startSpan(ctx, "AnomalyRepository.RecentBloodPressure")
+	defer span.End()
+
+	query := `
+		SELECT id::text, user_id::text, systolic, diastolic, pulse, measured_at, created_at
+		FROM blood_pressure_readings
+		WHERE user_id = $1 AND id::text <> $2
+		ORDER BY measured_at DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, excludeID, limit)
+	if err != nil {
+		r.logger.Error("failed to get recent blood pressure readings", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get recent blood pressure readings: %w", err)
+	}
+	defer rows.Close()
+
+	var readings []model.BloodPressureReading
+	for rows.Next() {
+		var reading model.BloodPressureReading
+		if err := rows.Scan(
+			&reading.ID,
+			&reading.UserID,
+			&reading.Systolic,
+			&reading.Diastolic,
+			&reading.Pulse,
+			&reading.MeasuredAt,
+			&reading.CreatedAt,
+		); err != nil {
+			r.logger.Error("failed to scan blood pressure reading", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan blood pressure reading: %w", err)
+		}
+		readings = append(readings, reading)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating blood pressure readings", zap.Error(err))
+		return nil, fmt.Errorf("error iterating blood pressure readings: %w", err)
+	}
+
+	return readings, nil
+}
+
+// RecentPainLevels retrieves the pain levels reported in a user's latest check-ins other
+// than excludeID, newest first. Check-ins without a pain level are skipped.
+func (r *AnomalyRepository) RecentPainLevels(ctx context.Context, userID, excludeID string, limit int) ([]int, error) {
+	ctx, span := startSpan(ctx, "AnomalyRepository.RecentPainLevels")
+	defer span.End()
+
+	query := `
+		SELECT pain_level
+		FROM health_check_ins
+		WHERE user_id = $1 AND id::text <> $2 AND pain_level IS NOT NULL
+		ORDER BY check_in_date DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, excludeID, limit)
+	if err != nil {
+		r.logger.Error("failed to get recent pain levels", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get recent pain levels: %w", err)
+	}
+	defer rows.Close()
+
+	var levels []int
+	for rows.Next() {
+		var level int
+		if err := rows.Scan(&level); err != nil {
+			r.logger.Error("failed to scan pain level", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan pain level: %w", err)
+		}
+		levels = append(levels, level)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating pain levels", zap.Error(err))
+		return nil, fmt.Errorf("error iterating pain levels: %w", err)
+	}
+
+	return levels, nil
+}
@@ -0,0 +1,368 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// ErrAuditArchiveNotFound is returned when a month of audit logs was not archived
+var ErrAuditArchiveNotFound = errors.New("audit archive not found")
+
+// auditArchiveColumns are the columns scanned by scanAuditArchive
+const auditArchiveColumns = `month, manifest_blob, row_count, archived_at, purged_at, restored_until`
+
+// AuditArchiveRepository tracks the months of audit logs moved to cold storage and
+// moves their rows out of and back into audit_logs
+type AuditArchiveRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewAuditArchiveRepository creates a new AuditArchiveRepository
+func NewAuditArchiveRepository(db *pgxpool.Pool, logger *zap.Logger) *AuditArchiveRepository {
+	return &AuditArchiveRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// FindArchivableMonths returns the first days of the months, oldest first, that have
+// audit logs before the given time and were not archived yet
+func (r *AuditArchiveRepository) FindArchivableMonths(ctx context.Context, before time.Time) ([]time.Time, error) {
+	ctx, span := startSpan(ctx, "AuditArchiveRepository.FindArchivableMonths")
+	defer span.End()
+
+	query := `
+		SELECT DISTINCT date_trunc('month', timestamp AT TIME ZONE 'UTC')::date AS month
+		FROM audit_logs
+		WHERE timestamp < $1 AND NOT restored
+			AND date_trunc('month', timestamp AT TIME ZONE 'UTC')::date NOT IN (SELECT month FROM audit_archives)
+		ORDER BY month
+	`
+
+	rows, err := r.db.Query(ctx, query, before)
+	if err != nil {
+		r.logger.Error("failed to find archivable audit log months", zap.Error(err))
+		return nil, fmt.Errorf("failed to find archivable audit log months: %w", err)
+	}
+	return r.scanMonths(rows)
+}
+
+// ListMonthLogs retrieves the audit logs of the month starting at month, oldest first,
+// leaving out restored copies
+func (r *AuditArchiveRepository) ListMonthLogs(ctx context.Context, month time.Time) ([]audit.AuditLog, error) {
+	ctx, span := startSpan(ctx, "AuditArchiveRepository.ListMonthLogs")
+	defer span.End()
+
+	query := `
+		SELECT id::text, user_id::text, operation_type, resource_type, resource_id::text,
+		       timestamp, COALESCE(ip_address, ''), COALESCE(user_agent, ''), additional_data
+		FROM audit_logs
+		WHERE timestamp >= $1 AND timestamp < $2 AND NOT restored
+		ORDER BY timestamp, id
+	`
+
+	rows, err := r.db.Query(ctx, query, month, month.AddDate(0, 1, 0))
+	if err != nil {
+		r.logger.Error("failed to list audit logs to archive", zap.Error(err), zap.Time("month", month))
+		return nil, fmt.Errorf("failed to list audit logs to archive: %w", err)
+	}
+	defer rows.Close()
+
+	var logs []audit.AuditLog
+	for rows.Next() {
+		var log audit.AuditLog
+		err := rows.Scan(
+			&log.ID,
+			&log.UserID,
+			&log.OperationType,
+			&log.ResourceType,
+			&log.ResourceID,
+			&log.Timestamp,
+			&log.IPAddress,
+			&log.UserAgent,
+			&log.AdditionalData,
+		)
+		if err != nil {
+			r.logger.Error("failed to scan audit log to archive", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan audit log to archive: %w", err)
+		}
+		logs = append(logs, log)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating audit logs to archive", zap.Error(err))
+		return nil, fmt.Errorf("error iterating audit logs to archive: %w", err)
+	}
+
+	return logs, nil
+}
+
+// CreateArchive records a month whose audit logs were written to blob storage
+func (r *AuditArchiveRepository) CreateArchive(ctx context.Context, archive *model.AuditArchive) error {
+	ctx, span := startSpan(ctx, "AuditArchiveRepository.CreateArchive")
+	defer span.End()
+
+	query := `
+		INSERT INTO audit_archives (month, manifest_blob, row_count, archived_at)
+		VALUES ($1, $2, $3, NOW())
+		RETURNING archived_at
+	`
+
+	err := r.db.QueryRow(ctx, query, archive.Month, archive.ManifestBlob, archive.RowCount).Scan(&archive.ArchivedAt)
+	if err != nil {
+		r.logger.Error("failed to record audit archive", zap.Error(err), zap.Time("month", archive.Month))
+		return fmt.Errorf("failed to record audit archive: %w", err)
+	}
+
+	return nil
+}
+
+// GetArchive retrieves the archive of the month starting at month
+func (r *AuditArchiveRepository) GetArchive(ctx context.Context, month time.Time) (*model.AuditArchive, error) {
+	ctx, span := startSpan(ctx, "AuditArchiveRepository.GetArchive")
+	defer span.End()
+
+	query := `SELECT ` + auditArchiveColumns + ` FROM audit_archives WHERE month = $1`
+
+	archive, err := scanAuditArchive(r.db.QueryRow(ctx, query, month))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAuditArchiveNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to get audit archive", zap.Error(err), zap.Time("month", month))
+		return nil, fmt.Errorf("failed to get audit archive: %w", err)
+	}
+
+	return archive, nil
+}
+
+// FindUnpurgedArchives retrieves the archives whose rows were not all deleted yet
+func (r *AuditArchiveRepository) FindUnpurgedArchives(ctx context.Context) ([]model.AuditArchive, error) {
+	ctx, span := startSpan(ctx, "AuditArchiveRepository.FindUnpurgedArchives")
+	defer span.End()
+
+	return r.findArchives(ctx, `WHERE purged_at IS NULL`)
+}
+
+// FindExpiredRestores retrieves the archives restored until now or earlier
+func (r *AuditArchiveRepository) FindExpiredRestores(ctx context.Context, now time.Time) ([]model.AuditArchive, error) {
+	ctx, span := startSpan(ctx, "AuditArchiveRepository.FindExpiredRestores")
+	defer span.End()
+
+	return r.findArchives(ctx, `WHERE restored_until <= $1`, now)
+}
+
+// findArchives retrieves the archives matching where, oldest month first
+func (r *AuditArchiveRepository) findArchives(ctx context.Context, where string, args ...interface{}) ([]model.AuditArchive, error) {
+	query := `SELECT ` + auditArchiveColumns + ` FROM audit_archives ` + where + ` ORDER BY month`
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("failed to find audit archives", zap.Error(err))
+		return nil, fmt.Errorf("failed to find audit archives: %w", err)
+	}
+	defer rows.Close()
+
+	var archives []model.AuditArchive
+	for rows.Next() {
+		archive, err := scanAuditArchive(rows)
+		if err != nil {
+			r.logger.Error("failed to scan audit archive", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan audit archive: %w", err)
+		}
+		archives = append(archives, *archive)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating audit archives", zap.Error(err))
+		return nil, fmt.Errorf("error iterating audit archives: %w", err)
+	}
+
+	return archives, nil
+}
+
+// FindArchivedMonths returns the first days of the archived months overlapping the
+// window from (inclusive) to (exclusive) that are not restored at now. Zero bounds do
+// not limit the window.
+func (r *AuditArchiveRepository) FindArchivedMonths(ctx context.Context, from, to, now time.Time) ([]time.Time, error) {
+	ctx, span := startSpan(ctx, "AuditArchiveRepository.FindArchivedMonths")
+	defer span.End()
+
+	conditions := []string{"(restored_until IS NULL OR restored_until <= $1)"}
+	args := []interface{}{now}
+	if !from.IsZero() {
+		args = append(args, from)
+		conditions = append(conditions, fmt.Sprintf("month + INTERVAL '1 month' > $%d", len(args)))
+	}
+	if !to.IsZero() {
+		args = append(args, to)
+		conditions = append(conditions, fmt.Sprintf("month < $%d", len(args)))
+	}
+	query := `SELECT month FROM audit_archives WHERE ` + strings.Join(conditions, " AND ") + ` ORDER BY month`
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("failed to find archived audit log months", zap.Error(err))
+		return nil, fmt.Errorf("failed to find archived audit log months: %w", err)
+	}
+	return r.scanMonths(rows)
+}
+
+// MarkPurged records that the archived rows of a month were deleted
+func (r *AuditArchiveRepository) MarkPurged(ctx context.Context, month time.Time) error {
+	ctx, span := startSpan(ctx, "AuditArchiveRepository.MarkPurged")
+	defer span.End()
+
+	if _, err := r.db.Exec(ctx, `UPDATE audit_archives SET purged_at = NOW() WHERE month = $1`, month); err != nil {
+		r.logger.Error("failed to mark audit archive purged", zap.Error(err), zap.Time("month", month))
+		return fmt.Errorf("failed to mark audit archive purged: %w", err)
+	}
+
+	return nil
+}
+
+// SetRestoredUntil records until when a month is restored, nil once its restored copies
+// were deleted
+func (r *AuditArchiveRepository) SetRestoredUntil(ctx context.Context, month time.Time, until *time.Time) error {
+	ctx, span := startSpan(ctx, "AuditArchiveRepository.SetRestoredUntil")
+	defer span.End()
+
+	if _, err := r.db.Exec(ctx, `UPDATE audit_archives SET restored_until = $2 WHERE month = $1`, month, until); err != nil {
+		r.logger.Error("failed to record audit archive restore", zap.Error(err), zap.Time("month", month))
+		return fmt.Errorf("failed to record audit archive restore: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAuditLogs deletes the audit logs with the given IDs, leaving restored copies,
+// and returns how many were deleted
+func (r *AuditArchiveRepository) DeleteAuditLogs(ctx context.Context, ids []string) (int64, error) {
+	ctx, span := startSpan(ctx, "AuditArchiveRepository.DeleteAuditLogs")
+	defer span.End()
+
+	result, err := r.db.Exec(ctx, `DELETE FROM audit_logs WHERE id = ANY($1::uuid[]) AND NOT restored`, ids)
+	if err != nil {
+		r.logger.Error("failed to delete archived audit logs", zap.Error(err), zap.Int("count", len(ids)))
+		return 0, fmt.Errorf("failed to delete archived audit logs: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// DeleteRestoredLogs deletes up to limit restored audit logs of the month starting at
+// month and returns how many were deleted
+func (r *AuditArchiveRepository) DeleteRestoredLogs(ctx context.Context, month time.Time, limit int) (int64, error) {
+	ctx, span := startSpan(ctx, "AuditArchiveRepository.DeleteRestoredLogs")
+	defer span.End()
+
+	query := `
+		DELETE FROM audit_logs WHERE id IN (
+			SELECT id FROM audit_logs
+			WHERE restored AND timestamp >= $1 AND timestamp < $2
+			LIMIT $3
+		)
+	`
+
+	result, err := r.db.Exec(ctx, query, month, month.AddDate(0, 1, 0), limit)
+	if err != nil {
+		r.logger.Error("failed to delete restored audit logs", zap.Error(err), zap.Time("month", month))
+		return 0, fmt.Errorf("failed to delete restored audit logs: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// InsertRestoredLogs copies archived audit logs back into audit_logs marked restored.
+// Logs still present are skipped. It returns how many were inserted.
+func (r *AuditArchiveRepository) InsertRestoredLogs(ctx context.Context, logs []audit.AuditLog) (int64, error) {
+	ctx, span := startSpan(ctx, "AuditArchiveRepository.InsertRestoredLogs")
+	defer span.End()
+
+	query := `
+		INSERT INTO audit_logs (
+			id, user_id, operation_type, resource_type, resource_id,
+			timestamp, ip_address, user_agent, additional_data, created_at, restored
+		) VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), $9, $6, TRUE)
+		ON CONFLICT (id) DO NOTHING
+	`
+
+	batch := &pgx.Batch{}
+	for _, log := range logs {
+		batch.Queue(query,
+			log.ID,
+			log.UserID,
+			log.OperationType,
+			log.ResourceType,
+			log.ResourceID,
+			log.Timestamp,
+			log.IPAddress,
+			log.UserAgent,
+			log.AdditionalData,
+		)
+	}
+
+	results := r.db.SendBatch(ctx, batch)
+	defer results.Close()
+
+	var inserted int64
+	for range logs {
+		tag, err := results.Exec()
+		if err != nil {
+			r.logger.Error("failed to restore audit logs", zap.Error(err))
+			return inserted, fmt.Errorf("failed to restore audit logs: %w", err)
+		}
+		inserted += tag.RowsAffected()
+	}
+
+	return inserted, nil
+}
+
+// scanMonths scans rows of a single month column and closes them
+func (r *AuditArchiveRepository) scanMonths(rows pgx.Rows) ([]time.Time, error) {
+	defer rows.Close()
+
+	var months []time.Time
+	for rows.Next() {
+		var month time.Time
+		if err := rows.Scan(&month); err != nil {
+			r.logger.Error("failed to scan audit log month", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan audit log month: %w", err)
+		}
+		months = append(months, month)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating audit log months", zap.Error(err))
+		return nil, fmt.Errorf("error iterating audit log months: %w", err)
+	}
+
+	return months, nil
+}
+
+// scanAuditArchive scans a row of auditArchiveColumns
+func scanAuditArchive(row pgx.Row) (*model.AuditArchive, error) {
+	var archive model.AuditArchive
+	err := row.Scan(
+		&archive.Month,
+		&archive.ManifestBlob,
+		&archive.RowCount,
+		&archive.ArchivedAt,
+		&archive.PurgedAt,
+		&archive.RestoredUntil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &archive, nil
+}
@@ -2,18 +2,25 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 	"go.uber.org/zap"
 )
 
+// ErrHealthCheckInNotFound is returned when a health check-in does not exist
+var ErrHealthCheckInNotFound = errors.New("health check-in not found")
+
 // CheckInRepository manages check-in session data
 type CheckInRepository struct {
 	db     *pgxpool.Pool
+	tx     pgx.Tx // set on a repository returned by withTx
+	reads  *ReadPools
 	logger *zap.Logger
 }
 
@@ -25,32 +32,112 @@ func NewCheckInRepository(db *pgxpool.Pool, logger *zap.Logger) *CheckInReposito
 	}
 }
 
+// withTx returns a repository whose session queries run in tx
+func (r *CheckInRepository) withTx(tx pgx.Tx) *CheckInRepository {
+	return &CheckInRepository{
+		db:     r.db,
+		tx:     tx,
+		reads:  r.reads,
+		logger: r.logger,
+	}
+}
+
+// sessions returns the transaction of the repository, or the pool outside one
+func (r *CheckInRepository) sessions() sessionQuerier {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.db
+}
+
+// SetReadPools lets the heavy reads of the repository use the read replica
+func (r *CheckInRepository) SetReadPools(reads *ReadPools) {
+	r.reads = reads
+}
+
 // CreateSession creates a new check-in session
 func (r *CheckInRepository) CreateSession(ctx context.Context, session *model.Session) error {
+	ctx, span := startSpan(ctx, "CheckInRepository.CreateSession")
+	defer span.End()
+
+	if err := insertSession(ctx, r.db, session); err != nil {
+		r.logger.Error("failed to create session", zap.Error(err), zap.String("session_id", session.ID))
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+// CreateSessionUnlessActive creates session unless its user already has an active
+// session, which it returns instead; it returns nil when session was created. The check
+// and the insert run in one transaction holding an advisory lock on the user, so
+// concurrent starts from two devices create a single session.
+func (r *CheckInRepository) CreateSessionUnlessActive(ctx context.Context, session *model.Session) (*model.Session, error) {
+	ctx, span := startSpan(ctx, "CheckInRepository.CreateSessionUnlessActive")
+	defer span.End()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, "check_in_session:"+session.UserID); err != nil {
+		r.logger.Error("failed to lock user sessions", zap.Error(err), zap.String("user_id", session.UserID))
+		return nil, fmt.Errorf("failed to lock user sessions: %w", err)
+	}
+
+	active, err := r.withTx(tx).GetActiveSessionByUserID(ctx, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return active, nil
+	}
+
+	if err := insertSession(ctx, tx, session); err != nil {
+		r.logger.Error("failed to create session", zap.Error(err), zap.String("session_id", session.ID))
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil, nil
+}
+
+// sessionQuerier is implemented by both the pool and a transaction
+type sessionQuerier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// insertSession inserts a check-in session
+func insertSession(ctx context.Context, db sessionQuerier, session *model.Session) error {
 	query := `
-		INSERT INTO check_in_sessions (id, user_id, started_at, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, NOW(), NOW())
+		INSERT INTO check_in_sessions (id, user_id, started_at, status, question_set_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
 	`
 
-	_, err := r.db.Exec(ctx, query,
+	_, err := db.Exec(ctx, query,
 		session.ID,
 		session.UserID,
 		session.StartedAt,
 		session.Status,
+		session.QuestionSetID,
 	)
-
-	if err != nil {
-		r.logger.Error("failed to create session", zap.Error(err), zap.String("session_id", session.ID))
-		return fmt.Errorf("failed to create session: %w", err)
-	}
-
-	return nil
+	return err
 }
 
 // GetSession retrieves a session by ID
 func (r *CheckInRepository) GetSession(ctx context.Context, sessionID string) (*model.Session, error) {
+	ctx, span := startSpan(ctx, "CheckInRepository.GetSession")
+	defer span.End()
+
 	query := `
-		SELECT id, user_id, started_at, completed_at, expired_at, status, created_at, updated_at
+		SELECT id, user_id, started_at, completed_at, expired_at, paused_at, resumed_at, status,
+			question_set_id::text, created_at, updated_at
 		FROM check_in_sessions
 		WHERE id = $1
 	`
@@ -63,7 +150,10 @@ func (r *CheckInRepository) GetSession(ctx context.Context, sessionID string) (*
 		&session.StartedAt,
 		&session.CompletedAt,
 		&session.ExpiredAt,
+		&session.PausedAt,
+		&session.ResumedAt,
 		&session.Status,
+		&session.QuestionSetID,
 		&createdAt,
 		&updatedAt,
 	)
@@ -79,17 +169,68 @@ func (r *CheckInRepository) GetSession(ctx context.Context, sessionID string) (*
 	return &session, nil
 }
 
+// GetActiveSessionByUserID retrieves a user's active session started, or last resumed,
+// within the last 30 minutes, the session timeout. It returns nil if there is none. The
+// window starts at resumed_at when set, not only at started_at, because the timeout of a
+// resumed session restarts on resume (Session.ActiveSince); otherwise a session resumed
+// after a long pause would not count and a second one could be started next to it.
+func (r *CheckInRepository) GetActiveSessionByUserID(ctx context.Context, userID string) (*model.Session, error) {
+	ctx, span := startSpan(ctx, "CheckInRepository.GetActiveSessionByUserID")
+	defer span.End()
+
+	query := `
+		SELECT id, user_id, started_at, completed_at, expired_at, paused_at, resumed_at, status,
+			question_set_id::text, created_at, updated_at
+		FROM check_in_sessions
+		WHERE user_id = $1 AND status = $2
+			AND COALESCE(resumed_at, started_at) > NOW() - INTERVAL '30 minutes'
+		ORDER BY started_at DESC
+		LIMIT 1
+	`
+
+	var session model.Session
+	var createdAt, updatedAt time.Time
+	err := r.sessions().QueryRow(ctx, query, userID, model.SessionStatusActive).Scan(
+		&session.ID,
+		&session.UserID,
+		&session.StartedAt,
+		&session.CompletedAt,
+		&session.ExpiredAt,
+		&session.PausedAt,
+		&session.ResumedAt,
+		&session.Status,
+		&session.QuestionSetID,
+		&createdAt,
+		&updatedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		r.logger.Error("failed to get active session", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get active session: %w", err)
+	}
+
+	return &session, nil
+}
+
 // UpdateSession updates an existing session
 func (r *CheckInRepository) UpdateSession(ctx context.Context, session *model.Session) error {
+	ctx, span := startSpan(ctx, "CheckInRepository.UpdateSession")
+	defer span.End()
+
 	query := `
 		UPDATE check_in_sessions
-		SET completed_at = $1, expired_at = $2, status = $3, updated_at = NOW()
-		WHERE id = $4
+		SET completed_at = $1, expired_at = $2, paused_at = $3, resumed_at = $4, status = $5, updated_at = NOW()
+		WHERE id = $6
 	`
 
 	result, err := r.db.Exec(ctx, query,
 		session.CompletedAt,
 		session.ExpiredAt,
+		session.PausedAt,
+		session.ResumedAt,
 		session.Status,
 		session.ID,
 	)
@@ -106,11 +247,99 @@ func (r *CheckInRepository) UpdateSession(ctx context.Context, session *model.Se
 	return nil
 }
 
+// ClaimSessionCompletion moves an active session to completing and reports whether this
+// call did so. Of concurrent completions of the same session exactly one succeeds.
+func (r *CheckInRepository) ClaimSessionCompletion(ctx context.Context, sessionID string) (bool, error) {
+	ctx, span := startSpan(ctx, "CheckInRepository.ClaimSessionCompletion")
+	defer span.End()
+
+	query := `
+		UPDATE check_in_sessions
+		SET status = $2, updated_at = NOW()
+		WHERE id = $1 AND status = $3
+		RETURNING id
+	`
+
+	var id string
+	err := r.db.QueryRow(ctx, query, sessionID, model.SessionStatusCompleting, model.SessionStatusActive).Scan(&id)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		r.logger.Error("failed to claim session completion", zap.Error(err), zap.String("session_id", sessionID))
+		return false, fmt.Errorf("failed to claim session completion: %w", err)
+	}
+
+	return true, nil
+}
+
+// ReleaseSessionCompletion returns a completing session to active after its completion
+// failed, so it can be completed again
+func (r *CheckInRepository) ReleaseSessionCompletion(ctx context.Context, sessionID string) error {
+	ctx, span := startSpan(ctx, "CheckInRepository.ReleaseSessionCompletion")
+	defer span.End()
+
+	query := `
+		UPDATE check_in_sessions
+		SET status = $2, updated_at = NOW()
+		WHERE id = $1 AND status = $3
+	`
+
+	if _, err := r.db.Exec(ctx, query, sessionID, model.SessionStatusActive, model.SessionStatusCompleting); err != nil {
+		r.logger.Error("failed to release session completion", zap.Error(err), zap.String("session_id", sessionID))
+		return fmt.Errorf("failed to release session completion: %w", err)
+	}
+
+	return nil
+}
+
+// RecoverStaleCompletions finishes sessions left completing for longer than staleAfter,
+// as happens when the server stops mid-completion. A session whose extracted check-in
+// was already saved is marked completed; any other is returned to active so it can be
+// completed again. It returns the number of recovered sessions.
+func (r *CheckInRepository) RecoverStaleCompletions(ctx context.Context, staleAfter time.Duration) (int64, error) {
+	ctx, span := startSpan(ctx, "CheckInRepository.RecoverStaleCompletions")
+	defer span.End()
+
+	query := `
+		WITH stale AS (
+			SELECT s.id, EXISTS (
+				SELECT 1 FROM health_check_ins h
+				WHERE h.session_id = s.id AND h.raw_transcript IS NULL
+			) AS saved
+			FROM check_in_sessions s
+			WHERE s.status = $3 AND s.updated_at < $4
+		)
+		UPDATE check_in_sessions s
+		SET status = CASE WHEN stale.saved THEN $1 ELSE $2 END,
+			completed_at = CASE WHEN stale.saved THEN NOW() ELSE s.completed_at END,
+			updated_at = NOW()
+		FROM stale
+		WHERE s.id = stale.id AND s.status = $3
+	`
+
+	tag, err := r.db.Exec(ctx, query,
+		model.SessionStatusCompleted,
+		model.SessionStatusActive,
+		model.SessionStatusCompleting,
+		time.Now().Add(-staleAfter),
+	)
+	if err != nil {
+		r.logger.Error("failed to recover stale session completions", zap.Error(err))
+		return 0, fmt.Errorf("failed to recover stale session completions: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
 // SaveConversationMessage saves a conversation message
 func (r *CheckInRepository) SaveConversationMessage(ctx context.Context, msg *model.Message) error {
+	ctx, span := startSpan(ctx, "CheckInRepository.SaveConversationMessage")
+	defer span.End()
+
 	query := `
-		INSERT INTO conversation_messages (id, session_id, role, content, audio_file_path, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO conversation_messages (id, session_id, role, content, audio_file_path, is_followup, created_at, storage_backend)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE(NULLIF($8, ''), 'default'))
 	`
 
 	_, err := r.db.Exec(ctx, query,
@@ -119,7 +348,9 @@ func (r *CheckInRepository) SaveConversationMessage(ctx context.Context, msg *mo
 		msg.Role,
 		msg.Content,
 		msg.AudioFilePath,
+		msg.IsFollowUp,
 		msg.CreatedAt,
+		msg.StorageBackend,
 	)
 
 	if err != nil {
@@ -136,8 +367,11 @@ func (r *CheckInRepository) SaveConversationMessage(ctx context.Context, msg *mo
 
 // GetConversationMessages retrieves all messages for a session
 func (r *CheckInRepository) GetConversationMessages(ctx context.Context, sessionID string) ([]model.Message, error) {
+	ctx, span := startSpan(ctx, "CheckInRepository.GetConversationMessages")
+	defer span.End()
+
 	query := `
-		SELECT id, session_id, role, content, audio_file_path, created_at
+		SELECT id, session_id, role, content, audio_file_path, is_followup, created_at
 		FROM conversation_messages
 		WHERE session_id = $1
 		ORDER BY created_at ASC
@@ -159,6 +393,7 @@ func (r *CheckInRepository) GetConversationMessages(ctx context.Context, session
 			&msg.Role,
 			&msg.Content,
 			&msg.AudioFilePath,
+			&msg.IsFollowUp,
 			&msg.CreatedAt,
 		)
 		if err != nil {
@@ -178,25 +413,88 @@ func (r *CheckInRepository) GetConversationMessages(ctx context.Context, session
 
 // SaveHealthCheckIn saves a completed health check-in
 func (r *CheckInRepository) SaveHealthCheckIn(ctx context.Context, checkIn *model.HealthCheckIn) error {
+	ctx, span := startSpan(ctx, "CheckInRepository.SaveHealthCheckIn")
+	defer span.End()
+
+	if err := insertHealthCheckIn(ctx, r.db, checkIn); err != nil {
+		r.logger.Error("failed to save health check-in",
+			zap.Error(err),
+			zap.String("check_in_id", checkIn.ID),
+			zap.String("user_id", checkIn.UserID),
+		)
+		return fmt.Errorf("failed to save health check-in: %w", err)
+	}
+
+	return nil
+}
+
+// SaveHealthCheckInAndCompleteSession saves checkIn and marks its session completed at
+// completedAt in one transaction, so a session is never completed without its check-in
+// nor left open with one
+func (r *CheckInRepository) SaveHealthCheckInAndCompleteSession(ctx context.Context, checkIn *model.HealthCheckIn, completedAt time.Time) error {
+	ctx, span := startSpan(ctx, "CheckInRepository.SaveHealthCheckInAndCompleteSession")
+	defer span.End()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertHealthCheckIn(ctx, tx, checkIn); err != nil {
+		r.logger.Error("failed to save health check-in",
+			zap.Error(err),
+			zap.String("check_in_id", checkIn.ID),
+			zap.String("user_id", checkIn.UserID),
+		)
+		return fmt.Errorf("failed to save health check-in: %w", err)
+	}
+
+	query := `
+		UPDATE check_in_sessions
+		SET status = $2, completed_at = $3, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	tag, err := tx.Exec(ctx, query, checkIn.SessionID, model.SessionStatusCompleted, completedAt)
+	if err != nil {
+		r.logger.Error("failed to complete session", zap.Error(err), zap.String("check_in_id", checkIn.ID))
+		return fmt.Errorf("failed to complete session: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("session not found for check-in: %s", checkIn.ID)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// insertHealthCheckIn inserts a health check-in
+func insertHealthCheckIn(ctx context.Context, db sessionQuerier, checkIn *model.HealthCheckIn) error {
 	query := `
 		INSERT INTO health_check_ins (
 			id, user_id, session_id, check_in_date,
 			symptoms, mood, pain_level, energy_level, sleep_quality,
 			medication_taken, physical_activity,
 			breakfast, lunch, dinner,
-			general_feeling, additional_notes, raw_transcript,
+			general_feeling, additional_notes, raw_transcript, low_confidence,
+			extraction_issues, extraction_prompt_version,
 			created_at, updated_at
 		) VALUES (
 			$1, $2, $3, $4,
 			$5, $6, $7, $8, $9,
 			$10, $11,
 			$12, $13, $14,
-			$15, $16, $17,
+			$15, $16, $17, $18,
+			$19, NULLIF($20, ''),
 			NOW(), NOW()
 		)
 	`
 
-	_, err := r.db.Exec(ctx, query,
+	_, err := db.Exec(ctx, query,
 		checkIn.ID,
 		checkIn.UserID,
 		checkIn.SessionID,
@@ -214,29 +512,26 @@ func (r *CheckInRepository) SaveHealthCheckIn(ctx context.Context, checkIn *mode
 		checkIn.GeneralFeeling,
 		checkIn.AdditionalNotes,
 		checkIn.RawTranscript,
+		checkIn.LowConfidence,
+		extractionIssues(checkIn),
+		checkIn.ExtractionPromptVersion,
 	)
-
-	if err != nil {
-		r.logger.Error("failed to save health check-in",
-			zap.Error(err),
-			zap.String("check_in_id", checkIn.ID),
-			zap.String("user_id", checkIn.UserID),
-		)
-		return fmt.Errorf("failed to save health check-in: %w", err)
-	}
-
-	return nil
+	return err
 }
 
 // GetHealthCheckInsByUserID retrieves health check-ins for a user
 func (r *CheckInRepository) GetHealthCheckInsByUserID(ctx context.Context, userID string) ([]model.HealthCheckIn, error) {
+	ctx, span := startSpan(ctx, "CheckInRepository.GetHealthCheckInsByUserID")
+	defer span.End()
+
 	query := `
 		SELECT 
 			id, user_id, session_id, check_in_date,
 			symptoms, mood, pain_level, energy_level, sleep_quality,
 			medication_taken, physical_activity,
 			breakfast, lunch, dinner,
-			general_feeling, additional_notes, raw_transcript,
+			general_feeling, additional_notes, raw_transcript, low_confidence,
+			extraction_issues, COALESCE(extraction_prompt_version, ''),
 			created_at, updated_at
 		FROM health_check_ins
 		WHERE user_id = $1
@@ -250,6 +545,182 @@ func (r *CheckInRepository) GetHealthCheckInsByUserID(ctx context.Context, userI
 	}
 	defer rows.Close()
 
+	return r.scanHealthCheckIns(rows)
+}
+
+// GetHealthCheckInsBySessionID retrieves the health check-ins saved for a session, newest first
+func (r *CheckInRepository) GetHealthCheckInsBySessionID(ctx context.Context, sessionID string) ([]model.HealthCheckIn, error) {
+	ctx, span := startSpan(ctx, "CheckInRepository.GetHealthCheckInsBySessionID")
+	defer span.End()
+
+	query := `
+		SELECT 
+			id, user_id, session_id, check_in_date,
+			symptoms, mood, pain_level, energy_level, sleep_quality,
+			medication_taken, physical_activity,
+			breakfast, lunch, dinner,
+			general_feeling, additional_notes, raw_transcript, low_confidence,
+			extraction_issues, COALESCE(extraction_prompt_version, ''),
+			created_at, updated_at
+		FROM health_check_ins
+		WHERE session_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, sessionID)
+	if err != nil {
+		r.logger.Error("failed to get health check-ins for session", zap.Error(err), zap.String("session_id", sessionID))
+		return nil, fmt.Errorf("failed to get health check-ins for session: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanHealthCheckIns(rows)
+}
+
+// GetHealthCheckIn retrieves a health check-in by ID
+func (r *CheckInRepository) GetHealthCheckIn(ctx context.Context, checkInID string) (*model.HealthCheckIn, error) {
+	ctx, span := startSpan(ctx, "CheckInRepository.GetHealthCheckIn")
+	defer span.End()
+
+	query := `
+		SELECT 
+			id, user_id, session_id, check_in_date,
+			symptoms, mood, pain_level, energy_level, sleep_quality,
+			medication_taken, physical_activity,
+			breakfast, lunch, dinner,
+			general_feeling, additional_notes, raw_transcript, low_confidence,
+			extraction_issues, COALESCE(extraction_prompt_version, ''),
+			created_at, updated_at
+		FROM health_check_ins
+		WHERE id = $1
+	`
+
+	rows, err := r.db.Query(ctx, query, checkInID)
+	if err != nil {
+		r.logger.Error("failed to get health check-in", zap.Error(err), zap.String("check_in_id", checkInID))
+		return nil, fmt.Errorf("failed to get health check-in: %w", err)
+	}
+	defer rows.Close()
+
+	checkIns, err := r.scanHealthCheckIns(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(checkIns) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrHealthCheckInNotFound, checkInID)
+	}
+
+	return &checkIns[0], nil
+}
+
+// UpdateHealthCheckIn stores corrected structured fields of an extracted check-in. The
+// raw transcript is never changed, and check-ins still awaiting extraction are not found.
+func (r *CheckInRepository) UpdateHealthCheckIn(ctx context.Context, checkIn *model.HealthCheckIn) error {
+	ctx, span := startSpan(ctx, "CheckInRepository.UpdateHealthCheckIn")
+	defer span.End()
+
+	query := `
+		UPDATE health_check_ins
+		SET symptoms = $2, mood = $3, pain_level = $4, energy_level = $5, sleep_quality = $6,
+			medication_taken = $7, physical_activity = $8,
+			breakfast = $9, lunch = $10, dinner = $11,
+			general_feeling = $12, additional_notes = $13, updated_at = NOW()
+		WHERE id = $1 AND raw_transcript IS NULL
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		checkIn.ID,
+		checkIn.Symptoms,
+		checkIn.Mood,
+		checkIn.PainLevel,
+		checkIn.EnergyLevel,
+		checkIn.SleepQuality,
+		checkIn.MedicationTaken,
+		checkIn.PhysicalActivity,
+		checkIn.Breakfast,
+		checkIn.Lunch,
+		checkIn.Dinner,
+		checkIn.GeneralFeeling,
+		checkIn.AdditionalNotes,
+	).Scan(&checkIn.UpdatedAt)
+
+	if err == pgx.ErrNoRows {
+		return fmt.Errorf("%w: %s", ErrHealthCheckInNotFound, checkIn.ID)
+	}
+	if err != nil {
+		r.logger.Error("failed to update health check-in",
+			zap.Error(err),
+			zap.String("check_in_id", checkIn.ID),
+		)
+		return fmt.Errorf("failed to update health check-in: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateHealthCheckInExtraction stores extracted fields on a check-in that was saved with
+// only its raw transcript and clears the transcript
+func (r *CheckInRepository) UpdateHealthCheckInExtraction(ctx context.Context, checkIn *model.HealthCheckIn) error {
+	ctx, span := startSpan(ctx, "CheckInRepository.UpdateHealthCheckInExtraction")
+	defer span.End()
+
+	query := `
+		UPDATE health_check_ins
+		SET symptoms = $2, mood = $3, pain_level = $4, energy_level = $5, sleep_quality = $6,
+			medication_taken = $7, physical_activity = $8,
+			breakfast = $9, lunch = $10, dinner = $11,
+			general_feeling = $12, additional_notes = $13, low_confidence = $14,
+			extraction_issues = $15, extraction_prompt_version = NULLIF($16, ''),
+			raw_transcript = NULL, updated_at = NOW()
+		WHERE id = $1 AND raw_transcript IS NOT NULL
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		checkIn.ID,
+		checkIn.Symptoms,
+		checkIn.Mood,
+		checkIn.PainLevel,
+		checkIn.EnergyLevel,
+		checkIn.SleepQuality,
+		checkIn.MedicationTaken,
+		checkIn.PhysicalActivity,
+		checkIn.Breakfast,
+		checkIn.Lunch,
+		checkIn.Dinner,
+		checkIn.GeneralFeeling,
+		checkIn.AdditionalNotes,
+		checkIn.LowConfidence,
+		extractionIssues(checkIn),
+		checkIn.ExtractionPromptVersion,
+	).Scan(&checkIn.UpdatedAt)
+
+	if err == pgx.ErrNoRows {
+		return fmt.Errorf("health check-in with raw transcript not found: %s", checkIn.ID)
+	}
+	if err != nil {
+		r.logger.Error("failed to update health check-in extraction",
+			zap.Error(err),
+			zap.String("check_in_id", checkIn.ID),
+		)
+		return fmt.Errorf("failed to update health check-in extraction: %w", err)
+	}
+
+	checkIn.RawTranscript = nil
+	return nil
+}
+
+// extractionIssues returns the check-in's extraction issues for the non-null column
+func extractionIssues(checkIn *model.HealthCheckIn) []string {
+	if checkIn.ExtractionIssues == nil {
+		return []string{}
+	}
+	return checkIn.ExtractionIssues
+}
+
+// scanHealthCheckIns reads health check-in rows in the column order of the check-in queries
+func (r *CheckInRepository) scanHealthCheckIns(rows pgx.Rows) ([]model.HealthCheckIn, error) {
 	var checkIns []model.HealthCheckIn
 	for rows.Next() {
 		var checkIn model.HealthCheckIn
@@ -271,6 +742,9 @@ func (r *CheckInRepository) GetHealthCheckInsByUserID(ctx context.Context, userI
 			&checkIn.GeneralFeeling,
 			&checkIn.AdditionalNotes,
 			&checkIn.RawTranscript,
+			&checkIn.LowConfidence,
+			&checkIn.ExtractionIssues,
+			&checkIn.ExtractionPromptVersion,
 			&checkIn.CreatedAt,
 			&checkIn.UpdatedAt,
 		)
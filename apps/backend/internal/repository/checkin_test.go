@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+func TestCreateSessionUnlessActive_ConcurrentStartsCreateOneSession(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewCheckInRepository(pool, zap.NewNop())
+	userID := createTestUser(t, pool)
+
+	const starts = 8
+	var wg sync.WaitGroup
+	created := make(chan string, starts)
+	existing := make(chan string, starts)
+	for i := 0; i < starts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			session := &model.Session{
+				ID:        uuid.New().String(),
+				UserID:    userID,
+				StartedAt: time.Now(),
+				Status:    model.SessionStatusActive,
+			}
+			active, err := repo.CreateSessionUnlessActive(ctx, session)
+			if !assert.NoError(t, err) {
+				return
+			}
+			if active == nil {
+				created <- session.ID
+			} else {
+				existing <- active.ID
+			}
+		}()
+	}
+	wg.Wait()
+	close(created)
+	close(existing)
+
+	require.Len(t, created, 1, "one start creates the session")
+	sessionID := <-created
+	for id := range existing {
+		assert.Equal(t, sessionID, id, "the other starts get the created session")
+	}
+
+	var count int
+	require.NoError(t, pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM check_in_sessions WHERE user_id = $1 AND status = 'active'`, userID,
+	).Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+func TestGetActiveSessionByUserID_CountsFromResume(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewCheckInRepository(pool, zap.NewNop())
+	userID := createTestUser(t, pool)
+
+	session := &model.Session{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		StartedAt: time.Now().Add(-2 * time.Hour),
+		Status:    model.SessionStatusActive,
+	}
+	require.NoError(t, repo.CreateSession(ctx, session))
+
+	active, err := repo.GetActiveSessionByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Nil(t, active, "a session started before the timeout is not active")
+
+	resumedAt := time.Now().Add(-5 * time.Minute)
+	session.ResumedAt = &resumedAt
+	require.NoError(t, repo.UpdateSession(ctx, session))
+
+	active, err = repo.GetActiveSessionByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.NotNil(t, active, "a session resumed within the timeout is active")
+	assert.Equal(t, session.ID, active.ID)
+}
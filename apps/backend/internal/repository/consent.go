@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// consentColumns are the columns scanned by scanConsent
+const consentColumns = `user_id, consent_type, granted, granted_at, revoked_at, updated_at`
+
+// ConsentRepository manages the consents users grant for processing their data
+type ConsentRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewConsentRepository creates a new ConsentRepository
+func NewConsentRepository(db *pgxpool.Pool, logger *zap.Logger) *ConsentRepository {
+	return &ConsentRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// SetConsent records whether a user grants a consent and returns its new state.
+// The grant or revocation time only changes when the state does, so repeating a
+// request keeps the original time.
+func (r *ConsentRepository) SetConsent(ctx context.Context, userID string, consentType model.ConsentType, granted bool) (*model.UserConsent, error) {
+	ctx, span := startSpan(ctx, "ConsentRepository.SetConsent")
+	defer span.End()
+
+	query := `
+		INSERT INTO user_consents (user_id, consent_type, granted, granted_at, revoked_at, updated_at)
+		VALUES (
+			$1, $2, $3,
+			CASE WHEN $3 THEN NOW() END,
+			CASE WHEN NOT $3 THEN NOW() END,
+			NOW()
+		)
+		ON CONFLICT (user_id, consent_type) DO UPDATE SET
+			granted = EXCLUDED.granted,
+			granted_at = CASE WHEN EXCLUDED.granted AND NOT user_consents.granted
+				THEN NOW() ELSE user_consents.granted_at END,
+			revoked_at = CASE WHEN NOT EXCLUDED.granted AND user_consents.granted
+				THEN NOW() ELSE user_consents.revoked_at END,
+			updated_at = NOW()
+		RETURNING ` + consentColumns
+
+	consent, err := scanConsent(r.db.QueryRow(ctx, query, userID, string(consentType), granted))
+	if err != nil {
+		r.logger.Error("failed to set consent",
+			zap.Error(err),
+			zap.String("user_id", userID),
+			zap.String("consent_type", string(consentType)),
+		)
+		return nil, fmt.Errorf("failed to set consent: %w", err)
+	}
+
+	return consent, nil
+}
+
+// GetConsentsByUserID retrieves every consent a user has answered
+func (r *ConsentRepository) GetConsentsByUserID(ctx context.Context, userID string) ([]model.UserConsent, error) {
+	ctx, span := startSpan(ctx, "ConsentRepository.GetConsentsByUserID")
+	defer span.End()
+
+	query := `SELECT ` + consentColumns + ` FROM user_consents WHERE user_id = $1 ORDER BY consent_type`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		r.logger.Error("failed to get consents", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get consents: %w", err)
+	}
+	defer rows.Close()
+
+	var consents []model.UserConsent
+	for rows.Next() {
+		consent, err := scanConsent(rows)
+		if err != nil {
+			r.logger.Error("failed to scan consent", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan consent: %w", err)
+		}
+		consents = append(consents, *consent)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating consents: %w", err)
+	}
+
+	return consents, nil
+}
+
+// HasConsent reports whether a user currently grants a consent. A consent that was
+// never answered is not granted.
+func (r *ConsentRepository) HasConsent(ctx context.Context, userID string, consentType model.ConsentType) (bool, error) {
+	ctx, span := startSpan(ctx, "ConsentRepository.HasConsent")
+	defer span.End()
+
+	query := `SELECT granted FROM user_consents WHERE user_id = $1 AND consent_type = $2`
+
+	var granted bool
+	err := r.db.QueryRow(ctx, query, userID, string(consentType)).Scan(&granted)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		r.logger.Error("failed to check consent",
+			zap.Error(err),
+			zap.String("user_id", userID),
+			zap.String("consent_type", string(consentType)),
+		)
+		return false, fmt.Errorf("failed to check consent: %w", err)
+	}
+
+	return granted, nil
+}
+
+// scanConsent scans a row selected with consentColumns
+func scanConsent(row pgx.Row) (*model.UserConsent, error) {
+	var consent model.UserConsent
+	var consentType string
+	err := row.Scan(
+		&consent.UserID,
+		&consentType,
+		&consent.Granted,
+		&consent.GrantedAt,
+		&consent.RevokedAt,
+		&consent.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	consent.ConsentType = model.ConsentType(consentType)
+	return &consent, nil
+}
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// ErrCycleSuggestionNotFound is returned when a cycle suggestion does not exist or belongs to another user
+var ErrCycleSuggestionNotFound = errors.New("cycle suggestion not found")
+
+// cycleSuggestionColumns are the columns scanned by scanCycleSuggestion
+const cycleSuggestionColumns = `
+	id, user_id, start_date, end_date, symptoms, check_in_ids::text[],
+	status, cycle_id::text, created_at, resolved_at
+`
+
+// CycleSuggestionRepository manages suggestions to log menstruation cycles
+type CycleSuggestionRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewCycleSuggestionRepository creates a new CycleSuggestionRepository
+func NewCycleSuggestionRepository(db *pgxpool.Pool, logger *zap.Logger) *CycleSuggestionRepository {
+	return &CycleSuggestionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateSuggestion saves an open cycle suggestion unless the user already has one
+// starting on the same day, and reports whether it was saved
+func (r *CycleSuggestionRepository) CreateSuggestion(ctx context.Context, suggestion *model.CycleSuggestion) (bool, error) {
+	ctx, span := startSpan(ctx, "CycleSuggestionRepository.CreateSuggestion")
+	defer span.End()
+
+	query := `
+		INSERT INTO cycle_suggestions (user_id, start_date, end_date, symptoms, check_in_ids, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (user_id, start_date) DO NOTHING
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		suggestion.UserID,
+		suggestion.StartDate,
+		suggestion.EndDate,
+		suggestion.Symptoms,
+		suggestion.CheckInIDs,
+		model.CycleSuggestionStatusOpen,
+	).Scan(&suggestion.ID, &suggestion.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		r.logger.Error("failed to create cycle suggestion", zap.Error(err), zap.String("user_id", suggestion.UserID))
+		return false, fmt.Errorf("failed to create cycle suggestion: %w", err)
+	}
+
+	suggestion.Status = model.CycleSuggestionStatusOpen
+	return true, nil
+}
+
+// GetSuggestionsByUserID retrieves every cycle suggestion of a user, including accepted
+// and dismissed ones, newest start first
+func (r *CycleSuggestionRepository) GetSuggestionsByUserID(ctx context.Context, userID string) ([]model.CycleSuggestion, error) {
+	ctx, span := startSpan(ctx, "CycleSuggestionRepository.GetSuggestionsByUserID")
+	defer span.End()
+
+	query := `SELECT ` + cycleSuggestionColumns + `
+		FROM cycle_suggestions
+		WHERE user_id = $1
+		ORDER BY start_date DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		r.logger.Error("failed to get cycle suggestions", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get cycle suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	var suggestions []model.CycleSuggestion
+	for rows.Next() {
+		suggestion, err := scanCycleSuggestion(rows)
+		if err != nil {
+			r.logger.Error("failed to scan cycle suggestion", zap.Error(err))
+			continue
+		}
+		suggestions = append(suggestions, *suggestion)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating cycle suggestions", zap.Error(err))
+		return nil, fmt.Errorf("error iterating cycle suggestions: %w", err)
+	}
+
+	return suggestions, nil
+}
+
+// GetSuggestion retrieves one of the user's cycle suggestions
+func (r *CycleSuggestionRepository) GetSuggestion(ctx context.Context, userID, suggestionID string) (*model.CycleSuggestion, error) {
+	ctx, span := startSpan(ctx, "CycleSuggestionRepository.GetSuggestion")
+	defer span.End()
+
+	query := `SELECT ` + cycleSuggestionColumns + `
+		FROM cycle_suggestions
+		WHERE id = $1 AND user_id = $2
+	`
+
+	suggestion, err := scanCycleSuggestion(r.db.QueryRow(ctx, query, suggestionID, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrCycleSuggestionNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to get cycle suggestion", zap.Error(err), zap.String("suggestion_id", suggestionID))
+		return nil, fmt.Errorf("failed to get cycle suggestion: %w", err)
+	}
+
+	return suggestion, nil
+}
+
+// DismissSuggestion dismisses one of the user's open cycle suggestions and reports
+// whether the suggestion exists. Dismissing a resolved suggestion leaves it unchanged.
+func (r *CycleSuggestionRepository) DismissSuggestion(ctx context.Context, userID, suggestionID string, now time.Time) (bool, error) {
+	ctx, span := startSpan(ctx, "CycleSuggestionRepository.DismissSuggestion")
+	defer span.End()
+
+	query := `
+		UPDATE cycle_suggestions
+		SET status = CASE WHEN status = $3 THEN $4 ELSE status END,
+			resolved_at = COALESCE(resolved_at, $5)
+		WHERE id = $1 AND user_id = $2
+	`
+
+	tag, err := r.db.Exec(ctx, query, suggestionID, userID,
+		model.CycleSuggestionStatusOpen, model.CycleSuggestionStatusDismissed, now)
+	if err != nil {
+		r.logger.Error("failed to dismiss cycle suggestion", zap.Error(err), zap.String("suggestion_id", suggestionID))
+		return false, fmt.Errorf("failed to dismiss cycle suggestion: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// AcceptSuggestion saves cycle and marks the open suggestion accepted with it in one
+// transaction. It reports false, saving nothing, when the suggestion is not open.
+func (r *CycleSuggestionRepository) AcceptSuggestion(ctx context.Context, suggestionID string, cycle *model.MenstruationCycle, now time.Time) (bool, error) {
+	ctx, span := startSpan(ctx, "CycleSuggestionRepository.AcceptSuggestion")
+	defer span.End()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertMenstruation(ctx, tx, cycle); err != nil {
+		r.logger.Error("failed to save suggested cycle", zap.Error(err), zap.String("suggestion_id", suggestionID))
+		return false, fmt.Errorf("failed to save suggested cycle: %w", err)
+	}
+
+	query := `
+		UPDATE cycle_suggestions
+		SET status = $3, cycle_id = $4, resolved_at = $5
+		WHERE id = $1 AND user_id = $2 AND status = $6
+	`
+
+	tag, err := tx.Exec(ctx, query, suggestionID, cycle.UserID,
+		model.CycleSuggestionStatusAccepted, cycle.ID, now, model.CycleSuggestionStatusOpen)
+	if err != nil {
+		r.logger.Error("failed to accept cycle suggestion", zap.Error(err), zap.String("suggestion_id", suggestionID))
+		return false, fmt.Errorf("failed to accept cycle suggestion: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+// scanCycleSuggestion scans a row selected with cycleSuggestionColumns
+func scanCycleSuggestion(row pgx.Row) (*model.CycleSuggestion, error) {
+	var suggestion model.CycleSuggestion
+	err := row.Scan(
+		&suggestion.ID,
+		&suggestion.UserID,
+		&suggestion.StartDate,
+		&suggestion.EndDate,
+		&suggestion.Symptoms,
+		&suggestion.CheckInIDs,
+		&suggestion.Status,
+		&suggestion.CycleID,
+		&suggestion.CreatedAt,
+		&suggestion.ResolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &suggestion, nil
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 	"go.uber.org/zap"
@@ -13,6 +15,7 @@ import (
 // DashboardRepository manages dashboard data aggregations
 type DashboardRepository struct {
 	db     *pgxpool.Pool
+	reads  *ReadPools
 	logger *zap.Logger
 }
 
@@ -24,42 +27,52 @@ func NewDashboardRepository(db *pgxpool.Pool, logger *zap.Logger) *DashboardRepo
 	}
 }
 
+// SetReadPools lets the heavy reads of the repository use the read replica
+func (r *DashboardRepository) SetReadPools(reads *ReadPools) {
+	r.reads = reads
+}
+
 // AggregatedMetrics represents aggregated health metrics
 type AggregatedMetrics struct {
 	AveragePainLevel float64
 	MoodDistribution map[string]int
 	EnergyLevels     map[string]int
+	MedicationTaken  map[string]int // counts per stored medication_taken value
 	CheckInCount     int
+	LowConfidence    int // check-ins whose extraction was flagged as low confidence
 }
 
 // DailyMetrics represents health metrics for a single day
 type DailyMetrics struct {
-	Date            time.Time
-	PainLevel       *int
-	Mood            *string
-	EnergyLevel     *string
-	SleepQuality    *string
-	MedicationTaken *string
-	SymptomCount    int
-	ActivityCount   int
+	Date            time.Time `json:"date"`
+	PainLevel       *int      `json:"pain_level"`
+	Mood            *string   `json:"mood"`
+	EnergyLevel     *string   `json:"energy_level"`
+	SleepQuality    *string   `json:"sleep_quality"`
+	MedicationTaken *string   `json:"medication_taken"`
+	SymptomCount    int       `json:"symptom_count"`
+	ActivityCount   int       `json:"activity_count"`
 }
 
 // GetHealthCheckIns retrieves health check-ins for a user within a date range
 func (r *DashboardRepository) GetHealthCheckIns(ctx context.Context, userID string, startDate, endDate time.Time) ([]model.HealthCheckIn, error) {
+	ctx, span := startSpan(ctx, "DashboardRepository.GetHealthCheckIns")
+	defer span.End()
+
 	query := `
 		SELECT 
 			id, user_id, session_id, check_in_date,
 			symptoms, mood, pain_level, energy_level, sleep_quality,
 			medication_taken, physical_activity,
 			breakfast, lunch, dinner,
-			general_feeling, additional_notes, raw_transcript,
+			general_feeling, additional_notes, raw_transcript, low_confidence,
 			created_at, updated_at
 		FROM health_check_ins
 		WHERE user_id = $1 AND check_in_date >= $2 AND check_in_date <= $3
 		ORDER BY check_in_date DESC
 	`
 
-	rows, err := r.db.Query(ctx, query, userID, startDate, endDate)
+	rows, err := readDB(ctx, r.reads, r.db).Query(ctx, query, userID, startDate, endDate)
 	if err != nil {
 		r.logger.Error("failed to get health check-ins for dashboard",
 			zap.Error(err),
@@ -90,6 +103,7 @@ func (r *DashboardRepository) GetHealthCheckIns(ctx context.Context, userID stri
 			&checkIn.GeneralFeeling,
 			&checkIn.AdditionalNotes,
 			&checkIn.RawTranscript,
+			&checkIn.LowConfidence,
 			&checkIn.CreatedAt,
 			&checkIn.UpdatedAt,
 		)
@@ -110,20 +124,32 @@ func (r *DashboardRepository) GetHealthCheckIns(ctx context.Context, userID stri
 
 // GetAggregatedMetrics computes aggregated metrics for a user over a time period
 func (r *DashboardRepository) GetAggregatedMetrics(ctx context.Context, userID string, days int) (*AggregatedMetrics, error) {
-	startDate := time.Now().AddDate(0, 0, -days)
+	ctx, span := startSpan(ctx, "DashboardRepository.GetAggregatedMetrics")
+	defer span.End()
+
+	now := time.Now()
+	return r.GetAggregatedMetricsForPeriod(ctx, userID, now.AddDate(0, 0, -days), now)
+}
+
+// GetAggregatedMetricsForPeriod computes aggregated metrics for a user over the check-ins
+// dated from start up to, but excluding, end
+func (r *DashboardRepository) GetAggregatedMetricsForPeriod(ctx context.Context, userID string, start, end time.Time) (*AggregatedMetrics, error) {
+	ctx, span := startSpan(ctx, "DashboardRepository.GetAggregatedMetricsForPeriod")
+	defer span.End()
 
 	query := `
 		SELECT 
 			AVG(CASE WHEN pain_level IS NOT NULL THEN pain_level ELSE 0 END) as avg_pain,
 			COUNT(*) as check_in_count,
+			COUNT(*) FILTER (WHERE low_confidence) as low_confidence_count,
 			mood,
 			energy_level
 		FROM health_check_ins
-		WHERE user_id = $1 AND check_in_date >= $2
+		WHERE user_id = $1 AND check_in_date >= $2 AND check_in_date < $3
 		GROUP BY mood, energy_level
 	`
 
-	rows, err := r.db.Query(ctx, query, userID, startDate)
+	rows, err := readDB(ctx, r.reads, r.db).Query(ctx, query, userID, start, end)
 	if err != nil {
 		r.logger.Error("failed to get aggregated metrics",
 			zap.Error(err),
@@ -143,10 +169,10 @@ func (r *DashboardRepository) GetAggregatedMetrics(ctx context.Context, userID s
 
 	for rows.Next() {
 		var avgPain float64
-		var count int
+		var count, lowConfidence int
 		var mood, energyLevel *string
 
-		err := rows.Scan(&avgPain, &count, &mood, &energyLevel)
+		err := rows.Scan(&avgPain, &count, &lowConfidence, &mood, &energyLevel)
 		if err != nil {
 			r.logger.Error("failed to scan aggregated metrics", zap.Error(err))
 			continue
@@ -158,6 +184,7 @@ func (r *DashboardRepository) GetAggregatedMetrics(ctx context.Context, userID s
 		}
 
 		metrics.CheckInCount += count
+		metrics.LowConfidence += lowConfidence
 
 		if mood != nil && *mood != "" {
 			metrics.MoodDistribution[*mood] += count
@@ -177,11 +204,112 @@ func (r *DashboardRepository) GetAggregatedMetrics(ctx context.Context, userID s
 		return nil, fmt.Errorf("error iterating aggregated metrics: %w", err)
 	}
 
+	metrics.MedicationTaken, err = r.getMedicationTakenCounts(ctx, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
 	return metrics, nil
 }
 
+// getMedicationTakenCounts counts check-ins per medication_taken value dated from start up
+// to, but excluding, end
+func (r *DashboardRepository) getMedicationTakenCounts(ctx context.Context, userID string, start, end time.Time) (map[string]int, error) {
+	query := `
+		SELECT medication_taken, COUNT(*)
+		FROM health_check_ins
+		WHERE user_id = $1 AND check_in_date >= $2 AND check_in_date < $3
+			AND medication_taken IS NOT NULL AND medication_taken <> ''
+		GROUP BY medication_taken
+	`
+
+	rows, err := readDB(ctx, r.reads, r.db).Query(ctx, query, userID, start, end)
+	if err != nil {
+		r.logger.Error("failed to get medication taken counts",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		return nil, fmt.Errorf("failed to get medication taken counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var value string
+		var count int
+		if err := rows.Scan(&value, &count); err != nil {
+			r.logger.Error("failed to scan medication taken count", zap.Error(err))
+			continue
+		}
+		counts[value] += count
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating medication taken counts", zap.Error(err))
+		return nil, fmt.Errorf("error iterating medication taken counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// MedicationDoseCount is a medication with the number of doses logged as taken in a window
+type MedicationDoseCount struct {
+	Medication model.Medication
+	Taken      int
+}
+
+// GetMedicationDoseCounts counts the doses logged as taken from start through end for each
+// active medication of a user prescribed at some point in that window
+func (r *DashboardRepository) GetMedicationDoseCounts(ctx context.Context, userID string, start, end time.Time) ([]MedicationDoseCount, error) {
+	ctx, span := startSpan(ctx, "DashboardRepository.GetMedicationDoseCounts")
+	defer span.End()
+
+	query := `
+		SELECT m.id, m.name, m.frequency, m.start_date, m.end_date, COUNT(l.id)
+		FROM medications m
+		LEFT JOIN medication_logs l ON l.medication_id = m.id
+			AND l.adherence AND l.taken_at >= $2 AND l.taken_at <= $3
+		WHERE m.user_id = $1 AND m.active AND m.deleted_at IS NULL
+			AND m.start_date <= $3 AND (m.end_date IS NULL OR m.end_date >= $2::date)
+		GROUP BY m.id
+		ORDER BY m.name, m.id
+	`
+
+	rows, err := readDB(ctx, r.reads, r.db).Query(ctx, query, userID, start, end)
+	if err != nil {
+		r.logger.Error("failed to get medication dose counts",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		return nil, fmt.Errorf("failed to get medication dose counts: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []MedicationDoseCount
+	for rows.Next() {
+		count := MedicationDoseCount{Medication: model.Medication{UserID: userID, Active: true}}
+		med := &count.Medication
+		err := rows.Scan(&med.ID, &med.Name, &med.Frequency, &med.StartDate, &med.EndDate, &count.Taken)
+		if err != nil {
+			r.logger.Error("failed to scan medication dose count", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan medication dose count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating medication dose counts", zap.Error(err))
+		return nil, fmt.Errorf("error iterating medication dose counts: %w", err)
+	}
+
+	return counts, nil
+}
+
 // GetDailyMetrics retrieves daily metrics for time-series data
 func (r *DashboardRepository) GetDailyMetrics(ctx context.Context, userID string, days int) ([]DailyMetrics, error) {
+	ctx, span := startSpan(ctx, "DashboardRepository.GetDailyMetrics")
+	defer span.End()
+
 	startDate := time.Now().AddDate(0, 0, -days)
 
 	query := `
@@ -199,7 +327,7 @@ func (r *DashboardRepository) GetDailyMetrics(ctx context.Context, userID string
 		ORDER BY check_in_date ASC
 	`
 
-	rows, err := r.db.Query(ctx, query, userID, startDate)
+	rows, err := readDB(ctx, r.reads, r.db).Query(ctx, query, userID, startDate)
 	if err != nil {
 		r.logger.Error("failed to get daily metrics",
 			zap.Error(err),
@@ -237,62 +365,183 @@ func (r *DashboardRepository) GetDailyMetrics(ctx context.Context, userID string
 	return dailyMetrics, nil
 }
 
-// SaveReport saves a report record
-func (r *DashboardRepository) SaveReport(ctx context.Context, report *model.Report) error {
+// reportExecer is implemented by both the pool and a transaction
+type reportExecer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// insertPendingReport saves the record of a report whose generation is being queued
+func insertPendingReport(ctx context.Context, db reportExecer, report *model.Report) error {
 	query := `
 		INSERT INTO reports (
-			id, user_id, start_date, end_date,
-			file_path, status, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+			id, user_id, start_date, end_date, status, format, sections, encrypted,
+			created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
 	`
 
-	status := "completed" // Default status for generated reports
+	format := report.Format
+	if format == "" {
+		format = model.ReportFormatPDF
+	}
+	sections := report.Sections
+	if len(sections) == 0 {
+		sections = model.AllReportSections
+	}
 
-	_, err := r.db.Exec(ctx, query,
+	_, err := db.Exec(ctx, query,
 		report.ID,
 		report.UserID,
 		report.DateRangeStart,
 		report.DateRangeEnd,
+		model.ReportStatusPending,
+		format,
+		reportSectionNames(sections),
+		report.Encrypted,
+	)
+	return err
+}
+
+// MarkReportProcessing records that a worker has started generating a report
+func (r *DashboardRepository) MarkReportProcessing(ctx context.Context, reportID string) error {
+	ctx, span := startSpan(ctx, "DashboardRepository.MarkReportProcessing")
+	defer span.End()
+
+	query := `
+		UPDATE reports
+		SET status = $2, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, reportID, model.ReportStatusProcessing); err != nil {
+		r.logger.Error("failed to mark report processing", zap.Error(err), zap.String("report_id", reportID))
+		return fmt.Errorf("failed to mark report processing: %w", err)
+	}
+
+	return nil
+}
+
+// CompleteReport stores the blob path, size and fingerprint of a generated report and marks it completed
+func (r *DashboardRepository) CompleteReport(ctx context.Context, report *model.Report) error {
+	ctx, span := startSpan(ctx, "DashboardRepository.CompleteReport")
+	defer span.End()
+
+	query := `
+		UPDATE reports
+		SET status = $2, file_path = $3, size_bytes = $4,
+			sha256 = NULLIF($5, ''), verification_code = NULLIF($6, ''),
+			storage_backend = COALESCE(NULLIF($7, ''), 'default'),
+			error_message = NULL, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		report.ID,
+		model.ReportStatusCompleted,
 		report.FilePath,
-		status,
+		report.SizeBytes,
+		report.SHA256,
+		report.VerificationCode,
+		report.StorageBackend,
 	)
 
 	if err != nil {
-		r.logger.Error("failed to save report",
-			zap.Error(err),
-			zap.String("report_id", report.ID),
-			zap.String("user_id", report.UserID),
-		)
-		return fmt.Errorf("failed to save report: %w", err)
+		r.logger.Error("failed to complete report", zap.Error(err), zap.String("report_id", report.ID))
+		return fmt.Errorf("failed to complete report: %w", err)
 	}
 
 	return nil
 }
 
+// FailReport marks a report as failed with the reason shown to the user
+func (r *DashboardRepository) FailReport(ctx context.Context, reportID, message string) error {
+	ctx, span := startSpan(ctx, "DashboardRepository.FailReport")
+	defer span.End()
+
+	query := `
+		UPDATE reports
+		SET status = $2, error_message = $3, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, reportID, model.ReportStatusFailed, message); err != nil {
+		r.logger.Error("failed to mark report failed", zap.Error(err), zap.String("report_id", reportID))
+		return fmt.Errorf("failed to mark report failed: %w", err)
+	}
+
+	return nil
+}
+
+// FailUnfinishedReports marks every pending or processing report without a pending or
+// processing job as failed with message and returns how many were affected. Such reports
+// were queued in memory by a server that stopped, or lost their job otherwise, and no
+// worker will generate them.
+func (r *DashboardRepository) FailUnfinishedReports(ctx context.Context, message string) (int64, error) {
+	ctx, span := startSpan(ctx, "DashboardRepository.FailUnfinishedReports")
+	defer span.End()
+
+	query := `
+		UPDATE reports
+		SET status = $1, error_message = $2, updated_at = NOW()
+		WHERE status IN ($3, $4)
+			AND NOT EXISTS (
+				SELECT 1 FROM reports_jobs
+				WHERE reports_jobs.report_id = reports.id AND reports_jobs.status IN ($3, $4)
+			)
+	`
+
+	tag, err := r.db.Exec(ctx, query,
+		model.ReportStatusFailed,
+		message,
+		model.ReportStatusPending,
+		model.ReportStatusProcessing,
+	)
+	if err != nil {
+		r.logger.Error("failed to fail unfinished reports", zap.Error(err))
+		return 0, fmt.Errorf("failed to fail unfinished reports: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
 // GetReportByID retrieves a report by ID
 func (r *DashboardRepository) GetReportByID(ctx context.Context, reportID string) (*model.Report, error) {
+	ctx, span := startSpan(ctx, "DashboardRepository.GetReportByID")
+	defer span.End()
+
 	query := `
 		SELECT 
 			id, user_id, start_date, end_date,
-			file_path, created_at
+			file_path, status, format, sections, encrypted, COALESCE(error_message, ''), size_bytes, created_at,
+			COALESCE(sha256, ''), COALESCE(verification_code, ''), storage_backend
 		FROM reports
 		WHERE id = $1
 	`
 
 	var report model.Report
+	var sections []string
 	err := r.db.QueryRow(ctx, query, reportID).Scan(
 		&report.ID,
 		&report.UserID,
 		&report.DateRangeStart,
 		&report.DateRangeEnd,
 		&report.FilePath,
+		&report.Status,
+		&report.Format,
+		&sections,
+		&report.Encrypted,
+		&report.ErrorMessage,
+		&report.SizeBytes,
 		&report.CreatedAt,
+		&report.SHA256,
+		&report.VerificationCode,
+		&report.StorageBackend,
 	)
 
 	if err != nil {
 		r.logger.Error("failed to get report", zap.Error(err), zap.String("report_id", reportID))
 		return nil, fmt.Errorf("failed to get report: %w", err)
 	}
+	report.Sections = reportSections(sections)
 
 	// Set GeneratedAt to CreatedAt for compatibility
 	report.GeneratedAt = report.CreatedAt
@@ -300,12 +549,62 @@ func (r *DashboardRepository) GetReportByID(ctx context.Context, reportID string
 	return &report, nil
 }
 
+// FindReportByFingerprint finds the most recent report whose verification code or, when
+// fullHash is set, whose SHA-256 matches value. It returns nil when no report matches.
+func (r *DashboardRepository) FindReportByFingerprint(ctx context.Context, value string, fullHash bool) (*model.Report, error) {
+	ctx, span := startSpan(ctx, "DashboardRepository.FindReportByFingerprint")
+	defer span.End()
+
+	column := "verification_code"
+	if fullHash {
+		column = "sha256"
+	}
+	query := fmt.Sprintf(`
+		SELECT 
+			id, user_id, start_date, end_date,
+			file_path, created_at,
+			COALESCE(sha256, ''), COALESCE(verification_code, '')
+		FROM reports
+		WHERE %s = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, column)
+
+	var report model.Report
+	err := r.db.QueryRow(ctx, query, value).Scan(
+		&report.ID,
+		&report.UserID,
+		&report.DateRangeStart,
+		&report.DateRangeEnd,
+		&report.FilePath,
+		&report.CreatedAt,
+		&report.SHA256,
+		&report.VerificationCode,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		r.logger.Error("failed to find report by fingerprint", zap.Error(err))
+		return nil, fmt.Errorf("failed to find report by fingerprint: %w", err)
+	}
+
+	report.GeneratedAt = report.CreatedAt
+
+	return &report, nil
+}
+
 // GetReportsByUserID retrieves all reports for a user
 func (r *DashboardRepository) GetReportsByUserID(ctx context.Context, userID string) ([]model.Report, error) {
+	ctx, span := startSpan(ctx, "DashboardRepository.GetReportsByUserID")
+	defer span.End()
+
 	query := `
 		SELECT 
 			id, user_id, start_date, end_date,
-			file_path, created_at
+			file_path, status, format, sections, encrypted, COALESCE(error_message, ''), created_at,
+			COALESCE(sha256, ''), COALESCE(verification_code, '')
 		FROM reports
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -321,18 +620,27 @@ func (r *DashboardRepository) GetReportsByUserID(ctx context.Context, userID str
 	var reports []model.Report
 	for rows.Next() {
 		var report model.Report
+		var sections []string
 		err := rows.Scan(
 			&report.ID,
 			&report.UserID,
 			&report.DateRangeStart,
 			&report.DateRangeEnd,
 			&report.FilePath,
+			&report.Status,
+			&report.Format,
+			&sections,
+			&report.Encrypted,
+			&report.ErrorMessage,
 			&report.CreatedAt,
+			&report.SHA256,
+			&report.VerificationCode,
 		)
 		if err != nil {
 			r.logger.Error("failed to scan report", zap.Error(err))
 			continue
 		}
+		report.Sections = reportSections(sections)
 		// Set GeneratedAt to CreatedAt for compatibility
 		report.GeneratedAt = report.CreatedAt
 		reports = append(reports, report)
@@ -345,3 +653,100 @@ func (r *DashboardRepository) GetReportsByUserID(ctx context.Context, userID str
 
 	return reports, nil
 }
+
+// ListReportsByUserID retrieves one page of a user's reports, newest first, with the
+// total number of reports
+func (r *DashboardRepository) ListReportsByUserID(ctx context.Context, userID string, page Page) ([]model.Report, int, error) {
+	ctx, span := startSpan(ctx, "DashboardRepository.ListReportsByUserID")
+	defer span.End()
+
+	page = page.Normalize()
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM reports WHERE user_id = $1`, userID).Scan(&total); err != nil {
+		r.logger.Error("failed to count reports", zap.Error(err), zap.String("user_id", userID))
+		return nil, 0, fmt.Errorf("failed to count reports: %w", err)
+	}
+
+	query := `
+		SELECT 
+			id, user_id, start_date, end_date,
+			status, format, sections, encrypted, COALESCE(error_message, ''), size_bytes, created_at
+		FROM reports
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, page.Limit, page.Offset)
+	if err != nil {
+		r.logger.Error("failed to list reports", zap.Error(err), zap.String("user_id", userID))
+		return nil, 0, fmt.Errorf("failed to list reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []model.Report
+	for rows.Next() {
+		var report model.Report
+		var sections []string
+		err := rows.Scan(
+			&report.ID,
+			&report.UserID,
+			&report.DateRangeStart,
+			&report.DateRangeEnd,
+			&report.Status,
+			&report.Format,
+			&sections,
+			&report.Encrypted,
+			&report.ErrorMessage,
+			&report.SizeBytes,
+			&report.CreatedAt,
+		)
+		if err != nil {
+			r.logger.Error("failed to scan report", zap.Error(err))
+			continue
+		}
+		report.Sections = reportSections(sections)
+		report.GeneratedAt = report.CreatedAt
+		reports = append(reports, report)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating reports", zap.Error(err))
+		return nil, 0, fmt.Errorf("error iterating reports: %w", err)
+	}
+
+	return reports, total, nil
+}
+
+// DeleteReport deletes a report record and reports whether it existed
+func (r *DashboardRepository) DeleteReport(ctx context.Context, reportID string) (bool, error) {
+	ctx, span := startSpan(ctx, "DashboardRepository.DeleteReport")
+	defer span.End()
+
+	tag, err := r.db.Exec(ctx, `DELETE FROM reports WHERE id = $1`, reportID)
+	if err != nil {
+		r.logger.Error("failed to delete report", zap.Error(err), zap.String("report_id", reportID))
+		return false, fmt.Errorf("failed to delete report: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// reportSectionNames converts report sections to the text array stored on a report
+func reportSectionNames(sections []model.ReportSection) []string {
+	names := make([]string, len(sections))
+	for i, section := range sections {
+		names[i] = string(section)
+	}
+	return names
+}
+
+// reportSections converts the text array stored on a report to report sections
+func reportSections(names []string) []model.ReportSection {
+	sections := make([]model.ReportSection, len(names))
+	for i, name := range names {
+		sections[i] = model.ReportSection(name)
+	}
+	return sections
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ExtractionQualityCounts holds extraction outcome counts of check-ins made with one prompt version
+type ExtractionQualityCounts struct {
+	PromptVersion string
+	CheckIns      int            // all check-ins, including extraction fallbacks
+	Fallbacks     int            // check-ins saved with only the raw transcript
+	LowConfidence int            // check-ins flagged as low confidence
+	Flagged       int            // check-ins with at least one extraction issue
+	Issues        map[string]int // check-ins per extraction issue
+}
+
+// GetExtractionQuality counts extraction outcomes per prompt version of check-ins made since
+// since. Check-ins saved before prompt versions were recorded are grouped under "unknown".
+func (r *CheckInRepository) GetExtractionQuality(ctx context.Context, since time.Time) ([]ExtractionQualityCounts, error) {
+	ctx, span := startSpan(ctx, "CheckInRepository.GetExtractionQuality")
+	defer span.End()
+
+	query := `
+		SELECT
+			COALESCE(extraction_prompt_version, 'unknown') AS prompt_version,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE raw_transcript IS NOT NULL),
+			COUNT(*) FILTER (WHERE low_confidence),
+			COUNT(*) FILTER (WHERE cardinality(extraction_issues) > 0)
+		FROM health_check_ins
+		WHERE created_at >= $1
+		GROUP BY prompt_version
+	`
+
+	rows, err := readDB(ctx, r.reads, r.db).Query(ctx, query, since)
+	if err != nil {
+		r.logger.Error("failed to get extraction quality", zap.Error(err))
+		return nil, fmt.Errorf("failed to get extraction quality: %w", err)
+	}
+	defer rows.Close()
+
+	byVersion := make(map[string]*ExtractionQualityCounts)
+	for rows.Next() {
+		counts := ExtractionQualityCounts{Issues: make(map[string]int)}
+		if err := rows.Scan(&counts.PromptVersion, &counts.CheckIns, &counts.Fallbacks, &counts.LowConfidence, &counts.Flagged); err != nil {
+			r.logger.Error("failed to scan extraction quality", zap.Error(err))
+			continue
+		}
+		byVersion[counts.PromptVersion] = &counts
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating extraction quality", zap.Error(err))
+		return nil, fmt.Errorf("error iterating extraction quality: %w", err)
+	}
+
+	if err := r.addExtractionIssueCounts(ctx, since, byVersion); err != nil {
+		return nil, err
+	}
+
+	result := make([]ExtractionQualityCounts, 0, len(byVersion))
+	for _, counts := range byVersion {
+		result = append(result, *counts)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PromptVersion < result[j].PromptVersion
+	})
+
+	return result, nil
+}
+
+// addExtractionIssueCounts counts check-ins per extraction issue and prompt version
+func (r *CheckInRepository) addExtractionIssueCounts(ctx context.Context, since time.Time, byVersion map[string]*ExtractionQualityCounts) error {
+	query := `
+		SELECT COALESCE(extraction_prompt_version, 'unknown') AS prompt_version, issue, COUNT(*)
+		FROM health_check_ins, unnest(extraction_issues) AS issue
+		WHERE created_at >= $1
+		GROUP BY prompt_version, issue
+	`
+
+	rows, err := readDB(ctx, r.reads, r.db).Query(ctx, query, since)
+	if err != nil {
+		r.logger.Error("failed to get extraction issue counts", zap.Error(err))
+		return fmt.Errorf("failed to get extraction issue counts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version, issue string
+		var count int
+		if err := rows.Scan(&version, &issue, &count); err != nil {
+			r.logger.Error("failed to scan extraction issue count", zap.Error(err))
+			continue
+		}
+		if counts, ok := byVersion[version]; ok {
+			counts.Issues[issue] += count
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating extraction issue counts", zap.Error(err))
+		return fmt.Errorf("error iterating extraction issue counts: %w", err)
+	}
+
+	return nil
+}
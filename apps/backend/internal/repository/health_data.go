@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 	"go.uber.org/zap"
@@ -13,6 +15,7 @@ import (
 // HealthDataRepository manages health metrics data
 type HealthDataRepository struct {
 	db     *pgxpool.Pool
+	reads  *ReadPools
 	logger *zap.Logger
 }
 
@@ -24,8 +27,34 @@ func NewHealthDataRepository(db *pgxpool.Pool, logger *zap.Logger) *HealthDataRe
 	}
 }
 
+// SetReadPools lets the heavy reads of the repository use the read replica
+func (r *HealthDataRepository) SetReadPools(reads *ReadPools) {
+	r.reads = reads
+}
+
 // SaveMenstruation saves a menstruation cycle record
 func (r *HealthDataRepository) SaveMenstruation(ctx context.Context, data *model.MenstruationCycle) error {
+	ctx, span := startSpan(ctx, "HealthDataRepository.SaveMenstruation")
+	defer span.End()
+
+	if err := insertMenstruation(ctx, r.db, data); err != nil {
+		r.logger.Error("failed to save menstruation data",
+			zap.Error(err),
+			zap.String("user_id", data.UserID),
+		)
+		return fmt.Errorf("failed to save menstruation data: %w", err)
+	}
+
+	return nil
+}
+
+// menstruationExecer is implemented by both the pool and a transaction
+type menstruationExecer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// insertMenstruation inserts a menstruation cycle record
+func insertMenstruation(ctx context.Context, db menstruationExecer, data *model.MenstruationCycle) error {
 	query := `
 		INSERT INTO menstruation_cycles (
 			id, user_id, start_date, end_date,
@@ -34,7 +63,7 @@ func (r *HealthDataRepository) SaveMenstruation(ctx context.Context, data *model
 		) VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
 	`
 
-	_, err := r.db.Exec(ctx, query,
+	_, err := db.Exec(ctx, query,
 		data.ID,
 		data.UserID,
 		data.StartDate,
@@ -42,20 +71,48 @@ func (r *HealthDataRepository) SaveMenstruation(ctx context.Context, data *model
 		data.FlowIntensity,
 		data.Symptoms,
 	)
+	return err
+}
 
+// GetMenstruationByUserID retrieves menstruation cycles for a user, sorted by start date descending
+func (r *HealthDataRepository) GetMenstruationByUserID(ctx context.Context, userID string) ([]model.MenstruationCycle, error) {
+	ctx, span := startSpan(ctx, "HealthDataRepository.GetMenstruationByUserID")
+	defer span.End()
+
+	query := `
+		SELECT 
+			id, user_id, start_date, end_date,
+			flow_intensity, symptoms,
+			created_at, updated_at
+		FROM menstruation_cycles
+		WHERE user_id = $1
+		ORDER BY start_date DESC, id DESC
+	`
+
+	rows, err := readDB(ctx, r.reads, r.db).Query(ctx, query, userID)
 	if err != nil {
-		r.logger.Error("failed to save menstruation data",
-			zap.Error(err),
-			zap.String("user_id", data.UserID),
-		)
-		return fmt.Errorf("failed to save menstruation data: %w", err)
+		r.logger.Error("failed to get menstruation data", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get menstruation data: %w", err)
 	}
+	defer rows.Close()
 
-	return nil
+	return r.scanMenstruationCycles(rows)
 }
 
-// GetMenstruationByUserID retrieves menstruation cycles for a user, sorted by start date descending
-func (r *HealthDataRepository) GetMenstruationByUserID(ctx context.Context, userID string) ([]model.MenstruationCycle, error) {
+// GetMenstruationPageByUserID retrieves a page of menstruation cycles for a user, sorted by
+// start date descending, and the total number of cycles
+func (r *HealthDataRepository) GetMenstruationPageByUserID(ctx context.Context, userID string, page Page) ([]model.MenstruationCycle, int, error) {
+	ctx, span := startSpan(ctx, "HealthDataRepository.GetMenstruationPageByUserID")
+	defer span.End()
+
+	page = page.Normalize()
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM menstruation_cycles WHERE user_id = $1`, userID).Scan(&total); err != nil {
+		r.logger.Error("failed to count menstruation data", zap.Error(err), zap.String("user_id", userID))
+		return nil, 0, fmt.Errorf("failed to count menstruation data: %w", err)
+	}
+
 	query := `
 		SELECT 
 			id, user_id, start_date, end_date,
@@ -63,29 +120,30 @@ func (r *HealthDataRepository) GetMenstruationByUserID(ctx context.Context, user
 			created_at, updated_at
 		FROM menstruation_cycles
 		WHERE user_id = $1
-		ORDER BY start_date DESC
+		ORDER BY start_date DESC, id DESC
+		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.db.Query(ctx, query, userID)
+	rows, err := r.db.Query(ctx, query, userID, page.Limit, page.Offset)
 	if err != nil {
 		r.logger.Error("failed to get menstruation data", zap.Error(err), zap.String("user_id", userID))
-		return nil, fmt.Errorf("failed to get menstruation data: %w", err)
+		return nil, 0, fmt.Errorf("failed to get menstruation data: %w", err)
 	}
 	defer rows.Close()
 
+	cycles, err := r.scanMenstruationCycles(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return cycles, total, nil
+}
+
+// scanMenstruationCycles reads menstruation cycle rows
+func (r *HealthDataRepository) scanMenstruationCycles(rows pgx.Rows) ([]model.MenstruationCycle, error) {
 	var cycles []model.MenstruationCycle
 	for rows.Next() {
-		var cycle model.MenstruationCycle
-		err := rows.Scan(
-			&cycle.ID,
-			&cycle.UserID,
-			&cycle.StartDate,
-			&cycle.EndDate,
-			&cycle.FlowIntensity,
-			&cycle.Symptoms,
-			&cycle.CreatedAt,
-			&cycle.UpdatedAt,
-		)
+		cycle, err := scanMenstruationCycle(rows)
 		if err != nil {
 			r.logger.Error("failed to scan menstruation cycle", zap.Error(err))
 			continue
@@ -101,8 +159,107 @@ func (r *HealthDataRepository) GetMenstruationByUserID(ctx context.Context, user
 	return cycles, nil
 }
 
+// scanMenstruationCycle reads the current menstruation cycle row
+func scanMenstruationCycle(rows pgx.Rows) (model.MenstruationCycle, error) {
+	var cycle model.MenstruationCycle
+	err := rows.Scan(
+		&cycle.ID,
+		&cycle.UserID,
+		&cycle.StartDate,
+		&cycle.EndDate,
+		&cycle.FlowIntensity,
+		&cycle.Symptoms,
+		&cycle.CreatedAt,
+		&cycle.UpdatedAt,
+	)
+	return cycle, err
+}
+
+// StreamMenstruationByUserID calls fn for each menstruation cycle of a user starting from
+// start up to, but excluding, end, in start date order, without loading them all into memory.
+// An error returned by fn stops the iteration and is returned.
+func (r *HealthDataRepository) StreamMenstruationByUserID(ctx context.Context, userID string, start, end time.Time, fn func(model.MenstruationCycle) error) error {
+	ctx, span := startSpan(ctx, "HealthDataRepository.StreamMenstruationByUserID")
+	defer span.End()
+
+	query := `
+		SELECT 
+			id, user_id, start_date, end_date,
+			flow_intensity, symptoms,
+			created_at, updated_at
+		FROM menstruation_cycles
+		WHERE user_id = $1 AND start_date >= $2 AND start_date < $3
+		ORDER BY start_date ASC, id ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, start, end)
+	if err != nil {
+		r.logger.Error("failed to stream menstruation data", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to stream menstruation data: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		cycle, err := scanMenstruationCycle(rows)
+		if err != nil {
+			r.logger.Error("failed to scan menstruation cycle", zap.Error(err))
+			continue
+		}
+		if err := fn(cycle); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating menstruation cycles", zap.Error(err))
+		return fmt.Errorf("error iterating menstruation cycles: %w", err)
+	}
+
+	return nil
+}
+
+// GetMenstruationByID retrieves a single menstruation cycle
+func (r *HealthDataRepository) GetMenstruationByID(ctx context.Context, cycleID string) (*model.MenstruationCycle, error) {
+	ctx, span := startSpan(ctx, "HealthDataRepository.GetMenstruationByID")
+	defer span.End()
+
+	query := `
+		SELECT 
+			id, user_id, start_date, end_date,
+			flow_intensity, symptoms,
+			created_at, updated_at
+		FROM menstruation_cycles
+		WHERE id = $1
+	`
+
+	var cycle model.MenstruationCycle
+	err := r.db.QueryRow(ctx, query, cycleID).Scan(
+		&cycle.ID,
+		&cycle.UserID,
+		&cycle.StartDate,
+		&cycle.EndDate,
+		&cycle.FlowIntensity,
+		&cycle.Symptoms,
+		&cycle.CreatedAt,
+		&cycle.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("menstruation cycle not found: %s", cycleID)
+		}
+		r.logger.Error("failed to get menstruation cycle", zap.Error(err), zap.String("cycle_id", cycleID))
+		return nil, fmt.Errorf("failed to get menstruation cycle: %w", err)
+	}
+
+	return &cycle, nil
+}
+
 // UpdateMenstruation updates a menstruation cycle record
 func (r *HealthDataRepository) UpdateMenstruation(ctx context.Context, data *model.MenstruationCycle) error {
+	ctx, span := startSpan(ctx, "HealthDataRepository.UpdateMenstruation")
+	defer span.End()
+
 	query := `
 		UPDATE menstruation_cycles
 		SET end_date = $1, flow_intensity = $2, symptoms = $3, updated_at = NOW()
@@ -133,11 +290,14 @@ func (r *HealthDataRepository) UpdateMenstruation(ctx context.Context, data *mod
 
 // SaveBloodPressure saves a blood pressure reading
 func (r *HealthDataRepository) SaveBloodPressure(ctx context.Context, reading *model.BloodPressureReading) error {
+	ctx, span := startSpan(ctx, "HealthDataRepository.SaveBloodPressure")
+	defer span.End()
+
 	query := `
 		INSERT INTO blood_pressure_readings (
 			id, user_id, systolic, diastolic, pulse,
-			measured_at, created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, NOW())
+			measured_at, notes, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
 	`
 
 	_, err := r.db.Exec(ctx, query,
@@ -147,6 +307,7 @@ func (r *HealthDataRepository) SaveBloodPressure(ctx context.Context, reading *m
 		reading.Diastolic,
 		reading.Pulse,
 		reading.MeasuredAt,
+		reading.Notes,
 	)
 
 	if err != nil {
@@ -162,34 +323,72 @@ func (r *HealthDataRepository) SaveBloodPressure(ctx context.Context, reading *m
 
 // GetBloodPressureByUserID retrieves blood pressure readings for a user, sorted by measured_at descending
 func (r *HealthDataRepository) GetBloodPressureByUserID(ctx context.Context, userID string) ([]model.BloodPressureReading, error) {
+	ctx, span := startSpan(ctx, "HealthDataRepository.GetBloodPressureByUserID")
+	defer span.End()
+
 	query := `
 		SELECT 
 			id, user_id, systolic, diastolic, pulse,
-			measured_at, created_at
+			measured_at, notes, created_at
 		FROM blood_pressure_readings
 		WHERE user_id = $1
-		ORDER BY measured_at DESC
+		ORDER BY measured_at DESC, id DESC
 	`
 
-	rows, err := r.db.Query(ctx, query, userID)
+	rows, err := readDB(ctx, r.reads, r.db).Query(ctx, query, userID)
 	if err != nil {
 		r.logger.Error("failed to get blood pressure readings", zap.Error(err), zap.String("user_id", userID))
 		return nil, fmt.Errorf("failed to get blood pressure readings: %w", err)
 	}
 	defer rows.Close()
 
+	return r.scanBloodPressureReadings(rows)
+}
+
+// GetBloodPressurePageByUserID retrieves a page of blood pressure readings for a user, sorted
+// by measured_at descending, and the total number of readings
+func (r *HealthDataRepository) GetBloodPressurePageByUserID(ctx context.Context, userID string, page Page) ([]model.BloodPressureReading, int, error) {
+	ctx, span := startSpan(ctx, "HealthDataRepository.GetBloodPressurePageByUserID")
+	defer span.End()
+
+	page = page.Normalize()
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM blood_pressure_readings WHERE user_id = $1`, userID).Scan(&total); err != nil {
+		r.logger.Error("failed to count blood pressure readings", zap.Error(err), zap.String("user_id", userID))
+		return nil, 0, fmt.Errorf("failed to count blood pressure readings: %w", err)
+	}
+
+	query := `
+		SELECT 
+			id, user_id, systolic, diastolic, pulse,
+			measured_at, notes, created_at
+		FROM blood_pressure_readings
+		WHERE user_id = $1
+		ORDER BY measured_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, page.Limit, page.Offset)
+	if err != nil {
+		r.logger.Error("failed to get blood pressure readings", zap.Error(err), zap.String("user_id", userID))
+		return nil, 0, fmt.Errorf("failed to get blood pressure readings: %w", err)
+	}
+	defer rows.Close()
+
+	readings, err := r.scanBloodPressureReadings(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return readings, total, nil
+}
+
+// scanBloodPressureReadings reads blood pressure reading rows
+func (r *HealthDataRepository) scanBloodPressureReadings(rows pgx.Rows) ([]model.BloodPressureReading, error) {
 	var readings []model.BloodPressureReading
 	for rows.Next() {
-		var reading model.BloodPressureReading
-		err := rows.Scan(
-			&reading.ID,
-			&reading.UserID,
-			&reading.Systolic,
-			&reading.Diastolic,
-			&reading.Pulse,
-			&reading.MeasuredAt,
-			&reading.CreatedAt,
-		)
+		reading, err := scanBloodPressureReading(rows)
 		if err != nil {
 			r.logger.Error("failed to scan blood pressure reading", zap.Error(err))
 			continue
@@ -205,67 +404,224 @@ func (r *HealthDataRepository) GetBloodPressureByUserID(ctx context.Context, use
 	return readings, nil
 }
 
-// SaveFitnessData saves a fitness data point
-func (r *HealthDataRepository) SaveFitnessData(ctx context.Context, data *model.FitnessDataPoint) error {
+// scanBloodPressureReading reads the current blood pressure reading row
+func scanBloodPressureReading(rows pgx.Rows) (model.BloodPressureReading, error) {
+	var reading model.BloodPressureReading
+	err := rows.Scan(
+		&reading.ID,
+		&reading.UserID,
+		&reading.Systolic,
+		&reading.Diastolic,
+		&reading.Pulse,
+		&reading.MeasuredAt,
+		&reading.Notes,
+		&reading.CreatedAt,
+	)
+	return reading, err
+}
+
+// StreamBloodPressureByUserID calls fn for each blood pressure reading of a user measured
+// from start up to, but excluding, end, in measurement order, without loading them all into
+// memory. An error returned by fn stops the iteration and is returned.
+func (r *HealthDataRepository) StreamBloodPressureByUserID(ctx context.Context, userID string, start, end time.Time, fn func(model.BloodPressureReading) error) error {
+	ctx, span := startSpan(ctx, "HealthDataRepository.StreamBloodPressureByUserID")
+	defer span.End()
+
 	query := `
-		INSERT INTO fitness_data (
-			id, user_id, date, data_type, value,
-			unit, source, source_data_id, created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		SELECT 
+			id, user_id, systolic, diastolic, pulse,
+			measured_at, notes, created_at
+		FROM blood_pressure_readings
+		WHERE user_id = $1 AND measured_at >= $2 AND measured_at < $3
+		ORDER BY measured_at ASC, id ASC
 	`
 
-	_, err := r.db.Exec(ctx, query,
-		data.ID,
-		data.UserID,
-		data.Date,
-		data.DataType,
-		data.Value,
-		data.Unit,
-		data.Source,
-		data.SourceDataID,
-	)
+	rows, err := r.db.Query(ctx, query, userID, start, end)
+	if err != nil {
+		r.logger.Error("failed to stream blood pressure readings", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to stream blood pressure readings: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		reading, err := scanBloodPressureReading(rows)
+		if err != nil {
+			r.logger.Error("failed to scan blood pressure reading", zap.Error(err))
+			continue
+		}
+		if err := fn(reading); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating blood pressure readings", zap.Error(err))
+		return fmt.Errorf("error iterating blood pressure readings: %w", err)
+	}
+
+	return nil
+}
+
+// BloodPressureBucket holds the average blood pressure of the readings measured in one
+// day or week starting at PeriodStart
+type BloodPressureBucket struct {
+	PeriodStart  time.Time
+	AvgSystolic  float64
+	AvgDiastolic float64
+	AvgPulse     float64 // 0 when no reading in the bucket has a pulse
+	ReadingCount int
+}
+
+// GetBloodPressureAggregatedByDay averages a user's blood pressure readings per day for
+// readings measured from start up to, but excluding, end. Days without readings are omitted.
+func (r *HealthDataRepository) GetBloodPressureAggregatedByDay(ctx context.Context, userID string, start, end time.Time) ([]BloodPressureBucket, error) {
+	ctx, span := startSpan(ctx, "HealthDataRepository.GetBloodPressureAggregatedByDay")
+	defer span.End()
+
+	return r.getBloodPressureAggregated(ctx, "day", userID, start, end)
+}
+
+// GetBloodPressureAggregatedByWeek averages a user's blood pressure readings per ISO week,
+// starting on Monday, for readings measured from start up to, but excluding, end. Weeks
+// without readings are omitted.
+func (r *HealthDataRepository) GetBloodPressureAggregatedByWeek(ctx context.Context, userID string, start, end time.Time) ([]BloodPressureBucket, error) {
+	ctx, span := startSpan(ctx, "HealthDataRepository.GetBloodPressureAggregatedByWeek")
+	defer span.End()
+
+	return r.getBloodPressureAggregated(ctx, "week", userID, start, end)
+}
 
+// getBloodPressureAggregated averages blood pressure readings per date_trunc unit
+func (r *HealthDataRepository) getBloodPressureAggregated(ctx context.Context, unit, userID string, start, end time.Time) ([]BloodPressureBucket, error) {
+	query := `
+		SELECT
+			date_trunc($1, measured_at) AS period_start,
+			AVG(systolic)::float8,
+			AVG(diastolic)::float8,
+			COALESCE(AVG(pulse), 0)::float8,
+			COUNT(*)
+		FROM blood_pressure_readings
+		WHERE user_id = $2 AND measured_at >= $3 AND measured_at < $4
+		GROUP BY period_start
+		ORDER BY period_start ASC
+	`
+
+	rows, err := readDB(ctx, r.reads, r.db).Query(ctx, query, unit, userID, start, end)
 	if err != nil {
-		r.logger.Error("failed to save fitness data",
+		r.logger.Error("failed to aggregate blood pressure readings",
 			zap.Error(err),
-			zap.String("user_id", data.UserID),
-			zap.String("data_type", data.DataType),
+			zap.String("user_id", userID),
+			zap.String("unit", unit),
 		)
-		return fmt.Errorf("failed to save fitness data: %w", err)
+		return nil, fmt.Errorf("failed to aggregate blood pressure readings: %w", err)
 	}
+	defer rows.Close()
 
-	return nil
+	var buckets []BloodPressureBucket
+	for rows.Next() {
+		var bucket BloodPressureBucket
+		err := rows.Scan(
+			&bucket.PeriodStart,
+			&bucket.AvgSystolic,
+			&bucket.AvgDiastolic,
+			&bucket.AvgPulse,
+			&bucket.ReadingCount,
+		)
+		if err != nil {
+			r.logger.Error("failed to scan blood pressure bucket", zap.Error(err))
+			continue
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating blood pressure buckets", zap.Error(err))
+		return nil, fmt.Errorf("error iterating blood pressure buckets: %w", err)
+	}
+
+	return buckets, nil
 }
 
-// FitnessDataExists checks if a fitness data point already exists by source_data_id
-func (r *HealthDataRepository) FitnessDataExists(ctx context.Context, sourceDataID string) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM fitness_data WHERE source_data_id = $1)`
+// BulkSaveFitnessData saves fitness data points, each of its UserID, with one multi-row
+// INSERT, so a sync of hundreds of points is a single round trip and the points are
+// readable as soon as it returns. Points whose source_data_id their user already synced,
+// or that repeat one earlier in points, are skipped. It returns the number inserted and
+// the number skipped.
+func (r *HealthDataRepository) BulkSaveFitnessData(ctx context.Context, points []model.FitnessDataPoint) (int, int, error) {
+	ctx, span := startSpan(ctx, "HealthDataRepository.BulkSaveFitnessData")
+	defer span.End()
+
+	if len(points) == 0 {
+		return 0, 0, nil
+	}
+
+	ids := make([]string, len(points))
+	userIDs := make([]string, len(points))
+	dates := make([]string, len(points))
+	dataTypes := make([]string, len(points))
+	values := make([]float64, len(points))
+	units := make([]string, len(points))
+	sources := make([]string, len(points))
+	sourceDataIDs := make([]string, len(points))
+	for i, data := range points {
+		ids[i] = data.ID
+		userIDs[i] = data.UserID
+		dates[i] = data.Date.Format(time.DateOnly)
+		dataTypes[i] = data.DataType
+		values[i] = data.Value
+		units[i] = data.Unit
+		sources[i] = data.Source
+		sourceDataIDs[i] = data.SourceDataID
+	}
+
+	// Deduplication is per user: two users' devices may report the same source ID
+	query := `
+		INSERT INTO fitness_data (
+			id, user_id, date, data_type, value,
+			unit, source, source_data_id, created_at
+		)
+		SELECT p.id::uuid, p.user_id::uuid, p.date::date, p.data_type, p.value,
+			p.unit, p.source, p.source_data_id, NOW()
+		FROM unnest($1::text[], $2::text[], $3::text[], $4::text[], $5::float8[], $6::text[], $7::text[], $8::text[])
+			AS p(id, user_id, date, data_type, value, unit, source, source_data_id)
+		ON CONFLICT (user_id, source_data_id) WHERE source_data_id <> '' DO NOTHING
+	`
 
-	var exists bool
-	err := r.db.QueryRow(ctx, query, sourceDataID).Scan(&exists)
+	tag, err := r.db.Exec(ctx, query, ids, userIDs, dates, dataTypes, values, units, sources, sourceDataIDs)
 	if err != nil {
-		r.logger.Error("failed to check fitness data existence",
+		r.logger.Error("failed to save fitness data",
 			zap.Error(err),
-			zap.String("source_data_id", sourceDataID),
+			zap.Int("count", len(points)),
 		)
-		return false, fmt.Errorf("failed to check fitness data existence: %w", err)
+		return 0, 0, fmt.Errorf("failed to save fitness data: %w", err)
 	}
 
-	return exists, nil
+	inserted := int(tag.RowsAffected())
+	return inserted, len(points) - inserted, nil
 }
 
-// GetFitnessDataByUserID retrieves fitness data for a user within a date range
-func (r *HealthDataRepository) GetFitnessDataByUserID(ctx context.Context, userID string, startDate, endDate time.Time) ([]model.FitnessDataPoint, error) {
+// fitnessDataFilter selects a user's fitness data from the day of $2 through the day of
+// $3, both inclusive, only of data type $4 unless it is empty. The days are bound as
+// dates, not timestamps, so the time of day and the session time zone cannot exclude a day.
+const fitnessDataFilter = `WHERE user_id = $1 AND date >= $2::date AND date <= $3::date AND ($4 = '' OR data_type = $4)`
+
+// GetFitnessDataByUserID retrieves fitness data for a user from startDate through endDate,
+// both inclusive, only of dataType unless it is empty
+func (r *HealthDataRepository) GetFitnessDataByUserID(ctx context.Context, userID string, startDate, endDate time.Time, dataType string) ([]model.FitnessDataPoint, error) {
+	ctx, span := startSpan(ctx, "HealthDataRepository.GetFitnessDataByUserID")
+	defer span.End()
+
 	query := `
 		SELECT 
 			id, user_id, date, data_type, value,
 			unit, source, source_data_id, created_at
 		FROM fitness_data
-		WHERE user_id = $1 AND date >= $2 AND date <= $3
+		` + fitnessDataFilter + `
 		ORDER BY date DESC, data_type ASC
 	`
 
-	rows, err := r.db.Query(ctx, query, userID, startDate, endDate)
+	rows, err := readDB(ctx, r.reads, r.db).Query(ctx, query, userID,
+		startDate.Format(time.DateOnly), endDate.Format(time.DateOnly), dataType)
 	if err != nil {
 		r.logger.Error("failed to get fitness data",
 			zap.Error(err),
@@ -277,18 +633,7 @@ func (r *HealthDataRepository) GetFitnessDataByUserID(ctx context.Context, userI
 
 	var dataPoints []model.FitnessDataPoint
 	for rows.Next() {
-		var data model.FitnessDataPoint
-		err := rows.Scan(
-			&data.ID,
-			&data.UserID,
-			&data.Date,
-			&data.DataType,
-			&data.Value,
-			&data.Unit,
-			&data.Source,
-			&data.SourceDataID,
-			&data.CreatedAt,
-		)
+		data, err := scanFitnessDataPoint(rows)
 		if err != nil {
 			r.logger.Error("failed to scan fitness data", zap.Error(err))
 			continue
@@ -304,13 +649,126 @@ func (r *HealthDataRepository) GetFitnessDataByUserID(ctx context.Context, userI
 	return dataPoints, nil
 }
 
+// GetFitnessDataPageByUserID retrieves a page of a user's fitness data from startDate
+// through endDate, both inclusive, oldest first, only of dataType unless it is empty, and the total count
+func (r *HealthDataRepository) GetFitnessDataPageByUserID(ctx context.Context, userID string, startDate, endDate time.Time, dataType string, page Page) ([]model.FitnessDataPoint, int, error) {
+	ctx, span := startSpan(ctx, "HealthDataRepository.GetFitnessDataPageByUserID")
+	defer span.End()
+
+	page = page.Normalize()
+	from, to := startDate.Format(time.DateOnly), endDate.Format(time.DateOnly)
+
+	var total int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM fitness_data `+fitnessDataFilter, userID, from, to, dataType).Scan(&total)
+	if err != nil {
+		r.logger.Error("failed to count fitness data", zap.Error(err), zap.String("user_id", userID))
+		return nil, 0, fmt.Errorf("failed to count fitness data: %w", err)
+	}
+
+	query := `
+		SELECT 
+			id, user_id, date, data_type, value,
+			unit, source, source_data_id, created_at
+		FROM fitness_data
+		` + fitnessDataFilter + `
+		ORDER BY date ASC, data_type ASC, id ASC
+		LIMIT $5 OFFSET $6
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, from, to, dataType, page.Limit, page.Offset)
+	if err != nil {
+		r.logger.Error("failed to get fitness data", zap.Error(err), zap.String("user_id", userID))
+		return nil, 0, fmt.Errorf("failed to get fitness data: %w", err)
+	}
+	defer rows.Close()
+
+	var dataPoints []model.FitnessDataPoint
+	for rows.Next() {
+		data, err := scanFitnessDataPoint(rows)
+		if err != nil {
+			r.logger.Error("failed to scan fitness data", zap.Error(err))
+			continue
+		}
+		dataPoints = append(dataPoints, data)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating fitness data", zap.Error(err))
+		return nil, 0, fmt.Errorf("error iterating fitness data: %w", err)
+	}
+
+	return dataPoints, total, nil
+}
+
+// scanFitnessDataPoint reads the current fitness data row
+func scanFitnessDataPoint(rows pgx.Rows) (model.FitnessDataPoint, error) {
+	var data model.FitnessDataPoint
+	err := rows.Scan(
+		&data.ID,
+		&data.UserID,
+		&data.Date,
+		&data.DataType,
+		&data.Value,
+		&data.Unit,
+		&data.Source,
+		&data.SourceDataID,
+		&data.CreatedAt,
+	)
+	return data, err
+}
+
+// StreamFitnessDataByUserID calls fn for each fitness data point of a user dated from start
+// up to, but excluding, end, in date order, without loading them all into memory. An error
+// returned by fn stops the iteration and is returned.
+func (r *HealthDataRepository) StreamFitnessDataByUserID(ctx context.Context, userID string, start, end time.Time, fn func(model.FitnessDataPoint) error) error {
+	ctx, span := startSpan(ctx, "HealthDataRepository.StreamFitnessDataByUserID")
+	defer span.End()
+
+	query := `
+		SELECT 
+			id, user_id, date, data_type, value,
+			unit, source, source_data_id, created_at
+		FROM fitness_data
+		WHERE user_id = $1 AND date >= $2 AND date < $3
+		ORDER BY date ASC, data_type ASC, id ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, start, end)
+	if err != nil {
+		r.logger.Error("failed to stream fitness data", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to stream fitness data: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		data, err := scanFitnessDataPoint(rows)
+		if err != nil {
+			r.logger.Error("failed to scan fitness data", zap.Error(err))
+			continue
+		}
+		if err := fn(data); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating fitness data", zap.Error(err))
+		return fmt.Errorf("error iterating fitness data: %w", err)
+	}
+
+	return nil
+}
+
 // SaveAudioRecording saves an audio recording record
 func (r *HealthDataRepository) SaveAudioRecording(ctx context.Context, recording *model.AudioRecording) error {
+	ctx, span := startSpan(ctx, "HealthDataRepository.SaveAudioRecording")
+	defer span.End()
+
 	query := `
 		INSERT INTO audio_recordings (
 			id, session_id, message_id, file_path,
-			duration_seconds, transcription, created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, NOW())
+			duration_seconds, transcription, storage_backend, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, COALESCE(NULLIF($7, ''), 'default'), NOW())
 	`
 
 	_, err := r.db.Exec(ctx, query,
@@ -320,6 +778,7 @@ func (r *HealthDataRepository) SaveAudioRecording(ctx context.Context, recording
 		recording.FilePath,
 		recording.DurationSeconds,
 		recording.Transcription,
+		recording.StorageBackend,
 	)
 
 	if err != nil {
@@ -335,6 +794,9 @@ func (r *HealthDataRepository) SaveAudioRecording(ctx context.Context, recording
 
 // GetAudioRecordingsBySessionID retrieves audio recordings for a session
 func (r *HealthDataRepository) GetAudioRecordingsBySessionID(ctx context.Context, sessionID string) ([]model.AudioRecording, error) {
+	ctx, span := startSpan(ctx, "HealthDataRepository.GetAudioRecordingsBySessionID")
+	defer span.End()
+
 	query := `
 		SELECT 
 			id, session_id, message_id, file_path,
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// fitnessPoints returns a user's steps, heart rate and calories points of day with the
+// given source IDs
+func fitnessPoints(userID string, day time.Time, sourceIDs ...string) []model.FitnessDataPoint {
+	dataTypes := []struct{ dataType, unit string }{{"steps", "count"}, {"heart_rate", "bpm"}, {"calories", "kcal"}}
+	points := make([]model.FitnessDataPoint, len(sourceIDs))
+	for i, sourceID := range sourceIDs {
+		points[i] = model.FitnessDataPoint{
+			ID:           uuid.New().String(),
+			UserID:       userID,
+			Date:         day,
+			DataType:     dataTypes[i%len(dataTypes)].dataType,
+			Value:        float64(1000 * (i + 1)),
+			Unit:         dataTypes[i%len(dataTypes)].unit,
+			Source:       "health_connect",
+			SourceDataID: sourceID,
+		}
+	}
+	return points
+}
+
+// Regression: synced fitness data is readable right after the sync returns, including
+// points dated today when the range ends now
+func TestBulkSaveFitnessData_ReadableImmediately(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewHealthDataRepository(pool, zap.NewNop())
+	userID := createTestUser(t, pool)
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	inserted, skipped, err := repo.BulkSaveFitnessData(ctx, fitnessPoints(userID, today, "steps-001", "hr-001", "cal-001"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, inserted)
+	assert.Zero(t, skipped)
+
+	points, err := repo.GetFitnessDataByUserID(ctx, userID, now.AddDate(0, 0, -30), now, "")
+	require.NoError(t, err)
+	assert.Len(t, points, 3)
+
+	points, total, err := repo.GetFitnessDataPageByUserID(ctx, userID, today, today, "steps", Page{Limit: 50})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, points, 1)
+	assert.Equal(t, "steps-001", points[0].SourceDataID)
+
+	// Resyncing skips the points, another user's points with the same source IDs are saved
+	inserted, skipped, err = repo.BulkSaveFitnessData(ctx, fitnessPoints(userID, today, "steps-001"))
+	require.NoError(t, err)
+	assert.Zero(t, inserted)
+	assert.Equal(t, 1, skipped)
+
+	otherUserID := createTestUser(t, pool)
+	inserted, _, err = repo.BulkSaveFitnessData(ctx, fitnessPoints(otherUserID, today, "steps-001", "hr-001"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, inserted)
+}
+
+func TestBulkSaveFitnessData_SkipsDuplicates(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewHealthDataRepository(pool, zap.NewNop())
+	userID := createTestUser(t, pool)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	// 500 points of which the last 100 repeat source IDs of the first 100
+	sourceIDs := make([]string, 500)
+	for i := range sourceIDs {
+		sourceIDs[i] = fmt.Sprintf("point-%d", i%400)
+	}
+
+	inserted, skipped, err := repo.BulkSaveFitnessData(ctx, fitnessPoints(userID, today, sourceIDs...))
+	require.NoError(t, err)
+	assert.Equal(t, 400, inserted)
+	assert.Equal(t, 100, skipped)
+
+	var rows int
+	require.NoError(t, pool.QueryRow(ctx, `SELECT COUNT(*) FROM fitness_data WHERE user_id = $1`, userID).Scan(&rows))
+	assert.Equal(t, 400, rows)
+
+	// Points without a source ID are never deduplicated
+	inserted, _, err = repo.BulkSaveFitnessData(ctx, fitnessPoints(userID, today, "", ""))
+	require.NoError(t, err)
+	assert.Equal(t, 2, inserted)
+}
+
+// BenchmarkBulkSaveFitnessData compares saving a 1,000-point payload in one statement with
+// saving the points one statement at a time
+func BenchmarkBulkSaveFitnessData(b *testing.B) {
+	pool, cleanup := setupTestDB(b)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewHealthDataRepository(pool, zap.NewNop())
+	userID := createTestUser(b, pool)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	payload := func(run string) []model.FitnessDataPoint {
+		sourceIDs := make([]string, 1000)
+		for i := range sourceIDs {
+			sourceIDs[i] = fmt.Sprintf("%s-%d", run, i)
+		}
+		return fitnessPoints(userID, today, sourceIDs...)
+	}
+
+	b.Run("bulk", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			points := payload(fmt.Sprintf("bulk-%d", i))
+			if _, _, err := repo.BulkSaveFitnessData(ctx, points); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("per_point", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, point := range payload(fmt.Sprintf("point-%d", i)) {
+				if _, _, err := repo.BulkSaveFitnessData(ctx, []model.FitnessDataPoint{point}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/middleware"
+)
+
+// idempotencyKeyTimeout bounds each query of IdempotencyKeyRepository, whose methods
+// have no request context
+const idempotencyKeyTimeout = 2 * time.Second
+
+// IdempotencyKeyRepository stores the responses of requests sent with an idempotency key.
+// It is the database backed middleware.IdempotencyStore; a reservation is a row without
+// a status code until the response is stored.
+type IdempotencyKeyRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewIdempotencyKeyRepository creates a new IdempotencyKeyRepository
+func NewIdempotencyKeyRepository(db *pgxpool.Pool, logger *zap.Logger) *IdempotencyKeyRepository {
+	return &IdempotencyKeyRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Reserve claims key for ttl unless an unexpired row exists for it. The insert is a
+// single statement, so of concurrent requests only one reserves the key; an expired row
+// is taken over in the same statement.
+func (r *IdempotencyKeyRepository) Reserve(key string, ttl time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), idempotencyKeyTimeout)
+	defer cancel()
+	ctx, span := startSpan(ctx, "IdempotencyKeyRepository.Reserve")
+	defer span.End()
+
+	query := `
+		INSERT INTO idempotency_keys (key, expires_at, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (key) DO UPDATE
+		SET request_hash = NULL,
+			status_code = NULL,
+			response_body = NULL,
+			expires_at = EXCLUDED.expires_at,
+			created_at = EXCLUDED.created_at
+		WHERE idempotency_keys.expires_at <= NOW()
+	`
+
+	tag, err := r.db.Exec(ctx, query, key, time.Now().Add(ttl))
+	if err != nil {
+		r.logger.Error("failed to reserve idempotency key", zap.Error(err))
+		return false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// Get returns the unexpired reservation or response stored for key
+func (r *IdempotencyKeyRepository) Get(key string) (middleware.IdempotentResponse, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), idempotencyKeyTimeout)
+	defer cancel()
+	ctx, span := startSpan(ctx, "IdempotencyKeyRepository.Get")
+	defer span.End()
+
+	query := `
+		SELECT request_hash, status_code, response_body
+		FROM idempotency_keys
+		WHERE key = $1 AND expires_at > NOW()
+	`
+
+	var requestHash *string
+	var status *int
+	var body []byte
+	err := r.db.QueryRow(ctx, query, key).Scan(&requestHash, &status, &body)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return middleware.IdempotentResponse{}, false, nil
+	}
+	if err != nil {
+		r.logger.Error("failed to get idempotency key", zap.Error(err))
+		return middleware.IdempotentResponse{}, false, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	if status == nil {
+		return middleware.IdempotentResponse{Pending: true}, true, nil
+	}
+	response := middleware.IdempotentResponse{Status: *status, Body: body}
+	if requestHash != nil {
+		response.RequestHash = *requestHash
+	}
+	return response, true, nil
+}
+
+// Complete stores a response for key for ttl, replacing its reservation
+func (r *IdempotencyKeyRepository) Complete(key, requestHash string, status int, body []byte, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), idempotencyKeyTimeout)
+	defer cancel()
+	ctx, span := startSpan(ctx, "IdempotencyKeyRepository.Complete")
+	defer span.End()
+
+	query := `
+		INSERT INTO idempotency_keys (key, request_hash, status_code, response_body, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash,
+			status_code = EXCLUDED.status_code,
+			response_body = EXCLUDED.response_body,
+			expires_at = EXCLUDED.expires_at,
+			created_at = EXCLUDED.created_at
+	`
+
+	if _, err := r.db.Exec(ctx, query, key, requestHash, status, body, time.Now().Add(ttl)); err != nil {
+		r.logger.Error("failed to save idempotency key", zap.Error(err))
+		return fmt.Errorf("failed to save idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// Release deletes the reservation of key, keeping a stored response
+func (r *IdempotencyKeyRepository) Release(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), idempotencyKeyTimeout)
+	defer cancel()
+	ctx, span := startSpan(ctx, "IdempotencyKeyRepository.Release")
+	defer span.End()
+
+	query := `DELETE FROM idempotency_keys WHERE key = $1 AND status_code IS NULL`
+
+	if _, err := r.db.Exec(ctx, query, key); err != nil {
+		r.logger.Error("failed to release idempotency key", zap.Error(err))
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,293 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// integrationColumns are the columns scanned by scanIntegration
+const integrationColumns = `
+	id, organization_id, name, kind, settings, subject_template, body_template,
+	enabled, COALESCE(created_by::text, ''), created_at, updated_at
+`
+
+// IntegrationRepository manages organization delivery integrations, their delivery
+// attempts and patients' consent to share check-ins with a care team
+type IntegrationRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewIntegrationRepository creates a new IntegrationRepository
+func NewIntegrationRepository(db *pgxpool.Pool, logger *zap.Logger) *IntegrationRepository {
+	return &IntegrationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateIntegration saves a new integration
+func (r *IntegrationRepository) CreateIntegration(ctx context.Context, integration *model.OrganizationIntegration) error {
+	ctx, span := startSpan(ctx, "IntegrationRepository.CreateIntegration")
+	defer span.End()
+
+	query := `
+		INSERT INTO organization_integrations (
+			id, organization_id, name, kind, settings, subject_template, body_template,
+			enabled, created_by, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		integration.ID,
+		integration.OrganizationID,
+		integration.Name,
+		integration.Kind,
+		integration.Settings,
+		integration.SubjectTemplate,
+		integration.BodyTemplate,
+		integration.Enabled,
+		nullableID(integration.CreatedBy),
+	).Scan(&integration.CreatedAt, &integration.UpdatedAt)
+	if err != nil {
+		r.logger.Error("failed to create integration",
+			zap.Error(err),
+			zap.String("organization_id", integration.OrganizationID),
+		)
+		return fmt.Errorf("failed to create integration: %w", err)
+	}
+
+	return nil
+}
+
+// GetIntegration retrieves an integration of an organization. It returns nil when the
+// integration does not exist or belongs to another organization.
+func (r *IntegrationRepository) GetIntegration(ctx context.Context, orgID, integrationID string) (*model.OrganizationIntegration, error) {
+	ctx, span := startSpan(ctx, "IntegrationRepository.GetIntegration")
+	defer span.End()
+
+	query := `SELECT ` + integrationColumns + ` FROM organization_integrations WHERE id = $1 AND organization_id = $2`
+
+	integration, err := scanIntegration(r.db.QueryRow(ctx, query, integrationID, orgID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		r.logger.Error("failed to get integration", zap.Error(err), zap.String("integration_id", integrationID))
+		return nil, fmt.Errorf("failed to get integration: %w", err)
+	}
+
+	return integration, nil
+}
+
+// ListIntegrations retrieves the integrations of an organization ordered by creation
+func (r *IntegrationRepository) ListIntegrations(ctx context.Context, orgID string) ([]model.OrganizationIntegration, error) {
+	ctx, span := startSpan(ctx, "IntegrationRepository.ListIntegrations")
+	defer span.End()
+
+	query := `SELECT ` + integrationColumns + ` FROM organization_integrations WHERE organization_id = $1 ORDER BY created_at`
+
+	return r.queryIntegrations(ctx, query, orgID)
+}
+
+// FindCheckInIntegrations retrieves the enabled integrations of every organization the
+// user belongs to and has consented to share check-ins with
+func (r *IntegrationRepository) FindCheckInIntegrations(ctx context.Context, userID string) ([]model.OrganizationIntegration, error) {
+	ctx, span := startSpan(ctx, "IntegrationRepository.FindCheckInIntegrations")
+	defer span.End()
+
+	query := `
+		SELECT ` + integrationColumns + `
+		FROM organization_integrations i
+		WHERE i.enabled
+			AND EXISTS (
+				SELECT 1 FROM care_team_sharing_consents c
+				WHERE c.organization_id = i.organization_id AND c.user_id = $1
+			)
+			AND EXISTS (
+				SELECT 1 FROM organization_roles m
+				WHERE m.organization_id = i.organization_id AND m.user_id = $1
+			)
+		ORDER BY i.organization_id, i.created_at
+	`
+
+	return r.queryIntegrations(ctx, query, userID)
+}
+
+func (r *IntegrationRepository) queryIntegrations(ctx context.Context, query string, arg string) ([]model.OrganizationIntegration, error) {
+	rows, err := r.db.Query(ctx, query, arg)
+	if err != nil {
+		r.logger.Error("failed to query integrations", zap.Error(err))
+		return nil, fmt.Errorf("failed to query integrations: %w", err)
+	}
+	defer rows.Close()
+
+	integrations := []model.OrganizationIntegration{}
+	for rows.Next() {
+		integration, err := scanIntegration(rows)
+		if err != nil {
+			r.logger.Error("failed to scan integration", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan integration: %w", err)
+		}
+		integrations = append(integrations, *integration)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating integrations", zap.Error(err))
+		return nil, fmt.Errorf("error iterating integrations: %w", err)
+	}
+
+	return integrations, nil
+}
+
+func scanIntegration(row pgx.Row) (*model.OrganizationIntegration, error) {
+	var integration model.OrganizationIntegration
+	err := row.Scan(
+		&integration.ID,
+		&integration.OrganizationID,
+		&integration.Name,
+		&integration.Kind,
+		&integration.Settings,
+		&integration.SubjectTemplate,
+		&integration.BodyTemplate,
+		&integration.Enabled,
+		&integration.CreatedBy,
+		&integration.CreatedAt,
+		&integration.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &integration, nil
+}
+
+// RecordDelivery saves a delivery attempt
+func (r *IntegrationRepository) RecordDelivery(ctx context.Context, delivery *model.IntegrationDelivery) error {
+	ctx, span := startSpan(ctx, "IntegrationRepository.RecordDelivery")
+	defer span.End()
+
+	query := `
+		INSERT INTO integration_deliveries (
+			id, integration_id, event, user_id, check_in_id, status, error_message, attempted_at
+		) VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		delivery.ID,
+		delivery.IntegrationID,
+		delivery.Event,
+		nullableID(delivery.UserID),
+		delivery.CheckInID,
+		delivery.Status,
+		delivery.ErrorMessage,
+		delivery.AttemptedAt,
+	)
+	if err != nil {
+		r.logger.Error("failed to record delivery",
+			zap.Error(err),
+			zap.String("integration_id", delivery.IntegrationID),
+		)
+		return fmt.Errorf("failed to record delivery: %w", err)
+	}
+
+	return nil
+}
+
+// ListDeliveries retrieves the most recent delivery attempts of an integration
+func (r *IntegrationRepository) ListDeliveries(ctx context.Context, integrationID string, limit int) ([]model.IntegrationDelivery, error) {
+	ctx, span := startSpan(ctx, "IntegrationRepository.ListDeliveries")
+	defer span.End()
+
+	query := `
+		SELECT id, integration_id, event, COALESCE(user_id::text, ''), check_in_id::text,
+			status, COALESCE(error_message, ''), attempted_at
+		FROM integration_deliveries
+		WHERE integration_id = $1
+		ORDER BY attempted_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, integrationID, limit)
+	if err != nil {
+		r.logger.Error("failed to list deliveries", zap.Error(err), zap.String("integration_id", integrationID))
+		return nil, fmt.Errorf("failed to list deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []model.IntegrationDelivery{}
+	for rows.Next() {
+		var delivery model.IntegrationDelivery
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.IntegrationID,
+			&delivery.Event,
+			&delivery.UserID,
+			&delivery.CheckInID,
+			&delivery.Status,
+			&delivery.ErrorMessage,
+			&delivery.AttemptedAt,
+		)
+		if err != nil {
+			r.logger.Error("failed to scan delivery", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating deliveries", zap.Error(err))
+		return nil, fmt.Errorf("error iterating deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// GrantSharingConsent records that a user agrees to share check-ins with an
+// organization's care team. Granting it again keeps the original grant time.
+func (r *IntegrationRepository) GrantSharingConsent(ctx context.Context, orgID, userID string) error {
+	ctx, span := startSpan(ctx, "IntegrationRepository.GrantSharingConsent")
+	defer span.End()
+
+	query := `
+		INSERT INTO care_team_sharing_consents (organization_id, user_id, granted_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (organization_id, user_id) DO NOTHING
+	`
+
+	if _, err := r.db.Exec(ctx, query, orgID, userID); err != nil {
+		r.logger.Error("failed to grant sharing consent",
+			zap.Error(err),
+			zap.String("organization_id", orgID),
+			zap.String("user_id", userID),
+		)
+		return fmt.Errorf("failed to grant sharing consent: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeSharingConsent withdraws a user's consent and reports whether it had been given
+func (r *IntegrationRepository) RevokeSharingConsent(ctx context.Context, orgID, userID string) (bool, error) {
+	ctx, span := startSpan(ctx, "IntegrationRepository.RevokeSharingConsent")
+	defer span.End()
+
+	query := `DELETE FROM care_team_sharing_consents WHERE organization_id = $1 AND user_id = $2`
+
+	result, err := r.db.Exec(ctx, query, orgID, userID)
+	if err != nil {
+		r.logger.Error("failed to revoke sharing consent",
+			zap.Error(err),
+			zap.String("organization_id", orgID),
+			zap.String("user_id", userID),
+		)
+		return false, fmt.Errorf("failed to revoke sharing consent: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -10,9 +12,13 @@ import (
 	"go.uber.org/zap"
 )
 
+// ErrMedicationNotFound is returned when a medication does not exist
+var ErrMedicationNotFound = errors.New("medication not found")
+
 // MedicationRepository manages medication data
 type MedicationRepository struct {
 	db     *pgxpool.Pool
+	reads  *ReadPools
 	logger *zap.Logger
 }
 
@@ -24,14 +30,22 @@ func NewMedicationRepository(db *pgxpool.Pool, logger *zap.Logger) *MedicationRe
 	}
 }
 
+// SetReadPools lets the heavy reads of the repository use the read replica
+func (r *MedicationRepository) SetReadPools(reads *ReadPools) {
+	r.reads = reads
+}
+
 // Create creates a new medication record
 func (r *MedicationRepository) Create(ctx context.Context, med *model.Medication) error {
+	ctx, span := startSpan(ctx, "MedicationRepository.Create")
+	defer span.End()
+
 	query := `
 		INSERT INTO medications (
 			id, user_id, name, dosage, frequency,
 			start_date, end_date, notes, active,
-			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
+			created_at, updated_at, interaction_warnings
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW(), $10)
 	`
 
 	_, err := r.db.Exec(ctx, query,
@@ -44,6 +58,7 @@ func (r *MedicationRepository) Create(ctx context.Context, med *model.Medication
 		med.EndDate,
 		med.Notes,
 		med.Active,
+		interactionWarnings(med),
 	)
 
 	if err != nil {
@@ -60,39 +75,89 @@ func (r *MedicationRepository) Create(ctx context.Context, med *model.Medication
 
 // FindByUserID retrieves all medications for a user, sorted by start date
 func (r *MedicationRepository) FindByUserID(ctx context.Context, userID string) ([]model.Medication, error) {
+	ctx, span := startSpan(ctx, "MedicationRepository.FindByUserID")
+	defer span.End()
+
 	query := `
 		SELECT 
 			id, user_id, name, dosage, frequency,
 			start_date, end_date, notes, active,
-			created_at, updated_at
+			created_at, updated_at, interaction_warnings
 		FROM medications
-		WHERE user_id = $1
-		ORDER BY start_date DESC
+		WHERE user_id = $1 AND deleted_at IS NULL
+		ORDER BY start_date DESC, id DESC
 	`
 
-	rows, err := r.db.Query(ctx, query, userID)
+	rows, err := readDB(ctx, r.reads, r.db).Query(ctx, query, userID)
 	if err != nil {
 		r.logger.Error("failed to find medications", zap.Error(err), zap.String("user_id", userID))
 		return nil, fmt.Errorf("failed to find medications: %w", err)
 	}
 	defer rows.Close()
 
+	return r.scanMedications(rows)
+}
+
+// medicationActiveFilter matches medications whose active flag, counting a past end date
+// as inactive even before the flag is updated, equals $2 unless $2 is NULL
+const medicationActiveFilter = `($2::boolean IS NULL OR (active AND (end_date IS NULL OR end_date > CURRENT_DATE)) = $2)`
+
+// FindPageByUserID retrieves a page of medications for a user, sorted by start date,
+// and the total number of medications. A non-nil active keeps only active or only
+// inactive medications.
+func (r *MedicationRepository) FindPageByUserID(ctx context.Context, userID string, active *bool, page Page) ([]model.Medication, int, error) {
+	ctx, span := startSpan(ctx, "MedicationRepository.FindPageByUserID")
+	defer span.End()
+
+	page = page.Normalize()
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM medications WHERE user_id = $1 AND deleted_at IS NULL AND ` + medicationActiveFilter
+	if err := r.db.QueryRow(ctx, countQuery, userID, active).Scan(&total); err != nil {
+		r.logger.Error("failed to count medications", zap.Error(err), zap.String("user_id", userID))
+		return nil, 0, fmt.Errorf("failed to count medications: %w", err)
+	}
+
+	query := `
+		SELECT 
+			id, user_id, name, dosage, frequency,
+			start_date, end_date, notes, active,
+			created_at, updated_at, interaction_warnings
+		FROM medications
+		WHERE user_id = $1 AND deleted_at IS NULL AND ` + medicationActiveFilter + `
+		ORDER BY start_date DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, active, page.Limit, page.Offset)
+	if err != nil {
+		r.logger.Error("failed to find medications", zap.Error(err), zap.String("user_id", userID))
+		return nil, 0, fmt.Errorf("failed to find medications: %w", err)
+	}
+	defer rows.Close()
+
+	medications, err := r.scanMedications(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return medications, total, nil
+}
+
+// interactionWarnings returns the warnings column value of a medication, an empty array
+// when it has none
+func interactionWarnings(med *model.Medication) []model.InteractionWarning {
+	if med.InteractionWarnings == nil {
+		return []model.InteractionWarning{}
+	}
+	return med.InteractionWarnings
+}
+
+// scanMedications reads medication rows
+func (r *MedicationRepository) scanMedications(rows pgx.Rows) ([]model.Medication, error) {
 	var medications []model.Medication
 	for rows.Next() {
-		var med model.Medication
-		err := rows.Scan(
-			&med.ID,
-			&med.UserID,
-			&med.Name,
-			&med.Dosage,
-			&med.Frequency,
-			&med.StartDate,
-			&med.EndDate,
-			&med.Notes,
-			&med.Active,
-			&med.CreatedAt,
-			&med.UpdatedAt,
-		)
+		med, err := scanMedication(rows)
 		if err != nil {
 			r.logger.Error("failed to scan medication", zap.Error(err))
 			continue
@@ -108,17 +173,96 @@ func (r *MedicationRepository) FindByUserID(ctx context.Context, userID string)
 	return medications, nil
 }
 
-// FindByID retrieves a medication by ID
-func (r *MedicationRepository) FindByID(ctx context.Context, medicationID string) (*model.Medication, error) {
+// scanMedication reads the current medication row
+func scanMedication(rows pgx.Rows) (model.Medication, error) {
+	var med model.Medication
+	err := rows.Scan(
+		&med.ID,
+		&med.UserID,
+		&med.Name,
+		&med.Dosage,
+		&med.Frequency,
+		&med.StartDate,
+		&med.EndDate,
+		&med.Notes,
+		&med.Active,
+		&med.CreatedAt,
+		&med.UpdatedAt,
+		&med.InteractionWarnings,
+	)
+	return med, err
+}
+
+// StreamByUserID calls fn for each medication of a user prescribed at some point from start
+// up to, but excluding, end, in start date order, without loading them all into memory.
+// An error returned by fn stops the iteration and is returned.
+func (r *MedicationRepository) StreamByUserID(ctx context.Context, userID string, start, end time.Time, fn func(model.Medication) error) error {
+	ctx, span := startSpan(ctx, "MedicationRepository.StreamByUserID")
+	defer span.End()
+
 	query := `
 		SELECT 
 			id, user_id, name, dosage, frequency,
 			start_date, end_date, notes, active,
-			created_at, updated_at
+			created_at, updated_at, interaction_warnings
 		FROM medications
-		WHERE id = $1
+		WHERE user_id = $1 AND deleted_at IS NULL
+			AND start_date < $3 AND (end_date IS NULL OR end_date >= $2)
+		ORDER BY start_date ASC, id ASC
 	`
 
+	rows, err := r.db.Query(ctx, query, userID, start, end)
+	if err != nil {
+		r.logger.Error("failed to stream medications", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to stream medications: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		med, err := scanMedication(rows)
+		if err != nil {
+			r.logger.Error("failed to scan medication", zap.Error(err))
+			continue
+		}
+		if err := fn(med); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating medications", zap.Error(err))
+		return fmt.Errorf("error iterating medications: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a medication by ID, not found once it has been deleted
+func (r *MedicationRepository) FindByID(ctx context.Context, medicationID string) (*model.Medication, error) {
+	ctx, span := startSpan(ctx, "MedicationRepository.FindByID")
+	defer span.End()
+
+	return r.findByID(ctx, medicationID, "deleted_at IS NULL")
+}
+
+// FindDeletedByID retrieves a soft-deleted medication by ID, so it can be restored
+func (r *MedicationRepository) FindDeletedByID(ctx context.Context, medicationID string) (*model.Medication, error) {
+	ctx, span := startSpan(ctx, "MedicationRepository.FindDeletedByID")
+	defer span.End()
+
+	return r.findByID(ctx, medicationID, "deleted_at IS NOT NULL")
+}
+
+// findByID retrieves a medication by ID whose row also matches deletedFilter
+func (r *MedicationRepository) findByID(ctx context.Context, medicationID, deletedFilter string) (*model.Medication, error) {
+	query := `
+		SELECT 
+			id, user_id, name, dosage, frequency,
+			start_date, end_date, notes, active,
+			created_at, updated_at, interaction_warnings
+		FROM medications
+		WHERE id = $1 AND ` + deletedFilter
+
 	var med model.Medication
 	err := r.db.QueryRow(ctx, query, medicationID).Scan(
 		&med.ID,
@@ -132,11 +276,12 @@ func (r *MedicationRepository) FindByID(ctx context.Context, medicationID string
 		&med.Active,
 		&med.CreatedAt,
 		&med.UpdatedAt,
+		&med.InteractionWarnings,
 	)
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("medication not found: %s", medicationID)
+			return nil, fmt.Errorf("%w: %s", ErrMedicationNotFound, medicationID)
 		}
 		r.logger.Error("failed to find medication", zap.Error(err), zap.String("medication_id", medicationID))
 		return nil, fmt.Errorf("failed to find medication: %w", err)
@@ -147,12 +292,15 @@ func (r *MedicationRepository) FindByID(ctx context.Context, medicationID string
 
 // Update updates an existing medication record
 func (r *MedicationRepository) Update(ctx context.Context, med *model.Medication) error {
+	ctx, span := startSpan(ctx, "MedicationRepository.Update")
+	defer span.End()
+
 	query := `
 		UPDATE medications
 		SET name = $1, dosage = $2, frequency = $3,
 		    start_date = $4, end_date = $5, notes = $6,
-		    active = $7, updated_at = NOW()
-		WHERE id = $8
+		    active = $7, interaction_warnings = $8, updated_at = NOW()
+		WHERE id = $9 AND deleted_at IS NULL
 	`
 
 	result, err := r.db.Exec(ctx, query,
@@ -163,6 +311,7 @@ func (r *MedicationRepository) Update(ctx context.Context, med *model.Medication
 		med.EndDate,
 		med.Notes,
 		med.Active,
+		interactionWarnings(med),
 		med.ID,
 	)
 
@@ -181,9 +330,45 @@ func (r *MedicationRepository) Update(ctx context.Context, med *model.Medication
 	return nil
 }
 
-// Delete deletes a medication record
+// SetActive sets whether a medication is taken and its end date
+func (r *MedicationRepository) SetActive(ctx context.Context, medicationID string, active bool, endDate *time.Time) error {
+	ctx, span := startSpan(ctx, "MedicationRepository.SetActive")
+	defer span.End()
+
+	query := `
+		UPDATE medications
+		SET active = $1, end_date = $2, updated_at = NOW()
+		WHERE id = $3 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, active, endDate, medicationID)
+	if err != nil {
+		r.logger.Error("failed to set medication active",
+			zap.Error(err),
+			zap.String("medication_id", medicationID),
+			zap.Bool("active", active),
+		)
+		return fmt.Errorf("failed to set medication active: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", ErrMedicationNotFound, medicationID)
+	}
+
+	return nil
+}
+
+// Delete soft-deletes a medication, hiding it until it is restored. Its adherence logs
+// and schedule are kept.
 func (r *MedicationRepository) Delete(ctx context.Context, medicationID string) error {
-	query := `DELETE FROM medications WHERE id = $1`
+	ctx, span := startSpan(ctx, "MedicationRepository.Delete")
+	defer span.End()
+
+	query := `
+		UPDATE medications
+		SET deleted_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
 
 	result, err := r.db.Exec(ctx, query, medicationID)
 	if err != nil {
@@ -195,24 +380,81 @@ func (r *MedicationRepository) Delete(ctx context.Context, medicationID string)
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("medication not found: %s", medicationID)
+		return fmt.Errorf("%w: %s", ErrMedicationNotFound, medicationID)
+	}
+
+	return nil
+}
+
+// Restore undoes the soft delete of a medication
+func (r *MedicationRepository) Restore(ctx context.Context, medicationID string) error {
+	ctx, span := startSpan(ctx, "MedicationRepository.Restore")
+	defer span.End()
+
+	query := `
+		UPDATE medications
+		SET deleted_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, medicationID)
+	if err != nil {
+		r.logger.Error("failed to restore medication",
+			zap.Error(err),
+			zap.String("medication_id", medicationID),
+		)
+		return fmt.Errorf("failed to restore medication: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", ErrMedicationNotFound, medicationID)
+	}
+
+	return nil
+}
+
+// DeletePermanently removes a medication, soft-deleted or not, with its adherence logs
+// and schedule
+func (r *MedicationRepository) DeletePermanently(ctx context.Context, medicationID string) error {
+	ctx, span := startSpan(ctx, "MedicationRepository.DeletePermanently")
+	defer span.End()
+
+	query := `DELETE FROM medications WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, medicationID)
+	if err != nil {
+		r.logger.Error("failed to permanently delete medication",
+			zap.Error(err),
+			zap.String("medication_id", medicationID),
+		)
+		return fmt.Errorf("failed to permanently delete medication: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", ErrMedicationNotFound, medicationID)
 	}
 
 	return nil
 }
 
-// LogAdherence logs medication adherence
+// LogAdherence logs medication adherence for the user the medication belongs to
 func (r *MedicationRepository) LogAdherence(ctx context.Context, log *model.MedicationLog) error {
+	ctx, span := startSpan(ctx, "MedicationRepository.LogAdherence")
+	defer span.End()
+
 	query := `
-		INSERT INTO medication_logs (id, medication_id, taken_at, adherence, created_at)
-		VALUES ($1, $2, $3, $4, NOW())
+		INSERT INTO medication_logs (id, medication_id, user_id, taken_at, adherence, notes, created_at)
+		SELECT $1, m.id, m.user_id, $3, $4, $5, NOW()
+		FROM medications m
+		WHERE m.id = $2 AND m.deleted_at IS NULL
 	`
 
-	_, err := r.db.Exec(ctx, query,
+	result, err := r.db.Exec(ctx, query,
 		log.ID,
 		log.MedicationID,
 		log.TakenAt,
 		log.Adherence,
+		log.Notes,
 	)
 
 	if err != nil {
@@ -223,19 +465,38 @@ func (r *MedicationRepository) LogAdherence(ctx context.Context, log *model.Medi
 		return fmt.Errorf("failed to log medication adherence: %w", err)
 	}
 
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", ErrMedicationNotFound, log.MedicationID)
+	}
+
 	return nil
 }
 
-// GetAdherenceLogs retrieves adherence logs for a medication
-func (r *MedicationRepository) GetAdherenceLogs(ctx context.Context, medicationID string) ([]model.MedicationLog, error) {
-	query := `
-		SELECT id, medication_id, taken_at, adherence, created_at
+// GetAdherenceLogs retrieves adherence logs for a medication taken from from, inclusive,
+// to to, exclusive, newest first. A zero bound leaves that side of the window open.
+func (r *MedicationRepository) GetAdherenceLogs(ctx context.Context, medicationID string, from, to time.Time) ([]model.MedicationLog, error) {
+	ctx, span := startSpan(ctx, "MedicationRepository.GetAdherenceLogs")
+	defer span.End()
+
+	args := []interface{}{medicationID}
+	where := "medication_id = $1"
+	if !from.IsZero() {
+		args = append(args, from)
+		where += fmt.Sprintf(" AND taken_at >= $%d", len(args))
+	}
+	if !to.IsZero() {
+		args = append(args, to)
+		where += fmt.Sprintf(" AND taken_at < $%d", len(args))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, medication_id, taken_at, adherence, notes, created_at
 		FROM medication_logs
-		WHERE medication_id = $1
+		WHERE %s
 		ORDER BY taken_at DESC
-	`
+	`, where)
 
-	rows, err := r.db.Query(ctx, query, medicationID)
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		r.logger.Error("failed to get adherence logs", zap.Error(err), zap.String("medication_id", medicationID))
 		return nil, fmt.Errorf("failed to get adherence logs: %w", err)
@@ -250,6 +511,7 @@ func (r *MedicationRepository) GetAdherenceLogs(ctx context.Context, medicationI
 			&log.MedicationID,
 			&log.TakenAt,
 			&log.Adherence,
+			&log.Notes,
 			&log.CreatedAt,
 		)
 		if err != nil {
@@ -266,3 +528,341 @@ func (r *MedicationRepository) GetAdherenceLogs(ctx context.Context, medicationI
 
 	return logs, nil
 }
+
+// MedicationAdherence is the adherence score of a medication over a time window
+type MedicationAdherence struct {
+	MedicationID string   `json:"medication_id"`
+	Name         string   `json:"name"`
+	Taken        int      `json:"taken"`
+	Expected     int      `json:"expected"`
+	Score        *float64 `json:"score"` // taken/expected in [0, 1], nil when the medication has no schedule
+}
+
+// GetAdherenceRate computes the adherence of a medication between start and end.
+// Expected doses are derived from the medication's schedule, limited to the part
+// of the window in which the medication was prescribed. Taken doses are logs with
+// adherence set; the score is capped at 1 when more doses were logged than scheduled.
+func (r *MedicationRepository) GetAdherenceRate(ctx context.Context, medicationID string, start, end time.Time) (*MedicationAdherence, error) {
+	ctx, span := startSpan(ctx, "MedicationRepository.GetAdherenceRate")
+	defer span.End()
+
+	med, err := r.FindByID(ctx, medicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := r.GetSchedule(ctx, medicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := PrescribedWindow(med, start, end)
+
+	query := `
+		SELECT COUNT(*)
+		FROM medication_logs
+		WHERE medication_id = $1 AND adherence AND taken_at >= $2 AND taken_at <= $3
+	`
+
+	var taken int
+	if err := r.db.QueryRow(ctx, query, medicationID, from, to).Scan(&taken); err != nil {
+		r.logger.Error("failed to count taken doses", zap.Error(err), zap.String("medication_id", medicationID))
+		return nil, fmt.Errorf("failed to count taken doses: %w", err)
+	}
+
+	adherence := computeAdherence(schedule, taken, from, to)
+	adherence.MedicationID = med.ID
+	adherence.Name = med.Name
+	return &adherence, nil
+}
+
+// PrescribedWindow narrows [start, end] to the dates the medication was prescribed
+func PrescribedWindow(med *model.Medication, start, end time.Time) (time.Time, time.Time) {
+	from, to := start, end
+	if med.StartDate.After(from) {
+		from = med.StartDate
+	}
+	if med.EndDate != nil {
+		// end_date is inclusive
+		lastDay := med.EndDate.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		if lastDay.Before(to) {
+			to = lastDay
+		}
+	}
+	return from, to
+}
+
+// computeAdherence scores taken doses against the doses scheduled in [from, to]
+func computeAdherence(schedule *model.MedicationSchedule, taken int, from, to time.Time) MedicationAdherence {
+	adherence := MedicationAdherence{Taken: taken}
+	if schedule == nil {
+		return adherence
+	}
+
+	adherence.Expected = ExpectedDoses(schedule, from, to)
+	if adherence.Expected == 0 {
+		return adherence
+	}
+
+	score := float64(min(taken, adherence.Expected)) / float64(adherence.Expected)
+	adherence.Score = &score
+	return adherence
+}
+
+// ExpectedDoses counts the scheduled dose times falling within [from, to]; as-needed
+// schedules expect none
+func ExpectedDoses(schedule *model.MedicationSchedule, from, to time.Time) int {
+	if schedule == nil || schedule.AsNeeded || to.Before(from) {
+		return 0
+	}
+
+	var offsets []time.Duration
+	for _, t := range schedule.TimesOfDay {
+		parsed, err := time.Parse("15:04", t)
+		if err != nil {
+			continue
+		}
+		offsets = append(offsets, time.Duration(parsed.Hour())*time.Hour+time.Duration(parsed.Minute())*time.Minute)
+	}
+
+	days := make(map[time.Weekday]bool, len(schedule.DaysOfWeek))
+	for _, d := range schedule.DaysOfWeek {
+		days[d] = true
+	}
+
+	count := 0
+	loc := from.Location()
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc); !day.After(to); day = day.AddDate(0, 0, 1) {
+		if len(days) > 0 && !days[day.Weekday()] {
+			continue
+		}
+		for _, offset := range offsets {
+			dose := day.Add(offset)
+			if !dose.Before(from) && !dose.After(to) {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// SaveSchedule creates or replaces the reminder schedule of a medication
+func (r *MedicationRepository) SaveSchedule(ctx context.Context, schedule *model.MedicationSchedule) error {
+	ctx, span := startSpan(ctx, "MedicationRepository.SaveSchedule")
+	defer span.End()
+
+	query := `
+		INSERT INTO medication_schedules (
+			id, medication_id, times_of_day, days_of_week, as_needed, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		ON CONFLICT (medication_id) DO UPDATE
+		SET times_of_day = EXCLUDED.times_of_day,
+		    days_of_week = EXCLUDED.days_of_week,
+		    as_needed = EXCLUDED.as_needed,
+		    updated_at = NOW()
+	`
+
+	days := make([]int32, 0, len(schedule.DaysOfWeek))
+	for _, d := range schedule.DaysOfWeek {
+		days = append(days, int32(d))
+	}
+
+	times := schedule.TimesOfDay
+	if times == nil {
+		times = []string{}
+	}
+
+	_, err := r.db.Exec(ctx, query,
+		schedule.ID,
+		schedule.MedicationID,
+		times,
+		days,
+		schedule.AsNeeded,
+	)
+
+	if err != nil {
+		r.logger.Error("failed to save medication schedule",
+			zap.Error(err),
+			zap.String("medication_id", schedule.MedicationID),
+		)
+		return fmt.Errorf("failed to save medication schedule: %w", err)
+	}
+
+	return nil
+}
+
+// GetSchedule retrieves the reminder schedule of a medication, or nil if none is stored
+func (r *MedicationRepository) GetSchedule(ctx context.Context, medicationID string) (*model.MedicationSchedule, error) {
+	ctx, span := startSpan(ctx, "MedicationRepository.GetSchedule")
+	defer span.End()
+
+	query := `
+		SELECT ` + medicationScheduleColumns + `
+		FROM medication_schedules
+		WHERE medication_id = $1
+	`
+
+	schedule, err := scanMedicationSchedule(r.db.QueryRow(ctx, query, medicationID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		r.logger.Error("failed to get medication schedule", zap.Error(err), zap.String("medication_id", medicationID))
+		return nil, fmt.Errorf("failed to get medication schedule: %w", err)
+	}
+
+	return schedule, nil
+}
+
+// GetSchedulesByUserID retrieves the stored reminder schedules of a user's medications,
+// keyed by medication ID
+func (r *MedicationRepository) GetSchedulesByUserID(ctx context.Context, userID string) (map[string]*model.MedicationSchedule, error) {
+	ctx, span := startSpan(ctx, "MedicationRepository.GetSchedulesByUserID")
+	defer span.End()
+
+	query := `
+		SELECT ` + medicationScheduleColumns + `
+		FROM medication_schedules
+		WHERE medication_id IN (SELECT id FROM medications WHERE user_id = $1 AND deleted_at IS NULL)
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		r.logger.Error("failed to get medication schedules", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get medication schedules: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := make(map[string]*model.MedicationSchedule)
+	for rows.Next() {
+		schedule, err := scanMedicationSchedule(rows)
+		if err != nil {
+			r.logger.Error("failed to scan medication schedule", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan medication schedule: %w", err)
+		}
+		schedules[schedule.MedicationID] = schedule
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating medication schedules", zap.Error(err))
+		return nil, fmt.Errorf("error iterating medication schedules: %w", err)
+	}
+
+	return schedules, nil
+}
+
+// medicationScheduleColumns are the columns scanned by scanMedicationSchedule
+const medicationScheduleColumns = `id, medication_id, times_of_day, days_of_week, as_needed, created_at, updated_at`
+
+// scanMedicationSchedule scans a row of medicationScheduleColumns
+func scanMedicationSchedule(row pgx.Row) (*model.MedicationSchedule, error) {
+	var schedule model.MedicationSchedule
+	var days []int32
+	err := row.Scan(
+		&schedule.ID,
+		&schedule.MedicationID,
+		&schedule.TimesOfDay,
+		&days,
+		&schedule.AsNeeded,
+		&schedule.CreatedAt,
+		&schedule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range days {
+		schedule.DaysOfWeek = append(schedule.DaysOfWeek, time.Weekday(d))
+	}
+	return &schedule, nil
+}
+
+// GetUserAdherenceLogs retrieves the adherence logs of all of a user's medications taken
+// from from, inclusive, to to, exclusive, oldest first
+func (r *MedicationRepository) GetUserAdherenceLogs(ctx context.Context, userID string, from, to time.Time) ([]model.MedicationLog, error) {
+	ctx, span := startSpan(ctx, "MedicationRepository.GetUserAdherenceLogs")
+	defer span.End()
+
+	query := `
+		SELECT ml.id, ml.medication_id, ml.taken_at, ml.adherence, ml.notes, ml.created_at
+		FROM medication_logs ml
+		JOIN medications m ON m.id = ml.medication_id
+		WHERE m.user_id = $1 AND m.deleted_at IS NULL AND ml.taken_at >= $2 AND ml.taken_at < $3
+		ORDER BY ml.taken_at, ml.id
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, from, to)
+	if err != nil {
+		r.logger.Error("failed to get user adherence logs", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get user adherence logs: %w", err)
+	}
+	defer rows.Close()
+
+	var logs []model.MedicationLog
+	for rows.Next() {
+		var log model.MedicationLog
+		err := rows.Scan(
+			&log.ID,
+			&log.MedicationID,
+			&log.TakenAt,
+			&log.Adherence,
+			&log.Notes,
+			&log.CreatedAt,
+		)
+		if err != nil {
+			r.logger.Error("failed to scan adherence log", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan adherence log: %w", err)
+		}
+		logs = append(logs, log)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating user adherence logs", zap.Error(err))
+		return nil, fmt.Errorf("error iterating user adherence logs: %w", err)
+	}
+
+	return logs, nil
+}
+
+// GetInteractions retrieves all known medication interactions
+func (r *MedicationRepository) GetInteractions(ctx context.Context) ([]model.MedicationInteraction, error) {
+	ctx, span := startSpan(ctx, "MedicationRepository.GetInteractions")
+	defer span.End()
+
+	query := `
+		SELECT id, drug_a, drug_b, severity, description
+		FROM medication_interactions
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		r.logger.Error("failed to get medication interactions", zap.Error(err))
+		return nil, fmt.Errorf("failed to get medication interactions: %w", err)
+	}
+	defer rows.Close()
+
+	var interactions []model.MedicationInteraction
+	for rows.Next() {
+		var interaction model.MedicationInteraction
+		err := rows.Scan(
+			&interaction.ID,
+			&interaction.DrugA,
+			&interaction.DrugB,
+			&interaction.Severity,
+			&interaction.Description,
+		)
+		if err != nil {
+			r.logger.Error("failed to scan medication interaction", zap.Error(err))
+			continue
+		}
+		interactions = append(interactions, interaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating medication interactions", zap.Error(err))
+		return nil, fmt.Errorf("error iterating medication interactions: %w", err)
+	}
+
+	return interactions, nil
+}
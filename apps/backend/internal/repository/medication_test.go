@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// createTestMedication adds an active medication for userID
+func createTestMedication(t *testing.T, repo *MedicationRepository, userID string) *model.Medication {
+	med := &model.Medication{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Name:      "Metformin",
+		Dosage:    "500mg",
+		Frequency: "twice daily",
+		StartDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Active:    true,
+	}
+	require.NoError(t, repo.Create(context.Background(), med))
+	return med
+}
+
+func TestMedicationDelete_SoftDeletesAndRestores(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewMedicationRepository(pool, zap.NewNop())
+	userID := createTestUser(t, pool)
+	med := createTestMedication(t, repo, userID)
+	require.NoError(t, repo.LogAdherence(ctx, &model.MedicationLog{
+		ID:           uuid.New().String(),
+		MedicationID: med.ID,
+		TakenAt:      time.Now(),
+		Adherence:    true,
+	}))
+
+	require.NoError(t, repo.Delete(ctx, med.ID))
+
+	medications, err := repo.FindByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, medications, "a deleted medication is not listed")
+	_, total, err := repo.FindPageByUserID(ctx, userID, nil, Page{Limit: 50})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+	_, err = repo.FindByID(ctx, med.ID)
+	assert.ErrorIs(t, err, ErrMedicationNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, med.ID), ErrMedicationNotFound, "a medication is deleted once")
+
+	deleted, err := repo.FindDeletedByID(ctx, med.ID)
+	require.NoError(t, err)
+	assert.Equal(t, userID, deleted.UserID)
+
+	require.NoError(t, repo.Restore(ctx, med.ID))
+	assert.ErrorIs(t, repo.Restore(ctx, med.ID), ErrMedicationNotFound, "only deleted medications are restored")
+
+	medications, err = repo.FindByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, medications, 1)
+	assert.Equal(t, med.ID, medications[0].ID)
+	logs, err := repo.GetAdherenceLogs(ctx, med.ID, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	assert.Len(t, logs, 1, "adherence logs survive the soft delete")
+}
+
+func TestMedicationDeletePermanently_RemovesRow(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewMedicationRepository(pool, zap.NewNop())
+	userID := createTestUser(t, pool)
+	med := createTestMedication(t, repo, userID)
+	softDeleted := createTestMedication(t, repo, userID)
+	require.NoError(t, repo.Delete(ctx, softDeleted.ID))
+
+	require.NoError(t, repo.DeletePermanently(ctx, med.ID))
+	require.NoError(t, repo.DeletePermanently(ctx, softDeleted.ID), "soft-deleted medications can be removed for good")
+
+	var count int
+	require.NoError(t, pool.QueryRow(ctx, `SELECT COUNT(*) FROM medications WHERE user_id = $1`, userID).Scan(&count))
+	assert.Zero(t, count)
+	assert.ErrorIs(t, repo.Restore(ctx, softDeleted.ID), ErrMedicationNotFound)
+	assert.ErrorIs(t, repo.DeletePermanently(ctx, med.ID), ErrMedicationNotFound)
+}
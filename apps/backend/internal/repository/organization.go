@@ -0,0 +1,410 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// OrganizationRepository manages organizations, role assignments and invitations
+type OrganizationRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewOrganizationRepository creates a new OrganizationRepository
+func NewOrganizationRepository(db *pgxpool.Pool, logger *zap.Logger) *OrganizationRepository {
+	return &OrganizationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateOrganization saves a new organization
+func (r *OrganizationRepository) CreateOrganization(ctx context.Context, org *model.Organization) error {
+	ctx, span := startSpan(ctx, "OrganizationRepository.CreateOrganization")
+	defer span.End()
+
+	query := `
+		INSERT INTO organizations (id, name, data_residency, created_at)
+		VALUES ($1, $2, NULLIF($3, ''), NOW())
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRow(ctx, query, org.ID, org.Name, org.DataResidency).Scan(&org.CreatedAt)
+	if err != nil {
+		r.logger.Error("failed to create organization", zap.Error(err), zap.String("organization_id", org.ID))
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	return nil
+}
+
+// GetOrganization retrieves an organization by ID. It returns nil when the organization
+// does not exist.
+func (r *OrganizationRepository) GetOrganization(ctx context.Context, orgID string) (*model.Organization, error) {
+	ctx, span := startSpan(ctx, "OrganizationRepository.GetOrganization")
+	defer span.End()
+
+	query := `SELECT id, name, COALESCE(data_residency, ''), created_at FROM organizations WHERE id = $1`
+
+	var org model.Organization
+	err := r.db.QueryRow(ctx, query, orgID).Scan(&org.ID, &org.Name, &org.DataResidency, &org.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		r.logger.Error("failed to get organization", zap.Error(err), zap.String("organization_id", orgID))
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	return &org, nil
+}
+
+// SetDataResidency changes the data residency of an organization, clearing it when
+// residency is empty, and reports whether the organization exists
+func (r *OrganizationRepository) SetDataResidency(ctx context.Context, orgID, residency string) (bool, error) {
+	ctx, span := startSpan(ctx, "OrganizationRepository.SetDataResidency")
+	defer span.End()
+
+	query := `UPDATE organizations SET data_residency = NULLIF($2, '') WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, orgID, residency)
+	if err != nil {
+		r.logger.Error("failed to set data residency", zap.Error(err), zap.String("organization_id", orgID))
+		return false, fmt.Errorf("failed to set data residency: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// GetUserDataResidencies retrieves the distinct data residencies of the organizations a
+// user is a patient of, by role or on a clinician's panel
+func (r *OrganizationRepository) GetUserDataResidencies(ctx context.Context, userID string) ([]string, error) {
+	ctx, span := startSpan(ctx, "OrganizationRepository.GetUserDataResidencies")
+	defer span.End()
+
+	query := `
+		SELECT DISTINCT o.data_residency
+		FROM organizations o
+		WHERE o.data_residency IS NOT NULL
+			AND (
+				EXISTS (
+					SELECT 1 FROM organization_roles r
+					WHERE r.organization_id = o.id AND r.user_id = $1 AND r.role = $2
+				)
+				OR EXISTS (
+					SELECT 1 FROM clinician_patient_assignments a
+					WHERE a.organization_id = o.id AND a.patient_id = $1
+				)
+			)
+		ORDER BY o.data_residency
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, model.RolePatient)
+	if err != nil {
+		r.logger.Error("failed to get data residencies", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get data residencies: %w", err)
+	}
+	defer rows.Close()
+
+	var residencies []string
+	for rows.Next() {
+		var residency string
+		if err := rows.Scan(&residency); err != nil {
+			r.logger.Error("failed to scan data residency", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan data residency: %w", err)
+		}
+		residencies = append(residencies, residency)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating data residencies", zap.Error(err))
+		return nil, fmt.Errorf("error iterating data residencies: %w", err)
+	}
+
+	return residencies, nil
+}
+
+// AssignRole grants a role and reports whether it was newly granted. Assigning a role
+// the user already holds is a no-op.
+func (r *OrganizationRepository) AssignRole(ctx context.Context, assignment *model.RoleAssignment) (bool, error) {
+	ctx, span := startSpan(ctx, "OrganizationRepository.AssignRole")
+	defer span.End()
+
+	created, err := assignRole(ctx, r.db, assignment)
+	if err != nil {
+		r.logger.Error("failed to assign role",
+			zap.Error(err),
+			zap.String("organization_id", assignment.OrganizationID),
+			zap.String("user_id", assignment.UserID),
+			zap.String("role", string(assignment.Role)),
+		)
+		return false, fmt.Errorf("failed to assign role: %w", err)
+	}
+	return created, nil
+}
+
+// RevokeRole removes a role and reports whether the user held it
+func (r *OrganizationRepository) RevokeRole(ctx context.Context, orgID, userID string, role model.Role) (bool, error) {
+	ctx, span := startSpan(ctx, "OrganizationRepository.RevokeRole")
+	defer span.End()
+
+	query := `
+		DELETE FROM organization_roles
+		WHERE organization_id IS NOT DISTINCT FROM $1 AND user_id = $2 AND role = $3
+	`
+
+	result, err := r.db.Exec(ctx, query, nullableID(orgID), userID, role)
+	if err != nil {
+		r.logger.Error("failed to revoke role",
+			zap.Error(err),
+			zap.String("organization_id", orgID),
+			zap.String("user_id", userID),
+			zap.String("role", string(role)),
+		)
+		return false, fmt.Errorf("failed to revoke role: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// GetMembers retrieves the role assignments of an organization ordered by user
+func (r *OrganizationRepository) GetMembers(ctx context.Context, orgID string) ([]model.RoleAssignment, error) {
+	ctx, span := startSpan(ctx, "OrganizationRepository.GetMembers")
+	defer span.End()
+
+	query := `
+		SELECT COALESCE(organization_id::text, ''), user_id, role, COALESCE(granted_by::text, ''), created_at
+		FROM organization_roles
+		WHERE organization_id = $1
+		ORDER BY user_id, role
+	`
+
+	rows, err := r.db.Query(ctx, query, orgID)
+	if err != nil {
+		r.logger.Error("failed to get organization members", zap.Error(err), zap.String("organization_id", orgID))
+		return nil, fmt.Errorf("failed to get organization members: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanRoleAssignments(rows)
+}
+
+// GetRolesByUserID retrieves every role a user holds, system-wide roles included
+func (r *OrganizationRepository) GetRolesByUserID(ctx context.Context, userID string) ([]model.RoleAssignment, error) {
+	ctx, span := startSpan(ctx, "OrganizationRepository.GetRolesByUserID")
+	defer span.End()
+
+	query := `
+		SELECT COALESCE(organization_id::text, ''), user_id, role, COALESCE(granted_by::text, ''), created_at
+		FROM organization_roles
+		WHERE user_id = $1
+		ORDER BY organization_id NULLS FIRST, role
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		r.logger.Error("failed to get user roles", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get user roles: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanRoleAssignments(rows)
+}
+
+// IsCaregiverOf reports whether a user is a caregiver in an organization the patient
+// belongs to and has consented to share check-ins with
+func (r *OrganizationRepository) IsCaregiverOf(ctx context.Context, caregiverID, patientID string) (bool, error) {
+	ctx, span := startSpan(ctx, "OrganizationRepository.IsCaregiverOf")
+	defer span.End()
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM organization_roles caregiver
+			JOIN care_team_sharing_consents c ON c.organization_id = caregiver.organization_id
+			JOIN organization_roles patient ON patient.organization_id = caregiver.organization_id
+			WHERE caregiver.user_id = $1 AND caregiver.role = $3
+				AND c.user_id = $2 AND patient.user_id = $2
+		)
+	`
+
+	var caregiver bool
+	if err := r.db.QueryRow(ctx, query, caregiverID, patientID, model.RoleCaregiver).Scan(&caregiver); err != nil {
+		r.logger.Error("failed to check caregiver access",
+			zap.Error(err),
+			zap.String("caregiver_id", caregiverID),
+			zap.String("patient_id", patientID),
+		)
+		return false, fmt.Errorf("failed to check caregiver access: %w", err)
+	}
+
+	return caregiver, nil
+}
+
+// scanRoleAssignments reads role assignment rows, skipping rows that fail to scan
+func (r *OrganizationRepository) scanRoleAssignments(rows pgx.Rows) ([]model.RoleAssignment, error) {
+	var assignments []model.RoleAssignment
+	for rows.Next() {
+		var assignment model.RoleAssignment
+		err := rows.Scan(
+			&assignment.OrganizationID,
+			&assignment.UserID,
+			&assignment.Role,
+			&assignment.GrantedBy,
+			&assignment.CreatedAt,
+		)
+		if err != nil {
+			r.logger.Error("failed to scan role assignment", zap.Error(err))
+			continue
+		}
+		assignments = append(assignments, assignment)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating role assignments", zap.Error(err))
+		return nil, fmt.Errorf("error iterating role assignments: %w", err)
+	}
+
+	return assignments, nil
+}
+
+// CreateInvitation saves a new invitation
+func (r *OrganizationRepository) CreateInvitation(ctx context.Context, invitation *model.OrganizationInvitation) error {
+	ctx, span := startSpan(ctx, "OrganizationRepository.CreateInvitation")
+	defer span.End()
+
+	query := `
+		INSERT INTO organization_invitations (
+			id, organization_id, email, role, token_hash,
+			invited_by, expires_at, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		invitation.ID,
+		invitation.OrganizationID,
+		invitation.Email,
+		invitation.Role,
+		invitation.TokenHash,
+		invitation.InvitedBy,
+		invitation.ExpiresAt,
+	).Scan(&invitation.CreatedAt)
+
+	if err != nil {
+		r.logger.Error("failed to create invitation",
+			zap.Error(err),
+			zap.String("organization_id", invitation.OrganizationID),
+		)
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	return nil
+}
+
+// AcceptInvitation marks the pending, unexpired invitation with tokenHash accepted by
+// userID and grants its role in one transaction. It returns nil when no such invitation
+// exists, so an invitation can be accepted only once.
+func (r *OrganizationRepository) AcceptInvitation(ctx context.Context, tokenHash, userID string, now time.Time) (*model.OrganizationInvitation, error) {
+	ctx, span := startSpan(ctx, "OrganizationRepository.AcceptInvitation")
+	defer span.End()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE organization_invitations
+		SET accepted_at = $3, accepted_by = $2
+		WHERE token_hash = $1 AND accepted_at IS NULL AND expires_at > $3
+		RETURNING id, organization_id, email, role, invited_by, expires_at, accepted_at, accepted_by, created_at
+	`
+
+	var invitation model.OrganizationInvitation
+	err = tx.QueryRow(ctx, query, tokenHash, userID, now).Scan(
+		&invitation.ID,
+		&invitation.OrganizationID,
+		&invitation.Email,
+		&invitation.Role,
+		&invitation.InvitedBy,
+		&invitation.ExpiresAt,
+		&invitation.AcceptedAt,
+		&invitation.AcceptedBy,
+		&invitation.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		r.logger.Error("failed to accept invitation", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	_, err = assignRole(ctx, tx, &model.RoleAssignment{
+		OrganizationID: invitation.OrganizationID,
+		UserID:         userID,
+		Role:           invitation.Role,
+		GrantedBy:      invitation.InvitedBy,
+	})
+	if err != nil {
+		r.logger.Error("failed to grant invited role",
+			zap.Error(err),
+			zap.String("invitation_id", invitation.ID),
+			zap.String("user_id", userID),
+		)
+		return nil, fmt.Errorf("failed to grant invited role: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &invitation, nil
+}
+
+// roleExecer is implemented by both the pool and a transaction
+type roleExecer interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// assignRole inserts a role assignment unless the user already holds the role, setting
+// its creation time and reporting whether it was inserted
+func assignRole(ctx context.Context, db roleExecer, assignment *model.RoleAssignment) (bool, error) {
+	query := `
+		INSERT INTO organization_roles (organization_id, user_id, role, granted_by, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT DO NOTHING
+		RETURNING created_at
+	`
+
+	err := db.QueryRow(ctx, query,
+		nullableID(assignment.OrganizationID),
+		assignment.UserID,
+		assignment.Role,
+		nullableID(assignment.GrantedBy),
+	).Scan(&assignment.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// nullableID maps an empty ID to NULL
+func nullableID(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
@@ -0,0 +1,34 @@
+package repository
+
+const (
+	// DefaultPageLimit is the page size of list queries that do not specify one
+	DefaultPageLimit = 50
+
+	// MaxPageLimit is the largest page size a list query may request
+	MaxPageLimit = 500
+)
+
+// Page selects a window of a list query
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+// Normalize applies the default and maximum page size and clamps a negative offset
+func (p Page) Normalize() Page {
+	if p.Limit <= 0 {
+		p.Limit = DefaultPageLimit
+	}
+	if p.Limit > MaxPageLimit {
+		p.Limit = MaxPageLimit
+	}
+	if p.Offset < 0 {
+		p.Offset = 0
+	}
+	return p
+}
+
+// HasMore reports whether items follow the page in a list of total items
+func (p Page) HasMore(total int) bool {
+	return p.Offset+p.Limit < total
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+func TestPage_Normalize(t *testing.T) {
+	assert.Equal(t, Page{Limit: DefaultPageLimit}, Page{}.Normalize())
+	assert.Equal(t, Page{Limit: MaxPageLimit, Offset: 10}, Page{Limit: 10000, Offset: 10}.Normalize())
+	assert.Equal(t, Page{Limit: 20}, Page{Limit: 20, Offset: -5}.Normalize())
+
+	assert.True(t, Page{Limit: 50, Offset: 50}.HasMore(120))
+	assert.False(t, Page{Limit: 50, Offset: 100}.HasMore(120))
+	assert.False(t, Page{Limit: 50}.HasMore(50))
+}
+
+func TestGetBloodPressurePageByUserID_PaginatesStably(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewHealthDataRepository(pool, zap.NewNop())
+	userID := createTestUser(t, pool)
+
+	// Readings share timestamps in pairs so the order depends on the id tiebreaker
+	base := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	for i := 0; i < 120; i++ {
+		require.NoError(t, repo.SaveBloodPressure(ctx, &model.BloodPressureReading{
+			ID:         uuid.New().String(),
+			UserID:     userID,
+			Systolic:   110 + i%30,
+			Diastolic:  70 + i%20,
+			Pulse:      60 + i%25,
+			MeasuredAt: base.Add(time.Duration(i/2) * time.Hour),
+		}))
+	}
+
+	seen := make(map[string]bool)
+	var pageSizes []int
+	var previous *model.BloodPressureReading
+	for page := (Page{Limit: 50}); ; page.Offset += page.Limit {
+		readings, total, err := repo.GetBloodPressurePageByUserID(ctx, userID, page)
+		require.NoError(t, err)
+		assert.Equal(t, 120, total)
+
+		pageSizes = append(pageSizes, len(readings))
+		for i := range readings {
+			reading := readings[i]
+			assert.False(t, seen[reading.ID], "reading %s returned twice", reading.ID)
+			seen[reading.ID] = true
+
+			if previous != nil {
+				assert.False(t, reading.MeasuredAt.After(previous.MeasuredAt), "readings not sorted by measured_at")
+				if reading.MeasuredAt.Equal(previous.MeasuredAt) {
+					assert.Less(t, reading.ID, previous.ID, "equal timestamps not ordered by id")
+				}
+			}
+			previous = &reading
+		}
+
+		if !page.HasMore(total) {
+			break
+		}
+	}
+
+	assert.Equal(t, []int{50, 50, 20}, pageSizes)
+	assert.Len(t, seen, 120)
+}
@@ -0,0 +1,339 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// panelFindingsQuery selects the findings of every patient on a clinician's panel
+// ($1 organization, $2 clinician) since $3 in one pass. Patients who no longer hold the
+// patient role in the organization drop off the panel.
+const panelFindingsQuery = `
+	WITH panel AS (
+		SELECT a.patient_id
+		FROM clinician_patient_assignments a
+		JOIN organization_roles r
+			ON r.organization_id = a.organization_id AND r.user_id = a.patient_id AND r.role = 'patient'
+		WHERE a.organization_id = $1 AND a.clinician_id = $2
+	),
+	findings AS (
+		SELECT 'alert' AS kind, al.severity AS severity, al.user_id AS patient_id,
+			al.id AS source_id, al.reason AS detail, al.created_at AS occurred_at
+		FROM alerts al
+		JOIN panel p ON p.patient_id = al.user_id
+		WHERE al.acknowledged_at IS NULL AND al.created_at >= $3
+
+		UNION ALL
+
+		SELECT 'extraction_failed', 'medium', h.user_id, h.id, '', h.created_at
+		FROM health_check_ins h
+		JOIN panel p ON p.patient_id = h.user_id
+		WHERE h.raw_transcript IS NOT NULL AND h.created_at >= $3
+
+		UNION ALL
+
+		SELECT 'extraction_issue', 'medium', h.user_id, h.id,
+			array_to_string(h.extraction_issues, ', '), h.created_at
+		FROM health_check_ins h
+		JOIN panel p ON p.patient_id = h.user_id
+		WHERE cardinality(h.extraction_issues) > 0 AND h.created_at >= $3
+
+		UNION ALL
+
+		SELECT 'low_confidence', 'low', h.user_id, h.id, '', h.created_at
+		FROM health_check_ins h
+		JOIN panel p ON p.patient_id = h.user_id
+		WHERE h.low_confidence AND h.created_at >= $3
+	)
+`
+
+// PanelRepository manages clinicians' patient panels and their digest subscriptions
+type PanelRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewPanelRepository creates a new PanelRepository
+func NewPanelRepository(db *pgxpool.Pool, logger *zap.Logger) *PanelRepository {
+	return &PanelRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// AssignPatient puts a patient on a clinician's panel. Assigning them again keeps the
+// original assignment.
+func (r *PanelRepository) AssignPatient(ctx context.Context, assignment *model.PanelAssignment) error {
+	ctx, span := startSpan(ctx, "PanelRepository.AssignPatient")
+	defer span.End()
+
+	query := `
+		INSERT INTO clinician_patient_assignments (organization_id, clinician_id, patient_id, assigned_by, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (organization_id, clinician_id, patient_id) DO UPDATE
+			SET assigned_by = clinician_patient_assignments.assigned_by
+		RETURNING COALESCE(assigned_by::text, ''), created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		assignment.OrganizationID,
+		assignment.ClinicianID,
+		assignment.PatientID,
+		nullableID(assignment.AssignedBy),
+	).Scan(&assignment.AssignedBy, &assignment.CreatedAt)
+	if err != nil {
+		r.logger.Error("failed to assign patient",
+			zap.Error(err),
+			zap.String("organization_id", assignment.OrganizationID),
+			zap.String("clinician_id", assignment.ClinicianID),
+		)
+		return fmt.Errorf("failed to assign patient: %w", err)
+	}
+
+	return nil
+}
+
+// UnassignPatient removes a patient from a clinician's panel and reports whether they were on it
+func (r *PanelRepository) UnassignPatient(ctx context.Context, orgID, clinicianID, patientID string) (bool, error) {
+	ctx, span := startSpan(ctx, "PanelRepository.UnassignPatient")
+	defer span.End()
+
+	query := `
+		DELETE FROM clinician_patient_assignments
+		WHERE organization_id = $1 AND clinician_id = $2 AND patient_id = $3
+	`
+
+	result, err := r.db.Exec(ctx, query, orgID, clinicianID, patientID)
+	if err != nil {
+		r.logger.Error("failed to unassign patient",
+			zap.Error(err),
+			zap.String("organization_id", orgID),
+			zap.String("clinician_id", clinicianID),
+		)
+		return false, fmt.Errorf("failed to unassign patient: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// ListAssignments retrieves the patients on a clinician's panel, oldest assignment first
+func (r *PanelRepository) ListAssignments(ctx context.Context, orgID, clinicianID string) ([]model.PanelAssignment, error) {
+	ctx, span := startSpan(ctx, "PanelRepository.ListAssignments")
+	defer span.End()
+
+	query := `
+		SELECT organization_id, clinician_id, patient_id, COALESCE(assigned_by::text, ''), created_at
+		FROM clinician_patient_assignments
+		WHERE organization_id = $1 AND clinician_id = $2
+		ORDER BY created_at, patient_id
+	`
+
+	rows, err := r.db.Query(ctx, query, orgID, clinicianID)
+	if err != nil {
+		r.logger.Error("failed to list panel assignments", zap.Error(err), zap.String("clinician_id", clinicianID))
+		return nil, fmt.Errorf("failed to list panel assignments: %w", err)
+	}
+	defer rows.Close()
+
+	var assignments []model.PanelAssignment
+	for rows.Next() {
+		var assignment model.PanelAssignment
+		err := rows.Scan(
+			&assignment.OrganizationID,
+			&assignment.ClinicianID,
+			&assignment.PatientID,
+			&assignment.AssignedBy,
+			&assignment.CreatedAt,
+		)
+		if err != nil {
+			r.logger.Error("failed to scan panel assignment", zap.Error(err))
+			continue
+		}
+		assignments = append(assignments, assignment)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating panel assignments", zap.Error(err))
+		return nil, fmt.Errorf("error iterating panel assignments: %w", err)
+	}
+
+	return assignments, nil
+}
+
+// ListFindings retrieves one page of the findings raised on a clinician's panel from
+// since on, most severe first and newest first within a severity, with the total number
+// of findings
+func (r *PanelRepository) ListFindings(ctx context.Context, orgID, clinicianID string, since time.Time, page Page) ([]model.PanelFinding, int, error) {
+	ctx, span := startSpan(ctx, "PanelRepository.ListFindings")
+	defer span.End()
+
+	page = page.Normalize()
+
+	var total int
+	err := r.db.QueryRow(ctx, panelFindingsQuery+`SELECT COUNT(*) FROM findings`, orgID, clinicianID, since).Scan(&total)
+	if err != nil {
+		r.logger.Error("failed to count panel findings", zap.Error(err), zap.String("clinician_id", clinicianID))
+		return nil, 0, fmt.Errorf("failed to count panel findings: %w", err)
+	}
+
+	query := panelFindingsQuery + `
+		SELECT kind, severity, patient_id::text, source_id::text, detail, occurred_at
+		FROM findings
+		ORDER BY
+			CASE severity WHEN 'critical' THEN 0 WHEN 'high' THEN 1 WHEN 'medium' THEN 2 ELSE 3 END,
+			occurred_at DESC, source_id, kind
+		LIMIT $4 OFFSET $5
+	`
+
+	rows, err := r.db.Query(ctx, query, orgID, clinicianID, since, page.Limit, page.Offset)
+	if err != nil {
+		r.logger.Error("failed to list panel findings", zap.Error(err), zap.String("clinician_id", clinicianID))
+		return nil, 0, fmt.Errorf("failed to list panel findings: %w", err)
+	}
+	defer rows.Close()
+
+	var findings []model.PanelFinding
+	for rows.Next() {
+		var finding model.PanelFinding
+		err := rows.Scan(
+			&finding.Kind,
+			&finding.Severity,
+			&finding.PatientID,
+			&finding.SourceID,
+			&finding.Detail,
+			&finding.OccurredAt,
+		)
+		if err != nil {
+			r.logger.Error("failed to scan panel finding", zap.Error(err))
+			continue
+		}
+		findings = append(findings, finding)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating panel findings", zap.Error(err))
+		return nil, 0, fmt.Errorf("error iterating panel findings: %w", err)
+	}
+
+	return findings, total, nil
+}
+
+// SubscribeDigest opts a clinician in to the daily digest, or changes its address
+func (r *PanelRepository) SubscribeDigest(ctx context.Context, subscription *model.PanelDigestSubscription) error {
+	ctx, span := startSpan(ctx, "PanelRepository.SubscribeDigest")
+	defer span.End()
+
+	query := `
+		INSERT INTO panel_digest_subscriptions (organization_id, clinician_id, email, created_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (organization_id, clinician_id) DO UPDATE SET email = EXCLUDED.email
+		RETURNING last_sent_at, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		subscription.OrganizationID,
+		subscription.ClinicianID,
+		subscription.Email,
+	).Scan(&subscription.LastSentAt, &subscription.CreatedAt)
+	if err != nil {
+		r.logger.Error("failed to subscribe to panel digest",
+			zap.Error(err),
+			zap.String("organization_id", subscription.OrganizationID),
+			zap.String("clinician_id", subscription.ClinicianID),
+		)
+		return fmt.Errorf("failed to subscribe to panel digest: %w", err)
+	}
+
+	return nil
+}
+
+// UnsubscribeDigest opts a clinician out of the daily digest and reports whether they were subscribed
+func (r *PanelRepository) UnsubscribeDigest(ctx context.Context, orgID, clinicianID string) (bool, error) {
+	ctx, span := startSpan(ctx, "PanelRepository.UnsubscribeDigest")
+	defer span.End()
+
+	query := `DELETE FROM panel_digest_subscriptions WHERE organization_id = $1 AND clinician_id = $2`
+
+	result, err := r.db.Exec(ctx, query, orgID, clinicianID)
+	if err != nil {
+		r.logger.Error("failed to unsubscribe from panel digest",
+			zap.Error(err),
+			zap.String("organization_id", orgID),
+			zap.String("clinician_id", clinicianID),
+		)
+		return false, fmt.Errorf("failed to unsubscribe from panel digest: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// FindDueDigests retrieves the subscriptions whose digest was not sent since sentBefore
+func (r *PanelRepository) FindDueDigests(ctx context.Context, sentBefore time.Time) ([]model.PanelDigestSubscription, error) {
+	ctx, span := startSpan(ctx, "PanelRepository.FindDueDigests")
+	defer span.End()
+
+	query := `
+		SELECT organization_id, clinician_id, email, last_sent_at, created_at
+		FROM panel_digest_subscriptions
+		WHERE last_sent_at IS NULL OR last_sent_at <= $1
+		ORDER BY organization_id, clinician_id
+	`
+
+	rows, err := r.db.Query(ctx, query, sentBefore)
+	if err != nil {
+		r.logger.Error("failed to find due panel digests", zap.Error(err))
+		return nil, fmt.Errorf("failed to find due panel digests: %w", err)
+	}
+	defer rows.Close()
+
+	var subscriptions []model.PanelDigestSubscription
+	for rows.Next() {
+		var subscription model.PanelDigestSubscription
+		err := rows.Scan(
+			&subscription.OrganizationID,
+			&subscription.ClinicianID,
+			&subscription.Email,
+			&subscription.LastSentAt,
+			&subscription.CreatedAt,
+		)
+		if err != nil {
+			r.logger.Error("failed to scan panel digest subscription", zap.Error(err))
+			continue
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating panel digest subscriptions", zap.Error(err))
+		return nil, fmt.Errorf("error iterating panel digest subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// MarkDigestSent records when a clinician's digest was sent
+func (r *PanelRepository) MarkDigestSent(ctx context.Context, orgID, clinicianID string, sentAt time.Time) error {
+	ctx, span := startSpan(ctx, "PanelRepository.MarkDigestSent")
+	defer span.End()
+
+	query := `
+		UPDATE panel_digest_subscriptions SET last_sent_at = $3
+		WHERE organization_id = $1 AND clinician_id = $2
+	`
+
+	if _, err := r.db.Exec(ctx, query, orgID, clinicianID, sentAt); err != nil {
+		r.logger.Error("failed to mark panel digest sent",
+			zap.Error(err),
+			zap.String("organization_id", orgID),
+			zap.String("clinician_id", clinicianID),
+		)
+		return fmt.Errorf("failed to mark panel digest sent: %w", err)
+	}
+
+	return nil
+}
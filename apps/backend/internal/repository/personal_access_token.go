@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// ErrPersonalAccessTokenNotFound is returned when a personal access token does not exist
+var ErrPersonalAccessTokenNotFound = errors.New("personal access token not found")
+
+// personalAccessTokenColumns are the columns scanned by scanPersonalAccessToken
+const personalAccessTokenColumns = `id::text, user_id::text, name, token_prefix, token_hash, scopes,
+	expires_at, last_used_at, revoked_at, created_at`
+
+// PersonalAccessTokenRepository manages users' personal access tokens
+type PersonalAccessTokenRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewPersonalAccessTokenRepository creates a new PersonalAccessTokenRepository
+func NewPersonalAccessTokenRepository(db *pgxpool.Pool, logger *zap.Logger) *PersonalAccessTokenRepository {
+	return &PersonalAccessTokenRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateToken saves a new personal access token
+func (r *PersonalAccessTokenRepository) CreateToken(ctx context.Context, token *model.PersonalAccessToken) error {
+	ctx, span := startSpan(ctx, "PersonalAccessTokenRepository.CreateToken")
+	defer span.End()
+
+	query := `
+		INSERT INTO personal_access_tokens (
+			id, user_id, name, token_prefix, token_hash, scopes, expires_at, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		RETURNING created_at
+	`
+
+	scopes := make([]string, len(token.Scopes))
+	for i, scope := range token.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	err := r.db.QueryRow(ctx, query,
+		token.ID,
+		token.UserID,
+		token.Name,
+		token.Prefix,
+		token.TokenHash,
+		scopes,
+		token.ExpiresAt,
+	).Scan(&token.CreatedAt)
+	if err != nil {
+		r.logger.Error("failed to create personal access token", zap.Error(err), zap.String("user_id", token.UserID))
+		return fmt.Errorf("failed to create personal access token: %w", err)
+	}
+
+	return nil
+}
+
+// GetTokenByHash retrieves a personal access token, including revoked and expired ones,
+// by the hash of the token
+func (r *PersonalAccessTokenRepository) GetTokenByHash(ctx context.Context, tokenHash string) (*model.PersonalAccessToken, error) {
+	ctx, span := startSpan(ctx, "PersonalAccessTokenRepository.GetTokenByHash")
+	defer span.End()
+
+	query := `SELECT ` + personalAccessTokenColumns + ` FROM personal_access_tokens WHERE token_hash = $1`
+
+	token, err := scanPersonalAccessToken(r.db.QueryRow(ctx, query, tokenHash))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPersonalAccessTokenNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to get personal access token", zap.Error(err))
+		return nil, fmt.Errorf("failed to get personal access token: %w", err)
+	}
+
+	return token, nil
+}
+
+// GetTokensByUserID lists a user's personal access tokens, newest first
+func (r *PersonalAccessTokenRepository) GetTokensByUserID(ctx context.Context, userID string) ([]model.PersonalAccessToken, error) {
+	ctx, span := startSpan(ctx, "PersonalAccessTokenRepository.GetTokensByUserID")
+	defer span.End()
+
+	query := `
+		SELECT ` + personalAccessTokenColumns + `
+		FROM personal_access_tokens
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		r.logger.Error("failed to query personal access tokens", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to query personal access tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []model.PersonalAccessToken
+	for rows.Next() {
+		token, err := scanPersonalAccessToken(rows)
+		if err != nil {
+			r.logger.Error("failed to scan personal access token", zap.Error(err))
+			continue
+		}
+		tokens = append(tokens, *token)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating personal access tokens", zap.Error(err))
+		return nil, fmt.Errorf("error iterating personal access tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// RevokeToken revokes one of a user's personal access tokens. Revoking a revoked token
+// keeps its original revocation time. It reports whether the user has such a token.
+func (r *PersonalAccessTokenRepository) RevokeToken(ctx context.Context, userID, tokenID string, now time.Time) (bool, error) {
+	ctx, span := startSpan(ctx, "PersonalAccessTokenRepository.RevokeToken")
+	defer span.End()
+
+	query := `
+		UPDATE personal_access_tokens
+		SET revoked_at = COALESCE(revoked_at, $3)
+		WHERE id = $1 AND user_id = $2
+	`
+
+	tag, err := r.db.Exec(ctx, query, tokenID, userID, now)
+	if err != nil {
+		r.logger.Error("failed to revoke personal access token",
+			zap.Error(err),
+			zap.String("user_id", userID),
+			zap.String("token_id", tokenID),
+		)
+		return false, fmt.Errorf("failed to revoke personal access token: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// TouchToken records that a personal access token was used
+func (r *PersonalAccessTokenRepository) TouchToken(ctx context.Context, tokenID string, now time.Time) error {
+	ctx, span := startSpan(ctx, "PersonalAccessTokenRepository.TouchToken")
+	defer span.End()
+
+	if _, err := r.db.Exec(ctx, `UPDATE personal_access_tokens SET last_used_at = $2 WHERE id = $1`, tokenID, now); err != nil {
+		r.logger.Error("failed to update personal access token last use", zap.Error(err), zap.String("token_id", tokenID))
+		return fmt.Errorf("failed to update personal access token last use: %w", err)
+	}
+
+	return nil
+}
+
+// scanPersonalAccessToken scans a row selected with personalAccessTokenColumns
+func scanPersonalAccessToken(row pgx.Row) (*model.PersonalAccessToken, error) {
+	var token model.PersonalAccessToken
+	var scopes []string
+	err := row.Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Name,
+		&token.Prefix,
+		&token.TokenHash,
+		&scopes,
+		&token.ExpiresAt,
+		&token.LastUsedAt,
+		&token.RevokedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	token.Scopes = make([]model.TokenScope, len(scopes))
+	for i, scope := range scopes {
+		token.Scopes[i] = model.TokenScope(scope)
+	}
+	return &token, nil
+}
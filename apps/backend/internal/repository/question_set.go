@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// ErrQuestionSetNotFound is returned when a question set does not exist
+var ErrQuestionSetNotFound = errors.New("question set not found")
+
+// questionSetColumns are the columns scanned by scanQuestionSet, qualified by qs
+const questionSetColumns = `qs.id::text, qs.name, qs.language, COALESCE(qs.created_by::text, ''), qs.questions, qs.created_at`
+
+// QuestionFlowRepository manages check-in question sets and which users are asked them
+type QuestionFlowRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewQuestionFlowRepository creates a new QuestionFlowRepository
+func NewQuestionFlowRepository(db *pgxpool.Pool, logger *zap.Logger) *QuestionFlowRepository {
+	return &QuestionFlowRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateQuestionSet stores a new question set, filling in its ID and creation time
+func (r *QuestionFlowRepository) CreateQuestionSet(ctx context.Context, set *model.QuestionSet) error {
+	ctx, span := startSpan(ctx, "QuestionFlowRepository.CreateQuestionSet")
+	defer span.End()
+
+	query := `
+		INSERT INTO question_sets (name, language, created_by, questions, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING id::text, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		set.Name,
+		set.Language,
+		nullableID(set.CreatedBy),
+		set.Questions,
+	).Scan(&set.ID, &set.CreatedAt)
+	if err != nil {
+		r.logger.Error("failed to create question set", zap.Error(err), zap.String("name", set.Name))
+		return fmt.Errorf("failed to create question set: %w", err)
+	}
+
+	return nil
+}
+
+// GetQuestionSet retrieves a question set by ID
+func (r *QuestionFlowRepository) GetQuestionSet(ctx context.Context, id string) (*model.QuestionSet, error) {
+	ctx, span := startSpan(ctx, "QuestionFlowRepository.GetQuestionSet")
+	defer span.End()
+
+	query := `SELECT ` + questionSetColumns + ` FROM question_sets qs WHERE qs.id = $1`
+
+	set, err := scanQuestionSet(r.db.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrQuestionSetNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to get question set", zap.Error(err), zap.String("question_set_id", id))
+		return nil, fmt.Errorf("failed to get question set: %w", err)
+	}
+
+	return set, nil
+}
+
+// GetForUser retrieves the question set assigned to a user. It returns nil when the
+// user is asked the built-in question set.
+func (r *QuestionFlowRepository) GetForUser(ctx context.Context, userID string) (*model.QuestionSet, error) {
+	ctx, span := startSpan(ctx, "QuestionFlowRepository.GetForUser")
+	defer span.End()
+
+	query := `
+		SELECT ` + questionSetColumns + `
+		FROM user_question_sets u
+		JOIN question_sets qs ON qs.id = u.question_set_id
+		WHERE u.user_id = $1
+	`
+
+	set, err := scanQuestionSet(r.db.QueryRow(ctx, query, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.Error("failed to get user's question set", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get user's question set: %w", err)
+	}
+
+	return set, nil
+}
+
+// AssignToUser makes a user's future check-ins ask a question set
+func (r *QuestionFlowRepository) AssignToUser(ctx context.Context, userID, questionSetID, assignedBy string) error {
+	ctx, span := startSpan(ctx, "QuestionFlowRepository.AssignToUser")
+	defer span.End()
+
+	query := `
+		INSERT INTO user_question_sets (user_id, question_set_id, assigned_by, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			question_set_id = EXCLUDED.question_set_id,
+			assigned_by = EXCLUDED.assigned_by,
+			updated_at = NOW()
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, questionSetID, nullableID(assignedBy)); err != nil {
+		r.logger.Error("failed to assign question set",
+			zap.Error(err),
+			zap.String("user_id", userID),
+			zap.String("question_set_id", questionSetID),
+		)
+		return fmt.Errorf("failed to assign question set: %w", err)
+	}
+
+	return nil
+}
+
+// UnassignFromUser returns a user to the built-in question set
+func (r *QuestionFlowRepository) UnassignFromUser(ctx context.Context, userID string) error {
+	ctx, span := startSpan(ctx, "QuestionFlowRepository.UnassignFromUser")
+	defer span.End()
+
+	if _, err := r.db.Exec(ctx, `DELETE FROM user_question_sets WHERE user_id = $1`, userID); err != nil {
+		r.logger.Error("failed to unassign question set", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to unassign question set: %w", err)
+	}
+
+	return nil
+}
+
+// scanQuestionSet scans a row selected with questionSetColumns
+func scanQuestionSet(row pgx.Row) (*model.QuestionSet, error) {
+	var set model.QuestionSet
+	err := row.Scan(
+		&set.ID,
+		&set.Name,
+		&set.Language,
+		&set.CreatedBy,
+		&set.Questions,
+		&set.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &set, nil
+}
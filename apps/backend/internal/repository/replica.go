@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// replicaLagQuery measures how far a standby's replay is behind its primary. A standby
+// that has replayed everything it received reports no lag, however long ago the last
+// write was, and a server that is not a standby reports none either.
+const replicaLagQuery = `
+	SELECT CASE
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM NOW() - pg_last_xact_replay_timestamp()), 0)
+	END::float8
+`
+
+type readReplicaKey struct{}
+
+type primaryKey struct{}
+
+// WithReadReplica marks ctx as a heavy read path that may be served by the read replica
+func WithReadReplica(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readReplicaKey{}, true)
+}
+
+// WithPrimary forces the reads of ctx to the primary, even on paths that may use the
+// replica. Requests that just wrote data use it to read their own writes.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// PrimaryRequired reports whether ctx was marked with WithPrimary
+func PrimaryRequired(ctx context.Context) bool {
+	required, _ := ctx.Value(primaryKey{}).(bool)
+	return required
+}
+
+// ReadPools routes the reads of heavy read paths to an optional read replica, falling
+// back to the primary while the replica is unconfigured, unhealthy or lagging behind
+type ReadPools struct {
+	primary *pgxpool.Pool
+	replica *pgxpool.Pool
+	maxLag  time.Duration
+	healthy atomic.Bool
+	probe   func(ctx context.Context) (time.Duration, error)
+	logger  *zap.Logger
+}
+
+// NewReadPools creates ReadPools over the primary and replica pools. replica may be
+// nil, in which case every read uses the primary. The replica is used only once a
+// health check found it reachable and at most maxLag behind.
+func NewReadPools(primary, replica *pgxpool.Pool, maxLag time.Duration, logger *zap.Logger) *ReadPools {
+	p := &ReadPools{
+		primary: primary,
+		replica: replica,
+		maxLag:  maxLag,
+		logger:  logger,
+	}
+	p.probe = p.replicaLag
+	return p
+}
+
+// ReadPool returns the pool the reads of ctx run on: the replica when ctx was marked
+// with WithReadReplica but not WithPrimary and the replica is healthy, the primary
+// otherwise
+func (p *ReadPools) ReadPool(ctx context.Context) *pgxpool.Pool {
+	return readDB(ctx, p, p.primary)
+}
+
+// Healthy reports whether the last health check found the replica usable
+func (p *ReadPools) Healthy() bool {
+	return p.healthy.Load()
+}
+
+// CheckHealth probes the replica and records whether reads may use it
+func (p *ReadPools) CheckHealth(ctx context.Context) {
+	if p.replica == nil {
+		return
+	}
+
+	lag, err := p.probe(ctx)
+	healthy := err == nil && lag <= p.maxLag
+	if p.healthy.Swap(healthy) == healthy {
+		return
+	}
+
+	if healthy {
+		p.logger.Info("read replica healthy, routing heavy reads to it", zap.Duration("lag", lag))
+		return
+	}
+	p.logger.Warn("read replica unhealthy, routing heavy reads to the primary",
+		zap.Error(err),
+		zap.Duration("lag", lag),
+		zap.Duration("max_lag", p.maxLag),
+	)
+}
+
+// RunHealthChecks checks the replica right away and then every interval until ctx is
+// cancelled
+func (p *ReadPools) RunHealthChecks(ctx context.Context, interval time.Duration) {
+	if p.replica == nil {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		checkCtx, cancel := context.WithTimeout(ctx, interval)
+		p.CheckHealth(checkCtx)
+		cancel()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// replicaLag pings the replica and returns its replication lag
+func (p *ReadPools) replicaLag(ctx context.Context) (time.Duration, error) {
+	var seconds float64
+	if err := p.replica.QueryRow(ctx, replicaLagQuery).Scan(&seconds); err != nil {
+		return 0, fmt.Errorf("failed to query replica lag: %w", err)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// readDB returns the pool a repository read of ctx runs on. Repositories without
+// ReadPools read from their own pool.
+func readDB(ctx context.Context, reads *ReadPools, db *pgxpool.Pool) *pgxpool.Pool {
+	if reads == nil || reads.replica == nil || !reads.healthy.Load() {
+		return db
+	}
+	if PrimaryRequired(ctx) {
+		return db
+	}
+	if heavy, _ := ctx.Value(readReplicaKey{}).(bool); !heavy {
+		return db
+	}
+	return reads.replica
+}
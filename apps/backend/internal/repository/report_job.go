@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// ErrReportJobNotFound is returned when a report job does not exist
+var ErrReportJobNotFound = errors.New("report job not found")
+
+// reportJobColumns are the columns scanned by scanReportJob
+const reportJobColumns = `
+	id, user_id, report_id::text, start_date, end_date, status, user_name, language,
+	format, sections, COALESCE(password, ''), read_primary, attempts,
+	COALESCE(error_text, ''), created_at, updated_at, started_at
+`
+
+// ReportJobRepository manages the queue of report generation jobs
+type ReportJobRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewReportJobRepository creates a new ReportJobRepository
+func NewReportJobRepository(db *pgxpool.Pool, logger *zap.Logger) *ReportJobRepository {
+	return &ReportJobRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// EnqueueReport saves the pending report record and the job generating it in one
+// transaction, and sets the job's ID, status and timestamps
+func (r *ReportJobRepository) EnqueueReport(ctx context.Context, report *model.Report, job *model.ReportJob) error {
+	ctx, span := startSpan(ctx, "ReportJobRepository.EnqueueReport")
+	defer span.End()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertPendingReport(ctx, tx, report); err != nil {
+		r.logger.Error("failed to save report", zap.Error(err), zap.String("report_id", report.ID))
+		return fmt.Errorf("failed to save report: %w", err)
+	}
+
+	query := `
+		INSERT INTO reports_jobs (
+			user_id, report_id, start_date, end_date, status, user_name, language,
+			format, sections, password, read_primary, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`
+
+	err = tx.QueryRow(ctx, query,
+		job.UserID,
+		report.ID,
+		job.StartDate,
+		job.EndDate,
+		model.ReportStatusPending,
+		job.UserName,
+		job.Language,
+		job.Format,
+		reportSectionNames(job.Sections),
+		job.Password,
+		job.Primary,
+	).Scan(&job.ID, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		r.logger.Error("failed to save report job", zap.Error(err), zap.String("report_id", report.ID))
+		return fmt.Errorf("failed to save report job: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	job.ReportID = &report.ID
+	job.Status = model.ReportStatusPending
+	return nil
+}
+
+// CountPendingJobs returns the number of jobs waiting for a worker
+func (r *ReportJobRepository) CountPendingJobs(ctx context.Context) (int, error) {
+	ctx, span := startSpan(ctx, "ReportJobRepository.CountPendingJobs")
+	defer span.End()
+
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM reports_jobs WHERE status = $1`, model.ReportStatusPending).Scan(&count)
+	if err != nil {
+		r.logger.Error("failed to count pending report jobs", zap.Error(err))
+		return 0, fmt.Errorf("failed to count pending report jobs: %w", err)
+	}
+	return count, nil
+}
+
+// ClaimNextJob marks the oldest pending job processing and returns it, or nil when no
+// job is waiting. A processing job started before staleBefore is claimed again, since
+// its worker stopped. SKIP LOCKED lets concurrent workers claim different jobs.
+func (r *ReportJobRepository) ClaimNextJob(ctx context.Context, staleBefore time.Time) (*model.ReportJob, error) {
+	ctx, span := startSpan(ctx, "ReportJobRepository.ClaimNextJob")
+	defer span.End()
+
+	query := `
+		UPDATE reports_jobs
+		SET status = $1, attempts = attempts + 1, started_at = NOW(), updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM reports_jobs
+			WHERE status = $2 OR (status = $1 AND started_at < $3)
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + reportJobColumns
+
+	job, err := scanReportJob(r.db.QueryRow(ctx, query,
+		model.ReportStatusProcessing, model.ReportStatusPending, staleBefore))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.Error("failed to claim report job", zap.Error(err))
+		return nil, fmt.Errorf("failed to claim report job: %w", err)
+	}
+
+	return job, nil
+}
+
+// CompleteJob marks a job completed and forgets the password of its report
+func (r *ReportJobRepository) CompleteJob(ctx context.Context, jobID string) error {
+	ctx, span := startSpan(ctx, "ReportJobRepository.CompleteJob")
+	defer span.End()
+
+	return r.finishJob(ctx, jobID, model.ReportStatusCompleted, "")
+}
+
+// FailJob marks a job failed with the reason shown to the user and forgets the password
+// of its report
+func (r *ReportJobRepository) FailJob(ctx context.Context, jobID, message string) error {
+	ctx, span := startSpan(ctx, "ReportJobRepository.FailJob")
+	defer span.End()
+
+	return r.finishJob(ctx, jobID, model.ReportStatusFailed, message)
+}
+
+// finishJob records the final status of a job
+func (r *ReportJobRepository) finishJob(ctx context.Context, jobID string, status model.ReportStatus, message string) error {
+	query := `
+		UPDATE reports_jobs
+		SET status = $2, error_text = NULLIF($3, ''), password = NULL, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, jobID, status, message); err != nil {
+		r.logger.Error("failed to finish report job", zap.Error(err), zap.String("job_id", jobID))
+		return fmt.Errorf("failed to finish report job: %w", err)
+	}
+
+	return nil
+}
+
+// GetJob retrieves a report job
+func (r *ReportJobRepository) GetJob(ctx context.Context, jobID string) (*model.ReportJob, error) {
+	ctx, span := startSpan(ctx, "ReportJobRepository.GetJob")
+	defer span.End()
+
+	query := `SELECT ` + reportJobColumns + ` FROM reports_jobs WHERE id = $1`
+
+	job, err := scanReportJob(r.db.QueryRow(ctx, query, jobID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrReportJobNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to get report job", zap.Error(err), zap.String("job_id", jobID))
+		return nil, fmt.Errorf("failed to get report job: %w", err)
+	}
+
+	return job, nil
+}
+
+// scanReportJob scans a row selected with reportJobColumns
+func scanReportJob(row pgx.Row) (*model.ReportJob, error) {
+	var job model.ReportJob
+	var sections []string
+	err := row.Scan(
+		&job.ID,
+		&job.UserID,
+		&job.ReportID,
+		&job.StartDate,
+		&job.EndDate,
+		&job.Status,
+		&job.UserName,
+		&job.Language,
+		&job.Format,
+		&sections,
+		&job.Password,
+		&job.Primary,
+		&job.Attempts,
+		&job.ErrorText,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+		&job.StartedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	job.Sections = reportSections(sections)
+	return &job, nil
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// ErrReportScheduleNotFound is returned when a user has no report schedule
+var ErrReportScheduleNotFound = errors.New("report schedule not found")
+
+// reportScheduleColumns are the columns scanned by scanReportSchedule
+const reportScheduleColumns = `user_id::text, cadence, day, enabled, language, last_run_on, created_at, updated_at`
+
+// ReportScheduleRepository manages the schedules of automatically generated reports
+type ReportScheduleRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewReportScheduleRepository creates a new ReportScheduleRepository
+func NewReportScheduleRepository(db *pgxpool.Pool, logger *zap.Logger) *ReportScheduleRepository {
+	return &ReportScheduleRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// UpsertSchedule creates a user's report schedule or replaces its settings, keeping
+// when the latest report was queued
+func (r *ReportScheduleRepository) UpsertSchedule(ctx context.Context, schedule *model.ReportSchedule) error {
+	ctx, span := startSpan(ctx, "ReportScheduleRepository.UpsertSchedule")
+	defer span.End()
+
+	query := `
+		INSERT INTO report_schedules (user_id, cadence, day, enabled, language, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			cadence = EXCLUDED.cadence,
+			day = EXCLUDED.day,
+			enabled = EXCLUDED.enabled,
+			language = EXCLUDED.language,
+			updated_at = NOW()
+		RETURNING last_run_on, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		schedule.UserID,
+		schedule.Cadence,
+		schedule.Day,
+		schedule.Enabled,
+		schedule.Language,
+	).Scan(&schedule.LastRunOn, &schedule.CreatedAt, &schedule.UpdatedAt)
+	if err != nil {
+		r.logger.Error("failed to save report schedule", zap.Error(err), zap.String("user_id", schedule.UserID))
+		return fmt.Errorf("failed to save report schedule: %w", err)
+	}
+
+	return nil
+}
+
+// GetSchedule retrieves a user's report schedule
+func (r *ReportScheduleRepository) GetSchedule(ctx context.Context, userID string) (*model.ReportSchedule, error) {
+	ctx, span := startSpan(ctx, "ReportScheduleRepository.GetSchedule")
+	defer span.End()
+
+	query := `SELECT ` + reportScheduleColumns + ` FROM report_schedules WHERE user_id = $1`
+
+	schedule, err := scanReportSchedule(r.db.QueryRow(ctx, query, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrReportScheduleNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to get report schedule", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get report schedule: %w", err)
+	}
+
+	return schedule, nil
+}
+
+// FindEnabledSchedules retrieves every enabled report schedule
+func (r *ReportScheduleRepository) FindEnabledSchedules(ctx context.Context) ([]model.ReportSchedule, error) {
+	ctx, span := startSpan(ctx, "ReportScheduleRepository.FindEnabledSchedules")
+	defer span.End()
+
+	query := `
+		SELECT ` + reportScheduleColumns + `
+		FROM report_schedules
+		WHERE enabled
+		ORDER BY user_id
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		r.logger.Error("failed to find report schedules", zap.Error(err))
+		return nil, fmt.Errorf("failed to find report schedules: %w", err)
+	}
+	defer rows.Close()
+
+	var schedules []model.ReportSchedule
+	for rows.Next() {
+		schedule, err := scanReportSchedule(rows)
+		if err != nil {
+			r.logger.Error("failed to scan report schedule", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan report schedule: %w", err)
+		}
+		schedules = append(schedules, *schedule)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating report schedules", zap.Error(err))
+		return nil, fmt.Errorf("error iterating report schedules: %w", err)
+	}
+
+	return schedules, nil
+}
+
+// ClaimRun records that the report scheduled on runOn is being queued and reports
+// whether it was not queued before, so each scheduled report is claimed once
+func (r *ReportScheduleRepository) ClaimRun(ctx context.Context, userID string, runOn time.Time) (bool, error) {
+	ctx, span := startSpan(ctx, "ReportScheduleRepository.ClaimRun")
+	defer span.End()
+
+	query := `
+		UPDATE report_schedules SET last_run_on = $2
+		WHERE user_id = $1 AND enabled AND (last_run_on IS NULL OR last_run_on < $2)
+	`
+
+	result, err := r.db.Exec(ctx, query, userID, runOn)
+	if err != nil {
+		r.logger.Error("failed to claim scheduled report", zap.Error(err), zap.String("user_id", userID))
+		return false, fmt.Errorf("failed to claim scheduled report: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// ReleaseRun undoes the claim of the report scheduled on runOn, restoring the scheduled
+// day of the previous report, so the report is queued again at the next check
+func (r *ReportScheduleRepository) ReleaseRun(ctx context.Context, userID string, runOn time.Time, previous *time.Time) error {
+	ctx, span := startSpan(ctx, "ReportScheduleRepository.ReleaseRun")
+	defer span.End()
+
+	query := `UPDATE report_schedules SET last_run_on = $3 WHERE user_id = $1 AND last_run_on = $2`
+
+	if _, err := r.db.Exec(ctx, query, userID, runOn, previous); err != nil {
+		r.logger.Error("failed to release scheduled report", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to release scheduled report: %w", err)
+	}
+
+	return nil
+}
+
+// scanReportSchedule scans a row of reportScheduleColumns
+func scanReportSchedule(row pgx.Row) (*model.ReportSchedule, error) {
+	var schedule model.ReportSchedule
+	err := row.Scan(
+		&schedule.UserID,
+		&schedule.Cadence,
+		&schedule.Day,
+		&schedule.Enabled,
+		&schedule.Language,
+		&schedule.LastRunOn,
+		&schedule.CreatedAt,
+		&schedule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
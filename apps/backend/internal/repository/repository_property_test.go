@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -20,7 +21,7 @@ import (
 )
 
 // setupTestDB creates a PostgreSQL testcontainer and returns the connection pool
-func setupTestDB(t *testing.T) (*pgxpool.Pool, func()) {
+func setupTestDB(t testing.TB) (*pgxpool.Pool, func()) {
 	ctx := context.Background()
 
 	// Start PostgreSQL container
@@ -58,7 +59,7 @@ func setupTestDB(t *testing.T) (*pgxpool.Pool, func()) {
 }
 
 // runMigrations runs the database migrations
-func runMigrations(t *testing.T, pool *pgxpool.Pool) {
+func runMigrations(t testing.TB, pool *pgxpool.Pool) {
 	ctx := context.Background()
 
 	// Create tables
@@ -77,7 +78,10 @@ func runMigrations(t *testing.T, pool *pgxpool.Pool) {
 			started_at TIMESTAMP NOT NULL DEFAULT NOW(),
 			completed_at TIMESTAMP,
 			expired_at TIMESTAMP,
+			paused_at TIMESTAMP,
+			resumed_at TIMESTAMP,
 			status VARCHAR(50) NOT NULL,
+			question_set_id UUID,
 			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
 			updated_at TIMESTAMP NOT NULL DEFAULT NOW()
 		)`,
@@ -87,6 +91,7 @@ func runMigrations(t *testing.T, pool *pgxpool.Pool) {
 			role VARCHAR(50) NOT NULL,
 			content TEXT NOT NULL,
 			audio_file_path VARCHAR(500),
+			storage_backend VARCHAR(64) NOT NULL DEFAULT 'default',
 			created_at TIMESTAMP NOT NULL DEFAULT NOW()
 		)`,
 		`CREATE TABLE IF NOT EXISTS health_check_ins (
@@ -121,11 +126,14 @@ func runMigrations(t *testing.T, pool *pgxpool.Pool) {
 			notes TEXT,
 			active BOOLEAN NOT NULL DEFAULT true,
 			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-			updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			interaction_warnings JSONB NOT NULL DEFAULT '[]',
+			deleted_at TIMESTAMP
 		)`,
 		`CREATE TABLE IF NOT EXISTS medication_logs (
 			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
 			medication_id UUID NOT NULL REFERENCES medications(id) ON DELETE CASCADE,
+			user_id UUID NOT NULL,
 			taken_at TIMESTAMP NOT NULL,
 			adherence BOOLEAN NOT NULL,
 			created_at TIMESTAMP NOT NULL DEFAULT NOW()
@@ -147,6 +155,29 @@ func runMigrations(t *testing.T, pool *pgxpool.Pool) {
 			diastolic INTEGER NOT NULL CHECK (diastolic >= 40 AND diastolic <= 150),
 			pulse INTEGER NOT NULL CHECK (pulse >= 30 AND pulse <= 220),
 			measured_at TIMESTAMP NOT NULL,
+			notes TEXT,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS fitness_data (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			user_id UUID NOT NULL,
+			date DATE NOT NULL,
+			data_type VARCHAR(50) NOT NULL,
+			value FLOAT NOT NULL,
+			unit VARCHAR(50) NOT NULL,
+			source VARCHAR(100),
+			source_data_id VARCHAR(255),
+			created_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_fitness_data_user_source_data_id
+			ON fitness_data (user_id, source_data_id)
+			WHERE source_data_id <> ''`,
+		`CREATE TABLE IF NOT EXISTS idempotency_keys (
+			key VARCHAR(1024) PRIMARY KEY,
+			request_hash CHAR(64),
+			status_code INTEGER,
+			response_body BYTEA,
+			expires_at TIMESTAMP NOT NULL,
 			created_at TIMESTAMP NOT NULL DEFAULT NOW()
 		)`,
 	}
@@ -158,7 +189,7 @@ func runMigrations(t *testing.T, pool *pgxpool.Pool) {
 }
 
 // createTestUser creates a test user and returns the user ID
-func createTestUser(t *testing.T, pool *pgxpool.Pool) string {
+func createTestUser(t testing.TB, pool *pgxpool.Pool) string {
 	ctx := context.Background()
 	userID := uuid.New().String()
 
@@ -288,6 +319,71 @@ func TestProperty_MedicationCRUDPreservesID(t *testing.T) {
 	properties.TestingRun(t, params)
 }
 
+// Feature: eva-health-backend, Property: Medication Deactivation Keeps Record
+func TestProperty_MedicationDeactivationKeepsRecord(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, _ := zap.NewDevelopment()
+	repo := NewMedicationRepository(pool, logger)
+
+	userID := createTestUser(t, pool)
+
+	properties := gopter.NewProperties(nil)
+
+	properties.Property("deactivated medication stays in user's medication list", prop.ForAll(
+		func(name string, endInDays int, hasEndDate bool) bool {
+			ctx := context.Background()
+
+			medicationID := uuid.New().String()
+			medication := &model.Medication{
+				ID:        medicationID,
+				UserID:    userID,
+				Name:      name,
+				Dosage:    "10mg",
+				Frequency: "daily",
+				StartDate: time.Now().AddDate(0, -1, 0),
+				Active:    true,
+			}
+			if err := repo.Create(ctx, medication); err != nil {
+				t.Logf("Failed to create medication: %v", err)
+				return false
+			}
+
+			var endDate *time.Time
+			if hasEndDate {
+				end := time.Now().AddDate(0, 0, endInDays).UTC().Truncate(24 * time.Hour)
+				endDate = &end
+			}
+			if err := repo.SetActive(ctx, medicationID, false, endDate); err != nil {
+				t.Logf("Failed to deactivate medication: %v", err)
+				return false
+			}
+
+			medications, err := repo.FindByUserID(ctx, userID)
+			if err != nil {
+				t.Logf("Failed to find medications: %v", err)
+				return false
+			}
+			for _, med := range medications {
+				if med.ID == medicationID {
+					return !med.Active && (med.EndDate != nil) == hasEndDate
+				}
+			}
+
+			t.Logf("Medication not found after deactivation")
+			return false
+		},
+		gen.AlphaString().SuchThat(func(s string) bool { return len(s) > 0 && len(s) < 100 }),
+		gen.IntRange(-30, 30),
+		gen.Bool(),
+	))
+
+	params := gopter.DefaultTestParameters()
+	params.MinSuccessfulTests = 100
+	properties.TestingRun(t, params)
+}
+
 // **Validates: Requirements 4.4**
 // Feature: eva-health-backend, Property 11: Medication Deletion Removes Record
 func TestProperty_MedicationDeletionRemovesRecord(t *testing.T) {
@@ -446,3 +542,70 @@ func TestProperty_ListSortingConsistency(t *testing.T) {
 	params.MinSuccessfulTests = 100
 	properties.TestingRun(t, params)
 }
+
+// Property: A key is reserved once, and its stored response is returned until it expires
+func TestProperty_IdempotencyKeyStoredResponse(t *testing.T) {
+	pool, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger, _ := zap.NewDevelopment()
+	repo := NewIdempotencyKeyRepository(pool, logger)
+
+	properties := gopter.NewProperties(nil)
+
+	properties.Property("a key is reserved once and returns its stored response until it expires", prop.ForAll(
+		func(status int, body string) bool {
+			key := "idempotency:" + uuid.New().String()
+			hash := strings.Repeat("a", 64)
+
+			if _, ok, err := repo.Get(key); err != nil || ok {
+				t.Logf("Expected no response for a new key")
+				return false
+			}
+
+			reserved, err := repo.Reserve(key, time.Hour)
+			if err != nil || !reserved {
+				t.Logf("Expected a new key to be reserved: %v", err)
+				return false
+			}
+			if reserved, _ := repo.Reserve(key, time.Hour); reserved {
+				t.Logf("Expected a reserved key not to be reserved again")
+				return false
+			}
+			if response, ok, _ := repo.Get(key); !ok || !response.Pending {
+				t.Logf("Expected a pending reservation")
+				return false
+			}
+
+			if err := repo.Complete(key, hash, status, []byte(body), time.Hour); err != nil {
+				t.Logf("Failed to complete key: %v", err)
+				return false
+			}
+			if err := repo.Release(key); err != nil {
+				t.Logf("Failed to release key: %v", err)
+				return false
+			}
+			response, ok, err := repo.Get(key)
+			if err != nil || !ok || response.Pending || response.Status != status ||
+				response.RequestHash != hash || string(response.Body) != body {
+				t.Logf("Expected the stored response, got %+v", response)
+				return false
+			}
+
+			expired := key + ":expired"
+			if err := repo.Complete(expired, hash, status, []byte(body), -time.Second); err != nil {
+				return false
+			}
+			if _, ok, _ := repo.Get(expired); ok {
+				t.Logf("Expected no response for an expired key")
+				return false
+			}
+			reserved, _ = repo.Reserve(expired, time.Hour)
+			return reserved
+		},
+		gen.IntRange(200, 499),
+		gen.AlphaString(),
+	))
+
+	properties.TestingRun(t)
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// RetentionRepository finds and removes health data past its retention period
+type RetentionRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewRetentionRepository creates a new RetentionRepository
+func NewRetentionRepository(db *pgxpool.Pool, logger *zap.Logger) *RetentionRepository {
+	return &RetentionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ExpiredMessage is a conversation message past the audio retention period with the blobs
+// of its audio: the message's own file and its audio recordings
+type ExpiredMessage struct {
+	ID    string
+	Audio []model.StoredBlob
+}
+
+// FindExpiredMessages returns up to limit conversation messages created before the given
+// time, oldest first
+func (r *RetentionRepository) FindExpiredMessages(ctx context.Context, before time.Time, limit int) ([]ExpiredMessage, error) {
+	ctx, span := startSpan(ctx, "RetentionRepository.FindExpiredMessages")
+	defer span.End()
+
+	query := `
+		SELECT cm.id::text, cm.audio_file_path, cm.storage_backend,
+		       COALESCE(array_agg(ar.file_path) FILTER (WHERE ar.file_path IS NOT NULL), '{}'),
+		       COALESCE(array_agg(ar.storage_backend) FILTER (WHERE ar.file_path IS NOT NULL), '{}')
+		FROM conversation_messages cm
+		LEFT JOIN audio_recordings ar ON ar.message_id = cm.id
+		WHERE cm.created_at < $1
+		GROUP BY cm.id
+		ORDER BY cm.created_at, cm.id
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, before, limit)
+	if err != nil {
+		r.logger.Error("failed to find expired conversation messages", zap.Error(err))
+		return nil, fmt.Errorf("failed to find expired conversation messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []ExpiredMessage
+	for rows.Next() {
+		var msg ExpiredMessage
+		var audioFilePath *string
+		var backend string
+		var recordingPaths, recordingBackends []string
+		if err := rows.Scan(&msg.ID, &audioFilePath, &backend, &recordingPaths, &recordingBackends); err != nil {
+			r.logger.Error("failed to scan expired conversation message", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan expired conversation message: %w", err)
+		}
+		for i, path := range recordingPaths {
+			msg.Audio = append(msg.Audio, model.StoredBlob{Backend: recordingBackends[i], Path: path})
+		}
+		if audioFilePath != nil && *audioFilePath != "" {
+			msg.Audio = append(msg.Audio, model.StoredBlob{Backend: backend, Path: *audioFilePath})
+		}
+		messages = append(messages, msg)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating expired conversation messages", zap.Error(err))
+		return nil, fmt.Errorf("error iterating expired conversation messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+// DeleteMessages deletes conversation messages and their audio recordings, returning the
+// number of messages deleted
+func (r *RetentionRepository) DeleteMessages(ctx context.Context, ids []string) (int64, error) {
+	ctx, span := startSpan(ctx, "RetentionRepository.DeleteMessages")
+	defer span.End()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM audio_recordings WHERE message_id = ANY($1::uuid[])`, ids); err != nil {
+		r.logger.Error("failed to delete expired audio recordings", zap.Error(err))
+		return 0, fmt.Errorf("failed to delete expired audio recordings: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM conversation_messages WHERE id = ANY($1::uuid[])`, ids)
+	if err != nil {
+		r.logger.Error("failed to delete expired conversation messages", zap.Error(err))
+		return 0, fmt.Errorf("failed to delete expired conversation messages: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// ArchiveCheckIns moves up to limit check-ins dated before the given day to
+// health_check_ins_archive, oldest first, returning the number moved. Alerts raised by a
+// moved check-in are deleted with it.
+func (r *RetentionRepository) ArchiveCheckIns(ctx context.Context, before time.Time, limit int) (int64, error) {
+	ctx, span := startSpan(ctx, "RetentionRepository.ArchiveCheckIns")
+	defer span.End()
+
+	query := `
+		WITH moved AS (
+			DELETE FROM health_check_ins
+			WHERE id IN (
+				SELECT id FROM health_check_ins
+				WHERE check_in_date < $1
+				ORDER BY check_in_date, id
+				LIMIT $2
+			)
+			RETURNING *
+		)
+		INSERT INTO health_check_ins_archive SELECT * FROM moved
+	`
+
+	tag, err := r.db.Exec(ctx, query, before, limit)
+	if err != nil {
+		r.logger.Error("failed to archive expired check-ins", zap.Error(err))
+		return 0, fmt.Errorf("failed to archive expired check-ins: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// ErrMigrationsNotApplied is returned when the database has no migration version
+// recorded, i.e. migrations were never run against it
+var ErrMigrationsNotApplied = errors.New("no migrations applied")
+
+// SchemaRepository reads the migration state golang-migrate records in schema_migrations
+type SchemaRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewSchemaRepository creates a new SchemaRepository
+func NewSchemaRepository(db *pgxpool.Pool, logger *zap.Logger) *SchemaRepository {
+	return &SchemaRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// MigrationVersion returns the version of the last applied migration and whether it
+// failed halfway, leaving the schema dirty
+func (r *SchemaRepository) MigrationVersion(ctx context.Context) (version int64, dirty bool, err error) {
+	ctx, span := startSpan(ctx, "SchemaRepository.MigrationVersion")
+	defer span.End()
+
+	var exists bool
+	if err := r.db.QueryRow(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+		r.logger.Error("failed to look up schema_migrations", zap.Error(err))
+		return 0, false, fmt.Errorf("failed to look up schema_migrations: %w", err)
+	}
+	if !exists {
+		return 0, false, ErrMigrationsNotApplied
+	}
+
+	err = r.db.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, ErrMigrationsNotApplied
+	}
+	if err != nil {
+		r.logger.Error("failed to get migration version", zap.Error(err))
+		return 0, false, fmt.Errorf("failed to get migration version: %w", err)
+	}
+
+	return version, dirty, nil
+}
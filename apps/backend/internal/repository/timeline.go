@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// TimelineSummaryLength is the longest summary a timeline event carries. Summaries are
+// read one character longer so that cut free text can be told apart.
+const TimelineSummaryLength = 120
+
+// timelineEventsQuery selects every event of user $1 as (type, resource_id, occurred_at,
+// summary). $2 is the user ID again as text, for the audit log, whose changes to the
+// user and their consents are the GDPR events. Health data events are the time the data
+// was written, not the time it was measured.
+const timelineEventsQuery = `
+	SELECT 'check_in', id::text, created_at, COALESCE(general_feeling, additional_notes)
+	FROM health_check_ins WHERE user_id = $1
+	UNION ALL
+	SELECT 'session_started', id::text, started_at, status
+	FROM check_in_sessions WHERE user_id = $1
+	UNION ALL
+	SELECT 'session_paused', id::text, paused_at, NULL
+	FROM check_in_sessions WHERE user_id = $1 AND paused_at IS NOT NULL
+	UNION ALL
+	SELECT 'session_resumed', id::text, resumed_at, NULL
+	FROM check_in_sessions WHERE user_id = $1 AND resumed_at IS NOT NULL
+	UNION ALL
+	SELECT 'session_completed', id::text, completed_at, NULL
+	FROM check_in_sessions WHERE user_id = $1 AND completed_at IS NOT NULL
+	UNION ALL
+	SELECT 'session_expired', id::text, expired_at, NULL
+	FROM check_in_sessions WHERE user_id = $1 AND expired_at IS NOT NULL
+	UNION ALL
+	SELECT 'blood_pressure_reading', id::text, created_at, systolic || '/' || diastolic || ' mmHg'
+	FROM blood_pressure_readings WHERE user_id = $1
+	UNION ALL
+	SELECT 'menstruation_cycle', id::text, created_at, 'started ' || start_date
+	FROM menstruation_cycles WHERE user_id = $1
+	UNION ALL
+	SELECT 'fitness_data', id::text, created_at, data_type || ' ' || value || ' ' || unit
+	FROM fitness_data WHERE user_id = $1
+	UNION ALL
+	SELECT 'medication', id::text, created_at, name || ' ' || dosage
+	FROM medications WHERE user_id = $1 AND deleted_at IS NULL
+	UNION ALL
+	SELECT 'medication_log', l.id::text, l.created_at,
+		m.name || CASE WHEN l.adherence THEN ' taken' ELSE ' missed' END
+	FROM medication_logs l JOIN medications m ON m.id = l.medication_id
+	WHERE l.user_id = $1 AND m.deleted_at IS NULL
+	UNION ALL
+	SELECT 'alert', id::text, created_at, severity || ': ' || reason
+	FROM alerts WHERE user_id = $1
+	UNION ALL
+	SELECT 'report', id::text, created_at, status
+	FROM reports WHERE user_id = $1
+	UNION ALL
+	SELECT 'gdpr', id::text, timestamp, operation_type || ' ' || resource_type
+	FROM audit_logs
+	WHERE user_id = $2 AND operation_type <> 'READ'
+		AND (resource_type IN ('user', 'user_consent', 'care_team_sharing_consent') OR operation_type = 'ANONYMIZE')
+`
+
+// TimelineCursor is the position of the last event of a timeline page. Events are
+// ordered newest first and ties are broken by type and resource ID, so a cursor keeps
+// its place while new events are written.
+type TimelineCursor struct {
+	OccurredAt time.Time
+	Type       model.TimelineEventType
+	ResourceID string
+}
+
+// TimelineRepository reads a user's events from every table into one timeline
+type TimelineRepository struct {
+	db     *pgxpool.Pool
+	reads  *ReadPools
+	logger *zap.Logger
+}
+
+// NewTimelineRepository creates a new TimelineRepository
+func NewTimelineRepository(db *pgxpool.Pool, logger *zap.Logger) *TimelineRepository {
+	return &TimelineRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// SetReadPools lets the timeline be read from the read replica
+func (r *TimelineRepository) SetReadPools(reads *ReadPools) {
+	r.reads = reads
+}
+
+// GetUserTimeline retrieves up to limit events of a user, newest first, following
+// after. after is nil for the first page. Summaries are cut to one character more than
+// TimelineSummaryLength.
+func (r *TimelineRepository) GetUserTimeline(ctx context.Context, userID string, after *TimelineCursor, limit int) ([]model.TimelineEvent, error) {
+	ctx, span := startSpan(ctx, "TimelineRepository.GetUserTimeline")
+	defer span.End()
+
+	args := []interface{}{userID, userID, TimelineSummaryLength + 1, limit}
+	where := ""
+	if after != nil {
+		args = append(args, after.OccurredAt, string(after.Type), after.ResourceID)
+		where = `WHERE (occurred_at, type, resource_id) < ($5::timestamp, $6::text, $7::text)`
+	}
+
+	query := fmt.Sprintf(`
+		SELECT type, resource_id, occurred_at, LEFT(COALESCE(summary, ''), $3)
+		FROM (%s) AS events (type, resource_id, occurred_at, summary)
+		%s
+		ORDER BY occurred_at DESC, type DESC, resource_id DESC
+		LIMIT $4
+	`, timelineEventsQuery, where)
+
+	rows, err := readDB(ctx, r.reads, r.db).Query(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("failed to get user timeline", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get user timeline: %w", err)
+	}
+	defer rows.Close()
+
+	var events []model.TimelineEvent
+	for rows.Next() {
+		var event model.TimelineEvent
+		if err := rows.Scan(&event.Type, &event.ResourceID, &event.OccurredAt, &event.Summary); err != nil {
+			r.logger.Error("failed to scan timeline event", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan timeline event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating timeline events", zap.Error(err))
+		return nil, fmt.Errorf("error iterating timeline events: %w", err)
+	}
+
+	return events, nil
+}
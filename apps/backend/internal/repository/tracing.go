@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/metrics"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// dbSystem is the db.system attribute of repository and query spans
+var dbSystem = attribute.String("db.system", "postgresql")
+
+// startSpan starts the span of a repository method; the queries it runs are its children
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return telemetry.StartSpan(ctx, name, dbSystem)
+}
+
+// QueryTracer is a pgx tracer running every query in a span of its own, marked failed
+// when the query fails, and recording its latency in the query duration metric. Set it
+// as the Tracer of a pool's connection config.
+type QueryTracer struct{}
+
+type querySpanKey struct{}
+
+// queryTrace is the span and start of a running query
+type queryTrace struct {
+	span  trace.Span
+	sql   string
+	start time.Time
+}
+
+// TraceQueryStart implements pgx.QueryTracer
+func (QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, span := telemetry.StartSpan(ctx, "db.query", dbSystem, attribute.String("db.statement", data.SQL))
+	return context.WithValue(ctx, querySpanKey{}, queryTrace{span: span, sql: data.SQL, start: time.Now()})
+}
+
+// TraceQueryEnd implements pgx.QueryTracer
+func (QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	query, ok := ctx.Value(querySpanKey{}).(queryTrace)
+	if !ok {
+		return
+	}
+	metrics.Default.RecordDBQuery(query.sql, time.Since(query.start), data.Err)
+	if data.Err == nil {
+		query.span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	}
+	telemetry.EndSpan(query.span, data.Err)
+}
@@ -0,0 +1,319 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// UsageDelta is an incremental change to a user's stored data
+type UsageDelta struct {
+	CheckIns        int64
+	AudioBytes      int64
+	AttachmentBytes int64
+	ReportBytes     int64
+}
+
+// UsageAggregate holds stored data totals across all users
+type UsageAggregate struct {
+	Users           int               `json:"users"`
+	CheckIns        int64             `json:"check_ins"`
+	AudioBytes      int64             `json:"audio_bytes"`
+	AttachmentBytes int64             `json:"attachment_bytes"`
+	ReportBytes     int64             `json:"report_bytes"`
+	TopUsers        []model.UserUsage `json:"top_users"`
+}
+
+// UsageRepository manages per-user stored data accounting
+type UsageRepository struct {
+	db     *pgxpool.Pool
+	reads  *ReadPools
+	logger *zap.Logger
+}
+
+// NewUsageRepository creates a new UsageRepository
+func NewUsageRepository(db *pgxpool.Pool, logger *zap.Logger) *UsageRepository {
+	return &UsageRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// SetReadPools lets the heavy reads of the repository use the read replica
+func (r *UsageRepository) SetReadPools(reads *ReadPools) {
+	r.reads = reads
+}
+
+// Increment adds delta to a user's usage, creating the row if needed
+func (r *UsageRepository) Increment(ctx context.Context, userID string, delta UsageDelta) error {
+	ctx, span := startSpan(ctx, "UsageRepository.Increment")
+	defer span.End()
+
+	query := `
+		INSERT INTO user_usage (user_id, check_ins, audio_bytes, attachment_bytes, report_bytes, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			check_ins = user_usage.check_ins + EXCLUDED.check_ins,
+			audio_bytes = user_usage.audio_bytes + EXCLUDED.audio_bytes,
+			attachment_bytes = user_usage.attachment_bytes + EXCLUDED.attachment_bytes,
+			report_bytes = user_usage.report_bytes + EXCLUDED.report_bytes,
+			updated_at = NOW()
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		userID,
+		delta.CheckIns,
+		delta.AudioBytes,
+		delta.AttachmentBytes,
+		delta.ReportBytes,
+	)
+	if err != nil {
+		r.logger.Error("failed to increment usage", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to increment usage: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUserID retrieves a user's usage, or nil if nothing has been recorded
+func (r *UsageRepository) GetByUserID(ctx context.Context, userID string) (*model.UserUsage, error) {
+	ctx, span := startSpan(ctx, "UsageRepository.GetByUserID")
+	defer span.End()
+
+	query := `
+		SELECT user_id, check_ins, audio_bytes, attachment_bytes, report_bytes, reconciled_at, updated_at
+		FROM user_usage
+		WHERE user_id = $1
+	`
+
+	var usage model.UserUsage
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&usage.UserID,
+		&usage.CheckIns,
+		&usage.AudioBytes,
+		&usage.AttachmentBytes,
+		&usage.ReportBytes,
+		&usage.ReconciledAt,
+		&usage.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		r.logger.Error("failed to get usage", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get usage: %w", err)
+	}
+
+	return &usage, nil
+}
+
+// GetAggregate retrieves usage totals across all users and the topN users by stored bytes
+func (r *UsageRepository) GetAggregate(ctx context.Context, topN int) (*UsageAggregate, error) {
+	ctx, span := startSpan(ctx, "UsageRepository.GetAggregate")
+	defer span.End()
+
+	totalsQuery := `
+		SELECT COUNT(*),
+			COALESCE(SUM(check_ins), 0),
+			COALESCE(SUM(audio_bytes), 0),
+			COALESCE(SUM(attachment_bytes), 0),
+			COALESCE(SUM(report_bytes), 0)
+		FROM user_usage
+	`
+
+	aggregate := &UsageAggregate{TopUsers: []model.UserUsage{}}
+	err := readDB(ctx, r.reads, r.db).QueryRow(ctx, totalsQuery).Scan(
+		&aggregate.Users,
+		&aggregate.CheckIns,
+		&aggregate.AudioBytes,
+		&aggregate.AttachmentBytes,
+		&aggregate.ReportBytes,
+	)
+	if err != nil {
+		r.logger.Error("failed to get usage totals", zap.Error(err))
+		return nil, fmt.Errorf("failed to get usage totals: %w", err)
+	}
+
+	topQuery := `
+		SELECT user_id, check_ins, audio_bytes, attachment_bytes, report_bytes, reconciled_at, updated_at
+		FROM user_usage
+		ORDER BY audio_bytes + attachment_bytes + report_bytes DESC
+		LIMIT $1
+	`
+
+	rows, err := readDB(ctx, r.reads, r.db).Query(ctx, topQuery, topN)
+	if err != nil {
+		r.logger.Error("failed to get top usage", zap.Error(err))
+		return nil, fmt.Errorf("failed to get top usage: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var usage model.UserUsage
+		if err := rows.Scan(
+			&usage.UserID,
+			&usage.CheckIns,
+			&usage.AudioBytes,
+			&usage.AttachmentBytes,
+			&usage.ReportBytes,
+			&usage.ReconciledAt,
+			&usage.UpdatedAt,
+		); err != nil {
+			r.logger.Error("failed to scan usage", zap.Error(err))
+			continue
+		}
+		aggregate.TopUsers = append(aggregate.TopUsers, usage)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating usage: %w", err)
+	}
+
+	return aggregate, nil
+}
+
+// CountCheckInsByUser counts stored check-ins per user
+func (r *UsageRepository) CountCheckInsByUser(ctx context.Context) (map[string]int64, error) {
+	ctx, span := startSpan(ctx, "UsageRepository.CountCheckInsByUser")
+	defer span.End()
+
+	query := `
+		SELECT user_id, COUNT(*)
+		FROM health_check_ins
+		GROUP BY user_id
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		r.logger.Error("failed to count check-ins", zap.Error(err))
+		return nil, fmt.Errorf("failed to count check-ins: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var userID string
+		var count int64
+		if err := rows.Scan(&userID, &count); err != nil {
+			r.logger.Error("failed to scan check-in count", zap.Error(err))
+			continue
+		}
+		counts[userID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating check-in counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// ResolveBlobOwners maps blob paths to the users owning them. Paths without a
+// known owner, such as shared question audio, are left out of the result.
+func (r *UsageRepository) ResolveBlobOwners(ctx context.Context, paths []string) (map[string]string, error) {
+	ctx, span := startSpan(ctx, "UsageRepository.ResolveBlobOwners")
+	defer span.End()
+
+	owners := make(map[string]string, len(paths))
+	if len(paths) == 0 {
+		return owners, nil
+	}
+
+	query := `
+		SELECT file_path, user_id FROM reports WHERE file_path = ANY($1)
+		UNION
+		SELECT ar.file_path, s.user_id
+		FROM audio_recordings ar
+		JOIN check_in_sessions s ON s.id = ar.session_id
+		WHERE ar.file_path = ANY($1)
+		UNION
+		SELECT cm.audio_file_path, s.user_id
+		FROM conversation_messages cm
+		JOIN check_in_sessions s ON s.id = cm.session_id
+		WHERE cm.audio_file_path = ANY($1)
+	`
+
+	rows, err := r.db.Query(ctx, query, paths)
+	if err != nil {
+		r.logger.Error("failed to resolve blob owners", zap.Error(err))
+		return nil, fmt.Errorf("failed to resolve blob owners: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var path, userID string
+		if err := rows.Scan(&path, &userID); err != nil {
+			r.logger.Error("failed to scan blob owner", zap.Error(err))
+			continue
+		}
+		owners[path] = userID
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating blob owners: %w", err)
+	}
+
+	return owners, nil
+}
+
+// ReplaceUsage overwrites usage with reconciled values. Users missing from
+// usages are reset to zero, since nothing is stored for them anymore.
+func (r *UsageRepository) ReplaceUsage(ctx context.Context, usages map[string]*model.UserUsage, reconciledAt time.Time) error {
+	ctx, span := startSpan(ctx, "UsageRepository.ReplaceUsage")
+	defer span.End()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	upsert := `
+		INSERT INTO user_usage (user_id, check_ins, audio_bytes, attachment_bytes, report_bytes, reconciled_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			check_ins = EXCLUDED.check_ins,
+			audio_bytes = EXCLUDED.audio_bytes,
+			attachment_bytes = EXCLUDED.attachment_bytes,
+			report_bytes = EXCLUDED.report_bytes,
+			reconciled_at = EXCLUDED.reconciled_at,
+			updated_at = NOW()
+	`
+
+	for userID, usage := range usages {
+		if _, err := tx.Exec(ctx, upsert,
+			userID,
+			usage.CheckIns,
+			usage.AudioBytes,
+			usage.AttachmentBytes,
+			usage.ReportBytes,
+			reconciledAt,
+		); err != nil {
+			r.logger.Error("failed to replace usage", zap.Error(err), zap.String("user_id", userID))
+			return fmt.Errorf("failed to replace usage: %w", err)
+		}
+	}
+
+	reset := `
+		UPDATE user_usage
+		SET check_ins = 0, audio_bytes = 0, attachment_bytes = 0, report_bytes = 0,
+			reconciled_at = $1, updated_at = NOW()
+		WHERE reconciled_at IS NULL OR reconciled_at < $1
+	`
+	if _, err := tx.Exec(ctx, reset, reconciledAt); err != nil {
+		r.logger.Error("failed to reset stale usage", zap.Error(err))
+		return fmt.Errorf("failed to reset stale usage: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit usage reconciliation: %w", err)
+	}
+
+	return nil
+}
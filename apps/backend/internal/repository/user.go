@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrUserNotFound is returned when a user has no row in the users table
+	ErrUserNotFound = errors.New("user not found")
+
+	// ErrEmailTaken is returned when another user already has the email address
+	ErrEmailTaken = errors.New("email address is used by another user")
+)
+
+// uniqueViolation is the PostgreSQL error code of a unique constraint violation
+const uniqueViolation = "23505"
+
+// userColumns are the columns scanned by scanUser
+const userColumns = `id, name, email, email_verified_at, created_at, updated_at, deleted_at`
+
+// UserRepository reads user profiles and manages their email verification
+type UserRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewUserRepository creates a new UserRepository
+func NewUserRepository(db *pgxpool.Pool, logger *zap.Logger) *UserRepository {
+	return &UserRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// FindByID retrieves a user, including users marked deleted
+func (r *UserRepository) FindByID(ctx context.Context, userID string) (*model.User, error) {
+	ctx, span := startSpan(ctx, "UserRepository.FindByID")
+	defer span.End()
+
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+
+	user, err := scanUser(r.db.QueryRow(ctx, query, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to get user", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return user, nil
+}
+
+// SetEmail creates the user with the email address, or changes the address of an
+// existing user. A changed address is no longer verified; setting the current address
+// again keeps its verification. It returns ErrEmailTaken when another user has the address.
+func (r *UserRepository) SetEmail(ctx context.Context, userID, email string) (*model.User, error) {
+	ctx, span := startSpan(ctx, "UserRepository.SetEmail")
+	defer span.End()
+
+	query := `
+		INSERT INTO users (id, name, email, created_at, updated_at)
+		VALUES ($1, '', $2, NOW(), NOW())
+		ON CONFLICT (id) DO UPDATE SET
+			email = EXCLUDED.email,
+			email_verified_at = CASE WHEN users.email = EXCLUDED.email THEN users.email_verified_at END,
+			updated_at = NOW()
+		RETURNING ` + userColumns
+
+	user, err := scanUser(r.db.QueryRow(ctx, query, userID, email))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return nil, ErrEmailTaken
+		}
+		r.logger.Error("failed to set user email", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to set user email: %w", err)
+	}
+
+	return user, nil
+}
+
+// CreateEmailVerificationToken stores an issued email confirmation token
+func (r *UserRepository) CreateEmailVerificationToken(ctx context.Context, token *model.EmailVerificationToken) error {
+	ctx, span := startSpan(ctx, "UserRepository.CreateEmailVerificationToken")
+	defer span.End()
+
+	query := `
+		INSERT INTO email_verification_tokens (id, user_id, email, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.Exec(ctx, query, token.ID, token.UserID, token.Email, token.ExpiresAt, token.CreatedAt)
+	if err != nil {
+		r.logger.Error("failed to create email verification token", zap.Error(err), zap.String("user_id", token.UserID))
+		return fmt.Errorf("failed to create email verification token: %w", err)
+	}
+
+	return nil
+}
+
+// CountEmailVerificationTokens returns how many confirmation tokens were issued to the
+// user since the given time, and when the latest of them was issued
+func (r *UserRepository) CountEmailVerificationTokens(ctx context.Context, userID string, since time.Time) (int, *time.Time, error) {
+	ctx, span := startSpan(ctx, "UserRepository.CountEmailVerificationTokens")
+	defer span.End()
+
+	query := `
+		SELECT COUNT(*), MAX(created_at)
+		FROM email_verification_tokens
+		WHERE user_id = $1 AND created_at >= $2
+	`
+
+	var count int
+	var latest *time.Time
+	if err := r.db.QueryRow(ctx, query, userID, since).Scan(&count, &latest); err != nil {
+		r.logger.Error("failed to count email verification tokens", zap.Error(err), zap.String("user_id", userID))
+		return 0, nil, fmt.Errorf("failed to count email verification tokens: %w", err)
+	}
+
+	return count, latest, nil
+}
+
+// ConfirmEmail uses a confirmation token and marks the address it was issued for
+// verified. It returns nil without an error when the token is unknown, expired, already
+// used, or the user's address changed since it was issued.
+func (r *UserRepository) ConfirmEmail(ctx context.Context, tokenID string, now time.Time) (*model.User, error) {
+	ctx, span := startSpan(ctx, "UserRepository.ConfirmEmail")
+	defer span.End()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var userID, email string
+	err = tx.QueryRow(ctx, `
+		UPDATE email_verification_tokens
+		SET used_at = $2
+		WHERE id = $1 AND used_at IS NULL AND expires_at > $2
+		RETURNING user_id, email
+	`, tokenID, now).Scan(&userID, &email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.Error("failed to use email verification token", zap.Error(err), zap.String("token_id", tokenID))
+		return nil, fmt.Errorf("failed to use email verification token: %w", err)
+	}
+
+	query := `
+		UPDATE users
+		SET email_verified_at = COALESCE(email_verified_at, $3), updated_at = $3
+		WHERE id = $1 AND email = $2 AND deleted_at IS NULL
+		RETURNING ` + userColumns
+
+	user, err := scanUser(tx.QueryRow(ctx, query, userID, email, now))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.Error("failed to verify user email", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to verify user email: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return user, nil
+}
+
+// scanUser scans a row of userColumns
+func scanUser(row pgx.Row) (*model.User, error) {
+	var user model.User
+	err := row.Scan(
+		&user.ID,
+		&user.Name,
+		&user.Email,
+		&user.EmailVerifiedAt,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
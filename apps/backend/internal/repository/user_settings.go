@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// ErrUserSettingsNotFound is returned when a user never saved settings
+var ErrUserSettingsNotFound = errors.New("user settings not found")
+
+// UserSettingsRepository manages user preferences
+type UserSettingsRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewUserSettingsRepository creates a new UserSettingsRepository
+func NewUserSettingsRepository(db *pgxpool.Pool, logger *zap.Logger) *UserSettingsRepository {
+	return &UserSettingsRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetSettings retrieves a user's settings
+func (r *UserSettingsRepository) GetSettings(ctx context.Context, userID string) (*model.UserSettings, error) {
+	ctx, span := startSpan(ctx, "UserSettingsRepository.GetSettings")
+	defer span.End()
+
+	query := `SELECT user_id::text, timezone, updated_at FROM user_settings WHERE user_id = $1`
+
+	var settings model.UserSettings
+	err := r.db.QueryRow(ctx, query, userID).Scan(&settings.UserID, &settings.Timezone, &settings.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrUserSettingsNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to get user settings", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get user settings: %w", err)
+	}
+
+	return &settings, nil
+}
+
+// UpsertSettings creates or replaces a user's settings
+func (r *UserSettingsRepository) UpsertSettings(ctx context.Context, settings *model.UserSettings) error {
+	ctx, span := startSpan(ctx, "UserSettingsRepository.UpsertSettings")
+	defer span.End()
+
+	query := `
+		INSERT INTO user_settings (user_id, timezone, created_at, updated_at)
+		VALUES ($1, $2, NOW(), NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			timezone = EXCLUDED.timezone,
+			updated_at = NOW()
+		RETURNING updated_at
+	`
+
+	if err := r.db.QueryRow(ctx, query, settings.UserID, settings.Timezone).Scan(&settings.UpdatedAt); err != nil {
+		r.logger.Error("failed to save user settings", zap.Error(err), zap.String("user_id", settings.UserID))
+		return fmt.Errorf("failed to save user settings: %w", err)
+	}
+
+	return nil
+}
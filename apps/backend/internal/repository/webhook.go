@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// ErrWebhookNotFound is returned when a webhook does not exist
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// webhookColumns are the columns scanned by scanWebhook
+const webhookColumns = `id::text, user_id::text, target_url, secret_token, events, created_at`
+
+// WebhookRepository manages the webhooks users register
+type WebhookRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewWebhookRepository creates a new WebhookRepository
+func NewWebhookRepository(db *pgxpool.Pool, logger *zap.Logger) *WebhookRepository {
+	return &WebhookRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateWebhook saves a new webhook
+func (r *WebhookRepository) CreateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	ctx, span := startSpan(ctx, "WebhookRepository.CreateWebhook")
+	defer span.End()
+
+	query := `
+		INSERT INTO webhooks (id, user_id, target_url, secret_token, events, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		webhook.ID,
+		webhook.UserID,
+		webhook.TargetURL,
+		webhook.SecretToken,
+		webhook.Events,
+	).Scan(&webhook.CreatedAt)
+	if err != nil {
+		r.logger.Error("failed to create webhook", zap.Error(err), zap.String("user_id", webhook.UserID))
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return nil
+}
+
+// GetWebhook retrieves a webhook
+func (r *WebhookRepository) GetWebhook(ctx context.Context, webhookID string) (*model.Webhook, error) {
+	ctx, span := startSpan(ctx, "WebhookRepository.GetWebhook")
+	defer span.End()
+
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1`
+
+	webhook, err := scanWebhook(r.db.QueryRow(ctx, query, webhookID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrWebhookNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to get webhook", zap.Error(err), zap.String("webhook_id", webhookID))
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// DeleteWebhook deletes a webhook
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, webhookID string) error {
+	ctx, span := startSpan(ctx, "WebhookRepository.DeleteWebhook")
+	defer span.End()
+
+	result, err := r.db.Exec(ctx, `DELETE FROM webhooks WHERE id = $1`, webhookID)
+	if err != nil {
+		r.logger.Error("failed to delete webhook", zap.Error(err), zap.String("webhook_id", webhookID))
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// FindWebhooksForEvent retrieves a user's webhooks subscribed to an event
+func (r *WebhookRepository) FindWebhooksForEvent(ctx context.Context, userID, event string) ([]model.Webhook, error) {
+	ctx, span := startSpan(ctx, "WebhookRepository.FindWebhooksForEvent")
+	defer span.End()
+
+	query := `
+		SELECT ` + webhookColumns + `
+		FROM webhooks
+		WHERE user_id = $1 AND $2 = ANY(events)
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, event)
+	if err != nil {
+		r.logger.Error("failed to find webhooks", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to find webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []model.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			r.logger.Error("failed to scan webhook", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("error iterating webhooks", zap.Error(err))
+		return nil, fmt.Errorf("error iterating webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// scanWebhook scans a row of webhookColumns
+func scanWebhook(row pgx.Row) (*model.Webhook, error) {
+	var webhook model.Webhook
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.UserID,
+		&webhook.TargetURL,
+		&webhook.SecretToken,
+		&webhook.Events,
+		&webhook.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
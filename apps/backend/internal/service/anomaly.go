@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+const (
+	anomalySourceBloodPressure = "blood_pressure"
+	anomalySourceCheckIn       = "check_in"
+)
+
+// AnomalyThresholds are the limits health readings are checked against. A hypertensive
+// crisis is not configurable: it is the crisis category of model.ClassifyBloodPressure,
+// the same that raises a critical alert.
+type AnomalyThresholds struct {
+	SystolicLow       int // systolic at or below this is low blood pressure
+	PulseHigh         int
+	PulseLow          int
+	PainHigh          int // pain level at or above this is severe
+	BaselineReadings  int // recent readings averaged into the baseline; with fewer there is none
+	SystolicDeviation int // systolic this far above the baseline is unusual
+	PainDeviation     int // pain this far above the baseline is a spike
+}
+
+// DefaultAnomalyThresholds returns the thresholds used unless configured otherwise
+func DefaultAnomalyThresholds() AnomalyThresholds {
+	return AnomalyThresholds{
+		SystolicLow:       90,
+		PulseHigh:         120,
+		PulseLow:          40,
+		PainHigh:          8,
+		BaselineReadings:  5,
+		SystolicDeviation: 25,
+		PainDeviation:     3,
+	}
+}
+
+// AnomalyStore defines the persistence operations of the anomaly detector
+type AnomalyStore interface {
+	Create(ctx context.Context, anomaly *model.Anomaly) error
+	FindPageByUserID(ctx context.Context, userID string, since time.Time, page repository.Page) ([]model.Anomaly, int, error)
+	RecentBloodPressure(ctx context.Context, userID, excludeID string, limit int) ([]model.BloodPressureReading, error)
+	RecentPainLevels(ctx context.Context, userID, excludeID string, limit int) ([]int, error)
+}
+
+// AnomalyDetector evaluates new health readings against fixed thresholds and the user's
+// baseline, and records the anomalies found
+type AnomalyDetector struct {
+	store      AnomalyStore
+	thresholds AnomalyThresholds
+	logger     *zap.Logger
+}
+
+// NewAnomalyDetector creates a new AnomalyDetector
+func NewAnomalyDetector(store AnomalyStore, thresholds AnomalyThresholds, logger *zap.Logger) *AnomalyDetector {
+	return &AnomalyDetector{
+		store:      store,
+		thresholds: thresholds,
+		logger:     logger,
+	}
+}
+
+// EvaluateBloodPressure checks a saved blood pressure reading and records its anomalies
+func (d *AnomalyDetector) EvaluateBloodPressure(ctx context.Context, reading *model.BloodPressureReading) ([]model.Anomaly, error) {
+	t := d.thresholds
+
+	var baseline *float64
+	recent, err := d.store.RecentBloodPressure(ctx, reading.UserID, reading.ID, t.BaselineReadings)
+	if err != nil {
+		d.logger.Warn("failed to get blood pressure baseline, checking thresholds only",
+			zap.Error(err),
+			zap.String("user_id", reading.UserID),
+		)
+	} else if t.BaselineReadings > 0 && len(recent) >= t.BaselineReadings {
+		sum := 0
+		for _, r := range recent {
+			sum += r.Systolic
+		}
+		mean := float64(sum) / float64(len(recent))
+		baseline = &mean
+	}
+
+	observedAt := reading.MeasuredAt
+	if observedAt.IsZero() {
+		observedAt = reading.CreatedAt
+	}
+	newAnomaly := func(metric string, value, threshold int, severity model.AnomalySeverity, reason string) model.Anomaly {
+		return model.Anomaly{
+			UserID:     reading.UserID,
+			Metric:     metric,
+			Value:      float64(value),
+			Threshold:  float64(threshold),
+			Severity:   severity,
+			Reason:     reason,
+			SourceType: anomalySourceBloodPressure,
+			SourceID:   reading.ID,
+			ObservedAt: observedAt,
+		}
+	}
+
+	var found []model.Anomaly
+	crisis := reading.Category() == model.BPCategoryCrisis
+	switch {
+	case crisis && reading.Systolic > model.BPCrisisSystolic:
+		found = append(found, newAnomaly("systolic", reading.Systolic, model.BPCrisisSystolic, model.AnomalySeverityCritical, "hypertensive crisis"))
+	case reading.Systolic <= t.SystolicLow:
+		found = append(found, newAnomaly("systolic", reading.Systolic, t.SystolicLow, model.AnomalySeverityHigh, "low blood pressure"))
+	case baseline != nil && float64(reading.Systolic) >= *baseline+float64(t.SystolicDeviation):
+		anomaly := newAnomaly("systolic", reading.Systolic, 0, model.AnomalySeverityModerate,
+			fmt.Sprintf("systolic %.0f mmHg above the usual %.0f mmHg", float64(reading.Systolic)-*baseline, *baseline))
+		anomaly.Baseline = baseline
+		anomaly.Threshold = *baseline + float64(t.SystolicDeviation)
+		found = append(found, anomaly)
+	}
+	if crisis && reading.Diastolic > model.BPCrisisDiastolic {
+		found = append(found, newAnomaly("diastolic", reading.Diastolic, model.BPCrisisDiastolic, model.AnomalySeverityCritical, "hypertensive crisis"))
+	}
+	switch {
+	case reading.Pulse >= t.PulseHigh:
+		found = append(found, newAnomaly("pulse", reading.Pulse, t.PulseHigh, model.AnomalySeverityHigh, "high pulse"))
+	case reading.Pulse <= t.PulseLow:
+		found = append(found, newAnomaly("pulse", reading.Pulse, t.PulseLow, model.AnomalySeverityHigh, "low pulse"))
+	}
+
+	return d.record(ctx, found)
+}
+
+// EvaluatePain checks the pain level of a saved check-in and records its anomalies
+func (d *AnomalyDetector) EvaluatePain(ctx context.Context, checkIn *model.HealthCheckIn) ([]model.Anomaly, error) {
+	if checkIn.PainLevel == nil {
+		return nil, nil
+	}
+	t := d.thresholds
+	pain := *checkIn.PainLevel
+
+	anomaly := model.Anomaly{
+		UserID:     checkIn.UserID,
+		Metric:     "pain_level",
+		Value:      float64(pain),
+		SourceType: anomalySourceCheckIn,
+		SourceID:   checkIn.ID,
+		ObservedAt: checkIn.CheckInDate,
+	}
+
+	if pain >= t.PainHigh {
+		anomaly.Threshold = float64(t.PainHigh)
+		anomaly.Severity = model.AnomalySeverityHigh
+		anomaly.Reason = "severe pain"
+		return d.record(ctx, []model.Anomaly{anomaly})
+	}
+
+	recent, err := d.store.RecentPainLevels(ctx, checkIn.UserID, checkIn.ID, t.BaselineReadings)
+	if err != nil {
+		d.logger.Warn("failed to get pain baseline, checking thresholds only",
+			zap.Error(err),
+			zap.String("user_id", checkIn.UserID),
+		)
+		return nil, nil
+	}
+	if t.BaselineReadings <= 0 || len(recent) < t.BaselineReadings {
+		return nil, nil
+	}
+
+	sum := 0
+	for _, level := range recent {
+		sum += level
+	}
+	baseline := float64(sum) / float64(len(recent))
+	if float64(pain) < baseline+float64(t.PainDeviation) {
+		return nil, nil
+	}
+
+	anomaly.Baseline = &baseline
+	anomaly.Threshold = baseline + float64(t.PainDeviation)
+	anomaly.Severity = model.AnomalySeverityModerate
+	anomaly.Reason = fmt.Sprintf("pain spike from the usual %.1f", baseline)
+	return d.record(ctx, []model.Anomaly{anomaly})
+}
+
+// CheckInCompleted checks the pain level of a completed check-in
+func (d *AnomalyDetector) CheckInCompleted(ctx context.Context, checkIn *model.HealthCheckIn) {
+	if _, err := d.EvaluatePain(ctx, checkIn); err != nil {
+		d.logger.Error("pain anomaly detection failed",
+			zap.Error(err),
+			zap.String("check_in_id", checkIn.ID),
+		)
+	}
+}
+
+// ListAnomalies retrieves a page of a user's anomalies observed at or after since,
+// newest first, and the total number of them
+func (d *AnomalyDetector) ListAnomalies(ctx context.Context, userID string, since time.Time, page repository.Page) ([]model.Anomaly, int, error) {
+	anomalies, total, err := d.store.FindPageByUserID(ctx, userID, since, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list anomalies: %w", err)
+	}
+	return anomalies, total, nil
+}
+
+// record saves the anomalies found
+func (d *AnomalyDetector) record(ctx context.Context, found []model.Anomaly) ([]model.Anomaly, error) {
+	for i := range found {
+		anomaly := &found[i]
+		anomaly.ID = uuid.New().String()
+		if err := d.store.Create(ctx, anomaly); err != nil {
+			return found[:i], fmt.Errorf("failed to save anomaly: %w", err)
+		}
+
+		d.logger.Warn("health data anomaly detected",
+			zap.String("anomaly_id", anomaly.ID),
+			zap.String("user_id", anomaly.UserID),
+			zap.String("metric", anomaly.Metric),
+			zap.Float64("value", anomaly.Value),
+			zap.String("severity", string(anomaly.Severity)),
+			zap.String("reason", anomaly.Reason),
+		)
+	}
+	return found, nil
+}
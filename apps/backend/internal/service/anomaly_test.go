@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+type fakeAnomalyStore struct {
+	created       []model.Anomaly
+	bloodPressure []model.BloodPressureReading
+	painLevels    []int
+	recentErr     error
+}
+
+func (f *fakeAnomalyStore) Create(ctx context.Context, anomaly *model.Anomaly) error {
+	f.created = append(f.created, *anomaly)
+	return nil
+}
+
+func (f *fakeAnomalyStore) FindPageByUserID(ctx context.Context, userID string, since time.Time, page repository.Page) ([]model.Anomaly, int, error) {
+	return f.created, len(f.created), nil
+}
+
+func (f *fakeAnomalyStore) RecentBloodPressure(ctx context.Context, userID, excludeID string, limit int) ([]model.BloodPressureReading, error) {
+	return f.bloodPressure, f.recentErr
+}
+
+func (f *fakeAnomalyStore) RecentPainLevels(ctx context.Context, userID, excludeID string, limit int) ([]int, error) {
+	return f.painLevels, f.recentErr
+}
+
+func bloodPressureReading(systolic, diastolic, pulse int) *model.BloodPressureReading {
+	return &model.BloodPressureReading{
+		ID:         "reading-1",
+		UserID:     "user-1",
+		Systolic:   systolic,
+		Diastolic:  diastolic,
+		Pulse:      pulse,
+		MeasuredAt: time.Date(2026, 3, 4, 8, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestAnomalyDetector_EvaluateBloodPressure(t *testing.T) {
+	t.Run("hypertensive crisis is critical", func(t *testing.T) {
+		store := &fakeAnomalyStore{}
+		detector := NewAnomalyDetector(store, DefaultAnomalyThresholds(), zap.NewNop())
+
+		anomalies, err := detector.EvaluateBloodPressure(context.Background(), bloodPressureReading(185, 125, 80))
+		require.NoError(t, err)
+
+		require.Len(t, anomalies, 2)
+		assert.Equal(t, "systolic", anomalies[0].Metric)
+		assert.Equal(t, 185.0, anomalies[0].Value)
+		assert.Equal(t, 180.0, anomalies[0].Threshold)
+		assert.Equal(t, model.AnomalySeverityCritical, anomalies[0].Severity)
+		assert.Equal(t, "hypertensive crisis", anomalies[0].Reason)
+		assert.Equal(t, "blood_pressure", anomalies[0].SourceType)
+		assert.Equal(t, "reading-1", anomalies[0].SourceID)
+		assert.NotEmpty(t, anomalies[0].ID)
+		assert.Equal(t, "diastolic", anomalies[1].Metric)
+		assert.Equal(t, model.AnomalySeverityCritical, anomalies[1].Severity)
+		assert.Equal(t, anomalies, store.created)
+	})
+
+	t.Run("crisis limits match the crisis category", func(t *testing.T) {
+		for _, reading := range []*model.BloodPressureReading{
+			bloodPressureReading(180, 120, 70),
+			bloodPressureReading(181, 80, 70),
+			bloodPressureReading(150, 121, 70),
+		} {
+			store := &fakeAnomalyStore{}
+			detector := NewAnomalyDetector(store, DefaultAnomalyThresholds(), zap.NewNop())
+
+			anomalies, err := detector.EvaluateBloodPressure(context.Background(), reading)
+			require.NoError(t, err)
+
+			critical := false
+			for _, anomaly := range anomalies {
+				critical = critical || anomaly.Severity == model.AnomalySeverityCritical
+			}
+			assert.Equal(t, reading.Category() == model.BPCategoryCrisis, critical,
+				"%d/%d mmHg", reading.Systolic, reading.Diastolic)
+		}
+	})
+
+	t.Run("normal reading has no anomalies", func(t *testing.T) {
+		store := &fakeAnomalyStore{}
+		detector := NewAnomalyDetector(store, DefaultAnomalyThresholds(), zap.NewNop())
+
+		anomalies, err := detector.EvaluateBloodPressure(context.Background(), bloodPressureReading(120, 80, 70))
+		require.NoError(t, err)
+
+		assert.Empty(t, anomalies)
+		assert.Empty(t, store.created)
+	})
+
+	t.Run("reading well above the baseline", func(t *testing.T) {
+		store := &fakeAnomalyStore{}
+		for _, systolic := range []int{118, 122, 120, 121, 119} {
+			store.bloodPressure = append(store.bloodPressure, model.BloodPressureReading{Systolic: systolic})
+		}
+		detector := NewAnomalyDetector(store, DefaultAnomalyThresholds(), zap.NewNop())
+
+		anomalies, err := detector.EvaluateBloodPressure(context.Background(), bloodPressureReading(150, 90, 70))
+		require.NoError(t, err)
+
+		require.Len(t, anomalies, 1)
+		assert.Equal(t, model.AnomalySeverityModerate, anomalies[0].Severity)
+		require.NotNil(t, anomalies[0].Baseline)
+		assert.Equal(t, 120.0, *anomalies[0].Baseline)
+		assert.Equal(t, 145.0, anomalies[0].Threshold)
+		assert.Equal(t, "systolic 30 mmHg above the usual 120 mmHg", anomalies[0].Reason)
+	})
+
+	t.Run("too few readings for a baseline", func(t *testing.T) {
+		store := &fakeAnomalyStore{bloodPressure: []model.BloodPressureReading{{Systolic: 110}}}
+		detector := NewAnomalyDetector(store, DefaultAnomalyThresholds(), zap.NewNop())
+
+		anomalies, err := detector.EvaluateBloodPressure(context.Background(), bloodPressureReading(150, 90, 70))
+		require.NoError(t, err)
+		assert.Empty(t, anomalies)
+	})
+
+	t.Run("baseline unavailable still checks thresholds", func(t *testing.T) {
+		store := &fakeAnomalyStore{recentErr: errors.New("connection refused")}
+		detector := NewAnomalyDetector(store, DefaultAnomalyThresholds(), zap.NewNop())
+
+		anomalies, err := detector.EvaluateBloodPressure(context.Background(), bloodPressureReading(85, 55, 130))
+		require.NoError(t, err)
+
+		require.Len(t, anomalies, 2)
+		assert.Equal(t, "low blood pressure", anomalies[0].Reason)
+		assert.Equal(t, "high pulse", anomalies[1].Reason)
+	})
+}
+
+func TestAnomalyDetector_EvaluatePain(t *testing.T) {
+	checkIn := func(pain *int) *model.HealthCheckIn {
+		return &model.HealthCheckIn{ID: "check-in-1", UserID: "user-1", PainLevel: pain, CheckInDate: time.Now()}
+	}
+
+	t.Run("severe pain", func(t *testing.T) {
+		store := &fakeAnomalyStore{}
+		detector := NewAnomalyDetector(store, DefaultAnomalyThresholds(), zap.NewNop())
+
+		detector.CheckInCompleted(context.Background(), checkIn(intPtr(9)))
+
+		require.Len(t, store.created, 1)
+		assert.Equal(t, "pain_level", store.created[0].Metric)
+		assert.Equal(t, model.AnomalySeverityHigh, store.created[0].Severity)
+		assert.Equal(t, "check_in", store.created[0].SourceType)
+	})
+
+	t.Run("pain spike from the baseline", func(t *testing.T) {
+		store := &fakeAnomalyStore{painLevels: []int{1, 2, 2, 1, 2}}
+		detector := NewAnomalyDetector(store, DefaultAnomalyThresholds(), zap.NewNop())
+
+		anomalies, err := detector.EvaluatePain(context.Background(), checkIn(intPtr(5)))
+		require.NoError(t, err)
+
+		require.Len(t, anomalies, 1)
+		assert.Equal(t, model.AnomalySeverityModerate, anomalies[0].Severity)
+		assert.InDelta(t, 1.6, *anomalies[0].Baseline, 0.001)
+	})
+
+	t.Run("usual pain and no pain level", func(t *testing.T) {
+		store := &fakeAnomalyStore{painLevels: []int{3, 4, 3, 4, 3}}
+		detector := NewAnomalyDetector(store, DefaultAnomalyThresholds(), zap.NewNop())
+
+		anomalies, err := detector.EvaluatePain(context.Background(), checkIn(intPtr(4)))
+		require.NoError(t, err)
+		assert.Empty(t, anomalies)
+
+		anomalies, err = detector.EvaluatePain(context.Background(), checkIn(nil))
+		require.NoError(t, err)
+		assert.Empty(t, anomalies)
+	})
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"container/list"
+	"sync"
+)
+
+// AudioCacheStats holds hit/miss metrics for the in-memory audio cache
+type AudioCacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+	Bytes     int64
+	MaxBytes  int64
+}
+
+// audioCacheEntry is a single cached audio clip
+type audioCacheEntry struct {
+	key  string
+	data []byte
+}
+
+// AudioCache is an in-memory LRU cache for synthesized question audio.
+// The cache is bounded by the total size of the stored audio, not by entry count,
+// and is safe for concurrent use.
+type AudioCache struct {
+	mu        sync.Mutex
+	maxBytes  int64
+	curBytes  int64
+	ll        *list.List
+	items     map[string]*list.Element
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// NewAudioCache creates a new AudioCache holding at most maxBytes of audio
+func NewAudioCache(maxBytes int64) *AudioCache {
+	return &AudioCache{
+		maxBytes: maxBytes,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached audio for key and marks it as recently used
+func (c *AudioCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+
+	c.ll.MoveToFront(elem)
+	c.hits++
+	return elem.Value.(*audioCacheEntry).data, true
+}
+
+// Add stores audio under key, evicting least recently used entries until the
+// cache fits within its byte budget. Clips larger than the budget are not cached.
+func (c *AudioCache) Add(key string, data []byte) {
+	size := int64(len(data))
+	if size == 0 || size > c.maxBytes {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*audioCacheEntry)
+		c.curBytes += size - int64(len(entry.data))
+		entry.data = data
+		c.ll.MoveToFront(elem)
+	} else {
+		c.items[key] = c.ll.PushFront(&audioCacheEntry{key: key, data: data})
+		c.curBytes += size
+	}
+
+	for c.curBytes > c.maxBytes {
+		oldest := c.ll.Back()
+		if oldest == nil {
+			break
+		}
+		c.removeElement(oldest)
+		c.evictions++
+	}
+}
+
+// Purge removes all entries from the cache
+func (c *AudioCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ll.Init()
+	c.items = make(map[string]*list.Element)
+	c.curBytes = 0
+}
+
+// Stats returns a snapshot of the cache metrics
+func (c *AudioCache) Stats() AudioCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return AudioCacheStats{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Entries:   c.ll.Len(),
+		Bytes:     c.curBytes,
+		MaxBytes:  c.maxBytes,
+	}
+}
+
+// removeElement unlinks an entry and updates the byte count
+func (c *AudioCache) removeElement(elem *list.Element) {
+	entry := elem.Value.(*audioCacheEntry)
+	c.ll.Remove(elem)
+	delete(c.items, entry.key)
+	c.curBytes -= int64(len(entry.data))
+}
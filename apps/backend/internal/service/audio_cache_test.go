@@ -0,0 +1,88 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudioCache_EvictsByTotalBytes(t *testing.T) {
+	cache := NewAudioCache(100)
+
+	cache.Add("a", make([]byte, 40))
+	cache.Add("b", make([]byte, 40))
+
+	// Touch "a" so "b" becomes the least recently used entry
+	_, ok := cache.Get("a")
+	require.True(t, ok)
+
+	cache.Add("c", make([]byte, 40))
+
+	_, ok = cache.Get("b")
+	assert.False(t, ok, "least recently used entry should be evicted")
+	_, ok = cache.Get("a")
+	assert.True(t, ok)
+	_, ok = cache.Get("c")
+	assert.True(t, ok)
+
+	stats := cache.Stats()
+	assert.Equal(t, 2, stats.Entries)
+	assert.Equal(t, int64(80), stats.Bytes)
+	assert.Equal(t, uint64(1), stats.Evictions)
+	assert.Equal(t, uint64(3), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+}
+
+func TestAudioCache_SkipsOversizedEntries(t *testing.T) {
+	cache := NewAudioCache(10)
+	cache.Add("big", make([]byte, 11))
+
+	_, ok := cache.Get("big")
+	assert.False(t, ok)
+	assert.Equal(t, int64(0), cache.Stats().Bytes)
+}
+
+func TestAudioCache_ReplaceUpdatesBytes(t *testing.T) {
+	cache := NewAudioCache(100)
+	cache.Add("a", make([]byte, 30))
+	cache.Add("a", make([]byte, 50))
+
+	stats := cache.Stats()
+	assert.Equal(t, 1, stats.Entries)
+	assert.Equal(t, int64(50), stats.Bytes)
+
+	cache.Purge()
+	assert.Equal(t, int64(0), cache.Stats().Bytes)
+}
+
+func TestAudioCache_ConcurrentAccess(t *testing.T) {
+	cache := NewAudioCache(1000)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("q%d", i%10)
+			if _, ok := cache.Get(key); !ok {
+				cache.Add(key, make([]byte, 150))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	stats := cache.Stats()
+	assert.LessOrEqual(t, stats.Bytes, int64(1000))
+	assert.Equal(t, uint64(50), stats.Hits+stats.Misses)
+}
+
+func TestQuestionAudioCacheKey(t *testing.T) {
+	assert.Equal(t, "question-audio/hu-HU/hu-HU-NoemiNeural/q1.mp3", questionAudioCacheKey("", "hu-HU-NoemiNeural", "q1"))
+	assert.Equal(t, "question-audio/v2/hu-HU/hu-HU-NoemiNeural/q1.mp3", questionAudioCacheKey("v2", "hu-HU-NoemiNeural", "q1"))
+
+	// Changing the voice must not serve audio cached for the previous voice
+	assert.NotEqual(t, questionAudioCacheKey("", "hu-HU-NoemiNeural", "q1"), questionAudioCacheKey("", "hu-HU-TamasNeural", "q1"))
+}
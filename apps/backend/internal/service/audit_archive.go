@@ -0,0 +1,528 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+const (
+	// AuditArchiveLockName is the advisory lock held while audit logs are archived or
+	// restored, so one server instance at a time moves them
+	AuditArchiveLockName = "audit_archive"
+
+	// auditArchiveBatchSize is how many audit logs are deleted or restored per statement
+	auditArchiveBatchSize = 1000
+
+	// auditArchiveMonthFormat names the archived months, e.g. 2025-01
+	auditArchiveMonthFormat = "2006-01"
+)
+
+var (
+	// ErrAuditArchiveBusy is returned when a restore is requested while another server
+	// instance archives or restores audit logs
+	ErrAuditArchiveBusy = errors.New("audit archive is busy")
+
+	// ErrAuditArchiveCorrupt is returned when an archive file does not match the checksum
+	// or row count recorded in its manifest
+	ErrAuditArchiveCorrupt = errors.New("audit archive failed verification")
+)
+
+// AuditArchiveStore defines the persistence operations needed to archive audit logs
+type AuditArchiveStore interface {
+	FindArchivableMonths(ctx context.Context, before time.Time) ([]time.Time, error)
+	ListMonthLogs(ctx context.Context, month time.Time) ([]audit.AuditLog, error)
+	CreateArchive(ctx context.Context, archive *model.AuditArchive) error
+	GetArchive(ctx context.Context, month time.Time) (*model.AuditArchive, error)
+	FindUnpurgedArchives(ctx context.Context) ([]model.AuditArchive, error)
+	FindExpiredRestores(ctx context.Context, now time.Time) ([]model.AuditArchive, error)
+	FindArchivedMonths(ctx context.Context, from, to, now time.Time) ([]time.Time, error)
+	MarkPurged(ctx context.Context, month time.Time) error
+	SetRestoredUntil(ctx context.Context, month time.Time, until *time.Time) error
+	DeleteAuditLogs(ctx context.Context, ids []string) (int64, error)
+	DeleteRestoredLogs(ctx context.Context, month time.Time, limit int) (int64, error)
+	InsertRestoredLogs(ctx context.Context, logs []audit.AuditLog) (int64, error)
+}
+
+// AuditArchiveStorage stores the archive files
+type AuditArchiveStorage interface {
+	UploadFile(ctx context.Context, blobName string, data []byte, contentType string) (string, error)
+	DownloadFile(ctx context.Context, blobName string) ([]byte, error)
+}
+
+// JobLocker takes locks shared by every server instance, so that a job runs on one of
+// them at a time
+type JobLocker interface {
+	TryLock(ctx context.Context, name string) (unlock func(), acquired bool, err error)
+}
+
+// AuditArchivePolicy configures how long audit logs stay in the database and in the archive
+type AuditArchivePolicy struct {
+	HotRetention     time.Duration // audit logs older than this are archived
+	ArchiveRetention time.Duration // recorded in the manifest for the storage lifecycle policy
+	Buckets          int           // files per month; a user's logs are all in one of them
+	RestoreTTL       time.Duration // how long a restored month stays in the database
+}
+
+// AuditArchiveManifest lists the files of an archived month with their checksums
+type AuditArchiveManifest struct {
+	Month       string             `json:"month"`
+	ArchivedAt  time.Time          `json:"archived_at"`
+	RetainUntil time.Time          `json:"retain_until"`
+	RowCount    int                `json:"row_count"`
+	Files       []AuditArchiveFile `json:"files"`
+}
+
+// AuditArchiveFile is a gzipped NDJSON file holding the audit logs of the users of a bucket
+type AuditArchiveFile struct {
+	Name     string `json:"name"`
+	Bucket   int    `json:"bucket"`
+	RowCount int    `json:"row_count"`
+	Bytes    int    `json:"bytes"`
+	SHA256   string `json:"sha256"`
+}
+
+// AuditArchiveRestore is the result of restoring an archived month
+type AuditArchiveRestore struct {
+	Month         string    `json:"month"`
+	RestoredCount int64     `json:"restored_count"`
+	RestoredUntil time.Time `json:"restored_until"`
+}
+
+// AuditArchiver moves audit logs older than the hot retention to blob storage, a month
+// at a time: the month's logs are written as gzipped NDJSON files, one per user bucket,
+// each upload is read back and checked against the checksum recorded in the month's
+// manifest, and only then are the archived rows deleted in batches. A month is archived
+// in memory. Archived months can be restored into the database on demand for a while.
+type AuditArchiver struct {
+	store       AuditArchiveStore
+	storage     AuditArchiveStorage
+	locker      JobLocker
+	policy      AuditArchivePolicy
+	auditLogger *audit.Logger
+	done        sync.WaitGroup
+	logger      *zap.Logger
+	now         func() time.Time
+}
+
+// NewAuditArchiver creates a new AuditArchiver
+func NewAuditArchiver(store AuditArchiveStore, storage AuditArchiveStorage, locker JobLocker, policy AuditArchivePolicy, logger *zap.Logger) *AuditArchiver {
+	return &AuditArchiver{
+		store:   store,
+		storage: storage,
+		locker:  locker,
+		policy:  policy,
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+// SetAuditLogger enables audit logging of restores
+func (a *AuditArchiver) SetAuditLogger(auditLogger *audit.Logger) {
+	a.auditLogger = auditLogger
+}
+
+// Start archives audit logs every interval until ctx is cancelled
+func (a *AuditArchiver) Start(ctx context.Context, interval time.Duration) {
+	a.done.Add(1)
+	go a.run(ctx, interval)
+	a.logger.Info("audit archiver started",
+		zap.Duration("interval", interval),
+		zap.Duration("hot_retention", a.policy.HotRetention),
+	)
+}
+
+// Wait blocks until the archiver has stopped after the context passed to Start was
+// cancelled
+func (a *AuditArchiver) Wait() {
+	a.done.Wait()
+}
+
+// run archives audit logs every interval until ctx is cancelled
+func (a *AuditArchiver) run(ctx context.Context, interval time.Duration) {
+	defer a.done.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := a.RunOnce(ctx); err != nil {
+			a.logger.Error("audit archiving failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce archives the months due for archiving, finishes the purges an earlier run did
+// not complete and deletes expired restores. It does nothing while another server
+// instance holds the archive lock. A month being archived when ctx is cancelled is left
+// for the next run.
+func (a *AuditArchiver) RunOnce(ctx context.Context) error {
+	unlock, acquired, err := a.locker.TryLock(ctx, AuditArchiveLockName)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		a.logger.Debug("audit archive lock held by another instance")
+		return nil
+	}
+	defer unlock()
+
+	now := a.now()
+	if err := a.expireRestores(ctx, now); err != nil {
+		return err
+	}
+
+	unpurged, err := a.store.FindUnpurgedArchives(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range unpurged {
+		if err := a.resumePurge(ctx, &unpurged[i]); err != nil {
+			return err
+		}
+	}
+
+	months, err := a.store.FindArchivableMonths(ctx, auditArchiveCutoff(now, a.policy.HotRetention))
+	if err != nil {
+		return err
+	}
+	for _, month := range months {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := a.archiveMonth(ctx, month, now); err != nil {
+			return fmt.Errorf("failed to archive %s: %w", month.Format(auditArchiveMonthFormat), err)
+		}
+	}
+
+	return nil
+}
+
+// archiveMonth writes the audit logs of a month to blob storage, verifies the upload
+// and deletes the archived rows
+func (a *AuditArchiver) archiveMonth(ctx context.Context, month, now time.Time) error {
+	logs, err := a.store.ListMonthLogs(ctx, month)
+	if err != nil || len(logs) == 0 {
+		return err
+	}
+
+	buckets := make(map[int][]audit.AuditLog)
+	for _, log := range logs {
+		bucket := auditArchiveBucket(log.UserID, a.policy.Buckets)
+		buckets[bucket] = append(buckets[bucket], log)
+	}
+	bucketNumbers := make([]int, 0, len(buckets))
+	for bucket := range buckets {
+		bucketNumbers = append(bucketNumbers, bucket)
+	}
+	sort.Ints(bucketNumbers)
+
+	label := month.Format(auditArchiveMonthFormat)
+	manifest := AuditArchiveManifest{
+		Month:       label,
+		ArchivedAt:  now,
+		RetainUntil: month.AddDate(0, 1, 0).Add(a.policy.ArchiveRetention),
+		RowCount:    len(logs),
+	}
+	for _, bucket := range bucketNumbers {
+		data, err := encodeAuditArchiveFile(buckets[bucket])
+		if err != nil {
+			return err
+		}
+		file := AuditArchiveFile{
+			Name:     fmt.Sprintf("%s/bucket-%02d.ndjson.gz", label, bucket),
+			Bucket:   bucket,
+			RowCount: len(buckets[bucket]),
+			Bytes:    len(data),
+			SHA256:   sha256Hex(data),
+		}
+		if err := a.upload(ctx, file.Name, data, "application/gzip"); err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	manifestData, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit archive manifest: %w", err)
+	}
+	manifestName := label + "/manifest.json"
+	if err := a.upload(ctx, manifestName, manifestData, "application/json"); err != nil {
+		return err
+	}
+
+	archive := &model.AuditArchive{Month: month, ManifestBlob: manifestName, RowCount: len(logs)}
+	if err := a.store.CreateArchive(ctx, archive); err != nil {
+		return err
+	}
+
+	ids := make([]string, len(logs))
+	for i, log := range logs {
+		ids[i] = log.ID
+	}
+	if err := a.purge(ctx, month, ids); err != nil {
+		return err
+	}
+
+	a.logger.Info("archived audit logs",
+		zap.String("month", label),
+		zap.Int("rows", len(logs)),
+		zap.Int("files", len(manifest.Files)),
+	)
+	return nil
+}
+
+// upload stores a file and reads it back to verify that it was stored intact
+func (a *AuditArchiver) upload(ctx context.Context, name string, data []byte, contentType string) error {
+	if _, err := a.storage.UploadFile(ctx, name, data, contentType); err != nil {
+		return err
+	}
+
+	stored, err := a.storage.DownloadFile(ctx, name)
+	if err != nil {
+		return err
+	}
+	if sha256Hex(stored) != sha256Hex(data) {
+		return fmt.Errorf("%w: %s differs from the uploaded data", ErrAuditArchiveCorrupt, name)
+	}
+	return nil
+}
+
+// resumePurge deletes the rows of a month that was archived but whose rows were not
+// all deleted, reading the archived IDs back from blob storage
+func (a *AuditArchiver) resumePurge(ctx context.Context, archive *model.AuditArchive) error {
+	logs, err := a.readArchive(ctx, archive)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, len(logs))
+	for i, log := range logs {
+		ids[i] = log.ID
+	}
+	return a.purge(ctx, archive.Month, ids)
+}
+
+// purge deletes the archived rows of a month in batches and records the month purged
+func (a *AuditArchiver) purge(ctx context.Context, month time.Time, ids []string) error {
+	for start := 0; start < len(ids); start += auditArchiveBatchSize {
+		end := min(start+auditArchiveBatchSize, len(ids))
+		if _, err := a.store.DeleteAuditLogs(ctx, ids[start:end]); err != nil {
+			return err
+		}
+	}
+	return a.store.MarkPurged(ctx, month)
+}
+
+// expireRestores deletes the restored copies of the months restored until now or earlier
+func (a *AuditArchiver) expireRestores(ctx context.Context, now time.Time) error {
+	archives, err := a.store.FindExpiredRestores(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, archive := range archives {
+		for {
+			deleted, err := a.store.DeleteRestoredLogs(ctx, archive.Month, auditArchiveBatchSize)
+			if err != nil {
+				return err
+			}
+			if deleted < auditArchiveBatchSize {
+				break
+			}
+		}
+		if err := a.store.SetRestoredUntil(ctx, archive.Month, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Restore copies an archived month back into the database until the restore TTL
+// expires, so the audit log endpoint finds its logs again. Restoring a restored month
+// extends its restore.
+func (a *AuditArchiver) Restore(ctx context.Context, month time.Time, requestedBy string) (*AuditArchiveRestore, error) {
+	unlock, acquired, err := a.locker.TryLock(ctx, AuditArchiveLockName)
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, ErrAuditArchiveBusy
+	}
+	defer unlock()
+
+	archive, err := a.store.GetArchive(ctx, month)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := a.readArchive(ctx, archive)
+	if err != nil {
+		return nil, err
+	}
+
+	// Recorded first so that copies of a partially failed restore still expire
+	until := a.now().Add(a.policy.RestoreTTL)
+	if err := a.store.SetRestoredUntil(ctx, month, &until); err != nil {
+		return nil, err
+	}
+
+	var restored int64
+	for start := 0; start < len(logs); start += auditArchiveBatchSize {
+		end := min(start+auditArchiveBatchSize, len(logs))
+		inserted, err := a.store.InsertRestoredLogs(ctx, logs[start:end])
+		if err != nil {
+			return nil, err
+		}
+		restored += inserted
+	}
+
+	label := month.Format(auditArchiveMonthFormat)
+	a.logger.Info("restored archived audit logs",
+		zap.String("month", label),
+		zap.Int64("rows", restored),
+		zap.String("requested_by", requestedBy),
+	)
+	if a.auditLogger != nil {
+		err := a.auditLogger.Log(ctx, audit.AuditLog{
+			UserID:         requestedBy,
+			OperationType:  audit.OperationRead,
+			ResourceType:   audit.ResourceAuditArchive,
+			ResourceID:     label,
+			AdditionalData: map[string]interface{}{"restored_count": restored, "restored_until": until},
+		})
+		if err != nil {
+			a.logger.Error("failed to audit audit archive restore", zap.Error(err), zap.String("month", label))
+		}
+	}
+
+	return &AuditArchiveRestore{Month: label, RestoredCount: restored, RestoredUntil: until}, nil
+}
+
+// ArchivedMonths lists the months overlapping the window from (inclusive) to (exclusive)
+// whose audit logs exist only in the archive. Zero bounds do not limit the window.
+func (a *AuditArchiver) ArchivedMonths(ctx context.Context, from, to time.Time) ([]string, error) {
+	months, err := a.store.FindArchivedMonths(ctx, from, to, a.now())
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]string, len(months))
+	for i, month := range months {
+		labels[i] = month.Format(auditArchiveMonthFormat)
+	}
+	return labels, nil
+}
+
+// readArchive reads the audit logs of an archived month, verifying every file against
+// the checksum and row count in the manifest
+func (a *AuditArchiver) readArchive(ctx context.Context, archive *model.AuditArchive) ([]audit.AuditLog, error) {
+	manifestData, err := a.storage.DownloadFile(ctx, archive.ManifestBlob)
+	if err != nil {
+		return nil, err
+	}
+	var manifest AuditArchiveManifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrAuditArchiveCorrupt, archive.ManifestBlob, err)
+	}
+
+	var logs []audit.AuditLog
+	for _, file := range manifest.Files {
+		data, err := a.storage.DownloadFile(ctx, file.Name)
+		if err != nil {
+			return nil, err
+		}
+		if sha256Hex(data) != file.SHA256 {
+			return nil, fmt.Errorf("%w: checksum of %s does not match the manifest", ErrAuditArchiveCorrupt, file.Name)
+		}
+
+		fileLogs, err := decodeAuditArchiveFile(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrAuditArchiveCorrupt, file.Name, err)
+		}
+		if len(fileLogs) != file.RowCount {
+			return nil, fmt.Errorf("%w: %s holds %d rows, the manifest lists %d", ErrAuditArchiveCorrupt, file.Name, len(fileLogs), file.RowCount)
+		}
+		logs = append(logs, fileLogs...)
+	}
+
+	return logs, nil
+}
+
+// auditArchiveCutoff returns the start of the latest month whose audit logs are all
+// older than the hot retention at now. Only whole months are archived.
+func auditArchiveCutoff(now time.Time, hotRetention time.Duration) time.Time {
+	cutoff := now.UTC().Add(-hotRetention)
+	return time.Date(cutoff.Year(), cutoff.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// auditArchiveBucket returns the archive file of a user's audit logs
+func auditArchiveBucket(userID string, buckets int) int {
+	h := fnv.New32a()
+	h.Write([]byte(userID))
+	return int(h.Sum32() % uint32(buckets))
+}
+
+// encodeAuditArchiveFile writes audit logs as gzipped NDJSON, one log per line
+func encodeAuditArchiveFile(logs []audit.AuditLog) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(gz)
+	for _, log := range logs {
+		if err := encoder.Encode(log); err != nil {
+			return nil, fmt.Errorf("failed to encode audit log %s: %w", log.ID, err)
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress audit archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeAuditArchiveFile reads the audit logs of a gzipped NDJSON file
+func decodeAuditArchiveFile(data []byte) ([]audit.AuditLog, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	var logs []audit.AuditLog
+	decoder := json.NewDecoder(gz)
+	for {
+		var log audit.AuditLog
+		err := decoder.Decode(&log)
+		if errors.Is(err, io.EOF) {
+			return logs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+}
+
+// sha256Hex returns the hex-encoded SHA-256 checksum of data
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,373 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// storedAuditLog is an audit log row of a fakeAuditArchiveStore
+type storedAuditLog struct {
+	log      audit.AuditLog
+	restored bool
+}
+
+// fakeAuditArchiveStore is an in-memory AuditArchiveStore
+type fakeAuditArchiveStore struct {
+	logs        map[string]*storedAuditLog
+	archives    map[time.Time]*model.AuditArchive
+	deleteCalls int
+}
+
+func newFakeAuditArchiveStore(logs ...audit.AuditLog) *fakeAuditArchiveStore {
+	store := &fakeAuditArchiveStore{
+		logs:     make(map[string]*storedAuditLog),
+		archives: make(map[time.Time]*model.AuditArchive),
+	}
+	for _, log := range logs {
+		store.logs[log.ID] = &storedAuditLog{log: log}
+	}
+	return store
+}
+
+func monthOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (f *fakeAuditArchiveStore) FindArchivableMonths(ctx context.Context, before time.Time) ([]time.Time, error) {
+	seen := make(map[time.Time]bool)
+	var months []time.Time
+	for _, stored := range f.logs {
+		month := monthOf(stored.log.Timestamp)
+		if stored.restored || !stored.log.Timestamp.Before(before) || seen[month] || f.archives[month] != nil {
+			continue
+		}
+		seen[month] = true
+		months = append(months, month)
+	}
+	sort.Slice(months, func(i, j int) bool { return months[i].Before(months[j]) })
+	return months, nil
+}
+
+func (f *fakeAuditArchiveStore) ListMonthLogs(ctx context.Context, month time.Time) ([]audit.AuditLog, error) {
+	var logs []audit.AuditLog
+	for _, stored := range f.logs {
+		if !stored.restored && monthOf(stored.log.Timestamp).Equal(month) {
+			logs = append(logs, stored.log)
+		}
+	}
+	sort.Slice(logs, func(i, j int) bool { return logs[i].ID < logs[j].ID })
+	return logs, nil
+}
+
+func (f *fakeAuditArchiveStore) CreateArchive(ctx context.Context, archive *model.AuditArchive) error {
+	if f.archives[archive.Month] != nil {
+		return fmt.Errorf("duplicate archive %s", archive.Month)
+	}
+	stored := *archive
+	f.archives[archive.Month] = &stored
+	return nil
+}
+
+func (f *fakeAuditArchiveStore) GetArchive(ctx context.Context, month time.Time) (*model.AuditArchive, error) {
+	archive, ok := f.archives[month]
+	if !ok {
+		return nil, repository.ErrAuditArchiveNotFound
+	}
+	found := *archive
+	return &found, nil
+}
+
+func (f *fakeAuditArchiveStore) FindUnpurgedArchives(ctx context.Context) ([]model.AuditArchive, error) {
+	var archives []model.AuditArchive
+	for _, archive := range f.archives {
+		if archive.PurgedAt == nil {
+			archives = append(archives, *archive)
+		}
+	}
+	return archives, nil
+}
+
+func (f *fakeAuditArchiveStore) FindExpiredRestores(ctx context.Context, now time.Time) ([]model.AuditArchive, error) {
+	var archives []model.AuditArchive
+	for _, archive := range f.archives {
+		if archive.RestoredUntil != nil && !archive.RestoredUntil.After(now) {
+			archives = append(archives, *archive)
+		}
+	}
+	return archives, nil
+}
+
+func (f *fakeAuditArchiveStore) FindArchivedMonths(ctx context.Context, from, to, now time.Time) ([]time.Time, error) {
+	var months []time.Time
+	for month, archive := range f.archives {
+		if archive.RestoredUntil != nil && archive.RestoredUntil.After(now) {
+			continue
+		}
+		if (!from.IsZero() && !month.AddDate(0, 1, 0).After(from)) || (!to.IsZero() && !month.Before(to)) {
+			continue
+		}
+		months = append(months, month)
+	}
+	sort.Slice(months, func(i, j int) bool { return months[i].Before(months[j]) })
+	return months, nil
+}
+
+func (f *fakeAuditArchiveStore) MarkPurged(ctx context.Context, month time.Time) error {
+	now := time.Now()
+	f.archives[month].PurgedAt = &now
+	return nil
+}
+
+func (f *fakeAuditArchiveStore) SetRestoredUntil(ctx context.Context, month time.Time, until *time.Time) error {
+	f.archives[month].RestoredUntil = until
+	return nil
+}
+
+func (f *fakeAuditArchiveStore) DeleteAuditLogs(ctx context.Context, ids []string) (int64, error) {
+	f.deleteCalls++
+	var deleted int64
+	for _, id := range ids {
+		if stored, ok := f.logs[id]; ok && !stored.restored {
+			delete(f.logs, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (f *fakeAuditArchiveStore) DeleteRestoredLogs(ctx context.Context, month time.Time, limit int) (int64, error) {
+	var deleted int64
+	for id, stored := range f.logs {
+		if int(deleted) == limit {
+			break
+		}
+		if stored.restored && monthOf(stored.log.Timestamp).Equal(month) {
+			delete(f.logs, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (f *fakeAuditArchiveStore) InsertRestoredLogs(ctx context.Context, logs []audit.AuditLog) (int64, error) {
+	var inserted int64
+	for _, log := range logs {
+		if _, ok := f.logs[log.ID]; !ok {
+			f.logs[log.ID] = &storedAuditLog{log: log, restored: true}
+			inserted++
+		}
+	}
+	return inserted, nil
+}
+
+// fakeAuditArchiveStorage is an in-memory AuditArchiveStorage
+type fakeAuditArchiveStorage struct {
+	files map[string][]byte
+}
+
+func newFakeAuditArchiveStorage() *fakeAuditArchiveStorage {
+	return &fakeAuditArchiveStorage{files: make(map[string][]byte)}
+}
+
+func (f *fakeAuditArchiveStorage) UploadFile(ctx context.Context, blobName string, data []byte, contentType string) (string, error) {
+	f.files[blobName] = append([]byte(nil), data...)
+	return "https://storage.example/" + blobName, nil
+}
+
+func (f *fakeAuditArchiveStorage) DownloadFile(ctx context.Context, blobName string) ([]byte, error) {
+	data, ok := f.files[blobName]
+	if !ok {
+		return nil, fmt.Errorf("blob %s not found", blobName)
+	}
+	return data, nil
+}
+
+// fakeJobLocker is a JobLocker that is either free or held by another instance
+type fakeJobLocker struct {
+	held bool
+}
+
+func (f *fakeJobLocker) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	if f.held {
+		return nil, false, nil
+	}
+	f.held = true
+	return func() { f.held = false }, true, nil
+}
+
+// archiveTestLogs returns n audit logs of a few users spread over the given month
+func archiveTestLogs(month time.Time, n int) []audit.AuditLog {
+	logs := make([]audit.AuditLog, n)
+	for i := range logs {
+		logs[i] = audit.AuditLog{
+			ID:             fmt.Sprintf("%s-%05d", month.Format("2006-01"), i),
+			UserID:         fmt.Sprintf("user-%d", i%7),
+			OperationType:  audit.OperationRead,
+			ResourceType:   audit.ResourceMedication,
+			ResourceID:     fmt.Sprintf("resource-%d", i),
+			Timestamp:      month.Add(time.Duration(i) * time.Minute),
+			AdditionalData: map[string]interface{}{"n": float64(i)},
+		}
+	}
+	return logs
+}
+
+func newTestAuditArchiver(store *fakeAuditArchiveStore, storage *fakeAuditArchiveStorage, locker *fakeJobLocker, now time.Time) *AuditArchiver {
+	archiver := NewAuditArchiver(store, storage, locker, AuditArchivePolicy{
+		HotRetention:     90 * 24 * time.Hour,
+		ArchiveRetention: 5 * 365 * 24 * time.Hour,
+		Buckets:          4,
+		RestoreTTL:       7 * 24 * time.Hour,
+	}, zap.NewNop())
+	archiver.now = func() time.Time { return now }
+	return archiver
+}
+
+func TestAuditArchiveCutoff(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), auditArchiveCutoff(now, 90*24*time.Hour))
+	assert.Equal(t, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), auditArchiveCutoff(now, 0))
+}
+
+func TestAuditArchiver_ArchivesMonthsPastHotRetention(t *testing.T) {
+	january := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	may := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	logs := append(archiveTestLogs(january, 2500), archiveTestLogs(may, 10)...)
+	store := newFakeAuditArchiveStore(logs...)
+	storage := newFakeAuditArchiveStorage()
+	archiver := newTestAuditArchiver(store, storage, &fakeJobLocker{}, time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC))
+
+	require.NoError(t, archiver.RunOnce(context.Background()))
+
+	// Only May, inside the hot retention, stays in the database
+	assert.Len(t, store.logs, 10)
+	archive := store.archives[january]
+	require.NotNil(t, archive)
+	assert.Equal(t, 2500, archive.RowCount)
+	assert.NotNil(t, archive.PurgedAt)
+	assert.Equal(t, 3, store.deleteCalls, "2500 rows are deleted in batches of 1000")
+
+	// Every user's logs are in a single bucket file listed in the manifest
+	restored, err := archiver.readArchive(context.Background(), archive)
+	require.NoError(t, err)
+	assert.Len(t, restored, 2500)
+	for name := range storage.files {
+		assert.True(t, strings.HasPrefix(name, "2025-01/"), name)
+	}
+	assert.Contains(t, storage.files, "2025-01/manifest.json")
+
+	// A second run finds nothing left to archive
+	require.NoError(t, archiver.RunOnce(context.Background()))
+	assert.Len(t, store.archives, 1)
+}
+
+func TestAuditArchiver_RestoreAndExpire(t *testing.T) {
+	january := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logs := archiveTestLogs(january, 20)
+	store := newFakeAuditArchiveStore(logs...)
+	storage := newFakeAuditArchiveStorage()
+	now := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	archiver := newTestAuditArchiver(store, storage, &fakeJobLocker{}, now)
+	ctx := context.Background()
+
+	require.NoError(t, archiver.RunOnce(ctx))
+	require.Empty(t, store.logs)
+
+	months, err := archiver.ArchivedMonths(ctx, time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC), time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2025-01"}, months)
+	months, err = archiver.ArchivedMonths(ctx, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, months)
+
+	result, err := archiver.Restore(ctx, january, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01", result.Month)
+	assert.EqualValues(t, 20, result.RestoredCount)
+	assert.Equal(t, now.Add(7*24*time.Hour), result.RestoredUntil)
+	require.Len(t, store.logs, 20)
+	assert.Equal(t, logs[3], store.logs[logs[3].ID].log)
+
+	// A restored month is in the database, so it is not reported as archived nor
+	// archived again
+	months, err = archiver.ArchivedMonths(ctx, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, months)
+	require.NoError(t, archiver.RunOnce(ctx))
+	assert.Len(t, store.logs, 20)
+
+	// Once the restore expires the copies are deleted again
+	archiver.now = func() time.Time { return now.Add(8 * 24 * time.Hour) }
+	require.NoError(t, archiver.RunOnce(ctx))
+	assert.Empty(t, store.logs)
+	assert.Nil(t, store.archives[january].RestoredUntil)
+}
+
+func TestAuditArchiver_RestoreRejectsCorruptArchive(t *testing.T) {
+	january := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := newFakeAuditArchiveStore(archiveTestLogs(january, 20)...)
+	storage := newFakeAuditArchiveStorage()
+	archiver := newTestAuditArchiver(store, storage, &fakeJobLocker{}, time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, archiver.RunOnce(context.Background()))
+
+	for name, data := range storage.files {
+		if strings.HasSuffix(name, ".ndjson.gz") {
+			data[len(data)-1] ^= 0xff
+			break
+		}
+	}
+
+	_, err := archiver.Restore(context.Background(), january, "admin")
+	assert.ErrorIs(t, err, ErrAuditArchiveCorrupt)
+	assert.Empty(t, store.logs)
+}
+
+func TestAuditArchiver_RestoreUnknownMonth(t *testing.T) {
+	archiver := newTestAuditArchiver(newFakeAuditArchiveStore(), newFakeAuditArchiveStorage(), &fakeJobLocker{}, time.Now())
+
+	_, err := archiver.Restore(context.Background(), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), "admin")
+	assert.ErrorIs(t, err, repository.ErrAuditArchiveNotFound)
+}
+
+func TestAuditArchiver_LockHeldByAnotherInstance(t *testing.T) {
+	january := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := newFakeAuditArchiveStore(archiveTestLogs(january, 5)...)
+	locker := &fakeJobLocker{held: true}
+	archiver := newTestAuditArchiver(store, newFakeAuditArchiveStorage(), locker, time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC))
+
+	require.NoError(t, archiver.RunOnce(context.Background()))
+	assert.Len(t, store.logs, 5)
+	assert.Empty(t, store.archives)
+
+	_, err := archiver.Restore(context.Background(), january, "admin")
+	assert.ErrorIs(t, err, ErrAuditArchiveBusy)
+}
+
+func TestAuditArchiver_ResumesInterruptedPurge(t *testing.T) {
+	january := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := newFakeAuditArchiveStore(archiveTestLogs(january, 20)...)
+	storage := newFakeAuditArchiveStorage()
+	archiver := newTestAuditArchiver(store, storage, &fakeJobLocker{}, time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, archiver.RunOnce(context.Background()))
+
+	// Simulate a run that recorded the archive but stopped before deleting the rows
+	for _, log := range archiveTestLogs(january, 20) {
+		store.logs[log.ID] = &storedAuditLog{log: log}
+	}
+	store.archives[january].PurgedAt = nil
+
+	require.NoError(t, archiver.RunOnce(context.Background()))
+	assert.Empty(t, store.logs)
+	assert.NotNil(t, store.archives[january].PurgedAt)
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"go.uber.org/zap"
+)
+
+func TestAuditLogger_GetAuditLogsFilters(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	auditLogger := audit.NewLogger(db, zap.NewNop())
+	base := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	userID := uuid.New().String()
+
+	entries := []struct {
+		operation audit.OperationType
+		resource  audit.ResourceType
+		at        time.Time
+	}{
+		{audit.OperationCreate, audit.ResourceMedication, base.AddDate(0, 0, -3)},
+		{audit.OperationDelete, audit.ResourceMedication, base.AddDate(0, 0, -2)},
+		{audit.OperationDelete, audit.ResourceReport, base.AddDate(0, 0, -1)},
+		{audit.OperationDelete, audit.ResourceReport, base.AddDate(0, 0, 5)},
+		{audit.OperationRead, audit.ResourceReport, base},
+	}
+	for _, entry := range entries {
+		require.NoError(t, auditLogger.Log(ctx, audit.AuditLog{
+			UserID:        userID,
+			OperationType: entry.operation,
+			ResourceType:  entry.resource,
+			ResourceID:    uuid.New().String(),
+			Timestamp:     entry.at,
+		}))
+	}
+
+	window := audit.AuditFilter{
+		UserID:        userID,
+		OperationType: audit.OperationDelete,
+		From:          base.AddDate(0, 0, -2),
+		To:            base.AddDate(0, 0, 1),
+	}
+	logs, _, err := auditLogger.GetAuditLogs(ctx, window)
+	require.NoError(t, err)
+	require.Len(t, logs, 2, "deletes inside the window only")
+	assert.Equal(t, audit.ResourceReport, logs[0].ResourceType, "newest first")
+	assert.Equal(t, audit.ResourceMedication, logs[1].ResourceType)
+	for _, log := range logs {
+		assert.Equal(t, audit.OperationDelete, log.OperationType)
+		assert.NotEmpty(t, log.ID)
+	}
+
+	total, err := auditLogger.CountAuditLogs(ctx, window)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+
+	reports, _, err := auditLogger.GetAuditLogs(ctx, audit.AuditFilter{UserID: userID, ResourceType: audit.ResourceReport, Limit: 1, Offset: 1})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, audit.OperationRead, reports[0].OperationType, "second newest report entry")
+}
+
+func TestAuditLogger_GetAuditLogsCursor(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	auditLogger := audit.NewLogger(db, zap.NewNop())
+	base := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	ipAddress := "203.0.113.7"
+
+	// Two logs share a timestamp so the cursor must break the tie
+	for i, at := range []time.Time{base, base, base.Add(-time.Hour), base.Add(-2 * time.Hour), base.Add(-3 * time.Hour)} {
+		require.NoError(t, auditLogger.Log(ctx, audit.AuditLog{
+			UserID:        uuid.New().String(),
+			OperationType: audit.OperationRead,
+			ResourceType:  audit.ResourceReport,
+			ResourceID:    uuid.New().String(),
+			Timestamp:     at,
+			IPAddress:     ipAddress,
+		}), "log %d", i)
+	}
+	require.NoError(t, auditLogger.Log(ctx, audit.AuditLog{
+		UserID:        uuid.New().String(),
+		OperationType: audit.OperationRead,
+		ResourceType:  audit.ResourceReport,
+		ResourceID:    uuid.New().String(),
+		Timestamp:     base,
+		IPAddress:     "198.51.100.1",
+	}))
+
+	filter := audit.AuditFilter{IPAddress: ipAddress, Limit: 2}
+	var pages [][]audit.AuditLog
+	for {
+		logs, next, err := auditLogger.GetAuditLogs(ctx, filter)
+		require.NoError(t, err)
+		pages = append(pages, logs)
+		if next == "" {
+			break
+		}
+		filter.Cursor = next
+	}
+
+	require.Len(t, pages, 3)
+	seen := make(map[string]bool)
+	var previous time.Time
+	for _, page := range pages {
+		for _, log := range page {
+			assert.Equal(t, ipAddress, log.IPAddress)
+			assert.False(t, seen[log.ID], "each log is on one page")
+			seen[log.ID] = true
+			if !previous.IsZero() {
+				assert.False(t, log.Timestamp.After(previous), "newest first")
+			}
+			previous = log.Timestamp
+		}
+	}
+	assert.Len(t, seen, 5)
+
+	total, err := auditLogger.CountAuditLogs(ctx, audit.AuditFilter{IPAddress: ipAddress})
+	require.NoError(t, err)
+	assert.Equal(t, 5, total)
+
+	_, _, err = auditLogger.GetAuditLogs(ctx, audit.AuditFilter{Cursor: "not-a-cursor"})
+	assert.ErrorIs(t, err, audit.ErrInvalidCursor)
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+const (
+	// bpTrendWindowDays is the length of the windows compared by the blood pressure trend
+	bpTrendWindowDays = 7
+
+	// bpTrendFlatMmHg is the largest change of the average systolic pressure still
+	// considered flat
+	bpTrendFlatMmHg = 2.0
+)
+
+// TrendDirection is the direction of a change between two periods
+type TrendDirection string
+
+const (
+	TrendUp   TrendDirection = "up"
+	TrendDown TrendDirection = "down"
+	TrendFlat TrendDirection = "flat"
+)
+
+// BloodPressureTrend compares the average blood pressure of the last 7 days with the 7
+// days before. Averages are nil for a window without readings; the deltas and direction,
+// which follows the systolic delta, are only set when both windows have readings.
+type BloodPressureTrend struct {
+	AverageSystolic          *float64       `json:"average_systolic"`
+	AverageDiastolic         *float64       `json:"average_diastolic"`
+	PreviousAverageSystolic  *float64       `json:"previous_average_systolic"`
+	PreviousAverageDiastolic *float64       `json:"previous_average_diastolic"`
+	SystolicDelta            *float64       `json:"systolic_delta"`
+	DiastolicDelta           *float64       `json:"diastolic_delta"`
+	Direction                TrendDirection `json:"direction,omitempty"`
+}
+
+// computeBloodPressureTrend compares the readings measured in the 7 days up to now with
+// those of the 7 days before
+func computeBloodPressureTrend(readings []model.BloodPressureReading, now time.Time) *BloodPressureTrend {
+	currentStart := now.AddDate(0, 0, -bpTrendWindowDays)
+	previousStart := currentStart.AddDate(0, 0, -bpTrendWindowDays)
+
+	var current, previous bpAverage
+	for _, reading := range readings {
+		switch {
+		case reading.MeasuredAt.After(now) || reading.MeasuredAt.Before(previousStart):
+		case reading.MeasuredAt.Before(currentStart):
+			previous.add(reading)
+		default:
+			current.add(reading)
+		}
+	}
+
+	trend := &BloodPressureTrend{}
+	trend.AverageSystolic, trend.AverageDiastolic = current.averages()
+	trend.PreviousAverageSystolic, trend.PreviousAverageDiastolic = previous.averages()
+	if current.count == 0 || previous.count == 0 {
+		return trend
+	}
+
+	systolicDelta := *trend.AverageSystolic - *trend.PreviousAverageSystolic
+	diastolicDelta := *trend.AverageDiastolic - *trend.PreviousAverageDiastolic
+	trend.SystolicDelta = &systolicDelta
+	trend.DiastolicDelta = &diastolicDelta
+
+	switch {
+	case math.Abs(systolicDelta) <= bpTrendFlatMmHg:
+		trend.Direction = TrendFlat
+	case systolicDelta > 0:
+		trend.Direction = TrendUp
+	default:
+		trend.Direction = TrendDown
+	}
+	return trend
+}
+
+// bpAverage sums blood pressure readings for averaging
+type bpAverage struct {
+	systolic, diastolic int
+	count               int
+}
+
+// add adds a reading to the sums
+func (a *bpAverage) add(reading model.BloodPressureReading) {
+	a.systolic += reading.Systolic
+	a.diastolic += reading.Diastolic
+	a.count++
+}
+
+// averages returns the average systolic and diastolic pressure, nil without readings
+func (a bpAverage) averages() (*float64, *float64) {
+	if a.count == 0 {
+		return nil, nil
+	}
+	systolic := float64(a.systolic) / float64(a.count)
+	diastolic := float64(a.diastolic) / float64(a.count)
+	return &systolic, &diastolic
+}
+
+// EvaluateBloodPressure stores a critical alert for a saved blood pressure reading in the
+// hypertensive crisis category. It returns nil for any other reading.
+func (s *AlertService) EvaluateBloodPressure(ctx context.Context, reading *model.BloodPressureReading) (*model.Alert, error) {
+	if reading.Category() != model.BPCategoryCrisis {
+		return nil, nil
+	}
+
+	alert := model.Alert{
+		ID:       uuid.New().String(),
+		UserID:   reading.UserID,
+		Severity: model.AlertSeverityCritical,
+		Reason:   fmt.Sprintf("hypertensive crisis: %d/%d mmHg", reading.Systolic, reading.Diastolic),
+		Source:   alertSourceRule,
+	}
+
+	if err := s.repo.Create(ctx, &alert); err != nil {
+		return nil, fmt.Errorf("failed to save alert: %w", err)
+	}
+
+	s.logger.Warn("hypertensive crisis alert raised",
+		zap.String("alert_id", alert.ID),
+		zap.String("user_id", alert.UserID),
+		zap.String("reading_id", reading.ID),
+		zap.Int("systolic", reading.Systolic),
+		zap.Int("diastolic", reading.Diastolic),
+	)
+	return &alert, nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// bpReadingsAt returns readings of systolic/diastolic pairs measured daysAgo days before now
+func bpReadingsAt(now time.Time, daysAgo int, pairs ...[2]int) []model.BloodPressureReading {
+	readings := make([]model.BloodPressureReading, len(pairs))
+	for i, pair := range pairs {
+		readings[i] = model.BloodPressureReading{
+			Systolic:   pair[0],
+			Diastolic:  pair[1],
+			MeasuredAt: now.AddDate(0, 0, -daysAgo),
+		}
+	}
+	return readings
+}
+
+func TestComputeBloodPressureTrend(t *testing.T) {
+	now := time.Date(2026, 5, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("rising pressure", func(t *testing.T) {
+		readings := append(bpReadingsAt(now, 10, [2]int{120, 78}, [2]int{124, 80}), bpReadingsAt(now, 2, [2]int{135, 85})...)
+
+		trend := computeBloodPressureTrend(readings, now)
+		require.NotNil(t, trend.SystolicDelta)
+		assert.InDelta(t, 135, *trend.AverageSystolic, 0.001)
+		assert.InDelta(t, 122, *trend.PreviousAverageSystolic, 0.001)
+		assert.InDelta(t, 13, *trend.SystolicDelta, 0.001)
+		assert.InDelta(t, 6, *trend.DiastolicDelta, 0.001)
+		assert.Equal(t, TrendUp, trend.Direction)
+	})
+
+	t.Run("falling pressure", func(t *testing.T) {
+		readings := append(bpReadingsAt(now, 8, [2]int{150, 95}), bpReadingsAt(now, 1, [2]int{140, 90})...)
+
+		trend := computeBloodPressureTrend(readings, now)
+		assert.Equal(t, TrendDown, trend.Direction)
+	})
+
+	t.Run("changes within 2 mmHg are flat", func(t *testing.T) {
+		readings := append(bpReadingsAt(now, 8, [2]int{128, 82}), bpReadingsAt(now, 1, [2]int{130, 70})...)
+
+		trend := computeBloodPressureTrend(readings, now)
+		assert.Equal(t, TrendFlat, trend.Direction)
+		assert.InDelta(t, -12, *trend.DiastolicDelta, 0.001, "the direction follows the systolic delta")
+	})
+
+	t.Run("no previous readings", func(t *testing.T) {
+		readings := append(bpReadingsAt(now, 15, [2]int{160, 100}), bpReadingsAt(now, 3, [2]int{130, 80})...)
+
+		trend := computeBloodPressureTrend(readings, now)
+		assert.InDelta(t, 130, *trend.AverageSystolic, 0.001)
+		assert.Nil(t, trend.PreviousAverageSystolic, "readings older than 14 days are left out")
+		assert.Nil(t, trend.SystolicDelta)
+		assert.Empty(t, trend.Direction)
+	})
+}
+
+func TestAlertService_EvaluateBloodPressure_IgnoresNonCrisisReadings(t *testing.T) {
+	svc := NewAlertService(nil, nil, zap.NewNop())
+
+	for _, pair := range [][2]int{{120, 80}, {180, 120}, {170, 110}} {
+		alert, err := svc.EvaluateBloodPressure(context.Background(), &model.BloodPressureReading{Systolic: pair[0], Diastolic: pair[1]})
+		require.NoError(t, err)
+		assert.Nil(t, alert, "%d/%d is not a crisis", pair[0], pair[1])
+	}
+}
+
+func TestLogBloodPressure_RaisesCrisisAlert(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	logger := zap.NewNop()
+	userID := uuid.New().String()
+
+	alertRepo := repository.NewAlertRepository(db, logger)
+	svc := NewHealthDataService(repository.NewHealthDataRepository(db, logger), logger)
+	svc.SetAlertService(NewAlertService(alertRepo, nil, logger))
+
+	require.NoError(t, svc.LogBloodPressure(ctx, userID, &model.BloodPressureReading{Systolic: 135, Diastolic: 85, Pulse: 70, MeasuredAt: time.Now()}))
+	require.NoError(t, svc.LogBloodPressure(ctx, userID, &model.BloodPressureReading{Systolic: 190, Diastolic: 100, Pulse: 90, MeasuredAt: time.Now()}))
+
+	alerts, err := alertRepo.FindByUserID(ctx, userID, false)
+	require.NoError(t, err)
+	require.Len(t, alerts, 1, "only the crisis reading raises an alert")
+	assert.Equal(t, model.AlertSeverityCritical, alerts[0].Severity)
+	assert.Equal(t, "hypertensive crisis: 190/100 mmHg", alerts[0].Reason)
+	assert.Nil(t, alerts[0].CheckInID)
+}
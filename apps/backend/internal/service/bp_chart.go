@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
+	"go.uber.org/zap"
+)
+
+// Granularities of blood pressure chart data
+const (
+	ChartGranularityDaily  = "daily"
+	ChartGranularityWeekly = "weekly"
+)
+
+// maxBloodPressureChartDays bounds the date range of a chart request, which returns one
+// point per day or week including empty ones
+const maxBloodPressureChartDays = 731
+
+var (
+	// ErrInvalidGranularity is returned for a chart granularity other than daily or weekly
+	ErrInvalidGranularity = errors.New("granularity must be daily or weekly")
+
+	// ErrInvalidChartRange is returned when a chart date range is reversed or too long
+	ErrInvalidChartRange = errors.New("invalid chart date range")
+)
+
+// BloodPressureChartPoint is the average blood pressure of one day or week. Periods
+// without readings have a zero ReadingCount and zero averages.
+type BloodPressureChartPoint struct {
+	Date         string  `json:"date"`
+	AvgSystolic  float64 `json:"avg_systolic"`
+	AvgDiastolic float64 `json:"avg_diastolic"`
+	AvgPulse     float64 `json:"avg_pulse"`
+	ReadingCount int     `json:"reading_count"`
+}
+
+// GetBloodPressureChart returns one chart point per day or week from startDate through
+// endDate, both inclusive. Weekly points start on Monday and are dated by that Monday.
+func (s *HealthDataService) GetBloodPressureChart(ctx context.Context, userID string, startDate, endDate time.Time, granularity string) ([]BloodPressureChartPoint, error) {
+	ctx, span := telemetry.StartSpan(ctx, "HealthDataService.GetBloodPressureChart")
+	defer span.End()
+
+	if granularity != ChartGranularityDaily && granularity != ChartGranularityWeekly {
+		return nil, ErrInvalidGranularity
+	}
+
+	startDate = truncateToDay(startDate)
+	endDate = truncateToDay(endDate)
+	if endDate.Before(startDate) {
+		return nil, fmt.Errorf("%w: end_date is before start_date", ErrInvalidChartRange)
+	}
+	if days := int(endDate.Sub(startDate).Hours()/24) + 1; days > maxBloodPressureChartDays {
+		return nil, fmt.Errorf("%w: at most %d days can be charted", ErrInvalidChartRange, maxBloodPressureChartDays)
+	}
+
+	queryEnd := endDate.AddDate(0, 0, 1)
+	var buckets []repository.BloodPressureBucket
+	var err error
+	if granularity == ChartGranularityWeekly {
+		startDate = startOfWeek(startDate)
+		buckets, err = s.repo.GetBloodPressureAggregatedByWeek(ctx, userID, startDate, queryEnd)
+	} else {
+		buckets, err = s.repo.GetBloodPressureAggregatedByDay(ctx, userID, startDate, queryEnd)
+	}
+	if err != nil {
+		s.logger.Error("failed to get blood pressure chart data",
+			zap.Error(err),
+			zap.String("user_id", userID),
+			zap.String("granularity", granularity),
+		)
+		return nil, err
+	}
+
+	return fillBloodPressureChart(buckets, startDate, endDate, granularity), nil
+}
+
+// fillBloodPressureChart returns one point per period from start through end, taking the
+// averages from buckets and leaving periods without a bucket empty
+func fillBloodPressureChart(buckets []repository.BloodPressureBucket, start, end time.Time, granularity string) []BloodPressureChartPoint {
+	step := 1
+	if granularity == ChartGranularityWeekly {
+		step = 7
+	}
+
+	byDate := make(map[string]repository.BloodPressureBucket, len(buckets))
+	for _, bucket := range buckets {
+		byDate[bucket.PeriodStart.Format(time.DateOnly)] = bucket
+	}
+
+	points := []BloodPressureChartPoint{}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, step) {
+		date := day.Format(time.DateOnly)
+		point := BloodPressureChartPoint{Date: date}
+		if bucket, ok := byDate[date]; ok {
+			point.AvgSystolic = roundTenth(bucket.AvgSystolic)
+			point.AvgDiastolic = roundTenth(bucket.AvgDiastolic)
+			point.AvgPulse = roundTenth(bucket.AvgPulse)
+			point.ReadingCount = bucket.ReadingCount
+		}
+		points = append(points, point)
+	}
+	return points
+}
+
+// truncateToDay drops the time of day of t, keeping its calendar date
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// startOfWeek returns the Monday of the week containing day
+func startOfWeek(day time.Time) time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// roundTenth rounds v to one decimal place
+func roundTenth(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"go.uber.org/zap"
+)
+
+func chartDate(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := time.Parse(time.DateOnly, s)
+	require.NoError(t, err)
+	return d
+}
+
+func TestFillBloodPressureChart_Daily(t *testing.T) {
+	buckets := []repository.BloodPressureBucket{
+		{PeriodStart: chartDate(t, "2024-01-01"), AvgSystolic: 122.33, AvgDiastolic: 81.36, AvgPulse: 70.25, ReadingCount: 3},
+		{PeriodStart: chartDate(t, "2024-01-03"), AvgSystolic: 130, AvgDiastolic: 85, ReadingCount: 1},
+	}
+
+	points := fillBloodPressureChart(buckets, chartDate(t, "2024-01-01"), chartDate(t, "2024-01-04"), ChartGranularityDaily)
+
+	assert.Equal(t, []BloodPressureChartPoint{
+		{Date: "2024-01-01", AvgSystolic: 122.3, AvgDiastolic: 81.4, AvgPulse: 70.3, ReadingCount: 3},
+		{Date: "2024-01-02"},
+		{Date: "2024-01-03", AvgSystolic: 130, AvgDiastolic: 85, ReadingCount: 1},
+		{Date: "2024-01-04"},
+	}, points)
+}
+
+func TestFillBloodPressureChart_Weekly(t *testing.T) {
+	// 2024-01-01 is a Monday
+	buckets := []repository.BloodPressureBucket{
+		{PeriodStart: chartDate(t, "2024-01-08"), AvgSystolic: 118, AvgDiastolic: 76, AvgPulse: 64, ReadingCount: 5},
+	}
+
+	points := fillBloodPressureChart(buckets, chartDate(t, "2024-01-01"), chartDate(t, "2024-01-20"), ChartGranularityWeekly)
+
+	require.Len(t, points, 3)
+	assert.Equal(t, "2024-01-01", points[0].Date)
+	assert.Zero(t, points[0].ReadingCount)
+	assert.Equal(t, BloodPressureChartPoint{Date: "2024-01-08", AvgSystolic: 118, AvgDiastolic: 76, AvgPulse: 64, ReadingCount: 5}, points[1])
+	assert.Equal(t, "2024-01-15", points[2].Date)
+}
+
+func TestStartOfWeek(t *testing.T) {
+	for day, want := range map[string]string{
+		"2024-01-01": "2024-01-01", // Monday
+		"2024-01-03": "2024-01-01",
+		"2024-01-07": "2024-01-01", // Sunday
+		"2024-01-08": "2024-01-08",
+	} {
+		assert.Equal(t, want, startOfWeek(chartDate(t, day)).Format(time.DateOnly), day)
+	}
+}
+
+func TestGetBloodPressureChart_ValidatesParameters(t *testing.T) {
+	svc := NewHealthDataService(nil, zap.NewNop())
+	ctx := context.Background()
+
+	_, err := svc.GetBloodPressureChart(ctx, "user-1", chartDate(t, "2024-01-01"), chartDate(t, "2024-01-31"), "hourly")
+	assert.ErrorIs(t, err, ErrInvalidGranularity)
+
+	_, err = svc.GetBloodPressureChart(ctx, "user-1", chartDate(t, "2024-02-01"), chartDate(t, "2024-01-01"), ChartGranularityDaily)
+	assert.ErrorIs(t, err, ErrInvalidChartRange)
+
+	_, err = svc.GetBloodPressureChart(ctx, "user-1", chartDate(t, "2020-01-01"), chartDate(t, "2024-01-01"), ChartGranularityDaily)
+	assert.ErrorIs(t, err, ErrInvalidChartRange)
+}
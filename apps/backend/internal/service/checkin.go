@@ -3,13 +3,18 @@ package service
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/metrics"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 	"go.uber.org/zap"
 )
@@ -21,10 +26,37 @@ type CheckInService struct {
 	speechClient   *azure.SpeechServiceClient
 	blobClient     *azure.BlobStorageClient
 	dataExtractor  *DataExtractor
+	followUps      *FollowUpGenerator
 	logger         *zap.Logger
 	sessionTimeout time.Duration
+
+	adaptiveFollowUps bool
+	maxFollowUps      int
+
+	audioCache        *AudioCache
+	audioCacheVersion string
+	voice             string
+	ttsBreaker        *azure.CircuitBreaker
+
+	alerts   *AlertService
+	usage    UsageRecorder
+	reporter telemetry.ErrorReporter
+
+	auditLogger *audit.Logger
+	completion  []CheckInCompletionListener
+	consents    ConsentChecker
+
+	questionSets QuestionSetSource
+
+	medications      MedicationListSource
+	extractionIssues extractionIssueCounter
+
+	events *SessionEventBus
 }
 
+// questionLanguage is the language check-in questions are asked in
+const questionLanguage = "hu-HU"
+
 // NewCheckInService creates a new CheckInService
 func NewCheckInService(
 	repo *repository.CheckInRepository,
@@ -39,26 +71,166 @@ func NewCheckInService(
 		speechClient:   speechClient,
 		blobClient:     blobClient,
 		dataExtractor:  NewDataExtractor(aiClient, logger),
+		followUps:      NewFollowUpGenerator(aiClient, logger),
 		logger:         logger,
 		sessionTimeout: 30 * time.Minute,
+		maxFollowUps:   2,
+		reporter:       telemetry.NopReporter{},
+		ttsBreaker:     azure.NewCircuitBreaker("azure-speech-tts", azure.DefaultBreakerThreshold, azure.DefaultBreakerCoolDown, logger),
+	}
+}
+
+// SetFollowUpPolicy configures adaptive follow-up questions.
+// enabled is the default used when a request does not specify the mode.
+func (s *CheckInService) SetFollowUpPolicy(enabled bool, maxPerSession int) {
+	s.adaptiveFollowUps = enabled
+	s.maxFollowUps = maxPerSession
+}
+
+// SetAudioCache configures the in-memory question audio cache consulted before blob storage.
+// Changing version invalidates both the in-memory and the blob cache entries.
+func (s *CheckInService) SetAudioCache(cache *AudioCache, version string) {
+	s.audioCache = cache
+	s.audioCacheVersion = version
+}
+
+// SetVoice selects the text-to-speech voice for questions. The voice is part of
+// the question audio cache key so changing it does not serve stale audio.
+func (s *CheckInService) SetVoice(voice string) {
+	s.voice = voice
+}
+
+// Voice returns the text-to-speech voice used for questions
+func (s *CheckInService) Voice() string {
+	if s.voice == "" {
+		return azure.DefaultVoice
+	}
+	return s.voice
+}
+
+// SetExtractionConfidenceThreshold sets the extraction confidence below which check-ins are flagged
+func (s *CheckInService) SetExtractionConfidenceThreshold(threshold float64) {
+	s.dataExtractor.SetConfidenceThreshold(threshold)
+}
+
+// SetMedicationSource enables plausibility rules that compare extracted data with the user's medications
+func (s *CheckInService) SetMedicationSource(medications MedicationListSource) {
+	s.medications = medications
+}
+
+// ExtractionIssueCounts returns the plausibility flags raised per rule since the service started
+func (s *CheckInService) ExtractionIssueCounts() map[string]int64 {
+	return s.extractionIssues.Snapshot()
+}
+
+// SetAlertService enables emergency symptom triage of completed check-ins
+func (s *CheckInService) SetAlertService(alerts *AlertService) {
+	s.alerts = alerts
+}
+
+// SetUsageRecorder enables per-user usage accounting of saved check-ins
+func (s *CheckInService) SetUsageRecorder(usage UsageRecorder) {
+	s.usage = usage
+}
+
+// SetErrorReporter exports upstream failures and extraction fallbacks to a telemetry sink
+func (s *CheckInService) SetErrorReporter(reporter telemetry.ErrorReporter) {
+	s.reporter = reporter
+}
+
+// SetAuditLogger records re-extractions of stored transcripts and corrections of
+// check-ins in the audit log
+func (s *CheckInService) SetAuditLogger(auditLogger *audit.Logger) {
+	s.auditLogger = auditLogger
+}
+
+// SetEventBus publishes saved messages, asked questions and session endings to the
+// session's live event streams
+func (s *CheckInService) SetEventBus(events *SessionEventBus) {
+	s.events = events
+}
+
+// CheckInCompletionListener is notified after a completed session's check-in is saved.
+// It must not block the request.
+type CheckInCompletionListener interface {
+	CheckInCompleted(ctx context.Context, checkIn *model.HealthCheckIn)
+}
+
+// AddCompletionListener registers a listener notified of completed check-ins
+func (s *CheckInService) AddCompletionListener(listener CheckInCompletionListener) {
+	s.completion = append(s.completion, listener)
+}
+
+// ConsentChecker reports whether a user currently grants a consent
+type ConsentChecker interface {
+	HasConsent(ctx context.Context, userID string, consentType model.ConsentType) (bool, error)
+}
+
+// SetConsentChecker makes starting a session require the user's voice recording consent
+func (s *CheckInService) SetConsentChecker(consents ConsentChecker) {
+	s.consents = consents
+}
+
+// SetQuestionSets makes sessions ask the question set assigned to their user instead
+// of the built-in set
+func (s *CheckInService) SetQuestionSets(questionSets QuestionSetSource) {
+	s.questionSets = questionSets
+}
+
+// questionFlowForUser returns the flow of the question set assigned to a user
+func (s *CheckInService) questionFlowForUser(ctx context.Context, userID string) (*QuestionFlow, error) {
+	if s.questionSets == nil {
+		return NewQuestionFlow(), nil
+	}
+
+	set, err := s.questionSets.GetForUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question set: %w", err)
+	}
+	return NewQuestionFlowFromSet(set), nil
+}
+
+// questionFlowForSession returns the flow of the question set a session started with
+func (s *CheckInService) questionFlowForSession(ctx context.Context, session *model.Session) (*QuestionFlow, error) {
+	if s.questionSets == nil || session.QuestionSetID == nil {
+		return NewQuestionFlow(), nil
+	}
+
+	set, err := s.questionSets.GetQuestionSet(ctx, *session.QuestionSetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question set: %w", err)
 	}
+	return NewQuestionFlowFromSet(set), nil
+}
+
+// ResponseOptions holds per-request options for processing a response
+type ResponseOptions struct {
+	// AdaptiveFollowUps overrides the service default when set
+	AdaptiveFollowUps *bool
 }
 
-// SessionWithAudio represents a session with audio for the first question
+// SessionWithAudio represents a session with audio for the first question.
+// AudioAvailable is false and AudioError says why when the question has no audio.
 type SessionWithAudio struct {
-	Session       *model.Session
-	QuestionText  string
-	QuestionAudio []byte
-	QuestionID    string
+	Session        *model.Session
+	QuestionText   string
+	QuestionAudio  []byte
+	QuestionID     string
+	AudioAvailable bool
+	AudioError     string
 }
 
-// ConversationStateWithAudio represents the conversation state with audio
+// ConversationStateWithAudio represents the conversation state with audio.
+// AudioAvailable is false and AudioError says why when the question has no audio.
 type ConversationStateWithAudio struct {
-	SessionID     string
-	QuestionText  string
-	QuestionAudio []byte
-	QuestionID    string
-	IsComplete    bool
+	SessionID      string
+	QuestionText   string
+	QuestionAudio  []byte
+	QuestionID     string
+	IsFollowUp     bool
+	IsComplete     bool
+	AudioAvailable bool
+	AudioError     string
 }
 
 // SessionStatus represents the status of a session
@@ -75,8 +247,27 @@ type SessionStatus struct {
 
 // StartSession creates a new check-in session and returns the first question with audio
 func (s *CheckInService) StartSession(ctx context.Context, userID string) (*SessionWithAudio, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CheckInService.StartSession")
+	defer span.End()
+
 	s.logger.Info("starting new check-in session", zap.String("user_id", userID))
 
+	// Check-ins record the user's voice, which needs their consent
+	if s.consents != nil {
+		granted, err := s.consents.HasConsent(ctx, userID, model.ConsentTypeVoiceRecording)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check voice recording consent: %w", err)
+		}
+		if !granted {
+			return nil, fmt.Errorf("%w: %s", ErrConsentRequired, model.ConsentTypeVoiceRecording)
+		}
+	}
+
+	questionFlow, err := s.questionFlowForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create new session
 	session := &model.Session{
 		ID:        uuid.New().String(),
@@ -84,14 +275,22 @@ func (s *CheckInService) StartSession(ctx context.Context, userID string) (*Sess
 		StartedAt: time.Now(),
 		Status:    model.SessionStatusActive,
 	}
+	if questionFlow.setID != "" {
+		session.QuestionSetID = &questionFlow.setID
+	}
 
-	// Save session to database
-	if err := s.repo.CreateSession(ctx, session); err != nil {
+	// Save session to database; a session started on another device, even concurrently,
+	// is resumed rather than duplicated
+	active, err := s.repo.CreateSessionUnlessActive(ctx, session)
+	if err != nil {
 		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
+	if active != nil {
+		return nil, &ActiveSessionExistsError{SessionID: active.ID}
+	}
+	metrics.Default.RecordCheckInSessionStarted()
 
 	// Get first question
-	questionFlow := NewQuestionFlow()
 	firstQuestion := questionFlow.GetNextQuestion()
 	if firstQuestion == nil {
 		return nil, fmt.Errorf("no questions available")
@@ -107,15 +306,12 @@ func (s *CheckInService) StartSession(ctx context.Context, userID string) (*Sess
 	}
 	if err := s.repo.SaveConversationMessage(ctx, assistantMsg); err != nil {
 		s.logger.Warn("failed to save assistant message", zap.Error(err))
+	} else {
+		s.publishQuestion(assistantMsg, firstQuestion.ID)
 	}
 
-	// Generate audio for first question
-	audioData, err := s.GetQuestionAudio(ctx, session.ID, firstQuestion.ID)
-	if err != nil {
-		s.logger.Warn("failed to generate question audio", zap.Error(err))
-		// Continue without audio
-		audioData = nil
-	}
+	// Generate audio for first question, continuing without audio on failure
+	audioData, audioAvailable, audioError := s.questionAudio(ctx, session.ID, firstQuestion.ID)
 
 	s.logger.Info("check-in session started successfully",
 		zap.String("session_id", session.ID),
@@ -123,19 +319,26 @@ func (s *CheckInService) StartSession(ctx context.Context, userID string) (*Sess
 	)
 
 	return &SessionWithAudio{
-		Session:       session,
-		QuestionText:  firstQuestion.TextHU,
-		QuestionAudio: audioData,
-		QuestionID:    firstQuestion.ID,
+		Session:        session,
+		QuestionText:   firstQuestion.TextHU,
+		QuestionAudio:  audioData,
+		QuestionID:     firstQuestion.ID,
+		AudioAvailable: audioAvailable,
+		AudioError:     audioError,
 	}, nil
 }
 
 // StreamAudioToSpeech performs real-time transcription of audio stream
 func (s *CheckInService) StreamAudioToSpeech(ctx context.Context, sessionID string, audioStream io.Reader) (string, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CheckInService.StreamAudioToSpeech")
+	defer span.End()
+
 	s.logger.Info("starting audio transcription", zap.String("session_id", sessionID))
 
 	// Verify session exists and is active
+	stopDBRead := telemetry.StartStage(ctx, telemetry.StageDBRead)
 	session, err := s.repo.GetSession(ctx, sessionID)
+	stopDBRead()
 	if err != nil {
 		return "", fmt.Errorf("failed to get session: %w", err)
 	}
@@ -145,9 +348,15 @@ func (s *CheckInService) StreamAudioToSpeech(ctx context.Context, sessionID stri
 	}
 
 	// Stream audio to Azure Speech Service for transcription
+	stopTranscription := telemetry.StartStage(ctx, telemetry.StageTranscription)
 	transcription, err := s.speechClient.StreamAudioToText(ctx, audioStream)
+	stopTranscription()
 	if err != nil {
 		s.logger.Error("speech-to-text failed", zap.String("session_id", sessionID), zap.Error(err))
+		var tooLong *azure.AnswerTooLongError
+		if !errors.As(err, &tooLong) && !isRejectedAudio(err) {
+			telemetry.ReportError(ctx, s.reporter, telemetry.KindUpstreamFailure, "speech.transcribe", err)
+		}
 		return "", fmt.Errorf("transcription failed: %w", err)
 	}
 
@@ -159,15 +368,34 @@ func (s *CheckInService) StreamAudioToSpeech(ctx context.Context, sessionID stri
 	return transcription, nil
 }
 
+// isRejectedAudio reports whether the speech client refused a recording as too short
+// or silent, or as not being audio it can transcribe
+func isRejectedAudio(err error) bool {
+	return errors.Is(err, azure.ErrAudioTooShort) ||
+		errors.Is(err, azure.ErrAudioSilent) ||
+		errors.Is(err, azure.ErrInvalidAudioFormat)
+}
+
 // ProcessResponse processes a user response and returns the next question
 func (s *CheckInService) ProcessResponse(ctx context.Context, sessionID string, response string) (*ConversationStateWithAudio, error) {
+	return s.ProcessResponseWithOptions(ctx, sessionID, response, ResponseOptions{})
+}
+
+// ProcessResponseWithOptions processes a user response and returns the next question,
+// optionally asking an AI-generated follow-up question first
+func (s *CheckInService) ProcessResponseWithOptions(ctx context.Context, sessionID string, response string, opts ResponseOptions) (*ConversationStateWithAudio, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CheckInService.ProcessResponseWithOptions")
+	defer span.End()
+
 	s.logger.Info("processing user response",
 		zap.String("session_id", sessionID),
 		zap.Int("response_length", len(response)),
 	)
 
 	// Verify session exists and is active
+	stopDBRead := telemetry.StartStage(ctx, telemetry.StageDBRead)
 	session, err := s.repo.GetSession(ctx, sessionID)
+	stopDBRead()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get session: %w", err)
 	}
@@ -177,15 +405,8 @@ func (s *CheckInService) ProcessResponse(ctx context.Context, sessionID string,
 	}
 
 	// Check for session timeout
-	if time.Since(session.StartedAt) > s.sessionTimeout {
-		s.logger.Warn("session timeout", zap.String("session_id", sessionID))
-		session.Status = model.SessionStatusExpired
-		now := time.Now()
-		session.ExpiredAt = &now
-		if err := s.repo.UpdateSession(ctx, session); err != nil {
-			s.logger.Error("failed to update expired session", zap.Error(err))
-		}
-		return nil, fmt.Errorf("session has expired")
+	if err := s.expireIfTimedOut(ctx, session); err != nil {
+		return nil, err
 	}
 
 	// Validate response is not empty
@@ -201,32 +422,54 @@ func (s *CheckInService) ProcessResponse(ctx context.Context, sessionID string,
 		Content:   response,
 		CreatedAt: time.Now(),
 	}
-	if err := s.repo.SaveConversationMessage(ctx, userMsg); err != nil {
+	stopDBWrite := telemetry.StartStage(ctx, telemetry.StageDBWrite)
+	err = s.repo.SaveConversationMessage(ctx, userMsg)
+	stopDBWrite()
+	if err != nil {
 		return nil, fmt.Errorf("failed to save user message: %w", err)
 	}
+	s.publishEvent(SessionEvent{
+		Type:       SessionEventMessageSaved,
+		SessionID:  sessionID,
+		MessageID:  userMsg.ID,
+		Text:       userMsg.Content,
+		OccurredAt: userMsg.CreatedAt,
+	})
 
 	// Get conversation history to determine current question
+	stopDBRead = telemetry.StartStage(ctx, telemetry.StageDBRead)
 	messages, err := s.repo.GetConversationMessages(ctx, sessionID)
+	stopDBRead()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get conversation messages: %w", err)
 	}
 
-	// Count how many questions have been asked (assistant messages)
-	questionCount := 0
-	for _, msg := range messages {
-		if msg.Role == model.MessageRoleAssistant {
-			questionCount++
+	// Count how many follow-ups have been asked
+	_, followUpCount := countQuestions(messages)
+
+	// Ask a clarifying follow-up before moving on if the answer signals a problem
+	adaptive := s.adaptiveFollowUps
+	if opts.AdaptiveFollowUps != nil {
+		adaptive = *opts.AdaptiveFollowUps
+	}
+	if adaptive {
+		if state := s.askFollowUp(ctx, sessionID, messages, followUpCount); state != nil {
+			return state, nil
 		}
 	}
 
 	// Get next question
-	questionFlow := NewQuestionFlow()
-	// Advance to current position
-	for i := 0; i < questionCount; i++ {
-		questionFlow.GetNextQuestion()
+	stopSelection := telemetry.StartStage(ctx, telemetry.StageQuestionSelection)
+	questionFlow, err := s.questionFlowForSession(ctx, session)
+	if err != nil {
+		stopSelection()
+		return nil, err
 	}
+	// Advance to current position, recording the answers later questions branch on
+	questionFlow.Replay(messages)
 
 	nextQuestion := questionFlow.GetNextQuestion()
+	stopSelection()
 	if nextQuestion == nil || questionFlow.IsComplete() {
 		// All questions answered
 		s.logger.Info("all questions answered", zap.String("session_id", sessionID))
@@ -244,16 +487,17 @@ func (s *CheckInService) ProcessResponse(ctx context.Context, sessionID string,
 		Content:   nextQuestion.TextHU,
 		CreatedAt: time.Now(),
 	}
-	if err := s.repo.SaveConversationMessage(ctx, assistantMsg); err != nil {
+	stopDBWrite = telemetry.StartStage(ctx, telemetry.StageDBWrite)
+	err = s.repo.SaveConversationMessage(ctx, assistantMsg)
+	stopDBWrite()
+	if err != nil {
 		s.logger.Warn("failed to save assistant message", zap.Error(err))
+	} else {
+		s.publishQuestion(assistantMsg, nextQuestion.ID)
 	}
 
-	// Generate audio for next question
-	audioData, err := s.GetQuestionAudio(ctx, sessionID, nextQuestion.ID)
-	if err != nil {
-		s.logger.Warn("failed to generate question audio", zap.Error(err))
-		audioData = nil
-	}
+	// Generate audio for next question, continuing without audio on failure
+	audioData, audioAvailable, audioError := s.questionAudio(ctx, sessionID, nextQuestion.ID)
 
 	s.logger.Info("response processed successfully",
 		zap.String("session_id", sessionID),
@@ -261,46 +505,182 @@ func (s *CheckInService) ProcessResponse(ctx context.Context, sessionID string,
 	)
 
 	return &ConversationStateWithAudio{
-		SessionID:     sessionID,
-		QuestionText:  nextQuestion.TextHU,
-		QuestionAudio: audioData,
-		QuestionID:    nextQuestion.ID,
-		IsComplete:    false,
+		SessionID:      sessionID,
+		QuestionText:   nextQuestion.TextHU,
+		QuestionAudio:  audioData,
+		QuestionID:     nextQuestion.ID,
+		IsComplete:     false,
+		AudioAvailable: audioAvailable,
+		AudioError:     audioError,
 	}, nil
 }
 
+// askFollowUp asks the AI whether the latest answer needs clarification and, if so,
+// saves and returns a follow-up question. It returns nil when the flow should continue.
+func (s *CheckInService) askFollowUp(ctx context.Context, sessionID string, messages []model.Message, followUpCount int) *ConversationStateWithAudio {
+	if followUpCount >= s.maxFollowUps {
+		return nil
+	}
+
+	// Only one follow-up per scripted question
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == model.MessageRoleAssistant {
+			if messages[i].IsFollowUp {
+				return nil
+			}
+			break
+		}
+	}
+
+	var conversationHistory []ConversationMessage
+	for _, msg := range messages {
+		conversationHistory = append(conversationHistory, ConversationMessage{
+			Role:    string(msg.Role),
+			Content: msg.Content,
+		})
+	}
+
+	stopFollowUp := telemetry.StartStage(ctx, telemetry.StageFollowUp)
+	decision, err := s.followUps.Suggest(ctx, conversationHistory)
+	stopFollowUp()
+	if err != nil {
+		s.logger.Warn("follow-up decision failed, continuing with scripted questions",
+			zap.String("session_id", sessionID),
+			zap.Error(err),
+		)
+		telemetry.ReportError(ctx, s.reporter, telemetry.KindUpstreamFailure, "openai.follow_up", err)
+		return nil
+	}
+	if !decision.NeedsFollowUp {
+		return nil
+	}
+
+	followUpMsg := &model.Message{
+		ID:         uuid.New().String(),
+		SessionID:  sessionID,
+		Role:       model.MessageRoleAssistant,
+		Content:    decision.Question,
+		IsFollowUp: true,
+		CreatedAt:  time.Now(),
+	}
+	stopDBWrite := telemetry.StartStage(ctx, telemetry.StageDBWrite)
+	err = s.repo.SaveConversationMessage(ctx, followUpMsg)
+	stopDBWrite()
+	if err != nil {
+		s.logger.Warn("failed to save follow-up message", zap.Error(err))
+		return nil
+	}
+	s.publishQuestion(followUpMsg, followUpQuestionID(followUpMsg.ID))
+
+	stopTTS := telemetry.StartStage(ctx, telemetry.StageTTS)
+	audioData, err := s.textToSpeech(ctx, decision.Question)
+	stopTTS()
+	audioAvailable, audioError := audioStatus(err)
+	if err != nil {
+		s.logger.Warn("failed to generate follow-up audio", zap.Error(err))
+		if !errors.Is(err, azure.ErrCircuitOpen) {
+			telemetry.ReportError(ctx, s.reporter, telemetry.KindUpstreamFailure, "speech.synthesize", err)
+		}
+		audioData = nil
+	}
+
+	s.logger.Info("follow-up question asked",
+		zap.String("session_id", sessionID),
+		zap.String("message_id", followUpMsg.ID),
+		zap.String("reason", decision.Reason),
+		zap.Int("follow_up_count", followUpCount+1),
+	)
+
+	return &ConversationStateWithAudio{
+		SessionID:      sessionID,
+		QuestionText:   decision.Question,
+		QuestionAudio:  audioData,
+		QuestionID:     followUpQuestionID(followUpMsg.ID),
+		IsFollowUp:     true,
+		IsComplete:     false,
+		AudioAvailable: audioAvailable,
+		AudioError:     audioError,
+	}
+}
+
 // GetQuestionAudio generates or retrieves cached audio for a question
 func (s *CheckInService) GetQuestionAudio(ctx context.Context, sessionID string, questionID string) ([]byte, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CheckInService.GetQuestionAudio")
+	defer span.End()
+
 	s.logger.Info("getting question audio",
 		zap.String("session_id", sessionID),
 		zap.String("question_id", questionID),
 	)
 
-	// Get question text
+	// Follow-up questions are generated per session and are not cached
+	if isFollowUpQuestionID(questionID) {
+		return s.getFollowUpAudio(ctx, sessionID, questionID)
+	}
+
+	// Get question text from the session's question set
 	questionFlow := NewQuestionFlow()
+	if s.questionSets != nil {
+		session, err := s.repo.GetSession(ctx, sessionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get session: %w", err)
+		}
+		if questionFlow, err = s.questionFlowForSession(ctx, session); err != nil {
+			return nil, err
+		}
+	}
 	question := questionFlow.GetQuestionByID(questionID)
 	if question == nil {
 		return nil, fmt.Errorf("question not found: %s", questionID)
 	}
 
+	cacheKey := questionAudioCacheKey(s.audioCacheVersion, s.Voice(), questionFlow.audioID(questionID))
+
+	// Check the in-memory cache before going to blob storage
+	if s.audioCache != nil {
+		stopCache := telemetry.StartStage(ctx, telemetry.StageAudioCache)
+		audioData, ok := s.audioCache.Get(cacheKey)
+		stopCache()
+		if ok {
+			s.logger.Debug("question audio retrieved from memory cache",
+				zap.String("question_id", questionID),
+				zap.Int("audio_size", len(audioData)),
+			)
+			return audioData, nil
+		}
+	}
+
 	// Check if audio is cached in blob storage
-	cacheKey := fmt.Sprintf("question-audio/hu-HU/%s.mp3", questionID)
+	stopBlob := telemetry.StartStage(ctx, telemetry.StageBlobCache)
 	audioData, err := s.blobClient.DownloadAudio(ctx, cacheKey)
+	stopBlob()
 	if err == nil {
 		s.logger.Info("question audio retrieved from cache",
 			zap.String("question_id", questionID),
 			zap.Int("audio_size", len(audioData)),
 		)
+		if s.audioCache != nil {
+			s.audioCache.Add(cacheKey, audioData)
+		}
 		return audioData, nil
 	}
 
 	// Generate audio using Text-to-Speech
 	s.logger.Info("generating question audio", zap.String("question_id", questionID))
-	audioData, err = s.speechClient.TextToSpeech(ctx, question.TextHU, "hu-HU")
+	stopTTS := telemetry.StartStage(ctx, telemetry.StageTTS)
+	audioData, err = s.textToSpeech(ctx, question.TextHU)
+	stopTTS()
 	if err != nil {
+		if !errors.Is(err, azure.ErrCircuitOpen) {
+			telemetry.ReportError(ctx, s.reporter, telemetry.KindUpstreamFailure, "speech.synthesize", err)
+		}
 		return nil, fmt.Errorf("TTS failed: %w", err)
 	}
 
+	if s.audioCache != nil {
+		s.audioCache.Add(cacheKey, audioData)
+	}
+
 	// Cache audio for future use (async)
 	go func() {
 		cacheCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -319,20 +699,108 @@ func (s *CheckInService) GetQuestionAudio(ctx context.Context, sessionID string,
 	return audioData, nil
 }
 
+// AudioCacheStats returns the in-memory audio cache metrics, or nil if the cache is disabled
+func (s *CheckInService) AudioCacheStats() *AudioCacheStats {
+	if s.audioCache == nil {
+		return nil
+	}
+	stats := s.audioCache.Stats()
+	return &stats
+}
+
+// questionAudioCacheKey builds the cache key for a scripted question's audio.
+// The voice and a non-empty version are part of the key so changing either invalidates cached audio.
+func questionAudioCacheKey(version string, voice string, questionID string) string {
+	if version == "" {
+		return fmt.Sprintf("question-audio/%s/%s/%s.mp3", questionLanguage, voice, questionID)
+	}
+	return fmt.Sprintf("question-audio/%s/%s/%s/%s.mp3", version, questionLanguage, voice, questionID)
+}
+
+// getFollowUpAudio synthesizes audio for a follow-up question stored in the session
+func (s *CheckInService) getFollowUpAudio(ctx context.Context, sessionID string, questionID string) ([]byte, error) {
+	messageID := strings.TrimPrefix(questionID, followUpQuestionIDPrefix)
+
+	messages, err := s.repo.GetConversationMessages(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversation messages: %w", err)
+	}
+
+	for _, msg := range messages {
+		if msg.ID == messageID && msg.IsFollowUp {
+			audioData, err := s.textToSpeech(ctx, msg.Content)
+			if err != nil {
+				return nil, fmt.Errorf("TTS failed: %w", err)
+			}
+			return audioData, nil
+		}
+	}
+
+	return nil, fmt.Errorf("question not found: %s", questionID)
+}
+
+// expireIfTimedOut marks an active session expired once the timeout has elapsed since
+// it was started or last resumed, returning ErrSessionExpired
+func (s *CheckInService) expireIfTimedOut(ctx context.Context, session *model.Session) error {
+	if time.Since(session.ActiveSince()) <= s.sessionTimeout {
+		return nil
+	}
+
+	s.logger.Warn("session timeout", zap.String("session_id", session.ID))
+	session.Status = model.SessionStatusExpired
+	now := time.Now()
+	session.ExpiredAt = &now
+	if err := s.repo.UpdateSession(ctx, session); err != nil {
+		s.logger.Error("failed to update expired session", zap.Error(err))
+	}
+	s.publishEvent(SessionEvent{Type: SessionEventExpired, SessionID: session.ID, OccurredAt: now})
+	return ErrSessionExpired
+}
+
+// countQuestions returns the number of scripted and follow-up questions asked
+func countQuestions(messages []model.Message) (scripted int, followUps int) {
+	for _, msg := range messages {
+		if msg.Role != model.MessageRoleAssistant {
+			continue
+		}
+		if msg.IsFollowUp {
+			followUps++
+		} else {
+			scripted++
+		}
+	}
+	return scripted, followUps
+}
+
 // CompleteSession completes a check-in session and extracts health data
 func (s *CheckInService) CompleteSession(ctx context.Context, sessionID string) (*model.HealthCheckIn, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CheckInService.CompleteSession")
+	defer span.End()
+
 	s.logger.Info("completing check-in session", zap.String("session_id", sessionID))
 
+	// Claim the session so a concurrent request for the same session waits for this one
+	claimed, err := s.repo.ClaimSessionCompletion(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return s.awaitCompletion(ctx, sessionID)
+	}
+
+	completed := false
+	defer func() {
+		if !completed {
+			s.releaseCompletion(ctx, sessionID)
+		}
+	}()
+
 	// Get session
 	session, err := s.repo.GetSession(ctx, sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get session: %w", err)
 	}
 
-	if session.Status != model.SessionStatusActive {
-		return nil, fmt.Errorf("session is not active: %s", session.Status)
-	}
-
 	// Get conversation history
 	messages, err := s.repo.GetConversationMessages(ctx, sessionID)
 	if err != nil {
@@ -351,13 +819,19 @@ func (s *CheckInService) CompleteSession(ctx context.Context, sessionID string)
 	// Extract structured data using AI
 	extractedData, err := s.dataExtractor.Extract(ctx, conversationHistory)
 	if err != nil {
-		s.logger.Error("data extraction failed", zap.String("session_id", sessionID), zap.Error(err))
-
-		// Store raw transcript for manual review
-		var rawTranscript string
-		for _, msg := range messages {
-			rawTranscript += fmt.Sprintf("%s: %s\n", msg.Role, msg.Content)
+		if errors.Is(err, azure.ErrCircuitOpen) {
+			// Azure OpenAI is known to be down; keep the transcript for re-extraction
+			s.logger.Warn("Azure OpenAI unavailable, saving raw transcript without extraction",
+				zap.String("session_id", sessionID),
+			)
+		} else {
+			s.logger.Error("data extraction failed", zap.String("session_id", sessionID), zap.Error(err))
 		}
+		telemetry.ReportError(ctx, s.reporter, telemetry.KindExtractionFallback, "checkin.extract", err)
+
+		// Store raw transcript for manual review and close the session with it, so a
+		// retried completion returns this check-in instead of saving another one
+		rawTranscript := formatRawTranscript(messages)
 
 		checkIn := &model.HealthCheckIn{
 			ID:            uuid.New().String(),
@@ -365,46 +839,54 @@ func (s *CheckInService) CompleteSession(ctx context.Context, sessionID string)
 			SessionID:     &sessionID,
 			CheckInDate:   time.Now(),
 			RawTranscript: &rawTranscript,
+
+			ExtractionPromptVersion: ExtractionPromptVersion,
 		}
 
-		if err := s.repo.SaveHealthCheckIn(ctx, checkIn); err != nil {
+		now := time.Now()
+		if err := s.repo.SaveHealthCheckInAndCompleteSession(ctx, checkIn, now); err != nil {
 			return nil, fmt.Errorf("failed to save health check-in with raw transcript: %w", err)
 		}
+		completed = true
+		s.recordCheckInUsage(ctx, checkIn.UserID)
+		s.publishEvent(SessionEvent{Type: SessionEventCompleted, SessionID: sessionID, CheckInID: checkIn.ID, OccurredAt: now})
 
 		return nil, fmt.Errorf("data extraction failed, raw transcript saved for manual review: %w", err)
 	}
 
 	// Create HealthCheckIn from extracted data
 	checkIn := &model.HealthCheckIn{
-		ID:               uuid.New().String(),
-		UserID:           session.UserID,
-		SessionID:        &sessionID,
-		CheckInDate:      time.Now(),
-		Symptoms:         extractedData.Symptoms,
-		Mood:             &extractedData.Mood,
-		PainLevel:        extractedData.PainLevel,
-		EnergyLevel:      &extractedData.EnergyLevel,
-		SleepQuality:     &extractedData.SleepQuality,
-		MedicationTaken:  &extractedData.MedicationTaken,
-		PhysicalActivity: extractedData.PhysicalActivity,
-		Breakfast:        &extractedData.Meals.Breakfast,
-		Lunch:            &extractedData.Meals.Lunch,
-		Dinner:           &extractedData.Meals.Dinner,
-		GeneralFeeling:   &extractedData.GeneralFeeling,
-		AdditionalNotes:  &extractedData.AdditionalNotes,
-	}
-
-	// Save health check-in
-	if err := s.repo.SaveHealthCheckIn(ctx, checkIn); err != nil {
-		return nil, fmt.Errorf("failed to save health check-in: %w", err)
+		ID:          uuid.New().String(),
+		UserID:      session.UserID,
+		SessionID:   &sessionID,
+		CheckInDate: time.Now(),
 	}
+	applyExtractedData(checkIn, extractedData)
+	checkIn.ExtractionIssues = s.checkPlausibility(ctx, session.UserID, extractedData)
 
-	// Update session status to completed
+	// Save the health check-in and close the session in one transaction
 	now := time.Now()
-	session.Status = model.SessionStatusCompleted
-	session.CompletedAt = &now
-	if err := s.repo.UpdateSession(ctx, session); err != nil {
-		s.logger.Error("failed to update session status", zap.Error(err))
+	if err := s.repo.SaveHealthCheckInAndCompleteSession(ctx, checkIn, now); err != nil {
+		return nil, fmt.Errorf("failed to save health check-in: %w", err)
+	}
+	completed = true
+	s.recordCheckInUsage(ctx, checkIn.UserID)
+
+	// Flag emergency symptoms instead of waiting for the weekly report
+	if s.alerts != nil {
+		if _, err := s.alerts.EvaluateCheckIn(ctx, checkIn, conversationHistory); err != nil {
+			s.logger.Error("symptom triage failed",
+				zap.Error(err),
+				zap.String("session_id", sessionID),
+				zap.String("check_in_id", checkIn.ID),
+			)
+		}
+	}
+
+	s.publishEvent(SessionEvent{Type: SessionEventCompleted, SessionID: sessionID, CheckInID: checkIn.ID, OccurredAt: now})
+
+	for _, listener := range s.completion {
+		listener.CheckInCompleted(ctx, checkIn)
 	}
 
 	// Calculate session duration and message count
@@ -425,8 +907,36 @@ func (s *CheckInService) CompleteSession(ctx context.Context, sessionID string)
 	return checkIn, nil
 }
 
+// applyExtractedData copies the fields extracted from a conversation onto a check-in
+func applyExtractedData(checkIn *model.HealthCheckIn, data *ExtractedData) {
+	checkIn.Symptoms = data.Symptoms
+	checkIn.Mood = &data.Mood
+	checkIn.PainLevel = data.PainLevel
+	checkIn.EnergyLevel = &data.EnergyLevel
+	checkIn.SleepQuality = &data.SleepQuality
+	checkIn.MedicationTaken = &data.MedicationTaken
+	checkIn.PhysicalActivity = data.PhysicalActivity
+	checkIn.Breakfast = &data.Meals.Breakfast
+	checkIn.Lunch = &data.Meals.Lunch
+	checkIn.Dinner = &data.Meals.Dinner
+	checkIn.GeneralFeeling = &data.GeneralFeeling
+	checkIn.AdditionalNotes = &data.AdditionalNotes
+	checkIn.LowConfidence = data.LowConfidence
+	checkIn.ExtractionPromptVersion = ExtractionPromptVersion
+}
+
+// recordCheckInUsage counts a saved check-in towards the user's usage
+func (s *CheckInService) recordCheckInUsage(ctx context.Context, userID string) {
+	if s.usage != nil {
+		s.usage.Record(ctx, userID, repository.UsageDelta{CheckIns: 1})
+	}
+}
+
 // GetSessionStatus returns the current status of a session
 func (s *CheckInService) GetSessionStatus(ctx context.Context, sessionID string) (*SessionStatus, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CheckInService.GetSessionStatus")
+	defer span.End()
+
 	s.logger.Info("getting session status", zap.String("session_id", sessionID))
 
 	// Get session
@@ -441,16 +951,15 @@ func (s *CheckInService) GetSessionStatus(ctx context.Context, sessionID string)
 		return nil, fmt.Errorf("failed to get conversation messages: %w", err)
 	}
 
-	// Count questions asked
-	questionCount := 0
-	for _, msg := range messages {
-		if msg.Role == model.MessageRoleAssistant {
-			questionCount++
-		}
-	}
+	// Count scripted questions asked (follow-ups do not advance the flow)
+	questionCount, _ := countQuestions(messages)
 
-	// Get total questions
-	questionFlow := NewQuestionFlow()
+	// Get total questions on the path the answers so far lead to
+	questionFlow, err := s.questionFlowForSession(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	questionFlow.Replay(messages)
 	totalQuestions := questionFlow.GetTotalQuestions()
 
 	status := &SessionStatus{
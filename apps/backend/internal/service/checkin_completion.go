@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrCompletionInProgress is returned when another request is still completing the
+	// session after the wait for it timed out
+	ErrCompletionInProgress = errors.New("session completion is in progress")
+
+	// ErrConcurrentCompletionFailed is returned to a request that waited for another
+	// request's completion of the same session when that completion failed
+	ErrConcurrentCompletionFailed = errors.New("concurrent session completion failed")
+)
+
+const (
+	// completionWaitTimeout bounds how long a request waits for a concurrent completion
+	// of the same session, which includes an extraction call to Azure OpenAI
+	completionWaitTimeout = 30 * time.Second
+
+	// completionPollInterval is how often a waiting request checks the session
+	completionPollInterval = 200 * time.Millisecond
+
+	// staleCompletionAfter is how long a session may stay completing before it is
+	// considered abandoned by a stopped server
+	staleCompletionAfter = 5 * time.Minute
+
+	// CompletionRecoveryInterval is how often sessions left completing are looked for
+	CompletionRecoveryInterval = time.Minute
+)
+
+// awaitCompletion waits for a concurrent request to finish completing the session and
+// returns the check-in it saved. A session that was already completed returns its
+// check-in right away, so repeated completion requests are idempotent.
+func (s *CheckInService) awaitCompletion(ctx context.Context, sessionID string) (*model.HealthCheckIn, error) {
+	ctx, cancel := context.WithTimeout(ctx, completionWaitTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(completionPollInterval)
+	defer ticker.Stop()
+
+	for {
+		session, err := s.repo.GetSession(ctx, sessionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get session: %w", err)
+		}
+
+		switch session.Status {
+		case model.SessionStatusCompleting:
+			// Still running in the other request
+		case model.SessionStatusCompleted:
+			return s.completedCheckIn(ctx, sessionID)
+		case model.SessionStatusActive:
+			return nil, fmt.Errorf("%w: session %s", ErrConcurrentCompletionFailed, sessionID)
+		default:
+			return nil, fmt.Errorf("session is not active: %s", session.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, fmt.Errorf("%w: session %s", ErrCompletionInProgress, sessionID)
+			}
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// completedCheckIn returns the check-in saved for a completed session: the extracted
+// one, or the raw transcript saved when extraction failed
+func (s *CheckInService) completedCheckIn(ctx context.Context, sessionID string) (*model.HealthCheckIn, error) {
+	checkIns, err := s.repo.GetHealthCheckInsBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get health check-ins: %w", err)
+	}
+
+	var rawTranscript *model.HealthCheckIn
+	for i := range checkIns {
+		if checkIns[i].RawTranscript == nil {
+			return &checkIns[i], nil
+		}
+		if rawTranscript == nil {
+			rawTranscript = &checkIns[i]
+		}
+	}
+	if rawTranscript != nil {
+		return rawTranscript, nil
+	}
+	return nil, fmt.Errorf("completed session has no check-in: %s", sessionID)
+}
+
+// releaseCompletion returns a session to active after its completion failed
+func (s *CheckInService) releaseCompletion(ctx context.Context, sessionID string) {
+	if err := s.repo.ReleaseSessionCompletion(context.WithoutCancel(ctx), sessionID); err != nil {
+		s.logger.Error("failed to release session after failed completion",
+			zap.Error(err),
+			zap.String("session_id", sessionID),
+		)
+	}
+}
+
+// RunCompletionRecovery periodically recovers sessions left completing by a server that
+// stopped mid-completion. It blocks until ctx is cancelled.
+func (s *CheckInService) RunCompletionRecovery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.recoverStaleCompletions(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// recoverStaleCompletions runs one recovery pass
+func (s *CheckInService) recoverStaleCompletions(ctx context.Context) {
+	recovered, err := s.repo.RecoverStaleCompletions(ctx, staleCompletionAfter)
+	if err != nil {
+		s.logger.Error("failed to recover stale session completions", zap.Error(err))
+		return
+	}
+	if recovered > 0 {
+		s.logger.Warn("recovered sessions left completing",
+			zap.Int64("sessions", recovered),
+		)
+	}
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// extractionResponse is a streamed chat completion carrying a valid extraction result
+var extractionResponse = sseChatChunk(`{"symptoms":["headache"],"mood":"neutral","energy_level":"medium",`) +
+	sseChatChunk(`"sleep_quality":"good","medication_taken":"yes","physical_activity":[],"meals":{},`) +
+	sseChatChunk(`"general_feeling":"ok","additional_notes":"","confidence":0.9}`) +
+	"data: [DONE]\n\n"
+
+// setupMigratedTestDB starts a PostgreSQL testcontainer with the real schema migrations applied
+func setupMigratedTestDB(t *testing.T) (*pgxpool.Pool, func()) {
+	pool, cleanup := startTestPostgres(t)
+
+	files, err := filepath.Glob(filepath.Join("..", "..", "migrations", "*.up.sql"))
+	require.NoError(t, err)
+	sort.Strings(files)
+
+	for _, file := range files {
+		migration, err := os.ReadFile(file)
+		require.NoError(t, err)
+		_, err = pool.Exec(context.Background(), string(migration))
+		require.NoError(t, err, "migration %s", filepath.Base(file))
+	}
+
+	return pool, cleanup
+}
+
+// createCompletableSession creates an active session with one answered question
+func createCompletableSession(t *testing.T, repo *repository.CheckInRepository) string {
+	ctx := context.Background()
+	session := &model.Session{
+		ID:        uuid.New().String(),
+		UserID:    uuid.New().String(),
+		StartedAt: time.Now(),
+		Status:    model.SessionStatusActive,
+	}
+	require.NoError(t, repo.CreateSession(ctx, session))
+	require.NoError(t, repo.SaveConversationMessage(ctx, &model.Message{
+		ID:        uuid.New().String(),
+		SessionID: session.ID,
+		Role:      model.MessageRoleUser,
+		Content:   "Fáj a fejem, de jól aludtam.",
+	}))
+	return session.ID
+}
+
+func TestCheckInCompletionGuard(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	logger := zap.NewNop()
+	repo := repository.NewCheckInRepository(db, logger)
+
+	var extractions atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		extractions.Add(1)
+		// A slow extraction keeps the session completing while the other requests arrive
+		time.Sleep(500 * time.Millisecond)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte(extractionResponse))
+	}))
+	defer server.Close()
+
+	aiClient, err := azure.NewOpenAIClient(server.URL, azure.KeyAuth("test-key"), "test-deployment", logger)
+	require.NoError(t, err)
+	svc := NewCheckInService(repo, aiClient, nil, nil, logger)
+
+	t.Run("concurrent completions extract once", func(t *testing.T) {
+		sessionID := createCompletableSession(t, repo)
+		extractions.Store(0)
+
+		const requests = 4
+		results := make([]*model.HealthCheckIn, requests)
+		errs := make([]error, requests)
+		start := make(chan struct{})
+		var wg sync.WaitGroup
+		for i := 0; i < requests; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				<-start
+				results[i], errs[i] = svc.CompleteSession(ctx, sessionID)
+			}(i)
+		}
+		close(start)
+		wg.Wait()
+
+		for i := 0; i < requests; i++ {
+			require.NoError(t, errs[i], "request %d", i)
+			assert.Equal(t, results[0].ID, results[i].ID, "every request returns the same check-in")
+		}
+		assert.EqualValues(t, 1, extractions.Load(), "only the winning request calls Azure OpenAI")
+
+		checkIns, err := repo.GetHealthCheckInsBySessionID(ctx, sessionID)
+		require.NoError(t, err)
+		assert.Len(t, checkIns, 1)
+
+		session, err := repo.GetSession(ctx, sessionID)
+		require.NoError(t, err)
+		assert.Equal(t, model.SessionStatusCompleted, session.Status)
+
+		// A later retry of the same request is answered from the saved check-in
+		again, err := svc.CompleteSession(ctx, sessionID)
+		require.NoError(t, err)
+		assert.Equal(t, results[0].ID, again.ID)
+		assert.EqualValues(t, 1, extractions.Load())
+	})
+
+	t.Run("failed extraction completes the session with its transcript", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"error":{"message":"invalid request"}}`, http.StatusBadRequest)
+		}))
+		defer failing.Close()
+		failingClient, err := azure.NewOpenAIClient(failing.URL, azure.KeyAuth("test-key"), "test-deployment", logger)
+		require.NoError(t, err)
+		failingSvc := NewCheckInService(repo, failingClient, nil, nil, logger)
+
+		sessionID := createCompletableSession(t, repo)
+		_, err = failingSvc.CompleteSession(ctx, sessionID)
+		require.Error(t, err)
+
+		session, err := repo.GetSession(ctx, sessionID)
+		require.NoError(t, err)
+		assert.Equal(t, model.SessionStatusCompleted, session.Status, "the session is closed with its transcript")
+
+		// A retry returns the saved transcript instead of saving another check-in
+		retried, err := failingSvc.CompleteSession(ctx, sessionID)
+		require.NoError(t, err)
+		require.NotNil(t, retried.RawTranscript)
+
+		checkIns, err := repo.GetHealthCheckInsBySessionID(ctx, sessionID)
+		require.NoError(t, err)
+		require.Len(t, checkIns, 1)
+		assert.Equal(t, checkIns[0].ID, retried.ID)
+	})
+
+	t.Run("stale completions are recovered", func(t *testing.T) {
+		saved := createCompletableSession(t, repo)
+		unsaved := createCompletableSession(t, repo)
+		fresh := createCompletableSession(t, repo)
+
+		sessionID := saved
+		require.NoError(t, repo.SaveHealthCheckIn(ctx, &model.HealthCheckIn{
+			ID:          uuid.New().String(),
+			UserID:      uuid.New().String(),
+			SessionID:   &sessionID,
+			CheckInDate: time.Now(),
+		}))
+
+		for _, id := range []string{saved, unsaved, fresh} {
+			claimed, err := repo.ClaimSessionCompletion(ctx, id)
+			require.NoError(t, err)
+			require.True(t, claimed)
+		}
+		// Only the first two were abandoned long enough ago
+		_, err := db.Exec(ctx, `UPDATE check_in_sessions SET updated_at = NOW() - INTERVAL '1 hour' WHERE id = ANY($1::uuid[])`, []string{saved, unsaved})
+		require.NoError(t, err)
+
+		svc.recoverStaleCompletions(ctx)
+
+		for id, want := range map[string]model.SessionStatus{
+			saved:   model.SessionStatusCompleted,
+			unsaved: model.SessionStatusActive,
+			fresh:   model.SessionStatusCompleting,
+		} {
+			session, err := repo.GetSession(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, want, session.Status, "session %s", id)
+		}
+	})
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrInvalidCheckInPatch is returned when a check-in correction has no fields or an
+	// invalid value
+	ErrInvalidCheckInPatch = errors.New("invalid check-in correction")
+
+	// ErrCheckInAwaitingExtraction is returned when correcting a check-in whose extraction
+	// failed; it has no extracted fields until it is re-extracted
+	ErrCheckInAwaitingExtraction = errors.New("check-in is awaiting re-extraction")
+)
+
+// CheckInPatch holds corrections of the structured fields of a completed check-in. Nil
+// fields are left unchanged; the raw transcript cannot be corrected.
+type CheckInPatch struct {
+	Symptoms         *[]string      `json:"symptoms,omitempty"`
+	Mood             *string        `json:"mood,omitempty"`
+	PainLevel        *int           `json:"pain_level,omitempty"`
+	EnergyLevel      *string        `json:"energy_level,omitempty"`
+	SleepQuality     *string        `json:"sleep_quality,omitempty"`
+	MedicationTaken  *string        `json:"medication_taken,omitempty"`
+	PhysicalActivity *[]string      `json:"physical_activity,omitempty"`
+	Meals            *MealInfoPatch `json:"meals,omitempty"`
+	GeneralFeeling   *string        `json:"general_feeling,omitempty"`
+	AdditionalNotes  *string        `json:"additional_notes,omitempty"`
+}
+
+// MealInfoPatch holds corrections of the meals of a check-in
+type MealInfoPatch struct {
+	Breakfast *string `json:"breakfast,omitempty"`
+	Lunch     *string `json:"lunch,omitempty"`
+	Dinner    *string `json:"dinner,omitempty"`
+}
+
+// GetCheckIn retrieves a health check-in. It fails with repository.ErrHealthCheckInNotFound
+// for an unknown check-in.
+func (s *CheckInService) GetCheckIn(ctx context.Context, checkInID string) (*model.HealthCheckIn, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CheckInService.GetCheckIn")
+	defer span.End()
+
+	return s.repo.GetHealthCheckIn(ctx, checkInID)
+}
+
+// UpdateCheckIn corrects the structured fields of a completed check-in, e.g. a mood or
+// pain level the extraction got wrong, enforcing the values extraction allows. The
+// correction is audited with the names of the changed fields.
+func (s *CheckInService) UpdateCheckIn(ctx context.Context, checkInID string, patch CheckInPatch) (*model.HealthCheckIn, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CheckInService.UpdateCheckIn")
+	defer span.End()
+
+	if err := patch.normalize(); err != nil {
+		return nil, err
+	}
+
+	checkIn, err := s.repo.GetHealthCheckIn(ctx, checkInID)
+	if err != nil {
+		return nil, err
+	}
+	if checkIn.RawTranscript != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCheckInAwaitingExtraction, checkInID)
+	}
+
+	fields := patch.apply(checkIn)
+	if err := s.repo.UpdateHealthCheckIn(ctx, checkIn); err != nil {
+		s.logger.Error("failed to update health check-in",
+			zap.Error(err),
+			zap.String("check_in_id", checkInID),
+		)
+		return nil, fmt.Errorf("failed to update health check-in: %w", err)
+	}
+	s.auditCorrection(ctx, checkIn, fields)
+
+	s.logger.Info("health check-in corrected",
+		zap.String("check_in_id", checkInID),
+		zap.Strings("fields", fields),
+	)
+
+	return checkIn, nil
+}
+
+// normalize trims and lower-cases the patch values like extraction does and checks them
+// against the allowed values
+func (p *CheckInPatch) normalize() error {
+	if p.Symptoms == nil && p.Mood == nil && p.PainLevel == nil && p.EnergyLevel == nil &&
+		p.SleepQuality == nil && p.MedicationTaken == nil && p.PhysicalActivity == nil &&
+		p.Meals == nil && p.GeneralFeeling == nil && p.AdditionalNotes == nil {
+		return fmt.Errorf("%w: no fields to correct", ErrInvalidCheckInPatch)
+	}
+
+	categories := []struct {
+		name    string
+		value   *string
+		allowed []string
+	}{
+		{"mood", p.Mood, checkInMoods},
+		{"energy_level", p.EnergyLevel, checkInEnergyLevels},
+		{"sleep_quality", p.SleepQuality, checkInSleepQualities},
+	}
+	for _, category := range categories {
+		if category.value == nil {
+			continue
+		}
+		*category.value = strings.ToLower(strings.TrimSpace(*category.value))
+		if !slices.Contains(category.allowed, *category.value) {
+			return fmt.Errorf("%w: %s must be one of %s", ErrInvalidCheckInPatch, category.name, strings.Join(category.allowed, ", "))
+		}
+	}
+
+	if p.MedicationTaken != nil {
+		medicationTaken, _ := model.NormalizeMedicationTaken(strings.ToLower(strings.TrimSpace(*p.MedicationTaken)))
+		if medicationTaken != model.MedicationTakenYes && medicationTaken != model.MedicationTakenNo && medicationTaken != model.MedicationTakenSome {
+			return fmt.Errorf("%w: medication_taken must be one of yes, no, some", ErrInvalidCheckInPatch)
+		}
+		*p.MedicationTaken = medicationTaken
+	}
+
+	if p.PainLevel != nil && (*p.PainLevel < minPainLevel || *p.PainLevel > maxPainLevel) {
+		return fmt.Errorf("%w: pain_level must be between %d and %d", ErrInvalidCheckInPatch, minPainLevel, maxPainLevel)
+	}
+
+	for _, list := range []*[]string{p.Symptoms, p.PhysicalActivity} {
+		if list != nil {
+			*list = trimmedEntries(*list)
+		}
+	}
+	return nil
+}
+
+// trimmedEntries trims the entries of a list, dropping empty ones
+func trimmedEntries(entries []string) []string {
+	trimmed := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			trimmed = append(trimmed, entry)
+		}
+	}
+	return trimmed
+}
+
+// apply copies the patch onto a check-in and returns the names of the fields it sets
+func (p *CheckInPatch) apply(checkIn *model.HealthCheckIn) []string {
+	var fields []string
+	set := func(name string, ok bool, assign func()) {
+		if ok {
+			assign()
+			fields = append(fields, name)
+		}
+	}
+
+	set("symptoms", p.Symptoms != nil, func() { checkIn.Symptoms = *p.Symptoms })
+	set("mood", p.Mood != nil, func() { checkIn.Mood = p.Mood })
+	set("pain_level", p.PainLevel != nil, func() { checkIn.PainLevel = p.PainLevel })
+	set("energy_level", p.EnergyLevel != nil, func() { checkIn.EnergyLevel = p.EnergyLevel })
+	set("sleep_quality", p.SleepQuality != nil, func() { checkIn.SleepQuality = p.SleepQuality })
+	set("medication_taken", p.MedicationTaken != nil, func() { checkIn.MedicationTaken = p.MedicationTaken })
+	set("physical_activity", p.PhysicalActivity != nil, func() { checkIn.PhysicalActivity = *p.PhysicalActivity })
+	if p.Meals != nil {
+		set("breakfast", p.Meals.Breakfast != nil, func() { checkIn.Breakfast = p.Meals.Breakfast })
+		set("lunch", p.Meals.Lunch != nil, func() { checkIn.Lunch = p.Meals.Lunch })
+		set("dinner", p.Meals.Dinner != nil, func() { checkIn.Dinner = p.Meals.Dinner })
+	}
+	set("general_feeling", p.GeneralFeeling != nil, func() { checkIn.GeneralFeeling = p.GeneralFeeling })
+	set("additional_notes", p.AdditionalNotes != nil, func() { checkIn.AdditionalNotes = p.AdditionalNotes })
+	return fields
+}
+
+// auditCorrection records a correction of a check-in in the audit log
+func (s *CheckInService) auditCorrection(ctx context.Context, checkIn *model.HealthCheckIn, fields []string) {
+	if s.auditLogger == nil {
+		return
+	}
+
+	additional := map[string]interface{}{
+		"action": "correct",
+		"fields": fields,
+	}
+	if checkIn.SessionID != nil {
+		additional["session_id"] = *checkIn.SessionID
+	}
+
+	err := s.auditLogger.Log(ctx, audit.AuditLog{
+		UserID:         checkIn.UserID,
+		OperationType:  audit.OperationUpdate,
+		ResourceType:   audit.ResourceHealthCheckIn,
+		ResourceID:     checkIn.ID,
+		AdditionalData: additional,
+	})
+	if err != nil {
+		s.logger.Error("failed to audit check-in correction", zap.Error(err), zap.String("check_in_id", checkIn.ID))
+	}
+}
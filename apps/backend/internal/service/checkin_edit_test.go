@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+func TestCheckInPatchNormalize(t *testing.T) {
+	str := func(s string) *string { return &s }
+	num := func(n int) *int { return &n }
+
+	t.Run("normalizes values like extraction", func(t *testing.T) {
+		symptoms := []string{" headache ", "", "nausea"}
+		patch := CheckInPatch{
+			Mood:            str(" Positive"),
+			EnergyLevel:     str("HIGH"),
+			MedicationTaken: str("partial"),
+			PainLevel:       num(0),
+			Symptoms:        &symptoms,
+		}
+
+		require.NoError(t, patch.normalize())
+		assert.Equal(t, "positive", *patch.Mood)
+		assert.Equal(t, "high", *patch.EnergyLevel)
+		assert.Equal(t, model.MedicationTakenSome, *patch.MedicationTaken)
+		assert.Equal(t, []string{"headache", "nausea"}, *patch.Symptoms)
+	})
+
+	tests := []struct {
+		name  string
+		patch CheckInPatch
+	}{
+		{"empty patch", CheckInPatch{}},
+		{"unknown mood", CheckInPatch{Mood: str("ecstatic")}},
+		{"unknown energy level", CheckInPatch{EnergyLevel: str("extreme")}},
+		{"unknown sleep quality", CheckInPatch{SleepQuality: str("great")}},
+		{"unknown medication taken", CheckInPatch{MedicationTaken: str("maybe")}},
+		{"pain below range", CheckInPatch{PainLevel: num(-1)}},
+		{"pain above range", CheckInPatch{PainLevel: num(11)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.patch.normalize(), ErrInvalidCheckInPatch)
+		})
+	}
+}
+
+func TestUpdateCheckIn_CorrectsPainLevel(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	logger := zap.NewNop()
+	repo := repository.NewCheckInRepository(db, logger)
+	auditLogger := audit.NewLogger(db, logger)
+	svc := NewCheckInService(repo, nil, nil, nil, logger)
+	svc.SetAuditLogger(auditLogger)
+	dashboard := NewDashboardService(repository.NewDashboardRepository(db, logger), logger)
+
+	userID := uuid.New().String()
+	painLevel := 8
+	checkIn := &model.HealthCheckIn{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		CheckInDate: time.Now(),
+		Symptoms:    []string{"headache"},
+		PainLevel:   &painLevel,
+	}
+	require.NoError(t, repo.SaveHealthCheckIn(ctx, checkIn))
+
+	corrected := 3
+	updated, err := svc.UpdateCheckIn(ctx, checkIn.ID, CheckInPatch{PainLevel: &corrected})
+	require.NoError(t, err)
+	assert.Equal(t, 3, *updated.PainLevel)
+	assert.Equal(t, []string{"headache"}, updated.Symptoms, "fields outside the patch are kept")
+
+	saved, err := svc.GetCheckIn(ctx, checkIn.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, *saved.PainLevel)
+
+	summary, err := dashboard.GetSummary(ctx, userID, 7)
+	require.NoError(t, err)
+	assert.InDelta(t, 3.0, summary.AveragePain, 0.001, "the next summary uses the corrected pain level")
+
+	logs, _, err := auditLogger.GetAuditLogs(ctx, audit.AuditFilter{UserID: userID, OperationType: audit.OperationUpdate})
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, checkIn.ID, logs[0].ResourceID)
+
+	t.Run("unknown check-in", func(t *testing.T) {
+		_, err := svc.UpdateCheckIn(ctx, uuid.New().String(), CheckInPatch{PainLevel: &corrected})
+		assert.ErrorIs(t, err, repository.ErrHealthCheckInNotFound)
+	})
+
+	t.Run("check-in awaiting re-extraction", func(t *testing.T) {
+		transcript := "user: fáj a fejem"
+		pending := &model.HealthCheckIn{
+			ID:            uuid.New().String(),
+			UserID:        userID,
+			CheckInDate:   time.Now(),
+			RawTranscript: &transcript,
+		}
+		require.NoError(t, repo.SaveHealthCheckIn(ctx, pending))
+
+		_, err := svc.UpdateCheckIn(ctx, pending.ID, CheckInPatch{PainLevel: &corrected})
+		assert.ErrorIs(t, err, ErrCheckInAwaitingExtraction)
+	})
+}
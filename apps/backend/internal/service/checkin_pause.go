@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrSessionNotActive is returned when a session that is not active is used or paused
+	ErrSessionNotActive = errors.New("session is not active")
+
+	// ErrSessionNotPaused is returned when resuming a session that is not paused
+	ErrSessionNotPaused = errors.New("session is not paused")
+
+	// ErrSessionExpired is returned when the session timeout elapsed
+	ErrSessionExpired = errors.New("session has expired")
+
+	// ErrActiveSessionExists matches every ActiveSessionExistsError
+	ErrActiveSessionExists = errors.New("user already has an active session")
+)
+
+// ActiveSessionExistsError is returned when starting a check-in while the user has an
+// active one, so the client can resume it. It matches ErrActiveSessionExists.
+type ActiveSessionExistsError struct {
+	SessionID string
+}
+
+func (e *ActiveSessionExistsError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrActiveSessionExists, e.SessionID)
+}
+
+// Is reports whether target is ErrActiveSessionExists
+func (e *ActiveSessionExistsError) Is(target error) bool {
+	return target == ErrActiveSessionExists
+}
+
+// resumePoint is the question a resumed session continues with
+type resumePoint struct {
+	QuestionID string
+	Text       string
+	// Unasked is set when the question has not been saved to the conversation yet
+	Unasked bool
+}
+
+// GetSession returns a session without its messages
+func (s *CheckInService) GetSession(ctx context.Context, sessionID string) (*model.Session, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CheckInService.GetSession")
+	defer span.End()
+
+	session, err := s.repo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return session, nil
+}
+
+// PauseSession pauses an active session. The session timeout does not run while paused.
+func (s *CheckInService) PauseSession(ctx context.Context, sessionID string) (*model.Session, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CheckInService.PauseSession")
+	defer span.End()
+
+	s.logger.Info("pausing check-in session", zap.String("session_id", sessionID))
+
+	session, err := s.repo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if session.Status != model.SessionStatusActive {
+		return nil, fmt.Errorf("%w: %s", ErrSessionNotActive, session.Status)
+	}
+
+	if err := s.expireIfTimedOut(ctx, session); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session.Status = model.SessionStatusPaused
+	session.PausedAt = &now
+	if err := s.repo.UpdateSession(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to pause session: %w", err)
+	}
+
+	s.logger.Info("check-in session paused", zap.String("session_id", sessionID))
+
+	return session, nil
+}
+
+// ResumeSession reactivates a paused session, restarts its timeout clock and
+// returns the question the conversation left off at with its audio
+func (s *CheckInService) ResumeSession(ctx context.Context, sessionID string) (*SessionWithAudio, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CheckInService.ResumeSession")
+	defer span.End()
+
+	s.logger.Info("resuming check-in session", zap.String("session_id", sessionID))
+
+	session, err := s.repo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if session.Status != model.SessionStatusPaused {
+		return nil, fmt.Errorf("%w: %s", ErrSessionNotPaused, session.Status)
+	}
+
+	messages, err := s.repo.GetConversationMessages(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversation messages: %w", err)
+	}
+
+	now := time.Now()
+	session.Status = model.SessionStatusActive
+	session.PausedAt = nil
+	session.ResumedAt = &now
+	if err := s.repo.UpdateSession(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to resume session: %w", err)
+	}
+
+	result := &SessionWithAudio{Session: session}
+
+	questionFlow, err := s.questionFlowForSession(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	point := resumeQuestion(questionFlow, messages)
+	if point == nil {
+		// All questions were answered; the client completes the session
+		s.logger.Info("check-in session resumed with all questions answered", zap.String("session_id", sessionID))
+		return result, nil
+	}
+
+	if point.Unasked {
+		assistantMsg := &model.Message{
+			ID:        uuid.New().String(),
+			SessionID: sessionID,
+			Role:      model.MessageRoleAssistant,
+			Content:   point.Text,
+			CreatedAt: time.Now(),
+		}
+		if err := s.repo.SaveConversationMessage(ctx, assistantMsg); err != nil {
+			s.logger.Warn("failed to save assistant message", zap.Error(err))
+		} else {
+			s.publishQuestion(assistantMsg, point.QuestionID)
+		}
+	}
+
+	audioData, audioAvailable, audioError := s.questionAudio(ctx, sessionID, point.QuestionID)
+
+	result.QuestionText = point.Text
+	result.QuestionID = point.QuestionID
+	result.QuestionAudio = audioData
+	result.AudioAvailable = audioAvailable
+	result.AudioError = audioError
+
+	s.logger.Info("check-in session resumed",
+		zap.String("session_id", sessionID),
+		zap.String("question_id", point.QuestionID),
+	)
+
+	return result, nil
+}
+
+// resumeQuestion determines where a conversation continues from its stored messages.
+// An unanswered question is asked again; after an answer the next scripted question
+// follows. It returns nil when all scripted questions were answered.
+func resumeQuestion(questionFlow *QuestionFlow, messages []model.Message) *resumePoint {
+	lastAsked := questionFlow.Replay(messages)
+
+	if n := len(messages); n > 0 && messages[n-1].Role == model.MessageRoleAssistant {
+		last := messages[n-1]
+		if last.IsFollowUp {
+			return &resumePoint{QuestionID: followUpQuestionID(last.ID), Text: last.Content}
+		}
+
+		if lastAsked != nil {
+			return &resumePoint{QuestionID: lastAsked.ID, Text: last.Content}
+		}
+		return nil
+	}
+
+	// The last answer was saved but the next question was not asked yet
+	nextQuestion := questionFlow.GetNextQuestion()
+	if nextQuestion == nil || questionFlow.IsComplete() {
+		return nil
+	}
+
+	return &resumePoint{QuestionID: nextQuestion.ID, Text: nextQuestion.TextHU, Unasked: true}
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+func TestResumeQuestion(t *testing.T) {
+	flow := NewQuestionFlow()
+	first := flow.GetNextQuestion()
+	second := flow.GetNextQuestion()
+
+	assistant := func(id, content string, followUp bool) model.Message {
+		return model.Message{ID: id, Role: model.MessageRoleAssistant, Content: content, IsFollowUp: followUp}
+	}
+	user := model.Message{Role: model.MessageRoleUser, Content: "jól vagyok"}
+
+	t.Run("unanswered scripted question is asked again", func(t *testing.T) {
+		point := resumeQuestion(NewQuestionFlow(), []model.Message{
+			assistant("m1", first.TextHU, false),
+			user,
+			assistant("m2", second.TextHU, false),
+		})
+		require.NotNil(t, point)
+		assert.Equal(t, second.ID, point.QuestionID)
+		assert.Equal(t, second.TextHU, point.Text)
+		assert.False(t, point.Unasked)
+	})
+
+	t.Run("unanswered follow-up is asked again", func(t *testing.T) {
+		point := resumeQuestion(NewQuestionFlow(), []model.Message{
+			assistant("m1", first.TextHU, false),
+			user,
+			assistant("m2", "Mióta fáj?", true),
+		})
+		require.NotNil(t, point)
+		assert.Equal(t, followUpQuestionID("m2"), point.QuestionID)
+		assert.Equal(t, "Mióta fáj?", point.Text)
+	})
+
+	t.Run("answered question continues with the next one", func(t *testing.T) {
+		point := resumeQuestion(NewQuestionFlow(), []model.Message{
+			assistant("m1", first.TextHU, false),
+			user,
+		})
+		require.NotNil(t, point)
+		assert.Equal(t, second.ID, point.QuestionID)
+		assert.True(t, point.Unasked)
+	})
+
+	t.Run("no messages starts with the first question", func(t *testing.T) {
+		point := resumeQuestion(NewQuestionFlow(), nil)
+		require.NotNil(t, point)
+		assert.Equal(t, first.ID, point.QuestionID)
+		assert.True(t, point.Unasked)
+	})
+
+	t.Run("all questions answered", func(t *testing.T) {
+		var messages []model.Message
+		for range flow.GetTotalQuestions() {
+			messages = append(messages, assistant("q", "question", false), user)
+		}
+		assert.Nil(t, resumeQuestion(flow, messages))
+	})
+
+	t.Run("follows the branch of the answers", func(t *testing.T) {
+		painScale := func(answer string) []model.Message {
+			return []model.Message{
+				assistant("a1", "Hogy érzed magad ma?", false),
+				{Role: model.MessageRoleUser, Content: "Jól"},
+				assistant("a2", "Fáj valamid?", false),
+				{Role: model.MessageRoleUser, Content: "Igen"},
+				assistant("a3", "Hol fáj?", false),
+				{Role: model.MessageRoleUser, Content: "A hátam"},
+				assistant("a4", "Mennyire fáj egy 1-10 skálán?", false),
+				{Role: model.MessageRoleUser, Content: answer},
+			}
+		}
+
+		point := resumeQuestion(NewQuestionFlowFromSet(painQuestionSet()), painScale("8"))
+		require.NotNil(t, point)
+		assert.Equal(t, "pain_doctor", point.QuestionID)
+		assert.True(t, point.Unasked)
+
+		// Mild back pain skips the remaining pain questions, completing the session
+		assert.Nil(t, resumeQuestion(NewQuestionFlowFromSet(painQuestionSet()), painScale("3")))
+	})
+}
+
+func TestSessionActiveSince(t *testing.T) {
+	started := time.Now().Add(-2 * time.Hour)
+	session := &model.Session{StartedAt: started}
+	assert.Equal(t, started, session.ActiveSince())
+
+	// Resuming restarts the timeout clock so paused time does not count
+	resumed := time.Now()
+	session.ResumedAt = &resumed
+	assert.Equal(t, resumed, session.ActiveSince())
+}
+
+func TestStartSession_RejectsConcurrentSession(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	logger := zap.NewNop()
+	repo := repository.NewCheckInRepository(db, logger)
+
+	// The first question's audio is served from memory so no Speech service is needed
+	svc := NewCheckInService(repo, nil, nil, nil, logger)
+	cache := NewAudioCache(1 << 20)
+	svc.SetAudioCache(cache, "test")
+	cache.Add(questionAudioCacheKey("test", svc.Voice(), NewQuestionFlow().GetNextQuestion().ID), []byte("audio"))
+
+	userID := uuid.New().String()
+	started, err := svc.StartSession(ctx, userID)
+	require.NoError(t, err)
+
+	_, err = svc.StartSession(ctx, userID)
+	require.ErrorIs(t, err, ErrActiveSessionExists)
+	var active *ActiveSessionExistsError
+	require.True(t, errors.As(err, &active))
+	assert.Equal(t, started.Session.ID, active.SessionID)
+
+	// A session past the timeout does not block a new one
+	_, err = db.Exec(ctx, `UPDATE check_in_sessions SET started_at = $2 WHERE id = $1`, started.Session.ID, time.Now().Add(-31*time.Minute))
+	require.NoError(t, err)
+	_, err = svc.StartSession(ctx, userID)
+	assert.NoError(t, err)
+
+	_, err = svc.StartSession(ctx, uuid.New().String())
+	assert.NoError(t, err, "other users are not affected")
+}
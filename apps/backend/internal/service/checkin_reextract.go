@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrRawTranscriptNotFound is returned when a session has no check-in awaiting re-extraction
+	ErrRawTranscriptNotFound = errors.New("no raw transcript found for session")
+
+	// ErrExtractionExists is returned when a session already has a successfully extracted check-in
+	ErrExtractionExists = errors.New("session already has an extracted check-in")
+)
+
+// ReExtractSession runs data extraction again on the raw transcript saved when a session's
+// extraction failed. On success the check-in is updated with the extracted fields, its
+// transcript is cleared and the session is marked completed.
+func (s *CheckInService) ReExtractSession(ctx context.Context, sessionID string) (*model.HealthCheckIn, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CheckInService.ReExtractSession")
+	defer span.End()
+
+	s.logger.Info("re-extracting check-in session", zap.String("session_id", sessionID))
+
+	checkIns, err := s.repo.GetHealthCheckInsBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get health check-ins: %w", err)
+	}
+
+	var checkIn *model.HealthCheckIn
+	for i := range checkIns {
+		if checkIns[i].RawTranscript == nil {
+			return nil, fmt.Errorf("%w: %s", ErrExtractionExists, checkIns[i].ID)
+		}
+		if checkIn == nil {
+			checkIn = &checkIns[i]
+		}
+	}
+	if checkIn == nil {
+		return nil, fmt.Errorf("%w: %s", ErrRawTranscriptNotFound, sessionID)
+	}
+
+	conversationHistory := parseRawTranscript(*checkIn.RawTranscript)
+
+	extractedData, err := s.dataExtractor.Extract(ctx, conversationHistory)
+	if err != nil {
+		s.logger.Error("data re-extraction failed", zap.String("session_id", sessionID), zap.Error(err))
+		telemetry.ReportError(ctx, s.reporter, telemetry.KindExtractionFallback, "checkin.re_extract", err)
+		s.auditReExtraction(ctx, checkIn, err)
+		return nil, fmt.Errorf("data re-extraction failed: %w", err)
+	}
+
+	applyExtractedData(checkIn, extractedData)
+	checkIn.ExtractionIssues = s.checkPlausibility(ctx, checkIn.UserID, extractedData)
+	if err := s.repo.UpdateHealthCheckInExtraction(ctx, checkIn); err != nil {
+		s.auditReExtraction(ctx, checkIn, err)
+		return nil, fmt.Errorf("failed to update health check-in: %w", err)
+	}
+	s.auditReExtraction(ctx, checkIn, nil)
+
+	if s.alerts != nil {
+		if _, err := s.alerts.EvaluateCheckIn(ctx, checkIn, conversationHistory); err != nil {
+			s.logger.Error("symptom triage failed",
+				zap.Error(err),
+				zap.String("session_id", sessionID),
+				zap.String("check_in_id", checkIn.ID),
+			)
+		}
+	}
+
+	// Sessions whose transcript was saved before they were closed with it are still open;
+	// close them now that the data is saved
+	session, err := s.repo.GetSession(ctx, sessionID)
+	if err != nil {
+		s.logger.Error("failed to get session after re-extraction", zap.Error(err), zap.String("session_id", sessionID))
+	} else if session.Status == model.SessionStatusActive || session.Status == model.SessionStatusPaused {
+		now := time.Now()
+		session.Status = model.SessionStatusCompleted
+		session.CompletedAt = &now
+		if err := s.repo.UpdateSession(ctx, session); err != nil {
+			s.logger.Error("failed to update session status", zap.Error(err))
+		}
+		s.publishEvent(SessionEvent{Type: SessionEventCompleted, SessionID: sessionID, CheckInID: checkIn.ID, OccurredAt: now})
+	}
+
+	s.logger.Info("check-in session re-extracted",
+		zap.String("session_id", sessionID),
+		zap.String("check_in_id", checkIn.ID),
+	)
+
+	return checkIn, nil
+}
+
+// auditReExtraction records a re-extraction attempt and its outcome in the audit log
+func (s *CheckInService) auditReExtraction(ctx context.Context, checkIn *model.HealthCheckIn, extractErr error) {
+	if s.auditLogger == nil {
+		return
+	}
+
+	additional := map[string]interface{}{
+		"action":  "re_extract",
+		"outcome": "succeeded",
+	}
+	if checkIn.SessionID != nil {
+		additional["session_id"] = *checkIn.SessionID
+	}
+	if extractErr != nil {
+		additional["outcome"] = "failed"
+		additional["error"] = extractErr.Error()
+	}
+
+	err := s.auditLogger.Log(ctx, audit.AuditLog{
+		UserID:         checkIn.UserID,
+		OperationType:  audit.OperationUpdate,
+		ResourceType:   audit.ResourceHealthCheckIn,
+		ResourceID:     checkIn.ID,
+		AdditionalData: additional,
+	})
+	if err != nil {
+		s.logger.Error("failed to audit re-extraction", zap.Error(err), zap.String("check_in_id", checkIn.ID))
+	}
+}
+
+// formatRawTranscript renders a conversation as the "role: content" lines saved for manual review
+func formatRawTranscript(messages []model.Message) string {
+	var transcript strings.Builder
+	for _, msg := range messages {
+		fmt.Fprintf(&transcript, "%s: %s\n", msg.Role, msg.Content)
+	}
+	return transcript.String()
+}
+
+// parseRawTranscript rebuilds the conversation of a transcript written by formatRawTranscript.
+// Lines without a role prefix continue the previous message.
+func parseRawTranscript(transcript string) []ConversationMessage {
+	var conversation []ConversationMessage
+	for _, line := range strings.Split(strings.TrimSuffix(transcript, "\n"), "\n") {
+		role, content, ok := strings.Cut(line, ": ")
+		if ok && (role == string(model.MessageRoleAssistant) || role == string(model.MessageRoleUser)) {
+			conversation = append(conversation, ConversationMessage{Role: role, Content: content})
+			continue
+		}
+		if len(conversation) == 0 {
+			if line != "" {
+				conversation = append(conversation, ConversationMessage{Role: string(model.MessageRoleUser), Content: line})
+			}
+			continue
+		}
+		conversation[len(conversation)-1].Content += "\n" + line
+	}
+	return conversation
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+)
+
+func TestParseRawTranscript(t *testing.T) {
+	t.Run("round-trips a formatted conversation", func(t *testing.T) {
+		messages := []model.Message{
+			{Role: model.MessageRoleAssistant, Content: "Hogy érzi magát ma?"},
+			{Role: model.MessageRoleUser, Content: "Fáj a fejem: reggel óta."},
+			{Role: model.MessageRoleAssistant, Content: "Bevette a gyógyszereit?"},
+			{Role: model.MessageRoleUser, Content: "Igen."},
+		}
+
+		conversation := parseRawTranscript(formatRawTranscript(messages))
+
+		assert.Equal(t, []ConversationMessage{
+			{Role: "assistant", Content: "Hogy érzi magát ma?"},
+			{Role: "user", Content: "Fáj a fejem: reggel óta."},
+			{Role: "assistant", Content: "Bevette a gyógyszereit?"},
+			{Role: "user", Content: "Igen."},
+		}, conversation)
+	})
+
+	t.Run("multi-line content continues the previous message", func(t *testing.T) {
+		conversation := parseRawTranscript("assistant: Mit evett?\nuser: Reggelire kenyeret\nebédre levest\n")
+
+		assert.Equal(t, []ConversationMessage{
+			{Role: "assistant", Content: "Mit evett?"},
+			{Role: "user", Content: "Reggelire kenyeret\nebédre levest"},
+		}, conversation)
+	})
+
+	t.Run("text before the first role is kept as user text", func(t *testing.T) {
+		conversation := parseRawTranscript("jól vagyok\nassistant: Köszönöm")
+
+		assert.Equal(t, []ConversationMessage{
+			{Role: "user", Content: "jól vagyok"},
+			{Role: "assistant", Content: "Köszönöm"},
+		}, conversation)
+	})
+
+	t.Run("empty transcript", func(t *testing.T) {
+		assert.Empty(t, parseRawTranscript(""))
+	})
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"go.uber.org/zap"
+)
+
+// Reasons reported to clients when a question is delivered without audio
+const (
+	audioErrorUnavailable = "speech synthesis is temporarily unavailable"
+	audioErrorFailed      = "speech synthesis failed"
+)
+
+// SetTTSCircuitBreaker replaces the circuit breaker guarding question text-to-speech
+func (s *CheckInService) SetTTSCircuitBreaker(breaker *azure.CircuitBreaker) {
+	s.ttsBreaker = breaker
+}
+
+// TTSBreakerStats returns the state of the text-to-speech circuit breaker
+func (s *CheckInService) TTSBreakerStats() azure.BreakerStats {
+	if s.ttsBreaker == nil {
+		return azure.BreakerStats{State: azure.BreakerClosed}
+	}
+	return s.ttsBreaker.Stats()
+}
+
+// textToSpeech synthesizes question audio. While the circuit breaker is open it fails
+// immediately with azure.ErrCircuitOpen instead of waiting on a failing Speech service.
+func (s *CheckInService) textToSpeech(ctx context.Context, text string) ([]byte, error) {
+	if s.ttsBreaker != nil {
+		if err := s.ttsBreaker.Allow(); err != nil {
+			return nil, err
+		}
+	}
+
+	audioData, err := s.speechClient.TextToSpeech(ctx, text, questionLanguage, s.Voice())
+
+	if s.ttsBreaker != nil {
+		s.ttsBreaker.Record(err)
+	}
+	return audioData, err
+}
+
+// questionAudio returns the audio of a question for a response. When no audio can be
+// produced the check-in continues text-only and the returned reason tells the client why.
+func (s *CheckInService) questionAudio(ctx context.Context, sessionID, questionID string) (audioData []byte, available bool, reason string) {
+	audioData, err := s.GetQuestionAudio(ctx, sessionID, questionID)
+	if err != nil {
+		s.logger.Warn("failed to generate question audio, continuing without audio",
+			zap.String("session_id", sessionID),
+			zap.String("question_id", questionID),
+			zap.Error(err),
+		)
+		audioData = nil
+	}
+	available, reason = audioStatus(err)
+	return audioData, available, reason
+}
+
+// audioStatus reports whether a question has audio after a text-to-speech attempt that
+// returned err, describing a failure without exposing upstream details
+func audioStatus(err error) (available bool, reason string) {
+	switch {
+	case err == nil:
+		return true, ""
+	case errors.Is(err, azure.ErrCircuitOpen):
+		return false, audioErrorUnavailable
+	default:
+		return false, audioErrorFailed
+	}
+}
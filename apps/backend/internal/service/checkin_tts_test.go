@@ -0,0 +1,81 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"go.uber.org/zap"
+)
+
+// newFailingTTSService returns a check-in service whose Speech service always answers 503,
+// and the number of synthesis requests that reached it
+func newFailingTTSService(t *testing.T) (*CheckInService, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	logger := zap.NewNop()
+	speechClient, err := azure.NewSpeechServiceClient("test-key", "test-region", logger)
+	require.NoError(t, err)
+	speechClient.SetEndpointForTesting(server.URL)
+	speechClient.SetRetryPolicy(azure.RetryPolicy{MaxAttempts: 1})
+
+	return NewCheckInService(nil, nil, speechClient, nil, logger), &calls
+}
+
+func TestTextToSpeech_BreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	s, calls := newFailingTTSService(t)
+	s.SetTTSCircuitBreaker(azure.NewCircuitBreaker("azure-speech-tts", 3, time.Hour, zap.NewNop()))
+
+	for i := 0; i < 3; i++ {
+		_, err := s.textToSpeech(t.Context(), "Hogy érzi magát ma?")
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, azure.ErrCircuitOpen)
+
+		available, reason := audioStatus(err)
+		assert.False(t, available)
+		assert.Equal(t, audioErrorFailed, reason)
+	}
+	assert.Equal(t, azure.BreakerOpen, s.TTSBreakerStats().State)
+
+	// The open breaker fails fast without calling the Speech service
+	_, err := s.textToSpeech(t.Context(), "Hogy érzi magát ma?")
+	assert.ErrorIs(t, err, azure.ErrCircuitOpen)
+	assert.Equal(t, int32(3), calls.Load())
+
+	available, reason := audioStatus(err)
+	assert.False(t, available)
+	assert.Equal(t, audioErrorUnavailable, reason)
+}
+
+func TestTextToSpeech_BreakerProbesAfterCoolDown(t *testing.T) {
+	s, calls := newFailingTTSService(t)
+	s.SetTTSCircuitBreaker(azure.NewCircuitBreaker("azure-speech-tts", 1, time.Millisecond, zap.NewNop()))
+
+	_, err := s.textToSpeech(t.Context(), "Hogy érzi magát ma?")
+	require.Error(t, err)
+	require.Equal(t, azure.BreakerOpen, s.TTSBreakerStats().State)
+
+	time.Sleep(5 * time.Millisecond)
+
+	// After the cool-down one probe reaches the Speech service again
+	_, err = s.textToSpeech(t.Context(), "Hogy érzi magát ma?")
+	assert.NotErrorIs(t, err, azure.ErrCircuitOpen)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestAudioStatus(t *testing.T) {
+	available, reason := audioStatus(nil)
+	assert.True(t, available)
+	assert.Empty(t, reason)
+}
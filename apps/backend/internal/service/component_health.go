@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Overall statuses of the health endpoint
+const (
+	HealthStatusHealthy   = "healthy"
+	HealthStatusDegraded  = "degraded"
+	HealthStatusUnhealthy = "unhealthy"
+)
+
+// Statuses of a single component
+const (
+	ComponentStatusOK      = "ok"
+	ComponentStatusTimeout = "timeout"
+	ComponentStatusError   = "error"
+)
+
+// componentFailureTTL is how long a failed check is reported without running it again,
+// so frequent /health probes do not pile onto a struggling dependency
+const componentFailureTTL = 10 * time.Second
+
+// ComponentCheck probes one dependency for the health endpoint
+type ComponentCheck struct {
+	Name string
+	// Critical components make the service unhealthy when they fail; failures of other
+	// components only degrade it
+	Critical bool
+	Timeout  time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// HealthReport is the outcome of the component checks
+type HealthReport struct {
+	Status     string            `json:"status"`
+	Components map[string]string `json:"components"`
+}
+
+// cachedFailure is the status of a failed check, reused until expires
+type cachedFailure struct {
+	status  string
+	expires time.Time
+}
+
+// ComponentHealthService runs the checks of the health endpoint concurrently, each
+// within its own timeout, and remembers failures for a short while
+type ComponentHealthService struct {
+	checks []ComponentCheck
+	logger *zap.Logger
+	now    func() time.Time
+
+	mu       sync.Mutex
+	failures map[string]cachedFailure
+}
+
+// NewComponentHealthService creates a new ComponentHealthService
+func NewComponentHealthService(logger *zap.Logger) *ComponentHealthService {
+	return &ComponentHealthService{
+		logger:   logger,
+		now:      time.Now,
+		failures: make(map[string]cachedFailure),
+	}
+}
+
+// Register adds a check
+func (s *ComponentHealthService) Register(check ComponentCheck) {
+	s.checks = append(s.checks, check)
+}
+
+// Check runs every check and reports the status of each component. The service is
+// unhealthy when a critical component failed and degraded when another one did.
+func (s *ComponentHealthService) Check(ctx context.Context) *HealthReport {
+	statuses := make([]string, len(s.checks))
+
+	var wg sync.WaitGroup
+	for i, check := range s.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i] = s.checkComponent(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := &HealthReport{
+		Status:     HealthStatusHealthy,
+		Components: make(map[string]string, len(s.checks)),
+	}
+	for i, check := range s.checks {
+		report.Components[check.Name] = statuses[i]
+		if statuses[i] == ComponentStatusOK {
+			continue
+		}
+		if check.Critical {
+			report.Status = HealthStatusUnhealthy
+		} else if report.Status == HealthStatusHealthy {
+			report.Status = HealthStatusDegraded
+		}
+	}
+	return report
+}
+
+// checkComponent returns the cached failure of a check or runs it
+func (s *ComponentHealthService) checkComponent(ctx context.Context, check ComponentCheck) string {
+	s.mu.Lock()
+	failure, cached := s.failures[check.Name]
+	s.mu.Unlock()
+	if cached && s.now().Before(failure.expires) {
+		return failure.status
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, check.Timeout)
+	defer cancel()
+
+	err := check.Run(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == nil {
+		delete(s.failures, check.Name)
+		return ComponentStatusOK
+	}
+
+	status := ComponentStatusError
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		status = ComponentStatusTimeout
+	}
+	s.failures[check.Name] = cachedFailure{status: status, expires: s.now().Add(componentFailureTTL)}
+
+	s.logger.Warn("health check component failed",
+		zap.String("component", check.Name),
+		zap.String("status", status),
+		zap.Error(err),
+	)
+	return status
+}
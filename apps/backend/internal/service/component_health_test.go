@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// countingCheck is a component check returning err and counting its runs
+func countingCheck(name string, critical bool, runs *atomic.Int32, err error) ComponentCheck {
+	return ComponentCheck{
+		Name:     name,
+		Critical: critical,
+		Timeout:  time.Second,
+		Run: func(ctx context.Context) error {
+			runs.Add(1)
+			return err
+		},
+	}
+}
+
+func TestComponentHealthService_Statuses(t *testing.T) {
+	var runs atomic.Int32
+	ctx := context.Background()
+
+	healthy := NewComponentHealthService(zap.NewNop())
+	healthy.Register(countingCheck("database", true, &runs, nil))
+	healthy.Register(countingCheck("openai", false, &runs, nil))
+	assert.Equal(t, &HealthReport{
+		Status:     HealthStatusHealthy,
+		Components: map[string]string{"database": ComponentStatusOK, "openai": ComponentStatusOK},
+	}, healthy.Check(ctx))
+
+	degraded := NewComponentHealthService(zap.NewNop())
+	degraded.Register(countingCheck("database", true, &runs, nil))
+	degraded.Register(countingCheck("openai", false, &runs, errors.New("401 Unauthorized")))
+	report := degraded.Check(ctx)
+	assert.Equal(t, HealthStatusDegraded, report.Status)
+	assert.Equal(t, ComponentStatusError, report.Components["openai"])
+
+	unhealthy := NewComponentHealthService(zap.NewNop())
+	unhealthy.Register(countingCheck("database", true, &runs, errors.New("connection refused")))
+	unhealthy.Register(countingCheck("openai", false, &runs, errors.New("401 Unauthorized")))
+	assert.Equal(t, HealthStatusUnhealthy, unhealthy.Check(ctx).Status)
+}
+
+func TestComponentHealthService_TimesOutChecksConcurrently(t *testing.T) {
+	svc := NewComponentHealthService(zap.NewNop())
+	hang := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	svc.Register(ComponentCheck{Name: "speech", Timeout: 50 * time.Millisecond, Run: hang})
+	svc.Register(ComponentCheck{Name: "storage", Timeout: 50 * time.Millisecond, Run: hang})
+
+	start := time.Now()
+	report := svc.Check(context.Background())
+
+	assert.Less(t, time.Since(start), 90*time.Millisecond, "checks run concurrently")
+	assert.Equal(t, HealthStatusDegraded, report.Status)
+	assert.Equal(t, map[string]string{"speech": ComponentStatusTimeout, "storage": ComponentStatusTimeout}, report.Components)
+}
+
+func TestComponentHealthService_CachesFailures(t *testing.T) {
+	var failing, passing atomic.Int32
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	svc := NewComponentHealthService(zap.NewNop())
+	svc.now = func() time.Time { return now }
+	svc.Register(countingCheck("openai", false, &failing, errors.New("503 Service Unavailable")))
+	svc.Register(countingCheck("storage", false, &passing, nil))
+	ctx := context.Background()
+
+	svc.Check(ctx)
+	now = now.Add(9 * time.Second)
+	report := svc.Check(ctx)
+	assert.Equal(t, ComponentStatusError, report.Components["openai"])
+	assert.Equal(t, int32(1), failing.Load(), "a failure is reused for 10 seconds")
+	assert.Equal(t, int32(2), passing.Load(), "successes are not cached")
+
+	now = now.Add(2 * time.Second)
+	svc.Check(ctx)
+	assert.Equal(t, int32(2), failing.Load(), "the check runs again once the failure expired")
+}
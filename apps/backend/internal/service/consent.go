@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrInvalidConsentType is returned for a consent type that does not exist
+	ErrInvalidConsentType = errors.New("invalid consent type")
+
+	// ErrConsentRequired is returned when an operation needs a consent the user has not granted
+	ErrConsentRequired = errors.New("consent required")
+)
+
+// ConsentStore defines the persistence operations needed for user consents
+type ConsentStore interface {
+	SetConsent(ctx context.Context, userID string, consentType model.ConsentType, granted bool) (*model.UserConsent, error)
+	GetConsentsByUserID(ctx context.Context, userID string) ([]model.UserConsent, error)
+	HasConsent(ctx context.Context, userID string, consentType model.ConsentType) (bool, error)
+}
+
+// ConsentService records and enforces the consents users grant for processing their data
+type ConsentService struct {
+	store       ConsentStore
+	auditLogger *audit.Logger
+	logger      *zap.Logger
+}
+
+// NewConsentService creates a new ConsentService
+func NewConsentService(store ConsentStore, logger *zap.Logger) *ConsentService {
+	return &ConsentService{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// SetAuditLogger enables audit logging of consent changes
+func (s *ConsentService) SetAuditLogger(auditLogger *audit.Logger) {
+	s.auditLogger = auditLogger
+}
+
+// GrantConsent records that a user grants a consent
+func (s *ConsentService) GrantConsent(ctx context.Context, userID string, consentType model.ConsentType) (*model.UserConsent, error) {
+	return s.setConsent(ctx, userID, consentType, true)
+}
+
+// RevokeConsent records that a user withdraws a consent. Revoking a consent that was
+// never granted records the refusal.
+func (s *ConsentService) RevokeConsent(ctx context.Context, userID string, consentType model.ConsentType) (*model.UserConsent, error) {
+	return s.setConsent(ctx, userID, consentType, false)
+}
+
+// HasConsent reports whether a user currently grants a consent
+func (s *ConsentService) HasConsent(ctx context.Context, userID string, consentType model.ConsentType) (bool, error) {
+	if !validConsentType(consentType) {
+		return false, fmt.Errorf("%w: %s", ErrInvalidConsentType, consentType)
+	}
+
+	granted, err := s.store.HasConsent(ctx, userID, consentType)
+	if err != nil {
+		return false, fmt.Errorf("failed to check consent: %w", err)
+	}
+	return granted, nil
+}
+
+// ListConsents returns the state of every consent type for a user. Consents the user
+// never answered are listed as not granted.
+func (s *ConsentService) ListConsents(ctx context.Context, userID string) ([]model.UserConsent, error) {
+	answered, err := s.store.GetConsentsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consents: %w", err)
+	}
+
+	byType := make(map[model.ConsentType]model.UserConsent, len(answered))
+	for _, consent := range answered {
+		byType[consent.ConsentType] = consent
+	}
+
+	consents := make([]model.UserConsent, 0, len(model.ConsentTypes))
+	for _, consentType := range model.ConsentTypes {
+		consent, ok := byType[consentType]
+		if !ok {
+			consent = model.UserConsent{UserID: userID, ConsentType: consentType}
+		}
+		consents = append(consents, consent)
+	}
+	return consents, nil
+}
+
+// setConsent validates the consent type and stores the user's answer
+func (s *ConsentService) setConsent(ctx context.Context, userID string, consentType model.ConsentType, granted bool) (*model.UserConsent, error) {
+	if !validConsentType(consentType) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidConsentType, consentType)
+	}
+
+	consent, err := s.store.SetConsent(ctx, userID, consentType, granted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set consent: %w", err)
+	}
+
+	s.logger.Info("consent updated",
+		zap.String("user_id", userID),
+		zap.String("consent_type", string(consentType)),
+		zap.Bool("granted", granted),
+	)
+
+	op := audit.OperationCreate
+	if !granted {
+		op = audit.OperationDelete
+	}
+	s.audit(ctx, userID, op, consentType)
+
+	return consent, nil
+}
+
+// audit records a consent change in the audit log
+func (s *ConsentService) audit(ctx context.Context, userID string, op audit.OperationType, consentType model.ConsentType) {
+	if s.auditLogger == nil {
+		return
+	}
+
+	err := s.auditLogger.Log(ctx, audit.AuditLog{
+		UserID:        userID,
+		OperationType: op,
+		ResourceType:  audit.ResourceUserConsent,
+		ResourceID:    string(consentType),
+	})
+	if err != nil {
+		s.logger.Error("failed to audit consent change", zap.Error(err), zap.String("user_id", userID))
+	}
+}
+
+// validConsentType reports whether consentType is a known consent type
+func validConsentType(consentType model.ConsentType) bool {
+	for _, known := range model.ConsentTypes {
+		if consentType == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// fakeConsentStore is an in-memory ConsentStore
+type fakeConsentStore struct {
+	consents map[string]model.UserConsent // user ID + "/" + consent type
+}
+
+func newFakeConsentStore() *fakeConsentStore {
+	return &fakeConsentStore{consents: make(map[string]model.UserConsent)}
+}
+
+func (f *fakeConsentStore) SetConsent(ctx context.Context, userID string, consentType model.ConsentType, granted bool) (*model.UserConsent, error) {
+	now := time.Now()
+	key := userID + "/" + string(consentType)
+	consent := f.consents[key]
+	consent.UserID = userID
+	consent.ConsentType = consentType
+	if granted && !consent.Granted {
+		consent.GrantedAt = &now
+	}
+	if !granted && consent.Granted {
+		consent.RevokedAt = &now
+	}
+	consent.Granted = granted
+	consent.UpdatedAt = now
+	f.consents[key] = consent
+	return &consent, nil
+}
+
+func (f *fakeConsentStore) GetConsentsByUserID(ctx context.Context, userID string) ([]model.UserConsent, error) {
+	var result []model.UserConsent
+	for _, consent := range f.consents {
+		if consent.UserID == userID {
+			result = append(result, consent)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeConsentStore) HasConsent(ctx context.Context, userID string, consentType model.ConsentType) (bool, error) {
+	return f.consents[userID+"/"+string(consentType)].Granted, nil
+}
+
+func TestConsentService_GrantAndRevoke(t *testing.T) {
+	svc := NewConsentService(newFakeConsentStore(), zap.NewNop())
+	ctx := context.Background()
+
+	_, err := svc.GrantConsent(ctx, "user-1", model.ConsentType("marketing"))
+	assert.ErrorIs(t, err, ErrInvalidConsentType)
+
+	consents, err := svc.ListConsents(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, consents, len(model.ConsentTypes), "unanswered consents are listed")
+	for _, consent := range consents {
+		assert.False(t, consent.Granted)
+	}
+
+	granted, err := svc.GrantConsent(ctx, "user-1", model.ConsentTypeVoiceRecording)
+	require.NoError(t, err)
+	assert.True(t, granted.Granted)
+	require.NotNil(t, granted.GrantedAt)
+
+	has, err := svc.HasConsent(ctx, "user-1", model.ConsentTypeVoiceRecording)
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	has, err = svc.HasConsent(ctx, "user-2", model.ConsentTypeVoiceRecording)
+	require.NoError(t, err)
+	assert.False(t, has, "consent belongs to one user")
+
+	revoked, err := svc.RevokeConsent(ctx, "user-1", model.ConsentTypeVoiceRecording)
+	require.NoError(t, err)
+	assert.False(t, revoked.Granted)
+	assert.NotNil(t, revoked.RevokedAt)
+	assert.Equal(t, granted.GrantedAt, revoked.GrantedAt, "the last grant time is kept")
+
+	has, err = svc.HasConsent(ctx, "user-1", model.ConsentTypeVoiceRecording)
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestStartSession_RequiresVoiceRecordingConsent(t *testing.T) {
+	consents := NewConsentService(newFakeConsentStore(), zap.NewNop())
+	svc := NewCheckInService(nil, nil, nil, nil, zap.NewNop())
+	svc.SetConsentChecker(consents)
+	ctx := context.Background()
+
+	_, err := svc.StartSession(ctx, "user-1")
+	assert.ErrorIs(t, err, ErrConsentRequired)
+
+	_, err = consents.GrantConsent(ctx, "user-1", model.ConsentTypeDataProcessing)
+	require.NoError(t, err)
+	_, err = svc.StartSession(ctx, "user-1")
+	assert.ErrorIs(t, err, ErrConsentRequired, "only voice recording consent allows a check-in")
+}
+
+func TestStartSession_ConsentFlow(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	logger := zap.NewNop()
+	repo := repository.NewCheckInRepository(db, logger)
+	consents := NewConsentService(repository.NewConsentRepository(db, logger), logger)
+
+	// The first question's audio is served from memory so no Speech service is needed
+	svc := NewCheckInService(repo, nil, nil, nil, logger)
+	cache := NewAudioCache(1 << 20)
+	svc.SetAudioCache(cache, "test")
+	firstQuestion := NewQuestionFlow().GetNextQuestion()
+	cache.Add(questionAudioCacheKey("test", svc.Voice(), firstQuestion.ID), []byte("audio"))
+	svc.SetConsentChecker(consents)
+
+	userID := uuid.New().String()
+
+	_, err := svc.StartSession(ctx, userID)
+	require.ErrorIs(t, err, ErrConsentRequired)
+
+	_, err = consents.GrantConsent(ctx, userID, model.ConsentTypeVoiceRecording)
+	require.NoError(t, err)
+
+	started, err := svc.StartSession(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, userID, started.Session.UserID)
+	assert.Equal(t, firstQuestion.ID, started.QuestionID)
+
+	_, err = consents.RevokeConsent(ctx, userID, model.ConsentTypeVoiceRecording)
+	require.NoError(t, err)
+
+	_, err = svc.StartSession(ctx, userID)
+	assert.ErrorIs(t, err, ErrConsentRequired, "revoking consent stops new check-ins")
+
+	listed, err := consents.ListConsents(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, listed, len(model.ConsentTypes))
+	assert.Equal(t, model.ConsentTypeVoiceRecording, listed[1].ConsentType)
+	assert.False(t, listed[1].Granted)
+	assert.NotNil(t, listed[1].GrantedAt)
+	assert.NotNil(t, listed[1].RevokedAt)
+}
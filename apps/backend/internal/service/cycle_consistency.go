@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+const (
+	// cycleSuggestionLookback is how far back check-ins are compared against cycles
+	cycleSuggestionLookback = 90 * 24 * time.Hour
+
+	// cycleToleranceDays is how many days before or after a cycle, or apart from each
+	// other, check-ins with menstrual symptoms may be and still belong to the same cycle
+	cycleToleranceDays = 3
+
+	// openCyclePeriodDays is the period length assumed for a cycle without an end date
+	openCyclePeriodDays = 7
+)
+
+// ErrCycleSuggestionResolved is returned when accepting a suggestion that was already
+// accepted or dismissed
+var ErrCycleSuggestionResolved = errors.New("cycle suggestion was already resolved")
+
+// menstrualSymptomTerms are symptom fragments describing menstruation, in Hungarian and English
+var menstrualSymptomTerms = []string{
+	"menstru",
+	"menzesz",
+	"havi vérzés",
+	"havivérzés",
+	"period pain",
+	"period cramp",
+	"dysmenorrh",
+}
+
+// CycleSuggestionStore defines the persistence operations needed for cycle suggestions
+type CycleSuggestionStore interface {
+	CreateSuggestion(ctx context.Context, suggestion *model.CycleSuggestion) (bool, error)
+	GetSuggestionsByUserID(ctx context.Context, userID string) ([]model.CycleSuggestion, error)
+	GetSuggestion(ctx context.Context, userID, suggestionID string) (*model.CycleSuggestion, error)
+	DismissSuggestion(ctx context.Context, userID, suggestionID string, now time.Time) (bool, error)
+	AcceptSuggestion(ctx context.Context, suggestionID string, cycle *model.MenstruationCycle, now time.Time) (bool, error)
+}
+
+// CheckInSource defines the interface for reading a user's check-ins over a date range
+type CheckInSource interface {
+	GetHealthCheckIns(ctx context.Context, userID string, startDate, endDate time.Time) ([]model.HealthCheckIn, error)
+}
+
+// CycleSource defines the interface for reading a user's menstruation cycles
+type CycleSource interface {
+	GetMenstruationByUserID(ctx context.Context, userID string) ([]model.MenstruationCycle, error)
+}
+
+// CycleConsistencyService checks that check-ins mentioning menstrual symptoms fall
+// within a recorded menstruation cycle, and suggests logging a cycle where they do not
+type CycleConsistencyService struct {
+	store    CycleSuggestionStore
+	checkIns CheckInSource
+	cycles   CycleSource
+	logger   *zap.Logger
+	now      func() time.Time
+}
+
+// NewCycleConsistencyService creates a new CycleConsistencyService
+func NewCycleConsistencyService(store CycleSuggestionStore, checkIns CheckInSource, cycles CycleSource, logger *zap.Logger) *CycleConsistencyService {
+	return &CycleConsistencyService{
+		store:    store,
+		checkIns: checkIns,
+		cycles:   cycles,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// GetSuggestions checks the user's recent check-ins against their cycles, saves a
+// suggestion for every group of check-ins outside them, and returns the open
+// suggestions not covered by a cycle logged since. Check-ins already covered by a
+// suggestion, including dismissed ones, are not suggested again.
+func (s *CycleConsistencyService) GetSuggestions(ctx context.Context, userID string) ([]model.CycleSuggestion, error) {
+	now := s.now()
+	checkIns, err := s.checkIns.GetHealthCheckIns(ctx, userID, now.Add(-cycleSuggestionLookback), now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get check-ins: %w", err)
+	}
+	cycles, err := s.cycles.GetMenstruationByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get menstruation cycles: %w", err)
+	}
+	existing, err := s.store.GetSuggestionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, suggestion := range detectCycleSuggestions(userID, checkIns, cycles, existing) {
+		created, err := s.store.CreateSuggestion(ctx, &suggestion)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			s.logger.Info("cycle suggestion raised",
+				zap.String("user_id", userID),
+				zap.String("suggestion_id", suggestion.ID),
+				zap.Int("check_ins", len(suggestion.CheckInIDs)),
+			)
+			existing = append(existing, suggestion)
+		}
+	}
+
+	open := []model.CycleSuggestion{}
+	for _, suggestion := range existing {
+		if suggestion.Status != model.CycleSuggestionStatusOpen {
+			continue
+		}
+		if coveredByCycle(suggestion.StartDate, cycles) && coveredByCycle(suggestion.EndDate, cycles) {
+			continue
+		}
+		suggestion.Message = cycleSuggestionMessage(suggestion.StartDate)
+		open = append(open, suggestion)
+	}
+	sort.Slice(open, func(i, j int) bool {
+		return open[i].StartDate.After(open[j].StartDate)
+	})
+
+	return open, nil
+}
+
+// AcceptSuggestion logs the cycle an open suggestion proposes, from its first to its last
+// check-in with the menstrual symptoms mentioned, and returns the cycle
+func (s *CycleConsistencyService) AcceptSuggestion(ctx context.Context, userID, suggestionID string) (*model.MenstruationCycle, error) {
+	suggestion, err := s.store.GetSuggestion(ctx, userID, suggestionID)
+	if err != nil {
+		return nil, err
+	}
+	if suggestion.Status != model.CycleSuggestionStatusOpen {
+		return nil, fmt.Errorf("%w: suggestion is %s", ErrCycleSuggestionResolved, suggestion.Status)
+	}
+
+	now := s.now()
+	endDate := suggestion.EndDate
+	cycle := &model.MenstruationCycle{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		StartDate: suggestion.StartDate,
+		EndDate:   &endDate,
+		Symptoms:  suggestion.Symptoms,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	accepted, err := s.store.AcceptSuggestion(ctx, suggestionID, cycle, now)
+	if err != nil {
+		return nil, err
+	}
+	if !accepted {
+		return nil, ErrCycleSuggestionResolved
+	}
+
+	s.logger.Info("cycle suggestion accepted",
+		zap.String("user_id", userID),
+		zap.String("suggestion_id", suggestionID),
+		zap.String("cycle_id", cycle.ID),
+	)
+	return cycle, nil
+}
+
+// DismissSuggestion dismisses a suggestion so it is not shown or raised again
+func (s *CycleConsistencyService) DismissSuggestion(ctx context.Context, userID, suggestionID string) error {
+	found, err := s.store.DismissSuggestion(ctx, userID, suggestionID, s.now())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return repository.ErrCycleSuggestionNotFound
+	}
+	return nil
+}
+
+// detectCycleSuggestions groups the check-ins mentioning menstrual symptoms that are
+// not within cycleToleranceDays of a cycle or an existing suggestion. Check-ins at most
+// cycleToleranceDays apart form one group and one suggestion.
+func detectCycleSuggestions(userID string, checkIns []model.HealthCheckIn, cycles []model.MenstruationCycle, existing []model.CycleSuggestion) []model.CycleSuggestion {
+	var flagged []model.HealthCheckIn
+	for _, checkIn := range checkIns {
+		if len(menstrualSymptoms(checkIn.Symptoms)) == 0 {
+			continue
+		}
+		if coveredByCycle(checkIn.CheckInDate, cycles) || coveredBySuggestion(checkIn.CheckInDate, existing) {
+			continue
+		}
+		flagged = append(flagged, checkIn)
+	}
+	sort.Slice(flagged, func(i, j int) bool {
+		return flagged[i].CheckInDate.Before(flagged[j].CheckInDate)
+	})
+
+	var suggestions []model.CycleSuggestion
+	for _, checkIn := range flagged {
+		n := len(suggestions)
+		if n == 0 || daysBetween(suggestions[n-1].EndDate, checkIn.CheckInDate) > cycleToleranceDays {
+			suggestions = append(suggestions, model.CycleSuggestion{
+				UserID:    userID,
+				StartDate: checkIn.CheckInDate,
+				Status:    model.CycleSuggestionStatusOpen,
+			})
+			n++
+		}
+
+		suggestion := &suggestions[n-1]
+		suggestion.EndDate = checkIn.CheckInDate
+		suggestion.CheckInIDs = append(suggestion.CheckInIDs, checkIn.ID)
+		for _, symptom := range menstrualSymptoms(checkIn.Symptoms) {
+			if !slices.Contains(suggestion.Symptoms, symptom) {
+				suggestion.Symptoms = append(suggestion.Symptoms, symptom)
+			}
+		}
+	}
+
+	return suggestions
+}
+
+// menstrualSymptoms returns the symptoms describing menstruation
+func menstrualSymptoms(symptoms []string) []string {
+	var matched []string
+	for _, symptom := range symptoms {
+		lower := strings.ToLower(symptom)
+		for _, term := range menstrualSymptomTerms {
+			if strings.Contains(lower, term) {
+				matched = append(matched, symptom)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// coveredByCycle reports whether date is within cycleToleranceDays of a cycle. A cycle
+// without an end date is assumed to last openCyclePeriodDays.
+func coveredByCycle(date time.Time, cycles []model.MenstruationCycle) bool {
+	for _, cycle := range cycles {
+		end := cycle.StartDate.AddDate(0, 0, openCyclePeriodDays-1)
+		if cycle.EndDate != nil {
+			end = *cycle.EndDate
+		}
+		if withinDays(date, cycle.StartDate, end, cycleToleranceDays) {
+			return true
+		}
+	}
+	return false
+}
+
+// coveredBySuggestion reports whether date is within cycleToleranceDays of a suggestion
+// in any status
+func coveredBySuggestion(date time.Time, suggestions []model.CycleSuggestion) bool {
+	for _, suggestion := range suggestions {
+		if withinDays(date, suggestion.StartDate, suggestion.EndDate, cycleToleranceDays) {
+			return true
+		}
+	}
+	return false
+}
+
+// withinDays reports whether date falls from days before start to days after end
+func withinDays(date, start, end time.Time, days int) bool {
+	return daysBetween(start, date) >= -days && daysBetween(end, date) <= days
+}
+
+// cycleSuggestionMessage is the prompt shown with a suggestion
+func cycleSuggestionMessage(startDate time.Time) string {
+	return fmt.Sprintf("Log a cycle starting around %s?", startDate.Format(time.DateOnly))
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// fakeCycleSuggestionStore is an in-memory CycleSuggestionStore that also serves the
+// check-ins and cycles the suggestions are derived from
+type fakeCycleSuggestionStore struct {
+	suggestions []*model.CycleSuggestion
+	checkIns    []model.HealthCheckIn
+	cycles      []model.MenstruationCycle
+}
+
+func (f *fakeCycleSuggestionStore) CreateSuggestion(ctx context.Context, suggestion *model.CycleSuggestion) (bool, error) {
+	for _, existing := range f.suggestions {
+		if existing.UserID == suggestion.UserID && existing.StartDate.Equal(suggestion.StartDate) {
+			return false, nil
+		}
+	}
+	suggestion.ID = uuid.New().String()
+	suggestion.Status = model.CycleSuggestionStatusOpen
+	stored := *suggestion
+	f.suggestions = append(f.suggestions, &stored)
+	return true, nil
+}
+
+func (f *fakeCycleSuggestionStore) GetSuggestionsByUserID(ctx context.Context, userID string) ([]model.CycleSuggestion, error) {
+	var result []model.CycleSuggestion
+	for _, suggestion := range f.suggestions {
+		if suggestion.UserID == userID {
+			result = append(result, *suggestion)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeCycleSuggestionStore) GetSuggestion(ctx context.Context, userID, suggestionID string) (*model.CycleSuggestion, error) {
+	for _, suggestion := range f.suggestions {
+		if suggestion.ID == suggestionID && suggestion.UserID == userID {
+			found := *suggestion
+			return &found, nil
+		}
+	}
+	return nil, repository.ErrCycleSuggestionNotFound
+}
+
+func (f *fakeCycleSuggestionStore) DismissSuggestion(ctx context.Context, userID, suggestionID string, now time.Time) (bool, error) {
+	for _, suggestion := range f.suggestions {
+		if suggestion.ID == suggestionID && suggestion.UserID == userID {
+			if suggestion.Status == model.CycleSuggestionStatusOpen {
+				suggestion.Status = model.CycleSuggestionStatusDismissed
+				suggestion.ResolvedAt = &now
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeCycleSuggestionStore) AcceptSuggestion(ctx context.Context, suggestionID string, cycle *model.MenstruationCycle, now time.Time) (bool, error) {
+	for _, suggestion := range f.suggestions {
+		if suggestion.ID == suggestionID && suggestion.UserID == cycle.UserID && suggestion.Status == model.CycleSuggestionStatusOpen {
+			suggestion.Status = model.CycleSuggestionStatusAccepted
+			suggestion.CycleID = &cycle.ID
+			suggestion.ResolvedAt = &now
+			f.cycles = append(f.cycles, *cycle)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeCycleSuggestionStore) GetHealthCheckIns(ctx context.Context, userID string, startDate, endDate time.Time) ([]model.HealthCheckIn, error) {
+	return f.checkIns, nil
+}
+
+func (f *fakeCycleSuggestionStore) GetMenstruationByUserID(ctx context.Context, userID string) ([]model.MenstruationCycle, error) {
+	return f.cycles, nil
+}
+
+// day returns midnight UTC of a March 2026 day
+func day(d int) time.Time {
+	return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC)
+}
+
+func newTestCycleConsistencyService(store *fakeCycleSuggestionStore) *CycleConsistencyService {
+	svc := NewCycleConsistencyService(store, store, store, zap.NewNop())
+	svc.now = func() time.Time { return day(31) }
+	return svc
+}
+
+func TestDetectCycleSuggestions(t *testing.T) {
+	cycleEnd := day(5)
+	checkIns := []model.HealthCheckIn{
+		{ID: "in-cycle", CheckInDate: day(7), Symptoms: []string{"menstruációs görcs"}},
+		{ID: "a2", CheckInDate: day(14), Symptoms: []string{"Period cramps", "fáradtság"}},
+		{ID: "a1", CheckInDate: day(12), Symptoms: []string{"menstrual cramps"}},
+		{ID: "unrelated", CheckInDate: day(13), Symptoms: []string{"fejfájás"}},
+		{ID: "b1", CheckInDate: day(20), Symptoms: []string{"menzesz"}},
+		{ID: "dismissed", CheckInDate: day(27), Symptoms: []string{"menstrual cramps"}},
+	}
+	cycles := []model.MenstruationCycle{{ID: "c1", StartDate: day(1), EndDate: &cycleEnd}}
+	existing := []model.CycleSuggestion{
+		{StartDate: day(26), EndDate: day(26), Status: model.CycleSuggestionStatusDismissed},
+	}
+
+	suggestions := detectCycleSuggestions("user-1", checkIns, cycles, existing)
+	require.Len(t, suggestions, 2)
+
+	assert.Equal(t, day(12), suggestions[0].StartDate)
+	assert.Equal(t, day(14), suggestions[0].EndDate)
+	assert.Equal(t, []string{"a1", "a2"}, suggestions[0].CheckInIDs)
+	assert.Equal(t, []string{"menstrual cramps", "Period cramps"}, suggestions[0].Symptoms)
+
+	assert.Equal(t, day(20), suggestions[1].StartDate, "check-ins more than 3 days apart are separate cycles")
+	assert.Equal(t, []string{"b1"}, suggestions[1].CheckInIDs)
+}
+
+func TestCoveredByCycle_OpenCycle(t *testing.T) {
+	cycles := []model.MenstruationCycle{{StartDate: day(10)}}
+
+	assert.True(t, coveredByCycle(day(7), cycles))
+	assert.True(t, coveredByCycle(day(19), cycles), "an open cycle is assumed to last a week")
+	assert.False(t, coveredByCycle(day(20), cycles))
+	assert.False(t, coveredByCycle(day(6), cycles))
+}
+
+func TestCycleConsistencyService_GetSuggestionsIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	store := &fakeCycleSuggestionStore{checkIns: []model.HealthCheckIn{
+		{ID: "a1", CheckInDate: day(12), Symptoms: []string{"menstrual cramps"}},
+	}}
+	svc := newTestCycleConsistencyService(store)
+
+	first, err := svc.GetSuggestions(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+	assert.Equal(t, "Log a cycle starting around 2026-03-12?", first[0].Message)
+
+	second, err := svc.GetSuggestions(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, second, 1)
+	assert.Equal(t, first[0].ID, second[0].ID)
+	assert.Len(t, store.suggestions, 1)
+
+	// A check-in added next to the suggestion joins it rather than raising another
+	store.checkIns = append(store.checkIns, model.HealthCheckIn{ID: "a0", CheckInDate: day(11), Symptoms: []string{"menzesz"}})
+	third, err := svc.GetSuggestions(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Len(t, third, 1)
+	assert.Len(t, store.suggestions, 1)
+}
+
+func TestCycleConsistencyService_DismissedSuggestionsDoNotReappear(t *testing.T) {
+	ctx := context.Background()
+	store := &fakeCycleSuggestionStore{checkIns: []model.HealthCheckIn{
+		{ID: "a1", CheckInDate: day(12), Symptoms: []string{"menstrual cramps"}},
+	}}
+	svc := newTestCycleConsistencyService(store)
+
+	suggestions, err := svc.GetSuggestions(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, suggestions, 1)
+
+	require.NoError(t, svc.DismissSuggestion(ctx, "user-1", suggestions[0].ID))
+	assert.ErrorIs(t, svc.DismissSuggestion(ctx, "user-2", suggestions[0].ID), repository.ErrCycleSuggestionNotFound)
+
+	suggestions, err = svc.GetSuggestions(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Empty(t, suggestions)
+	assert.Len(t, store.suggestions, 1)
+}
+
+func TestCycleConsistencyService_AcceptSuggestion(t *testing.T) {
+	ctx := context.Background()
+	store := &fakeCycleSuggestionStore{checkIns: []model.HealthCheckIn{
+		{ID: "a1", CheckInDate: day(12), Symptoms: []string{"menstrual cramps"}},
+		{ID: "a2", CheckInDate: day(13), Symptoms: []string{"menstrual cramps"}},
+	}}
+	svc := newTestCycleConsistencyService(store)
+
+	suggestions, err := svc.GetSuggestions(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, suggestions, 1)
+
+	cycle, err := svc.AcceptSuggestion(ctx, "user-1", suggestions[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, day(12), cycle.StartDate)
+	require.NotNil(t, cycle.EndDate)
+	assert.Equal(t, day(13), *cycle.EndDate)
+	assert.Equal(t, []string{"menstrual cramps"}, cycle.Symptoms)
+	assert.Equal(t, &cycle.ID, store.suggestions[0].CycleID)
+
+	_, err = svc.AcceptSuggestion(ctx, "user-1", suggestions[0].ID)
+	assert.ErrorIs(t, err, ErrCycleSuggestionResolved, "a suggestion creates one cycle")
+
+	suggestions, err = svc.GetSuggestions(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Empty(t, suggestions)
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// ErrInsufficientCycles is returned when too few cycles are logged to predict the next one
+var ErrInsufficientCycles = errors.New("at least 3 cycles are required for a prediction")
+
+const (
+	// minPredictionCycles is the number of logged cycles needed for a prediction
+	minPredictionCycles = 3
+
+	// Cycle counts from which a prediction has medium and high confidence
+	mediumConfidenceCycles = 6
+	highConfidenceCycles   = 12
+)
+
+// PredictNextCycle predicts when a user's next menstruation cycle starts from the
+// intervals between logged cycle starts
+func (s *HealthDataService) PredictNextCycle(ctx context.Context, userID string) (*model.CyclePrediction, error) {
+	ctx, span := telemetry.StartSpan(ctx, "HealthDataService.PredictNextCycle")
+	defer span.End()
+
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	cycles, err := s.repo.GetMenstruationByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to get menstruation cycles for prediction",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		return nil, fmt.Errorf("failed to get menstruation cycles: %w", err)
+	}
+
+	prediction, err := PredictCycle(cycles)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("cycle prediction computed",
+		zap.String("user_id", userID),
+		zap.String("confidence", prediction.Confidence),
+	)
+
+	return prediction, nil
+}
+
+// PredictCycle predicts the next cycle start as the latest logged start plus the average
+// cycle length. Unlike ComputeCycleStats it also uses a cycle that is still open, since
+// only start dates matter; cycles logged twice for the same day count once.
+func PredictCycle(cycles []model.MenstruationCycle) (*model.CyclePrediction, error) {
+	starts := uniqueCycleStarts(cycles)
+	if len(starts) < minPredictionCycles {
+		return nil, fmt.Errorf("%w: %d logged", ErrInsufficientCycles, len(starts))
+	}
+
+	lengths := make([]float64, 0, len(starts)-1)
+	var total float64
+	for i := 1; i < len(starts); i++ {
+		length := float64(daysBetween(starts[i-1], starts[i]))
+		lengths = append(lengths, length)
+		total += length
+	}
+	mean := total / float64(len(lengths))
+
+	var variance float64
+	for _, length := range lengths {
+		variance += (length - mean) * (length - mean)
+	}
+	stdDev := math.Sqrt(variance / float64(len(lengths)))
+
+	latest := starts[len(starts)-1]
+	return &model.CyclePrediction{
+		PredictedStartDate: latest.AddDate(0, 0, int(math.Round(mean))),
+		AverageCycleLength: mean,
+		RegularityScore:    math.Min(stdDev/mean, 1),
+		Confidence:         predictionConfidence(len(starts)),
+	}, nil
+}
+
+// uniqueCycleStarts returns the distinct start days of cycles in chronological order
+func uniqueCycleStarts(cycles []model.MenstruationCycle) []time.Time {
+	seen := make(map[time.Time]bool, len(cycles))
+	starts := make([]time.Time, 0, len(cycles))
+	for _, cycle := range cycles {
+		day := time.Date(cycle.StartDate.Year(), cycle.StartDate.Month(), cycle.StartDate.Day(), 0, 0, 0, 0, time.UTC)
+		if !seen[day] {
+			seen[day] = true
+			starts = append(starts, day)
+		}
+	}
+	sort.Slice(starts, func(i, j int) bool {
+		return starts[i].Before(starts[j])
+	})
+	return starts
+}
+
+// predictionConfidence grades a prediction by the number of cycles it is based on
+func predictionConfidence(cycles int) string {
+	switch {
+	case cycles >= highConfidenceCycles:
+		return "high"
+	case cycles >= mediumConfidenceCycles:
+		return "medium"
+	default:
+		return "low"
+	}
+}
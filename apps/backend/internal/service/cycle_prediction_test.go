@@ -0,0 +1,73 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+)
+
+func TestPredictCycle(t *testing.T) {
+	jan1 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := PredictCycle([]model.MenstruationCycle{
+		cycleOn("c2", jan1.AddDate(0, 0, 28), 5),
+		cycleOn("c1", jan1, 5),
+		cycleOn("c1-again", jan1, 5),
+	})
+	assert.ErrorIs(t, err, ErrInsufficientCycles, "a cycle logged twice counts once")
+
+	// Newest first, as returned by the repository; the open cycle counts
+	prediction, err := PredictCycle([]model.MenstruationCycle{
+		cycleOn("open", jan1.AddDate(0, 0, 88), 0),
+		cycleOn("c3", jan1.AddDate(0, 0, 58), 6),
+		cycleOn("c2", jan1.AddDate(0, 0, 28), 5),
+		cycleOn("c1", jan1, 5),
+	})
+	require.NoError(t, err)
+
+	// Lengths 28, 30 and 30
+	assert.InDelta(t, 29.333, prediction.AverageCycleLength, 0.001)
+	assert.Equal(t, jan1.AddDate(0, 0, 88+29), prediction.PredictedStartDate)
+	assert.InDelta(t, 0.943/29.333, prediction.RegularityScore, 0.001)
+	assert.Equal(t, "low", prediction.Confidence)
+}
+
+func TestPredictionConfidence(t *testing.T) {
+	assert.Equal(t, "low", predictionConfidence(3))
+	assert.Equal(t, "medium", predictionConfidence(6))
+	assert.Equal(t, "high", predictionConfidence(12))
+}
+
+// Property: the regularity score is always between 0 and 1
+func TestProperty_CycleRegularityScoreBounded(t *testing.T) {
+	properties := gopter.NewProperties(nil)
+
+	properties.Property("regularity score is within [0, 1]", prop.ForAll(
+		func(first, second int, more []int) bool {
+			lengths := append([]int{first, second}, more...)
+			start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			cycles := []model.MenstruationCycle{cycleOn("c0", start, 5)}
+			for i, length := range lengths {
+				start = start.AddDate(0, 0, length)
+				cycles = append(cycles, cycleOn(string(rune('a'+i)), start, 5))
+			}
+
+			prediction, err := PredictCycle(cycles)
+			if err != nil {
+				return false
+			}
+			return prediction.RegularityScore >= 0 && prediction.RegularityScore <= 1
+		},
+		gen.IntRange(1, 120),
+		gen.IntRange(1, 120),
+		gen.SliceOf(gen.IntRange(1, 120)),
+	))
+
+	properties.TestingRun(t)
+}
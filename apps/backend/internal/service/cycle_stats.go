@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// GetCycleStats computes cycle statistics from a user's completed menstruation cycles
+func (s *HealthDataService) GetCycleStats(ctx context.Context, userID string) (*model.CycleStats, error) {
+	ctx, span := telemetry.StartSpan(ctx, "HealthDataService.GetCycleStats")
+	defer span.End()
+
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	cycles, err := s.repo.GetMenstruationByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to get menstruation cycles for stats",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		return nil, fmt.Errorf("failed to get menstruation cycles: %w", err)
+	}
+
+	stats := ComputeCycleStats(cycles)
+
+	s.logger.Info("cycle stats computed",
+		zap.String("user_id", userID),
+		zap.Int("completed_cycles", stats.CompletedCycles),
+	)
+
+	return stats, nil
+}
+
+// ComputeCycleStats computes cycle statistics from completed cycles; open cycles are ignored.
+// A cycle's length runs from its start to the start of the next completed cycle, and its
+// period duration from start to end inclusive. Cycle length figures need two completed cycles.
+func ComputeCycleStats(cycles []model.MenstruationCycle) *model.CycleStats {
+	var completed []model.MenstruationCycle
+	for _, cycle := range cycles {
+		if cycle.EndDate != nil {
+			completed = append(completed, cycle)
+		}
+	}
+	sort.Slice(completed, func(i, j int) bool {
+		return completed[i].StartDate.Before(completed[j].StartDate)
+	})
+
+	stats := &model.CycleStats{
+		CompletedCycles: len(completed),
+		SufficientData:  len(completed) >= 2,
+		Cycles:          make([]model.CycleLength, 0, len(completed)),
+	}
+
+	var lengths []int
+	var totalPeriod int
+	for i, cycle := range completed {
+		entry := model.CycleLength{
+			CycleID:        cycle.ID,
+			StartDate:      cycle.StartDate,
+			EndDate:        *cycle.EndDate,
+			PeriodDuration: daysBetween(cycle.StartDate, *cycle.EndDate) + 1,
+		}
+		if i+1 < len(completed) {
+			length := daysBetween(cycle.StartDate, completed[i+1].StartDate)
+			entry.CycleLength = &length
+			lengths = append(lengths, length)
+		}
+		totalPeriod += entry.PeriodDuration
+		stats.Cycles = append(stats.Cycles, entry)
+	}
+
+	if len(completed) > 0 {
+		avg := float64(totalPeriod) / float64(len(completed))
+		stats.AveragePeriodDuration = &avg
+	}
+
+	if len(lengths) == 0 {
+		return stats
+	}
+
+	shortest, longest, total := lengths[0], lengths[0], 0
+	for _, length := range lengths {
+		shortest = min(shortest, length)
+		longest = max(longest, length)
+		total += length
+	}
+	mean := float64(total) / float64(len(lengths))
+
+	var variance float64
+	for _, length := range lengths {
+		variance += (float64(length) - mean) * (float64(length) - mean)
+	}
+	stdDev := math.Sqrt(variance / float64(len(lengths)))
+
+	stats.AverageCycleLength = &mean
+	stats.CycleLengthStdDev = &stdDev
+	stats.ShortestCycle = &shortest
+	stats.LongestCycle = &longest
+
+	return stats
+}
+
+// daysBetween returns the number of calendar days from a to b
+func daysBetween(a, b time.Time) int {
+	a = time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	b = time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+)
+
+func cycleOn(id string, start time.Time, periodDays int) model.MenstruationCycle {
+	cycle := model.MenstruationCycle{ID: id, StartDate: start}
+	if periodDays > 0 {
+		end := start.AddDate(0, 0, periodDays-1)
+		cycle.EndDate = &end
+	}
+	return cycle
+}
+
+func TestComputeCycleStats(t *testing.T) {
+	jan1 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Newest first, as returned by the repository; the open cycle is ignored
+	cycles := []model.MenstruationCycle{
+		cycleOn("open", jan1.AddDate(0, 0, 90), 0),
+		cycleOn("c4", jan1.AddDate(0, 0, 86), 4),
+		cycleOn("c3", jan1.AddDate(0, 0, 56), 6),
+		cycleOn("c2", jan1.AddDate(0, 0, 28), 5),
+		cycleOn("c1", jan1, 5),
+	}
+
+	stats := ComputeCycleStats(cycles)
+
+	assert.Equal(t, 4, stats.CompletedCycles)
+	assert.True(t, stats.SufficientData)
+	require.Len(t, stats.Cycles, 4)
+	assert.Equal(t, "c1", stats.Cycles[0].CycleID)
+	assert.Equal(t, 28, *stats.Cycles[0].CycleLength)
+	assert.Equal(t, 5, stats.Cycles[0].PeriodDuration)
+	assert.Nil(t, stats.Cycles[3].CycleLength, "latest cycle has no length yet")
+
+	// Lengths 28, 28, 30
+	assert.InDelta(t, 28.667, *stats.AverageCycleLength, 0.001)
+	assert.InDelta(t, 0.943, *stats.CycleLengthStdDev, 0.001)
+	assert.Equal(t, 28, *stats.ShortestCycle)
+	assert.Equal(t, 30, *stats.LongestCycle)
+	assert.InDelta(t, 5.0, *stats.AveragePeriodDuration, 0.001)
+}
+
+func TestComputeCycleStats_InsufficientData(t *testing.T) {
+	jan1 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	stats := ComputeCycleStats(nil)
+	assert.Equal(t, 0, stats.CompletedCycles)
+	assert.False(t, stats.SufficientData)
+	assert.Empty(t, stats.Cycles)
+	assert.Nil(t, stats.AveragePeriodDuration)
+	assert.Nil(t, stats.AverageCycleLength)
+
+	// One completed and one open cycle: period duration is known, cycle length is not
+	stats = ComputeCycleStats([]model.MenstruationCycle{
+		cycleOn("open", jan1.AddDate(0, 0, 29), 0),
+		cycleOn("c1", jan1, 4),
+	})
+	assert.Equal(t, 1, stats.CompletedCycles)
+	assert.False(t, stats.SufficientData)
+	require.NotNil(t, stats.AveragePeriodDuration)
+	assert.Equal(t, 4.0, *stats.AveragePeriodDuration)
+	assert.Nil(t, stats.AverageCycleLength)
+	assert.Nil(t, stats.CycleLengthStdDev)
+	assert.Nil(t, stats.ShortestCycle)
+	assert.Nil(t, stats.LongestCycle)
+}
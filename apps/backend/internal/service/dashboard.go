@@ -3,21 +3,59 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 	"go.uber.org/zap"
 )
 
 // DashboardRepositoryInterface defines the interface for dashboard data access
 type DashboardRepositoryInterface interface {
 	GetAggregatedMetrics(ctx context.Context, userID string, days int) (*repository.AggregatedMetrics, error)
+	GetAggregatedMetricsForPeriod(ctx context.Context, userID string, start, end time.Time) (*repository.AggregatedMetrics, error)
 	GetDailyMetrics(ctx context.Context, userID string, days int) ([]repository.DailyMetrics, error)
 }
 
+// AlertSummaryProvider defines the interface for alert counts shown on the dashboard
+type AlertSummaryProvider interface {
+	GetAlertSummary(ctx context.Context, userID string, limit int) (*repository.AlertSummary, error)
+}
+
+// MedicationAdherenceSource defines the interface for medication adherence shown on the dashboard
+type MedicationAdherenceSource interface {
+	FindByUserID(ctx context.Context, userID string) ([]model.Medication, error)
+	GetAdherenceRate(ctx context.Context, medicationID string, start, end time.Time) (*repository.MedicationAdherence, error)
+}
+
+// MedicationDoseSource defines the interface for the logged doses the adherence block is
+// computed from
+type MedicationDoseSource interface {
+	GetMedicationDoseCounts(ctx context.Context, userID string, start, end time.Time) ([]repository.MedicationDoseCount, error)
+}
+
+// BloodPressureSource defines the interface for the readings the blood pressure
+// category distribution is computed from
+type BloodPressureSource interface {
+	StreamBloodPressureByUserID(ctx context.Context, userID string, start, end time.Time, fn func(model.BloodPressureReading) error) error
+}
+
+// FitnessDataSource defines the interface for the synced fitness data the sleep and
+// weight figures are computed from
+type FitnessDataSource interface {
+	GetFitnessDataByUserID(ctx context.Context, userID string, startDate, endDate time.Time, dataType string) ([]model.FitnessDataPoint, error)
+}
+
 // DashboardService manages dashboard data aggregation and trends
 type DashboardService struct {
-	repo   DashboardRepositoryInterface
-	logger *zap.Logger
+	repo          DashboardRepositoryInterface
+	alerts        AlertSummaryProvider
+	medications   MedicationAdherenceSource
+	doses         MedicationDoseSource
+	bloodPressure BloodPressureSource
+	fitness       FitnessDataSource
+	logger        *zap.Logger
 }
 
 // NewDashboardService creates a new DashboardService
@@ -28,16 +66,113 @@ func NewDashboardService(repo DashboardRepositoryInterface, logger *zap.Logger)
 	}
 }
 
+// SetAlertSource enables the alerts block in the dashboard summary
+func (s *DashboardService) SetAlertSource(alerts AlertSummaryProvider) {
+	s.alerts = alerts
+}
+
+// SetAdherenceSource enables per-medication adherence scores in the dashboard summary
+func (s *DashboardService) SetAdherenceSource(medications MedicationAdherenceSource) {
+	s.medications = medications
+}
+
+// SetDoseSource enables the adherence block in the dashboard summary
+func (s *DashboardService) SetDoseSource(doses MedicationDoseSource) {
+	s.doses = doses
+}
+
+// SetBloodPressureSource enables the blood pressure category distribution in the
+// dashboard summary
+func (s *DashboardService) SetBloodPressureSource(bloodPressure BloodPressureSource) {
+	s.bloodPressure = bloodPressure
+}
+
+// SetFitnessSource enables the average sleep and latest weight in the dashboard summary
+func (s *DashboardService) SetFitnessSource(fitness FitnessDataSource) {
+	s.fitness = fitness
+}
+
 // DashboardSummary represents aggregated dashboard data
 type DashboardSummary struct {
-	Period           string                    `json:"period"`
-	AveragePain      float64                   `json:"average_pain"`
-	MoodDistribution map[string]int            `json:"mood_distribution"`
-	EnergyLevels     map[string]int            `json:"energy_levels"`
-	CheckInCount     int                       `json:"check_in_count"`
-	TimeSeriesData   []repository.DailyMetrics `json:"time_series_data"`
+	Period            string                           `json:"period"`
+	AveragePain       float64                          `json:"average_pain"`
+	MoodDistribution  map[string]int                   `json:"mood_distribution"`
+	EnergyLevels      map[string]int                   `json:"energy_levels"`
+	MedicationTaken   map[string]int                   `json:"medication_taken"`
+	CheckInCount      int                              `json:"check_in_count"`
+	LowConfidenceRate float64                          `json:"low_confidence_rate"` // share of check-ins with a low confidence extraction
+	TimeSeriesData    []repository.DailyMetrics        `json:"time_series_data"`
+	Alerts            *repository.AlertSummary         `json:"alerts,omitempty"`
+	AdherenceScores   []repository.MedicationAdherence `json:"adherence_scores,omitempty"`
+	Adherence         *AdherenceSummary                `json:"adherence,omitempty"`
+	Comparison        *SummaryComparison               `json:"comparison,omitempty"`
+
+	// Trends against the preceding window of the same length
+	PainTrend         MetricTrend `json:"pain_trend"`
+	MoodTrend         MetricTrend `json:"mood_trend"` // positive mood share in [0, 1]
+	CheckInCountTrend MetricTrend `json:"check_in_count_trend"`
+
+	BloodPressureCategories map[model.BPCategory]int `json:"blood_pressure_categories,omitempty"`
+	BloodPressureTrend      *BloodPressureTrend      `json:"blood_pressure_trend,omitempty"`
+
+	// From synced fitness data, nil without sleep or weight data in the period
+	AverageSleepMinutes *float64 `json:"average_sleep_minutes,omitempty"`
+	LatestWeightKg      *float64 `json:"latest_weight_kg,omitempty"`
+}
+
+// AdherenceSummary is the share of the expected medication doses that were logged as
+// taken in the summary period. Rate is nil when no dose was expected.
+type AdherenceSummary struct {
+	Rate        *float64                  `json:"rate"`
+	Medications []MedicationAdherenceRate `json:"medications"`
+}
+
+// MedicationAdherenceRate is the adherence of a medication, with the doses expected
+// derived from its frequency. Expected and Rate are nil when the frequency is not
+// recognized; Rate is also nil when no dose was expected.
+type MedicationAdherenceRate struct {
+	MedicationID string   `json:"medication_id"`
+	Name         string   `json:"name"`
+	Frequency    string   `json:"frequency"`
+	Expected     *int     `json:"expected"`
+	Taken        int      `json:"taken"`
+	Rate         *float64 `json:"rate"` // taken/expected in [0, 1]
 }
 
+// SummaryComparison is the change from the preceding period of the same length. The pain,
+// mood and energy changes are 0 when either period has no data for them.
+type SummaryComparison struct {
+	PainDelta              float64 `json:"pain_delta"`
+	MoodImprovementPercent float64 `json:"mood_improvement_percent"` // change of the positive mood share in percentage points
+	CheckInCountDelta      int     `json:"check_in_count_delta"`
+	EnergyDelta            float64 `json:"energy_delta"` // change of the average energy on a 1 (low) to 3 (high) scale
+}
+
+// MetricTrend is the change of a summary metric from the preceding window of the same
+// length. Previous and Delta are nil when a window has no data for the metric;
+// PercentChange is also nil when the previous value is 0, where it is undefined.
+type MetricTrend struct {
+	Previous      *float64 `json:"previous"`
+	Delta         *float64 `json:"delta"`
+	PercentChange *float64 `json:"percent_change"` // Delta relative to Previous, in percent
+}
+
+// SummaryOptions holds per-request options for the dashboard summary
+type SummaryOptions struct {
+	// ComparePrevious adds the comparison with the preceding period
+	ComparePrevious bool
+}
+
+// energyScores maps energy levels onto a scale for averaging
+var energyScores = map[string]float64{
+	"low":    1,
+	"medium": 2,
+	"high":   3,
+}
+
+// dashboardAlertLimit is the number of open alerts included in the dashboard summary
+const dashboardAlertLimit = 5
+
 // TrendAnalysis represents trend analysis data
 type TrendAnalysis struct {
 	Period           string                    `json:"period"`
@@ -49,9 +184,19 @@ type TrendAnalysis struct {
 
 // GetSummary retrieves dashboard summary with time range filtering
 func (s *DashboardService) GetSummary(ctx context.Context, userID string, days int) (*DashboardSummary, error) {
+	return s.GetSummaryWithOptions(ctx, userID, days, SummaryOptions{})
+}
+
+// GetSummaryWithOptions retrieves dashboard summary with time range filtering, optionally
+// compared with the preceding period of the same length
+func (s *DashboardService) GetSummaryWithOptions(ctx context.Context, userID string, days int, opts SummaryOptions) (*DashboardSummary, error) {
+	ctx, span := telemetry.StartSpan(ctx, "DashboardService.GetSummaryWithOptions")
+	defer span.End()
+
 	s.logger.Info("getting dashboard summary",
 		zap.String("user_id", userID),
 		zap.Int("days", days),
+		zap.Bool("compare_previous", opts.ComparePrevious),
 	)
 
 	// Validate days parameter
@@ -82,6 +227,19 @@ func (s *DashboardService) GetSummary(ctx context.Context, userID string, days i
 		return nil, fmt.Errorf("failed to get daily metrics: %w", err)
 	}
 
+	previous, err := s.getPreviousPeriodMetrics(ctx, userID, days)
+	if err != nil {
+		return nil, err
+	}
+
+	var comparison *SummaryComparison
+	if opts.ComparePrevious {
+		comparison = compareMetrics(metrics, previous)
+	}
+
+	sleepWeight := s.getSleepAndWeight(ctx, userID, days)
+	bpTrend := s.getBloodPressureTrend(ctx, userID)
+
 	// Handle empty datasets gracefully
 	if metrics.CheckInCount == 0 {
 		s.logger.Info("no check-ins found for user in time period",
@@ -93,18 +251,47 @@ func (s *DashboardService) GetSummary(ctx context.Context, userID string, days i
 			AveragePain:      0,
 			MoodDistribution: make(map[string]int),
 			EnergyLevels:     make(map[string]int),
+			MedicationTaken:  make(map[string]int),
 			CheckInCount:     0,
 			TimeSeriesData:   []repository.DailyMetrics{},
+			Alerts:           s.getAlertSummary(ctx, userID),
+			AdherenceScores:  s.getAdherenceScores(ctx, userID, days),
+			Adherence:        s.getAdherence(ctx, userID, days),
+			Comparison:       comparison,
+
+			PainTrend:         painTrend(metrics, previous),
+			MoodTrend:         moodTrend(metrics, previous),
+			CheckInCountTrend: checkInCountTrend(metrics, previous),
+
+			BloodPressureCategories: s.getBloodPressureCategories(ctx, userID, days),
+			BloodPressureTrend:      bpTrend,
+			AverageSleepMinutes:     sleepWeight.AverageSleepMinutes,
+			LatestWeightKg:          sleepWeight.LatestWeightKg,
 		}, nil
 	}
 
 	summary := &DashboardSummary{
-		Period:           fmt.Sprintf("%d days", days),
-		AveragePain:      metrics.AveragePainLevel,
-		MoodDistribution: metrics.MoodDistribution,
-		EnergyLevels:     metrics.EnergyLevels,
-		CheckInCount:     metrics.CheckInCount,
-		TimeSeriesData:   dailyMetrics,
+		Period:            fmt.Sprintf("%d days", days),
+		AveragePain:       metrics.AveragePainLevel,
+		MoodDistribution:  metrics.MoodDistribution,
+		EnergyLevels:      metrics.EnergyLevels,
+		MedicationTaken:   mergeMedicationTaken(metrics.MedicationTaken),
+		CheckInCount:      metrics.CheckInCount,
+		LowConfidenceRate: float64(metrics.LowConfidence) / float64(metrics.CheckInCount),
+		TimeSeriesData:    normalizeDailyMetrics(dailyMetrics),
+		Alerts:            s.getAlertSummary(ctx, userID),
+		AdherenceScores:   s.getAdherenceScores(ctx, userID, days),
+		Adherence:         s.getAdherence(ctx, userID, days),
+		Comparison:        comparison,
+
+		PainTrend:         painTrend(metrics, previous),
+		MoodTrend:         moodTrend(metrics, previous),
+		CheckInCountTrend: checkInCountTrend(metrics, previous),
+
+		BloodPressureCategories: s.getBloodPressureCategories(ctx, userID, days),
+		BloodPressureTrend:      bpTrend,
+		AverageSleepMinutes:     sleepWeight.AverageSleepMinutes,
+		LatestWeightKg:          sleepWeight.LatestWeightKg,
 	}
 
 	s.logger.Info("dashboard summary retrieved successfully",
@@ -117,6 +304,9 @@ func (s *DashboardService) GetSummary(ctx context.Context, userID string, days i
 
 // GetTrends retrieves trend analysis with aggregations
 func (s *DashboardService) GetTrends(ctx context.Context, userID string, days int) (*TrendAnalysis, error) {
+	ctx, span := telemetry.StartSpan(ctx, "DashboardService.GetTrends")
+	defer span.End()
+
 	s.logger.Info("getting trend analysis",
 		zap.String("user_id", userID),
 		zap.Int("days", days),
@@ -170,7 +360,7 @@ func (s *DashboardService) GetTrends(ctx context.Context, userID string, days in
 		AveragePain:      metrics.AveragePainLevel,
 		MoodDistribution: metrics.MoodDistribution,
 		EnergyLevels:     metrics.EnergyLevels,
-		TimeSeriesData:   dailyMetrics,
+		TimeSeriesData:   normalizeDailyMetrics(dailyMetrics),
 	}
 
 	s.logger.Info("trend analysis retrieved successfully",
@@ -180,3 +370,339 @@ func (s *DashboardService) GetTrends(ctx context.Context, userID string, days in
 
 	return trends, nil
 }
+
+// getAlertSummary loads open alerts for the dashboard; failures are logged and do not fail the summary
+func (s *DashboardService) getAlertSummary(ctx context.Context, userID string) *repository.AlertSummary {
+	if s.alerts == nil {
+		return nil
+	}
+
+	summary, err := s.alerts.GetAlertSummary(ctx, userID, dashboardAlertLimit)
+	if err != nil {
+		s.logger.Warn("failed to get alert summary for dashboard",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		return nil
+	}
+
+	return summary
+}
+
+// getAdherenceScores scores active medications prescribed during the last days;
+// failures are logged and do not fail the summary
+func (s *DashboardService) getAdherenceScores(ctx context.Context, userID string, days int) []repository.MedicationAdherence {
+	if s.medications == nil {
+		return nil
+	}
+
+	medications, err := s.medications.FindByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Warn("failed to get medications for adherence scores",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		return nil
+	}
+
+	end := time.Now()
+	start := end.AddDate(0, 0, -days)
+
+	var scores []repository.MedicationAdherence
+	for _, med := range medications {
+		if !med.Active || med.StartDate.After(end) || (med.EndDate != nil && med.EndDate.Before(start)) {
+			continue
+		}
+
+		adherence, err := s.medications.GetAdherenceRate(ctx, med.ID, start, end)
+		if err != nil {
+			s.logger.Warn("failed to get medication adherence",
+				zap.Error(err),
+				zap.String("medication_id", med.ID),
+			)
+			continue
+		}
+		scores = append(scores, *adherence)
+	}
+
+	return scores
+}
+
+// getAdherence returns the adherence block of the summary, or nil when it is not enabled
+// or the dose counts could not be read
+func (s *DashboardService) getAdherence(ctx context.Context, userID string, days int) *AdherenceSummary {
+	if s.doses == nil {
+		return nil
+	}
+
+	end := time.Now()
+	start := end.AddDate(0, 0, -days)
+
+	counts, err := s.doses.GetMedicationDoseCounts(ctx, userID, start, end)
+	if err != nil {
+		s.logger.Warn("failed to get medication dose counts",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		return nil
+	}
+
+	return computeAdherenceSummary(counts, start, end)
+}
+
+// getBloodPressureCategories counts the user's blood pressure readings of the last days
+// per AHA category, every category included. It returns nil when no blood pressure source
+// is configured or the readings are unavailable.
+func (s *DashboardService) getBloodPressureCategories(ctx context.Context, userID string, days int) map[model.BPCategory]int {
+	if s.bloodPressure == nil {
+		return nil
+	}
+
+	end := time.Now()
+	start := end.AddDate(0, 0, -days)
+
+	counts := make(map[model.BPCategory]int, len(model.BPCategories))
+	for _, category := range model.BPCategories {
+		counts[category] = 0
+	}
+	err := s.bloodPressure.StreamBloodPressureByUserID(ctx, userID, start, end, func(reading model.BloodPressureReading) error {
+		counts[reading.Category()]++
+		return nil
+	})
+	if err != nil {
+		s.logger.Warn("failed to get blood pressure readings",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		return nil
+	}
+
+	return counts
+}
+
+// getBloodPressureTrend compares the user's average blood pressure of the last 7 days with
+// the 7 days before. It returns nil when no blood pressure source is configured or the
+// readings are unavailable.
+func (s *DashboardService) getBloodPressureTrend(ctx context.Context, userID string) *BloodPressureTrend {
+	if s.bloodPressure == nil {
+		return nil
+	}
+
+	now := time.Now()
+	start := now.AddDate(0, 0, -2*bpTrendWindowDays)
+
+	var readings []model.BloodPressureReading
+	err := s.bloodPressure.StreamBloodPressureByUserID(ctx, userID, start, now, func(reading model.BloodPressureReading) error {
+		readings = append(readings, reading)
+		return nil
+	})
+	if err != nil {
+		s.logger.Warn("failed to get blood pressure readings",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		return nil
+	}
+
+	return computeBloodPressureTrend(readings, now)
+}
+
+// getSleepAndWeight summarizes the user's sleep and weight data of the last days. Its
+// fields are nil when no fitness source is configured or the data is unavailable.
+func (s *DashboardService) getSleepAndWeight(ctx context.Context, userID string, days int) model.SleepWeightSummary {
+	if s.fitness == nil {
+		return model.SleepWeightSummary{}
+	}
+
+	end := time.Now()
+	start := end.AddDate(0, 0, -days)
+
+	points, err := s.fitness.GetFitnessDataByUserID(ctx, userID, start, end, "")
+	if err != nil {
+		s.logger.Warn("failed to get fitness data",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		return model.SleepWeightSummary{}
+	}
+
+	return model.SummarizeSleepAndWeight(points)
+}
+
+// computeAdherenceSummary scores the doses taken from start through end against the doses
+// each medication's frequency expects while it was prescribed. Doses logged beyond the
+// expected ones do not count.
+func computeAdherenceSummary(counts []repository.MedicationDoseCount, start, end time.Time) *AdherenceSummary {
+	summary := &AdherenceSummary{Medications: make([]MedicationAdherenceRate, 0, len(counts))}
+
+	var totalExpected, totalTaken int
+	for _, count := range counts {
+		med := count.Medication
+		rate := MedicationAdherenceRate{
+			MedicationID: med.ID,
+			Name:         med.Name,
+			Frequency:    med.Frequency,
+			Taken:        count.Taken,
+		}
+
+		// As-needed medications have no expected doses to compare with
+		if schedule, err := ParseFrequency(med.Frequency); err == nil && !schedule.AsNeeded {
+			from, to := repository.PrescribedWindow(&med, start, end)
+			expected := repository.ExpectedDoses(schedule, from, to)
+			rate.Expected = &expected
+			if expected > 0 {
+				taken := min(count.Taken, expected)
+				rate.Rate = adherenceRatio(taken, expected)
+				totalExpected += expected
+				totalTaken += taken
+			}
+		}
+
+		summary.Medications = append(summary.Medications, rate)
+	}
+
+	if totalExpected > 0 {
+		summary.Rate = adherenceRatio(totalTaken, totalExpected)
+	}
+	return summary
+}
+
+// adherenceRatio returns part/whole
+func adherenceRatio(part, whole int) *float64 {
+	r := float64(part) / float64(whole)
+	return &r
+}
+
+// getPreviousPeriodMetrics aggregates the days before the current period
+func (s *DashboardService) getPreviousPeriodMetrics(ctx context.Context, userID string, days int) (*repository.AggregatedMetrics, error) {
+	end := time.Now().AddDate(0, 0, -days)
+	start := end.AddDate(0, 0, -days)
+
+	previous, err := s.repo.GetAggregatedMetricsForPeriod(ctx, userID, start, end)
+	if err != nil {
+		s.logger.Error("failed to get aggregated metrics for previous period",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		return nil, fmt.Errorf("failed to get aggregated metrics for previous period: %w", err)
+	}
+
+	return previous, nil
+}
+
+// painTrend is the change of the average pain level. Periods without pain data average 0.
+func painTrend(current, previous *repository.AggregatedMetrics) MetricTrend {
+	return newMetricTrend(current.AveragePainLevel, current.AveragePainLevel > 0,
+		previous.AveragePainLevel, previous.AveragePainLevel > 0)
+}
+
+// moodTrend is the change of the positive mood share
+func moodTrend(current, previous *repository.AggregatedMetrics) MetricTrend {
+	currentMood, currentOK := positiveMoodShare(current.MoodDistribution)
+	previousMood, previousOK := positiveMoodShare(previous.MoodDistribution)
+	return newMetricTrend(currentMood, currentOK, previousMood, previousOK)
+}
+
+// checkInCountTrend is the change of the number of check-ins, which is always known
+func checkInCountTrend(current, previous *repository.AggregatedMetrics) MetricTrend {
+	return newMetricTrend(float64(current.CheckInCount), true, float64(previous.CheckInCount), true)
+}
+
+// newMetricTrend computes the trend from previous to current, leaving out what is undefined
+// because a window has no data or the previous value is 0
+func newMetricTrend(current float64, currentOK bool, previous float64, previousOK bool) MetricTrend {
+	var trend MetricTrend
+	if !previousOK {
+		return trend
+	}
+	trend.Previous = &previous
+	if !currentOK {
+		return trend
+	}
+
+	delta := current - previous
+	trend.Delta = &delta
+	if previous != 0 {
+		percent := delta / previous * 100
+		trend.PercentChange = &percent
+	}
+	return trend
+}
+
+// compareMetrics computes the change from previous to current
+func compareMetrics(current, previous *repository.AggregatedMetrics) *SummaryComparison {
+	comparison := &SummaryComparison{
+		CheckInCountDelta: current.CheckInCount - previous.CheckInCount,
+	}
+
+	if current.AveragePainLevel > 0 && previous.AveragePainLevel > 0 {
+		comparison.PainDelta = current.AveragePainLevel - previous.AveragePainLevel
+	}
+
+	currentMood, currentOK := positiveMoodShare(current.MoodDistribution)
+	previousMood, previousOK := positiveMoodShare(previous.MoodDistribution)
+	if currentOK && previousOK {
+		comparison.MoodImprovementPercent = (currentMood - previousMood) * 100
+	}
+
+	currentEnergy, currentOK := averageEnergy(current.EnergyLevels)
+	previousEnergy, previousOK := averageEnergy(previous.EnergyLevels)
+	if currentOK && previousOK {
+		comparison.EnergyDelta = currentEnergy - previousEnergy
+	}
+
+	return comparison
+}
+
+// positiveMoodShare returns the share of positive moods among the recorded moods
+func positiveMoodShare(moods map[string]int) (float64, bool) {
+	var total int
+	for _, count := range moods {
+		total += count
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return float64(moods["positive"]) / float64(total), true
+}
+
+// averageEnergy averages the energy levels on the energyScores scale, ignoring unknown levels
+func averageEnergy(levels map[string]int) (float64, bool) {
+	var sum float64
+	var count int
+	for level, n := range levels {
+		score, ok := energyScores[level]
+		if !ok {
+			continue
+		}
+		sum += score * float64(n)
+		count += n
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return sum / float64(count), true
+}
+
+// mergeMedicationTaken counts the deprecated "partial" spelling in the "some" bucket
+func mergeMedicationTaken(counts map[string]int) map[string]int {
+	merged := make(map[string]int, len(counts))
+	for value, count := range counts {
+		value, _ = model.NormalizeMedicationTaken(value)
+		merged[value] += count
+	}
+	return merged
+}
+
+// normalizeDailyMetrics rewrites the deprecated medication taken spelling in place
+func normalizeDailyMetrics(metrics []repository.DailyMetrics) []repository.DailyMetrics {
+	for i := range metrics {
+		if metrics[i].MedicationTaken == nil {
+			continue
+		}
+		if value, legacy := model.NormalizeMedicationTaken(*metrics[i].MedicationTaken); legacy {
+			metrics[i].MedicationTaken = &value
+		}
+	}
+	return metrics
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
+	"go.uber.org/zap"
+)
+
+// Formats of the dashboard time-series export
+const (
+	TimeSeriesFormatCSV  = "csv"
+	TimeSeriesFormatJSON = "json"
+)
+
+// ErrUnsupportedExportFormat is returned for a time-series export format other than CSV or JSON
+var ErrUnsupportedExportFormat = errors.New("unsupported export format")
+
+// ExportTimeSeries writes the daily metrics backing the dashboard charts for the last days
+// days to w, oldest first, as CSV with a header row or as a JSON array of DailyMetrics.
+// Nothing is written to w when the metrics cannot be loaded.
+func (s *DashboardService) ExportTimeSeries(ctx context.Context, userID string, days int, format string, w io.Writer) error {
+	ctx, span := telemetry.StartSpan(ctx, "DashboardService.ExportTimeSeries")
+	defer span.End()
+
+	if format != TimeSeriesFormatCSV && format != TimeSeriesFormatJSON {
+		return fmt.Errorf("%w: %q", ErrUnsupportedExportFormat, format)
+	}
+
+	dailyMetrics, err := s.repo.GetDailyMetrics(ctx, userID, days)
+	if err != nil {
+		s.logger.Error("failed to get daily metrics for export",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		return fmt.Errorf("failed to get daily metrics: %w", err)
+	}
+	dailyMetrics = normalizeDailyMetrics(dailyMetrics)
+
+	if format == TimeSeriesFormatJSON {
+		if dailyMetrics == nil {
+			dailyMetrics = []repository.DailyMetrics{}
+		}
+		if err := json.NewEncoder(w).Encode(dailyMetrics); err != nil {
+			return fmt.Errorf("failed to write JSON: %w", err)
+		}
+	} else if err := writeTimeSeriesCSV(w, dailyMetrics); err != nil {
+		return err
+	}
+
+	s.logger.Info("dashboard time series exported",
+		zap.String("user_id", userID),
+		zap.String("format", format),
+		zap.Int("days", days),
+		zap.Int("rows", len(dailyMetrics)),
+	)
+
+	return nil
+}
+
+// writeTimeSeriesCSV writes one row per day of daily metrics after a header row; missing
+// values are empty
+func writeTimeSeriesCSV(w io.Writer, dailyMetrics []repository.DailyMetrics) error {
+	out := &csvExport{writer: csv.NewWriter(w)}
+	out.header("Date", "Pain Level", "Mood", "Energy Level", "Sleep Quality", "Symptom Count", "Activity Count")
+	for _, daily := range dailyMetrics {
+		pain := ""
+		if daily.PainLevel != nil {
+			pain = strconv.Itoa(*daily.PainLevel)
+		}
+		err := out.row(
+			isoDate(daily.Date),
+			pain,
+			stringValue(daily.Mood),
+			stringValue(daily.EnergyLevel),
+			stringValue(daily.SleepQuality),
+			strconv.Itoa(daily.SymptomCount),
+			strconv.Itoa(daily.ActivityCount),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	out.writer.Flush()
+	if err := out.writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
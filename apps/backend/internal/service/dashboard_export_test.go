@@ -0,0 +1,77 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"go.uber.org/zap"
+)
+
+// exportDailyMetrics are three days of metrics, one of them without a pain level or mood
+func exportDailyMetrics() []repository.DailyMetrics {
+	pain3, pain5 := 3, 5
+	positive, low, good := "positive", "low", "good, restful"
+	return []repository.DailyMetrics{
+		{Date: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), PainLevel: &pain3, Mood: &positive, EnergyLevel: &low, SleepQuality: &good, SymptomCount: 2, ActivityCount: 1},
+		{Date: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), SymptomCount: 0, ActivityCount: 3},
+		{Date: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), PainLevel: &pain5, Mood: &positive, SymptomCount: 1},
+	}
+}
+
+func TestDashboardService_ExportTimeSeries_CSV(t *testing.T) {
+	mockRepo := new(MockDashboardRepository)
+	mockRepo.On("GetDailyMetrics", mock.Anything, "user-1", 30).Return(exportDailyMetrics(), nil)
+	svc := NewDashboardService(mockRepo, zap.NewNop())
+
+	var out bytes.Buffer
+	require.NoError(t, svc.ExportTimeSeries(context.Background(), "user-1", 30, TimeSeriesFormatCSV, &out))
+
+	records, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 1+len(exportDailyMetrics()), "a header row and one row per day with data")
+	assert.Equal(t, []string{"Date", "Pain Level", "Mood", "Energy Level", "Sleep Quality", "Symptom Count", "Activity Count"}, records[0])
+	assert.Equal(t, []string{"2026-03-01", "3", "positive", "low", "good, restful", "2", "1"}, records[1])
+	assert.Equal(t, []string{"2026-03-02", "", "", "", "", "0", "3"}, records[2])
+}
+
+func TestDashboardService_ExportTimeSeries_JSONRoundTrip(t *testing.T) {
+	mockRepo := new(MockDashboardRepository)
+	mockRepo.On("GetDailyMetrics", mock.Anything, "user-1", 7).Return(exportDailyMetrics(), nil)
+	svc := NewDashboardService(mockRepo, zap.NewNop())
+
+	var out bytes.Buffer
+	require.NoError(t, svc.ExportTimeSeries(context.Background(), "user-1", 7, TimeSeriesFormatJSON, &out))
+
+	var parsed []repository.DailyMetrics
+	require.NoError(t, json.Unmarshal(out.Bytes(), &parsed))
+	assert.Equal(t, exportDailyMetrics(), parsed)
+}
+
+func TestDashboardService_ExportTimeSeries_Errors(t *testing.T) {
+	mockRepo := new(MockDashboardRepository)
+	mockRepo.On("GetDailyMetrics", mock.Anything, "user-empty", 7).Return([]repository.DailyMetrics(nil), nil)
+	mockRepo.On("GetDailyMetrics", mock.Anything, "user-failing", 7).Return(nil, errors.New("connection refused"))
+	svc := NewDashboardService(mockRepo, zap.NewNop())
+	ctx := context.Background()
+
+	var out bytes.Buffer
+	err := svc.ExportTimeSeries(ctx, "user-empty", 7, "xlsx", &out)
+	assert.ErrorIs(t, err, ErrUnsupportedExportFormat)
+
+	require.NoError(t, svc.ExportTimeSeries(ctx, "user-empty", 7, TimeSeriesFormatJSON, &out))
+	assert.JSONEq(t, "[]", out.String())
+
+	out.Reset()
+	err = svc.ExportTimeSeries(ctx, "user-failing", 7, TimeSeriesFormatCSV, &out)
+	require.Error(t, err)
+	assert.Empty(t, out.String(), "nothing is written when the metrics cannot be loaded")
+}
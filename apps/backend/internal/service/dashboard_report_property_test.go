@@ -76,6 +76,7 @@ func TestProperty_DashboardTimeRangeFiltering(t *testing.T) {
 			// Setup expectations
 			repo.On("GetAggregatedMetrics", mock.Anything, userID, days).Return(aggregatedMetrics, nil)
 			repo.On("GetDailyMetrics", mock.Anything, userID, days).Return(dailyMetrics, nil)
+			repo.On("GetAggregatedMetricsForPeriod", mock.Anything, userID, mock.Anything, mock.Anything).Return(&repository.AggregatedMetrics{}, nil)
 
 			// Execute
 			ctx := context.Background()
@@ -158,6 +159,7 @@ func TestProperty_DashboardAggregationAccuracy(t *testing.T) {
 			// Setup expectations
 			repo.On("GetAggregatedMetrics", mock.Anything, userID, 7).Return(aggregatedMetrics, nil)
 			repo.On("GetDailyMetrics", mock.Anything, userID, 7).Return([]repository.DailyMetrics{}, nil)
+			repo.On("GetAggregatedMetricsForPeriod", mock.Anything, userID, mock.Anything, mock.Anything).Return(&repository.AggregatedMetrics{}, nil)
 
 			// Execute
 			ctx := context.Background()
@@ -259,6 +261,7 @@ func TestProperty_TimeSeriesDataGrouping(t *testing.T) {
 			// Setup expectations
 			repo.On("GetAggregatedMetrics", mock.Anything, userID, mock.Anything).Return(aggregatedMetrics, nil)
 			repo.On("GetDailyMetrics", mock.Anything, userID, mock.Anything).Return(dailyMetrics, nil)
+			repo.On("GetAggregatedMetricsForPeriod", mock.Anything, userID, mock.Anything, mock.Anything).Return(&repository.AggregatedMetrics{}, nil)
 
 			// Execute
 			ctx := context.Background()
@@ -300,6 +303,86 @@ func TestProperty_TimeSeriesDataGrouping(t *testing.T) {
 	properties.TestingRun(t)
 }
 
+// Property: Dashboard trends point in the direction of the change from the previous window
+func TestProperty_DashboardTrendDirection(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 100
+	properties := gopter.NewProperties(parameters)
+
+	// sign returns -1, 0 or 1 for a negative, zero or positive value
+	sign := func(v float64) int {
+		switch {
+		case v > 1e-9:
+			return 1
+		case v < -1e-9:
+			return -1
+		}
+		return 0
+	}
+
+	properties.Property("Trend deltas have the sign of the seeded differences", prop.ForAll(
+		func(currentPain, previousPain, currentPositive, previousPositive, currentCount, previousCount int) bool {
+			repo := new(MockDashboardRepository)
+			service := NewDashboardService(repo, zap.NewNop())
+
+			// Every window has two non-positive moods so the positive share is defined
+			current := &repository.AggregatedMetrics{
+				AveragePainLevel: float64(currentPain),
+				MoodDistribution: map[string]int{"positive": currentPositive, "neutral": 2},
+				CheckInCount:     currentCount,
+			}
+			previous := &repository.AggregatedMetrics{
+				AveragePainLevel: float64(previousPain),
+				MoodDistribution: map[string]int{"positive": previousPositive, "negative": 2},
+				CheckInCount:     previousCount,
+			}
+
+			repo.On("GetAggregatedMetrics", mock.Anything, "user-1", 7).Return(current, nil)
+			repo.On("GetDailyMetrics", mock.Anything, "user-1", 7).Return([]repository.DailyMetrics{}, nil)
+			repo.On("GetAggregatedMetricsForPeriod", mock.Anything, "user-1", mock.Anything, mock.Anything).Return(previous, nil)
+
+			summary, err := service.GetSummary(context.Background(), "user-1", 7)
+			if err != nil {
+				t.Logf("GetSummary failed: %v", err)
+				return false
+			}
+
+			trends := []struct {
+				name            string
+				trend           MetricTrend
+				current, before float64
+			}{
+				{"pain", summary.PainTrend, float64(currentPain), float64(previousPain)},
+				{"mood", summary.MoodTrend, float64(currentPositive) / float64(currentPositive+2), float64(previousPositive) / float64(previousPositive+2)},
+				{"check-in count", summary.CheckInCountTrend, float64(currentCount), float64(previousCount)},
+			}
+			for _, tc := range trends {
+				if tc.trend.Delta == nil {
+					t.Logf("%s trend has no delta", tc.name)
+					return false
+				}
+				if sign(*tc.trend.Delta) != sign(tc.current-tc.before) {
+					t.Logf("%s trend delta %.3f for %.3f -> %.3f", tc.name, *tc.trend.Delta, tc.before, tc.current)
+					return false
+				}
+				if tc.trend.PercentChange != nil && sign(*tc.trend.PercentChange) != sign(*tc.trend.Delta) {
+					t.Logf("%s trend percent change %.3f disagrees with delta %.3f", tc.name, *tc.trend.PercentChange, *tc.trend.Delta)
+					return false
+				}
+			}
+			return true
+		},
+		gen.IntRange(1, 10),
+		gen.IntRange(1, 10),
+		gen.IntRange(0, 20),
+		gen.IntRange(0, 20),
+		gen.IntRange(1, 90),
+		gen.IntRange(0, 90),
+	))
+
+	properties.TestingRun(t)
+}
+
 // Feature: eva-health-backend, Property 19: Report Content Completeness
 // **Validates: Requirements 8.1, 8.2**
 // Note: This property test validates that the PDF generator includes all required sections
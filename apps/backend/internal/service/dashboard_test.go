@@ -7,7 +7,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 	"go.uber.org/zap"
 )
 
@@ -24,6 +26,14 @@ func (m *MockDashboardRepository) GetAggregatedMetrics(ctx context.Context, user
 	return args.Get(0).(*repository.AggregatedMetrics), args.Error(1)
 }
 
+func (m *MockDashboardRepository) GetAggregatedMetricsForPeriod(ctx context.Context, userID string, start, end time.Time) (*repository.AggregatedMetrics, error) {
+	args := m.Called(ctx, userID, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.AggregatedMetrics), args.Error(1)
+}
+
 func (m *MockDashboardRepository) GetDailyMetrics(ctx context.Context, userID string, days int) ([]repository.DailyMetrics, error) {
 	args := m.Called(ctx, userID, days)
 	if args.Get(0) == nil {
@@ -61,8 +71,9 @@ func TestDashboardService_GetSummary_Success(t *testing.T) {
 		},
 	}
 
-	mockRepo.On("GetAggregatedMetrics", ctx, userID, days).Return(expectedMetrics, nil)
-	mockRepo.On("GetDailyMetrics", ctx, userID, days).Return(expectedDailyMetrics, nil)
+	mockRepo.On("GetAggregatedMetrics", mock.Anything, userID, days).Return(expectedMetrics, nil)
+	mockRepo.On("GetDailyMetrics", mock.Anything, userID, days).Return(expectedDailyMetrics, nil)
+	mockRepo.On("GetAggregatedMetricsForPeriod", mock.Anything, userID, mock.Anything, mock.Anything).Return(&repository.AggregatedMetrics{}, nil)
 
 	// Act
 	summary, err := service.GetSummary(ctx, userID, days)
@@ -99,8 +110,9 @@ func TestDashboardService_GetSummary_EmptyDataset(t *testing.T) {
 
 	emptyDailyMetrics := []repository.DailyMetrics{}
 
-	mockRepo.On("GetAggregatedMetrics", ctx, userID, days).Return(emptyMetrics, nil)
-	mockRepo.On("GetDailyMetrics", ctx, userID, days).Return(emptyDailyMetrics, nil)
+	mockRepo.On("GetAggregatedMetrics", mock.Anything, userID, days).Return(emptyMetrics, nil)
+	mockRepo.On("GetDailyMetrics", mock.Anything, userID, days).Return(emptyDailyMetrics, nil)
+	mockRepo.On("GetAggregatedMetricsForPeriod", mock.Anything, userID, mock.Anything, mock.Anything).Return(&repository.AggregatedMetrics{}, nil)
 
 	// Act
 	summary, err := service.GetSummary(ctx, userID, days)
@@ -138,8 +150,9 @@ func TestDashboardService_GetSummary_InvalidDays(t *testing.T) {
 	emptyDailyMetrics := []repository.DailyMetrics{}
 
 	// Should default to 7 days
-	mockRepo.On("GetAggregatedMetrics", ctx, userID, 7).Return(emptyMetrics, nil)
-	mockRepo.On("GetDailyMetrics", ctx, userID, 7).Return(emptyDailyMetrics, nil)
+	mockRepo.On("GetAggregatedMetrics", mock.Anything, userID, 7).Return(emptyMetrics, nil)
+	mockRepo.On("GetDailyMetrics", mock.Anything, userID, 7).Return(emptyDailyMetrics, nil)
+	mockRepo.On("GetAggregatedMetricsForPeriod", mock.Anything, userID, mock.Anything, mock.Anything).Return(&repository.AggregatedMetrics{}, nil)
 
 	// Act
 	summary, err := service.GetSummary(ctx, userID, invalidDays)
@@ -187,8 +200,8 @@ func TestDashboardService_GetTrends_Success(t *testing.T) {
 		},
 	}
 
-	mockRepo.On("GetAggregatedMetrics", ctx, userID, days).Return(expectedMetrics, nil)
-	mockRepo.On("GetDailyMetrics", ctx, userID, days).Return(expectedDailyMetrics, nil)
+	mockRepo.On("GetAggregatedMetrics", mock.Anything, userID, days).Return(expectedMetrics, nil)
+	mockRepo.On("GetDailyMetrics", mock.Anything, userID, days).Return(expectedDailyMetrics, nil)
 
 	// Act
 	trends, err := service.GetTrends(ctx, userID, days)
@@ -224,8 +237,8 @@ func TestDashboardService_GetTrends_EmptyDataset(t *testing.T) {
 
 	emptyDailyMetrics := []repository.DailyMetrics{}
 
-	mockRepo.On("GetAggregatedMetrics", ctx, userID, days).Return(emptyMetrics, nil)
-	mockRepo.On("GetDailyMetrics", ctx, userID, days).Return(emptyDailyMetrics, nil)
+	mockRepo.On("GetAggregatedMetrics", mock.Anything, userID, days).Return(emptyMetrics, nil)
+	mockRepo.On("GetDailyMetrics", mock.Anything, userID, days).Return(emptyDailyMetrics, nil)
 
 	// Act
 	trends, err := service.GetTrends(ctx, userID, days)
@@ -241,3 +254,383 @@ func TestDashboardService_GetTrends_EmptyDataset(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+// fakeAdherenceSource serves medications with fixed adherence results
+type fakeAdherenceSource struct {
+	medications []model.Medication
+	scores      map[string]*repository.MedicationAdherence
+	requested   []string
+}
+
+func (f *fakeAdherenceSource) FindByUserID(ctx context.Context, userID string) ([]model.Medication, error) {
+	return f.medications, nil
+}
+
+func (f *fakeAdherenceSource) GetAdherenceRate(ctx context.Context, medicationID string, start, end time.Time) (*repository.MedicationAdherence, error) {
+	f.requested = append(f.requested, medicationID)
+	return f.scores[medicationID], nil
+}
+
+func TestDashboardService_GetSummary_AdherenceScores(t *testing.T) {
+	mockRepo := new(MockDashboardRepository)
+	service := NewDashboardService(mockRepo, zap.NewNop())
+
+	ctx := context.Background()
+	ended := time.Now().AddDate(0, 0, -60)
+	half := 0.5
+	source := &fakeAdherenceSource{
+		medications: []model.Medication{
+			{ID: "scheduled", Name: "Metformin", Active: true, StartDate: time.Now().AddDate(0, -1, 0)},
+			{ID: "unscheduled", Name: "Ibuprofen", Active: true, StartDate: time.Now().AddDate(0, -1, 0)},
+			{ID: "inactive", Active: false, StartDate: time.Now().AddDate(0, -1, 0)},
+			{ID: "ended", Active: true, StartDate: time.Now().AddDate(0, -3, 0), EndDate: &ended},
+			{ID: "future", Active: true, StartDate: time.Now().AddDate(0, 0, 5)},
+		},
+		scores: map[string]*repository.MedicationAdherence{
+			"scheduled":   {MedicationID: "scheduled", Name: "Metformin", Taken: 7, Expected: 14, Score: &half},
+			"unscheduled": {MedicationID: "unscheduled", Name: "Ibuprofen", Taken: 2},
+		},
+	}
+	service.SetAdherenceSource(source)
+
+	mockRepo.On("GetAggregatedMetrics", mock.Anything, "user-1", 7).Return(&repository.AggregatedMetrics{}, nil)
+	mockRepo.On("GetDailyMetrics", mock.Anything, "user-1", 7).Return([]repository.DailyMetrics{}, nil)
+	mockRepo.On("GetAggregatedMetricsForPeriod", mock.Anything, "user-1", mock.Anything, mock.Anything).Return(&repository.AggregatedMetrics{}, nil)
+
+	summary, err := service.GetSummary(ctx, "user-1", 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"scheduled", "unscheduled"}, source.requested)
+	assert.Len(t, summary.AdherenceScores, 2)
+	assert.Equal(t, 0.5, *summary.AdherenceScores[0].Score)
+	assert.Nil(t, summary.AdherenceScores[1].Score)
+}
+
+func TestDashboardService_GetSummary_MergesMedicationTakenSpellings(t *testing.T) {
+	mockRepo := new(MockDashboardRepository)
+	service := NewDashboardService(mockRepo, zap.NewNop())
+
+	ctx := context.Background()
+	partial := "partial"
+
+	mockRepo.On("GetAggregatedMetrics", mock.Anything, "user-1", 7).Return(&repository.AggregatedMetrics{
+		MoodDistribution: map[string]int{"neutral": 6},
+		EnergyLevels:     map[string]int{"medium": 6},
+		MedicationTaken:  map[string]int{"yes": 2, "some": 1, "partial": 3},
+		CheckInCount:     6,
+	}, nil)
+	mockRepo.On("GetDailyMetrics", mock.Anything, "user-1", 7).Return([]repository.DailyMetrics{
+		{Date: time.Now(), MedicationTaken: &partial},
+	}, nil)
+	mockRepo.On("GetAggregatedMetricsForPeriod", mock.Anything, "user-1", mock.Anything, mock.Anything).Return(&repository.AggregatedMetrics{}, nil)
+
+	summary, err := service.GetSummary(ctx, "user-1", 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"yes": 2, "some": 4}, summary.MedicationTaken)
+	assert.Equal(t, "some", *summary.TimeSeriesData[0].MedicationTaken)
+}
+
+func TestDashboardService_GetSummary_LowConfidenceRate(t *testing.T) {
+	mockRepo := new(MockDashboardRepository)
+	service := NewDashboardService(mockRepo, zap.NewNop())
+
+	ctx := context.Background()
+
+	mockRepo.On("GetAggregatedMetrics", mock.Anything, "user-1", 7).Return(&repository.AggregatedMetrics{
+		MoodDistribution: map[string]int{"neutral": 8},
+		EnergyLevels:     map[string]int{"medium": 8},
+		CheckInCount:     8,
+		LowConfidence:    2,
+	}, nil)
+	mockRepo.On("GetDailyMetrics", mock.Anything, "user-1", 7).Return([]repository.DailyMetrics{}, nil)
+	mockRepo.On("GetAggregatedMetricsForPeriod", mock.Anything, "user-1", mock.Anything, mock.Anything).Return(&repository.AggregatedMetrics{}, nil)
+
+	summary, err := service.GetSummary(ctx, "user-1", 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0.25, summary.LowConfidenceRate)
+}
+
+func TestDashboardService_GetSummary_ComparePrevious(t *testing.T) {
+	mockRepo := new(MockDashboardRepository)
+	service := NewDashboardService(mockRepo, zap.NewNop())
+
+	ctx := context.Background()
+
+	mockRepo.On("GetAggregatedMetrics", mock.Anything, "user-1", 7).Return(&repository.AggregatedMetrics{
+		AveragePainLevel: 3,
+		MoodDistribution: map[string]int{"positive": 3, "neutral": 1},
+		EnergyLevels:     map[string]int{"high": 2, "medium": 2},
+		CheckInCount:     4,
+	}, nil)
+	mockRepo.On("GetDailyMetrics", mock.Anything, "user-1", 7).Return([]repository.DailyMetrics{}, nil)
+	mockRepo.On("GetAggregatedMetricsForPeriod", mock.Anything, "user-1", mock.Anything, mock.Anything).Return(&repository.AggregatedMetrics{
+		AveragePainLevel: 5,
+		MoodDistribution: map[string]int{"positive": 1, "negative": 1},
+		EnergyLevels:     map[string]int{"low": 2},
+		CheckInCount:     6,
+	}, nil)
+
+	summary, err := service.GetSummaryWithOptions(ctx, "user-1", 7, SummaryOptions{ComparePrevious: true})
+
+	require.NoError(t, err)
+	require.NotNil(t, summary.Comparison)
+	assert.Equal(t, -2.0, summary.Comparison.PainDelta)
+	assert.InDelta(t, 25.0, summary.Comparison.MoodImprovementPercent, 1e-9)
+	assert.Equal(t, -2, summary.Comparison.CheckInCountDelta)
+	assert.Equal(t, 1.5, summary.Comparison.EnergyDelta)
+
+	// The previous period ends where the current one starts and has the same length
+	call := mockRepo.Calls[len(mockRepo.Calls)-1]
+	start, end := call.Arguments.Get(2).(time.Time), call.Arguments.Get(3).(time.Time)
+	assert.Equal(t, end.AddDate(0, 0, -7), start)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -7), end, time.Minute)
+}
+
+func TestDashboardService_GetSummary_ComparePreviousWithoutData(t *testing.T) {
+	mockRepo := new(MockDashboardRepository)
+	service := NewDashboardService(mockRepo, zap.NewNop())
+
+	ctx := context.Background()
+
+	mockRepo.On("GetAggregatedMetrics", mock.Anything, "user-1", 30).Return(&repository.AggregatedMetrics{CheckInCount: 0}, nil)
+	mockRepo.On("GetDailyMetrics", mock.Anything, "user-1", 30).Return([]repository.DailyMetrics{}, nil)
+	mockRepo.On("GetAggregatedMetricsForPeriod", mock.Anything, "user-1", mock.Anything, mock.Anything).Return(&repository.AggregatedMetrics{
+		AveragePainLevel: 4,
+		MoodDistribution: map[string]int{"positive": 2},
+		EnergyLevels:     map[string]int{"high": 2},
+		CheckInCount:     2,
+	}, nil)
+
+	summary, err := service.GetSummaryWithOptions(ctx, "user-1", 30, SummaryOptions{ComparePrevious: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, &SummaryComparison{CheckInCountDelta: -2}, summary.Comparison)
+}
+
+func TestDashboardService_GetSummary_WithoutComparison(t *testing.T) {
+	mockRepo := new(MockDashboardRepository)
+	service := NewDashboardService(mockRepo, zap.NewNop())
+
+	ctx := context.Background()
+
+	mockRepo.On("GetAggregatedMetrics", mock.Anything, "user-1", 7).Return(&repository.AggregatedMetrics{CheckInCount: 0}, nil)
+	mockRepo.On("GetDailyMetrics", mock.Anything, "user-1", 7).Return([]repository.DailyMetrics{}, nil)
+	mockRepo.On("GetAggregatedMetricsForPeriod", mock.Anything, "user-1", mock.Anything, mock.Anything).Return(&repository.AggregatedMetrics{CheckInCount: 2}, nil)
+
+	summary, err := service.GetSummary(ctx, "user-1", 7)
+
+	require.NoError(t, err)
+	assert.Nil(t, summary.Comparison)
+	require.NotNil(t, summary.CheckInCountTrend.Delta, "trends do not depend on the comparison option")
+	assert.Equal(t, -2.0, *summary.CheckInCountTrend.Delta)
+}
+
+func TestDashboardService_GetSummary_Trends(t *testing.T) {
+	mockRepo := new(MockDashboardRepository)
+	service := NewDashboardService(mockRepo, zap.NewNop())
+
+	ctx := context.Background()
+
+	mockRepo.On("GetAggregatedMetrics", mock.Anything, "user-1", 7).Return(&repository.AggregatedMetrics{
+		AveragePainLevel: 3,
+		MoodDistribution: map[string]int{"positive": 3, "neutral": 1},
+		CheckInCount:     4,
+	}, nil)
+	mockRepo.On("GetDailyMetrics", mock.Anything, "user-1", 7).Return([]repository.DailyMetrics{}, nil)
+	mockRepo.On("GetAggregatedMetricsForPeriod", mock.Anything, "user-1", mock.Anything, mock.Anything).Return(&repository.AggregatedMetrics{
+		AveragePainLevel: 6,
+		MoodDistribution: map[string]int{"positive": 1, "negative": 1},
+		CheckInCount:     2,
+	}, nil)
+
+	summary, err := service.GetSummary(ctx, "user-1", 7)
+
+	require.NoError(t, err)
+	assert.Equal(t, 6.0, *summary.PainTrend.Previous)
+	assert.Equal(t, -3.0, *summary.PainTrend.Delta)
+	assert.Equal(t, -50.0, *summary.PainTrend.PercentChange)
+	assert.InDelta(t, 0.25, *summary.MoodTrend.Delta, 1e-9)
+	assert.InDelta(t, 50.0, *summary.MoodTrend.PercentChange, 1e-9)
+	assert.Equal(t, 2.0, *summary.CheckInCountTrend.Delta)
+	assert.Equal(t, 100.0, *summary.CheckInCountTrend.PercentChange)
+}
+
+func TestDashboardService_GetSummary_TrendsWithoutPreviousData(t *testing.T) {
+	mockRepo := new(MockDashboardRepository)
+	service := NewDashboardService(mockRepo, zap.NewNop())
+
+	ctx := context.Background()
+
+	mockRepo.On("GetAggregatedMetrics", mock.Anything, "user-1", 7).Return(&repository.AggregatedMetrics{
+		AveragePainLevel: 3,
+		MoodDistribution: map[string]int{"positive": 1},
+		CheckInCount:     1,
+	}, nil)
+	mockRepo.On("GetDailyMetrics", mock.Anything, "user-1", 7).Return([]repository.DailyMetrics{}, nil)
+	mockRepo.On("GetAggregatedMetricsForPeriod", mock.Anything, "user-1", mock.Anything, mock.Anything).Return(&repository.AggregatedMetrics{
+		MoodDistribution: map[string]int{},
+	}, nil)
+
+	summary, err := service.GetSummary(ctx, "user-1", 7)
+
+	require.NoError(t, err)
+	assert.Equal(t, MetricTrend{}, summary.PainTrend)
+	assert.Equal(t, MetricTrend{}, summary.MoodTrend)
+	assert.Equal(t, 0.0, *summary.CheckInCountTrend.Previous)
+	assert.Equal(t, 1.0, *summary.CheckInCountTrend.Delta)
+	assert.Nil(t, summary.CheckInCountTrend.PercentChange, "percent change from zero is undefined")
+}
+
+// fakeDoseSource serves fixed medication dose counts
+type fakeDoseSource struct {
+	counts []repository.MedicationDoseCount
+	err    error
+}
+
+func (f *fakeDoseSource) GetMedicationDoseCounts(ctx context.Context, userID string, start, end time.Time) ([]repository.MedicationDoseCount, error) {
+	return f.counts, f.err
+}
+
+func TestComputeAdherenceSummary(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 7, 23, 59, 0, 0, time.UTC)
+	counts := []repository.MedicationDoseCount{
+		{Medication: model.Medication{ID: "daily", Name: "Metformin", Frequency: "twice daily", StartDate: start}, Taken: 7},
+		{Medication: model.Medication{ID: "extra", Name: "Vitamin D", Frequency: "daily", StartDate: start}, Taken: 9},
+		{Medication: model.Medication{ID: "unknown", Name: "Ibuprofen", Frequency: "as needed", StartDate: start}, Taken: 2},
+		{Medication: model.Medication{ID: "late", Name: "Statin", Frequency: "once daily", StartDate: start.AddDate(0, 0, 5)}, Taken: 0},
+	}
+
+	summary := computeAdherenceSummary(counts, start, end)
+
+	require.Len(t, summary.Medications, 4)
+	assert.Equal(t, 14, *summary.Medications[0].Expected)
+	assert.Equal(t, 0.5, *summary.Medications[0].Rate)
+
+	// Doses logged beyond the expected ones do not count
+	assert.Equal(t, 7, *summary.Medications[1].Expected)
+	assert.Equal(t, 1.0, *summary.Medications[1].Rate)
+
+	// An unrecognized frequency is reported without a rate
+	assert.Nil(t, summary.Medications[2].Expected)
+	assert.Nil(t, summary.Medications[2].Rate)
+	assert.Equal(t, 2, summary.Medications[2].Taken)
+
+	// Doses are only expected once the medication was prescribed
+	assert.Equal(t, 2, *summary.Medications[3].Expected)
+	assert.Equal(t, 0.0, *summary.Medications[3].Rate)
+
+	assert.InDelta(t, 14.0/23.0, *summary.Rate, 1e-9)
+}
+
+func TestComputeAdherenceSummary_NoExpectedDoses(t *testing.T) {
+	summary := computeAdherenceSummary([]repository.MedicationDoseCount{
+		{Medication: model.Medication{ID: "prn", Frequency: "when needed"}, Taken: 3},
+	}, time.Now().AddDate(0, 0, -7), time.Now())
+
+	assert.Nil(t, summary.Rate)
+	assert.Len(t, summary.Medications, 1)
+}
+
+func TestDashboardService_GetSummary_AdherenceSurvivesDoseCountFailure(t *testing.T) {
+	mockRepo := new(MockDashboardRepository)
+	service := NewDashboardService(mockRepo, zap.NewNop())
+	service.SetDoseSource(&fakeDoseSource{err: assert.AnError})
+
+	ctx := context.Background()
+	mockRepo.On("GetAggregatedMetrics", mock.Anything, "user-1", 7).Return(&repository.AggregatedMetrics{}, nil)
+	mockRepo.On("GetDailyMetrics", mock.Anything, "user-1", 7).Return([]repository.DailyMetrics{}, nil)
+	mockRepo.On("GetAggregatedMetricsForPeriod", mock.Anything, "user-1", mock.Anything, mock.Anything).Return(&repository.AggregatedMetrics{}, nil)
+
+	summary, err := service.GetSummary(ctx, "user-1", 7)
+
+	require.NoError(t, err)
+	assert.Nil(t, summary.Adherence)
+}
+
+// fakeBloodPressureSource serves fixed blood pressure readings
+type fakeBloodPressureSource struct {
+	readings []model.BloodPressureReading
+}
+
+func (f *fakeBloodPressureSource) StreamBloodPressureByUserID(ctx context.Context, userID string, start, end time.Time, fn func(model.BloodPressureReading) error) error {
+	for _, reading := range f.readings {
+		if err := fn(reading); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestDashboardService_GetSummary_BloodPressureCategories(t *testing.T) {
+	mockRepo := new(MockDashboardRepository)
+	service := NewDashboardService(mockRepo, zap.NewNop())
+
+	ctx := context.Background()
+	mockRepo.On("GetAggregatedMetrics", mock.Anything, "user-1", 30).Return(&repository.AggregatedMetrics{}, nil)
+	mockRepo.On("GetDailyMetrics", mock.Anything, "user-1", 30).Return([]repository.DailyMetrics{}, nil)
+	mockRepo.On("GetAggregatedMetricsForPeriod", mock.Anything, "user-1", mock.Anything, mock.Anything).Return(&repository.AggregatedMetrics{}, nil)
+
+	summary, err := service.GetSummary(ctx, "user-1", 30)
+	require.NoError(t, err)
+	assert.Nil(t, summary.BloodPressureCategories)
+
+	service.SetBloodPressureSource(&fakeBloodPressureSource{readings: []model.BloodPressureReading{
+		{Systolic: 118, Diastolic: 76},
+		{Systolic: 125, Diastolic: 78},
+		{Systolic: 135, Diastolic: 82},
+		{Systolic: 132, Diastolic: 79},
+		{Systolic: 190, Diastolic: 110},
+	}})
+
+	summary, err = service.GetSummary(ctx, "user-1", 30)
+	require.NoError(t, err)
+	assert.Equal(t, map[model.BPCategory]int{
+		model.BPCategoryNormal:   1,
+		model.BPCategoryElevated: 1,
+		model.BPCategoryStage1:   2,
+		model.BPCategoryStage2:   0,
+		model.BPCategoryCrisis:   1,
+	}, summary.BloodPressureCategories)
+}
+
+// fakeFitnessSource serves fixed fitness data points
+type fakeFitnessSource struct {
+	points []model.FitnessDataPoint
+}
+
+func (f *fakeFitnessSource) GetFitnessDataByUserID(ctx context.Context, userID string, startDate, endDate time.Time, dataType string) ([]model.FitnessDataPoint, error) {
+	return f.points, nil
+}
+
+func TestDashboardService_GetSummary_SleepAndWeight(t *testing.T) {
+	mockRepo := new(MockDashboardRepository)
+	service := NewDashboardService(mockRepo, zap.NewNop())
+
+	ctx := context.Background()
+	mockRepo.On("GetAggregatedMetrics", mock.Anything, "user-1", 7).Return(&repository.AggregatedMetrics{}, nil)
+	mockRepo.On("GetDailyMetrics", mock.Anything, "user-1", 7).Return([]repository.DailyMetrics{}, nil)
+	mockRepo.On("GetAggregatedMetricsForPeriod", mock.Anything, "user-1", mock.Anything, mock.Anything).Return(&repository.AggregatedMetrics{}, nil)
+
+	service.SetFitnessSource(&fakeFitnessSource{points: []model.FitnessDataPoint{
+		{Date: time.Now().AddDate(0, 0, -1), DataType: "steps", Value: 9000, Unit: "count"},
+	}})
+	summary, err := service.GetSummary(ctx, "user-1", 7)
+	require.NoError(t, err)
+	assert.Nil(t, summary.AverageSleepMinutes, "no sleep data in the period")
+	assert.Nil(t, summary.LatestWeightKg)
+
+	service.SetFitnessSource(&fakeFitnessSource{points: []model.FitnessDataPoint{
+		{Date: time.Now().AddDate(0, 0, -2), DataType: "sleep_minutes", Value: 420, Unit: "minutes"},
+		{Date: time.Now().AddDate(0, 0, -1), DataType: "sleep_minutes", Value: 480, Unit: "minutes"},
+		{Date: time.Now().AddDate(0, 0, -2), DataType: "weight", Value: 70.2, Unit: "kg"},
+	}})
+	summary, err = service.GetSummary(ctx, "user-1", 7)
+	require.NoError(t, err)
+	require.NotNil(t, summary.AverageSleepMinutes)
+	assert.InDelta(t, 450, *summary.AverageSleepMinutes, 0.001)
+	require.NotNil(t, summary.LatestWeightKg)
+	assert.Equal(t, 70.2, *summary.LatestWeightKg)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/openai/openai-go/v3"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 	"go.uber.org/zap"
 )
 
@@ -18,11 +20,15 @@ type ExtractedData struct {
 	PainLevel        *int     `json:"pain_level,omitempty"`
 	EnergyLevel      string   `json:"energy_level"`     // low, medium, high
 	SleepQuality     string   `json:"sleep_quality"`    // poor, fair, good, excellent
-	MedicationTaken  string   `json:"medication_taken"` // yes, no, partial
+	MedicationTaken  string   `json:"medication_taken"` // yes, no, some
 	PhysicalActivity []string `json:"physical_activity"`
 	Meals            MealInfo `json:"meals"`
 	GeneralFeeling   string   `json:"general_feeling"`
 	AdditionalNotes  string   `json:"additional_notes"`
+	Confidence       float64  `json:"confidence"` // 0-1, the model's confidence in the extraction
+
+	// LowConfidence is set when Confidence is below the extractor's threshold
+	LowConfidence bool `json:"-"`
 }
 
 // MealInfo represents meal information
@@ -32,20 +38,47 @@ type MealInfo struct {
 	Dinner    string `json:"dinner"`
 }
 
+// Values of the categorical check-in fields, enforced on extracted data and corrections alike
+var (
+	checkInMoods          = []string{"positive", "neutral", "negative"}
+	checkInEnergyLevels   = []string{"low", "medium", "high"}
+	checkInSleepQualities = []string{"poor", "fair", "good", "excellent"}
+)
+
+// Bounds of a check-in pain level
+const (
+	minPainLevel = 0
+	maxPainLevel = 10
+)
+
+// ExtractionPromptVersion identifies the extraction prompt stored with each check-in.
+// Bump it whenever buildExtractionPrompt changes so extraction quality can be compared.
+const ExtractionPromptVersion = "2"
+
+// DefaultConfidenceThreshold is the extraction confidence below which a result is flagged
+const DefaultConfidenceThreshold = 0.6
+
 // DataExtractor extracts structured data from conversation using Azure OpenAI
 type DataExtractor struct {
-	aiClient *azure.OpenAIClient
-	logger   *zap.Logger
+	aiClient            *azure.OpenAIClient
+	logger              *zap.Logger
+	confidenceThreshold float64
 }
 
 // NewDataExtractor creates a new DataExtractor
 func NewDataExtractor(aiClient *azure.OpenAIClient, logger *zap.Logger) *DataExtractor {
 	return &DataExtractor{
-		aiClient: aiClient,
-		logger:   logger,
+		aiClient:            aiClient,
+		logger:              logger,
+		confidenceThreshold: DefaultConfidenceThreshold,
 	}
 }
 
+// SetConfidenceThreshold sets the confidence below which extractions are flagged as low confidence
+func (de *DataExtractor) SetConfidenceThreshold(threshold float64) {
+	de.confidenceThreshold = threshold
+}
+
 // Extract extracts structured health data from conversation history
 func (de *DataExtractor) Extract(ctx context.Context, conversationHistory []ConversationMessage) (*ExtractedData, error) {
 	de.logger.Info("starting data extraction from conversation",
@@ -67,7 +100,7 @@ func (de *DataExtractor) Extract(ctx context.Context, conversationHistory []Conv
 		openai.UserMessage("Extract the health data from the conversation above and return it as JSON."),
 	}
 
-	response, err := de.aiClient.Complete(ctx, messages)
+	response, err := de.completeJSON(ctx, messages)
 	if err != nil {
 		de.logger.Error("AI extraction failed", zap.Error(err))
 		return nil, fmt.Errorf("AI extraction failed: %w", err)
@@ -84,6 +117,7 @@ func (de *DataExtractor) Extract(ctx context.Context, conversationHistory []Conv
 	}
 
 	de.logger.Info("data extraction completed successfully",
+		zap.Float64("confidence", extractedData.Confidence),
 		zap.String("mood", extractedData.Mood),
 		zap.String("energy_level", extractedData.EnergyLevel),
 		zap.String("sleep_quality", extractedData.SleepQuality),
@@ -93,6 +127,77 @@ func (de *DataExtractor) Extract(ctx context.Context, conversationHistory []Conv
 	return extractedData, nil
 }
 
+// completeJSON streams the extraction response and returns it as soon as its top-level
+// JSON object is complete, without waiting for the rest of the stream
+func (de *DataExtractor) completeJSON(ctx context.Context, messages []openai.ChatCompletionMessageParamUnion) (string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunks := make(chan string, 16)
+	done := make(chan error, 1)
+	go func() {
+		done <- de.aiClient.CompleteStreaming(ctx, messages, chunks)
+	}()
+
+	var response strings.Builder
+	var object jsonObjectEnd
+	for chunk := range chunks {
+		n, complete := object.write(chunk)
+		response.WriteString(chunk[:n])
+		if complete {
+			// Anything after the object, such as a closing code fence, is not needed
+			de.logger.Debug("extraction response complete, ending stream early",
+				zap.Int("response_bytes", response.Len()),
+			)
+			return response.String(), nil
+		}
+	}
+
+	if err := <-done; err != nil {
+		return "", err
+	}
+	// The stream ended before the object did; parsing reports the truncated JSON
+	return response.String(), nil
+}
+
+// jsonObjectEnd follows a JSON response chunk by chunk to find where its top-level
+// object ends. Text before the opening brace, such as a markdown code fence, is skipped
+// over; braces inside strings are ignored.
+type jsonObjectEnd struct {
+	depth    int
+	inString bool
+	escaped  bool
+}
+
+// write consumes a chunk and returns how many of its bytes belong to the response, up to
+// and including the brace closing the top-level object, and whether that brace was seen
+func (j *jsonObjectEnd) write(chunk string) (int, bool) {
+	for i := 0; i < len(chunk); i++ {
+		ch := chunk[i]
+		switch {
+		case j.inString:
+			switch {
+			case j.escaped:
+				j.escaped = false
+			case ch == '\\':
+				j.escaped = true
+			case ch == '"':
+				j.inString = false
+			}
+		case ch == '"':
+			j.inString = j.depth > 0
+		case ch == '{':
+			j.depth++
+		case ch == '}' && j.depth > 0:
+			j.depth--
+			if j.depth == 0 {
+				return i + 1, true
+			}
+		}
+	}
+	return len(chunk), false
+}
+
 // buildExtractionPrompt creates the AI prompt for data extraction
 func (de *DataExtractor) buildExtractionPrompt(conversationHistory string) string {
 	return fmt.Sprintf(`You are a medical data extraction assistant. Extract structured health information from the following conversation in Hungarian.
@@ -107,7 +212,7 @@ Extract the following information and return it as valid JSON:
   "pain_level": 0-10 or null if no pain reported,
   "energy_level": "low/medium/high",
   "sleep_quality": "poor/fair/good/excellent",
-  "medication_taken": "yes/no/partial",
+  "medication_taken": "yes/no/some",
   "physical_activity": ["list of activities mentioned"],
   "meals": {
     "breakfast": "description or empty string",
@@ -115,7 +220,8 @@ Extract the following information and return it as valid JSON:
     "dinner": "description or empty string"
   },
   "general_feeling": "free text summary of how they feel",
-  "additional_notes": "any other relevant information"
+  "additional_notes": "any other relevant information",
+  "confidence": 0.0-1.0
 }
 
 Rules:
@@ -123,9 +229,11 @@ Rules:
 - Mood should be classified based on the overall tone of the conversation
 - Energy level should be inferred from their descriptions
 - Sleep quality should be based on their sleep description
-- Medication taken should be "yes" if they took all medications, "no" if they took none, "partial" if they took some
+- Medication taken should be "yes" if they took all medications, "no" if they took none, "some" if they took some of them
 - Extract all symptoms and pain descriptions mentioned
+- Answers to clarifying follow-up questions (pain location, duration, severity) add detail to the preceding answer; include them in symptoms and pain_level
 - Extract all physical activities mentioned (sports, walks, exercise)
+- Confidence is how sure you are that the extracted data reflects the conversation, from 0 (guessing) to 1 (stated clearly); lower it for vague, contradictory or missing answers
 - Return ONLY valid JSON, no additional text
 
 Return the JSON now:`, conversationHistory)
@@ -148,6 +256,14 @@ func (de *DataExtractor) parseExtractionResponse(response string) (*ExtractedDat
 	// Validate and normalize extracted data
 	data = de.normalizeExtractedData(data)
 
+	if data.Confidence < de.confidenceThreshold {
+		data.LowConfidence = true
+		de.logger.Warn("low confidence data extraction",
+			zap.Float64("confidence", data.Confidence),
+			zap.Float64("threshold", de.confidenceThreshold),
+		)
+	}
+
 	return &data, nil
 }
 
@@ -155,45 +271,55 @@ func (de *DataExtractor) parseExtractionResponse(response string) (*ExtractedDat
 func (de *DataExtractor) normalizeExtractedData(data ExtractedData) ExtractedData {
 	// Normalize mood
 	data.Mood = strings.ToLower(strings.TrimSpace(data.Mood))
-	if data.Mood != "positive" && data.Mood != "neutral" && data.Mood != "negative" {
+	if !slices.Contains(checkInMoods, data.Mood) {
 		de.logger.Warn("invalid mood value, defaulting to neutral", zap.String("mood", data.Mood))
 		data.Mood = "neutral"
 	}
 
 	// Normalize energy level
 	data.EnergyLevel = strings.ToLower(strings.TrimSpace(data.EnergyLevel))
-	if data.EnergyLevel != "low" && data.EnergyLevel != "medium" && data.EnergyLevel != "high" {
+	if !slices.Contains(checkInEnergyLevels, data.EnergyLevel) {
 		de.logger.Warn("invalid energy level, defaulting to medium", zap.String("energy_level", data.EnergyLevel))
 		data.EnergyLevel = "medium"
 	}
 
 	// Normalize sleep quality
 	data.SleepQuality = strings.ToLower(strings.TrimSpace(data.SleepQuality))
-	if data.SleepQuality != "poor" && data.SleepQuality != "fair" && data.SleepQuality != "good" && data.SleepQuality != "excellent" {
+	if !slices.Contains(checkInSleepQualities, data.SleepQuality) {
 		de.logger.Warn("invalid sleep quality, defaulting to fair", zap.String("sleep_quality", data.SleepQuality))
 		data.SleepQuality = "fair"
 	}
 
 	// Normalize medication taken
 	data.MedicationTaken = strings.ToLower(strings.TrimSpace(data.MedicationTaken))
-	if data.MedicationTaken != "yes" && data.MedicationTaken != "no" && data.MedicationTaken != "partial" {
+	if medicationTaken, legacy := model.NormalizeMedicationTaken(data.MedicationTaken); legacy {
+		de.logger.Debug("normalized deprecated medication taken value", zap.String("medication_taken", data.MedicationTaken))
+		data.MedicationTaken = medicationTaken
+	}
+	if data.MedicationTaken != model.MedicationTakenYes && data.MedicationTaken != model.MedicationTakenNo && data.MedicationTaken != model.MedicationTakenSome {
 		de.logger.Warn("invalid medication taken value, defaulting to no", zap.String("medication_taken", data.MedicationTaken))
-		data.MedicationTaken = "no"
+		data.MedicationTaken = model.MedicationTakenNo
 	}
 
 	// Validate pain level
 	if data.PainLevel != nil {
-		if *data.PainLevel < 0 {
+		if *data.PainLevel < minPainLevel {
 			de.logger.Warn("pain level below 0, setting to 0", zap.Int("pain_level", *data.PainLevel))
-			zero := 0
-			data.PainLevel = &zero
-		} else if *data.PainLevel > 10 {
+			lowest := minPainLevel
+			data.PainLevel = &lowest
+		} else if *data.PainLevel > maxPainLevel {
 			de.logger.Warn("pain level above 10, setting to 10", zap.Int("pain_level", *data.PainLevel))
-			ten := 10
-			data.PainLevel = &ten
+			highest := maxPainLevel
+			data.PainLevel = &highest
 		}
 	}
 
+	// Clamp confidence; a missing value stays 0 and is flagged as low confidence
+	if data.Confidence < 0 || data.Confidence > 1 {
+		de.logger.Warn("confidence out of range, clamping", zap.Float64("confidence", data.Confidence))
+		data.Confidence = min(max(data.Confidence, 0), 1)
+	}
+
 	// Initialize empty arrays if nil
 	if data.Symptoms == nil {
 		data.Symptoms = []string{}
@@ -1,8 +1,16 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
 	"go.uber.org/zap"
 )
 
@@ -74,13 +82,30 @@ func TestDataExtractor_normalizeExtractedData(t *testing.T) {
 				Mood:            "neutral",
 				EnergyLevel:     "low",
 				SleepQuality:    "amazing",
-				MedicationTaken: "partial",
+				MedicationTaken: "some",
 			},
 			expected: ExtractedData{
 				Mood:             "neutral",
 				EnergyLevel:      "low",
 				SleepQuality:     "fair",
-				MedicationTaken:  "partial",
+				MedicationTaken:  "some",
+				Symptoms:         []string{},
+				PhysicalActivity: []string{},
+			},
+		},
+		{
+			name: "deprecated partial medication taken normalized to some",
+			input: ExtractedData{
+				Mood:            "neutral",
+				EnergyLevel:     "medium",
+				SleepQuality:    "good",
+				MedicationTaken: "Partial",
+			},
+			expected: ExtractedData{
+				Mood:             "neutral",
+				EnergyLevel:      "medium",
+				SleepQuality:     "good",
+				MedicationTaken:  "some",
 				Symptoms:         []string{},
 				PhysicalActivity: []string{},
 			},
@@ -253,6 +278,55 @@ func TestDataExtractor_parseExtractionResponse(t *testing.T) {
 	}
 }
 
+func TestDataExtractor_parseExtractionResponse_Confidence(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	de := NewDataExtractor(nil, logger)
+
+	tests := []struct {
+		name               string
+		confidence         string
+		expectedConfidence float64
+		expectedLow        bool
+	}{
+		{name: "confident extraction", confidence: `, "confidence": 0.9`, expectedConfidence: 0.9, expectedLow: false},
+		{name: "at threshold is not low", confidence: `, "confidence": 0.6`, expectedConfidence: 0.6, expectedLow: false},
+		{name: "below threshold is low", confidence: `, "confidence": 0.4`, expectedConfidence: 0.4, expectedLow: true},
+		{name: "missing confidence is low", confidence: ``, expectedConfidence: 0, expectedLow: true},
+		{name: "above range is clamped", confidence: `, "confidence": 1.5`, expectedConfidence: 1, expectedLow: false},
+		{name: "below range is clamped", confidence: `, "confidence": -0.2`, expectedConfidence: 0, expectedLow: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := `{"mood": "positive", "energy_level": "high", "sleep_quality": "good", "medication_taken": "yes"` + tt.confidence + `}`
+
+			result, err := de.parseExtractionResponse(response)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Confidence != tt.expectedConfidence {
+				t.Errorf("expected confidence %v, got %v", tt.expectedConfidence, result.Confidence)
+			}
+			if result.LowConfidence != tt.expectedLow {
+				t.Errorf("expected low confidence %v, got %v", tt.expectedLow, result.LowConfidence)
+			}
+		})
+	}
+
+	t.Run("threshold is configurable", func(t *testing.T) {
+		strict := NewDataExtractor(nil, logger)
+		strict.SetConfidenceThreshold(0.95)
+
+		result, err := strict.parseExtractionResponse(`{"mood": "positive", "confidence": 0.9}`)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.LowConfidence {
+			t.Error("expected confidence 0.9 to be low against threshold 0.95")
+		}
+	})
+}
+
 func TestDataExtractor_buildExtractionPrompt(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 	de := &DataExtractor{logger: logger}
@@ -271,12 +345,20 @@ func TestDataExtractor_buildExtractionPrompt(t *testing.T) {
 	}
 
 	// Check that prompt contains key instructions
-	expectedKeywords := []string{"symptoms", "mood", "pain_level", "energy_level", "sleep_quality", "medication_taken"}
+	expectedKeywords := []string{"symptoms", "mood", "pain_level", "energy_level", "sleep_quality", "medication_taken", "confidence"}
 	for _, keyword := range expectedKeywords {
 		if !contains(prompt, keyword) {
 			t.Errorf("prompt should contain keyword: %s", keyword)
 		}
 	}
+
+	// The prompt asks for the renamed medication taken value
+	if !contains(prompt, `"medication_taken": "yes/no/some"`) {
+		t.Error("prompt should list yes/no/some as medication_taken values")
+	}
+	if contains(prompt, "partial") {
+		t.Error("prompt should not mention the deprecated partial value")
+	}
 }
 
 // Helper functions
@@ -296,3 +378,141 @@ func containsMiddle(s, substr string) bool {
 	}
 	return false
 }
+
+// sseChatChunk formats a streamed chat completion chunk carrying content as an SSE event
+func sseChatChunk(content string) string {
+	data, _ := json.Marshal(map[string]any{
+		"id":      "chatcmpl-1",
+		"object":  "chat.completion.chunk",
+		"created": 0,
+		"model":   "gpt-4o",
+		"choices": []map[string]any{{"index": 0, "delta": map[string]string{"content": content}}},
+	})
+	return fmt.Sprintf("data: %s\n\n", data)
+}
+
+func TestJSONObjectEnd(t *testing.T) {
+	tests := []struct {
+		name     string
+		chunks   []string
+		complete bool
+		response string
+	}{
+		{
+			name:     "object split across chunks",
+			chunks:   []string{`{"mood": "pos`, `itive", "meals": {"lunch": ""`, `}}`},
+			complete: true,
+			response: `{"mood": "positive", "meals": {"lunch": ""}}`,
+		},
+		{
+			name:     "braces and escaped quotes inside strings",
+			chunks:   []string{`{"general_feeling": "a } and \"{\" ", `, `"additional_notes": "\\"}`},
+			complete: true,
+			response: `{"general_feeling": "a } and \"{\" ", "additional_notes": "\\"}`,
+		},
+		{
+			name:     "code fence around the object",
+			chunks:   []string{"```json\n{\"mood\": \"neutral\"", "}\n```"},
+			complete: true,
+			response: "```json\n{\"mood\": \"neutral\"}",
+		},
+		{
+			name:     "unfinished object",
+			chunks:   []string{`{"mood": {`, `"x": 1}`},
+			complete: false,
+			response: `{"mood": {"x": 1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var object jsonObjectEnd
+			var response string
+			complete := false
+			for _, chunk := range tt.chunks {
+				n, done := object.write(chunk)
+				response += chunk[:n]
+				if done {
+					complete = true
+					break
+				}
+			}
+
+			if complete != tt.complete {
+				t.Errorf("expected complete %v, got %v", tt.complete, complete)
+			}
+			if response != tt.response {
+				t.Errorf("expected response %q, got %q", tt.response, response)
+			}
+		})
+	}
+}
+
+func TestDataExtractor_Extract_ReturnsWhenJSONIsComplete(t *testing.T) {
+	logger := zap.NewNop()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, chunk := range []string{
+			"```json\n",
+			`{"symptoms": ["fejfájás"], "mood": "negative",`,
+			` "energy_level": "low", "sleep_quality": "poor",`,
+			` "medication_taken": "yes", "pain_level": 6, "confidence": 0.8}`,
+			"\n```",
+		} {
+			fmt.Fprint(w, sseChatChunk(chunk))
+			w.(http.Flusher).Flush()
+		}
+		// Never finish the stream; the extractor must not wait for it
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	aiClient, err := azure.NewOpenAIClient(server.URL, azure.KeyAuth("test-key"), "test-deployment", logger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	de := NewDataExtractor(aiClient, logger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+
+	data, err := de.Extract(ctx, []ConversationMessage{
+		{Role: "assistant", Content: "Hogy érzed magad ma?"},
+		{Role: "user", Content: "Fáj a fejem, rosszul aludtam."},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected extraction to return once the JSON was complete, took %v", elapsed)
+	}
+	if data.Mood != "negative" || data.PainLevel == nil || *data.PainLevel != 6 {
+		t.Errorf("unexpected extraction result: %+v", data)
+	}
+	if len(data.Symptoms) != 1 || data.Symptoms[0] != "fejfájás" {
+		t.Errorf("expected symptoms [fejfájás], got %v", data.Symptoms)
+	}
+}
+
+func TestDataExtractor_Extract_TruncatedStream(t *testing.T) {
+	logger := zap.NewNop()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, sseChatChunk(`{"mood": "negative",`))
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	aiClient, err := azure.NewOpenAIClient(server.URL, azure.KeyAuth("test-key"), "test-deployment", logger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = NewDataExtractor(aiClient, logger).Extract(context.Background(), []ConversationMessage{
+		{Role: "user", Content: "Rosszul vagyok."},
+	})
+	if err == nil || !strings.Contains(err.Error(), "failed to parse extraction response") {
+		t.Errorf("expected a truncated JSON response to fail parsing, got %v", err)
+	}
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	// DataRetentionLockName is the advisory lock held while retention is enforced, so one
+	// server instance at a time deletes expired data
+	DataRetentionLockName = "data_retention"
+
+	// retentionBatchSize is how many messages or check-ins are removed per batch
+	retentionBatchSize = 500
+
+	// retentionAuditUserID is the user recorded in the audit log for enforcement runs
+	retentionAuditUserID = "system"
+)
+
+// RetentionStore defines the persistence operations needed to enforce data retention
+type RetentionStore interface {
+	FindExpiredMessages(ctx context.Context, before time.Time, limit int) ([]repository.ExpiredMessage, error)
+	DeleteMessages(ctx context.Context, ids []string) (int64, error)
+	ArchiveCheckIns(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// AudioDeleter deletes audio files from blob storage
+type AudioDeleter interface {
+	DeleteAudio(ctx context.Context, blobName string) error
+}
+
+// RetentionPolicy holds the retention periods in days; 0 keeps the data
+type RetentionPolicy struct {
+	AudioRetentionDays   int
+	CheckInRetentionDays int
+}
+
+// RetentionResult is the outcome of an enforcement run
+type RetentionResult struct {
+	MessagesDeleted   int64 `json:"messages_deleted"`
+	AudioFilesDeleted int   `json:"audio_files_deleted"`
+	AudioFailures     int   `json:"audio_failures"` // messages kept because their audio could not be deleted
+	CheckInsArchived  int64 `json:"check_ins_archived"`
+}
+
+// DataRetentionService deletes conversation messages and their audio past the audio
+// retention period and moves check-ins past the check-in retention period to
+// health_check_ins_archive. A message is only deleted once all its audio files are, so
+// audio that failed to delete is retried on the next run.
+type DataRetentionService struct {
+	store       RetentionStore
+	audio       AudioDeleter
+	locker      JobLocker
+	policy      RetentionPolicy
+	storage     *StorageRouter
+	auditLogger *audit.Logger
+	done        sync.WaitGroup
+	logger      *zap.Logger
+	now         func() time.Time
+}
+
+// NewDataRetentionService creates a new DataRetentionService
+func NewDataRetentionService(store RetentionStore, audio AudioDeleter, locker JobLocker, policy RetentionPolicy, logger *zap.Logger) *DataRetentionService {
+	return &DataRetentionService{
+		store:  store,
+		audio:  audio,
+		locker: locker,
+		policy: policy,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// SetAuditLogger enables audit logging of enforcement runs
+func (s *DataRetentionService) SetAuditLogger(auditLogger *audit.Logger) {
+	s.auditLogger = auditLogger
+}
+
+// SetStorageRouter deletes audio from the storage backend recorded with it. Without a
+// router only audio in the default backend can be deleted.
+func (s *DataRetentionService) SetStorageRouter(router *StorageRouter) {
+	s.storage = router
+}
+
+// Start enforces retention every day at hour:00 UTC until ctx is cancelled
+func (s *DataRetentionService) Start(ctx context.Context, hour int) {
+	s.done.Add(1)
+	go s.run(ctx, hour)
+	s.logger.Info("data retention enforcement scheduled",
+		zap.Int("hour_utc", hour),
+		zap.Int("audio_retention_days", s.policy.AudioRetentionDays),
+		zap.Int("check_in_retention_days", s.policy.CheckInRetentionDays),
+	)
+}
+
+// Wait blocks until the enforcement job has stopped after the context passed to Start
+// was cancelled
+func (s *DataRetentionService) Wait() {
+	s.done.Wait()
+}
+
+// run enforces retention every day at hour:00 UTC until ctx is cancelled
+func (s *DataRetentionService) run(ctx context.Context, hour int) {
+	defer s.done.Done()
+
+	for {
+		timer := time.NewTimer(time.Until(nextDailyRun(s.now().UTC(), hour)))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if _, err := s.EnforceRetention(ctx); err != nil {
+			s.logger.Error("data retention enforcement failed", zap.Error(err))
+		}
+	}
+}
+
+// EnforceRetention deletes and archives the data past its retention period. It returns a
+// nil result without doing anything while another server instance enforces retention.
+func (s *DataRetentionService) EnforceRetention(ctx context.Context) (*RetentionResult, error) {
+	unlock, acquired, err := s.locker.TryLock(ctx, DataRetentionLockName)
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		s.logger.Debug("data retention lock held by another instance")
+		return nil, nil
+	}
+	defer unlock()
+
+	now := s.now()
+	result := &RetentionResult{}
+
+	// Record what was done even when a later step fails
+	defer s.audit(ctx, now, result)
+
+	if s.policy.AudioRetentionDays > 0 {
+		if err := s.deleteExpiredMessages(ctx, now.AddDate(0, 0, -s.policy.AudioRetentionDays), result); err != nil {
+			return result, err
+		}
+	}
+
+	if s.policy.CheckInRetentionDays > 0 {
+		before := truncateToDay(now.UTC()).AddDate(0, 0, -s.policy.CheckInRetentionDays)
+		for {
+			archived, err := s.store.ArchiveCheckIns(ctx, before, retentionBatchSize)
+			if err != nil {
+				return result, err
+			}
+			result.CheckInsArchived += archived
+			if archived < retentionBatchSize {
+				break
+			}
+		}
+	}
+
+	s.logger.Info("data retention enforced",
+		zap.Int64("messages_deleted", result.MessagesDeleted),
+		zap.Int("audio_files_deleted", result.AudioFilesDeleted),
+		zap.Int("audio_failures", result.AudioFailures),
+		zap.Int64("check_ins_archived", result.CheckInsArchived),
+	)
+	return result, nil
+}
+
+// deleteExpiredMessages deletes the conversation messages created before the given time
+// in batches, each after its audio files
+func (s *DataRetentionService) deleteExpiredMessages(ctx context.Context, before time.Time, result *RetentionResult) error {
+	// Messages whose audio failed to delete are found again; skip them for this run
+	failed := make(map[string]bool)
+
+	for {
+		limit := retentionBatchSize + len(failed)
+		messages, err := s.store.FindExpiredMessages(ctx, before, limit)
+		if err != nil {
+			return err
+		}
+
+		var ids []string
+		for _, msg := range messages {
+			if failed[msg.ID] {
+				continue
+			}
+			if s.deleteAudio(ctx, msg, result) {
+				ids = append(ids, msg.ID)
+			} else {
+				failed[msg.ID] = true
+				result.AudioFailures++
+			}
+		}
+
+		if len(ids) > 0 {
+			deleted, err := s.store.DeleteMessages(ctx, ids)
+			if err != nil {
+				return err
+			}
+			result.MessagesDeleted += deleted
+		}
+
+		if len(messages) < limit || len(ids) == 0 {
+			return ctx.Err()
+		}
+	}
+}
+
+// deleteAudio deletes the audio files of a message, reporting whether all were deleted
+func (s *DataRetentionService) deleteAudio(ctx context.Context, msg repository.ExpiredMessage, result *RetentionResult) bool {
+	for _, blob := range msg.Audio {
+		deleter, err := s.audioDeleter(blob.Backend)
+		if err == nil {
+			err = deleter.DeleteAudio(ctx, blob.Path)
+		}
+		if err != nil {
+			s.logger.Warn("failed to delete expired audio, keeping its message",
+				zap.Error(err),
+				zap.String("message_id", msg.ID),
+				zap.String("blob_name", blob.Path),
+				zap.String("backend", blob.Backend),
+			)
+			return false
+		}
+		result.AudioFilesDeleted++
+	}
+	return true
+}
+
+// audioDeleter returns the deleter of audio stored in backend
+func (s *DataRetentionService) audioDeleter(backend string) (AudioDeleter, error) {
+	if s.storage != nil {
+		return s.storage.Backend(backend)
+	}
+	if backend != "" && backend != DefaultStorageBackend {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStorageBackend, backend)
+	}
+	return s.audio, nil
+}
+
+// audit records an enforcement run in the audit log
+func (s *DataRetentionService) audit(ctx context.Context, startedAt time.Time, result *RetentionResult) {
+	if s.auditLogger == nil {
+		return
+	}
+
+	err := s.auditLogger.Log(context.WithoutCancel(ctx), audit.AuditLog{
+		UserID:        retentionAuditUserID,
+		OperationType: audit.OperationDelete,
+		ResourceType:  audit.ResourceDataRetention,
+		ResourceID:    startedAt.UTC().Format(time.DateOnly),
+		AdditionalData: map[string]interface{}{
+			"messages_deleted":        result.MessagesDeleted,
+			"audio_files_deleted":     result.AudioFilesDeleted,
+			"audio_failures":          result.AudioFailures,
+			"check_ins_archived":      result.CheckInsArchived,
+			"audio_retention_days":    s.policy.AudioRetentionDays,
+			"check_in_retention_days": s.policy.CheckInRetentionDays,
+		},
+	})
+	if err != nil {
+		s.logger.Error("failed to audit data retention run", zap.Error(err))
+	}
+}
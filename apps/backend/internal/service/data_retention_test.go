@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// retainedMessage is a conversation message of a fakeRetentionStore
+type retainedMessage struct {
+	createdAt  time.Time
+	audioPaths []string
+	backend    string // storage backend of the audio, the default when empty
+}
+
+// fakeRetentionStore is an in-memory RetentionStore
+type fakeRetentionStore struct {
+	messages     map[string]retainedMessage
+	checkIns     map[string]time.Time
+	archived     []string
+	archiveCalls int
+}
+
+func (f *fakeRetentionStore) FindExpiredMessages(ctx context.Context, before time.Time, limit int) ([]repository.ExpiredMessage, error) {
+	var ids []string
+	for id, msg := range f.messages {
+		if msg.createdAt.Before(before) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	messages := make([]repository.ExpiredMessage, len(ids))
+	for i, id := range ids {
+		messages[i] = repository.ExpiredMessage{ID: id}
+		for _, path := range f.messages[id].audioPaths {
+			messages[i].Audio = append(messages[i].Audio, model.StoredBlob{Backend: f.messages[id].backend, Path: path})
+		}
+	}
+	return messages, nil
+}
+
+func (f *fakeRetentionStore) DeleteMessages(ctx context.Context, ids []string) (int64, error) {
+	for _, id := range ids {
+		delete(f.messages, id)
+	}
+	return int64(len(ids)), nil
+}
+
+func (f *fakeRetentionStore) ArchiveCheckIns(ctx context.Context, before time.Time, limit int) (int64, error) {
+	f.archiveCalls++
+	var ids []string
+	for id, date := range f.checkIns {
+		if date.Before(before) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	for _, id := range ids {
+		delete(f.checkIns, id)
+		f.archived = append(f.archived, id)
+	}
+	return int64(len(ids)), nil
+}
+
+// fakeAudioDeleter records deleted audio files, failing for the given ones
+type fakeAudioDeleter struct {
+	deleted []string
+	failing map[string]bool
+}
+
+func (f *fakeAudioDeleter) DeleteAudio(ctx context.Context, blobName string) error {
+	if f.failing[blobName] {
+		return errors.New("storage unavailable")
+	}
+	f.deleted = append(f.deleted, blobName)
+	return nil
+}
+
+func newTestRetentionService(store *fakeRetentionStore, audio *fakeAudioDeleter, locker *fakeJobLocker, policy RetentionPolicy, now time.Time) *DataRetentionService {
+	service := NewDataRetentionService(store, audio, locker, policy, zap.NewNop())
+	service.now = func() time.Time { return now }
+	return service
+}
+
+func TestDataRetentionService_DeletesExpiredMessagesAndAudio(t *testing.T) {
+	now := time.Date(2026, 6, 1, 3, 0, 0, 0, time.UTC)
+	store := &fakeRetentionStore{messages: map[string]retainedMessage{
+		"old-1":  {createdAt: now.AddDate(0, 0, -120), audioPaths: []string{"audio/old-1.wav", "audio/old-1-recording.wav"}},
+		"old-2":  {createdAt: now.AddDate(0, 0, -91)},
+		"recent": {createdAt: now.AddDate(0, 0, -89), audioPaths: []string{"audio/recent.wav"}},
+	}}
+	audio := &fakeAudioDeleter{}
+	service := newTestRetentionService(store, audio, &fakeJobLocker{}, RetentionPolicy{AudioRetentionDays: 90}, now)
+
+	result, err := service.EnforceRetention(context.Background())
+
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, result.MessagesDeleted)
+	assert.Equal(t, 2, result.AudioFilesDeleted)
+	assert.ElementsMatch(t, []string{"audio/old-1.wav", "audio/old-1-recording.wav"}, audio.deleted)
+	assert.Contains(t, store.messages, "recent")
+	assert.Len(t, store.messages, 1)
+}
+
+func TestDataRetentionService_DeletesAudioFromRecordedBackend(t *testing.T) {
+	now := time.Date(2026, 6, 1, 3, 0, 0, 0, time.UTC)
+	store := &fakeRetentionStore{messages: map[string]retainedMessage{
+		"old": {createdAt: now.AddDate(0, 0, -120), audioPaths: []string{"audio/old.wav"}, backend: "eu"},
+	}}
+	defaultAudio := azure.NewMockBlobStorageClient(nil)
+	euAudio := azure.NewMockBlobStorageClient(nil)
+	for _, storage := range []*azure.MockBlobStorageClient{defaultAudio, euAudio} {
+		_, err := storage.UploadAudio(context.Background(), "old.wav", strings.NewReader("audio"))
+		require.NoError(t, err)
+	}
+	service := newTestRetentionService(store, &fakeAudioDeleter{}, &fakeJobLocker{}, RetentionPolicy{AudioRetentionDays: 90}, now)
+
+	// Without a router audio outside the default backend cannot be deleted
+	result, err := service.EnforceRetention(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.AudioFailures)
+	assert.Contains(t, store.messages, "old")
+
+	router := NewStorageRouter(defaultAudio, zap.NewNop())
+	router.AddBackend("eu", euAudio)
+	service.SetStorageRouter(router)
+
+	result, err = service.EnforceRetention(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.AudioFilesDeleted)
+	assert.Empty(t, euAudio.ListBlobs())
+	assert.Equal(t, []string{"audio/old.wav"}, defaultAudio.ListBlobs(), "the same name in the default backend is left alone")
+	assert.NotContains(t, store.messages, "old")
+}
+
+func TestDataRetentionService_KeepsMessagesWhoseAudioFailedToDelete(t *testing.T) {
+	now := time.Date(2026, 6, 1, 3, 0, 0, 0, time.UTC)
+	store := &fakeRetentionStore{messages: make(map[string]retainedMessage)}
+	for i := 0; i < retentionBatchSize+10; i++ {
+		id := fmt.Sprintf("msg-%04d", i)
+		store.messages[id] = retainedMessage{createdAt: now.AddDate(-1, 0, 0), audioPaths: []string{"audio/" + id + ".wav"}}
+	}
+	audio := &fakeAudioDeleter{failing: map[string]bool{"audio/msg-0000.wav": true, "audio/msg-0003.wav": true}}
+	service := newTestRetentionService(store, audio, &fakeJobLocker{}, RetentionPolicy{AudioRetentionDays: 90}, now)
+
+	result, err := service.EnforceRetention(context.Background())
+
+	require.NoError(t, err)
+	assert.EqualValues(t, retentionBatchSize+8, result.MessagesDeleted)
+	assert.Equal(t, 2, result.AudioFailures)
+	assert.Len(t, store.messages, 2)
+	assert.Contains(t, store.messages, "msg-0000")
+	assert.Contains(t, store.messages, "msg-0003")
+}
+
+func TestDataRetentionService_ArchivesExpiredCheckInsInBatches(t *testing.T) {
+	now := time.Date(2026, 6, 1, 3, 0, 0, 0, time.UTC)
+	store := &fakeRetentionStore{checkIns: map[string]time.Time{"recent": now.AddDate(0, 0, -30)}}
+	for i := 0; i < retentionBatchSize+1; i++ {
+		store.checkIns[fmt.Sprintf("old-%04d", i)] = now.AddDate(-3, 0, 0)
+	}
+	service := newTestRetentionService(store, &fakeAudioDeleter{}, &fakeJobLocker{}, RetentionPolicy{CheckInRetentionDays: 730}, now)
+
+	result, err := service.EnforceRetention(context.Background())
+
+	require.NoError(t, err)
+	assert.EqualValues(t, retentionBatchSize+1, result.CheckInsArchived)
+	assert.Equal(t, 2, store.archiveCalls)
+	assert.Equal(t, map[string]time.Time{"recent": now.AddDate(0, 0, -30)}, store.checkIns)
+}
+
+func TestDataRetentionService_DisabledPeriodsKeepData(t *testing.T) {
+	now := time.Date(2026, 6, 1, 3, 0, 0, 0, time.UTC)
+	store := &fakeRetentionStore{
+		messages: map[string]retainedMessage{"old": {createdAt: now.AddDate(-5, 0, 0)}},
+		checkIns: map[string]time.Time{"old": now.AddDate(-5, 0, 0)},
+	}
+	service := newTestRetentionService(store, &fakeAudioDeleter{}, &fakeJobLocker{}, RetentionPolicy{}, now)
+
+	result, err := service.EnforceRetention(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, &RetentionResult{}, result)
+	assert.Len(t, store.messages, 1)
+	assert.Len(t, store.checkIns, 1)
+}
+
+func TestDataRetentionService_SkipsWhileAnotherInstanceEnforces(t *testing.T) {
+	now := time.Date(2026, 6, 1, 3, 0, 0, 0, time.UTC)
+	store := &fakeRetentionStore{messages: map[string]retainedMessage{"old": {createdAt: now.AddDate(-1, 0, 0)}}}
+	service := newTestRetentionService(store, &fakeAudioDeleter{}, &fakeJobLocker{held: true}, RetentionPolicy{AudioRetentionDays: 90}, now)
+
+	result, err := service.EnforceRetention(context.Background())
+
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Len(t, store.messages, 1)
+}
@@ -0,0 +1,346 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"go.uber.org/zap"
+)
+
+// DiagnosticStatus is the outcome of a diagnostic check
+type DiagnosticStatus string
+
+const (
+	DiagnosticPass DiagnosticStatus = "pass"
+	DiagnosticWarn DiagnosticStatus = "warn"
+	DiagnosticFail DiagnosticStatus = "fail"
+)
+
+// DiagnosticCheck verifies that one dependency is reachable and configured correctly
+type DiagnosticCheck struct {
+	Name string
+	// Critical checks stop the server at startup when they fail; failures of other
+	// checks are reported as warnings
+	Critical bool
+	Run      func(ctx context.Context) error
+}
+
+// DiagnosticError is a failed check with a hint on how to fix it. Warning failures do
+// not stop the server even when the check is critical.
+type DiagnosticError struct {
+	Err         error
+	Remediation string
+	Warning     bool
+}
+
+func (e *DiagnosticError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DiagnosticError) Unwrap() error {
+	return e.Err
+}
+
+// DiagnosticResult is the outcome of one check
+type DiagnosticResult struct {
+	Name        string           `json:"name"`
+	Status      DiagnosticStatus `json:"status"`
+	Critical    bool             `json:"critical"`
+	DurationMs  int64            `json:"duration_ms"`
+	Error       string           `json:"error,omitempty"`
+	Remediation string           `json:"remediation,omitempty"`
+}
+
+// DiagnosticsReport is the outcome of running every check
+type DiagnosticsReport struct {
+	Healthy   bool               `json:"healthy"` // false when a critical check failed
+	CheckedAt time.Time          `json:"checked_at"`
+	Results   []DiagnosticResult `json:"results"`
+}
+
+// DiagnosticsService runs cheap real requests against the database and Azure services to
+// catch misconfiguration, at startup and on demand
+type DiagnosticsService struct {
+	checks  []DiagnosticCheck
+	timeout time.Duration // bound on a single check
+	logger  *zap.Logger
+}
+
+// NewDiagnosticsService creates a new DiagnosticsService
+func NewDiagnosticsService(timeout time.Duration, logger *zap.Logger) *DiagnosticsService {
+	return &DiagnosticsService{
+		timeout: timeout,
+		logger:  logger,
+	}
+}
+
+// Register adds a check, run in the order registered
+func (s *DiagnosticsService) Register(check DiagnosticCheck) {
+	s.checks = append(s.checks, check)
+}
+
+// Run runs every check concurrently and reports their outcomes in registration order
+func (s *DiagnosticsService) Run(ctx context.Context) *DiagnosticsReport {
+	report := &DiagnosticsReport{
+		Healthy:   true,
+		CheckedAt: time.Now().UTC(),
+		Results:   make([]DiagnosticResult, len(s.checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, check := range s.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Results[i] = s.runCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range report.Results {
+		if result.Status == DiagnosticFail {
+			report.Healthy = false
+		}
+	}
+	return report
+}
+
+// runCheck runs a single check within the check timeout
+func (s *DiagnosticsService) runCheck(ctx context.Context, check DiagnosticCheck) DiagnosticResult {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Run(ctx)
+	result := DiagnosticResult{
+		Name:       check.Name,
+		Status:     DiagnosticPass,
+		Critical:   check.Critical,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err == nil {
+		return result
+	}
+
+	result.Error = err.Error()
+	result.Status = DiagnosticWarn
+	var diagErr *DiagnosticError
+	if errors.As(err, &diagErr) {
+		result.Remediation = diagErr.Remediation
+		if check.Critical && !diagErr.Warning {
+			result.Status = DiagnosticFail
+		}
+	} else if check.Critical {
+		result.Status = DiagnosticFail
+	}
+	if errors.Is(err, context.DeadlineExceeded) && result.Remediation == "" {
+		result.Remediation = fmt.Sprintf("no answer within %s; check network access to the service", s.timeout)
+	}
+
+	s.logger.Warn("diagnostic check failed",
+		zap.String("check", check.Name),
+		zap.String("status", string(result.Status)),
+		zap.Error(err),
+	)
+	return result
+}
+
+// WriteTable writes the report as a table of check outcomes followed by the remediation
+// hints of failed checks
+func (r *DiagnosticsReport) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tSTATUS\tTIME\tERROR")
+	for _, result := range r.Results {
+		fmt.Fprintf(tw, "%s\t%s\t%dms\t%s\n", result.Name, strings.ToUpper(string(result.Status)), result.DurationMs, result.Error)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, result := range r.Results {
+		if result.Remediation != "" {
+			if _, err := fmt.Fprintf(w, "  %s: %s\n", result.Name, result.Remediation); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ContainerVerifier checks access to a blob storage container
+type ContainerVerifier interface {
+	ContainerName() string
+	VerifyContainer(ctx context.Context) error
+}
+
+// BlobContainerCheck checks that a blob container exists and is accessible; envVar names
+// the setting choosing the container, if there is one
+func BlobContainerCheck(name, envVar string, container ContainerVerifier) DiagnosticCheck {
+	return DiagnosticCheck{
+		Name:     name,
+		Critical: true,
+		Run: func(ctx context.Context) error {
+			err := container.VerifyContainer(ctx)
+			if err == nil {
+				return nil
+			}
+
+			remediation := "check AZURE_STORAGE_ACCOUNT_NAME and network access to the storage account"
+			switch azure.HTTPStatus(err) {
+			case http.StatusNotFound:
+				remediation = fmt.Sprintf("create the container %q in the storage account", container.ContainerName())
+				if envVar != "" {
+					remediation += fmt.Sprintf(" or set %s to an existing container", envVar)
+				}
+			case http.StatusUnauthorized, http.StatusForbidden:
+				remediation = "check AZURE_STORAGE_ACCOUNT_KEY, or in managed_identity mode that the identity has the Storage Blob Data Contributor role"
+			}
+			return &DiagnosticError{Err: err, Remediation: remediation}
+		},
+	}
+}
+
+// SpeechTokenVerifier checks the speech service subscription key
+type SpeechTokenVerifier interface {
+	VerifyToken(ctx context.Context) error
+}
+
+// SpeechTokenCheck checks that the speech service issues tokens for the subscription key
+func SpeechTokenCheck(speech SpeechTokenVerifier) DiagnosticCheck {
+	return DiagnosticCheck{
+		Name:     "azure_speech",
+		Critical: true,
+		Run: func(ctx context.Context) error {
+			err := speech.VerifyToken(ctx)
+			if err == nil {
+				return nil
+			}
+
+			remediation := "check AZURE_SPEECH_REGION is the region of the Speech resource, e.g. westeurope"
+			switch azure.HTTPStatus(err) {
+			case http.StatusUnauthorized, http.StatusForbidden:
+				remediation = "check AZURE_SPEECH_KEY is a key of the Speech resource in AZURE_SPEECH_REGION"
+			case http.StatusTooManyRequests:
+				return &DiagnosticError{Err: err, Remediation: "the Speech resource is throttling requests", Warning: true}
+			}
+			return &DiagnosticError{Err: err, Remediation: remediation}
+		},
+	}
+}
+
+// DeploymentPinger sends a minimal request to an Azure OpenAI deployment
+type DeploymentPinger interface {
+	Deployment() string
+	Ping(ctx context.Context) error
+}
+
+// OpenAIDeploymentCheck checks that the Azure OpenAI deployment answers a one-token
+// completion
+func OpenAIDeploymentCheck(openAI DeploymentPinger) DiagnosticCheck {
+	return DiagnosticCheck{
+		Name:     "azure_openai",
+		Critical: true,
+		Run: func(ctx context.Context) error {
+			err := openAI.Ping(ctx)
+			if err == nil {
+				return nil
+			}
+
+			remediation := "check AZURE_OPENAI_ENDPOINT and network access to the Azure OpenAI resource"
+			switch azure.HTTPStatus(err) {
+			case http.StatusNotFound:
+				remediation = fmt.Sprintf("no deployment %q on AZURE_OPENAI_ENDPOINT; set AZURE_OPENAI_DEPLOYMENT to the deployment name, not the model name", openAI.Deployment())
+			case http.StatusUnauthorized, http.StatusForbidden:
+				remediation = "check AZURE_OPENAI_API_KEY, or in managed_identity mode that the identity has the Cognitive Services OpenAI User role"
+			case http.StatusTooManyRequests:
+				// The deployment exists and answered, it is only at its quota
+				return &DiagnosticError{Err: err, Remediation: "the deployment is at its rate limit; consider raising its quota", Warning: true}
+			}
+			return &DiagnosticError{Err: err, Remediation: remediation}
+		},
+	}
+}
+
+// MigrationVersionSource reads the applied migration version
+type MigrationVersionSource interface {
+	MigrationVersion(ctx context.Context) (version int64, dirty bool, err error)
+}
+
+// MigrationCheck checks that the database schema is at the latest migration this build
+// ships. A schema ahead of the build, e.g. while rolling back a deployment, is a warning.
+func MigrationCheck(source MigrationVersionSource, latest int64) DiagnosticCheck {
+	const migrateUp = "run migrate -path migrations -database $DATABASE_URL up"
+
+	return DiagnosticCheck{
+		Name:     "database_migrations",
+		Critical: true,
+		Run: func(ctx context.Context) error {
+			version, dirty, err := source.MigrationVersion(ctx)
+			if errors.Is(err, repository.ErrMigrationsNotApplied) {
+				return &DiagnosticError{Err: err, Remediation: migrateUp}
+			}
+			if err != nil {
+				return &DiagnosticError{Err: err, Remediation: "check DATABASE_URL and that the database user may read schema_migrations"}
+			}
+
+			switch {
+			case dirty:
+				return &DiagnosticError{
+					Err:         fmt.Errorf("migration %d failed halfway", version),
+					Remediation: fmt.Sprintf("repair the schema by hand, run migrate force %d, then %s", version-1, migrateUp),
+				}
+			case version < latest:
+				return &DiagnosticError{
+					Err:         fmt.Errorf("schema at migration %d, this build needs %d", version, latest),
+					Remediation: migrateUp,
+				}
+			case version > latest:
+				return &DiagnosticError{
+					Err:         fmt.Errorf("schema at migration %d, newer than this build's %d", version, latest),
+					Remediation: "a newer build migrated the database; deploy it or migrate down if rolling back",
+					Warning:     true,
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// LatestMigrationVersion returns the highest version among the NNNNNN_name.up.sql
+// migration files in fsys
+func LatestMigrationVersion(fsys fs.FS) (int64, error) {
+	names, err := fs.Glob(fsys, "*.up.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest int64
+	for _, name := range names {
+		prefix, _, ok := strings.Cut(path.Base(name), "_")
+		if !ok {
+			return 0, fmt.Errorf("migration file %q has no version prefix", name)
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("migration file %q has an invalid version: %w", name, err)
+		}
+		latest = max(latest, version)
+	}
+
+	if latest == 0 {
+		return 0, errors.New("no migration files found")
+	}
+	return latest, nil
+}
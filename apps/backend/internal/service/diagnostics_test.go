@@ -0,0 +1,172 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"go.uber.org/zap"
+)
+
+type fakeMigrationSource struct {
+	version int64
+	dirty   bool
+	err     error
+}
+
+func (f fakeMigrationSource) MigrationVersion(ctx context.Context) (int64, bool, error) {
+	return f.version, f.dirty, f.err
+}
+
+type fakeContainer struct {
+	err error
+}
+
+func (f fakeContainer) ContainerName() string { return "health-reports" }
+
+func (f fakeContainer) VerifyContainer(ctx context.Context) error { return f.err }
+
+type fakeDeployment struct {
+	err error
+}
+
+func (f fakeDeployment) Deployment() string { return "gpt-4o-prod" }
+
+func (f fakeDeployment) Ping(ctx context.Context) error { return f.err }
+
+func TestDiagnosticsService_Run(t *testing.T) {
+	diagnostics := NewDiagnosticsService(time.Second, zap.NewNop())
+	diagnostics.Register(DiagnosticCheck{Name: "ok", Critical: true, Run: func(ctx context.Context) error { return nil }})
+	diagnostics.Register(DiagnosticCheck{Name: "optional", Run: func(ctx context.Context) error { return errors.New("down") }})
+	diagnostics.Register(DiagnosticCheck{Name: "throttled", Critical: true, Run: func(ctx context.Context) error {
+		return &DiagnosticError{Err: errors.New("slow down"), Warning: true}
+	}})
+
+	report := diagnostics.Run(context.Background())
+
+	assert.True(t, report.Healthy)
+	require.Len(t, report.Results, 3)
+	assert.Equal(t, "ok", report.Results[0].Name)
+	assert.Equal(t, DiagnosticPass, report.Results[0].Status)
+	assert.Equal(t, DiagnosticWarn, report.Results[1].Status)
+	assert.Equal(t, "down", report.Results[1].Error)
+	assert.Equal(t, DiagnosticWarn, report.Results[2].Status)
+
+	diagnostics.Register(BlobContainerCheck("blob_report_container", "", fakeContainer{
+		err: &azure.StatusError{StatusCode: http.StatusNotFound},
+	}))
+
+	report = diagnostics.Run(context.Background())
+
+	assert.False(t, report.Healthy)
+	assert.Equal(t, DiagnosticFail, report.Results[3].Status)
+	assert.Equal(t, `create the container "health-reports" in the storage account`, report.Results[3].Remediation)
+
+	var table bytes.Buffer
+	require.NoError(t, report.WriteTable(&table))
+	assert.Contains(t, table.String(), "blob_report_container")
+	assert.Contains(t, table.String(), "FAIL")
+	assert.Contains(t, table.String(), `  blob_report_container: create the container "health-reports"`)
+}
+
+func TestDiagnosticsService_TimesOutSlowChecks(t *testing.T) {
+	diagnostics := NewDiagnosticsService(10*time.Millisecond, zap.NewNop())
+	diagnostics.Register(DiagnosticCheck{Name: "hangs", Critical: true, Run: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+
+	report := diagnostics.Run(context.Background())
+
+	assert.False(t, report.Healthy)
+	assert.Equal(t, DiagnosticFail, report.Results[0].Status)
+	assert.Contains(t, report.Results[0].Remediation, "no answer within 10ms")
+}
+
+func TestOpenAIDeploymentCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantErr     bool
+		warning     bool
+		remediation string
+	}{
+		{name: "answers"},
+		{name: "unknown deployment", err: &azure.StatusError{StatusCode: http.StatusNotFound}, wantErr: true, remediation: `no deployment "gpt-4o-prod"`},
+		{name: "wrong key", err: &azure.StatusError{StatusCode: http.StatusUnauthorized}, wantErr: true, remediation: "AZURE_OPENAI_API_KEY"},
+		{name: "rate limited", err: &azure.StatusError{StatusCode: http.StatusTooManyRequests}, wantErr: true, warning: true, remediation: "rate limit"},
+		{name: "unreachable", err: errors.New("dial tcp: no such host"), wantErr: true, remediation: "AZURE_OPENAI_ENDPOINT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := OpenAIDeploymentCheck(fakeDeployment{err: tt.err}).Run(context.Background())
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			var diagErr *DiagnosticError
+			require.ErrorAs(t, err, &diagErr)
+			assert.Equal(t, tt.warning, diagErr.Warning)
+			assert.Contains(t, diagErr.Remediation, tt.remediation)
+		})
+	}
+}
+
+func TestMigrationCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		source      fakeMigrationSource
+		wantErr     bool
+		warning     bool
+		remediation string
+	}{
+		{name: "current", source: fakeMigrationSource{version: 33}},
+		{name: "never migrated", source: fakeMigrationSource{err: repository.ErrMigrationsNotApplied}, wantErr: true, remediation: "migrate -path migrations"},
+		{name: "behind", source: fakeMigrationSource{version: 31}, wantErr: true, remediation: "migrate -path migrations"},
+		{name: "dirty", source: fakeMigrationSource{version: 33, dirty: true}, wantErr: true, remediation: "migrate force 32"},
+		{name: "ahead", source: fakeMigrationSource{version: 34}, wantErr: true, warning: true, remediation: "newer build"},
+		{name: "unreachable", source: fakeMigrationSource{err: errors.New("connection refused")}, wantErr: true, remediation: "DATABASE_URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := MigrationCheck(tt.source, 33).Run(context.Background())
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			var diagErr *DiagnosticError
+			require.ErrorAs(t, err, &diagErr)
+			assert.Equal(t, tt.warning, diagErr.Warning)
+			assert.Contains(t, diagErr.Remediation, tt.remediation)
+		})
+	}
+}
+
+func TestLatestMigrationVersion(t *testing.T) {
+	version, err := LatestMigrationVersion(fstest.MapFS{
+		"000001_init_schema.up.sql":             {},
+		"000033_add_check_ins_archive.up.sql":   {},
+		"000009_add_alerts.up.sql":              {},
+		"000033_add_check_ins_archive.down.sql": {},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(33), version)
+
+	_, err = LatestMigrationVersion(fstest.MapFS{"init.up.sql": {}})
+	assert.Error(t, err)
+
+	_, err = LatestMigrationVersion(fstest.MapFS{})
+	assert.Error(t, err)
+}
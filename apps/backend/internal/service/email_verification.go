@@ -0,0 +1,414 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/delivery"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+	"golang.org/x/net/idna"
+)
+
+const (
+	// maxEmailLength is the longest address that fits a SMTP forward path
+	maxEmailLength = 254
+
+	// emailVerificationWindow is the period the daily confirmation email limit counts over
+	emailVerificationWindow = 24 * time.Hour
+
+	// Defaults of the email verification settings
+	defaultEmailVerificationTTL   = 24 * time.Hour
+	defaultEmailResendInterval    = time.Minute
+	defaultEmailVerificationDaily = 5
+)
+
+// Templates of the confirmation email
+const (
+	emailVerificationSubjectTemplate = `Confirm your email address`
+	emailVerificationBodyTemplate    = `Please confirm that {{.Email}} is your email address by opening this link:
+
+{{.Link}}
+
+The link can be used once and expires on {{.ExpiresAt.UTC.Format "2006-01-02 15:04"}} UTC. If you did not add this address, you can ignore this email.
+`
+)
+
+var (
+	// ErrEmailNotVerified is returned when email would be sent to an address its user
+	// has not confirmed
+	ErrEmailNotVerified = errors.New("email address is not verified")
+
+	// ErrEmailAlreadyVerified is returned when requesting a confirmation email for an
+	// address that is already verified
+	ErrEmailAlreadyVerified = errors.New("email address is already verified")
+
+	// ErrEmailVerificationInvalid is returned for a confirmation token that is malformed,
+	// forged, expired, already used, or issued for an address the user no longer has
+	ErrEmailVerificationInvalid = errors.New("email confirmation token is invalid or has expired")
+
+	// ErrNoEmail is returned when requesting a confirmation email for a user without an address
+	ErrNoEmail = errors.New("user has no email address")
+)
+
+// EmailVerificationRateLimitError is returned when a user asks for confirmation emails
+// too often. It matches ErrEmailVerificationRateLimited.
+type EmailVerificationRateLimitError struct {
+	RetryAfter time.Duration
+}
+
+// ErrEmailVerificationRateLimited matches every EmailVerificationRateLimitError
+var ErrEmailVerificationRateLimited = errors.New("too many confirmation emails requested")
+
+func (e *EmailVerificationRateLimitError) Error() string {
+	return fmt.Sprintf("%v, retry after %s", ErrEmailVerificationRateLimited, e.RetryAfter.Round(time.Second))
+}
+
+// Is reports whether target is ErrEmailVerificationRateLimited
+func (e *EmailVerificationRateLimitError) Is(target error) bool {
+	return target == ErrEmailVerificationRateLimited
+}
+
+// NormalizeEmail validates a bare email address and returns it trimmed, lowercased and
+// with an internationalized domain in its ASCII (punycode) form. It returns an error
+// wrapping ErrInvalidEmail for anything else, including addresses with a display name.
+func NormalizeEmail(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	address, err := mail.ParseAddress(raw)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidEmail, err)
+	}
+	if address.Address != raw {
+		return "", fmt.Errorf("%w: expected a bare address without a display name", ErrInvalidEmail)
+	}
+
+	at := strings.LastIndex(address.Address, "@")
+	local, domain := address.Address[:at], address.Address[at+1:]
+	domain, err = idna.Lookup.ToASCII(domain)
+	if err != nil {
+		return "", fmt.Errorf("%w: domain: %v", ErrInvalidEmail, err)
+	}
+
+	normalized := strings.ToLower(local) + "@" + domain
+	if len(normalized) > maxEmailLength {
+		return "", fmt.Errorf("%w: longer than %d characters", ErrInvalidEmail, maxEmailLength)
+	}
+	return normalized, nil
+}
+
+// EmailVerificationStore defines the persistence operations for user email addresses
+type EmailVerificationStore interface {
+	FindByID(ctx context.Context, userID string) (*model.User, error)
+	SetEmail(ctx context.Context, userID, email string) (*model.User, error)
+	CreateEmailVerificationToken(ctx context.Context, token *model.EmailVerificationToken) error
+	CountEmailVerificationTokens(ctx context.Context, userID string, since time.Time) (int, *time.Time, error)
+	ConfirmEmail(ctx context.Context, tokenID string, now time.Time) (*model.User, error)
+}
+
+// Notifier sends a message to a single email address
+type Notifier interface {
+	NotifyEmail(ctx context.Context, to string, msg delivery.Message) error
+}
+
+// EmailVerificationConfig configures the confirmation tokens and their emails
+type EmailVerificationConfig struct {
+	Secret         []byte        // signs the tokens; a random secret is used when empty
+	TTL            time.Duration // how long a token stays valid
+	ResendInterval time.Duration // minimum time between two confirmation emails of a user
+	MaxPerDay      int           // confirmation emails a user may get within 24 hours
+	ConfirmURL     string        // link the token is appended to as ?token=
+}
+
+// EmailVerificationService normalizes user email addresses and confirms them with signed,
+// single-use tokens emailed to the address
+type EmailVerificationService struct {
+	store       EmailVerificationStore
+	notifier    Notifier
+	cfg         EmailVerificationConfig
+	message     *delivery.Template
+	logger      *zap.Logger
+	auditLogger *audit.Logger
+	now         func() time.Time
+}
+
+// NewEmailVerificationService creates a new EmailVerificationService. Unset settings
+// take their defaults.
+func NewEmailVerificationService(store EmailVerificationStore, notifier Notifier, cfg EmailVerificationConfig, logger *zap.Logger) *EmailVerificationService {
+	message, err := delivery.ParseTemplate(emailVerificationSubjectTemplate, emailVerificationBodyTemplate)
+	if err != nil {
+		panic(fmt.Sprintf("invalid email verification template: %v", err))
+	}
+
+	if len(cfg.Secret) == 0 {
+		cfg.Secret = make([]byte, 32)
+		if _, err := rand.Read(cfg.Secret); err != nil {
+			panic(fmt.Sprintf("failed to generate email verification secret: %v", err))
+		}
+		logger.Warn("no email verification secret configured, confirmation links stop working on restart")
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultEmailVerificationTTL
+	}
+	if cfg.ResendInterval <= 0 {
+		cfg.ResendInterval = defaultEmailResendInterval
+	}
+	if cfg.MaxPerDay <= 0 {
+		cfg.MaxPerDay = defaultEmailVerificationDaily
+	}
+
+	return &EmailVerificationService{
+		store:    store,
+		notifier: notifier,
+		cfg:      cfg,
+		message:  message,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// SetAuditLogger enables audit logging of email changes and confirmations
+func (s *EmailVerificationService) SetAuditLogger(auditLogger *audit.Logger) {
+	s.auditLogger = auditLogger
+}
+
+// GetProfile returns a user's profile with the verification state of their address. It
+// returns repository.ErrUserNotFound for unknown and deleted users.
+func (s *EmailVerificationService) GetProfile(ctx context.Context, userID string) (*model.User, error) {
+	user, err := s.store.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.DeletedAt != nil {
+		return nil, repository.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// ChangeEmail sets a user's email address, creating the user if needed, and emails a
+// confirmation token to a new or still unverified address. Setting the verified address
+// again changes nothing. It fails with ErrInvalidEmail, repository.ErrEmailTaken or an
+// EmailVerificationRateLimitError; the address is not changed then.
+func (s *EmailVerificationService) ChangeEmail(ctx context.Context, userID, email string) (*model.User, error) {
+	email, err := NormalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.store.FindByID(ctx, userID)
+	switch {
+	case errors.Is(err, repository.ErrUserNotFound):
+	case err != nil:
+		return nil, err
+	case current.DeletedAt != nil:
+		return nil, repository.ErrUserNotFound
+	case current.Email == email && current.EmailVerified():
+		return current, nil
+	}
+
+	// Checked before the change, so a limited user cannot switch to an address left unconfirmed
+	if err := s.checkRateLimit(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.store.SetEmail(ctx, userID, email)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, userID, audit.OperationUpdate, map[string]interface{}{"action": "change_email"})
+
+	if err := s.sendConfirmation(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ResendConfirmation emails a new confirmation token for the user's unverified address
+func (s *EmailVerificationService) ResendConfirmation(ctx context.Context, userID string) error {
+	user, err := s.GetProfile(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Email == "" {
+		return ErrNoEmail
+	}
+	if user.EmailVerified() {
+		return ErrEmailAlreadyVerified
+	}
+
+	if err := s.checkRateLimit(ctx, userID); err != nil {
+		return err
+	}
+	return s.sendConfirmation(ctx, user)
+}
+
+// ConfirmEmail uses a confirmation token, marking the address it was issued for verified
+func (s *EmailVerificationService) ConfirmEmail(ctx context.Context, token string) (*model.User, error) {
+	tokenID, err := s.verifyToken(strings.TrimSpace(token))
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.store.ConfirmEmail(ctx, tokenID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrEmailVerificationInvalid
+	}
+
+	s.audit(ctx, user.ID, audit.OperationUpdate, map[string]interface{}{"action": "confirm_email"})
+	s.logger.Info("email address verified", zap.String("user_id", user.ID))
+	return user, nil
+}
+
+// RequireVerified returns ErrEmailNotVerified unless email is the user's current,
+// verified address. Features sending email to users check it before every send.
+func (s *EmailVerificationService) RequireVerified(ctx context.Context, userID, email string) error {
+	email, err := NormalizeEmail(email)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.GetProfile(ctx, userID)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return ErrEmailNotVerified
+	}
+	if err != nil {
+		return err
+	}
+	if user.Email != email || !user.EmailVerified() {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
+
+// checkRateLimit fails with an EmailVerificationRateLimitError when the user got a
+// confirmation email within the resend interval, or the daily maximum of them
+func (s *EmailVerificationService) checkRateLimit(ctx context.Context, userID string) error {
+	now := s.now()
+	count, latest, err := s.store.CountEmailVerificationTokens(ctx, userID, now.Add(-emailVerificationWindow))
+	if err != nil {
+		return err
+	}
+	if latest == nil {
+		return nil
+	}
+
+	if wait := latest.Add(s.cfg.ResendInterval).Sub(now); wait > 0 {
+		return &EmailVerificationRateLimitError{RetryAfter: wait}
+	}
+	if count >= s.cfg.MaxPerDay {
+		// The window frees up gradually; the latest email bounds the wait
+		return &EmailVerificationRateLimitError{RetryAfter: latest.Add(emailVerificationWindow).Sub(now)}
+	}
+	return nil
+}
+
+// sendConfirmation issues a token for the user's address and emails its link
+func (s *EmailVerificationService) sendConfirmation(ctx context.Context, user *model.User) error {
+	now := s.now()
+	record := &model.EmailVerificationToken{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		Email:     user.Email,
+		ExpiresAt: now.Add(s.cfg.TTL),
+		CreatedAt: now,
+	}
+	if err := s.store.CreateEmailVerificationToken(ctx, record); err != nil {
+		return err
+	}
+
+	msg, err := s.message.Render(map[string]interface{}{
+		"Email":     record.Email,
+		"Link":      s.confirmLink(s.signToken(record.ID, record.ExpiresAt)),
+		"ExpiresAt": record.ExpiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultDeliveryTimeout)
+	defer cancel()
+	if err := s.notifier.NotifyEmail(ctx, record.Email, msg); err != nil {
+		s.logger.Error("failed to send confirmation email", zap.Error(err), zap.String("user_id", user.ID))
+		return fmt.Errorf("failed to send confirmation email: %w", err)
+	}
+
+	s.logger.Info("confirmation email sent", zap.String("user_id", user.ID))
+	return nil
+}
+
+// confirmLink appends the token to the configured confirmation URL
+func (s *EmailVerificationService) confirmLink(token string) string {
+	separator := "?"
+	if strings.Contains(s.cfg.ConfirmURL, "?") {
+		separator = "&"
+	}
+	return s.cfg.ConfirmURL + separator + "token=" + token
+}
+
+// signToken builds the token handed to the user: the token ID and expiry, signed with
+// HMAC-SHA256 so they cannot be forged or altered
+func (s *EmailVerificationService) signToken(tokenID string, expiresAt time.Time) string {
+	payload := tokenID + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return payload + "." + s.signature(payload)
+}
+
+// verifyToken checks a token's signature and expiry and returns its ID
+func (s *EmailVerificationService) verifyToken(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", ErrEmailVerificationInvalid
+	}
+
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(s.signature(payload))) {
+		return "", ErrEmailVerificationInvalid
+	}
+
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || !s.now().Before(time.Unix(expiresAt, 0)) {
+		return "", ErrEmailVerificationInvalid
+	}
+	if _, err := uuid.Parse(parts[0]); err != nil {
+		return "", ErrEmailVerificationInvalid
+	}
+	return parts[0], nil
+}
+
+// signature returns the base64url-encoded HMAC of a token payload
+func (s *EmailVerificationService) signature(payload string) string {
+	mac := hmac.New(sha256.New, s.cfg.Secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// audit records a change of a user's email address when audit logging is enabled
+func (s *EmailVerificationService) audit(ctx context.Context, userID string, operation audit.OperationType, details map[string]interface{}) {
+	if s.auditLogger == nil {
+		return
+	}
+	err := s.auditLogger.Log(ctx, audit.AuditLog{
+		UserID:         userID,
+		OperationType:  operation,
+		ResourceType:   audit.ResourceUser,
+		ResourceID:     userID,
+		AdditionalData: details,
+	})
+	if err != nil {
+		s.logger.Error("failed to audit email change", zap.Error(err), zap.String("user_id", userID))
+	}
+}
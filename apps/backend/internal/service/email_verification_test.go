@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/delivery"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// fakeEmailVerificationStore is an in-memory EmailVerificationStore
+type fakeEmailVerificationStore struct {
+	users  map[string]*model.User
+	tokens []*model.EmailVerificationToken
+}
+
+func newFakeEmailVerificationStore() *fakeEmailVerificationStore {
+	return &fakeEmailVerificationStore{users: make(map[string]*model.User)}
+}
+
+func (f *fakeEmailVerificationStore) FindByID(ctx context.Context, userID string) (*model.User, error) {
+	user, ok := f.users[userID]
+	if !ok {
+		return nil, repository.ErrUserNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (f *fakeEmailVerificationStore) SetEmail(ctx context.Context, userID, email string) (*model.User, error) {
+	for id, other := range f.users {
+		if id != userID && other.Email == email {
+			return nil, repository.ErrEmailTaken
+		}
+	}
+	user, ok := f.users[userID]
+	if !ok {
+		user = &model.User{ID: userID}
+		f.users[userID] = user
+	}
+	if user.Email != email {
+		user.Email = email
+		user.EmailVerifiedAt = nil
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (f *fakeEmailVerificationStore) CreateEmailVerificationToken(ctx context.Context, token *model.EmailVerificationToken) error {
+	copied := *token
+	f.tokens = append(f.tokens, &copied)
+	return nil
+}
+
+func (f *fakeEmailVerificationStore) CountEmailVerificationTokens(ctx context.Context, userID string, since time.Time) (int, *time.Time, error) {
+	count := 0
+	var latest *time.Time
+	for _, token := range f.tokens {
+		if token.UserID != userID || token.CreatedAt.Before(since) {
+			continue
+		}
+		count++
+		if latest == nil || token.CreatedAt.After(*latest) {
+			createdAt := token.CreatedAt
+			latest = &createdAt
+		}
+	}
+	return count, latest, nil
+}
+
+func (f *fakeEmailVerificationStore) ConfirmEmail(ctx context.Context, tokenID string, now time.Time) (*model.User, error) {
+	for _, token := range f.tokens {
+		if token.ID != tokenID {
+			continue
+		}
+		user := f.users[token.UserID]
+		if token.UsedAt != nil || !now.Before(token.ExpiresAt) || user == nil || user.Email != token.Email {
+			return nil, nil
+		}
+		token.UsedAt = &now
+		user.EmailVerifiedAt = &now
+		copied := *user
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+// recordingNotifier records the emails it is asked to send
+type recordingNotifier struct {
+	to   []string
+	sent []delivery.Message
+}
+
+func (r *recordingNotifier) NotifyEmail(ctx context.Context, to string, msg delivery.Message) error {
+	r.to = append(r.to, to)
+	r.sent = append(r.sent, msg)
+	return nil
+}
+
+// lastToken extracts the token from the link of the latest confirmation email
+func (r *recordingNotifier) lastToken(t *testing.T) string {
+	t.Helper()
+	require.NotEmpty(t, r.sent)
+	body := r.sent[len(r.sent)-1].Body
+	start := strings.Index(body, "https://")
+	require.GreaterOrEqual(t, start, 0)
+	link, err := url.Parse(strings.Fields(body[start:])[0])
+	require.NoError(t, err)
+	return link.Query().Get("token")
+}
+
+func newTestEmailVerificationService(t *testing.T) (*EmailVerificationService, *fakeEmailVerificationStore, *recordingNotifier, *time.Time) {
+	t.Helper()
+	store := newFakeEmailVerificationStore()
+	notifier := &recordingNotifier{}
+	svc := NewEmailVerificationService(store, notifier, EmailVerificationConfig{
+		Secret:     []byte("test-secret"),
+		ConfirmURL: "https://app.example.com/api/v1/users/confirm-email",
+	}, zap.NewNop())
+
+	now := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	return svc, store, notifier, &now
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	valid := map[string]string{
+		"  Anna.Kovacs@Example.COM ": "anna.kovacs@example.com",
+		"user+tag@example.org":       "user+tag@example.org",
+		"péter@bücher.example":       "péter@xn--bcher-kva.example",
+	}
+	for raw, expected := range valid {
+		normalized, err := NormalizeEmail(raw)
+		require.NoError(t, err, raw)
+		assert.Equal(t, expected, normalized)
+	}
+
+	for _, raw := range []string{"", "not-an-email", "Anna <anna@example.com>", "a@b@example.com", strings.Repeat("a", 250) + "@example.com"} {
+		_, err := NormalizeEmail(raw)
+		assert.ErrorIs(t, err, ErrInvalidEmail, raw)
+	}
+}
+
+func TestEmailVerificationService_ConfirmationFlow(t *testing.T) {
+	svc, store, notifier, now := newTestEmailVerificationService(t)
+	ctx := context.Background()
+
+	user, err := svc.ChangeEmail(ctx, "user-1", " Anna@Example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "anna@example.com", user.Email)
+	assert.False(t, user.EmailVerified())
+	require.Len(t, notifier.sent, 1)
+	assert.Equal(t, "anna@example.com", notifier.to[0])
+	assert.Contains(t, notifier.sent[0].Body, "expires on 2026-05-05 09:00 UTC")
+
+	assert.ErrorIs(t, svc.RequireVerified(ctx, "user-1", "anna@example.com"), ErrEmailNotVerified)
+
+	token := notifier.lastToken(t)
+	user, err = svc.ConfirmEmail(ctx, token)
+	require.NoError(t, err)
+	assert.True(t, user.EmailVerified())
+	assert.NoError(t, svc.RequireVerified(ctx, "user-1", "ANNA@example.com"))
+
+	_, err = svc.ConfirmEmail(ctx, token)
+	assert.ErrorIs(t, err, ErrEmailVerificationInvalid, "tokens are single-use")
+
+	// Setting the verified address again keeps it verified without another email
+	user, err = svc.ChangeEmail(ctx, "user-1", "anna@example.com")
+	require.NoError(t, err)
+	assert.True(t, user.EmailVerified())
+	assert.Len(t, notifier.sent, 1)
+	assert.ErrorIs(t, svc.ResendConfirmation(ctx, "user-1"), ErrEmailAlreadyVerified)
+
+	// A new address is unverified until confirmed; older tokens do not confirm it
+	*now = now.Add(time.Hour)
+	user, err = svc.ChangeEmail(ctx, "user-1", "anna@example.org")
+	require.NoError(t, err)
+	assert.False(t, user.EmailVerified())
+	assert.ErrorIs(t, svc.RequireVerified(ctx, "user-1", "anna@example.com"), ErrEmailNotVerified)
+
+	*now = now.Add(defaultEmailResendInterval)
+	store.users["user-2"] = &model.User{ID: "user-2", Email: "taken@example.com"}
+	_, err = svc.ChangeEmail(ctx, "user-1", "taken@example.com")
+	assert.ErrorIs(t, err, repository.ErrEmailTaken)
+}
+
+func TestEmailVerificationService_RejectsBadTokens(t *testing.T) {
+	svc, _, notifier, now := newTestEmailVerificationService(t)
+	ctx := context.Background()
+
+	_, err := svc.ChangeEmail(ctx, "user-1", "anna@example.com")
+	require.NoError(t, err)
+	token := notifier.lastToken(t)
+
+	parts := strings.Split(token, ".")
+	forged := parts[0] + "." + "9999999999" + "." + parts[2]
+	for _, bad := range []string{"", "garbage", forged, token + "x"} {
+		_, err := svc.ConfirmEmail(ctx, bad)
+		assert.ErrorIs(t, err, ErrEmailVerificationInvalid, bad)
+	}
+
+	other := NewEmailVerificationService(newFakeEmailVerificationStore(), notifier, EmailVerificationConfig{Secret: []byte("other-secret")}, zap.NewNop())
+	other.now = svc.now
+	_, err = other.ConfirmEmail(ctx, token)
+	assert.ErrorIs(t, err, ErrEmailVerificationInvalid, "tokens are bound to the signing secret")
+
+	*now = now.Add(defaultEmailVerificationTTL)
+	_, err = svc.ConfirmEmail(ctx, token)
+	assert.ErrorIs(t, err, ErrEmailVerificationInvalid, "tokens expire")
+}
+
+func TestEmailVerificationService_RateLimitsConfirmationEmails(t *testing.T) {
+	svc, _, notifier, now := newTestEmailVerificationService(t)
+	ctx := context.Background()
+
+	_, err := svc.ChangeEmail(ctx, "user-1", "anna@example.com")
+	require.NoError(t, err)
+
+	err = svc.ResendConfirmation(ctx, "user-1")
+	var rateLimit *EmailVerificationRateLimitError
+	require.ErrorAs(t, err, &rateLimit)
+	assert.ErrorIs(t, err, ErrEmailVerificationRateLimited)
+	assert.Equal(t, defaultEmailResendInterval, rateLimit.RetryAfter)
+
+	_, err = svc.ChangeEmail(ctx, "user-1", "anna@example.org")
+	assert.ErrorIs(t, err, ErrEmailVerificationRateLimited, "changing the address is limited as well")
+	user, err := svc.GetProfile(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, "anna@example.com", user.Email, "a limited change leaves the address alone")
+
+	for i := 1; i < defaultEmailVerificationDaily; i++ {
+		*now = now.Add(defaultEmailResendInterval)
+		require.NoError(t, svc.ResendConfirmation(ctx, "user-1"))
+	}
+	assert.Len(t, notifier.sent, defaultEmailVerificationDaily)
+
+	*now = now.Add(time.Hour)
+	err = svc.ResendConfirmation(ctx, "user-1")
+	require.ErrorAs(t, err, &rateLimit)
+	assert.Equal(t, emailVerificationWindow-time.Hour, rateLimit.RetryAfter)
+
+	*now = now.Add(emailVerificationWindow)
+	assert.NoError(t, svc.ResendConfirmation(ctx, "user-1"))
+
+	assert.ErrorIs(t, svc.ResendConfirmation(ctx, "user-2"), repository.ErrUserNotFound)
+}
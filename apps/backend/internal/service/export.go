@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/fhir"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// Health data types that can be exported as CSV
+const (
+	ExportTypeBloodPressure = "blood_pressure"
+	ExportTypeMenstruation  = "menstruation"
+	ExportTypeFitness       = "fitness"
+	ExportTypeMedications   = "medications"
+)
+
+// ErrUnsupportedExportType is returned for a data type that cannot be exported
+var ErrUnsupportedExportType = errors.New("unsupported export type")
+
+// exportFlushEvery is the number of rows written between flushes of the CSV output
+const exportFlushEvery = 100
+
+// fhirExportStart and fhirExportEnd bound the FHIR bulk export, which covers all of a
+// user's data including medications scheduled to start in the future
+var (
+	fhirExportStart = time.Time{}
+	fhirExportEnd   = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+)
+
+// HealthDataStreamer defines the interface for streaming health data rows
+type HealthDataStreamer interface {
+	StreamBloodPressureByUserID(ctx context.Context, userID string, start, end time.Time, fn func(model.BloodPressureReading) error) error
+	StreamMenstruationByUserID(ctx context.Context, userID string, start, end time.Time, fn func(model.MenstruationCycle) error) error
+	StreamFitnessDataByUserID(ctx context.Context, userID string, start, end time.Time, fn func(model.FitnessDataPoint) error) error
+}
+
+// MedicationStreamer defines the interface for streaming medication rows
+type MedicationStreamer interface {
+	StreamByUserID(ctx context.Context, userID string, start, end time.Time, fn func(model.Medication) error) error
+}
+
+// ExportService exports health data in spreadsheet-friendly formats
+type ExportService struct {
+	healthData  HealthDataStreamer
+	medications MedicationStreamer
+	fhir        *fhir.FHIRExporter
+	logger      *zap.Logger
+}
+
+// NewExportService creates a new ExportService
+func NewExportService(healthData HealthDataStreamer, medications MedicationStreamer, logger *zap.Logger) *ExportService {
+	return &ExportService{
+		healthData:  healthData,
+		medications: medications,
+		fhir:        fhir.NewFHIRExporter(),
+		logger:      logger,
+	}
+}
+
+// ExportToCSV writes the user's data of dataType recorded from start up to, but excluding,
+// end to w as RFC 4180 CSV with a header row. Rows are streamed from the database and
+// flushed in batches, so nothing is written to w when the query fails.
+func (s *ExportService) ExportToCSV(ctx context.Context, userID, dataType string, start, end time.Time, w io.Writer) error {
+	s.logger.Info("exporting health data as CSV",
+		zap.String("user_id", userID),
+		zap.String("type", dataType),
+		zap.Time("start", start),
+		zap.Time("end", end),
+	)
+
+	out := &csvExport{writer: csv.NewWriter(w)}
+
+	var err error
+	switch dataType {
+	case ExportTypeBloodPressure:
+		out.header("Measured At", "Systolic (mmHg)", "Diastolic (mmHg)", "Pulse (bpm)")
+		err = s.healthData.StreamBloodPressureByUserID(ctx, userID, start, end, func(reading model.BloodPressureReading) error {
+			return out.row(
+				isoTimestamp(reading.MeasuredAt),
+				strconv.Itoa(reading.Systolic),
+				strconv.Itoa(reading.Diastolic),
+				strconv.Itoa(reading.Pulse),
+			)
+		})
+	case ExportTypeMenstruation:
+		out.header("Start Date", "End Date", "Flow Intensity", "Symptoms")
+		err = s.healthData.StreamMenstruationByUserID(ctx, userID, start, end, func(cycle model.MenstruationCycle) error {
+			return out.row(
+				isoDate(cycle.StartDate),
+				isoDatePtr(cycle.EndDate),
+				stringValue(cycle.FlowIntensity),
+				strings.Join(cycle.Symptoms, "; "),
+			)
+		})
+	case ExportTypeFitness:
+		out.header("Date", "Type", "Value", "Unit", "Source")
+		err = s.healthData.StreamFitnessDataByUserID(ctx, userID, start, end, func(data model.FitnessDataPoint) error {
+			return out.row(
+				isoDate(data.Date),
+				data.DataType,
+				strconv.FormatFloat(data.Value, 'f', -1, 64),
+				data.Unit,
+				data.Source,
+			)
+		})
+	case ExportTypeMedications:
+		out.header("Name", "Dosage", "Frequency", "Start Date", "End Date", "Active", "Notes")
+		err = s.medications.StreamByUserID(ctx, userID, start, end, func(med model.Medication) error {
+			return out.row(
+				med.Name,
+				med.Dosage,
+				med.Frequency,
+				isoDate(med.StartDate),
+				isoDatePtr(med.EndDate),
+				strconv.FormatBool(med.Active),
+				stringValue(med.Notes),
+			)
+		})
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedExportType, dataType)
+	}
+	if err != nil {
+		s.logger.Error("failed to export health data as CSV",
+			zap.Error(err),
+			zap.String("user_id", userID),
+			zap.String("type", dataType),
+			zap.Int("rows_written", out.rows),
+		)
+		return fmt.Errorf("failed to export %s: %w", dataType, err)
+	}
+
+	out.writer.Flush()
+	if err := out.writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	s.logger.Info("health data exported as CSV",
+		zap.String("user_id", userID),
+		zap.String("type", dataType),
+		zap.Int("rows", out.rows),
+	)
+
+	return nil
+}
+
+// ExportFHIR returns all of the user's blood pressure readings, fitness data and
+// medications as a FHIR R4 collection bundle with the user's Patient resource
+func (s *ExportService) ExportFHIR(ctx context.Context, userID string) (*fhir.FHIRBundle, error) {
+	s.logger.Info("exporting health data as FHIR bundle", zap.String("user_id", userID))
+
+	bundle := s.fhir.NewBundle(userID)
+
+	err := s.healthData.StreamBloodPressureByUserID(ctx, userID, fhirExportStart, fhirExportEnd, func(reading model.BloodPressureReading) error {
+		observation := s.fhir.BloodPressureToObservation(reading)
+		bundle.Add(observation.ID, &observation)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export blood pressure: %w", err)
+	}
+
+	err = s.healthData.StreamFitnessDataByUserID(ctx, userID, fhirExportStart, fhirExportEnd, func(data model.FitnessDataPoint) error {
+		observation := s.fhir.FitnessToObservation(data)
+		bundle.Add(observation.ID, &observation)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export fitness data: %w", err)
+	}
+
+	err = s.medications.StreamByUserID(ctx, userID, fhirExportStart, fhirExportEnd, func(med model.Medication) error {
+		statement := s.fhir.MedicationToStatement(med)
+		bundle.Add(statement.ID, &statement)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export medications: %w", err)
+	}
+
+	s.logger.Info("health data exported as FHIR bundle",
+		zap.String("user_id", userID),
+		zap.Int("entries", len(bundle.Entry)),
+	)
+
+	return bundle, nil
+}
+
+// csvExport writes CSV rows and flushes them in batches
+type csvExport struct {
+	writer *csv.Writer
+	rows   int
+}
+
+// header writes the header row; it is flushed with the first batch of rows
+func (e *csvExport) header(columns ...string) {
+	_ = e.writer.Write(columns)
+}
+
+// row writes one data row, flushing every exportFlushEvery rows
+func (e *csvExport) row(fields ...string) error {
+	if err := e.writer.Write(fields); err != nil {
+		return fmt.Errorf("failed to write CSV row: %w", err)
+	}
+	e.rows++
+	if e.rows%exportFlushEvery == 0 {
+		e.writer.Flush()
+		if err := e.writer.Error(); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	return nil
+}
+
+// isoTimestamp formats t as an ISO-8601 timestamp in UTC
+func isoTimestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// isoDate formats t as an ISO-8601 calendar date
+func isoDate(t time.Time) string {
+	return t.Format(time.DateOnly)
+}
+
+// isoDatePtr formats t as an ISO-8601 calendar date, or an empty string when t is nil
+func isoDatePtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return isoDate(*t)
+}
+
+// stringValue returns the value of s, or an empty string when s is nil
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// fakeExportSource streams fixed rows, or fails with err before the first row
+type fakeExportSource struct {
+	readings    []model.BloodPressureReading
+	cycles      []model.MenstruationCycle
+	fitness     []model.FitnessDataPoint
+	medications []model.Medication
+	err         error
+
+	end time.Time // end bound of the last blood pressure query
+}
+
+func streamRows[T any](rows []T, err error, fn func(T) error) error {
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeExportSource) StreamBloodPressureByUserID(ctx context.Context, userID string, start, end time.Time, fn func(model.BloodPressureReading) error) error {
+	f.end = end
+	return streamRows(f.readings, f.err, fn)
+}
+
+func (f *fakeExportSource) StreamMenstruationByUserID(ctx context.Context, userID string, start, end time.Time, fn func(model.MenstruationCycle) error) error {
+	return streamRows(f.cycles, f.err, fn)
+}
+
+func (f *fakeExportSource) StreamFitnessDataByUserID(ctx context.Context, userID string, start, end time.Time, fn func(model.FitnessDataPoint) error) error {
+	return streamRows(f.fitness, f.err, fn)
+}
+
+func (f *fakeExportSource) StreamByUserID(ctx context.Context, userID string, start, end time.Time, fn func(model.Medication) error) error {
+	return streamRows(f.medications, f.err, fn)
+}
+
+func exportCSV(t *testing.T, source *fakeExportSource, dataType string) [][]string {
+	t.Helper()
+	var buf bytes.Buffer
+	svc := NewExportService(source, source, zap.NewNop())
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, svc.ExportToCSV(context.Background(), "user-1", dataType, start, start.AddDate(0, 1, 0), &buf))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	return records
+}
+
+func TestExportService_ExportToCSV_BloodPressure(t *testing.T) {
+	budapest := time.FixedZone("CET", 3600)
+	source := &fakeExportSource{readings: []model.BloodPressureReading{
+		{Systolic: 128, Diastolic: 84, Pulse: 71, MeasuredAt: time.Date(2026, 3, 2, 8, 30, 0, 0, budapest)},
+	}}
+
+	records := exportCSV(t, source, ExportTypeBloodPressure)
+
+	assert.Equal(t, [][]string{
+		{"Measured At", "Systolic (mmHg)", "Diastolic (mmHg)", "Pulse (bpm)"},
+		{"2026-03-02T07:30:00Z", "128", "84", "71"},
+	}, records)
+	assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), source.end)
+}
+
+func TestExportService_ExportToCSV_QuotesFields(t *testing.T) {
+	notes := "Take with food, \"not\" on an empty stomach\nEvening dose optional"
+	end := time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)
+	source := &fakeExportSource{medications: []model.Medication{
+		{Name: "Metformin", Dosage: "500 mg", Frequency: "twice daily", StartDate: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), EndDate: &end, Notes: &notes, Active: true},
+	}}
+
+	var buf bytes.Buffer
+	svc := NewExportService(source, source, zap.NewNop())
+	require.NoError(t, svc.ExportToCSV(context.Background(), "user-1", ExportTypeMedications, time.Time{}, end, &buf))
+
+	assert.Equal(t, "Name,Dosage,Frequency,Start Date,End Date,Active,Notes\n"+
+		"Metformin,500 mg,twice daily,2026-01-05,2026-03-20,true,\"Take with food, \"\"not\"\" on an empty stomach\nEvening dose optional\"\n",
+		buf.String())
+}
+
+func TestExportService_ExportToCSV_MenstruationAndFitness(t *testing.T) {
+	flow := "heavy"
+	source := &fakeExportSource{
+		cycles: []model.MenstruationCycle{
+			{StartDate: time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC), FlowIntensity: &flow, Symptoms: []string{"cramps", "fatigue"}},
+		},
+		fitness: []model.FitnessDataPoint{
+			{Date: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), DataType: "distance", Value: 5234.5, Unit: "meters", Source: "health_connect"},
+		},
+	}
+
+	assert.Equal(t, [][]string{
+		{"Start Date", "End Date", "Flow Intensity", "Symptoms"},
+		{"2026-03-03", "", "heavy", "cramps; fatigue"},
+	}, exportCSV(t, source, ExportTypeMenstruation))
+
+	assert.Equal(t, [][]string{
+		{"Date", "Type", "Value", "Unit", "Source"},
+		{"2026-03-04", "distance", "5234.5", "meters", "health_connect"},
+	}, exportCSV(t, source, ExportTypeFitness))
+}
+
+func TestExportService_ExportToCSV_FlushesInBatches(t *testing.T) {
+	source := &fakeExportSource{}
+	for i := 0; i < 2*exportFlushEvery+1; i++ {
+		source.readings = append(source.readings, model.BloodPressureReading{Systolic: 120, Diastolic: 80, Pulse: 60 + i%10})
+	}
+
+	records := exportCSV(t, source, ExportTypeBloodPressure)
+	assert.Len(t, records, 2*exportFlushEvery+2)
+}
+
+func TestExportService_ExportToCSV_Errors(t *testing.T) {
+	svc := NewExportService(&fakeExportSource{err: errors.New("connection reset")}, &fakeExportSource{}, zap.NewNop())
+
+	var buf bytes.Buffer
+	err := svc.ExportToCSV(context.Background(), "user-1", ExportTypeFitness, time.Time{}, time.Now(), &buf)
+	require.Error(t, err)
+	assert.Zero(t, buf.Len(), "a failed query must not write the header")
+
+	err = svc.ExportToCSV(context.Background(), "user-1", "sleep", time.Time{}, time.Now(), &buf)
+	assert.ErrorIs(t, err, ErrUnsupportedExportType)
+	assert.Zero(t, buf.Len())
+}
+
+func TestExportFHIR(t *testing.T) {
+	source := &fakeExportSource{
+		readings:    []model.BloodPressureReading{{ID: "bp-1", UserID: "user-1", Systolic: 120, Diastolic: 80}},
+		fitness:     []model.FitnessDataPoint{{ID: "fit-1", UserID: "user-1", DataType: "steps", Value: 9000, Unit: "count"}},
+		medications: []model.Medication{{ID: "med-1", UserID: "user-1", Name: "Aspirin", Active: true}},
+	}
+
+	bundle, err := NewExportService(source, source, zap.NewNop()).ExportFHIR(context.Background(), "user-1")
+	require.NoError(t, err)
+
+	var fullURLs []string
+	for _, entry := range bundle.Entry {
+		fullURLs = append(fullURLs, entry.FullURL)
+	}
+	assert.Equal(t, []string{"urn:uuid:user-1", "urn:uuid:bp-1", "urn:uuid:fit-1", "urn:uuid:med-1"}, fullURLs)
+
+	// The bulk export is not limited to a date range
+	assert.True(t, source.end.After(time.Now().AddDate(100, 0, 0)))
+
+	source.err = errors.New("connection reset")
+	_, err = NewExportService(source, source, zap.NewNop()).ExportFHIR(context.Background(), "user-1")
+	assert.Error(t, err)
+}
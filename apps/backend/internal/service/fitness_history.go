@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// FitnessAggregateDaily aggregates fitness data per day and data type
+const FitnessAggregateDaily = "daily"
+
+// maxFitnessAggregateDays bounds the date range of aggregated fitness data, which is not
+// paginated
+const maxFitnessAggregateDays = 366
+
+// fitnessDataUnits are the data types accepted from Health Connect and the unit each
+// must be reported in
+var fitnessDataUnits = map[string]string{
+	"steps":                           "count",
+	"heart_rate":                      "bpm",
+	model.FitnessDataTypeSleep:        "minutes",
+	model.FitnessDataTypeSleepMinutes: "minutes",
+	"calories":                        "kcal",
+	"distance":                        "meters",
+	"active_minutes":                  "minutes",
+	model.FitnessDataTypeWeight:       "kg",
+}
+
+var (
+	// ErrInvalidFitnessDataType is returned for a fitness data type filter that is not a known type
+	ErrInvalidFitnessDataType = errors.New("data_type must be steps, heart_rate, sleep, sleep_minutes, calories, distance, active_minutes or weight")
+
+	// ErrInvalidFitnessRange is returned when a fitness date range is reversed or too long
+	ErrInvalidFitnessRange = errors.New("invalid fitness date range")
+
+	// ErrInvalidFitnessDataPoint is returned for a synced data point of an unknown data type
+	// or in a unit other than its data type's
+	ErrInvalidFitnessDataPoint = errors.New("invalid fitness data point")
+)
+
+// FitnessDailyAggregate is one data type's fitness data of a day: the average for heart
+// rate and weight and the sum for the other types
+type FitnessDailyAggregate struct {
+	Date       string  `json:"date"`
+	DataType   string  `json:"data_type"`
+	Value      float64 `json:"value"`
+	Unit       string  `json:"unit"`
+	PointCount int     `json:"point_count"`
+}
+
+// ListFitnessData returns a page of a user's fitness data from startDate through endDate,
+// oldest first, only of dataType unless it is empty, and the total count
+func (s *HealthDataService) ListFitnessData(ctx context.Context, userID string, startDate, endDate time.Time, dataType string, page repository.Page) ([]model.FitnessDataPoint, int, error) {
+	ctx, span := telemetry.StartSpan(ctx, "HealthDataService.ListFitnessData")
+	defer span.End()
+
+	startDate, endDate, err := validateFitnessQuery(startDate, endDate, dataType)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dataPoints, total, err := s.repo.GetFitnessDataPageByUserID(ctx, userID, startDate, endDate, dataType, page)
+	if err != nil {
+		s.logger.Error("failed to list fitness data",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		return nil, 0, fmt.Errorf("failed to list fitness data: %w", err)
+	}
+
+	return dataPoints, total, nil
+}
+
+// GetDailyFitness returns a user's fitness data from startDate through endDate aggregated
+// per day and data type, only of dataType unless it is empty, ordered by date and type
+func (s *HealthDataService) GetDailyFitness(ctx context.Context, userID string, startDate, endDate time.Time, dataType string) ([]FitnessDailyAggregate, error) {
+	ctx, span := telemetry.StartSpan(ctx, "HealthDataService.GetDailyFitness")
+	defer span.End()
+
+	startDate, endDate, err := validateFitnessQuery(startDate, endDate, dataType)
+	if err != nil {
+		return nil, err
+	}
+	if days := int(endDate.Sub(startDate).Hours()/24) + 1; days > maxFitnessAggregateDays {
+		return nil, fmt.Errorf("%w: at most %d days can be aggregated", ErrInvalidFitnessRange, maxFitnessAggregateDays)
+	}
+
+	dataPoints, err := s.repo.GetFitnessDataByUserID(ctx, userID, startDate, endDate, dataType)
+	if err != nil {
+		s.logger.Error("failed to get fitness data for daily aggregates",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		return nil, fmt.Errorf("failed to get fitness data: %w", err)
+	}
+
+	return aggregateFitnessDaily(dataPoints), nil
+}
+
+// validateFitnessQuery checks a fitness data type filter and returns the date range
+// truncated to days
+func validateFitnessQuery(startDate, endDate time.Time, dataType string) (time.Time, time.Time, error) {
+	if _, ok := fitnessDataUnits[dataType]; dataType != "" && !ok {
+		return time.Time{}, time.Time{}, ErrInvalidFitnessDataType
+	}
+
+	startDate = truncateToDay(startDate)
+	endDate = truncateToDay(endDate)
+	if endDate.Before(startDate) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: to is before from", ErrInvalidFitnessRange)
+	}
+	return startDate, endDate, nil
+}
+
+// aggregateFitnessDaily sums each day's points per data type, averaging heart rate and weight instead
+func aggregateFitnessDaily(dataPoints []model.FitnessDataPoint) []FitnessDailyAggregate {
+	type key struct{ date, dataType string }
+	byKey := make(map[key]*FitnessDailyAggregate)
+	for _, point := range dataPoints {
+		k := key{point.Date.Format(time.DateOnly), point.DataType}
+		aggregate, ok := byKey[k]
+		if !ok {
+			aggregate = &FitnessDailyAggregate{Date: k.date, DataType: k.dataType, Unit: point.Unit}
+			byKey[k] = aggregate
+		}
+		aggregate.Value += point.Value
+		aggregate.PointCount++
+	}
+
+	aggregates := make([]FitnessDailyAggregate, 0, len(byKey))
+	for _, aggregate := range byKey {
+		if aggregate.DataType == "heart_rate" || aggregate.DataType == model.FitnessDataTypeWeight {
+			aggregate.Value /= float64(aggregate.PointCount)
+		}
+		aggregates = append(aggregates, *aggregate)
+	}
+	sort.Slice(aggregates, func(i, j int) bool {
+		if aggregates[i].Date != aggregates[j].Date {
+			return aggregates[i].Date < aggregates[j].Date
+		}
+		return aggregates[i].DataType < aggregates[j].DataType
+	})
+	return aggregates
+}
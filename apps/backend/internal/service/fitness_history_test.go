@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+func TestAggregateFitnessDaily(t *testing.T) {
+	point := func(date, dataType string, value float64, unit string) model.FitnessDataPoint {
+		return model.FitnessDataPoint{Date: chartDate(t, date), DataType: dataType, Value: value, Unit: unit}
+	}
+	dataPoints := []model.FitnessDataPoint{
+		point("2026-03-02", "steps", 4000, "count"),
+		point("2026-03-01", "heart_rate", 60, "bpm"),
+		point("2026-03-01", "steps", 3000, "count"),
+		point("2026-03-01", "heart_rate", 90, "bpm"),
+		point("2026-03-01", "steps", 2500, "count"),
+		point("2026-03-01", "distance", 1200.5, "meters"),
+		point("2026-03-02", "weight", 72, "kg"),
+		point("2026-03-02", "weight", 71, "kg"),
+	}
+
+	assert.Equal(t, []FitnessDailyAggregate{
+		{Date: "2026-03-01", DataType: "distance", Value: 1200.5, Unit: "meters", PointCount: 1},
+		{Date: "2026-03-01", DataType: "heart_rate", Value: 75, Unit: "bpm", PointCount: 2},
+		{Date: "2026-03-01", DataType: "steps", Value: 5500, Unit: "count", PointCount: 2},
+		{Date: "2026-03-02", DataType: "steps", Value: 4000, Unit: "count", PointCount: 1},
+		{Date: "2026-03-02", DataType: "weight", Value: 71.5, Unit: "kg", PointCount: 2},
+	}, aggregateFitnessDaily(dataPoints))
+	assert.Empty(t, aggregateFitnessDaily(nil))
+}
+
+func TestFitnessQuery_Validation(t *testing.T) {
+	svc := NewHealthDataService(nil, zap.NewNop())
+	ctx := context.Background()
+
+	_, _, err := svc.ListFitnessData(ctx, "user-1", chartDate(t, "2026-03-01"), chartDate(t, "2026-03-07"), "swimming", repository.Page{})
+	assert.ErrorIs(t, err, ErrInvalidFitnessDataType)
+
+	_, _, err = svc.ListFitnessData(ctx, "user-1", chartDate(t, "2026-03-07"), chartDate(t, "2026-03-01"), "", repository.Page{})
+	assert.ErrorIs(t, err, ErrInvalidFitnessRange)
+
+	_, err = svc.GetDailyFitness(ctx, "user-1", chartDate(t, "2024-01-01"), chartDate(t, "2026-03-01"), "steps")
+	assert.ErrorIs(t, err, ErrInvalidFitnessRange)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"go.uber.org/zap"
+)
+
+// followUpQuestionIDPrefix marks question IDs that refer to adaptive follow-up messages
+const followUpQuestionIDPrefix = "followup-"
+
+// FollowUpDecision represents the AI decision on whether a clarifying question is needed
+type FollowUpDecision struct {
+	NeedsFollowUp bool   `json:"needs_follow_up"`
+	Question      string `json:"question"`
+	Reason        string `json:"reason"`
+}
+
+// FollowUpGenerator decides whether an answer warrants a clarifying follow-up question
+type FollowUpGenerator struct {
+	aiClient *azure.OpenAIClient
+	logger   *zap.Logger
+}
+
+// NewFollowUpGenerator creates a new FollowUpGenerator
+func NewFollowUpGenerator(aiClient *azure.OpenAIClient, logger *zap.Logger) *FollowUpGenerator {
+	return &FollowUpGenerator{
+		aiClient: aiClient,
+		logger:   logger,
+	}
+}
+
+// Suggest asks Azure OpenAI whether the latest answer needs a single clarifying follow-up
+func (g *FollowUpGenerator) Suggest(ctx context.Context, conversationHistory []ConversationMessage) (*FollowUpDecision, error) {
+	var conversationText strings.Builder
+	for _, msg := range conversationHistory {
+		conversationText.WriteString(fmt.Sprintf("%s: %s\n", msg.Role, msg.Content))
+	}
+
+	messages := []openai.ChatCompletionMessageParamUnion{
+		openai.SystemMessage(g.buildFollowUpPrompt(conversationText.String())),
+		openai.UserMessage("Decide whether a follow-up question is needed and return it as JSON."),
+	}
+
+	response, err := g.aiClient.Complete(ctx, messages)
+	if err != nil {
+		return nil, fmt.Errorf("follow-up decision failed: %w", err)
+	}
+
+	decision, err := parseFollowUpDecision(response)
+	if err != nil {
+		g.logger.Warn("failed to parse follow-up decision",
+			zap.Error(err),
+			zap.String("response", response),
+		)
+		return nil, err
+	}
+
+	return decision, nil
+}
+
+// buildFollowUpPrompt creates the AI prompt for the follow-up decision
+func (g *FollowUpGenerator) buildFollowUpPrompt(conversationHistory string) string {
+	return fmt.Sprintf(`You are Eva, a caring Hungarian-speaking health assistant conducting a daily check-in.
+
+Conversation so far:
+%s
+
+Look at the user's most recent answer. If it signals a health problem (pain, breathing difficulty, chest tightness, dizziness, bleeding, fever or similar) and important details are missing, ask ONE short clarifying question in Hungarian about the location, duration or severity of the problem.
+
+Return valid JSON:
+{
+  "needs_follow_up": true or false,
+  "question": "the follow-up question in Hungarian, or empty string",
+  "reason": "short English explanation"
+}
+
+Rules:
+- Ask at most one question and never repeat a question already asked
+- Do not ask a follow-up if the answer is unremarkable or already detailed
+- Return ONLY valid JSON, no additional text`, conversationHistory)
+}
+
+// parseFollowUpDecision parses the AI response into a FollowUpDecision
+func parseFollowUpDecision(response string) (*FollowUpDecision, error) {
+	response = strings.TrimSpace(response)
+	response = strings.TrimPrefix(response, "```json")
+	response = strings.TrimPrefix(response, "```")
+	response = strings.TrimSuffix(response, "```")
+	response = strings.TrimSpace(response)
+
+	var decision FollowUpDecision
+	if err := json.Unmarshal([]byte(response), &decision); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	decision.Question = strings.TrimSpace(decision.Question)
+	if decision.Question == "" {
+		decision.NeedsFollowUp = false
+	}
+
+	return &decision, nil
+}
+
+// followUpQuestionID builds the question ID used to reference a follow-up message
+func followUpQuestionID(messageID string) string {
+	return followUpQuestionIDPrefix + messageID
+}
+
+// isFollowUpQuestionID reports whether a question ID refers to a follow-up message
+func isFollowUpQuestionID(questionID string) bool {
+	return strings.HasPrefix(questionID, followUpQuestionIDPrefix)
+}
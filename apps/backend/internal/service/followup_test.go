@@ -0,0 +1,82 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+)
+
+func TestParseFollowUpDecision(t *testing.T) {
+	tests := []struct {
+		name         string
+		response     string
+		wantFollowUp bool
+		wantQuestion string
+		wantErr      bool
+	}{
+		{
+			name:         "follow-up requested",
+			response:     `{"needs_follow_up": true, "question": "Mióta érzed a mellkasi szorítást?", "reason": "chest tightness"}`,
+			wantFollowUp: true,
+			wantQuestion: "Mióta érzed a mellkasi szorítást?",
+		},
+		{
+			name:         "markdown wrapped",
+			response:     "```json\n{\"needs_follow_up\": true, \"question\": \"Hol fáj?\"}\n```",
+			wantFollowUp: true,
+			wantQuestion: "Hol fáj?",
+		},
+		{
+			name:         "no follow-up",
+			response:     `{"needs_follow_up": false, "question": ""}`,
+			wantFollowUp: false,
+		},
+		{
+			name:         "follow-up without question text is ignored",
+			response:     `{"needs_follow_up": true, "question": "  "}`,
+			wantFollowUp: false,
+		},
+		{
+			name:     "invalid JSON",
+			response: "not json",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := parseFollowUpDecision(tt.response)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFollowUp, decision.NeedsFollowUp)
+			if tt.wantFollowUp {
+				assert.Equal(t, tt.wantQuestion, decision.Question)
+			}
+		})
+	}
+}
+
+func TestCountQuestions(t *testing.T) {
+	messages := []model.Message{
+		{Role: model.MessageRoleAssistant, Content: "Szia! Hogy érzed magad ma?"},
+		{Role: model.MessageRoleUser, Content: "A mellkasom szorít és nehezen kapok levegőt"},
+		{Role: model.MessageRoleAssistant, Content: "Mióta érzed ezt?", IsFollowUp: true},
+		{Role: model.MessageRoleUser, Content: "Reggel óta"},
+		{Role: model.MessageRoleAssistant, Content: "Sportoltál ma, vagy mentél sétálni?"},
+	}
+
+	scripted, followUps := countQuestions(messages)
+	assert.Equal(t, 2, scripted)
+	assert.Equal(t, 1, followUps)
+}
+
+func TestFollowUpQuestionID(t *testing.T) {
+	id := followUpQuestionID("abc")
+	assert.True(t, isFollowUpQuestionID(id))
+	assert.False(t, isFollowUpQuestionID("q1_general_feeling"))
+}
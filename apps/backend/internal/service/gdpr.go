@@ -1,6 +1,7 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 	"go.uber.org/zap"
 )
@@ -15,8 +17,13 @@ import (
 // GDPRService handles GDPR compliance operations
 type GDPRService struct {
 	db          *pgxpool.Pool
+	reads       *repository.ReadPools
 	auditLogger *audit.Logger
-	logger      *zap.Logger
+	consents    *ConsentService
+
+	reportStorage *StorageRouter
+	audioStorage  *StorageRouter
+	logger        *zap.Logger
 }
 
 // NewGDPRService creates a new GDPR service
@@ -28,6 +35,25 @@ func NewGDPRService(db *pgxpool.Pool, auditLogger *audit.Logger, logger *zap.Log
 	}
 }
 
+// SetReadPools lets data exports read from the read replica
+func (s *GDPRService) SetReadPools(reads *repository.ReadPools) {
+	s.reads = reads
+}
+
+// SetConsentService keeps the data_processing consent in user_consents in step with
+// recorded GDPR processing answers
+func (s *GDPRService) SetConsentService(consents *ConsentService) {
+	s.consents = consents
+}
+
+// readDB returns the pool the export queries of ctx run on
+func (s *GDPRService) readDB(ctx context.Context) *pgxpool.Pool {
+	if s.reads == nil {
+		return s.db
+	}
+	return s.reads.ReadPool(ctx)
+}
+
 // UserDataExport represents all user data for export
 type UserDataExport struct {
 	User                  *model.User                  `json:"user"`
@@ -40,7 +66,9 @@ type UserDataExport struct {
 	ExportedAt            time.Time                    `json:"exported_at"`
 }
 
-// DeleteUserData deletes all user data (GDPR right to be forgotten)
+// DeleteUserData deletes all user data (GDPR right to be forgotten). The user must have
+// consented to processing and recorded a granted right_to_erasure consent, otherwise
+// ErrErasureConsentRequired is returned and nothing is deleted.
 // Validates: Requirements 10.3
 func (s *GDPRService) DeleteUserData(ctx context.Context, userID, ipAddress, userAgent string) error {
 	s.logger.Info("Starting user data deletion (GDPR)",
@@ -54,18 +82,39 @@ func (s *GDPRService) DeleteUserData(ctx context.Context, userID, ipAddress, use
 	}
 	defer tx.Rollback(ctx)
 
+	if err := checkErasureConsent(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	// Delete report and audio files from the storage backends they were written to
+	if err := s.deleteUserBlobs(ctx, tx, userID); err != nil {
+		return err
+	}
+
 	// Delete health check-ins
 	_, err = tx.Exec(ctx, "DELETE FROM health_check_ins WHERE user_id = $1", userID)
 	if err != nil {
 		return fmt.Errorf("failed to delete health check-ins: %w", err)
 	}
 
+	// Delete check-ins moved to the archive by the retention job
+	_, err = tx.Exec(ctx, "DELETE FROM health_check_ins_archive WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete archived health check-ins: %w", err)
+	}
+
 	// Delete medications
 	_, err = tx.Exec(ctx, "DELETE FROM medications WHERE user_id = $1", userID)
 	if err != nil {
 		return fmt.Errorf("failed to delete medications: %w", err)
 	}
 
+	// Delete cycle suggestions, then the menstruation cycles they may refer to
+	_, err = tx.Exec(ctx, "DELETE FROM cycle_suggestions WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete cycle suggestions: %w", err)
+	}
+
 	// Delete menstruation cycles
 	_, err = tx.Exec(ctx, "DELETE FROM menstruation_cycles WHERE user_id = $1", userID)
 	if err != nil {
@@ -84,18 +133,92 @@ func (s *GDPRService) DeleteUserData(ctx context.Context, userID, ipAddress, use
 		return fmt.Errorf("failed to delete fitness data: %w", err)
 	}
 
+	// Delete report jobs
+	_, err = tx.Exec(ctx, "DELETE FROM reports_jobs WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete report jobs: %w", err)
+	}
+
 	// Delete reports
 	_, err = tx.Exec(ctx, "DELETE FROM reports WHERE user_id = $1", userID)
 	if err != nil {
 		return fmt.Errorf("failed to delete reports: %w", err)
 	}
 
+	// Delete report schedules
+	_, err = tx.Exec(ctx, "DELETE FROM report_schedules WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete report schedules: %w", err)
+	}
+
 	// Delete check-in sessions
 	_, err = tx.Exec(ctx, "DELETE FROM check_in_sessions WHERE user_id = $1", userID)
 	if err != nil {
 		return fmt.Errorf("failed to delete check-in sessions: %w", err)
 	}
 
+	// Delete care team sharing consents and the record of check-ins delivered to care teams
+	_, err = tx.Exec(ctx, "DELETE FROM care_team_sharing_consents WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete sharing consents: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM integration_deliveries WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete integration deliveries: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM user_consents WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete user consents: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM gdpr_consents WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete GDPR consents: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM user_question_sets WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete question set assignment: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM personal_access_tokens WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete personal access tokens: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM webhooks WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhooks: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM user_settings WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete user settings: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM anomalies WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete anomalies: %w", err)
+	}
+
+	// Remove the user from clinicians' panels, and their own panel and digest as a clinician
+	_, err = tx.Exec(ctx, "DELETE FROM clinician_patient_assignments WHERE patient_id = $1 OR clinician_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete panel assignments: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM panel_digest_subscriptions WHERE clinician_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete panel digest subscriptions: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM email_verification_tokens WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete email verification tokens: %w", err)
+	}
+
 	// Mark user as deleted (soft delete to maintain referential integrity in audit logs)
 	_, err = tx.Exec(ctx, "UPDATE users SET deleted_at = $1 WHERE id = $2", time.Now(), userID)
 	if err != nil {
@@ -126,13 +249,68 @@ func (s *GDPRService) ExportUserData(ctx context.Context, userID string) ([]byte
 		zap.String("user_id", userID),
 	)
 
-	export := UserDataExport{
+	export, err := s.collectUserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to JSON
+	jsonData, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal export data: %w", err)
+	}
+
+	s.logExportCompleted(userID, "json", export)
+
+	return jsonData, nil
+}
+
+// ExportUserDataCSV exports all user data as a ZIP archive holding one CSV file per
+// table. It reads the data with the same queries as ExportUserData.
+func (s *GDPRService) ExportUserDataCSV(ctx context.Context, userID string) ([]byte, error) {
+	s.logger.Info("Starting user data CSV export (GDPR)",
+		zap.String("user_id", userID),
+	)
+
+	export, err := s.collectUserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := writeUserDataCSVZip(&buf, export); err != nil {
+		return nil, err
+	}
+
+	s.logExportCompleted(userID, "csv", export)
+
+	return buf.Bytes(), nil
+}
+
+// logExportCompleted logs the number of exported rows per table
+func (s *GDPRService) logExportCompleted(userID, format string, export *UserDataExport) {
+	s.logger.Info("User data export completed (GDPR)",
+		zap.String("user_id", userID),
+		zap.String("format", format),
+		zap.Int("health_check_ins", len(export.HealthCheckIns)),
+		zap.Int("medications", len(export.Medications)),
+		zap.Int("menstruation_cycles", len(export.MenstruationCycles)),
+		zap.Int("blood_pressure_readings", len(export.BloodPressureReadings)),
+		zap.Int("fitness_data", len(export.FitnessData)),
+		zap.Int("reports", len(export.Reports)),
+	)
+}
+
+// collectUserData reads all data stored about a user
+func (s *GDPRService) collectUserData(ctx context.Context, userID string) (*UserDataExport, error) {
+	export := &UserDataExport{
 		ExportedAt: time.Now(),
 	}
+	db := s.readDB(repository.WithReadReplica(ctx))
 
 	// Get user
 	var user model.User
-	err := s.db.QueryRow(ctx, `
+	err := db.QueryRow(ctx, `
 		SELECT id, name, email, created_at, updated_at, deleted_at
 		FROM users WHERE id = $1
 	`, userID).Scan(&user.ID, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt)
@@ -142,11 +320,12 @@ func (s *GDPRService) ExportUserData(ctx context.Context, userID string) ([]byte
 	export.User = &user
 
 	// Get health check-ins
-	checkInRows, err := s.db.Query(ctx, `
+	checkInRows, err := db.Query(ctx, `
 		SELECT id, user_id, session_id, check_in_date, symptoms, mood, pain_level,
 		       energy_level, sleep_quality, medication_taken, physical_activity,
 		       breakfast, lunch, dinner, general_feeling, additional_notes,
-		       raw_transcript, created_at, updated_at
+		       raw_transcript, low_confidence, extraction_issues,
+		       COALESCE(extraction_prompt_version, ''), created_at, updated_at
 		FROM health_check_ins WHERE user_id = $1
 		ORDER BY check_in_date DESC
 	`, userID)
@@ -162,7 +341,8 @@ func (s *GDPRService) ExportUserData(ctx context.Context, userID string) ([]byte
 			&checkIn.Symptoms, &checkIn.Mood, &checkIn.PainLevel, &checkIn.EnergyLevel,
 			&checkIn.SleepQuality, &checkIn.MedicationTaken, &checkIn.PhysicalActivity,
 			&checkIn.Breakfast, &checkIn.Lunch, &checkIn.Dinner, &checkIn.GeneralFeeling,
-			&checkIn.AdditionalNotes, &checkIn.RawTranscript, &checkIn.CreatedAt, &checkIn.UpdatedAt,
+			&checkIn.AdditionalNotes, &checkIn.RawTranscript, &checkIn.LowConfidence, &checkIn.ExtractionIssues,
+			&checkIn.ExtractionPromptVersion, &checkIn.CreatedAt, &checkIn.UpdatedAt,
 		)
 		if err != nil {
 			s.logger.Error("Failed to scan health check-in", zap.Error(err))
@@ -172,7 +352,7 @@ func (s *GDPRService) ExportUserData(ctx context.Context, userID string) ([]byte
 	}
 
 	// Get medications
-	medRows, err := s.db.Query(ctx, `
+	medRows, err := db.Query(ctx, `
 		SELECT id, user_id, name, dosage, frequency, start_date, end_date,
 		       notes, active, created_at, updated_at
 		FROM medications WHERE user_id = $1
@@ -198,7 +378,7 @@ func (s *GDPRService) ExportUserData(ctx context.Context, userID string) ([]byte
 	}
 
 	// Get menstruation cycles
-	cycleRows, err := s.db.Query(ctx, `
+	cycleRows, err := db.Query(ctx, `
 		SELECT id, user_id, start_date, end_date, flow_intensity, symptoms,
 		       created_at, updated_at
 		FROM menstruation_cycles WHERE user_id = $1
@@ -223,8 +403,8 @@ func (s *GDPRService) ExportUserData(ctx context.Context, userID string) ([]byte
 	}
 
 	// Get blood pressure readings
-	bpRows, err := s.db.Query(ctx, `
-		SELECT id, user_id, systolic, diastolic, pulse, measured_at, created_at
+	bpRows, err := db.Query(ctx, `
+		SELECT id, user_id, systolic, diastolic, pulse, measured_at, notes, created_at
 		FROM blood_pressure_readings WHERE user_id = $1
 		ORDER BY measured_at DESC
 	`, userID)
@@ -237,7 +417,7 @@ func (s *GDPRService) ExportUserData(ctx context.Context, userID string) ([]byte
 		var bp model.BloodPressureReading
 		err := bpRows.Scan(
 			&bp.ID, &bp.UserID, &bp.Systolic, &bp.Diastolic,
-			&bp.Pulse, &bp.MeasuredAt, &bp.CreatedAt,
+			&bp.Pulse, &bp.MeasuredAt, &bp.Notes, &bp.CreatedAt,
 		)
 		if err != nil {
 			s.logger.Error("Failed to scan blood pressure reading", zap.Error(err))
@@ -247,7 +427,7 @@ func (s *GDPRService) ExportUserData(ctx context.Context, userID string) ([]byte
 	}
 
 	// Get fitness data
-	fitnessRows, err := s.db.Query(ctx, `
+	fitnessRows, err := db.Query(ctx, `
 		SELECT id, user_id, date, data_type, value, unit, source, source_data_id, created_at
 		FROM fitness_data WHERE user_id = $1
 		ORDER BY date DESC
@@ -272,7 +452,7 @@ func (s *GDPRService) ExportUserData(ctx context.Context, userID string) ([]byte
 	}
 
 	// Get reports
-	reportRows, err := s.db.Query(ctx, `
+	reportRows, err := db.Query(ctx, `
 		SELECT id, user_id, date_range_start, date_range_end, file_path,
 		       generated_at, created_at
 		FROM reports WHERE user_id = $1
@@ -296,21 +476,5 @@ func (s *GDPRService) ExportUserData(ctx context.Context, userID string) ([]byte
 		export.Reports = append(export.Reports, report)
 	}
 
-	// Convert to JSON
-	jsonData, err := json.MarshalIndent(export, "", "  ")
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal export data: %w", err)
-	}
-
-	s.logger.Info("User data export completed (GDPR)",
-		zap.String("user_id", userID),
-		zap.Int("health_check_ins", len(export.HealthCheckIns)),
-		zap.Int("medications", len(export.Medications)),
-		zap.Int("menstruation_cycles", len(export.MenstruationCycles)),
-		zap.Int("blood_pressure_readings", len(export.BloodPressureReadings)),
-		zap.Int("fitness_data", len(export.FitnessData)),
-		zap.Int("reports", len(export.Reports)),
-	)
-
-	return jsonData, nil
+	return export, nil
 }
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"go.uber.org/zap"
+)
+
+// ErrUserNotFound is returned when a user does not exist
+var ErrUserNotFound = errors.New("user not found")
+
+// anonymizeStatements scrub everything that can identify a user apart from the user row
+// itself, keeping structured health metrics for aggregate research. Conversation
+// messages and audio transcriptions hold the user's own words and are removed; reports,
+// invitations, digest subscriptions and email confirmations are removed because they
+// carry the name or email address.
+var anonymizeStatements = []struct {
+	description string
+	query       string
+}{
+	{"check-in free text", `
+		UPDATE health_check_ins
+		SET raw_transcript = NULL, general_feeling = NULL, additional_notes = NULL,
+			breakfast = NULL, lunch = NULL, dinner = NULL, updated_at = NOW()
+		WHERE user_id = $1`},
+	{"archived check-in free text", `
+		UPDATE health_check_ins_archive
+		SET raw_transcript = NULL, general_feeling = NULL, additional_notes = NULL,
+			breakfast = NULL, lunch = NULL, dinner = NULL, updated_at = NOW()
+		WHERE user_id = $1`},
+	{"medication notes", "UPDATE medications SET notes = NULL, updated_at = NOW() WHERE user_id = $1"},
+	{"medication log notes", "UPDATE medication_logs SET notes = NULL WHERE user_id = $1"},
+	{"menstruation cycle notes", "UPDATE menstruation_cycles SET notes = NULL, updated_at = NOW() WHERE user_id = $1"},
+	{"blood pressure notes", "UPDATE blood_pressure_readings SET notes = NULL WHERE user_id = $1"},
+	{"audio recordings", "DELETE FROM audio_recordings WHERE session_id IN (SELECT id FROM check_in_sessions WHERE user_id = $1)"},
+	{"conversation messages", "DELETE FROM conversation_messages WHERE session_id IN (SELECT id FROM check_in_sessions WHERE user_id = $1)"},
+	{"reports", "DELETE FROM reports WHERE user_id = $1"},
+	{"organization invitations", "DELETE FROM organization_invitations WHERE accepted_by = $1"},
+	{"panel digest subscriptions", "DELETE FROM panel_digest_subscriptions WHERE clinician_id = $1"},
+	{"email confirmations", "DELETE FROM email_verification_tokens WHERE user_id = $1"},
+}
+
+// AnonymizeUserData replaces a user's name and email with random tokens and scrubs
+// free-text fields, keeping numeric and categorical health data so aggregates survive.
+// It is an alternative to DeleteUserData where anonymization is preferred.
+func (s *GDPRService) AnonymizeUserData(ctx context.Context, userID, ipAddress, userAgent string) error {
+	s.logger.Info("Starting user data anonymization (GDPR)",
+		zap.String("user_id", userID),
+	)
+
+	token, err := newAnonymizationToken()
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx,
+		"UPDATE users SET name = $2, email = $3, email_verified_at = NULL, updated_at = NOW() WHERE id = $1",
+		userID, token, token+"@anonymized.invalid",
+	)
+	if err != nil {
+		return fmt.Errorf("failed to anonymize user: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+
+	for _, stmt := range anonymizeStatements {
+		if _, err := tx.Exec(ctx, stmt.query, userID); err != nil {
+			return fmt.Errorf("failed to anonymize %s: %w", stmt.description, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	err = s.auditLogger.Log(ctx, audit.AuditLog{
+		UserID:        userID,
+		OperationType: audit.OperationAnonymize,
+		ResourceType:  audit.ResourceUser,
+		ResourceID:    userID,
+		IPAddress:     ipAddress,
+		UserAgent:     userAgent,
+	})
+	if err != nil {
+		s.logger.Error("Failed to log audit entry for user anonymization", zap.Error(err))
+	}
+
+	s.logger.Info("User data anonymization completed (GDPR)",
+		zap.String("user_id", userID),
+	)
+
+	return nil
+}
+
+// newAnonymizationToken returns a random token replacing a user's identifiers
+func newAnonymizationToken() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate anonymization token: %w", err)
+	}
+	return "anonymized-" + hex.EncodeToString(b), nil
+}
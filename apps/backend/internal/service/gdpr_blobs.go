@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// SetArtifactStorage enables deleting a user's report and audio files from the storage
+// backends they were written to when their data is deleted
+func (s *GDPRService) SetArtifactStorage(reports, audio *StorageRouter) {
+	s.reportStorage = reports
+	s.audioStorage = audio
+}
+
+// deleteUserBlobs deletes the report and audio files of a user. It runs before their
+// records are deleted: if the erasure fails afterwards, retrying finds the records again
+// and a missing blob is not an error.
+func (s *GDPRService) deleteUserBlobs(ctx context.Context, tx pgx.Tx, userID string) error {
+	if s.reportStorage != nil {
+		reports, err := queryStoredBlobs(ctx, tx, `
+			SELECT storage_backend, file_path FROM reports
+			WHERE user_id = $1 AND file_path <> ''
+		`, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get report files: %w", err)
+		}
+		if err := s.deleteBlobs(ctx, s.reportStorage, reports, azure.BlobStorage.DeletePDF); err != nil {
+			return fmt.Errorf("failed to delete report files: %w", err)
+		}
+	}
+
+	if s.audioStorage != nil {
+		audio, err := queryStoredBlobs(ctx, tx, `
+			SELECT ar.storage_backend, ar.file_path
+			FROM audio_recordings ar
+			JOIN check_in_sessions cs ON cs.id = ar.session_id
+			WHERE cs.user_id = $1
+			UNION
+			SELECT cm.storage_backend, cm.audio_file_path
+			FROM conversation_messages cm
+			JOIN check_in_sessions cs ON cs.id = cm.session_id
+			WHERE cs.user_id = $1 AND COALESCE(cm.audio_file_path, '') <> ''
+		`, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get audio files: %w", err)
+		}
+		if err := s.deleteBlobs(ctx, s.audioStorage, audio, azure.BlobStorage.DeleteAudio); err != nil {
+			return fmt.Errorf("failed to delete audio files: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// deleteBlobs deletes blobs from the backends they were written to with deleteBlob,
+// stopping at the first failure
+func (s *GDPRService) deleteBlobs(ctx context.Context, router *StorageRouter, blobs []model.StoredBlob, deleteBlob func(azure.BlobStorage, context.Context, string) error) error {
+	for _, blob := range blobs {
+		storage, err := router.Backend(blob.Backend)
+		if err == nil {
+			err = deleteBlob(storage, ctx, blob.Path)
+		}
+		if err != nil {
+			s.logger.Error("failed to delete user file",
+				zap.Error(err),
+				zap.String("backend", blob.Backend),
+				zap.String("blob_name", blob.Path),
+			)
+			return err
+		}
+	}
+	return nil
+}
+
+// queryStoredBlobs runs a query returning storage backend and blob path pairs
+func queryStoredBlobs(ctx context.Context, tx pgx.Tx, query, userID string) ([]model.StoredBlob, error) {
+	rows, err := tx.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var blobs []model.StoredBlob
+	for rows.Next() {
+		var blob model.StoredBlob
+		if err := rows.Scan(&blob.Backend, &blob.Path); err != nil {
+			return nil, err
+		}
+		blobs = append(blobs, blob)
+	}
+	return blobs, rows.Err()
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// maxGDPRConsentVersionLength is the length of the gdpr_consents.version column
+const maxGDPRConsentVersionLength = 50
+
+var (
+	// ErrInvalidGDPRConsent is returned for a consent record with an unknown type or
+	// without a version
+	ErrInvalidGDPRConsent = errors.New("invalid GDPR consent")
+
+	// ErrErasureConsentRequired is returned when data deletion is requested by a user who
+	// never consented to processing or has no granted right_to_erasure consent record
+	ErrErasureConsentRequired = errors.New("erasure consent required")
+)
+
+// gdprConsentTypes lists every GDPR consent type
+var gdprConsentTypes = []model.GDPRConsentType{
+	model.GDPRConsentProcessing,
+	model.GDPRConsentMarketing,
+	model.GDPRConsentThirdPartySharing,
+	model.GDPRConsentRightToErasure,
+}
+
+// GDPR consent records in gdpr_consents are the versioned evidence of what a user agreed
+// to and when. The current state of a consent is kept in user_consents by ConsentService,
+// which is authoritative: a processing answer recorded here also grants or revokes the
+// data_processing consent there.
+
+// RecordConsent records a user's answer to a version of a GDPR consent text. Answering
+// the same version again replaces the earlier answer; answers to other versions stay in
+// the history.
+func (s *GDPRService) RecordConsent(ctx context.Context, consent *model.GDPRConsent) error {
+	if !validGDPRConsentType(consent.ConsentType) {
+		return fmt.Errorf("%w: unknown consent type %q", ErrInvalidGDPRConsent, consent.ConsentType)
+	}
+	consent.Version = strings.TrimSpace(consent.Version)
+	if consent.Version == "" || len(consent.Version) > maxGDPRConsentVersionLength {
+		return fmt.Errorf("%w: version must be 1 to %d characters", ErrInvalidGDPRConsent, maxGDPRConsentVersionLength)
+	}
+
+	query := `
+		INSERT INTO gdpr_consents (user_id, consent_type, granted, version, timestamp, ip_address)
+		VALUES ($1, $2, $3, $4, NOW(), $5)
+		ON CONFLICT (user_id, consent_type, version) DO UPDATE
+		SET granted = EXCLUDED.granted, timestamp = EXCLUDED.timestamp, ip_address = EXCLUDED.ip_address
+		RETURNING id, timestamp
+	`
+
+	err := s.db.QueryRow(ctx, query,
+		consent.UserID,
+		consent.ConsentType,
+		consent.Granted,
+		consent.Version,
+		consent.IPAddress,
+	).Scan(&consent.ID, &consent.Timestamp)
+	if err != nil {
+		return fmt.Errorf("failed to record GDPR consent: %w", err)
+	}
+
+	s.logger.Info("GDPR consent recorded",
+		zap.String("user_id", consent.UserID),
+		zap.String("consent_type", string(consent.ConsentType)),
+		zap.String("version", consent.Version),
+		zap.Bool("granted", consent.Granted),
+	)
+
+	if consent.ConsentType == model.GDPRConsentProcessing && s.consents != nil {
+		if _, err := s.consents.setConsent(ctx, consent.UserID, model.ConsentTypeDataProcessing, consent.Granted); err != nil {
+			return fmt.Errorf("failed to update data processing consent: %w", err)
+		}
+	}
+
+	err = s.auditLogger.Log(ctx, audit.AuditLog{
+		UserID:         consent.UserID,
+		OperationType:  audit.OperationCreate,
+		ResourceType:   audit.ResourceGDPRConsent,
+		ResourceID:     consent.ID,
+		AdditionalData: map[string]interface{}{"consent_type": consent.ConsentType, "granted": consent.Granted, "version": consent.Version},
+	})
+	if err != nil {
+		s.logger.Error("Failed to log audit entry for GDPR consent", zap.Error(err))
+	}
+
+	return nil
+}
+
+// GetConsentHistory returns every GDPR consent a user answered, oldest first
+func (s *GDPRService) GetConsentHistory(ctx context.Context, userID string) ([]model.GDPRConsent, error) {
+	rows, err := s.db.Query(ctx, `
+		SELECT id, user_id, consent_type, granted, version, timestamp, ip_address
+		FROM gdpr_consents WHERE user_id = $1
+		ORDER BY timestamp, id
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GDPR consent history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []model.GDPRConsent{}
+	for rows.Next() {
+		var consent model.GDPRConsent
+		err := rows.Scan(
+			&consent.ID, &consent.UserID, &consent.ConsentType, &consent.Granted,
+			&consent.Version, &consent.Timestamp, &consent.IPAddress,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan GDPR consent: %w", err)
+		}
+		history = append(history, consent)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating GDPR consents: %w", err)
+	}
+
+	return history, nil
+}
+
+// checkErasureConsent returns ErrErasureConsentRequired unless the user once consented
+// to processing, through either consent endpoint, and their latest right_to_erasure
+// answer grants it
+func checkErasureConsent(ctx context.Context, tx pgx.Tx, userID string) error {
+	var processingGiven, erasureGranted bool
+	err := tx.QueryRow(ctx, `
+		SELECT
+			EXISTS (
+				SELECT 1 FROM gdpr_consents
+				WHERE user_id = $1 AND consent_type = 'processing' AND granted
+			) OR EXISTS (
+				SELECT 1 FROM user_consents
+				WHERE user_id = $1 AND consent_type = 'data_processing' AND granted_at IS NOT NULL
+			),
+			COALESCE((
+				SELECT granted FROM gdpr_consents
+				WHERE user_id = $1 AND consent_type = 'right_to_erasure'
+				ORDER BY timestamp DESC, id DESC
+				LIMIT 1
+			), FALSE)
+	`, userID).Scan(&processingGiven, &erasureGranted)
+	if err != nil {
+		return fmt.Errorf("failed to check erasure consent: %w", err)
+	}
+
+	if !processingGiven {
+		return fmt.Errorf("%w: consent to processing was never given", ErrErasureConsentRequired)
+	}
+	if !erasureGranted {
+		return fmt.Errorf("%w: no granted right_to_erasure consent record", ErrErasureConsentRequired)
+	}
+	return nil
+}
+
+// validGDPRConsentType reports whether consentType is a known GDPR consent type
+func validGDPRConsentType(consentType model.GDPRConsentType) bool {
+	for _, known := range gdprConsentTypes {
+		if consentType == known {
+			return true
+		}
+	}
+	return false
+}
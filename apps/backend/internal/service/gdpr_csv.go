@@ -0,0 +1,177 @@
+package service
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvListSeparator joins list values such as symptoms within one CSV field
+const csvListSeparator = "; "
+
+// csvTable is one CSV file of a ZIP archive
+type csvTable struct {
+	name    string
+	columns []string
+	rows    [][]string
+}
+
+// writeUserDataCSVZip writes export as a ZIP archive holding one CSV file with a header
+// row per table. Tables without rows are included with their header only.
+func writeUserDataCSVZip(w io.Writer, export *UserDataExport) error {
+	return writeCSVZip(w, gdprCSVTables(export), export.ExportedAt)
+}
+
+// writeCSVZip writes tables as a ZIP archive of CSV files, each starting with its header
+// row, dated modified
+func writeCSVZip(w io.Writer, tables []csvTable, modified time.Time) error {
+	archive := zip.NewWriter(w)
+
+	for _, table := range tables {
+		file, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     table.name,
+			Method:   zip.Deflate,
+			Modified: modified,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add %s to archive: %w", table.name, err)
+		}
+
+		writer := csv.NewWriter(file)
+		if err := writer.Write(table.columns); err != nil {
+			return fmt.Errorf("failed to write %s: %w", table.name, err)
+		}
+		if err := writer.WriteAll(table.rows); err != nil {
+			return fmt.Errorf("failed to write %s: %w", table.name, err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return nil
+}
+
+// gdprCSVTables converts the export to its CSV tables, one per database table
+func gdprCSVTables(export *UserDataExport) []csvTable {
+	users := csvTable{
+		name:    "users.csv",
+		columns: []string{"id", "name", "email", "created_at", "updated_at", "deleted_at"},
+	}
+	if u := export.User; u != nil {
+		users.rows = append(users.rows, []string{
+			u.ID, u.Name, u.Email, isoTimestamp(u.CreatedAt), isoTimestamp(u.UpdatedAt), isoTimestampPtr(u.DeletedAt),
+		})
+	}
+
+	checkIns := csvTable{
+		name: "health_check_ins.csv",
+		columns: []string{
+			"id", "user_id", "session_id", "check_in_date", "symptoms", "mood", "pain_level",
+			"energy_level", "sleep_quality", "medication_taken", "physical_activity",
+			"breakfast", "lunch", "dinner", "general_feeling", "additional_notes",
+			"raw_transcript", "low_confidence", "extraction_issues",
+			"extraction_prompt_version", "created_at", "updated_at",
+		},
+	}
+	for _, c := range export.HealthCheckIns {
+		checkIns.rows = append(checkIns.rows, []string{
+			c.ID, c.UserID, stringValue(c.SessionID), isoDate(c.CheckInDate),
+			strings.Join(c.Symptoms, csvListSeparator), stringValue(c.Mood), intValue(c.PainLevel),
+			stringValue(c.EnergyLevel), stringValue(c.SleepQuality), stringValue(c.MedicationTaken),
+			strings.Join(c.PhysicalActivity, csvListSeparator),
+			stringValue(c.Breakfast), stringValue(c.Lunch), stringValue(c.Dinner),
+			stringValue(c.GeneralFeeling), stringValue(c.AdditionalNotes),
+			stringValue(c.RawTranscript), strconv.FormatBool(c.LowConfidence),
+			strings.Join(c.ExtractionIssues, csvListSeparator),
+			c.ExtractionPromptVersion, isoTimestamp(c.CreatedAt), isoTimestamp(c.UpdatedAt),
+		})
+	}
+
+	medications := csvTable{
+		name: "medications.csv",
+		columns: []string{
+			"id", "user_id", "name", "dosage", "frequency", "start_date", "end_date",
+			"notes", "active", "created_at", "updated_at",
+		},
+	}
+	for _, m := range export.Medications {
+		medications.rows = append(medications.rows, []string{
+			m.ID, m.UserID, m.Name, m.Dosage, m.Frequency, isoDate(m.StartDate), isoDatePtr(m.EndDate),
+			stringValue(m.Notes), strconv.FormatBool(m.Active), isoTimestamp(m.CreatedAt), isoTimestamp(m.UpdatedAt),
+		})
+	}
+
+	cycles := csvTable{
+		name: "menstruation_cycles.csv",
+		columns: []string{
+			"id", "user_id", "start_date", "end_date", "flow_intensity", "symptoms",
+			"created_at", "updated_at",
+		},
+	}
+	for _, c := range export.MenstruationCycles {
+		cycles.rows = append(cycles.rows, []string{
+			c.ID, c.UserID, isoDate(c.StartDate), isoDatePtr(c.EndDate), stringValue(c.FlowIntensity),
+			strings.Join(c.Symptoms, csvListSeparator), isoTimestamp(c.CreatedAt), isoTimestamp(c.UpdatedAt),
+		})
+	}
+
+	bloodPressure := csvTable{
+		name:    "blood_pressure_readings.csv",
+		columns: []string{"id", "user_id", "systolic", "diastolic", "pulse", "measured_at", "notes", "created_at"},
+	}
+	for _, bp := range export.BloodPressureReadings {
+		bloodPressure.rows = append(bloodPressure.rows, []string{
+			bp.ID, bp.UserID, strconv.Itoa(bp.Systolic), strconv.Itoa(bp.Diastolic), strconv.Itoa(bp.Pulse),
+			isoTimestamp(bp.MeasuredAt), stringValue(bp.Notes), isoTimestamp(bp.CreatedAt),
+		})
+	}
+
+	fitness := csvTable{
+		name: "fitness_data.csv",
+		columns: []string{
+			"id", "user_id", "date", "data_type", "value", "unit", "source", "source_data_id", "created_at",
+		},
+	}
+	for _, f := range export.FitnessData {
+		fitness.rows = append(fitness.rows, []string{
+			f.ID, f.UserID, isoDate(f.Date), f.DataType, strconv.FormatFloat(f.Value, 'f', -1, 64),
+			f.Unit, f.Source, f.SourceDataID, isoTimestamp(f.CreatedAt),
+		})
+	}
+
+	reports := csvTable{
+		name: "reports.csv",
+		columns: []string{
+			"id", "user_id", "date_range_start", "date_range_end", "file_path", "generated_at", "created_at",
+		},
+	}
+	for _, r := range export.Reports {
+		reports.rows = append(reports.rows, []string{
+			r.ID, r.UserID, isoDate(r.DateRangeStart), isoDate(r.DateRangeEnd), r.FilePath,
+			isoTimestamp(r.GeneratedAt), isoTimestamp(r.CreatedAt),
+		})
+	}
+
+	return []csvTable{users, checkIns, medications, cycles, bloodPressure, fitness, reports}
+}
+
+// isoTimestampPtr formats t as an ISO-8601 timestamp, or an empty string when t is nil
+func isoTimestampPtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return isoTimestamp(*t)
+}
+
+// intValue formats i, or returns an empty string when i is nil
+func intValue(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(*i)
+}
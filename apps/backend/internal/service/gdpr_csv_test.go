@@ -0,0 +1,109 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+)
+
+func TestWriteUserDataCSVZip_OneCSVPerTable(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	userID := "user-1"
+	mood := "good, \"mostly\""
+	pain := 3
+	notes := "with food"
+
+	export := &UserDataExport{
+		User: &model.User{ID: userID, Name: "Test User", Email: "test@example.com", CreatedAt: now, UpdatedAt: now},
+		HealthCheckIns: []model.HealthCheckIn{
+			{ID: "c1", UserID: userID, CheckInDate: now, Symptoms: []string{"headache", "nausea"}, Mood: &mood, PainLevel: &pain},
+			{ID: "c2", UserID: userID, CheckInDate: now.AddDate(0, 0, -1)},
+		},
+		Medications: []model.Medication{
+			{ID: "m1", UserID: userID, Name: "Ibuprofen", Dosage: "200mg", Frequency: "daily", StartDate: now, Notes: &notes, Active: true},
+		},
+		MenstruationCycles: []model.MenstruationCycle{
+			{ID: "mc1", UserID: userID, StartDate: now},
+			{ID: "mc2", UserID: userID, StartDate: now.AddDate(0, -1, 0)},
+			{ID: "mc3", UserID: userID, StartDate: now.AddDate(0, -2, 0)},
+		},
+		BloodPressureReadings: []model.BloodPressureReading{
+			{ID: "bp1", UserID: userID, Systolic: 120, Diastolic: 80, Pulse: 70, MeasuredAt: now},
+		},
+		FitnessData: []model.FitnessDataPoint{
+			{ID: "f1", UserID: userID, Date: now, DataType: "steps", Value: 8000, Unit: "count"},
+			{ID: "f2", UserID: userID, Date: now, DataType: "heart_rate", Value: 62.5, Unit: "bpm"},
+		},
+		Reports: []model.Report{
+			{ID: "r1", UserID: userID, DateRangeStart: now.AddDate(0, -1, 0), DateRangeEnd: now, FilePath: "reports/r1.pdf"},
+		},
+		ExportedAt: now,
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeUserDataCSVZip(&buf, export))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	tables := make(map[string][][]string)
+	for _, file := range archive.File {
+		f, err := file.Open()
+		require.NoError(t, err)
+		records, err := csv.NewReader(f).ReadAll()
+		f.Close()
+		require.NoError(t, err, file.Name)
+		tables[file.Name] = records
+	}
+
+	wantRows := map[string]int{
+		"users.csv":                   1,
+		"health_check_ins.csv":        2,
+		"medications.csv":             1,
+		"menstruation_cycles.csv":     3,
+		"blood_pressure_readings.csv": 1,
+		"fitness_data.csv":            2,
+		"reports.csv":                 1,
+	}
+	require.Len(t, tables, len(wantRows))
+	for name, rows := range wantRows {
+		records, ok := tables[name]
+		require.True(t, ok, "%s missing from archive", name)
+		require.NotEmpty(t, records, "%s has no header", name)
+		assert.Equal(t, "id", records[0][0], "%s header", name)
+		assert.Len(t, records[1:], rows, "%s rows", name)
+		for _, record := range records[1:] {
+			assert.Len(t, record, len(records[0]), "%s row width", name)
+		}
+	}
+
+	checkIn := tables["health_check_ins.csv"][1]
+	assert.Equal(t, "headache; nausea", checkIn[4])
+	assert.Equal(t, mood, checkIn[5], "quoted values survive the round trip")
+	assert.Equal(t, "3", checkIn[6])
+	assert.Equal(t, "62.5", tables["fitness_data.csv"][2][4])
+}
+
+func TestWriteUserDataCSVZip_EmptyTablesKeepHeaders(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeUserDataCSVZip(&buf, &UserDataExport{ExportedAt: time.Now()}))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	assert.Len(t, archive.File, 7)
+
+	for _, file := range archive.File {
+		f, err := file.Open()
+		require.NoError(t, err)
+		records, err := csv.NewReader(f).ReadAll()
+		f.Close()
+		require.NoError(t, err)
+		assert.Len(t, records, 1, "%s should hold only its header", file.Name)
+	}
+}
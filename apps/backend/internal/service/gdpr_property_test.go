@@ -21,8 +21,26 @@ import (
 
 // setupTestDB creates a PostgreSQL testcontainer and returns the connection pool
 func setupTestDB(t *testing.T) (*pgxpool.Pool, func()) {
+	pool, cleanup := startTestPostgres(t)
+
+	// Run migrations
+	runMigrations(t, pool)
+
+	return pool, cleanup
+}
+
+// startTestPostgres starts an empty PostgreSQL testcontainer and returns the connection pool
+func startTestPostgres(t *testing.T) (*pgxpool.Pool, func()) {
 	ctx := context.Background()
 
+	// testcontainers panics when no Docker host is found; fail this test only so the
+	// rest of the package still runs
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("failed to start PostgreSQL container: %v", r)
+		}
+	}()
+
 	// Start PostgreSQL container
 	postgresContainer, err := postgres.Run(ctx,
 		"postgres:15-alpine",
@@ -44,9 +62,6 @@ func setupTestDB(t *testing.T) (*pgxpool.Pool, func()) {
 	pool, err := pgxpool.New(ctx, connString)
 	require.NoError(t, err)
 
-	// Run migrations
-	runMigrations(t, pool)
-
 	cleanup := func() {
 		pool.Close()
 		if err := postgresContainer.Terminate(ctx); err != nil {
@@ -68,7 +83,8 @@ func runMigrations(t *testing.T, pool *pgxpool.Pool) {
 			email VARCHAR(255) UNIQUE NOT NULL,
 			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
 			updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
-			deleted_at TIMESTAMP
+			deleted_at TIMESTAMP,
+			email_verified_at TIMESTAMP
 		)`,
 		`CREATE TABLE IF NOT EXISTS check_in_sessions (
 			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -76,7 +92,10 @@ func runMigrations(t *testing.T, pool *pgxpool.Pool) {
 			started_at TIMESTAMP NOT NULL DEFAULT NOW(),
 			completed_at TIMESTAMP,
 			expired_at TIMESTAMP,
+			paused_at TIMESTAMP,
+			resumed_at TIMESTAMP,
 			status VARCHAR(50) NOT NULL,
+			question_set_id UUID,
 			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
 			updated_at TIMESTAMP NOT NULL DEFAULT NOW()
 		)`,
@@ -112,7 +131,35 @@ func runMigrations(t *testing.T, pool *pgxpool.Pool) {
 			notes TEXT,
 			active BOOLEAN NOT NULL DEFAULT true,
 			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-			updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			interaction_warnings JSONB NOT NULL DEFAULT '[]'
+		)`,
+		`CREATE TABLE IF NOT EXISTS medication_logs (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			medication_id UUID NOT NULL REFERENCES medications(id) ON DELETE CASCADE,
+			user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			taken_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			notes TEXT,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS conversation_messages (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			session_id UUID NOT NULL REFERENCES check_in_sessions(id) ON DELETE CASCADE,
+			role VARCHAR(50) NOT NULL,
+			content TEXT NOT NULL,
+			audio_file_path VARCHAR(500),
+			storage_backend VARCHAR(64) NOT NULL DEFAULT 'default',
+			created_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS audio_recordings (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			session_id UUID NOT NULL REFERENCES check_in_sessions(id) ON DELETE CASCADE,
+			message_id UUID REFERENCES conversation_messages(id) ON DELETE SET NULL,
+			file_path VARCHAR(500) NOT NULL,
+			duration_seconds FLOAT,
+			transcription TEXT,
+			storage_backend VARCHAR(64) NOT NULL DEFAULT 'default',
+			created_at TIMESTAMP NOT NULL DEFAULT NOW()
 		)`,
 		`CREATE TABLE IF NOT EXISTS menstruation_cycles (
 			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -121,6 +168,7 @@ func runMigrations(t *testing.T, pool *pgxpool.Pool) {
 			end_date DATE,
 			flow_intensity VARCHAR(50),
 			symptoms TEXT[],
+			notes TEXT,
 			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
 			updated_at TIMESTAMP NOT NULL DEFAULT NOW()
 		)`,
@@ -131,6 +179,7 @@ func runMigrations(t *testing.T, pool *pgxpool.Pool) {
 			diastolic INTEGER NOT NULL,
 			pulse INTEGER NOT NULL,
 			measured_at TIMESTAMP NOT NULL,
+			notes TEXT,
 			created_at TIMESTAMP NOT NULL DEFAULT NOW()
 		)`,
 		`CREATE TABLE IF NOT EXISTS fitness_data (
@@ -150,9 +199,20 @@ func runMigrations(t *testing.T, pool *pgxpool.Pool) {
 			date_range_start DATE NOT NULL,
 			date_range_end DATE NOT NULL,
 			file_path VARCHAR(500) NOT NULL,
+			storage_backend VARCHAR(64) NOT NULL DEFAULT 'default',
 			generated_at TIMESTAMP NOT NULL,
 			created_at TIMESTAMP NOT NULL DEFAULT NOW()
 		)`,
+		`CREATE TABLE IF NOT EXISTS reports_jobs (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			user_id UUID NOT NULL,
+			report_id UUID REFERENCES reports(id) ON DELETE SET NULL,
+			start_date DATE NOT NULL,
+			end_date DATE NOT NULL,
+			status VARCHAR(20) NOT NULL DEFAULT 'pending',
+			password TEXT,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)`,
 		`CREATE TABLE IF NOT EXISTS audit_logs (
 			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
 			user_id UUID NOT NULL,
@@ -164,6 +224,149 @@ func runMigrations(t *testing.T, pool *pgxpool.Pool) {
 			user_agent TEXT,
 			additional_data JSONB
 		)`,
+		`CREATE TABLE IF NOT EXISTS organization_invitations (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			organization_id UUID NOT NULL,
+			email VARCHAR(255) NOT NULL,
+			role VARCHAR(32) NOT NULL,
+			token_hash CHAR(64) NOT NULL UNIQUE,
+			invited_by UUID NOT NULL,
+			expires_at TIMESTAMP NOT NULL,
+			accepted_at TIMESTAMP,
+			accepted_by UUID,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS care_team_sharing_consents (
+			organization_id UUID NOT NULL,
+			user_id UUID NOT NULL,
+			granted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (organization_id, user_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS integration_deliveries (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			integration_id UUID NOT NULL,
+			event VARCHAR(64) NOT NULL,
+			user_id UUID,
+			check_in_id UUID,
+			status VARCHAR(16) NOT NULL,
+			error_message TEXT,
+			attempted_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS user_consents (
+			user_id UUID NOT NULL,
+			consent_type VARCHAR(32) NOT NULL,
+			granted BOOLEAN NOT NULL,
+			granted_at TIMESTAMP,
+			revoked_at TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (user_id, consent_type)
+		)`,
+		`CREATE TABLE IF NOT EXISTS health_check_ins_archive (
+			LIKE health_check_ins INCLUDING DEFAULTS
+		)`,
+		`CREATE TABLE IF NOT EXISTS gdpr_consents (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			user_id UUID NOT NULL,
+			consent_type VARCHAR(30) NOT NULL,
+			granted BOOLEAN NOT NULL,
+			version VARCHAR(50) NOT NULL,
+			timestamp TIMESTAMP NOT NULL DEFAULT NOW(),
+			ip_address VARCHAR(45),
+			UNIQUE (user_id, consent_type, version)
+		)`,
+		`CREATE TABLE IF NOT EXISTS user_question_sets (
+			user_id UUID PRIMARY KEY,
+			question_set_id UUID NOT NULL,
+			assigned_by UUID,
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS personal_access_tokens (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			user_id UUID NOT NULL,
+			name VARCHAR(255) NOT NULL,
+			token_prefix VARCHAR(16) NOT NULL,
+			token_hash CHAR(64) NOT NULL UNIQUE,
+			scopes TEXT[] NOT NULL,
+			expires_at TIMESTAMP NOT NULL,
+			last_used_at TIMESTAMP,
+			revoked_at TIMESTAMP,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS webhooks (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			user_id UUID NOT NULL,
+			target_url TEXT NOT NULL,
+			secret_token TEXT NOT NULL,
+			events TEXT[] NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS report_schedules (
+			user_id UUID PRIMARY KEY,
+			cadence VARCHAR(10) NOT NULL,
+			day INTEGER NOT NULL,
+			enabled BOOLEAN NOT NULL DEFAULT TRUE,
+			language VARCHAR(10) NOT NULL DEFAULT '',
+			last_run_on DATE,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS user_settings (
+			user_id UUID PRIMARY KEY,
+			timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS anomalies (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			user_id UUID NOT NULL,
+			metric VARCHAR(50) NOT NULL,
+			value DOUBLE PRECISION NOT NULL,
+			baseline DOUBLE PRECISION,
+			threshold DOUBLE PRECISION NOT NULL,
+			severity VARCHAR(20) NOT NULL,
+			reason TEXT NOT NULL,
+			source_type VARCHAR(50) NOT NULL,
+			source_id UUID NOT NULL,
+			observed_at TIMESTAMP NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS cycle_suggestions (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			user_id UUID NOT NULL,
+			start_date DATE NOT NULL,
+			end_date DATE NOT NULL,
+			symptoms TEXT[] NOT NULL DEFAULT '{}',
+			check_in_ids UUID[] NOT NULL DEFAULT '{}',
+			status VARCHAR(20) NOT NULL DEFAULT 'open',
+			cycle_id UUID REFERENCES menstruation_cycles(id) ON DELETE SET NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			resolved_at TIMESTAMP,
+			UNIQUE (user_id, start_date)
+		)`,
+		`CREATE TABLE IF NOT EXISTS clinician_patient_assignments (
+			organization_id UUID NOT NULL,
+			clinician_id UUID NOT NULL,
+			patient_id UUID NOT NULL,
+			assigned_by UUID,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (organization_id, clinician_id, patient_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS panel_digest_subscriptions (
+			organization_id UUID NOT NULL,
+			clinician_id UUID NOT NULL,
+			email VARCHAR(255) NOT NULL,
+			last_sent_at TIMESTAMP,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (organization_id, clinician_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS email_verification_tokens (
+			id UUID PRIMARY KEY,
+			user_id UUID NOT NULL,
+			email VARCHAR(255) NOT NULL,
+			expires_at TIMESTAMP NOT NULL,
+			used_at TIMESTAMP,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)`,
 	}
 
 	for _, migration := range migrations {
@@ -325,7 +528,7 @@ func TestProperty_AuditLogCreation(t *testing.T) {
 			}
 
 			// Verify audit log was created
-			logs, err := auditLogger.GetAuditLogs(ctx, userID, 10)
+			logs, _, err := auditLogger.GetAuditLogs(ctx, audit.AuditFilter{UserID: userID, Limit: 10})
 			if err != nil {
 				t.Logf("Failed to retrieve audit logs: %v", err)
 				return false
@@ -364,6 +567,55 @@ func TestProperty_AuditLogCreation(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+// Property 24: Anonymization Removes Identifiers
+// After anonymization, neither the user's name nor email appears in any table, while
+// numeric health metrics are kept
+func TestProperty_AnonymizationRemovesIdentifiers(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	auditLogger := audit.NewLogger(db, zap.NewNop())
+	service := NewGDPRService(db, auditLogger, zap.NewNop())
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 10
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("no original name or email remains after anonymization", prop.ForAll(
+		func(name string) bool {
+			ctx := context.Background()
+			userID := uuid.New().String()
+			email := name + "@example.com"
+
+			createIdentifyingUserData(t, db, userID, name, email)
+
+			if err := service.AnonymizeUserData(ctx, userID, "127.0.0.1", "test-agent"); err != nil {
+				t.Logf("AnonymizeUserData failed: %v", err)
+				return false
+			}
+
+			for _, identifier := range []string{name, email} {
+				if table, found := findIdentifier(t, db, identifier); found {
+					t.Logf("Identifier %q still present in %s", identifier, table)
+					return false
+				}
+			}
+
+			var readings int
+			err := db.QueryRow(ctx, "SELECT COUNT(*) FROM blood_pressure_readings WHERE user_id = $1 AND systolic = 120", userID).Scan(&readings)
+			if err != nil || readings != 1 {
+				t.Logf("Blood pressure readings were not kept: count=%d err=%v", readings, err)
+				return false
+			}
+
+			return true
+		},
+		gen.Identifier().SuchThat(func(name string) bool { return len(name) >= 8 }),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
 // Helper types and functions
 
 type DataCounts struct {
@@ -449,6 +701,15 @@ func createTestUserData(t *testing.T, db *pgxpool.Pool, userID string) {
 	if err != nil {
 		t.Fatalf("Failed to create check-in session: %v", err)
 	}
+
+	// Record the consents a deletion requires
+	_, err = db.Exec(ctx, `
+		INSERT INTO gdpr_consents (user_id, consent_type, granted, version)
+		VALUES ($1, 'processing', TRUE, 'v1'), ($1, 'right_to_erasure', TRUE, 'v1')
+	`, userID)
+	if err != nil {
+		t.Fatalf("Failed to create GDPR consents: %v", err)
+	}
 }
 
 func createTestUserDataWithCounts(t *testing.T, db *pgxpool.Pool, userID string) DataCounts {
@@ -623,3 +884,70 @@ func verifyUserDataDeleted(t *testing.T, db *pgxpool.Pool, userID string) bool {
 
 	return true
 }
+
+// createIdentifyingUserData creates a user whose name and email also appear in
+// free-text fields across the schema
+func createIdentifyingUserData(t *testing.T, db *pgxpool.Pool, userID, name, email string) {
+	ctx := context.Background()
+	mention := "I am " + name + ", reach me at " + email
+	sessionID := uuid.New().String()
+	medicationID := uuid.New().String()
+	messageID := uuid.New().String()
+
+	statements := []struct {
+		query string
+		args  []any
+	}{
+		{"INSERT INTO users (id, name, email) VALUES ($1, $2, $3)", []any{userID, name, email}},
+		{"INSERT INTO check_in_sessions (id, user_id, status) VALUES ($1, $2, 'completed')", []any{sessionID, userID}},
+		{`INSERT INTO health_check_ins (user_id, session_id, check_in_date, pain_level, general_feeling, additional_notes, raw_transcript, breakfast)
+			VALUES ($1, $2, CURRENT_DATE, 3, $3, $3, $3, $3)`, []any{userID, sessionID, mention}},
+		{"INSERT INTO conversation_messages (id, session_id, role, content) VALUES ($1, $2, 'user', $3)", []any{messageID, sessionID, mention}},
+		{"INSERT INTO audio_recordings (session_id, message_id, file_path, transcription) VALUES ($1, $2, 'audio/test.wav', $3)", []any{sessionID, messageID, mention}},
+		{"INSERT INTO medications (id, user_id, name, dosage, frequency, start_date, notes) VALUES ($1, $2, 'Test Med', '10mg', 'daily', CURRENT_DATE, $3)", []any{medicationID, userID, mention}},
+		{"INSERT INTO medication_logs (medication_id, user_id, notes) VALUES ($1, $2, $3)", []any{medicationID, userID, mention}},
+		{"INSERT INTO menstruation_cycles (user_id, start_date, notes) VALUES ($1, CURRENT_DATE, $2)", []any{userID, mention}},
+		{"INSERT INTO blood_pressure_readings (user_id, systolic, diastolic, pulse, measured_at, notes) VALUES ($1, 120, 80, 70, NOW(), $2)", []any{userID, mention}},
+		{"INSERT INTO reports (user_id, date_range_start, date_range_end, file_path, generated_at) VALUES ($1, CURRENT_DATE, CURRENT_DATE, $2, NOW())", []any{userID, "reports/" + name + ".pdf"}},
+		{`INSERT INTO organization_invitations (organization_id, email, role, token_hash, invited_by, expires_at, accepted_at, accepted_by)
+			VALUES (gen_random_uuid(), $1, 'patient', md5($1) || md5($2), gen_random_uuid(), NOW(), NOW(), $2)`, []any{email, userID}},
+	}
+
+	for _, stmt := range statements {
+		if _, err := db.Exec(ctx, stmt.query, stmt.args...); err != nil {
+			t.Fatalf("Failed to create identifying test data: %v", err)
+		}
+	}
+}
+
+// findIdentifier searches every row of every table for the identifier and returns the
+// first table containing it
+func findIdentifier(t *testing.T, db *pgxpool.Pool, identifier string) (string, bool) {
+	ctx := context.Background()
+
+	rows, err := db.Query(ctx, "SELECT table_name FROM information_schema.tables WHERE table_schema = 'public'")
+	if err != nil {
+		t.Fatalf("Failed to list tables: %v", err)
+	}
+	var tables []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			t.Fatalf("Failed to scan table name: %v", err)
+		}
+		tables = append(tables, table)
+	}
+	rows.Close()
+
+	for _, table := range tables {
+		var found bool
+		query := "SELECT EXISTS (SELECT 1 FROM " + table + " t WHERE strpos(t::text, $1) > 0)"
+		if err := db.QueryRow(ctx, query, identifier).Scan(&found); err != nil {
+			t.Fatalf("Failed to search %s: %v", table, err)
+		}
+		if found {
+			return table, true
+		}
+	}
+	return "", false
+}
@@ -7,14 +7,17 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 	"go.uber.org/zap"
 )
 
 // HealthDataService handles health data management business logic
 type HealthDataService struct {
-	repo   *repository.HealthDataRepository
-	logger *zap.Logger
+	repo      *repository.HealthDataRepository
+	anomalies *AnomalyDetector
+	alerts    *AlertService
+	logger    *zap.Logger
 }
 
 // NewHealthDataService creates a new HealthDataService
@@ -25,8 +28,21 @@ func NewHealthDataService(repo *repository.HealthDataRepository, logger *zap.Log
 	}
 }
 
+// SetAnomalyDetector checks new blood pressure readings for anomalies
+func (s *HealthDataService) SetAnomalyDetector(anomalies *AnomalyDetector) {
+	s.anomalies = anomalies
+}
+
+// SetAlertService raises alerts for blood pressure readings in hypertensive crisis
+func (s *HealthDataService) SetAlertService(alerts *AlertService) {
+	s.alerts = alerts
+}
+
 // LogMenstruation logs menstruation cycle data
 func (s *HealthDataService) LogMenstruation(ctx context.Context, userID string, data *model.MenstruationCycle) error {
+	ctx, span := telemetry.StartSpan(ctx, "HealthDataService.LogMenstruation")
+	defer span.End()
+
 	if userID == "" {
 		return fmt.Errorf("user ID is required")
 	}
@@ -72,31 +88,112 @@ func (s *HealthDataService) LogMenstruation(ctx context.Context, userID string,
 	return nil
 }
 
-// GetMenstruationHistory retrieves menstruation cycle history for a user
-func (s *HealthDataService) GetMenstruationHistory(ctx context.Context, userID string) ([]model.MenstruationCycle, error) {
+// GetMenstruationHistory retrieves a page of menstruation cycle history for a user and the total number of entries
+func (s *HealthDataService) GetMenstruationHistory(ctx context.Context, userID string, page repository.Page) ([]model.MenstruationCycle, int, error) {
+	ctx, span := telemetry.StartSpan(ctx, "HealthDataService.GetMenstruationHistory")
+	defer span.End()
+
 	if userID == "" {
-		return nil, fmt.Errorf("user ID is required")
+		return nil, 0, fmt.Errorf("user ID is required")
 	}
 
-	cycles, err := s.repo.GetMenstruationByUserID(ctx, userID)
+	cycles, total, err := s.repo.GetMenstruationPageByUserID(ctx, userID, page)
 	if err != nil {
 		s.logger.Error("failed to get menstruation history",
 			zap.Error(err),
 			zap.String("user_id", userID),
 		)
-		return nil, fmt.Errorf("failed to get menstruation history: %w", err)
+		return nil, 0, fmt.Errorf("failed to get menstruation history: %w", err)
 	}
 
 	s.logger.Info("menstruation history retrieved successfully",
 		zap.String("user_id", userID),
 		zap.Int("count", len(cycles)),
+		zap.Int("total_count", total),
+	)
+
+	return cycles, total, nil
+}
+
+// MenstruationUpdate holds a partial update of a menstruation cycle.
+// Nil fields are left unchanged.
+type MenstruationUpdate struct {
+	EndDate       *time.Time
+	FlowIntensity *string
+	Symptoms      []string
+	SymptomsMode  SymptomUpdateMode
+}
+
+// UpdateMenstruation applies a partial update to a menstruation cycle. Symptoms are
+// appended to or replace the stored list depending on the update mode.
+func (s *HealthDataService) UpdateMenstruation(ctx context.Context, cycleID string, update *MenstruationUpdate) (*model.MenstruationCycle, error) {
+	ctx, span := telemetry.StartSpan(ctx, "HealthDataService.UpdateMenstruation")
+	defer span.End()
+
+	if cycleID == "" {
+		return nil, fmt.Errorf("cycle ID is required")
+	}
+
+	mode := update.SymptomsMode
+	if mode == "" {
+		mode = SymptomUpdateReplace
+	}
+	if mode != SymptomUpdateAppend && mode != SymptomUpdateReplace {
+		return nil, fmt.Errorf("invalid symptoms mode: must be append or replace")
+	}
+
+	if update.FlowIntensity != nil {
+		validIntensities := map[string]bool{
+			"light":    true,
+			"moderate": true,
+			"heavy":    true,
+		}
+		if !validIntensities[*update.FlowIntensity] {
+			return nil, fmt.Errorf("invalid flow intensity: must be light, moderate, or heavy")
+		}
+	}
+
+	cycle, err := s.repo.GetMenstruationByID(ctx, cycleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get menstruation cycle: %w", err)
+	}
+
+	if update.EndDate != nil {
+		if update.EndDate.Before(cycle.StartDate) {
+			return nil, fmt.Errorf("end date must not be before start date")
+		}
+		cycle.EndDate = update.EndDate
+	}
+	if update.FlowIntensity != nil {
+		cycle.FlowIntensity = update.FlowIntensity
+	}
+	if update.Symptoms != nil {
+		cycle.Symptoms = mergeSymptoms(cycle.Symptoms, update.Symptoms, mode)
+	}
+
+	if err := s.repo.UpdateMenstruation(ctx, cycle); err != nil {
+		s.logger.Error("failed to update menstruation data",
+			zap.Error(err),
+			zap.String("cycle_id", cycleID),
+		)
+		return nil, fmt.Errorf("failed to update menstruation data: %w", err)
+	}
+	cycle.UpdatedAt = time.Now()
+
+	s.logger.Info("menstruation data updated successfully",
+		zap.String("cycle_id", cycleID),
+		zap.String("symptoms_mode", string(mode)),
+		zap.Int("symptom_count", len(cycle.Symptoms)),
 	)
 
-	return cycles, nil
+	return cycle, nil
 }
 
 // LogBloodPressure logs a blood pressure reading
 func (s *HealthDataService) LogBloodPressure(ctx context.Context, userID string, reading *model.BloodPressureReading) error {
+	ctx, span := telemetry.StartSpan(ctx, "HealthDataService.LogBloodPressure")
+	defer span.End()
+
 	if userID == "" {
 		return fmt.Errorf("user ID is required")
 	}
@@ -138,76 +235,76 @@ func (s *HealthDataService) LogBloodPressure(ctx context.Context, userID string,
 		zap.Int("diastolic", reading.Diastolic),
 	)
 
+	// Surface dangerous readings instead of leaving them to the dashboard
+	if s.anomalies != nil {
+		if _, err := s.anomalies.EvaluateBloodPressure(ctx, reading); err != nil {
+			s.logger.Error("blood pressure anomaly detection failed",
+				zap.Error(err),
+				zap.String("reading_id", reading.ID),
+			)
+		}
+	}
+	if s.alerts != nil {
+		if _, err := s.alerts.EvaluateBloodPressure(ctx, reading); err != nil {
+			s.logger.Error("blood pressure alert failed",
+				zap.Error(err),
+				zap.String("reading_id", reading.ID),
+			)
+		}
+	}
+
 	return nil
 }
 
-// GetBloodPressureHistory retrieves blood pressure reading history for a user
-func (s *HealthDataService) GetBloodPressureHistory(ctx context.Context, userID string) ([]model.BloodPressureReading, error) {
+// GetBloodPressureHistory retrieves a page of blood pressure reading history for a user and the total number of entries
+func (s *HealthDataService) GetBloodPressureHistory(ctx context.Context, userID string, page repository.Page) ([]model.BloodPressureReading, int, error) {
+	ctx, span := telemetry.StartSpan(ctx, "HealthDataService.GetBloodPressureHistory")
+	defer span.End()
+
 	if userID == "" {
-		return nil, fmt.Errorf("user ID is required")
+		return nil, 0, fmt.Errorf("user ID is required")
 	}
 
-	readings, err := s.repo.GetBloodPressureByUserID(ctx, userID)
+	readings, total, err := s.repo.GetBloodPressurePageByUserID(ctx, userID, page)
 	if err != nil {
 		s.logger.Error("failed to get blood pressure history",
 			zap.Error(err),
 			zap.String("user_id", userID),
 		)
-		return nil, fmt.Errorf("failed to get blood pressure history: %w", err)
+		return nil, 0, fmt.Errorf("failed to get blood pressure history: %w", err)
 	}
 
 	s.logger.Info("blood pressure history retrieved successfully",
 		zap.String("user_id", userID),
 		zap.Int("count", len(readings)),
+		zap.Int("total_count", total),
 	)
 
-	return readings, nil
+	return readings, total, nil
 }
 
-// SyncFitnessData syncs fitness data from Health Connect with deduplication
-func (s *HealthDataService) SyncFitnessData(ctx context.Context, userID string, fitnessData []model.FitnessDataPoint) error {
-	if userID == "" {
-		return fmt.Errorf("user ID is required")
-	}
+// FitnessSyncResult counts the data points of a fitness sync that were saved and those
+// skipped as already synced
+type FitnessSyncResult struct {
+	Inserted int `json:"inserted_count"`
+	Skipped  int `json:"skipped_count"`
+}
 
-	syncedCount := 0
-	skippedCount := 0
+// SyncFitnessData syncs fitness data from Health Connect with deduplication. Every data
+// point must have a known data type in that type's unit, otherwise nothing is saved and
+// ErrInvalidFitnessDataPoint is returned.
+func (s *HealthDataService) SyncFitnessData(ctx context.Context, userID string, fitnessData []model.FitnessDataPoint) (*FitnessSyncResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "HealthDataService.SyncFitnessData")
+	defer span.End()
 
-	for _, dataPoint := range fitnessData {
-		// Validate data type
-		validDataTypes := map[string]bool{
-			"steps":          true,
-			"heart_rate":     true,
-			"sleep":          true,
-			"calories":       true,
-			"distance":       true,
-			"active_minutes": true,
-		}
-		if !validDataTypes[dataPoint.DataType] {
-			s.logger.Warn("invalid fitness data type",
-				zap.String("data_type", dataPoint.DataType),
-			)
-			continue
-		}
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
 
-		// Check if data point already exists (deduplication by source_data_id)
-		if dataPoint.SourceDataID != "" {
-			exists, err := s.repo.FitnessDataExists(ctx, dataPoint.SourceDataID)
-			if err != nil {
-				s.logger.Error("failed to check fitness data existence",
-					zap.Error(err),
-					zap.String("source_data_id", dataPoint.SourceDataID),
-				)
-				return fmt.Errorf("failed to check fitness data existence: %w", err)
-			}
-
-			if exists {
-				s.logger.Debug("fitness data already synced, skipping",
-					zap.String("source_data_id", dataPoint.SourceDataID),
-				)
-				skippedCount++
-				continue
-			}
+	valid := make([]model.FitnessDataPoint, 0, len(fitnessData))
+	for i, dataPoint := range fitnessData {
+		if err := validateFitnessDataPoint(dataPoint); err != nil {
+			return nil, fmt.Errorf("data point %d: %w", i, err)
 		}
 
 		// Generate ID if not provided
@@ -215,37 +312,49 @@ func (s *HealthDataService) SyncFitnessData(ctx context.Context, userID string,
 			dataPoint.ID = uuid.New().String()
 		}
 
-		// Set user ID
 		dataPoint.UserID = userID
-
-		// Set timestamp
 		dataPoint.CreatedAt = time.Now()
+		valid = append(valid, dataPoint)
+	}
 
-		// Save new data point
-		if err := s.repo.SaveFitnessData(ctx, &dataPoint); err != nil {
-			s.logger.Error("failed to save fitness data",
-				zap.Error(err),
-				zap.String("user_id", userID),
-				zap.String("data_type", dataPoint.DataType),
-			)
-			return fmt.Errorf("failed to save fitness data: %w", err)
-		}
-
-		syncedCount++
+	// Saved in one statement, deduplicated by source_data_id, before the handler responds
+	inserted, skipped, err := s.repo.BulkSaveFitnessData(ctx, valid)
+	if err != nil {
+		s.logger.Error("failed to save fitness data",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		return nil, fmt.Errorf("failed to save fitness data: %w", err)
 	}
+	result := &FitnessSyncResult{Inserted: inserted, Skipped: skipped}
 
 	s.logger.Info("fitness data synced successfully",
 		zap.String("user_id", userID),
-		zap.Int("synced_count", syncedCount),
-		zap.Int("skipped_count", skippedCount),
-		zap.Int("total_count", len(fitnessData)),
+		zap.Int("inserted_count", result.Inserted),
+		zap.Int("skipped_count", result.Skipped),
 	)
 
+	return result, nil
+}
+
+// validateFitnessDataPoint checks that a data point has a known data type reported in
+// that type's unit
+func validateFitnessDataPoint(dataPoint model.FitnessDataPoint) error {
+	unit, ok := fitnessDataUnits[dataPoint.DataType]
+	if !ok {
+		return fmt.Errorf("%w: unknown data_type %q", ErrInvalidFitnessDataPoint, dataPoint.DataType)
+	}
+	if dataPoint.Unit != unit {
+		return fmt.Errorf("%w: %s must be in %s, got %q", ErrInvalidFitnessDataPoint, dataPoint.DataType, unit, dataPoint.Unit)
+	}
 	return nil
 }
 
 // GetFitnessHistory retrieves fitness data history for a user within a date range
 func (s *HealthDataService) GetFitnessHistory(ctx context.Context, userID string, startDate, endDate time.Time) ([]model.FitnessDataPoint, error) {
+	ctx, span := telemetry.StartSpan(ctx, "HealthDataService.GetFitnessHistory")
+	defer span.End()
+
 	if userID == "" {
 		return nil, fmt.Errorf("user ID is required")
 	}
@@ -255,7 +364,7 @@ func (s *HealthDataService) GetFitnessHistory(ctx context.Context, userID string
 		return nil, fmt.Errorf("start date must be before or equal to end date")
 	}
 
-	dataPoints, err := s.repo.GetFitnessDataByUserID(ctx, userID, startDate, endDate)
+	dataPoints, err := s.repo.GetFitnessDataByUserID(ctx, userID, startDate, endDate, "")
 	if err != nil {
 		s.logger.Error("failed to get fitness history",
 			zap.Error(err),
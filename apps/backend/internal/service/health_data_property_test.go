@@ -86,18 +86,13 @@ func (m *MockHealthDataRepository) GetBloodPressureByUserID(ctx context.Context,
 	return args.Get(0).([]model.BloodPressureReading), args.Error(1)
 }
 
-func (m *MockHealthDataRepository) FitnessDataExists(ctx context.Context, sourceDataID string) (bool, error) {
-	args := m.Called(ctx, sourceDataID)
-	return args.Bool(0), args.Error(1)
+func (m *MockHealthDataRepository) BulkSaveFitnessData(ctx context.Context, points []model.FitnessDataPoint) (int, int, error) {
+	args := m.Called(ctx, points)
+	return args.Int(0), args.Int(1), args.Error(2)
 }
 
-func (m *MockHealthDataRepository) SaveFitnessData(ctx context.Context, dataPoint *model.FitnessDataPoint) error {
-	args := m.Called(ctx, dataPoint)
-	return args.Error(0)
-}
-
-func (m *MockHealthDataRepository) GetFitnessDataByUserID(ctx context.Context, userID string, startDate, endDate time.Time) ([]model.FitnessDataPoint, error) {
-	args := m.Called(ctx, userID, startDate, endDate)
+func (m *MockHealthDataRepository) GetFitnessDataByUserID(ctx context.Context, userID string, startDate, endDate time.Time, dataType string) ([]model.FitnessDataPoint, error) {
+	args := m.Called(ctx, userID, startDate, endDate, dataType)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
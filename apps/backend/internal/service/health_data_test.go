@@ -172,26 +172,47 @@ func TestLogBloodPressure_BoundaryValues(t *testing.T) {
 }
 
 func TestSyncFitnessData_ValidDataTypes(t *testing.T) {
-	validDataTypes := []string{"steps", "heart_rate", "sleep", "calories", "distance", "active_minutes"}
+	validDataPoints := map[string]string{
+		"steps":          "count",
+		"heart_rate":     "bpm",
+		"sleep":          "minutes",
+		"sleep_minutes":  "minutes",
+		"calories":       "kcal",
+		"distance":       "meters",
+		"active_minutes": "minutes",
+		"weight":         "kg",
+	}
 
-	for _, dataType := range validDataTypes {
+	for dataType, unit := range validDataPoints {
 		t.Run(dataType, func(t *testing.T) {
-			// Test validation logic
-			validDataTypesMap := map[string]bool{
-				"steps":          true,
-				"heart_rate":     true,
-				"sleep":          true,
-				"calories":       true,
-				"distance":       true,
-				"active_minutes": true,
-			}
-
-			isValid := validDataTypesMap[dataType]
-			assert.True(t, isValid, "data type %s should be valid", dataType)
+			err := validateFitnessDataPoint(model.FitnessDataPoint{DataType: dataType, Unit: unit})
+			assert.NoError(t, err, "data type %s in %s should be valid", dataType, unit)
 		})
 	}
 }
 
+func TestSyncFitnessData_RejectsInvalidDataPoints(t *testing.T) {
+	service := &HealthDataService{}
+
+	_, err := service.SyncFitnessData(context.Background(), "user-123", []model.FitnessDataPoint{
+		{DataType: "steps", Unit: "count", Value: 1000},
+		{DataType: "heart_rate", Unit: "count", Value: 70},
+	})
+	assert.ErrorIs(t, err, ErrInvalidFitnessDataPoint)
+	assert.Contains(t, err.Error(), "data point 1")
+	assert.Contains(t, err.Error(), "heart_rate must be in bpm")
+
+	_, err = service.SyncFitnessData(context.Background(), "user-123", []model.FitnessDataPoint{
+		{DataType: "blood_oxygen", Unit: "percent", Value: 98},
+	})
+	assert.ErrorIs(t, err, ErrInvalidFitnessDataPoint)
+
+	_, err = service.SyncFitnessData(context.Background(), "user-123", []model.FitnessDataPoint{
+		{DataType: "weight", Unit: "minutes", Value: 72},
+	})
+	assert.ErrorIs(t, err, ErrInvalidFitnessDataPoint, "weight is only accepted in kg")
+}
+
 func TestGetFitnessHistory_InvalidDateRange(t *testing.T) {
 	service := &HealthDataService{}
 
@@ -0,0 +1,439 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/delivery"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// Events delivered through organization integrations
+const (
+	EventCheckInCompleted = "checkin.completed"
+	EventIntegrationTest  = "integration.test"
+)
+
+const (
+	// defaultDeliveryTimeout bounds a single delivery attempt
+	defaultDeliveryTimeout = 10 * time.Second
+
+	// maxDeliveriesListed caps the delivery attempts returned for an integration
+	maxDeliveriesListed = 100
+)
+
+// Default templates used when an integration is created without its own. Webhooks
+// receive the summary as JSON; other kinds get a plain text summary.
+const (
+	defaultSubjectTemplate = `Daily check-in {{date .CheckInDate}}`
+	defaultBodyTemplate    = `Check-in of patient {{.UserID}} on {{date .CheckInDate}}
+Mood: {{.Mood}}
+Pain level: {{with .PainLevel}}{{.}}/10{{else}}-{{end}}
+Energy: {{.EnergyLevel}}
+Sleep: {{.SleepQuality}}
+Symptoms: {{if .Symptoms}}{{join .Symptoms ", "}}{{else}}none reported{{end}}
+Medication taken: {{.MedicationTaken}}
+{{with .AdditionalNotes}}Notes: {{.}}
+{{end}}`
+	defaultWebhookBodyTemplate = `{{json .}}`
+)
+
+var (
+	// ErrIntegrationNotFound is returned when an integration does not exist in the organization
+	ErrIntegrationNotFound = errors.New("integration not found")
+
+	// ErrInvalidIntegration is returned for an unknown kind, incomplete settings or a
+	// template that does not parse or render
+	ErrInvalidIntegration = errors.New("invalid integration")
+
+	// ErrNotOrganizationMember is returned when consenting to share with an organization
+	// the user does not belong to
+	ErrNotOrganizationMember = errors.New("user is not a member of the organization")
+
+	// ErrSharingConsentNotFound is returned when revoking consent that was never given
+	ErrSharingConsentNotFound = errors.New("sharing consent not found")
+)
+
+// IntegrationStore defines the persistence operations needed for delivery integrations
+type IntegrationStore interface {
+	CreateIntegration(ctx context.Context, integration *model.OrganizationIntegration) error
+	GetIntegration(ctx context.Context, orgID, integrationID string) (*model.OrganizationIntegration, error)
+	ListIntegrations(ctx context.Context, orgID string) ([]model.OrganizationIntegration, error)
+	FindCheckInIntegrations(ctx context.Context, userID string) ([]model.OrganizationIntegration, error)
+	RecordDelivery(ctx context.Context, delivery *model.IntegrationDelivery) error
+	ListDeliveries(ctx context.Context, integrationID string, limit int) ([]model.IntegrationDelivery, error)
+	GrantSharingConsent(ctx context.Context, orgID, userID string) error
+	RevokeSharingConsent(ctx context.Context, orgID, userID string) (bool, error)
+}
+
+// MembershipSource looks up the organizations a user belongs to
+type MembershipSource interface {
+	GetRolesByUserID(ctx context.Context, userID string) ([]model.RoleAssignment, error)
+}
+
+// IntegrationInput is the configuration of a new integration. Empty templates are
+// replaced with the defaults for the kind.
+type IntegrationInput struct {
+	Name            string            `json:"name" binding:"required"`
+	Kind            string            `json:"kind" binding:"required"`
+	Settings        map[string]string `json:"settings"`
+	SubjectTemplate string            `json:"subject_template"`
+	BodyTemplate    string            `json:"body_template"`
+	Enabled         *bool             `json:"enabled"`
+}
+
+// CheckInSummary is the data integration templates render for a completed check-in
+type CheckInSummary struct {
+	OrganizationID   string    `json:"organization_id"`
+	UserID           string    `json:"user_id"`
+	CheckInID        string    `json:"check_in_id"`
+	CheckInDate      time.Time `json:"check_in_date"`
+	Symptoms         []string  `json:"symptoms"`
+	Mood             string    `json:"mood"`
+	PainLevel        *int      `json:"pain_level"`
+	EnergyLevel      string    `json:"energy_level"`
+	SleepQuality     string    `json:"sleep_quality"`
+	MedicationTaken  string    `json:"medication_taken"`
+	PhysicalActivity []string  `json:"physical_activity"`
+	GeneralFeeling   string    `json:"general_feeling"`
+	AdditionalNotes  string    `json:"additional_notes"`
+	LowConfidence    bool      `json:"low_confidence"`
+	ExtractionIssues []string  `json:"extraction_issues"`
+}
+
+// IntegrationService manages organization delivery integrations and delivers completed
+// check-ins to the care teams patients have agreed to share them with
+type IntegrationService struct {
+	store       IntegrationStore
+	members     MembershipSource
+	registry    *delivery.Registry
+	logger      *zap.Logger
+	auditLogger *audit.Logger
+	timeout     time.Duration
+	deliveries  sync.WaitGroup
+	now         func() time.Time
+}
+
+// NewIntegrationService creates a new IntegrationService
+func NewIntegrationService(store IntegrationStore, members MembershipSource, registry *delivery.Registry, logger *zap.Logger) *IntegrationService {
+	return &IntegrationService{
+		store:    store,
+		members:  members,
+		registry: registry,
+		logger:   logger,
+		timeout:  defaultDeliveryTimeout,
+		now:      time.Now,
+	}
+}
+
+// SetAuditLogger enables audit logging of integration and consent changes
+func (s *IntegrationService) SetAuditLogger(auditLogger *audit.Logger) {
+	s.auditLogger = auditLogger
+}
+
+// SetDeliveryTimeout bounds a single delivery attempt
+func (s *IntegrationService) SetDeliveryTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		s.timeout = timeout
+	}
+}
+
+// CreateIntegration validates and saves an integration of an organization
+func (s *IntegrationService) CreateIntegration(ctx context.Context, orgID string, input IntegrationInput, createdBy string) (*model.OrganizationIntegration, error) {
+	integration := &model.OrganizationIntegration{
+		ID:              uuid.New().String(),
+		OrganizationID:  orgID,
+		Name:            strings.TrimSpace(input.Name),
+		Kind:            input.Kind,
+		Settings:        input.Settings,
+		SubjectTemplate: input.SubjectTemplate,
+		BodyTemplate:    input.BodyTemplate,
+		Enabled:         input.Enabled == nil || *input.Enabled,
+		CreatedBy:       createdBy,
+	}
+	if integration.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidIntegration)
+	}
+	if integration.Settings == nil {
+		integration.Settings = map[string]string{}
+	}
+	if integration.SubjectTemplate == "" {
+		integration.SubjectTemplate = defaultSubjectTemplate
+	}
+	if integration.BodyTemplate == "" {
+		integration.BodyTemplate = defaultBodyTemplate
+		if integration.Kind == delivery.KindWebhook {
+			integration.BodyTemplate = defaultWebhookBodyTemplate
+		}
+	}
+
+	if err := s.validate(integration); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.CreateIntegration(ctx, integration); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, createdBy, audit.OperationCreate, audit.ResourceIntegration, integration.ID, map[string]interface{}{
+		"organization_id": orgID,
+		"kind":            integration.Kind,
+	})
+
+	s.logger.Info("integration created",
+		zap.String("organization_id", orgID),
+		zap.String("integration_id", integration.ID),
+		zap.String("kind", integration.Kind),
+	)
+	return integration, nil
+}
+
+// validate checks that the integration can be built and its templates render a sample summary
+func (s *IntegrationService) validate(integration *model.OrganizationIntegration) error {
+	if _, err := s.registry.Build(integration.Kind, integration.Settings); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidIntegration, err)
+	}
+
+	tmpl, err := delivery.ParseTemplate(integration.SubjectTemplate, integration.BodyTemplate)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidIntegration, err)
+	}
+	if _, err := tmpl.Render(sampleCheckInSummary(integration.OrganizationID, s.now())); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidIntegration, err)
+	}
+	return nil
+}
+
+// ListIntegrations returns the integrations of an organization
+func (s *IntegrationService) ListIntegrations(ctx context.Context, orgID string) ([]model.OrganizationIntegration, error) {
+	return s.store.ListIntegrations(ctx, orgID)
+}
+
+// ListDeliveries returns the most recent delivery attempts of an integration
+func (s *IntegrationService) ListDeliveries(ctx context.Context, orgID, integrationID string) ([]model.IntegrationDelivery, error) {
+	if _, err := s.getIntegration(ctx, orgID, integrationID); err != nil {
+		return nil, err
+	}
+	return s.store.ListDeliveries(ctx, integrationID, maxDeliveriesListed)
+}
+
+// TestDelivery sends a sample check-in summary through an integration, whether or not
+// it is enabled, so admins can verify its configuration. The attempt is recorded like
+// any other delivery.
+func (s *IntegrationService) TestDelivery(ctx context.Context, orgID, integrationID, requestedBy string) (*model.IntegrationDelivery, error) {
+	integration, err := s.getIntegration(ctx, orgID, integrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.deliver(ctx, integration, EventIntegrationTest, sampleCheckInSummary(orgID, s.now()), requestedBy, nil)
+
+	s.logger.Info("integration test delivery",
+		zap.String("integration_id", integrationID),
+		zap.String("status", string(result.Status)),
+	)
+	return result, nil
+}
+
+// GrantSharingConsent records that a user agrees to have completed check-ins delivered
+// to an organization's care team
+func (s *IntegrationService) GrantSharingConsent(ctx context.Context, orgID, userID string) error {
+	roles, err := s.members.GetRolesByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	member := false
+	for _, role := range roles {
+		if role.OrganizationID == orgID {
+			member = true
+			break
+		}
+	}
+	if !member {
+		return ErrNotOrganizationMember
+	}
+
+	if err := s.store.GrantSharingConsent(ctx, orgID, userID); err != nil {
+		return err
+	}
+
+	s.audit(ctx, userID, audit.OperationCreate, audit.ResourceCareTeamConsent, orgID, nil)
+	return nil
+}
+
+// RevokeSharingConsent stops deliveries of a user's check-ins to an organization
+func (s *IntegrationService) RevokeSharingConsent(ctx context.Context, orgID, userID string) error {
+	revoked, err := s.store.RevokeSharingConsent(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrSharingConsentNotFound
+	}
+
+	s.audit(ctx, userID, audit.OperationDelete, audit.ResourceCareTeamConsent, orgID, nil)
+	return nil
+}
+
+// CheckInCompleted delivers a completed check-in in the background to every enabled
+// integration of the organizations the user has consented to share with
+func (s *IntegrationService) CheckInCompleted(ctx context.Context, checkIn *model.HealthCheckIn) {
+	s.deliveries.Add(1)
+	go func() {
+		defer s.deliveries.Done()
+		s.deliverCheckIn(context.WithoutCancel(ctx), checkIn)
+	}()
+}
+
+// WaitForDeliveries blocks until background check-in deliveries have finished
+func (s *IntegrationService) WaitForDeliveries() {
+	s.deliveries.Wait()
+}
+
+// deliverCheckIn delivers a completed check-in to the integrations the user shares with
+func (s *IntegrationService) deliverCheckIn(ctx context.Context, checkIn *model.HealthCheckIn) {
+	integrations, err := s.store.FindCheckInIntegrations(ctx, checkIn.UserID)
+	if err != nil {
+		s.logger.Error("failed to find check-in integrations",
+			zap.Error(err),
+			zap.String("check_in_id", checkIn.ID),
+		)
+		return
+	}
+
+	for i := range integrations {
+		integration := &integrations[i]
+		summary := newCheckInSummary(integration.OrganizationID, checkIn)
+		s.deliver(ctx, integration, EventCheckInCompleted, summary, checkIn.UserID, &checkIn.ID)
+	}
+}
+
+// deliver renders and sends one event through an integration and records the attempt
+func (s *IntegrationService) deliver(ctx context.Context, integration *model.OrganizationIntegration, event string, data CheckInSummary, userID string, checkInID *string) *model.IntegrationDelivery {
+	result := &model.IntegrationDelivery{
+		ID:            uuid.New().String(),
+		IntegrationID: integration.ID,
+		Event:         event,
+		UserID:        userID,
+		CheckInID:     checkInID,
+		Status:        model.DeliveryStatusDelivered,
+		AttemptedAt:   s.now(),
+	}
+
+	if err := s.send(ctx, integration, data); err != nil {
+		s.logger.Warn("integration delivery failed",
+			zap.Error(err),
+			zap.String("integration_id", integration.ID),
+			zap.String("event", event),
+		)
+		result.Status = model.DeliveryStatusFailed
+		result.ErrorMessage = err.Error()
+	}
+
+	if err := s.store.RecordDelivery(ctx, result); err != nil {
+		s.logger.Error("failed to record integration delivery",
+			zap.Error(err),
+			zap.String("integration_id", integration.ID),
+		)
+	}
+	return result
+}
+
+// send renders data with the integration's templates and sends it within the delivery timeout
+func (s *IntegrationService) send(ctx context.Context, integration *model.OrganizationIntegration, data CheckInSummary) error {
+	sender, err := s.registry.Build(integration.Kind, integration.Settings)
+	if err != nil {
+		return err
+	}
+	tmpl, err := delivery.ParseTemplate(integration.SubjectTemplate, integration.BodyTemplate)
+	if err != nil {
+		return err
+	}
+	msg, err := tmpl.Render(data)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	return sender.Send(ctx, msg)
+}
+
+// getIntegration returns an integration of the organization or ErrIntegrationNotFound
+func (s *IntegrationService) getIntegration(ctx context.Context, orgID, integrationID string) (*model.OrganizationIntegration, error) {
+	integration, err := s.store.GetIntegration(ctx, orgID, integrationID)
+	if err != nil {
+		return nil, err
+	}
+	if integration == nil {
+		return nil, ErrIntegrationNotFound
+	}
+	return integration, nil
+}
+
+// audit records an integration or consent change in the audit log
+func (s *IntegrationService) audit(ctx context.Context, actorID string, op audit.OperationType, resource audit.ResourceType, resourceID string, additional map[string]interface{}) {
+	if s.auditLogger == nil || actorID == "" {
+		return
+	}
+
+	err := s.auditLogger.Log(ctx, audit.AuditLog{
+		UserID:         actorID,
+		OperationType:  op,
+		ResourceType:   resource,
+		ResourceID:     resourceID,
+		AdditionalData: additional,
+	})
+	if err != nil {
+		s.logger.Error("failed to audit integration change", zap.Error(err), zap.String("resource_id", resourceID))
+	}
+}
+
+// newCheckInSummary converts a check-in to the data rendered by integration templates
+func newCheckInSummary(orgID string, checkIn *model.HealthCheckIn) CheckInSummary {
+	return CheckInSummary{
+		OrganizationID:   orgID,
+		UserID:           checkIn.UserID,
+		CheckInID:        checkIn.ID,
+		CheckInDate:      checkIn.CheckInDate,
+		Symptoms:         checkIn.Symptoms,
+		Mood:             stringValue(checkIn.Mood),
+		PainLevel:        checkIn.PainLevel,
+		EnergyLevel:      stringValue(checkIn.EnergyLevel),
+		SleepQuality:     stringValue(checkIn.SleepQuality),
+		MedicationTaken:  stringValue(checkIn.MedicationTaken),
+		PhysicalActivity: checkIn.PhysicalActivity,
+		GeneralFeeling:   stringValue(checkIn.GeneralFeeling),
+		AdditionalNotes:  stringValue(checkIn.AdditionalNotes),
+		LowConfidence:    checkIn.LowConfidence,
+		ExtractionIssues: checkIn.ExtractionIssues,
+	}
+}
+
+// sampleCheckInSummary is the made-up check-in sent by test deliveries and used to
+// validate templates
+func sampleCheckInSummary(orgID string, now time.Time) CheckInSummary {
+	pain := 3
+	return CheckInSummary{
+		OrganizationID:   orgID,
+		UserID:           "00000000-0000-0000-0000-000000000000",
+		CheckInID:        "00000000-0000-0000-0000-000000000000",
+		CheckInDate:      now,
+		Symptoms:         []string{"headache"},
+		Mood:             "good",
+		PainLevel:        &pain,
+		EnergyLevel:      "medium",
+		SleepQuality:     "good",
+		MedicationTaken:  "yes",
+		PhysicalActivity: []string{"walking"},
+		GeneralFeeling:   "This is a test delivery",
+		ExtractionIssues: []string{},
+	}
+}
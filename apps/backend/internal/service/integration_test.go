@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/delivery"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// fakeIntegrationStore is an in-memory IntegrationStore
+type fakeIntegrationStore struct {
+	integrations []model.OrganizationIntegration
+	deliveries   []model.IntegrationDelivery
+	consents     map[string]bool // organization ID + "/" + user ID
+}
+
+func newFakeIntegrationStore() *fakeIntegrationStore {
+	return &fakeIntegrationStore{consents: make(map[string]bool)}
+}
+
+func (f *fakeIntegrationStore) CreateIntegration(ctx context.Context, integration *model.OrganizationIntegration) error {
+	f.integrations = append(f.integrations, *integration)
+	return nil
+}
+
+func (f *fakeIntegrationStore) GetIntegration(ctx context.Context, orgID, integrationID string) (*model.OrganizationIntegration, error) {
+	for i := range f.integrations {
+		if f.integrations[i].ID == integrationID && f.integrations[i].OrganizationID == orgID {
+			return &f.integrations[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeIntegrationStore) ListIntegrations(ctx context.Context, orgID string) ([]model.OrganizationIntegration, error) {
+	var result []model.OrganizationIntegration
+	for _, integration := range f.integrations {
+		if integration.OrganizationID == orgID {
+			result = append(result, integration)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeIntegrationStore) FindCheckInIntegrations(ctx context.Context, userID string) ([]model.OrganizationIntegration, error) {
+	var result []model.OrganizationIntegration
+	for _, integration := range f.integrations {
+		if integration.Enabled && f.consents[integration.OrganizationID+"/"+userID] {
+			result = append(result, integration)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeIntegrationStore) RecordDelivery(ctx context.Context, delivery *model.IntegrationDelivery) error {
+	f.deliveries = append(f.deliveries, *delivery)
+	return nil
+}
+
+func (f *fakeIntegrationStore) ListDeliveries(ctx context.Context, integrationID string, limit int) ([]model.IntegrationDelivery, error) {
+	var result []model.IntegrationDelivery
+	for _, d := range f.deliveries {
+		if d.IntegrationID == integrationID {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeIntegrationStore) GrantSharingConsent(ctx context.Context, orgID, userID string) error {
+	f.consents[orgID+"/"+userID] = true
+	return nil
+}
+
+func (f *fakeIntegrationStore) RevokeSharingConsent(ctx context.Context, orgID, userID string) (bool, error) {
+	key := orgID + "/" + userID
+	had := f.consents[key]
+	delete(f.consents, key)
+	return had, nil
+}
+
+// recordingSender collects sent messages and fails when err is set
+type recordingSender struct {
+	sent []delivery.Message
+	err  error
+}
+
+func (r *recordingSender) Send(ctx context.Context, msg delivery.Message) error {
+	r.sent = append(r.sent, msg)
+	return r.err
+}
+
+func newTestIntegrationService(t *testing.T) (*IntegrationService, *fakeIntegrationStore, *fakeOrganizationStore, *recordingSender) {
+	t.Helper()
+	store := newFakeIntegrationStore()
+	members := newFakeOrganizationStore()
+	sender := &recordingSender{}
+
+	registry := delivery.NewRegistry()
+	registry.Register(delivery.KindConsole, func(settings map[string]string) (delivery.Sender, error) {
+		return sender, nil
+	})
+	registry.Register(delivery.KindWebhook, delivery.NewWebhookFactory(nil, false))
+
+	return NewIntegrationService(store, members, registry, zap.NewNop()), store, members, sender
+}
+
+func TestIntegrationService_CreateIntegrationValidates(t *testing.T) {
+	svc, _, _, _ := newTestIntegrationService(t)
+	ctx := context.Background()
+
+	_, err := svc.CreateIntegration(ctx, "org-1", IntegrationInput{Name: "Chat", Kind: "pager"}, "admin")
+	assert.ErrorIs(t, err, ErrInvalidIntegration, "unknown kind")
+
+	_, err = svc.CreateIntegration(ctx, "org-1", IntegrationInput{Name: "Chat", Kind: delivery.KindWebhook}, "admin")
+	assert.ErrorIs(t, err, ErrInvalidIntegration, "webhook without url")
+
+	_, err = svc.CreateIntegration(ctx, "org-1", IntegrationInput{Name: "Log", Kind: delivery.KindConsole, BodyTemplate: "{{.NoSuchField}}"}, "admin")
+	assert.ErrorIs(t, err, ErrInvalidIntegration, "template referencing a missing field")
+
+	webhook, err := svc.CreateIntegration(ctx, "org-1", IntegrationInput{
+		Name:     "Chat",
+		Kind:     delivery.KindWebhook,
+		Settings: map[string]string{"url": "https://chat.example.com/hook"},
+	}, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, defaultWebhookBodyTemplate, webhook.BodyTemplate)
+	assert.True(t, webhook.Enabled)
+}
+
+func TestIntegrationService_DeliversOnlyWithConsent(t *testing.T) {
+	svc, store, members, sender := newTestIntegrationService(t)
+	ctx := context.Background()
+
+	integration, err := svc.CreateIntegration(ctx, "org-1", IntegrationInput{Name: "Log", Kind: delivery.KindConsole}, "admin")
+	require.NoError(t, err)
+
+	mood := "tired"
+	checkIn := &model.HealthCheckIn{ID: "checkin-1", UserID: "patient-1", Mood: &mood, Symptoms: []string{"headache"}}
+
+	svc.deliverCheckIn(ctx, checkIn)
+	assert.Empty(t, sender.sent, "no delivery without consent")
+
+	assert.ErrorIs(t, svc.GrantSharingConsent(ctx, "org-1", "patient-1"), ErrNotOrganizationMember)
+
+	members.roles = append(members.roles, model.RoleAssignment{OrganizationID: "org-1", UserID: "patient-1", Role: model.RolePatient})
+	require.NoError(t, svc.GrantSharingConsent(ctx, "org-1", "patient-1"))
+
+	svc.deliverCheckIn(ctx, checkIn)
+	require.Len(t, sender.sent, 1)
+	assert.Contains(t, sender.sent[0].Body, "Mood: tired")
+	assert.Contains(t, sender.sent[0].Body, "Symptoms: headache")
+
+	require.Len(t, store.deliveries, 1)
+	assert.Equal(t, integration.ID, store.deliveries[0].IntegrationID)
+	assert.Equal(t, EventCheckInCompleted, store.deliveries[0].Event)
+	assert.Equal(t, model.DeliveryStatusDelivered, store.deliveries[0].Status)
+
+	require.NoError(t, svc.RevokeSharingConsent(ctx, "org-1", "patient-1"))
+	svc.deliverCheckIn(ctx, checkIn)
+	assert.Len(t, sender.sent, 1, "revoking consent stops deliveries")
+	assert.ErrorIs(t, svc.RevokeSharingConsent(ctx, "org-1", "patient-1"), ErrSharingConsentNotFound)
+}
+
+func TestIntegrationService_TestDeliveryRecordsFailure(t *testing.T) {
+	svc, store, _, sender := newTestIntegrationService(t)
+	ctx := context.Background()
+
+	integration, err := svc.CreateIntegration(ctx, "org-1", IntegrationInput{Name: "Log", Kind: delivery.KindConsole}, "admin")
+	require.NoError(t, err)
+
+	_, err = svc.TestDelivery(ctx, "org-2", integration.ID, "admin")
+	assert.ErrorIs(t, err, ErrIntegrationNotFound, "integrations of other organizations are not visible")
+
+	sender.err = errors.New("connection refused")
+	result, err := svc.TestDelivery(ctx, "org-1", integration.ID, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, model.DeliveryStatusFailed, result.Status)
+	assert.Equal(t, "connection refused", result.ErrorMessage)
+	assert.Equal(t, EventIntegrationTest, result.Event)
+
+	deliveries, err := svc.ListDeliveries(ctx, "org-1", integration.ID)
+	require.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+	assert.Equal(t, store.deliveries, deliveries)
+}
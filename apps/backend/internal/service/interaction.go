@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// interactionRulesTTL controls how long loaded interaction rules are reused
+const interactionRulesTTL = 5 * time.Minute
+
+// interactionTableJSON is the bundled interaction table: ingredient pairs with their
+// severity, and brand names mapped to their generic ingredient
+//
+//go:embed interactions.json
+var interactionTableJSON []byte
+
+// interactionTable is the decoded bundled interaction table
+type interactionTable struct {
+	Synonyms     map[string]string             `json:"synonyms"`
+	Interactions []model.MedicationInteraction `json:"interactions"`
+}
+
+// InteractionRuleSource defines the interface for loading interaction rules
+type InteractionRuleSource interface {
+	GetInteractions(ctx context.Context) ([]model.MedicationInteraction, error)
+}
+
+// InteractionFallback judges whether two medications interact when the interaction
+// table does not know one of them. It returns nil when they do not interact.
+type InteractionFallback interface {
+	CheckInteraction(ctx context.Context, medication, existing string) (*model.InteractionWarning, error)
+}
+
+// InteractionWarning describes an interaction between a new and an existing medication
+type InteractionWarning = model.InteractionWarning
+
+// InteractionChecker matches medications against the bundled interaction table and the
+// configured interaction rule set. Brand names are resolved to their generic ingredient.
+type InteractionChecker struct {
+	source   InteractionRuleSource
+	fallback InteractionFallback
+	logger   *zap.Logger
+
+	table    []model.MedicationInteraction
+	synonyms []drugSynonym // longest brand name first
+	known    map[string]bool
+
+	mu       sync.Mutex
+	rules    []model.MedicationInteraction
+	loadedAt time.Time
+}
+
+// drugSynonym maps a normalized brand name to its normalized generic ingredient
+type drugSynonym struct {
+	brand   string
+	generic string
+}
+
+// NewInteractionChecker creates a new InteractionChecker. source may be nil to use the
+// bundled table only.
+func NewInteractionChecker(source InteractionRuleSource, logger *zap.Logger) *InteractionChecker {
+	var table interactionTable
+	if err := json.Unmarshal(interactionTableJSON, &table); err != nil {
+		panic(fmt.Sprintf("invalid bundled interaction table: %v", err))
+	}
+
+	c := &InteractionChecker{
+		source: source,
+		logger: logger,
+		table:  table.Interactions,
+		known:  make(map[string]bool),
+	}
+	for brand, generic := range table.Synonyms {
+		c.synonyms = append(c.synonyms, drugSynonym{brand: normalizeDrugName(brand), generic: normalizeDrugName(generic)})
+	}
+	sort.Slice(c.synonyms, func(i, j int) bool {
+		if len(c.synonyms[i].brand) != len(c.synonyms[j].brand) {
+			return len(c.synonyms[i].brand) > len(c.synonyms[j].brand)
+		}
+		return c.synonyms[i].brand < c.synonyms[j].brand
+	})
+	for _, rule := range c.table {
+		c.known[normalizeDrugName(rule.DrugA)] = true
+		c.known[normalizeDrugName(rule.DrugB)] = true
+	}
+
+	return c
+}
+
+// SetFallback asks fallback about medications missing from the interaction table
+func (c *InteractionChecker) SetFallback(fallback InteractionFallback) {
+	c.fallback = fallback
+}
+
+// Check returns warnings for interactions between newMedName and the existing medications.
+// A pair matched by several rules is reported once. Fallback failures are logged and skipped.
+func (c *InteractionChecker) Check(ctx context.Context, newMedName string, existing []model.Medication) ([]InteractionWarning, error) {
+	rules, err := c.loadRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newName := c.canonicalDrugName(newMedName)
+	var warnings []InteractionWarning
+	for _, med := range existing {
+		existingName := c.canonicalDrugName(med.Name)
+		reported := make(map[string]bool)
+		for _, rule := range rules {
+			drugA := normalizeDrugName(rule.DrugA)
+			drugB := normalizeDrugName(rule.DrugB)
+
+			if (matchesDrug(newName, drugA) && matchesDrug(existingName, drugB)) ||
+				(matchesDrug(newName, drugB) && matchesDrug(existingName, drugA)) {
+				pair := drugPairKey(drugA, drugB)
+				if reported[pair] {
+					continue
+				}
+				reported[pair] = true
+				warnings = append(warnings, InteractionWarning{
+					Medication:         newMedName,
+					ExistingMedication: med.Name,
+					Severity:           rule.Severity,
+					Description:        rule.Description,
+					Source:             model.InteractionSourceTable,
+				})
+			}
+		}
+
+		if len(reported) == 0 && c.fallback != nil && (!c.isKnown(newName) || !c.isKnown(existingName)) {
+			warning, err := c.fallback.CheckInteraction(ctx, newMedName, med.Name)
+			if err != nil {
+				c.logger.Warn("interaction fallback failed",
+					zap.Error(err),
+					zap.String("medication", newMedName),
+					zap.String("existing_medication", med.Name),
+				)
+				continue
+			}
+			if warning != nil {
+				warnings = append(warnings, *warning)
+			}
+		}
+	}
+
+	return warnings, nil
+}
+
+// loadRules returns the bundled table followed by the cached rule set of the source,
+// reloading the latter when stale
+func (c *InteractionChecker) loadRules(ctx context.Context) ([]model.MedicationInteraction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.source == nil {
+		return c.table, nil
+	}
+	if c.rules != nil && time.Since(c.loadedAt) < interactionRulesTTL {
+		return c.rules, nil
+	}
+
+	rules, err := c.source.GetInteractions(ctx)
+	if err != nil {
+		if c.rules != nil {
+			c.logger.Warn("failed to reload interaction rules, using cached rules", zap.Error(err))
+			return c.rules, nil
+		}
+		return nil, fmt.Errorf("failed to load interaction rules: %w", err)
+	}
+
+	c.rules = append(append(make([]model.MedicationInteraction, 0, len(c.table)+len(rules)), c.table...), rules...)
+	c.loadedAt = time.Now()
+
+	return c.rules, nil
+}
+
+// canonicalDrugName normalizes a medication name and replaces a leading brand name with
+// its generic ingredient, so "Coumadin 5mg" becomes "warfarin 5mg"
+func (c *InteractionChecker) canonicalDrugName(name string) string {
+	name = normalizeDrugName(name)
+	for _, synonym := range c.synonyms {
+		if matchesDrug(name, synonym.brand) {
+			return synonym.generic + strings.TrimPrefix(name, synonym.brand)
+		}
+	}
+	return name
+}
+
+// isKnown reports whether a canonical medication name refers to a drug of the table
+func (c *InteractionChecker) isKnown(name string) bool {
+	for drug := range c.known {
+		if matchesDrug(name, drug) {
+			return true
+		}
+	}
+	return false
+}
+
+// drugPairKey identifies an unordered pair of normalized drugs
+func drugPairKey(drugA, drugB string) string {
+	if drugA > drugB {
+		drugA, drugB = drugB, drugA
+	}
+	return drugA + "\x00" + drugB
+}
+
+// normalizeDrugName lowercases a drug name and collapses whitespace
+func normalizeDrugName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// matchesDrug reports whether a normalized medication name refers to a normalized drug,
+// allowing brand suffixes and strengths such as "aspirin protect 100mg"
+func matchesDrug(medName, drug string) bool {
+	if drug == "" {
+		return false
+	}
+	return medName == drug || strings.HasPrefix(medName, drug+" ")
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// maxCachedInteractionVerdicts bounds the per-instance cache of AI interaction verdicts
+const maxCachedInteractionVerdicts = 1000
+
+// interactionVerdict is the AI response for a medication pair
+type interactionVerdict struct {
+	Interacts   bool   `json:"interacts"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+}
+
+// OpenAIInteractionFallback asks Azure OpenAI about medication pairs missing from the
+// interaction table. Verdicts are cached per pair, since medication names repeat.
+type OpenAIInteractionFallback struct {
+	aiClient *azure.OpenAIClient
+	logger   *zap.Logger
+
+	mu    sync.Mutex
+	cache map[string]*interactionVerdict
+}
+
+// NewOpenAIInteractionFallback creates a new OpenAIInteractionFallback
+func NewOpenAIInteractionFallback(aiClient *azure.OpenAIClient, logger *zap.Logger) *OpenAIInteractionFallback {
+	return &OpenAIInteractionFallback{
+		aiClient: aiClient,
+		logger:   logger,
+		cache:    make(map[string]*interactionVerdict),
+	}
+}
+
+// CheckInteraction returns a warning when Azure OpenAI considers the medications to interact
+func (f *OpenAIInteractionFallback) CheckInteraction(ctx context.Context, medication, existing string) (*model.InteractionWarning, error) {
+	key := drugPairKey(normalizeDrugName(medication), normalizeDrugName(existing))
+
+	f.mu.Lock()
+	verdict, cached := f.cache[key]
+	f.mu.Unlock()
+
+	if !cached {
+		messages := []openai.ChatCompletionMessageParamUnion{
+			openai.SystemMessage(`You are a clinical pharmacist checking a patient's medication list for drug-drug interactions.
+
+Medication names may be brand names, generic names, or include strengths. Report only clinically relevant interactions documented in standard references; when unsure, report no interaction.
+
+Return valid JSON:
+{
+  "interacts": true or false,
+  "severity": "high" | "moderate" | "low",
+  "description": "one short English sentence describing the risk, or empty string"
+}
+
+Return ONLY valid JSON, no additional text`),
+			openai.UserMessage(fmt.Sprintf("Do %q and %q interact?", medication, existing)),
+		}
+
+		response, err := f.aiClient.Complete(ctx, messages)
+		if err != nil {
+			return nil, fmt.Errorf("interaction check failed: %w", err)
+		}
+
+		verdict, err = parseInteractionVerdict(response)
+		if err != nil {
+			f.logger.Warn("failed to parse interaction verdict",
+				zap.Error(err),
+				zap.String("response", response),
+			)
+			return nil, err
+		}
+
+		f.mu.Lock()
+		if len(f.cache) >= maxCachedInteractionVerdicts {
+			f.cache = make(map[string]*interactionVerdict)
+		}
+		f.cache[key] = verdict
+		f.mu.Unlock()
+	}
+
+	if !verdict.Interacts {
+		return nil, nil
+	}
+	return &model.InteractionWarning{
+		Medication:         medication,
+		ExistingMedication: existing,
+		Severity:           verdict.Severity,
+		Description:        verdict.Description,
+		Source:             model.InteractionSourceAI,
+	}, nil
+}
+
+// parseInteractionVerdict parses the AI response, defaulting unknown severities to moderate
+func parseInteractionVerdict(response string) (*interactionVerdict, error) {
+	response = strings.TrimSpace(response)
+	response = strings.TrimPrefix(response, "```json")
+	response = strings.TrimPrefix(response, "```")
+	response = strings.TrimSuffix(response, "```")
+	response = strings.TrimSpace(response)
+
+	var verdict interactionVerdict
+	if err := json.Unmarshal([]byte(response), &verdict); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	switch verdict.Severity {
+	case "high", "moderate", "low":
+	default:
+		verdict.Severity = "moderate"
+	}
+	verdict.Description = strings.TrimSpace(verdict.Description)
+	if verdict.Interacts && verdict.Description == "" {
+		verdict.Description = "Possible interaction reported by automated check"
+	}
+
+	return &verdict, nil
+}
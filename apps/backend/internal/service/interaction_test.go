@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// fakeInteractionSource returns a fixed rule set and counts loads
+type fakeInteractionSource struct {
+	rules []model.MedicationInteraction
+	err   error
+	loads int
+}
+
+func (f *fakeInteractionSource) GetInteractions(ctx context.Context) ([]model.MedicationInteraction, error) {
+	f.loads++
+	return f.rules, f.err
+}
+
+func testInteractionRules() []model.MedicationInteraction {
+	return []model.MedicationInteraction{
+		{DrugA: "aspirin", DrugB: "warfarin", Severity: "high", Description: "Increased risk of serious bleeding"},
+		{DrugA: "aspirin", DrugB: "ibuprofen", Severity: "moderate", Description: "Reduced cardioprotective effect"},
+	}
+}
+
+func TestInteractionChecker_AspirinWarfarin(t *testing.T) {
+	checker := NewInteractionChecker(&fakeInteractionSource{rules: testInteractionRules()}, zap.NewNop())
+
+	warnings, err := checker.Check(context.Background(), "Aspirin", []model.Medication{{Name: "Warfarin"}})
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "high", warnings[0].Severity)
+	assert.Equal(t, "Aspirin", warnings[0].Medication)
+	assert.Equal(t, "Warfarin", warnings[0].ExistingMedication)
+}
+
+func TestInteractionChecker_Matching(t *testing.T) {
+	tests := []struct {
+		name      string
+		newMed    string
+		existing  []string
+		wantCount int
+	}{
+		{name: "reverse order", newMed: "WARFARIN", existing: []string{"aspirin"}, wantCount: 1},
+		{name: "strength suffix and extra whitespace", newMed: "  Aspirin   100mg ", existing: []string{"Warfarin 5 mg"}, wantCount: 1},
+		{name: "multiple existing medications", newMed: "aspirin", existing: []string{"Warfarin", "Ibuprofen", "Metformin"}, wantCount: 2},
+		{name: "no interaction", newMed: "Metformin", existing: []string{"Warfarin"}, wantCount: 0},
+		{name: "substring is not a match", newMed: "Aspirinex", existing: []string{"Warfarin"}, wantCount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewInteractionChecker(&fakeInteractionSource{rules: testInteractionRules()}, zap.NewNop())
+
+			var existing []model.Medication
+			for _, name := range tt.existing {
+				existing = append(existing, model.Medication{Name: name})
+			}
+
+			warnings, err := checker.Check(context.Background(), tt.newMed, existing)
+			require.NoError(t, err)
+			assert.Len(t, warnings, tt.wantCount)
+		})
+	}
+}
+
+func TestInteractionChecker_CachesRules(t *testing.T) {
+	source := &fakeInteractionSource{rules: testInteractionRules()}
+	checker := NewInteractionChecker(source, zap.NewNop())
+
+	for i := 0; i < 3; i++ {
+		_, err := checker.Check(context.Background(), "aspirin", nil)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, source.loads)
+}
+
+func TestInteractionChecker_SourceError(t *testing.T) {
+	checker := NewInteractionChecker(&fakeInteractionSource{err: errors.New("db down")}, zap.NewNop())
+
+	_, err := checker.Check(context.Background(), "aspirin", []model.Medication{{Name: "warfarin"}})
+	assert.Error(t, err)
+}
+
+// fakeInteractionFallback returns a fixed verdict and records the pairs it was asked about
+type fakeInteractionFallback struct {
+	warning *model.InteractionWarning
+	err     error
+	asked   [][2]string
+}
+
+func (f *fakeInteractionFallback) CheckInteraction(ctx context.Context, medication, existing string) (*model.InteractionWarning, error) {
+	f.asked = append(f.asked, [2]string{medication, existing})
+	return f.warning, f.err
+}
+
+func TestInteractionChecker_BundledTable(t *testing.T) {
+	checker := NewInteractionChecker(nil, zap.NewNop())
+
+	tests := []struct {
+		newMed       string
+		existing     string
+		wantSeverity string
+	}{
+		{newMed: "Aspirin", existing: "Warfarin", wantSeverity: "high"},
+		{newMed: "Sildenafil 50mg", existing: "Nitroglycerin", wantSeverity: "high"},
+		{newMed: "Tramadol", existing: "Sertraline 100 mg", wantSeverity: "high"},
+		{newMed: "Fluconazole", existing: "Warfarin", wantSeverity: "high"},
+		{newMed: "Digoxin", existing: "Amiodarone", wantSeverity: "high"},
+		{newMed: "Methotrexate", existing: "Trimethoprim", wantSeverity: "high"},
+		{newMed: "Spironolactone", existing: "Lisinopril", wantSeverity: "moderate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.newMed+"+"+tt.existing, func(t *testing.T) {
+			warnings, err := checker.Check(context.Background(), tt.newMed, []model.Medication{{Name: tt.existing}})
+			require.NoError(t, err)
+			require.Len(t, warnings, 1)
+			assert.Equal(t, tt.wantSeverity, warnings[0].Severity)
+			assert.Equal(t, model.InteractionSourceTable, warnings[0].Source)
+			assert.NotEmpty(t, warnings[0].Description)
+		})
+	}
+}
+
+func TestInteractionChecker_BrandSynonyms(t *testing.T) {
+	checker := NewInteractionChecker(nil, zap.NewNop())
+
+	warnings, err := checker.Check(context.Background(), "ADVIL 200mg", []model.Medication{{Name: "Coumadin 5 mg"}, {Name: "Zoloft"}})
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "ADVIL 200mg", warnings[0].Medication)
+	assert.Equal(t, "Coumadin 5 mg", warnings[0].ExistingMedication)
+	assert.Equal(t, "high", warnings[0].Severity)
+
+	warnings, err = checker.Check(context.Background(), "Viagra", []model.Medication{{Name: "glyceryl trinitrate spray"}})
+	require.NoError(t, err)
+	assert.Len(t, warnings, 1, "multi-word brand and generic names resolve")
+}
+
+func TestInteractionChecker_ReportsPairOnce(t *testing.T) {
+	// The database rule set repeats a pair of the bundled table
+	checker := NewInteractionChecker(&fakeInteractionSource{rules: testInteractionRules()}, zap.NewNop())
+
+	warnings, err := checker.Check(context.Background(), "Warfarin", []model.Medication{{Name: "Aspirin"}})
+	require.NoError(t, err)
+	assert.Len(t, warnings, 1)
+}
+
+func TestInteractionChecker_Fallback(t *testing.T) {
+	fallback := &fakeInteractionFallback{warning: &model.InteractionWarning{
+		Medication:         "Exoticin",
+		ExistingMedication: "Warfarin",
+		Severity:           "moderate",
+		Description:        "Possible bleeding risk",
+		Source:             model.InteractionSourceAI,
+	}}
+	checker := NewInteractionChecker(nil, zap.NewNop())
+	checker.SetFallback(fallback)
+
+	warnings, err := checker.Check(context.Background(), "Exoticin", []model.Medication{{Name: "Warfarin"}})
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, model.InteractionSourceAI, warnings[0].Source)
+
+	// Pairs of known drugs are decided by the table alone
+	fallback.asked = nil
+	warnings, err = checker.Check(context.Background(), "Aspirin", []model.Medication{{Name: "Warfarin"}, {Name: "Lisinopril"}})
+	require.NoError(t, err)
+	assert.Len(t, warnings, 1)
+	assert.Empty(t, fallback.asked)
+
+	// Fallback failures never fail the check
+	fallback.err = errors.New("openai down")
+	warnings, err = checker.Check(context.Background(), "Exoticin", []model.Medication{{Name: "Warfarin"}})
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+}
+
+func TestParseInteractionVerdict(t *testing.T) {
+	verdict, err := parseInteractionVerdict("```json\n{\"interacts\": true, \"severity\": \"severe\", \"description\": \" Bleeding risk \"}\n```")
+	require.NoError(t, err)
+	assert.True(t, verdict.Interacts)
+	assert.Equal(t, "moderate", verdict.Severity, "unknown severities default to moderate")
+	assert.Equal(t, "Bleeding risk", verdict.Description)
+
+	_, err = parseInteractionVerdict("not json")
+	assert.Error(t, err)
+}
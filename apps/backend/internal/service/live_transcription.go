@@ -0,0 +1,176 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+const (
+	// liveSampleRate, liveBytesPerSample and liveChannels describe the PCM audio
+	// expected on the live transcription socket (16 kHz, 16-bit, mono)
+	liveSampleRate     = 16000
+	liveBytesPerSample = 2
+	liveChannels       = 1
+
+	// DefaultLiveSegmentBytes is the amount of audio (about 3 seconds) sent to the
+	// speech service per transcription segment
+	DefaultLiveSegmentBytes = 3 * liveSampleRate * liveBytesPerSample * liveChannels
+
+	// MaxLiveAudioBytes bounds the audio accepted per live transcription (about 5 minutes)
+	MaxLiveAudioBytes = 300 * liveSampleRate * liveBytesPerSample * liveChannels
+
+	// wavHeaderSize is the size of a canonical RIFF/WAVE header
+	wavHeaderSize = 44
+)
+
+// Transcriber converts an audio stream to text
+type Transcriber interface {
+	StreamAudioToText(ctx context.Context, audioStream io.Reader) (string, error)
+}
+
+// LiveTranscription incrementally transcribes audio chunks received from a client.
+// Audio is buffered into fixed-size segments that are transcribed one at a time,
+// so partial text becomes available while the user is still speaking.
+// A LiveTranscription is not safe for concurrent use.
+type LiveTranscription struct {
+	transcriber  Transcriber
+	segmentBytes int
+
+	buf           []byte
+	headerChecked bool
+	totalBytes    int
+	parts         []string
+}
+
+// NewLiveTranscription creates a LiveTranscription sending segmentBytes of PCM audio per request
+func NewLiveTranscription(transcriber Transcriber, segmentBytes int) *LiveTranscription {
+	if segmentBytes <= 0 {
+		segmentBytes = DefaultLiveSegmentBytes
+	}
+	return &LiveTranscription{
+		transcriber:  transcriber,
+		segmentBytes: segmentBytes,
+	}
+}
+
+// Write adds an audio chunk and transcribes every complete segment. It returns
+// the text recognized in those segments, or an empty string if no segment was completed.
+// A WAV header at the start of the stream is skipped.
+func (t *LiveTranscription) Write(ctx context.Context, chunk []byte) (string, error) {
+	if t.totalBytes+len(chunk) > MaxLiveAudioBytes {
+		return "", fmt.Errorf("audio exceeds the maximum of %d bytes", MaxLiveAudioBytes)
+	}
+	t.totalBytes += len(chunk)
+	t.buf = append(t.buf, chunk...)
+
+	if !t.headerChecked {
+		if len(t.buf) < wavHeaderSize {
+			return "", nil
+		}
+		if bytes.HasPrefix(t.buf, []byte("RIFF")) {
+			t.buf = t.buf[wavHeaderSize:]
+		}
+		t.headerChecked = true
+	}
+
+	var recognized []string
+	for len(t.buf) >= t.segmentBytes {
+		text, err := t.transcribe(ctx, t.buf[:t.segmentBytes])
+		if err != nil {
+			return "", err
+		}
+		t.buf = t.buf[t.segmentBytes:]
+		if text != "" {
+			recognized = append(recognized, text)
+		}
+	}
+
+	return strings.Join(recognized, " "), nil
+}
+
+// Finish transcribes any buffered audio and returns the complete transcription
+func (t *LiveTranscription) Finish(ctx context.Context) (string, error) {
+	t.headerChecked = true
+	if len(t.buf) > 0 {
+		if _, err := t.transcribe(ctx, t.buf); err != nil {
+			return "", err
+		}
+		t.buf = nil
+	}
+	return t.Transcript(), nil
+}
+
+// Transcript returns the text recognized so far
+func (t *LiveTranscription) Transcript() string {
+	return strings.Join(t.parts, " ")
+}
+
+// transcribe sends one PCM segment to the speech service and records the result
+func (t *LiveTranscription) transcribe(ctx context.Context, pcm []byte) (string, error) {
+	text, err := t.transcriber.StreamAudioToText(ctx, bytes.NewReader(wavSegment(pcm)))
+	// Pauses and the short tail of a recording hold nothing to recognize
+	if errors.Is(err, azure.ErrAudioTooShort) || errors.Is(err, azure.ErrAudioSilent) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("segment transcription failed: %w", err)
+	}
+
+	text = strings.TrimSpace(text)
+	if text != "" {
+		t.parts = append(t.parts, text)
+	}
+	return text, nil
+}
+
+// wavSegment wraps raw PCM audio in a WAV header so each segment is a valid recording
+func wavSegment(pcm []byte) []byte {
+	var header bytes.Buffer
+	byteRate := liveSampleRate * liveChannels * liveBytesPerSample
+
+	header.WriteString("RIFF")
+	binary.Write(&header, binary.LittleEndian, uint32(36+len(pcm)))
+	header.WriteString("WAVE")
+	header.WriteString("fmt ")
+	binary.Write(&header, binary.LittleEndian, uint32(16))
+	binary.Write(&header, binary.LittleEndian, uint16(1)) // PCM
+	binary.Write(&header, binary.LittleEndian, uint16(liveChannels))
+	binary.Write(&header, binary.LittleEndian, uint32(liveSampleRate))
+	binary.Write(&header, binary.LittleEndian, uint32(byteRate))
+	binary.Write(&header, binary.LittleEndian, uint16(liveChannels*liveBytesPerSample))
+	binary.Write(&header, binary.LittleEndian, uint16(8*liveBytesPerSample))
+	header.WriteString("data")
+	binary.Write(&header, binary.LittleEndian, uint32(len(pcm)))
+
+	return append(header.Bytes(), pcm...)
+}
+
+// StartLiveTranscription validates that the session is active and returns the
+// session with a LiveTranscription forwarding audio to the speech service
+func (s *CheckInService) StartLiveTranscription(ctx context.Context, sessionID string) (*model.Session, *LiveTranscription, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CheckInService.StartLiveTranscription")
+	defer span.End()
+
+	session, err := s.repo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if session.Status != model.SessionStatusActive {
+		return nil, nil, fmt.Errorf("%w: %s", ErrSessionNotActive, session.Status)
+	}
+
+	s.logger.Info("starting live transcription", zap.String("session_id", sessionID))
+
+	return session, NewLiveTranscription(s.speechClient, DefaultLiveSegmentBytes), nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+)
+
+// fakeTranscriber records the segments it receives and returns a numbered text per segment
+type fakeTranscriber struct {
+	segments [][]byte
+}
+
+func (f *fakeTranscriber) StreamAudioToText(ctx context.Context, audioStream io.Reader) (string, error) {
+	data, err := io.ReadAll(audioStream)
+	if err != nil {
+		return "", err
+	}
+	f.segments = append(f.segments, data)
+	return fmt.Sprintf("part%d", len(f.segments)), nil
+}
+
+func TestLiveTranscription_TranscribesCompleteSegments(t *testing.T) {
+	transcriber := &fakeTranscriber{}
+	live := NewLiveTranscription(transcriber, 100)
+	ctx := context.Background()
+
+	text, err := live.Write(ctx, make([]byte, 60))
+	require.NoError(t, err)
+	assert.Empty(t, text)
+
+	text, err = live.Write(ctx, make([]byte, 150))
+	require.NoError(t, err)
+	assert.Equal(t, "part1 part2", text)
+
+	final, err := live.Finish(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "part1 part2 part3", final)
+
+	require.Len(t, transcriber.segments, 3)
+	for _, segment := range transcriber.segments {
+		assert.True(t, bytes.HasPrefix(segment, []byte("RIFF")), "segments are sent as WAV")
+	}
+	assert.Len(t, transcriber.segments[0], wavHeaderSize+100)
+	assert.Len(t, transcriber.segments[2], wavHeaderSize+10)
+}
+
+func TestLiveTranscription_SkipsClientWAVHeader(t *testing.T) {
+	transcriber := &fakeTranscriber{}
+	live := NewLiveTranscription(transcriber, 100)
+
+	audio := wavSegment(make([]byte, 100))
+	_, err := live.Write(context.Background(), audio)
+	require.NoError(t, err)
+
+	require.Len(t, transcriber.segments, 1)
+	assert.Equal(t, audio, transcriber.segments[0])
+}
+
+func TestLiveTranscription_RejectsOversizedAudio(t *testing.T) {
+	live := NewLiveTranscription(&fakeTranscriber{}, MaxLiveAudioBytes)
+
+	_, err := live.Write(context.Background(), make([]byte, MaxLiveAudioBytes+1))
+	assert.Error(t, err)
+}
+
+// rejectingTranscriber rejects every segment like the speech client rejects silence
+type rejectingTranscriber struct {
+	err error
+}
+
+func (r rejectingTranscriber) StreamAudioToText(ctx context.Context, audioStream io.Reader) (string, error) {
+	return "", r.err
+}
+
+func TestLiveTranscription_SkipsSilentAndShortSegments(t *testing.T) {
+	for _, rejection := range []error{azure.ErrAudioSilent, azure.ErrAudioTooShort} {
+		live := NewLiveTranscription(rejectingTranscriber{err: fmt.Errorf("%w: details", rejection)}, 100)
+
+		text, err := live.Write(context.Background(), make([]byte, 150))
+		require.NoError(t, err)
+		assert.Empty(t, text)
+
+		final, err := live.Finish(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, final)
+	}
+
+	live := NewLiveTranscription(rejectingTranscriber{err: azure.ErrInvalidAudioFormat}, 100)
+	_, err := live.Write(context.Background(), make([]byte, 150))
+	assert.ErrorIs(t, err, azure.ErrInvalidAudioFormat)
+}
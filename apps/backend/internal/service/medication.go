@@ -2,19 +2,34 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 	"go.uber.org/zap"
 )
 
+// ErrMedicationAccessDenied is returned when a user accesses another user's medication
+var ErrMedicationAccessDenied = errors.New("medication belongs to another user")
+
 // MedicationService handles medication management business logic
 type MedicationService struct {
-	repo   *repository.MedicationRepository
-	logger *zap.Logger
+	repo         *repository.MedicationRepository
+	interactions *InteractionChecker
+	events       EventDispatcher
+	locations    UserLocationSource
+	auditLogger  *audit.Logger
+	logger       *zap.Logger
+}
+
+// UserLocationSource provides the time zone a user's medication schedules are read in
+type UserLocationSource interface {
+	UserLocation(ctx context.Context, userID string) (*time.Location, error)
 }
 
 // NewMedicationService creates a new MedicationService
@@ -25,8 +40,40 @@ func NewMedicationService(repo *repository.MedicationRepository, logger *zap.Log
 	}
 }
 
-// AddMedication adds a new medication for a user
+// SetInteractionChecker enables interaction warnings for added and updated medications
+func (s *MedicationService) SetInteractionChecker(checker *InteractionChecker) {
+	s.interactions = checker
+}
+
+// SetEventDispatcher notifies external systems of added medications
+func (s *MedicationService) SetEventDispatcher(events EventDispatcher) {
+	s.events = events
+}
+
+// SetLocationSource reads schedules in each user's time zone instead of UTC
+func (s *MedicationService) SetLocationSource(locations UserLocationSource) {
+	s.locations = locations
+}
+
+// SetAuditLogger records deactivations and reactivations of medications
+func (s *MedicationService) SetAuditLogger(auditLogger *audit.Logger) {
+	s.auditLogger = auditLogger
+}
+
+// userLocation returns the time zone of a user's schedules, UTC without a location source
+func (s *MedicationService) userLocation(ctx context.Context, userID string) (*time.Location, error) {
+	if s.locations == nil {
+		return time.UTC, nil
+	}
+	return s.locations.UserLocation(ctx, userID)
+}
+
+// AddMedication adds a new medication for a user. Interactions with the user's other
+// active medications are stored in med.InteractionWarnings; they never block the addition.
 func (s *MedicationService) AddMedication(ctx context.Context, userID string, med *model.Medication) error {
+	ctx, span := telemetry.StartSpan(ctx, "MedicationService.AddMedication")
+	defer span.End()
+
 	if userID == "" {
 		return fmt.Errorf("user ID is required")
 	}
@@ -49,16 +96,15 @@ func (s *MedicationService) AddMedication(ctx context.Context, userID string, me
 	med.UserID = userID
 
 	// Set active status based on end date
-	med.Active = true
-	if med.EndDate != nil && med.EndDate.Before(time.Now()) {
-		med.Active = false
-	}
+	med.Active = !medicationEnded(med.EndDate, time.Now())
 
 	// Set timestamps
 	now := time.Now()
 	med.CreatedAt = now
 	med.UpdatedAt = now
 
+	med.InteractionWarnings = s.checkInteractions(ctx, med)
+
 	if err := s.repo.Create(ctx, med); err != nil {
 		s.logger.Error("failed to add medication",
 			zap.Error(err),
@@ -74,28 +120,87 @@ func (s *MedicationService) AddMedication(ctx context.Context, userID string, me
 		zap.String("name", med.Name),
 	)
 
+	if s.events != nil {
+		if err := s.events.Dispatch(ctx, userID, EventMedicationAdded, med); err != nil {
+			s.logger.Error("failed to dispatch medication event",
+				zap.Error(err),
+				zap.String("medication_id", med.ID),
+			)
+		}
+	}
+
 	return nil
 }
 
-// ListMedications retrieves all medications for a user
-func (s *MedicationService) ListMedications(ctx context.Context, userID string) ([]model.Medication, error) {
+// checkInteractions returns warnings for interactions between a medication and the
+// user's other active medications. Failures are logged and yield no warnings.
+func (s *MedicationService) checkInteractions(ctx context.Context, med *model.Medication) []InteractionWarning {
+	ctx, span := telemetry.StartSpan(ctx, "MedicationService.checkInteractions")
+	defer span.End()
+
+	if s.interactions == nil {
+		return nil
+	}
+
+	medications, err := s.repo.FindByUserID(ctx, med.UserID)
+	if err != nil {
+		s.logger.Warn("failed to get medications for interaction check",
+			zap.Error(err),
+			zap.String("user_id", med.UserID),
+		)
+		return nil
+	}
+
+	var active []model.Medication
+	for _, other := range medications {
+		if other.Active && other.ID != med.ID {
+			active = append(active, other)
+		}
+	}
+
+	warnings, err := s.interactions.Check(ctx, med.Name, active)
+	if err != nil {
+		s.logger.Warn("failed to check medication interactions",
+			zap.Error(err),
+			zap.String("user_id", med.UserID),
+		)
+		return nil
+	}
+
+	if len(warnings) > 0 {
+		s.logger.Info("medication interactions found",
+			zap.String("user_id", med.UserID),
+			zap.String("medication_name", med.Name),
+			zap.Int("warning_count", len(warnings)),
+		)
+	}
+
+	return warnings
+}
+
+// ListMedications retrieves a page of medications for a user and the total number of
+// medications. A non-nil active keeps only active or only inactive medications.
+func (s *MedicationService) ListMedications(ctx context.Context, userID string, active *bool, page repository.Page) ([]model.Medication, int, error) {
+	ctx, span := telemetry.StartSpan(ctx, "MedicationService.ListMedications")
+	defer span.End()
+
 	if userID == "" {
-		return nil, fmt.Errorf("user ID is required")
+		return nil, 0, fmt.Errorf("user ID is required")
 	}
 
-	medications, err := s.repo.FindByUserID(ctx, userID)
+	medications, total, err := s.repo.FindPageByUserID(ctx, userID, active, page)
 	if err != nil {
 		s.logger.Error("failed to list medications",
 			zap.Error(err),
 			zap.String("user_id", userID),
 		)
-		return nil, fmt.Errorf("failed to list medications: %w", err)
+		return nil, 0, fmt.Errorf("failed to list medications: %w", err)
 	}
 
 	// Update active status for medications with past end dates
 	now := time.Now()
 	for i := range medications {
-		if medications[i].EndDate != nil && medications[i].EndDate.Before(now) && medications[i].Active {
+		if medications[i].Active && medicationEnded(medications[i].EndDate, now) {
 			medications[i].Active = false
 			// Update in database
 			if err := s.repo.Update(ctx, &medications[i]); err != nil {
@@ -110,13 +215,19 @@ func (s *MedicationService) ListMedications(ctx context.Context, userID string)
 	s.logger.Info("medications listed successfully",
 		zap.String("user_id", userID),
 		zap.Int("count", len(medications)),
+		zap.Int("total_count", total),
 	)
 
-	return medications, nil
+	return medications, total, nil
 }
 
-// UpdateMedication updates an existing medication
+// UpdateMedication updates an existing medication and rechecks its interactions. Empty
+// fields of updates keep their stored values. The active flag is recomputed from the end
+// date only when the end date changes, so a deactivated medication stays inactive.
 func (s *MedicationService) UpdateMedication(ctx context.Context, medID string, updates *model.Medication) error {
+	ctx, span := telemetry.StartSpan(ctx, "MedicationService.UpdateMedication")
+	defer span.End()
+
 	if medID == "" {
 		return fmt.Errorf("medication ID is required")
 	}
@@ -131,20 +242,37 @@ func (s *MedicationService) UpdateMedication(ctx context.Context, medID string,
 		return fmt.Errorf("medication not found: %w", err)
 	}
 
-	// Preserve ID and user_id
+	// Preserve ID, user_id and the fields left out of the update
 	updates.ID = existing.ID
 	updates.UserID = existing.UserID
+	updates.StartDate = existing.StartDate
+	updates.CreatedAt = existing.CreatedAt
+	if updates.Name == "" {
+		updates.Name = existing.Name
+	}
+	if updates.Dosage == "" {
+		updates.Dosage = existing.Dosage
+	}
+	if updates.Frequency == "" {
+		updates.Frequency = existing.Frequency
+	}
+	if updates.Notes == nil {
+		updates.Notes = existing.Notes
+	}
 
-	// Update active status based on end date
-	if updates.EndDate != nil && updates.EndDate.Before(time.Now()) {
-		updates.Active = false
-	} else {
-		updates.Active = true
+	// Recompute the active status only when the end date changes
+	updates.Active = existing.Active
+	if updates.EndDate == nil {
+		updates.EndDate = existing.EndDate
+	} else if existing.EndDate == nil || !updates.EndDate.Equal(*existing.EndDate) {
+		updates.Active = !medicationEnded(updates.EndDate, time.Now())
 	}
 
 	// Update timestamp
 	updates.UpdatedAt = time.Now()
 
+	updates.InteractionWarnings = s.checkInteractions(ctx, updates)
+
 	if err := s.repo.Update(ctx, updates); err != nil {
 		s.logger.Error("failed to update medication",
 			zap.Error(err),
@@ -161,31 +289,190 @@ func (s *MedicationService) UpdateMedication(ctx context.Context, medID string,
 	return nil
 }
 
-// DeleteMedication deletes a medication
-func (s *MedicationService) DeleteMedication(ctx context.Context, medID string) error {
+// DeactivateMedication marks a medication as no longer taken, stamping today in the
+// user's time zone as its end date unless it has one. A non-empty userID must own the
+// medication. The medication is kept and still listed.
+func (s *MedicationService) DeactivateMedication(ctx context.Context, userID, medicationID string) (*model.Medication, error) {
+	ctx, span := telemetry.StartSpan(ctx, "MedicationService.DeactivateMedication")
+	defer span.End()
+
+	med, err := s.ownedMedication(ctx, userID, medicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if med.EndDate == nil {
+		loc, err := s.userLocation(ctx, med.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user time zone: %w", err)
+		}
+		year, month, day := time.Now().In(loc).Date()
+		today := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		med.EndDate = &today
+	}
+	med.Active = false
+
+	if err := s.setActive(ctx, med, userID, "deactivate"); err != nil {
+		return nil, err
+	}
+	return med, nil
+}
+
+// ReactivateMedication marks a medication as taken again. An end date that has passed
+// is cleared, since it would deactivate the medication again; a future one is kept. A
+// non-empty userID must own the medication.
+func (s *MedicationService) ReactivateMedication(ctx context.Context, userID, medicationID string) (*model.Medication, error) {
+	ctx, span := telemetry.StartSpan(ctx, "MedicationService.ReactivateMedication")
+	defer span.End()
+
+	med, err := s.ownedMedication(ctx, userID, medicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if medicationEnded(med.EndDate, time.Now()) {
+		med.EndDate = nil
+	}
+	med.Active = true
+
+	if err := s.setActive(ctx, med, userID, "reactivate"); err != nil {
+		return nil, err
+	}
+	return med, nil
+}
+
+// ownedMedication returns a medication, which must belong to userID unless it is empty
+func (s *MedicationService) ownedMedication(ctx context.Context, userID, medicationID string) (*model.Medication, error) {
+	med, err := s.repo.FindByID(ctx, medicationID)
+	if err != nil {
+		return nil, err
+	}
+	if userID != "" && med.UserID != userID {
+		return nil, ErrMedicationAccessDenied
+	}
+	return med, nil
+}
+
+// setActive stores the active flag and end date of med and audits the change
+func (s *MedicationService) setActive(ctx context.Context, med *model.Medication, actorID, action string) error {
+	if err := s.repo.SetActive(ctx, med.ID, med.Active, med.EndDate); err != nil {
+		s.logger.Error("failed to set medication active",
+			zap.Error(err),
+			zap.String("medication_id", med.ID),
+			zap.String("action", action),
+		)
+		return fmt.Errorf("failed to %s medication: %w", action, err)
+	}
+	med.UpdatedAt = time.Now()
+
+	s.logger.Info("medication active status changed",
+		zap.String("medication_id", med.ID),
+		zap.String("user_id", med.UserID),
+		zap.Bool("active", med.Active),
+	)
+
+	if s.auditLogger != nil {
+		if actorID == "" {
+			actorID = med.UserID
+		}
+		additional := map[string]interface{}{"action": action, "active": med.Active}
+		if med.EndDate != nil {
+			additional["end_date"] = med.EndDate.Format(time.DateOnly)
+		}
+		err := s.auditLogger.Log(ctx, audit.AuditLog{
+			UserID:         actorID,
+			OperationType:  audit.OperationUpdate,
+			ResourceType:   audit.ResourceMedication,
+			ResourceID:     med.ID,
+			AdditionalData: additional,
+		})
+		if err != nil {
+			s.logger.Error("failed to audit medication change", zap.Error(err), zap.String("medication_id", med.ID))
+		}
+	}
+	return nil
+}
+
+// medicationEnded reports whether a medication with endDate is no longer taken at now
+func medicationEnded(endDate *time.Time, now time.Time) bool {
+	return endDate != nil && endDate.Before(now)
+}
+
+// DeleteMedication deletes a medication. It is soft-deleted, so it can be restored,
+// unless permanent is set, which removes it with its adherence logs for good.
+func (s *MedicationService) DeleteMedication(ctx context.Context, medID string, permanent bool) error {
+	ctx, span := telemetry.StartSpan(ctx, "MedicationService.DeleteMedication")
+	defer span.End()
+
 	if medID == "" {
 		return fmt.Errorf("medication ID is required")
 	}
 
-	if err := s.repo.Delete(ctx, medID); err != nil {
+	deleteMedication := s.repo.Delete
+	if permanent {
+		deleteMedication = s.repo.DeletePermanently
+	}
+
+	if err := deleteMedication(ctx, medID); err != nil {
 		s.logger.Error("failed to delete medication",
 			zap.Error(err),
 			zap.String("medication_id", medID),
+			zap.Bool("permanent", permanent),
 		)
 		return fmt.Errorf("failed to delete medication: %w", err)
 	}
 
 	s.logger.Info("medication deleted successfully",
 		zap.String("medication_id", medID),
+		zap.Bool("permanent", permanent),
 	)
 
 	return nil
 }
 
-// LogAdherence logs medication adherence
-func (s *MedicationService) LogAdherence(ctx context.Context, medicationID string, takenAt time.Time, adherence bool) error {
+// RestoreMedication undoes the soft delete of a medication. A non-empty userID must own
+// the medication. It fails with repository.ErrMedicationNotFound unless the medication
+// is soft-deleted.
+func (s *MedicationService) RestoreMedication(ctx context.Context, userID, medicationID string) (*model.Medication, error) {
+	ctx, span := telemetry.StartSpan(ctx, "MedicationService.RestoreMedication")
+	defer span.End()
+
+	med, err := s.repo.FindDeletedByID(ctx, medicationID)
+	if err != nil {
+		return nil, err
+	}
+	if userID != "" && med.UserID != userID {
+		return nil, ErrMedicationAccessDenied
+	}
+
+	if err := s.repo.Restore(ctx, medicationID); err != nil {
+		s.logger.Error("failed to restore medication",
+			zap.Error(err),
+			zap.String("medication_id", medicationID),
+		)
+		return nil, fmt.Errorf("failed to restore medication: %w", err)
+	}
+
+	s.logger.Info("medication restored successfully",
+		zap.String("medication_id", medicationID),
+		zap.String("user_id", med.UserID),
+	)
+
+	return med, nil
+}
+
+// LogAdherence logs whether a dose of a medication was taken at takenAt. A non-empty
+// userID must own the medication. It fails with repository.ErrMedicationNotFound for
+// an unknown medication and ErrMedicationAccessDenied for another user's.
+func (s *MedicationService) LogAdherence(ctx context.Context, userID, medicationID string, takenAt time.Time, adherence bool, notes *string) (*model.MedicationLog, error) {
+	ctx, span := telemetry.StartSpan(ctx, "MedicationService.LogAdherence")
+	defer span.End()
+
 	if medicationID == "" {
-		return fmt.Errorf("medication ID is required")
+		return nil, fmt.Errorf("medication ID is required")
+	}
+	if err := s.authorizeMedication(ctx, userID, medicationID); err != nil {
+		return nil, err
 	}
 
 	log := &model.MedicationLog{
@@ -193,6 +480,7 @@ func (s *MedicationService) LogAdherence(ctx context.Context, medicationID strin
 		MedicationID: medicationID,
 		TakenAt:      takenAt,
 		Adherence:    adherence,
+		Notes:        notes,
 		CreatedAt:    time.Now(),
 	}
 
@@ -201,7 +489,7 @@ func (s *MedicationService) LogAdherence(ctx context.Context, medicationID strin
 			zap.Error(err),
 			zap.String("medication_id", medicationID),
 		)
-		return fmt.Errorf("failed to log adherence: %w", err)
+		return nil, fmt.Errorf("failed to log adherence: %w", err)
 	}
 
 	s.logger.Info("medication adherence logged",
@@ -209,5 +497,161 @@ func (s *MedicationService) LogAdherence(ctx context.Context, medicationID strin
 		zap.Bool("adherence", adherence),
 	)
 
-	return nil
+	return log, nil
+}
+
+// GetAdherenceLogs returns the adherence logs of a medication taken from from,
+// inclusive, to to, exclusive, newest first. Zero bounds leave the window open. A
+// non-empty userID must own the medication.
+func (s *MedicationService) GetAdherenceLogs(ctx context.Context, userID, medicationID string, from, to time.Time) ([]model.MedicationLog, error) {
+	ctx, span := telemetry.StartSpan(ctx, "MedicationService.GetAdherenceLogs")
+	defer span.End()
+
+	if err := s.authorizeMedication(ctx, userID, medicationID); err != nil {
+		return nil, err
+	}
+
+	logs, err := s.repo.GetAdherenceLogs(ctx, medicationID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get adherence logs: %w", err)
+	}
+	return logs, nil
+}
+
+// authorizeMedication checks that a medication exists and, for a non-empty userID,
+// belongs to that user
+func (s *MedicationService) authorizeMedication(ctx context.Context, userID, medicationID string) error {
+	_, err := s.ownedMedication(ctx, userID, medicationID)
+	return err
+}
+
+// ScheduleReminders stores a structured reminder schedule for a medication.
+// A nil schedule is derived from the medication's free-text frequency.
+func (s *MedicationService) ScheduleReminders(ctx context.Context, medicationID string, schedule *model.MedicationSchedule) (*model.MedicationSchedule, error) {
+	ctx, span := telemetry.StartSpan(ctx, "MedicationService.ScheduleReminders")
+	defer span.End()
+
+	if medicationID == "" {
+		return nil, fmt.Errorf("medication ID is required")
+	}
+
+	med, err := s.repo.FindByID(ctx, medicationID)
+	if err != nil {
+		return nil, fmt.Errorf("medication not found: %w", err)
+	}
+
+	if schedule == nil {
+		schedule, err = ParseFrequency(med.Frequency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse medication frequency: %w", err)
+		}
+	}
+
+	if err := ValidateSchedule(schedule); err != nil {
+		return nil, err
+	}
+
+	if schedule.ID == "" {
+		schedule.ID = uuid.New().String()
+	}
+	schedule.MedicationID = medicationID
+
+	if err := s.repo.SaveSchedule(ctx, schedule); err != nil {
+		s.logger.Error("failed to save medication schedule",
+			zap.Error(err),
+			zap.String("medication_id", medicationID),
+		)
+		return nil, fmt.Errorf("failed to schedule reminders: %w", err)
+	}
+
+	s.logger.Info("medication reminders scheduled",
+		zap.String("medication_id", medicationID),
+		zap.Strings("times_of_day", schedule.TimesOfDay),
+		zap.Int("days_of_week", len(schedule.DaysOfWeek)),
+	)
+
+	return schedule, nil
+}
+
+// UpcomingReminders represents a medication schedule with its next due times
+type UpcomingReminders struct {
+	Schedule *model.MedicationSchedule
+	Derived  bool // true when the schedule was parsed from the frequency text and not stored
+	DueTimes []time.Time
+}
+
+// GetUpcomingReminders computes the next count due times for a medication
+func (s *MedicationService) GetUpcomingReminders(ctx context.Context, medicationID string, count int) (*UpcomingReminders, error) {
+	ctx, span := telemetry.StartSpan(ctx, "MedicationService.GetUpcomingReminders")
+	defer span.End()
+
+	if medicationID == "" {
+		return nil, fmt.Errorf("medication ID is required")
+	}
+
+	med, err := s.repo.FindByID(ctx, medicationID)
+	if err != nil {
+		return nil, fmt.Errorf("medication not found: %w", err)
+	}
+
+	schedule, err := s.repo.GetSchedule(ctx, medicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get medication schedule: %w", err)
+	}
+
+	derived := false
+	if schedule == nil {
+		schedule, err = ParseFrequency(med.Frequency)
+		if err != nil {
+			return nil, fmt.Errorf("no schedule stored and frequency could not be parsed: %w", err)
+		}
+		schedule.MedicationID = medicationID
+		derived = true
+	}
+
+	loc, err := s.userLocation(ctx, med.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user time zone: %w", err)
+	}
+
+	return &UpcomingReminders{
+		Schedule: schedule,
+		Derived:  derived,
+		DueTimes: NextDueTimes(schedule, med, time.Now().In(loc), count),
+	}, nil
+}
+
+// GetDueDoses computes the doses of a user's active medications scheduled within window
+// before or after at, in the user's time zone, and whether each was logged. Medications
+// without a stored schedule use the schedule parsed from their frequency text.
+func (s *MedicationService) GetDueDoses(ctx context.Context, userID string, at time.Time, window time.Duration) (*DueDoses, error) {
+	ctx, span := telemetry.StartSpan(ctx, "MedicationService.GetDueDoses")
+	defer span.End()
+
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	loc, err := s.userLocation(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user time zone: %w", err)
+	}
+	at = at.In(loc)
+
+	meds, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get medications: %w", err)
+	}
+
+	schedules, err := s.repo.GetSchedulesByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get medication schedules: %w", err)
+	}
+
+	logs, err := s.repo.GetUserAdherenceLogs(ctx, userID, at.Add(-3*window), at.Add(2*window))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get adherence logs: %w", err)
+	}
+
+	return ComputeDueDoses(meds, schedules, logs, at, window), nil
 }
@@ -0,0 +1,392 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+)
+
+const (
+	// defaultReminderTime is used for once-a-day schedules
+	defaultReminderTime = "08:00"
+
+	// maxScheduleLookaheadDays bounds the search for upcoming reminders
+	maxScheduleLookaheadDays = 366
+)
+
+// timesPerDayDefaults maps a daily dose count to evenly spread reminder times
+var timesPerDayDefaults = map[int][]string{
+	1: {"08:00"},
+	2: {"08:00", "20:00"},
+	3: {"08:00", "14:00", "20:00"},
+	4: {"08:00", "12:00", "16:00", "20:00"},
+}
+
+// timesPerDayWords maps frequency phrases (English and Hungarian) to a daily dose count
+var timesPerDayWords = []struct {
+	phrase string
+	count  int
+}{
+	{"four times", 4},
+	{"négyszer", 4},
+	{"three times", 3},
+	{"háromszor", 3},
+	{"twice", 2},
+	{"two times", 2},
+	{"kétszer", 2},
+	{"once", 1},
+	{"egyszer", 1},
+}
+
+// weekdayNames maps day names and abbreviations to weekdays
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+var (
+	asNeededPattern    = regexp.MustCompile(`\b(?:as needed|when needed|prn)\b|szükség szerint|szükség esetén`)
+	everyNHoursPattern = regexp.MustCompile(`every\s+(\d+)\s*(?:hours?|hrs?|h)\b|(\d+)\s*óránként`)
+	timesPerDayPattern = regexp.MustCompile(`(\d+)\s*(?:x|times)\s*(?:a\s+|per\s+)?(?:daily|day)`)
+	timeOfDayPattern   = regexp.MustCompile(`^([01]?\d|2[0-3]):([0-5]\d)$`)
+)
+
+// ParseFrequency converts a free-text medication frequency such as "daily",
+// "every 8 hours", "Mon/Wed/Fri" or "as needed" into a structured schedule
+func ParseFrequency(frequency string) (*model.MedicationSchedule, error) {
+	normalized := strings.ToLower(strings.TrimSpace(frequency))
+	if normalized == "" {
+		return nil, fmt.Errorf("frequency is empty")
+	}
+
+	// As needed: "as needed", "PRN", "szükség szerint"
+	if asNeededPattern.MatchString(normalized) {
+		return &model.MedicationSchedule{AsNeeded: true}, nil
+	}
+
+	schedule := &model.MedicationSchedule{}
+
+	// Interval based: "every 8 hours", "8 óránként"
+	if m := everyNHoursPattern.FindStringSubmatch(normalized); m != nil {
+		hoursStr := m[1]
+		if hoursStr == "" {
+			hoursStr = m[2]
+		}
+		hours, _ := strconv.Atoi(hoursStr)
+		if hours <= 0 || hours > 24 || 24%hours != 0 {
+			return nil, fmt.Errorf("unsupported interval: every %d hours", hours)
+		}
+		for h := 8; h < 8+24; h += hours {
+			schedule.TimesOfDay = append(schedule.TimesOfDay, fmt.Sprintf("%02d:00", h%24))
+		}
+		sort.Strings(schedule.TimesOfDay)
+		return schedule, nil
+	}
+
+	// Day-of-week based: "Mon/Wed/Fri", "monday, thursday"
+	days := parseWeekdays(normalized)
+	if len(days) > 0 {
+		schedule.DaysOfWeek = days
+	}
+
+	// Doses per day: "twice daily", "3x daily", "naponta kétszer"
+	count := 0
+	if m := timesPerDayPattern.FindStringSubmatch(normalized); m != nil {
+		count, _ = strconv.Atoi(m[1])
+	} else {
+		for _, word := range timesPerDayWords {
+			if strings.Contains(normalized, word.phrase) {
+				count = word.count
+				break
+			}
+		}
+	}
+
+	switch {
+	case count > 0:
+		times, ok := timesPerDayDefaults[count]
+		if !ok {
+			return nil, fmt.Errorf("unsupported number of doses per day: %d", count)
+		}
+		schedule.TimesOfDay = times
+	case len(schedule.DaysOfWeek) > 0,
+		strings.Contains(normalized, "daily"),
+		strings.Contains(normalized, "every day"),
+		strings.Contains(normalized, "naponta"),
+		strings.Contains(normalized, "minden nap"):
+		schedule.TimesOfDay = []string{defaultReminderTime}
+	case strings.Contains(normalized, "weekly") || strings.Contains(normalized, "hetente"):
+		schedule.TimesOfDay = []string{defaultReminderTime}
+		schedule.DaysOfWeek = []time.Weekday{time.Monday}
+	default:
+		return nil, fmt.Errorf("unrecognized frequency: %q", frequency)
+	}
+
+	return schedule, nil
+}
+
+// parseWeekdays extracts weekday names from a frequency string
+func parseWeekdays(normalized string) []time.Weekday {
+	tokens := strings.FieldsFunc(normalized, func(r rune) bool {
+		return r == '/' || r == ',' || r == ' ' || r == '-' || r == ';'
+	})
+
+	seen := make(map[time.Weekday]bool)
+	var days []time.Weekday
+	for _, token := range tokens {
+		if day, ok := weekdayNames[token]; ok && !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool { return days[i] < days[j] })
+	return days
+}
+
+// ValidateSchedule checks that a schedule has well-formed times and days, or none at
+// all for an as-needed schedule
+func ValidateSchedule(schedule *model.MedicationSchedule) error {
+	if schedule != nil && schedule.AsNeeded {
+		if len(schedule.TimesOfDay) > 0 || len(schedule.DaysOfWeek) > 0 {
+			return fmt.Errorf("as-needed schedules have no times of day or days of week")
+		}
+		return nil
+	}
+
+	if schedule == nil || len(schedule.TimesOfDay) == 0 {
+		return fmt.Errorf("schedule must contain at least one time of day")
+	}
+
+	for _, t := range schedule.TimesOfDay {
+		if !timeOfDayPattern.MatchString(t) {
+			return fmt.Errorf("invalid time of day %q, expected HH:MM", t)
+		}
+	}
+
+	for _, d := range schedule.DaysOfWeek {
+		if d < time.Sunday || d > time.Saturday {
+			return fmt.Errorf("invalid day of week: %d", d)
+		}
+	}
+
+	return nil
+}
+
+// NextDueTimes computes the next n reminder times at or after from, bounded by
+// the medication's start and end dates. Times of day are interpreted in from's location.
+// As-needed schedules have no reminder times.
+func NextDueTimes(schedule *model.MedicationSchedule, med *model.Medication, from time.Time, n int) []time.Time {
+	if schedule == nil || n <= 0 {
+		return nil
+	}
+
+	loc := from.Location()
+
+	// Parse and sort times of day as minutes after midnight
+	var minutes []int
+	for _, t := range schedule.TimesOfDay {
+		m := timeOfDayPattern.FindStringSubmatch(t)
+		if m == nil {
+			continue
+		}
+		hour, _ := strconv.Atoi(m[1])
+		minute, _ := strconv.Atoi(m[2])
+		minutes = append(minutes, hour*60+minute)
+	}
+	sort.Ints(minutes)
+	if len(minutes) == 0 {
+		return nil
+	}
+
+	allowedDays := make(map[time.Weekday]bool)
+	for _, d := range schedule.DaysOfWeek {
+		allowedDays[d] = true
+	}
+
+	start := from
+	startDay := time.Date(med.StartDate.Year(), med.StartDate.Month(), med.StartDate.Day(), 0, 0, 0, 0, loc)
+	if startDay.After(start) {
+		start = startDay
+	}
+
+	var endLimit time.Time
+	if med.EndDate != nil {
+		// End date is inclusive
+		endLimit = time.Date(med.EndDate.Year(), med.EndDate.Month(), med.EndDate.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+	}
+
+	var due []time.Time
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	for i := 0; i < maxScheduleLookaheadDays && len(due) < n; i++ {
+		if !endLimit.IsZero() && !day.Before(endLimit) {
+			break
+		}
+
+		if len(allowedDays) == 0 || allowedDays[day.Weekday()] {
+			for _, m := range minutes {
+				// Built from the wall clock so the time holds across DST changes
+				at := time.Date(day.Year(), day.Month(), day.Day(), m/60, m%60, 0, 0, loc)
+				if at.Before(start) {
+					continue
+				}
+				due = append(due, at)
+				if len(due) == n {
+					break
+				}
+			}
+		}
+
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return due
+}
+
+// DoseStatus is the state of a scheduled dose
+type DoseStatus string
+
+const (
+	DoseDue      DoseStatus = "due"      // scheduled at or before the time asked about and not logged
+	DoseUpcoming DoseStatus = "upcoming" // scheduled after the time asked about and not logged
+	DoseTaken    DoseStatus = "taken"
+	DoseSkipped  DoseStatus = "skipped" // logged as not taken
+)
+
+// ScheduledDose is a dose of a medication scheduled around the time asked about
+type ScheduledDose struct {
+	MedicationID string     `json:"medication_id"`
+	Name         string     `json:"name"`
+	Dosage       string     `json:"dosage"`
+	ScheduledAt  time.Time  `json:"scheduled_at"`
+	Status       DoseStatus `json:"status"`
+	LogID        *string    `json:"log_id,omitempty"` // adherence log matched to the dose
+	Derived      bool       `json:"derived"`          // schedule parsed from the frequency text
+}
+
+// MedicationRef identifies a medication that has no scheduled doses
+type MedicationRef struct {
+	MedicationID string `json:"medication_id"`
+	Name         string `json:"name"`
+	Dosage       string `json:"dosage"`
+	Frequency    string `json:"frequency"`
+}
+
+// DueDoses are the doses of a user's active medications scheduled within a window
+// around a point in time
+type DueDoses struct {
+	At       time.Time       `json:"at"`
+	Timezone string          `json:"timezone"`
+	Doses    []ScheduledDose `json:"doses"`
+	// AsNeeded medications are taken when needed and never due
+	AsNeeded []MedicationRef `json:"as_needed"`
+	// Unscheduled medications have no stored schedule and a frequency text that could
+	// not be parsed
+	Unscheduled []MedicationRef `json:"unscheduled"`
+}
+
+// ComputeDueDoses finds the doses of the active medications scheduled within window
+// before or after at, read in at's location. A medication without a stored schedule
+// uses the schedule parsed from its frequency text. Each adherence log within window of
+// a dose marks that dose taken or skipped; logs must cover [at-3*window, at+2*window).
+func ComputeDueDoses(meds []model.Medication, schedules map[string]*model.MedicationSchedule, logs []model.MedicationLog, at time.Time, window time.Duration) *DueDoses {
+	due := &DueDoses{
+		At:          at,
+		Timezone:    at.Location().String(),
+		Doses:       []ScheduledDose{},
+		AsNeeded:    []MedicationRef{},
+		Unscheduled: []MedicationRef{},
+	}
+
+	logsByMedication := make(map[string][]model.MedicationLog)
+	for _, log := range logs {
+		logsByMedication[log.MedicationID] = append(logsByMedication[log.MedicationID], log)
+	}
+
+	for i := range meds {
+		med := &meds[i]
+		if !med.Active {
+			continue
+		}
+
+		ref := MedicationRef{MedicationID: med.ID, Name: med.Name, Dosage: med.Dosage, Frequency: med.Frequency}
+		schedule, derived := schedules[med.ID], false
+		if schedule == nil {
+			parsed, err := ParseFrequency(med.Frequency)
+			if err != nil {
+				due.Unscheduled = append(due.Unscheduled, ref)
+				continue
+			}
+			schedule, derived = parsed, true
+		}
+		if schedule.AsNeeded {
+			due.AsNeeded = append(due.AsNeeded, ref)
+			continue
+		}
+
+		// Doses from before the window are matched too, so their logs are not taken for
+		// the first dose in the window
+		doses := dosesBetween(schedule, med, at.Add(-2*window), at.Add(window))
+		used := make(map[string]bool)
+		for _, scheduledAt := range doses {
+			dose := ScheduledDose{
+				MedicationID: med.ID,
+				Name:         med.Name,
+				Dosage:       med.Dosage,
+				ScheduledAt:  scheduledAt,
+				Status:       DoseUpcoming,
+				Derived:      derived,
+			}
+			if !scheduledAt.After(at) {
+				dose.Status = DoseDue
+			}
+
+			for _, log := range logsByMedication[med.ID] {
+				if used[log.ID] || log.TakenAt.Sub(scheduledAt).Abs() > window {
+					continue
+				}
+				used[log.ID] = true
+				logID := log.ID
+				dose.LogID = &logID
+				dose.Status = DoseSkipped
+				if log.Adherence {
+					dose.Status = DoseTaken
+				}
+				break
+			}
+
+			if !scheduledAt.Before(at.Add(-window)) {
+				due.Doses = append(due.Doses, dose)
+			}
+		}
+	}
+
+	sort.SliceStable(due.Doses, func(i, j int) bool {
+		return due.Doses[i].ScheduledAt.Before(due.Doses[j].ScheduledAt)
+	})
+	return due
+}
+
+// dosesBetween returns the dose times of a schedule within [from, to], read in from's
+// location
+func dosesBetween(schedule *model.MedicationSchedule, med *model.Medication, from, to time.Time) []time.Time {
+	days := int(to.Sub(from).Hours()/24) + 2
+	var doses []time.Time
+	for _, at := range NextDueTimes(schedule, med, from, len(schedule.TimesOfDay)*days) {
+		if at.After(to) {
+			break
+		}
+		doses = append(doses, at)
+	}
+	return doses
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+)
+
+func TestParseFrequency(t *testing.T) {
+	tests := []struct {
+		frequency string
+		wantTimes []string
+		wantDays  []time.Weekday
+		asNeeded  bool
+		wantErr   bool
+	}{
+		{frequency: "daily", wantTimes: []string{"08:00"}},
+		{frequency: "Once daily", wantTimes: []string{"08:00"}},
+		{frequency: "Twice daily", wantTimes: []string{"08:00", "20:00"}},
+		{frequency: "3x daily", wantTimes: []string{"08:00", "14:00", "20:00"}},
+		{frequency: "naponta kétszer", wantTimes: []string{"08:00", "20:00"}},
+		{frequency: "every 8 hours", wantTimes: []string{"00:00", "08:00", "16:00"}},
+		{frequency: "Every 12 hrs", wantTimes: []string{"08:00", "20:00"}},
+		{frequency: "6 óránként", wantTimes: []string{"02:00", "08:00", "14:00", "20:00"}},
+		{frequency: "Mon/Wed/Fri", wantTimes: []string{"08:00"}, wantDays: []time.Weekday{time.Monday, time.Wednesday, time.Friday}},
+		{frequency: "twice daily on Tue, Thu", wantTimes: []string{"08:00", "20:00"}, wantDays: []time.Weekday{time.Tuesday, time.Thursday}},
+		{frequency: "weekly", wantTimes: []string{"08:00"}, wantDays: []time.Weekday{time.Monday}},
+		{frequency: "as needed", asNeeded: true},
+		{frequency: "1 tablet PRN for pain", asNeeded: true},
+		{frequency: "szükség szerint", asNeeded: true},
+		{frequency: "every 7 hours", wantErr: true},
+		{frequency: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.frequency, func(t *testing.T) {
+			schedule, err := ParseFrequency(tt.frequency)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTimes, schedule.TimesOfDay)
+			assert.Equal(t, tt.wantDays, schedule.DaysOfWeek)
+			assert.Equal(t, tt.asNeeded, schedule.AsNeeded)
+		})
+	}
+}
+
+func TestValidateSchedule(t *testing.T) {
+	assert.NoError(t, ValidateSchedule(&model.MedicationSchedule{TimesOfDay: []string{"08:00", "23:59"}}))
+	assert.Error(t, ValidateSchedule(&model.MedicationSchedule{}))
+	assert.Error(t, ValidateSchedule(&model.MedicationSchedule{TimesOfDay: []string{"24:00"}}))
+	assert.Error(t, ValidateSchedule(&model.MedicationSchedule{TimesOfDay: []string{"08:00"}, DaysOfWeek: []time.Weekday{7}}))
+	assert.NoError(t, ValidateSchedule(&model.MedicationSchedule{AsNeeded: true}))
+	assert.Error(t, ValidateSchedule(&model.MedicationSchedule{AsNeeded: true, TimesOfDay: []string{"08:00"}}))
+}
+
+func TestNextDueTimes(t *testing.T) {
+	// Wednesday 2026-03-04 10:30
+	from := time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC)
+	med := &model.Medication{StartDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)}
+
+	t.Run("twice daily skips past times today", func(t *testing.T) {
+		schedule := &model.MedicationSchedule{TimesOfDay: []string{"20:00", "08:00"}}
+		due := NextDueTimes(schedule, med, from, 3)
+		assert.Equal(t, []time.Time{
+			time.Date(2026, 3, 4, 20, 0, 0, 0, time.UTC),
+			time.Date(2026, 3, 5, 8, 0, 0, 0, time.UTC),
+			time.Date(2026, 3, 5, 20, 0, 0, 0, time.UTC),
+		}, due)
+	})
+
+	t.Run("day of week pattern", func(t *testing.T) {
+		schedule := &model.MedicationSchedule{
+			TimesOfDay: []string{"08:00"},
+			DaysOfWeek: []time.Weekday{time.Monday, time.Wednesday, time.Friday},
+		}
+		due := NextDueTimes(schedule, med, from, 3)
+		assert.Equal(t, []time.Time{
+			time.Date(2026, 3, 6, 8, 0, 0, 0, time.UTC),
+			time.Date(2026, 3, 9, 8, 0, 0, 0, time.UTC),
+			time.Date(2026, 3, 11, 8, 0, 0, 0, time.UTC),
+		}, due)
+	})
+
+	t.Run("respects future start date", func(t *testing.T) {
+		future := &model.Medication{StartDate: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)}
+		schedule := &model.MedicationSchedule{TimesOfDay: []string{"08:00"}}
+		due := NextDueTimes(schedule, future, from, 1)
+		assert.Equal(t, []time.Time{time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)}, due)
+	})
+
+	t.Run("stops at end date", func(t *testing.T) {
+		end := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+		ending := &model.Medication{StartDate: med.StartDate, EndDate: &end}
+		schedule := &model.MedicationSchedule{TimesOfDay: []string{"08:00", "20:00"}}
+		due := NextDueTimes(schedule, ending, from, 10)
+		assert.Len(t, due, 3)
+	})
+
+	t.Run("keeps the wall clock time across a DST change", func(t *testing.T) {
+		budapest, err := time.LoadLocation("Europe/Budapest")
+		require.NoError(t, err)
+
+		// Clocks go forward on 2026-03-29
+		schedule := &model.MedicationSchedule{TimesOfDay: []string{"08:00"}}
+		due := NextDueTimes(schedule, med, time.Date(2026, 3, 28, 9, 0, 0, 0, budapest), 2)
+		assert.Equal(t, []time.Time{
+			time.Date(2026, 3, 29, 8, 0, 0, 0, budapest),
+			time.Date(2026, 3, 30, 8, 0, 0, 0, budapest),
+		}, due)
+		assert.Equal(t, 6, due[0].UTC().Hour())
+	})
+}
+
+func TestComputeDueDoses(t *testing.T) {
+	budapest, err := time.LoadLocation("Europe/Budapest")
+	require.NoError(t, err)
+
+	// Wednesday 2026-03-04 08:20 in Budapest, 07:20 UTC
+	at := time.Date(2026, 3, 4, 8, 20, 0, 0, budapest)
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	meds := []model.Medication{
+		{ID: "stored", Name: "Metformin", Dosage: "500mg", Frequency: "daily", StartDate: start, Active: true},
+		{ID: "legacy", Name: "Lisinopril", Dosage: "10mg", Frequency: "Twice daily", StartDate: start, Active: true},
+		{ID: "prn", Name: "Ibuprofen", Dosage: "200mg", Frequency: "as needed", StartDate: start, Active: true},
+		{ID: "free-text", Name: "Vitamin D", Dosage: "1000IU", Frequency: "with breakfast", StartDate: start, Active: true},
+		{ID: "stopped", Name: "Amoxicillin", Dosage: "250mg", Frequency: "daily", StartDate: start},
+	}
+	schedules := map[string]*model.MedicationSchedule{
+		"stored": {MedicationID: "stored", TimesOfDay: []string{"07:30", "09:00"}},
+	}
+	logs := []model.MedicationLog{
+		// Taken at 07:40 Budapest time, for the 07:30 dose
+		{ID: "log-1", MedicationID: "stored", TakenAt: time.Date(2026, 3, 4, 6, 40, 0, 0, time.UTC), Adherence: true},
+		// Skipped the 08:00 dose of the legacy medication
+		{ID: "log-2", MedicationID: "legacy", TakenAt: time.Date(2026, 3, 4, 7, 5, 0, 0, time.UTC), Adherence: false},
+	}
+
+	due := ComputeDueDoses(meds, schedules, logs, at, time.Hour)
+
+	require.Len(t, due.Doses, 3)
+	assert.Equal(t, "stored", due.Doses[0].MedicationID)
+	assert.Equal(t, time.Date(2026, 3, 4, 7, 30, 0, 0, budapest), due.Doses[0].ScheduledAt)
+	assert.Equal(t, DoseTaken, due.Doses[0].Status)
+	require.NotNil(t, due.Doses[0].LogID)
+	assert.Equal(t, "log-1", *due.Doses[0].LogID)
+	assert.False(t, due.Doses[0].Derived)
+
+	assert.Equal(t, "legacy", due.Doses[1].MedicationID)
+	assert.Equal(t, DoseSkipped, due.Doses[1].Status)
+	assert.True(t, due.Doses[1].Derived)
+
+	assert.Equal(t, "stored", due.Doses[2].MedicationID)
+	assert.Equal(t, time.Date(2026, 3, 4, 9, 0, 0, 0, budapest), due.Doses[2].ScheduledAt)
+	assert.Equal(t, DoseUpcoming, due.Doses[2].Status)
+	assert.Nil(t, due.Doses[2].LogID)
+
+	require.Len(t, due.AsNeeded, 1)
+	assert.Equal(t, "prn", due.AsNeeded[0].MedicationID)
+	require.Len(t, due.Unscheduled, 1)
+	assert.Equal(t, "free-text", due.Unscheduled[0].MedicationID)
+	assert.Equal(t, "Europe/Budapest", due.Timezone)
+
+	t.Run("dose not logged is due", func(t *testing.T) {
+		due := ComputeDueDoses(meds[:1], schedules, nil, at, time.Hour)
+		require.Len(t, due.Doses, 2)
+		assert.Equal(t, DoseDue, due.Doses[0].Status)
+		assert.Equal(t, DoseUpcoming, due.Doses[1].Status)
+	})
+
+	t.Run("log of a dose before the window is not reused", func(t *testing.T) {
+		schedules := map[string]*model.MedicationSchedule{
+			"stored": {MedicationID: "stored", TimesOfDay: []string{"06:45", "07:30"}},
+		}
+		// Taken at 06:50 for the 06:45 dose, which is before the window
+		logs := []model.MedicationLog{
+			{ID: "early", MedicationID: "stored", TakenAt: time.Date(2026, 3, 4, 5, 50, 0, 0, time.UTC), Adherence: true},
+		}
+		due := ComputeDueDoses(meds[:1], schedules, logs, at, time.Hour)
+		require.Len(t, due.Doses, 1)
+		assert.Equal(t, time.Date(2026, 3, 4, 7, 30, 0, 0, budapest), due.Doses[0].ScheduledAt)
+		assert.Equal(t, DoseDue, due.Doses[0].Status)
+	})
+}
@@ -0,0 +1,390 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultInvitationTTL is how long an invitation token stays valid
+	defaultInvitationTTL = 7 * 24 * time.Hour
+
+	// invitationTokenBytes is the amount of randomness in an invitation token
+	invitationTokenBytes = 32
+)
+
+var (
+	// ErrInvalidRole is returned for unknown roles and roles that cannot be granted
+	// within an organization
+	ErrInvalidRole = errors.New("invalid role")
+
+	// ErrOrganizationNotFound is returned when an organization does not exist
+	ErrOrganizationNotFound = errors.New("organization not found")
+
+	// ErrInvitationInvalid is returned when an invitation token is unknown, expired or
+	// already used
+	ErrInvitationInvalid = errors.New("invitation is invalid or has expired")
+
+	// ErrInvalidEmail is returned when an email address cannot be parsed
+	ErrInvalidEmail = errors.New("invalid email address")
+
+	// ErrRoleAssignmentNotFound is returned when revoking a role the user does not hold
+	ErrRoleAssignmentNotFound = errors.New("role assignment not found")
+)
+
+// OrganizationStore defines the persistence operations needed for organization roles
+type OrganizationStore interface {
+	CreateOrganization(ctx context.Context, org *model.Organization) error
+	GetOrganization(ctx context.Context, orgID string) (*model.Organization, error)
+	SetDataResidency(ctx context.Context, orgID, residency string) (bool, error)
+	AssignRole(ctx context.Context, assignment *model.RoleAssignment) (bool, error)
+	RevokeRole(ctx context.Context, orgID, userID string, role model.Role) (bool, error)
+	GetMembers(ctx context.Context, orgID string) ([]model.RoleAssignment, error)
+	GetRolesByUserID(ctx context.Context, userID string) ([]model.RoleAssignment, error)
+	CreateInvitation(ctx context.Context, invitation *model.OrganizationInvitation) error
+	AcceptInvitation(ctx context.Context, tokenHash, userID string, now time.Time) (*model.OrganizationInvitation, error)
+}
+
+// RoleInvalidator drops cached roles of a user after they change
+type RoleInvalidator interface {
+	Invalidate(userID string)
+}
+
+// OrganizationMember is a user together with the roles they hold in an organization
+type OrganizationMember struct {
+	UserID string       `json:"user_id"`
+	Roles  []model.Role `json:"roles"`
+}
+
+// OrganizationService manages organizations, their members' roles and invitations
+type OrganizationService struct {
+	store         OrganizationStore
+	logger        *zap.Logger
+	auditLogger   *audit.Logger
+	roleCache     RoleInvalidator
+	invitationTTL time.Duration
+	residencies   []string
+	now           func() time.Time
+}
+
+// NewOrganizationService creates a new OrganizationService
+func NewOrganizationService(store OrganizationStore, logger *zap.Logger) *OrganizationService {
+	return &OrganizationService{
+		store:         store,
+		logger:        logger,
+		invitationTTL: defaultInvitationTTL,
+		now:           time.Now,
+	}
+}
+
+// SetAuditLogger enables audit logging of role changes
+func (s *OrganizationService) SetAuditLogger(auditLogger *audit.Logger) {
+	s.auditLogger = auditLogger
+}
+
+// SetRoleCache registers the cache invalidated whenever a user's roles change
+func (s *OrganizationService) SetRoleCache(cache RoleInvalidator) {
+	s.roleCache = cache
+}
+
+// SetInvitationTTL configures how long invitation tokens stay valid
+func (s *OrganizationService) SetInvitationTTL(ttl time.Duration) {
+	if ttl > 0 {
+		s.invitationTTL = ttl
+	}
+}
+
+// SetResidencies configures the data residencies organizations may require, the names
+// of the configured storage backends
+func (s *OrganizationService) SetResidencies(names []string) {
+	s.residencies = names
+}
+
+// CreateOrganization creates an organization whose patients' artifacts are written to
+// the storage backend named by residency, the default backend when empty
+func (s *OrganizationService) CreateOrganization(ctx context.Context, name, residency string) (*model.Organization, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("organization name is required")
+	}
+	residency, err := s.validResidency(residency)
+	if err != nil {
+		return nil, err
+	}
+
+	org := &model.Organization{
+		ID:            uuid.New().String(),
+		Name:          name,
+		DataResidency: residency,
+	}
+	if err := s.store.CreateOrganization(ctx, org); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("organization created", zap.String("organization_id", org.ID))
+	return org, nil
+}
+
+// SetDataResidency changes where the artifacts of an organization's patients are written
+// to; empty clears the residency. Existing artifacts stay in the backend they were
+// written to.
+func (s *OrganizationService) SetDataResidency(ctx context.Context, orgID, residency, changedBy string) (*model.Organization, error) {
+	residency, err := s.validResidency(residency)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := s.store.SetDataResidency(ctx, orgID, residency)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrOrganizationNotFound
+	}
+
+	s.audit(ctx, changedBy, audit.OperationUpdate, audit.ResourceOrganization, orgID, map[string]interface{}{
+		"action":         "set_data_residency",
+		"data_residency": residency,
+	})
+
+	return s.store.GetOrganization(ctx, orgID)
+}
+
+// InviteMember creates an invitation to join an organization with a role. The returned
+// token is handed to the invitee; only its hash is stored, so it cannot be recovered later.
+func (s *OrganizationService) InviteMember(ctx context.Context, orgID, email string, role model.Role, invitedBy string) (*model.OrganizationInvitation, string, error) {
+	if !organizationRole(role) {
+		return nil, "", ErrInvalidRole
+	}
+	email, err := NormalizeEmail(email)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.requireOrganization(ctx, orgID); err != nil {
+		return nil, "", err
+	}
+
+	token, err := newInvitationToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	invitation := &model.OrganizationInvitation{
+		ID:             uuid.New().String(),
+		OrganizationID: orgID,
+		Email:          email,
+		Role:           role,
+		TokenHash:      hashInvitationToken(token),
+		InvitedBy:      invitedBy,
+		ExpiresAt:      s.now().Add(s.invitationTTL),
+	}
+	if err := s.store.CreateInvitation(ctx, invitation); err != nil {
+		return nil, "", err
+	}
+
+	s.audit(ctx, invitedBy, audit.OperationCreate, audit.ResourceOrganizationInvitation, invitation.ID, map[string]interface{}{
+		"organization_id": orgID,
+		"role":            string(role),
+	})
+
+	return invitation, token, nil
+}
+
+// AcceptInvitation grants the invited role to the user presenting the token
+func (s *OrganizationService) AcceptInvitation(ctx context.Context, token, userID string) (*model.OrganizationInvitation, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, ErrInvitationInvalid
+	}
+
+	invitation, err := s.store.AcceptInvitation(ctx, hashInvitationToken(token), userID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if invitation == nil {
+		return nil, ErrInvitationInvalid
+	}
+
+	s.invalidate(userID)
+	s.audit(ctx, userID, audit.OperationCreate, audit.ResourceOrganizationRole, invitation.OrganizationID, map[string]interface{}{
+		"action":        "accept_invitation",
+		"invitation_id": invitation.ID,
+		"member_id":     userID,
+		"role":          string(invitation.Role),
+	})
+
+	return invitation, nil
+}
+
+// AssignRole grants a member a role in an organization. Granting a role the member
+// already holds succeeds without changes.
+func (s *OrganizationService) AssignRole(ctx context.Context, orgID, userID string, role model.Role, grantedBy string) (*model.RoleAssignment, error) {
+	if !organizationRole(role) {
+		return nil, ErrInvalidRole
+	}
+	if err := s.requireOrganization(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	assignment := &model.RoleAssignment{
+		OrganizationID: orgID,
+		UserID:         userID,
+		Role:           role,
+		GrantedBy:      grantedBy,
+	}
+	created, err := s.store.AssignRole(ctx, assignment)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return assignment, nil
+	}
+
+	s.invalidate(userID)
+	s.audit(ctx, grantedBy, audit.OperationCreate, audit.ResourceOrganizationRole, orgID, map[string]interface{}{
+		"action":    "assign_role",
+		"member_id": userID,
+		"role":      string(role),
+	})
+
+	return assignment, nil
+}
+
+// RevokeRole removes a role from a member of an organization
+func (s *OrganizationService) RevokeRole(ctx context.Context, orgID, userID string, role model.Role, revokedBy string) error {
+	if !organizationRole(role) {
+		return ErrInvalidRole
+	}
+
+	revoked, err := s.store.RevokeRole(ctx, orgID, userID, role)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrRoleAssignmentNotFound
+	}
+
+	s.invalidate(userID)
+	s.audit(ctx, revokedBy, audit.OperationDelete, audit.ResourceOrganizationRole, orgID, map[string]interface{}{
+		"action":    "revoke_role",
+		"member_id": userID,
+		"role":      string(role),
+	})
+
+	return nil
+}
+
+// ListMembers lists the members of an organization with their roles
+func (s *OrganizationService) ListMembers(ctx context.Context, orgID string) ([]OrganizationMember, error) {
+	if err := s.requireOrganization(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.store.GetMembers(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := []OrganizationMember{}
+	index := make(map[string]int)
+	for _, assignment := range assignments {
+		i, ok := index[assignment.UserID]
+		if !ok {
+			i = len(members)
+			index[assignment.UserID] = i
+			members = append(members, OrganizationMember{UserID: assignment.UserID})
+		}
+		members[i].Roles = append(members[i].Roles, assignment.Role)
+	}
+
+	return members, nil
+}
+
+// RolesForUser retrieves every role a user holds
+func (s *OrganizationService) RolesForUser(ctx context.Context, userID string) ([]model.RoleAssignment, error) {
+	return s.store.GetRolesByUserID(ctx, userID)
+}
+
+// requireOrganization returns ErrOrganizationNotFound unless the organization exists
+func (s *OrganizationService) requireOrganization(ctx context.Context, orgID string) error {
+	org, err := s.store.GetOrganization(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if org == nil {
+		return ErrOrganizationNotFound
+	}
+	return nil
+}
+
+// invalidate drops the cached roles of a user
+func (s *OrganizationService) invalidate(userID string) {
+	if s.roleCache != nil {
+		s.roleCache.Invalidate(userID)
+	}
+}
+
+// audit records a change of an organization or its roles in the audit log
+func (s *OrganizationService) audit(ctx context.Context, actorID string, op audit.OperationType, resource audit.ResourceType, resourceID string, additional map[string]interface{}) {
+	if s.auditLogger == nil {
+		return
+	}
+
+	err := s.auditLogger.Log(ctx, audit.AuditLog{
+		UserID:         actorID,
+		OperationType:  op,
+		ResourceType:   resource,
+		ResourceID:     resourceID,
+		AdditionalData: additional,
+	})
+	if err != nil {
+		s.logger.Error("failed to audit organization change", zap.Error(err), zap.String("resource_id", resourceID))
+	}
+}
+
+// validResidency normalizes a data residency and checks that a storage backend of that
+// name is configured. The default backend is stored as no residency.
+func (s *OrganizationService) validResidency(residency string) (string, error) {
+	residency = strings.TrimSpace(residency)
+	if residency == "" || residency == DefaultStorageBackend {
+		return "", nil
+	}
+	if !slices.Contains(s.residencies, residency) {
+		return "", fmt.Errorf("%w: %q", ErrUnknownStorageBackend, residency)
+	}
+	return residency, nil
+}
+
+// organizationRole reports whether role can be granted within an organization.
+// system_admin is system-wide and only granted outside the API.
+func organizationRole(role model.Role) bool {
+	return role.Valid() && role != model.RoleSystemAdmin
+}
+
+// newInvitationToken returns a random URL-safe invitation token
+func newInvitationToken() (string, error) {
+	b := make([]byte, invitationTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashInvitationToken returns the stored form of an invitation token
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
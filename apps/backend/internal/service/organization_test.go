@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// fakeOrganizationStore is an in-memory OrganizationStore
+type fakeOrganizationStore struct {
+	orgs        map[string]*model.Organization
+	roles       []model.RoleAssignment
+	invitations map[string]*model.OrganizationInvitation
+}
+
+func newFakeOrganizationStore() *fakeOrganizationStore {
+	return &fakeOrganizationStore{
+		orgs:        make(map[string]*model.Organization),
+		invitations: make(map[string]*model.OrganizationInvitation),
+	}
+}
+
+func (f *fakeOrganizationStore) CreateOrganization(ctx context.Context, org *model.Organization) error {
+	f.orgs[org.ID] = org
+	return nil
+}
+
+func (f *fakeOrganizationStore) GetOrganization(ctx context.Context, orgID string) (*model.Organization, error) {
+	return f.orgs[orgID], nil
+}
+
+func (f *fakeOrganizationStore) SetDataResidency(ctx context.Context, orgID, residency string) (bool, error) {
+	org, ok := f.orgs[orgID]
+	if !ok {
+		return false, nil
+	}
+	org.DataResidency = residency
+	return true, nil
+}
+
+func (f *fakeOrganizationStore) AssignRole(ctx context.Context, assignment *model.RoleAssignment) (bool, error) {
+	for _, existing := range f.roles {
+		if existing.OrganizationID == assignment.OrganizationID && existing.UserID == assignment.UserID && existing.Role == assignment.Role {
+			return false, nil
+		}
+	}
+	f.roles = append(f.roles, *assignment)
+	return true, nil
+}
+
+func (f *fakeOrganizationStore) RevokeRole(ctx context.Context, orgID, userID string, role model.Role) (bool, error) {
+	for i, existing := range f.roles {
+		if existing.OrganizationID == orgID && existing.UserID == userID && existing.Role == role {
+			f.roles = append(f.roles[:i], f.roles[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeOrganizationStore) GetMembers(ctx context.Context, orgID string) ([]model.RoleAssignment, error) {
+	var members []model.RoleAssignment
+	for _, assignment := range f.roles {
+		if assignment.OrganizationID == orgID {
+			members = append(members, assignment)
+		}
+	}
+	return members, nil
+}
+
+func (f *fakeOrganizationStore) GetRolesByUserID(ctx context.Context, userID string) ([]model.RoleAssignment, error) {
+	var roles []model.RoleAssignment
+	for _, assignment := range f.roles {
+		if assignment.UserID == userID {
+			roles = append(roles, assignment)
+		}
+	}
+	return roles, nil
+}
+
+func (f *fakeOrganizationStore) CreateInvitation(ctx context.Context, invitation *model.OrganizationInvitation) error {
+	f.invitations[invitation.TokenHash] = invitation
+	return nil
+}
+
+func (f *fakeOrganizationStore) AcceptInvitation(ctx context.Context, tokenHash, userID string, now time.Time) (*model.OrganizationInvitation, error) {
+	invitation, ok := f.invitations[tokenHash]
+	if !ok || invitation.AcceptedAt != nil || !now.Before(invitation.ExpiresAt) {
+		return nil, nil
+	}
+	invitation.AcceptedAt = &now
+	invitation.AcceptedBy = &userID
+	f.roles = append(f.roles, model.RoleAssignment{
+		OrganizationID: invitation.OrganizationID,
+		UserID:         userID,
+		Role:           invitation.Role,
+		GrantedBy:      invitation.InvitedBy,
+	})
+	return invitation, nil
+}
+
+// recordingInvalidator records the users whose cached roles were invalidated
+type recordingInvalidator struct {
+	users []string
+}
+
+func (r *recordingInvalidator) Invalidate(userID string) {
+	r.users = append(r.users, userID)
+}
+
+func TestOrganizationService_InvitationFlow(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeOrganizationStore()
+	svc := NewOrganizationService(store, zap.NewNop())
+	cache := &recordingInvalidator{}
+	svc.SetRoleCache(cache)
+
+	org, err := svc.CreateOrganization(ctx, "  Clinic  ", "")
+	require.NoError(t, err)
+	assert.Equal(t, "Clinic", org.Name)
+
+	invitation, token, err := svc.InviteMember(ctx, org.ID, "Doc@Example.com", model.RoleClinician, "admin-1")
+	require.NoError(t, err)
+	assert.Equal(t, "doc@example.com", invitation.Email)
+	assert.NotEmpty(t, token)
+	assert.NotContains(t, invitation.TokenHash, token, "only the token hash is stored")
+
+	_, err = svc.AcceptInvitation(ctx, "wrong-token", "user-1")
+	assert.ErrorIs(t, err, ErrInvitationInvalid)
+
+	accepted, err := svc.AcceptInvitation(ctx, token, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, model.RoleClinician, accepted.Role)
+	assert.Equal(t, []string{"user-1"}, cache.users)
+
+	_, err = svc.AcceptInvitation(ctx, token, "user-2")
+	assert.ErrorIs(t, err, ErrInvitationInvalid, "an invitation is accepted only once")
+
+	members, err := svc.ListMembers(ctx, org.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []OrganizationMember{{UserID: "user-1", Roles: []model.Role{model.RoleClinician}}}, members)
+}
+
+func TestOrganizationService_ExpiredInvitation(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeOrganizationStore()
+	svc := NewOrganizationService(store, zap.NewNop())
+	svc.SetInvitationTTL(time.Hour)
+
+	org, err := svc.CreateOrganization(ctx, "Clinic", "")
+	require.NoError(t, err)
+	_, token, err := svc.InviteMember(ctx, org.ID, "doc@example.com", model.RoleClinician, "admin-1")
+	require.NoError(t, err)
+
+	svc.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	_, err = svc.AcceptInvitation(ctx, token, "user-1")
+	assert.ErrorIs(t, err, ErrInvitationInvalid)
+}
+
+func TestOrganizationService_AssignAndRevokeRole(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeOrganizationStore()
+	svc := NewOrganizationService(store, zap.NewNop())
+	cache := &recordingInvalidator{}
+	svc.SetRoleCache(cache)
+
+	org, err := svc.CreateOrganization(ctx, "Clinic", "")
+	require.NoError(t, err)
+
+	_, err = svc.AssignRole(ctx, org.ID, "user-1", model.RoleCaregiver, "admin-1")
+	require.NoError(t, err)
+	_, err = svc.AssignRole(ctx, org.ID, "user-1", model.RoleCaregiver, "admin-1")
+	require.NoError(t, err, "assigning a held role is a no-op")
+	_, err = svc.AssignRole(ctx, org.ID, "user-1", model.RoleOrgAdmin, "admin-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-1", "user-1"}, cache.users, "only actual changes invalidate the cache")
+
+	members, err := svc.ListMembers(ctx, org.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.ElementsMatch(t, []model.Role{model.RoleCaregiver, model.RoleOrgAdmin}, members[0].Roles)
+
+	require.NoError(t, svc.RevokeRole(ctx, org.ID, "user-1", model.RoleCaregiver, "admin-1"))
+	assert.ErrorIs(t, svc.RevokeRole(ctx, org.ID, "user-1", model.RoleCaregiver, "admin-1"), ErrRoleAssignmentNotFound)
+
+	roles, err := svc.RolesForUser(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+	assert.Equal(t, model.RoleOrgAdmin, roles[0].Role)
+}
+
+func TestOrganizationService_RejectsInvalidRequests(t *testing.T) {
+	ctx := context.Background()
+	svc := NewOrganizationService(newFakeOrganizationStore(), zap.NewNop())
+	org, err := svc.CreateOrganization(ctx, "Clinic", "")
+	require.NoError(t, err)
+
+	_, err = svc.CreateOrganization(ctx, " ", "")
+	assert.Error(t, err)
+
+	_, err = svc.AssignRole(ctx, org.ID, "user-1", model.RoleSystemAdmin, "admin-1")
+	assert.ErrorIs(t, err, ErrInvalidRole, "system_admin cannot be granted within an organization")
+	_, err = svc.AssignRole(ctx, org.ID, "user-1", model.Role("owner"), "admin-1")
+	assert.ErrorIs(t, err, ErrInvalidRole)
+	_, err = svc.AssignRole(ctx, "missing-org", "user-1", model.RoleClinician, "admin-1")
+	assert.ErrorIs(t, err, ErrOrganizationNotFound)
+
+	_, _, err = svc.InviteMember(ctx, org.ID, "not an email", model.RoleClinician, "admin-1")
+	assert.ErrorIs(t, err, ErrInvalidEmail)
+	_, _, err = svc.InviteMember(ctx, org.ID, "doc@example.com", model.RoleSystemAdmin, "admin-1")
+	assert.ErrorIs(t, err, ErrInvalidRole)
+}
+
+func TestOrganizationService_DataResidency(t *testing.T) {
+	ctx := context.Background()
+	svc := NewOrganizationService(newFakeOrganizationStore(), zap.NewNop())
+	svc.SetResidencies([]string{DefaultStorageBackend, "eu"})
+
+	_, err := svc.CreateOrganization(ctx, "Clinic", "us")
+	assert.ErrorIs(t, err, ErrUnknownStorageBackend)
+
+	org, err := svc.CreateOrganization(ctx, "Clinic", " eu ")
+	require.NoError(t, err)
+	assert.Equal(t, "eu", org.DataResidency)
+
+	org, err = svc.SetDataResidency(ctx, org.ID, DefaultStorageBackend, "admin-1")
+	require.NoError(t, err)
+	assert.Empty(t, org.DataResidency, "the default backend is stored as no residency")
+
+	_, err = svc.SetDataResidency(ctx, org.ID, "us", "admin-1")
+	assert.ErrorIs(t, err, ErrUnknownStorageBackend)
+	_, err = svc.SetDataResidency(ctx, "missing-org", "eu", "admin-1")
+	assert.ErrorIs(t, err, ErrOrganizationNotFound)
+}
@@ -0,0 +1,384 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/delivery"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultPanelFindingsWindow is how far back panel findings are listed when no start is given
+	DefaultPanelFindingsWindow = 7 * 24 * time.Hour
+
+	// PanelDigestCheckInterval is how often subscriptions are checked for a due digest
+	PanelDigestCheckInterval = time.Hour
+
+	// panelDigestPeriod is the time between two digests of a clinician
+	panelDigestPeriod = 24 * time.Hour
+
+	// panelDigestMaxFindings caps the findings listed in one digest
+	panelDigestMaxFindings = 20
+)
+
+// Templates of the panel digest email. The body lists the most severe findings first.
+const (
+	panelDigestSubjectTemplate = `Patient panel digest {{date .GeneratedAt}}: {{.TotalCount}} findings`
+	panelDigestBodyTemplate    = `{{.TotalCount}} findings on your patient panel since {{date .Since}}.
+{{- if gt .TotalCount (len .Findings)}} The {{len .Findings}} most urgent are listed.{{end}}
+
+{{range .Findings}}[{{.Severity}}] {{.Kind}}, patient {{.PatientID}}, {{date .OccurredAt}}{{with .Detail}}: {{.}}{{end}}
+{{end}}`
+)
+
+var (
+	// ErrNotClinician is returned when a user does not hold the clinician role in the organization
+	ErrNotClinician = errors.New("user is not a clinician of the organization")
+
+	// ErrNotPatient is returned when a user does not hold the patient role in the organization
+	ErrNotPatient = errors.New("user is not a patient of the organization")
+
+	// ErrPanelAssignmentNotFound is returned when removing a patient who is not on the panel
+	ErrPanelAssignmentNotFound = errors.New("panel assignment not found")
+
+	// ErrDigestSubscriptionNotFound is returned when opting out of a digest that was not subscribed
+	ErrDigestSubscriptionNotFound = errors.New("digest subscription not found")
+
+	// ErrDigestUnavailable is returned when subscribing while email delivery is not configured
+	ErrDigestUnavailable = errors.New("email digests are not configured")
+
+	// ErrInvalidDigestEmail is returned for a digest address that cannot receive email
+	ErrInvalidDigestEmail = errors.New("invalid digest email address")
+)
+
+// PanelStore defines the persistence operations needed for clinicians' patient panels
+type PanelStore interface {
+	AssignPatient(ctx context.Context, assignment *model.PanelAssignment) error
+	UnassignPatient(ctx context.Context, orgID, clinicianID, patientID string) (bool, error)
+	ListAssignments(ctx context.Context, orgID, clinicianID string) ([]model.PanelAssignment, error)
+	ListFindings(ctx context.Context, orgID, clinicianID string, since time.Time, page repository.Page) ([]model.PanelFinding, int, error)
+	SubscribeDigest(ctx context.Context, subscription *model.PanelDigestSubscription) error
+	UnsubscribeDigest(ctx context.Context, orgID, clinicianID string) (bool, error)
+	FindDueDigests(ctx context.Context, sentBefore time.Time) ([]model.PanelDigestSubscription, error)
+	MarkDigestSent(ctx context.Context, orgID, clinicianID string, sentAt time.Time) error
+}
+
+// EmailVerifier checks that an address is a user's confirmed email address
+type EmailVerifier interface {
+	RequireVerified(ctx context.Context, userID, email string) error
+}
+
+// PanelDigest is the data the digest templates render for one clinician
+type PanelDigest struct {
+	OrganizationID string               `json:"organization_id"`
+	ClinicianID    string               `json:"clinician_id"`
+	Since          time.Time            `json:"since"`
+	GeneratedAt    time.Time            `json:"generated_at"`
+	TotalCount     int                  `json:"total_count"`
+	Findings       []model.PanelFinding `json:"findings"`
+}
+
+// PanelService gives clinicians an overview of the alerts and data-quality findings
+// across the patients assigned to them, and emails an opt-in daily digest of it
+type PanelService struct {
+	store       PanelStore
+	members     MembershipSource
+	registry    *delivery.Registry
+	digest      *delivery.Template
+	verifier    EmailVerifier
+	logger      *zap.Logger
+	auditLogger *audit.Logger
+	now         func() time.Time
+}
+
+// NewPanelService creates a new PanelService. Digests are sent through the registry's
+// smtp kind.
+func NewPanelService(store PanelStore, members MembershipSource, registry *delivery.Registry, logger *zap.Logger) *PanelService {
+	digest, err := delivery.ParseTemplate(panelDigestSubjectTemplate, panelDigestBodyTemplate)
+	if err != nil {
+		panic(fmt.Sprintf("invalid panel digest template: %v", err))
+	}
+
+	return &PanelService{
+		store:    store,
+		members:  members,
+		registry: registry,
+		digest:   digest,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// SetAuditLogger enables audit logging of panel assignment changes
+func (s *PanelService) SetAuditLogger(auditLogger *audit.Logger) {
+	s.auditLogger = auditLogger
+}
+
+// SetEmailVerifier makes digests go only to clinicians' confirmed email addresses
+func (s *PanelService) SetEmailVerifier(verifier EmailVerifier) {
+	s.verifier = verifier
+}
+
+// AssignPatient puts a patient of the organization on one of its clinicians' panels
+func (s *PanelService) AssignPatient(ctx context.Context, orgID, clinicianID, patientID, assignedBy string) (*model.PanelAssignment, error) {
+	if err := s.requireRole(ctx, orgID, clinicianID, model.RoleClinician, ErrNotClinician); err != nil {
+		return nil, err
+	}
+	if err := s.requireRole(ctx, orgID, patientID, model.RolePatient, ErrNotPatient); err != nil {
+		return nil, err
+	}
+
+	assignment := &model.PanelAssignment{
+		OrganizationID: orgID,
+		ClinicianID:    clinicianID,
+		PatientID:      patientID,
+		AssignedBy:     assignedBy,
+	}
+	if err := s.store.AssignPatient(ctx, assignment); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, assignedBy, audit.OperationCreate, orgID, clinicianID, patientID)
+	return assignment, nil
+}
+
+// UnassignPatient removes a patient from a clinician's panel
+func (s *PanelService) UnassignPatient(ctx context.Context, orgID, clinicianID, patientID, removedBy string) error {
+	removed, err := s.store.UnassignPatient(ctx, orgID, clinicianID, patientID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrPanelAssignmentNotFound
+	}
+
+	s.audit(ctx, removedBy, audit.OperationDelete, orgID, clinicianID, patientID)
+	return nil
+}
+
+// ListAssignments returns the patients on a clinician's panel
+func (s *PanelService) ListAssignments(ctx context.Context, orgID, clinicianID string) ([]model.PanelAssignment, error) {
+	return s.store.ListAssignments(ctx, orgID, clinicianID)
+}
+
+// ListFindings returns one page of the findings raised on a clinician's panel from
+// since on, most severe first, with the total number of findings
+func (s *PanelService) ListFindings(ctx context.Context, orgID, clinicianID string, since time.Time, page repository.Page) ([]model.PanelFinding, int, error) {
+	findings, total, err := s.store.ListFindings(ctx, orgID, clinicianID, since, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list panel findings: %w", err)
+	}
+	return findings, total, nil
+}
+
+// SubscribeDigest opts a clinician in to the daily email digest of their panel. With an
+// EmailVerifier set the address must be the clinician's confirmed one, otherwise it
+// fails with ErrEmailNotVerified.
+func (s *PanelService) SubscribeDigest(ctx context.Context, orgID, clinicianID, email string) (*model.PanelDigestSubscription, error) {
+	if err := s.requireRole(ctx, orgID, clinicianID, model.RoleClinician, ErrNotClinician); err != nil {
+		return nil, err
+	}
+
+	email, err := NormalizeEmail(email)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDigestEmail, err)
+	}
+
+	// Building the sender validates the address before it is stored
+	if _, err := s.digestSender(email); err != nil {
+		return nil, err
+	}
+	if err := s.requireVerified(ctx, clinicianID, email); err != nil {
+		return nil, err
+	}
+
+	subscription := &model.PanelDigestSubscription{
+		OrganizationID: orgID,
+		ClinicianID:    clinicianID,
+		Email:          email,
+	}
+	if err := s.store.SubscribeDigest(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("panel digest subscribed",
+		zap.String("organization_id", orgID),
+		zap.String("clinician_id", clinicianID),
+	)
+	return subscription, nil
+}
+
+// UnsubscribeDigest opts a clinician out of the daily email digest
+func (s *PanelService) UnsubscribeDigest(ctx context.Context, orgID, clinicianID string) error {
+	removed, err := s.store.UnsubscribeDigest(ctx, orgID, clinicianID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrDigestSubscriptionNotFound
+	}
+	return nil
+}
+
+// RunDigests periodically sends the digests that are due. It blocks until ctx is cancelled.
+func (s *PanelService) RunDigests(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.sendDueDigests(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendDueDigests sends every digest not sent within the last digest period
+func (s *PanelService) sendDueDigests(ctx context.Context) {
+	now := s.now()
+	subscriptions, err := s.store.FindDueDigests(ctx, now.Add(-panelDigestPeriod))
+	if err != nil {
+		s.logger.Error("failed to find due panel digests", zap.Error(err))
+		return
+	}
+
+	for i := range subscriptions {
+		if ctx.Err() != nil {
+			return
+		}
+		s.sendDigest(ctx, &subscriptions[i], now)
+	}
+}
+
+// sendDigest emails a clinician the top findings since their previous digest. A digest
+// without findings is not emailed but still counts as sent. Failed digests are retried
+// at the next check.
+func (s *PanelService) sendDigest(ctx context.Context, subscription *model.PanelDigestSubscription, now time.Time) {
+	logger := s.logger.With(
+		zap.String("organization_id", subscription.OrganizationID),
+		zap.String("clinician_id", subscription.ClinicianID),
+	)
+
+	since := now.Add(-panelDigestPeriod)
+	if subscription.LastSentAt != nil && subscription.LastSentAt.After(since) {
+		since = *subscription.LastSentAt
+	}
+
+	findings, total, err := s.store.ListFindings(ctx, subscription.OrganizationID, subscription.ClinicianID, since,
+		repository.Page{Limit: panelDigestMaxFindings})
+	if err != nil {
+		logger.Error("failed to list panel digest findings", zap.Error(err))
+		return
+	}
+
+	if total > 0 {
+		// The clinician may have changed their address since subscribing
+		if err := s.requireVerified(ctx, subscription.ClinicianID, subscription.Email); err != nil {
+			logger.Warn("panel digest not sent", zap.Error(err))
+			return
+		}
+
+		err := s.emailDigest(ctx, subscription.Email, PanelDigest{
+			OrganizationID: subscription.OrganizationID,
+			ClinicianID:    subscription.ClinicianID,
+			Since:          since,
+			GeneratedAt:    now,
+			TotalCount:     total,
+			Findings:       findings,
+		})
+		if err != nil {
+			logger.Warn("failed to send panel digest", zap.Error(err))
+			return
+		}
+	}
+
+	if err := s.store.MarkDigestSent(ctx, subscription.OrganizationID, subscription.ClinicianID, now); err != nil {
+		logger.Error("failed to mark panel digest sent", zap.Error(err))
+		return
+	}
+
+	logger.Info("panel digest processed", zap.Int("findings", total))
+}
+
+// emailDigest renders a digest and sends it to email
+func (s *PanelService) emailDigest(ctx context.Context, email string, digest PanelDigest) error {
+	sender, err := s.digestSender(email)
+	if err != nil {
+		return err
+	}
+	msg, err := s.digest.Render(digest)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultDeliveryTimeout)
+	defer cancel()
+	return sender.Send(ctx, msg)
+}
+
+// digestSender builds the email sender of a digest address
+func (s *PanelService) digestSender(email string) (delivery.Sender, error) {
+	sender, err := s.registry.Build(delivery.KindSMTP, map[string]string{"to": email})
+	switch {
+	case errors.Is(err, delivery.ErrUnknownKind):
+		return nil, ErrDigestUnavailable
+	case errors.Is(err, delivery.ErrInvalidSettings):
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDigestEmail, err)
+	case err != nil:
+		return nil, err
+	}
+	return sender, nil
+}
+
+// requireVerified returns ErrEmailNotVerified unless email is the user's confirmed
+// address. Without an EmailVerifier every address passes.
+func (s *PanelService) requireVerified(ctx context.Context, userID, email string) error {
+	if s.verifier == nil {
+		return nil
+	}
+	return s.verifier.RequireVerified(ctx, userID, email)
+}
+
+// requireRole returns notHeld unless the user holds role in the organization
+func (s *PanelService) requireRole(ctx context.Context, orgID, userID string, role model.Role, notHeld error) error {
+	roles, err := s.members.GetRolesByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, assignment := range roles {
+		if assignment.OrganizationID == orgID && assignment.Role == role {
+			return nil
+		}
+	}
+	return notHeld
+}
+
+// audit records a panel assignment change in the audit log
+func (s *PanelService) audit(ctx context.Context, actorID string, op audit.OperationType, orgID, clinicianID, patientID string) {
+	if s.auditLogger == nil || actorID == "" {
+		return
+	}
+
+	err := s.auditLogger.Log(ctx, audit.AuditLog{
+		UserID:        actorID,
+		OperationType: op,
+		ResourceType:  audit.ResourcePanelAssignment,
+		ResourceID:    orgID,
+		AdditionalData: map[string]interface{}{
+			"clinician_id": clinicianID,
+			"patient_id":   patientID,
+		},
+	})
+	if err != nil {
+		s.logger.Error("failed to audit panel assignment change", zap.Error(err), zap.String("organization_id", orgID))
+	}
+}
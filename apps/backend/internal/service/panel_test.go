@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/delivery"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// fakePanelStore is an in-memory PanelStore returning a fixed list of findings
+type fakePanelStore struct {
+	assignments   []model.PanelAssignment
+	subscriptions map[string]*model.PanelDigestSubscription // organization ID + "/" + clinician ID
+	findings      []model.PanelFinding
+	findingsSince []time.Time
+}
+
+func newFakePanelStore() *fakePanelStore {
+	return &fakePanelStore{subscriptions: make(map[string]*model.PanelDigestSubscription)}
+}
+
+func (f *fakePanelStore) AssignPatient(ctx context.Context, assignment *model.PanelAssignment) error {
+	f.assignments = append(f.assignments, *assignment)
+	return nil
+}
+
+func (f *fakePanelStore) UnassignPatient(ctx context.Context, orgID, clinicianID, patientID string) (bool, error) {
+	for i, a := range f.assignments {
+		if a.OrganizationID == orgID && a.ClinicianID == clinicianID && a.PatientID == patientID {
+			f.assignments = append(f.assignments[:i], f.assignments[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakePanelStore) ListAssignments(ctx context.Context, orgID, clinicianID string) ([]model.PanelAssignment, error) {
+	var result []model.PanelAssignment
+	for _, a := range f.assignments {
+		if a.OrganizationID == orgID && a.ClinicianID == clinicianID {
+			result = append(result, a)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakePanelStore) ListFindings(ctx context.Context, orgID, clinicianID string, since time.Time, page repository.Page) ([]model.PanelFinding, int, error) {
+	f.findingsSince = append(f.findingsSince, since)
+	findings := f.findings
+	if len(findings) > page.Limit {
+		findings = findings[:page.Limit]
+	}
+	return findings, len(f.findings), nil
+}
+
+func (f *fakePanelStore) SubscribeDigest(ctx context.Context, subscription *model.PanelDigestSubscription) error {
+	f.subscriptions[subscription.OrganizationID+"/"+subscription.ClinicianID] = subscription
+	return nil
+}
+
+func (f *fakePanelStore) UnsubscribeDigest(ctx context.Context, orgID, clinicianID string) (bool, error) {
+	key := orgID + "/" + clinicianID
+	_, had := f.subscriptions[key]
+	delete(f.subscriptions, key)
+	return had, nil
+}
+
+func (f *fakePanelStore) FindDueDigests(ctx context.Context, sentBefore time.Time) ([]model.PanelDigestSubscription, error) {
+	var result []model.PanelDigestSubscription
+	for _, s := range f.subscriptions {
+		if s.LastSentAt == nil || !s.LastSentAt.After(sentBefore) {
+			result = append(result, *s)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakePanelStore) MarkDigestSent(ctx context.Context, orgID, clinicianID string, sentAt time.Time) error {
+	f.subscriptions[orgID+"/"+clinicianID].LastSentAt = &sentAt
+	return nil
+}
+
+func newTestPanelService(t *testing.T) (*PanelService, *fakePanelStore, *fakeOrganizationStore, *recordingSender) {
+	t.Helper()
+	store := newFakePanelStore()
+	members := newFakeOrganizationStore()
+	members.roles = []model.RoleAssignment{
+		{OrganizationID: "org-1", UserID: "clinician-1", Role: model.RoleClinician},
+		{OrganizationID: "org-1", UserID: "patient-1", Role: model.RolePatient},
+		{OrganizationID: "org-2", UserID: "patient-2", Role: model.RolePatient},
+	}
+	sender := &recordingSender{}
+
+	registry := delivery.NewRegistry()
+	registry.Register(delivery.KindSMTP, func(settings map[string]string) (delivery.Sender, error) {
+		if settings["to"] == "" {
+			return nil, delivery.ErrInvalidSettings
+		}
+		return sender, nil
+	})
+
+	return NewPanelService(store, members, registry, zap.NewNop()), store, members, sender
+}
+
+func TestPanelService_AssignPatientRequiresRoles(t *testing.T) {
+	svc, store, _, _ := newTestPanelService(t)
+	ctx := context.Background()
+
+	_, err := svc.AssignPatient(ctx, "org-1", "patient-1", "patient-1", "admin")
+	assert.ErrorIs(t, err, ErrNotClinician)
+
+	_, err = svc.AssignPatient(ctx, "org-1", "clinician-1", "patient-2", "admin")
+	assert.ErrorIs(t, err, ErrNotPatient, "patients of other organizations cannot be assigned")
+
+	assignment, err := svc.AssignPatient(ctx, "org-1", "clinician-1", "patient-1", "admin")
+	require.NoError(t, err)
+	assert.Equal(t, "admin", assignment.AssignedBy)
+	assert.Len(t, store.assignments, 1)
+
+	require.NoError(t, svc.UnassignPatient(ctx, "org-1", "clinician-1", "patient-1", "admin"))
+	assert.ErrorIs(t, svc.UnassignPatient(ctx, "org-1", "clinician-1", "patient-1", "admin"), ErrPanelAssignmentNotFound)
+}
+
+func TestPanelService_SubscribeDigest(t *testing.T) {
+	svc, store, _, _ := newTestPanelService(t)
+	ctx := context.Background()
+
+	_, err := svc.SubscribeDigest(ctx, "org-2", "clinician-1", "dr@example.com")
+	assert.ErrorIs(t, err, ErrNotClinician)
+
+	_, err = svc.SubscribeDigest(ctx, "org-1", "clinician-1", "")
+	assert.ErrorIs(t, err, ErrInvalidDigestEmail)
+
+	_, err = svc.SubscribeDigest(ctx, "org-1", "clinician-1", "dr@example.com")
+	require.NoError(t, err)
+	assert.Len(t, store.subscriptions, 1)
+
+	unconfigured := NewPanelService(store, newFakeOrganizationStore(), delivery.NewRegistry(), zap.NewNop())
+	_, err = unconfigured.digestSender("dr@example.com")
+	assert.ErrorIs(t, err, ErrDigestUnavailable, "digests need the smtp kind")
+}
+
+func TestPanelService_DigestsRequireVerifiedEmail(t *testing.T) {
+	svc, store, _, sender := newTestPanelService(t)
+	verification, users, _, _ := newTestEmailVerificationService(t)
+	svc.SetEmailVerifier(verification)
+	ctx := context.Background()
+
+	_, err := svc.SubscribeDigest(ctx, "org-1", "clinician-1", "dr@example.com")
+	assert.ErrorIs(t, err, ErrEmailNotVerified)
+
+	verifiedAt := time.Now()
+	users.users["clinician-1"] = &model.User{ID: "clinician-1", Email: "dr@example.com", EmailVerifiedAt: &verifiedAt}
+	_, err = svc.SubscribeDigest(ctx, "org-1", "clinician-1", " DR@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "dr@example.com", store.subscriptions["org-1/clinician-1"].Email)
+
+	// A digest is not sent once the clinician changed to an unconfirmed address
+	users.users["clinician-1"].Email = "new@example.com"
+	users.users["clinician-1"].EmailVerifiedAt = nil
+	store.findings = []model.PanelFinding{{Kind: model.FindingKindAlert, Severity: model.FindingSeverityHigh, PatientID: "patient-1"}}
+	svc.sendDueDigests(ctx)
+	assert.Empty(t, sender.sent)
+}
+
+func TestPanelService_SendDueDigests(t *testing.T) {
+	svc, store, _, sender := newTestPanelService(t)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 2, 7, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	_, err := svc.SubscribeDigest(ctx, "org-1", "clinician-1", "dr@example.com")
+	require.NoError(t, err)
+
+	// Without findings nothing is emailed but the digest counts as sent
+	svc.sendDueDigests(ctx)
+	assert.Empty(t, sender.sent)
+	require.NotNil(t, store.subscriptions["org-1/clinician-1"].LastSentAt)
+
+	for i := 0; i < panelDigestMaxFindings+5; i++ {
+		store.findings = append(store.findings, model.PanelFinding{
+			Kind:       model.FindingKindExtractionIssue,
+			Severity:   model.FindingSeverityMedium,
+			PatientID:  "patient-1",
+			SourceID:   uuid.New().String(),
+			Detail:     "no_pain_with_severe_pain_symptom",
+			OccurredAt: now.Add(-time.Hour),
+		})
+	}
+	store.findings[0] = model.PanelFinding{
+		Kind:       model.FindingKindAlert,
+		Severity:   model.FindingSeverityCritical,
+		PatientID:  "patient-1",
+		SourceID:   "alert-1",
+		Detail:     "chest pain",
+		OccurredAt: now.Add(-2 * time.Hour),
+	}
+
+	svc.sendDueDigests(ctx)
+	assert.Empty(t, sender.sent, "a digest is sent once per day")
+
+	sentAt := now
+	now = now.Add(panelDigestPeriod)
+	svc.sendDueDigests(ctx)
+	require.Len(t, sender.sent, 1)
+	assert.Equal(t, sentAt, store.findingsSince[len(store.findingsSince)-1], "findings since the previous digest")
+
+	msg := sender.sent[0]
+	assert.Equal(t, "Patient panel digest 2026-03-03: 25 findings", msg.Subject)
+	assert.Contains(t, msg.Body, "25 findings on your patient panel since 2026-03-02. The 20 most urgent are listed.")
+	assert.Contains(t, msg.Body, "[critical] alert, patient patient-1, 2026-03-02: chest pain")
+}
+
+func TestPanelRepository_ListFindings(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := repository.NewPanelRepository(db, zap.NewNop())
+	now := time.Now().UTC().Truncate(time.Second)
+
+	orgID := uuid.New().String()
+	clinicianID := uuid.New().String()
+	patientID := uuid.New().String()
+	formerPatientID := uuid.New().String()
+	otherPatientID := uuid.New().String()
+
+	_, err := db.Exec(ctx, `INSERT INTO organizations (id, name) VALUES ($1, 'Clinic')`, orgID)
+	require.NoError(t, err)
+	for _, userID := range []string{patientID, otherPatientID} {
+		_, err = db.Exec(ctx, `INSERT INTO organization_roles (organization_id, user_id, role) VALUES ($1, $2, 'patient')`, orgID, userID)
+		require.NoError(t, err)
+	}
+	for _, userID := range []string{patientID, formerPatientID} {
+		require.NoError(t, repo.AssignPatient(ctx, &model.PanelAssignment{OrganizationID: orgID, ClinicianID: clinicianID, PatientID: userID}))
+	}
+
+	insertCheckIn := func(userID string, createdAt time.Time, fallback, lowConfidence bool, issues []string) {
+		var transcript *string
+		if fallback {
+			text := "raw transcript"
+			transcript = &text
+		}
+		_, err := db.Exec(ctx, `
+			INSERT INTO health_check_ins (id, user_id, check_in_date, raw_transcript, low_confidence, extraction_issues, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $7)`,
+			uuid.New().String(), userID, createdAt, transcript, lowConfidence, issues, createdAt)
+		require.NoError(t, err)
+	}
+	insertAlert := func(userID, severity string, createdAt time.Time, acknowledged bool) {
+		var acknowledgedAt *time.Time
+		if acknowledged {
+			acknowledgedAt = &createdAt
+		}
+		_, err := db.Exec(ctx, `
+			INSERT INTO alerts (user_id, severity, reason, source, acknowledged_at, created_at)
+			VALUES ($1, $2, 'chest pain', 'rule', $3, $4)`,
+			userID, severity, acknowledgedAt, createdAt)
+		require.NoError(t, err)
+	}
+
+	insertCheckIn(patientID, now.Add(-time.Hour), false, true, []string{})
+	insertCheckIn(patientID, now.Add(-2*time.Hour), true, false, []string{})
+	insertCheckIn(patientID, now.Add(-3*time.Hour), false, false, []string{"high_pain_without_symptoms"})
+	insertCheckIn(patientID, now.Add(-30*24*time.Hour), true, false, []string{})
+	insertAlert(patientID, "high", now.Add(-4*time.Hour), false)
+	insertAlert(patientID, "critical", now.Add(-5*time.Hour), false)
+	insertAlert(patientID, "critical", now.Add(-time.Hour), true)
+
+	// Patients who are not on the panel, or no longer patients of the organization, are left out
+	insertCheckIn(otherPatientID, now.Add(-time.Hour), true, false, []string{})
+	insertCheckIn(formerPatientID, now.Add(-time.Hour), true, false, []string{})
+
+	since := now.Add(-7 * 24 * time.Hour)
+	findings, total, err := repo.ListFindings(ctx, orgID, clinicianID, since, repository.Page{Limit: 3})
+	require.NoError(t, err)
+	assert.Equal(t, 5, total)
+	require.Len(t, findings, 3)
+	assert.Equal(t, model.FindingKindAlert, findings[0].Kind)
+	assert.Equal(t, model.FindingSeverityCritical, findings[0].Severity)
+	assert.Equal(t, model.FindingSeverityHigh, findings[1].Severity)
+	assert.Equal(t, model.FindingKindExtractionFailed, findings[2].Kind, "newer medium findings first")
+
+	rest, _, err := repo.ListFindings(ctx, orgID, clinicianID, since, repository.Page{Limit: 3, Offset: 3})
+	require.NoError(t, err)
+	require.Len(t, rest, 2)
+	assert.Equal(t, model.FindingKindExtractionIssue, rest[0].Kind)
+	assert.Equal(t, "high_pain_without_symptoms", rest[0].Detail)
+	assert.Equal(t, model.FindingKindLowConfidence, rest[1].Kind)
+	for _, finding := range append(findings, rest...) {
+		assert.Equal(t, patientID, finding.PatientID)
+	}
+}
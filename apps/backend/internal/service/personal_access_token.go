@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+const (
+	// personalAccessTokenBytes is the amount of randomness in a personal access token
+	personalAccessTokenBytes = 32
+
+	// personalAccessTokenPrefixLength is how much of a token is kept to identify it
+	personalAccessTokenPrefixLength = len(model.PersonalAccessTokenPrefix) + 8
+
+	// defaultPersonalAccessTokenTTL is how long a token stays valid without an expiry
+	defaultPersonalAccessTokenTTL = 90 * 24 * time.Hour
+
+	// maxPersonalAccessTokenTTL is the longest a token can stay valid
+	maxPersonalAccessTokenTTL = 365 * 24 * time.Hour
+)
+
+// ErrInvalidPersonalAccessToken is returned when a personal access token cannot be
+// created as requested
+var ErrInvalidPersonalAccessToken = errors.New("invalid personal access token")
+
+// PersonalAccessTokenStore defines the persistence operations needed for personal access tokens
+type PersonalAccessTokenStore interface {
+	CreateToken(ctx context.Context, token *model.PersonalAccessToken) error
+	GetTokenByHash(ctx context.Context, tokenHash string) (*model.PersonalAccessToken, error)
+	GetTokensByUserID(ctx context.Context, userID string) ([]model.PersonalAccessToken, error)
+	RevokeToken(ctx context.Context, userID, tokenID string, now time.Time) (bool, error)
+	TouchToken(ctx context.Context, tokenID string, now time.Time) error
+}
+
+// PersonalAccessTokenService manages the tokens users read their own data with
+// programmatically
+type PersonalAccessTokenService struct {
+	store       PersonalAccessTokenStore
+	auditLogger *audit.Logger
+	logger      *zap.Logger
+	now         func() time.Time
+}
+
+// NewPersonalAccessTokenService creates a new PersonalAccessTokenService
+func NewPersonalAccessTokenService(store PersonalAccessTokenStore, logger *zap.Logger) *PersonalAccessTokenService {
+	return &PersonalAccessTokenService{
+		store:  store,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// SetAuditLogger enables audit logging of token changes and use
+func (s *PersonalAccessTokenService) SetAuditLogger(auditLogger *audit.Logger) {
+	s.auditLogger = auditLogger
+}
+
+// CreateToken creates a personal access token with read-only scopes. A nil expiresAt
+// keeps the token valid for 90 days. The returned token is handed to the user once;
+// only its hash is stored, so it cannot be recovered later.
+func (s *PersonalAccessTokenService) CreateToken(ctx context.Context, userID, name string, scopes []model.TokenScope, expiresAt *time.Time) (*model.PersonalAccessToken, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", fmt.Errorf("%w: name is required", ErrInvalidPersonalAccessToken)
+	}
+	scopes, err := validTokenScopes(scopes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := s.now()
+	expiry := now.Add(defaultPersonalAccessTokenTTL)
+	if expiresAt != nil {
+		expiry = *expiresAt
+	}
+	if !expiry.After(now) {
+		return nil, "", fmt.Errorf("%w: expiry must be in the future", ErrInvalidPersonalAccessToken)
+	}
+	if expiry.After(now.Add(maxPersonalAccessTokenTTL)) {
+		return nil, "", fmt.Errorf("%w: tokens expire within a year", ErrInvalidPersonalAccessToken)
+	}
+
+	secret, err := newPersonalAccessToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	token := &model.PersonalAccessToken{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    secret[:personalAccessTokenPrefixLength],
+		TokenHash: hashPersonalAccessToken(secret),
+		Scopes:    scopes,
+		ExpiresAt: expiry.UTC(),
+	}
+	if err := s.store.CreateToken(ctx, token); err != nil {
+		return nil, "", err
+	}
+
+	s.audit(ctx, audit.AuditLog{
+		UserID:        userID,
+		OperationType: audit.OperationCreate,
+		ResourceType:  audit.ResourcePersonalAccessToken,
+		ResourceID:    token.ID,
+	})
+
+	return token, secret, nil
+}
+
+// ListTokens lists a user's personal access tokens, newest first
+func (s *PersonalAccessTokenService) ListTokens(ctx context.Context, userID string) ([]model.PersonalAccessToken, error) {
+	tokens, err := s.store.GetTokensByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tokens == nil {
+		tokens = []model.PersonalAccessToken{}
+	}
+	return tokens, nil
+}
+
+// RevokeToken revokes one of a user's personal access tokens. It fails with
+// repository.ErrPersonalAccessTokenNotFound when the user has no such token.
+func (s *PersonalAccessTokenService) RevokeToken(ctx context.Context, userID, tokenID string) error {
+	found, err := s.store.RevokeToken(ctx, userID, tokenID, s.now())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return repository.ErrPersonalAccessTokenNotFound
+	}
+
+	s.audit(ctx, audit.AuditLog{
+		UserID:        userID,
+		OperationType: audit.OperationDelete,
+		ResourceType:  audit.ResourcePersonalAccessToken,
+		ResourceID:    tokenID,
+	})
+
+	return nil
+}
+
+// LookupToken returns the personal access token presented by a request, including
+// revoked and expired ones so callers can tell why it is rejected. It returns nil for
+// unknown tokens.
+func (s *PersonalAccessTokenService) LookupToken(ctx context.Context, secret string) (*model.PersonalAccessToken, error) {
+	token, err := s.store.GetTokenByHash(ctx, hashPersonalAccessToken(secret))
+	if errors.Is(err, repository.ErrPersonalAccessTokenNotFound) {
+		return nil, nil
+	}
+	return token, err
+}
+
+// RecordTokenUse updates the last use of a token that authenticated a request and
+// audits the request. Failures are logged rather than failing the request.
+func (s *PersonalAccessTokenService) RecordTokenUse(ctx context.Context, token *model.PersonalAccessToken, ipAddress, userAgent, path string) {
+	if err := s.store.TouchToken(ctx, token.ID, s.now()); err != nil {
+		s.logger.Error("failed to record personal access token use", zap.Error(err), zap.String("token_id", token.ID))
+	}
+
+	s.audit(ctx, audit.AuditLog{
+		UserID:         token.UserID,
+		OperationType:  audit.OperationRead,
+		ResourceType:   audit.ResourcePersonalAccessToken,
+		ResourceID:     token.ID,
+		IPAddress:      ipAddress,
+		UserAgent:      userAgent,
+		AdditionalData: map[string]interface{}{"path": path},
+	})
+}
+
+// audit records a token change or use in the audit log
+func (s *PersonalAccessTokenService) audit(ctx context.Context, entry audit.AuditLog) {
+	if s.auditLogger == nil {
+		return
+	}
+
+	if err := s.auditLogger.Log(ctx, entry); err != nil {
+		s.logger.Error("failed to audit personal access token", zap.Error(err), zap.String("token_id", entry.ResourceID))
+	}
+}
+
+// validTokenScopes checks every requested scope is known and drops duplicates
+func validTokenScopes(scopes []model.TokenScope) ([]model.TokenScope, error) {
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", ErrInvalidPersonalAccessToken)
+	}
+
+	var valid []model.TokenScope
+	for _, scope := range scopes {
+		if !slices.Contains(model.TokenScopes, scope) {
+			return nil, fmt.Errorf("%w: unknown scope %q", ErrInvalidPersonalAccessToken, scope)
+		}
+		if !slices.Contains(valid, scope) {
+			valid = append(valid, scope)
+		}
+	}
+	return valid, nil
+}
+
+// newPersonalAccessToken returns a random personal access token
+func newPersonalAccessToken() (string, error) {
+	b := make([]byte, personalAccessTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate personal access token: %w", err)
+	}
+	return model.PersonalAccessTokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashPersonalAccessToken returns the stored form of a personal access token
+func hashPersonalAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
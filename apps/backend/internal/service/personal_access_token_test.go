@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// fakeTokenStore is an in-memory PersonalAccessTokenStore
+type fakeTokenStore struct {
+	tokens []*model.PersonalAccessToken
+}
+
+func (f *fakeTokenStore) CreateToken(ctx context.Context, token *model.PersonalAccessToken) error {
+	token.CreatedAt = time.Now()
+	f.tokens = append(f.tokens, token)
+	return nil
+}
+
+func (f *fakeTokenStore) GetTokenByHash(ctx context.Context, tokenHash string) (*model.PersonalAccessToken, error) {
+	for _, token := range f.tokens {
+		if token.TokenHash == tokenHash {
+			return token, nil
+		}
+	}
+	return nil, repository.ErrPersonalAccessTokenNotFound
+}
+
+func (f *fakeTokenStore) GetTokensByUserID(ctx context.Context, userID string) ([]model.PersonalAccessToken, error) {
+	var result []model.PersonalAccessToken
+	for _, token := range f.tokens {
+		if token.UserID == userID {
+			result = append(result, *token)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeTokenStore) RevokeToken(ctx context.Context, userID, tokenID string, now time.Time) (bool, error) {
+	for _, token := range f.tokens {
+		if token.ID == tokenID && token.UserID == userID {
+			if token.RevokedAt == nil {
+				token.RevokedAt = &now
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeTokenStore) TouchToken(ctx context.Context, tokenID string, now time.Time) error {
+	for _, token := range f.tokens {
+		if token.ID == tokenID {
+			token.LastUsedAt = &now
+		}
+	}
+	return nil
+}
+
+func TestPersonalAccessTokenService_CreateAndLookup(t *testing.T) {
+	ctx := context.Background()
+	store := &fakeTokenStore{}
+	svc := NewPersonalAccessTokenService(store, zap.NewNop())
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	userID := uuid.New().String()
+
+	token, secret, err := svc.CreateToken(ctx, userID, " Spreadsheet ", []model.TokenScope{
+		model.TokenScopeHealthRead, model.TokenScopeHealthRead, model.TokenScopeExportRead,
+	}, nil)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(secret, model.PersonalAccessTokenPrefix))
+	assert.True(t, strings.HasPrefix(secret, token.Prefix))
+	assert.Len(t, token.Prefix, len(model.PersonalAccessTokenPrefix)+8)
+	assert.NotContains(t, token.TokenHash, secret, "only the hash is stored")
+	assert.Equal(t, "Spreadsheet", token.Name)
+	assert.Equal(t, []model.TokenScope{model.TokenScopeHealthRead, model.TokenScopeExportRead}, token.Scopes)
+	assert.Equal(t, now.Add(defaultPersonalAccessTokenTTL), token.ExpiresAt)
+
+	found, err := svc.LookupToken(ctx, secret)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, token.ID, found.ID)
+
+	unknown, err := svc.LookupToken(ctx, model.PersonalAccessTokenPrefix+"unknown")
+	require.NoError(t, err)
+	assert.Nil(t, unknown)
+
+	svc.RecordTokenUse(ctx, found, "127.0.0.1", "curl", "/api/v1/health/blood-pressure")
+	require.NotNil(t, store.tokens[0].LastUsedAt)
+	assert.Equal(t, now, *store.tokens[0].LastUsedAt)
+}
+
+func TestPersonalAccessTokenService_CreateToken_Invalid(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	tooLate := now.Add(maxPersonalAccessTokenTTL + time.Hour)
+
+	tests := []struct {
+		name      string
+		tokenName string
+		scopes    []model.TokenScope
+		expiresAt *time.Time
+	}{
+		{"no name", " ", []model.TokenScope{model.TokenScopeHealthRead}, nil},
+		{"no scopes", "token", nil, nil},
+		{"unknown scope", "token", []model.TokenScope{"health:write"}, nil},
+		{"expiry in the past", "token", []model.TokenScope{model.TokenScopeHealthRead}, &past},
+		{"expiry beyond a year", "token", []model.TokenScope{model.TokenScopeHealthRead}, &tooLate},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &fakeTokenStore{}
+			svc := NewPersonalAccessTokenService(store, zap.NewNop())
+			svc.now = func() time.Time { return now }
+
+			_, _, err := svc.CreateToken(context.Background(), uuid.New().String(), tc.tokenName, tc.scopes, tc.expiresAt)
+			assert.ErrorIs(t, err, ErrInvalidPersonalAccessToken)
+			assert.Empty(t, store.tokens)
+		})
+	}
+}
+
+func TestPersonalAccessTokenService_RevokeToken(t *testing.T) {
+	ctx := context.Background()
+	svc := NewPersonalAccessTokenService(&fakeTokenStore{}, zap.NewNop())
+	userID := uuid.New().String()
+
+	token, secret, err := svc.CreateToken(ctx, userID, "token", []model.TokenScope{model.TokenScopeReportsRead}, nil)
+	require.NoError(t, err)
+
+	err = svc.RevokeToken(ctx, uuid.New().String(), token.ID)
+	assert.ErrorIs(t, err, repository.ErrPersonalAccessTokenNotFound, "other users cannot revoke the token")
+
+	require.NoError(t, svc.RevokeToken(ctx, userID, token.ID))
+	found, err := svc.LookupToken(ctx, secret)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.NotNil(t, found.RevokedAt)
+
+	tokens, err := svc.ListTokens(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.NotNil(t, tokens[0].RevokedAt)
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// MedicationListSource defines the interface for the medications plausibility rules compare against
+type MedicationListSource interface {
+	FindByUserID(ctx context.Context, userID string) ([]model.Medication, error)
+}
+
+// plausibilityInput is what plausibility rules see of a check-in
+type plausibilityInput struct {
+	Data *ExtractedData
+	// ActiveMedications is the number of the user's active medications, -1 if unknown
+	ActiveMedications int
+}
+
+// plausibilityRule flags a medically implausible combination of extracted fields.
+// Rules do not block saving; flagged codes are stored in extraction_issues.
+type plausibilityRule struct {
+	Code  string
+	Check func(in plausibilityInput) bool
+}
+
+// plausibilityRules are evaluated in order after every successful extraction
+var plausibilityRules = []plausibilityRule{
+	{Code: "no_pain_with_severe_pain_symptom", Check: noPainWithSeverePainSymptom},
+	{Code: "high_pain_without_symptoms", Check: highPainWithoutSymptoms},
+	{Code: "medication_taken_without_medications", Check: medicationTakenWithoutMedications},
+	{Code: "positive_mood_with_severe_pain", Check: positiveMoodWithSeverePain},
+}
+
+// severePainTerms are symptom fragments describing strong pain, in Hungarian and English
+var severePainTerms = []string{
+	"erős fájdalom",
+	"erős fájdalm",
+	"nagyon fáj",
+	"elviselhetetlen",
+	"szörnyű fájdalom",
+	"severe pain",
+	"unbearable",
+}
+
+// noPainWithSeverePainSymptom flags pain_level 0 alongside a symptom describing strong pain
+func noPainWithSeverePainSymptom(in plausibilityInput) bool {
+	if in.Data.PainLevel == nil || *in.Data.PainLevel != 0 {
+		return false
+	}
+	for _, symptom := range in.Data.Symptoms {
+		symptom = strings.ToLower(symptom)
+		for _, term := range severePainTerms {
+			if strings.Contains(symptom, term) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// highPainWithoutSymptoms flags a pain level of 7 or more with no symptom mentioned
+func highPainWithoutSymptoms(in plausibilityInput) bool {
+	return in.Data.PainLevel != nil && *in.Data.PainLevel >= 7 && len(in.Data.Symptoms) == 0
+}
+
+// medicationTakenWithoutMedications flags taking medication when the user has no active medications
+func medicationTakenWithoutMedications(in plausibilityInput) bool {
+	if in.ActiveMedications != 0 {
+		return false
+	}
+	return in.Data.MedicationTaken == model.MedicationTakenYes || in.Data.MedicationTaken == model.MedicationTakenSome
+}
+
+// positiveMoodWithSeverePain flags a positive mood alongside a pain level of 9 or more
+func positiveMoodWithSeverePain(in plausibilityInput) bool {
+	return in.Data.Mood == "positive" && in.Data.PainLevel != nil && *in.Data.PainLevel >= 9
+}
+
+// evaluatePlausibility returns the codes of the rules that flag the input, in rule order
+func evaluatePlausibility(rules []plausibilityRule, in plausibilityInput) []string {
+	issues := []string{}
+	for _, rule := range rules {
+		if rule.Check(in) {
+			issues = append(issues, rule.Code)
+		}
+	}
+	return issues
+}
+
+// checkPlausibility runs the plausibility rules on extracted data, counts and logs the
+// flags, and returns the flagged rule codes
+func (s *CheckInService) checkPlausibility(ctx context.Context, userID string, data *ExtractedData) []string {
+	in := plausibilityInput{Data: data, ActiveMedications: -1}
+	if s.medications != nil {
+		medications, err := s.medications.FindByUserID(ctx, userID)
+		if err != nil {
+			s.logger.Warn("failed to get medications for plausibility check", zap.Error(err), zap.String("user_id", userID))
+		} else {
+			in.ActiveMedications = countActiveMedications(medications, time.Now())
+		}
+	}
+
+	issues := evaluatePlausibility(plausibilityRules, in)
+	s.extractionIssues.record(issues)
+	if len(issues) > 0 {
+		s.logger.Warn("implausible extraction flagged",
+			zap.String("user_id", userID),
+			zap.Strings("extraction_issues", issues),
+		)
+	}
+	return issues
+}
+
+// countActiveMedications counts the medications that are active and not ended at now
+func countActiveMedications(medications []model.Medication, now time.Time) int {
+	var count int
+	for _, medication := range medications {
+		if medication.Active && (medication.EndDate == nil || !medication.EndDate.Before(now)) {
+			count++
+		}
+	}
+	return count
+}
+
+// extractionIssueCounter counts plausibility flags per rule since the service started
+type extractionIssueCounter struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+// record counts one occurrence of each issue
+func (c *extractionIssueCounter) record(issues []string) {
+	if len(issues) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counts == nil {
+		c.counts = make(map[string]int64)
+	}
+	for _, issue := range issues {
+		c.counts[issue]++
+	}
+}
+
+// Snapshot returns the count of every rule, including rules that never fired
+func (c *extractionIssueCounter) Snapshot() map[string]int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	snapshot := make(map[string]int64, len(plausibilityRules))
+	for _, rule := range plausibilityRules {
+		snapshot[rule.Code] = c.counts[rule.Code]
+	}
+	return snapshot
+}
+
+// ExtractionQuality holds extraction outcome rates of one prompt version. Rates other than
+// the fallback rate are shares of the successfully extracted check-ins.
+type ExtractionQuality struct {
+	PromptVersion     string             `json:"prompt_version"`
+	CheckIns          int                `json:"check_ins"`
+	FallbackRate      float64            `json:"fallback_rate"`
+	LowConfidenceRate float64            `json:"low_confidence_rate"`
+	FlaggedRate       float64            `json:"flagged_rate"`
+	IssueRates        map[string]float64 `json:"issue_rates"`
+}
+
+// ExtractionQualityReport summarizes extraction quality per prompt version
+type ExtractionQualityReport struct {
+	Since    time.Time           `json:"since"`
+	Versions []ExtractionQuality `json:"versions"`
+	// IssuesSinceStart counts plausibility flags per rule since the service started
+	IssuesSinceStart map[string]int64 `json:"issues_since_start"`
+}
+
+// GetExtractionQuality reports extraction quality per prompt version over the last days
+// days, reading from the read replica when one is healthy
+func (s *CheckInService) GetExtractionQuality(ctx context.Context, days int) (*ExtractionQualityReport, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CheckInService.GetExtractionQuality")
+	defer span.End()
+
+	since := time.Now().AddDate(0, 0, -days)
+
+	counts, err := s.repo.GetExtractionQuality(repository.WithReadReplica(ctx), since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get extraction quality: %w", err)
+	}
+
+	report := &ExtractionQualityReport{
+		Since:            since,
+		Versions:         make([]ExtractionQuality, 0, len(counts)),
+		IssuesSinceStart: s.extractionIssues.Snapshot(),
+	}
+	for _, c := range counts {
+		report.Versions = append(report.Versions, extractionQuality(c))
+	}
+	return report, nil
+}
+
+// extractionQuality converts the extraction outcome counts of a prompt version to rates.
+// Every plausibility rule is listed in the issue rates, including rules that never fired.
+func extractionQuality(counts repository.ExtractionQualityCounts) ExtractionQuality {
+	quality := ExtractionQuality{
+		PromptVersion: counts.PromptVersion,
+		CheckIns:      counts.CheckIns,
+		IssueRates:    make(map[string]float64, len(plausibilityRules)),
+	}
+	for _, rule := range plausibilityRules {
+		quality.IssueRates[rule.Code] = 0
+	}
+
+	if counts.CheckIns > 0 {
+		quality.FallbackRate = float64(counts.Fallbacks) / float64(counts.CheckIns)
+	}
+
+	extracted := counts.CheckIns - counts.Fallbacks
+	if extracted <= 0 {
+		return quality
+	}
+	quality.LowConfidenceRate = float64(counts.LowConfidence) / float64(extracted)
+	quality.FlaggedRate = float64(counts.Flagged) / float64(extracted)
+	for issue, count := range counts.Issues {
+		quality.IssueRates[issue] = float64(count) / float64(extracted)
+	}
+	return quality
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+)
+
+func TestNoPainWithSeverePainSymptom(t *testing.T) {
+	tests := []struct {
+		name     string
+		pain     *int
+		symptoms []string
+		flagged  bool
+	}{
+		{"zero pain with strong pain symptom", intPtr(0), []string{"Erős fájdalom a hátban"}, true},
+		{"zero pain with unbearable pain", intPtr(0), []string{"elviselhetetlen fejfájás"}, true},
+		{"zero pain with mild symptom", intPtr(0), []string{"enyhe fejfájás"}, false},
+		{"pain reported with strong pain symptom", intPtr(8), []string{"erős fájdalom"}, false},
+		{"no pain level", nil, []string{"erős fájdalom"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := plausibilityInput{Data: &ExtractedData{PainLevel: tt.pain, Symptoms: tt.symptoms}}
+			assert.Equal(t, tt.flagged, noPainWithSeverePainSymptom(in))
+		})
+	}
+}
+
+func TestHighPainWithoutSymptoms(t *testing.T) {
+	tests := []struct {
+		name     string
+		pain     *int
+		symptoms []string
+		flagged  bool
+	}{
+		{"high pain without symptoms", intPtr(7), []string{}, true},
+		{"high pain with symptom", intPtr(9), []string{"migrén"}, false},
+		{"moderate pain without symptoms", intPtr(6), []string{}, false},
+		{"no pain level", nil, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := plausibilityInput{Data: &ExtractedData{PainLevel: tt.pain, Symptoms: tt.symptoms}}
+			assert.Equal(t, tt.flagged, highPainWithoutSymptoms(in))
+		})
+	}
+}
+
+func TestMedicationTakenWithoutMedications(t *testing.T) {
+	tests := []struct {
+		name              string
+		medicationTaken   string
+		activeMedications int
+		flagged           bool
+	}{
+		{"taken without medications", model.MedicationTakenYes, 0, true},
+		{"some taken without medications", model.MedicationTakenSome, 0, true},
+		{"not taken without medications", model.MedicationTakenNo, 0, false},
+		{"taken with medications", model.MedicationTakenYes, 2, false},
+		{"medications unknown", model.MedicationTakenYes, -1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := plausibilityInput{
+				Data:              &ExtractedData{MedicationTaken: tt.medicationTaken},
+				ActiveMedications: tt.activeMedications,
+			}
+			assert.Equal(t, tt.flagged, medicationTakenWithoutMedications(in))
+		})
+	}
+}
+
+func TestPositiveMoodWithSeverePain(t *testing.T) {
+	tests := []struct {
+		name    string
+		mood    string
+		pain    *int
+		flagged bool
+	}{
+		{"positive mood with severe pain", "positive", intPtr(9), true},
+		{"negative mood with severe pain", "negative", intPtr(10), false},
+		{"positive mood with moderate pain", "positive", intPtr(5), false},
+		{"positive mood without pain level", "positive", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := plausibilityInput{Data: &ExtractedData{Mood: tt.mood, PainLevel: tt.pain}}
+			assert.Equal(t, tt.flagged, positiveMoodWithSeverePain(in))
+		})
+	}
+}
+
+func TestEvaluatePlausibility(t *testing.T) {
+	t.Run("returns flagged codes in rule order", func(t *testing.T) {
+		in := plausibilityInput{
+			Data: &ExtractedData{
+				Mood:            "positive",
+				PainLevel:       intPtr(9),
+				Symptoms:        []string{},
+				MedicationTaken: model.MedicationTakenYes,
+			},
+			ActiveMedications: 0,
+		}
+
+		assert.Equal(t, []string{
+			"high_pain_without_symptoms",
+			"medication_taken_without_medications",
+			"positive_mood_with_severe_pain",
+		}, evaluatePlausibility(plausibilityRules, in))
+	})
+
+	t.Run("plausible data has no issues", func(t *testing.T) {
+		in := plausibilityInput{
+			Data: &ExtractedData{
+				Mood:            "neutral",
+				PainLevel:       intPtr(3),
+				Symptoms:        []string{"fejfájás"},
+				MedicationTaken: model.MedicationTakenYes,
+			},
+			ActiveMedications: 1,
+		}
+
+		issues := evaluatePlausibility(plausibilityRules, in)
+		assert.NotNil(t, issues)
+		assert.Empty(t, issues)
+	})
+
+	t.Run("rule codes are unique", func(t *testing.T) {
+		seen := make(map[string]bool)
+		for _, rule := range plausibilityRules {
+			assert.False(t, seen[rule.Code], rule.Code)
+			seen[rule.Code] = true
+		}
+	})
+}
+
+func TestCountActiveMedications(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	ended := now.AddDate(0, 0, -1)
+	ending := now.AddDate(0, 0, 7)
+
+	medications := []model.Medication{
+		{Active: true},
+		{Active: true, EndDate: &ending},
+		{Active: true, EndDate: &ended},
+		{Active: false},
+	}
+
+	assert.Equal(t, 2, countActiveMedications(medications, now))
+}
+
+func TestExtractionIssueCounter(t *testing.T) {
+	var counter extractionIssueCounter
+	counter.record([]string{"high_pain_without_symptoms"})
+	counter.record([]string{"high_pain_without_symptoms", "positive_mood_with_severe_pain"})
+	counter.record(nil)
+
+	snapshot := counter.Snapshot()
+	assert.Len(t, snapshot, len(plausibilityRules))
+	assert.Equal(t, int64(2), snapshot["high_pain_without_symptoms"])
+	assert.Equal(t, int64(1), snapshot["positive_mood_with_severe_pain"])
+	assert.Equal(t, int64(0), snapshot["no_pain_with_severe_pain_symptom"])
+}
+
+func TestExtractionQuality(t *testing.T) {
+	quality := extractionQuality(repository.ExtractionQualityCounts{
+		PromptVersion: "2",
+		CheckIns:      12,
+		Fallbacks:     2,
+		LowConfidence: 1,
+		Flagged:       3,
+		Issues:        map[string]int{"high_pain_without_symptoms": 2, "positive_mood_with_severe_pain": 1},
+	})
+
+	assert.Equal(t, "2", quality.PromptVersion)
+	assert.Equal(t, 12, quality.CheckIns)
+	assert.InDelta(t, 2.0/12, quality.FallbackRate, 1e-9)
+	assert.InDelta(t, 0.1, quality.LowConfidenceRate, 1e-9)
+	assert.InDelta(t, 0.3, quality.FlaggedRate, 1e-9)
+	assert.InDelta(t, 0.2, quality.IssueRates["high_pain_without_symptoms"], 1e-9)
+	assert.InDelta(t, 0.1, quality.IssueRates["positive_mood_with_severe_pain"], 1e-9)
+	assert.Contains(t, quality.IssueRates, "no_pain_with_severe_pain_symptom")
+
+	t.Run("only fallbacks", func(t *testing.T) {
+		quality := extractionQuality(repository.ExtractionQualityCounts{CheckIns: 2, Fallbacks: 2})
+		assert.Equal(t, 1.0, quality.FallbackRate)
+		assert.Zero(t, quality.FlaggedRate)
+	})
+}
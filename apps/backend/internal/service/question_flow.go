@@ -2,6 +2,12 @@ package service
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 )
 
 // QuestionType represents the type of question
@@ -13,18 +19,29 @@ const (
 	QuestionTypeYesNo     QuestionType = "yes_no"
 )
 
-// Question represents a health question in the conversation flow
+// Structured answers to yes_no questions, as referenced by show_if conditions
+const (
+	AnswerYes = "yes"
+	AnswerNo  = "no"
+)
+
+// Question represents a health question in the conversation flow. A question with
+// ShowIf conditions is skipped unless all of them hold.
 type Question struct {
 	ID       string
 	TextHU   string
 	Type     QuestionType
 	Required bool
+	ShowIf   []model.QuestionCondition
 }
 
 // QuestionFlow manages the sequence of health questions
 type QuestionFlow struct {
 	questions []Question
 	current   int
+	asked     []string          // IDs of the questions asked, in order
+	answers   map[string]string // answers recorded by question ID
+	setID     string            // empty for the built-in question set
 }
 
 // NewQuestionFlow creates a new QuestionFlow with the Hungarian question set
@@ -83,18 +100,85 @@ func NewQuestionFlow() *QuestionFlow {
 	return &QuestionFlow{
 		questions: questions,
 		current:   0,
+		answers:   make(map[string]string),
+	}
+}
+
+// NewQuestionFlowFromSet creates a QuestionFlow asking a stored question set, or the
+// built-in question set when set is nil
+func NewQuestionFlowFromSet(set *model.QuestionSet) *QuestionFlow {
+	if set == nil {
+		return NewQuestionFlow()
+	}
+
+	questions := make([]Question, len(set.Questions))
+	for i, question := range set.Questions {
+		questions[i] = Question{
+			ID:       question.ID,
+			TextHU:   question.Text,
+			Type:     QuestionType(question.Type),
+			Required: question.Required,
+			ShowIf:   question.ShowIf,
+		}
+	}
+
+	return &QuestionFlow{
+		questions: questions,
+		answers:   make(map[string]string),
+		setID:     set.ID,
+	}
+}
+
+// audioID identifies a question's audio. Questions of stored sets are namespaced by
+// their set, whose question IDs may repeat those of other sets with another text.
+func (qf *QuestionFlow) audioID(questionID string) string {
+	if qf.setID == "" {
+		return questionID
 	}
+	return qf.setID + "/" + questionID
 }
 
-// GetNextQuestion returns the next question in the flow
+// GetNextQuestion returns the next question in the flow, skipping questions whose
+// conditions do not hold for the answers recorded so far
 func (qf *QuestionFlow) GetNextQuestion() *Question {
-	if qf.current >= len(qf.questions) {
-		return nil
+	for qf.current < len(qf.questions) {
+		question := &qf.questions[qf.current]
+		qf.current++
+		if show, _ := qf.evaluate(question, nil); show {
+			qf.asked = append(qf.asked, question.ID)
+			return question
+		}
 	}
+	return nil
+}
 
-	question := &qf.questions[qf.current]
-	qf.current++
-	return question
+// RecordAnswer records the answer to a question for the conditions of later questions.
+// Several answers to the same question are joined.
+func (qf *QuestionFlow) RecordAnswer(questionID, answer string) {
+	if previous, ok := qf.answers[questionID]; ok {
+		answer = previous + " " + answer
+	}
+	qf.answers[questionID] = answer
+}
+
+// Replay advances the flow over the stored messages of a session, recording the user's
+// answers to the scripted questions, and returns the question asked last, or nil when
+// none was asked. Answers to follow-up questions are not recorded.
+func (qf *QuestionFlow) Replay(messages []model.Message) *Question {
+	var current *Question
+	answering := false
+	for _, msg := range messages {
+		switch {
+		case msg.Role == model.MessageRoleAssistant && msg.IsFollowUp:
+			answering = false
+		case msg.Role == model.MessageRoleAssistant:
+			current = qf.GetNextQuestion()
+			answering = current != nil
+		case msg.Role == model.MessageRoleUser && answering:
+			qf.RecordAnswer(current.ID, msg.Content)
+		}
+	}
+	return current
 }
 
 // GetQuestionByID returns a question by its ID
@@ -120,11 +204,116 @@ func (qf *QuestionFlow) IsComplete() bool {
 // Reset resets the question flow to the beginning
 func (qf *QuestionFlow) Reset() {
 	qf.current = 0
+	qf.asked = nil
+	qf.answers = make(map[string]string)
 }
 
-// GetTotalQuestions returns the total number of questions
+// GetTotalQuestions returns the number of questions on the evaluated path: the
+// questions asked so far and those still to come whose conditions hold or depend on
+// answers not given yet. It shrinks as answers rule branches out.
 func (qf *QuestionFlow) GetTotalQuestions() int {
-	return len(qf.questions)
+	// Questions that may still be answered leave the conditions on them undecided
+	pending := make(map[string]bool)
+	if n := len(qf.asked); n > 0 {
+		if _, answered := qf.answers[qf.asked[n-1]]; !answered {
+			pending[qf.asked[n-1]] = true
+		}
+	}
+
+	total := len(qf.asked)
+	for i := qf.current; i < len(qf.questions); i++ {
+		question := &qf.questions[i]
+		if show, decided := qf.evaluate(question, pending); show || !decided {
+			pending[question.ID] = true
+			total++
+		}
+	}
+	return total
+}
+
+// evaluate reports whether a question is asked for the answers recorded so far.
+// decided is false when no condition fails but one references a pending question,
+// whose answer is not known yet. Unanswered questions that are not pending were
+// skipped, and conditions on them fail.
+func (qf *QuestionFlow) evaluate(question *Question, pending map[string]bool) (show bool, decided bool) {
+	decided = true
+	for _, condition := range question.ShowIf {
+		answer, answered := qf.answers[condition.QuestionID]
+		if !answered {
+			if pending[condition.QuestionID] {
+				decided = false
+				continue
+			}
+			return false, true
+		}
+		if !conditionHolds(condition, answer) {
+			return false, true
+		}
+	}
+	return decided, decided
+}
+
+// conditionHolds reports whether an answer satisfies a show_if condition
+func conditionHolds(condition model.QuestionCondition, answer string) bool {
+	switch {
+	case condition.Answer != "":
+		return parseYesNo(answer) == condition.Answer
+	case condition.Min != nil || condition.Max != nil:
+		value, ok := parseNumber(answer)
+		if !ok {
+			return false
+		}
+		return (condition.Min == nil || value >= *condition.Min) && (condition.Max == nil || value <= *condition.Max)
+	default:
+		lower := strings.ToLower(answer)
+		for _, keyword := range condition.Keywords {
+			if strings.Contains(lower, strings.ToLower(keyword)) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// yesNoWords maps the words answering a yes_no question to the structured answer
+var yesNoWords = map[string]string{
+	"igen":   AnswerYes,
+	"persze": AnswerYes,
+	"aha":    AnswerYes,
+	"yes":    AnswerYes,
+	"nem":    AnswerNo,
+	"dehogy": AnswerNo,
+	"no":     AnswerNo,
+}
+
+// parseYesNo returns the structured answer to a yes_no question, decided by its first
+// yes or no word, or "" when it has none
+func parseYesNo(answer string) string {
+	words := strings.FieldsFunc(strings.ToLower(answer), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		if structured, ok := yesNoWords[word]; ok {
+			return structured
+		}
+	}
+	return ""
+}
+
+// numberPattern matches a number with a decimal point or the Hungarian decimal comma
+var numberPattern = regexp.MustCompile(`-?\d+(?:[.,]\d+)?`)
+
+// parseNumber returns the first number in the answer to a numeric question
+func parseNumber(answer string) (float64, bool) {
+	match := numberPattern.FindString(answer)
+	if match == "" {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(strings.Replace(match, ",", ".", 1), 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
 }
 
 // ValidateResponse validates a response based on the question type
@@ -1,7 +1,10 @@
 package service
 
 import (
+	"reflect"
 	"testing"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 )
 
 func TestQuestionFlow_GetNextQuestion(t *testing.T) {
@@ -120,3 +123,146 @@ func TestQuestionFlow_GetTotalQuestions(t *testing.T) {
 		t.Errorf("expected 8 questions, got %d", total)
 	}
 }
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+// painQuestionSet asks about pain in detail only when the user has some
+func painQuestionSet() *model.QuestionSet {
+	return &model.QuestionSet{
+		ID:   "pain-set",
+		Name: "Pain",
+		Questions: []model.QuestionSetQuestion{
+			{ID: "feeling", Text: "Hogy érzed magad ma?", Type: string(QuestionTypeOpenEnded), Required: true},
+			{ID: "pain", Text: "Fáj valamid?", Type: string(QuestionTypeYesNo), Required: true},
+			{ID: "pain_where", Text: "Hol fáj?", Type: string(QuestionTypeOpenEnded), Required: true,
+				ShowIf: []model.QuestionCondition{{QuestionID: "pain", Answer: AnswerYes}}},
+			{ID: "pain_scale", Text: "Mennyire fáj egy 1-10 skálán?", Type: string(QuestionTypeNumeric), Required: true,
+				ShowIf: []model.QuestionCondition{{QuestionID: "pain", Answer: AnswerYes}}},
+			{ID: "pain_doctor", Text: "Voltál orvosnál miatta?", Type: string(QuestionTypeYesNo), Required: true,
+				ShowIf: []model.QuestionCondition{{QuestionID: "pain_scale", Min: float64Ptr(7)}}},
+			{ID: "headache_light", Text: "Zavar a fény?", Type: string(QuestionTypeYesNo), Required: true,
+				ShowIf: []model.QuestionCondition{
+					{QuestionID: "pain_where", Keywords: []string{"fej"}},
+					{QuestionID: "pain_scale", Max: float64Ptr(10)},
+				}},
+			{ID: "notes", Text: "Van még valami?", Type: string(QuestionTypeOpenEnded)},
+		},
+	}
+}
+
+func TestQuestionFlow_Branching(t *testing.T) {
+	tests := []struct {
+		name    string
+		answers map[string]string
+		path    []string
+	}{
+		{
+			name:    "no pain",
+			answers: map[string]string{"pain": "Nem, semmi"},
+			path:    []string{"feeling", "pain", "notes"},
+		},
+		{
+			name:    "undecided pain answer",
+			answers: map[string]string{"pain": "Talán"},
+			path:    []string{"feeling", "pain", "notes"},
+		},
+		{
+			name:    "mild headache",
+			answers: map[string]string{"pain": "Igen", "pain_where": "A fejem fáj", "pain_scale": "3"},
+			path:    []string{"feeling", "pain", "pain_where", "pain_scale", "headache_light", "notes"},
+		},
+		{
+			name:    "severe back pain",
+			answers: map[string]string{"pain": "igen, nagyon", "pain_where": "a hátam", "pain_scale": "8-as"},
+			path:    []string{"feeling", "pain", "pain_where", "pain_scale", "pain_doctor", "notes"},
+		},
+		{
+			name:    "severe headache with a decimal comma",
+			answers: map[string]string{"pain": "Igen", "pain_where": "FEJFÁJÁS", "pain_scale": "7,5"},
+			path:    []string{"feeling", "pain", "pain_where", "pain_scale", "pain_doctor", "headache_light", "notes"},
+		},
+		{
+			name:    "headache off the scale",
+			answers: map[string]string{"pain": "igen", "pain_where": "fej", "pain_scale": "11"},
+			path:    []string{"feeling", "pain", "pain_where", "pain_scale", "pain_doctor", "notes"},
+		},
+		{
+			name:    "pain scale without a number",
+			answers: map[string]string{"pain": "Persze", "pain_where": "a fejem", "pain_scale": "nagyon"},
+			path:    []string{"feeling", "pain", "pain_where", "pain_scale", "notes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Evaluating the same answers again takes the same path
+			for run := 0; run < 2; run++ {
+				qf := NewQuestionFlowFromSet(painQuestionSet())
+				if total := qf.GetTotalQuestions(); total != 7 {
+					t.Errorf("expected 7 possible questions before any answer, got %d", total)
+				}
+
+				var path []string
+				for q := qf.GetNextQuestion(); q != nil; q = qf.GetNextQuestion() {
+					path = append(path, q.ID)
+					qf.RecordAnswer(q.ID, tt.answers[q.ID])
+					if total := qf.GetTotalQuestions(); total < len(tt.path) {
+						t.Errorf("total %d after %s is below the final path length %d", total, q.ID, len(tt.path))
+					}
+				}
+
+				if !reflect.DeepEqual(path, tt.path) {
+					t.Fatalf("expected path %v, got %v", tt.path, path)
+				}
+				if total := qf.GetTotalQuestions(); total != len(tt.path) {
+					t.Errorf("expected %d questions on the evaluated path, got %d", len(tt.path), total)
+				}
+			}
+		})
+	}
+}
+
+func TestQuestionFlow_GetTotalQuestionsShrinksWithAnswers(t *testing.T) {
+	qf := NewQuestionFlowFromSet(painQuestionSet())
+
+	qf.GetNextQuestion()
+	qf.RecordAnswer("feeling", "Jól")
+	qf.GetNextQuestion()
+	if total := qf.GetTotalQuestions(); total != 7 {
+		t.Errorf("expected 7 questions while pain is unanswered, got %d", total)
+	}
+
+	qf.RecordAnswer("pain", "nem")
+	if total := qf.GetTotalQuestions(); total != 3 {
+		t.Errorf("expected 3 questions after no pain, got %d", total)
+	}
+}
+
+func TestQuestionFlow_Replay(t *testing.T) {
+	messages := []model.Message{
+		{Role: model.MessageRoleAssistant, Content: "Hogy érzed magad ma?"},
+		{Role: model.MessageRoleUser, Content: "Fáradt vagyok"},
+		{Role: model.MessageRoleAssistant, Content: "Fáj valamid?"},
+		{Role: model.MessageRoleUser, Content: "Igen"},
+		{Role: model.MessageRoleAssistant, Content: "Hol fáj?"},
+		{Role: model.MessageRoleUser, Content: "A hátam"},
+		{Role: model.MessageRoleAssistant, Content: "Mióta fáj a hátad?", IsFollowUp: true},
+		{Role: model.MessageRoleUser, Content: "Tegnap óta, és a fejem is"},
+		{Role: model.MessageRoleAssistant, Content: "Mennyire fáj egy 1-10 skálán?"},
+		{Role: model.MessageRoleUser, Content: "4"},
+	}
+
+	qf := NewQuestionFlowFromSet(painQuestionSet())
+	last := qf.Replay(messages)
+	if last == nil || last.ID != "pain_scale" {
+		t.Fatalf("expected pain_scale asked last, got %v", last)
+	}
+
+	// The follow-up's mention of a headache is not an answer to pain_where
+	next := qf.GetNextQuestion()
+	if next == nil || next.ID != "notes" {
+		t.Fatalf("expected notes next, got %v", next)
+	}
+}
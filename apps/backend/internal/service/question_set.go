@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// ErrInvalidQuestionSet is returned when a question set cannot be asked in a check-in
+var ErrInvalidQuestionSet = errors.New("invalid question set")
+
+// QuestionSetSource looks up the question sets check-ins ask
+type QuestionSetSource interface {
+	GetQuestionSet(ctx context.Context, id string) (*model.QuestionSet, error)
+	GetForUser(ctx context.Context, userID string) (*model.QuestionSet, error)
+}
+
+// QuestionSetStore defines the persistence operations needed for question sets
+type QuestionSetStore interface {
+	QuestionSetSource
+	CreateQuestionSet(ctx context.Context, set *model.QuestionSet) error
+	AssignToUser(ctx context.Context, userID, questionSetID, assignedBy string) error
+	UnassignFromUser(ctx context.Context, userID string) error
+}
+
+// QuestionSetService manages clinician-defined check-in question sets
+type QuestionSetService struct {
+	store       QuestionSetStore
+	auditLogger *audit.Logger
+	logger      *zap.Logger
+}
+
+// NewQuestionSetService creates a new QuestionSetService
+func NewQuestionSetService(store QuestionSetStore, logger *zap.Logger) *QuestionSetService {
+	return &QuestionSetService{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// SetAuditLogger enables audit logging of question set changes
+func (s *QuestionSetService) SetAuditLogger(auditLogger *audit.Logger) {
+	s.auditLogger = auditLogger
+}
+
+// CreateQuestionSet validates and stores a question set. An empty language is the
+// language check-in questions are asked in.
+func (s *QuestionSetService) CreateQuestionSet(ctx context.Context, set *model.QuestionSet, createdBy string) error {
+	if set.Language == "" {
+		set.Language = questionLanguage
+	}
+	if err := validateQuestionSet(set); err != nil {
+		return err
+	}
+
+	set.CreatedBy = createdBy
+	if err := s.store.CreateQuestionSet(ctx, set); err != nil {
+		return fmt.Errorf("failed to create question set: %w", err)
+	}
+
+	s.logger.Info("question set created",
+		zap.String("question_set_id", set.ID),
+		zap.Int("questions", len(set.Questions)),
+	)
+	s.audit(ctx, createdBy, audit.OperationCreate, audit.ResourceQuestionSet, set.ID)
+
+	return nil
+}
+
+// AssignQuestionSet makes a user's future check-ins ask a question set. An empty
+// questionSetID returns the user to the built-in set. Sessions already started keep
+// the set they started with.
+func (s *QuestionSetService) AssignQuestionSet(ctx context.Context, userID, questionSetID, assignedBy string) error {
+	if questionSetID == "" {
+		if err := s.store.UnassignFromUser(ctx, userID); err != nil {
+			return fmt.Errorf("failed to unassign question set: %w", err)
+		}
+		s.audit(ctx, assignedBy, audit.OperationDelete, audit.ResourceUserQuestionSet, userID)
+		return nil
+	}
+
+	// Fails with repository.ErrQuestionSetNotFound for an unknown set
+	if _, err := s.store.GetQuestionSet(ctx, questionSetID); err != nil {
+		return err
+	}
+	if err := s.store.AssignToUser(ctx, userID, questionSetID, assignedBy); err != nil {
+		return fmt.Errorf("failed to assign question set: %w", err)
+	}
+	s.audit(ctx, assignedBy, audit.OperationUpdate, audit.ResourceUserQuestionSet, userID)
+
+	return nil
+}
+
+// audit records a question set change in the audit log
+func (s *QuestionSetService) audit(ctx context.Context, actorID string, op audit.OperationType, resourceType audit.ResourceType, resourceID string) {
+	if s.auditLogger == nil {
+		return
+	}
+
+	err := s.auditLogger.Log(ctx, audit.AuditLog{
+		UserID:        actorID,
+		OperationType: op,
+		ResourceType:  resourceType,
+		ResourceID:    resourceID,
+	})
+	if err != nil {
+		s.logger.Error("failed to audit question set change", zap.Error(err), zap.String("resource_id", resourceID))
+	}
+}
+
+// validateQuestionSet checks a question set can be asked: it is in the check-in
+// language and has at least one question, each with a unique ID, a text, a known type
+// and show_if conditions on earlier questions only
+func validateQuestionSet(set *model.QuestionSet) error {
+	if strings.TrimSpace(set.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidQuestionSet)
+	}
+	if set.Language != questionLanguage {
+		return fmt.Errorf("%w: check-ins are only asked in %s", ErrInvalidQuestionSet, questionLanguage)
+	}
+	if len(set.Questions) == 0 {
+		return fmt.Errorf("%w: at least one question is required", ErrInvalidQuestionSet)
+	}
+
+	// Types of the questions before the one validated, by ID
+	seen := make(map[string]QuestionType, len(set.Questions))
+	for i, question := range set.Questions {
+		_, duplicate := seen[question.ID]
+		switch {
+		case question.ID == "":
+			return fmt.Errorf("%w: question %d has no ID", ErrInvalidQuestionSet, i+1)
+		case duplicate:
+			return fmt.Errorf("%w: duplicate question ID %s", ErrInvalidQuestionSet, question.ID)
+		case isFollowUpQuestionID(question.ID) || strings.Contains(question.ID, "/"):
+			return fmt.Errorf("%w: reserved question ID %s", ErrInvalidQuestionSet, question.ID)
+		case strings.TrimSpace(question.Text) == "":
+			return fmt.Errorf("%w: question %s has no text", ErrInvalidQuestionSet, question.ID)
+		}
+		switch QuestionType(question.Type) {
+		case QuestionTypeOpenEnded, QuestionTypeNumeric, QuestionTypeYesNo:
+		default:
+			return fmt.Errorf("%w: question %s has unknown type %q", ErrInvalidQuestionSet, question.ID, question.Type)
+		}
+		for _, condition := range question.ShowIf {
+			if err := validateQuestionCondition(condition, seen); err != nil {
+				return fmt.Errorf("%w: question %s: %s", ErrInvalidQuestionSet, question.ID, err)
+			}
+		}
+		seen[question.ID] = QuestionType(question.Type)
+	}
+
+	return nil
+}
+
+// validateQuestionCondition checks a show_if condition references one of the earlier
+// questions and sets exactly one kind of condition that suits the question's type
+func validateQuestionCondition(condition model.QuestionCondition, earlier map[string]QuestionType) error {
+	questionType, ok := earlier[condition.QuestionID]
+	if !ok {
+		return fmt.Errorf("show_if must reference an earlier question, not %q", condition.QuestionID)
+	}
+
+	kinds := 0
+	if condition.Answer != "" {
+		kinds++
+	}
+	if condition.Min != nil || condition.Max != nil {
+		kinds++
+	}
+	if len(condition.Keywords) > 0 {
+		kinds++
+	}
+	if kinds != 1 {
+		return fmt.Errorf("show_if on %s must set exactly one of answer, min/max and keywords", condition.QuestionID)
+	}
+
+	switch {
+	case condition.Answer != "":
+		if questionType != QuestionTypeYesNo {
+			return fmt.Errorf("show_if answer requires yes_no question %s", condition.QuestionID)
+		}
+		if condition.Answer != AnswerYes && condition.Answer != AnswerNo {
+			return fmt.Errorf("show_if answer must be %q or %q", AnswerYes, AnswerNo)
+		}
+	case condition.Min != nil || condition.Max != nil:
+		if questionType != QuestionTypeNumeric {
+			return fmt.Errorf("show_if min/max requires numeric question %s", condition.QuestionID)
+		}
+		if condition.Min != nil && condition.Max != nil && *condition.Min > *condition.Max {
+			return fmt.Errorf("show_if min is greater than max")
+		}
+	default:
+		for _, keyword := range condition.Keywords {
+			if strings.TrimSpace(keyword) == "" {
+				return fmt.Errorf("show_if keywords must not be empty")
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// fakeQuestionSetStore is an in-memory QuestionSetStore
+type fakeQuestionSetStore struct {
+	sets        map[string]*model.QuestionSet
+	assignments map[string]string // user ID to question set ID
+}
+
+func newFakeQuestionSetStore() *fakeQuestionSetStore {
+	return &fakeQuestionSetStore{
+		sets:        make(map[string]*model.QuestionSet),
+		assignments: make(map[string]string),
+	}
+}
+
+func (f *fakeQuestionSetStore) CreateQuestionSet(ctx context.Context, set *model.QuestionSet) error {
+	set.ID = uuid.New().String()
+	f.sets[set.ID] = set
+	return nil
+}
+
+func (f *fakeQuestionSetStore) GetQuestionSet(ctx context.Context, id string) (*model.QuestionSet, error) {
+	set, ok := f.sets[id]
+	if !ok {
+		return nil, repository.ErrQuestionSetNotFound
+	}
+	return set, nil
+}
+
+func (f *fakeQuestionSetStore) GetForUser(ctx context.Context, userID string) (*model.QuestionSet, error) {
+	id, ok := f.assignments[userID]
+	if !ok {
+		return nil, nil
+	}
+	return f.sets[id], nil
+}
+
+func (f *fakeQuestionSetStore) AssignToUser(ctx context.Context, userID, questionSetID, assignedBy string) error {
+	f.assignments[userID] = questionSetID
+	return nil
+}
+
+func (f *fakeQuestionSetStore) UnassignFromUser(ctx context.Context, userID string) error {
+	delete(f.assignments, userID)
+	return nil
+}
+
+func diabetesQuestionSet() *model.QuestionSet {
+	return &model.QuestionSet{
+		Name: "Diabetes",
+		Questions: []model.QuestionSetQuestion{
+			{ID: "feeling", Text: "Hogy érzed magad ma?", Type: string(QuestionTypeOpenEnded), Required: true},
+			{ID: "glucose", Text: "Mennyi volt ma reggel a vércukorszinted?", Type: string(QuestionTypeNumeric), Required: true},
+		},
+	}
+}
+
+func TestQuestionSetService_CreateQuestionSet(t *testing.T) {
+	ctx := context.Background()
+	adminID := uuid.New().String()
+
+	t.Run("defaults the language", func(t *testing.T) {
+		svc := NewQuestionSetService(newFakeQuestionSetStore(), zap.NewNop())
+		set := diabetesQuestionSet()
+
+		require.NoError(t, svc.CreateQuestionSet(ctx, set, adminID))
+		assert.NotEmpty(t, set.ID)
+		assert.Equal(t, questionLanguage, set.Language)
+		assert.Equal(t, adminID, set.CreatedBy)
+	})
+
+	t.Run("accepts branching", func(t *testing.T) {
+		svc := NewQuestionSetService(newFakeQuestionSetStore(), zap.NewNop())
+		require.NoError(t, svc.CreateQuestionSet(ctx, painQuestionSet(), adminID))
+	})
+
+	invalid := map[string]func(set *model.QuestionSet){
+		"no name":               func(set *model.QuestionSet) { set.Name = " " },
+		"other language":        func(set *model.QuestionSet) { set.Language = "en-US" },
+		"no questions":          func(set *model.QuestionSet) { set.Questions = nil },
+		"missing question ID":   func(set *model.QuestionSet) { set.Questions[0].ID = "" },
+		"duplicate ID":          func(set *model.QuestionSet) { set.Questions[1].ID = set.Questions[0].ID },
+		"follow-up ID":          func(set *model.QuestionSet) { set.Questions[0].ID = followUpQuestionID("message") },
+		"ID with a slash":       func(set *model.QuestionSet) { set.Questions[0].ID = "a/b" },
+		"missing text":          func(set *model.QuestionSet) { set.Questions[1].Text = "" },
+		"unknown question type": func(set *model.QuestionSet) { set.Questions[1].Type = "scale" },
+		"show_if on a later question": func(set *model.QuestionSet) {
+			set.Questions[0].ShowIf = []model.QuestionCondition{{QuestionID: "glucose", Keywords: []string{"5"}}}
+		},
+		"show_if on itself": func(set *model.QuestionSet) {
+			set.Questions[1].ShowIf = []model.QuestionCondition{{QuestionID: "glucose", Keywords: []string{"5"}}}
+		},
+		"show_if answer on an open question": func(set *model.QuestionSet) {
+			set.Questions[1].ShowIf = []model.QuestionCondition{{QuestionID: "feeling", Answer: AnswerYes}}
+		},
+		"show_if min on an open question": func(set *model.QuestionSet) {
+			set.Questions[1].ShowIf = []model.QuestionCondition{{QuestionID: "feeling", Min: float64Ptr(1)}}
+		},
+		"show_if min above max": func(set *model.QuestionSet) {
+			set.Questions = append(set.Questions, model.QuestionSetQuestion{ID: "hypo", Text: "Volt rosszulléted?", Type: string(QuestionTypeOpenEnded),
+				ShowIf: []model.QuestionCondition{{QuestionID: "glucose", Min: float64Ptr(10), Max: float64Ptr(4)}}})
+		},
+		"show_if with two kinds": func(set *model.QuestionSet) {
+			set.Questions[1].ShowIf = []model.QuestionCondition{{QuestionID: "feeling", Keywords: []string{"rossz"}, Answer: AnswerNo}}
+		},
+		"show_if without a kind": func(set *model.QuestionSet) {
+			set.Questions[1].ShowIf = []model.QuestionCondition{{QuestionID: "feeling"}}
+		},
+		"show_if empty keyword": func(set *model.QuestionSet) {
+			set.Questions[1].ShowIf = []model.QuestionCondition{{QuestionID: "feeling", Keywords: []string{" "}}}
+		},
+		"show_if unknown answer": func(set *model.QuestionSet) {
+			set.Questions[1].Type = string(QuestionTypeYesNo)
+			set.Questions = append(set.Questions, model.QuestionSetQuestion{ID: "hypo", Text: "Volt rosszulléted?", Type: string(QuestionTypeOpenEnded),
+				ShowIf: []model.QuestionCondition{{QuestionID: "glucose", Answer: "maybe"}}})
+		},
+	}
+	for name, mutate := range invalid {
+		t.Run(name, func(t *testing.T) {
+			store := newFakeQuestionSetStore()
+			svc := NewQuestionSetService(store, zap.NewNop())
+			set := diabetesQuestionSet()
+			mutate(set)
+
+			err := svc.CreateQuestionSet(ctx, set, adminID)
+			assert.ErrorIs(t, err, ErrInvalidQuestionSet)
+			assert.Empty(t, store.sets)
+		})
+	}
+}
+
+func TestQuestionSetService_AssignQuestionSet(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeQuestionSetStore()
+	svc := NewQuestionSetService(store, zap.NewNop())
+	userID := uuid.New().String()
+	adminID := uuid.New().String()
+
+	set := diabetesQuestionSet()
+	require.NoError(t, svc.CreateQuestionSet(ctx, set, adminID))
+
+	require.NoError(t, svc.AssignQuestionSet(ctx, userID, set.ID, adminID))
+	assigned, err := store.GetForUser(ctx, userID)
+	require.NoError(t, err)
+	require.NotNil(t, assigned)
+	assert.Equal(t, set.ID, assigned.ID)
+
+	err = svc.AssignQuestionSet(ctx, userID, uuid.New().String(), adminID)
+	assert.ErrorIs(t, err, repository.ErrQuestionSetNotFound)
+	assert.Equal(t, set.ID, store.assignments[userID], "a failed assignment keeps the previous set")
+
+	require.NoError(t, svc.AssignQuestionSet(ctx, userID, "", adminID))
+	assigned, err = store.GetForUser(ctx, userID)
+	require.NoError(t, err)
+	assert.Nil(t, assigned)
+}
+
+func TestNewQuestionFlowFromSet(t *testing.T) {
+	t.Run("nil set is the built-in set", func(t *testing.T) {
+		flow := NewQuestionFlowFromSet(nil)
+		assert.Equal(t, NewQuestionFlow().GetTotalQuestions(), flow.GetTotalQuestions())
+		assert.Equal(t, "feeling", flow.audioID("feeling"))
+	})
+
+	t.Run("stored set", func(t *testing.T) {
+		set := diabetesQuestionSet()
+		set.ID = uuid.New().String()
+		flow := NewQuestionFlowFromSet(set)
+
+		require.Equal(t, 2, flow.GetTotalQuestions())
+		first := flow.GetNextQuestion()
+		require.NotNil(t, first)
+		assert.Equal(t, "feeling", first.ID)
+		second := flow.GetNextQuestion()
+		require.NotNil(t, second)
+		assert.Equal(t, "glucose", second.ID)
+		assert.Equal(t, QuestionTypeNumeric, second.Type)
+		assert.Equal(t, "Mennyi volt ma reggel a vércukorszinted?", second.TextHU)
+		assert.Nil(t, flow.GetNextQuestion())
+
+		assert.Equal(t, set.ID+"/glucose", flow.audioID("glucose"))
+	})
+}
@@ -2,24 +2,80 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/pdf"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/security"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 	"go.uber.org/zap"
 )
 
+var (
+	// ErrReportNotVerified is returned when no report matches a verification code or hash
+	ErrReportNotVerified = errors.New("report could not be verified")
+
+	// ErrUnsupportedReportFormat is returned when a report is requested in an unknown format
+	ErrUnsupportedReportFormat = errors.New("unsupported report format")
+
+	// ErrUnknownReportSection is returned when a report is requested with an unknown section
+	ErrUnknownReportSection = errors.New("unknown report section")
+
+	// ErrReportEncryptionUnsupported is returned when password protection is requested for
+	// a format other than PDF
+	ErrReportEncryptionUnsupported = errors.New("only PDF reports can be password protected")
+
+	// ErrReportEncryptionUnavailable is returned when password protection is requested
+	// but no key is configured to keep the password encrypted while the report is queued
+	ErrReportEncryptionUnavailable = errors.New("password protected reports are not configured")
+
+	// ErrReportUserDeleted is returned when a report is requested for a user whose data
+	// was deleted
+	ErrReportUserDeleted = errors.New("user was deleted")
+)
+
+// unknownPatientName is printed on the reports of users without a users row
+const unknownPatientName = "Unknown patient"
+
+// UserStore defines the lookup of the user a report is generated for
+type UserStore interface {
+	FindByID(ctx context.Context, userID string) (*model.User, error)
+}
+
+// ReportFile is the content of a generated report with the format it was generated in
+// and the sections it includes
+type ReportFile struct {
+	Data     []byte
+	Format   model.ReportFormat
+	Sections []model.ReportSection
+}
+
 // ReportService manages health report generation
 type ReportService struct {
 	dashboardRepo  *repository.DashboardRepository
 	healthRepo     *repository.HealthDataRepository
 	medicationRepo *repository.MedicationRepository
-	blobClient     azure.BlobStorage
+	storage        *StorageRouter
 	pdfGen         *pdf.PDFGenerator
+	limiter        *ReportLimiter
+	usage          UsageRecorder
+	reporter       telemetry.ErrorReporter
+	urlSigner      azure.BlobURLSigner
+	urlTTL         time.Duration
+	auditLogger    *audit.Logger
+	jobStore       ReportJobStore
+	maxPending     int
+	passwords      *security.Encryptor
+	users          UserStore
+	jobsQueued     chan struct{}
 	logger         *zap.Logger
 }
 
@@ -36,132 +92,416 @@ func NewReportService(
 		dashboardRepo:  dashboardRepo,
 		healthRepo:     healthRepo,
 		medicationRepo: medicationRepo,
-		blobClient:     blobClient,
+		storage:        NewStorageRouter(blobClient, logger),
 		pdfGen:         pdfGen,
+		reporter:       telemetry.NopReporter{},
+		jobsQueued:     make(chan struct{}, 1),
 		logger:         logger,
 	}
 }
 
-// GenerateReport generates a health report asynchronously
-func (s *ReportService) GenerateReport(ctx context.Context, userID string, userName string, startDate, endDate time.Time) (string, error) {
-	s.logger.Info("generating health report",
+// SetJobStore enables queueing reports in store, accepting up to maxPending jobs waiting
+// for a worker before new requests are rejected
+func (s *ReportService) SetJobStore(store ReportJobStore, maxPending int) {
+	s.jobStore = store
+	s.maxPending = maxPending
+}
+
+// SetPasswordEncryptor enables password protected reports. The passwords of queued
+// reports are stored encrypted with passwords until their job finishes.
+func (s *ReportService) SetPasswordEncryptor(passwords *security.Encryptor) {
+	s.passwords = passwords
+}
+
+// SetStorageRouter writes reports to the storage backend of their user's data residency
+// and reads and deletes them in the backend they were written to
+func (s *ReportService) SetStorageRouter(router *StorageRouter) {
+	s.storage = router
+}
+
+// SetUserStore lets reports print the name stored for their user and rejects reports
+// of deleted users
+func (s *ReportService) SetUserStore(users UserStore) {
+	s.users = users
+}
+
+// SetLimiter configures per-user rate limiting and deduplication of report generation
+func (s *ReportService) SetLimiter(limiter *ReportLimiter) {
+	s.limiter = limiter
+}
+
+// SetUsageRecorder enables per-user usage accounting of stored reports
+func (s *ReportService) SetUsageRecorder(usage UsageRecorder) {
+	s.usage = usage
+}
+
+// SetErrorReporter exports blob storage failures to a telemetry sink
+func (s *ReportService) SetErrorReporter(reporter telemetry.ErrorReporter) {
+	s.reporter = reporter
+}
+
+// GenerateReport queues generation of a health report in format, PDF when empty, printed
+// in language with the given sections, all when empty, and returns its status right away.
+// A recent identical request returns that completed report instead of queueing a new one.
+// An encrypted PDF is protected with a new random password, returned in the status only.
+// Reports of deleted users are rejected with ErrReportUserDeleted.
+func (s *ReportService) GenerateReport(ctx context.Context, userID string, language pdf.Language, format model.ReportFormat, sections []model.ReportSection, encrypt bool, startDate, endDate time.Time) (*ReportStatus, error) {
+	if format == "" {
+		format = model.ReportFormatPDF
+	}
+	if !format.Valid() {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedReportFormat, format)
+	}
+	if encrypt && format != model.ReportFormatPDF {
+		return nil, fmt.Errorf("%w: %q", ErrReportEncryptionUnsupported, format)
+	}
+	if encrypt && s.passwords == nil {
+		return nil, ErrReportEncryptionUnavailable
+	}
+	sections, err := normalizeReportSections(sections)
+	if err != nil {
+		return nil, err
+	}
+	userName, err := s.patientName(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("queueing health report",
 		zap.String("user_id", userID),
+		zap.String("format", string(format)),
+		zap.Any("sections", sections),
+		zap.Bool("encrypted", encrypt),
 		zap.Time("start_date", startDate),
 		zap.Time("end_date", endDate),
 	)
 
-	// Generate report ID
-	reportID := uuid.New().String()
+	if s.limiter != nil {
+		// Identical recent request returns the existing report without counting against the
+		// limit. The password of an encrypted report is not kept, so those are never reused.
+		if existingID, ok := s.limiter.Recent(userID, format, sections, startDate, endDate); ok && !encrypt {
+			s.logger.Info("returning recently generated report for identical request",
+				zap.String("report_id", existingID),
+				zap.String("user_id", userID),
+			)
+			return &ReportStatus{ReportID: existingID, Status: model.ReportStatusCompleted}, nil
+		}
 
-	// Fetch all required data
-	checkIns, err := s.dashboardRepo.GetHealthCheckIns(ctx, userID, startDate, endDate)
-	if err != nil {
-		s.logger.Error("failed to get health check-ins for report",
-			zap.Error(err),
-			zap.String("user_id", userID),
-		)
-		return "", fmt.Errorf("failed to get health check-ins: %w", err)
+		if err := s.limiter.Reserve(userID); err != nil {
+			s.logger.Warn("report generation rate limited",
+				zap.Error(err),
+				zap.String("user_id", userID),
+			)
+			return nil, err
+		}
 	}
 
-	medications, err := s.medicationRepo.FindByUserID(ctx, userID)
+	if s.jobStore == nil {
+		return nil, ErrReportQueueUnavailable
+	}
+	pending, err := s.jobStore.CountPendingJobs(ctx)
 	if err != nil {
-		s.logger.Error("failed to get medications for report",
-			zap.Error(err),
-			zap.String("user_id", userID),
-		)
-		return "", fmt.Errorf("failed to get medications: %w", err)
+		return nil, err
+	}
+	if pending >= s.maxPending {
+		s.logger.Warn("report generation queue is full", zap.Int("pending", pending))
+		return nil, ErrReportQueueUnavailable
 	}
 
-	bloodPressure, err := s.healthRepo.GetBloodPressureByUserID(ctx, userID)
-	if err != nil {
-		s.logger.Error("failed to get blood pressure for report",
-			zap.Error(err),
-			zap.String("user_id", userID),
-		)
-		return "", fmt.Errorf("failed to get blood pressure: %w", err)
+	report := &model.Report{
+		ID:             uuid.New().String(),
+		UserID:         userID,
+		DateRangeStart: startDate,
+		DateRangeEnd:   endDate,
+		Format:         format,
+		Sections:       sections,
+		Encrypted:      encrypt,
+	}
+	job := &model.ReportJob{
+		UserID:    userID,
+		StartDate: startDate,
+		EndDate:   endDate,
+		UserName:  userName,
+		Language:  string(language),
+		Format:    format,
+		Sections:  sections,
+		Primary:   repository.PrimaryRequired(ctx),
+	}
+	var password string
+	if encrypt {
+		password = rand.Text()
+		if job.Password, err = s.passwords.Encrypt(password); err != nil {
+			return nil, fmt.Errorf("failed to encrypt report password: %w", err)
+		}
 	}
 
-	menstruationCycles, err := s.healthRepo.GetMenstruationByUserID(ctx, userID)
-	if err != nil {
-		s.logger.Error("failed to get menstruation cycles for report",
-			zap.Error(err),
-			zap.String("user_id", userID),
-		)
-		return "", fmt.Errorf("failed to get menstruation cycles: %w", err)
+	if err := s.jobStore.EnqueueReport(ctx, report, job); err != nil {
+		return nil, fmt.Errorf("failed to queue report: %w", err)
 	}
+	s.notifyJobQueued()
+
+	return &ReportStatus{JobID: job.ID, ReportID: report.ID, Status: model.ReportStatusPending, Password: password}, nil
+}
 
-	fitnessData, err := s.healthRepo.GetFitnessDataByUserID(ctx, userID, startDate, endDate)
+// patientName returns the name printed on a user's reports: the stored name, or a
+// placeholder when the user has no users row or no UserStore is set
+func (s *ReportService) patientName(ctx context.Context, userID string) (string, error) {
+	if s.users == nil {
+		return unknownPatientName, nil
+	}
+
+	user, err := s.users.FindByID(ctx, userID)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		s.logger.Warn("user not found, printing a placeholder name on the report", zap.String("user_id", userID))
+		return unknownPatientName, nil
+	}
 	if err != nil {
-		s.logger.Error("failed to get fitness data for report",
-			zap.Error(err),
-			zap.String("user_id", userID),
-		)
-		return "", fmt.Errorf("failed to get fitness data: %w", err)
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.DeletedAt != nil {
+		return "", ErrReportUserDeleted
+	}
+	return user.Name, nil
+}
+
+// buildReport collects the user's data, renders it in the job's format and uploads it.
+// It returns the completed report record and the size of the file.
+func (s *ReportService) buildReport(ctx context.Context, job reportJob) (*model.Report, int, error) {
+	reportID := job.reportID
+	userID := job.userID
+	startDate := job.startDate
+	endDate := job.endDate
+
+	// Collect the data from the read replica when one is healthy, unless the request
+	// asked to read its own writes
+	ctx = repository.WithReadReplica(ctx)
+	if job.primary {
+		ctx = repository.WithPrimary(ctx)
+	}
+
+	// Fetch the data of the requested sections only, so excluded data never reaches the file
+	var checkIns []model.HealthCheckIn
+	var err error
+	if includesAnySection(job.sections, checkInReportSections) {
+		checkIns, err = s.dashboardRepo.GetHealthCheckIns(ctx, userID, startDate, endDate)
+		if err != nil {
+			s.logger.Error("failed to get health check-ins for report",
+				zap.Error(err),
+				zap.String("user_id", userID),
+			)
+			return nil, 0, fmt.Errorf("failed to get health check-ins: %w", err)
+		}
+	}
+
+	var medications []model.Medication
+	if slices.Contains(job.sections, model.ReportSectionMedications) {
+		medications, err = s.medicationRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			s.logger.Error("failed to get medications for report",
+				zap.Error(err),
+				zap.String("user_id", userID),
+			)
+			return nil, 0, fmt.Errorf("failed to get medications: %w", err)
+		}
+	}
+
+	var bloodPressure []model.BloodPressureReading
+	if slices.Contains(job.sections, model.ReportSectionBloodPressure) {
+		bloodPressure, err = s.healthRepo.GetBloodPressureByUserID(ctx, userID)
+		if err != nil {
+			s.logger.Error("failed to get blood pressure for report",
+				zap.Error(err),
+				zap.String("user_id", userID),
+			)
+			return nil, 0, fmt.Errorf("failed to get blood pressure: %w", err)
+		}
+	}
+
+	var menstruationCycles []model.MenstruationCycle
+	var cycleStats *model.CycleStats
+	if slices.Contains(job.sections, model.ReportSectionMenstruation) {
+		menstruationCycles, err = s.healthRepo.GetMenstruationByUserID(ctx, userID)
+		if err != nil {
+			s.logger.Error("failed to get menstruation cycles for report",
+				zap.Error(err),
+				zap.String("user_id", userID),
+			)
+			return nil, 0, fmt.Errorf("failed to get menstruation cycles: %w", err)
+		}
+		cycleStats = ComputeCycleStats(menstruationCycles)
+	}
+
+	var fitnessData []model.FitnessDataPoint
+	if slices.Contains(job.sections, model.ReportSectionActivity) {
+		fitnessData, err = s.healthRepo.GetFitnessDataByUserID(ctx, userID, startDate, endDate, "")
+		if err != nil {
+			s.logger.Error("failed to get fitness data for report",
+				zap.Error(err),
+				zap.String("user_id", userID),
+			)
+			return nil, 0, fmt.Errorf("failed to get fitness data: %w", err)
+		}
 	}
 
 	// Prepare report data
-	dateRange := fmt.Sprintf("%s to %s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	dateRange := job.language.DateRange(startDate, endDate)
 	reportData := &pdf.ReportData{
-		UserName:           userName,
+		UserName:           job.userName,
 		DateRange:          dateRange,
 		CheckIns:           checkIns,
 		Medications:        medications,
 		BloodPressure:      bloodPressure,
 		MenstruationCycles: menstruationCycles,
+		CycleStats:         cycleStats,
 		FitnessData:        fitnessData,
+		Language:           job.language,
+		Sections:           job.sections,
+		ReportID:           reportID,
+		Password:           job.password,
 	}
 
-	// Generate PDF
-	pdfBytes, err := s.pdfGen.Generate(reportData)
+	// Resolve the storage backend before rendering, so a misconfigured data residency
+	// fails the report instead of writing it to another region
+	backend, storage, err := s.storage.ForUser(ctx, userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to resolve report storage: %w", err)
+	}
+
+	var data []byte
+	var blobPath string
+	var fingerprint *pdf.Fingerprint
+	if job.format == model.ReportFormatCSV {
+		data, blobPath, fingerprint, err = s.uploadCSVReport(ctx, storage, reportData)
+	} else {
+		data, blobPath, fingerprint, err = s.uploadPDFReport(ctx, storage, reportData)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	report := &model.Report{
+		ID:               reportID,
+		UserID:           userID,
+		DateRangeStart:   startDate,
+		DateRangeEnd:     endDate,
+		FilePath:         blobPath,
+		Status:           model.ReportStatusCompleted,
+		Format:           job.format,
+		Sections:         job.sections,
+		Encrypted:        job.password != "",
+		SizeBytes:        int64(len(data)),
+		GeneratedAt:      time.Now(),
+		SHA256:           fingerprint.SHA256,
+		VerificationCode: fingerprint.VerificationCode,
+		StorageBackend:   backend,
+	}
+
+	return report, len(data), nil
+}
+
+// checkInReportSections are the report sections drawn from check-ins
+var checkInReportSections = []model.ReportSection{
+	model.ReportSectionSymptoms,
+	model.ReportSectionAdherence,
+	model.ReportSectionActivity,
+	model.ReportSectionMeals,
+	model.ReportSectionSummaries,
+}
+
+// normalizeReportSections validates requested report sections and returns them without
+// duplicates in print order, or every section when none are requested
+func normalizeReportSections(requested []model.ReportSection) ([]model.ReportSection, error) {
+	if len(requested) == 0 {
+		return slices.Clone(model.AllReportSections), nil
+	}
+	for _, section := range requested {
+		if !section.Valid() {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownReportSection, section)
+		}
+	}
+
+	var sections []model.ReportSection
+	for _, section := range model.AllReportSections {
+		if slices.Contains(requested, section) {
+			sections = append(sections, section)
+		}
+	}
+	return sections, nil
+}
+
+// includesAnySection reports whether sections include any of wanted
+func includesAnySection(sections, wanted []model.ReportSection) bool {
+	for _, section := range wanted {
+		if slices.Contains(sections, section) {
+			return true
+		}
+	}
+	return false
+}
+
+// uploadPDFReport renders the report as a PDF and uploads it to storage. It returns the
+// PDF, its blob path and fingerprint.
+func (s *ReportService) uploadPDFReport(ctx context.Context, storage azure.BlobStorage, reportData *pdf.ReportData) ([]byte, string, *pdf.Fingerprint, error) {
+	reportID := reportData.ReportID
+
+	// Generate PDF with its verification code in the footer
+	pdfBytes, fingerprint, err := s.pdfGen.GenerateWithFingerprint(reportData)
 	if err != nil {
 		s.logger.Error("failed to generate PDF",
 			zap.Error(err),
 			zap.String("report_id", reportID),
 		)
-		return "", fmt.Errorf("failed to generate PDF: %w", err)
+		return nil, "", nil, fmt.Errorf("failed to generate PDF: %w", err)
 	}
 
 	// Upload to Azure Blob Storage
 	filename := fmt.Sprintf("%s_%s.pdf", reportID, time.Now().Format("20060102"))
-	blobPath, err := s.blobClient.UploadPDF(ctx, filename, pdfBytes)
+	blobPath, err := storage.UploadPDF(ctx, filename, pdfBytes)
 	if err != nil {
 		s.logger.Error("failed to upload PDF to blob storage",
 			zap.Error(err),
 			zap.String("report_id", reportID),
 		)
-		return "", fmt.Errorf("failed to upload PDF: %w", err)
+		telemetry.ReportError(ctx, s.reporter, telemetry.KindUpstreamFailure, "blob.upload_report", err)
+		return nil, "", nil, fmt.Errorf("failed to upload PDF: %w", err)
 	}
 
-	// Create report record in database
-	report := &model.Report{
-		ID:             reportID,
-		UserID:         userID,
-		DateRangeStart: startDate,
-		DateRangeEnd:   endDate,
-		FilePath:       blobPath,
-		GeneratedAt:    time.Now(),
-	}
+	return pdfBytes, blobPath, fingerprint, nil
+}
 
-	err = s.dashboardRepo.SaveReport(ctx, report)
+// uploadCSVReport renders the report as a ZIP of CSV files and uploads it to storage. It
+// returns the archive, its blob path and fingerprint. Nothing is printed in a CSV file, so the
+// fingerprint only has the SHA-256 of the archive.
+func (s *ReportService) uploadCSVReport(ctx context.Context, storage azure.BlobStorage, reportData *pdf.ReportData) ([]byte, string, *pdf.Fingerprint, error) {
+	reportID := reportData.ReportID
+	generatedAt := time.Now()
+
+	archive, err := generateReportCSVZip(reportData, generatedAt)
 	if err != nil {
-		s.logger.Error("failed to save report record",
+		s.logger.Error("failed to generate CSV report",
 			zap.Error(err),
 			zap.String("report_id", reportID),
 		)
-		return "", fmt.Errorf("failed to save report record: %w", err)
+		return nil, "", nil, fmt.Errorf("failed to generate CSV report: %w", err)
 	}
 
-	s.logger.Info("health report generated successfully",
-		zap.String("report_id", reportID),
-		zap.String("user_id", userID),
-		zap.String("blob_path", blobPath),
-	)
+	// Upload to Azure Blob Storage
+	blobName := fmt.Sprintf("reports/%s_%s.zip", reportID, generatedAt.Format("20060102"))
+	blobPath, err := storage.UploadFile(ctx, blobName, archive, model.ReportFormatCSV.ContentType())
+	if err != nil {
+		s.logger.Error("failed to upload CSV report to blob storage",
+			zap.Error(err),
+			zap.String("report_id", reportID),
+		)
+		telemetry.ReportError(ctx, s.reporter, telemetry.KindUpstreamFailure, "blob.upload_report", err)
+		return nil, "", nil, fmt.Errorf("failed to upload CSV report: %w", err)
+	}
 
-	return reportID, nil
+	return archive, blobPath, &pdf.Fingerprint{SHA256: pdf.SHA256Hex(archive)}, nil
 }
 
-// GetReport retrieves a report PDF for download
-func (s *ReportService) GetReport(ctx context.Context, reportID string) ([]byte, error) {
+// GetReport retrieves a report file for download
+func (s *ReportService) GetReport(ctx context.Context, reportID string) (*ReportFile, error) {
 	s.logger.Info("retrieving report",
 		zap.String("report_id", reportID),
 	)
@@ -175,24 +515,62 @@ func (s *ReportService) GetReport(ctx context.Context, reportID string) ([]byte,
 		)
 		return nil, fmt.Errorf("failed to get report record: %w", err)
 	}
+	if report.Status != model.ReportStatusCompleted {
+		return nil, fmt.Errorf("%w: report is %s", ErrReportNotReady, report.Status)
+	}
 
-	// Download PDF from Azure Blob Storage
-	pdfBytes, err := s.blobClient.DownloadPDF(ctx, report.FilePath)
+	// Download the file from the storage backend it was written to
+	storage, err := s.storage.Backend(report.StorageBackend)
 	if err != nil {
-		s.logger.Error("failed to download PDF from blob storage",
+		s.logger.Error("report is stored in an unconfigured storage backend",
+			zap.Error(err),
+			zap.String("report_id", reportID),
+		)
+		return nil, err
+	}
+	data, err := storage.DownloadPDF(ctx, report.FilePath)
+	if err != nil {
+		s.logger.Error("failed to download report from blob storage",
 			zap.Error(err),
 			zap.String("report_id", reportID),
 			zap.String("blob_path", report.FilePath),
 		)
-		return nil, fmt.Errorf("failed to download PDF: %w", err)
+		telemetry.ReportError(ctx, s.reporter, telemetry.KindUpstreamFailure, "blob.download_report", err)
+		return nil, fmt.Errorf("failed to download report: %w", err)
 	}
 
 	s.logger.Info("report retrieved successfully",
 		zap.String("report_id", reportID),
-		zap.Int("size_bytes", len(pdfBytes)),
+		zap.String("format", string(report.Format)),
+		zap.Int("size_bytes", len(data)),
+	)
+
+	return &ReportFile{Data: data, Format: report.Format, Sections: report.Sections}, nil
+}
+
+// VerifyReport looks up the report matching a printed verification code or the SHA-256 of
+// a PDF. Malformed input and unknown fingerprints both return ErrReportNotVerified so
+// callers cannot tell them apart.
+func (s *ReportService) VerifyReport(ctx context.Context, input string) (*model.Report, error) {
+	value, fullHash, err := pdf.ParseVerificationInput(input)
+	if err != nil {
+		return nil, ErrReportNotVerified
+	}
+
+	report, err := s.dashboardRepo.FindReportByFingerprint(ctx, value, fullHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify report: %w", err)
+	}
+	if report == nil {
+		return nil, ErrReportNotVerified
+	}
+
+	s.logger.Info("report verified",
+		zap.String("report_id", report.ID),
+		zap.Bool("full_hash", fullHash),
 	)
 
-	return pdfBytes, nil
+	return report, nil
 }
 
 // GetReportsByUserID retrieves all reports for a user
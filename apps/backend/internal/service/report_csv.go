@@ -0,0 +1,93 @@
+package service
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/pdf"
+)
+
+// Column headers of the CSV report files. Clinics import these files into their own
+// systems, so columns may only be appended, never renamed, reordered or removed. They
+// are documented with the GenerateReportRequest schema in api/openapi.json.
+var (
+	reportCheckInColumns = []string{
+		"id", "check_in_date", "symptoms", "mood", "pain_level", "energy_level",
+		"sleep_quality", "medication_taken", "physical_activity", "breakfast", "lunch",
+		"dinner", "general_feeling", "additional_notes", "low_confidence",
+	}
+	reportMedicationColumns = []string{
+		"id", "name", "dosage", "frequency", "start_date", "end_date", "notes", "active",
+	}
+	reportBloodPressureColumns = []string{
+		"id", "measured_at", "systolic", "diastolic", "pulse",
+	}
+	reportMenstruationColumns = []string{
+		"id", "start_date", "end_date", "flow_intensity", "symptoms",
+	}
+	reportFitnessColumns = []string{
+		"id", "date", "data_type", "value", "unit", "source",
+	}
+)
+
+// generateReportCSVZip renders the report data as a ZIP archive of CSV files, one per
+// dataset. Datasets without rows are included with their header only.
+func generateReportCSVZip(data *pdf.ReportData, generatedAt time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeCSVZip(&buf, reportCSVTables(data), generatedAt); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// reportCSVTables converts the report data to its CSV tables
+func reportCSVTables(data *pdf.ReportData) []csvTable {
+	checkIns := csvTable{name: "check_ins.csv", columns: reportCheckInColumns}
+	for _, c := range data.CheckIns {
+		checkIns.rows = append(checkIns.rows, []string{
+			c.ID, isoDate(c.CheckInDate), strings.Join(c.Symptoms, csvListSeparator),
+			stringValue(c.Mood), intValue(c.PainLevel), stringValue(c.EnergyLevel),
+			stringValue(c.SleepQuality), stringValue(c.MedicationTaken),
+			strings.Join(c.PhysicalActivity, csvListSeparator),
+			stringValue(c.Breakfast), stringValue(c.Lunch), stringValue(c.Dinner),
+			stringValue(c.GeneralFeeling), stringValue(c.AdditionalNotes),
+			strconv.FormatBool(c.LowConfidence),
+		})
+	}
+
+	medications := csvTable{name: "medications.csv", columns: reportMedicationColumns}
+	for _, m := range data.Medications {
+		medications.rows = append(medications.rows, []string{
+			m.ID, m.Name, m.Dosage, m.Frequency, isoDate(m.StartDate), isoDatePtr(m.EndDate),
+			stringValue(m.Notes), strconv.FormatBool(m.Active),
+		})
+	}
+
+	bloodPressure := csvTable{name: "blood_pressure.csv", columns: reportBloodPressureColumns}
+	for _, bp := range data.BloodPressure {
+		bloodPressure.rows = append(bloodPressure.rows, []string{
+			bp.ID, isoTimestamp(bp.MeasuredAt), strconv.Itoa(bp.Systolic),
+			strconv.Itoa(bp.Diastolic), strconv.Itoa(bp.Pulse),
+		})
+	}
+
+	menstruation := csvTable{name: "menstruation.csv", columns: reportMenstruationColumns}
+	for _, c := range data.MenstruationCycles {
+		menstruation.rows = append(menstruation.rows, []string{
+			c.ID, isoDate(c.StartDate), isoDatePtr(c.EndDate), stringValue(c.FlowIntensity),
+			strings.Join(c.Symptoms, csvListSeparator),
+		})
+	}
+
+	fitness := csvTable{name: "fitness.csv", columns: reportFitnessColumns}
+	for _, f := range data.FitnessData {
+		fitness.rows = append(fitness.rows, []string{
+			f.ID, isoDate(f.Date), f.DataType, strconv.FormatFloat(f.Value, 'f', -1, 64),
+			f.Unit, f.Source,
+		})
+	}
+
+	return []csvTable{checkIns, medications, bloodPressure, menstruation, fitness}
+}
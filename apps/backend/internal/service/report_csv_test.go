@@ -0,0 +1,117 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/pdf"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// readCSVZip reads every CSV file of a ZIP archive, keyed by file name, keeping the
+// order of the files in the archive
+func readCSVZip(t *testing.T, data []byte) (map[string][][]string, []string) {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	tables := make(map[string][][]string)
+	var names []string
+	for _, file := range archive.File {
+		f, err := file.Open()
+		require.NoError(t, err)
+		records, err := csv.NewReader(f).ReadAll()
+		f.Close()
+		require.NoError(t, err, file.Name)
+		tables[file.Name] = records
+		names = append(names, file.Name)
+	}
+	return tables, names
+}
+
+func TestGenerateReportCSVZip_StableHeaders(t *testing.T) {
+	archive, err := generateReportCSVZip(&pdf.ReportData{}, time.Now())
+	require.NoError(t, err)
+
+	tables, names := readCSVZip(t, archive)
+	assert.Equal(t, []string{
+		"check_ins.csv", "medications.csv", "blood_pressure.csv", "menstruation.csv", "fitness.csv",
+	}, names)
+
+	// Changing these breaks clinic imports; append columns and update api/openapi.json instead
+	want := map[string]string{
+		"check_ins.csv": "id,check_in_date,symptoms,mood,pain_level,energy_level,sleep_quality," +
+			"medication_taken,physical_activity,breakfast,lunch,dinner,general_feeling,additional_notes,low_confidence",
+		"medications.csv":    "id,name,dosage,frequency,start_date,end_date,notes,active",
+		"blood_pressure.csv": "id,measured_at,systolic,diastolic,pulse",
+		"menstruation.csv":   "id,start_date,end_date,flow_intensity,symptoms",
+		"fitness.csv":        "id,date,data_type,value,unit,source",
+	}
+	for name, header := range want {
+		require.Len(t, tables[name], 1, "%s holds only its header", name)
+		assert.Equal(t, header, strings.Join(tables[name][0], ","), name)
+	}
+}
+
+func TestGenerateReportCSVZip_Rows(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	end := now.AddDate(0, 0, 5)
+	notes := "after breakfast, \"with water\""
+	pain := 4
+	flow := "light"
+
+	archive, err := generateReportCSVZip(&pdf.ReportData{
+		CheckIns: []model.HealthCheckIn{
+			{ID: "c1", CheckInDate: now, Symptoms: []string{"headache", "nausea"}, PainLevel: &pain, LowConfidence: true},
+			{ID: "c2", CheckInDate: now.AddDate(0, 0, -1)},
+		},
+		Medications: []model.Medication{
+			{ID: "m1", Name: "Ibuprofen", Dosage: "200mg", Frequency: "daily", StartDate: now, EndDate: &end, Notes: &notes, Active: true},
+		},
+		BloodPressure: []model.BloodPressureReading{
+			{ID: "bp1", Systolic: 128, Diastolic: 84, Pulse: 71, MeasuredAt: now},
+		},
+		MenstruationCycles: []model.MenstruationCycle{
+			{ID: "mc1", StartDate: now, FlowIntensity: &flow},
+		},
+		FitnessData: []model.FitnessDataPoint{
+			{ID: "f1", Date: now, DataType: "distance", Value: 5230.5, Unit: "meters", Source: "health_connect"},
+		},
+	}, now)
+	require.NoError(t, err)
+
+	tables, _ := readCSVZip(t, archive)
+	assert.Len(t, tables["check_ins.csv"], 3)
+	assert.Equal(t, []string{
+		"c1", "2026-03-01", "headache; nausea", "", "4", "", "", "", "", "", "", "", "", "", "true",
+	}, tables["check_ins.csv"][1])
+	assert.Equal(t, []string{
+		"m1", "Ibuprofen", "200mg", "daily", "2026-03-01", "2026-03-06", notes, "true",
+	}, tables["medications.csv"][1], "quoted values survive the round trip")
+	assert.Equal(t, []string{"bp1", "2026-03-01T09:30:00Z", "128", "84", "71"}, tables["blood_pressure.csv"][1])
+	assert.Equal(t, []string{"mc1", "2026-03-01", "", "light", ""}, tables["menstruation.csv"][1])
+	assert.Equal(t, []string{"f1", "2026-03-01", "distance", "5230.5", "meters", "health_connect"}, tables["fitness.csv"][1])
+}
+
+func TestReportService_UploadCSVReport(t *testing.T) {
+	blob := azure.NewMockBlobStorageClient(zap.NewNop())
+	svc := NewReportService(nil, nil, nil, blob, nil, zap.NewNop())
+
+	archive, blobPath, fingerprint, err := svc.uploadCSVReport(context.Background(), blob, &pdf.ReportData{ReportID: "r1"})
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(blobPath, "reports/r1_"))
+	assert.True(t, strings.HasSuffix(blobPath, ".zip"))
+	assert.Equal(t, archive, blob.Storage[blobPath])
+	assert.Equal(t, pdf.SHA256Hex(archive), fingerprint.SHA256)
+	assert.Empty(t, fingerprint.VerificationCode, "a CSV report has no printed verification code")
+}
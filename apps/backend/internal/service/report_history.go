@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// ListReports retrieves one page of a user's previous reports, newest first, with the
+// total number of reports
+func (s *ReportService) ListReports(ctx context.Context, userID string, page repository.Page) ([]model.Report, int, error) {
+	reports, total, err := s.dashboardRepo.ListReportsByUserID(ctx, userID, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list reports: %w", err)
+	}
+
+	return reports, total, nil
+}
+
+// DeleteReport deletes a report's PDF and its record. Reports of other users are
+// reported as not found so their existence is not disclosed. Reports that are still
+// being generated cannot be deleted. requestedBy is the authenticated user, or empty
+// when authentication is disabled.
+func (s *ReportService) DeleteReport(ctx context.Context, reportID, requestedBy, ipAddress, userAgent string) error {
+	report, err := s.dashboardRepo.GetReportByID(ctx, reportID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: %s", ErrReportNotFound, reportID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get report record: %w", err)
+	}
+	if requestedBy != "" && requestedBy != report.UserID {
+		return fmt.Errorf("%w: %s", ErrReportNotFound, reportID)
+	}
+	if report.Status == model.ReportStatusPending || report.Status == model.ReportStatusProcessing {
+		return fmt.Errorf("%w: report is %s", ErrReportNotReady, report.Status)
+	}
+
+	// The blob goes first: if deleting the record fails afterwards, retrying finds the
+	// record again and a missing blob is not an error
+	if report.FilePath != "" {
+		storage, err := s.storage.Backend(report.StorageBackend)
+		if err != nil {
+			return err
+		}
+		if err := storage.DeletePDF(ctx, report.FilePath); err != nil {
+			s.logger.Error("failed to delete report PDF",
+				zap.Error(err),
+				zap.String("report_id", reportID),
+				zap.String("blob_path", report.FilePath),
+				zap.String("backend", report.StorageBackend),
+			)
+			telemetry.ReportError(ctx, s.reporter, telemetry.KindUpstreamFailure, "blob.delete_report", err)
+			return fmt.Errorf("failed to delete report PDF: %w", err)
+		}
+	}
+
+	deleted, err := s.dashboardRepo.DeleteReport(ctx, reportID)
+	if err != nil {
+		return fmt.Errorf("failed to delete report record: %w", err)
+	}
+	if !deleted {
+		return fmt.Errorf("%w: %s", ErrReportNotFound, reportID)
+	}
+
+	if s.usage != nil && report.SizeBytes > 0 {
+		s.usage.Record(ctx, report.UserID, repository.UsageDelta{ReportBytes: -report.SizeBytes})
+	}
+
+	if s.limiter != nil {
+		s.limiter.Forget(reportID)
+	}
+
+	s.auditDeleted(ctx, report, requestedBy, ipAddress, userAgent)
+
+	s.logger.Info("report deleted",
+		zap.String("report_id", reportID),
+		zap.String("user_id", report.UserID),
+	)
+
+	return nil
+}
+
+// auditDeleted records the deletion of a report in the audit log
+func (s *ReportService) auditDeleted(ctx context.Context, report *model.Report, requestedBy, ipAddress, userAgent string) {
+	if s.auditLogger == nil {
+		return
+	}
+
+	actorID := requestedBy
+	if actorID == "" {
+		actorID = report.UserID
+	}
+
+	err := s.auditLogger.Log(ctx, audit.AuditLog{
+		UserID:        actorID,
+		OperationType: audit.OperationDelete,
+		ResourceType:  audit.ResourceReport,
+		ResourceID:    report.ID,
+		IPAddress:     ipAddress,
+		UserAgent:     userAgent,
+		AdditionalData: map[string]interface{}{
+			"owner_id":         report.UserID,
+			"date_range_start": report.DateRangeStart.Format("2006-01-02"),
+			"date_range_end":   report.DateRangeEnd.Format("2006-01-02"),
+			"sections":         report.Sections,
+		},
+	})
+	if err != nil {
+		s.logger.Error("failed to audit report deletion", zap.Error(err), zap.String("report_id", report.ID))
+	}
+}
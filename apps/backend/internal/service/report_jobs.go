@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/pdf"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// interruptedReportMessage is recorded on reports whose generation was interrupted and
+// will not be retried
+const interruptedReportMessage = "report generation was interrupted by a server restart, please request it again"
+
+var (
+	// ErrReportQueueUnavailable is returned when report generation cannot be queued,
+	// because no job store is configured or the queue is full
+	ErrReportQueueUnavailable = errors.New("report generation queue is unavailable")
+
+	// ErrReportNotReady is returned when downloading a report that is not completed
+	ErrReportNotReady = errors.New("report is not ready")
+)
+
+// ReportStatus is the generation status of a report. Error is set for failed reports.
+type ReportStatus struct {
+	JobID    string             `json:"job_id,omitempty"`
+	ReportID string             `json:"report_id,omitempty"`
+	Status   model.ReportStatus `json:"status"`
+	Error    string             `json:"error,omitempty"`
+	// Password opens an encrypted report. It is returned only when the report is
+	// requested and cannot be recovered later.
+	Password string `json:"password,omitempty"`
+}
+
+// ReportJobStore defines the persistence operations of the report generation queue
+type ReportJobStore interface {
+	EnqueueReport(ctx context.Context, report *model.Report, job *model.ReportJob) error
+	CountPendingJobs(ctx context.Context) (int, error)
+	ClaimNextJob(ctx context.Context, staleBefore time.Time) (*model.ReportJob, error)
+	CompleteJob(ctx context.Context, jobID string) error
+	FailJob(ctx context.Context, jobID, message string) error
+	GetJob(ctx context.Context, jobID string) (*model.ReportJob, error)
+}
+
+// reportJob is a claimed report generation job
+type reportJob struct {
+	reportID  string
+	userID    string
+	userName  string
+	language  pdf.Language
+	format    model.ReportFormat
+	sections  []model.ReportSection
+	startDate time.Time
+	endDate   time.Time
+	// primary forces the data collection to the primary database, for requests
+	// that must see writes the read replica may not have replayed yet
+	primary bool
+	// password protects the PDF when set
+	password string
+}
+
+// newReportJob converts a queued job of the report with reportID, decrypting the
+// password of a password protected report
+func (s *ReportService) newReportJob(reportID string, job *model.ReportJob) (reportJob, error) {
+	password := job.Password
+	if password != "" {
+		if s.passwords == nil {
+			return reportJob{}, ErrReportEncryptionUnavailable
+		}
+		decrypted, err := s.passwords.Decrypt(password)
+		if err != nil {
+			return reportJob{}, fmt.Errorf("failed to decrypt report password: %w", err)
+		}
+		password = decrypted
+	}
+
+	return reportJob{
+		reportID:  reportID,
+		userID:    job.UserID,
+		userName:  job.UserName,
+		language:  pdf.Language(job.Language),
+		format:    job.Format,
+		sections:  job.Sections,
+		startDate: job.StartDate,
+		endDate:   job.EndDate,
+		primary:   job.Primary,
+		password:  password,
+	}, nil
+}
+
+// notifyJobQueued wakes a waiting worker without blocking
+func (s *ReportService) notifyJobQueued() {
+	select {
+	case s.jobsQueued <- struct{}{}:
+	default:
+	}
+}
+
+// processJob generates one report and persists each status transition of the report
+func (s *ReportService) processJob(ctx context.Context, job reportJob) error {
+	if err := s.dashboardRepo.MarkReportProcessing(ctx, job.reportID); err != nil {
+		s.logger.Error("failed to mark report processing", zap.Error(err), zap.String("report_id", job.reportID))
+	}
+
+	report, size, err := s.buildReport(ctx, job)
+	if err == nil {
+		err = s.dashboardRepo.CompleteReport(ctx, report)
+	}
+	if err != nil {
+		s.logger.Error("report generation failed",
+			zap.Error(err),
+			zap.String("report_id", job.reportID),
+			zap.String("user_id", job.userID),
+		)
+		s.failReport(ctx, job.reportID, err.Error())
+		return err
+	}
+
+	if s.usage != nil {
+		s.usage.Record(ctx, job.userID, repository.UsageDelta{ReportBytes: int64(size)})
+	}
+
+	if s.limiter != nil && job.password == "" {
+		s.limiter.Remember(job.userID, job.format, job.sections, job.startDate, job.endDate, job.reportID)
+	}
+
+	s.logger.Info("health report generated successfully",
+		zap.String("report_id", report.ID),
+		zap.String("user_id", report.UserID),
+		zap.String("blob_path", report.FilePath),
+		zap.String("verification_code", report.VerificationCode),
+	)
+	return nil
+}
+
+// failReport marks a report failed, logging when that fails too
+func (s *ReportService) failReport(ctx context.Context, reportID, message string) {
+	if err := s.dashboardRepo.FailReport(ctx, reportID, message); err != nil {
+		s.logger.Error("failed to mark report failed", zap.Error(err), zap.String("report_id", reportID))
+	}
+}
+
+// GetReportJob returns the status of a report generation job. requestedBy is the
+// authenticated user, or empty when authentication is disabled.
+func (s *ReportService) GetReportJob(ctx context.Context, jobID, requestedBy string) (*ReportStatus, error) {
+	if s.jobStore == nil {
+		return nil, ErrReportQueueUnavailable
+	}
+	job, err := s.jobStore.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if requestedBy != "" && requestedBy != job.UserID {
+		return nil, ErrReportAccessDenied
+	}
+
+	status := &ReportStatus{JobID: job.ID, Status: job.Status, Error: job.ErrorText}
+	if job.ReportID != nil {
+		status.ReportID = *job.ReportID
+	}
+	return status, nil
+}
+
+// GetReportStatus returns the generation status of a report. requestedBy is the
+// authenticated user, or empty when authentication is disabled.
+func (s *ReportService) GetReportStatus(ctx context.Context, reportID, requestedBy string) (*ReportStatus, error) {
+	report, err := s.dashboardRepo.GetReportByID(ctx, reportID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrReportNotFound, reportID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report record: %w", err)
+	}
+	if requestedBy != "" && requestedBy != report.UserID {
+		return nil, ErrReportAccessDenied
+	}
+
+	return &ReportStatus{
+		ReportID: report.ID,
+		Status:   report.Status,
+		Error:    report.ErrorMessage,
+	}, nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/pdf"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+func TestReportService_GenerateReportWithoutJobStore(t *testing.T) {
+	svc := NewReportService(nil, nil, nil, nil, nil, zap.NewNop())
+
+	_, err := svc.GenerateReport(context.Background(), "user-1", pdf.LanguageEnglish, model.ReportFormatPDF, nil, false, time.Now().AddDate(0, 0, -7), time.Now())
+	assert.ErrorIs(t, err, ErrReportQueueUnavailable)
+}
+
+func TestReportService_GenerateReportRejectsUnknownFormat(t *testing.T) {
+	store := &fakeReportJobStore{}
+	svc := NewReportService(nil, nil, nil, nil, nil, zap.NewNop())
+	svc.SetJobStore(store, 10)
+
+	_, err := svc.GenerateReport(context.Background(), "user-1", pdf.LanguageEnglish, "xlsx", nil, false, time.Now().AddDate(0, 0, -7), time.Now())
+	assert.ErrorIs(t, err, ErrUnsupportedReportFormat)
+	assert.Empty(t, store.jobs)
+}
+
+func TestReportService_GenerateReportRejectsUnknownSection(t *testing.T) {
+	store := &fakeReportJobStore{}
+	svc := NewReportService(nil, nil, nil, nil, nil, zap.NewNop())
+	svc.SetJobStore(store, 10)
+
+	sections := []model.ReportSection{model.ReportSectionBloodPressure, "lab_results"}
+	_, err := svc.GenerateReport(context.Background(), "user-1", pdf.LanguageEnglish, model.ReportFormatPDF, sections, false, time.Now().AddDate(0, 0, -7), time.Now())
+	assert.ErrorIs(t, err, ErrUnknownReportSection)
+	assert.Empty(t, store.jobs)
+}
+
+func TestReportService_GenerateReportRejectsEncryptedCSV(t *testing.T) {
+	store := &fakeReportJobStore{}
+	svc := NewReportService(nil, nil, nil, nil, nil, zap.NewNop())
+	svc.SetJobStore(store, 10)
+
+	_, err := svc.GenerateReport(context.Background(), "user-1", pdf.LanguageEnglish, model.ReportFormatCSV, nil, true, time.Now().AddDate(0, 0, -7), time.Now())
+	assert.ErrorIs(t, err, ErrReportEncryptionUnsupported)
+	assert.Empty(t, store.jobs)
+}
+
+func TestReportService_GenerateReportDoesNotReuseForEncryptedRequest(t *testing.T) {
+	start, end := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+	limiter := NewReportLimiter(0, time.Hour, 10*time.Minute)
+	limiter.Remember("user-1", model.ReportFormatPDF, model.AllReportSections, start, end, "existing-report")
+	svc := NewReportService(nil, nil, nil, nil, nil, zap.NewNop())
+	svc.SetLimiter(limiter)
+	svc.SetPasswordEncryptor(newTestPasswordEncryptor(t))
+
+	// Without a job store a report that is not reused cannot be queued
+	_, err := svc.GenerateReport(context.Background(), "user-1", pdf.LanguageEnglish, model.ReportFormatPDF, nil, true, start, end)
+	assert.ErrorIs(t, err, ErrReportQueueUnavailable, "the password of the earlier report cannot be returned again")
+}
+
+func TestReportService_UploadPDFReportEncrypted(t *testing.T) {
+	blob := azure.NewMockBlobStorageClient(zap.NewNop())
+	svc := NewReportService(nil, nil, nil, blob, pdf.NewPDFGenerator(zap.NewNop()), zap.NewNop())
+
+	data, blobPath, fingerprint, err := svc.uploadPDFReport(context.Background(), blob, &pdf.ReportData{ReportID: "r1", Password: rand.Text()})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(data), "/Encrypt")
+	assert.Equal(t, data, blob.Storage[blobPath])
+	assert.Equal(t, pdf.SHA256Hex(data), fingerprint.SHA256)
+}
+
+func TestNormalizeReportSections(t *testing.T) {
+	sections, err := normalizeReportSections(nil)
+	require.NoError(t, err)
+	assert.Equal(t, model.AllReportSections, sections, "all sections when none are requested")
+
+	sections, err = normalizeReportSections([]model.ReportSection{
+		model.ReportSectionMedications, model.ReportSectionBloodPressure, model.ReportSectionMedications,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []model.ReportSection{model.ReportSectionMedications, model.ReportSectionBloodPressure}, sections)
+}
+
+// fakeUserStore is an in-memory UserStore
+type fakeUserStore map[string]*model.User
+
+func (f fakeUserStore) FindByID(ctx context.Context, userID string) (*model.User, error) {
+	if user, ok := f[userID]; ok {
+		return user, nil
+	}
+	return nil, repository.ErrUserNotFound
+}
+
+func TestReportService_GenerateReportPrintsStoredUserName(t *testing.T) {
+	deletedAt := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	store := &fakeReportJobStore{}
+	svc := NewReportService(nil, nil, nil, nil, nil, zap.NewNop())
+	svc.SetJobStore(store, 10)
+	svc.SetUserStore(fakeUserStore{
+		"user-1": {ID: "user-1", Name: "Kovács Anna"},
+		"user-2": {ID: "user-2", Name: "Deleted", DeletedAt: &deletedAt},
+	})
+	generate := func(userID string) error {
+		_, err := svc.GenerateReport(context.Background(), userID, pdf.LanguageEnglish, model.ReportFormatPDF, nil, false, time.Now().AddDate(0, 0, -7), time.Now())
+		return err
+	}
+
+	require.NoError(t, generate("user-1"))
+	require.NoError(t, generate("user-3"))
+	require.Len(t, store.jobs, 2)
+	assert.Equal(t, "Kovács Anna", store.jobs[0].UserName)
+	assert.Equal(t, unknownPatientName, store.jobs[1].UserName, "a user without a users row gets a placeholder")
+
+	assert.ErrorIs(t, generate("user-2"), ErrReportUserDeleted)
+	assert.Len(t, store.jobs, 2)
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+)
+
+// ReportRateLimitError is returned when a user exceeds the report generation limit
+type ReportRateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ReportRateLimitError) Error() string {
+	return fmt.Sprintf("report generation limit exceeded, retry after %s", e.RetryAfter)
+}
+
+// recentReport remembers a generated report for deduplication
+type recentReport struct {
+	reportID    string
+	generatedAt time.Time
+}
+
+// ReportLimiter enforces a per-user sliding window limit on report generation
+// and remembers recently generated reports so identical requests can be deduplicated
+type ReportLimiter struct {
+	mu           sync.Mutex
+	maxPerWindow int
+	window       time.Duration
+	dedupeWindow time.Duration
+	attempts     map[string][]time.Time
+	recent       map[string]recentReport
+	now          func() time.Time
+}
+
+// NewReportLimiter creates a new ReportLimiter. A maxPerWindow of zero disables
+// the limit and a dedupeWindow of zero disables deduplication.
+func NewReportLimiter(maxPerWindow int, window, dedupeWindow time.Duration) *ReportLimiter {
+	return &ReportLimiter{
+		maxPerWindow: maxPerWindow,
+		window:       window,
+		dedupeWindow: dedupeWindow,
+		attempts:     make(map[string][]time.Time),
+		recent:       make(map[string]recentReport),
+		now:          time.Now,
+	}
+}
+
+// Reserve consumes one report generation slot for the user, or returns a
+// ReportRateLimitError carrying the time until the oldest slot frees up
+func (l *ReportLimiter) Reserve(userID string) error {
+	if l.maxPerWindow <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	cutoff := now.Add(-l.window)
+
+	// Drop attempts that fell out of the window
+	attempts := l.attempts[userID]
+	kept := attempts[:0]
+	for _, t := range attempts {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+
+	if len(kept) >= l.maxPerWindow {
+		l.attempts[userID] = kept
+		retryAfter := kept[0].Add(l.window).Sub(now)
+		if retryAfter < time.Second {
+			retryAfter = time.Second
+		}
+		return &ReportRateLimitError{RetryAfter: retryAfter}
+	}
+
+	l.attempts[userID] = append(kept, now)
+	return nil
+}
+
+// Recent returns the ID of a report generated for the same user, format, sections and
+// date range within the dedupe window
+func (l *ReportLimiter) Recent(userID string, format model.ReportFormat, sections []model.ReportSection, startDate, endDate time.Time) (string, bool) {
+	if l.dedupeWindow <= 0 {
+		return "", false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := reportDedupeKey(userID, format, sections, startDate, endDate)
+	report, ok := l.recent[key]
+	if !ok {
+		return "", false
+	}
+
+	if l.now().Sub(report.generatedAt) > l.dedupeWindow {
+		delete(l.recent, key)
+		return "", false
+	}
+
+	return report.reportID, true
+}
+
+// Remember records a generated report for deduplication of identical requests
+func (l *ReportLimiter) Remember(userID string, format model.ReportFormat, sections []model.ReportSection, startDate, endDate time.Time, reportID string) {
+	if l.dedupeWindow <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	for key, report := range l.recent {
+		if now.Sub(report.generatedAt) > l.dedupeWindow {
+			delete(l.recent, key)
+		}
+	}
+
+	l.recent[reportDedupeKey(userID, format, sections, startDate, endDate)] = recentReport{
+		reportID:    reportID,
+		generatedAt: now,
+	}
+}
+
+// Forget drops a deleted report so identical requests generate a new one
+func (l *ReportLimiter) Forget(reportID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key, report := range l.recent {
+		if report.reportID == reportID {
+			delete(l.recent, key)
+		}
+	}
+}
+
+// reportDedupeKey builds the key identifying identical report requests
+func reportDedupeKey(userID string, format model.ReportFormat, sections []model.ReportSection, startDate, endDate time.Time) string {
+	return fmt.Sprintf("%s|%s|%v|%s|%s", userID, format, sections, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+)
+
+func TestReportLimiter_ExhaustsPerUserLimit(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewReportLimiter(3, time.Hour, 0)
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, limiter.Reserve("user-1"))
+		now = now.Add(time.Minute)
+	}
+
+	err := limiter.Reserve("user-1")
+	var rateLimitErr *ReportRateLimitError
+	require.True(t, errors.As(err, &rateLimitErr))
+	// The first attempt was made 3 minutes ago and frees up after one hour
+	assert.Equal(t, 57*time.Minute, rateLimitErr.RetryAfter)
+
+	// Other users are not affected
+	assert.NoError(t, limiter.Reserve("user-2"))
+
+	// Slot frees up once the oldest attempt leaves the window
+	now = now.Add(57 * time.Minute)
+	assert.NoError(t, limiter.Reserve("user-1"))
+}
+
+func TestReportLimiter_DisabledLimit(t *testing.T) {
+	limiter := NewReportLimiter(0, time.Hour, 0)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, limiter.Reserve("user-1"))
+	}
+}
+
+func TestReportLimiter_DedupesIdenticalRecentRequest(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewReportLimiter(5, time.Hour, 10*time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	start := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+
+	_, ok := limiter.Recent("user-1", model.ReportFormatPDF, model.AllReportSections, start, end)
+	assert.False(t, ok)
+
+	limiter.Remember("user-1", model.ReportFormatPDF, model.AllReportSections, start, end, "report-1")
+
+	reportID, ok := limiter.Recent("user-1", model.ReportFormatPDF, model.AllReportSections, start, end)
+	require.True(t, ok)
+	assert.Equal(t, "report-1", reportID)
+
+	// Different range, format, sections or user is not deduplicated
+	_, ok = limiter.Recent("user-1", model.ReportFormatPDF, model.AllReportSections, start, end.AddDate(0, 0, -1))
+	assert.False(t, ok)
+	_, ok = limiter.Recent("user-1", model.ReportFormatCSV, model.AllReportSections, start, end)
+	assert.False(t, ok)
+	_, ok = limiter.Recent("user-1", model.ReportFormatPDF, []model.ReportSection{model.ReportSectionBloodPressure}, start, end)
+	assert.False(t, ok)
+	_, ok = limiter.Recent("user-2", model.ReportFormatPDF, model.AllReportSections, start, end)
+	assert.False(t, ok)
+
+	// Expires after the dedupe window
+	now = now.Add(11 * time.Minute)
+	_, ok = limiter.Recent("user-1", model.ReportFormatPDF, model.AllReportSections, start, end)
+	assert.False(t, ok)
+}
+
+func TestReportLimiter_ForgetDeletedReport(t *testing.T) {
+	limiter := NewReportLimiter(5, time.Hour, 10*time.Minute)
+
+	start := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+
+	limiter.Remember("user-1", model.ReportFormatPDF, model.AllReportSections, start, end, "report-1")
+	limiter.Remember("user-1", model.ReportFormatPDF, model.AllReportSections, start, end.AddDate(0, 0, -7), "report-2")
+
+	limiter.Forget("report-1")
+
+	_, ok := limiter.Recent("user-1", model.ReportFormatPDF, model.AllReportSections, start, end)
+	assert.False(t, ok, "a deleted report is not returned for identical requests")
+
+	reportID, ok := limiter.Recent("user-1", model.ReportFormatPDF, model.AllReportSections, start, end.AddDate(0, 0, -7))
+	require.True(t, ok)
+	assert.Equal(t, "report-2", reportID)
+}
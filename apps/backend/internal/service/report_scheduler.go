@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/pdf"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// ReportScheduleCheckInterval is how often report schedules are checked for a due report
+const ReportScheduleCheckInterval = time.Hour
+
+// ErrInvalidReportSchedule is returned for a report schedule with an unknown cadence or
+// a day outside its range
+var ErrInvalidReportSchedule = errors.New("invalid report schedule")
+
+// ReportScheduleStore defines the persistence operations needed for report schedules
+type ReportScheduleStore interface {
+	UpsertSchedule(ctx context.Context, schedule *model.ReportSchedule) error
+	GetSchedule(ctx context.Context, userID string) (*model.ReportSchedule, error)
+	FindEnabledSchedules(ctx context.Context) ([]model.ReportSchedule, error)
+	ClaimRun(ctx context.Context, userID string, runOn time.Time) (bool, error)
+	ReleaseRun(ctx context.Context, userID string, runOn time.Time, previous *time.Time) error
+}
+
+// ReportGenerator queues the generation of a report
+type ReportGenerator interface {
+	GenerateReport(ctx context.Context, userID string, language pdf.Language, format model.ReportFormat, sections []model.ReportSection, encrypt bool, startDate, endDate time.Time) (*ReportStatus, error)
+}
+
+// ReportScheduler queues the reports users scheduled weekly or monthly. Schedules are
+// evaluated in UTC. A report is queued once per scheduled day, also when several server
+// instances run the scheduler, and a day missed while no server ran is caught up at the
+// next check.
+type ReportScheduler struct {
+	store   ReportScheduleStore
+	reports ReportGenerator
+	done    sync.WaitGroup
+	logger  *zap.Logger
+	now     func() time.Time
+}
+
+// NewReportScheduler creates a new ReportScheduler queueing reports with reports
+func NewReportScheduler(store ReportScheduleStore, reports ReportGenerator, logger *zap.Logger) *ReportScheduler {
+	return &ReportScheduler{
+		store:   store,
+		reports: reports,
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+// SetSchedule creates or replaces a user's report schedule
+func (s *ReportScheduler) SetSchedule(ctx context.Context, schedule *model.ReportSchedule) error {
+	switch schedule.Cadence {
+	case model.ReportCadenceWeekly:
+		if schedule.Day < 1 || schedule.Day > 7 {
+			return fmt.Errorf("%w: a weekly report's day must be from 1 (Monday) to 7 (Sunday)", ErrInvalidReportSchedule)
+		}
+	case model.ReportCadenceMonthly:
+		if schedule.Day < 1 || schedule.Day > 28 {
+			return fmt.Errorf("%w: a monthly report's day must be from 1 to 28", ErrInvalidReportSchedule)
+		}
+	default:
+		return fmt.Errorf("%w: cadence must be weekly or monthly", ErrInvalidReportSchedule)
+	}
+
+	return s.store.UpsertSchedule(ctx, schedule)
+}
+
+// GetSchedule returns a user's report schedule
+func (s *ReportScheduler) GetSchedule(ctx context.Context, userID string) (*model.ReportSchedule, error) {
+	return s.store.GetSchedule(ctx, userID)
+}
+
+// Start checks the schedules for due reports every interval until ctx is cancelled
+func (s *ReportScheduler) Start(ctx context.Context, interval time.Duration) {
+	s.done.Add(1)
+	go s.run(ctx, interval)
+	s.logger.Info("report scheduler started", zap.Duration("interval", interval))
+}
+
+// Wait blocks until the scheduler has finished queueing its current report after the
+// context passed to Start was cancelled
+func (s *ReportScheduler) Wait() {
+	s.done.Wait()
+}
+
+// run queues the due reports every interval until ctx is cancelled
+func (s *ReportScheduler) run(ctx context.Context, interval time.Duration) {
+	defer s.done.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.queueDueReports(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// queueDueReports queues the report of every schedule that is due. A report being
+// queued when ctx is cancelled is finished; the remaining ones wait for the next start.
+func (s *ReportScheduler) queueDueReports(ctx context.Context) {
+	schedules, err := s.store.FindEnabledSchedules(ctx)
+	if err != nil {
+		s.logger.Error("failed to find report schedules", zap.Error(err))
+		return
+	}
+
+	now := s.now()
+	for i := range schedules {
+		if ctx.Err() != nil {
+			return
+		}
+		s.queueReport(context.WithoutCancel(ctx), &schedules[i], now)
+	}
+}
+
+// queueReport queues a schedule's report if it is due and no other scheduler queued it.
+// A report that could not be queued is released to be retried at the next check,
+// unless the user was deleted.
+func (s *ReportScheduler) queueReport(ctx context.Context, schedule *model.ReportSchedule, now time.Time) {
+	runOn, due := dueReportRun(schedule, now)
+	if !due {
+		return
+	}
+
+	logger := s.logger.With(
+		zap.String("user_id", schedule.UserID),
+		zap.String("cadence", string(schedule.Cadence)),
+		zap.Time("run_on", runOn),
+	)
+
+	claimed, err := s.store.ClaimRun(ctx, schedule.UserID, runOn)
+	if err != nil || !claimed {
+		return
+	}
+
+	start, end := reportSchedulePeriod(schedule.Cadence, runOn)
+	status, err := s.reports.GenerateReport(ctx, schedule.UserID, pdf.Language(schedule.Language), model.ReportFormatPDF, nil, false, start, end)
+	if errors.Is(err, ErrReportUserDeleted) {
+		logger.Warn("skipping scheduled report of deleted user")
+		return
+	}
+	if err != nil {
+		logger.Error("failed to queue scheduled report", zap.Error(err))
+		if err := s.store.ReleaseRun(ctx, schedule.UserID, runOn, schedule.LastRunOn); err != nil {
+			logger.Error("failed to release scheduled report", zap.Error(err))
+		}
+		return
+	}
+
+	logger.Info("queued scheduled report", zap.String("report_id", status.ReportID))
+}
+
+// dueReportRun returns the latest day a schedule's report was scheduled on and whether
+// it is due: the schedule is enabled, that day's report was not queued yet and the day
+// is not before the schedule was last changed, so a new schedule does not report a past
+// period
+func dueReportRun(schedule *model.ReportSchedule, now time.Time) (time.Time, bool) {
+	runOn := latestReportRun(schedule.Cadence, schedule.Day, now)
+	if !schedule.Enabled {
+		return runOn, false
+	}
+	if schedule.LastRunOn != nil && !schedule.LastRunOn.Before(runOn) {
+		return runOn, false
+	}
+	return runOn, !runOn.Before(truncateToDay(schedule.UpdatedAt.UTC()))
+}
+
+// latestReportRun returns the latest day, today or before, a report with cadence is
+// scheduled on day
+func latestReportRun(cadence model.ReportCadence, day int, now time.Time) time.Time {
+	today := truncateToDay(now.UTC())
+	if cadence == model.ReportCadenceMonthly {
+		runOn := time.Date(today.Year(), today.Month(), day, 0, 0, 0, 0, time.UTC)
+		if runOn.After(today) {
+			runOn = runOn.AddDate(0, -1, 0)
+		}
+		return runOn
+	}
+
+	weekday := (int(today.Weekday())+6)%7 + 1 // ISO weekday, 1 is Monday
+	return today.AddDate(0, 0, -((weekday - day + 7) % 7))
+}
+
+// reportSchedulePeriod returns the first and last day reported by a report scheduled on
+// runOn: the week or month ending the day before
+func reportSchedulePeriod(cadence model.ReportCadence, runOn time.Time) (time.Time, time.Time) {
+	start := runOn.AddDate(0, 0, -7)
+	if cadence == model.ReportCadenceMonthly {
+		start = runOn.AddDate(0, -1, 0)
+	}
+	return start, runOn.AddDate(0, 0, -1)
+}
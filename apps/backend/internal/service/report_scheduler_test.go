@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/pdf"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// fakeReportScheduleStore is an in-memory ReportScheduleStore
+type fakeReportScheduleStore struct {
+	schedules map[string]*model.ReportSchedule
+}
+
+func newFakeReportScheduleStore(schedules ...model.ReportSchedule) *fakeReportScheduleStore {
+	store := &fakeReportScheduleStore{schedules: make(map[string]*model.ReportSchedule)}
+	for i := range schedules {
+		store.schedules[schedules[i].UserID] = &schedules[i]
+	}
+	return store
+}
+
+func (f *fakeReportScheduleStore) UpsertSchedule(ctx context.Context, schedule *model.ReportSchedule) error {
+	if existing, ok := f.schedules[schedule.UserID]; ok {
+		schedule.LastRunOn = existing.LastRunOn
+	}
+	stored := *schedule
+	f.schedules[schedule.UserID] = &stored
+	return nil
+}
+
+func (f *fakeReportScheduleStore) GetSchedule(ctx context.Context, userID string) (*model.ReportSchedule, error) {
+	schedule, ok := f.schedules[userID]
+	if !ok {
+		return nil, repository.ErrReportScheduleNotFound
+	}
+	found := *schedule
+	return &found, nil
+}
+
+func (f *fakeReportScheduleStore) FindEnabledSchedules(ctx context.Context) ([]model.ReportSchedule, error) {
+	var schedules []model.ReportSchedule
+	for _, schedule := range f.schedules {
+		if schedule.Enabled {
+			schedules = append(schedules, *schedule)
+		}
+	}
+	return schedules, nil
+}
+
+func (f *fakeReportScheduleStore) ClaimRun(ctx context.Context, userID string, runOn time.Time) (bool, error) {
+	schedule, ok := f.schedules[userID]
+	if !ok || !schedule.Enabled || (schedule.LastRunOn != nil && !schedule.LastRunOn.Before(runOn)) {
+		return false, nil
+	}
+	schedule.LastRunOn = &runOn
+	return true, nil
+}
+
+func (f *fakeReportScheduleStore) ReleaseRun(ctx context.Context, userID string, runOn time.Time, previous *time.Time) error {
+	if schedule, ok := f.schedules[userID]; ok && schedule.LastRunOn != nil && schedule.LastRunOn.Equal(runOn) {
+		schedule.LastRunOn = previous
+	}
+	return nil
+}
+
+// scheduledReport is a report queued by a fakeReportGenerator
+type scheduledReport struct {
+	userID     string
+	language   pdf.Language
+	start, end time.Time
+}
+
+// fakeReportGenerator records the reports it is asked to queue, failing with the given
+// errors in turn
+type fakeReportGenerator struct {
+	reports []scheduledReport
+	errs    []error
+}
+
+func (f *fakeReportGenerator) GenerateReport(ctx context.Context, userID string, language pdf.Language, format model.ReportFormat, sections []model.ReportSection, encrypt bool, startDate, endDate time.Time) (*ReportStatus, error) {
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	f.reports = append(f.reports, scheduledReport{userID: userID, language: language, start: startDate, end: endDate})
+	return &ReportStatus{ReportID: "report-1", Status: model.ReportStatusPending}, nil
+}
+
+func utcDay(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestLatestReportRun(t *testing.T) {
+	friday := time.Date(2026, 10, 16, 14, 30, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		cadence model.ReportCadence
+		day     int
+		now     time.Time
+		want    time.Time
+	}{
+		"weekly on today":                 {model.ReportCadenceWeekly, 5, friday, utcDay(2026, 10, 16)},
+		"weekly earlier this week":        {model.ReportCadenceWeekly, 1, friday, utcDay(2026, 10, 12)},
+		"weekly later in the week":        {model.ReportCadenceWeekly, 6, friday, utcDay(2026, 10, 10)},
+		"weekly on Sunday":                {model.ReportCadenceWeekly, 7, friday, utcDay(2026, 10, 11)},
+		"monthly on today":                {model.ReportCadenceMonthly, 16, friday, utcDay(2026, 10, 16)},
+		"monthly earlier this month":      {model.ReportCadenceMonthly, 1, friday, utcDay(2026, 10, 1)},
+		"monthly later in the month":      {model.ReportCadenceMonthly, 20, friday, utcDay(2026, 9, 20)},
+		"monthly across the year":         {model.ReportCadenceMonthly, 10, utcDay(2026, 1, 5), utcDay(2025, 12, 10)},
+		"evaluated in UTC":                {model.ReportCadenceWeekly, 5, time.Date(2026, 10, 16, 1, 0, 0, 0, time.FixedZone("EEST", 3*3600)), utcDay(2026, 10, 9)},
+		"monthly before a short February": {model.ReportCadenceMonthly, 28, utcDay(2026, 3, 1), utcDay(2026, 2, 28)},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, latestReportRun(tt.cadence, tt.day, tt.now))
+		})
+	}
+}
+
+func TestReportSchedulePeriod(t *testing.T) {
+	start, end := reportSchedulePeriod(model.ReportCadenceWeekly, utcDay(2026, 10, 12))
+	assert.Equal(t, utcDay(2026, 10, 5), start)
+	assert.Equal(t, utcDay(2026, 10, 11), end)
+
+	start, end = reportSchedulePeriod(model.ReportCadenceMonthly, utcDay(2026, 3, 1))
+	assert.Equal(t, utcDay(2026, 2, 1), start)
+	assert.Equal(t, utcDay(2026, 2, 28), end)
+}
+
+func TestDueReportRun(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	lastWeek, today := utcDay(2026, 10, 9), utcDay(2026, 10, 16)
+
+	tests := map[string]struct {
+		schedule model.ReportSchedule
+		due      bool
+	}{
+		"never run":               {model.ReportSchedule{Enabled: true, UpdatedAt: lastWeek}, true},
+		"run last period":         {model.ReportSchedule{Enabled: true, LastRunOn: &lastWeek, UpdatedAt: lastWeek}, true},
+		"run this period":         {model.ReportSchedule{Enabled: true, LastRunOn: &today, UpdatedAt: lastWeek}, false},
+		"disabled":                {model.ReportSchedule{UpdatedAt: lastWeek}, false},
+		"scheduled today":         {model.ReportSchedule{Enabled: true, UpdatedAt: now.Add(-time.Hour)}, true},
+		"scheduled after the day": {model.ReportSchedule{Enabled: true, UpdatedAt: now.AddDate(0, 0, 1)}, false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tt.schedule.Cadence = model.ReportCadenceWeekly
+			tt.schedule.Day = 5
+			runOn, due := dueReportRun(&tt.schedule, now)
+			assert.Equal(t, today, runOn)
+			assert.Equal(t, tt.due, due)
+		})
+	}
+}
+
+func TestReportScheduler_SetSchedule(t *testing.T) {
+	scheduler := NewReportScheduler(newFakeReportScheduleStore(), &fakeReportGenerator{}, zap.NewNop())
+
+	valid := []model.ReportSchedule{
+		{UserID: "user-1", Cadence: model.ReportCadenceWeekly, Day: 7},
+		{UserID: "user-2", Cadence: model.ReportCadenceMonthly, Day: 28},
+	}
+	for _, schedule := range valid {
+		require.NoError(t, scheduler.SetSchedule(context.Background(), &schedule))
+	}
+
+	invalid := map[string]model.ReportSchedule{
+		"unknown cadence":     {UserID: "user-3", Cadence: "daily", Day: 1},
+		"weekly day too late": {UserID: "user-3", Cadence: model.ReportCadenceWeekly, Day: 8},
+		"monthly day 29":      {UserID: "user-3", Cadence: model.ReportCadenceMonthly, Day: 29},
+		"day zero":            {UserID: "user-3", Cadence: model.ReportCadenceMonthly, Day: 0},
+	}
+	for name, schedule := range invalid {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, scheduler.SetSchedule(context.Background(), &schedule), ErrInvalidReportSchedule)
+		})
+	}
+
+	_, err := scheduler.GetSchedule(context.Background(), "user-3")
+	assert.ErrorIs(t, err, repository.ErrReportScheduleNotFound)
+}
+
+func TestReportScheduler_QueuesEachPeriodOnce(t *testing.T) {
+	store := newFakeReportScheduleStore(
+		model.ReportSchedule{UserID: "user-1", Cadence: model.ReportCadenceWeekly, Day: 1, Enabled: true, Language: "hu", UpdatedAt: utcDay(2026, 10, 1)},
+		model.ReportSchedule{UserID: "user-2", Cadence: model.ReportCadenceWeekly, Day: 1, UpdatedAt: utcDay(2026, 10, 1)},
+	)
+	reports := &fakeReportGenerator{}
+	scheduler := NewReportScheduler(store, reports, zap.NewNop())
+	scheduler.now = func() time.Time { return time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC) }
+
+	scheduler.queueDueReports(context.Background())
+	scheduler.queueDueReports(context.Background())
+
+	require.Len(t, reports.reports, 1, "a period is reported once and disabled schedules not at all")
+	assert.Equal(t, scheduledReport{userID: "user-1", language: pdf.LanguageHungarian, start: utcDay(2026, 10, 5), end: utcDay(2026, 10, 11)}, reports.reports[0])
+
+	// Another instance holding the schedule as it was before the report was queued
+	stale := model.ReportSchedule{UserID: "user-1", Cadence: model.ReportCadenceWeekly, Day: 1, Enabled: true, UpdatedAt: utcDay(2026, 10, 1)}
+	NewReportScheduler(store, reports, zap.NewNop()).queueReport(context.Background(), &stale, scheduler.now())
+	assert.Len(t, reports.reports, 1, "a report queued by another instance is not queued again")
+
+	scheduler.now = func() time.Time { return time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC) }
+	scheduler.queueDueReports(context.Background())
+	require.Len(t, reports.reports, 2)
+	assert.Equal(t, utcDay(2026, 10, 12), reports.reports[1].start)
+}
+
+func TestReportScheduler_RetriesFailedReports(t *testing.T) {
+	lastRun := utcDay(2026, 10, 5)
+	store := newFakeReportScheduleStore(
+		model.ReportSchedule{UserID: "user-1", Cadence: model.ReportCadenceWeekly, Day: 1, Enabled: true, LastRunOn: &lastRun, UpdatedAt: utcDay(2026, 10, 1)},
+	)
+	reports := &fakeReportGenerator{errs: []error{ErrReportQueueUnavailable}}
+	scheduler := NewReportScheduler(store, reports, zap.NewNop())
+	scheduler.now = func() time.Time { return time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC) }
+
+	scheduler.queueDueReports(context.Background())
+	assert.Empty(t, reports.reports)
+	assert.Equal(t, &lastRun, store.schedules["user-1"].LastRunOn, "the claim of a failed report is released")
+
+	scheduler.queueDueReports(context.Background())
+	assert.Len(t, reports.reports, 1)
+	assert.Equal(t, utcDay(2026, 10, 12), *store.schedules["user-1"].LastRunOn)
+}
+
+func TestReportScheduler_SkipsDeletedUsers(t *testing.T) {
+	store := newFakeReportScheduleStore(
+		model.ReportSchedule{UserID: "user-1", Cadence: model.ReportCadenceMonthly, Day: 1, Enabled: true, UpdatedAt: utcDay(2026, 9, 1)},
+	)
+	reports := &fakeReportGenerator{errs: []error{ErrReportUserDeleted}}
+	scheduler := NewReportScheduler(store, reports, zap.NewNop())
+	scheduler.now = func() time.Time { return time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC) }
+
+	scheduler.queueDueReports(context.Background())
+	scheduler.queueDueReports(context.Background())
+
+	assert.Empty(t, reports.reports)
+	assert.Equal(t, utcDay(2026, 10, 1), *store.schedules["user-1"].LastRunOn, "a deleted user's report is not retried")
+}
+
+func TestReportScheduler_StopsOnCancel(t *testing.T) {
+	scheduler := NewReportScheduler(newFakeReportScheduleStore(), &fakeReportGenerator{}, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler.Start(ctx, time.Hour)
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		scheduler.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.Fail(t, "scheduler did not stop after cancellation")
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrReportURLUnavailable is returned when no URL signer is configured, so reports
+	// can only be downloaded through the backend
+	ErrReportURLUnavailable = errors.New("signed report URLs are not available")
+
+	// ErrReportNotFound is returned when a report does not exist
+	ErrReportNotFound = errors.New("report not found")
+
+	// ErrReportAccessDenied is returned when a user requests another user's report
+	ErrReportAccessDenied = errors.New("report belongs to another user")
+)
+
+// ReportURL is a signed, time-limited URL to download a report directly from storage
+type ReportURL struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SetURLSigner enables signed download URLs valid for ttl
+func (s *ReportService) SetURLSigner(signer azure.BlobURLSigner, ttl time.Duration) {
+	s.urlSigner = signer
+	s.urlTTL = ttl
+}
+
+// SetAuditLogger enables audit logging of download URL issuance
+func (s *ReportService) SetAuditLogger(auditLogger *audit.Logger) {
+	s.auditLogger = auditLogger
+}
+
+// GetReportURL issues a signed download URL for a report. requestedBy is the
+// authenticated user, or empty when authentication is disabled.
+func (s *ReportService) GetReportURL(ctx context.Context, reportID, requestedBy string) (*ReportURL, error) {
+	if s.urlSigner == nil {
+		return nil, ErrReportURLUnavailable
+	}
+
+	report, err := s.dashboardRepo.GetReportByID(ctx, reportID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrReportNotFound, reportID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report record: %w", err)
+	}
+	if requestedBy != "" && requestedBy != report.UserID {
+		return nil, ErrReportAccessDenied
+	}
+	if report.Status != model.ReportStatusCompleted {
+		return nil, fmt.Errorf("%w: report is %s", ErrReportNotReady, report.Status)
+	}
+
+	signer, err := s.urlSignerFor(report)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.urlTTL)
+	signed, err := signer.GenerateSASURL(ctx, report.FilePath, s.urlTTL)
+	if err != nil {
+		s.logger.Error("failed to sign report URL",
+			zap.Error(err),
+			zap.String("report_id", reportID),
+		)
+		telemetry.ReportError(ctx, s.reporter, telemetry.KindUpstreamFailure, "blob.sign_report_url", err)
+		return nil, fmt.Errorf("failed to sign report URL: %w", err)
+	}
+
+	s.auditURLIssued(ctx, report, requestedBy, expiresAt)
+
+	return &ReportURL{URL: signed, ExpiresAt: expiresAt}, nil
+}
+
+// urlSignerFor returns the signer of the storage backend a report was written to. Reports
+// in the default backend use the configured signer; other backends sign their own URLs.
+func (s *ReportService) urlSignerFor(report *model.Report) (azure.BlobURLSigner, error) {
+	if report.StorageBackend == "" || report.StorageBackend == DefaultStorageBackend {
+		return s.urlSigner, nil
+	}
+	storage, err := s.storage.Backend(report.StorageBackend)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := storage.(azure.BlobURLSigner)
+	if !ok {
+		return nil, ErrReportURLUnavailable
+	}
+	return signer, nil
+}
+
+// auditURLIssued records the issuance of a download URL in the audit log
+func (s *ReportService) auditURLIssued(ctx context.Context, report *model.Report, requestedBy string, expiresAt time.Time) {
+	if s.auditLogger == nil {
+		return
+	}
+
+	actorID := requestedBy
+	if actorID == "" {
+		actorID = report.UserID
+	}
+
+	err := s.auditLogger.Log(ctx, audit.AuditLog{
+		UserID:        actorID,
+		OperationType: audit.OperationRead,
+		ResourceType:  audit.ResourceReport,
+		ResourceID:    report.ID,
+		AdditionalData: map[string]interface{}{
+			"action":       "issue_download_url",
+			"requested_by": requestedBy,
+			"expires_at":   expiresAt.UTC().Format(time.RFC3339),
+			"sections":     report.Sections,
+		},
+	})
+	if err != nil {
+		s.logger.Error("failed to audit report URL issuance", zap.Error(err), zap.String("report_id", report.ID))
+	}
+}
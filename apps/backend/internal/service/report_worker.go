@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+const (
+	// ReportJobPollInterval is how often idle workers check the queue for jobs queued by
+	// other server instances; jobs queued by this instance wake a worker right away
+	ReportJobPollInterval = 5 * time.Second
+
+	// reportJobTimeout is how long a job may be processing before it is assumed that its
+	// worker stopped and the job is claimed again
+	reportJobTimeout = 10 * time.Minute
+
+	// maxReportJobAttempts is how often a job is claimed before it is failed
+	maxReportJobAttempts = 3
+)
+
+// ReportWorker generates the reports queued in a ReportJobStore. Every server instance
+// may run workers; each job is claimed by one of them.
+type ReportWorker struct {
+	reports    *ReportService
+	store      ReportJobStore
+	interval   time.Duration
+	process    func(ctx context.Context, job reportJob) error
+	failReport func(ctx context.Context, reportID, message string)
+	workers    sync.WaitGroup
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+// NewReportWorker creates a new ReportWorker generating reports with reports
+func NewReportWorker(reports *ReportService, store ReportJobStore, logger *zap.Logger) *ReportWorker {
+	return &ReportWorker{
+		reports:    reports,
+		store:      store,
+		interval:   ReportJobPollInterval,
+		process:    reports.processJob,
+		failReport: reports.failReport,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// Start starts workers goroutines generating queued reports until ctx is cancelled.
+// Unfinished reports without a job, queued in memory by an earlier version, are marked
+// failed first since no worker will generate them.
+func (w *ReportWorker) Start(ctx context.Context, workers int) error {
+	failed, err := w.reports.dashboardRepo.FailUnfinishedReports(ctx, interruptedReportMessage)
+	if err != nil {
+		return fmt.Errorf("failed to recover unfinished reports: %w", err)
+	}
+	if failed > 0 {
+		w.logger.Warn("marked interrupted reports as failed", zap.Int64("count", failed))
+	}
+
+	for i := 0; i < workers; i++ {
+		w.workers.Add(1)
+		go w.run(ctx)
+	}
+
+	w.logger.Info("report workers started",
+		zap.Int("workers", workers),
+		zap.Duration("poll_interval", w.interval),
+	)
+	return nil
+}
+
+// Wait blocks until the workers have finished their current reports after the context
+// passed to Start was cancelled
+func (w *ReportWorker) Wait() {
+	w.workers.Wait()
+}
+
+// run generates queued reports until ctx is cancelled, draining the queue whenever a
+// job is queued or the poll interval passes
+func (w *ReportWorker) run(ctx context.Context) {
+	defer w.workers.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil && w.processNext(ctx) {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.reports.jobsQueued:
+		case <-ticker.C:
+		}
+	}
+}
+
+// processNext claims and processes one job and reports whether there was one
+func (w *ReportWorker) processNext(ctx context.Context) bool {
+	job, err := w.store.ClaimNextJob(ctx, w.now().Add(-reportJobTimeout))
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.Error("failed to claim report job", zap.Error(err))
+		}
+		return false
+	}
+	if job == nil {
+		return false
+	}
+
+	// A report that has started is finished even when shutdown begins
+	ctx = context.WithoutCancel(ctx)
+
+	switch {
+	case job.ReportID == nil:
+		w.finish(ctx, job, fmt.Errorf("report was deleted before it was generated"))
+	case job.Attempts > maxReportJobAttempts:
+		w.failReport(ctx, *job.ReportID, interruptedReportMessage)
+		w.finish(ctx, job, fmt.Errorf("report generation was interrupted %d times", job.Attempts-1))
+	default:
+		next, err := w.reports.newReportJob(*job.ReportID, job)
+		if err != nil {
+			w.failReport(ctx, *job.ReportID, err.Error())
+		} else {
+			err = w.process(ctx, next)
+		}
+		w.finish(ctx, job, err)
+	}
+	return true
+}
+
+// finish records the outcome of a job
+func (w *ReportWorker) finish(ctx context.Context, job *model.ReportJob, err error) {
+	if err != nil {
+		w.logger.Warn("report job failed", zap.Error(err), zap.String("job_id", job.ID))
+		err = w.store.FailJob(ctx, job.ID, err.Error())
+	} else {
+		err = w.store.CompleteJob(ctx, job.ID)
+	}
+	if err != nil {
+		w.logger.Error("failed to record report job outcome", zap.Error(err), zap.String("job_id", job.ID))
+	}
+}
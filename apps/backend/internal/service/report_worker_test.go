@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/pdf"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/security"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// fakeReportJobStore is an in-memory ReportJobStore claiming jobs in the order they
+// were queued
+type fakeReportJobStore struct {
+	jobs []*model.ReportJob
+}
+
+func (f *fakeReportJobStore) EnqueueReport(ctx context.Context, report *model.Report, job *model.ReportJob) error {
+	job.ID = fmt.Sprintf("job-%d", len(f.jobs)+1)
+	job.ReportID = &report.ID
+	job.Status = model.ReportStatusPending
+	stored := *job
+	f.jobs = append(f.jobs, &stored)
+	return nil
+}
+
+func (f *fakeReportJobStore) CountPendingJobs(ctx context.Context) (int, error) {
+	count := 0
+	for _, job := range f.jobs {
+		if job.Status == model.ReportStatusPending {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeReportJobStore) ClaimNextJob(ctx context.Context, staleBefore time.Time) (*model.ReportJob, error) {
+	for _, job := range f.jobs {
+		if job.Status == model.ReportStatusPending {
+			job.Status = model.ReportStatusProcessing
+			job.Attempts++
+			claimed := *job
+			return &claimed, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeReportJobStore) CompleteJob(ctx context.Context, jobID string) error {
+	return f.finish(jobID, model.ReportStatusCompleted, "")
+}
+
+func (f *fakeReportJobStore) FailJob(ctx context.Context, jobID, message string) error {
+	return f.finish(jobID, model.ReportStatusFailed, message)
+}
+
+func (f *fakeReportJobStore) finish(jobID string, status model.ReportStatus, message string) error {
+	for _, job := range f.jobs {
+		if job.ID == jobID {
+			job.Status = status
+			job.ErrorText = message
+			job.Password = ""
+			return nil
+		}
+	}
+	return repository.ErrReportJobNotFound
+}
+
+func (f *fakeReportJobStore) GetJob(ctx context.Context, jobID string) (*model.ReportJob, error) {
+	for _, job := range f.jobs {
+		if job.ID == jobID {
+			found := *job
+			return &found, nil
+		}
+	}
+	return nil, repository.ErrReportJobNotFound
+}
+
+// newTestPasswordEncryptor returns an encryptor for report passwords with a fixed key
+func newTestPasswordEncryptor(t *testing.T) *security.Encryptor {
+	t.Helper()
+	passwords, err := security.NewEncryptor([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	return passwords
+}
+
+// newTestReportWorker creates a worker over store recording the jobs it processes and
+// the reports it fails instead of generating them. Passwords are decrypted with
+// passwords.
+func newTestReportWorker(store *fakeReportJobStore, passwords *security.Encryptor, processErr error) (*ReportWorker, *[]reportJob, *[]string) {
+	var processed []reportJob
+	var failed []string
+	reports := NewReportService(nil, nil, nil, nil, nil, zap.NewNop())
+	reports.SetPasswordEncryptor(passwords)
+	w := NewReportWorker(reports, store, zap.NewNop())
+	w.process = func(ctx context.Context, job reportJob) error {
+		processed = append(processed, job)
+		return processErr
+	}
+	w.failReport = func(ctx context.Context, reportID, message string) {
+		failed = append(failed, reportID)
+	}
+	return w, &processed, &failed
+}
+
+func TestReportService_GenerateReportQueuesJob(t *testing.T) {
+	store := &fakeReportJobStore{}
+	passwords := newTestPasswordEncryptor(t)
+	svc := NewReportService(nil, nil, nil, nil, nil, zap.NewNop())
+	svc.SetJobStore(store, 10)
+	svc.SetPasswordEncryptor(passwords)
+	start, end := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+
+	status, err := svc.GenerateReport(context.Background(), "user-1", pdf.LanguageHungarian, model.ReportFormatPDF, nil, true, start, end)
+	require.NoError(t, err)
+	require.Len(t, store.jobs, 1)
+
+	job := store.jobs[0]
+	assert.Equal(t, job.ID, status.JobID)
+	assert.Equal(t, *job.ReportID, status.ReportID)
+	assert.Equal(t, model.ReportStatusPending, status.Status)
+	assert.NotEmpty(t, status.Password)
+	assert.NotEqual(t, status.Password, job.Password, "the queued password is encrypted")
+	stored, err := passwords.Decrypt(job.Password)
+	require.NoError(t, err)
+	assert.Equal(t, status.Password, stored, "the worker needs the password to protect the PDF")
+	assert.Equal(t, string(pdf.LanguageHungarian), job.Language)
+	assert.Equal(t, model.AllReportSections, job.Sections)
+	assert.False(t, job.Primary)
+
+	select {
+	case <-svc.jobsQueued:
+	default:
+		assert.Fail(t, "queueing a job wakes a worker")
+	}
+}
+
+func TestReportService_GenerateReportRejectsWhenQueueFull(t *testing.T) {
+	store := &fakeReportJobStore{}
+	svc := NewReportService(nil, nil, nil, nil, nil, zap.NewNop())
+	svc.SetJobStore(store, 1)
+
+	_, err := svc.GenerateReport(context.Background(), "user-1", pdf.LanguageEnglish, model.ReportFormatPDF, nil, false, time.Now().AddDate(0, 0, -7), time.Now())
+	require.NoError(t, err)
+
+	_, err = svc.GenerateReport(context.Background(), "user-2", pdf.LanguageEnglish, model.ReportFormatPDF, nil, false, time.Now().AddDate(0, 0, -7), time.Now())
+	assert.ErrorIs(t, err, ErrReportQueueUnavailable)
+	assert.Len(t, store.jobs, 1)
+}
+
+func TestReportService_GenerateReportRejectsEncryptionWithoutKey(t *testing.T) {
+	store := &fakeReportJobStore{}
+	svc := NewReportService(nil, nil, nil, nil, nil, zap.NewNop())
+	svc.SetJobStore(store, 10)
+
+	_, err := svc.GenerateReport(context.Background(), "user-1", pdf.LanguageEnglish, model.ReportFormatPDF, nil, true, time.Now().AddDate(0, 0, -7), time.Now())
+	assert.ErrorIs(t, err, ErrReportEncryptionUnavailable)
+	assert.Empty(t, store.jobs, "a password is never queued in the clear")
+}
+
+func TestReportService_GetReportJob(t *testing.T) {
+	store := &fakeReportJobStore{}
+	svc := NewReportService(nil, nil, nil, nil, nil, zap.NewNop())
+	svc.SetJobStore(store, 10)
+	svc.SetPasswordEncryptor(newTestPasswordEncryptor(t))
+
+	queued, err := svc.GenerateReport(context.Background(), "user-1", pdf.LanguageEnglish, model.ReportFormatPDF, nil, true, time.Now().AddDate(0, 0, -7), time.Now())
+	require.NoError(t, err)
+
+	status, err := svc.GetReportJob(context.Background(), queued.JobID, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, queued.ReportID, status.ReportID)
+	assert.Equal(t, model.ReportStatusPending, status.Status)
+	assert.Empty(t, status.Password, "the password is returned only when the report is requested")
+
+	_, err = svc.GetReportJob(context.Background(), queued.JobID, "user-2")
+	assert.ErrorIs(t, err, ErrReportAccessDenied)
+
+	_, err = svc.GetReportJob(context.Background(), "missing", "user-1")
+	assert.ErrorIs(t, err, repository.ErrReportJobNotFound)
+}
+
+func TestReportWorker_ProcessesJobs(t *testing.T) {
+	store := &fakeReportJobStore{}
+	passwords := newTestPasswordEncryptor(t)
+	encrypted, err := passwords.Encrypt("secret")
+	require.NoError(t, err)
+	reportID := "report-1"
+	store.jobs = []*model.ReportJob{{ID: "job-1", UserID: "user-1", ReportID: &reportID, Status: model.ReportStatusPending, Password: encrypted}}
+	w, processed, failed := newTestReportWorker(store, passwords, nil)
+
+	assert.True(t, w.processNext(context.Background()))
+	assert.False(t, w.processNext(context.Background()), "an empty queue has nothing to claim")
+
+	require.Len(t, *processed, 1)
+	assert.Equal(t, reportID, (*processed)[0].reportID)
+	assert.Equal(t, "secret", (*processed)[0].password)
+	assert.Empty(t, *failed)
+	assert.Equal(t, model.ReportStatusCompleted, store.jobs[0].Status)
+	assert.Empty(t, store.jobs[0].Password, "the password is forgotten once the report is generated")
+}
+
+func TestReportWorker_FailsJobs(t *testing.T) {
+	store := &fakeReportJobStore{}
+	reportID, retriedID := "report-1", "report-2"
+	store.jobs = []*model.ReportJob{
+		{ID: "job-1", ReportID: &reportID, Status: model.ReportStatusPending},
+		{ID: "job-2", ReportID: &retriedID, Status: model.ReportStatusPending, Attempts: maxReportJobAttempts},
+		{ID: "job-3", Status: model.ReportStatusPending},
+	}
+	w, processed, failed := newTestReportWorker(store, nil, errors.New("blob storage unavailable"))
+
+	for w.processNext(context.Background()) {
+	}
+
+	assert.Len(t, *processed, 1, "only the first job is generated")
+	assert.Equal(t, []string{retriedID}, *failed, "a job interrupted too often fails its report")
+	for _, job := range store.jobs {
+		assert.Equal(t, model.ReportStatusFailed, job.Status, job.ID)
+	}
+	assert.Equal(t, "blob storage unavailable", store.jobs[0].ErrorText)
+	assert.Equal(t, "report was deleted before it was generated", store.jobs[2].ErrorText)
+}
+
+func TestReportWorker_FailsUndecryptablePassword(t *testing.T) {
+	store := &fakeReportJobStore{}
+	reportID := "report-1"
+	store.jobs = []*model.ReportJob{{ID: "job-1", ReportID: &reportID, Status: model.ReportStatusPending, Password: "queued-in-the-clear"}}
+	w, processed, failed := newTestReportWorker(store, newTestPasswordEncryptor(t), nil)
+
+	assert.True(t, w.processNext(context.Background()))
+
+	assert.Empty(t, *processed, "the report is not generated without its password")
+	assert.Equal(t, []string{reportID}, *failed)
+	assert.Equal(t, model.ReportStatusFailed, store.jobs[0].Status)
+}
+
+func TestReportWorker_StopsOnCancel(t *testing.T) {
+	w, _, _ := newTestReportWorker(&fakeReportJobStore{}, nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	for i := 0; i < 3; i++ {
+		w.workers.Add(1)
+		go w.run(ctx)
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		w.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.Fail(t, "workers did not stop after cancellation")
+	}
+}
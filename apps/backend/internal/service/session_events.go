@@ -0,0 +1,187 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrTooManyStreams is returned when a user already holds the maximum number of
+	// session event streams
+	ErrTooManyStreams = errors.New("too many open session event streams")
+
+	// ErrSessionEventsDisabled is returned when no event bus is configured
+	ErrSessionEventsDisabled = errors.New("session event streams are disabled")
+)
+
+// sessionEventBuffer is the number of events a subscriber may fall behind before it
+// is dropped, so a stalled client never blocks the check-in flow
+const sessionEventBuffer = 32
+
+// SessionEventType identifies what happened in a check-in session
+type SessionEventType string
+
+const (
+	SessionEventMessageSaved  SessionEventType = "message_saved"
+	SessionEventQuestionAsked SessionEventType = "question_asked"
+	SessionEventCompleted     SessionEventType = "session_completed"
+	SessionEventExpired       SessionEventType = "session_expired"
+)
+
+// SessionEvent is a change in a check-in session published to its live streams
+type SessionEvent struct {
+	Type       SessionEventType `json:"type"`
+	SessionID  string           `json:"session_id"`
+	MessageID  string           `json:"message_id,omitempty"`
+	QuestionID string           `json:"question_id,omitempty"`
+	Text       string           `json:"text,omitempty"`
+	IsFollowUp bool             `json:"is_follow_up,omitempty"`
+	CheckInID  string           `json:"check_in_id,omitempty"`
+	OccurredAt time.Time        `json:"occurred_at"`
+}
+
+// Terminal reports whether the event ends the session, after which no more events follow
+func (e SessionEvent) Terminal() bool {
+	return e.Type == SessionEventCompleted || e.Type == SessionEventExpired
+}
+
+// sessionSubscription is one open stream of a session's events
+type sessionSubscription struct {
+	sessionID    string
+	subscriberID string
+	events       chan SessionEvent
+}
+
+// SessionEventBus fans check-in session events out to the streams subscribed to each
+// session, limiting how many streams a user may hold open at once
+type SessionEventBus struct {
+	mu                sync.Mutex
+	maxStreamsPerUser int
+	sessions          map[string]map[*sessionSubscription]struct{}
+	streams           map[string]int
+	logger            *zap.Logger
+}
+
+// NewSessionEventBus creates a new SessionEventBus
+func NewSessionEventBus(maxStreamsPerUser int, logger *zap.Logger) *SessionEventBus {
+	return &SessionEventBus{
+		maxStreamsPerUser: maxStreamsPerUser,
+		sessions:          make(map[string]map[*sessionSubscription]struct{}),
+		streams:           make(map[string]int),
+		logger:            logger,
+	}
+}
+
+// Subscribe opens a stream of a session's events for the subscriber. The channel is
+// closed after a terminal event, when the subscriber falls too far behind, or when the
+// returned cancel function is called; cancel must always be called once the stream ends.
+// It returns ErrTooManyStreams when the subscriber is at the limit.
+func (b *SessionEventBus) Subscribe(sessionID, subscriberID string) (<-chan SessionEvent, func(), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.streams[subscriberID] >= b.maxStreamsPerUser {
+		return nil, nil, ErrTooManyStreams
+	}
+
+	sub := &sessionSubscription{
+		sessionID:    sessionID,
+		subscriberID: subscriberID,
+		events:       make(chan SessionEvent, sessionEventBuffer),
+	}
+	if b.sessions[sessionID] == nil {
+		b.sessions[sessionID] = make(map[*sessionSubscription]struct{})
+	}
+	b.sessions[sessionID][sub] = struct{}{}
+	b.streams[subscriberID]++
+
+	cancel := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(sub)
+	}
+	return sub.events, cancel, nil
+}
+
+// Publish delivers an event to every stream of its session without blocking. A terminal
+// event closes the session's streams.
+func (b *SessionEventBus) Publish(event SessionEvent) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.sessions[event.SessionID] {
+		select {
+		case sub.events <- event:
+			if event.Terminal() {
+				b.remove(sub)
+			}
+		default:
+			b.logger.Warn("dropping slow session event stream",
+				zap.String("session_id", sub.sessionID),
+				zap.String("subscriber_id", sub.subscriberID),
+			)
+			b.remove(sub)
+		}
+	}
+}
+
+// OpenStreams returns the number of streams the subscriber holds open
+func (b *SessionEventBus) OpenStreams(subscriberID string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.streams[subscriberID]
+}
+
+// remove closes a stream unless it was removed already. The caller holds the lock.
+func (b *SessionEventBus) remove(sub *sessionSubscription) {
+	subs := b.sessions[sub.sessionID]
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(b.sessions, sub.sessionID)
+	}
+	if b.streams[sub.subscriberID]--; b.streams[sub.subscriberID] <= 0 {
+		delete(b.streams, sub.subscriberID)
+	}
+	close(sub.events)
+}
+
+// SubscribeSessionEvents opens a stream of a session's events for the subscriber,
+// see SessionEventBus.Subscribe
+func (s *CheckInService) SubscribeSessionEvents(sessionID, subscriberID string) (<-chan SessionEvent, func(), error) {
+	if s.events == nil {
+		return nil, nil, ErrSessionEventsDisabled
+	}
+	return s.events.Subscribe(sessionID, subscriberID)
+}
+
+// publishEvent publishes a session event when an event bus is configured
+func (s *CheckInService) publishEvent(event SessionEvent) {
+	if s.events != nil {
+		s.events.Publish(event)
+	}
+}
+
+// publishQuestion publishes a saved assistant message as an asked question
+func (s *CheckInService) publishQuestion(msg *model.Message, questionID string) {
+	s.publishEvent(SessionEvent{
+		Type:       SessionEventQuestionAsked,
+		SessionID:  msg.SessionID,
+		MessageID:  msg.ID,
+		QuestionID: questionID,
+		Text:       msg.Content,
+		IsFollowUp: msg.IsFollowUp,
+		OccurredAt: msg.CreatedAt,
+	})
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSessionEventBus(t *testing.T) {
+	t.Run("fans events out per session and closes on completion", func(t *testing.T) {
+		bus := NewSessionEventBus(5, zap.NewNop())
+
+		owner, cancelOwner, err := bus.Subscribe("session-1", "user-1")
+		require.NoError(t, err)
+		defer cancelOwner()
+		caregiver, cancelCaregiver, err := bus.Subscribe("session-1", "caregiver-1")
+		require.NoError(t, err)
+		defer cancelCaregiver()
+		other, cancelOther, err := bus.Subscribe("session-2", "user-1")
+		require.NoError(t, err)
+		defer cancelOther()
+
+		bus.Publish(SessionEvent{Type: SessionEventQuestionAsked, SessionID: "session-1", QuestionID: "mood"})
+		bus.Publish(SessionEvent{Type: SessionEventCompleted, SessionID: "session-1", CheckInID: "check-in-1"})
+
+		for _, events := range []<-chan SessionEvent{owner, caregiver} {
+			event := <-events
+			assert.Equal(t, SessionEventQuestionAsked, event.Type)
+			assert.False(t, event.OccurredAt.IsZero())
+			event = <-events
+			assert.True(t, event.Terminal())
+			assert.Equal(t, "check-in-1", event.CheckInID)
+			_, open := <-events
+			assert.False(t, open)
+		}
+
+		assert.Empty(t, other)
+		assert.Equal(t, 1, bus.OpenStreams("user-1"))
+		assert.Equal(t, 0, bus.OpenStreams("caregiver-1"))
+	})
+
+	t.Run("limits streams per user", func(t *testing.T) {
+		bus := NewSessionEventBus(2, zap.NewNop())
+
+		_, cancel, err := bus.Subscribe("session-1", "user-1")
+		require.NoError(t, err)
+		_, _, err = bus.Subscribe("session-2", "user-1")
+		require.NoError(t, err)
+
+		_, _, err = bus.Subscribe("session-3", "user-1")
+		assert.ErrorIs(t, err, ErrTooManyStreams)
+		_, _, err = bus.Subscribe("session-3", "user-2")
+		assert.NoError(t, err)
+
+		cancel()
+		cancel()
+		assert.Equal(t, 1, bus.OpenStreams("user-1"))
+		_, _, err = bus.Subscribe("session-3", "user-1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("drops a subscriber that falls behind", func(t *testing.T) {
+		bus := NewSessionEventBus(5, zap.NewNop())
+
+		events, cancel, err := bus.Subscribe("session-1", "user-1")
+		require.NoError(t, err)
+		defer cancel()
+
+		for i := 0; i <= sessionEventBuffer; i++ {
+			bus.Publish(SessionEvent{Type: SessionEventMessageSaved, SessionID: "session-1"})
+		}
+
+		received := 0
+		for range events {
+			received++
+		}
+		assert.Equal(t, sessionEventBuffer, received)
+		assert.Equal(t, 0, bus.OpenStreams("user-1"))
+	})
+}
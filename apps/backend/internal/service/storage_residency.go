@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
+	"go.uber.org/zap"
+)
+
+// DefaultStorageBackend names the storage account configured in AZURE_STORAGE_*. It
+// holds the artifacts of users without a data residency.
+const DefaultStorageBackend = "default"
+
+var (
+	// ErrUnknownStorageBackend is returned when a data residency or a stored artifact
+	// names a storage backend that is not configured
+	ErrUnknownStorageBackend = errors.New("unknown storage backend")
+
+	// ErrConflictingResidency is returned when a user belongs to organizations requiring
+	// different data residencies
+	ErrConflictingResidency = errors.New("conflicting data residencies")
+)
+
+// ResidencyStore looks up the data residencies required by the organizations of a user
+type ResidencyStore interface {
+	GetUserDataResidencies(ctx context.Context, userID string) ([]string, error)
+}
+
+// StorageRouter picks the blob storage backend of one kind of artifact. New artifacts go
+// to the backend named by the data residency of the owner's organization, while reads
+// and deletions go to the backend recorded with the artifact. A residency naming a
+// backend that is not configured fails the write instead of falling back to the
+// default, so artifacts never silently leave their region.
+type StorageRouter struct {
+	backends  map[string]azure.BlobStorage
+	residency ResidencyStore
+	logger    *zap.Logger
+}
+
+// NewStorageRouter creates a StorageRouter writing every artifact to defaultStorage until
+// named backends and a residency store are added
+func NewStorageRouter(defaultStorage azure.BlobStorage, logger *zap.Logger) *StorageRouter {
+	return &StorageRouter{
+		backends: map[string]azure.BlobStorage{DefaultStorageBackend: defaultStorage},
+		logger:   logger,
+	}
+}
+
+// AddBackend registers the backend of a named data residency
+func (r *StorageRouter) AddBackend(name string, storage azure.BlobStorage) {
+	r.backends[name] = storage
+}
+
+// SetResidencyStore enables writing artifacts to the backend of their owner's residency
+func (r *StorageRouter) SetResidencyStore(store ResidencyStore) {
+	r.residency = store
+}
+
+// Names returns the names of the configured backends, sorted
+func (r *StorageRouter) Names() []string {
+	names := make([]string, 0, len(r.backends))
+	for name := range r.backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ForUser returns the backend new artifacts of userID are written to, with its name
+func (r *StorageRouter) ForUser(ctx context.Context, userID string) (string, azure.BlobStorage, error) {
+	ctx, span := telemetry.StartSpan(ctx, "StorageRouter.ForUser")
+	defer span.End()
+
+	name := DefaultStorageBackend
+	if r.residency != nil {
+		residencies, err := r.residency.GetUserDataResidencies(ctx, userID)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get data residency: %w", err)
+		}
+		switch len(residencies) {
+		case 0:
+		case 1:
+			name = residencies[0]
+		default:
+			return "", nil, fmt.Errorf("%w: user %s requires %s", ErrConflictingResidency, userID, strings.Join(residencies, ", "))
+		}
+	}
+
+	storage, err := r.Backend(name)
+	if err != nil {
+		r.logger.Error("data residency names an unconfigured storage backend",
+			zap.Error(err),
+			zap.String("user_id", userID),
+			zap.String("backend", name),
+		)
+		return "", nil, err
+	}
+	return name, storage, nil
+}
+
+// Backend returns the backend an artifact was recorded in; empty names the default
+func (r *StorageRouter) Backend(name string) (azure.BlobStorage, error) {
+	if name == "" {
+		name = DefaultStorageBackend
+	}
+	storage, ok := r.backends[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStorageBackend, name)
+	}
+	return storage, nil
+}
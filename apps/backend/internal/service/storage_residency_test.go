@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// fakeResidencyStore maps users to the data residencies of their organizations
+type fakeResidencyStore map[string][]string
+
+func (f fakeResidencyStore) GetUserDataResidencies(ctx context.Context, userID string) ([]string, error) {
+	return f[userID], nil
+}
+
+// newTestStorageRouter returns a router with an in-memory default and "eu" backend
+func newTestStorageRouter() (*StorageRouter, *azure.MockBlobStorageClient, *azure.MockBlobStorageClient) {
+	defaultStorage := azure.NewMockBlobStorageClient(nil)
+	euStorage := azure.NewMockBlobStorageClient(nil)
+
+	router := NewStorageRouter(defaultStorage, zap.NewNop())
+	router.AddBackend("eu", euStorage)
+	router.SetResidencyStore(fakeResidencyStore{
+		"user-eu":          {"eu"},
+		"user-misconfig":   {"us"},
+		"user-conflicting": {"eu", "us"},
+	})
+	return router, defaultStorage, euStorage
+}
+
+func TestStorageRouter_ForUser(t *testing.T) {
+	router, defaultStorage, euStorage := newTestStorageRouter()
+	ctx := context.Background()
+
+	name, storage, err := router.ForUser(ctx, "user-eu")
+	require.NoError(t, err)
+	assert.Equal(t, "eu", name)
+	assert.Same(t, euStorage, storage)
+
+	name, storage, err = router.ForUser(ctx, "user-plain")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultStorageBackend, name)
+	assert.Same(t, defaultStorage, storage)
+
+	_, _, err = router.ForUser(ctx, "user-misconfig")
+	assert.ErrorIs(t, err, ErrUnknownStorageBackend, "an unknown residency must not fall back to the default")
+
+	_, _, err = router.ForUser(ctx, "user-conflicting")
+	assert.ErrorIs(t, err, ErrConflictingResidency)
+
+	assert.Equal(t, []string{DefaultStorageBackend, "eu"}, router.Names())
+}
+
+func TestStorageRouter_Backend(t *testing.T) {
+	router, defaultStorage, euStorage := newTestStorageRouter()
+
+	storage, err := router.Backend("")
+	require.NoError(t, err)
+	assert.Same(t, defaultStorage, storage, "artifacts without a recorded backend are in the default")
+
+	storage, err = router.Backend("eu")
+	require.NoError(t, err)
+	assert.Same(t, euStorage, storage)
+
+	_, err = router.Backend("us")
+	assert.ErrorIs(t, err, ErrUnknownStorageBackend)
+}
+
+func TestReportService_WritesReportsToResidencyBackend(t *testing.T) {
+	router, defaultStorage, euStorage := newTestStorageRouter()
+	svc := NewReportService(nil, nil, nil, defaultStorage, nil, zap.NewNop())
+	svc.SetStorageRouter(router)
+	ctx := context.Background()
+
+	job := reportJob{
+		reportID:  "r-eu",
+		userID:    "user-eu",
+		format:    model.ReportFormatCSV,
+		sections:  []model.ReportSection{},
+		startDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		endDate:   time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+	report, _, err := svc.buildReport(ctx, job)
+	require.NoError(t, err)
+	assert.Equal(t, "eu", report.StorageBackend)
+	assert.Contains(t, euStorage.ListBlobs(), report.FilePath)
+	assert.Empty(t, defaultStorage.ListBlobs())
+
+	job.reportID, job.userID = "r-default", "user-plain"
+	report, _, err = svc.buildReport(ctx, job)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultStorageBackend, report.StorageBackend)
+	assert.Contains(t, defaultStorage.ListBlobs(), report.FilePath)
+	assert.Len(t, euStorage.ListBlobs(), 1)
+
+	job.reportID, job.userID = "r-misconfig", "user-misconfig"
+	_, _, err = svc.buildReport(ctx, job)
+	assert.ErrorIs(t, err, ErrUnknownStorageBackend)
+	assert.Len(t, defaultStorage.ListBlobs(), 1, "nothing is written when the residency is misconfigured")
+	assert.Len(t, euStorage.ListBlobs(), 1)
+}
+
+func TestReportService_SignsURLsInResidencyBackend(t *testing.T) {
+	router, defaultStorage, _ := newTestStorageRouter()
+	svc := NewReportService(nil, nil, nil, defaultStorage, nil, zap.NewNop())
+	svc.SetStorageRouter(router)
+
+	_, err := svc.urlSignerFor(&model.Report{StorageBackend: "eu"})
+	assert.ErrorIs(t, err, ErrReportURLUnavailable, "the in-memory backend cannot sign URLs")
+	_, err = svc.urlSignerFor(&model.Report{StorageBackend: "us"})
+	assert.ErrorIs(t, err, ErrUnknownStorageBackend)
+}
+
+func TestGDPRService_DeletesBlobsAcrossBackends(t *testing.T) {
+	reports, defaultReports, euReports := newTestStorageRouter()
+	ctx := context.Background()
+
+	_, err := defaultReports.UploadFile(ctx, "reports/a.pdf", []byte("a"), "application/pdf")
+	require.NoError(t, err)
+	_, err = euReports.UploadFile(ctx, "reports/b.pdf", []byte("b"), "application/pdf")
+	require.NoError(t, err)
+	_, err = euReports.UploadFile(ctx, "reports/other-user.pdf", []byte("c"), "application/pdf")
+	require.NoError(t, err)
+
+	svc := NewGDPRService(nil, nil, zap.NewNop())
+	err = svc.deleteBlobs(ctx, reports, []model.StoredBlob{
+		{Backend: DefaultStorageBackend, Path: "reports/a.pdf"},
+		{Backend: "eu", Path: "reports/b.pdf"},
+	}, azure.BlobStorage.DeletePDF)
+	require.NoError(t, err)
+
+	assert.Empty(t, defaultReports.ListBlobs())
+	assert.Equal(t, []string{"reports/other-user.pdf"}, euReports.ListBlobs())
+
+	err = svc.deleteBlobs(ctx, reports, []model.StoredBlob{{Backend: "us", Path: "reports/c.pdf"}}, azure.BlobStorage.DeletePDF)
+	assert.ErrorIs(t, err, ErrUnknownStorageBackend, "erasure fails rather than leaving files behind")
+}
@@ -0,0 +1,45 @@
+package service
+
+import "strings"
+
+// SymptomUpdateMode selects how incoming symptoms are combined with stored ones
+type SymptomUpdateMode string
+
+const (
+	// SymptomUpdateAppend adds new symptoms and keeps the existing ones
+	SymptomUpdateAppend SymptomUpdateMode = "append"
+	// SymptomUpdateReplace overwrites the stored symptoms
+	SymptomUpdateReplace SymptomUpdateMode = "replace"
+)
+
+// normalizeSymptomKey returns the comparison key for a symptom
+func normalizeSymptomKey(symptom string) string {
+	return strings.ToLower(strings.Join(strings.Fields(symptom), " "))
+}
+
+// normalizeSymptoms trims symptoms, drops empty entries and removes duplicates
+// case-insensitively, keeping the first occurrence in order
+func normalizeSymptoms(symptoms []string) []string {
+	seen := make(map[string]bool, len(symptoms))
+	result := make([]string, 0, len(symptoms))
+	for _, symptom := range symptoms {
+		key := normalizeSymptomKey(symptom)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, strings.Join(strings.Fields(symptom), " "))
+	}
+	return result
+}
+
+// mergeSymptoms combines existing and incoming symptoms according to mode
+func mergeSymptoms(existing, incoming []string, mode SymptomUpdateMode) []string {
+	if mode == SymptomUpdateAppend {
+		combined := make([]string, 0, len(existing)+len(incoming))
+		combined = append(combined, existing...)
+		combined = append(combined, incoming...)
+		return normalizeSymptoms(combined)
+	}
+	return normalizeSymptoms(incoming)
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeSymptoms(t *testing.T) {
+	existing := []string{"cramps", "fatigue"}
+
+	tests := []struct {
+		name     string
+		incoming []string
+		mode     SymptomUpdateMode
+		want     []string
+	}{
+		{
+			name:     "append preserves existing symptoms",
+			incoming: []string{"headache"},
+			mode:     SymptomUpdateAppend,
+			want:     []string{"cramps", "fatigue", "headache"},
+		},
+		{
+			name:     "append dedupes case-insensitively",
+			incoming: []string{"  Cramps ", "headache", "HEADACHE"},
+			mode:     SymptomUpdateAppend,
+			want:     []string{"cramps", "fatigue", "headache"},
+		},
+		{
+			name:     "replace overwrites existing symptoms",
+			incoming: []string{"headache", "bloating"},
+			mode:     SymptomUpdateReplace,
+			want:     []string{"headache", "bloating"},
+		},
+		{
+			name:     "replace with empty list clears symptoms",
+			incoming: []string{},
+			mode:     SymptomUpdateReplace,
+			want:     []string{},
+		},
+		{
+			name:     "empty entries are dropped",
+			incoming: []string{"", "   ", "back  pain"},
+			mode:     SymptomUpdateAppend,
+			want:     []string{"cramps", "fatigue", "back pain"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mergeSymptoms(existing, tt.incoming, tt.mode))
+		})
+	}
+}
+
+func TestUpdateMenstruation_ValidationErrors(t *testing.T) {
+	service := &HealthDataService{}
+	ctx := context.Background()
+
+	_, err := service.UpdateMenstruation(ctx, "", &MenstruationUpdate{})
+	assert.ErrorContains(t, err, "cycle ID is required")
+
+	_, err = service.UpdateMenstruation(ctx, "cycle-1", &MenstruationUpdate{SymptomsMode: "merge"})
+	assert.ErrorContains(t, err, "invalid symptoms mode")
+
+	extreme := "extreme"
+	_, err = service.UpdateMenstruation(ctx, "cycle-1", &MenstruationUpdate{FlowIntensity: &extreme})
+	assert.ErrorContains(t, err, "invalid flow intensity")
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// TimelineStore defines the data access of a user's timeline
+type TimelineStore interface {
+	GetUserTimeline(ctx context.Context, userID string, after *repository.TimelineCursor, limit int) ([]model.TimelineEvent, error)
+}
+
+// TimelinePage is one page of a user's timeline. Next is the cursor of the following
+// page, or nil on the last page.
+type TimelinePage struct {
+	Events []model.TimelineEvent
+	Next   *repository.TimelineCursor
+}
+
+// TimelineService lets administrators browse everything that happened for a user
+type TimelineService struct {
+	store       TimelineStore
+	auditLogger *audit.Logger
+	logger      *zap.Logger
+}
+
+// NewTimelineService creates a new TimelineService
+func NewTimelineService(store TimelineStore, logger *zap.Logger) *TimelineService {
+	return &TimelineService{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// SetAuditLogger enables audit logging of timeline views
+func (s *TimelineService) SetAuditLogger(auditLogger *audit.Logger) {
+	s.auditLogger = auditLogger
+}
+
+// GetUserTimeline returns a page of up to limit events of a user, newest first,
+// following after. Summaries longer than repository.TimelineSummaryLength are cut and
+// flagged. The view is audited under adminID.
+func (s *TimelineService) GetUserTimeline(ctx context.Context, adminID, userID string, after *repository.TimelineCursor, limit int) (*TimelinePage, error) {
+	// One extra event tells whether another page follows
+	events, err := s.store.GetUserTimeline(repository.WithReadReplica(ctx), userID, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &TimelinePage{Events: events}
+	if len(events) > limit {
+		page.Events = events[:limit]
+		last := page.Events[limit-1]
+		page.Next = &repository.TimelineCursor{OccurredAt: last.OccurredAt, Type: last.Type, ResourceID: last.ResourceID}
+	}
+	for i := range page.Events {
+		page.Events[i].Summary, page.Events[i].Truncated = truncateSummary(page.Events[i].Summary, repository.TimelineSummaryLength)
+	}
+
+	s.auditView(ctx, adminID, userID)
+	return page, nil
+}
+
+// truncateSummary cuts summary to at most length characters and reports whether it did
+func truncateSummary(summary string, length int) (string, bool) {
+	if utf8.RuneCountInString(summary) <= length {
+		return summary, false
+	}
+	return string([]rune(summary)[:length]), true
+}
+
+// auditView records an administrator's view of a user's timeline in the audit log
+func (s *TimelineService) auditView(ctx context.Context, adminID, userID string) {
+	if s.auditLogger == nil {
+		return
+	}
+
+	actorID := adminID
+	if actorID == "" {
+		actorID = userID
+	}
+
+	err := s.auditLogger.Log(ctx, audit.AuditLog{
+		UserID:        actorID,
+		OperationType: audit.OperationRead,
+		ResourceType:  audit.ResourceUser,
+		ResourceID:    userID,
+		AdditionalData: map[string]interface{}{
+			"action": "view_timeline",
+		},
+	})
+	if err != nil {
+		s.logger.Error("failed to audit timeline view", zap.Error(err), zap.String("user_id", userID))
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// fakeTimelineStore serves events sorted newest first, following the cursor like the
+// repository does
+type fakeTimelineStore struct {
+	events []model.TimelineEvent
+}
+
+func (f *fakeTimelineStore) GetUserTimeline(ctx context.Context, userID string, after *repository.TimelineCursor, limit int) ([]model.TimelineEvent, error) {
+	var result []model.TimelineEvent
+	for _, event := range f.events {
+		if after != nil && !event.OccurredAt.Before(after.OccurredAt) {
+			continue
+		}
+		if len(result) == limit {
+			break
+		}
+		result = append(result, event)
+	}
+	return result, nil
+}
+
+func TestTimelineService_PagesWithCursor(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	store := &fakeTimelineStore{}
+	for i := 0; i < 5; i++ {
+		store.events = append(store.events, model.TimelineEvent{
+			Type:       model.TimelineEventCheckIn,
+			ResourceID: string(rune('a' + i)),
+			OccurredAt: start.Add(-time.Duration(i) * time.Hour),
+		})
+	}
+	svc := NewTimelineService(store, zap.NewNop())
+
+	first, err := svc.GetUserTimeline(context.Background(), "admin", "user-1", nil, 2)
+	require.NoError(t, err)
+	require.Len(t, first.Events, 2)
+	require.NotNil(t, first.Next)
+	assert.Equal(t, "b", first.Next.ResourceID)
+
+	last, err := svc.GetUserTimeline(context.Background(), "admin", "user-1", &repository.TimelineCursor{OccurredAt: start.Add(-3 * time.Hour)}, 2)
+	require.NoError(t, err)
+	assert.Len(t, last.Events, 1)
+	assert.Nil(t, last.Next, "the last page has no cursor")
+}
+
+func TestTimelineService_TruncatesSummaries(t *testing.T) {
+	long := strings.Repeat("fejfájás ", 20)
+	store := &fakeTimelineStore{events: []model.TimelineEvent{
+		{Type: model.TimelineEventCheckIn, ResourceID: "c1", Summary: string([]rune(long)[:repository.TimelineSummaryLength+1])},
+		{Type: model.TimelineEventReport, ResourceID: "r1", Summary: "completed"},
+	}}
+	svc := NewTimelineService(store, zap.NewNop())
+
+	page, err := svc.GetUserTimeline(context.Background(), "admin", "user-1", nil, 10)
+	require.NoError(t, err)
+	require.Len(t, page.Events, 2)
+
+	assert.True(t, page.Events[0].Truncated)
+	assert.Len(t, []rune(page.Events[0].Summary), repository.TimelineSummaryLength)
+	assert.False(t, page.Events[1].Truncated)
+	assert.Equal(t, "completed", page.Events[1].Summary)
+}
+
+func TestTruncateSummary(t *testing.T) {
+	summary, truncated := truncateSummary("fáradtság", 9)
+	assert.Equal(t, "fáradtság", summary)
+	assert.False(t, truncated, "length counts characters, not bytes")
+
+	summary, truncated = truncateSummary("fáradtság", 4)
+	assert.Equal(t, "fára", summary)
+	assert.True(t, truncated)
+}
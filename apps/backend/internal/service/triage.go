@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/openai/openai-go/v3"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+const (
+	alertSourceRule = "rule"
+	alertSourceAI   = "ai"
+
+	// emergencyPainLevel is the pain level from which a check-in is flagged
+	emergencyPainLevel = 9
+)
+
+// TriageFinding is a single reason for raising an alert
+type TriageFinding struct {
+	Severity model.AlertSeverity
+	Reason   string
+	Source   string
+}
+
+// emergencySymptomRule maps symptom keywords (Hungarian and English) to an alert reason
+type emergencySymptomRule struct {
+	reason   string
+	keywords []string
+}
+
+// emergencySymptomRules lists symptoms that must never wait for the weekly report
+var emergencySymptomRules = []emergencySymptomRule{
+	{
+		reason:   "chest pain",
+		keywords: []string{"mellkasi fájdalom", "mellkasfájdalom", "mellkasi szorítás", "mellkasom", "chest pain", "chest tightness"},
+	},
+	{
+		reason:   "possible stroke symptoms",
+		keywords: []string{"stroke", "szélütés", "agyvérzés", "arcfél", "lebénult", "zsibbad az arc", "beszédzavar", "elmosódott beszéd", "slurred speech", "facial droop", "one-sided weakness"},
+	},
+	{
+		reason:   "severe breathing difficulty",
+		keywords: []string{"nem kapok levegőt", "fulladás", "fullad", "can't breathe", "cannot breathe"},
+	},
+	{
+		reason:   "loss of consciousness",
+		keywords: []string{"elájultam", "ájulás", "eszméletvesztés", "fainted", "passed out"},
+	},
+}
+
+// AlertService triages check-ins for emergency symptoms and manages alerts
+type AlertService struct {
+	repo     *repository.AlertRepository
+	aiClient *azure.OpenAIClient
+	logger   *zap.Logger
+}
+
+// NewAlertService creates a new AlertService. aiClient may be nil to use the rule set only.
+func NewAlertService(repo *repository.AlertRepository, aiClient *azure.OpenAIClient, logger *zap.Logger) *AlertService {
+	return &AlertService{
+		repo:     repo,
+		aiClient: aiClient,
+		logger:   logger,
+	}
+}
+
+// EvaluateCheckIn runs a completed check-in through the triage rules and the AI
+// classification and stores an alert for every finding
+func (s *AlertService) EvaluateCheckIn(ctx context.Context, checkIn *model.HealthCheckIn, conversationHistory []ConversationMessage) ([]model.Alert, error) {
+	findings := evaluateTriageRules(checkIn)
+
+	// The AI classification catches emergencies the keyword rules miss
+	if len(findings) == 0 && s.aiClient != nil {
+		finding, err := s.classify(ctx, conversationHistory)
+		if err != nil {
+			s.logger.Warn("AI triage classification failed, using rule set only",
+				zap.Error(err),
+				zap.String("check_in_id", checkIn.ID),
+			)
+		} else if finding != nil {
+			findings = append(findings, *finding)
+		}
+	}
+
+	var alerts []model.Alert
+	for _, finding := range findings {
+		alert := model.Alert{
+			ID:        uuid.New().String(),
+			UserID:    checkIn.UserID,
+			CheckInID: &checkIn.ID,
+			SessionID: checkIn.SessionID,
+			Severity:  finding.Severity,
+			Reason:    finding.Reason,
+			Source:    finding.Source,
+		}
+
+		if err := s.repo.Create(ctx, &alert); err != nil {
+			return alerts, fmt.Errorf("failed to save alert: %w", err)
+		}
+
+		s.logger.Warn("emergency symptom alert raised",
+			zap.String("alert_id", alert.ID),
+			zap.String("user_id", alert.UserID),
+			zap.String("check_in_id", checkIn.ID),
+			zap.String("severity", string(alert.Severity)),
+			zap.String("reason", alert.Reason),
+		)
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, nil
+}
+
+// ListAlerts retrieves alerts for a user
+func (s *AlertService) ListAlerts(ctx context.Context, userID string, includeAcknowledged bool) ([]model.Alert, error) {
+	alerts, err := s.repo.FindByUserID(ctx, userID, includeAcknowledged)
+	if err != nil {
+		s.logger.Error("failed to list alerts",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		return nil, fmt.Errorf("failed to list alerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// AcknowledgeAlert marks an alert as reviewed
+func (s *AlertService) AcknowledgeAlert(ctx context.Context, alertID string) error {
+	if err := s.repo.Acknowledge(ctx, alertID); err != nil {
+		return fmt.Errorf("failed to acknowledge alert: %w", err)
+	}
+
+	s.logger.Info("alert acknowledged", zap.String("alert_id", alertID))
+	return nil
+}
+
+// evaluateTriageRules applies the deterministic emergency rule set to a check-in
+func evaluateTriageRules(checkIn *model.HealthCheckIn) []TriageFinding {
+	var findings []TriageFinding
+
+	if checkIn.PainLevel != nil && *checkIn.PainLevel >= emergencyPainLevel {
+		findings = append(findings, TriageFinding{
+			Severity: model.AlertSeverityHigh,
+			Reason:   fmt.Sprintf("pain level %d", *checkIn.PainLevel),
+			Source:   alertSourceRule,
+		})
+	}
+
+	var text strings.Builder
+	for _, symptom := range checkIn.Symptoms {
+		text.WriteString(symptom)
+		text.WriteString("\n")
+	}
+	if checkIn.GeneralFeeling != nil {
+		text.WriteString(*checkIn.GeneralFeeling)
+		text.WriteString("\n")
+	}
+	if checkIn.AdditionalNotes != nil {
+		text.WriteString(*checkIn.AdditionalNotes)
+	}
+	lowered := strings.ToLower(text.String())
+
+	for _, rule := range emergencySymptomRules {
+		for _, keyword := range rule.keywords {
+			if strings.Contains(lowered, keyword) {
+				findings = append(findings, TriageFinding{
+					Severity: model.AlertSeverityCritical,
+					Reason:   rule.reason,
+					Source:   alertSourceRule,
+				})
+				break
+			}
+		}
+	}
+
+	return findings
+}
+
+// triageClassification is the AI response for emergency classification
+type triageClassification struct {
+	Emergency bool   `json:"emergency"`
+	Severity  string `json:"severity"`
+	Reason    string `json:"reason"`
+}
+
+// classify asks Azure OpenAI whether the conversation describes an emergency
+func (s *AlertService) classify(ctx context.Context, conversationHistory []ConversationMessage) (*TriageFinding, error) {
+	var conversationText strings.Builder
+	for _, msg := range conversationHistory {
+		conversationText.WriteString(fmt.Sprintf("%s: %s\n", msg.Role, msg.Content))
+	}
+
+	messages := []openai.ChatCompletionMessageParamUnion{
+		openai.SystemMessage(fmt.Sprintf(`You are a medical triage assistant reviewing a Hungarian daily health check-in.
+
+Conversation:
+%s
+
+Decide whether the user describes symptoms that need urgent medical attention (chest pain, stroke symptoms, severe breathing difficulty, loss of consciousness, severe bleeding, pain level 9-10 or similar).
+
+Return valid JSON:
+{
+  "emergency": true or false,
+  "severity": "critical" | "high" | "none",
+  "reason": "short English explanation"
+}
+
+Return ONLY valid JSON, no additional text`, conversationText.String())),
+		openai.UserMessage("Classify this check-in."),
+	}
+
+	response, err := s.aiClient.Complete(ctx, messages)
+	if err != nil {
+		return nil, fmt.Errorf("triage classification failed: %w", err)
+	}
+
+	return parseTriageClassification(response)
+}
+
+// parseTriageClassification parses the AI response into a finding, or nil if no emergency
+func parseTriageClassification(response string) (*TriageFinding, error) {
+	response = strings.TrimSpace(response)
+	response = strings.TrimPrefix(response, "```json")
+	response = strings.TrimPrefix(response, "```")
+	response = strings.TrimSuffix(response, "```")
+	response = strings.TrimSpace(response)
+
+	var classification triageClassification
+	if err := json.Unmarshal([]byte(response), &classification); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	if !classification.Emergency {
+		return nil, nil
+	}
+
+	severity := model.AlertSeverityHigh
+	if classification.Severity == string(model.AlertSeverityCritical) {
+		severity = model.AlertSeverityCritical
+	}
+
+	reason := strings.TrimSpace(classification.Reason)
+	if reason == "" {
+		reason = "emergency symptoms reported"
+	}
+
+	return &TriageFinding{
+		Severity: severity,
+		Reason:   reason,
+		Source:   alertSourceAI,
+	}, nil
+}
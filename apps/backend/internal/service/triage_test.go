@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+func TestEvaluateTriageRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		checkIn     model.HealthCheckIn
+		wantReasons []string
+	}{
+		{
+			name:    "unremarkable check-in",
+			checkIn: model.HealthCheckIn{Symptoms: []string{"fáradtság"}, PainLevel: intPtr(3)},
+		},
+		{
+			name:        "severe pain",
+			checkIn:     model.HealthCheckIn{PainLevel: intPtr(9)},
+			wantReasons: []string{"pain level 9"},
+		},
+		{
+			name:        "chest pain in symptoms",
+			checkIn:     model.HealthCheckIn{Symptoms: []string{"Mellkasi fájdalom"}},
+			wantReasons: []string{"chest pain"},
+		},
+		{
+			name: "stroke symptoms in notes with maximum pain",
+			checkIn: model.HealthCheckIn{
+				PainLevel:       intPtr(10),
+				AdditionalNotes: ptrString("Reggel óta elmosódott beszéd és zsibbad az arcom"),
+			},
+			wantReasons: []string{"pain level 10", "possible stroke symptoms"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := evaluateTriageRules(&tt.checkIn)
+
+			var reasons []string
+			for _, finding := range findings {
+				assert.Equal(t, alertSourceRule, finding.Source)
+				reasons = append(reasons, finding.Reason)
+			}
+			assert.Equal(t, tt.wantReasons, reasons)
+		})
+	}
+}
+
+func TestParseTriageClassification(t *testing.T) {
+	finding, err := parseTriageClassification("```json\n{\"emergency\": true, \"severity\": \"critical\", \"reason\": \"sudden weakness\"}\n```")
+	require.NoError(t, err)
+	require.NotNil(t, finding)
+	assert.Equal(t, model.AlertSeverityCritical, finding.Severity)
+	assert.Equal(t, "sudden weakness", finding.Reason)
+	assert.Equal(t, alertSourceAI, finding.Source)
+
+	finding, err = parseTriageClassification(`{"emergency": false, "severity": "none", "reason": ""}`)
+	require.NoError(t, err)
+	assert.Nil(t, finding)
+
+	_, err = parseTriageClassification("not json")
+	assert.Error(t, err)
+}
+
+// MockAlertSummaryProvider is a mock implementation of AlertSummaryProvider
+type MockAlertSummaryProvider struct {
+	mock.Mock
+}
+
+func (m *MockAlertSummaryProvider) GetAlertSummary(ctx context.Context, userID string, limit int) (*repository.AlertSummary, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.AlertSummary), args.Error(1)
+}
+
+func TestDashboardService_GetSummary_IncludesAlerts(t *testing.T) {
+	mockRepo := new(MockDashboardRepository)
+	mockAlerts := new(MockAlertSummaryProvider)
+	service := NewDashboardService(mockRepo, zap.NewNop())
+	service.SetAlertSource(mockAlerts)
+
+	ctx := context.Background()
+	userID := "test-user-id"
+
+	mockRepo.On("GetAggregatedMetrics", mock.Anything, userID, 7).Return(&repository.AggregatedMetrics{CheckInCount: 0}, nil)
+	mockRepo.On("GetDailyMetrics", mock.Anything, userID, 7).Return([]repository.DailyMetrics{}, nil)
+	mockRepo.On("GetAggregatedMetricsForPeriod", mock.Anything, userID, mock.Anything, mock.Anything).Return(&repository.AggregatedMetrics{}, nil)
+	mockAlerts.On("GetAlertSummary", mock.Anything, userID, dashboardAlertLimit).Return(&repository.AlertSummary{
+		Unacknowledged: 1,
+		Critical:       1,
+		Latest:         []model.Alert{{ID: "alert-1", Severity: model.AlertSeverityCritical, Reason: "chest pain"}},
+	}, nil)
+
+	summary, err := service.GetSummary(ctx, userID, 7)
+	require.NoError(t, err)
+	require.NotNil(t, summary.Alerts)
+	assert.Equal(t, 1, summary.Alerts.Critical)
+	assert.Len(t, summary.Alerts.Latest, 1)
+
+	mockAlerts.AssertExpectations(t)
+}
+
+func TestDashboardService_GetSummary_AlertFailureDoesNotFailSummary(t *testing.T) {
+	mockRepo := new(MockDashboardRepository)
+	mockAlerts := new(MockAlertSummaryProvider)
+	service := NewDashboardService(mockRepo, zap.NewNop())
+	service.SetAlertSource(mockAlerts)
+
+	ctx := context.Background()
+	userID := "test-user-id"
+
+	mockRepo.On("GetAggregatedMetrics", mock.Anything, userID, 7).Return(&repository.AggregatedMetrics{CheckInCount: 0}, nil)
+	mockRepo.On("GetDailyMetrics", mock.Anything, userID, 7).Return([]repository.DailyMetrics{}, nil)
+	mockRepo.On("GetAggregatedMetricsForPeriod", mock.Anything, userID, mock.Anything, mock.Anything).Return(&repository.AggregatedMetrics{}, nil)
+	mockAlerts.On("GetAlertSummary", mock.Anything, userID, dashboardAlertLimit).Return(nil, errors.New("db down"))
+
+	summary, err := service.GetSummary(ctx, userID, 7)
+	require.NoError(t, err)
+	assert.Nil(t, summary.Alerts)
+}
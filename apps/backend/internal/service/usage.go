@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+const (
+	// usageReconcilePageSize is the number of blobs fetched per listing request
+	usageReconcilePageSize = 500
+
+	// usageAggregateTopUsers is the number of heaviest users included in the aggregate
+	usageAggregateTopUsers = 20
+)
+
+// Usage kinds used for blob sources and exceeded soft limits
+const (
+	UsageKindCheckIns    = "check_ins"
+	UsageKindAudio       = "audio_bytes"
+	UsageKindAttachments = "attachment_bytes"
+	UsageKindReports     = "report_bytes"
+)
+
+// UsageStore defines the persistence operations needed for usage accounting
+type UsageStore interface {
+	Increment(ctx context.Context, userID string, delta repository.UsageDelta) error
+	GetByUserID(ctx context.Context, userID string) (*model.UserUsage, error)
+	GetAggregate(ctx context.Context, topN int) (*repository.UsageAggregate, error)
+	CountCheckInsByUser(ctx context.Context) (map[string]int64, error)
+	ResolveBlobOwners(ctx context.Context, paths []string) (map[string]string, error)
+	ReplaceUsage(ctx context.Context, usages map[string]*model.UserUsage, reconciledAt time.Time) error
+}
+
+// UsageRecorder records incremental usage on writes
+type UsageRecorder interface {
+	Record(ctx context.Context, userID string, delta repository.UsageDelta)
+}
+
+// BlobLister lists a blob container one page at a time
+type BlobLister interface {
+	ListBlobsPage(ctx context.Context, prefix, marker string, maxResults int32) (*azure.BlobPage, error)
+}
+
+// UsageBlobSource is a blob container prefix whose blobs count towards one usage kind
+type UsageBlobSource struct {
+	Kind   string
+	Prefix string
+	Lister BlobLister
+}
+
+// UsageLimits holds the soft per-user limits. Zero disables a limit.
+type UsageLimits struct {
+	MaxCheckIns        int64 `json:"max_check_ins,omitempty"`
+	MaxAudioBytes      int64 `json:"max_audio_bytes,omitempty"`
+	MaxAttachmentBytes int64 `json:"max_attachment_bytes,omitempty"`
+	MaxReportBytes     int64 `json:"max_report_bytes,omitempty"`
+}
+
+// UsageReport is a user's usage together with the soft limits it is measured against
+type UsageReport struct {
+	Usage    model.UserUsage `json:"usage"`
+	Limits   UsageLimits     `json:"limits"`
+	Exceeded []string        `json:"exceeded"`
+}
+
+// ReconcileResult summarizes a reconciliation run
+type ReconcileResult struct {
+	Users             int           `json:"users"`
+	BlobsScanned      int           `json:"blobs_scanned"`
+	UnattributedBlobs int           `json:"unattributed_blobs"`
+	Duration          time.Duration `json:"duration"`
+}
+
+// UsageService tracks per-user stored data and checks it against soft limits
+type UsageService struct {
+	store   UsageStore
+	logger  *zap.Logger
+	limits  UsageLimits
+	sources []UsageBlobSource
+}
+
+// NewUsageService creates a new UsageService
+func NewUsageService(store UsageStore, logger *zap.Logger) *UsageService {
+	return &UsageService{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// SetLimits configures the soft per-user limits
+func (s *UsageService) SetLimits(limits UsageLimits) {
+	s.limits = limits
+}
+
+// AddBlobSource registers a blob container prefix scanned during reconciliation
+func (s *UsageService) AddBlobSource(source UsageBlobSource) {
+	s.sources = append(s.sources, source)
+}
+
+// Record adds an incremental change to a user's usage. Accounting failures are
+// logged and never fail the write that triggered them; reconciliation corrects the drift.
+func (s *UsageService) Record(ctx context.Context, userID string, delta repository.UsageDelta) {
+	if err := s.store.Increment(ctx, userID, delta); err != nil {
+		s.logger.Warn("failed to record usage",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+	}
+}
+
+// GetUsage retrieves a user's usage and the soft limits it exceeds
+func (s *UsageService) GetUsage(ctx context.Context, userID string) (*UsageReport, error) {
+	usage, err := s.store.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage: %w", err)
+	}
+	if usage == nil {
+		usage = &model.UserUsage{UserID: userID}
+	}
+
+	return &UsageReport{
+		Usage:    *usage,
+		Limits:   s.limits,
+		Exceeded: s.limits.exceeded(usage),
+	}, nil
+}
+
+// ExceededLimits returns the usage kinds for which the user is over the soft limit
+func (s *UsageService) ExceededLimits(ctx context.Context, userID string) ([]string, error) {
+	if s.limits == (UsageLimits{}) {
+		return nil, nil
+	}
+
+	usage, err := s.store.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage: %w", err)
+	}
+	if usage == nil {
+		return nil, nil
+	}
+
+	return s.limits.exceeded(usage), nil
+}
+
+// GetAggregate retrieves usage totals across all users, from the read replica when
+// one is healthy
+func (s *UsageService) GetAggregate(ctx context.Context) (*repository.UsageAggregate, error) {
+	aggregate, err := s.store.GetAggregate(repository.WithReadReplica(ctx), usageAggregateTopUsers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage aggregate: %w", err)
+	}
+	return aggregate, nil
+}
+
+// Reconcile recomputes usage from the check-in table and blob listings to correct
+// drift in the incremental counters. Blob listings are processed page by page.
+func (s *UsageService) Reconcile(ctx context.Context) (*ReconcileResult, error) {
+	started := time.Now()
+	result := &ReconcileResult{}
+
+	counts, err := s.store.CountCheckInsByUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count check-ins: %w", err)
+	}
+
+	usages := make(map[string]*model.UserUsage)
+	usageFor := func(userID string) *model.UserUsage {
+		usage, ok := usages[userID]
+		if !ok {
+			usage = &model.UserUsage{UserID: userID}
+			usages[userID] = usage
+		}
+		return usage
+	}
+
+	for userID, count := range counts {
+		usageFor(userID).CheckIns = count
+	}
+
+	for _, source := range s.sources {
+		marker := ""
+		for {
+			page, err := source.Lister.ListBlobsPage(ctx, source.Prefix, marker, usageReconcilePageSize)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list %s blobs: %w", source.Kind, err)
+			}
+
+			paths := make([]string, 0, len(page.Blobs))
+			for _, blob := range page.Blobs {
+				paths = append(paths, blob.Name)
+			}
+
+			owners, err := s.store.ResolveBlobOwners(ctx, paths)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve blob owners: %w", err)
+			}
+
+			for _, blob := range page.Blobs {
+				result.BlobsScanned++
+				userID, ok := owners[blob.Name]
+				if !ok {
+					result.UnattributedBlobs++
+					continue
+				}
+				addUsageBytes(usageFor(userID), source.Kind, blob.SizeBytes)
+			}
+
+			if page.NextMarker == "" {
+				break
+			}
+			marker = page.NextMarker
+		}
+	}
+
+	if err := s.store.ReplaceUsage(ctx, usages, started); err != nil {
+		return nil, fmt.Errorf("failed to store reconciled usage: %w", err)
+	}
+
+	result.Users = len(usages)
+	result.Duration = time.Since(started)
+
+	s.logger.Info("usage reconciliation completed",
+		zap.Int("users", result.Users),
+		zap.Int("blobs_scanned", result.BlobsScanned),
+		zap.Int("unattributed_blobs", result.UnattributedBlobs),
+		zap.Duration("duration", result.Duration),
+	)
+
+	return result, nil
+}
+
+// RunNightlyReconciliation reconciles usage every day at the given UTC hour until ctx is cancelled
+func (s *UsageService) RunNightlyReconciliation(ctx context.Context, hour int) {
+	for {
+		timer := time.NewTimer(time.Until(nextDailyRun(time.Now().UTC(), hour)))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if _, err := s.Reconcile(ctx); err != nil {
+			s.logger.Error("usage reconciliation failed", zap.Error(err))
+		}
+	}
+}
+
+// nextDailyRun returns the next time at hour:00 UTC strictly after now
+func nextDailyRun(now time.Time, hour int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, time.UTC)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// exceeded lists the usage kinds over their limit
+func (l UsageLimits) exceeded(usage *model.UserUsage) []string {
+	exceeded := []string{}
+	if l.MaxCheckIns > 0 && usage.CheckIns > l.MaxCheckIns {
+		exceeded = append(exceeded, UsageKindCheckIns)
+	}
+	if l.MaxAudioBytes > 0 && usage.AudioBytes > l.MaxAudioBytes {
+		exceeded = append(exceeded, UsageKindAudio)
+	}
+	if l.MaxAttachmentBytes > 0 && usage.AttachmentBytes > l.MaxAttachmentBytes {
+		exceeded = append(exceeded, UsageKindAttachments)
+	}
+	if l.MaxReportBytes > 0 && usage.ReportBytes > l.MaxReportBytes {
+		exceeded = append(exceeded, UsageKindReports)
+	}
+	return exceeded
+}
+
+// addUsageBytes attributes blob bytes to the usage kind of its source
+func addUsageBytes(usage *model.UserUsage, kind string, size int64) {
+	switch kind {
+	case UsageKindAudio:
+		usage.AudioBytes += size
+	case UsageKindAttachments:
+		usage.AttachmentBytes += size
+	case UsageKindReports:
+		usage.ReportBytes += size
+	}
+}
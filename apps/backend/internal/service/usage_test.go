@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// fakeUsageStore is an in-memory UsageStore
+type fakeUsageStore struct {
+	usage       map[string]*model.UserUsage
+	checkIns    map[string]int64
+	owners      map[string]string
+	resolveSize []int
+}
+
+func newFakeUsageStore() *fakeUsageStore {
+	return &fakeUsageStore{
+		usage:    make(map[string]*model.UserUsage),
+		checkIns: make(map[string]int64),
+		owners:   make(map[string]string),
+	}
+}
+
+func (f *fakeUsageStore) Increment(ctx context.Context, userID string, delta repository.UsageDelta) error {
+	u, ok := f.usage[userID]
+	if !ok {
+		u = &model.UserUsage{UserID: userID}
+		f.usage[userID] = u
+	}
+	u.CheckIns += delta.CheckIns
+	u.AudioBytes += delta.AudioBytes
+	u.AttachmentBytes += delta.AttachmentBytes
+	u.ReportBytes += delta.ReportBytes
+	return nil
+}
+
+func (f *fakeUsageStore) GetByUserID(ctx context.Context, userID string) (*model.UserUsage, error) {
+	u, ok := f.usage[userID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *u
+	return &copied, nil
+}
+
+func (f *fakeUsageStore) GetAggregate(ctx context.Context, topN int) (*repository.UsageAggregate, error) {
+	return &repository.UsageAggregate{Users: len(f.usage)}, nil
+}
+
+func (f *fakeUsageStore) CountCheckInsByUser(ctx context.Context) (map[string]int64, error) {
+	return f.checkIns, nil
+}
+
+func (f *fakeUsageStore) ResolveBlobOwners(ctx context.Context, paths []string) (map[string]string, error) {
+	f.resolveSize = append(f.resolveSize, len(paths))
+	owners := make(map[string]string)
+	for _, p := range paths {
+		if userID, ok := f.owners[p]; ok {
+			owners[p] = userID
+		}
+	}
+	return owners, nil
+}
+
+func (f *fakeUsageStore) ReplaceUsage(ctx context.Context, usages map[string]*model.UserUsage, reconciledAt time.Time) error {
+	f.usage = make(map[string]*model.UserUsage)
+	for userID, u := range usages {
+		copied := *u
+		copied.ReconciledAt = &reconciledAt
+		f.usage[userID] = &copied
+	}
+	return nil
+}
+
+func TestUsageService_RecordAndSoftLimits(t *testing.T) {
+	store := newFakeUsageStore()
+	svc := NewUsageService(store, zap.NewNop())
+	svc.SetLimits(UsageLimits{MaxCheckIns: 2, MaxReportBytes: 1000})
+	ctx := context.Background()
+
+	svc.Record(ctx, "user-1", repository.UsageDelta{CheckIns: 1})
+	svc.Record(ctx, "user-1", repository.UsageDelta{CheckIns: 1, ReportBytes: 400})
+
+	exceeded, err := svc.ExceededLimits(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Empty(t, exceeded)
+
+	svc.Record(ctx, "user-1", repository.UsageDelta{CheckIns: 1, ReportBytes: 700})
+
+	report, err := svc.GetUsage(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), report.Usage.CheckIns)
+	assert.Equal(t, int64(1100), report.Usage.ReportBytes)
+	assert.Equal(t, []string{UsageKindCheckIns, UsageKindReports}, report.Exceeded)
+}
+
+func TestUsageService_GetUsageForUnknownUser(t *testing.T) {
+	svc := NewUsageService(newFakeUsageStore(), zap.NewNop())
+
+	report, err := svc.GetUsage(context.Background(), "nobody")
+	require.NoError(t, err)
+	assert.Equal(t, "nobody", report.Usage.UserID)
+	assert.Zero(t, report.Usage.TotalBytes())
+	assert.Empty(t, report.Exceeded)
+}
+
+func TestUsageService_ReconcilePagesThroughBlobs(t *testing.T) {
+	store := newFakeUsageStore()
+	store.checkIns["user-1"] = 4
+
+	reports := azure.NewMockBlobStorageClient(nil)
+	blobCount := usageReconcilePageSize*2 + 17
+	for i := 0; i < blobCount; i++ {
+		name := fmt.Sprintf("reports/r%04d.pdf", i)
+		reports.Storage[name] = make([]byte, 10)
+		if i%2 == 0 {
+			store.owners[name] = "user-1"
+		} else {
+			store.owners[name] = "user-2"
+		}
+	}
+	// Shared blobs without an owner are not attributed to anyone
+	reports.Storage["reports/orphan.pdf"] = make([]byte, 99)
+
+	// Drifted incremental counter is corrected
+	store.usage["user-1"] = &model.UserUsage{UserID: "user-1", CheckIns: 100, ReportBytes: 1}
+	store.usage["gone"] = &model.UserUsage{UserID: "gone", CheckIns: 5}
+
+	svc := NewUsageService(store, zap.NewNop())
+	svc.AddBlobSource(UsageBlobSource{Kind: UsageKindReports, Prefix: "reports/", Lister: reports})
+
+	result, err := svc.Reconcile(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, blobCount+1, result.BlobsScanned)
+	assert.Equal(t, 1, result.UnattributedBlobs)
+	assert.Equal(t, 2, result.Users)
+
+	// Owners are resolved one page at a time
+	assert.Len(t, store.resolveSize, 3)
+	for _, size := range store.resolveSize {
+		assert.LessOrEqual(t, size, usageReconcilePageSize)
+	}
+
+	user1 := store.usage["user-1"]
+	require.NotNil(t, user1)
+	assert.Equal(t, int64(4), user1.CheckIns)
+	assert.Equal(t, int64((blobCount+1)/2*10), user1.ReportBytes)
+	assert.NotNil(t, user1.ReconciledAt)
+
+	user2 := store.usage["user-2"]
+	require.NotNil(t, user2)
+	assert.Equal(t, int64(blobCount/2*10), user2.ReportBytes)
+
+	assert.NotContains(t, store.usage, "gone")
+}
+
+func TestNextDailyRun(t *testing.T) {
+	now := time.Date(2026, 3, 10, 2, 30, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 3, 10, 3, 0, 0, 0, time.UTC), nextDailyRun(now, 3))
+
+	now = time.Date(2026, 3, 10, 3, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 3, 11, 3, 0, 0, 0, time.UTC), nextDailyRun(now, 3))
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// DefaultTimezone is the time zone of users who never chose one
+const DefaultTimezone = "UTC"
+
+// ErrInvalidTimezone is returned for a time zone that is not an IANA time zone name
+var ErrInvalidTimezone = errors.New("invalid time zone")
+
+// UserSettingsStore defines the persistence operations for user settings
+type UserSettingsStore interface {
+	GetSettings(ctx context.Context, userID string) (*model.UserSettings, error)
+	UpsertSettings(ctx context.Context, settings *model.UserSettings) error
+}
+
+// UserSettingsService manages user preferences
+type UserSettingsService struct {
+	store  UserSettingsStore
+	logger *zap.Logger
+}
+
+// NewUserSettingsService creates a new UserSettingsService
+func NewUserSettingsService(store UserSettingsStore, logger *zap.Logger) *UserSettingsService {
+	return &UserSettingsService{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// GetSettings returns a user's settings, with defaults for a user who never saved any
+func (s *UserSettingsService) GetSettings(ctx context.Context, userID string) (*model.UserSettings, error) {
+	settings, err := s.store.GetSettings(ctx, userID)
+	if errors.Is(err, repository.ErrUserSettingsNotFound) {
+		return &model.UserSettings{UserID: userID, Timezone: DefaultTimezone}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// UpdateSettings saves a user's settings, failing with ErrInvalidTimezone unless the
+// time zone is an IANA name such as Europe/Budapest
+func (s *UserSettingsService) UpdateSettings(ctx context.Context, settings *model.UserSettings) error {
+	settings.Timezone = strings.TrimSpace(settings.Timezone)
+	if _, err := loadTimezone(settings.Timezone); err != nil {
+		return err
+	}
+
+	if err := s.store.UpsertSettings(ctx, settings); err != nil {
+		return err
+	}
+
+	s.logger.Info("user settings updated",
+		zap.String("user_id", settings.UserID),
+		zap.String("timezone", settings.Timezone),
+	)
+	return nil
+}
+
+// UserLocation returns the time zone a user's schedules are read in
+func (s *UserSettingsService) UserLocation(ctx context.Context, userID string) (*time.Location, error) {
+	settings, err := s.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return loadTimezone(settings.Timezone)
+}
+
+// loadTimezone loads an IANA time zone. "Local" is rejected as it depends on the server.
+func loadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTimezone, name)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTimezone, name)
+	}
+	return loc, nil
+}
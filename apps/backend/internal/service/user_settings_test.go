@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+type fakeUserSettingsStore struct {
+	settings map[string]model.UserSettings
+}
+
+func (f *fakeUserSettingsStore) GetSettings(ctx context.Context, userID string) (*model.UserSettings, error) {
+	settings, ok := f.settings[userID]
+	if !ok {
+		return nil, repository.ErrUserSettingsNotFound
+	}
+	return &settings, nil
+}
+
+func (f *fakeUserSettingsStore) UpsertSettings(ctx context.Context, settings *model.UserSettings) error {
+	f.settings[settings.UserID] = *settings
+	return nil
+}
+
+func TestUserSettingsService(t *testing.T) {
+	store := &fakeUserSettingsStore{settings: make(map[string]model.UserSettings)}
+	svc := NewUserSettingsService(store, zap.NewNop())
+	ctx := context.Background()
+
+	loc, err := svc.UserLocation(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", loc.String())
+
+	require.NoError(t, svc.UpdateSettings(ctx, &model.UserSettings{UserID: "user-1", Timezone: " Europe/Budapest "}))
+	assert.Equal(t, "Europe/Budapest", store.settings["user-1"].Timezone)
+
+	loc, err = svc.UserLocation(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Budapest", loc.String())
+
+	for _, timezone := range []string{"", "Local", "Mars/Olympus_Mons", "CET+1"} {
+		err := svc.UpdateSettings(ctx, &model.UserSettings{UserID: "user-1", Timezone: timezone})
+		assert.ErrorIs(t, err, ErrInvalidTimezone, timezone)
+	}
+	assert.Equal(t, "Europe/Budapest", store.settings["user-1"].Timezone)
+}
@@ -0,0 +1,391 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// EventMedicationAdded is delivered to user webhooks when a medication is added
+const EventMedicationAdded = "medication.added"
+
+// WebhookEvents lists the events users can register webhooks for
+var WebhookEvents = []string{EventCheckInCompleted, EventMedicationAdded}
+
+// WebhookSignatureHeader carries the hex HMAC-SHA256 of a delivery's body, keyed with
+// the webhook's secret token
+const WebhookSignatureHeader = "X-Signature-SHA256"
+
+const (
+	// maxWebhookRetries is how often a failed delivery is retried
+	maxWebhookRetries = 3
+
+	// webhookRetryBackoff is the wait before the first retry; it doubles for every
+	// further retry
+	webhookRetryBackoff = time.Second
+
+	// webhookQueueSize bounds the deliveries waiting for a worker
+	webhookQueueSize = 1000
+
+	// webhookSecretBytes is the length of a webhook's random secret token
+	webhookSecretBytes = 32
+)
+
+var (
+	// ErrInvalidWebhook is returned for a webhook without a valid target URL or events
+	ErrInvalidWebhook = errors.New("invalid webhook")
+
+	// ErrWebhookAccessDenied is returned when a user deletes another user's webhook
+	ErrWebhookAccessDenied = errors.New("webhook belongs to another user")
+)
+
+// EventDispatcher notifies external systems of events about a user's data
+type EventDispatcher interface {
+	Dispatch(ctx context.Context, userID, event string, payload interface{}) error
+}
+
+// WebhookStore defines the persistence operations needed for user webhooks
+type WebhookStore interface {
+	CreateWebhook(ctx context.Context, webhook *model.Webhook) error
+	GetWebhook(ctx context.Context, webhookID string) (*model.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID string) error
+	FindWebhooksForEvent(ctx context.Context, userID, event string) ([]model.Webhook, error)
+}
+
+// webhookPayload is the body of a webhook delivery
+type webhookPayload struct {
+	ID         string      `json:"id"`
+	Event      string      `json:"event"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+// webhookDelivery is a signed delivery waiting for a worker
+type webhookDelivery struct {
+	id        string
+	webhookID string
+	event     string
+	targetURL string
+	body      []byte
+	signature string
+}
+
+// WebhookService manages the webhooks users register and delivers events to them. A
+// pool of workers sends the deliveries, retrying failed ones with exponential backoff.
+type WebhookService struct {
+	store         WebhookStore
+	client        *http.Client
+	allowInsecure bool
+	timeout       time.Duration
+	backoff       time.Duration
+	queue         chan webhookDelivery
+	workers       sync.WaitGroup
+	auditLogger   *audit.Logger
+	logger        *zap.Logger
+	now           func() time.Time
+}
+
+// NewWebhookService creates a new WebhookService. Target URLs must use HTTPS unless
+// allowInsecure is set.
+func NewWebhookService(store WebhookStore, client *http.Client, allowInsecure bool, logger *zap.Logger) *WebhookService {
+	return &WebhookService{
+		store:         store,
+		client:        client,
+		allowInsecure: allowInsecure,
+		timeout:       defaultDeliveryTimeout,
+		backoff:       webhookRetryBackoff,
+		queue:         make(chan webhookDelivery, webhookQueueSize),
+		logger:        logger,
+		now:           time.Now,
+	}
+}
+
+// SetAuditLogger enables audit logging of webhook registrations
+func (s *WebhookService) SetAuditLogger(auditLogger *audit.Logger) {
+	s.auditLogger = auditLogger
+}
+
+// SetDeliveryTimeout bounds a single delivery attempt
+func (s *WebhookService) SetDeliveryTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		s.timeout = timeout
+	}
+}
+
+// CreateWebhook registers a webhook of a user for events with a new secret token
+func (s *WebhookService) CreateWebhook(ctx context.Context, userID, targetURL string, events []string) (*model.Webhook, error) {
+	if err := s.validateTargetURL(targetURL); err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("%w: at least one event is required", ErrInvalidWebhook)
+	}
+	var subscribed []string
+	for _, event := range events {
+		if !slices.Contains(WebhookEvents, event) {
+			return nil, fmt.Errorf("%w: unknown event %q", ErrInvalidWebhook, event)
+		}
+		if !slices.Contains(subscribed, event) {
+			subscribed = append(subscribed, event)
+		}
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := &model.Webhook{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		TargetURL:   targetURL,
+		SecretToken: secret,
+		Events:      subscribed,
+	}
+	if err := s.store.CreateWebhook(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, userID, audit.OperationCreate, webhook.ID)
+	s.logger.Info("webhook created",
+		zap.String("user_id", userID),
+		zap.String("webhook_id", webhook.ID),
+		zap.Strings("events", subscribed),
+	)
+	return webhook, nil
+}
+
+// DeleteWebhook deletes a webhook. A non-empty userID must own it.
+func (s *WebhookService) DeleteWebhook(ctx context.Context, userID, webhookID string) error {
+	webhook, err := s.store.GetWebhook(ctx, webhookID)
+	if err != nil {
+		return err
+	}
+	if userID != "" && webhook.UserID != userID {
+		return ErrWebhookAccessDenied
+	}
+
+	if err := s.store.DeleteWebhook(ctx, webhookID); err != nil {
+		return err
+	}
+
+	s.audit(ctx, webhook.UserID, audit.OperationDelete, webhookID)
+	return nil
+}
+
+// validateTargetURL checks that a target URL is absolute and uses HTTPS, or HTTP when
+// insecure webhooks are allowed
+func (s *WebhookService) validateTargetURL(targetURL string) error {
+	target, err := url.Parse(targetURL)
+	if err != nil || target.Host == "" {
+		return fmt.Errorf("%w: target_url is not an absolute URL", ErrInvalidWebhook)
+	}
+	if target.Scheme != "https" && !(s.allowInsecure && target.Scheme == "http") {
+		return fmt.Errorf("%w: target_url must use https", ErrInvalidWebhook)
+	}
+	return nil
+}
+
+// Dispatch queues a delivery of an event about a user to each of the user's webhooks
+// subscribed to it. The payload is sent as the data of the delivery body, signed with
+// the webhook's secret token. Deliveries are dropped when the queue is full.
+func (s *WebhookService) Dispatch(ctx context.Context, userID, event string, payload interface{}) error {
+	webhooks, err := s.store.FindWebhooksForEvent(ctx, userID, event)
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		delivery := webhookDelivery{
+			id:        uuid.New().String(),
+			webhookID: webhook.ID,
+			event:     event,
+			targetURL: webhook.TargetURL,
+		}
+		delivery.body, err = json.Marshal(webhookPayload{
+			ID:         delivery.id,
+			Event:      event,
+			OccurredAt: s.now().UTC(),
+			Data:       payload,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to encode webhook payload: %w", err)
+		}
+		delivery.signature = signWebhookBody(webhook.SecretToken, delivery.body)
+
+		select {
+		case s.queue <- delivery:
+		default:
+			s.logger.Error("webhook queue full, dropping delivery",
+				zap.String("webhook_id", webhook.ID),
+				zap.String("event", event),
+			)
+		}
+	}
+	return nil
+}
+
+// CheckInCompleted delivers a completed check-in to the user's webhooks subscribed to
+// checkin.completed
+func (s *WebhookService) CheckInCompleted(ctx context.Context, checkIn *model.HealthCheckIn) {
+	if err := s.Dispatch(ctx, checkIn.UserID, EventCheckInCompleted, checkIn); err != nil {
+		s.logger.Error("failed to dispatch check-in webhooks",
+			zap.Error(err),
+			zap.String("check_in_id", checkIn.ID),
+		)
+	}
+}
+
+// Start starts workers goroutines sending queued deliveries until ctx is cancelled.
+// Deliveries still queued then are dropped.
+func (s *WebhookService) Start(ctx context.Context, workers int) {
+	for i := 0; i < workers; i++ {
+		s.workers.Add(1)
+		go s.run(ctx)
+	}
+	s.logger.Info("webhook workers started", zap.Int("workers", workers))
+}
+
+// Wait blocks until the workers have stopped after the context passed to Start was
+// cancelled
+func (s *WebhookService) Wait() {
+	s.workers.Wait()
+}
+
+// run sends queued deliveries until ctx is cancelled
+func (s *WebhookService) run(ctx context.Context) {
+	defer s.workers.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case delivery := <-s.queue:
+			s.deliver(ctx, delivery)
+		}
+	}
+}
+
+// deliver sends a delivery, retrying it up to maxWebhookRetries times with exponential
+// backoff. Retries are abandoned when ctx is cancelled.
+func (s *WebhookService) deliver(ctx context.Context, delivery webhookDelivery) {
+	backoff := s.backoff
+	for attempt := 0; ; attempt++ {
+		err := s.send(ctx, delivery)
+		if err == nil {
+			s.logger.Info("webhook delivered",
+				zap.String("webhook_id", delivery.webhookID),
+				zap.String("delivery_id", delivery.id),
+				zap.Int("attempts", attempt+1),
+			)
+			return
+		}
+
+		if attempt == maxWebhookRetries {
+			s.logger.Error("webhook delivery failed",
+				zap.Error(err),
+				zap.String("webhook_id", delivery.webhookID),
+				zap.String("delivery_id", delivery.id),
+				zap.Int("attempts", attempt+1),
+			)
+			return
+		}
+		s.logger.Warn("webhook delivery attempt failed, retrying",
+			zap.Error(err),
+			zap.String("webhook_id", delivery.webhookID),
+			zap.String("delivery_id", delivery.id),
+			zap.Duration("backoff", backoff),
+		)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			s.logger.Warn("webhook retries abandoned at shutdown",
+				zap.String("webhook_id", delivery.webhookID),
+				zap.String("delivery_id", delivery.id),
+			)
+			return
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// send posts a delivery within the delivery timeout. Any response other than 2xx is an
+// error.
+func (s *WebhookService) send(ctx context.Context, delivery webhookDelivery) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.targetURL, bytes.NewReader(delivery.body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookSignatureHeader, delivery.signature)
+	req.Header.Set("X-Webhook-Event", delivery.event)
+	req.Header.Set("X-Webhook-Delivery", delivery.id)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// audit records a webhook registration change in the audit log
+func (s *WebhookService) audit(ctx context.Context, userID string, op audit.OperationType, webhookID string) {
+	if s.auditLogger == nil {
+		return
+	}
+
+	err := s.auditLogger.Log(ctx, audit.AuditLog{
+		UserID:        userID,
+		OperationType: op,
+		ResourceType:  audit.ResourceWebhook,
+		ResourceID:    webhookID,
+	})
+	if err != nil {
+		s.logger.Error("failed to audit webhook change", zap.Error(err), zap.String("webhook_id", webhookID))
+	}
+}
+
+// signWebhookBody returns the hex HMAC-SHA256 of a delivery body keyed with secret
+func signWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// newWebhookSecret returns a random webhook secret token
+func newWebhookSecret() (string, error) {
+	b := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+// fakeWebhookStore is an in-memory WebhookStore
+type fakeWebhookStore struct {
+	webhooks map[string]*model.Webhook
+}
+
+func newFakeWebhookStore() *fakeWebhookStore {
+	return &fakeWebhookStore{webhooks: make(map[string]*model.Webhook)}
+}
+
+func (f *fakeWebhookStore) CreateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	webhook.CreatedAt = time.Now()
+	stored := *webhook
+	f.webhooks[webhook.ID] = &stored
+	return nil
+}
+
+func (f *fakeWebhookStore) GetWebhook(ctx context.Context, webhookID string) (*model.Webhook, error) {
+	webhook, ok := f.webhooks[webhookID]
+	if !ok {
+		return nil, repository.ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+func (f *fakeWebhookStore) DeleteWebhook(ctx context.Context, webhookID string) error {
+	if _, ok := f.webhooks[webhookID]; !ok {
+		return repository.ErrWebhookNotFound
+	}
+	delete(f.webhooks, webhookID)
+	return nil
+}
+
+func (f *fakeWebhookStore) FindWebhooksForEvent(ctx context.Context, userID, event string) ([]model.Webhook, error) {
+	var found []model.Webhook
+	for _, webhook := range f.webhooks {
+		if webhook.UserID == userID && slices.Contains(webhook.Events, event) {
+			found = append(found, *webhook)
+		}
+	}
+	return found, nil
+}
+
+// webhookReceiver records the deliveries posted to it, answering with the given
+// statuses in turn and 200 once they run out
+type webhookReceiver struct {
+	mu       sync.Mutex
+	statuses []int
+	bodies   [][]byte
+	headers  []http.Header
+	received chan struct{}
+}
+
+func newWebhookReceiver(statuses ...int) (*webhookReceiver, *httptest.Server) {
+	receiver := &webhookReceiver{statuses: statuses, received: make(chan struct{}, 100)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		receiver.mu.Lock()
+		receiver.bodies = append(receiver.bodies, body)
+		receiver.headers = append(receiver.headers, r.Header.Clone())
+		status := http.StatusOK
+		if len(receiver.statuses) > 0 {
+			status, receiver.statuses = receiver.statuses[0], receiver.statuses[1:]
+		}
+		receiver.mu.Unlock()
+		w.WriteHeader(status)
+		receiver.received <- struct{}{}
+	}))
+	return receiver, server
+}
+
+// await waits for n deliveries
+func (r *webhookReceiver) await(t *testing.T, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		select {
+		case <-r.received:
+		case <-time.After(5 * time.Second):
+			require.Failf(t, "webhook not delivered", "got %d of %d deliveries", i, n)
+		}
+	}
+}
+
+// newTestWebhookService creates a service with workers running until the test ends
+func newTestWebhookService(t *testing.T, store WebhookStore) *WebhookService {
+	svc := NewWebhookService(store, &http.Client{}, true, zap.NewNop())
+	svc.backoff = time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	svc.Start(ctx, 2)
+	t.Cleanup(func() {
+		cancel()
+		svc.Wait()
+	})
+	return svc
+}
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+
+	t.Run("generates a secret token", func(t *testing.T) {
+		store := newFakeWebhookStore()
+		svc := NewWebhookService(store, &http.Client{}, false, zap.NewNop())
+
+		webhook, err := svc.CreateWebhook(ctx, userID, "https://ehr.example.com/hooks", []string{EventCheckInCompleted, EventMedicationAdded, EventCheckInCompleted})
+		require.NoError(t, err)
+		assert.Equal(t, userID, webhook.UserID)
+		assert.Equal(t, []string{EventCheckInCompleted, EventMedicationAdded}, webhook.Events)
+		assert.Len(t, webhook.SecretToken, 2*webhookSecretBytes)
+		assert.Contains(t, store.webhooks, webhook.ID)
+	})
+
+	invalid := map[string]struct {
+		url    string
+		events []string
+	}{
+		"relative URL":  {"/hooks", []string{EventCheckInCompleted}},
+		"insecure URL":  {"http://ehr.example.com/hooks", []string{EventCheckInCompleted}},
+		"no events":     {"https://ehr.example.com/hooks", nil},
+		"unknown event": {"https://ehr.example.com/hooks", []string{"user.deleted"}},
+	}
+	for name, tt := range invalid {
+		t.Run(name, func(t *testing.T) {
+			store := newFakeWebhookStore()
+			svc := NewWebhookService(store, &http.Client{}, false, zap.NewNop())
+
+			_, err := svc.CreateWebhook(ctx, userID, tt.url, tt.events)
+			assert.ErrorIs(t, err, ErrInvalidWebhook)
+			assert.Empty(t, store.webhooks)
+		})
+	}
+}
+
+func TestWebhookService_DeleteWebhook(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeWebhookStore()
+	svc := NewWebhookService(store, &http.Client{}, false, zap.NewNop())
+	userID := uuid.New().String()
+
+	webhook, err := svc.CreateWebhook(ctx, userID, "https://ehr.example.com/hooks", []string{EventCheckInCompleted})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, svc.DeleteWebhook(ctx, uuid.New().String(), webhook.ID), ErrWebhookAccessDenied)
+	assert.Contains(t, store.webhooks, webhook.ID)
+
+	require.NoError(t, svc.DeleteWebhook(ctx, userID, webhook.ID))
+	assert.Empty(t, store.webhooks)
+
+	assert.ErrorIs(t, svc.DeleteWebhook(ctx, userID, webhook.ID), repository.ErrWebhookNotFound)
+}
+
+func TestWebhookService_DispatchSignsDeliveries(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeWebhookStore()
+	svc := newTestWebhookService(t, store)
+	receiver, server := newWebhookReceiver()
+	defer server.Close()
+
+	userID := uuid.New().String()
+	webhook, err := svc.CreateWebhook(ctx, userID, server.URL, []string{EventCheckInCompleted})
+	require.NoError(t, err)
+	// Another user's webhook and one for another event are not notified
+	_, err = svc.CreateWebhook(ctx, uuid.New().String(), server.URL, []string{EventCheckInCompleted})
+	require.NoError(t, err)
+	_, err = svc.CreateWebhook(ctx, userID, server.URL, []string{EventMedicationAdded})
+	require.NoError(t, err)
+
+	svc.CheckInCompleted(ctx, &model.HealthCheckIn{ID: "check-in-1", UserID: userID})
+	receiver.await(t, 1)
+
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	require.Len(t, receiver.bodies, 1)
+	body, header := receiver.bodies[0], receiver.headers[0]
+	assert.Equal(t, signWebhookBody(webhook.SecretToken, body), header.Get(WebhookSignatureHeader))
+	assert.Equal(t, EventCheckInCompleted, header.Get("X-Webhook-Event"))
+
+	var payload struct {
+		ID    string              `json:"id"`
+		Event string              `json:"event"`
+		Data  model.HealthCheckIn `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, header.Get("X-Webhook-Delivery"), payload.ID)
+	assert.Equal(t, EventCheckInCompleted, payload.Event)
+	assert.Equal(t, "check-in-1", payload.Data.ID)
+}
+
+func TestWebhookService_RetriesFailedDeliveries(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("succeeds on a retry", func(t *testing.T) {
+		svc := newTestWebhookService(t, newFakeWebhookStore())
+		receiver, server := newWebhookReceiver(http.StatusInternalServerError, http.StatusBadGateway)
+		defer server.Close()
+
+		userID := uuid.New().String()
+		_, err := svc.CreateWebhook(ctx, userID, server.URL, []string{EventMedicationAdded})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.Dispatch(ctx, userID, EventMedicationAdded, model.Medication{Name: "Metformin"}))
+		receiver.await(t, 3)
+
+		receiver.mu.Lock()
+		defer receiver.mu.Unlock()
+		assert.Equal(t, receiver.bodies[0], receiver.bodies[2], "a retry resends the same delivery")
+	})
+
+	t.Run("gives up after three retries", func(t *testing.T) {
+		failures := make([]int, 10)
+		for i := range failures {
+			failures[i] = http.StatusServiceUnavailable
+		}
+		store := newFakeWebhookStore()
+		svc := NewWebhookService(store, &http.Client{}, true, zap.NewNop())
+		svc.backoff = time.Millisecond
+		receiver, server := newWebhookReceiver(failures...)
+		defer server.Close()
+
+		userID := uuid.New().String()
+		_, err := svc.CreateWebhook(ctx, userID, server.URL, []string{EventMedicationAdded})
+		require.NoError(t, err)
+		require.NoError(t, svc.Dispatch(ctx, userID, EventMedicationAdded, nil))
+
+		// The worker returns once the delivery is given up
+		svc.deliver(ctx, <-svc.queue)
+
+		receiver.mu.Lock()
+		defer receiver.mu.Unlock()
+		assert.Len(t, receiver.bodies, 1+maxWebhookRetries)
+	})
+}
+
+func TestWebhookService_AbandonsRetriesOnCancel(t *testing.T) {
+	store := newFakeWebhookStore()
+	svc := NewWebhookService(store, &http.Client{}, true, zap.NewNop())
+	svc.backoff = time.Hour
+	receiver, server := newWebhookReceiver(http.StatusInternalServerError)
+	defer server.Close()
+
+	userID := uuid.New().String()
+	_, err := svc.CreateWebhook(context.Background(), userID, server.URL, []string{EventCheckInCompleted})
+	require.NoError(t, err)
+	require.NoError(t, svc.Dispatch(context.Background(), userID, EventCheckInCompleted, nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	svc.Start(ctx, 1)
+	receiver.await(t, 1)
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		svc.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.Fail(t, "workers did not stop during the retry backoff")
+	}
+}
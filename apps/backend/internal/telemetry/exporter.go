@@ -0,0 +1,261 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Format selects the payload encoding of an HTTPExporter
+type Format string
+
+const (
+	// FormatWebhook posts {"events": [...]} as plain JSON
+	FormatWebhook Format = "webhook"
+	// FormatOTLP posts OTLP/HTTP JSON log records, e.g. to a collector's /v1/logs endpoint
+	FormatOTLP Format = "otlp"
+)
+
+const (
+	exporterQueueSize = 256
+	exporterMaxBatch  = 50
+)
+
+// ExporterConfig configures an HTTPExporter
+type ExporterConfig struct {
+	Format      Format
+	Endpoint    string
+	Headers     map[string]string
+	ServiceName string
+	Timeout     time.Duration
+}
+
+// HTTPExporter is an ErrorReporter that posts scrubbed events to an HTTP endpoint.
+// Events are queued and sent in batches by a background worker; when the queue
+// is full new events are dropped rather than blocking the request path.
+type HTTPExporter struct {
+	cfg    ExporterConfig
+	client *http.Client
+	logger *zap.Logger
+
+	queue     chan ErrorEvent
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewHTTPExporter creates an HTTPExporter and starts its background worker
+func NewHTTPExporter(cfg ExporterConfig, logger *zap.Logger) (*HTTPExporter, error) {
+	if cfg.Endpoint == "" {
+		return nil, fmt.Errorf("telemetry endpoint is required")
+	}
+	if cfg.Format != FormatWebhook && cfg.Format != FormatOTLP {
+		return nil, fmt.Errorf("unsupported telemetry format: %s", cfg.Format)
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+
+	e := &HTTPExporter{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+		logger: logger,
+		queue:  make(chan ErrorEvent, exporterQueueSize),
+		done:   make(chan struct{}),
+	}
+	go e.run()
+	return e, nil
+}
+
+// Report implements ErrorReporter
+func (e *HTTPExporter) Report(ctx context.Context, event ErrorEvent) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+
+	select {
+	case e.queue <- ScrubEvent(event):
+	default:
+		e.logger.Warn("telemetry queue full, dropping error event",
+			zap.String("kind", string(event.Kind)),
+			zap.String("operation", event.Operation),
+		)
+	}
+}
+
+// Close stops accepting events and waits until queued events are sent or ctx expires
+func (e *HTTPExporter) Close(ctx context.Context) error {
+	e.closeOnce.Do(func() { close(e.queue) })
+
+	select {
+	case <-e.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run sends queued events in batches until the queue is closed
+func (e *HTTPExporter) run() {
+	defer close(e.done)
+
+	for event := range e.queue {
+		batch := []ErrorEvent{event}
+	drain:
+		for len(batch) < exporterMaxBatch {
+			select {
+			case next, ok := <-e.queue:
+				if !ok {
+					break drain
+				}
+				batch = append(batch, next)
+			default:
+				break drain
+			}
+		}
+
+		if err := e.send(batch); err != nil {
+			e.logger.Warn("failed to export error events",
+				zap.Int("events", len(batch)),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// send posts one batch of events to the configured endpoint
+func (e *HTTPExporter) send(batch []ErrorEvent) error {
+	var payload interface{}
+	if e.cfg.Format == FormatOTLP {
+		payload = otlpPayload(e.cfg.ServiceName, batch)
+	} else {
+		payload = map[string]interface{}{"events": batch}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode events: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.cfg.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("telemetry sink returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// OTLP/HTTP JSON log payload types
+// https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+type (
+	otlpLogsData struct {
+		ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+	}
+	otlpResourceLogs struct {
+		Resource  otlpResource    `json:"resource"`
+		ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+	}
+	otlpResource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	}
+	otlpScopeLogs struct {
+		Scope      otlpScope       `json:"scope"`
+		LogRecords []otlpLogRecord `json:"logRecords"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpLogRecord struct {
+		TimeUnixNano   string         `json:"timeUnixNano"`
+		SeverityNumber int            `json:"severityNumber"`
+		SeverityText   string         `json:"severityText"`
+		Body           otlpAnyValue   `json:"body"`
+		Attributes     []otlpKeyValue `json:"attributes"`
+	}
+	otlpKeyValue struct {
+		Key   string       `json:"key"`
+		Value otlpAnyValue `json:"value"`
+	}
+	otlpAnyValue struct {
+		StringValue *string `json:"stringValue,omitempty"`
+		IntValue    *string `json:"intValue,omitempty"`
+	}
+)
+
+// otlpSeverityError is the OTLP severity number of ERROR
+const otlpSeverityError = 17
+
+// otlpPayload converts events to OTLP log records
+func otlpPayload(serviceName string, batch []ErrorEvent) otlpLogsData {
+	records := make([]otlpLogRecord, 0, len(batch))
+	for _, event := range batch {
+		attributes := []otlpKeyValue{
+			otlpString("error.kind", string(event.Kind)),
+			otlpString("operation", event.Operation),
+		}
+		if event.RequestID != "" {
+			attributes = append(attributes, otlpString("request.id", event.RequestID))
+		}
+		if event.TraceID != "" {
+			attributes = append(attributes, otlpString("trace.id", event.TraceID))
+		}
+		if event.Method != "" {
+			attributes = append(attributes, otlpString("http.request.method", event.Method))
+		}
+		if event.Route != "" {
+			attributes = append(attributes, otlpString("http.route", event.Route))
+		}
+		if event.Status != 0 {
+			status := strconv.Itoa(event.Status)
+			attributes = append(attributes, otlpKeyValue{Key: "http.response.status_code", Value: otlpAnyValue{IntValue: &status}})
+		}
+		for k, v := range event.Attributes {
+			attributes = append(attributes, otlpString(k, v))
+		}
+
+		message := event.Message
+		records = append(records, otlpLogRecord{
+			TimeUnixNano:   strconv.FormatInt(event.Timestamp.UnixNano(), 10),
+			SeverityNumber: otlpSeverityError,
+			SeverityText:   "ERROR",
+			Body:           otlpAnyValue{StringValue: &message},
+			Attributes:     attributes,
+		})
+	}
+
+	return otlpLogsData{
+		ResourceLogs: []otlpResourceLogs{{
+			Resource: otlpResource{Attributes: []otlpKeyValue{otlpString("service.name", serviceName)}},
+			ScopeLogs: []otlpScopeLogs{{
+				Scope:      otlpScope{Name: "healthcare-backend/telemetry"},
+				LogRecords: records,
+			}},
+		}},
+	}
+}
+
+func otlpString(key, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{StringValue: &value}}
+}
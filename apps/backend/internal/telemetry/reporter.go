@@ -0,0 +1,83 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+)
+
+// Kind classifies an error event
+type Kind string
+
+const (
+	// KindHTTPError is a request that completed with a 5xx status
+	KindHTTPError Kind = "http_error"
+	// KindUpstreamFailure is a failed call to an external dependency such as Azure
+	KindUpstreamFailure Kind = "upstream_failure"
+	// KindExtractionFallback is a check-in whose structured data extraction failed
+	// and fell back to storing the raw transcript
+	KindExtractionFallback Kind = "extraction_fallback"
+)
+
+// ErrorEvent is a structured error exported to a telemetry sink.
+// Events must not carry personal data; use Scrub on free text before reporting.
+type ErrorEvent struct {
+	Timestamp  time.Time         `json:"timestamp"`
+	Kind       Kind              `json:"kind"`
+	Operation  string            `json:"operation"`
+	Message    string            `json:"message"`
+	RequestID  string            `json:"request_id,omitempty"`
+	TraceID    string            `json:"trace_id,omitempty"`
+	Method     string            `json:"method,omitempty"`
+	Route      string            `json:"route,omitempty"`
+	Status     int               `json:"status,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// ErrorReporter receives error events. Implementations must not block the caller.
+type ErrorReporter interface {
+	Report(ctx context.Context, event ErrorEvent)
+}
+
+// NopReporter discards all events
+type NopReporter struct{}
+
+// Report implements ErrorReporter
+func (NopReporter) Report(context.Context, ErrorEvent) {}
+
+type requestInfoKey struct{}
+
+// requestInfo identifies the request an event originated from
+type requestInfo struct {
+	requestID string
+	traceID   string
+}
+
+// WithRequest returns a context carrying the request and trace IDs, so that
+// service failure paths can correlate their events with the failed request
+func WithRequest(ctx context.Context, requestID, traceID string) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, requestInfo{requestID: requestID, traceID: traceID})
+}
+
+// RequestIDs returns the request and trace IDs stored by WithRequest
+func RequestIDs(ctx context.Context) (requestID, traceID string) {
+	info, _ := ctx.Value(requestInfoKey{}).(requestInfo)
+	return info.requestID, info.traceID
+}
+
+// ReportError reports err from a service failure path. The message is scrubbed
+// and the event is correlated with the request stored in ctx.
+func ReportError(ctx context.Context, reporter ErrorReporter, kind Kind, operation string, err error) {
+	if reporter == nil || err == nil {
+		return
+	}
+
+	requestID, traceID := RequestIDs(ctx)
+	reporter.Report(ctx, ErrorEvent{
+		Timestamp: time.Now().UTC(),
+		Kind:      kind,
+		Operation: operation,
+		Message:   Scrub(err.Error()),
+		RequestID: requestID,
+		TraceID:   traceID,
+	})
+}
@@ -0,0 +1,53 @@
+package telemetry
+
+import (
+	"regexp"
+	"unicode/utf8"
+)
+
+// maxMessageLength bounds exported messages; longer text is more likely to
+// contain echoed user content such as transcripts
+const maxMessageLength = 512
+
+var scrubRules = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	// Bearer tokens and JWTs
+	{regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9\-._~+/]+=*`), "Bearer [REDACTED]"},
+	{regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`), "[TOKEN]"},
+	// SAS signatures and account keys in URLs or connection strings
+	{regexp.MustCompile(`(?i)(sig|accountkey)=[^&;\s]+`), "$1=[REDACTED]"},
+	{regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`), "[EMAIL]"},
+	// User, session and record identifiers
+	{regexp.MustCompile(`(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`), "[ID]"},
+	// Phone numbers and national identifiers (TAJ numbers)
+	{regexp.MustCompile(`\+?\d[\d\s\-()]{6,}\d`), "[NUMBER]"},
+}
+
+// Scrub removes personal data (emails, phone numbers, identifiers, tokens) from
+// free text and truncates it to a bounded length
+func Scrub(s string) string {
+	for _, rule := range scrubRules {
+		s = rule.pattern.ReplaceAllString(s, rule.replacement)
+	}
+
+	if utf8.RuneCountInString(s) > maxMessageLength {
+		runes := []rune(s)
+		s = string(runes[:maxMessageLength]) + "…"
+	}
+	return s
+}
+
+// ScrubEvent returns a copy of event with its message and attributes scrubbed
+func ScrubEvent(event ErrorEvent) ErrorEvent {
+	event.Message = Scrub(event.Message)
+	if len(event.Attributes) > 0 {
+		attributes := make(map[string]string, len(event.Attributes))
+		for k, v := range event.Attributes {
+			attributes[k] = Scrub(v)
+		}
+		event.Attributes = attributes
+	}
+	return event
+}
@@ -0,0 +1,178 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestScrub(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"email", "no account for anna.kovacs@example.hu", "no account for [EMAIL]"},
+		{"uuid", "user 0b7f6f0e-3c1d-4a38-9a57-5f0d2a1c6e11 not found", "user [ID] not found"},
+		{"phone", "call +36 30 123 4567 now", "call [NUMBER] now"},
+		{"bearer", "header Bearer abc.def-ghi rejected", "header Bearer [REDACTED] rejected"},
+		{"sas", "GET https://x.blob.core.windows.net/a?sv=1&sig=secret%3D failed", "GET https://x.blob.core.windows.net/a?sv=1&sig=[REDACTED] failed"},
+		{"plain", "speech service returned status 503", "speech service returned status 503"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Scrub(tt.in))
+		})
+	}
+}
+
+func TestScrub_Truncates(t *testing.T) {
+	scrubbed := Scrub(strings.Repeat("á", maxMessageLength+100))
+	assert.Equal(t, maxMessageLength+1, len([]rune(scrubbed)))
+}
+
+func TestReportError_UsesRequestFromContext(t *testing.T) {
+	var got []ErrorEvent
+	reporter := reporterFunc(func(ctx context.Context, event ErrorEvent) { got = append(got, event) })
+
+	ctx := WithRequest(context.Background(), "req-1", "trace-1")
+	ReportError(ctx, reporter, KindUpstreamFailure, "speech.stt", errors.New("user a@b.io: 503"))
+	ReportError(ctx, reporter, KindUpstreamFailure, "speech.stt", nil)
+	ReportError(ctx, nil, KindUpstreamFailure, "speech.stt", errors.New("ignored"))
+
+	require.Len(t, got, 1)
+	assert.Equal(t, "req-1", got[0].RequestID)
+	assert.Equal(t, "trace-1", got[0].TraceID)
+	assert.Equal(t, "user [EMAIL]: 503", got[0].Message)
+}
+
+type reporterFunc func(ctx context.Context, event ErrorEvent)
+
+func (f reporterFunc) Report(ctx context.Context, event ErrorEvent) { f(ctx, event) }
+
+func TestHTTPExporter_Formats(t *testing.T) {
+	tests := []struct {
+		format Format
+		check  func(t *testing.T, body map[string]interface{})
+	}{
+		{FormatWebhook, func(t *testing.T, body map[string]interface{}) {
+			events := body["events"].([]interface{})
+			require.Len(t, events, 1)
+			event := events[0].(map[string]interface{})
+			assert.Equal(t, "req-9", event["request_id"])
+			assert.Equal(t, "lookup for [EMAIL] failed", event["message"])
+			assert.Equal(t, "[ID]", event["attributes"].(map[string]interface{})["session"])
+		}},
+		{FormatOTLP, func(t *testing.T, body map[string]interface{}) {
+			resourceLogs := body["resourceLogs"].([]interface{})
+			scopeLogs := resourceLogs[0].(map[string]interface{})["scopeLogs"].([]interface{})
+			records := scopeLogs[0].(map[string]interface{})["logRecords"].([]interface{})
+			require.Len(t, records, 1)
+			record := records[0].(map[string]interface{})
+			assert.Equal(t, "ERROR", record["severityText"])
+			assert.Equal(t, "lookup for [EMAIL] failed", record["body"].(map[string]interface{})["stringValue"])
+			assert.Contains(t, string(mustJSON(t, record["attributes"])), `"request.id"`)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			bodies := make(chan []byte, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+				data, _ := io.ReadAll(r.Body)
+				bodies <- data
+			}))
+			defer server.Close()
+
+			exporter, err := NewHTTPExporter(ExporterConfig{
+				Format:      tt.format,
+				Endpoint:    server.URL,
+				Headers:     map[string]string{"X-Api-Key": "secret"},
+				ServiceName: "test",
+			}, zap.NewNop())
+			require.NoError(t, err)
+
+			exporter.Report(context.Background(), ErrorEvent{
+				Kind:       KindHTTPError,
+				Message:    "lookup for a@b.io failed",
+				RequestID:  "req-9",
+				Status:     500,
+				Attributes: map[string]string{"session": "0b7f6f0e-3c1d-4a38-9a57-5f0d2a1c6e11"},
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			require.NoError(t, exporter.Close(ctx))
+
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(<-bodies, &body))
+			tt.check(t, body)
+		})
+	}
+}
+
+func TestNewHTTPExporter_Validates(t *testing.T) {
+	_, err := NewHTTPExporter(ExporterConfig{Format: FormatWebhook}, zap.NewNop())
+	assert.Error(t, err)
+
+	_, err = NewHTTPExporter(ExporterConfig{Format: "statsd", Endpoint: "http://localhost"}, zap.NewNop())
+	assert.Error(t, err)
+}
+
+func mustJSON(t *testing.T, v interface{}) []byte {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return data
+}
+
+func TestStartStage_AccumulatesRequestTimings(t *testing.T) {
+	timings := NewStageTimings()
+	ctx := WithStageTimings(context.Background(), timings)
+
+	stop := StartStage(ctx, StageDBRead)
+	time.Sleep(2 * time.Millisecond)
+	stop()
+	StartStage(ctx, StageTTS)()
+	StartStage(ctx, StageDBRead)()
+
+	assert.Equal(t, []string{StageDBRead, StageTTS}, timings.Stages())
+	assert.GreaterOrEqual(t, timings.Duration(StageDBRead), 2*time.Millisecond)
+
+	breakdown := timings.Breakdown()
+	assert.Len(t, breakdown.Stages, 2)
+	assert.GreaterOrEqual(t, breakdown.Stages[StageDBRead], 2.0)
+	assert.GreaterOrEqual(t, breakdown.TotalMS, breakdown.Stages[StageDBRead])
+
+	// Without request timings only the histograms are updated
+	assert.NotPanics(t, func() { StartStage(context.Background(), StageTTS)() })
+}
+
+func TestLatencyHistograms_Snapshot(t *testing.T) {
+	histograms := NewLatencyHistograms()
+	histograms.Observe("tts", 3*time.Millisecond)
+	histograms.Observe("tts", 40*time.Millisecond)
+	histograms.Observe("tts", 20*time.Second)
+
+	snapshot := histograms.Snapshot()
+	require.Contains(t, snapshot, "tts")
+	tts := snapshot["tts"]
+	assert.Equal(t, int64(3), tts.Count)
+	assert.InDelta(t, 20043.0, tts.SumMS, 0.001)
+
+	require.Len(t, tts.Buckets, len(latencyBucketsMS))
+	assert.Equal(t, LatencyBucket{LeMS: 5, Count: 1}, tts.Buckets[0])
+	assert.Equal(t, LatencyBucket{LeMS: 50, Count: 2}, tts.Buckets[3])
+	assert.Equal(t, LatencyBucket{LeMS: 10000, Count: 2}, tts.Buckets[len(tts.Buckets)-1])
+}
@@ -0,0 +1,192 @@
+package telemetry
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Check-in latency stages
+const (
+	StageTranscription     = "transcription"
+	StageDBRead            = "db_read"
+	StageDBWrite           = "db_write"
+	StageFollowUp          = "follow_up"
+	StageQuestionSelection = "question_selection"
+	StageAudioCache        = "audio_cache"
+	StageBlobCache         = "blob_cache"
+	StageTTS               = "tts"
+)
+
+// StageTimings collects how long the stages of one request took. A stage entered more
+// than once accumulates its durations.
+type StageTimings struct {
+	mu     sync.Mutex
+	start  time.Time
+	stages map[string]time.Duration
+	order  []string
+}
+
+// NewStageTimings starts timing a request
+func NewStageTimings() *StageTimings {
+	return &StageTimings{
+		start:  time.Now(),
+		stages: make(map[string]time.Duration),
+	}
+}
+
+// add accumulates d into stage
+func (t *StageTimings) add(stage string, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.stages[stage]; !ok {
+		t.order = append(t.order, stage)
+	}
+	t.stages[stage] += d
+}
+
+// Total returns the time since the request started
+func (t *StageTimings) Total() time.Duration {
+	return time.Since(t.start)
+}
+
+// TimingBreakdown is the per-stage latency of a request in milliseconds
+type TimingBreakdown struct {
+	TotalMS float64            `json:"total_ms"`
+	Stages  map[string]float64 `json:"stages_ms"`
+}
+
+// Breakdown returns the total and per-stage durations recorded so far
+func (t *StageTimings) Breakdown() TimingBreakdown {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	breakdown := TimingBreakdown{
+		TotalMS: milliseconds(time.Since(t.start)),
+		Stages:  make(map[string]float64, len(t.stages)),
+	}
+	for stage, d := range t.stages {
+		breakdown.Stages[stage] = milliseconds(d)
+	}
+	return breakdown
+}
+
+// Stages returns the recorded stages in the order they were first entered
+func (t *StageTimings) Stages() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.order...)
+}
+
+// Duration returns the accumulated duration of stage
+func (t *StageTimings) Duration(stage string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stages[stage]
+}
+
+type stageTimingsKey struct{}
+
+// WithStageTimings returns a context that collects stage durations into t
+func WithStageTimings(ctx context.Context, t *StageTimings) context.Context {
+	return context.WithValue(ctx, stageTimingsKey{}, t)
+}
+
+// StageTimingsFrom returns the timings stored by WithStageTimings, or nil
+func StageTimingsFrom(ctx context.Context) *StageTimings {
+	t, _ := ctx.Value(stageTimingsKey{}).(*StageTimings)
+	return t
+}
+
+// StartStage starts timing stage and returns a function that stops it. The duration is
+// observed in the stage latency histograms and added to the request's timings, if any.
+func StartStage(ctx context.Context, stage string) func() {
+	start := time.Now()
+	return func() {
+		d := time.Since(start)
+		StageLatencies.Observe(stage, d)
+		if t := StageTimingsFrom(ctx); t != nil {
+			t.add(stage, d)
+		}
+	}
+}
+
+// latencyBucketsMS are the upper bounds of the stage latency histogram buckets
+var latencyBucketsMS = []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+// StageLatencies holds the latency histograms of all stages since the service started
+var StageLatencies = NewLatencyHistograms()
+
+// LatencyHistograms keeps a latency histogram per stage
+type LatencyHistograms struct {
+	mu     sync.Mutex
+	stages map[string]*latencyHistogram
+}
+
+type latencyHistogram struct {
+	counts []int64 // per bucket, the last one counting observations above every bound
+	count  int64
+	sum    time.Duration
+}
+
+// NewLatencyHistograms creates an empty set of histograms
+func NewLatencyHistograms() *LatencyHistograms {
+	return &LatencyHistograms{stages: make(map[string]*latencyHistogram)}
+}
+
+// Observe records one duration of stage
+func (h *LatencyHistograms) Observe(stage string, d time.Duration) {
+	ms := milliseconds(d)
+	bucket := sort.SearchFloat64s(latencyBucketsMS, ms)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hist, ok := h.stages[stage]
+	if !ok {
+		hist = &latencyHistogram{counts: make([]int64, len(latencyBucketsMS)+1)}
+		h.stages[stage] = hist
+	}
+	hist.counts[bucket]++
+	hist.count++
+	hist.sum += d
+}
+
+// LatencyBucket is a cumulative histogram bucket: the observations of at most LeMS
+// milliseconds. Observations above the last bound are only included in the total count.
+type LatencyBucket struct {
+	LeMS  float64 `json:"le_ms"`
+	Count int64   `json:"count"`
+}
+
+// LatencySnapshot is the histogram of one stage
+type LatencySnapshot struct {
+	Count   int64           `json:"count"`
+	SumMS   float64         `json:"sum_ms"`
+	Buckets []LatencyBucket `json:"buckets"`
+}
+
+// Snapshot returns the histogram of every observed stage
+func (h *LatencyHistograms) Snapshot() map[string]LatencySnapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	snapshot := make(map[string]LatencySnapshot, len(h.stages))
+	for stage, hist := range h.stages {
+		s := LatencySnapshot{
+			Count:   hist.count,
+			SumMS:   milliseconds(hist.sum),
+			Buckets: make([]LatencyBucket, 0, len(latencyBucketsMS)),
+		}
+		var cumulative int64
+		for i, bound := range latencyBucketsMS {
+			cumulative += hist.counts[i]
+			s.Buckets = append(s.Buckets, LatencyBucket{LeMS: bound, Count: cumulative})
+		}
+		snapshot[stage] = s
+	}
+	return snapshot
+}
+
+// milliseconds converts d to fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,107 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// tracerName identifies the spans started by the backend
+const tracerName = "github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend"
+
+// Trace exporters
+const (
+	// TraceExporterOTLP sends spans over OTLP/gRPC, e.g. to an OpenTelemetry Collector
+	TraceExporterOTLP = "otlp"
+	// TraceExporterJaeger sends spans over OTLP/gRPC to Jaeger, which accepts OTLP
+	// natively on port 4317
+	TraceExporterJaeger = "jaeger"
+)
+
+// TracingConfig configures trace export
+type TracingConfig struct {
+	Exporter    string  // otlp or jaeger
+	Endpoint    string  // collector host:port or URL, tracing is disabled when empty
+	Insecure    bool    // send without TLS, e.g. to a collector sidecar
+	ServiceName string  // service.name resource attribute
+	SampleRatio float64 // fraction of new traces sampled; propagated decisions are kept
+}
+
+// InitTracing installs the global tracer provider and propagator. It returns a function
+// flushing pending spans on shutdown. Without an endpoint tracing stays disabled: spans
+// are no-ops and shutdown does nothing.
+func InitTracing(ctx context.Context, cfg TracingConfig, logger *zap.Logger) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if cfg.Endpoint == "" {
+		logger.Info("Tracing disabled, no OTLP endpoint configured")
+		return func(context.Context) error { return nil }, nil
+	}
+	if cfg.Exporter != TraceExporterOTLP && cfg.Exporter != TraceExporterJaeger {
+		return nil, fmt.Errorf("unsupported trace exporter: %s", cfg.Exporter)
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if strings.Contains(cfg.Endpoint, "://") {
+		// A URL as in the standard OTEL_EXPORTER_OTLP_ENDPOINT; http:// implies no TLS
+		opts = []otlptracegrpc.Option{otlptracegrpc.WithEndpointURL(cfg.Endpoint)}
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	logger.Info("Tracing enabled",
+		zap.String("exporter", cfg.Exporter),
+		zap.String("endpoint", cfg.Endpoint),
+		zap.Float64("sample_ratio", cfg.SampleRatio),
+	)
+
+	return provider.Shutdown, nil
+}
+
+// StartSpan starts a span as a child of the span in ctx, if any. The caller must end it.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan marks the span failed when err is set and ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
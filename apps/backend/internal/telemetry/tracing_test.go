@@ -0,0 +1,60 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+)
+
+// recordSpans installs a tracer provider recording ended spans for the duration of the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestInitTracing(t *testing.T) {
+	t.Run("disabled without an endpoint", func(t *testing.T) {
+		shutdown, err := InitTracing(context.Background(), TracingConfig{Exporter: TraceExporterOTLP}, zap.NewNop())
+		require.NoError(t, err)
+		assert.NoError(t, shutdown(context.Background()))
+
+		_, span := StartSpan(context.Background(), "noop")
+		defer span.End()
+		assert.False(t, span.IsRecording())
+	})
+
+	t.Run("rejects an unknown exporter", func(t *testing.T) {
+		_, err := InitTracing(context.Background(), TracingConfig{Exporter: "zipkin", Endpoint: "localhost:4317"}, zap.NewNop())
+		assert.Error(t, err)
+	})
+}
+
+func TestStartSpan(t *testing.T) {
+	recorder := recordSpans(t)
+
+	ctx, parent := StartSpan(context.Background(), "CheckInService.StartSession")
+	_, child := StartSpan(ctx, "CheckInRepository.CreateSession", attribute.String("db.system", "postgresql"))
+	EndSpan(child, errors.New("connection refused"))
+	EndSpan(parent, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "CheckInRepository.CreateSession", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Contains(t, spans[0].Attributes(), attribute.String("db.system", "postgresql"))
+	assert.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
@@ -2,29 +2,45 @@ package main
 
 import (
 	"context"
+	"embed"
+	"io/fs"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
+	_ "time/tzdata" // medication schedules are read in user time zones; the image has no zoneinfo
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/lib/pq"
 	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/redis/go-redis/v9"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/audit"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/azure"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/config"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/delivery"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/handler"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/metrics"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/middleware"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/pdf"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/repository"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/security"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/service"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/internal/telemetry"
 	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/api"
+	"github.com/vcscsvcscs/Healthcare-challenge-GDE-MIT/apps/backend/pkg/model"
 	"go.uber.org/zap"
 )
 
+// migrationsFS holds the up migrations, so startup checks know the schema version this
+// build expects
+//
+//go:embed migrations/*.up.sql
+var migrationsFS embed.FS
+
 var (
 	logger *zap.Logger
 	pool   *pgxpool.Pool
@@ -55,8 +71,20 @@ func main() {
 		zap.String("port", cfg.Server.Port),
 	)
 
-	// Initialize database connection pool with pgx
-	pool, err = pgxpool.New(context.Background(), cfg.Database.URL)
+	// Initialize distributed tracing; disabled while no OTLP endpoint is configured
+	shutdownTracing, err := telemetry.InitTracing(context.Background(), telemetry.TracingConfig{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		ServiceName: cfg.Tracing.ServiceName,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	}, logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize tracing", zap.Error(err))
+	}
+
+	// Initialize database connection pool with pgx, tracing every query
+	pool, err = newTracedPool(cfg.Database.URL)
 	if err != nil {
 		logger.Fatal("Failed to connect to database", zap.Error(err))
 	}
@@ -67,17 +95,47 @@ func main() {
 		logger.Fatal("Failed to ping database", zap.Error(err))
 	}
 	logger.Info("Successfully connected to database")
+	metrics.Default.RegisterPool("primary", pool)
+
+	// Route heavy reads to the read replica when one is configured
+	var replicaPool *pgxpool.Pool
+	if cfg.Database.ReplicaURL != "" {
+		replicaPool, err = newTracedPool(cfg.Database.ReplicaURL)
+		if err != nil {
+			logger.Fatal("Failed to configure read replica", zap.Error(err))
+		}
+		defer replicaPool.Close()
+		metrics.Default.RegisterPool("replica", replicaPool)
+	}
+	readPools := repository.NewReadPools(pool, replicaPool, cfg.Database.ReplicaMaxLag, logger)
 
 	// Initialize Azure clients
+	azureRetryPolicy := azure.RetryPolicy{
+		MaxAttempts: cfg.Azure.Retry.MaxAttempts,
+		BaseDelay:   cfg.Azure.Retry.BaseDelay,
+		MaxDelay:    cfg.Azure.Retry.MaxDelay,
+		Jitter:      cfg.Azure.Retry.Jitter,
+	}
+
+	azureAuthMode := azure.AuthMode(cfg.Azure.AuthMode)
+	storageAuth := azure.Auth{Mode: azureAuthMode, Key: cfg.Azure.Storage.AccountKey}
+
 	openAIClient, err := azure.NewOpenAIClient(
 		cfg.Azure.OpenAI.Endpoint,
-		cfg.Azure.OpenAI.APIKey,
+		azure.Auth{Mode: azureAuthMode, Key: cfg.Azure.OpenAI.APIKey},
 		cfg.Azure.OpenAI.Deployment,
 		logger,
 	)
 	if err != nil {
 		logger.Fatal("Failed to initialize Azure OpenAI client", zap.Error(err))
 	}
+	openAIClient.SetRetryPolicy(azureRetryPolicy)
+	openAIClient.SetCircuitBreaker(azure.NewCircuitBreaker(
+		"azure-openai",
+		cfg.Azure.OpenAI.BreakerThreshold,
+		cfg.Azure.OpenAI.BreakerCoolDown,
+		logger,
+	))
 
 	speechClient, err := azure.NewSpeechServiceClient(
 		cfg.Azure.Speech.SubscriptionKey,
@@ -87,24 +145,95 @@ func main() {
 	if err != nil {
 		logger.Fatal("Failed to initialize Azure Speech Service client", zap.Error(err))
 	}
+	speechClient.SetMaxAnswerDuration(cfg.CheckIn.MaxAnswerDuration)
+	speechClient.SetRetryPolicy(azureRetryPolicy)
 
 	blobClient, err := azure.NewBlobStorageClient(
 		cfg.Azure.Storage.AccountName,
-		cfg.Azure.Storage.AccountKey,
+		storageAuth,
 		cfg.Azure.Storage.AudioContainer,
 		logger,
 	)
 	if err != nil {
 		logger.Fatal("Failed to initialize Azure Blob Storage client", zap.Error(err))
 	}
+	blobClient.SetRetryPolicy(azureRetryPolicy)
+
+	// Initialize error telemetry export
+	var errorReporter telemetry.ErrorReporter = telemetry.NopReporter{}
+	var telemetryExporter *telemetry.HTTPExporter
+	if cfg.Telemetry.Exporter == "webhook" || cfg.Telemetry.Exporter == "otlp" {
+		telemetryExporter, err = telemetry.NewHTTPExporter(telemetry.ExporterConfig{
+			Format:      telemetry.Format(cfg.Telemetry.Exporter),
+			Endpoint:    cfg.Telemetry.Endpoint,
+			Headers:     cfg.Telemetry.HeaderMap(),
+			ServiceName: cfg.Telemetry.ServiceName,
+			Timeout:     cfg.Telemetry.Timeout,
+		}, logger)
+		if err != nil {
+			logger.Fatal("Failed to initialize telemetry exporter", zap.Error(err))
+		}
+		errorReporter = telemetryExporter
+		logger.Info("Error telemetry export enabled", zap.String("exporter", cfg.Telemetry.Exporter))
+	}
 
 	// Initialize repositories
 	checkInRepo := repository.NewCheckInRepository(pool, logger)
 	medicationRepo := repository.NewMedicationRepository(pool, logger)
 	healthDataRepo := repository.NewHealthDataRepository(pool, logger)
 	dashboardRepo := repository.NewDashboardRepository(pool, logger)
+	alertRepo := repository.NewAlertRepository(pool, logger)
+	anomalyRepo := repository.NewAnomalyRepository(pool, logger)
+	usageRepo := repository.NewUsageRepository(pool, logger)
+	organizationRepo := repository.NewOrganizationRepository(pool, logger)
+	integrationRepo := repository.NewIntegrationRepository(pool, logger)
+	consentRepo := repository.NewConsentRepository(pool, logger)
+	questionFlowRepo := repository.NewQuestionFlowRepository(pool, logger)
+	personalAccessTokenRepo := repository.NewPersonalAccessTokenRepository(pool, logger)
+	webhookRepo := repository.NewWebhookRepository(pool, logger)
+	cycleSuggestionRepo := repository.NewCycleSuggestionRepository(pool, logger)
+	panelRepo := repository.NewPanelRepository(pool, logger)
+	reportJobRepo := repository.NewReportJobRepository(pool, logger)
+	reportScheduleRepo := repository.NewReportScheduleRepository(pool, logger)
+	timelineRepo := repository.NewTimelineRepository(pool, logger)
+	userRepo := repository.NewUserRepository(pool, logger)
+	userSettingsRepo := repository.NewUserSettingsRepository(pool, logger)
+	var redisClient *redis.Client
+	if cfg.Server.RedisURL != "" {
+		redisOptions, err := redis.ParseURL(cfg.Server.RedisURL)
+		if err != nil {
+			logger.Fatal("Failed to parse Redis URL", zap.Error(err))
+		}
+		redisClient = redis.NewClient(redisOptions)
+	}
+	// Keep idempotent responses in the database unless Redis or memory is configured
+	var idempotencyStore middleware.IdempotencyStore
+	switch cfg.Server.IdempotencyStore {
+	case "redis":
+		idempotencyStore = middleware.NewRedisIdempotencyStore(redisClient)
+	case "memory":
+		idempotencyStore = middleware.NewInMemoryIdempotencyStore()
+	default:
+		idempotencyStore = repository.NewIdempotencyKeyRepository(pool, logger)
+	}
+
+	checkInRepo.SetReadPools(readPools)
+	medicationRepo.SetReadPools(readPools)
+	healthDataRepo.SetReadPools(readPools)
+	dashboardRepo.SetReadPools(readPools)
+	usageRepo.SetReadPools(readPools)
+	timelineRepo.SetReadPools(readPools)
 
 	// Initialize services
+	usageService := service.NewUsageService(usageRepo, logger)
+	usageService.SetLimits(service.UsageLimits{
+		MaxCheckIns:        cfg.Usage.SoftMaxCheckIns,
+		MaxAudioBytes:      cfg.Usage.SoftMaxAudioBytes,
+		MaxAttachmentBytes: cfg.Usage.SoftMaxAttachmentBytes,
+		MaxReportBytes:     cfg.Usage.SoftMaxReportBytes,
+	})
+	usageService.AddBlobSource(service.UsageBlobSource{Kind: service.UsageKindAudio, Prefix: "audio/", Lister: blobClient})
+
 	checkInService := service.NewCheckInService(
 		checkInRepo,
 		openAIClient,
@@ -112,9 +241,117 @@ func main() {
 		blobClient,
 		logger,
 	)
+	checkInService.SetFollowUpPolicy(cfg.CheckIn.AdaptiveFollowUps, cfg.CheckIn.MaxFollowUps)
+	if err := azure.ValidateVoice(cfg.Azure.Speech.Voice); err != nil {
+		logger.Fatal("Invalid text-to-speech voice", zap.Error(err))
+	}
+	checkInService.SetVoice(cfg.Azure.Speech.Voice)
+	checkInService.SetTTSCircuitBreaker(azure.NewCircuitBreaker(
+		"azure-speech-tts",
+		cfg.Azure.Speech.TTSBreakerThreshold,
+		cfg.Azure.Speech.TTSBreakerCoolDown,
+		logger,
+	))
+	checkInService.SetExtractionConfidenceThreshold(cfg.CheckIn.ExtractionConfidenceThreshold)
+	if cfg.CheckIn.AudioCacheMaxBytes > 0 {
+		checkInService.SetAudioCache(service.NewAudioCache(cfg.CheckIn.AudioCacheMaxBytes), cfg.CheckIn.AudioCacheVersion)
+	}
+	alertService := service.NewAlertService(alertRepo, openAIClient, logger)
+	checkInService.SetAlertService(alertService)
+
+	// Record readings beyond the anomaly thresholds or the user's baseline
+	anomalyDetector := service.NewAnomalyDetector(anomalyRepo, service.AnomalyThresholds{
+		SystolicLow:       cfg.Anomaly.SystolicLow,
+		PulseHigh:         cfg.Anomaly.PulseHigh,
+		PulseLow:          cfg.Anomaly.PulseLow,
+		PainHigh:          cfg.Anomaly.PainHigh,
+		BaselineReadings:  cfg.Anomaly.BaselineReadings,
+		SystolicDeviation: cfg.Anomaly.SystolicDeviation,
+		PainDeviation:     cfg.Anomaly.PainDeviation,
+	}, logger)
+	checkInService.AddCompletionListener(anomalyDetector)
+	checkInService.SetMedicationSource(medicationRepo)
+	checkInService.SetUsageRecorder(usageService)
+	checkInService.SetErrorReporter(errorReporter)
+	auditLogger := audit.NewLogger(pool, logger)
+	checkInService.SetAuditLogger(auditLogger)
+	consentService := service.NewConsentService(consentRepo, logger)
+	consentService.SetAuditLogger(auditLogger)
+	checkInService.SetConsentChecker(consentService)
+	checkInService.SetQuestionSets(questionFlowRepo)
+	checkInService.SetEventBus(service.NewSessionEventBus(cfg.CheckIn.EventStreamsPerUser, logger))
+	questionSetService := service.NewQuestionSetService(questionFlowRepo, logger)
+	questionSetService.SetAuditLogger(auditLogger)
+	personalAccessTokenService := service.NewPersonalAccessTokenService(personalAccessTokenRepo, logger)
+	personalAccessTokenService.SetAuditLogger(auditLogger)
+	cycleConsistencyService := service.NewCycleConsistencyService(cycleSuggestionRepo, dashboardRepo, healthDataRepo, logger)
+	organizationService := service.NewOrganizationService(organizationRepo, logger)
+	organizationService.SetAuditLogger(auditLogger)
+	organizationService.SetInvitationTTL(cfg.Auth.InvitationTTL)
+	roleCache := middleware.NewRoleCache(organizationService, cfg.Auth.RoleCacheTTL)
+	organizationService.SetRoleCache(roleCache)
+
+	// Deliver completed check-ins to care teams through organization integrations
+	deliveryRegistry := delivery.NewRegistry()
+	deliveryRegistry.Register(delivery.KindConsole, delivery.NewConsoleFactory(logger))
+	deliveryRegistry.Register(delivery.KindWebhook, delivery.NewWebhookFactory(&http.Client{}, cfg.Delivery.AllowInsecureWebhooks))
+	if cfg.Delivery.SMTPHost != "" {
+		deliveryRegistry.Register(delivery.KindSMTP, delivery.NewSMTPFactory(delivery.SMTPConfig{
+			Host:     cfg.Delivery.SMTPHost,
+			Port:     cfg.Delivery.SMTPPort,
+			Username: cfg.Delivery.SMTPUsername,
+			Password: cfg.Delivery.SMTPPassword,
+			From:     cfg.Delivery.SMTPFrom,
+		}))
+	}
+	integrationService := service.NewIntegrationService(integrationRepo, organizationRepo, deliveryRegistry, logger)
+	integrationService.SetAuditLogger(auditLogger)
+	integrationService.SetDeliveryTimeout(cfg.Delivery.Timeout)
+	checkInService.AddCompletionListener(integrationService)
+
+	// Notify the webhooks users registered, such as their EHR, of events about their data
+	webhookService := service.NewWebhookService(webhookRepo, &http.Client{}, cfg.Delivery.AllowInsecureWebhooks, logger)
+	webhookService.SetAuditLogger(auditLogger)
+	webhookService.SetDeliveryTimeout(cfg.Delivery.Timeout)
+	checkInService.AddCompletionListener(webhookService)
+	panelService := service.NewPanelService(panelRepo, organizationRepo, deliveryRegistry, logger)
+	panelService.SetAuditLogger(auditLogger)
+
+	// Confirm user email addresses before anything is emailed to them
+	emailTokenSecret := cfg.Auth.EmailTokenSecret
+	if emailTokenSecret == "" {
+		emailTokenSecret = cfg.Auth.JWTSecret
+	}
+	emailVerificationService := service.NewEmailVerificationService(userRepo, delivery.NewEmailNotifier(deliveryRegistry), service.EmailVerificationConfig{
+		Secret:         []byte(emailTokenSecret),
+		TTL:            cfg.Auth.EmailTokenTTL,
+		ResendInterval: cfg.Auth.EmailResendInterval,
+		MaxPerDay:      cfg.Auth.EmailMaxPerDay,
+		ConfirmURL:     cfg.Auth.EmailConfirmURL,
+	}, logger)
+	emailVerificationService.SetAuditLogger(auditLogger)
+	panelService.SetEmailVerifier(emailVerificationService)
+	timelineService := service.NewTimelineService(timelineRepo, logger)
+	timelineService.SetAuditLogger(auditLogger)
+	userSettingsService := service.NewUserSettingsService(userSettingsRepo, logger)
 	medicationService := service.NewMedicationService(medicationRepo, logger)
+	medicationService.SetLocationSource(userSettingsService)
+	interactionChecker := service.NewInteractionChecker(medicationRepo, logger)
+	if cfg.Medication.InteractionAIFallback {
+		interactionChecker.SetFallback(service.NewOpenAIInteractionFallback(openAIClient, logger))
+	}
+	medicationService.SetInteractionChecker(interactionChecker)
+	medicationService.SetEventDispatcher(webhookService)
+	medicationService.SetAuditLogger(auditLogger)
 	healthDataService := service.NewHealthDataService(healthDataRepo, logger)
+	healthDataService.SetAnomalyDetector(anomalyDetector)
+	healthDataService.SetAlertService(alertService)
 	dashboardService := service.NewDashboardService(dashboardRepo, logger)
+	dashboardService.SetAlertSource(alertRepo)
+	dashboardService.SetAdherenceSource(medicationRepo)
+	dashboardService.SetDoseSource(dashboardRepo)
+	dashboardService.SetBloodPressureSource(healthDataRepo)
+	dashboardService.SetFitnessSource(healthDataRepo)
 
 	// Initialize PDF generator
 	pdfGenerator := pdf.NewPDFGenerator(logger)
@@ -122,13 +359,60 @@ func main() {
 	// Initialize report service with separate blob client for reports
 	reportBlobClient, err := azure.NewBlobStorageClient(
 		cfg.Azure.Storage.AccountName,
-		cfg.Azure.Storage.AccountKey,
+		storageAuth,
 		cfg.Azure.Storage.ReportContainer,
 		logger,
 	)
 	if err != nil {
 		logger.Fatal("Failed to initialize report blob storage client", zap.Error(err))
 	}
+	reportBlobClient.SetRetryPolicy(azureRetryPolicy)
+
+	// Blob client for the audit log archive
+	auditBlobClient, err := azure.NewBlobStorageClient(
+		cfg.Azure.Storage.AccountName,
+		storageAuth,
+		cfg.Azure.Storage.AuditContainer,
+		logger,
+	)
+	if err != nil {
+		logger.Fatal("Failed to initialize audit archive blob storage client", zap.Error(err))
+	}
+	auditBlobClient.SetRetryPolicy(azureRetryPolicy)
+
+	// Storage backends of data residencies: reports and audio of patients whose
+	// organization requires one are written to its account, everything else to the
+	// default account. Artifacts record their backend, so reads and deletions follow them.
+	reportStorage := service.NewStorageRouter(reportBlobClient, logger)
+	audioStorage := service.NewStorageRouter(blobClient, logger)
+	residencyStorages, err := cfg.Azure.Storage.ResidencyStorages()
+	if err != nil {
+		logger.Fatal("Invalid data residency storage configuration", zap.Error(err))
+	}
+	var residencyChecks []service.DiagnosticCheck
+	for _, residency := range residencyStorages {
+		residencyAuth := azure.Auth{Mode: azureAuthMode, Key: residency.AccountKey}
+		residencyReports, err := azure.NewBlobStorageClient(residency.AccountName, residencyAuth, residency.ReportContainer, logger)
+		if err != nil {
+			logger.Fatal("Failed to initialize residency report storage client", zap.Error(err), zap.String("residency", residency.Name))
+		}
+		residencyReports.SetRetryPolicy(azureRetryPolicy)
+		residencyAudio, err := azure.NewBlobStorageClient(residency.AccountName, residencyAuth, residency.AudioContainer, logger)
+		if err != nil {
+			logger.Fatal("Failed to initialize residency audio storage client", zap.Error(err), zap.String("residency", residency.Name))
+		}
+		residencyAudio.SetRetryPolicy(azureRetryPolicy)
+
+		reportStorage.AddBackend(residency.Name, residencyReports)
+		audioStorage.AddBackend(residency.Name, residencyAudio)
+		residencyChecks = append(residencyChecks,
+			service.BlobContainerCheck("blob_"+residency.Name+"_report_container", "AZURE_STORAGE_RESIDENCIES", residencyReports),
+			service.BlobContainerCheck("blob_"+residency.Name+"_audio_container", "AZURE_STORAGE_RESIDENCIES", residencyAudio),
+		)
+	}
+	reportStorage.SetResidencyStore(organizationRepo)
+	audioStorage.SetResidencyStore(organizationRepo)
+	organizationService.SetResidencies(reportStorage.Names())
 
 	reportService := service.NewReportService(
 		dashboardRepo,
@@ -138,33 +422,203 @@ func main() {
 		pdfGenerator,
 		logger,
 	)
+	reportService.SetLimiter(service.NewReportLimiter(
+		cfg.Report.MaxPerWindow,
+		cfg.Report.Window,
+		cfg.Report.DedupeWindow,
+	))
+	reportService.SetUsageRecorder(usageService)
+	reportService.SetErrorReporter(errorReporter)
+	reportService.SetURLSigner(reportBlobClient, cfg.Report.DownloadURLTTL)
+	reportService.SetAuditLogger(auditLogger)
+	reportService.SetUserStore(userRepo)
+	reportService.SetStorageRouter(reportStorage)
+	if key, _ := cfg.Report.PasswordKeyBytes(); key != nil {
+		reportPasswords, err := security.NewEncryptor(key)
+		if err != nil {
+			logger.Fatal("Failed to configure report password encryption", zap.Error(err))
+		}
+		reportService.SetPasswordEncryptor(reportPasswords)
+	} else {
+		logger.Warn("REPORT_PASSWORD_KEY is not set, password protected reports are disabled")
+	}
+	usageService.AddBlobSource(service.UsageBlobSource{Kind: service.UsageKindReports, Prefix: "reports/", Lister: reportBlobClient})
+
+	// Verify the dependencies with cheap real requests; the same checks serve the admin
+	// diagnostics endpoint
+	migrationFiles, err := fs.Sub(migrationsFS, "migrations")
+	if err != nil {
+		logger.Fatal("Failed to read embedded migrations", zap.Error(err))
+	}
+	latestMigration, err := service.LatestMigrationVersion(migrationFiles)
+	if err != nil {
+		logger.Fatal("Failed to read embedded migrations", zap.Error(err))
+	}
+	diagnosticsService := service.NewDiagnosticsService(cfg.Diagnostics.Timeout, logger)
+	diagnosticsService.Register(service.MigrationCheck(repository.NewSchemaRepository(pool, logger), latestMigration))
+	diagnosticsService.Register(service.BlobContainerCheck("blob_audio_container", "", blobClient))
+	diagnosticsService.Register(service.BlobContainerCheck("blob_report_container", "", reportBlobClient))
+	diagnosticsService.Register(service.BlobContainerCheck("blob_audit_container", "AZURE_STORAGE_AUDIT_CONTAINER", auditBlobClient))
+	for _, check := range residencyChecks {
+		diagnosticsService.Register(check)
+	}
+	diagnosticsService.Register(service.SpeechTokenCheck(speechClient))
+	diagnosticsService.Register(service.OpenAIDeploymentCheck(openAIClient))
+
+	// Probe the database and Azure services on /health, the storage account within a second
+	componentHealth := service.NewComponentHealthService(logger)
+	componentHealth.Register(service.ComponentCheck{Name: "database", Critical: true, Timeout: 2 * time.Second, Run: pool.Ping})
+	componentHealth.Register(service.ComponentCheck{Name: "openai", Timeout: 2 * time.Second, Run: openAIClient.PingModels})
+	componentHealth.Register(service.ComponentCheck{Name: "speech", Timeout: 2 * time.Second, Run: speechClient.VerifyToken})
+	componentHealth.Register(service.ComponentCheck{Name: "storage", Timeout: time.Second, Run: blobClient.Ping})
+
+	if cfg.Diagnostics.StartupChecks {
+		report := diagnosticsService.Run(context.Background())
+		report.WriteTable(os.Stderr)
+		if !report.Healthy {
+			logger.Fatal("Startup checks failed, see the remediation hints above (STARTUP_CHECKS=false skips them)")
+		}
+		logger.Info("Startup checks passed")
+	}
+
+	// Start nightly usage reconciliation
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	if cfg.Usage.ReconcileHour >= 0 {
+		go usageService.RunNightlyReconciliation(jobsCtx, cfg.Usage.ReconcileHour)
+	}
+
+	// Keep checking the read replica's health and replication lag
+	go readPools.RunHealthChecks(jobsCtx, cfg.Database.ReplicaHealthInterval)
+
+	// Recover check-in sessions left completing by a previous process
+	go checkInService.RunCompletionRecovery(jobsCtx, service.CompletionRecoveryInterval)
+
+	// Email the clinicians who opted in to a daily digest of their patient panel's findings
+	go panelService.RunDigests(jobsCtx, service.PanelDigestCheckInterval)
+
+	// Start background report generation from the job queue shared by every instance
+	reportService.SetJobStore(reportJobRepo, cfg.Report.QueueSize)
+	reportWorker := service.NewReportWorker(reportService, reportJobRepo, logger)
+	if err := reportWorker.Start(jobsCtx, cfg.Report.Workers); err != nil {
+		logger.Fatal("Failed to start report workers", zap.Error(err))
+	}
+	webhookService.Start(jobsCtx, cfg.Delivery.WebhookWorkers)
+
+	// Queue the weekly and monthly reports users scheduled
+	reportScheduler := service.NewReportScheduler(reportScheduleRepo, reportService, logger)
+	reportScheduler.Start(jobsCtx, service.ReportScheduleCheckInterval)
+
+	// Move audit logs past the hot retention to cold storage, one instance at a time
+	jobLocker := repository.NewAdvisoryLocker(pool, logger)
+	auditArchiver := service.NewAuditArchiver(
+		repository.NewAuditArchiveRepository(pool, logger),
+		auditBlobClient,
+		jobLocker,
+		service.AuditArchivePolicy{
+			HotRetention:     cfg.Audit.HotRetention,
+			ArchiveRetention: cfg.Audit.ArchiveRetention,
+			Buckets:          cfg.Audit.ArchiveBuckets,
+			RestoreTTL:       cfg.Audit.RestoreTTL,
+		},
+		logger,
+	)
+	auditArchiver.SetAuditLogger(auditLogger)
+	if cfg.Audit.HotRetention > 0 {
+		auditArchiver.Start(jobsCtx, cfg.Audit.ArchiveInterval)
+	}
+
+	// Delete conversation audio and archive check-ins past their retention period daily
+	retentionService := service.NewDataRetentionService(
+		repository.NewRetentionRepository(pool, logger),
+		blobClient,
+		jobLocker,
+		service.RetentionPolicy{
+			AudioRetentionDays:   cfg.Retention.AudioRetentionDays,
+			CheckInRetentionDays: cfg.Retention.CheckInRetentionDays,
+		},
+		logger,
+	)
+	retentionService.SetAuditLogger(auditLogger)
+	retentionService.SetStorageRouter(audioStorage)
+	if cfg.Retention.Hour >= 0 {
+		retentionService.Start(jobsCtx, cfg.Retention.Hour)
+	}
 
 	// Initialize GDPR service
-	auditLogger := audit.NewLogger(pool, logger)
 	gdprService := service.NewGDPRService(
 		pool,
 		auditLogger,
 		logger,
 	)
+	gdprService.SetReadPools(readPools)
+	gdprService.SetConsentService(consentService)
+	gdprService.SetArtifactStorage(reportStorage, audioStorage)
+
+	exportService := service.NewExportService(healthDataRepo, medicationRepo, logger)
 
 	// Initialize handlers
 	checkInHandler := handler.NewCheckInHandler(checkInService, logger)
+	checkInHandler.SetLegacyMedicationTaken(cfg.CheckIn.LegacyMedicationTaken)
+	checkInHandler.SetDebugTiming(cfg.CheckIn.DebugTiming)
+	checkInHandler.SetSlowResponseThreshold(cfg.CheckIn.SlowResponseThreshold)
+	checkInHandler.SetCaregiverChecker(organizationRepo)
+	checkInHandler.SetEventKeepAlive(cfg.CheckIn.EventKeepAlive)
 	medicationHandler := handler.NewMedicationHandler(medicationService, logger)
 	healthHandler := handler.NewHealthHandler(healthDataService, logger)
 	dashboardHandler := handler.NewDashboardHandler(dashboardService, logger)
 	reportHandler := handler.NewReportHandler(reportService, logger)
 	gdprHandler := handler.NewGDPRHandler(gdprService, logger)
+	exportHandler := handler.NewExportHandler(exportService, logger)
+	alertHandler := handler.NewAlertHandler(alertService, logger)
+	anomalyHandler := handler.NewAnomalyHandler(anomalyDetector, logger)
+	usageHandler := handler.NewUsageHandler(usageService, logger)
+	diagnosticsHandler := handler.NewDiagnosticsHandler(diagnosticsService, logger)
+	organizationHandler := handler.NewOrganizationHandler(organizationService, logger)
+	integrationHandler := handler.NewIntegrationHandler(integrationService, logger)
+	consentHandler := handler.NewConsentHandler(consentService, logger)
+	panelHandler := handler.NewPanelHandler(panelService, logger)
+	auditHandler := handler.NewAuditHandler(auditLogger, logger)
+	auditHandler.SetArchive(auditArchiver)
+	timelineHandler := handler.NewTimelineHandler(timelineService, logger)
+	questionSetHandler := handler.NewQuestionSetHandler(questionSetService, logger)
+	personalAccessTokenHandler := handler.NewPersonalAccessTokenHandler(personalAccessTokenService, logger)
+	userHandler := handler.NewUserHandler(emailVerificationService, logger)
+	webhookHandler := handler.NewWebhookHandler(webhookService, logger)
+	reportScheduleHandler := handler.NewReportScheduleHandler(reportScheduler, logger)
+	userSettingsHandler := handler.NewUserSettingsHandler(userSettingsService, logger)
+	cycleSuggestionHandler := handler.NewCycleSuggestionHandler(cycleConsistencyService, logger)
 
 	// Create a unified handler that implements the ServerInterface
 	apiHandler := &APIHandler{
-		checkIn:    checkInHandler,
-		medication: medicationHandler,
-		health:     healthHandler,
-		dashboard:  dashboardHandler,
-		report:     reportHandler,
-		gdpr:       gdprHandler,
-		pool:       pool,
-		logger:     logger,
+		checkIn:             checkInHandler,
+		medication:          medicationHandler,
+		health:              healthHandler,
+		dashboard:           dashboardHandler,
+		report:              reportHandler,
+		gdpr:                gdprHandler,
+		export:              exportHandler,
+		alert:               alertHandler,
+		usage:               usageHandler,
+		organization:        organizationHandler,
+		integration:         integrationHandler,
+		consent:             consentHandler,
+		panel:               panelHandler,
+		questionSet:         questionSetHandler,
+		personalAccessToken: personalAccessTokenHandler,
+		audit:               auditHandler,
+		cycleSuggestion:     cycleSuggestionHandler,
+		timeline:            timelineHandler,
+		webhook:             webhookHandler,
+		reportSchedule:      reportScheduleHandler,
+		diagnostics:         diagnosticsHandler,
+		userSettings:        userSettingsHandler,
+		anomaly:             anomalyHandler,
+		user:                userHandler,
+		checkInSvc:          checkInService,
+		openAI:              openAIClient,
+		components:          componentHealth,
+		logger:              logger,
 	}
 
 	// Set Gin mode
@@ -178,31 +632,183 @@ func main() {
 	// Add recovery middleware (must be first)
 	r.Use(middleware.RecoveryMiddleware(logger))
 
+	// Add request ID, tracing, logging and metrics before authentication and rate
+	// limiting, so rejected requests are logged, traced and counted too
+	r.Use(middleware.RequestIDMiddleware())
+	r.Use(middleware.TracingMiddleware())
+	r.Use(middleware.RequestLoggingMiddleware(logger))
+	r.Use(middleware.MetricsMiddleware(metrics.Default))
+
 	// Add CORS middleware
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"}, // Configure appropriately for production
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID"},
-		ExposeHeaders:    []string{"Content-Length", "X-Request-ID", "X-Trace-ID"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID", "Cache-Control", "If-None-Match", middleware.IdempotencyKeyHeader, middleware.LegacyIdempotencyKeyHeader},
+		ExposeHeaders:    []string{"Content-Length", "ETag", "X-Request-ID", "X-Trace-ID", "X-Report-Sections", middleware.UsageWarningHeader, middleware.IdempotentReplayedHeader},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
 
-	// Add request ID middleware
-	r.Use(middleware.RequestIDMiddleware())
+	// Add authentication middleware; /health, email confirmation links and report
+	// verification stay public
+	if cfg.Auth.Enabled {
+		requireAuth := middleware.JWTAuth(cfg.Auth.Issuer, cfg.Auth.Audience, cfg.Auth.JWKSURL, logger)
+		optionalAuth := middleware.OptionalJWTAuth(cfg.Auth.Issuer, cfg.Auth.Audience, cfg.Auth.JWKSURL, logger)
+		if cfg.Auth.JWTSecret != "" {
+			requireAuth = middleware.AuthMiddleware(logger, cfg.Auth.JWTSecret)
+			optionalAuth = middleware.OptionalAuthMiddleware(logger, cfg.Auth.JWTSecret)
+		}
+		// Personal access tokens ("pat_...") authenticate alongside JWTs
+		requireAuth = middleware.PersonalAccessTokenAuth(personalAccessTokenService, requireAuth, logger)
+		optionalAuth = middleware.PersonalAccessTokenAuth(personalAccessTokenService, optionalAuth, logger)
+		r.Use(middleware.AuthGate(requireAuth, optionalAuth, handler.ConfirmEmailPath, handler.ReportVerifyPath))
+	}
 
-	// Add tracing middleware
-	r.Use(middleware.TracingMiddleware())
+	// Limit personal access tokens to the read-only routes of their scopes
+	r.Use(middleware.RequireTokenScopes(
+		middleware.TokenScopeRoute{Method: http.MethodGet, Path: "/api/v1/health/*", Scope: model.TokenScopeHealthRead},
+		middleware.TokenScopeRoute{Method: http.MethodGet, Path: "/api/v1/dashboard/*", Scope: model.TokenScopeHealthRead},
+		middleware.TokenScopeRoute{Method: http.MethodGet, Path: "/api/v1/alerts", Scope: model.TokenScopeHealthRead},
+		middleware.TokenScopeRoute{Method: http.MethodGet, Path: "/api/v1/export/*", Scope: model.TokenScopeExportRead},
+		middleware.TokenScopeRoute{Method: http.MethodGet, Path: "/api/v1/reports", Scope: model.TokenScopeReportsRead},
+		middleware.TokenScopeRoute{Method: http.MethodGet, Path: "/api/v1/reports/*", Scope: model.TokenScopeReportsRead},
+	))
 
-	// Add request logging middleware
-	r.Use(middleware.RequestLoggingMiddleware(logger))
+	// Load the authenticated user's roles for role checks
+	r.Use(middleware.LoadRoles(roleCache, cfg.Auth.AdminUserIDs, logger))
+
+	// Serve every read of a "Cache-Control: no-cache" request from the primary, so a
+	// client that just wrote data sees it even while the read replica lags behind
+	r.Use(func(c *gin.Context) {
+		if strings.Contains(c.GetHeader("Cache-Control"), "no-cache") {
+			c.Request = c.Request.WithContext(repository.WithPrimary(c.Request.Context()))
+		}
+		c.Next()
+	})
+
+	// Add rate limiting middleware, sharing sliding windows and token buckets through Redis
+	// when configured
+	var rateLimitStore middleware.RateLimitStore = middleware.NewInMemoryRateLimitStore()
+	var tokenBucketStore middleware.TokenBucketStore = middleware.NewInMemoryTokenBucketStore()
+	if redisClient != nil {
+		rateLimitStore = middleware.NewRedisRateLimitStore(redisClient)
+		tokenBucketStore = middleware.NewRedisTokenBucketStore(redisClient)
+	}
+	r.Use(middleware.RateLimiter(cfg.RateLimit.GlobalRPS, cfg.RateLimit.PerUserRPS, rateLimitStore))
+
+	// Add per-route limits for the endpoints that call Azure Speech and OpenAI
+	r.Use(middleware.RateLimitMiddleware(tokenBucketStore,
+		middleware.RouteRateLimit{
+			Name:      "audio-stream",
+			Method:    http.MethodPost,
+			Path:      "/api/v1/checkin/audio-stream",
+			PerMinute: cfg.RateLimit.AudioStreamPerMinute,
+		},
+		middleware.RouteRateLimit{
+			Name:      "checkin",
+			Method:    http.MethodPost,
+			Path:      "/api/v1/checkin/*",
+			PerMinute: cfg.RateLimit.CheckInPerMinute,
+		},
+		middleware.RouteRateLimit{
+			Name:      "report-generate",
+			Method:    http.MethodPost,
+			Path:      "/api/v1/reports/generate",
+			PerMinute: cfg.RateLimit.ReportPerMinute,
+		},
+		middleware.RouteRateLimit{
+			Name:      "report-verify",
+			Method:    http.MethodGet,
+			Path:      handler.ReportVerifyPath,
+			PerMinute: cfg.RateLimit.ReportVerifyPerMinute,
+		},
+	))
+
+	// Add soft usage limit warnings on writes
+	r.Use(middleware.UsageWarning(usageService, logger))
+
+	// Normalize the deprecated medication_taken value "partial" to "some" on the routes
+	// accepting medication_taken
+	r.Use(middleware.MedicationTakenCompat(cfg.CheckIn.LegacyMedicationTaken, "/api/v1/checkin/:id"))
 
 	// Add error logging middleware
-	r.Use(middleware.ErrorLoggingMiddleware(logger))
+	r.Use(middleware.ErrorLoggingMiddlewareWithReporter(logger, errorReporter))
 
 	// Add slow query logging middleware
 	r.Use(middleware.SlowQueryLoggingMiddleware(logger, 1*time.Second))
 
+	// Require an administrator on the admin routes
+	requireAdmin := middleware.RequireAdmin(cfg.Auth.AdminUserIDs)
+	adminRoutes := map[string]bool{
+		"/api/v1/admin/usage":                         true,
+		"/api/v1/admin/extraction-quality":            true,
+		"/api/v1/admin/latency":                       true,
+		"/api/v1/admin/organizations":                 true,
+		"/api/v1/admin/question-sets":                 true,
+		"/api/v1/users/:id/question-set":              true,
+		"/api/v1/audit/logs":                          true,
+		"/api/v1/admin/audit-logs":                    true,
+		"/api/v1/admin/users/:id/timeline":            true,
+		"/api/v1/admin/audit/archives/:month/restore": true,
+		"/api/v1/admin/diagnostics":                   true,
+		"/api/v1/admin/organizations/:id/residency":   true,
+	}
+	r.Use(func(c *gin.Context) {
+		if adminRoutes[c.FullPath()] {
+			requireAdmin(c)
+			return
+		}
+		c.Next()
+	})
+
+	// Require the org_admin role of the organization in the path on organization admin routes
+	requireOrgAdmin := middleware.RequireOrgRole("id", model.RoleOrgAdmin)
+	orgAdminRoutes := map[string]bool{
+		"/api/v1/orgs/:id/invitations":                                 true,
+		"/api/v1/orgs/:id/members":                                     true,
+		"/api/v1/orgs/:id/members/:user_id/roles":                      true,
+		"/api/v1/orgs/:id/members/:user_id/roles/:role":                true,
+		"/api/v1/orgs/:id/integrations":                                true,
+		"/api/v1/orgs/:id/integrations/:integration_id/test":           true,
+		"/api/v1/orgs/:id/integrations/:integration_id/deliveries":     true,
+		"/api/v1/orgs/:id/panel-assignments":                           true,
+		"/api/v1/orgs/:id/panel-assignments/:clinician_id/:patient_id": true,
+	}
+	r.Use(func(c *gin.Context) {
+		if orgAdminRoutes[c.FullPath()] {
+			requireOrgAdmin(c)
+			return
+		}
+		c.Next()
+	})
+
+	// Replay the response of create requests retried with the same X-Idempotency-Key
+	idempotentRoutes := map[string]bool{
+		"/api/v1/checkin/start":                    true,
+		"/api/v1/health/blood-pressure":            true,
+		"/api/v1/health/fitness-sync":              true,
+		"/api/v1/health/medications":               true,
+		"/api/v1/health/medications/:id/adherence": true,
+		"/api/v1/health/menstruation":              true,
+		"/api/v1/reports/generate":                 true,
+		"/api/v1/gdpr/consent":                     true,
+		"/api/v1/admin/organizations":              true,
+		"/api/v1/orgs/:id/invitations":             true,
+		"/api/v1/orgs/:id/integrations":            true,
+		"/api/v1/orgs/:id/panel-assignments":       true,
+		"/api/v1/admin/question-sets":              true,
+		"/api/v1/users/:id/tokens":                 true,
+		"/api/v1/webhooks":                         true,
+	}
+	idempotency := middleware.Idempotency(idempotencyStore, cfg.Server.IdempotencyTTL, logger)
+	r.Use(func(c *gin.Context) {
+		if c.Request.Method == http.MethodPost && idempotentRoutes[c.FullPath()] {
+			idempotency(c)
+			return
+		}
+		c.Next()
+	})
+
 	// Register generated API handlers
 	api.RegisterHandlers(r, apiHandler)
 
@@ -226,6 +832,7 @@ func main() {
 	<-quit
 
 	logger.Info("Shutting down server...")
+	stopJobs()
 
 	// Create shutdown context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
@@ -236,22 +843,82 @@ func main() {
 		logger.Error("Server forced to shutdown", zap.Error(err))
 	}
 
+	// Let a scheduled report being queued finish, then reports being generated; queued
+	// ones stay queued for the next start
+	reportScheduler.Wait()
+	reportWorker.Wait()
+
+	// Let care team deliveries of completed check-ins finish
+	integrationService.WaitForDeliveries()
+
+	// Let webhook deliveries being sent finish; retries still pending are abandoned
+	webhookService.Wait()
+
+	// Let audit archiving and retention runs stop; both resume where they left off next run
+	auditArchiver.Wait()
+	retentionService.Wait()
+
+	// Flush pending error events
+	if telemetryExporter != nil {
+		if err := telemetryExporter.Close(ctx); err != nil {
+			logger.Warn("Failed to flush error telemetry", zap.Error(err))
+		}
+	}
+
+	// Export the spans still buffered
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Warn("Failed to flush traces", zap.Error(err))
+	}
+
 	// Close database connections
 	pool.Close()
+	if redisClient != nil {
+		redisClient.Close()
+	}
 
 	logger.Info("Server exited")
 }
 
+// newTracedPool creates a connection pool running every query in a span
+func newTracedPool(url string) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		return nil, err
+	}
+	poolConfig.ConnConfig.Tracer = repository.QueryTracer{}
+	return pgxpool.NewWithConfig(context.Background(), poolConfig)
+}
+
 // APIHandler implements the generated ServerInterface by delegating to individual handlers
 type APIHandler struct {
-	checkIn    *handler.CheckInHandler
-	medication *handler.MedicationHandler
-	health     *handler.HealthHandler
-	dashboard  *handler.DashboardHandler
-	report     *handler.ReportHandler
-	gdpr       *handler.GDPRHandler
-	pool       *pgxpool.Pool
-	logger     *zap.Logger
+	checkIn             *handler.CheckInHandler
+	medication          *handler.MedicationHandler
+	health              *handler.HealthHandler
+	dashboard           *handler.DashboardHandler
+	report              *handler.ReportHandler
+	gdpr                *handler.GDPRHandler
+	export              *handler.ExportHandler
+	alert               *handler.AlertHandler
+	usage               *handler.UsageHandler
+	organization        *handler.OrganizationHandler
+	integration         *handler.IntegrationHandler
+	consent             *handler.ConsentHandler
+	panel               *handler.PanelHandler
+	questionSet         *handler.QuestionSetHandler
+	personalAccessToken *handler.PersonalAccessTokenHandler
+	audit               *handler.AuditHandler
+	cycleSuggestion     *handler.CycleSuggestionHandler
+	timeline            *handler.TimelineHandler
+	webhook             *handler.WebhookHandler
+	reportSchedule      *handler.ReportScheduleHandler
+	diagnostics         *handler.DiagnosticsHandler
+	userSettings        *handler.UserSettingsHandler
+	anomaly             *handler.AnomalyHandler
+	user                *handler.UserHandler
+	checkInSvc          *service.CheckInService
+	openAI              *azure.OpenAIClient
+	components          *service.ComponentHealthService
+	logger              *zap.Logger
 }
 
 // Check-in endpoints
@@ -279,11 +946,79 @@ func (h *APIHandler) PostApiV1CheckinComplete(c *gin.Context) {
 	h.checkIn.PostApiV1CheckinComplete(c)
 }
 
+func (h *APIHandler) GetApiV1CheckinAudioWs(c *gin.Context, params api.GetApiV1CheckinAudioWsParams) {
+	h.checkIn.CheckinAudioWebSocket(c)
+}
+
+func (h *APIHandler) PostApiV1CheckinPause(c *gin.Context) {
+	h.checkIn.PostCheckinPause(c)
+}
+
+func (h *APIHandler) PostApiV1CheckinResume(c *gin.Context) {
+	h.checkIn.PostCheckinResume(c)
+}
+
+func (h *APIHandler) PostApiV1CheckinReExtract(c *gin.Context) {
+	h.checkIn.PostCheckinReExtract(c)
+}
+
+func (h *APIHandler) GetApiV1AdminExtractionQuality(c *gin.Context, params api.GetApiV1AdminExtractionQualityParams) {
+	h.checkIn.GetExtractionQuality(c)
+}
+
+func (h *APIHandler) GetApiV1AdminLatency(c *gin.Context) {
+	h.checkIn.GetLatencyHistograms(c)
+}
+
+func (h *APIHandler) PostApiV1AdminQuestionSets(c *gin.Context) {
+	h.questionSet.CreateQuestionSet(c)
+}
+
+func (h *APIHandler) PutApiV1UsersIdQuestionSet(c *gin.Context, id openapi_types.UUID) {
+	h.questionSet.AssignQuestionSet(c)
+}
+
+func (h *APIHandler) GetApiV1AuditLogs(c *gin.Context, params api.GetApiV1AuditLogsParams) {
+	h.audit.ListAuditLogs(c)
+}
+
+func (h *APIHandler) GetApiV1AdminAuditLogs(c *gin.Context, params api.GetApiV1AdminAuditLogsParams) {
+	h.audit.ListAuditLogs(c)
+}
+
+func (h *APIHandler) GetApiV1AdminUsersIdTimeline(c *gin.Context, id openapi_types.UUID, params api.GetApiV1AdminUsersIdTimelineParams) {
+	h.timeline.GetUserTimeline(c)
+}
+
+func (h *APIHandler) PostApiV1AdminAuditArchivesMonthRestore(c *gin.Context, month string) {
+	h.audit.RestoreAuditArchive(c)
+}
+
+func (h *APIHandler) GetApiV1AdminDiagnostics(c *gin.Context) {
+	h.diagnostics.GetDiagnostics(c)
+}
+
+func (h *APIHandler) GetApiV1CheckinSessionIdEvents(c *gin.Context, sessionId openapi_types.UUID) {
+	h.checkIn.GetCheckinEvents(c)
+}
+
+func (h *APIHandler) PutApiV1AdminOrganizationsIdResidency(c *gin.Context, id openapi_types.UUID) {
+	h.organization.PutDataResidency(c)
+}
+
+func (h *APIHandler) PutApiV1CheckinId(c *gin.Context, id openapi_types.UUID) {
+	h.checkIn.PutCheckin(c)
+}
+
 // Dashboard endpoints
 func (h *APIHandler) GetApiV1DashboardSummary(c *gin.Context, params api.GetApiV1DashboardSummaryParams) {
 	h.dashboard.GetApiV1DashboardSummary(c, params)
 }
 
+func (h *APIHandler) GetApiV1DashboardExport(c *gin.Context, params api.GetApiV1DashboardExportParams) {
+	h.dashboard.GetDashboardExport(c)
+}
+
 // Health data endpoints
 func (h *APIHandler) GetApiV1HealthBloodPressure(c *gin.Context, params api.GetApiV1HealthBloodPressureParams) {
 	h.health.GetApiV1HealthBloodPressure(c, params)
@@ -293,6 +1028,10 @@ func (h *APIHandler) PostApiV1HealthBloodPressure(c *gin.Context) {
 	h.health.PostApiV1HealthBloodPressure(c)
 }
 
+func (h *APIHandler) GetApiV1HealthFitness(c *gin.Context, params api.GetApiV1HealthFitnessParams) {
+	h.health.GetFitnessData(c)
+}
+
 func (h *APIHandler) PostApiV1HealthFitnessSync(c *gin.Context) {
 	h.health.PostApiV1HealthFitnessSync(c)
 }
@@ -313,6 +1052,38 @@ func (h *APIHandler) PutApiV1HealthMedicationsId(c *gin.Context, id openapi_type
 	h.medication.PutApiV1HealthMedicationsId(c, id)
 }
 
+func (h *APIHandler) GetApiV1HealthMedicationsIdSchedule(c *gin.Context, id openapi_types.UUID, params api.GetApiV1HealthMedicationsIdScheduleParams) {
+	h.medication.GetMedicationSchedule(c)
+}
+
+func (h *APIHandler) PutApiV1HealthMedicationsIdSchedule(c *gin.Context, id openapi_types.UUID) {
+	h.medication.PutMedicationSchedule(c)
+}
+
+func (h *APIHandler) PostApiV1HealthMedicationsIdAdherence(c *gin.Context, id openapi_types.UUID) {
+	h.medication.PostMedicationAdherence(c)
+}
+
+func (h *APIHandler) GetApiV1HealthMedicationsIdAdherence(c *gin.Context, id openapi_types.UUID, params api.GetApiV1HealthMedicationsIdAdherenceParams) {
+	h.medication.GetMedicationAdherence(c)
+}
+
+func (h *APIHandler) GetApiV1HealthMedicationsDue(c *gin.Context, params api.GetApiV1HealthMedicationsDueParams) {
+	h.medication.GetDueMedications(c)
+}
+
+func (h *APIHandler) PostApiV1HealthMedicationsIdDeactivate(c *gin.Context, id openapi_types.UUID) {
+	h.medication.PostMedicationDeactivate(c)
+}
+
+func (h *APIHandler) PostApiV1HealthMedicationsIdReactivate(c *gin.Context, id openapi_types.UUID) {
+	h.medication.PostMedicationReactivate(c)
+}
+
+func (h *APIHandler) PostApiV1HealthMedicationsIdRestore(c *gin.Context, id openapi_types.UUID) {
+	h.medication.PostMedicationRestore(c)
+}
+
 func (h *APIHandler) GetApiV1HealthMenstruation(c *gin.Context, params api.GetApiV1HealthMenstruationParams) {
 	h.health.GetApiV1HealthMenstruation(c, params)
 }
@@ -321,6 +1092,38 @@ func (h *APIHandler) PostApiV1HealthMenstruation(c *gin.Context) {
 	h.health.PostApiV1HealthMenstruation(c)
 }
 
+func (h *APIHandler) PatchApiV1HealthMenstruationId(c *gin.Context, id openapi_types.UUID) {
+	h.health.PatchMenstruation(c)
+}
+
+func (h *APIHandler) GetApiV1HealthMenstruationStats(c *gin.Context, params api.GetApiV1HealthMenstruationStatsParams) {
+	h.health.GetMenstruationStats(c)
+}
+
+func (h *APIHandler) GetApiV1HealthBloodPressureChart(c *gin.Context, params api.GetApiV1HealthBloodPressureChartParams) {
+	h.health.GetBloodPressureChart(c)
+}
+
+func (h *APIHandler) GetApiV1HealthMenstruationPrediction(c *gin.Context, params api.GetApiV1HealthMenstruationPredictionParams) {
+	h.health.GetMenstruationPrediction(c)
+}
+
+func (h *APIHandler) GetApiV1UsersIdCycleSuggestions(c *gin.Context, id openapi_types.UUID) {
+	h.cycleSuggestion.ListCycleSuggestions(c)
+}
+
+func (h *APIHandler) PostApiV1UsersIdCycleSuggestionsSuggestionIdAccept(c *gin.Context, id openapi_types.UUID, suggestionId openapi_types.UUID) {
+	h.cycleSuggestion.AcceptCycleSuggestion(c)
+}
+
+func (h *APIHandler) PostApiV1UsersIdCycleSuggestionsSuggestionIdDismiss(c *gin.Context, id openapi_types.UUID, suggestionId openapi_types.UUID) {
+	h.cycleSuggestion.DismissCycleSuggestion(c)
+}
+
+func (h *APIHandler) GetApiV1HealthAnomalies(c *gin.Context, params api.GetApiV1HealthAnomaliesParams) {
+	h.anomaly.GetAnomalies(c)
+}
+
 // Report endpoints
 func (h *APIHandler) PostApiV1ReportsGenerate(c *gin.Context) {
 	h.report.PostApiV1ReportsGenerate(c)
@@ -330,27 +1133,257 @@ func (h *APIHandler) GetApiV1ReportsId(c *gin.Context, id openapi_types.UUID) {
 	h.report.GetApiV1ReportsId(c, id)
 }
 
-// GetHealth implements the health check endpoint
+func (h *APIHandler) GetApiV1ReportsJobsJobId(c *gin.Context, jobId openapi_types.UUID) {
+	h.report.GetReportJob(c)
+}
+
+func (h *APIHandler) GetApiV1ReportsIdUrl(c *gin.Context, id openapi_types.UUID) {
+	h.report.GetReportURL(c)
+}
+
+func (h *APIHandler) GetApiV1ReportsVerify(c *gin.Context, params api.GetApiV1ReportsVerifyParams) {
+	h.report.GetReportVerification(c)
+}
+
+func (h *APIHandler) GetApiV1ReportsIdStatus(c *gin.Context, id openapi_types.UUID) {
+	h.report.GetReportStatus(c)
+}
+
+// GDPR endpoints
+func (h *APIHandler) PostApiV1GdprAnonymize(c *gin.Context) {
+	h.gdpr.AnonymizeUserData(c)
+}
+
+func (h *APIHandler) GetApiV1Reports(c *gin.Context, params api.GetApiV1ReportsParams) {
+	h.report.ListReports(c)
+}
+
+func (h *APIHandler) DeleteApiV1ReportsId(c *gin.Context, id openapi_types.UUID) {
+	h.report.DeleteReport(c)
+}
+
+func (h *APIHandler) PostApiV1Consents(c *gin.Context) {
+	h.consent.SetConsent(c)
+}
+
+func (h *APIHandler) GetApiV1Consents(c *gin.Context, params api.GetApiV1ConsentsParams) {
+	h.consent.ListConsents(c)
+}
+
+// Panel endpoints
+func (h *APIHandler) PostApiV1OrgsIdPanelAssignments(c *gin.Context, id openapi_types.UUID) {
+	h.panel.AssignPatient(c)
+}
+
+func (h *APIHandler) GetApiV1OrgsIdPanelAssignments(c *gin.Context, id openapi_types.UUID, params api.GetApiV1OrgsIdPanelAssignmentsParams) {
+	h.panel.ListAssignments(c)
+}
+
+func (h *APIHandler) DeleteApiV1OrgsIdPanelAssignmentsClinicianIdPatientId(c *gin.Context, id openapi_types.UUID, clinicianId openapi_types.UUID, patientId openapi_types.UUID) {
+	h.panel.UnassignPatient(c)
+}
+
+func (h *APIHandler) GetApiV1AdminPanelFindings(c *gin.Context, params api.GetApiV1AdminPanelFindingsParams) {
+	h.panel.ListFindings(c)
+}
+
+func (h *APIHandler) PutApiV1AdminPanelDigest(c *gin.Context, params api.PutApiV1AdminPanelDigestParams) {
+	h.panel.SubscribeDigest(c)
+}
+
+func (h *APIHandler) DeleteApiV1AdminPanelDigest(c *gin.Context, params api.DeleteApiV1AdminPanelDigestParams) {
+	h.panel.UnsubscribeDigest(c)
+}
+
+// Personal access token endpoints
+func (h *APIHandler) PostApiV1UsersIdTokens(c *gin.Context, id openapi_types.UUID) {
+	h.personalAccessToken.CreateToken(c)
+}
+
+func (h *APIHandler) GetApiV1UsersIdTokens(c *gin.Context, id openapi_types.UUID) {
+	h.personalAccessToken.ListTokens(c)
+}
+
+func (h *APIHandler) DeleteApiV1UsersIdTokensTokenId(c *gin.Context, id openapi_types.UUID, tokenId openapi_types.UUID) {
+	h.personalAccessToken.RevokeToken(c)
+}
+
+// Webhook endpoints
+func (h *APIHandler) PostApiV1Webhooks(c *gin.Context) {
+	h.webhook.CreateWebhook(c)
+}
+
+func (h *APIHandler) DeleteApiV1WebhooksId(c *gin.Context, id openapi_types.UUID) {
+	h.webhook.DeleteWebhook(c)
+}
+
+func (h *APIHandler) PostApiV1GdprConsent(c *gin.Context) {
+	h.gdpr.RecordConsent(c)
+}
+
+func (h *APIHandler) GetApiV1GdprConsent(c *gin.Context, params api.GetApiV1GdprConsentParams) {
+	h.gdpr.GetConsentHistory(c)
+}
+
+func (h *APIHandler) PutApiV1UsersIdReportSchedule(c *gin.Context, id openapi_types.UUID) {
+	h.reportSchedule.PutReportSchedule(c)
+}
+
+func (h *APIHandler) GetApiV1UsersIdReportSchedule(c *gin.Context, id openapi_types.UUID) {
+	h.reportSchedule.GetReportSchedule(c)
+}
+
+func (h *APIHandler) PutApiV1UsersIdSettings(c *gin.Context, id openapi_types.UUID) {
+	h.userSettings.PutUserSettings(c)
+}
+
+func (h *APIHandler) GetApiV1UsersIdSettings(c *gin.Context, id openapi_types.UUID) {
+	h.userSettings.GetUserSettings(c)
+}
+
+func (h *APIHandler) GetApiV1UsersIdProfile(c *gin.Context, id openapi_types.UUID) {
+	h.user.GetUserProfile(c)
+}
+
+func (h *APIHandler) PutApiV1UsersIdEmail(c *gin.Context, id openapi_types.UUID) {
+	h.user.PutUserEmail(c)
+}
+
+func (h *APIHandler) PostApiV1UsersIdEmailConfirmation(c *gin.Context, id openapi_types.UUID) {
+	h.user.ResendEmailConfirmation(c)
+}
+
+func (h *APIHandler) GetApiV1UsersConfirmEmail(c *gin.Context, params api.GetApiV1UsersConfirmEmailParams) {
+	h.user.ConfirmEmail(c)
+}
+
+// Export endpoints
+func (h *APIHandler) GetApiV1ExportHealth(c *gin.Context, params api.GetApiV1ExportHealthParams) {
+	h.export.GetHealthExport(c)
+}
+
+func (h *APIHandler) GetApiV1ExportFhir(c *gin.Context, params api.GetApiV1ExportFhirParams) {
+	h.export.GetFHIRExport(c)
+}
+
+// Alert endpoints
+func (h *APIHandler) GetApiV1Alerts(c *gin.Context, params api.GetApiV1AlertsParams) {
+	h.alert.GetAlerts(c)
+}
+
+func (h *APIHandler) PostApiV1AlertsIdAcknowledge(c *gin.Context, id openapi_types.UUID) {
+	h.alert.AcknowledgeAlert(c)
+}
+
+// Usage endpoints
+func (h *APIHandler) GetApiV1UsersIdUsage(c *gin.Context, id openapi_types.UUID) {
+	h.usage.GetUserUsage(c)
+}
+
+func (h *APIHandler) GetApiV1AdminUsage(c *gin.Context) {
+	h.usage.GetUsageAggregate(c)
+}
+
+// GetMetrics implements the Prometheus metrics endpoint
+func (h *APIHandler) GetMetrics(c *gin.Context) {
+	metrics.Default.Handler().ServeHTTP(c.Writer, c.Request)
+}
+
+// Organization endpoints
+func (h *APIHandler) PostApiV1AdminOrganizations(c *gin.Context) {
+	h.organization.CreateOrganization(c)
+}
+
+func (h *APIHandler) PostApiV1OrgsIdInvitations(c *gin.Context, id openapi_types.UUID) {
+	h.organization.InviteMember(c)
+}
+
+func (h *APIHandler) GetApiV1OrgsIdMembers(c *gin.Context, id openapi_types.UUID) {
+	h.organization.ListMembers(c)
+}
+
+func (h *APIHandler) PostApiV1OrgsIdMembersUserIdRoles(c *gin.Context, id openapi_types.UUID, userId openapi_types.UUID) {
+	h.organization.AssignRole(c)
+}
+
+func (h *APIHandler) DeleteApiV1OrgsIdMembersUserIdRolesRole(c *gin.Context, id openapi_types.UUID, userId openapi_types.UUID, role api.Role) {
+	h.organization.RevokeRole(c)
+}
+
+func (h *APIHandler) PostApiV1InvitationsAccept(c *gin.Context) {
+	h.organization.AcceptInvitation(c)
+}
+
+// Integration endpoints
+func (h *APIHandler) PostApiV1OrgsIdIntegrations(c *gin.Context, id openapi_types.UUID) {
+	h.integration.CreateIntegration(c)
+}
+
+func (h *APIHandler) GetApiV1OrgsIdIntegrations(c *gin.Context, id openapi_types.UUID) {
+	h.integration.ListIntegrations(c)
+}
+
+func (h *APIHandler) PostApiV1OrgsIdIntegrationsIntegrationIdTest(c *gin.Context, id openapi_types.UUID, integrationId openapi_types.UUID) {
+	h.integration.TestIntegration(c)
+}
+
+func (h *APIHandler) GetApiV1OrgsIdIntegrationsIntegrationIdDeliveries(c *gin.Context, id openapi_types.UUID, integrationId openapi_types.UUID) {
+	h.integration.ListDeliveries(c)
+}
+
+func (h *APIHandler) PutApiV1OrgsIdSharingConsent(c *gin.Context, id openapi_types.UUID) {
+	h.integration.GrantSharingConsent(c)
+}
+
+func (h *APIHandler) DeleteApiV1OrgsIdSharingConsent(c *gin.Context, id openapi_types.UUID) {
+	h.integration.RevokeSharingConsent(c)
+}
+
+// GetHealth implements the health check endpoint. It answers 200 when every component
+// is healthy, 207 when only Azure services fail or are short-circuited, and 503 when the
+// database is unreachable.
 // Requirements: Deployment, 12.2
 func (h *APIHandler) GetHealth(c *gin.Context) {
-	ctx := c.Request.Context()
-
-	// Check database connectivity
-	if err := h.pool.Ping(ctx); err != nil {
-		h.logger.Error("health check failed: database unreachable", zap.Error(err))
-		c.JSON(http.StatusServiceUnavailable, gin.H{
-			"status":   "unhealthy",
-			"database": "disconnected",
-			"error":    err.Error(),
-		})
+	report := h.components.Check(c.Request.Context())
+	if report.Status == service.HealthStatusUnhealthy {
+		h.logger.Error("health check failed: database unreachable")
+		c.JSON(http.StatusServiceUnavailable, report)
 		return
 	}
 
-	// Return healthy status
-	c.JSON(http.StatusOK, gin.H{
-		"status":   "healthy",
-		"database": "connected",
-		"service":  "eva-health-backend",
-		"version":  "1.0.0",
-	})
+	// Report degraded while Azure OpenAI or text-to-speech calls are short-circuited
+	response := gin.H{
+		"status":     report.Status,
+		"components": report.Components,
+		"service":    "eva-health-backend",
+		"version":    "1.0.0",
+	}
+	if h.openAI != nil {
+		breaker := h.openAI.BreakerStats()
+		response["azure_openai"] = breaker
+		if breaker.State != azure.BreakerClosed {
+			response["status"] = service.HealthStatusDegraded
+		}
+	}
+	if breaker := h.checkInSvc.TTSBreakerStats(); breaker.State != azure.BreakerClosed {
+		// Check-ins continue text-only while speech synthesis is short-circuited
+		response["azure_speech_tts"] = breaker
+		response["status"] = service.HealthStatusDegraded
+	}
+	if stats := h.checkInSvc.AudioCacheStats(); stats != nil {
+		response["audio_cache"] = gin.H{
+			"hits":      stats.Hits,
+			"misses":    stats.Misses,
+			"evictions": stats.Evictions,
+			"entries":   stats.Entries,
+			"bytes":     stats.Bytes,
+			"max_bytes": stats.MaxBytes,
+		}
+	}
+
+	status := http.StatusOK
+	if response["status"] == service.HealthStatusDegraded {
+		status = http.StatusMultiStatus
+	}
+	c.JSON(status, response)
 }
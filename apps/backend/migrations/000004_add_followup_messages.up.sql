@@ -0,0 +1,3 @@
+-- Flag assistant messages that were generated as adaptive follow-up questions
+ALTER TABLE conversation_messages
+    ADD COLUMN IF NOT EXISTS is_followup BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_alerts_unacknowledged;
+DROP INDEX IF EXISTS idx_alerts_check_in_id;
+DROP INDEX IF EXISTS idx_alerts_user_id;
+DROP TABLE IF EXISTS alerts;
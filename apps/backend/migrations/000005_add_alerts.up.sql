@@ -0,0 +1,17 @@
+-- Emergency symptom alerts raised during check-in triage
+
+CREATE TABLE IF NOT EXISTS alerts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    check_in_id UUID REFERENCES health_check_ins(id) ON DELETE CASCADE,
+    session_id UUID REFERENCES check_in_sessions(id) ON DELETE SET NULL,
+    severity VARCHAR(50) NOT NULL,
+    reason TEXT NOT NULL,
+    source VARCHAR(50) NOT NULL,
+    acknowledged_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_alerts_user_id ON alerts(user_id);
+CREATE INDEX IF NOT EXISTS idx_alerts_check_in_id ON alerts(check_in_id);
+CREATE INDEX IF NOT EXISTS idx_alerts_unacknowledged ON alerts(user_id) WHERE acknowledged_at IS NULL;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS medication_schedules;
@@ -0,0 +1,10 @@
+-- Structured reminder schedules parsed from free-text medication frequency
+
+CREATE TABLE IF NOT EXISTS medication_schedules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    medication_id UUID NOT NULL UNIQUE REFERENCES medications(id) ON DELETE CASCADE,
+    times_of_day TEXT[] NOT NULL,
+    days_of_week INTEGER[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
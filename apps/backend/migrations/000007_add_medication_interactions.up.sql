@@ -0,0 +1,22 @@
+-- Known drug-drug interactions used to warn when a medication is added
+
+CREATE TABLE IF NOT EXISTS medication_interactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    drug_a VARCHAR(255) NOT NULL,
+    drug_b VARCHAR(255) NOT NULL,
+    severity VARCHAR(50) NOT NULL,
+    description TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (drug_a, drug_b)
+);
+
+INSERT INTO medication_interactions (drug_a, drug_b, severity, description) VALUES
+    ('aspirin', 'warfarin', 'high', 'Increased risk of serious bleeding'),
+    ('ibuprofen', 'warfarin', 'high', 'Increased risk of gastrointestinal bleeding'),
+    ('aspirin', 'ibuprofen', 'moderate', 'Ibuprofen may reduce the cardioprotective effect of aspirin'),
+    ('clarithromycin', 'simvastatin', 'high', 'Increased risk of muscle damage (rhabdomyolysis)'),
+    ('nitroglycerin', 'sildenafil', 'high', 'Severe drop in blood pressure'),
+    ('sertraline', 'tramadol', 'high', 'Risk of serotonin syndrome and seizures'),
+    ('lisinopril', 'spironolactone', 'moderate', 'Risk of high potassium levels'),
+    ('clopidogrel', 'omeprazole', 'moderate', 'Omeprazole may reduce the effect of clopidogrel')
+ON CONFLICT (drug_a, drug_b) DO NOTHING;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_audio_recordings_file_path;
+DROP INDEX IF EXISTS idx_reports_file_path;
+DROP TABLE IF EXISTS user_usage;
@@ -0,0 +1,14 @@
+-- Per-user stored data accounting for storage cost planning and soft quotas
+
+CREATE TABLE IF NOT EXISTS user_usage (
+    user_id UUID PRIMARY KEY,
+    check_ins BIGINT NOT NULL DEFAULT 0,
+    audio_bytes BIGINT NOT NULL DEFAULT 0,
+    attachment_bytes BIGINT NOT NULL DEFAULT 0,
+    report_bytes BIGINT NOT NULL DEFAULT 0,
+    reconciled_at TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_reports_file_path ON reports(file_path);
+CREATE INDEX idx_audio_recordings_file_path ON audio_recordings(file_path);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_medication_logs_medication_taken_at;
+
+ALTER TABLE medication_logs DROP COLUMN IF EXISTS adherence;
@@ -0,0 +1,5 @@
+-- Adherence flag for medication logs, used for dashboard adherence scores
+
+ALTER TABLE medication_logs ADD COLUMN IF NOT EXISTS adherence BOOLEAN NOT NULL DEFAULT true;
+
+CREATE INDEX IF NOT EXISTS idx_medication_logs_medication_taken_at ON medication_logs(medication_id, taken_at);
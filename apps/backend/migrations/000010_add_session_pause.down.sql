@@ -0,0 +1,5 @@
+-- Paused sessions cannot be resumed without the pause columns
+UPDATE check_in_sessions SET status = 'expired', expired_at = NOW() WHERE status = 'paused';
+
+ALTER TABLE check_in_sessions DROP COLUMN IF EXISTS resumed_at;
+ALTER TABLE check_in_sessions DROP COLUMN IF EXISTS paused_at;
@@ -0,0 +1,4 @@
+-- Pause and resume of check-in sessions
+
+ALTER TABLE check_in_sessions ADD COLUMN IF NOT EXISTS paused_at TIMESTAMP;
+ALTER TABLE check_in_sessions ADD COLUMN IF NOT EXISTS resumed_at TIMESTAMP;
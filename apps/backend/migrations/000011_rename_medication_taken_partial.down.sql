@@ -0,0 +1 @@
+UPDATE health_check_ins SET medication_taken = 'partial' WHERE medication_taken = 'some';
@@ -0,0 +1,3 @@
+-- Rename the medication_taken value 'partial' to 'some'
+
+UPDATE health_check_ins SET medication_taken = 'some' WHERE medication_taken = 'partial';
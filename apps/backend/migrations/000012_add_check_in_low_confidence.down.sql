@@ -0,0 +1 @@
+ALTER TABLE health_check_ins DROP COLUMN IF EXISTS low_confidence;
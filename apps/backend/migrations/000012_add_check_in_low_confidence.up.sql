@@ -0,0 +1,3 @@
+-- Flag check-ins whose AI extraction was below the confidence threshold
+
+ALTER TABLE health_check_ins ADD COLUMN IF NOT EXISTS low_confidence BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_health_check_ins_extraction_prompt_version;
+
+ALTER TABLE health_check_ins DROP COLUMN IF EXISTS extraction_prompt_version;
+ALTER TABLE health_check_ins DROP COLUMN IF EXISTS extraction_issues;
@@ -0,0 +1,6 @@
+-- Plausibility flags and prompt version of AI-extracted check-ins
+
+ALTER TABLE health_check_ins ADD COLUMN IF NOT EXISTS extraction_issues TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE health_check_ins ADD COLUMN IF NOT EXISTS extraction_prompt_version VARCHAR(32);
+
+CREATE INDEX IF NOT EXISTS idx_health_check_ins_extraction_prompt_version ON health_check_ins(extraction_prompt_version);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_reports_verification_code;
+DROP INDEX IF EXISTS idx_reports_sha256;
+
+ALTER TABLE reports DROP COLUMN IF EXISTS verification_code;
+ALTER TABLE reports DROP COLUMN IF EXISTS sha256;
@@ -0,0 +1,7 @@
+-- Tamper-evident fingerprints of generated report PDFs
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS sha256 CHAR(64);
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS verification_code VARCHAR(16);
+
+CREATE INDEX IF NOT EXISTS idx_reports_sha256 ON reports(sha256);
+CREATE INDEX IF NOT EXISTS idx_reports_verification_code ON reports(verification_code);
@@ -0,0 +1,36 @@
+-- Organizations, per-organization user roles and invitations
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- organization_id is NULL for system-wide roles (system_admin)
+CREATE TABLE IF NOT EXISTS organization_roles (
+    organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    role VARCHAR(32) NOT NULL,
+    granted_by UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_roles_unique
+    ON organization_roles(COALESCE(organization_id, '00000000-0000-0000-0000-000000000000'::uuid), user_id, role);
+CREATE INDEX IF NOT EXISTS idx_organization_roles_user_id ON organization_roles(user_id);
+CREATE INDEX IF NOT EXISTS idx_organization_roles_organization_id ON organization_roles(organization_id);
+
+CREATE TABLE IF NOT EXISTS organization_invitations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(32) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    invited_by UUID NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP,
+    accepted_by UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_invitations_organization_id ON organization_invitations(organization_id);
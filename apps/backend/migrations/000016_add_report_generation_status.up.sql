@@ -0,0 +1,5 @@
+-- Reports are generated in the background; the row exists from the moment the
+-- request is accepted and file_path is only known once the PDF is uploaded
+
+ALTER TABLE reports ALTER COLUMN file_path SET DEFAULT '';
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS error_message TEXT;
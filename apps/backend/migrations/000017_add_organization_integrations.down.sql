@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS care_team_sharing_consents;
+DROP TABLE IF EXISTS integration_deliveries;
+DROP TABLE IF EXISTS organization_integrations;
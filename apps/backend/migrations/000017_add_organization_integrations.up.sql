@@ -0,0 +1,40 @@
+-- Per-organization delivery integrations, their delivery attempts, and patients'
+-- consent to share check-ins with an organization's care team
+
+CREATE TABLE IF NOT EXISTS organization_integrations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    settings JSONB NOT NULL DEFAULT '{}',
+    subject_template TEXT NOT NULL DEFAULT '',
+    body_template TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_integrations_organization_id ON organization_integrations(organization_id);
+
+CREATE TABLE IF NOT EXISTS integration_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    integration_id UUID NOT NULL REFERENCES organization_integrations(id) ON DELETE CASCADE,
+    event VARCHAR(64) NOT NULL,
+    user_id UUID,
+    check_in_id UUID,
+    status VARCHAR(16) NOT NULL,
+    error_message TEXT,
+    attempted_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_integration_deliveries_integration_id ON integration_deliveries(integration_id, attempted_at DESC);
+
+CREATE TABLE IF NOT EXISTS care_team_sharing_consents (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    granted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_care_team_sharing_consents_user_id ON care_team_sharing_consents(user_id);
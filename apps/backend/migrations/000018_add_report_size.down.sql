@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_reports_user_id_created_at;
+ALTER TABLE reports DROP COLUMN IF EXISTS size_bytes;
@@ -0,0 +1,6 @@
+-- Size of the generated PDF, listed with a user's previous reports and subtracted
+-- from their usage when a report is deleted
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS size_bytes BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_reports_user_id_created_at ON reports(user_id, created_at DESC);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS user_consents;
+DROP TYPE IF EXISTS consent_type;
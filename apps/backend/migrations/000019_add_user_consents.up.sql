@@ -0,0 +1,18 @@
+-- Consent a user has granted or revoked for each kind of processing. One row per user
+-- and consent type holds its current state; a consent that was never answered has no row.
+
+DO $$ BEGIN
+    CREATE TYPE consent_type AS ENUM ('data_processing', 'voice_recording', 'research_sharing');
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$;
+
+CREATE TABLE IF NOT EXISTS user_consents (
+    user_id UUID NOT NULL,
+    consent_type consent_type NOT NULL,
+    granted BOOLEAN NOT NULL,
+    granted_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, consent_type)
+);
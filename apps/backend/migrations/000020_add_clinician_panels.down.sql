@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_health_check_ins_user_id_created_at;
+DROP TABLE IF EXISTS panel_digest_subscriptions;
+DROP TABLE IF EXISTS clinician_patient_assignments;
@@ -0,0 +1,25 @@
+-- Patients assigned to a clinician's panel within an organization, and clinicians who
+-- opted in to a daily email digest of their panel's findings
+
+CREATE TABLE IF NOT EXISTS clinician_patient_assignments (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    clinician_id UUID NOT NULL,
+    patient_id UUID NOT NULL,
+    assigned_by UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, clinician_id, patient_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_clinician_patient_assignments_patient_id ON clinician_patient_assignments(patient_id);
+
+CREATE TABLE IF NOT EXISTS panel_digest_subscriptions (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    clinician_id UUID NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    last_sent_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, clinician_id)
+);
+
+-- Panel findings select recent check-ins of many patients at once
+CREATE INDEX IF NOT EXISTS idx_health_check_ins_user_id_created_at ON health_check_ins(user_id, created_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_audit_logs_resource_type_timestamp;
+DROP INDEX IF EXISTS idx_audit_logs_operation_type_timestamp;
+DROP INDEX IF EXISTS idx_audit_logs_user_id_timestamp;
@@ -0,0 +1,5 @@
+-- Audit log queries filter on one column and page newest first, so each filter column
+-- is indexed together with the timestamp
+CREATE INDEX IF NOT EXISTS idx_audit_logs_user_id_timestamp ON audit_logs(user_id, timestamp DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_operation_type_timestamp ON audit_logs(operation_type, timestamp DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_resource_type_timestamp ON audit_logs(resource_type, timestamp DESC);
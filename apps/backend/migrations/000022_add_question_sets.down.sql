@@ -0,0 +1,3 @@
+ALTER TABLE check_in_sessions DROP COLUMN IF EXISTS question_set_id;
+DROP TABLE IF EXISTS user_question_sets;
+DROP TABLE IF EXISTS question_sets;
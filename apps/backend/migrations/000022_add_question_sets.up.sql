@@ -0,0 +1,26 @@
+-- Check-in question sets clinicians define in place of the built-in set. Users are
+-- identified by the UUID of their identity provider, which the legacy users table does
+-- not hold, so assignments are kept in their own table keyed by that ID.
+
+CREATE TABLE IF NOT EXISTS question_sets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    language VARCHAR(16) NOT NULL,
+    created_by UUID,
+    questions JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Users without a row are asked the built-in question set
+CREATE TABLE IF NOT EXISTS user_question_sets (
+    user_id UUID PRIMARY KEY,
+    question_set_id UUID NOT NULL REFERENCES question_sets(id) ON DELETE CASCADE,
+    assigned_by UUID,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_question_sets_question_set_id ON user_question_sets(question_set_id);
+
+-- A session keeps the question set it started with, NULL being the built-in set
+ALTER TABLE check_in_sessions
+    ADD COLUMN IF NOT EXISTS question_set_id UUID REFERENCES question_sets(id) ON DELETE SET NULL;
@@ -0,0 +1,4 @@
+-- File format of a report: a printable PDF or a ZIP of CSV files. Existing reports
+-- are all PDFs.
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS format VARCHAR(10) NOT NULL DEFAULT 'pdf';
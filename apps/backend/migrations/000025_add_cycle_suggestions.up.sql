@@ -0,0 +1,17 @@
+-- Suggestions to log a menstruation cycle for check-ins that mention menstrual symptoms
+-- outside any recorded cycle. Suggestions are kept after they are accepted or dismissed
+-- so the same check-ins are not suggested again; one suggestion exists per start date.
+
+CREATE TABLE IF NOT EXISTS cycle_suggestions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    symptoms TEXT[] NOT NULL DEFAULT '{}',
+    check_in_ids UUID[] NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    cycle_id UUID REFERENCES menstruation_cycles(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP,
+    UNIQUE (user_id, start_date)
+);
@@ -0,0 +1,4 @@
+-- Sections a report includes. Existing reports include every section.
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS sections TEXT[] NOT NULL
+    DEFAULT '{symptoms,medications,adherence,blood_pressure,menstruation,activity,meals,summaries}';
@@ -0,0 +1,4 @@
+-- Whether a report PDF is password protected. The password is returned once when the
+-- report is requested and never stored.
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS encrypted BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,29 @@
+-- Report generation jobs. Workers claim pending jobs with FOR UPDATE SKIP LOCKED, so the
+-- workers of every server share the queue and queued reports survive restarts. A job left
+-- processing by a worker that stopped is claimed again once it exceeds the job timeout.
+-- password holds the password of an encrypted report until its job finishes.
+
+CREATE TABLE IF NOT EXISTS report_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    report_id UUID REFERENCES reports(id) ON DELETE SET NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    user_name TEXT NOT NULL DEFAULT '',
+    language VARCHAR(10) NOT NULL DEFAULT '',
+    format VARCHAR(10) NOT NULL DEFAULT 'pdf',
+    sections TEXT[] NOT NULL DEFAULT '{}',
+    password TEXT,
+    read_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    error_text TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_jobs_queue ON report_jobs (created_at)
+    WHERE status IN ('pending', 'processing');
+CREATE INDEX IF NOT EXISTS idx_report_jobs_user_id ON report_jobs (user_id);
@@ -0,0 +1,13 @@
+-- Webhooks users register to notify external systems, such as their EHR, of events
+-- about their data. Deliveries are signed with HMAC-SHA256 using secret_token.
+
+CREATE TABLE IF NOT EXISTS webhooks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    target_url TEXT NOT NULL,
+    secret_token TEXT NOT NULL,
+    events TEXT[] NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_user_id ON webhooks (user_id);
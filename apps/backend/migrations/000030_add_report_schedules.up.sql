@@ -0,0 +1,17 @@
+-- Schedules of the reports generated for a user automatically, weekly on an ISO weekday
+-- (1 Monday to 7 Sunday) or monthly on a day of the month (1 to 28). last_run_on is the
+-- scheduled day of the latest report queued, so every period is reported once even when
+-- several servers run the scheduler.
+
+CREATE TABLE IF NOT EXISTS report_schedules (
+    user_id UUID PRIMARY KEY,
+    cadence VARCHAR(10) NOT NULL CHECK (cadence IN ('weekly', 'monthly')),
+    day INTEGER NOT NULL CHECK (day BETWEEN 1 AND 28),
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    language VARCHAR(10) NOT NULL DEFAULT '',
+    last_run_on DATE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_schedules_enabled ON report_schedules (user_id) WHERE enabled;
@@ -0,0 +1,3 @@
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS restored;
+
+DROP TABLE IF EXISTS audit_archives;
@@ -0,0 +1,15 @@
+-- Months of audit logs moved to cold storage. Each month is written to blob storage as
+-- gzipped NDJSON files with a manifest of their checksums; purged_at is set once the
+-- archived rows were deleted. A month restored on demand is copied back into audit_logs
+-- with restored set until restored_until, when the copies are deleted again.
+
+CREATE TABLE IF NOT EXISTS audit_archives (
+    month DATE PRIMARY KEY,
+    manifest_blob TEXT NOT NULL,
+    row_count INTEGER NOT NULL,
+    archived_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    purged_at TIMESTAMP,
+    restored_until TIMESTAMP
+);
+
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS restored BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS gdpr_consents;
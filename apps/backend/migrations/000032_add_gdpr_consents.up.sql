@@ -0,0 +1,17 @@
+-- History of the GDPR consents users gave, kept as evidence of what they agreed to and
+-- when. One row per user, consent type and version of the consent text holds the latest
+-- answer given to that version.
+
+CREATE TABLE IF NOT EXISTS gdpr_consents (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    consent_type VARCHAR(30) NOT NULL
+        CHECK (consent_type IN ('processing', 'marketing', 'third_party_sharing', 'right_to_erasure')),
+    granted BOOLEAN NOT NULL,
+    version VARCHAR(50) NOT NULL,
+    timestamp TIMESTAMP NOT NULL DEFAULT NOW(),
+    ip_address VARCHAR(45),
+    UNIQUE (user_id, consent_type, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_gdpr_consents_user_timestamp ON gdpr_consents (user_id, timestamp);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS health_check_ins_archive;
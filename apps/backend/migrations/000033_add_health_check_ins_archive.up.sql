@@ -0,0 +1,8 @@
+-- Cold storage for check-ins past the check-in retention period. The retention job moves
+-- rows here unchanged, so every column added to health_check_ins must be added here too.
+
+CREATE TABLE IF NOT EXISTS health_check_ins_archive (
+    LIKE health_check_ins INCLUDING DEFAULTS
+);
+
+CREATE INDEX IF NOT EXISTS idx_health_check_ins_archive_user_id ON health_check_ins_archive (user_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_settings;
+
+ALTER TABLE medication_schedules DROP COLUMN IF EXISTS as_needed;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_anomalies_user_observed;
+DROP TABLE IF EXISTS anomalies;
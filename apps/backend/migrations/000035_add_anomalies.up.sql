@@ -0,0 +1,18 @@
+-- Health readings outside the anomaly thresholds or far from the user's baseline
+
+CREATE TABLE IF NOT EXISTS anomalies (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    metric VARCHAR(50) NOT NULL,
+    value DOUBLE PRECISION NOT NULL,
+    baseline DOUBLE PRECISION,
+    threshold DOUBLE PRECISION NOT NULL,
+    severity VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL,
+    source_type VARCHAR(50) NOT NULL,
+    source_id UUID NOT NULL,
+    observed_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_anomalies_user_observed ON anomalies(user_id, observed_at DESC);
@@ -0,0 +1,2 @@
+-- Audit log search filters on the client IP address, e.g. to trace one address across users
+CREATE INDEX IF NOT EXISTS idx_audit_logs_ip_address_timestamp ON audit_logs(ip_address, timestamp DESC);
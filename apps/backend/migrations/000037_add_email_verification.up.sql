@@ -0,0 +1,16 @@
+-- Verification of user email addresses. Email is only sent to verified addresses; a
+-- changed address stays unverified until a token issued for it is confirmed. Tokens
+-- are signed, so only their IDs are stored, and each can be used once.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_user_created ON email_verification_tokens (user_id, created_at DESC);
@@ -0,0 +1 @@
+ALTER TABLE medications DROP COLUMN IF EXISTS interaction_warnings;
@@ -0,0 +1,4 @@
+-- Interaction warnings found when a medication was last added or updated, kept for
+-- display alongside the medication
+
+ALTER TABLE medications ADD COLUMN IF NOT EXISTS interaction_warnings JSONB NOT NULL DEFAULT '[]';
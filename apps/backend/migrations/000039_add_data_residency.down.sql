@@ -0,0 +1,4 @@
+ALTER TABLE audio_recordings DROP COLUMN IF EXISTS storage_backend;
+ALTER TABLE conversation_messages DROP COLUMN IF EXISTS storage_backend;
+ALTER TABLE reports DROP COLUMN IF EXISTS storage_backend;
+ALTER TABLE organizations DROP COLUMN IF EXISTS data_residency;
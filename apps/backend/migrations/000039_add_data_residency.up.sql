@@ -0,0 +1,9 @@
+-- Organizations may require their patients' artifacts to stay in a named storage
+-- backend, e.g. an EU-only storage account. Artifacts record the backend they were
+-- written to, so reads and deletions reach it after the residency changes.
+
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS data_residency VARCHAR(64);
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS storage_backend VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE conversation_messages ADD COLUMN IF NOT EXISTS storage_backend VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE audio_recordings ADD COLUMN IF NOT EXISTS storage_backend VARCHAR(64) NOT NULL DEFAULT 'default';
@@ -0,0 +1,15 @@
+-- Idempotent responses are now keyed by the middleware's store key, which already scopes
+-- the Idempotency-Key to the user, method, path and request body. Stored responses are
+-- short-lived, so the old rows are dropped rather than converted.
+
+DROP TABLE IF EXISTS idempotency_keys;
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key VARCHAR(1024) PRIMARY KEY,
+    status_code INTEGER NOT NULL,
+    response_body BYTEA NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
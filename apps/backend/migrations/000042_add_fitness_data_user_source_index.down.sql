@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_fitness_data_user_source_data_id;
@@ -0,0 +1,14 @@
+-- Fitness data points are deduplicated per user by their Health Connect ID, so syncs can
+-- insert with ON CONFLICT DO NOTHING. Duplicates saved before the index existed are
+-- removed first, keeping the earliest. Points without a source ID are never deduplicated.
+
+DELETE FROM fitness_data a
+USING fitness_data b
+WHERE a.user_id = b.user_id
+    AND a.source_data_id = b.source_data_id
+    AND a.source_data_id <> ''
+    AND (a.created_at, a.id) > (b.created_at, b.id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_fitness_data_user_source_data_id
+    ON fitness_data (user_id, source_data_id)
+    WHERE source_data_id <> '';
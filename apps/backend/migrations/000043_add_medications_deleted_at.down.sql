@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_medications_deleted_at;
+DELETE FROM medications WHERE deleted_at IS NOT NULL;
+ALTER TABLE medications DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,8 @@
+-- Deleted medications are kept with a deletion time so they can be restored and their
+-- adherence logs keep their context; only a permanent delete removes the row
+
+ALTER TABLE medications ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_medications_deleted_at
+    ON medications (deleted_at)
+    WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,8 @@
+-- Requests reserve their idempotency key before they are processed, so a reservation is
+-- a row without a response yet. The request hash is stored with the response so a key
+-- reused for a different request is rejected instead of being part of the key.
+
+ALTER TABLE idempotency_keys
+    ALTER COLUMN status_code DROP NOT NULL,
+    ALTER COLUMN response_body DROP NOT NULL,
+    ADD COLUMN IF NOT EXISTS request_hash CHAR(64);
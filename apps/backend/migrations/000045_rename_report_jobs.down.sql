@@ -0,0 +1,3 @@
+ALTER INDEX IF EXISTS idx_reports_jobs_user_id RENAME TO idx_report_jobs_user_id;
+ALTER INDEX IF EXISTS idx_reports_jobs_queue RENAME TO idx_report_jobs_queue;
+ALTER TABLE reports_jobs RENAME TO report_jobs;
@@ -0,0 +1,8 @@
+-- The report generation queue is named reports_jobs after the reports it generates.
+-- password now holds the password of an encrypted report encrypted with the server's
+-- REPORT_PASSWORD_KEY; jobs queued with a plain password fail instead of producing an
+-- unprotected report.
+
+ALTER TABLE report_jobs RENAME TO reports_jobs;
+ALTER INDEX IF EXISTS idx_report_jobs_queue RENAME TO idx_reports_jobs_queue;
+ALTER INDEX IF EXISTS idx_report_jobs_user_id RENAME TO idx_reports_jobs_user_id;
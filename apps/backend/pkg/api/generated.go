@@ -20,30 +20,234 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for AuditLogOperationType.
+const (
+	AuditLogOperationTypeANONYMIZE AuditLogOperationType = "ANONYMIZE"
+	AuditLogOperationTypeCREATE    AuditLogOperationType = "CREATE"
+	AuditLogOperationTypeDELETE    AuditLogOperationType = "DELETE"
+	AuditLogOperationTypeREAD      AuditLogOperationType = "READ"
+	AuditLogOperationTypeUPDATE    AuditLogOperationType = "UPDATE"
+)
+
+// Valid indicates whether the value is a known member of the AuditLogOperationType enum.
+func (e AuditLogOperationType) Valid() bool {
+	switch e {
+	case AuditLogOperationTypeANONYMIZE:
+		return true
+	case AuditLogOperationTypeCREATE:
+		return true
+	case AuditLogOperationTypeDELETE:
+		return true
+	case AuditLogOperationTypeREAD:
+		return true
+	case AuditLogOperationTypeUPDATE:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for BloodPressureResponseCategory.
+const (
+	Crisis   BloodPressureResponseCategory = "crisis"
+	Elevated BloodPressureResponseCategory = "elevated"
+	Normal   BloodPressureResponseCategory = "normal"
+	Stage1   BloodPressureResponseCategory = "stage_1"
+	Stage2   BloodPressureResponseCategory = "stage_2"
+)
+
+// Valid indicates whether the value is a known member of the BloodPressureResponseCategory enum.
+func (e BloodPressureResponseCategory) Valid() bool {
+	switch e {
+	case Crisis:
+		return true
+	case Elevated:
+		return true
+	case Normal:
+		return true
+	case Stage1:
+		return true
+	case Stage2:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for BloodPressureTrendDirection.
+const (
+	Down BloodPressureTrendDirection = "down"
+	Flat BloodPressureTrendDirection = "flat"
+	Up   BloodPressureTrendDirection = "up"
+)
+
+// Valid indicates whether the value is a known member of the BloodPressureTrendDirection enum.
+func (e BloodPressureTrendDirection) Valid() bool {
+	switch e {
+	case Down:
+		return true
+	case Flat:
+		return true
+	case Up:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ConsentRequestConsentType.
+const (
+	ConsentRequestConsentTypeDataProcessing  ConsentRequestConsentType = "data_processing"
+	ConsentRequestConsentTypeResearchSharing ConsentRequestConsentType = "research_sharing"
+	ConsentRequestConsentTypeVoiceRecording  ConsentRequestConsentType = "voice_recording"
+)
+
+// Valid indicates whether the value is a known member of the ConsentRequestConsentType enum.
+func (e ConsentRequestConsentType) Valid() bool {
+	switch e {
+	case ConsentRequestConsentTypeDataProcessing:
+		return true
+	case ConsentRequestConsentTypeResearchSharing:
+		return true
+	case ConsentRequestConsentTypeVoiceRecording:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for CreateTokenRequestScopes.
+const (
+	CreateTokenRequestScopesExportRead  CreateTokenRequestScopes = "export:read"
+	CreateTokenRequestScopesHealthRead  CreateTokenRequestScopes = "health:read"
+	CreateTokenRequestScopesReportsRead CreateTokenRequestScopes = "reports:read"
+)
+
+// Valid indicates whether the value is a known member of the CreateTokenRequestScopes enum.
+func (e CreateTokenRequestScopes) Valid() bool {
+	switch e {
+	case CreateTokenRequestScopesExportRead:
+		return true
+	case CreateTokenRequestScopesHealthRead:
+		return true
+	case CreateTokenRequestScopesReportsRead:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for CreateWebhookRequestEvents.
+const (
+	CreateWebhookRequestEventsCheckinCompleted CreateWebhookRequestEvents = "checkin.completed"
+	CreateWebhookRequestEventsMedicationAdded  CreateWebhookRequestEvents = "medication.added"
+)
+
+// Valid indicates whether the value is a known member of the CreateWebhookRequestEvents enum.
+func (e CreateWebhookRequestEvents) Valid() bool {
+	switch e {
+	case CreateWebhookRequestEventsCheckinCompleted:
+		return true
+	case CreateWebhookRequestEventsMedicationAdded:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for CreatedTokenScopes.
+const (
+	CreatedTokenScopesExportRead  CreatedTokenScopes = "export:read"
+	CreatedTokenScopesHealthRead  CreatedTokenScopes = "health:read"
+	CreatedTokenScopesReportsRead CreatedTokenScopes = "reports:read"
+)
+
+// Valid indicates whether the value is a known member of the CreatedTokenScopes enum.
+func (e CreatedTokenScopes) Valid() bool {
+	switch e {
+	case CreatedTokenScopesExportRead:
+		return true
+	case CreatedTokenScopesHealthRead:
+		return true
+	case CreatedTokenScopesReportsRead:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for CyclePredictionConfidence.
+const (
+	CyclePredictionConfidenceHigh   CyclePredictionConfidence = "high"
+	CyclePredictionConfidenceLow    CyclePredictionConfidence = "low"
+	CyclePredictionConfidenceMedium CyclePredictionConfidence = "medium"
+)
+
+// Valid indicates whether the value is a known member of the CyclePredictionConfidence enum.
+func (e CyclePredictionConfidence) Valid() bool {
+	switch e {
+	case CyclePredictionConfidenceHigh:
+		return true
+	case CyclePredictionConfidenceLow:
+		return true
+	case CyclePredictionConfidenceMedium:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for CycleSuggestionStatus.
+const (
+	Accepted  CycleSuggestionStatus = "accepted"
+	Dismissed CycleSuggestionStatus = "dismissed"
+	Open      CycleSuggestionStatus = "open"
+)
+
+// Valid indicates whether the value is a known member of the CycleSuggestionStatus enum.
+func (e CycleSuggestionStatus) Valid() bool {
+	switch e {
+	case Accepted:
+		return true
+	case Dismissed:
+		return true
+	case Open:
+		return true
+	default:
+		return false
+	}
+}
+
 // Defines values for FitnessDataPointDataType.
 const (
-	ActiveMinutes FitnessDataPointDataType = "active_minutes"
-	Calories      FitnessDataPointDataType = "calories"
-	Distance      FitnessDataPointDataType = "distance"
-	HeartRate     FitnessDataPointDataType = "heart_rate"
-	Sleep         FitnessDataPointDataType = "sleep"
-	Steps         FitnessDataPointDataType = "steps"
+	FitnessDataPointDataTypeActiveMinutes FitnessDataPointDataType = "active_minutes"
+	FitnessDataPointDataTypeCalories      FitnessDataPointDataType = "calories"
+	FitnessDataPointDataTypeDistance      FitnessDataPointDataType = "distance"
+	FitnessDataPointDataTypeHeartRate     FitnessDataPointDataType = "heart_rate"
+	FitnessDataPointDataTypeSleep         FitnessDataPointDataType = "sleep"
+	FitnessDataPointDataTypeSleepMinutes  FitnessDataPointDataType = "sleep_minutes"
+	FitnessDataPointDataTypeSteps         FitnessDataPointDataType = "steps"
+	FitnessDataPointDataTypeWeight        FitnessDataPointDataType = "weight"
 )
 
 // Valid indicates whether the value is a known member of the FitnessDataPointDataType enum.
 func (e FitnessDataPointDataType) Valid() bool {
 	switch e {
-	case ActiveMinutes:
+	case FitnessDataPointDataTypeActiveMinutes:
+		return true
+	case FitnessDataPointDataTypeCalories:
 		return true
-	case Calories:
+	case FitnessDataPointDataTypeDistance:
 		return true
-	case Distance:
+	case FitnessDataPointDataTypeHeartRate:
 		return true
-	case HeartRate:
+	case FitnessDataPointDataTypeSleep:
 		return true
-	case Sleep:
+	case FitnessDataPointDataTypeSleepMinutes:
 		return true
-	case Steps:
+	case FitnessDataPointDataTypeSteps:
+		return true
+	case FitnessDataPointDataTypeWeight:
 		return true
 	default:
 		return false
@@ -52,16 +256,16 @@ func (e FitnessDataPointDataType) Valid() bool {
 
 // Defines values for FitnessDataPointSource.
 const (
-	GoogleFit     FitnessDataPointSource = "google_fit"
-	HealthConnect FitnessDataPointSource = "health_connect"
+	FitnessDataPointSourceGoogleFit     FitnessDataPointSource = "google_fit"
+	FitnessDataPointSourceHealthConnect FitnessDataPointSource = "health_connect"
 )
 
 // Valid indicates whether the value is a known member of the FitnessDataPointSource enum.
 func (e FitnessDataPointSource) Valid() bool {
 	switch e {
-	case GoogleFit:
+	case FitnessDataPointSourceGoogleFit:
 		return true
-	case HealthConnect:
+	case FitnessDataPointSourceHealthConnect:
 		return true
 	default:
 		return false
@@ -70,25 +274,166 @@ func (e FitnessDataPointSource) Valid() bool {
 
 // Defines values for FitnessDataPointUnit.
 const (
-	Bpm     FitnessDataPointUnit = "bpm"
-	Count   FitnessDataPointUnit = "count"
-	Kcal    FitnessDataPointUnit = "kcal"
-	Meters  FitnessDataPointUnit = "meters"
-	Minutes FitnessDataPointUnit = "minutes"
+	FitnessDataPointUnitBpm     FitnessDataPointUnit = "bpm"
+	FitnessDataPointUnitCount   FitnessDataPointUnit = "count"
+	FitnessDataPointUnitKcal    FitnessDataPointUnit = "kcal"
+	FitnessDataPointUnitKg      FitnessDataPointUnit = "kg"
+	FitnessDataPointUnitMeters  FitnessDataPointUnit = "meters"
+	FitnessDataPointUnitMinutes FitnessDataPointUnit = "minutes"
 )
 
 // Valid indicates whether the value is a known member of the FitnessDataPointUnit enum.
 func (e FitnessDataPointUnit) Valid() bool {
 	switch e {
-	case Bpm:
+	case FitnessDataPointUnitBpm:
+		return true
+	case FitnessDataPointUnitCount:
+		return true
+	case FitnessDataPointUnitKcal:
+		return true
+	case FitnessDataPointUnitKg:
+		return true
+	case FitnessDataPointUnitMeters:
+		return true
+	case FitnessDataPointUnitMinutes:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for FitnessDataResponseDataType.
+const (
+	FitnessDataResponseDataTypeActiveMinutes FitnessDataResponseDataType = "active_minutes"
+	FitnessDataResponseDataTypeCalories      FitnessDataResponseDataType = "calories"
+	FitnessDataResponseDataTypeDistance      FitnessDataResponseDataType = "distance"
+	FitnessDataResponseDataTypeHeartRate     FitnessDataResponseDataType = "heart_rate"
+	FitnessDataResponseDataTypeSleep         FitnessDataResponseDataType = "sleep"
+	FitnessDataResponseDataTypeSleepMinutes  FitnessDataResponseDataType = "sleep_minutes"
+	FitnessDataResponseDataTypeSteps         FitnessDataResponseDataType = "steps"
+	FitnessDataResponseDataTypeWeight        FitnessDataResponseDataType = "weight"
+)
+
+// Valid indicates whether the value is a known member of the FitnessDataResponseDataType enum.
+func (e FitnessDataResponseDataType) Valid() bool {
+	switch e {
+	case FitnessDataResponseDataTypeActiveMinutes:
 		return true
-	case Count:
+	case FitnessDataResponseDataTypeCalories:
 		return true
-	case Kcal:
+	case FitnessDataResponseDataTypeDistance:
 		return true
-	case Meters:
+	case FitnessDataResponseDataTypeHeartRate:
 		return true
-	case Minutes:
+	case FitnessDataResponseDataTypeSleep:
+		return true
+	case FitnessDataResponseDataTypeSleepMinutes:
+		return true
+	case FitnessDataResponseDataTypeSteps:
+		return true
+	case FitnessDataResponseDataTypeWeight:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for FitnessDataResponseSource.
+const (
+	FitnessDataResponseSourceGoogleFit     FitnessDataResponseSource = "google_fit"
+	FitnessDataResponseSourceHealthConnect FitnessDataResponseSource = "health_connect"
+)
+
+// Valid indicates whether the value is a known member of the FitnessDataResponseSource enum.
+func (e FitnessDataResponseSource) Valid() bool {
+	switch e {
+	case FitnessDataResponseSourceGoogleFit:
+		return true
+	case FitnessDataResponseSourceHealthConnect:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for FitnessDataResponseUnit.
+const (
+	FitnessDataResponseUnitBpm     FitnessDataResponseUnit = "bpm"
+	FitnessDataResponseUnitCount   FitnessDataResponseUnit = "count"
+	FitnessDataResponseUnitKcal    FitnessDataResponseUnit = "kcal"
+	FitnessDataResponseUnitKg      FitnessDataResponseUnit = "kg"
+	FitnessDataResponseUnitMeters  FitnessDataResponseUnit = "meters"
+	FitnessDataResponseUnitMinutes FitnessDataResponseUnit = "minutes"
+)
+
+// Valid indicates whether the value is a known member of the FitnessDataResponseUnit enum.
+func (e FitnessDataResponseUnit) Valid() bool {
+	switch e {
+	case FitnessDataResponseUnitBpm:
+		return true
+	case FitnessDataResponseUnitCount:
+		return true
+	case FitnessDataResponseUnitKcal:
+		return true
+	case FitnessDataResponseUnitKg:
+		return true
+	case FitnessDataResponseUnitMeters:
+		return true
+	case FitnessDataResponseUnitMinutes:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for GenerateReportRequestFormat.
+const (
+	GenerateReportRequestFormatCsv GenerateReportRequestFormat = "csv"
+	GenerateReportRequestFormatPdf GenerateReportRequestFormat = "pdf"
+)
+
+// Valid indicates whether the value is a known member of the GenerateReportRequestFormat enum.
+func (e GenerateReportRequestFormat) Valid() bool {
+	switch e {
+	case GenerateReportRequestFormatCsv:
+		return true
+	case GenerateReportRequestFormatPdf:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for GenerateReportRequestSections.
+const (
+	GenerateReportRequestSectionsActivity      GenerateReportRequestSections = "activity"
+	GenerateReportRequestSectionsAdherence     GenerateReportRequestSections = "adherence"
+	GenerateReportRequestSectionsBloodPressure GenerateReportRequestSections = "blood_pressure"
+	GenerateReportRequestSectionsMeals         GenerateReportRequestSections = "meals"
+	GenerateReportRequestSectionsMedications   GenerateReportRequestSections = "medications"
+	GenerateReportRequestSectionsMenstruation  GenerateReportRequestSections = "menstruation"
+	GenerateReportRequestSectionsSummaries     GenerateReportRequestSections = "summaries"
+	GenerateReportRequestSectionsSymptoms      GenerateReportRequestSections = "symptoms"
+)
+
+// Valid indicates whether the value is a known member of the GenerateReportRequestSections enum.
+func (e GenerateReportRequestSections) Valid() bool {
+	switch e {
+	case GenerateReportRequestSectionsActivity:
+		return true
+	case GenerateReportRequestSectionsAdherence:
+		return true
+	case GenerateReportRequestSectionsBloodPressure:
+		return true
+	case GenerateReportRequestSectionsMeals:
+		return true
+	case GenerateReportRequestSectionsMedications:
+		return true
+	case GenerateReportRequestSectionsMenstruation:
+		return true
+	case GenerateReportRequestSectionsSummaries:
+		return true
+	case GenerateReportRequestSectionsSymptoms:
 		return true
 	default:
 		return false
@@ -97,19 +442,19 @@ func (e FitnessDataPointUnit) Valid() bool {
 
 // Defines values for HealthCheckInResponseEnergyLevel.
 const (
-	High   HealthCheckInResponseEnergyLevel = "high"
-	Low    HealthCheckInResponseEnergyLevel = "low"
-	Medium HealthCheckInResponseEnergyLevel = "medium"
+	HealthCheckInResponseEnergyLevelHigh   HealthCheckInResponseEnergyLevel = "high"
+	HealthCheckInResponseEnergyLevelLow    HealthCheckInResponseEnergyLevel = "low"
+	HealthCheckInResponseEnergyLevelMedium HealthCheckInResponseEnergyLevel = "medium"
 )
 
 // Valid indicates whether the value is a known member of the HealthCheckInResponseEnergyLevel enum.
 func (e HealthCheckInResponseEnergyLevel) Valid() bool {
 	switch e {
-	case High:
+	case HealthCheckInResponseEnergyLevelHigh:
 		return true
-	case Low:
+	case HealthCheckInResponseEnergyLevelLow:
 		return true
-	case Medium:
+	case HealthCheckInResponseEnergyLevelMedium:
 		return true
 	default:
 		return false
@@ -182,18 +527,21 @@ func (e HealthCheckInResponseSleepQuality) Valid() bool {
 	}
 }
 
-// Defines values for HealthStatusDatabase.
+// Defines values for HealthStatusComponents.
 const (
-	Connected    HealthStatusDatabase = "connected"
-	Disconnected HealthStatusDatabase = "disconnected"
+	Error   HealthStatusComponents = "error"
+	Ok      HealthStatusComponents = "ok"
+	Timeout HealthStatusComponents = "timeout"
 )
 
-// Valid indicates whether the value is a known member of the HealthStatusDatabase enum.
-func (e HealthStatusDatabase) Valid() bool {
+// Valid indicates whether the value is a known member of the HealthStatusComponents enum.
+func (e HealthStatusComponents) Valid() bool {
 	switch e {
-	case Connected:
+	case Error:
 		return true
-	case Disconnected:
+	case Ok:
+		return true
+	case Timeout:
 		return true
 	default:
 		return false
@@ -202,6 +550,7 @@ func (e HealthStatusDatabase) Valid() bool {
 
 // Defines values for HealthStatusStatus.
 const (
+	Degraded  HealthStatusStatus = "degraded"
 	Healthy   HealthStatusStatus = "healthy"
 	Unhealthy HealthStatusStatus = "unhealthy"
 )
@@ -209,6 +558,8 @@ const (
 // Valid indicates whether the value is a known member of the HealthStatusStatus enum.
 func (e HealthStatusStatus) Valid() bool {
 	switch e {
+	case Degraded:
+		return true
 	case Healthy:
 		return true
 	case Unhealthy:
@@ -218,554 +569,5508 @@ func (e HealthStatusStatus) Valid() bool {
 	}
 }
 
-// Defines values for MenstruationRequestFlowIntensity.
+// Defines values for IntegrationKind.
 const (
-	MenstruationRequestFlowIntensityHeavy    MenstruationRequestFlowIntensity = "heavy"
-	MenstruationRequestFlowIntensityLight    MenstruationRequestFlowIntensity = "light"
-	MenstruationRequestFlowIntensityModerate MenstruationRequestFlowIntensity = "moderate"
+	IntegrationKindConsole IntegrationKind = "console"
+	IntegrationKindSmtp    IntegrationKind = "smtp"
+	IntegrationKindWebhook IntegrationKind = "webhook"
 )
 
-// Valid indicates whether the value is a known member of the MenstruationRequestFlowIntensity enum.
-func (e MenstruationRequestFlowIntensity) Valid() bool {
+// Valid indicates whether the value is a known member of the IntegrationKind enum.
+func (e IntegrationKind) Valid() bool {
 	switch e {
-	case MenstruationRequestFlowIntensityHeavy:
+	case IntegrationKindConsole:
 		return true
-	case MenstruationRequestFlowIntensityLight:
+	case IntegrationKindSmtp:
 		return true
-	case MenstruationRequestFlowIntensityModerate:
+	case IntegrationKindWebhook:
 		return true
 	default:
 		return false
 	}
 }
 
-// Defines values for MenstruationResponseFlowIntensity.
+// Defines values for IntegrationDeliveryStatus.
 const (
-	MenstruationResponseFlowIntensityHeavy    MenstruationResponseFlowIntensity = "heavy"
-	MenstruationResponseFlowIntensityLight    MenstruationResponseFlowIntensity = "light"
-	MenstruationResponseFlowIntensityModerate MenstruationResponseFlowIntensity = "moderate"
+	IntegrationDeliveryStatusDelivered IntegrationDeliveryStatus = "delivered"
+	IntegrationDeliveryStatusFailed    IntegrationDeliveryStatus = "failed"
 )
 
-// Valid indicates whether the value is a known member of the MenstruationResponseFlowIntensity enum.
-func (e MenstruationResponseFlowIntensity) Valid() bool {
+// Valid indicates whether the value is a known member of the IntegrationDeliveryStatus enum.
+func (e IntegrationDeliveryStatus) Valid() bool {
 	switch e {
-	case MenstruationResponseFlowIntensityHeavy:
-		return true
-	case MenstruationResponseFlowIntensityLight:
+	case IntegrationDeliveryStatusDelivered:
 		return true
-	case MenstruationResponseFlowIntensityModerate:
+	case IntegrationDeliveryStatusFailed:
 		return true
 	default:
 		return false
 	}
 }
 
-// Defines values for ReportResponseStatus.
+// Defines values for IntegrationRequestKind.
 const (
-	ReportResponseStatusCompleted  ReportResponseStatus = "completed"
-	ReportResponseStatusFailed     ReportResponseStatus = "failed"
-	ReportResponseStatusGenerating ReportResponseStatus = "generating"
+	IntegrationRequestKindConsole IntegrationRequestKind = "console"
+	IntegrationRequestKindSmtp    IntegrationRequestKind = "smtp"
+	IntegrationRequestKindWebhook IntegrationRequestKind = "webhook"
 )
 
-// Valid indicates whether the value is a known member of the ReportResponseStatus enum.
-func (e ReportResponseStatus) Valid() bool {
+// Valid indicates whether the value is a known member of the IntegrationRequestKind enum.
+func (e IntegrationRequestKind) Valid() bool {
 	switch e {
-	case ReportResponseStatusCompleted:
+	case IntegrationRequestKindConsole:
 		return true
-	case ReportResponseStatusFailed:
+	case IntegrationRequestKindSmtp:
 		return true
-	case ReportResponseStatusGenerating:
+	case IntegrationRequestKindWebhook:
 		return true
 	default:
 		return false
 	}
 }
 
-// Defines values for SessionResponseStatus.
+// Defines values for InteractionWarningSeverity.
 const (
-	SessionResponseStatusActive    SessionResponseStatus = "active"
-	SessionResponseStatusCompleted SessionResponseStatus = "completed"
-	SessionResponseStatusExpired   SessionResponseStatus = "expired"
+	InteractionWarningSeverityHigh     InteractionWarningSeverity = "high"
+	InteractionWarningSeverityLow      InteractionWarningSeverity = "low"
+	InteractionWarningSeverityModerate InteractionWarningSeverity = "moderate"
 )
 
-// Valid indicates whether the value is a known member of the SessionResponseStatus enum.
-func (e SessionResponseStatus) Valid() bool {
+// Valid indicates whether the value is a known member of the InteractionWarningSeverity enum.
+func (e InteractionWarningSeverity) Valid() bool {
 	switch e {
-	case SessionResponseStatusActive:
+	case InteractionWarningSeverityHigh:
 		return true
-	case SessionResponseStatusCompleted:
+	case InteractionWarningSeverityLow:
 		return true
-	case SessionResponseStatusExpired:
+	case InteractionWarningSeverityModerate:
 		return true
 	default:
 		return false
 	}
 }
 
-// Defines values for SessionStatusStatus.
+// Defines values for InteractionWarningSource.
 const (
-	Active    SessionStatusStatus = "active"
-	Completed SessionStatusStatus = "completed"
-	Expired   SessionStatusStatus = "expired"
+	Ai    InteractionWarningSource = "ai"
+	Table InteractionWarningSource = "table"
 )
 
-// Valid indicates whether the value is a known member of the SessionStatusStatus enum.
-func (e SessionStatusStatus) Valid() bool {
+// Valid indicates whether the value is a known member of the InteractionWarningSource enum.
+func (e InteractionWarningSource) Valid() bool {
 	switch e {
-	case Active:
-		return true
-	case Completed:
+	case Ai:
 		return true
-	case Expired:
+	case Table:
 		return true
 	default:
 		return false
 	}
 }
 
-// Defines values for GetApiV1DashboardSummaryParamsDays.
+// Defines values for MenstruationRequestFlowIntensity.
 const (
-	N30 GetApiV1DashboardSummaryParamsDays = 30
-	N7  GetApiV1DashboardSummaryParamsDays = 7
-	N90 GetApiV1DashboardSummaryParamsDays = 90
+	MenstruationRequestFlowIntensityHeavy    MenstruationRequestFlowIntensity = "heavy"
+	MenstruationRequestFlowIntensityLight    MenstruationRequestFlowIntensity = "light"
+	MenstruationRequestFlowIntensityModerate MenstruationRequestFlowIntensity = "moderate"
 )
 
-// Valid indicates whether the value is a known member of the GetApiV1DashboardSummaryParamsDays enum.
-func (e GetApiV1DashboardSummaryParamsDays) Valid() bool {
+// Valid indicates whether the value is a known member of the MenstruationRequestFlowIntensity enum.
+func (e MenstruationRequestFlowIntensity) Valid() bool {
 	switch e {
-	case N30:
+	case MenstruationRequestFlowIntensityHeavy:
 		return true
-	case N7:
+	case MenstruationRequestFlowIntensityLight:
 		return true
-	case N90:
+	case MenstruationRequestFlowIntensityModerate:
 		return true
 	default:
 		return false
 	}
 }
 
-// BloodPressureRequest defines model for BloodPressureRequest.
-type BloodPressureRequest struct {
-	Diastolic  int                `json:"diastolic"`
-	MeasuredAt *time.Time         `json:"measured_at,omitempty"`
-	Pulse      int                `json:"pulse"`
-	Systolic   int                `json:"systolic"`
-	UserId     openapi_types.UUID `json:"user_id"`
-}
+// Defines values for MenstruationResponseFlowIntensity.
+const (
+	MenstruationResponseFlowIntensityHeavy    MenstruationResponseFlowIntensity = "heavy"
+	MenstruationResponseFlowIntensityLight    MenstruationResponseFlowIntensity = "light"
+	MenstruationResponseFlowIntensityModerate MenstruationResponseFlowIntensity = "moderate"
+)
 
-// BloodPressureResponse defines model for BloodPressureResponse.
-type BloodPressureResponse struct {
-	CreatedAt  *time.Time          `json:"created_at,omitempty"`
-	Diastolic  *int                `json:"diastolic,omitempty"`
-	Id         *openapi_types.UUID `json:"id,omitempty"`
-	MeasuredAt *time.Time          `json:"measured_at,omitempty"`
-	Pulse      *int                `json:"pulse,omitempty"`
-	Systolic   *int                `json:"systolic,omitempty"`
-	UserId     *openapi_types.UUID `json:"user_id,omitempty"`
+// Valid indicates whether the value is a known member of the MenstruationResponseFlowIntensity enum.
+func (e MenstruationResponseFlowIntensity) Valid() bool {
+	switch e {
+	case MenstruationResponseFlowIntensityHeavy:
+		return true
+	case MenstruationResponseFlowIntensityLight:
+		return true
+	case MenstruationResponseFlowIntensityModerate:
+		return true
+	default:
+		return false
+	}
 }
 
-// CompleteSessionRequest defines model for CompleteSessionRequest.
-type CompleteSessionRequest struct {
-	SessionId openapi_types.UUID `json:"session_id"`
+// Defines values for MenstruationUpdateRequestFlowIntensity.
+const (
+	MenstruationUpdateRequestFlowIntensityHeavy    MenstruationUpdateRequestFlowIntensity = "heavy"
+	MenstruationUpdateRequestFlowIntensityLight    MenstruationUpdateRequestFlowIntensity = "light"
+	MenstruationUpdateRequestFlowIntensityModerate MenstruationUpdateRequestFlowIntensity = "moderate"
+)
+
+// Valid indicates whether the value is a known member of the MenstruationUpdateRequestFlowIntensity enum.
+func (e MenstruationUpdateRequestFlowIntensity) Valid() bool {
+	switch e {
+	case MenstruationUpdateRequestFlowIntensityHeavy:
+		return true
+	case MenstruationUpdateRequestFlowIntensityLight:
+		return true
+	case MenstruationUpdateRequestFlowIntensityModerate:
+		return true
+	default:
+		return false
+	}
 }
 
-// ConversationStateResponse defines model for ConversationStateResponse.
-type ConversationStateResponse struct {
-	// IsComplete Whether all questions have been answered
-	IsComplete *bool   `json:"is_complete,omitempty"`
-	QuestionId *string `json:"question_id,omitempty"`
+// Defines values for MenstruationUpdateRequestSymptomsMode.
+const (
+	Append  MenstruationUpdateRequestSymptomsMode = "append"
+	Replace MenstruationUpdateRequestSymptomsMode = "replace"
+)
 
-	// QuestionText Next question in Hungarian
-	QuestionText *string             `json:"question_text,omitempty"`
-	SessionId    *openapi_types.UUID `json:"session_id,omitempty"`
+// Valid indicates whether the value is a known member of the MenstruationUpdateRequestSymptomsMode enum.
+func (e MenstruationUpdateRequestSymptomsMode) Valid() bool {
+	switch e {
+	case Append:
+		return true
+	case Replace:
+		return true
+	default:
+		return false
+	}
 }
 
-// CreateMedicationRequest defines model for CreateMedicationRequest.
-type CreateMedicationRequest struct {
-	Dosage    string              `json:"dosage"`
-	EndDate   *openapi_types.Date `json:"end_date,omitempty"`
-	Frequency string              `json:"frequency"`
-	Name      string              `json:"name"`
-	Notes     *string             `json:"notes,omitempty"`
-	StartDate openapi_types.Date  `json:"start_date"`
-	UserId    openapi_types.UUID  `json:"user_id"`
-}
+// Defines values for PanelFindingKind.
+const (
+	PanelFindingKindAlert            PanelFindingKind = "alert"
+	PanelFindingKindExtractionFailed PanelFindingKind = "extraction_failed"
+	PanelFindingKindExtractionIssue  PanelFindingKind = "extraction_issue"
+	PanelFindingKindLowConfidence    PanelFindingKind = "low_confidence"
+)
 
-// DailyMetrics defines model for DailyMetrics.
-type DailyMetrics struct {
-	Date         *openapi_types.Date `json:"date,omitempty"`
-	EnergyLevel  *string             `json:"energy_level,omitempty"`
-	Mood         *string             `json:"mood,omitempty"`
-	PainLevel    *int                `json:"pain_level,omitempty"`
-	SleepQuality *string             `json:"sleep_quality,omitempty"`
+// Valid indicates whether the value is a known member of the PanelFindingKind enum.
+func (e PanelFindingKind) Valid() bool {
+	switch e {
+	case PanelFindingKindAlert:
+		return true
+	case PanelFindingKindExtractionFailed:
+		return true
+	case PanelFindingKindExtractionIssue:
+		return true
+	case PanelFindingKindLowConfidence:
+		return true
+	default:
+		return false
+	}
 }
 
-// DashboardSummary defines model for DashboardSummary.
-type DashboardSummary struct {
-	AveragePain  *float64 `json:"average_pain,omitempty"`
-	CheckInCount *int     `json:"check_in_count,omitempty"`
-	EnergyLevels *struct {
-		High   *int `json:"high,omitempty"`
-		Low    *int `json:"low,omitempty"`
-		Medium *int `json:"medium,omitempty"`
-	} `json:"energy_levels,omitempty"`
-	MoodDistribution *struct {
-		Negative *int `json:"negative,omitempty"`
-		Neutral  *int `json:"neutral,omitempty"`
-		Positive *int `json:"positive,omitempty"`
-	} `json:"mood_distribution,omitempty"`
-	Period         *string         `json:"period,omitempty"`
-	TimeSeriesData *[]DailyMetrics `json:"time_series_data,omitempty"`
-}
+// Defines values for PanelFindingSeverity.
+const (
+	Critical PanelFindingSeverity = "critical"
+	High     PanelFindingSeverity = "high"
+	Low      PanelFindingSeverity = "low"
+	Medium   PanelFindingSeverity = "medium"
+)
 
-// ErrorResponse defines model for ErrorResponse.
-type ErrorResponse struct {
-	Code    string  `json:"code"`
-	Details *string `json:"details,omitempty"`
-	Message string  `json:"message"`
+// Valid indicates whether the value is a known member of the PanelFindingSeverity enum.
+func (e PanelFindingSeverity) Valid() bool {
+	switch e {
+	case Critical:
+		return true
+	case High:
+		return true
+	case Low:
+		return true
+	case Medium:
+		return true
+	default:
+		return false
+	}
 }
 
-// FitnessDataPoint defines model for FitnessDataPoint.
-type FitnessDataPoint struct {
-	DataType FitnessDataPointDataType `json:"data_type"`
-	Date     openapi_types.Date       `json:"date"`
-	Source   FitnessDataPointSource   `json:"source"`
+// Defines values for PauseSessionResponseStatus.
+const (
+	PauseSessionResponseStatusActive    PauseSessionResponseStatus = "active"
+	PauseSessionResponseStatusCompleted PauseSessionResponseStatus = "completed"
+	PauseSessionResponseStatusExpired   PauseSessionResponseStatus = "expired"
+	PauseSessionResponseStatusPaused    PauseSessionResponseStatus = "paused"
+)
 
-	// SourceDataId Original ID from Health Connect for deduplication
-	SourceDataId string               `json:"source_data_id"`
-	Unit         FitnessDataPointUnit `json:"unit"`
-	Value        float64              `json:"value"`
+// Valid indicates whether the value is a known member of the PauseSessionResponseStatus enum.
+func (e PauseSessionResponseStatus) Valid() bool {
+	switch e {
+	case PauseSessionResponseStatusActive:
+		return true
+	case PauseSessionResponseStatusCompleted:
+		return true
+	case PauseSessionResponseStatusExpired:
+		return true
+	case PauseSessionResponseStatusPaused:
+		return true
+	default:
+		return false
+	}
 }
 
-// FitnessDataPointDataType defines model for FitnessDataPoint.DataType.
-type FitnessDataPointDataType string
+// Defines values for PersonalAccessTokenScopes.
+const (
+	ExportRead  PersonalAccessTokenScopes = "export:read"
+	HealthRead  PersonalAccessTokenScopes = "health:read"
+	ReportsRead PersonalAccessTokenScopes = "reports:read"
+)
 
-// FitnessDataPointSource defines model for FitnessDataPoint.Source.
-type FitnessDataPointSource string
+// Valid indicates whether the value is a known member of the PersonalAccessTokenScopes enum.
+func (e PersonalAccessTokenScopes) Valid() bool {
+	switch e {
+	case ExportRead:
+		return true
+	case HealthRead:
+		return true
+	case ReportsRead:
+		return true
+	default:
+		return false
+	}
+}
 
-// FitnessDataPointUnit defines model for FitnessDataPoint.Unit.
-type FitnessDataPointUnit string
+// Defines values for QuestionSetQuestionType.
+const (
+	Numeric   QuestionSetQuestionType = "numeric"
+	OpenEnded QuestionSetQuestionType = "open_ended"
+	YesNo     QuestionSetQuestionType = "yes_no"
+)
 
-// FitnessSyncRequest defines model for FitnessSyncRequest.
-type FitnessSyncRequest struct {
-	DataPoints []FitnessDataPoint `json:"data_points"`
-	UserId     openapi_types.UUID `json:"user_id"`
+// Valid indicates whether the value is a known member of the QuestionSetQuestionType enum.
+func (e QuestionSetQuestionType) Valid() bool {
+	switch e {
+	case Numeric:
+		return true
+	case OpenEnded:
+		return true
+	case YesNo:
+		return true
+	default:
+		return false
+	}
 }
 
-// GenerateReportRequest defines model for GenerateReportRequest.
-type GenerateReportRequest struct {
-	EndDate   openapi_types.Date `json:"end_date"`
-	StartDate openapi_types.Date `json:"start_date"`
-	UserId    openapi_types.UUID `json:"user_id"`
-}
+// Defines values for ReportResponseStatus.
+const (
+	ReportResponseStatusCompleted  ReportResponseStatus = "completed"
+	ReportResponseStatusFailed     ReportResponseStatus = "failed"
+	ReportResponseStatusGenerating ReportResponseStatus = "generating"
+)
 
-// HealthCheckInResponse defines model for HealthCheckInResponse.
-type HealthCheckInResponse struct {
-	AdditionalNotes *string                           `json:"additional_notes,omitempty"`
-	CheckInDate     *openapi_types.Date               `json:"check_in_date,omitempty"`
-	CreatedAt       *time.Time                        `json:"created_at,omitempty"`
-	EnergyLevel     *HealthCheckInResponseEnergyLevel `json:"energy_level,omitempty"`
-	GeneralFeeling  *string                           `json:"general_feeling,omitempty"`
-	Id              *openapi_types.UUID               `json:"id,omitempty"`
-	Meals           *struct {
-		Breakfast *string `json:"breakfast,omitempty"`
-		Dinner    *string `json:"dinner,omitempty"`
-		Lunch     *string `json:"lunch,omitempty"`
-	} `json:"meals,omitempty"`
-	MedicationTaken  *HealthCheckInResponseMedicationTaken `json:"medication_taken,omitempty"`
-	Mood             *HealthCheckInResponseMood            `json:"mood,omitempty"`
-	PainLevel        *int                                  `json:"pain_level,omitempty"`
-	PhysicalActivity *[]string                             `json:"physical_activity,omitempty"`
-	SleepQuality     *HealthCheckInResponseSleepQuality    `json:"sleep_quality,omitempty"`
-	Symptoms         *[]string                             `json:"symptoms,omitempty"`
-	UserId           *openapi_types.UUID                   `json:"user_id,omitempty"`
+// Valid indicates whether the value is a known member of the ReportResponseStatus enum.
+func (e ReportResponseStatus) Valid() bool {
+	switch e {
+	case ReportResponseStatusCompleted:
+		return true
+	case ReportResponseStatusFailed:
+		return true
+	case ReportResponseStatusGenerating:
+		return true
+	default:
+		return false
+	}
 }
 
-// HealthCheckInResponseEnergyLevel defines model for HealthCheckInResponse.EnergyLevel.
-type HealthCheckInResponseEnergyLevel string
-
-// HealthCheckInResponseMedicationTaken defines model for HealthCheckInResponse.MedicationTaken.
-type HealthCheckInResponseMedicationTaken string
+// Defines values for ReportStatusStatus.
+const (
+	ReportStatusStatusCompleted  ReportStatusStatus = "completed"
+	ReportStatusStatusFailed     ReportStatusStatus = "failed"
+	ReportStatusStatusPending    ReportStatusStatus = "pending"
+	ReportStatusStatusProcessing ReportStatusStatus = "processing"
+)
 
-// HealthCheckInResponseMood defines model for HealthCheckInResponse.Mood.
-type HealthCheckInResponseMood string
+// Valid indicates whether the value is a known member of the ReportStatusStatus enum.
+func (e ReportStatusStatus) Valid() bool {
+	switch e {
+	case ReportStatusStatusCompleted:
+		return true
+	case ReportStatusStatusFailed:
+		return true
+	case ReportStatusStatusPending:
+		return true
+	case ReportStatusStatusProcessing:
+		return true
+	default:
+		return false
+	}
+}
 
-// HealthCheckInResponseSleepQuality defines model for HealthCheckInResponse.SleepQuality.
-type HealthCheckInResponseSleepQuality string
+// Defines values for ReportSummaryFormat.
+const (
+	ReportSummaryFormatCsv ReportSummaryFormat = "csv"
+	ReportSummaryFormatPdf ReportSummaryFormat = "pdf"
+)
 
-// HealthStatus defines model for HealthStatus.
-type HealthStatus struct {
-	Database *HealthStatusDatabase `json:"database,omitempty"`
-	Status   *HealthStatusStatus   `json:"status,omitempty"`
+// Valid indicates whether the value is a known member of the ReportSummaryFormat enum.
+func (e ReportSummaryFormat) Valid() bool {
+	switch e {
+	case ReportSummaryFormatCsv:
+		return true
+	case ReportSummaryFormatPdf:
+		return true
+	default:
+		return false
+	}
 }
 
-// HealthStatusDatabase defines model for HealthStatus.Database.
-type HealthStatusDatabase string
-
-// HealthStatusStatus defines model for HealthStatus.Status.
-type HealthStatusStatus string
+// Defines values for ReportSummarySections.
+const (
+	ReportSummarySectionsActivity      ReportSummarySections = "activity"
+	ReportSummarySectionsAdherence     ReportSummarySections = "adherence"
+	ReportSummarySectionsBloodPressure ReportSummarySections = "blood_pressure"
+	ReportSummarySectionsMeals         ReportSummarySections = "meals"
+	ReportSummarySectionsMedications   ReportSummarySections = "medications"
+	ReportSummarySectionsMenstruation  ReportSummarySections = "menstruation"
+	ReportSummarySectionsSummaries     ReportSummarySections = "summaries"
+	ReportSummarySectionsSymptoms      ReportSummarySections = "symptoms"
+)
 
-// MedicationResponse defines model for MedicationResponse.
-type MedicationResponse struct {
-	Active    *bool               `json:"active,omitempty"`
-	CreatedAt *time.Time          `json:"created_at,omitempty"`
-	Dosage    *string             `json:"dosage,omitempty"`
-	EndDate   *openapi_types.Date `json:"end_date,omitempty"`
-	Frequency *string             `json:"frequency,omitempty"`
-	Id        *openapi_types.UUID `json:"id,omitempty"`
-	Name      *string             `json:"name,omitempty"`
-	Notes     *string             `json:"notes,omitempty"`
-	StartDate *openapi_types.Date `json:"start_date,omitempty"`
-	UserId    *openapi_types.UUID `json:"user_id,omitempty"`
+// Valid indicates whether the value is a known member of the ReportSummarySections enum.
+func (e ReportSummarySections) Valid() bool {
+	switch e {
+	case ReportSummarySectionsActivity:
+		return true
+	case ReportSummarySectionsAdherence:
+		return true
+	case ReportSummarySectionsBloodPressure:
+		return true
+	case ReportSummarySectionsMeals:
+		return true
+	case ReportSummarySectionsMedications:
+		return true
+	case ReportSummarySectionsMenstruation:
+		return true
+	case ReportSummarySectionsSummaries:
+		return true
+	case ReportSummarySectionsSymptoms:
+		return true
+	default:
+		return false
+	}
 }
 
-// MenstruationRequest defines model for MenstruationRequest.
-type MenstruationRequest struct {
-	EndDate       *openapi_types.Date               `json:"end_date,omitempty"`
-	FlowIntensity *MenstruationRequestFlowIntensity `json:"flow_intensity,omitempty"`
+// Defines values for ReportSummaryStatus.
+const (
+	ReportSummaryStatusCompleted  ReportSummaryStatus = "completed"
+	ReportSummaryStatusFailed     ReportSummaryStatus = "failed"
+	ReportSummaryStatusPending    ReportSummaryStatus = "pending"
+	ReportSummaryStatusProcessing ReportSummaryStatus = "processing"
+)
+
+// Valid indicates whether the value is a known member of the ReportSummaryStatus enum.
+func (e ReportSummaryStatus) Valid() bool {
+	switch e {
+	case ReportSummaryStatusCompleted:
+		return true
+	case ReportSummaryStatusFailed:
+		return true
+	case ReportSummaryStatusPending:
+		return true
+	case ReportSummaryStatusProcessing:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for Role.
+const (
+	Caregiver   Role = "caregiver"
+	Clinician   Role = "clinician"
+	OrgAdmin    Role = "org_admin"
+	Patient     Role = "patient"
+	SystemAdmin Role = "system_admin"
+)
+
+// Valid indicates whether the value is a known member of the Role enum.
+func (e Role) Valid() bool {
+	switch e {
+	case Caregiver:
+		return true
+	case Clinician:
+		return true
+	case OrgAdmin:
+		return true
+	case Patient:
+		return true
+	case SystemAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for SessionResponseStatus.
+const (
+	SessionResponseStatusActive    SessionResponseStatus = "active"
+	SessionResponseStatusCompleted SessionResponseStatus = "completed"
+	SessionResponseStatusExpired   SessionResponseStatus = "expired"
+)
+
+// Valid indicates whether the value is a known member of the SessionResponseStatus enum.
+func (e SessionResponseStatus) Valid() bool {
+	switch e {
+	case SessionResponseStatusActive:
+		return true
+	case SessionResponseStatusCompleted:
+		return true
+	case SessionResponseStatusExpired:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for SessionStatusStatus.
+const (
+	Active    SessionStatusStatus = "active"
+	Completed SessionStatusStatus = "completed"
+	Expired   SessionStatusStatus = "expired"
+)
+
+// Valid indicates whether the value is a known member of the SessionStatusStatus enum.
+func (e SessionStatusStatus) Valid() bool {
+	switch e {
+	case Active:
+		return true
+	case Completed:
+		return true
+	case Expired:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for TimelineEventType.
+const (
+	TimelineEventTypeAlert                TimelineEventType = "alert"
+	TimelineEventTypeBloodPressureReading TimelineEventType = "blood_pressure_reading"
+	TimelineEventTypeCheckIn              TimelineEventType = "check_in"
+	TimelineEventTypeFitnessData          TimelineEventType = "fitness_data"
+	TimelineEventTypeGdpr                 TimelineEventType = "gdpr"
+	TimelineEventTypeMedication           TimelineEventType = "medication"
+	TimelineEventTypeMedicationLog        TimelineEventType = "medication_log"
+	TimelineEventTypeMenstruationCycle    TimelineEventType = "menstruation_cycle"
+	TimelineEventTypeReport               TimelineEventType = "report"
+	TimelineEventTypeSessionCompleted     TimelineEventType = "session_completed"
+	TimelineEventTypeSessionExpired       TimelineEventType = "session_expired"
+	TimelineEventTypeSessionPaused        TimelineEventType = "session_paused"
+	TimelineEventTypeSessionResumed       TimelineEventType = "session_resumed"
+	TimelineEventTypeSessionStarted       TimelineEventType = "session_started"
+)
+
+// Valid indicates whether the value is a known member of the TimelineEventType enum.
+func (e TimelineEventType) Valid() bool {
+	switch e {
+	case TimelineEventTypeAlert:
+		return true
+	case TimelineEventTypeBloodPressureReading:
+		return true
+	case TimelineEventTypeCheckIn:
+		return true
+	case TimelineEventTypeFitnessData:
+		return true
+	case TimelineEventTypeGdpr:
+		return true
+	case TimelineEventTypeMedication:
+		return true
+	case TimelineEventTypeMedicationLog:
+		return true
+	case TimelineEventTypeMenstruationCycle:
+		return true
+	case TimelineEventTypeReport:
+		return true
+	case TimelineEventTypeSessionCompleted:
+		return true
+	case TimelineEventTypeSessionExpired:
+		return true
+	case TimelineEventTypeSessionPaused:
+		return true
+	case TimelineEventTypeSessionResumed:
+		return true
+	case TimelineEventTypeSessionStarted:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for UserConsentConsentType.
+const (
+	UserConsentConsentTypeDataProcessing  UserConsentConsentType = "data_processing"
+	UserConsentConsentTypeResearchSharing UserConsentConsentType = "research_sharing"
+	UserConsentConsentTypeVoiceRecording  UserConsentConsentType = "voice_recording"
+)
+
+// Valid indicates whether the value is a known member of the UserConsentConsentType enum.
+func (e UserConsentConsentType) Valid() bool {
+	switch e {
+	case UserConsentConsentTypeDataProcessing:
+		return true
+	case UserConsentConsentTypeResearchSharing:
+		return true
+	case UserConsentConsentTypeVoiceRecording:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for WebhookEvents.
+const (
+	WebhookEventsCheckinCompleted WebhookEvents = "checkin.completed"
+	WebhookEventsMedicationAdded  WebhookEvents = "medication.added"
+)
+
+// Valid indicates whether the value is a known member of the WebhookEvents enum.
+func (e WebhookEvents) Valid() bool {
+	switch e {
+	case WebhookEventsCheckinCompleted:
+		return true
+	case WebhookEventsMedicationAdded:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for GetApiV1AdminAuditLogsParamsOperationType.
+const (
+	GetApiV1AdminAuditLogsParamsOperationTypeANONYMIZE GetApiV1AdminAuditLogsParamsOperationType = "ANONYMIZE"
+	GetApiV1AdminAuditLogsParamsOperationTypeCREATE    GetApiV1AdminAuditLogsParamsOperationType = "CREATE"
+	GetApiV1AdminAuditLogsParamsOperationTypeDELETE    GetApiV1AdminAuditLogsParamsOperationType = "DELETE"
+	GetApiV1AdminAuditLogsParamsOperationTypeREAD      GetApiV1AdminAuditLogsParamsOperationType = "READ"
+	GetApiV1AdminAuditLogsParamsOperationTypeUPDATE    GetApiV1AdminAuditLogsParamsOperationType = "UPDATE"
+)
+
+// Valid indicates whether the value is a known member of the GetApiV1AdminAuditLogsParamsOperationType enum.
+func (e GetApiV1AdminAuditLogsParamsOperationType) Valid() bool {
+	switch e {
+	case GetApiV1AdminAuditLogsParamsOperationTypeANONYMIZE:
+		return true
+	case GetApiV1AdminAuditLogsParamsOperationTypeCREATE:
+		return true
+	case GetApiV1AdminAuditLogsParamsOperationTypeDELETE:
+		return true
+	case GetApiV1AdminAuditLogsParamsOperationTypeREAD:
+		return true
+	case GetApiV1AdminAuditLogsParamsOperationTypeUPDATE:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for GetApiV1AuditLogsParamsOperationType.
+const (
+	ANONYMIZE GetApiV1AuditLogsParamsOperationType = "ANONYMIZE"
+	CREATE    GetApiV1AuditLogsParamsOperationType = "CREATE"
+	DELETE    GetApiV1AuditLogsParamsOperationType = "DELETE"
+	READ      GetApiV1AuditLogsParamsOperationType = "READ"
+	UPDATE    GetApiV1AuditLogsParamsOperationType = "UPDATE"
+)
+
+// Valid indicates whether the value is a known member of the GetApiV1AuditLogsParamsOperationType enum.
+func (e GetApiV1AuditLogsParamsOperationType) Valid() bool {
+	switch e {
+	case ANONYMIZE:
+		return true
+	case CREATE:
+		return true
+	case DELETE:
+		return true
+	case READ:
+		return true
+	case UPDATE:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for GetApiV1DashboardSummaryParamsDays.
+const (
+	N30 GetApiV1DashboardSummaryParamsDays = 30
+	N7  GetApiV1DashboardSummaryParamsDays = 7
+	N90 GetApiV1DashboardSummaryParamsDays = 90
+)
+
+// Valid indicates whether the value is a known member of the GetApiV1DashboardSummaryParamsDays enum.
+func (e GetApiV1DashboardSummaryParamsDays) Valid() bool {
+	switch e {
+	case N30:
+		return true
+	case N7:
+		return true
+	case N90:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for GetApiV1ExportHealthParamsType.
+const (
+	BloodPressure GetApiV1ExportHealthParamsType = "blood_pressure"
+	Fitness       GetApiV1ExportHealthParamsType = "fitness"
+	Medications   GetApiV1ExportHealthParamsType = "medications"
+	Menstruation  GetApiV1ExportHealthParamsType = "menstruation"
+)
+
+// Valid indicates whether the value is a known member of the GetApiV1ExportHealthParamsType enum.
+func (e GetApiV1ExportHealthParamsType) Valid() bool {
+	switch e {
+	case BloodPressure:
+		return true
+	case Fitness:
+		return true
+	case Medications:
+		return true
+	case Menstruation:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for GetApiV1HealthBloodPressureChartParamsGranularity.
+const (
+	Daily  GetApiV1HealthBloodPressureChartParamsGranularity = "daily"
+	Weekly GetApiV1HealthBloodPressureChartParamsGranularity = "weekly"
+)
+
+// Valid indicates whether the value is a known member of the GetApiV1HealthBloodPressureChartParamsGranularity enum.
+func (e GetApiV1HealthBloodPressureChartParamsGranularity) Valid() bool {
+	switch e {
+	case Daily:
+		return true
+	case Weekly:
+		return true
+	default:
+		return false
+	}
+}
+
+// AcceptInvitationRequest defines model for AcceptInvitationRequest.
+type AcceptInvitationRequest struct {
+	Token string `json:"token"`
+}
+
+// ActiveSessionExistsResponse Conflict of starting a check-in while another session of the user is active
+type ActiveSessionExistsResponse struct {
+	Code    string  `json:"code"`
+	Details *string `json:"details,omitempty"`
+	Error   string  `json:"error"`
+	Message string  `json:"message"`
+
+	// SessionId Active session to resume
+	SessionId openapi_types.UUID `json:"session_id"`
+}
+
+// AdherenceSummary Share of the expected doses in the period that were logged as taken, null when no dose was expected
+type AdherenceSummary struct {
+	Medications []MedicationAdherenceRate `json:"medications"`
+	Rate        *float64                  `json:"rate"`
+}
+
+// Alert defines model for Alert.
+type Alert struct {
+	Acknowledged   bool       `json:"acknowledged"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+
+	// CheckInId Check-in that raised the alert, omitted for alerts from other readings
+	CheckInId *openapi_types.UUID `json:"check_in_id,omitempty"`
+	CreatedAt time.Time           `json:"created_at"`
+	Id        openapi_types.UUID  `json:"id"`
+	Reason    string              `json:"reason"`
+	Severity  string              `json:"severity"`
+	Source    string              `json:"source"`
+	UserId    openapi_types.UUID  `json:"user_id"`
+}
+
+// Anomaly defines model for Anomaly.
+type Anomaly struct {
+	// Baseline Mean of the user's recent readings
+	Baseline  *float32           `json:"baseline,omitempty"`
+	CreatedAt time.Time          `json:"created_at"`
+	Id        openapi_types.UUID `json:"id"`
+
+	// Metric systolic, diastolic, pulse or pain_level
+	Metric     string    `json:"metric"`
+	ObservedAt time.Time `json:"observed_at"`
+	Reason     string    `json:"reason"`
+
+	// Severity critical, high or moderate
+	Severity string             `json:"severity"`
+	SourceId openapi_types.UUID `json:"source_id"`
+
+	// SourceType blood_pressure or check_in
+	SourceType string `json:"source_type"`
+
+	// Threshold Limit the value crossed
+	Threshold float32            `json:"threshold"`
+	UserId    openapi_types.UUID `json:"user_id"`
+	Value     float32            `json:"value"`
+}
+
+// AnomalyPage defines model for AnomalyPage.
+type AnomalyPage struct {
+	Items []Anomaly `json:"items"`
+
+	// NextCursor Cursor of the next page, null on the last page
+	NextCursor *string `json:"next_cursor"`
+
+	// TotalCount Number of items across all pages
+	TotalCount int `json:"total_count"`
+}
+
+// AnonymizeRequest defines model for AnonymizeRequest.
+type AnonymizeRequest struct {
+	UserId openapi_types.UUID `json:"user_id"`
+}
+
+// AssignPatientRequest defines model for AssignPatientRequest.
+type AssignPatientRequest struct {
+	ClinicianId openapi_types.UUID `json:"clinician_id"`
+	PatientId   openapi_types.UUID `json:"patient_id"`
+}
+
+// AssignQuestionSetRequest defines model for AssignQuestionSetRequest.
+type AssignQuestionSetRequest struct {
+	// QuestionSetId Question set to assign, null for the built-in set
+	QuestionSetId *openapi_types.UUID `json:"question_set_id"`
+}
+
+// AssignRoleRequest defines model for AssignRoleRequest.
+type AssignRoleRequest struct {
+	// Role Role of a user, in an organization or system-wide
+	Role Role `json:"role"`
+}
+
+// AuditArchiveRestore defines model for AuditArchiveRestore.
+type AuditArchiveRestore struct {
+	// Month Restored month as YYYY-MM
+	Month         string `json:"month"`
+	RestoredCount int64  `json:"restored_count"`
+
+	// RestoredUntil When the restored logs are removed again
+	RestoredUntil time.Time `json:"restored_until"`
+}
+
+// AuditLog Audit trail entry of an operation on health data
+type AuditLog struct {
+	AdditionalData *map[string]interface{} `json:"additional_data,omitempty"`
+	Id             openapi_types.UUID      `json:"id"`
+	IpAddress      *string                 `json:"ip_address,omitempty"`
+	OperationType  AuditLogOperationType   `json:"operation_type"`
+	ResourceId     string                  `json:"resource_id"`
+
+	// ResourceType Kind of resource accessed, such as health_check_in or medication
+	ResourceType string             `json:"resource_type"`
+	Timestamp    time.Time          `json:"timestamp"`
+	UserAgent    *string            `json:"user_agent,omitempty"`
+	UserId       openapi_types.UUID `json:"user_id"`
+}
+
+// AuditLogOperationType defines model for AuditLog.OperationType.
+type AuditLogOperationType string
+
+// AuditLogPage defines model for AuditLogPage.
+type AuditLogPage struct {
+	// ArchivedMonths Months in the queried window whose logs were archived and are missing from the page until restored
+	ArchivedMonths *[]string  `json:"archived_months,omitempty"`
+	Items          []AuditLog `json:"items"`
+
+	// NextCursor Cursor of the next page, null on the last page
+	NextCursor *string `json:"next_cursor"`
+	TotalCount int     `json:"total_count"`
+}
+
+// BloodPressureCategoryCounts Number of blood pressure readings in the period per category, see BloodPressureResponse.category
+type BloodPressureCategoryCounts struct {
+	Crisis   *int `json:"crisis,omitempty"`
+	Elevated *int `json:"elevated,omitempty"`
+	Normal   *int `json:"normal,omitempty"`
+	Stage1   *int `json:"stage_1,omitempty"`
+	Stage2   *int `json:"stage_2,omitempty"`
+}
+
+// BloodPressureChartPoint defines model for BloodPressureChartPoint.
+type BloodPressureChartPoint struct {
+	AvgDiastolic float64 `json:"avg_diastolic"`
+	AvgPulse     float64 `json:"avg_pulse"`
+	AvgSystolic  float64 `json:"avg_systolic"`
+
+	// Date Day, or the Monday starting the week
+	Date openapi_types.Date `json:"date"`
+
+	// ReadingCount Readings in the period, 0 for an empty point
+	ReadingCount int `json:"reading_count"`
+}
+
+// BloodPressurePage defines model for BloodPressurePage.
+type BloodPressurePage struct {
+	Items []BloodPressureResponse `json:"items"`
+
+	// NextCursor Cursor of the next page, null on the last page
+	NextCursor *string `json:"next_cursor"`
+
+	// TotalCount Number of items across all pages
+	TotalCount int `json:"total_count"`
+}
+
+// BloodPressureRequest defines model for BloodPressureRequest.
+type BloodPressureRequest struct {
+	Diastolic  int        `json:"diastolic"`
+	MeasuredAt *time.Time `json:"measured_at,omitempty"`
+
+	// Notes Context of the reading, e.g. "after exercise"
+	Notes    *string            `json:"notes,omitempty"`
+	Pulse    int                `json:"pulse"`
+	Systolic int                `json:"systolic"`
+	UserId   openapi_types.UUID `json:"user_id"`
+}
+
+// BloodPressureResponse defines model for BloodPressureResponse.
+type BloodPressureResponse struct {
+	// Category American Heart Association category: normal below 120/80, elevated at 120-129 systolic and below 80 diastolic, stage_1 at 130-139 or 80-89, stage_2 at 140 or 90 and above, crisis above 180 or 120. The higher category of systolic and diastolic applies.
+	Category   *BloodPressureResponseCategory `json:"category,omitempty"`
+	CreatedAt  *time.Time                     `json:"created_at,omitempty"`
+	Diastolic  *int                           `json:"diastolic,omitempty"`
+	Id         *openapi_types.UUID            `json:"id,omitempty"`
+	MeasuredAt *time.Time                     `json:"measured_at,omitempty"`
+
+	// Notes Context of the reading given by the patient, omitted without one
+	Notes    *string             `json:"notes,omitempty"`
+	Pulse    *int                `json:"pulse,omitempty"`
+	Systolic *int                `json:"systolic,omitempty"`
+	UserId   *openapi_types.UUID `json:"user_id,omitempty"`
+}
+
+// BloodPressureResponseCategory American Heart Association category: normal below 120/80, elevated at 120-129 systolic and below 80 diastolic, stage_1 at 130-139 or 80-89, stage_2 at 140 or 90 and above, crisis above 180 or 120. The higher category of systolic and diastolic applies.
+type BloodPressureResponseCategory string
+
+// BloodPressureTrend Average blood pressure of the last 7 days against the 7 days before; averages are null for a week without readings, deltas and direction need readings in both
+type BloodPressureTrend struct {
+	AverageDiastolic *float64 `json:"average_diastolic"`
+	AverageSystolic  *float64 `json:"average_systolic"`
+	DiastolicDelta   *float64 `json:"diastolic_delta"`
+
+	// Direction Follows the systolic delta, flat within 2 mmHg
+	Direction                *BloodPressureTrendDirection `json:"direction,omitempty"`
+	PreviousAverageDiastolic *float64                     `json:"previous_average_diastolic"`
+	PreviousAverageSystolic  *float64                     `json:"previous_average_systolic"`
+	SystolicDelta            *float64                     `json:"systolic_delta"`
+}
+
+// BloodPressureTrendDirection Follows the systolic delta, flat within 2 mmHg
+type BloodPressureTrendDirection string
+
+// CheckInPatchRequest Fields of the check-in to correct; omitted fields are kept
+type CheckInPatchRequest struct {
+	AdditionalNotes *string `json:"additional_notes,omitempty"`
+
+	// EnergyLevel low, medium or high
+	EnergyLevel    *string `json:"energy_level,omitempty"`
+	GeneralFeeling *string `json:"general_feeling,omitempty"`
+	Meals          *struct {
+		Breakfast *string `json:"breakfast,omitempty"`
+		Dinner    *string `json:"dinner,omitempty"`
+		Lunch     *string `json:"lunch,omitempty"`
+	} `json:"meals,omitempty"`
+
+	// MedicationTaken yes, no or some
+	MedicationTaken *string `json:"medication_taken,omitempty"`
+
+	// Mood positive, neutral or negative
+	Mood             *string   `json:"mood,omitempty"`
+	PainLevel        *int      `json:"pain_level,omitempty"`
+	PhysicalActivity *[]string `json:"physical_activity,omitempty"`
+
+	// SleepQuality poor, fair, good or excellent
+	SleepQuality *string   `json:"sleep_quality,omitempty"`
+	Symptoms     *[]string `json:"symptoms,omitempty"`
+}
+
+// CompleteSessionRequest defines model for CompleteSessionRequest.
+type CompleteSessionRequest struct {
+	SessionId openapi_types.UUID `json:"session_id"`
+}
+
+// ConsentRequest defines model for ConsentRequest.
+type ConsentRequest struct {
+	ConsentType ConsentRequestConsentType `json:"consent_type"`
+
+	// Granted True grants the consent, false revokes it
+	Granted bool               `json:"granted"`
+	UserId  openapi_types.UUID `json:"user_id"`
+}
+
+// ConsentRequestConsentType defines model for ConsentRequest.ConsentType.
+type ConsentRequestConsentType string
+
+// ConversationStateResponse defines model for ConversationStateResponse.
+type ConversationStateResponse struct {
+	// AudioAvailable Whether the question comes with audio; omitted without a question. False while speech synthesis is failing, so clients can show a text-only notice
+	AudioAvailable *bool `json:"audio_available,omitempty"`
+
+	// AudioError Why the question has no audio, omitted while audio is available
+	AudioError *string `json:"audio_error,omitempty"`
+
+	// DebugTiming Per-stage latency of a check-in request, returned with debug timing enabled
+	DebugTiming *TimingBreakdown `json:"debug_timing,omitempty"`
+
+	// IsComplete Whether all questions have been answered
+	IsComplete *bool `json:"is_complete,omitempty"`
+
+	// IsFollowup Whether the next question is an adaptive follow-up
+	IsFollowup *bool   `json:"is_followup,omitempty"`
+	QuestionId *string `json:"question_id,omitempty"`
+
+	// QuestionText Next question in Hungarian
+	QuestionText *string             `json:"question_text,omitempty"`
+	SessionId    *openapi_types.UUID `json:"session_id,omitempty"`
+}
+
+// CreateMedicationRequest defines model for CreateMedicationRequest.
+type CreateMedicationRequest struct {
+	Dosage    string              `json:"dosage"`
+	EndDate   *openapi_types.Date `json:"end_date,omitempty"`
+	Frequency string              `json:"frequency"`
+	Name      string              `json:"name"`
+	Notes     *string             `json:"notes,omitempty"`
+	StartDate openapi_types.Date  `json:"start_date"`
+	UserId    openapi_types.UUID  `json:"user_id"`
+}
+
+// CreateOrganizationRequest defines model for CreateOrganizationRequest.
+type CreateOrganizationRequest struct {
+	// DataResidency Storage backend for the artifacts of its patients, the default storage account when omitted
+	DataResidency *string `json:"data_residency,omitempty"`
+	Name          string  `json:"name"`
+}
+
+// CreateQuestionSetRequest defines model for CreateQuestionSetRequest.
+type CreateQuestionSetRequest struct {
+	Language  *string               `json:"language,omitempty"`
+	Name      string                `json:"name"`
+	Questions []QuestionSetQuestion `json:"questions"`
+}
+
+// CreateTokenRequest defines model for CreateTokenRequest.
+type CreateTokenRequest struct {
+	// ExpiresAt Expiry of the token, 90 days after creation when omitted
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Name      string     `json:"name"`
+
+	// Scopes Read-only permissions of the token
+	Scopes []CreateTokenRequestScopes `json:"scopes"`
+}
+
+// CreateTokenRequestScopes defines model for CreateTokenRequest.Scopes.
+type CreateTokenRequestScopes string
+
+// CreateWebhookRequest defines model for CreateWebhookRequest.
+type CreateWebhookRequest struct {
+	Events []CreateWebhookRequestEvents `json:"events"`
+
+	// TargetUrl HTTPS endpoint the events are posted to
+	TargetUrl string             `json:"target_url"`
+	UserId    openapi_types.UUID `json:"user_id"`
+}
+
+// CreateWebhookRequestEvents defines model for CreateWebhookRequest.Events.
+type CreateWebhookRequestEvents string
+
+// CreatedToken defines model for CreatedToken.
+type CreatedToken struct {
+	CreatedAt  time.Time          `json:"created_at"`
+	ExpiresAt  time.Time          `json:"expires_at"`
+	Id         openapi_types.UUID `json:"id"`
+	LastUsedAt *time.Time         `json:"last_used_at,omitempty"`
+	Name       string             `json:"name"`
+
+	// Prefix First characters of the token, to tell tokens apart
+	Prefix    string               `json:"prefix"`
+	RevokedAt *time.Time           `json:"revoked_at,omitempty"`
+	Scopes    []CreatedTokenScopes `json:"scopes"`
+
+	// Token The token, returned once; it cannot be retrieved again
+	Token  string             `json:"token"`
+	UserId openapi_types.UUID `json:"user_id"`
+}
+
+// CreatedTokenScopes defines model for CreatedToken.Scopes.
+type CreatedTokenScopes string
+
+// CycleLength Computed lengths of one completed cycle
+type CycleLength struct {
+	CycleId openapi_types.UUID `json:"cycle_id"`
+
+	// CycleLengthDays Days until the next completed cycle starts, omitted for the latest cycle
+	CycleLengthDays    *int      `json:"cycle_length_days,omitempty"`
+	EndDate            time.Time `json:"end_date"`
+	PeriodDurationDays int       `json:"period_duration_days"`
+	StartDate          time.Time `json:"start_date"`
+}
+
+// CyclePrediction Estimated start of the next menstruation cycle
+type CyclePrediction struct {
+	AverageCycleLengthDays float64 `json:"average_cycle_length_days"`
+
+	// Confidence Depends on how many cycles were logged
+	Confidence CyclePredictionConfidence `json:"confidence"`
+
+	// CycleRegularityScore Coefficient of variation of cycle lengths capped at 1; lower is more regular
+	CycleRegularityScore float64   `json:"cycle_regularity_score"`
+	PredictedStartDate   time.Time `json:"predicted_start_date"`
+}
+
+// CyclePredictionConfidence Depends on how many cycles were logged
+type CyclePredictionConfidence string
+
+// CycleStats Summary of the completed menstruation cycles of a user
+type CycleStats struct {
+	AverageCycleLengthDays    *float64      `json:"average_cycle_length_days,omitempty"`
+	AveragePeriodDurationDays *float64      `json:"average_period_duration_days,omitempty"`
+	CompletedCycles           int           `json:"completed_cycles"`
+	CycleLengthStdDevDays     *float64      `json:"cycle_length_std_dev_days,omitempty"`
+	Cycles                    []CycleLength `json:"cycles"`
+	LongestCycleDays          *int          `json:"longest_cycle_days,omitempty"`
+	ShortestCycleDays         *int          `json:"shortest_cycle_days,omitempty"`
+
+	// SufficientData False with fewer than two completed cycles; cycle length figures are then omitted
+	SufficientData bool `json:"sufficient_data"`
+}
+
+// CycleSuggestion Proposal to log a menstruation cycle for check-ins with menstrual symptoms outside any recorded cycle
+type CycleSuggestion struct {
+	CheckInIds []openapi_types.UUID `json:"check_in_ids"`
+	CreatedAt  time.Time            `json:"created_at"`
+
+	// CycleId Cycle created when the suggestion was accepted
+	CycleId *openapi_types.UUID `json:"cycle_id,omitempty"`
+
+	// EndDate Last check-in mentioning menstrual symptoms
+	EndDate    time.Time          `json:"end_date"`
+	Id         openapi_types.UUID `json:"id"`
+	Message    string             `json:"message"`
+	ResolvedAt *time.Time         `json:"resolved_at,omitempty"`
+
+	// StartDate First check-in mentioning menstrual symptoms
+	StartDate time.Time             `json:"start_date"`
+	Status    CycleSuggestionStatus `json:"status"`
+	Symptoms  []string              `json:"symptoms"`
+	UserId    openapi_types.UUID    `json:"user_id"`
+}
+
+// CycleSuggestionStatus defines model for CycleSuggestion.Status.
+type CycleSuggestionStatus string
+
+// DailyMetrics defines model for DailyMetrics.
+type DailyMetrics struct {
+	Date         *openapi_types.Date `json:"date,omitempty"`
+	EnergyLevel  *string             `json:"energy_level,omitempty"`
+	Mood         *string             `json:"mood,omitempty"`
+	PainLevel    *int                `json:"pain_level,omitempty"`
+	SleepQuality *string             `json:"sleep_quality,omitempty"`
+}
+
+// DashboardAlerts Unacknowledged alerts of the user and the latest ones
+type DashboardAlerts struct {
+	Critical       int     `json:"critical"`
+	Latest         []Alert `json:"latest"`
+	Unacknowledged int     `json:"unacknowledged"`
+}
+
+// DashboardSummary defines model for DashboardSummary.
+type DashboardSummary struct {
+	// Adherence Share of the expected doses in the period that were logged as taken, null when no dose was expected
+	Adherence       *AdherenceSummary      `json:"adherence,omitempty"`
+	AdherenceScores *[]MedicationAdherence `json:"adherence_scores,omitempty"`
+
+	// Alerts Unacknowledged alerts of the user and the latest ones
+	Alerts      *DashboardAlerts `json:"alerts,omitempty"`
+	AveragePain *float64         `json:"average_pain,omitempty"`
+
+	// AverageSleepMinutes Average nightly sleep in the period from synced sleep data, omitted without any
+	AverageSleepMinutes *float64 `json:"average_sleep_minutes,omitempty"`
+
+	// BloodPressureCategories Number of blood pressure readings in the period per category, see BloodPressureResponse.category
+	BloodPressureCategories *BloodPressureCategoryCounts `json:"blood_pressure_categories,omitempty"`
+
+	// BloodPressureTrend Average blood pressure of the last 7 days against the 7 days before; averages are null for a week without readings, deltas and direction need readings in both
+	BloodPressureTrend *BloodPressureTrend `json:"blood_pressure_trend,omitempty"`
+	CheckInCount       *int                `json:"check_in_count,omitempty"`
+
+	// CheckInCountTrend Change of a summary metric from the preceding window of the same length. The mood trend is of the positive mood share (0 to 1). Fields are null where the change is undefined: without data in a window, or for percent_change when the previous value is 0.
+	CheckInCountTrend MetricTrend `json:"check_in_count_trend"`
+
+	// Comparison Change from the preceding period, returned with compare_previous=true
+	Comparison   *SummaryComparison `json:"comparison,omitempty"`
+	EnergyLevels *struct {
+		High   *int `json:"high,omitempty"`
+		Low    *int `json:"low,omitempty"`
+		Medium *int `json:"medium,omitempty"`
+	} `json:"energy_levels,omitempty"`
+
+	// LatestWeightKg Most recent synced body weight in the period, omitted without any
+	LatestWeightKg *float64 `json:"latest_weight_kg,omitempty"`
+
+	// LowConfidenceRate Share of check-ins with a low confidence extraction
+	LowConfidenceRate float64 `json:"low_confidence_rate"`
+
+	// MedicationTaken Number of check-ins per medication_taken answer
+	MedicationTaken  *map[string]int `json:"medication_taken,omitempty"`
+	MoodDistribution *struct {
+		Negative *int `json:"negative,omitempty"`
+		Neutral  *int `json:"neutral,omitempty"`
+		Positive *int `json:"positive,omitempty"`
+	} `json:"mood_distribution,omitempty"`
+
+	// MoodTrend Change of a summary metric from the preceding window of the same length. The mood trend is of the positive mood share (0 to 1). Fields are null where the change is undefined: without data in a window, or for percent_change when the previous value is 0.
+	MoodTrend MetricTrend `json:"mood_trend"`
+
+	// PainTrend Change of a summary metric from the preceding window of the same length. The mood trend is of the positive mood share (0 to 1). Fields are null where the change is undefined: without data in a window, or for percent_change when the previous value is 0.
+	PainTrend      MetricTrend     `json:"pain_trend"`
+	Period         *string         `json:"period,omitempty"`
+	TimeSeriesData *[]DailyMetrics `json:"time_series_data,omitempty"`
+}
+
+// DataResidencyRequest defines model for DataResidencyRequest.
+type DataResidencyRequest struct {
+	// DataResidency One of the AZURE_STORAGE_RESIDENCIES names; empty or default clears it
+	DataResidency string `json:"data_residency"`
+}
+
+// DiagnosticResult defines model for DiagnosticResult.
+type DiagnosticResult struct {
+	// Critical Whether a failure stops the server at startup
+	Critical   bool    `json:"critical"`
+	DurationMs int64   `json:"duration_ms"`
+	Error      *string `json:"error,omitempty"`
+	Name       string  `json:"name"`
+
+	// Remediation How to fix the failure
+	Remediation *string `json:"remediation,omitempty"`
+
+	// Status pass, warn or fail
+	Status string `json:"status"`
+}
+
+// DiagnosticsReport defines model for DiagnosticsReport.
+type DiagnosticsReport struct {
+	CheckedAt time.Time `json:"checked_at"`
+
+	// Healthy false when a critical check failed
+	Healthy bool               `json:"healthy"`
+	Results []DiagnosticResult `json:"results"`
+}
+
+// DigestSubscriptionRequest defines model for DigestSubscriptionRequest.
+type DigestSubscriptionRequest struct {
+	Email openapi_types.Email `json:"email"`
+}
+
+// DueDoses defines model for DueDoses.
+type DueDoses struct {
+	// AsNeeded Medications taken when needed, never due
+	AsNeeded []MedicationRef `json:"as_needed"`
+	At       time.Time       `json:"at"`
+	Doses    []ScheduledDose `json:"doses"`
+	Timezone string          `json:"timezone"`
+
+	// Unscheduled Medications without a schedule or a parsable frequency
+	Unscheduled []MedicationRef `json:"unscheduled"`
+}
+
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse struct {
+	Code    string  `json:"code"`
+	Details *string `json:"details,omitempty"`
+	Message string  `json:"message"`
+}
+
+// ExtractionQuality defines model for ExtractionQuality.
+type ExtractionQuality struct {
+	CheckIns int `json:"check_ins"`
+
+	// FallbackRate Share of check-ins saved with only the raw transcript
+	FallbackRate float64 `json:"fallback_rate"`
+
+	// FlaggedRate Share of check-ins with at least one extraction issue
+	FlaggedRate float64 `json:"flagged_rate"`
+
+	// IssueRates Share of check-ins per extraction issue
+	IssueRates        map[string]float64 `json:"issue_rates"`
+	LowConfidenceRate float64            `json:"low_confidence_rate"`
+
+	// PromptVersion Extraction prompt version, "unknown" for check-ins saved before versions were recorded
+	PromptVersion string `json:"prompt_version"`
+}
+
+// ExtractionQualityReport Extraction quality per prompt version
+type ExtractionQualityReport struct {
+	// IssuesSinceStart Plausibility flags per rule since the service started
+	IssuesSinceStart map[string]int64    `json:"issues_since_start"`
+	Since            time.Time           `json:"since"`
+	Versions         []ExtractionQuality `json:"versions"`
+}
+
+// FitnessDailyAggregate defines model for FitnessDailyAggregate.
+type FitnessDailyAggregate struct {
+	DataType string             `json:"data_type"`
+	Date     openapi_types.Date `json:"date"`
+
+	// PointCount Number of data points of the day
+	PointCount int    `json:"point_count"`
+	Unit       string `json:"unit"`
+
+	// Value Average for heart_rate and weight, sum for the other types
+	Value float64 `json:"value"`
+}
+
+// FitnessDataPage defines model for FitnessDataPage.
+type FitnessDataPage struct {
+	Items []FitnessDataResponse `json:"items"`
+
+	// NextCursor Cursor of the next page, null on the last page
+	NextCursor *string `json:"next_cursor"`
+
+	// TotalCount Number of items across all pages
+	TotalCount int `json:"total_count"`
+}
+
+// FitnessDataPoint defines model for FitnessDataPoint.
+type FitnessDataPoint struct {
+	// DataType sleep is the older name of sleep_minutes. Each type has one unit: steps count, heart_rate bpm, sleep and sleep_minutes minutes, calories kcal, distance meters, active_minutes minutes, weight kg.
+	DataType FitnessDataPointDataType `json:"data_type"`
+	Date     openapi_types.Date       `json:"date"`
+	Source   FitnessDataPointSource   `json:"source"`
+
+	// SourceDataId Original ID from Health Connect for deduplication
+	SourceDataId string               `json:"source_data_id"`
+	Unit         FitnessDataPointUnit `json:"unit"`
+	Value        float64              `json:"value"`
+}
+
+// FitnessDataPointDataType sleep is the older name of sleep_minutes. Each type has one unit: steps count, heart_rate bpm, sleep and sleep_minutes minutes, calories kcal, distance meters, active_minutes minutes, weight kg.
+type FitnessDataPointDataType string
+
+// FitnessDataPointSource defines model for FitnessDataPoint.Source.
+type FitnessDataPointSource string
+
+// FitnessDataPointUnit defines model for FitnessDataPoint.Unit.
+type FitnessDataPointUnit string
+
+// FitnessDataResponse defines model for FitnessDataResponse.
+type FitnessDataResponse struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// DataType sleep is the older name of sleep_minutes. Each type has one unit: steps count, heart_rate bpm, sleep and sleep_minutes minutes, calories kcal, distance meters, active_minutes minutes, weight kg.
+	DataType FitnessDataResponseDataType `json:"data_type"`
+	Date     openapi_types.Date          `json:"date"`
+	Id       openapi_types.UUID          `json:"id"`
+	Source   FitnessDataResponseSource   `json:"source"`
+
+	// SourceDataId Original ID from Health Connect for deduplication
+	SourceDataId string                  `json:"source_data_id"`
+	Unit         FitnessDataResponseUnit `json:"unit"`
+	Value        float64                 `json:"value"`
+}
+
+// FitnessDataResponseDataType sleep is the older name of sleep_minutes. Each type has one unit: steps count, heart_rate bpm, sleep and sleep_minutes minutes, calories kcal, distance meters, active_minutes minutes, weight kg.
+type FitnessDataResponseDataType string
+
+// FitnessDataResponseSource defines model for FitnessDataResponse.Source.
+type FitnessDataResponseSource string
+
+// FitnessDataResponseUnit defines model for FitnessDataResponse.Unit.
+type FitnessDataResponseUnit string
+
+// FitnessSyncRequest defines model for FitnessSyncRequest.
+type FitnessSyncRequest struct {
+	DataPoints []FitnessDataPoint `json:"data_points"`
+	UserId     openapi_types.UUID `json:"user_id"`
+}
+
+// FitnessSyncResponse defines model for FitnessSyncResponse.
+type FitnessSyncResponse struct {
+	// InsertedCount Data points stored by this sync
+	InsertedCount int    `json:"inserted_count"`
+	Message       string `json:"message"`
+
+	// SkippedCount Data points skipped because their source_data_id was already synced
+	SkippedCount int `json:"skipped_count"`
+
+	// SyncedCount Data points stored by this sync, the same as inserted_count
+	SyncedCount int `json:"synced_count"`
+}
+
+// GDPRConsent defines model for GDPRConsent.
+type GDPRConsent struct {
+	ConsentType string             `json:"consent_type"`
+	Granted     bool               `json:"granted"`
+	Id          openapi_types.UUID `json:"id"`
+	IpAddress   *string            `json:"ip_address,omitempty"`
+	Timestamp   time.Time          `json:"timestamp"`
+	UserId      openapi_types.UUID `json:"user_id"`
+	Version     string             `json:"version"`
+}
+
+// GenerateReportRequest defines model for GenerateReportRequest.
+type GenerateReportRequest struct {
+	// Encrypt Password protect the PDF. The password is returned once, in the password field of the response, and is kept encrypted with the server key only until the report is generated; identical earlier reports are not reused for encrypted requests. Only PDF reports can be encrypted, requesting it with the csv format is rejected with 400. Servers without REPORT_PASSWORD_KEY reject encrypted requests with 503.
+	Encrypt *bool              `json:"encrypt,omitempty"`
+	EndDate openapi_types.Date `json:"end_date"`
+
+	// Format File format of the report. "csv" produces a ZIP archive of CSV files with a header row each; a dataset without data is a header-only file. Columns are only ever appended. check_ins.csv: id, check_in_date, symptoms, mood, pain_level, energy_level, sleep_quality, medication_taken, physical_activity, breakfast, lunch, dinner, general_feeling, additional_notes, low_confidence. medications.csv: id, name, dosage, frequency, start_date, end_date, notes, active. blood_pressure.csv: id, measured_at, systolic, diastolic, pulse. menstruation.csv: id, start_date, end_date, flow_intensity, symptoms. fitness.csv: id, date, data_type, value, unit, source. Dates are YYYY-MM-DD, measured_at is an RFC 3339 UTC timestamp, list values are joined with "; " and missing values are empty.
+	Format *GenerateReportRequestFormat `json:"format,omitempty"`
+
+	// Sections Sections to include, all when omitted. Unknown sections are rejected with 400. Data of excluded sections is not read: a CSV report leaves the matching files header-only, with check_ins.csv filled only when symptoms, adherence, activity, meals or summaries is included and fitness.csv only with activity. The sections are stored on the report and returned by the report list and the X-Report-Sections download header.
+	Sections  *[]GenerateReportRequestSections `json:"sections,omitempty"`
+	StartDate openapi_types.Date               `json:"start_date"`
+	UserId    openapi_types.UUID               `json:"user_id"`
+}
+
+// GenerateReportRequestFormat File format of the report. "csv" produces a ZIP archive of CSV files with a header row each; a dataset without data is a header-only file. Columns are only ever appended. check_ins.csv: id, check_in_date, symptoms, mood, pain_level, energy_level, sleep_quality, medication_taken, physical_activity, breakfast, lunch, dinner, general_feeling, additional_notes, low_confidence. medications.csv: id, name, dosage, frequency, start_date, end_date, notes, active. blood_pressure.csv: id, measured_at, systolic, diastolic, pulse. menstruation.csv: id, start_date, end_date, flow_intensity, symptoms. fitness.csv: id, date, data_type, value, unit, source. Dates are YYYY-MM-DD, measured_at is an RFC 3339 UTC timestamp, list values are joined with "; " and missing values are empty.
+type GenerateReportRequestFormat string
+
+// GenerateReportRequestSections defines model for GenerateReportRequest.Sections.
+type GenerateReportRequestSections string
+
+// HealthCheckInResponse defines model for HealthCheckInResponse.
+type HealthCheckInResponse struct {
+	AdditionalNotes *string                           `json:"additional_notes,omitempty"`
+	CheckInDate     *openapi_types.Date               `json:"check_in_date,omitempty"`
+	CreatedAt       *time.Time                        `json:"created_at,omitempty"`
+	EnergyLevel     *HealthCheckInResponseEnergyLevel `json:"energy_level,omitempty"`
+	GeneralFeeling  *string                           `json:"general_feeling,omitempty"`
+	Id              *openapi_types.UUID               `json:"id,omitempty"`
+	Meals           *struct {
+		Breakfast *string `json:"breakfast,omitempty"`
+		Dinner    *string `json:"dinner,omitempty"`
+		Lunch     *string `json:"lunch,omitempty"`
+	} `json:"meals,omitempty"`
+	MedicationTaken *HealthCheckInResponseMedicationTaken `json:"medication_taken,omitempty"`
+
+	// MedicationTakenLegacy The former "partial" spelling of medication_taken "some", returned during its deprecation window
+	// Deprecated: this property has been marked as deprecated upstream, but no `x-deprecated-reason` was set
+	MedicationTakenLegacy *string                            `json:"medication_taken_legacy,omitempty"`
+	Mood                  *HealthCheckInResponseMood         `json:"mood,omitempty"`
+	PainLevel             *int                               `json:"pain_level,omitempty"`
+	PhysicalActivity      *[]string                          `json:"physical_activity,omitempty"`
+	SleepQuality          *HealthCheckInResponseSleepQuality `json:"sleep_quality,omitempty"`
+	Symptoms              *[]string                          `json:"symptoms,omitempty"`
+	UserId                *openapi_types.UUID                `json:"user_id,omitempty"`
+}
+
+// HealthCheckInResponseEnergyLevel defines model for HealthCheckInResponse.EnergyLevel.
+type HealthCheckInResponseEnergyLevel string
+
+// HealthCheckInResponseMedicationTaken defines model for HealthCheckInResponse.MedicationTaken.
+type HealthCheckInResponseMedicationTaken string
+
+// HealthCheckInResponseMood defines model for HealthCheckInResponse.Mood.
+type HealthCheckInResponseMood string
+
+// HealthCheckInResponseSleepQuality defines model for HealthCheckInResponse.SleepQuality.
+type HealthCheckInResponseSleepQuality string
+
+// HealthStatus defines model for HealthStatus.
+type HealthStatus struct {
+	// Components Status of each of database, openai, speech and storage
+	Components *map[string]HealthStatusComponents `json:"components,omitempty"`
+	Status     *HealthStatusStatus                `json:"status,omitempty"`
+}
+
+// HealthStatusComponents defines model for HealthStatus.Components.
+type HealthStatusComponents string
+
+// HealthStatusStatus defines model for HealthStatus.Status.
+type HealthStatusStatus string
+
+// Integration Delivers event messages of an organization's patients to an external system
+type Integration struct {
+	BodyTemplate   string              `json:"body_template"`
+	CreatedAt      time.Time           `json:"created_at"`
+	CreatedBy      *openapi_types.UUID `json:"created_by,omitempty"`
+	Enabled        bool                `json:"enabled"`
+	Id             openapi_types.UUID  `json:"id"`
+	Kind           IntegrationKind     `json:"kind"`
+	Name           string              `json:"name"`
+	OrganizationId openapi_types.UUID  `json:"organization_id"`
+
+	// Settings Kind specific settings such as the webhook URL or SMTP recipients
+	Settings        map[string]string `json:"settings"`
+	SubjectTemplate string            `json:"subject_template"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// IntegrationKind defines model for Integration.Kind.
+type IntegrationKind string
+
+// IntegrationDelivery Delivery attempt of an integration
+type IntegrationDelivery struct {
+	AttemptedAt   time.Time                 `json:"attempted_at"`
+	CheckInId     *openapi_types.UUID       `json:"check_in_id,omitempty"`
+	ErrorMessage  *string                   `json:"error_message,omitempty"`
+	Event         string                    `json:"event"`
+	Id            openapi_types.UUID        `json:"id"`
+	IntegrationId openapi_types.UUID        `json:"integration_id"`
+	Status        IntegrationDeliveryStatus `json:"status"`
+	UserId        *openapi_types.UUID       `json:"user_id,omitempty"`
+}
+
+// IntegrationDeliveryStatus defines model for IntegrationDelivery.Status.
+type IntegrationDeliveryStatus string
+
+// IntegrationRequest Configuration of a delivery integration
+type IntegrationRequest struct {
+	// BodyTemplate Go template of the message body, the default for the kind when empty
+	BodyTemplate *string                `json:"body_template,omitempty"`
+	Enabled      *bool                  `json:"enabled,omitempty"`
+	Kind         IntegrationRequestKind `json:"kind"`
+	Name         string                 `json:"name"`
+
+	// Settings Kind specific settings such as the webhook URL or SMTP recipients
+	Settings *map[string]string `json:"settings,omitempty"`
+
+	// SubjectTemplate Go template of the message subject, the default for the kind when empty
+	SubjectTemplate *string `json:"subject_template,omitempty"`
+}
+
+// IntegrationRequestKind defines model for IntegrationRequest.Kind.
+type IntegrationRequestKind string
+
+// InteractionWarning defines model for InteractionWarning.
+type InteractionWarning struct {
+	Description        string                     `json:"description"`
+	ExistingMedication string                     `json:"existing_medication"`
+	Medication         string                     `json:"medication"`
+	Severity           InteractionWarningSeverity `json:"severity"`
+
+	// Source table for the bundled interaction table, ai for the optional Azure OpenAI check of medications missing from it
+	Source InteractionWarningSource `json:"source"`
+}
+
+// InteractionWarningSeverity defines model for InteractionWarning.Severity.
+type InteractionWarningSeverity string
+
+// InteractionWarningSource table for the bundled interaction table, ai for the optional Azure OpenAI check of medications missing from it
+type InteractionWarningSource string
+
+// InvitationResponse Created invitation
+type InvitationResponse struct {
+	AcceptedAt     *time.Time          `json:"accepted_at,omitempty"`
+	AcceptedBy     *openapi_types.UUID `json:"accepted_by,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	Email          openapi_types.Email `json:"email"`
+	ExpiresAt      time.Time           `json:"expires_at"`
+	Id             openapi_types.UUID  `json:"id"`
+	InvitedBy      openapi_types.UUID  `json:"invited_by"`
+	OrganizationId openapi_types.UUID  `json:"organization_id"`
+
+	// Role Role of a user, in an organization or system-wide
+	Role Role `json:"role"`
+
+	// Token Invitation token to deliver to the invitee; returned only once
+	Token string `json:"token"`
+}
+
+// InviteMemberRequest defines model for InviteMemberRequest.
+type InviteMemberRequest struct {
+	Email openapi_types.Email `json:"email"`
+
+	// Role Role of a user, in an organization or system-wide
+	Role Role `json:"role"`
+}
+
+// LatencyBucket Cumulative histogram bucket; observations above the last bound are only in the total count
+type LatencyBucket struct {
+	// Count Observations of at most le_ms
+	Count int64 `json:"count"`
+
+	// LeMs Upper bound of the bucket in milliseconds
+	LeMs float64 `json:"le_ms"`
+}
+
+// LatencySnapshot Latency histogram of one stage
+type LatencySnapshot struct {
+	Buckets []LatencyBucket `json:"buckets"`
+	Count   int64           `json:"count"`
+	SumMs   float64         `json:"sum_ms"`
+}
+
+// MedicationAdherence defines model for MedicationAdherence.
+type MedicationAdherence struct {
+	Expected     int                `json:"expected"`
+	MedicationId openapi_types.UUID `json:"medication_id"`
+	Name         string             `json:"name"`
+
+	// Score taken/expected in [0, 1], null when the medication has no schedule
+	Score *float64 `json:"score"`
+	Taken int      `json:"taken"`
+}
+
+// MedicationAdherenceRate Adherence of a medication, with the doses expected derived from its frequency
+type MedicationAdherenceRate struct {
+	// Expected Doses expected from the frequency, null when it is not recognized
+	Expected     *int               `json:"expected"`
+	Frequency    string             `json:"frequency"`
+	MedicationId openapi_types.UUID `json:"medication_id"`
+	Name         string             `json:"name"`
+
+	// Rate taken/expected in [0, 1]
+	Rate  *float64 `json:"rate"`
+	Taken int      `json:"taken"`
+}
+
+// MedicationAdherenceRequest defines model for MedicationAdherenceRequest.
+type MedicationAdherenceRequest struct {
+	// Adherence Whether the dose was taken
+	Adherence bool    `json:"adherence"`
+	Notes     *string `json:"notes,omitempty"`
+
+	// TakenAt When the dose was due or taken, now when omitted
+	TakenAt *time.Time `json:"taken_at,omitempty"`
+}
+
+// MedicationLog Adherence log entry of a medication dose
+type MedicationLog struct {
+	Adherence    bool               `json:"adherence"`
+	CreatedAt    time.Time          `json:"created_at"`
+	Id           openapi_types.UUID `json:"id"`
+	MedicationId openapi_types.UUID `json:"medication_id"`
+	Notes        *string            `json:"notes,omitempty"`
+	TakenAt      time.Time          `json:"taken_at"`
+}
+
+// MedicationPage defines model for MedicationPage.
+type MedicationPage struct {
+	Items []MedicationResponse `json:"items"`
+
+	// NextCursor Cursor of the next page, null on the last page
+	NextCursor *string `json:"next_cursor"`
+
+	// TotalCount Number of items across all pages
+	TotalCount int `json:"total_count"`
+}
+
+// MedicationRef defines model for MedicationRef.
+type MedicationRef struct {
+	Dosage       string             `json:"dosage"`
+	Frequency    string             `json:"frequency"`
+	MedicationId openapi_types.UUID `json:"medication_id"`
+	Name         string             `json:"name"`
+}
+
+// MedicationResponse defines model for MedicationResponse.
+type MedicationResponse struct {
+	Active    *bool               `json:"active,omitempty"`
+	CreatedAt *time.Time          `json:"created_at,omitempty"`
+	Dosage    *string             `json:"dosage,omitempty"`
+	EndDate   *openapi_types.Date `json:"end_date,omitempty"`
+	Frequency *string             `json:"frequency,omitempty"`
+	Id        *openapi_types.UUID `json:"id,omitempty"`
+	Name      *string             `json:"name,omitempty"`
+	Notes     *string             `json:"notes,omitempty"`
+	StartDate *openapi_types.Date `json:"start_date,omitempty"`
+	UserId    *openapi_types.UUID `json:"user_id,omitempty"`
+
+	// Warnings Interactions with the user's other active medications found when the medication was last added or updated; they never block the change
+	Warnings *[]InteractionWarning `json:"warnings,omitempty"`
+}
+
+// MedicationSchedule defines model for MedicationSchedule.
+type MedicationSchedule struct {
+	AsNeeded   bool  `json:"as_needed"`
+	DaysOfWeek []int `json:"days_of_week"`
+
+	// Derived The schedule was derived from the frequency text, not set explicitly
+	Derived      bool               `json:"derived"`
+	MedicationId openapi_types.UUID `json:"medication_id"`
+
+	// NextDue Upcoming reminder times, only returned by GET
+	NextDue    *[]time.Time `json:"next_due,omitempty"`
+	TimesOfDay []string     `json:"times_of_day"`
+}
+
+// MedicationScheduleRequest Reminder schedule of a medication. An empty body derives the schedule from the medication's frequency text.
+type MedicationScheduleRequest struct {
+	// AsNeeded Taken when needed, without reminder times
+	AsNeeded *bool `json:"as_needed,omitempty"`
+
+	// DaysOfWeek Days the medication is taken, 0 is Sunday; every day when empty
+	DaysOfWeek *[]int `json:"days_of_week,omitempty"`
+
+	// TimesOfDay Reminder times as HH:MM in the user's time zone
+	TimesOfDay *[]string `json:"times_of_day,omitempty"`
+}
+
+// MenstruationPage defines model for MenstruationPage.
+type MenstruationPage struct {
+	Items []MenstruationResponse `json:"items"`
+
+	// NextCursor Cursor of the next page, null on the last page
+	NextCursor *string `json:"next_cursor"`
+
+	// TotalCount Number of items across all pages
+	TotalCount int `json:"total_count"`
+}
+
+// MenstruationRequest defines model for MenstruationRequest.
+type MenstruationRequest struct {
+	EndDate       *openapi_types.Date               `json:"end_date,omitempty"`
+	FlowIntensity *MenstruationRequestFlowIntensity `json:"flow_intensity,omitempty"`
 	StartDate     openapi_types.Date                `json:"start_date"`
 	Symptoms      *[]string                         `json:"symptoms,omitempty"`
 	UserId        openapi_types.UUID                `json:"user_id"`
 }
 
-// MenstruationRequestFlowIntensity defines model for MenstruationRequest.FlowIntensity.
-type MenstruationRequestFlowIntensity string
+// MenstruationRequestFlowIntensity defines model for MenstruationRequest.FlowIntensity.
+type MenstruationRequestFlowIntensity string
+
+// MenstruationResponse defines model for MenstruationResponse.
+type MenstruationResponse struct {
+	CreatedAt     *time.Time                         `json:"created_at,omitempty"`
+	EndDate       *openapi_types.Date                `json:"end_date,omitempty"`
+	FlowIntensity *MenstruationResponseFlowIntensity `json:"flow_intensity,omitempty"`
+	Id            *openapi_types.UUID                `json:"id,omitempty"`
+	StartDate     *openapi_types.Date                `json:"start_date,omitempty"`
+	Symptoms      *[]string                          `json:"symptoms,omitempty"`
+	UserId        *openapi_types.UUID                `json:"user_id,omitempty"`
+}
+
+// MenstruationResponseFlowIntensity defines model for MenstruationResponse.FlowIntensity.
+type MenstruationResponseFlowIntensity string
+
+// MenstruationUpdateRequest Partial update of a menstruation cycle; omitted fields are kept
+type MenstruationUpdateRequest struct {
+	EndDate       *openapi_types.Date                     `json:"end_date,omitempty"`
+	FlowIntensity *MenstruationUpdateRequestFlowIntensity `json:"flow_intensity,omitempty"`
+	Symptoms      *[]string                               `json:"symptoms,omitempty"`
+
+	// SymptomsMode How symptoms are applied: replace the stored ones (default) or append the new ones
+	SymptomsMode *MenstruationUpdateRequestSymptomsMode `json:"symptoms_mode,omitempty"`
+}
+
+// MenstruationUpdateRequestFlowIntensity defines model for MenstruationUpdateRequest.FlowIntensity.
+type MenstruationUpdateRequestFlowIntensity string
+
+// MenstruationUpdateRequestSymptomsMode How symptoms are applied: replace the stored ones (default) or append the new ones
+type MenstruationUpdateRequestSymptomsMode string
+
+// MetricTrend Change of a summary metric from the preceding window of the same length. The mood trend is of the positive mood share (0 to 1). Fields are null where the change is undefined: without data in a window, or for percent_change when the previous value is 0.
+type MetricTrend struct {
+	Delta         *float64 `json:"delta"`
+	PercentChange *float64 `json:"percent_change"`
+	Previous      *float64 `json:"previous"`
+}
+
+// Organization defines model for Organization.
+type Organization struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// DataResidency Storage backend the artifacts of its patients are written to, omitted for the default storage account
+	DataResidency *string            `json:"data_residency,omitempty"`
+	Id            openapi_types.UUID `json:"id"`
+	Name          string             `json:"name"`
+}
+
+// OrganizationMember defines model for OrganizationMember.
+type OrganizationMember struct {
+	Roles  []Role             `json:"roles"`
+	UserId openapi_types.UUID `json:"user_id"`
+}
+
+// PanelAssignment Patient of an organization on one of its clinicians' panels
+type PanelAssignment struct {
+	AssignedBy     *openapi_types.UUID `json:"assigned_by,omitempty"`
+	ClinicianId    openapi_types.UUID  `json:"clinician_id"`
+	CreatedAt      time.Time           `json:"created_at"`
+	OrganizationId openapi_types.UUID  `json:"organization_id"`
+	PatientId      openapi_types.UUID  `json:"patient_id"`
+}
+
+// PanelDigestSubscription Opt-in of a clinician to the daily email digest of their panel's findings
+type PanelDigestSubscription struct {
+	ClinicianId    openapi_types.UUID  `json:"clinician_id"`
+	CreatedAt      time.Time           `json:"created_at"`
+	Email          openapi_types.Email `json:"email"`
+	LastSentAt     *time.Time          `json:"last_sent_at,omitempty"`
+	OrganizationId openapi_types.UUID  `json:"organization_id"`
+}
+
+// PanelFinding Alert or data-quality problem of a patient on a clinician's panel
+type PanelFinding struct {
+	Detail     *string              `json:"detail,omitempty"`
+	Kind       PanelFindingKind     `json:"kind"`
+	OccurredAt time.Time            `json:"occurred_at"`
+	PatientId  openapi_types.UUID   `json:"patient_id"`
+	Severity   PanelFindingSeverity `json:"severity"`
+
+	// SourceId Alert or check-in the finding was raised for
+	SourceId openapi_types.UUID `json:"source_id"`
+}
+
+// PanelFindingKind defines model for PanelFinding.Kind.
+type PanelFindingKind string
+
+// PanelFindingSeverity defines model for PanelFinding.Severity.
+type PanelFindingSeverity string
+
+// PanelFindingPage defines model for PanelFindingPage.
+type PanelFindingPage struct {
+	Items []PanelFinding `json:"items"`
+
+	// NextCursor Cursor of the next page, null on the last page
+	NextCursor *string `json:"next_cursor"`
+	TotalCount int     `json:"total_count"`
+}
+
+// PauseSessionResponse defines model for PauseSessionResponse.
+type PauseSessionResponse struct {
+	PausedAt  *time.Time                 `json:"paused_at,omitempty"`
+	SessionId openapi_types.UUID         `json:"session_id"`
+	Status    PauseSessionResponseStatus `json:"status"`
+}
+
+// PauseSessionResponseStatus defines model for PauseSessionResponse.Status.
+type PauseSessionResponseStatus string
+
+// PersonalAccessToken Read-only token a user created for scripts and integrations
+type PersonalAccessToken struct {
+	CreatedAt  time.Time          `json:"created_at"`
+	ExpiresAt  time.Time          `json:"expires_at"`
+	Id         openapi_types.UUID `json:"id"`
+	LastUsedAt *time.Time         `json:"last_used_at,omitempty"`
+	Name       string             `json:"name"`
+
+	// Prefix First characters of the token, to tell tokens apart
+	Prefix    string                      `json:"prefix"`
+	RevokedAt *time.Time                  `json:"revoked_at,omitempty"`
+	Scopes    []PersonalAccessTokenScopes `json:"scopes"`
+	UserId    openapi_types.UUID          `json:"user_id"`
+}
+
+// PersonalAccessTokenScopes defines model for PersonalAccessToken.Scopes.
+type PersonalAccessTokenScopes string
+
+// QuestionCondition Condition on the answer to an earlier question. Exactly one of answer, min and max, and keywords is set.
+type QuestionCondition struct {
+	// Answer yes or no, the answer to a yes_no question
+	Answer *string `json:"answer,omitempty"`
+
+	// Keywords Words of which the answer must contain at least one
+	Keywords *[]string `json:"keywords,omitempty"`
+
+	// Max Upper bound of the number answered to a numeric question
+	Max *float32 `json:"max,omitempty"`
+
+	// Min Lower bound of the number answered to a numeric question
+	Min *float32 `json:"min,omitempty"`
+
+	// QuestionId Earlier question of the set the condition is on
+	QuestionId string `json:"question_id"`
+}
+
+// QuestionSet Check-in questions asked in place of the built-in set to the users it is assigned to
+type QuestionSet struct {
+	CreatedAt time.Time             `json:"created_at"`
+	CreatedBy *openapi_types.UUID   `json:"created_by,omitempty"`
+	Id        openapi_types.UUID    `json:"id"`
+	Language  string                `json:"language"`
+	Name      string                `json:"name"`
+	Questions []QuestionSetQuestion `json:"questions"`
+}
+
+// QuestionSetAssignment defines model for QuestionSetAssignment.
+type QuestionSetAssignment struct {
+	QuestionSetId *openapi_types.UUID `json:"question_set_id"`
+	UserId        openapi_types.UUID  `json:"user_id"`
+}
+
+// QuestionSetQuestion defines model for QuestionSetQuestion.
+type QuestionSetQuestion struct {
+	Id       string `json:"id"`
+	Required bool   `json:"required"`
+
+	// ShowIf Conditions that must all hold for the question to be asked
+	ShowIf *[]QuestionCondition    `json:"show_if,omitempty"`
+	Text   string                  `json:"text"`
+	Type   QuestionSetQuestionType `json:"type"`
+}
+
+// QuestionSetQuestionType defines model for QuestionSetQuestion.Type.
+type QuestionSetQuestionType string
+
+// RecordGDPRConsentRequest defines model for RecordGDPRConsentRequest.
+type RecordGDPRConsentRequest struct {
+	// ConsentType One of processing, marketing, third_party_sharing or right_to_erasure
+	ConsentType string             `json:"consent_type"`
+	Granted     bool               `json:"granted"`
+	UserId      openapi_types.UUID `json:"user_id"`
+
+	// Version Version of the consent text that was answered
+	Version string `json:"version"`
+}
+
+// ReportPage defines model for ReportPage.
+type ReportPage struct {
+	Items []ReportSummary `json:"items"`
+
+	// NextCursor Cursor of the next page, null on the last page
+	NextCursor *string `json:"next_cursor"`
+
+	// TotalCount Number of items across all pages
+	TotalCount int `json:"total_count"`
+}
+
+// ReportResponse defines model for ReportResponse.
+type ReportResponse struct {
+	DateRangeEnd   *openapi_types.Date `json:"date_range_end,omitempty"`
+	DateRangeStart *openapi_types.Date `json:"date_range_start,omitempty"`
+	GeneratedAt    *time.Time          `json:"generated_at,omitempty"`
+	Id             *openapi_types.UUID `json:"id,omitempty"`
+
+	// JobId Generation job whose progress GET /api/v1/reports/jobs/{job_id} reports
+	JobId *openapi_types.UUID `json:"job_id,omitempty"`
+
+	// Password Password opening the PDF, returned only when an encrypted report is requested
+	Password *string               `json:"password,omitempty"`
+	Status   *ReportResponseStatus `json:"status,omitempty"`
+	UserId   *openapi_types.UUID   `json:"user_id,omitempty"`
+}
+
+// ReportResponseStatus defines model for ReportResponse.Status.
+type ReportResponseStatus string
+
+// ReportSchedule defines model for ReportSchedule.
+type ReportSchedule struct {
+	Cadence   string    `json:"cadence"`
+	CreatedAt time.Time `json:"created_at"`
+	Day       int       `json:"day"`
+	Enabled   bool      `json:"enabled"`
+
+	// Language Language the reports are printed in
+	Language string `json:"language"`
+
+	// LastRunOn Scheduled day of the latest report
+	LastRunOn *time.Time         `json:"last_run_on,omitempty"`
+	UpdatedAt time.Time          `json:"updated_at"`
+	UserId    openapi_types.UUID `json:"user_id"`
+}
+
+// ReportScheduleRequest defines model for ReportScheduleRequest.
+type ReportScheduleRequest struct {
+	// Cadence weekly or monthly
+	Cadence string `json:"cadence"`
+
+	// Day ISO weekday from 1 (Monday) to 7 for weekly, day of the month from 1 to 28 for monthly
+	Day int `json:"day"`
+
+	// Enabled false pauses the schedule, true when omitted
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// ReportStatus Generation status of a report
+type ReportStatus struct {
+	// Error Why generation failed, set only for failed reports
+	Error    *string             `json:"error,omitempty"`
+	JobId    *openapi_types.UUID `json:"job_id,omitempty"`
+	ReportId *openapi_types.UUID `json:"report_id,omitempty"`
+	Status   ReportStatusStatus  `json:"status"`
+}
+
+// ReportStatusStatus defines model for ReportStatus.Status.
+type ReportStatusStatus string
+
+// ReportSummary Previously generated report in a user's report list
+type ReportSummary struct {
+	DateRangeEnd   openapi_types.Date      `json:"date_range_end"`
+	DateRangeStart openapi_types.Date      `json:"date_range_start"`
+	Encrypted      bool                    `json:"encrypted"`
+	Format         ReportSummaryFormat     `json:"format"`
+	GeneratedAt    time.Time               `json:"generated_at"`
+	Id             openapi_types.UUID      `json:"id"`
+	Sections       []ReportSummarySections `json:"sections"`
+	SizeBytes      int64                   `json:"size_bytes"`
+	Status         ReportSummaryStatus     `json:"status"`
+}
+
+// ReportSummaryFormat defines model for ReportSummary.Format.
+type ReportSummaryFormat string
+
+// ReportSummarySections defines model for ReportSummary.Sections.
+type ReportSummarySections string
+
+// ReportSummaryStatus defines model for ReportSummary.Status.
+type ReportSummaryStatus string
+
+// ReportURL defines model for ReportURL.
+type ReportURL struct {
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Url Signed URL downloading the report directly from storage
+	Url string `json:"url"`
+}
+
+// ReportVerification Generated report matching a verification code
+type ReportVerification struct {
+	DateRangeEnd   openapi_types.Date `json:"date_range_end"`
+	DateRangeStart openapi_types.Date `json:"date_range_start"`
+	GeneratedAt    time.Time          `json:"generated_at"`
+	Verified       bool               `json:"verified"`
+}
+
+// RespondRequest defines model for RespondRequest.
+type RespondRequest struct {
+	// Adaptive Ask adaptive follow-up questions in this session, the server default when omitted
+	Adaptive *bool `json:"adaptive,omitempty"`
+
+	// Response User's transcribed response
+	Response  string             `json:"response"`
+	SessionId openapi_types.UUID `json:"session_id"`
+}
+
+// Role Role of a user, in an organization or system-wide
+type Role string
+
+// RoleAssignment Role of a user; organization_id is omitted for system-wide roles
+type RoleAssignment struct {
+	CreatedAt      time.Time           `json:"created_at"`
+	GrantedBy      *openapi_types.UUID `json:"granted_by,omitempty"`
+	OrganizationId *openapi_types.UUID `json:"organization_id,omitempty"`
+
+	// Role Role of a user, in an organization or system-wide
+	Role   Role               `json:"role"`
+	UserId openapi_types.UUID `json:"user_id"`
+}
+
+// ScheduledDose defines model for ScheduledDose.
+type ScheduledDose struct {
+	// Derived The schedule was parsed from the frequency text
+	Derived bool   `json:"derived"`
+	Dosage  string `json:"dosage"`
+
+	// LogId Adherence log matched to the dose
+	LogId        *openapi_types.UUID `json:"log_id,omitempty"`
+	MedicationId openapi_types.UUID  `json:"medication_id"`
+	Name         string              `json:"name"`
+	ScheduledAt  time.Time           `json:"scheduled_at"`
+
+	// Status due, upcoming, taken or skipped
+	Status string `json:"status"`
+}
+
+// SessionEvent defines model for SessionEvent.
+type SessionEvent struct {
+	// CheckInId Check-in created when the session completed
+	CheckInId  *openapi_types.UUID `json:"check_in_id,omitempty"`
+	IsFollowUp *bool               `json:"is_follow_up,omitempty"`
+	MessageId  *openapi_types.UUID `json:"message_id,omitempty"`
+	OccurredAt time.Time           `json:"occurred_at"`
+	QuestionId *string             `json:"question_id,omitempty"`
+	SessionId  openapi_types.UUID  `json:"session_id"`
+	Text       *string             `json:"text,omitempty"`
+
+	// Type message_saved, question_asked, session_completed or session_expired
+	Type string `json:"type"`
+}
+
+// SessionRequest Identifies the check-in session an action applies to
+type SessionRequest struct {
+	SessionId openapi_types.UUID `json:"session_id"`
+}
+
+// SessionResponse defines model for SessionResponse.
+type SessionResponse struct {
+	// AudioAvailable Whether the question comes with audio; omitted without a question. False while speech synthesis is failing, so clients can show a text-only notice
+	AudioAvailable *bool `json:"audio_available,omitempty"`
+
+	// AudioError Why the question has no audio, omitted while audio is available
+	AudioError *string `json:"audio_error,omitempty"`
+
+	// QuestionAudio Base64 encoded audio of the question, returned when a session is resumed
+	QuestionAudio *[]byte `json:"question_audio,omitempty"`
+	QuestionId    *string `json:"question_id,omitempty"`
+
+	// QuestionText First question in Hungarian
+	QuestionText *string                `json:"question_text,omitempty"`
+	SessionId    *openapi_types.UUID    `json:"session_id,omitempty"`
+	StartedAt    *time.Time             `json:"started_at,omitempty"`
+	Status       *SessionResponseStatus `json:"status,omitempty"`
+	UserId       *openapi_types.UUID    `json:"user_id,omitempty"`
+
+	// Voice Azure Speech voice of the question audio, returned when a session is started
+	Voice *string `json:"voice,omitempty"`
+}
+
+// SessionResponseStatus defines model for SessionResponse.Status.
+type SessionResponseStatus string
+
+// SessionStatus defines model for SessionStatus.
+type SessionStatus struct {
+	CompletedAt       *time.Time           `json:"completed_at,omitempty"`
+	QuestionsAnswered *int                 `json:"questions_answered,omitempty"`
+	SessionId         *openapi_types.UUID  `json:"session_id,omitempty"`
+	StartedAt         *time.Time           `json:"started_at,omitempty"`
+	Status            *SessionStatusStatus `json:"status,omitempty"`
+	TotalQuestions    *int                 `json:"total_questions,omitempty"`
+}
+
+// SessionStatusStatus defines model for SessionStatus.Status.
+type SessionStatusStatus string
+
+// StartSessionRequest defines model for StartSessionRequest.
+type StartSessionRequest struct {
+	UserId openapi_types.UUID `json:"user_id"`
+}
+
+// SummaryComparison Change from the preceding period, returned with compare_previous=true
+type SummaryComparison struct {
+	CheckInCountDelta int `json:"check_in_count_delta"`
+
+	// EnergyDelta Change of the average energy on a 1 (low) to 3 (high) scale
+	EnergyDelta float64 `json:"energy_delta"`
+
+	// MoodImprovementPercent Change of the positive mood share in percentage points
+	MoodImprovementPercent float64 `json:"mood_improvement_percent"`
+	PainDelta              float64 `json:"pain_delta"`
+}
+
+// TimelineEvent One entry of a user's timeline
+type TimelineEvent struct {
+	OccurredAt time.Time `json:"occurred_at"`
+
+	// ResourceId Row the event was read from
+	ResourceId string `json:"resource_id"`
+
+	// Summary Short description of the event
+	Summary *string `json:"summary,omitempty"`
+
+	// Truncated Whether free text in the summary was cut to 120 characters
+	Truncated bool              `json:"truncated"`
+	Type      TimelineEventType `json:"type"`
+}
+
+// TimelineEventType defines model for TimelineEvent.Type.
+type TimelineEventType string
+
+// TimelinePage defines model for TimelinePage.
+type TimelinePage struct {
+	Items []TimelineEvent `json:"items"`
+
+	// NextCursor Cursor of the next page, null on the last page
+	NextCursor *string `json:"next_cursor"`
+}
+
+// TimingBreakdown Per-stage latency of a check-in request, returned with debug timing enabled
+type TimingBreakdown struct {
+	StagesMs map[string]float64 `json:"stages_ms"`
+	TotalMs  float64            `json:"total_ms"`
+}
+
+// UpdateMedicationRequest defines model for UpdateMedicationRequest.
+type UpdateMedicationRequest struct {
+	Dosage    *string             `json:"dosage,omitempty"`
+	EndDate   *openapi_types.Date `json:"end_date,omitempty"`
+	Frequency *string             `json:"frequency,omitempty"`
+	Name      *string             `json:"name,omitempty"`
+	Notes     *string             `json:"notes,omitempty"`
+}
+
+// UsageAggregate Stored data totals across all users
+type UsageAggregate struct {
+	AttachmentBytes int64 `json:"attachment_bytes"`
+	AudioBytes      int64 `json:"audio_bytes"`
+	CheckIns        int64 `json:"check_ins"`
+	ReportBytes     int64 `json:"report_bytes"`
+
+	// TopUsers Users storing the most data
+	TopUsers []UserUsage `json:"top_users"`
+	Users    int         `json:"users"`
+}
+
+// UsageReport defines model for UsageReport.
+type UsageReport struct {
+	// Exceeded Names of the limits the usage exceeds
+	Exceeded []string `json:"exceeded"`
+
+	// Limits Soft limits; a disabled limit is omitted
+	Limits struct {
+		MaxAttachmentBytes *int64 `json:"max_attachment_bytes,omitempty"`
+		MaxAudioBytes      *int64 `json:"max_audio_bytes,omitempty"`
+		MaxCheckIns        *int64 `json:"max_check_ins,omitempty"`
+		MaxReportBytes     *int64 `json:"max_report_bytes,omitempty"`
+	} `json:"limits"`
+
+	// Usage Stored data of a user
+	Usage UserUsage `json:"usage"`
+}
+
+// UserConsent Current state of one of a user's consents
+type UserConsent struct {
+	ConsentType UserConsentConsentType `json:"consent_type"`
+	Granted     bool                   `json:"granted"`
+	GrantedAt   *time.Time             `json:"granted_at,omitempty"`
+	RevokedAt   *time.Time             `json:"revoked_at,omitempty"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+	UserId      openapi_types.UUID     `json:"user_id"`
+}
+
+// UserConsentConsentType defines model for UserConsent.ConsentType.
+type UserConsentConsentType string
+
+// UserEmailRequest defines model for UserEmailRequest.
+type UserEmailRequest struct {
+	Email openapi_types.Email `json:"email"`
+}
+
+// UserProfile defines model for UserProfile.
+type UserProfile struct {
+	CreatedAt       time.Time          `json:"created_at"`
+	Email           string             `json:"email"`
+	EmailVerified   bool               `json:"email_verified"`
+	EmailVerifiedAt *time.Time         `json:"email_verified_at,omitempty"`
+	Id              openapi_types.UUID `json:"id"`
+	Name            string             `json:"name"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+}
+
+// UserSettings defines model for UserSettings.
+type UserSettings struct {
+	// Timezone IANA time zone medication schedules are read in
+	Timezone  string             `json:"timezone"`
+	UpdatedAt time.Time          `json:"updated_at"`
+	UserId    openapi_types.UUID `json:"user_id"`
+}
+
+// UserSettingsRequest defines model for UserSettingsRequest.
+type UserSettingsRequest struct {
+	// Timezone IANA time zone such as Europe/Budapest
+	Timezone string `json:"timezone"`
+}
+
+// UserUsage Stored data of a user
+type UserUsage struct {
+	AttachmentBytes int64 `json:"attachment_bytes"`
+	AudioBytes      int64 `json:"audio_bytes"`
+	CheckIns        int64 `json:"check_ins"`
+
+	// ReconciledAt Last reconciliation against stored files, omitted before the first one
+	ReconciledAt *time.Time         `json:"reconciled_at,omitempty"`
+	ReportBytes  int64              `json:"report_bytes"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+	UserId       openapi_types.UUID `json:"user_id"`
+}
+
+// Webhook Endpoint notified of events about a user's data
+type Webhook struct {
+	CreatedAt time.Time          `json:"created_at"`
+	Events    []WebhookEvents    `json:"events"`
+	Id        openapi_types.UUID `json:"id"`
+
+	// SecretToken Key of the X-Signature-SHA256 HMAC of every delivery, returned once when the webhook is created
+	SecretToken *string            `json:"secret_token,omitempty"`
+	TargetUrl   string             `json:"target_url"`
+	UserId      openapi_types.UUID `json:"user_id"`
+}
+
+// WebhookEvents defines model for Webhook.Events.
+type WebhookEvents string
+
+// Cursor defines model for Cursor.
+type Cursor = string
+
+// Limit defines model for Limit.
+type Limit = int
+
+// Offset defines model for Offset.
+type Offset = int
+
+// BadRequest defines model for BadRequest.
+type BadRequest = ErrorResponse
+
+// InternalError defines model for InternalError.
+type InternalError = ErrorResponse
+
+// NotFound defines model for NotFound.
+type NotFound = ErrorResponse
+
+// ServiceUnavailable defines model for ServiceUnavailable.
+type ServiceUnavailable = ErrorResponse
+
+// GetApiV1AdminAuditLogsParams defines parameters for GetApiV1AdminAuditLogs.
+type GetApiV1AdminAuditLogsParams struct {
+	UserId        *openapi_types.UUID                        `form:"user_id,omitempty" json:"user_id,omitempty"`
+	OperationType *GetApiV1AdminAuditLogsParamsOperationType `form:"operation_type,omitempty" json:"operation_type,omitempty"`
+	ResourceType  *string                                    `form:"resource_type,omitempty" json:"resource_type,omitempty"`
+	IpAddress     *string                                    `form:"ip_address,omitempty" json:"ip_address,omitempty"`
+
+	// StartTime Date (YYYY-MM-DD) or RFC 3339 time of the oldest log
+	StartTime *string `form:"start_time,omitempty" json:"start_time,omitempty"`
+
+	// EndTime Date (YYYY-MM-DD) or RFC 3339 time the logs precede; a date includes that whole day
+	EndTime *string `form:"end_time,omitempty" json:"end_time,omitempty"`
+
+	// Limit Page size, 50 by default and capped at 500
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next_cursor of the previous page; takes precedence over offset
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetApiV1AdminAuditLogsParamsOperationType defines parameters for GetApiV1AdminAuditLogs.
+type GetApiV1AdminAuditLogsParamsOperationType string
+
+// GetApiV1AdminExtractionQualityParams defines parameters for GetApiV1AdminExtractionQuality.
+type GetApiV1AdminExtractionQualityParams struct {
+	// Days Days to look back
+	Days *int `form:"days,omitempty" json:"days,omitempty"`
+}
+
+// DeleteApiV1AdminPanelDigestParams defines parameters for DeleteApiV1AdminPanelDigest.
+type DeleteApiV1AdminPanelDigestParams struct {
+	OrganizationId openapi_types.UUID `form:"organization_id" json:"organization_id"`
+}
+
+// PutApiV1AdminPanelDigestParams defines parameters for PutApiV1AdminPanelDigest.
+type PutApiV1AdminPanelDigestParams struct {
+	OrganizationId openapi_types.UUID `form:"organization_id" json:"organization_id"`
+}
+
+// GetApiV1AdminPanelFindingsParams defines parameters for GetApiV1AdminPanelFindings.
+type GetApiV1AdminPanelFindingsParams struct {
+	OrganizationId openapi_types.UUID `form:"organization_id" json:"organization_id"`
+
+	// ClinicianId Clinician whose panel is read, the authenticated clinician when omitted. Requires org_admin for another clinician.
+	ClinicianId *openapi_types.UUID `form:"clinician_id,omitempty" json:"clinician_id,omitempty"`
+
+	// Since Date (YYYY-MM-DD) or RFC 3339 time of the oldest finding, seven days ago when omitted
+	Since *string `form:"since,omitempty" json:"since,omitempty"`
+
+	// Limit Page size, 50 by default and capped at 500
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor next_cursor of the previous page; takes precedence over offset
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetApiV1AdminUsersIdTimelineParams defines parameters for GetApiV1AdminUsersIdTimeline.
+type GetApiV1AdminUsersIdTimelineParams struct {
+	// Limit Page size, 50 by default and capped at 500
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next_cursor of the previous page; takes precedence over offset
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetApiV1AlertsParams defines parameters for GetApiV1Alerts.
+type GetApiV1AlertsParams struct {
+	// UserId User whose data is read, the authenticated user when omitted
+	UserId *openapi_types.UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// IncludeAcknowledged Include alerts that were already acknowledged
+	IncludeAcknowledged *bool `form:"include_acknowledged,omitempty" json:"include_acknowledged,omitempty"`
+}
+
+// GetApiV1AuditLogsParams defines parameters for GetApiV1AuditLogs.
+type GetApiV1AuditLogsParams struct {
+	UserId        *openapi_types.UUID                   `form:"user_id,omitempty" json:"user_id,omitempty"`
+	OperationType *GetApiV1AuditLogsParamsOperationType `form:"operation_type,omitempty" json:"operation_type,omitempty"`
+	ResourceType  *string                               `form:"resource_type,omitempty" json:"resource_type,omitempty"`
+	IpAddress     *string                               `form:"ip_address,omitempty" json:"ip_address,omitempty"`
+
+	// From Date (YYYY-MM-DD) or RFC 3339 time of the oldest log
+	From *string `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Date (YYYY-MM-DD) or RFC 3339 time the logs precede; a date includes that whole day
+	To *string `form:"to,omitempty" json:"to,omitempty"`
+
+	// Limit Page size, 50 by default and capped at 500
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor next_cursor of the previous page; takes precedence over offset
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetApiV1AuditLogsParamsOperationType defines parameters for GetApiV1AuditLogs.
+type GetApiV1AuditLogsParamsOperationType string
+
+// PostApiV1CheckinAudioStreamParams defines parameters for PostApiV1CheckinAudioStream.
+type PostApiV1CheckinAudioStreamParams struct {
+	// SessionId Session ID for the check-in
+	SessionId openapi_types.UUID `form:"session_id" json:"session_id"`
+}
+
+// GetApiV1CheckinAudioWsParams defines parameters for GetApiV1CheckinAudioWs.
+type GetApiV1CheckinAudioWsParams struct {
+	// SessionId Active check-in session to transcribe
+	SessionId openapi_types.UUID `form:"session_id" json:"session_id"`
+}
+
+// GetApiV1ConsentsParams defines parameters for GetApiV1Consents.
+type GetApiV1ConsentsParams struct {
+	// UserId User whose data is read, the authenticated user when omitted
+	UserId *openapi_types.UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+}
+
+// GetApiV1DashboardExportParams defines parameters for GetApiV1DashboardExport.
+type GetApiV1DashboardExportParams struct {
+	// UserId User whose data is read, the authenticated user when omitted
+	UserId *openapi_types.UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// Format csv or json
+	Format *string `form:"format,omitempty" json:"format,omitempty"`
+
+	// Days Number of days up to today
+	Days *int `form:"days,omitempty" json:"days,omitempty"`
+}
+
+// GetApiV1DashboardSummaryParams defines parameters for GetApiV1DashboardSummary.
+type GetApiV1DashboardSummaryParams struct {
+	UserId openapi_types.UUID                  `form:"user_id" json:"user_id"`
+	Days   *GetApiV1DashboardSummaryParamsDays `form:"days,omitempty" json:"days,omitempty"`
+}
+
+// GetApiV1DashboardSummaryParamsDays defines parameters for GetApiV1DashboardSummary.
+type GetApiV1DashboardSummaryParamsDays int
+
+// GetApiV1ExportFhirParams defines parameters for GetApiV1ExportFhir.
+type GetApiV1ExportFhirParams struct {
+	// UserId User whose data is read, the authenticated user when omitted
+	UserId *openapi_types.UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+}
+
+// GetApiV1ExportHealthParams defines parameters for GetApiV1ExportHealth.
+type GetApiV1ExportHealthParams struct {
+	// UserId User whose data is read, the authenticated user when omitted
+	UserId *openapi_types.UUID            `form:"user_id,omitempty" json:"user_id,omitempty"`
+	Type   GetApiV1ExportHealthParamsType `form:"type" json:"type"`
+
+	// StartDate First day exported, inclusive
+	StartDate openapi_types.Date `form:"start_date" json:"start_date"`
+
+	// EndDate Last day exported, inclusive
+	EndDate openapi_types.Date `form:"end_date" json:"end_date"`
+}
+
+// GetApiV1ExportHealthParamsType defines parameters for GetApiV1ExportHealth.
+type GetApiV1ExportHealthParamsType string
+
+// GetApiV1GdprConsentParams defines parameters for GetApiV1GdprConsent.
+type GetApiV1GdprConsentParams struct {
+	// UserId User whose data is read, the authenticated user when omitted
+	UserId *openapi_types.UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+}
+
+// GetApiV1HealthAnomaliesParams defines parameters for GetApiV1HealthAnomalies.
+type GetApiV1HealthAnomaliesParams struct {
+	// UserId User whose data is read, the authenticated user when omitted
+	UserId *openapi_types.UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// Since Only anomalies observed since this date (YYYY-MM-DD) or RFC 3339 time
+	Since *string `form:"since,omitempty" json:"since,omitempty"`
+
+	// Limit Page size, 50 by default and capped at 500
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor next_cursor of the previous page; takes precedence over offset
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetApiV1HealthBloodPressureParams defines parameters for GetApiV1HealthBloodPressure.
+type GetApiV1HealthBloodPressureParams struct {
+	UserId openapi_types.UUID `form:"user_id" json:"user_id"`
+
+	// Limit Page size, 50 by default and capped at 500
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor next_cursor of the previous page; takes precedence over offset
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetApiV1HealthBloodPressureChartParams defines parameters for GetApiV1HealthBloodPressureChart.
+type GetApiV1HealthBloodPressureChartParams struct {
+	// UserId User whose data is read, the authenticated user when omitted
+	UserId *openapi_types.UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// StartDate First day charted, inclusive
+	StartDate openapi_types.Date `form:"start_date" json:"start_date"`
+
+	// EndDate Last day charted, inclusive; at most 731 days after start_date
+	EndDate     openapi_types.Date                                 `form:"end_date" json:"end_date"`
+	Granularity *GetApiV1HealthBloodPressureChartParamsGranularity `form:"granularity,omitempty" json:"granularity,omitempty"`
+}
+
+// GetApiV1HealthBloodPressureChartParamsGranularity defines parameters for GetApiV1HealthBloodPressureChart.
+type GetApiV1HealthBloodPressureChartParamsGranularity string
+
+// GetApiV1HealthFitnessParams defines parameters for GetApiV1HealthFitness.
+type GetApiV1HealthFitnessParams struct {
+	// UserId User whose data is read, the authenticated user when omitted
+	UserId *openapi_types.UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// From First day as YYYY-MM-DD, 30 days before to when omitted
+	From *string `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Last day as YYYY-MM-DD, today when omitted
+	To *string `form:"to,omitempty" json:"to,omitempty"`
+
+	// DataType Only data points of this type
+	DataType *string `form:"data_type,omitempty" json:"data_type,omitempty"`
+
+	// Aggregate daily returns per-day aggregates per data type for at most 366 days instead of a page of data points
+	Aggregate *string `form:"aggregate,omitempty" json:"aggregate,omitempty"`
+
+	// Limit Page size, 50 by default and capped at 500
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor next_cursor of the previous page; takes precedence over offset
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetApiV1HealthMedicationsParams defines parameters for GetApiV1HealthMedications.
+type GetApiV1HealthMedicationsParams struct {
+	UserId openapi_types.UUID `form:"user_id" json:"user_id"`
+
+	// Limit Page size, 50 by default and capped at 500
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor next_cursor of the previous page; takes precedence over offset
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetApiV1HealthMedicationsDueParams defines parameters for GetApiV1HealthMedicationsDue.
+type GetApiV1HealthMedicationsDueParams struct {
+	// UserId User whose data is read, the authenticated user when omitted
+	UserId *openapi_types.UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// At Time asked about, now when omitted
+	At *time.Time `form:"at,omitempty" json:"at,omitempty"`
+
+	// Window Duration from 1m to 12h before and after at, 1h when omitted
+	Window *string `form:"window,omitempty" json:"window,omitempty"`
+}
+
+// GetApiV1HealthMedicationsIdAdherenceParams defines parameters for GetApiV1HealthMedicationsIdAdherence.
+type GetApiV1HealthMedicationsIdAdherenceParams struct {
+	// From Date (YYYY-MM-DD) or RFC 3339 time of the oldest log
+	From *string `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Date (YYYY-MM-DD) or RFC 3339 time the logs precede; a date includes that whole day
+	To *string `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetApiV1HealthMedicationsIdScheduleParams defines parameters for GetApiV1HealthMedicationsIdSchedule.
+type GetApiV1HealthMedicationsIdScheduleParams struct {
+	// Count Number of upcoming reminders
+	Count *int `form:"count,omitempty" json:"count,omitempty"`
+}
+
+// GetApiV1HealthMenstruationParams defines parameters for GetApiV1HealthMenstruation.
+type GetApiV1HealthMenstruationParams struct {
+	UserId openapi_types.UUID `form:"user_id" json:"user_id"`
+
+	// Limit Page size, 50 by default and capped at 500
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor next_cursor of the previous page; takes precedence over offset
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetApiV1HealthMenstruationPredictionParams defines parameters for GetApiV1HealthMenstruationPrediction.
+type GetApiV1HealthMenstruationPredictionParams struct {
+	// UserId User whose data is read, the authenticated user when omitted
+	UserId *openapi_types.UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+}
+
+// GetApiV1HealthMenstruationStatsParams defines parameters for GetApiV1HealthMenstruationStats.
+type GetApiV1HealthMenstruationStatsParams struct {
+	// UserId User whose data is read, the authenticated user when omitted
+	UserId *openapi_types.UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+}
+
+// GetApiV1OrgsIdPanelAssignmentsParams defines parameters for GetApiV1OrgsIdPanelAssignments.
+type GetApiV1OrgsIdPanelAssignmentsParams struct {
+	ClinicianId openapi_types.UUID `form:"clinician_id" json:"clinician_id"`
+}
+
+// GetApiV1ReportsParams defines parameters for GetApiV1Reports.
+type GetApiV1ReportsParams struct {
+	// UserId User whose data is read, the authenticated user when omitted
+	UserId *openapi_types.UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// Limit Page size, 50 by default and capped at 500
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor next_cursor of the previous page; takes precedence over offset
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetApiV1ReportsVerifyParams defines parameters for GetApiV1ReportsVerify.
+type GetApiV1ReportsVerifyParams struct {
+	// Code Verification code printed on the report, or the SHA-256 of the PDF
+	Code *string `form:"code,omitempty" json:"code,omitempty"`
+}
+
+// GetApiV1UsersConfirmEmailParams defines parameters for GetApiV1UsersConfirmEmail.
+type GetApiV1UsersConfirmEmailParams struct {
+	// Token Signed token of the confirmation link
+	Token *string `form:"token,omitempty" json:"token,omitempty"`
+}
+
+// PostApiV1AdminOrganizationsJSONRequestBody defines body for PostApiV1AdminOrganizations for application/json ContentType.
+type PostApiV1AdminOrganizationsJSONRequestBody = CreateOrganizationRequest
+
+// PutApiV1AdminOrganizationsIdResidencyJSONRequestBody defines body for PutApiV1AdminOrganizationsIdResidency for application/json ContentType.
+type PutApiV1AdminOrganizationsIdResidencyJSONRequestBody = DataResidencyRequest
+
+// PutApiV1AdminPanelDigestJSONRequestBody defines body for PutApiV1AdminPanelDigest for application/json ContentType.
+type PutApiV1AdminPanelDigestJSONRequestBody = DigestSubscriptionRequest
+
+// PostApiV1AdminQuestionSetsJSONRequestBody defines body for PostApiV1AdminQuestionSets for application/json ContentType.
+type PostApiV1AdminQuestionSetsJSONRequestBody = CreateQuestionSetRequest
+
+// PostApiV1CheckinCompleteJSONRequestBody defines body for PostApiV1CheckinComplete for application/json ContentType.
+type PostApiV1CheckinCompleteJSONRequestBody = CompleteSessionRequest
+
+// PostApiV1CheckinPauseJSONRequestBody defines body for PostApiV1CheckinPause for application/json ContentType.
+type PostApiV1CheckinPauseJSONRequestBody = SessionRequest
+
+// PostApiV1CheckinReExtractJSONRequestBody defines body for PostApiV1CheckinReExtract for application/json ContentType.
+type PostApiV1CheckinReExtractJSONRequestBody = SessionRequest
+
+// PostApiV1CheckinRespondJSONRequestBody defines body for PostApiV1CheckinRespond for application/json ContentType.
+type PostApiV1CheckinRespondJSONRequestBody = RespondRequest
+
+// PostApiV1CheckinResumeJSONRequestBody defines body for PostApiV1CheckinResume for application/json ContentType.
+type PostApiV1CheckinResumeJSONRequestBody = SessionRequest
+
+// PostApiV1CheckinStartJSONRequestBody defines body for PostApiV1CheckinStart for application/json ContentType.
+type PostApiV1CheckinStartJSONRequestBody = StartSessionRequest
+
+// PutApiV1CheckinIdJSONRequestBody defines body for PutApiV1CheckinId for application/json ContentType.
+type PutApiV1CheckinIdJSONRequestBody = CheckInPatchRequest
+
+// PostApiV1ConsentsJSONRequestBody defines body for PostApiV1Consents for application/json ContentType.
+type PostApiV1ConsentsJSONRequestBody = ConsentRequest
+
+// PostApiV1GdprAnonymizeJSONRequestBody defines body for PostApiV1GdprAnonymize for application/json ContentType.
+type PostApiV1GdprAnonymizeJSONRequestBody = AnonymizeRequest
+
+// PostApiV1GdprConsentJSONRequestBody defines body for PostApiV1GdprConsent for application/json ContentType.
+type PostApiV1GdprConsentJSONRequestBody = RecordGDPRConsentRequest
+
+// PostApiV1HealthBloodPressureJSONRequestBody defines body for PostApiV1HealthBloodPressure for application/json ContentType.
+type PostApiV1HealthBloodPressureJSONRequestBody = BloodPressureRequest
+
+// PostApiV1HealthFitnessSyncJSONRequestBody defines body for PostApiV1HealthFitnessSync for application/json ContentType.
+type PostApiV1HealthFitnessSyncJSONRequestBody = FitnessSyncRequest
+
+// PostApiV1HealthMedicationsJSONRequestBody defines body for PostApiV1HealthMedications for application/json ContentType.
+type PostApiV1HealthMedicationsJSONRequestBody = CreateMedicationRequest
+
+// PutApiV1HealthMedicationsIdJSONRequestBody defines body for PutApiV1HealthMedicationsId for application/json ContentType.
+type PutApiV1HealthMedicationsIdJSONRequestBody = UpdateMedicationRequest
+
+// PostApiV1HealthMedicationsIdAdherenceJSONRequestBody defines body for PostApiV1HealthMedicationsIdAdherence for application/json ContentType.
+type PostApiV1HealthMedicationsIdAdherenceJSONRequestBody = MedicationAdherenceRequest
+
+// PutApiV1HealthMedicationsIdScheduleJSONRequestBody defines body for PutApiV1HealthMedicationsIdSchedule for application/json ContentType.
+type PutApiV1HealthMedicationsIdScheduleJSONRequestBody = MedicationScheduleRequest
+
+// PostApiV1HealthMenstruationJSONRequestBody defines body for PostApiV1HealthMenstruation for application/json ContentType.
+type PostApiV1HealthMenstruationJSONRequestBody = MenstruationRequest
+
+// PatchApiV1HealthMenstruationIdJSONRequestBody defines body for PatchApiV1HealthMenstruationId for application/json ContentType.
+type PatchApiV1HealthMenstruationIdJSONRequestBody = MenstruationUpdateRequest
+
+// PostApiV1InvitationsAcceptJSONRequestBody defines body for PostApiV1InvitationsAccept for application/json ContentType.
+type PostApiV1InvitationsAcceptJSONRequestBody = AcceptInvitationRequest
+
+// PostApiV1OrgsIdIntegrationsJSONRequestBody defines body for PostApiV1OrgsIdIntegrations for application/json ContentType.
+type PostApiV1OrgsIdIntegrationsJSONRequestBody = IntegrationRequest
+
+// PostApiV1OrgsIdInvitationsJSONRequestBody defines body for PostApiV1OrgsIdInvitations for application/json ContentType.
+type PostApiV1OrgsIdInvitationsJSONRequestBody = InviteMemberRequest
+
+// PostApiV1OrgsIdMembersUserIdRolesJSONRequestBody defines body for PostApiV1OrgsIdMembersUserIdRoles for application/json ContentType.
+type PostApiV1OrgsIdMembersUserIdRolesJSONRequestBody = AssignRoleRequest
+
+// PostApiV1OrgsIdPanelAssignmentsJSONRequestBody defines body for PostApiV1OrgsIdPanelAssignments for application/json ContentType.
+type PostApiV1OrgsIdPanelAssignmentsJSONRequestBody = AssignPatientRequest
+
+// PostApiV1ReportsGenerateJSONRequestBody defines body for PostApiV1ReportsGenerate for application/json ContentType.
+type PostApiV1ReportsGenerateJSONRequestBody = GenerateReportRequest
+
+// PutApiV1UsersIdEmailJSONRequestBody defines body for PutApiV1UsersIdEmail for application/json ContentType.
+type PutApiV1UsersIdEmailJSONRequestBody = UserEmailRequest
+
+// PutApiV1UsersIdQuestionSetJSONRequestBody defines body for PutApiV1UsersIdQuestionSet for application/json ContentType.
+type PutApiV1UsersIdQuestionSetJSONRequestBody = AssignQuestionSetRequest
+
+// PutApiV1UsersIdReportScheduleJSONRequestBody defines body for PutApiV1UsersIdReportSchedule for application/json ContentType.
+type PutApiV1UsersIdReportScheduleJSONRequestBody = ReportScheduleRequest
+
+// PutApiV1UsersIdSettingsJSONRequestBody defines body for PutApiV1UsersIdSettings for application/json ContentType.
+type PutApiV1UsersIdSettingsJSONRequestBody = UserSettingsRequest
+
+// PostApiV1UsersIdTokensJSONRequestBody defines body for PostApiV1UsersIdTokens for application/json ContentType.
+type PostApiV1UsersIdTokensJSONRequestBody = CreateTokenRequest
+
+// PostApiV1WebhooksJSONRequestBody defines body for PostApiV1Webhooks for application/json ContentType.
+type PostApiV1WebhooksJSONRequestBody = CreateWebhookRequest
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Search audit logs
+	// (GET /api/v1/admin/audit-logs)
+	GetApiV1AdminAuditLogs(c *gin.Context, params GetApiV1AdminAuditLogsParams)
+	// Restore archived audit logs
+	// (POST /api/v1/admin/audit/archives/{month}/restore)
+	PostApiV1AdminAuditArchivesMonthRestore(c *gin.Context, month string)
+	// Run dependency diagnostics
+	// (GET /api/v1/admin/diagnostics)
+	GetApiV1AdminDiagnostics(c *gin.Context)
+	// Get extraction quality
+	// (GET /api/v1/admin/extraction-quality)
+	GetApiV1AdminExtractionQuality(c *gin.Context, params GetApiV1AdminExtractionQualityParams)
+	// Get check-in stage latencies
+	// (GET /api/v1/admin/latency)
+	GetApiV1AdminLatency(c *gin.Context)
+	// Create organization
+	// (POST /api/v1/admin/organizations)
+	PostApiV1AdminOrganizations(c *gin.Context)
+	// Set organization data residency
+	// (PUT /api/v1/admin/organizations/{id}/residency)
+	PutApiV1AdminOrganizationsIdResidency(c *gin.Context, id openapi_types.UUID)
+	// Unsubscribe from panel digest
+	// (DELETE /api/v1/admin/panel/digest)
+	DeleteApiV1AdminPanelDigest(c *gin.Context, params DeleteApiV1AdminPanelDigestParams)
+	// Subscribe to panel digest
+	// (PUT /api/v1/admin/panel/digest)
+	PutApiV1AdminPanelDigest(c *gin.Context, params PutApiV1AdminPanelDigestParams)
+	// List panel findings
+	// (GET /api/v1/admin/panel/findings)
+	GetApiV1AdminPanelFindings(c *gin.Context, params GetApiV1AdminPanelFindingsParams)
+	// Create question set
+	// (POST /api/v1/admin/question-sets)
+	PostApiV1AdminQuestionSets(c *gin.Context)
+	// Get usage across all users
+	// (GET /api/v1/admin/usage)
+	GetApiV1AdminUsage(c *gin.Context)
+	// Get user timeline
+	// (GET /api/v1/admin/users/{id}/timeline)
+	GetApiV1AdminUsersIdTimeline(c *gin.Context, id openapi_types.UUID, params GetApiV1AdminUsersIdTimelineParams)
+	// List alerts
+	// (GET /api/v1/alerts)
+	GetApiV1Alerts(c *gin.Context, params GetApiV1AlertsParams)
+	// Acknowledge alert
+	// (POST /api/v1/alerts/{id}/acknowledge)
+	PostApiV1AlertsIdAcknowledge(c *gin.Context, id openapi_types.UUID)
+	// List audit logs
+	// (GET /api/v1/audit/logs)
+	GetApiV1AuditLogs(c *gin.Context, params GetApiV1AuditLogsParams)
+	// Stream audio from mobile app
+	// (POST /api/v1/checkin/audio-stream)
+	PostApiV1CheckinAudioStream(c *gin.Context, params PostApiV1CheckinAudioStreamParams)
+	// Stream live transcription
+	// (GET /api/v1/checkin/audio-ws)
+	GetApiV1CheckinAudioWs(c *gin.Context, params GetApiV1CheckinAudioWsParams)
+	// Complete check-in session
+	// (POST /api/v1/checkin/complete)
+	PostApiV1CheckinComplete(c *gin.Context)
+	// Pause check-in session
+	// (POST /api/v1/checkin/pause)
+	PostApiV1CheckinPause(c *gin.Context)
+	// Get question audio
+	// (GET /api/v1/checkin/question-audio/{sessionId}/{questionId})
+	GetApiV1CheckinQuestionAudioSessionIdQuestionId(c *gin.Context, sessionId openapi_types.UUID, questionId string)
+	// Re-extract check-in session
+	// (POST /api/v1/checkin/re-extract)
+	PostApiV1CheckinReExtract(c *gin.Context)
+	// Submit user response
+	// (POST /api/v1/checkin/respond)
+	PostApiV1CheckinRespond(c *gin.Context)
+	// Resume check-in session
+	// (POST /api/v1/checkin/resume)
+	PostApiV1CheckinResume(c *gin.Context)
+	// Start new check-in session
+	// (POST /api/v1/checkin/start)
+	PostApiV1CheckinStart(c *gin.Context)
+	// Get session status
+	// (GET /api/v1/checkin/status/{sessionId})
+	GetApiV1CheckinStatusSessionId(c *gin.Context, sessionId openapi_types.UUID)
+	// Correct check-in
+	// (PUT /api/v1/checkin/{id})
+	PutApiV1CheckinId(c *gin.Context, id openapi_types.UUID)
+	// Stream check-in session events
+	// (GET /api/v1/checkin/{sessionId}/events)
+	GetApiV1CheckinSessionIdEvents(c *gin.Context, sessionId openapi_types.UUID)
+	// List consents
+	// (GET /api/v1/consents)
+	GetApiV1Consents(c *gin.Context, params GetApiV1ConsentsParams)
+	// Grant or revoke consent
+	// (POST /api/v1/consents)
+	PostApiV1Consents(c *gin.Context)
+	// Export dashboard time series
+	// (GET /api/v1/dashboard/export)
+	GetApiV1DashboardExport(c *gin.Context, params GetApiV1DashboardExportParams)
+	// Get dashboard summary
+	// (GET /api/v1/dashboard/summary)
+	GetApiV1DashboardSummary(c *gin.Context, params GetApiV1DashboardSummaryParams)
+	// Export health data as FHIR
+	// (GET /api/v1/export/fhir)
+	GetApiV1ExportFhir(c *gin.Context, params GetApiV1ExportFhirParams)
+	// Export health data as CSV
+	// (GET /api/v1/export/health)
+	GetApiV1ExportHealth(c *gin.Context, params GetApiV1ExportHealthParams)
+	// Anonymize user data
+	// (POST /api/v1/gdpr/anonymize)
+	PostApiV1GdprAnonymize(c *gin.Context)
+	// GDPR consent history
+	// (GET /api/v1/gdpr/consent)
+	GetApiV1GdprConsent(c *gin.Context, params GetApiV1GdprConsentParams)
+	// Record GDPR consent
+	// (POST /api/v1/gdpr/consent)
+	PostApiV1GdprConsent(c *gin.Context)
+	// List health data anomalies
+	// (GET /api/v1/health/anomalies)
+	GetApiV1HealthAnomalies(c *gin.Context, params GetApiV1HealthAnomaliesParams)
+	// Get blood pressure history
+	// (GET /api/v1/health/blood-pressure)
+	GetApiV1HealthBloodPressure(c *gin.Context, params GetApiV1HealthBloodPressureParams)
+	// Log blood pressure reading
+	// (POST /api/v1/health/blood-pressure)
+	PostApiV1HealthBloodPressure(c *gin.Context)
+	// Get blood pressure chart data
+	// (GET /api/v1/health/blood-pressure/chart)
+	GetApiV1HealthBloodPressureChart(c *gin.Context, params GetApiV1HealthBloodPressureChartParams)
+	// List fitness data
+	// (GET /api/v1/health/fitness)
+	GetApiV1HealthFitness(c *gin.Context, params GetApiV1HealthFitnessParams)
+	// Sync fitness data from Health Connect
+	// (POST /api/v1/health/fitness-sync)
+	PostApiV1HealthFitnessSync(c *gin.Context)
+	// List medications
+	// (GET /api/v1/health/medications)
+	GetApiV1HealthMedications(c *gin.Context, params GetApiV1HealthMedicationsParams)
+	// Add medication
+	// (POST /api/v1/health/medications)
+	PostApiV1HealthMedications(c *gin.Context)
+	// List due medication doses
+	// (GET /api/v1/health/medications/due)
+	GetApiV1HealthMedicationsDue(c *gin.Context, params GetApiV1HealthMedicationsDueParams)
+	// Delete medication
+	// (DELETE /api/v1/health/medications/{id})
+	DeleteApiV1HealthMedicationsId(c *gin.Context, id openapi_types.UUID)
+	// Update medication
+	// (PUT /api/v1/health/medications/{id})
+	PutApiV1HealthMedicationsId(c *gin.Context, id openapi_types.UUID)
+	// List medication adherence
+	// (GET /api/v1/health/medications/{id}/adherence)
+	GetApiV1HealthMedicationsIdAdherence(c *gin.Context, id openapi_types.UUID, params GetApiV1HealthMedicationsIdAdherenceParams)
+	// Log medication adherence
+	// (POST /api/v1/health/medications/{id}/adherence)
+	PostApiV1HealthMedicationsIdAdherence(c *gin.Context, id openapi_types.UUID)
+	// Deactivate medication
+	// (POST /api/v1/health/medications/{id}/deactivate)
+	PostApiV1HealthMedicationsIdDeactivate(c *gin.Context, id openapi_types.UUID)
+	// Reactivate medication
+	// (POST /api/v1/health/medications/{id}/reactivate)
+	PostApiV1HealthMedicationsIdReactivate(c *gin.Context, id openapi_types.UUID)
+	// Restore medication
+	// (POST /api/v1/health/medications/{id}/restore)
+	PostApiV1HealthMedicationsIdRestore(c *gin.Context, id openapi_types.UUID)
+	// Get medication schedule
+	// (GET /api/v1/health/medications/{id}/schedule)
+	GetApiV1HealthMedicationsIdSchedule(c *gin.Context, id openapi_types.UUID, params GetApiV1HealthMedicationsIdScheduleParams)
+	// Set medication schedule
+	// (PUT /api/v1/health/medications/{id}/schedule)
+	PutApiV1HealthMedicationsIdSchedule(c *gin.Context, id openapi_types.UUID)
+	// Get menstruation history
+	// (GET /api/v1/health/menstruation)
+	GetApiV1HealthMenstruation(c *gin.Context, params GetApiV1HealthMenstruationParams)
+	// Log menstruation data
+	// (POST /api/v1/health/menstruation)
+	PostApiV1HealthMenstruation(c *gin.Context)
+	// Predict next menstruation cycle
+	// (GET /api/v1/health/menstruation/prediction)
+	GetApiV1HealthMenstruationPrediction(c *gin.Context, params GetApiV1HealthMenstruationPredictionParams)
+	// Get menstruation cycle statistics
+	// (GET /api/v1/health/menstruation/stats)
+	GetApiV1HealthMenstruationStats(c *gin.Context, params GetApiV1HealthMenstruationStatsParams)
+	// Update menstruation cycle
+	// (PATCH /api/v1/health/menstruation/{id})
+	PatchApiV1HealthMenstruationId(c *gin.Context, id openapi_types.UUID)
+	// Accept invitation
+	// (POST /api/v1/invitations/accept)
+	PostApiV1InvitationsAccept(c *gin.Context)
+	// List integrations
+	// (GET /api/v1/orgs/{id}/integrations)
+	GetApiV1OrgsIdIntegrations(c *gin.Context, id openapi_types.UUID)
+	// Create integration
+	// (POST /api/v1/orgs/{id}/integrations)
+	PostApiV1OrgsIdIntegrations(c *gin.Context, id openapi_types.UUID)
+	// List integration deliveries
+	// (GET /api/v1/orgs/{id}/integrations/{integration_id}/deliveries)
+	GetApiV1OrgsIdIntegrationsIntegrationIdDeliveries(c *gin.Context, id openapi_types.UUID, integrationId openapi_types.UUID)
+	// Test integration
+	// (POST /api/v1/orgs/{id}/integrations/{integration_id}/test)
+	PostApiV1OrgsIdIntegrationsIntegrationIdTest(c *gin.Context, id openapi_types.UUID, integrationId openapi_types.UUID)
+	// Invite member
+	// (POST /api/v1/orgs/{id}/invitations)
+	PostApiV1OrgsIdInvitations(c *gin.Context, id openapi_types.UUID)
+	// List members
+	// (GET /api/v1/orgs/{id}/members)
+	GetApiV1OrgsIdMembers(c *gin.Context, id openapi_types.UUID)
+	// Assign role
+	// (POST /api/v1/orgs/{id}/members/{user_id}/roles)
+	PostApiV1OrgsIdMembersUserIdRoles(c *gin.Context, id openapi_types.UUID, userId openapi_types.UUID)
+	// Revoke role
+	// (DELETE /api/v1/orgs/{id}/members/{user_id}/roles/{role})
+	DeleteApiV1OrgsIdMembersUserIdRolesRole(c *gin.Context, id openapi_types.UUID, userId openapi_types.UUID, role Role)
+	// List panel assignments
+	// (GET /api/v1/orgs/{id}/panel-assignments)
+	GetApiV1OrgsIdPanelAssignments(c *gin.Context, id openapi_types.UUID, params GetApiV1OrgsIdPanelAssignmentsParams)
+	// Assign patient to panel
+	// (POST /api/v1/orgs/{id}/panel-assignments)
+	PostApiV1OrgsIdPanelAssignments(c *gin.Context, id openapi_types.UUID)
+	// Unassign patient from panel
+	// (DELETE /api/v1/orgs/{id}/panel-assignments/{clinician_id}/{patient_id})
+	DeleteApiV1OrgsIdPanelAssignmentsClinicianIdPatientId(c *gin.Context, id openapi_types.UUID, clinicianId openapi_types.UUID, patientId openapi_types.UUID)
+	// Revoke sharing consent
+	// (DELETE /api/v1/orgs/{id}/sharing-consent)
+	DeleteApiV1OrgsIdSharingConsent(c *gin.Context, id openapi_types.UUID)
+	// Grant sharing consent
+	// (PUT /api/v1/orgs/{id}/sharing-consent)
+	PutApiV1OrgsIdSharingConsent(c *gin.Context, id openapi_types.UUID)
+	// List reports
+	// (GET /api/v1/reports)
+	GetApiV1Reports(c *gin.Context, params GetApiV1ReportsParams)
+	// Generate health report
+	// (POST /api/v1/reports/generate)
+	PostApiV1ReportsGenerate(c *gin.Context)
+	// Get report generation job status
+	// (GET /api/v1/reports/jobs/{job_id})
+	GetApiV1ReportsJobsJobId(c *gin.Context, jobId openapi_types.UUID)
+	// Verify report
+	// (GET /api/v1/reports/verify)
+	GetApiV1ReportsVerify(c *gin.Context, params GetApiV1ReportsVerifyParams)
+	// Delete report
+	// (DELETE /api/v1/reports/{id})
+	DeleteApiV1ReportsId(c *gin.Context, id openapi_types.UUID)
+	// Download report
+	// (GET /api/v1/reports/{id})
+	GetApiV1ReportsId(c *gin.Context, id openapi_types.UUID)
+	// Get report status
+	// (GET /api/v1/reports/{id}/status)
+	GetApiV1ReportsIdStatus(c *gin.Context, id openapi_types.UUID)
+	// Get report download URL
+	// (GET /api/v1/reports/{id}/url)
+	GetApiV1ReportsIdUrl(c *gin.Context, id openapi_types.UUID)
+	// Confirm email address
+	// (GET /api/v1/users/confirm-email)
+	GetApiV1UsersConfirmEmail(c *gin.Context, params GetApiV1UsersConfirmEmailParams)
+	// List cycle suggestions
+	// (GET /api/v1/users/{id}/cycle-suggestions)
+	GetApiV1UsersIdCycleSuggestions(c *gin.Context, id openapi_types.UUID)
+	// Accept cycle suggestion
+	// (POST /api/v1/users/{id}/cycle-suggestions/{suggestion_id}/accept)
+	PostApiV1UsersIdCycleSuggestionsSuggestionIdAccept(c *gin.Context, id openapi_types.UUID, suggestionId openapi_types.UUID)
+	// Dismiss cycle suggestion
+	// (POST /api/v1/users/{id}/cycle-suggestions/{suggestion_id}/dismiss)
+	PostApiV1UsersIdCycleSuggestionsSuggestionIdDismiss(c *gin.Context, id openapi_types.UUID, suggestionId openapi_types.UUID)
+	// Change email address
+	// (PUT /api/v1/users/{id}/email)
+	PutApiV1UsersIdEmail(c *gin.Context, id openapi_types.UUID)
+	// Resend email confirmation
+	// (POST /api/v1/users/{id}/email/confirmation)
+	PostApiV1UsersIdEmailConfirmation(c *gin.Context, id openapi_types.UUID)
+	// Get user profile
+	// (GET /api/v1/users/{id}/profile)
+	GetApiV1UsersIdProfile(c *gin.Context, id openapi_types.UUID)
+	// Assign question set
+	// (PUT /api/v1/users/{id}/question-set)
+	PutApiV1UsersIdQuestionSet(c *gin.Context, id openapi_types.UUID)
+	// Get report schedule
+	// (GET /api/v1/users/{id}/report-schedule)
+	GetApiV1UsersIdReportSchedule(c *gin.Context, id openapi_types.UUID)
+	// Set report schedule
+	// (PUT /api/v1/users/{id}/report-schedule)
+	PutApiV1UsersIdReportSchedule(c *gin.Context, id openapi_types.UUID)
+	// Get user settings
+	// (GET /api/v1/users/{id}/settings)
+	GetApiV1UsersIdSettings(c *gin.Context, id openapi_types.UUID)
+	// Update user settings
+	// (PUT /api/v1/users/{id}/settings)
+	PutApiV1UsersIdSettings(c *gin.Context, id openapi_types.UUID)
+	// List personal access tokens
+	// (GET /api/v1/users/{id}/tokens)
+	GetApiV1UsersIdTokens(c *gin.Context, id openapi_types.UUID)
+	// Create personal access token
+	// (POST /api/v1/users/{id}/tokens)
+	PostApiV1UsersIdTokens(c *gin.Context, id openapi_types.UUID)
+	// Revoke personal access token
+	// (DELETE /api/v1/users/{id}/tokens/{token_id})
+	DeleteApiV1UsersIdTokensTokenId(c *gin.Context, id openapi_types.UUID, tokenId openapi_types.UUID)
+	// Get stored data usage
+	// (GET /api/v1/users/{id}/usage)
+	GetApiV1UsersIdUsage(c *gin.Context, id openapi_types.UUID)
+	// Register webhook
+	// (POST /api/v1/webhooks)
+	PostApiV1Webhooks(c *gin.Context)
+	// Delete webhook
+	// (DELETE /api/v1/webhooks/{id})
+	DeleteApiV1WebhooksId(c *gin.Context, id openapi_types.UUID)
+	// Health check endpoint
+	// (GET /health)
+	GetHealth(c *gin.Context)
+	// Prometheus metrics
+	// (GET /metrics)
+	GetMetrics(c *gin.Context)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandler       func(*gin.Context, error, int)
+}
+
+type MiddlewareFunc func(c *gin.Context)
+
+// GetApiV1AdminAuditLogs operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1AdminAuditLogs(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1AdminAuditLogsParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "operation_type" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "operation_type", c.Request.URL.Query(), &params.OperationType, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter operation_type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "resource_type" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "resource_type", c.Request.URL.Query(), &params.ResourceType, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter resource_type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "ip_address" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "ip_address", c.Request.URL.Query(), &params.IpAddress, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter ip_address: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "start_time" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "start_time", c.Request.URL.Query(), &params.StartTime, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter start_time: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "end_time" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "end_time", c.Request.URL.Query(), &params.EndTime, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter end_time: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", c.Request.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1AdminAuditLogs(c, params)
+}
+
+// PostApiV1AdminAuditArchivesMonthRestore operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1AdminAuditArchivesMonthRestore(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "month" -------------
+	var month string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "month", c.Param("month"), &month, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter month: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1AdminAuditArchivesMonthRestore(c, month)
+}
+
+// GetApiV1AdminDiagnostics operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1AdminDiagnostics(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1AdminDiagnostics(c)
+}
+
+// GetApiV1AdminExtractionQuality operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1AdminExtractionQuality(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1AdminExtractionQualityParams
+
+	// ------------- Optional query parameter "days" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "days", c.Request.URL.Query(), &params.Days, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter days: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1AdminExtractionQuality(c, params)
+}
+
+// GetApiV1AdminLatency operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1AdminLatency(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1AdminLatency(c)
+}
+
+// PostApiV1AdminOrganizations operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1AdminOrganizations(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1AdminOrganizations(c)
+}
+
+// PutApiV1AdminOrganizationsIdResidency operation middleware
+func (siw *ServerInterfaceWrapper) PutApiV1AdminOrganizationsIdResidency(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutApiV1AdminOrganizationsIdResidency(c, id)
+}
+
+// DeleteApiV1AdminPanelDigest operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiV1AdminPanelDigest(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteApiV1AdminPanelDigestParams
+
+	// ------------- Required query parameter "organization_id" -------------
+
+	if paramValue := c.Query("organization_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument organization_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "organization_id", c.Request.URL.Query(), &params.OrganizationId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter organization_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteApiV1AdminPanelDigest(c, params)
+}
+
+// PutApiV1AdminPanelDigest operation middleware
+func (siw *ServerInterfaceWrapper) PutApiV1AdminPanelDigest(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutApiV1AdminPanelDigestParams
+
+	// ------------- Required query parameter "organization_id" -------------
+
+	if paramValue := c.Query("organization_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument organization_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "organization_id", c.Request.URL.Query(), &params.OrganizationId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter organization_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutApiV1AdminPanelDigest(c, params)
+}
+
+// GetApiV1AdminPanelFindings operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1AdminPanelFindings(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1AdminPanelFindingsParams
+
+	// ------------- Required query parameter "organization_id" -------------
+
+	if paramValue := c.Query("organization_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument organization_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "organization_id", c.Request.URL.Query(), &params.OrganizationId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter organization_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "clinician_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "clinician_id", c.Request.URL.Query(), &params.ClinicianId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter clinician_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "since", c.Request.URL.Query(), &params.Since, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter since: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", c.Request.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "offset", c.Request.URL.Query(), &params.Offset, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1AdminPanelFindings(c, params)
+}
+
+// PostApiV1AdminQuestionSets operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1AdminQuestionSets(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1AdminQuestionSets(c)
+}
+
+// GetApiV1AdminUsage operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1AdminUsage(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1AdminUsage(c)
+}
+
+// GetApiV1AdminUsersIdTimeline operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1AdminUsersIdTimeline(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1AdminUsersIdTimelineParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", c.Request.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1AdminUsersIdTimeline(c, id, params)
+}
+
+// GetApiV1Alerts operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1Alerts(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1AlertsParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "include_acknowledged" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "include_acknowledged", c.Request.URL.Query(), &params.IncludeAcknowledged, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter include_acknowledged: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1Alerts(c, params)
+}
+
+// PostApiV1AlertsIdAcknowledge operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1AlertsIdAcknowledge(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1AlertsIdAcknowledge(c, id)
+}
+
+// GetApiV1AuditLogs operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1AuditLogs(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1AuditLogsParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "operation_type" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "operation_type", c.Request.URL.Query(), &params.OperationType, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter operation_type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "resource_type" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "resource_type", c.Request.URL.Query(), &params.ResourceType, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter resource_type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "ip_address" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "ip_address", c.Request.URL.Query(), &params.IpAddress, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter ip_address: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "from", c.Request.URL.Query(), &params.From, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "to", c.Request.URL.Query(), &params.To, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", c.Request.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "offset", c.Request.URL.Query(), &params.Offset, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1AuditLogs(c, params)
+}
+
+// PostApiV1CheckinAudioStream operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1CheckinAudioStream(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostApiV1CheckinAudioStreamParams
+
+	// ------------- Required query parameter "session_id" -------------
+
+	if paramValue := c.Query("session_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument session_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "session_id", c.Request.URL.Query(), &params.SessionId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter session_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1CheckinAudioStream(c, params)
+}
+
+// GetApiV1CheckinAudioWs operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1CheckinAudioWs(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1CheckinAudioWsParams
+
+	// ------------- Required query parameter "session_id" -------------
+
+	if paramValue := c.Query("session_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument session_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "session_id", c.Request.URL.Query(), &params.SessionId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter session_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1CheckinAudioWs(c, params)
+}
+
+// PostApiV1CheckinComplete operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1CheckinComplete(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1CheckinComplete(c)
+}
+
+// PostApiV1CheckinPause operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1CheckinPause(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1CheckinPause(c)
+}
+
+// GetApiV1CheckinQuestionAudioSessionIdQuestionId operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1CheckinQuestionAudioSessionIdQuestionId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sessionId" -------------
+	var sessionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sessionId", c.Param("sessionId"), &sessionId, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sessionId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "questionId" -------------
+	var questionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "questionId", c.Param("questionId"), &questionId, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter questionId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1CheckinQuestionAudioSessionIdQuestionId(c, sessionId, questionId)
+}
+
+// PostApiV1CheckinReExtract operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1CheckinReExtract(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1CheckinReExtract(c)
+}
+
+// PostApiV1CheckinRespond operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1CheckinRespond(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1CheckinRespond(c)
+}
+
+// PostApiV1CheckinResume operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1CheckinResume(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1CheckinResume(c)
+}
+
+// PostApiV1CheckinStart operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1CheckinStart(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1CheckinStart(c)
+}
+
+// GetApiV1CheckinStatusSessionId operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1CheckinStatusSessionId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sessionId" -------------
+	var sessionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sessionId", c.Param("sessionId"), &sessionId, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sessionId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1CheckinStatusSessionId(c, sessionId)
+}
+
+// PutApiV1CheckinId operation middleware
+func (siw *ServerInterfaceWrapper) PutApiV1CheckinId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutApiV1CheckinId(c, id)
+}
+
+// GetApiV1CheckinSessionIdEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1CheckinSessionIdEvents(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sessionId" -------------
+	var sessionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sessionId", c.Param("sessionId"), &sessionId, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sessionId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1CheckinSessionIdEvents(c, sessionId)
+}
+
+// GetApiV1Consents operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1Consents(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1ConsentsParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1Consents(c, params)
+}
+
+// PostApiV1Consents operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1Consents(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1Consents(c)
+}
+
+// GetApiV1DashboardExport operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1DashboardExport(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1DashboardExportParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "format", c.Request.URL.Query(), &params.Format, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "days" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "days", c.Request.URL.Query(), &params.Days, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter days: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1DashboardExport(c, params)
+}
+
+// GetApiV1DashboardSummary operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1DashboardSummary(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1DashboardSummaryParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := c.Query("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument user_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "days" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "days", c.Request.URL.Query(), &params.Days, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter days: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1DashboardSummary(c, params)
+}
+
+// GetApiV1ExportFhir operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1ExportFhir(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1ExportFhirParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1ExportFhir(c, params)
+}
+
+// GetApiV1ExportHealth operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1ExportHealth(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1ExportHealthParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "type" -------------
+
+	if paramValue := c.Query("type"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument type is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "type", c.Request.URL.Query(), &params.Type, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "start_date" -------------
+
+	if paramValue := c.Query("start_date"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument start_date is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "start_date", c.Request.URL.Query(), &params.StartDate, runtime.BindQueryParameterOptions{Type: "string", Format: "date"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter start_date: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "end_date" -------------
+
+	if paramValue := c.Query("end_date"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument end_date is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "end_date", c.Request.URL.Query(), &params.EndDate, runtime.BindQueryParameterOptions{Type: "string", Format: "date"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter end_date: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1ExportHealth(c, params)
+}
+
+// PostApiV1GdprAnonymize operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1GdprAnonymize(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1GdprAnonymize(c)
+}
+
+// GetApiV1GdprConsent operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1GdprConsent(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1GdprConsentParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1GdprConsent(c, params)
+}
+
+// PostApiV1GdprConsent operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1GdprConsent(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1GdprConsent(c)
+}
+
+// GetApiV1HealthAnomalies operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1HealthAnomalies(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1HealthAnomaliesParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "since", c.Request.URL.Query(), &params.Since, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter since: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", c.Request.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "offset", c.Request.URL.Query(), &params.Offset, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1HealthAnomalies(c, params)
+}
+
+// GetApiV1HealthBloodPressure operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1HealthBloodPressure(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1HealthBloodPressureParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := c.Query("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument user_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", c.Request.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "offset", c.Request.URL.Query(), &params.Offset, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1HealthBloodPressure(c, params)
+}
+
+// PostApiV1HealthBloodPressure operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1HealthBloodPressure(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1HealthBloodPressure(c)
+}
+
+// GetApiV1HealthBloodPressureChart operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1HealthBloodPressureChart(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1HealthBloodPressureChartParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "start_date" -------------
+
+	if paramValue := c.Query("start_date"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument start_date is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "start_date", c.Request.URL.Query(), &params.StartDate, runtime.BindQueryParameterOptions{Type: "string", Format: "date"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter start_date: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "end_date" -------------
+
+	if paramValue := c.Query("end_date"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument end_date is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "end_date", c.Request.URL.Query(), &params.EndDate, runtime.BindQueryParameterOptions{Type: "string", Format: "date"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter end_date: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "granularity" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "granularity", c.Request.URL.Query(), &params.Granularity, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter granularity: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1HealthBloodPressureChart(c, params)
+}
+
+// GetApiV1HealthFitness operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1HealthFitness(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1HealthFitnessParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "from", c.Request.URL.Query(), &params.From, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "to", c.Request.URL.Query(), &params.To, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "data_type" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "data_type", c.Request.URL.Query(), &params.DataType, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter data_type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "aggregate" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "aggregate", c.Request.URL.Query(), &params.Aggregate, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter aggregate: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", c.Request.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "offset", c.Request.URL.Query(), &params.Offset, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1HealthFitness(c, params)
+}
+
+// PostApiV1HealthFitnessSync operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1HealthFitnessSync(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1HealthFitnessSync(c)
+}
+
+// GetApiV1HealthMedications operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1HealthMedications(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1HealthMedicationsParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := c.Query("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument user_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", c.Request.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "offset", c.Request.URL.Query(), &params.Offset, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1HealthMedications(c, params)
+}
+
+// PostApiV1HealthMedications operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1HealthMedications(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1HealthMedications(c)
+}
+
+// GetApiV1HealthMedicationsDue operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1HealthMedicationsDue(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1HealthMedicationsDueParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "at" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "at", c.Request.URL.Query(), &params.At, runtime.BindQueryParameterOptions{Type: "string", Format: "date-time"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter at: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "window" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "window", c.Request.URL.Query(), &params.Window, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter window: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1HealthMedicationsDue(c, params)
+}
+
+// DeleteApiV1HealthMedicationsId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiV1HealthMedicationsId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteApiV1HealthMedicationsId(c, id)
+}
+
+// PutApiV1HealthMedicationsId operation middleware
+func (siw *ServerInterfaceWrapper) PutApiV1HealthMedicationsId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutApiV1HealthMedicationsId(c, id)
+}
+
+// GetApiV1HealthMedicationsIdAdherence operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1HealthMedicationsIdAdherence(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1HealthMedicationsIdAdherenceParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "from", c.Request.URL.Query(), &params.From, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "to", c.Request.URL.Query(), &params.To, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1HealthMedicationsIdAdherence(c, id, params)
+}
+
+// PostApiV1HealthMedicationsIdAdherence operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1HealthMedicationsIdAdherence(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1HealthMedicationsIdAdherence(c, id)
+}
+
+// PostApiV1HealthMedicationsIdDeactivate operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1HealthMedicationsIdDeactivate(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1HealthMedicationsIdDeactivate(c, id)
+}
+
+// PostApiV1HealthMedicationsIdReactivate operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1HealthMedicationsIdReactivate(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1HealthMedicationsIdReactivate(c, id)
+}
+
+// PostApiV1HealthMedicationsIdRestore operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1HealthMedicationsIdRestore(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1HealthMedicationsIdRestore(c, id)
+}
+
+// GetApiV1HealthMedicationsIdSchedule operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1HealthMedicationsIdSchedule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1HealthMedicationsIdScheduleParams
+
+	// ------------- Optional query parameter "count" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "count", c.Request.URL.Query(), &params.Count, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter count: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1HealthMedicationsIdSchedule(c, id, params)
+}
+
+// PutApiV1HealthMedicationsIdSchedule operation middleware
+func (siw *ServerInterfaceWrapper) PutApiV1HealthMedicationsIdSchedule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutApiV1HealthMedicationsIdSchedule(c, id)
+}
+
+// GetApiV1HealthMenstruation operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1HealthMenstruation(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1HealthMenstruationParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := c.Query("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument user_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", c.Request.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "offset", c.Request.URL.Query(), &params.Offset, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1HealthMenstruation(c, params)
+}
+
+// PostApiV1HealthMenstruation operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1HealthMenstruation(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1HealthMenstruation(c)
+}
+
+// GetApiV1HealthMenstruationPrediction operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1HealthMenstruationPrediction(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1HealthMenstruationPredictionParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1HealthMenstruationPrediction(c, params)
+}
+
+// GetApiV1HealthMenstruationStats operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1HealthMenstruationStats(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1HealthMenstruationStatsParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1HealthMenstruationStats(c, params)
+}
+
+// PatchApiV1HealthMenstruationId operation middleware
+func (siw *ServerInterfaceWrapper) PatchApiV1HealthMenstruationId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PatchApiV1HealthMenstruationId(c, id)
+}
+
+// PostApiV1InvitationsAccept operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1InvitationsAccept(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1InvitationsAccept(c)
+}
+
+// GetApiV1OrgsIdIntegrations operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1OrgsIdIntegrations(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1OrgsIdIntegrations(c, id)
+}
+
+// PostApiV1OrgsIdIntegrations operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1OrgsIdIntegrations(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1OrgsIdIntegrations(c, id)
+}
+
+// GetApiV1OrgsIdIntegrationsIntegrationIdDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1OrgsIdIntegrationsIntegrationIdDeliveries(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "integration_id" -------------
+	var integrationId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "integration_id", c.Param("integration_id"), &integrationId, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter integration_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1OrgsIdIntegrationsIntegrationIdDeliveries(c, id, integrationId)
+}
+
+// PostApiV1OrgsIdIntegrationsIntegrationIdTest operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1OrgsIdIntegrationsIntegrationIdTest(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "integration_id" -------------
+	var integrationId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "integration_id", c.Param("integration_id"), &integrationId, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter integration_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1OrgsIdIntegrationsIntegrationIdTest(c, id, integrationId)
+}
+
+// PostApiV1OrgsIdInvitations operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1OrgsIdInvitations(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1OrgsIdInvitations(c, id)
+}
+
+// GetApiV1OrgsIdMembers operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1OrgsIdMembers(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1OrgsIdMembers(c, id)
+}
+
+// PostApiV1OrgsIdMembersUserIdRoles operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1OrgsIdMembersUserIdRoles(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1OrgsIdMembersUserIdRoles(c, id, userId)
+}
+
+// DeleteApiV1OrgsIdMembersUserIdRolesRole operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiV1OrgsIdMembersUserIdRolesRole(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "role" -------------
+	var role Role
+
+	err = runtime.BindStyledParameterWithOptions("simple", "role", c.Param("role"), &role, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter role: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteApiV1OrgsIdMembersUserIdRolesRole(c, id, userId, role)
+}
+
+// GetApiV1OrgsIdPanelAssignments operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1OrgsIdPanelAssignments(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1OrgsIdPanelAssignmentsParams
+
+	// ------------- Required query parameter "clinician_id" -------------
+
+	if paramValue := c.Query("clinician_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument clinician_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "clinician_id", c.Request.URL.Query(), &params.ClinicianId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter clinician_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1OrgsIdPanelAssignments(c, id, params)
+}
+
+// PostApiV1OrgsIdPanelAssignments operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1OrgsIdPanelAssignments(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1OrgsIdPanelAssignments(c, id)
+}
+
+// DeleteApiV1OrgsIdPanelAssignmentsClinicianIdPatientId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiV1OrgsIdPanelAssignmentsClinicianIdPatientId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "clinician_id" -------------
+	var clinicianId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "clinician_id", c.Param("clinician_id"), &clinicianId, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter clinician_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "patient_id" -------------
+	var patientId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "patient_id", c.Param("patient_id"), &patientId, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter patient_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteApiV1OrgsIdPanelAssignmentsClinicianIdPatientId(c, id, clinicianId, patientId)
+}
+
+// DeleteApiV1OrgsIdSharingConsent operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiV1OrgsIdSharingConsent(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteApiV1OrgsIdSharingConsent(c, id)
+}
+
+// PutApiV1OrgsIdSharingConsent operation middleware
+func (siw *ServerInterfaceWrapper) PutApiV1OrgsIdSharingConsent(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutApiV1OrgsIdSharingConsent(c, id)
+}
+
+// GetApiV1Reports operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1Reports(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1ReportsParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", c.Request.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "offset", c.Request.URL.Query(), &params.Offset, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1Reports(c, params)
+}
+
+// PostApiV1ReportsGenerate operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1ReportsGenerate(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostApiV1ReportsGenerate(c)
+}
+
+// GetApiV1ReportsJobsJobId operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1ReportsJobsJobId(c *gin.Context) {
 
-// MenstruationResponse defines model for MenstruationResponse.
-type MenstruationResponse struct {
-	CreatedAt     *time.Time                         `json:"created_at,omitempty"`
-	EndDate       *openapi_types.Date                `json:"end_date,omitempty"`
-	FlowIntensity *MenstruationResponseFlowIntensity `json:"flow_intensity,omitempty"`
-	Id            *openapi_types.UUID                `json:"id,omitempty"`
-	StartDate     *openapi_types.Date                `json:"start_date,omitempty"`
-	Symptoms      *[]string                          `json:"symptoms,omitempty"`
-	UserId        *openapi_types.UUID                `json:"user_id,omitempty"`
-}
+	var err error
 
-// MenstruationResponseFlowIntensity defines model for MenstruationResponse.FlowIntensity.
-type MenstruationResponseFlowIntensity string
+	// ------------- Path parameter "job_id" -------------
+	var jobId openapi_types.UUID
 
-// ReportResponse defines model for ReportResponse.
-type ReportResponse struct {
-	DateRangeEnd   *openapi_types.Date   `json:"date_range_end,omitempty"`
-	DateRangeStart *openapi_types.Date   `json:"date_range_start,omitempty"`
-	GeneratedAt    *time.Time            `json:"generated_at,omitempty"`
-	Id             *openapi_types.UUID   `json:"id,omitempty"`
-	Status         *ReportResponseStatus `json:"status,omitempty"`
-	UserId         *openapi_types.UUID   `json:"user_id,omitempty"`
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "job_id", c.Param("job_id"), &jobId, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter job_id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-// ReportResponseStatus defines model for ReportResponse.Status.
-type ReportResponseStatus string
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-// RespondRequest defines model for RespondRequest.
-type RespondRequest struct {
-	// Response User's transcribed response
-	Response  string             `json:"response"`
-	SessionId openapi_types.UUID `json:"session_id"`
+	siw.Handler.GetApiV1ReportsJobsJobId(c, jobId)
 }
 
-// SessionResponse defines model for SessionResponse.
-type SessionResponse struct {
-	QuestionId *string `json:"question_id,omitempty"`
+// GetApiV1ReportsVerify operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1ReportsVerify(c *gin.Context) {
 
-	// QuestionText First question in Hungarian
-	QuestionText *string                `json:"question_text,omitempty"`
-	SessionId    *openapi_types.UUID    `json:"session_id,omitempty"`
-	StartedAt    *time.Time             `json:"started_at,omitempty"`
-	Status       *SessionResponseStatus `json:"status,omitempty"`
-	UserId       *openapi_types.UUID    `json:"user_id,omitempty"`
-}
+	var err error
 
-// SessionResponseStatus defines model for SessionResponse.Status.
-type SessionResponseStatus string
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1ReportsVerifyParams
 
-// SessionStatus defines model for SessionStatus.
-type SessionStatus struct {
-	CompletedAt       *time.Time           `json:"completed_at,omitempty"`
-	QuestionsAnswered *int                 `json:"questions_answered,omitempty"`
-	SessionId         *openapi_types.UUID  `json:"session_id,omitempty"`
-	StartedAt         *time.Time           `json:"started_at,omitempty"`
-	Status            *SessionStatusStatus `json:"status,omitempty"`
-	TotalQuestions    *int                 `json:"total_questions,omitempty"`
-}
+	// ------------- Optional query parameter "code" -------------
 
-// SessionStatusStatus defines model for SessionStatus.Status.
-type SessionStatusStatus string
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "code", c.Request.URL.Query(), &params.Code, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter code: %w", err), http.StatusBadRequest)
+		return
+	}
 
-// StartSessionRequest defines model for StartSessionRequest.
-type StartSessionRequest struct {
-	UserId openapi_types.UUID `json:"user_id"`
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-// UpdateMedicationRequest defines model for UpdateMedicationRequest.
-type UpdateMedicationRequest struct {
-	Dosage    *string             `json:"dosage,omitempty"`
-	EndDate   *openapi_types.Date `json:"end_date,omitempty"`
-	Frequency *string             `json:"frequency,omitempty"`
-	Name      *string             `json:"name,omitempty"`
-	Notes     *string             `json:"notes,omitempty"`
+	siw.Handler.GetApiV1ReportsVerify(c, params)
 }
 
-// BadRequest defines model for BadRequest.
-type BadRequest = ErrorResponse
+// DeleteApiV1ReportsId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiV1ReportsId(c *gin.Context) {
 
-// InternalError defines model for InternalError.
-type InternalError = ErrorResponse
+	var err error
 
-// NotFound defines model for NotFound.
-type NotFound = ErrorResponse
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
 
-// ServiceUnavailable defines model for ServiceUnavailable.
-type ServiceUnavailable = ErrorResponse
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-// PostApiV1CheckinAudioStreamParams defines parameters for PostApiV1CheckinAudioStream.
-type PostApiV1CheckinAudioStreamParams struct {
-	// SessionId Session ID for the check-in
-	SessionId openapi_types.UUID `form:"session_id" json:"session_id"`
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-// GetApiV1DashboardSummaryParams defines parameters for GetApiV1DashboardSummary.
-type GetApiV1DashboardSummaryParams struct {
-	UserId openapi_types.UUID                  `form:"user_id" json:"user_id"`
-	Days   *GetApiV1DashboardSummaryParamsDays `form:"days,omitempty" json:"days,omitempty"`
+	siw.Handler.DeleteApiV1ReportsId(c, id)
 }
 
-// GetApiV1DashboardSummaryParamsDays defines parameters for GetApiV1DashboardSummary.
-type GetApiV1DashboardSummaryParamsDays int
+// GetApiV1ReportsId operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1ReportsId(c *gin.Context) {
 
-// GetApiV1HealthBloodPressureParams defines parameters for GetApiV1HealthBloodPressure.
-type GetApiV1HealthBloodPressureParams struct {
-	UserId openapi_types.UUID `form:"user_id" json:"user_id"`
-}
+	var err error
 
-// GetApiV1HealthMedicationsParams defines parameters for GetApiV1HealthMedications.
-type GetApiV1HealthMedicationsParams struct {
-	UserId openapi_types.UUID `form:"user_id" json:"user_id"`
-}
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
 
-// GetApiV1HealthMenstruationParams defines parameters for GetApiV1HealthMenstruation.
-type GetApiV1HealthMenstruationParams struct {
-	UserId openapi_types.UUID `form:"user_id" json:"user_id"`
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetApiV1ReportsId(c, id)
 }
 
-// PostApiV1CheckinCompleteJSONRequestBody defines body for PostApiV1CheckinComplete for application/json ContentType.
-type PostApiV1CheckinCompleteJSONRequestBody = CompleteSessionRequest
+// GetApiV1ReportsIdStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1ReportsIdStatus(c *gin.Context) {
 
-// PostApiV1CheckinRespondJSONRequestBody defines body for PostApiV1CheckinRespond for application/json ContentType.
-type PostApiV1CheckinRespondJSONRequestBody = RespondRequest
+	var err error
 
-// PostApiV1CheckinStartJSONRequestBody defines body for PostApiV1CheckinStart for application/json ContentType.
-type PostApiV1CheckinStartJSONRequestBody = StartSessionRequest
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
 
-// PostApiV1HealthBloodPressureJSONRequestBody defines body for PostApiV1HealthBloodPressure for application/json ContentType.
-type PostApiV1HealthBloodPressureJSONRequestBody = BloodPressureRequest
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-// PostApiV1HealthFitnessSyncJSONRequestBody defines body for PostApiV1HealthFitnessSync for application/json ContentType.
-type PostApiV1HealthFitnessSyncJSONRequestBody = FitnessSyncRequest
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-// PostApiV1HealthMedicationsJSONRequestBody defines body for PostApiV1HealthMedications for application/json ContentType.
-type PostApiV1HealthMedicationsJSONRequestBody = CreateMedicationRequest
+	siw.Handler.GetApiV1ReportsIdStatus(c, id)
+}
 
-// PutApiV1HealthMedicationsIdJSONRequestBody defines body for PutApiV1HealthMedicationsId for application/json ContentType.
-type PutApiV1HealthMedicationsIdJSONRequestBody = UpdateMedicationRequest
+// GetApiV1ReportsIdUrl operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1ReportsIdUrl(c *gin.Context) {
 
-// PostApiV1HealthMenstruationJSONRequestBody defines body for PostApiV1HealthMenstruation for application/json ContentType.
-type PostApiV1HealthMenstruationJSONRequestBody = MenstruationRequest
+	var err error
 
-// PostApiV1ReportsGenerateJSONRequestBody defines body for PostApiV1ReportsGenerate for application/json ContentType.
-type PostApiV1ReportsGenerateJSONRequestBody = GenerateReportRequest
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Stream audio from mobile app
-	// (POST /api/v1/checkin/audio-stream)
-	PostApiV1CheckinAudioStream(c *gin.Context, params PostApiV1CheckinAudioStreamParams)
-	// Complete check-in session
-	// (POST /api/v1/checkin/complete)
-	PostApiV1CheckinComplete(c *gin.Context)
-	// Get question audio
-	// (GET /api/v1/checkin/question-audio/{sessionId}/{questionId})
-	GetApiV1CheckinQuestionAudioSessionIdQuestionId(c *gin.Context, sessionId openapi_types.UUID, questionId string)
-	// Submit user response
-	// (POST /api/v1/checkin/respond)
-	PostApiV1CheckinRespond(c *gin.Context)
-	// Start new check-in session
-	// (POST /api/v1/checkin/start)
-	PostApiV1CheckinStart(c *gin.Context)
-	// Get session status
-	// (GET /api/v1/checkin/status/{sessionId})
-	GetApiV1CheckinStatusSessionId(c *gin.Context, sessionId openapi_types.UUID)
-	// Get dashboard summary
-	// (GET /api/v1/dashboard/summary)
-	GetApiV1DashboardSummary(c *gin.Context, params GetApiV1DashboardSummaryParams)
-	// Get blood pressure history
-	// (GET /api/v1/health/blood-pressure)
-	GetApiV1HealthBloodPressure(c *gin.Context, params GetApiV1HealthBloodPressureParams)
-	// Log blood pressure reading
-	// (POST /api/v1/health/blood-pressure)
-	PostApiV1HealthBloodPressure(c *gin.Context)
-	// Sync fitness data from Health Connect
-	// (POST /api/v1/health/fitness-sync)
-	PostApiV1HealthFitnessSync(c *gin.Context)
-	// List medications
-	// (GET /api/v1/health/medications)
-	GetApiV1HealthMedications(c *gin.Context, params GetApiV1HealthMedicationsParams)
-	// Add medication
-	// (POST /api/v1/health/medications)
-	PostApiV1HealthMedications(c *gin.Context)
-	// Delete medication
-	// (DELETE /api/v1/health/medications/{id})
-	DeleteApiV1HealthMedicationsId(c *gin.Context, id openapi_types.UUID)
-	// Update medication
-	// (PUT /api/v1/health/medications/{id})
-	PutApiV1HealthMedicationsId(c *gin.Context, id openapi_types.UUID)
-	// Get menstruation history
-	// (GET /api/v1/health/menstruation)
-	GetApiV1HealthMenstruation(c *gin.Context, params GetApiV1HealthMenstruationParams)
-	// Log menstruation data
-	// (POST /api/v1/health/menstruation)
-	PostApiV1HealthMenstruation(c *gin.Context)
-	// Generate health report
-	// (POST /api/v1/reports/generate)
-	PostApiV1ReportsGenerate(c *gin.Context)
-	// Download report
-	// (GET /api/v1/reports/{id})
-	GetApiV1ReportsId(c *gin.Context, id openapi_types.UUID)
-	// Health check endpoint
-	// (GET /health)
-	GetHealth(c *gin.Context)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandler       func(*gin.Context, error, int)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-type MiddlewareFunc func(c *gin.Context)
+	siw.Handler.GetApiV1ReportsIdUrl(c, id)
+}
 
-// PostApiV1CheckinAudioStream operation middleware
-func (siw *ServerInterfaceWrapper) PostApiV1CheckinAudioStream(c *gin.Context) {
+// GetApiV1UsersConfirmEmail operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1UsersConfirmEmail(c *gin.Context) {
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PostApiV1CheckinAudioStreamParams
-
-	// ------------- Required query parameter "session_id" -------------
-
-	if paramValue := c.Query("session_id"); paramValue != "" {
+	var params GetApiV1UsersConfirmEmailParams
 
-	} else {
-		siw.ErrorHandler(c, fmt.Errorf("Query argument session_id is required, but not found"), http.StatusBadRequest)
-		return
-	}
+	// ------------- Optional query parameter "token" -------------
 
-	err = runtime.BindQueryParameterWithOptions("form", true, true, "session_id", c.Request.URL.Query(), &params.SessionId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "token", c.Request.URL.Query(), &params.Token, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter session_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter token: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -776,11 +6081,22 @@ func (siw *ServerInterfaceWrapper) PostApiV1CheckinAudioStream(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostApiV1CheckinAudioStream(c, params)
+	siw.Handler.GetApiV1UsersConfirmEmail(c, params)
 }
 
-// PostApiV1CheckinComplete operation middleware
-func (siw *ServerInterfaceWrapper) PostApiV1CheckinComplete(c *gin.Context) {
+// GetApiV1UsersIdCycleSuggestions operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1UsersIdCycleSuggestions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -789,29 +6105,29 @@ func (siw *ServerInterfaceWrapper) PostApiV1CheckinComplete(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostApiV1CheckinComplete(c)
+	siw.Handler.GetApiV1UsersIdCycleSuggestions(c, id)
 }
 
-// GetApiV1CheckinQuestionAudioSessionIdQuestionId operation middleware
-func (siw *ServerInterfaceWrapper) GetApiV1CheckinQuestionAudioSessionIdQuestionId(c *gin.Context) {
+// PostApiV1UsersIdCycleSuggestionsSuggestionIdAccept operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1UsersIdCycleSuggestionsSuggestionIdAccept(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "sessionId" -------------
-	var sessionId openapi_types.UUID
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "sessionId", c.Param("sessionId"), &sessionId, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sessionId: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Path parameter "questionId" -------------
-	var questionId string
+	// ------------- Path parameter "suggestion_id" -------------
+	var suggestionId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "questionId", c.Param("questionId"), &questionId, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: ""})
+	err = runtime.BindStyledParameterWithOptions("simple", "suggestion_id", c.Param("suggestion_id"), &suggestionId, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter questionId: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter suggestion_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -822,24 +6138,31 @@ func (siw *ServerInterfaceWrapper) GetApiV1CheckinQuestionAudioSessionIdQuestion
 		}
 	}
 
-	siw.Handler.GetApiV1CheckinQuestionAudioSessionIdQuestionId(c, sessionId, questionId)
+	siw.Handler.PostApiV1UsersIdCycleSuggestionsSuggestionIdAccept(c, id, suggestionId)
 }
 
-// PostApiV1CheckinRespond operation middleware
-func (siw *ServerInterfaceWrapper) PostApiV1CheckinRespond(c *gin.Context) {
+// PostApiV1UsersIdCycleSuggestionsSuggestionIdDismiss operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1UsersIdCycleSuggestionsSuggestionIdDismiss(c *gin.Context) {
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	siw.Handler.PostApiV1CheckinRespond(c)
-}
+	// ------------- Path parameter "suggestion_id" -------------
+	var suggestionId openapi_types.UUID
 
-// PostApiV1CheckinStart operation middleware
-func (siw *ServerInterfaceWrapper) PostApiV1CheckinStart(c *gin.Context) {
+	err = runtime.BindStyledParameterWithOptions("simple", "suggestion_id", c.Param("suggestion_id"), &suggestionId, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter suggestion_id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -848,20 +6171,20 @@ func (siw *ServerInterfaceWrapper) PostApiV1CheckinStart(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostApiV1CheckinStart(c)
+	siw.Handler.PostApiV1UsersIdCycleSuggestionsSuggestionIdDismiss(c, id, suggestionId)
 }
 
-// GetApiV1CheckinStatusSessionId operation middleware
-func (siw *ServerInterfaceWrapper) GetApiV1CheckinStatusSessionId(c *gin.Context) {
+// PutApiV1UsersIdEmail operation middleware
+func (siw *ServerInterfaceWrapper) PutApiV1UsersIdEmail(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "sessionId" -------------
-	var sessionId openapi_types.UUID
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "sessionId", c.Param("sessionId"), &sessionId, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sessionId: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -872,37 +6195,44 @@ func (siw *ServerInterfaceWrapper) GetApiV1CheckinStatusSessionId(c *gin.Context
 		}
 	}
 
-	siw.Handler.GetApiV1CheckinStatusSessionId(c, sessionId)
+	siw.Handler.PutApiV1UsersIdEmail(c, id)
 }
 
-// GetApiV1DashboardSummary operation middleware
-func (siw *ServerInterfaceWrapper) GetApiV1DashboardSummary(c *gin.Context) {
+// PostApiV1UsersIdEmailConfirmation operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1UsersIdEmailConfirmation(c *gin.Context) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetApiV1DashboardSummaryParams
-
-	// ------------- Required query parameter "user_id" -------------
-
-	if paramValue := c.Query("user_id"); paramValue != "" {
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
 
-	} else {
-		siw.ErrorHandler(c, fmt.Errorf("Query argument user_id is required, but not found"), http.StatusBadRequest)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	err = runtime.BindQueryParameterWithOptions("form", true, true, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
 
-	// ------------- Optional query parameter "days" -------------
+	siw.Handler.PostApiV1UsersIdEmailConfirmation(c, id)
+}
 
-	err = runtime.BindQueryParameterWithOptions("form", true, false, "days", c.Request.URL.Query(), &params.Days, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+// GetApiV1UsersIdProfile operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1UsersIdProfile(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter days: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -913,29 +6243,20 @@ func (siw *ServerInterfaceWrapper) GetApiV1DashboardSummary(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetApiV1DashboardSummary(c, params)
+	siw.Handler.GetApiV1UsersIdProfile(c, id)
 }
 
-// GetApiV1HealthBloodPressure operation middleware
-func (siw *ServerInterfaceWrapper) GetApiV1HealthBloodPressure(c *gin.Context) {
+// PutApiV1UsersIdQuestionSet operation middleware
+func (siw *ServerInterfaceWrapper) PutApiV1UsersIdQuestionSet(c *gin.Context) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetApiV1HealthBloodPressureParams
-
-	// ------------- Required query parameter "user_id" -------------
-
-	if paramValue := c.Query("user_id"); paramValue != "" {
-
-	} else {
-		siw.ErrorHandler(c, fmt.Errorf("Query argument user_id is required, but not found"), http.StatusBadRequest)
-		return
-	}
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
 
-	err = runtime.BindQueryParameterWithOptions("form", true, true, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -946,11 +6267,22 @@ func (siw *ServerInterfaceWrapper) GetApiV1HealthBloodPressure(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetApiV1HealthBloodPressure(c, params)
+	siw.Handler.PutApiV1UsersIdQuestionSet(c, id)
 }
 
-// PostApiV1HealthBloodPressure operation middleware
-func (siw *ServerInterfaceWrapper) PostApiV1HealthBloodPressure(c *gin.Context) {
+// GetApiV1UsersIdReportSchedule operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1UsersIdReportSchedule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -959,11 +6291,22 @@ func (siw *ServerInterfaceWrapper) PostApiV1HealthBloodPressure(c *gin.Context)
 		}
 	}
 
-	siw.Handler.PostApiV1HealthBloodPressure(c)
+	siw.Handler.GetApiV1UsersIdReportSchedule(c, id)
 }
 
-// PostApiV1HealthFitnessSync operation middleware
-func (siw *ServerInterfaceWrapper) PostApiV1HealthFitnessSync(c *gin.Context) {
+// PutApiV1UsersIdReportSchedule operation middleware
+func (siw *ServerInterfaceWrapper) PutApiV1UsersIdReportSchedule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -972,29 +6315,20 @@ func (siw *ServerInterfaceWrapper) PostApiV1HealthFitnessSync(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostApiV1HealthFitnessSync(c)
+	siw.Handler.PutApiV1UsersIdReportSchedule(c, id)
 }
 
-// GetApiV1HealthMedications operation middleware
-func (siw *ServerInterfaceWrapper) GetApiV1HealthMedications(c *gin.Context) {
+// GetApiV1UsersIdSettings operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1UsersIdSettings(c *gin.Context) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetApiV1HealthMedicationsParams
-
-	// ------------- Required query parameter "user_id" -------------
-
-	if paramValue := c.Query("user_id"); paramValue != "" {
-
-	} else {
-		siw.ErrorHandler(c, fmt.Errorf("Query argument user_id is required, but not found"), http.StatusBadRequest)
-		return
-	}
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
 
-	err = runtime.BindQueryParameterWithOptions("form", true, true, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -1005,11 +6339,22 @@ func (siw *ServerInterfaceWrapper) GetApiV1HealthMedications(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetApiV1HealthMedications(c, params)
+	siw.Handler.GetApiV1UsersIdSettings(c, id)
 }
 
-// PostApiV1HealthMedications operation middleware
-func (siw *ServerInterfaceWrapper) PostApiV1HealthMedications(c *gin.Context) {
+// PutApiV1UsersIdSettings operation middleware
+func (siw *ServerInterfaceWrapper) PutApiV1UsersIdSettings(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -1018,11 +6363,11 @@ func (siw *ServerInterfaceWrapper) PostApiV1HealthMedications(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostApiV1HealthMedications(c)
+	siw.Handler.PutApiV1UsersIdSettings(c, id)
 }
 
-// DeleteApiV1HealthMedicationsId operation middleware
-func (siw *ServerInterfaceWrapper) DeleteApiV1HealthMedicationsId(c *gin.Context) {
+// GetApiV1UsersIdTokens operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1UsersIdTokens(c *gin.Context) {
 
 	var err error
 
@@ -1042,11 +6387,11 @@ func (siw *ServerInterfaceWrapper) DeleteApiV1HealthMedicationsId(c *gin.Context
 		}
 	}
 
-	siw.Handler.DeleteApiV1HealthMedicationsId(c, id)
+	siw.Handler.GetApiV1UsersIdTokens(c, id)
 }
 
-// PutApiV1HealthMedicationsId operation middleware
-func (siw *ServerInterfaceWrapper) PutApiV1HealthMedicationsId(c *gin.Context) {
+// PostApiV1UsersIdTokens operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1UsersIdTokens(c *gin.Context) {
 
 	var err error
 
@@ -1066,29 +6411,29 @@ func (siw *ServerInterfaceWrapper) PutApiV1HealthMedicationsId(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PutApiV1HealthMedicationsId(c, id)
+	siw.Handler.PostApiV1UsersIdTokens(c, id)
 }
 
-// GetApiV1HealthMenstruation operation middleware
-func (siw *ServerInterfaceWrapper) GetApiV1HealthMenstruation(c *gin.Context) {
+// DeleteApiV1UsersIdTokensTokenId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiV1UsersIdTokensTokenId(c *gin.Context) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetApiV1HealthMenstruationParams
-
-	// ------------- Required query parameter "user_id" -------------
-
-	if paramValue := c.Query("user_id"); paramValue != "" {
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
 
-	} else {
-		siw.ErrorHandler(c, fmt.Errorf("Query argument user_id is required, but not found"), http.StatusBadRequest)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	err = runtime.BindQueryParameterWithOptions("form", true, true, "user_id", c.Request.URL.Query(), &params.UserId, runtime.BindQueryParameterOptions{Type: "string", Format: "uuid"})
+	// ------------- Path parameter "token_id" -------------
+	var tokenId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "token_id", c.Param("token_id"), &tokenId, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter token_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -1099,11 +6444,22 @@ func (siw *ServerInterfaceWrapper) GetApiV1HealthMenstruation(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetApiV1HealthMenstruation(c, params)
+	siw.Handler.DeleteApiV1UsersIdTokensTokenId(c, id, tokenId)
 }
 
-// PostApiV1HealthMenstruation operation middleware
-func (siw *ServerInterfaceWrapper) PostApiV1HealthMenstruation(c *gin.Context) {
+// GetApiV1UsersIdUsage operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1UsersIdUsage(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -1112,11 +6468,11 @@ func (siw *ServerInterfaceWrapper) PostApiV1HealthMenstruation(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostApiV1HealthMenstruation(c)
+	siw.Handler.GetApiV1UsersIdUsage(c, id)
 }
 
-// PostApiV1ReportsGenerate operation middleware
-func (siw *ServerInterfaceWrapper) PostApiV1ReportsGenerate(c *gin.Context) {
+// PostApiV1Webhooks operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1Webhooks(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -1125,11 +6481,11 @@ func (siw *ServerInterfaceWrapper) PostApiV1ReportsGenerate(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostApiV1ReportsGenerate(c)
+	siw.Handler.PostApiV1Webhooks(c)
 }
 
-// GetApiV1ReportsId operation middleware
-func (siw *ServerInterfaceWrapper) GetApiV1ReportsId(c *gin.Context) {
+// DeleteApiV1WebhooksId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiV1WebhooksId(c *gin.Context) {
 
 	var err error
 
@@ -1149,7 +6505,7 @@ func (siw *ServerInterfaceWrapper) GetApiV1ReportsId(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetApiV1ReportsId(c, id)
+	siw.Handler.DeleteApiV1WebhooksId(c, id)
 }
 
 // GetHealth operation middleware
@@ -1165,6 +6521,19 @@ func (siw *ServerInterfaceWrapper) GetHealth(c *gin.Context) {
 	siw.Handler.GetHealth(c)
 }
 
+// GetMetrics operation middleware
+func (siw *ServerInterfaceWrapper) GetMetrics(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetMetrics(c)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
 	BaseURL      string
@@ -1192,84 +6561,458 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.GET(options.BaseURL+"/api/v1/admin/audit-logs", wrapper.GetApiV1AdminAuditLogs)
+	router.POST(options.BaseURL+"/api/v1/admin/audit/archives/:month/restore", wrapper.PostApiV1AdminAuditArchivesMonthRestore)
+	router.GET(options.BaseURL+"/api/v1/admin/diagnostics", wrapper.GetApiV1AdminDiagnostics)
+	router.GET(options.BaseURL+"/api/v1/admin/extraction-quality", wrapper.GetApiV1AdminExtractionQuality)
+	router.GET(options.BaseURL+"/api/v1/admin/latency", wrapper.GetApiV1AdminLatency)
+	router.POST(options.BaseURL+"/api/v1/admin/organizations", wrapper.PostApiV1AdminOrganizations)
+	router.PUT(options.BaseURL+"/api/v1/admin/organizations/:id/residency", wrapper.PutApiV1AdminOrganizationsIdResidency)
+	router.DELETE(options.BaseURL+"/api/v1/admin/panel/digest", wrapper.DeleteApiV1AdminPanelDigest)
+	router.PUT(options.BaseURL+"/api/v1/admin/panel/digest", wrapper.PutApiV1AdminPanelDigest)
+	router.GET(options.BaseURL+"/api/v1/admin/panel/findings", wrapper.GetApiV1AdminPanelFindings)
+	router.POST(options.BaseURL+"/api/v1/admin/question-sets", wrapper.PostApiV1AdminQuestionSets)
+	router.GET(options.BaseURL+"/api/v1/admin/usage", wrapper.GetApiV1AdminUsage)
+	router.GET(options.BaseURL+"/api/v1/admin/users/:id/timeline", wrapper.GetApiV1AdminUsersIdTimeline)
+	router.GET(options.BaseURL+"/api/v1/alerts", wrapper.GetApiV1Alerts)
+	router.POST(options.BaseURL+"/api/v1/alerts/:id/acknowledge", wrapper.PostApiV1AlertsIdAcknowledge)
+	router.GET(options.BaseURL+"/api/v1/audit/logs", wrapper.GetApiV1AuditLogs)
 	router.POST(options.BaseURL+"/api/v1/checkin/audio-stream", wrapper.PostApiV1CheckinAudioStream)
+	router.GET(options.BaseURL+"/api/v1/checkin/audio-ws", wrapper.GetApiV1CheckinAudioWs)
 	router.POST(options.BaseURL+"/api/v1/checkin/complete", wrapper.PostApiV1CheckinComplete)
+	router.POST(options.BaseURL+"/api/v1/checkin/pause", wrapper.PostApiV1CheckinPause)
 	router.GET(options.BaseURL+"/api/v1/checkin/question-audio/:sessionId/:questionId", wrapper.GetApiV1CheckinQuestionAudioSessionIdQuestionId)
+	router.POST(options.BaseURL+"/api/v1/checkin/re-extract", wrapper.PostApiV1CheckinReExtract)
 	router.POST(options.BaseURL+"/api/v1/checkin/respond", wrapper.PostApiV1CheckinRespond)
+	router.POST(options.BaseURL+"/api/v1/checkin/resume", wrapper.PostApiV1CheckinResume)
 	router.POST(options.BaseURL+"/api/v1/checkin/start", wrapper.PostApiV1CheckinStart)
 	router.GET(options.BaseURL+"/api/v1/checkin/status/:sessionId", wrapper.GetApiV1CheckinStatusSessionId)
+	router.PUT(options.BaseURL+"/api/v1/checkin/:id", wrapper.PutApiV1CheckinId)
+	router.GET(options.BaseURL+"/api/v1/checkin/:sessionId/events", wrapper.GetApiV1CheckinSessionIdEvents)
+	router.GET(options.BaseURL+"/api/v1/consents", wrapper.GetApiV1Consents)
+	router.POST(options.BaseURL+"/api/v1/consents", wrapper.PostApiV1Consents)
+	router.GET(options.BaseURL+"/api/v1/dashboard/export", wrapper.GetApiV1DashboardExport)
 	router.GET(options.BaseURL+"/api/v1/dashboard/summary", wrapper.GetApiV1DashboardSummary)
+	router.GET(options.BaseURL+"/api/v1/export/fhir", wrapper.GetApiV1ExportFhir)
+	router.GET(options.BaseURL+"/api/v1/export/health", wrapper.GetApiV1ExportHealth)
+	router.POST(options.BaseURL+"/api/v1/gdpr/anonymize", wrapper.PostApiV1GdprAnonymize)
+	router.GET(options.BaseURL+"/api/v1/gdpr/consent", wrapper.GetApiV1GdprConsent)
+	router.POST(options.BaseURL+"/api/v1/gdpr/consent", wrapper.PostApiV1GdprConsent)
+	router.GET(options.BaseURL+"/api/v1/health/anomalies", wrapper.GetApiV1HealthAnomalies)
 	router.GET(options.BaseURL+"/api/v1/health/blood-pressure", wrapper.GetApiV1HealthBloodPressure)
 	router.POST(options.BaseURL+"/api/v1/health/blood-pressure", wrapper.PostApiV1HealthBloodPressure)
+	router.GET(options.BaseURL+"/api/v1/health/blood-pressure/chart", wrapper.GetApiV1HealthBloodPressureChart)
+	router.GET(options.BaseURL+"/api/v1/health/fitness", wrapper.GetApiV1HealthFitness)
 	router.POST(options.BaseURL+"/api/v1/health/fitness-sync", wrapper.PostApiV1HealthFitnessSync)
 	router.GET(options.BaseURL+"/api/v1/health/medications", wrapper.GetApiV1HealthMedications)
 	router.POST(options.BaseURL+"/api/v1/health/medications", wrapper.PostApiV1HealthMedications)
+	router.GET(options.BaseURL+"/api/v1/health/medications/due", wrapper.GetApiV1HealthMedicationsDue)
 	router.DELETE(options.BaseURL+"/api/v1/health/medications/:id", wrapper.DeleteApiV1HealthMedicationsId)
 	router.PUT(options.BaseURL+"/api/v1/health/medications/:id", wrapper.PutApiV1HealthMedicationsId)
+	router.GET(options.BaseURL+"/api/v1/health/medications/:id/adherence", wrapper.GetApiV1HealthMedicationsIdAdherence)
+	router.POST(options.BaseURL+"/api/v1/health/medications/:id/adherence", wrapper.PostApiV1HealthMedicationsIdAdherence)
+	router.POST(options.BaseURL+"/api/v1/health/medications/:id/deactivate", wrapper.PostApiV1HealthMedicationsIdDeactivate)
+	router.POST(options.BaseURL+"/api/v1/health/medications/:id/reactivate", wrapper.PostApiV1HealthMedicationsIdReactivate)
+	router.POST(options.BaseURL+"/api/v1/health/medications/:id/restore", wrapper.PostApiV1HealthMedicationsIdRestore)
+	router.GET(options.BaseURL+"/api/v1/health/medications/:id/schedule", wrapper.GetApiV1HealthMedicationsIdSchedule)
+	router.PUT(options.BaseURL+"/api/v1/health/medications/:id/schedule", wrapper.PutApiV1HealthMedicationsIdSchedule)
 	router.GET(options.BaseURL+"/api/v1/health/menstruation", wrapper.GetApiV1HealthMenstruation)
 	router.POST(options.BaseURL+"/api/v1/health/menstruation", wrapper.PostApiV1HealthMenstruation)
+	router.GET(options.BaseURL+"/api/v1/health/menstruation/prediction", wrapper.GetApiV1HealthMenstruationPrediction)
+	router.GET(options.BaseURL+"/api/v1/health/menstruation/stats", wrapper.GetApiV1HealthMenstruationStats)
+	router.PATCH(options.BaseURL+"/api/v1/health/menstruation/:id", wrapper.PatchApiV1HealthMenstruationId)
+	router.POST(options.BaseURL+"/api/v1/invitations/accept", wrapper.PostApiV1InvitationsAccept)
+	router.GET(options.BaseURL+"/api/v1/orgs/:id/integrations", wrapper.GetApiV1OrgsIdIntegrations)
+	router.POST(options.BaseURL+"/api/v1/orgs/:id/integrations", wrapper.PostApiV1OrgsIdIntegrations)
+	router.GET(options.BaseURL+"/api/v1/orgs/:id/integrations/:integration_id/deliveries", wrapper.GetApiV1OrgsIdIntegrationsIntegrationIdDeliveries)
+	router.POST(options.BaseURL+"/api/v1/orgs/:id/integrations/:integration_id/test", wrapper.PostApiV1OrgsIdIntegrationsIntegrationIdTest)
+	router.POST(options.BaseURL+"/api/v1/orgs/:id/invitations", wrapper.PostApiV1OrgsIdInvitations)
+	router.GET(options.BaseURL+"/api/v1/orgs/:id/members", wrapper.GetApiV1OrgsIdMembers)
+	router.POST(options.BaseURL+"/api/v1/orgs/:id/members/:user_id/roles", wrapper.PostApiV1OrgsIdMembersUserIdRoles)
+	router.DELETE(options.BaseURL+"/api/v1/orgs/:id/members/:user_id/roles/:role", wrapper.DeleteApiV1OrgsIdMembersUserIdRolesRole)
+	router.GET(options.BaseURL+"/api/v1/orgs/:id/panel-assignments", wrapper.GetApiV1OrgsIdPanelAssignments)
+	router.POST(options.BaseURL+"/api/v1/orgs/:id/panel-assignments", wrapper.PostApiV1OrgsIdPanelAssignments)
+	router.DELETE(options.BaseURL+"/api/v1/orgs/:id/panel-assignments/:clinician_id/:patient_id", wrapper.DeleteApiV1OrgsIdPanelAssignmentsClinicianIdPatientId)
+	router.DELETE(options.BaseURL+"/api/v1/orgs/:id/sharing-consent", wrapper.DeleteApiV1OrgsIdSharingConsent)
+	router.PUT(options.BaseURL+"/api/v1/orgs/:id/sharing-consent", wrapper.PutApiV1OrgsIdSharingConsent)
+	router.GET(options.BaseURL+"/api/v1/reports", wrapper.GetApiV1Reports)
 	router.POST(options.BaseURL+"/api/v1/reports/generate", wrapper.PostApiV1ReportsGenerate)
+	router.GET(options.BaseURL+"/api/v1/reports/jobs/:job_id", wrapper.GetApiV1ReportsJobsJobId)
+	router.GET(options.BaseURL+"/api/v1/reports/verify", wrapper.GetApiV1ReportsVerify)
+	router.DELETE(options.BaseURL+"/api/v1/reports/:id", wrapper.DeleteApiV1ReportsId)
 	router.GET(options.BaseURL+"/api/v1/reports/:id", wrapper.GetApiV1ReportsId)
+	router.GET(options.BaseURL+"/api/v1/reports/:id/status", wrapper.GetApiV1ReportsIdStatus)
+	router.GET(options.BaseURL+"/api/v1/reports/:id/url", wrapper.GetApiV1ReportsIdUrl)
+	router.GET(options.BaseURL+"/api/v1/users/confirm-email", wrapper.GetApiV1UsersConfirmEmail)
+	router.GET(options.BaseURL+"/api/v1/users/:id/cycle-suggestions", wrapper.GetApiV1UsersIdCycleSuggestions)
+	router.POST(options.BaseURL+"/api/v1/users/:id/cycle-suggestions/:suggestion_id/accept", wrapper.PostApiV1UsersIdCycleSuggestionsSuggestionIdAccept)
+	router.POST(options.BaseURL+"/api/v1/users/:id/cycle-suggestions/:suggestion_id/dismiss", wrapper.PostApiV1UsersIdCycleSuggestionsSuggestionIdDismiss)
+	router.PUT(options.BaseURL+"/api/v1/users/:id/email", wrapper.PutApiV1UsersIdEmail)
+	router.POST(options.BaseURL+"/api/v1/users/:id/email/confirmation", wrapper.PostApiV1UsersIdEmailConfirmation)
+	router.GET(options.BaseURL+"/api/v1/users/:id/profile", wrapper.GetApiV1UsersIdProfile)
+	router.PUT(options.BaseURL+"/api/v1/users/:id/question-set", wrapper.PutApiV1UsersIdQuestionSet)
+	router.GET(options.BaseURL+"/api/v1/users/:id/report-schedule", wrapper.GetApiV1UsersIdReportSchedule)
+	router.PUT(options.BaseURL+"/api/v1/users/:id/report-schedule", wrapper.PutApiV1UsersIdReportSchedule)
+	router.GET(options.BaseURL+"/api/v1/users/:id/settings", wrapper.GetApiV1UsersIdSettings)
+	router.PUT(options.BaseURL+"/api/v1/users/:id/settings", wrapper.PutApiV1UsersIdSettings)
+	router.GET(options.BaseURL+"/api/v1/users/:id/tokens", wrapper.GetApiV1UsersIdTokens)
+	router.POST(options.BaseURL+"/api/v1/users/:id/tokens", wrapper.PostApiV1UsersIdTokens)
+	router.DELETE(options.BaseURL+"/api/v1/users/:id/tokens/:token_id", wrapper.DeleteApiV1UsersIdTokensTokenId)
+	router.GET(options.BaseURL+"/api/v1/users/:id/usage", wrapper.GetApiV1UsersIdUsage)
+	router.POST(options.BaseURL+"/api/v1/webhooks", wrapper.PostApiV1Webhooks)
+	router.DELETE(options.BaseURL+"/api/v1/webhooks/:id", wrapper.DeleteApiV1WebhooksId)
 	router.GET(options.BaseURL+"/health", wrapper.GetHealth)
+	router.GET(options.BaseURL+"/metrics", wrapper.GetMetrics)
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9w8a2/bttp/heD7AjsHUOL0MnTH37Jm3QKsW5fs8mEwDFp8LHOhSJWknBiF//sBSd1F",
-	"2Upsp+35Zlskn/uVj/wJxzLNpABhNJ5+wgp0JoUG9+V7Qm/gYw7a2G+xFAaE+0iyjLOYGCbF5B8thf1N",
-	"xytIif30/wqWeIr/b1IfPfFP9eQHpaS6KYDg7XYbYQo6Viyzh+GphYmUB4rO0JpwRh0cBHYn3kb4WhhQ",
-	"gnB31PMhVoJFGtQaVI3PL9K8k7mgz4fKDWiZqxiQkAYtHexthG9BrVkMfwiyJoyTBYfnw6iAjfIGcLuq",
-	"OMApE5eSflCgda6goVaZkhkow7zKUUa0kZzF9ktKHliap3j64tuLCKdM+G+vLyJsNhngKWbCQAJODCkQ",
-	"ezKdE3fsUqrUfsKUGDgzLAVc7dJGMZHYTVnONbRAvXzZBPUqCEpvAji+bOH4Jrgx16DmjLbwy3NG+6ht",
-	"I2ytgCmgePp3tbEBO2rwqiRkVp0jF/9AbCzMDt8LGfYYHysg5pHcawmrT+0oQg8V3G7pHCiCHjPfyjTj",
-	"YOAWtGZSDKqx9s+fJOvG3lkQBbEGpZ0Z3xpidsiU6XlcIOxsq2Wyf63ArEAhwjlyVDApNFqRNaAFgEBE",
-	"6HuwKFU4LKTkQIRFotxQENgTT/XcwIPpw/4FHkwFFDGBfspFQhQjIiTrxzKzzzKn2++BFt5v2PtITRII",
-	"UgSCzq1G9lQUR1jkvPC3RuUQoGDpIpqIN8GjBUnDMIU0Hq+9ALQhygzi11t+BD/kkI5KjjVJbGET0uAr",
-	"wvjmPRjFYh2QwVgiQIBKNnMOa+CjmJRKSUctzAgTe89tehwOkM0/5oQzsxkBYRtkil4tJFH0Nk9TojZ9",
-	"xpA1KJLA3GLXZpDMLaxBPRR5uvCIxiuI7+ZMzGOZ+5ygT0yTrQHxrFiyCm/k8j78IAXKbEzsPwsxwkpp",
-	"Tpnl1SL3/qKLg4CEGLYe8P8CcqMIDz/MpGZDW0PYZKCY1xp4INaV4in+mWiD3iBKNjqklzZkzTUoBtra",
-	"gEuumIFU78uyWnZRI0OUIpswdu28rB/TJYU26n9e/nx9dfn79a+/zH+4ufn1JhjVwRDmRV9vfMeAU/RN",
-	"Yf/fIKZR5ReCMV2XnrQ+41q4dL5K7x1z9nkcR0N9YMifvGNGgNZXxJAPkgkT9Clk7vd9wiCsMv6NtYHM",
-	"CnAF1lsp72WcKeMIx4RLK0GXZmlDRGyfktjqzjxlIreeeRbi3lj35VP4JkIrINys5rEUwlIW4UTKhMN8",
-	"yUwQlD/B6VjhzNtR9lfFEmYrlusrtFQyRT85AOitB4CWUiEKNK+qgmCoEMw0kfSeI8KLLMUu33WciPBd",
-	"TLiTkwEV5sya8BzCjqvjqDoqUHCwFmJ5VoFdxcseS3Zoy+1GxMN5gN2fWV3So623p4U9Cz5K3G2iFiLv",
-	"R+u/XVaYSWUGKdyVznwB2UUDYiP1CtHrdfqtDWzXYtgVEkqZVXHC5+Ozqipcjib9KVVUN48pLc3G0yp4",
-	"Rj7wzkYkm4nTAD5fAnD70xhKx5dqoZxgoYDcLYnXs72wKBMC1KilPBfx6okJVVql+3ND7kA0WbtxLktI",
-	"WzgTZRjhozhbJpDlMVUyUScdUZ2cjDmxnWnW/Y5mK+EiGpGCZquNZjHhcxeiijS08lv9HKXjmHoZbE2i",
-	"VDa7J0z5gGT1Ah5i4ByEGUWj3qSZkal+HEaH1eneK9jyOA+XGGRBNLTjmguKLp2hTNdfZ2GHWBzcjt0b",
-	"F5LKz7NRqDbL0kHvFXdy1kYp/qTGzWepc0c6mS+8HA4IUGij8t2dhcPYyuX93Bq70B3z5CxZ2QQolRSK",
-	"DHYFZL0ZZ5iP49Iz2PHeZGC2l//H7G9+iUIbaURfnmx7cisz1CGJWVzniogE5iDoKDIaWxwDRm1Kiox5",
-	"p24cUS6dyFGAt88jXLZrqY+5fCD+HMp4y3I66KtUQyTtivIPDeobjYwiwv68AFvMF4uP0Lgd6IJHNUYh",
-	"+6968UOKdGCz+h1T+lTd6sJQH+mX+kpUJAhtBYKHzHHz+BpUsHwov6qQOMiiqkuJeXUVEb7v+SoYbqQh",
-	"fF7RNLb/eGux3XfbdHCMDZnVHxn9n7406XPb/sTEUpbX5iT2Ba2DhH9Yk7KB9juQFPfuwP+ULIazpfMW",
-	"vgRwHU5EkkS5mlAKlHFiLCPQgsR3IKjrwVXuBFl56HP0ngiSgEZx46qP8PJQ15U4Y0JHSBupQCOb/sQm",
-	"V0CbgCNEBEVldNPIF8QcKRd39bnVDmZ4h7ZLrV2/06DLD9c4whYBT9+L84vzC0u2zECQjOEpfnV+cf7K",
-	"FdFm5Xg+IRmbrF9MHI5MTEhOmTzTRlmOWc2ROuBhb91z5BY7jigg3BljFWrcUpRrJhL0FyxuZXwHBkmF",
-	"4lUu7oCiPOOSWI23quk4dk3xFH+Q2lxm7M8Xbz1GlxaGh+eLf1J0LKd/97DyVue6p1Ihs4KK9dgqCp5a",
-	"F6U25WXYtBuzSjvz6ldPWuyz0ZnfDNp8L+mmO8RhCZjck3V7eqM6c8EEcTh1T912UdpG7WmflxcXjxoY",
-	"aXuBlqAChhk2tzbHnXBa2YXO4xi0Xuacu4zztccx1IetaJk0xpbcltf7t1QzPNsIfzsGRnsIyY26lPd2",
-	"HXVWMkWpXDAOiGSZFQxJrLrht6Uyzez2ruU0L+7DVvOeqDtUqBwi1lv4Hc7sjWJJAsp7IHgwygYs1+Hf",
-	"bR/lgAPeqYNPHiQamJ84gXbuwiLcMw6ONXnuVkH+61TIkuuV/yrVZrQ2lnnLmXc/n4r913Q7+VQ+u6Zb",
-	"i2YCAV39EQzKFJxVtZZ13VKcUUibQYo2YgBBOoOYLVlc5d497f0RWsr7W7HOO/kSxd8q/MZ7/NLB28DW",
-	"8+/Xh7n3qAu2RHAQ7scmBcOAg3FktwkdEEwGaHBHfh41t0r2sY3HWP32AOiOFCVfpMy0YpNN1qryt8i1",
-	"DBKt0aZ7ZlYVKrs9b1GVn8jxdmr+Z3a4wzNr4elWz9JMSetrv9o0wKtMS01GK2TVvgqro59oQwQJuN9T",
-	"JtQpgqBIgcmVcLnsst3WeISmupr0RHoaqnefWVm7/aRdeYFvKh9DP4+QdRJlvD48Ncr7Nkczug8G9Bsw",
-	"isEafFmUKwXCIL8fySUiISR2xm7fS7ptRNgvIFTPTq9mRQ9th5IVXFUFx+nnC666hdFetaLlSONE1zON",
-	"hTaFdaE3BNnTglDZXd8UHZSUhY4uZvzqcygsSc4Nnr6Jyl7gm+jVRfSfi1n/Wv6k+tPjVUCFqjVIV4u6",
-	"QqW9NbVcq/1twfoIM1lwKelZVrxasFe4vupqvY7wfPKdHbXZoYBQJpLxw2HhlzBGzHgGXpCyR6GS62jF",
-	"tJFBwS7CC2vpFh2/K2IInvnZ2ID4qugflt8pkoDgq0KjsoAXp8JhxwtrbTZzmSSlj35kEtCS4M8y6Uqw",
-	"0LpBCfYtdOlnEs/0RsTNZHKnhBsDkieSb2AE8+T9ScsCoMOD72NMr8DbN9X8gd0kbCNitGwuCwzePkKA",
-	"9RCbHulf3zd2fKXetUP0KAcbmKJ6kndtsA9x1rdKpg1KWywuRdlk/Ghv2pbWSTquA+8+PbM7DclnF/fL",
-	"0upwR3pJaUNigwLbaXuTT8zXQhTKnnxbrFfu97BgQ8VMoGI5ugm+DlwZ1Pz1lDylmGhx1xM+hsERzvKQ",
-	"QeTms7Pt+FY3dHn+zK2MR1td7vA+WCs8+U81u3qcb3TMa2z5SoNevIk5PCbeBYYenxjx6pN2VBNpaNmB",
-	"tURHbqcwxNBw7rOHvpCo9gjC5Y5lLdErDNLu0jEpZTH3MSkv20YUBH5EU5fvFJ1IRuFXlkZJ6eURL0ha",
-	"06jBewm7oryrLPpgyvQEVJJTNuI93xsSKrgalk6Zaex0e8UJnyuz2OXnMro8+DKx4PSHq3cHpyfyXnDp",
-	"/lJmpxC8qAa73a6/idjSdbt18V8nRFBUvkmCiAJUycq9B9STnDdMfPK5hl0dZY8506h8R8W1hl/t52/g",
-	"z2XanP6pceuEQFD3imKD37cbbSC17HYjm2od7utfwRq4zFJ3neBW4QjniuMpXhmTTScTLmPCV1Kb6XcX",
-	"313g/nX6ByVpHnsD7Z+gpxNrcuewJmeeCeexTLHV8gLV3lWDw7y0Ziv1oiNfUqlrUyuo7CP1dvcdXeom",
-	"/izV9VlVU71/WiNjM4rEd0wkDjFCV6DAv6tcnNJMt/oHFVJL/Uvn9WH/akaYqNOIisoOx79rMM2oMwim",
-	"Nw7pJ5VA0AYL657zEN3lCGPTFTtjLIy9Pqs08u1s+98AAAD//+PU53DpSgAA",
+	"H4sIAAAAAAAC/+y9e3PcNrI4+lVQc39V2a1LPWwn2cSu84ciyYnOsWOtJCdnN/GdwpCYGUQcgAFAyRNf",
+	"f/dfofEgSIJDzmj08qpqa2MNSaDR6G40+vlplPJFwRlhSo5efhoVWOAFUUTAX4elkFzof2VEpoIWinI2",
+	"ejli5KMap/AQ8SlSc4IKQa4oLyUq8Iy8QgpfEql/TElGWEoQvyL63akkapSMqB7lz5KI5SgZMbwgo5cj",
+	"M94oGcl0ThZYz6qWhX4ilaBsNvr8ORm9oQuq2gCd4hlBkv5FEvTNPposUUamuMwVwixDKS4KkiGs0Df7",
+	"+x2T5zBuOPeCMrooF6OXzxIHB2WKzIgAQN6ZpbQg+blcTGCliCqykEhxJC9p0TGtR0hk3v3IvJ+TkSCy",
+	"4EwS2KAfcHZG/iyJBEhSzhRh8E9cFDlNsQZq7w+pIfsUzPF/BJmOXo7+n71q8/fMU7l3LAQXZ3YSM2V9",
+	"hT/gDAkzKdpBVzinGcyDiP5y9DkZnTBFBMM5DHV3gLlpkSRCU5uH52euXvOSZXcHyhmRvBQpQYwrNIW5",
+	"PyejcyKuaEreM3yFaY4nObk7iOzcqAwm12/ZAfT4B2lKCnXCrqgCEALKKgQviFDUUJ3il4TF+VMTBhUk",
+	"G738zb72wZMxn/xBUqURcZAqekXOiZSUs+OPVCrpYW9x1CFn05ymSvOUVFgoymYIo3RO0ssdytD1nOYE",
+	"YcbVnAgkzaBOLJWSCEQlwjDjKGmsJOUZzEg+4kWht2N0cHhx8svx+Pz4/Pzk3c/j4/89Ob84HyXNpWr0",
+	"KkxzGUFDMiKO8KtxDQBjC96YwKJj4y6IlHhGouO6r2nWRpPBqV+/4kgQWS70mqdcLLAavRyVJc1GSc+2",
+	"AU4qONxqarNHNzWbE0FYSs7LxQKLZRvE8zkWxO0M+ViQVJEMZVwSiSiDXwsiKM+QmmOFrokgKOezmRbe",
+	"Eo4UliBW5jm6nhOGGIdv0TWWfrTWDi9IZjkK/gSh3MdMb/03fk1nWJHRZ79qLARe6r+F/v3lpwrFGS81",
+	"ayUjDadhcSVK4r9kcD60kA7jJDVoozjOiYgwJE4vGb/OSTYjWUA4E85zgpn+MHxjjFUdZKzIjqJAKi2S",
+	"AzYb0zjNHToehP0SmEqSwTZiDWeC+IIqvcVTLsxPEk0FXyDDqoLgjLKZ7KfQZJQKgtWaoNOs9m7X0IJg",
+	"K2oj/HZFBFXLOiungiqa4jw2mBH79fdFmUfhKyUR40FANogFXnFfB1D6tXg46hs/quExSl+ML3C+bFPY",
+	"BEuSUxYRz28JrknbryQSJCVMhfvbIP5b3c8FUYKmbUDlUiqe0zRBGcXun0WZS4K4QAWmbJyTKxLdVj4B",
+	"nWI9eAcSVh1KR1wJmtPZXEO24Bmx4qGD3MYDMWPfNr83J57knGfjQhApSwEocawfG0rNBZFznkeEAqjo",
+	"QA5XOC8JSgWXkmQxKhjOAckIBguQ2SFIG7xhicF9HgK+inEMjkL81mlgKCOd2pO8zkz+EBp0GtmhYqdP",
+	"cA+LCOfa/Uy/Cncze4Jyc97mWJqfu0+sYNO5wvk45SUbcPHBsO8I5zmML0eR60xj6/R3o/o09TV2YJot",
+	"F/Qv0qmubixn3YfRaaWkM3aKFSVMdU6d5pTRlGI2lMoLM+BG4NYmqw3VvYB/argpZ+ekexF/2nfGkqio",
+	"GuAGQZIorXhiGNoSmj77NaVNSporrSuYC29zaT3E11hqE6TuBZ7xvJsyBM9JH/vpAVrzw4fRScuMqgOR",
+	"zukVOSNScRHh/wVnat5Go30/Q/Bcq7z/+te//rXz9m38cDEvV+zoMUqZ+vbrCLsFH5VM0bwNwa9ardab",
+	"5V7U6rdEWOhfFvxKK+IzDCfCkCOwgTSz7BboLbA68fqGzyL3Hv0EKYFpjghTYqlFkFZJCiKMXYIzNCc4",
+	"V3OUYYVbNwScZVS/h/MxPK/9dBq8WiPMCrSBnE2LMc4yQWT8yujB9cczYdoK9Nvo8Oz44OJ4lIzenx6Z",
+	"fxwdvzmGf5wdHxyNktHBz+9+/tfbk38fB6irUUqoJXQ/j+sF/0NZplHqXkM4TYmUJEuQLFMgU4PdsdMX",
+	"QGnxF5mo9kAXRCq8KIYrUyCL8cwaSm5Nl25sQxM7dWyGC1lFtHEtABspkY2BL2REs4bf3c1YWw4pydA1",
+	"ZRm/RtdzLolhT7gnu9HA4qkZdkGl1JYSuHDpAfQpjIDDPHuPkkoFiczdI4Ka2siaao3j6Aen19yKmvKD",
+	"Vq9PrXZ9iBWZcbE81B/LVboUaOXIa+XuStUwlxREoNSOmSBJCKpN52xru+6dth1MUEllbPHJiOTkCiuS",
+	"xZ8yzW15/JlUeEbGz1Y9fN6B8B78zbFQp5yymC3kajb2F7y4aaZ1D9HfwE1wjffddXLgJ5k1FNU3+ggv",
+	"E2QVpLecZXhZmTj1b9eEXDYP246rpqaLLt38LEo2Cdo3lhmGyKJQS1QARnv1dAtEDQlJA+8hTpvg9bLH",
+	"Nm5NUQZ4ukMNEE6d2nKNqxb4o3WPfbOfVE6rr/djeueCYD3yeuYTxhWRUXeA0vtg98SSVoLI7mwX/T7C",
+	"U0UEIh+JSKkkv49GiQb1DWEzrXJ/s78fmcmzvl/U8+fhol5EFxUKgOrDGjb+Ef3wxvfRYO5kFPKcWciA",
+	"Ha58LY1zwB0QbTV7QQRNMUM/ESwUOpCSp9To1+6jl8gcBmhCcn6Nnj3f3/tuP0Hu/NAO2GfP93eePf8e",
+	"OfhBWzGvf7cf2uXs0QHfvNjfefbiey0mv9vf+e579/A5PPx6Xz/4fh9GwhN+RRJkTjPzF3r2Hbzx7Pn+",
+	"LrqYEzCrBccleJVCaDwQCLxxRO6OEq+LmwWOgkOxOuWqIy1x5+mHLVmya5zXJqjBhtHb5kI0o1eEaf+7",
+	"UTjBAFG5Aa6pmvNSIc6iU3k2XM1rN2So1axxIQiLOdeuiNDqc0Md49PqAPgHyvBSmvuxNPZP+9OETLkg",
+	"rxA2g5j7tLeNYDjkPW6chpegjOQKS0uTgqTAa4yQrKYFTjjcqZs6EMzUpwf1uKgSP45c3mgYD8YY1rTx",
+	"KBYJ7e15zfOcX0tAumdmmCtB01y7EqmaU4aeo8Xip1nAz2UxSkYZvwaLRl6z5QZ0aUNbxttCa2vAG+JX",
+	"Lm+M3sZJ0wIsidDUqoWsxFoL4jaJxM4w8DaeaJtrOg+UlAYpUJJn0nGmDxJQHKVcaAJ6VXklzauaGy9J",
+	"oVYZhrwUbBEHYUTMltZz1IIm59cJ2ELKhT6E9NkTk3wzPQrOx1NCcv1TbKIFwSbaoOGYEwRfTrGMm0Uy",
+	"yhgR0Ud5ydJ5PIijhfnKmjMGL3x7oUsiE+2Q5wJJHj9JFpxHJGvBJVVUn9qMlErgXA/ByAzbeI2Ihdw7",
+	"6mpqaKh3RdWuYr6U2rM2hlAM63zzd4le64bMCSnGf5Y4j/rtCs5FgqaYigTN9CHBtRaakjwn4Z2qGl8u",
+	"F4XijRtNDxSxvTnk2s2sXDRNp/Zejx1ZT/Hsifw45Eyu9IKY5y3Lpja3jgvBUwKmKu2l4zQlY0FSLjLz",
+	"iyCSaNvWWM4xwBaT0DOBmbVQ1HflQpQEwVNzOFhI9EblkiBBrriOU6TBDgVBE1tQ1GtLrwDtwOIVERLY",
+	"7FxhtUJNx2VG+bgWRdYy5EOIhTUcGu9MyhdEwlGIYIBXLcUM+5d30WvAkAmukgUh6RzJJVNzIqlEVGpS",
+	"z+HiJTlKc0o0ivX9QM75NcJIa4c7nOVLxLiiKYki2KzDR0s117Cswz/HUosY+ChQKgFC+FGDVSElGrM1",
+	"KWdjRRdWyK4yIFzAWz9o8QoKgtaz5Ti13NaNcn1ZdyBLNMdXBE0IYQgzeU0EyaKIoHI8BR2mLFZvJpgg",
+	"PEb0ehnCGS4g9ssMsVMW0TncV11+AP9cb13ENlGfmaGfSjbDguKohX9dadPmBrgmVZFY3VYJ3hkuR1g2",
+	"zloBWlit0Ieqj6eaoQlLl9GhTfzupxX3pd4JwNDXCd/2PByVMAKgE4excIk1aD50bsc7McOM/tWzIVqq",
+	"CyJp5rDXiAJU3NylcHpJWOYdxFgoOsWpksb+Jd39USbw2EV0S/s5TsG6ZUIBrTCIXmDjO9VAErzVvfAh",
+	"bvIcs1nZRYqd9OJFxWDLZgCL+2dUU2gtL5yse6kXOmq3c5HkY0EFkdaEUN/YY/1s6VRviP5NtGXG3IvB",
+	"LgfGDy0/Grs20BbRhUSZ8oLIuN3bHEIFEeAQ0zI5BDD0gDm1xLgzXwqCNWjkY8GFcn8Jov+S5s8PyQCF",
+	"LbINFtzuPfiVTOacX3bvwpXL12gBDzceynbdQZXVokp3cZaRIYAnI4XFjKhxKSLXmp8uLk7PEWEZeAwA",
+	"mwYkuEwVXOqDWfFwY0tBb0mqBYAmDjPdqM0uXPB60wO2vlmuzgxbDWLUJqVxKdcEqJNBCkGm9GPstiyk",
+	"QukcC5wqImSDeRVHiuS5+VMiXGCh4u4nrUevB2vFs7fJgEmVrNC4GVSrFESVgpEMcZaSV4gqrccyrtCE",
+	"6GeCkjDw5dZCD6xwsFvlEVQjs5r5OFmRYXG4THPivB5t4+2iKDWL5vAC7DpnBHmZgVL9edtLrH8dGslm",
+	"XjYzjPUREPV+ShuR4HXbBgzGHSrroeTG5qqIVOalaKxTp/LXSZHGKzrOShsB4oCO+q6FWmv0xtZ7TNbG",
+	"CoDugKZzq0+FlvFx6+ixVHQBHhiYq+bNXBAmlSitIye6685+F93QAZ7vlLMp6IIx/zcpCNPmOob0tXGB",
+	"2dJAIcPsj8Bgm/Nre6CVi1EyAoPah07qE2RW5lhQtRzLlIsIAIecTKc0pYQBXq70hUbZ/CFDgI5HqizC",
+	"Z69Qzq9NXtGCC4LsNKNkCDoKs1MkG9+YiqJDJSs2rBMvtV3qJDJtlYiwsc338TZXz8Ft4gJRgyFPYOt0",
+	"5r7vYuNBpGpBN0B0cH8NQKmycUau1prFjz1I3w9FeeR8yzmbEaks2lbIrDkXatCLpeMIHw/ZUBqMZUjb",
+	"kabkGgwTmCF1zZvCW76q8RCa0lkprP9LRa9t3lzRyklrbEwbTI/XbvItZzN7X2onEAtecIm1qqOFDsIR",
+	"4oWzx3kUrCHNvZUjZ89FvFSSZgRpWWYsmd0HapVcVaeH3tO1SQWbqK/hcd6QirBcO6a5q4FrzSMQcu4w",
+	"5IvW729d8IancSNdBEvlsarxqX/XvuQ2agdfFAc7xLtTLQWRPF8346cu0eOq9lYXKhVWZU135gVcaoO9",
+	"yajUV9+Oa98mbogtpq51KD8BImo84lcc5qf2pOIcYZov30IekIxaq4bZ35qOvl77nvN39b5Y92h1vB4K",
+	"6KYnqj93IoIUOZ9wLDLIKI0c6u9ZmDnosjfDrGodmBBo4pyBWG7FlZpUyehJY74cHjGsYYhSI+tIgO2K",
+	"kmt8kIQpnRaoD6uQFmQ4N13GNl+4dy3NZGmtwLjfjFJ2o3zlGJqw3+pVgzUpI9SsMGU3jSQB2l1QVkbD",
+	"ilycDaOzucqXCF5vBDtDQLtcspRk9rk+/9tRRpgth2nk9czHsY0Mo6QXVatiutvjKhdaNHhIE4wUJmF3",
+	"xqg33xk2m5GK1TR8UWBBbdLqqg8t1R5WHzQkZETSwl0tLgf4dfyBvecNDBE3nDu+Jpp4xpezWFaDVC47",
+	"2VLQhGdLZD5pRkdvTFA5vx5X96mxiOoDvhhCQ6PE+nKJqs8R+agENlf7QbPHgjXiCU0xlHcFJFdQFkSg",
+	"5hzWuzmKxY5oJsioVIJOSqd81ynDh3tEIbJxIfGHLnxkKI0ANJvwBhzSG30I1FSvCPCmChjsSo8aSyIo",
+	"kf4KNuggqKk6fc6IGJXW1lnDVoeAiR+TCp85/9/GbsJ3zMdXHvz7/dnx+Pzi3dnBj8fjs+Pzk6Pjnw9P",
+	"js+RtpnKVzZ9gQvvJExzgkU9rqNDIW2AEV0PxTPGpaLpGZFlrmLOg0rN6YgKgHiJUhAkFS9spKIpU4SV",
+	"sct1uO29HWMhB2Z5+niK4f4BQTRH4/jd+Cd+ra/FU/oRwLYLWX0dqY9QYCkTdI0F5AbqAXr3xbnKnLof",
+	"aGghRlbvlzwDb0FkwzQtr3m5M16JCKVObaSMFoPIwWkEJqy1I+ZDADEN1/JaZNjH4A7gJFxuNW8cdTMi",
+	"1Xk58evr5F+ywDSvYc/80rex5q3o5CU54rawWX0uLMeMkCwW4VXpvrZCkNkI83qCGNEslpVklAzDchhx",
+	"Mo1q0evE7bvlDJr6PJ2TrMxJprEQm1pP8xdncRYumXTfr8ZSFezlPkAQhl5gIbUOj8KwkG3grBlbrEbB",
+	"UhySkmCT64uJkUq93FmbvVtlvX45eHNydHABJb3Ozt6d9VT0qj6EeGL0lTVZfIWoRH4xq01K1RgnDKrk",
+	"+ap51l65VhmuKBa8ZvjPyhgQtzB2aHtTnOc6Dme4jirxlVWJEQRWQPYHvkZKYGY+HaalTnOsXTvrKscK",
+	"5QQba0OgGCMqZUmGTQyvwrRylWY8YKRekAsiYkC2Ly7x+8IgZxJfFGp8RYSMu/6q2c2ryL6aoN9HJdM2",
+	"EPb7qGHYNltsslbc+9Yf5+zZA1xTNcCSgBCbVJd0aKI1Cqnv2yBmqM7+TpxYGxpsVB0/LUsWTC/HkmoI",
+	"QV8bRD3dWlrD/5DjUtIJBXD0yg31CC2dYU6vMdLUOsXDXajQAC8PP6Hc9g4+pNoyp0/eG4iCqZIYMmNb",
+	"+poqRqSEW83BbCb0NZF03CRcbHlbqg818EIsU39mrp7NZEp7c2iGl9EtLhmNp2X4WlZx65dmxznRlnFN",
+	"7mBoNRaKBMly4UMgTBU9PbgcIvriWdwV8qoaWQB3HSErt0fhbWRsB8M95WuvztcOMR+vglDjiTrQ1qZq",
+	"7qA8z4iAa7ReRc06u4uOcToH+oLQe33kasp4iaQihUQAbBIS6qRYJGYMoNnaaMj+N0EpzsG6ii6hzF1G",
+	"pcJawJmy04kt1dr+zhrpLmdhQi6AMkpGFRQj6yAZJSM3k3GCwSzgGgvHd68Hf5uJot6ywcIkqANZC2rT",
+	"5xzTu5iMZpzPcjKe0vhUZgSw/0R9tO8EnVFd7fjkyJjEf4IJ0KGZAKRERrLSVxSOgekklAPSEeCkWIyS",
+	"UYWSS3PzNluk/47n4Xi5tn1h5AtaNvDSwx7hHQHn+bvp6OVvg8WQ4a3PyTZiRTd2lq70bn5o6hEHyBbu",
+	"mpplBMdVgJnzJUtXG+bgi41kt0Pa9nzGlbs4BG3FxpvldV0OKZNEqLByWjMqsTrgLTIhoZ1K8BmM4gUu",
+	"VhRtvqRFMXA68yqakBSXEnQ+KlCd4k3oRS4IzpbWiTGKl6dg6cBZ24s0KRdSHwtYogbGeg+1yjVfAyIZ",
+	"tQaq4ya2pT8enZ7Z/ML+xMJViYGRpKutFG7btJbZwNmDq91akRUdiYfVgH3Fy36EnGRFzDWq2wzIUrEs",
+	"LIWB8X30EgyirRsOlvKai0xftJQ+oDSFnR69NlU5CveUyno8duK9cu4NSNuuCk8YNk9A46ASMrmRBcoZ",
+	"KgJj+yVZGrtFFXZsIsr1tzO75OwVohlhxo5LsMgpEfY1W7yBKyRIKW08cjWdtfDIXfROT3J69Np/p3Mj",
+	"J6R6N3Ev6xAgqipIU3mFDFkYZPxhyqTD86/393fROSylMuSdHZ++O7sYnx6cn//67uxo/D/H/7KfRSAz",
+	"43yz/2I3apRelTLXTpGzLwRbPyqy6ShphT7lxC3J75vGii7Yk8qr30eaKLIyJRJh9O+TU1fNTr99eP4L",
+	"mtLcZ65qhTPT+8GvEcHp/BXCcMhJojxG9N8aee5lkwOkR9lFhzwvF8zsI/wMFmJcFIRlJNv1VY/lbiqv",
+	"XiKaJf4nwEzio7QSpP1jSVA6OkGhDzxBtUidpOU1TVArJz1BPqE/QZCinyCTxJ+gRpWABDULFCSobknZ",
+	"DWYMlqO1/QSZLMCksvYmqIrGSpAjhATZoQFCsovqUQ3VqEF1mQR119rerQVWVp/H557qBVGmCJOAHIf6",
+	"XaffVAOYD7wGmZjq0wlcWRJ7hu6iI6xsAKotbLhzdFSD3ebUnr0+RC9evPgevb84RF5QJiinUpmRzSh/",
+	"cMocc/4+eoV+H4EgcsUXgzfBRxleXQynpPIqrv6bWiexcGv7RPvkKEvzMtPSzzVFsEELu+i9se4hNxAA",
+	"EZEmoAnwqS5WoIfKqg+otIIOZy8RBka0sjIn+IqYC+RCV+PQSzU8GvBbYiap8ZN+KwfJni8NvBUz+fAn",
+	"S2uWZXAuoaoERJxQAmDZZZtilwEl2HFBTtghzPFSQ4LVdzgLxb8eyR88k2X4CPbcRbv97445EHf8Nujs",
+	"8JzjzK59N5ZSGMQzBiw5CmK+Rs14IXi14hR3cTXlygEtEARtsTIoFeruM47j8Z0xdcPcXm2JlxWVDyI1",
+	"WXptODX5PWjpG2UDNgJEe1JWeqGOFIXp/WZ4JbDeWjK9c1W1ZXpf9bVmNohUjYUzOdQuiRwlI8YhbkUo",
+	"ivNBmG0OOc7JDLsYlEKQ1JQ7NV+30wY1eolAv7s5fx8hWZBcb5IWpM3R0e8jyRfk91GQaZiVwqh9ErkZ",
+	"IaoeavuuKp7jDw8X91TFRyVVHNUQJAyso9MfjrztujrVEjmkVGEqjLXMJIO6kjpD1ngHwe0dguzcR8I0",
+	"b6xht70u95FDAb+01zReKt+IKWqXbFRY0JPDoa4tuNZpMcGSJIgXhGGauJIuYKc1FRWi3qRWfkEVVJKR",
+	"mcDOXe9+/jAIR7pT20x0hBsdkZzC/QZyuZE1JkhX1j0oQfFVVSMC2g4w7W21LeCWUpFFy4unoz3HiiyK",
+	"3J4EW5H87pvJcpD0JUwT7c2MEpeUZXXDLZMcDK3XpnbAKBnJhSqi1NIZBhYid3BTGaIUdPnpDzPtolco",
+	"MS8LktIpTZEb0JeXN4WQYVXo/dkbrQ2ev704RYKktIDdj5JuCf9cvdtlka252zGrSxNtPpcbdilAUQSq",
+	"pEGTFXk0cr0DUD+sZinLQMtO1loirPSEyvIUrb5tZ2WaN2/WNayfJbRkG6+yooIwiD4ZOEWwyMGk3ZJ+",
+	"mUGgyXkzoX0ftl4SoAGpW3sQDFnblB5q6KyUqPsq0lkpfL4zRpmjj1UU0ZKh9WF/5Mg9dMYeu68Qa18v",
+	"5uNc2ZpRzH0Qrsk9UtNbm2rHfiBEb0c6PiZJN3hP7Mcbbks8aFd/1kmWNnrkVyyYvdU03E8h5DFBoDtn",
+	"6mLylZ4dfa/nca21X/2mVvVb6ywvULl364hWJoDStzximbZ20GrZCN5IEKb+LV4YQkIHf5WCoHcFYQcn",
+	"Nny4dp2Q9Z4e4BZ1oCtb8g7T0Ye+Xar1Zomhs9YZLVygX3h8c6vGsZ29XG1KMfXvtg8cm7m61nnjPxqo",
+	"gm10vx8a8HyrhYEAc8MXuolGN7wrVmd1nYoWTJEdrZ7bw0X/U5O9WQh5Fbp78iX4fDbVutx+CCPrA1Rt",
+	"VkQHVkHeEh2ycPM4+GTzdmO1hcUgfYOVNuH/UKaXsabkh+WizME0gOZUKj4TeIEm8PIrZPoaWgljaub7",
+	"KKkJL207Idgc64qDYCXkfLjNC27U3/wunETrGgotuFQoJ+N6unt3wKR5tZ2oXBREWEDt2WZWpqFd0Dyn",
+	"kqScZZvEyDnout3TFvHnDBdyzlWsvAG8EODdFluCZgFt5QpAHx54Ud/4WGGINdrDyXLRzDIaiCjvzzcj",
+	"JH4dMZzF0pVjhQZNQ+fOxNB0LaG2qnagiJ7kl4TtOSg0Lf22n6BnH8IG1EaPcpC4+rQucyFKb72J0t7G",
+	"2RtjEWLA3zjN58ko6IdtFjhwI86i+qN/bK4J1dxJ5bY2bbw9wjIioB2ZVVVkLauke6sb99X6mL6VWeCz",
+	"rHaDqspjlfIZo3+RFY0lwySIlZVet0hq8VyHLkq7F/oJdymgIUdWAqvhpNR1YtaKJHSXOva93d3k7Xte",
+	"d1l++CZapdT3uPTjZyVkQLn+8vy65kndrNdltcbV2Iq3tPTspksRVU0tQ2GjoY90LAgw20bXrTbdXptL",
+	"Bu3dpia5Jnn7Mesu157SMdU+bSPAPUyTe4pvXxXfXk8oXKfk9x3J8mHCNFJou2+1nX7vtFHl4IZsfT9V",
+	"0296gD6A4urJ6NpYrmTs1uvtPLJSjPTYX0mbrWP2sWbUmcLVJaZQ6sMJmBmqNuszyjoBXuk3lza5eZLz",
+	"9NJ2u8FsNjjTOWKMG9RwpCJXl7Dck7DdptgML+WYT8fQ3jLimw2Us6Z4tHplvKCvT2iGYz3UQGtaIzSn",
+	"gMA26FhOPuocCaryZVTJ2EBoaPGWxTK83hcp120lkCALyjIiTGxZYq7XYfzRj8cX4UYO4+pYwjggOsN1",
+	"r3yVm7z/3cv9/dG6hcxbx2s4UWN/65ndbv8+DKKsTufFmcOf3/KGgrSLDlxbUyjxY+a1hTfcN540qu++",
+	"kg062W1rWd3VCC7aFQiqxm7hjkcprckWkWrJDQlBpVNb9/W/z0vdQvYVhLQudVpi3Xjvt99He3zb1zSp",
+	"j6I6dgVe0x6Nn356+fatsxtZSagfIpv8v4IiC6wUEXrY/+9vv+0/+/Db/s73H/7/57/t77z48PeXv+3v",
+	"fGN++j+DqDdCbFVw3Xa0u2q8J/2uT78LcdWZWXATPaQWOFxz8kByX93NQ/DVclhA0XpqxR0X14zGXfbj",
+	"v7OKxkZBkA9v04Z7+x/Y3q7ct/egCnYekKcmNtFqjO50bJY0Ht4Mcb3EkI02cksodl+NF7YKTLualXsF",
+	"lmva/GYvkSBFjl2lBRcjTiT6m3WL/x1xlyhixfO1q3rqlmeemjYVeqyB8XBhybj2mQBavdlBaUutL+CD",
+	"Sn8pBEkJdOA1IaTuBIEMPlN92wTC6zhSBKXbtL5g33LBpOaphEImf9vXjrpnf99FryvKcMZWQYL7hh6o",
+	"ZBmZUqaxWM/BYQhbkKDPvfZ5F0SkhKmx/dpffFzDUJM0oUfdb+teN2kgW5/4hr1bt9Fl1Y+VjFwf1AaM",
+	"MeEdNuHajtBet2PXym5dQCjXgioFbt92546ORl6jZNv2gphd0Fpmeux+IYqN+7eNaMHXKeLv/eXbP+sN",
+	"ILFlnGJG8gMp6YwtCIseEsp1vmiE1iL4H3Gbm+aU0ZRiJr9ChR5VRm5Fep41QjDckIMbymxA2ZuEP1hC",
+	"3mhX2jEJtWXWBu+lQti+dkW+iHO9ULqkPJwQfj4XZ5Hp6jkIIghQBoNZsU+F2Up946UsswGqDWlyF5u0",
+	"RgQFtMWCvObbpYJ1t9UBPGRHXxtkR9w+OREKqphihXd8bSrBJzlZmN0tHMOycKshDp6RPHJaKova3gBy",
+	"KA4OTj9X32nsa1cGv7kaZvVE06j2xtO0FGJNWliL+eJRfEGlUNtv2+de6QC/FbVWaLZiU6pW4tqUaPYQ",
+	"7IwCU5sIPkrWpCsfH+6j7WryoQKrjs0+0tqGOSMc7+GZMW7FKnGKS1m10e66FRd47bZ8azXDjYWdW+9P",
+	"YicfBZ2KfGhb1h/4GcDhZ4kiggipI1IP0pRIeREP8at6a5oIP9PVyTeN0dqeeV2a0gxVRHnkmHlqvvhl",
+	"Nl+8t96IMbJ23XIPOTPB+9GcCPPICSlT195ll9lSIFWT9OOPOFX50qnK5u0ELSgzZQDwR1OY5JIsde0S",
+	"SF6XJOZTgC/bAC0JZL8znjTBQUsix4x7YKJZYnbaSBQMQMOnun16Og/HXpSaKDlTWC8iqLcaWut7N36B",
+	"Pw4K1TQ3Yzs3NI1FWP9IBE0jSwu6HNDI9r3h11uboNEuvVFDtEEJbjZJTF2b1NMRlYizXnIPJ1tFuufR",
+	"6F6nmVRt57G8NKFkxqLl42JpDjcFAJN7n4y0sXPuCmda995cRK+ZFzlYOj/cPtuhsPJwhpMPFlLnRNVv",
+	"7vXt8AQjSZey3KtTbcH20ASjZ0Xun+310KyjL4GbNRZGIOfazDxdIca1wxQrI9O0V2vO88oQ5ZlXcTQh",
+	"hmeGBk+0z5KYs5R8VCukZb1p2xjqD8G+gXAaJSMj4fv1OrNlejL7ZvA4tiNnUMU5qKzW6YNrFliLtugo",
+	"BE8JJCYlaIHFJVHwTzWnIhtrrWU51jZlKI8gkIDuPIqPicCyo4/EyrJtG1ZPq4P+i3lQ9SyFdYLD35AM",
+	"VNmzh8YIzjPXTfmb/eH80VuFLb49WsvaxiXOjBS0GHtyRnehu/vCp4+4sdDm+DFhdbLr8n8Fn/hS4b0f",
+	"+epzq87YbTk7/+CTqGJjq/5p1viDT9D1nEuiGXwmiJQ6KAnt4YLuXT3bs3eBvT/4RO59MuN9dtXuhrQE",
+	"dQX9YmZp8wSKVWixYUsFJo1cMdNyhdWq3LlafrbeHRl4w7bI18/rl+ttZXl3kF13CF2Ks0Yk982srDZg",
+	"J9YofUU5ilDZaiY2mSdBpSzj+ymEHlxrn513a1GycUwqO2xkELtkBY/tL2mmGNwZdf3KDltRiNyuGXyH",
+	"xRwCdXCdug51Muk+qHFHh3UdS5ZDb6wFZ2qeL1fQRiOa9fwd0l/rrQBH8zP0t7dcB5j9XWtM/wA9ygyf",
+	"hPsF87gvFEfPv4M3W9PHSTDWWgmsXvXQvQQpUZJmokZfF+na5qzAdkcbq0A4Sl9iB1eUWd8S34armX+y",
+	"RLNqICNfEriSmSqVtkMWyQJhukJ+96fzwiibGx8LYozAyahS9IYKycYGrLA51lWV9pFgXeX5sqrS6qW9",
+	"Mz5+JcOSfW1vyB0d5P44iovUqmrqsEqQg/SCjYOegjKTD7VqIf2LjCdLNbizyq2SsKtDXieLpElcSVWs",
+	"xUIc4Dokkcb+1pbbzSfvz95EU2bXtoiXItK08NxYgXQFElfc0mlhlr8yKggYPkHMVwXEKnoTtP/UFHnd",
+	"gtu93l+IoNOgnEdULlcSwRclxegq+BLZnloPWL+P3WDplMZlSQOf/tVhBFqDJ456fSXKVuRx4sJlJTWc",
+	"pvISuadoyvOcX++URWCfBDcq2MKl6UYVlOh28UE9Z7tee1eZkfc20Nz2JJsAZdiXb+qfW+VT85NE0cnz",
+	"CKj6V6NGlJIIqHbeCsURtpzdzjXNSFhB2DiLQe0UZEaviAhDE0yNjDHOFqCKmzHsnzHBq0FZFS1UB/UV",
+	"akRFgKk7iPUKYEYmRmkbNmVrQnko9U+2FL/VbxeuN4dsGykGp0UVWMjurKh4Xkp3smDOZ/GwiVoKM8hj",
+	"43xxmddDLARbrfJg0beem7TjIpBBYXGby5XYvqOa5E07i1Fyw3zRGrSBKrEqecoGLhxfxTtl1AvydTiQ",
+	"nPPex+Ra6YZqmlLfJlA5NjJ/XBZxFdgWHxu6qxtFEjX8dzcNyui15ddR6lYIvRQTf/KNwcmQOLyOPV6B",
+	"euyPLp6jj4rgaVI/gfrChHx4S0fawAn0vZhSe932QU+OEDBDtpCZCZuXMV/hls7TlfB3JkqXGeVjfIWp",
+	"tZOuKjHhPUApX/gGE3qAV+3m/4HX/7Vt+Exz4uroyiVTcyIpePj1XQIEg+Q6SI8w2/5D+6sQBjlrImcY",
+	"VzQsdxWwiFnHChtCDX5begY+qiKuDYTwowarQsoqdoHX21P+gCX59mtEmNahMzuotfi4bwP7rO2G7cgG",
+	"DLKyXNQFiL7lbMK6/rljylhcjccNZeinks2wMCrRFqKzhNr4HGlFdA0L5FrT68VpzBRo6gueG4KFd5ob",
+	"6AhoxTa2Wo+usnFbbl1VDDsnPcjs9Xg44OXY++uidu7Hsc/Gd1WLVoh4vdqY1tC2hXsd3zdWVqMS2Zjs",
+	"DvmiwILKaFSVyfSJZCsVRFCehQQHLUNgLDJ2+TH/pXe+fXtwKg04+sY+MyhmXobODP6NrvwqDRu2PVjN",
+	"NybQ+hn6W86vwer9Av1NBxX/HckU5wM7TuusqjFdFIJfEX2zGtsknz5QYmlZlLn8KQ2kbYU3CAqo978i",
+	"faonVan6esWCkvimNHYgRkUXdEFyysjxVRQxOtIgqIMUJJLrj1qksZHCKMiKMPAzfg17YmrSQ9g3weYW",
+	"FRtLdtmxz+dgPqt+c5vtajy3hlKiZLYhRZcqMxWEmNAFG55upwc40xJivZ493w9CTaMqRzMqxe1loGNW",
+	"0t/94iOS3Q/VOd9ScoPfKh23bj8ea7Qa62xoRx5DtqomdNPsZ2xb6NeK1lZ/jHOuR3A5Dd5BM8sK0W/i",
+	"9SE0XfH34aasIuZthHDUGeO+Qzg6Ai76QiwuqL4o/yAIvtQG5Yh7h4gdKIgJ3l6WWj731w/rzW8eFBmZ",
+	"lDMtBvRZUrlaG7cRPa4cL1bW7R4gQFurMkf1ZgUz/bdJAF8MdSbNOywR1dW29V4qOt24VFMMse/1SmoN",
+	"39v5qBAjoLCpRVsLFoKI1lgfA5zO4bRax5dkrmHrfOGk5tD3rXt2nSkUL8ZmlVHLt+no6jw2UGbXistB",
+	"EkcPATvQFdAvB2TguE2osFHHZdLekAYqwmV+6CIS4x2KucLSjgo9P+MF8ZkWOV1QZSwdpQStD76Ta4W6",
+	"m0EiVMqnys4APSqpBPlkfgqM5S1SXeCP4w3JFT5dm2T1V+uSrf5mbdKNMXvpxNZAmmwRmjm47C4k1dbH",
+	"iYaIoJtw67AUhCmI7SCuTHOob9pozogjoxEm69uFQL/q0OUM1+6xgABc84sgkuh2py5GdvSh2+sRt6ba",
+	"h2squ+snDd1XSFVXDG1P5JTe62Odl3vz0u3Rcuxdc54KPqX5turpLLoSeOHJeJV7uPnObcSPdJ7/2+ms",
+	"ZB0jblsaa14vkk7vzXnQwqW+ORomqEnWNokf/HxQ1SwLq685N43rrYq7gh7viXP8mtbCTSe7DEaRa2dz",
+	"XOrv934oM1zoEfsg9xN0gfhe4lmPPujl9WPSAFPOUlo5J5uBtlIh9w41lIdnmDKpXJkiLW9kZfefkCm3",
+	"JXqmYAs3NDD0ZFhbH72vg+EGumUPP/xq20S1E/9YBkY3cN1oIaQJDmw30DsDHEVWW7A69xbOgCvXL7IV",
+	"oQcooGw3NLEENTChbuygILvh4YKCqHFH15f/IT4I+H93dBAZVqUgO+c/HTz/5lv009uDQ4stsfStxpJ6",
+	"u//K9ez6YFHp3NIxgBQWM6LGNoxtdfjZ9tKRg1n99vREcOgRKZtyqy4qnAIFmPNzdHyFkWkcii4IXrQb",
+	"h/3CaUp2DDebDG0j7rC9JWuhUORY6WX5Ok06Cse7vsy9eBe9xQzaaaacXREhsW0+ZQd1FheZGNkikVSi",
+	"TPU+ZuHEJq3ZhZBJeyrmLmR5F04flTfWpqOLpMJMoYPTkyAL6uXo2e7+7r5eNvQnLejo5ejF7v7uC1MS",
+	"Yw5E7zJPIIJpT3O82sm5OcxnscTYc7wA35ZYuuZq8JGtqm8YOehgAQeYrZ+m9yWzTenhd71cbUexTKp5",
+	"GlB3kkEAojoo6C/PDjRkB3qON9xU08ECL4iCO/Nvn0ZUQwUAOd3mZUBV5rIziDjjQ3mgnK5cjegExuHZ",
+	"8cHF8SgZvT89Mv84On5zDP84Oz44GiWjg5/f/fyvtyf/Ph59GDyxN5W25h04AC3GOMsEkbLv62ZNXEXQ",
+	"36pW/n9HXFS9+2HjrEDieUYkbP0oiYJQ7fXWQQBTA59J6/giYA7Qn9k+9jZL9XrOc4JM3kgMwoD8VsIX",
+	"u0hXhLj3Rt+URwNeNMbj0ecPVWAj8Nrz/X0nxew9GmJBzJmz94d1AVYgrrrYO2Y5NXf7ltw7cAwrE11v",
+	"UW8hCEEtKr7e3+8a3sO79wP2AazwyYutgX4sBBdVpd8I7FoaUKkEVlwgDMVUkD9VPiejb4YsAMq0M5zD",
+	"dHAweefS6BwsB5VUA6sZ1hLxt3B2SDTVX0Yk6J4egV4RufcJMnQ+66mVbYlU8Hjj0GIJgUDmy8xm/GjN",
+	"2wMCZxCizBchM/2n4Ug6eH90cjE+Oz6/eHd2PL64eAOBMsACcRktIZ9DP1wYzbclgE+5bErgA7uutxq4",
+	"M7umlkSurwze1WeFZWfHiPoIqvgQlhvmWFvLdkU2QelqKFH96evPO+Yfzz9HylXfPodZZDg0RIjVLN3u",
+	"ffZFsNfX+1/fHTQ/85D6PWuYWgNUGh4xUH1/hzgKQSJoQiApwgHHRW3DNxFH+qsX97AetwjX8iu1zYxJ",
+	"1hCRluSrRW8oLDOKZ4xLRdNuffOstO53hYUqC6NMS39ZrwlCrU+agCxJxBVNiWwJtZpWeRTMf4vSIpjG",
+	"+lYiu3AMNzhYHSqwlIaUTEo2Fsxx38M6al/cLY4OkKtDaBFlE8wa1FkylJGCMKi8i7LaJg8nzqpAoysb",
+	"GdDoCqI69t/9037Wc0CaHhQc5fpmro/4DlU1w8u6Ju9bdr/YT6r2Ey++/SZoQPEs4jC6zZOxtfoVFO9f",
+	"RRbBiF/ZIGJzY3xSSJeGuhBp4WotWrYBIMMI2LY/valEjAWMrIoWGdCR1XeEbe3CT74TbEGCsqKuH2zT",
+	"btTKoJ5FM0Pbu93qPCuRpMzVtzeHjg/nfWCk2CKqOppslBAl64nJMBlMhvebVZeJd7WPzG4QqX7g2XJr",
+	"6DJt0cOZvIj4/Ll50fjcIvZnWwMkBCG2beFzb5Z9kny+s30tJzKgzToR9ZHm3ieawT28KshflN3hwq5f",
+	"RViiX/emqJXo1y+Fk3zVWbFfV7WkUpmbghtBKtNMyriVlvqT3fYtvOzim5PszK+mR8WokdjJUfwKbrNt",
+	"u+7fffbTD7fDxkdYYb/OtTh4/8442MQTZnVCfbrtrwlNjUj1/ROaOG7Jpqdqm2P8PCJgn8FCBYrC75mi",
+	"/7Z5CjHxlHW+PYLfK9YNGg8M82G06+PfkDdrzPF1pBceAIdk0BYBCbLgV4/jODphspxOaQq1/IW2+ns7",
+	"UZsv75CsY2jdLnW/Z3bwic0HAhq1jSlW0HYSPwHfFc5mrOaE6eu2lm1VDwzKTMHfNZtgrDjW7p83buHc",
+	"avUYuafDq6vjyTBS/eI4/w4NxbpMhGEP64d1hlXIAg8ajYChVWjnuH3xkViOjwPet83MamZjkwxDpa1C",
+	"0zyUvdBSfKjI6jiOvZjpsiJDxxHTNKHWCMZ96HMdGg1gTGcY1whmhdEkbO0h716ItTzoh15c23KjgF9q",
+	"UuySlfI9rBG0i84MTMBKps4NcBdmpve2/2y3w2rZaOpzgyWtHZdgNzdBUocT6SAA7TfgzSJIMajBqHNX",
+	"MQHvplNJHkz0QKvpTYTxXzu2sQgH6kpMWoxGtiCPJ6JgjdPjxpraGyqtNAk1o8HCzmWw70iiBtvaglLx",
+	"t2tqCya6J0tbAEFsp91jqAv6ZGhrG9r+DBC0lhHY5xv1exfe29yiW5NfjUTHmLlGQollSHL8Qv1FsCGx",
+	"BM519pQIazX1NQG61KsfBL+WJEjqCiJebZkTJTCTpn9EUou21UZSIhMEieUyqcpeswzpdgo2EnwXGQ/5",
+	"FSXXUHhHhxyQbHe1WgapmyfZRVDUYJWdVL9+W/bRRxZEWMu3j8crsMoKbuosPgUTxnmRiLCsxiAWBGbo",
+	"l6jmtSFUba4BwHErbgGlebVXP94wwLqpeUGkruV8G69LhP5Bw7dEOL1k/Don2awTEBvtO268GgmSgLLn",
+	"kXLmN2WiQfnfsFGRTkdtkjS42DZbbUdzxY7cPAmbHyKkaw6OYFe6Q1/fYqEDupgZHkEZGC3lY8K9Um1h",
+	"lpPsIJghfuvevpNra0ERsOChtbNs9UOTiY91apIjlyaf9FaD6CC7+jibyu+v+z/5mavXW7N+BxSAXHGa",
+	"lfQJEdorc1yCCE8+rdQn0+2/0JqVqcuCLgkppOnUDj3hTHFA7Sf24aHWB7xCT3lKbXmMqS22StWDTGpR",
+	"/D/TdPWU+HLzI37dSG6bLQtile9IJQhedJ/15/DcVjqdQqw8zncM7dvC8vAqKqWOlfmVTM55eklss/CS",
+	"6Q6cZaGbJ3SrBocGIr3Z3MzXpyDbGo/o5Mj3MXQ32C77cL1C/e34HvUC9q7xVZ2KqkqvlGERaT20ffdi",
+	"o2RBuFFR+TJA3wACCHsJyBJIelrm+fLR6B51chZ8gRZ8AvWBiyLgH1cLfBXnXHerIxUXuOQto4mYMshI",
+	"EpZJZKgBPfsWXf70F3r27c6EKrTgjKPTw7fob1ygXw9++bthImNcwdoGjXP0+4iw7PeRKXY41WzyKmwd",
+	"UZRyTqDEvaI4b7ApvC61zi7JbOED3wRJ+YzRv0hWmwnernKDXYJ9fcwkaJ9sV6hvqNorDYWlriiGZ2aH",
+	"sgonnRpWKBB+7b0tH0B12XaVbhXS6x2IhYBfnxkbeUNoXVPbkMXmA1ZkUgiueMrzR3GumZNMce9StDZE",
+	"i8uNGPtO3fznVSFnxhWy1YmjgkKXZ6hT+2Ap4Zhl9T3aUyuWFXtpFlSCzmZEGANQlU3Qe4oeumlvyXNk",
+	"h29UWb7jEBlTSQFWfMKGbHXVYuBRHlsO6y0hN5gaoUJtNyme6seur8FV1fJCckQVlO2fEFe8HvIORC8h",
+	"wpC3RIX3S32wsmYPhhXEZ6sDP8n2u5ft0BXRlDKEizjWHVlsFZTUKC9caBJ3pZi3wa2GmTZmVR80YO4T",
+	"n+z3J9nnvU/u2Un2uVP7/BEUCrJTtYiEvNSdjCzCcjVZcKnDSBYk1S3ivEu5Tzlzvnlza3Mg/tPDN/wK",
+	"F3fe+VVvN8zKAdg575/hCron3sDOfIPbYccaYMj7OZE0kdUbZgymb0F2rD7TfR5BSnBd8zEZ5K5ct8DX",
+	"gV6GoMNQVTYr+ApK4bnjzKYf9x1dZ8Smun6Rx9dg5clto0Nn2DDN1tyrb8MXdsTd7YkF55BsEnYt8eBe",
+	"TlLn251j8PlVtOAtbjeNfV791blJ0n3Pqs5NzeIWTp5sfuaa6bIVdlAwZtQMYOB6d3DaSnDKtBfwkrFq",
+	"pjVA6BgQbkfkNNqY3rHIOQzK7Ok+SGQV4blnyNaMfrS2RkMyNTJZhyDLBRkQMlpRT7n4Mq9ba9y03A3V",
+	"Wyw9IyowX1ZUiHIy1elPU4TV083sP+VmZrhk82PC97nuqAlnonIxBBSsLi0a9JLMbPHXoFTxJufHuW1x",
+	"fSsCINJY7eFKAdfAdSunxvY4xPgpXIdaXV1A9iWjwdlhFa+mZc7ULAEKoUELoxf7aEFZCRG6xi8j57zM",
+	"s8CAtyVPGhbKEPoNuEmVMjRwdBcVI0pQcmUCLtKgRUUpG02SKiBWmi9MN8bzwMjwAKwVH26ff8y6V3GP",
+	"xaqwGM/uz74gaxANJisdyNhdLYQLQVJl77C+qPOUkjxzpOR9BY6odtEvOC9th4MrnFNTPSKnlyS4D3Gm",
+	"eS5inEgx0yfehCACIfAg8wlO5yg10NhjsTNC3uVeW9rtJ1d/c39cpUOsTeIUq3T+0I0jroRIeAt+tEok",
+	"VOm/a8OHJ9L7s3FchN29NQdeYwo1fyob5bYyCZ3gCeKUBoqzwP5fNUGIl3o3TmvT1OuKuJYM0QNSUwHV",
+	"D68ZEcgEUVGBUizITPckkL0nqAPr2JX+HyaS5AM8Sj/usGyj49Q2pLSt8c3+BDF13f6CdjEdRlA4KNTF",
+	"0wkocA/Sf5jwnh2pH9oOrGDvbk4MhJympFC6yX7JFM1teFDdFy71wOZ2JZ+k16bX2brwen6XwotztMBs",
+	"iXhBKrYylGEoQd55gYnzGBS+0ESXBdfKrZaI8k1FeiSl60jXl311GLSuewT5V9vNXQmxNLgFpsVYJBGq",
+	"XpHTDz6kJue5ay1omuDYb8MUqi9CHG0nvjtot+i4QCfZmmpQPabh6tPbCQWD4e9JUa9R5wr1vCLgJ4L6",
+	"UWCmTMF73fjSI6dFWoFwzbCcTzgW2R756NrMRpXPI37NdJy/rfCuS40tiBI0NW0wXCdePx40YlfdauaR",
+	"e/H4o21c/ihzZlN5pTEOtBKfxg4ZzYHVnw+Z5WdosK1FKBTNKQtNRop3Zxw9mNrkg46iI01Obw01Rc8i",
+	"UII1rtZXugW/Np4GYmpRZ3iZVAWJhNQ7Myc4sy2fD83Cdo6oLLipj9Dmhar13itoS6iR/l+e8sefNPY/",
+	"Z7ufzNZ/Xpn89flJdEHteS0EAukBKUiSiHr5ay80uoSYH7FHVfQDndsP1sz1vFHI2noM+4/EZX/+I3mx",
+	"n3y//+GOWwi0cBWrVOg3TvqXmvberPVO78aaM2lvOqeid0sNCb3Wr36J+r/Gwf/b3rh49X5DlL2a+uuf",
+	"Ts7Q2dfoh5JlOQk19K9kWBPmSb0KZFStM6VEGocBIZuXolRsPhxIx8ZQ/Xi0othQNpu8W1ZauTaa5Jxn",
+	"40IQKUtBoL0rk0qUrsL8lCpmcsurvq+yI7+9QeHgb8/wEplN0CYzyN2WOidoVZNEfb8YJujtm72wvMFr",
+	"g6K7Id4YkH45s6F6dXj+C6g/TnDYW6jrUm23fyvK1Sc92Ofxp2pvPo8/Oex83jVq9JOStYkAOzz/pUd+",
+	"zbJC7GHG2XJB/1qRZXRGTNWN4BChmZZAU0qEyXGVqSgnaCoI2THprcYxa+p06Ood+h7JygURNHWA2mum",
+	"SYKFCmQ4h0VCwITiCEq+r0ye+zErxIFfwO3YS/z4t2gxqZv8ggo02+sC7Qathhhi84ODyFCUQ0P2JTkd",
+	"7rJ+jkOgObJth/VuCw5wpzP19CkXmhEOvVnoyUrea5rQ+O60km+nw/86tnVTcxGEoP0OYSavjW2oqlBW",
+	"s288nX2aAUKUQbew2v2zZXNvnmwpF8b0adGtQUW2tbvxwNdmgMMNS0SuoKMJQdTHKIcQ2LoRQcAbKFP2",
+	"LahPf0kKhSZL1PSG6VJENoJen5kWLJxLjmYC64+9IVj6rpzj4AsP6pxE+xyFZ2clMm4neUFjN+C0eypX",
+	"XOP1WOKCBvPJ6dCMuQbWCKl/5XFllLo9zPgC51YqRx0Ob2w7bORfRRlRBGLxLDfZtcD1EbnrI5xSpocA",
+	"q+K3uh0R5qZ94OF5pI6Id7ozbYUqPoF4ksy3Q6QSZb0Fz56q70e0+gXOl50VzBzCH2EBs7txcNdumwGT",
+	"OQlh2A/ptnJRQQHMveOYu1fBNcP9oD86rexJd2fb/zK5oIbPLl74oS6GnZK1CSu0fAeT+NhdZNQbOxEn",
+	"k9tQbmpz3JNi04ChWyY0tjDns00rz9YFAZ91HNIbC4I9CC/orhh7RUyr0Pqs1gGsz71rQi6hOAYMRNls",
+	"F/1KyGW+RFC9TJoMHMQZestZhpe7PQpEDceHc/x4Qxoqmzmg5kGYzNuQvEJYmQY3/3jxzPYSmioiUA2W",
+	"WzOqd7g89NWrzLEwDcFjER8QOjPyPl3/9zUQX8ypcSchGG3yPdVsMKREuo6yAJ4B9iqIoNxtlGZxRBaF",
+	"WkL6zJNa1HGeAXk3TX19AtF5xYapRK+9D+2RCyUsUXWFSXRCJDD/hEy5AHfAAJDWLxb9BsenhyCsIVMq",
+	"vt6EcJ+DLbDnERj4qHRutXgQicKDanjXpzLRfCZLWWoG3oGFupY90h6b1qUHh6aTvS++/dagnzKpCM6M",
+	"KQxKsvNpCH4HxH6SL/d2yRl5NwVOWyWXLHtqZjf6ddI0XXsxPkie+/FovgyaL/XEeZuh24boD9GQn4A2",
+	"A3szJLSYxHY3738ZCqsiR/2TpyOhuilbeb7hMbAjlywdUFCkdhqc629u594TzHBPIeQ1CLp393WAdqSR",
+	"SLLG7ugRartj6mTZjTnkjJFUrbFjYRDLsMP7bfDFkzXjpoRRYbPLlFG9IVFON7TntTl8UdtGRy7h5g42",
+	"XdQp4vaaSFbz3JPtIgSgm4mrt27USLLuiM+yYMc6N2wlf+9lJen3cGRcEtkIv7T1QIKxkEZJVub1qiDX",
+	"lGX8Omm4RCB8+i/OSALvWkuOnWiBhe7boPAlgbp08pIWRaRuYqcMOirJY71H6DZ6CEu9fjzhpUoQ49dD",
+	"ZscqPnGGFdmxvpP+ljOlQa85QJ4ttE7x7PncXV6gNj8YMbBK0LP5ELjM/tdgq9pRvdhf3HEdkqOSHGki",
+	"iwaJ6werqPhJGfRHRVaGvG8Yd0MR5AqmQJgcaZ8sR/B7nNNjtUjuoovb15Fy+gE2AOJNStfUEG0WPkTG",
+	"J67aTLxwy32ibfsHv8mt3PDg37+/g78EuG9MFWb5Nz/5TcPFbE4EYSlZX9E/yQ78xz2HbYCE22uW+9S5",
+	"7dMtBye6HoiDDDzVnr/hs17DDgw9JMDQ09xjbcv28AJ6G1c/hAO27rsDNi4MfKZVQlgWBp3A2FuDwa+x",
+	"NIp9d0Dfw5Y0t3SoVYD7td77hRYYt4cFZ09x9JuyHZ+tx3UDjvOMwLUc97Z90sdPOLlEjKOcsxkRhj0T",
+	"RBh4R6myPqSS5RqFVPlS7AwSqchanHxUQfgwWPletUNXJqTaiidm2oiZKrLakmIsNuckY7eCdiEJSnOC",
+	"BQTAowJLtRnTnD0xzRPTbJ9pzrbPNFJxsTIDE17QyqExkYSsYy482NRnF2TBdXh4QcQC60XlyzVZxoDy",
+	"xC8O6U8MswWGMeS7HW5xhuZNTC/n7ts7MOOtqPZUFilfmIK1C8oyU7Y1ZqdIeck6akx9E9R7era/f8v1",
+	"noaxk0dvrHygfVZ1+YCeO9og7rAAJp5NQ0e2Er8XiFVZkcq2jMd3SX23ft92iwmu258fDpFBZ7n7oqTz",
+	"NSkpJvSCQilD5VzwyVMwyc3prUJndzhJ9c5282IWsZFvmBXTIJDbkQ7VFPdmhgtBWOVgCjAMoQzOKhex",
+	"NjVeXSsmrPp2rxCa7Tfk6dPq4/+MCg8rg5iWaU4CjEQ2uHpatb80W4xS/fUXUjz9+fM7hEahnEC3ojom",
+	"TaVwQjKSaVAtmVc6Hry1nR7NdmgYtsaXZo4NGVMqrOQGPHkO3z2xI7CjQUZH4XAqFU0lNDEofUfaoGXP",
+	"l8ORW7qHNEkbSY/FTancd1jCKp1H1AX9cwehP+rAl3Ahxgh6b6Evw3QTYKd63MvdX2J8vMwmQpayK6qs",
+	"0cY0Nek2bpry5h3CUP8suKlFiBmqxu22ap5Ucx+YqW+pMBwMXs12T0R1xnNyICWdsUVXgRuNPygeRDJd",
+	"ckjjNEDkpkL32R0K3YowTL9Yi9y77h5TbbY+xSmDpnKIm2aP2+x4amirTu6O6d6JGWb0r5j1gIuZNZKC",
+	"5U8MTEZ5J2byJDsJP+nRaUIYHqoHoJFm10DIoGCsACX9OXbhBENCskJ8+6C6AK+PQRu6MDCPcbagzAvq",
+	"5kqMyvtnuTX2gNArWqfXLvbotY48YOrf/qEVLPOeDDQ1nlrJFTdK+fnPYATbujpghZscFHufgr/GFAKj",
+	"dEdCUa/nNvQQCf6tA5j8SA+Au5L49aW2+gd0eNW3Yd2jy6J+2XuEBdMMOcA0zT/b3zcVAwRJCVPIDrFE",
+	"WCmyKJT8cpn3niKOAyJFWchUW2R7ReSKC9s5YRnCSEI2WtX+T80FL2dzc03z4yU+spkLiFChSiOSMN1Q",
+	"MOu+xfWIkwsN4ZMg2dpRXMmIFQVSLU+HRYA0kxBNqsZs6dl/imlOsifm3x7za4q/2UHvzSLdrA0XXIKw",
+	"Mb5MlogsMM2R4ugPTlkbK6YeCGCtn5Wr+b9k/Voj8C3RkT73pmBXFqlBpowvXs2+e2a1fLQAOliXU81X",
+	"QzXut/btL85iE6BhkMYbrtAgpVfhdVMM0XYtnn34GhVAf08K7vZT6hxBb8I1e5+sc/Tzntme/tJJNT7S",
+	"3tqT7Aw+fRj6ZYwMzfncNec2Artu6Xw0ngqN3oftLsHwytOhuNUOOIBTpyxug7n3Pun/DK2C0cXnZzwn",
+	"/9G8Hr/E2n3qHraPzYZWAAGGMy1Nnvhtq2kXGqUb8VuBGcl3sJeTQ5XRU/3dQfDZAzLRNFMrcspoSvED",
+	"M/U2cD5I821gvVftDecYovqeYkUJ1AO13Y4c6r6SCCjlyUOzWqUFJCFc44sb+isfIqfdqs5oifCe1MYW",
+	"i8W4pL7JT0zRpwkWZkshZhjEyE1Pqb1PoVT/vPfJzjAeXiotzl2Hblj9CIaMRUQ+FPfD1o62+PAVUm+/",
+	"OpzFts9r9qH8X/i5c6dhbQ7J1OSQrzrlbx5WynCd+WFHN2J/OYcSETtBk9TBDH5uvh3YM/V+jKcRdjh0",
+	"be9MyoWvPaNRcYPL00MJ5bxDtoQ+DNasgFLMDAqrvoLWtcUiIXl3yJmWTH1TT82e021fEGV9ktW66aqU",
+	"58fNWlUyiqcBPu2KS8fC4M2Wu4XXUv2jInjxxId3wIc3z7OBvIPhxB+cQYIUXAwwipzZ9x5NUe0vM5fb",
+	"bENXFvep7WoTlFkvBLmivNTbABv41AW0w7AhPIE7rnEkH+OXvRlhmk3IAKecHedH98XtmBbc8Ga2tWwL",
+	"z7dMnqtLEek3kEWfltfQDc+K62f7d3ubCSgJ6k7ZmlSJ1kfNToMgnxAHsLMa3CH9tzFGJZqUcgltjAos",
+	"5TUXGSoEt62nLYlavVrp82BKZ6VoVQRwJOM64JoPh3LAH3wi9z79wSfOJBHtIGGHMBddwWdC8zKUhP2z",
+	"JKWHdhf9N58YkC9NupDvCD/BkiRIcv3DEslSXOmuE4IA3ZgW2voz2ze+ygu75uKSCDMZWyJoNi2gCxhm",
+	"Kelulmkh1vD8N58MTBc1aHhAxneIZIwUhE4cqP0QaXg0Koa+LRVWpZncdo4sTAVRjUHf1X+UjHyy9CgZ",
+	"2ejKSFPJAdb8/+YTZGe9YUl1naksWoz2RzX+QKbQIczTZSc3wKUXYVQIylRA/FoWEZaZHpVUoqKc5DR9",
+	"qTUpoql2zvNMtr4zqiUUZNWqJS+V1i5xCqW2egn8FwNqj0IHb/nGNTwjHgZrWzGggCzSf57/dLDz/Jtv",
+	"nRZyevS6sx5YRm61cnn/ORWureuEgCVPiDZOGB2kOgns0u/8Jv2zP5sWOs+dGOGqAX2FSnbJ+DUDqbjA",
+	"ueZZfQ3kGZFoRkxussQLkJ92Al154/s7PHY5RwstkK9CyrIakdyKPmcoe83jbI0eJHacB9R5xOoIetep",
+	"kmhKdcuAsAXJBir+13eu4niT0Cuvw/ApqnT+SqWBtxCh+pGB9vs7h5ZKJBXNczQh+tbdUBBvXM1Yb94q",
+	"Ek4G3dfvi0ZXieoim9Z3ww8/oQyLZWSCpDbAX7RYd4CuTTw9eg1HF0b/PjlFWKRzrVzyKTo8/wXYSEIz",
+	"WUeOley3Cmoqr5CdfZPAmHuiW1NeF2dLWFzGr1nOcfYKFTzP0Y/HFygmHPeMJoRKpmiudQ6nxskm7drx",
+	"NhDAe5UOGdWffvWtJYRfjFUyE1TpmElQj4cLl8GT9LHKuVP1HhjDbKLb2LV0k0GoNz+VAd6gsJGo4XEd",
+	"Ii9F3knhJ1KWBGEk51yoHZ2CliETv4ven73RSHDsWjFBRgVJVb40DkipuMAzstvJyEiQBQbH2xWmuU5e",
+	"BDGQ5iYyCopwp5iZczbP+TWi/beJk+y9yL8M1nl/9ibuwGrtiN8K+OQ/kZMe1AG2KWvrr+7QX3XeJp5K",
+	"s/U8+ap6obpme1bvlkfhsH1SCZTqPbAPisUOJEh2CqZ3BWEII/uyubXllF12Gy802JZRFNcNMazOpL+q",
+	"+YKkXSEwRbek0b4leWjmPwZYe2wX5+Hk1iDRgr+zrZrpUHU/9gm91FPBtQIaLwgKjyp/rblLkwzhLBNE",
+	"hsf63dD0WwqKl8G1NwbBTlflpRKwsePccG4pSazg1J1ePMFdud1IBEugNt3Y7UfFiEDFMTYE1QDK0u3I",
+	"cjYjslnxKmZKDDx9tmxF5W7W1oDcNXDmmn1t+cVg9JW8dpKZapi19/vdv48iJbOB4kHB6Q1s9Aanh3MM",
+	"CU5/F9+jJx+t89HG6Le3gOMq7tr7VP0BYbbtCo8dTt0OBqn+eZL5ko33xjLxoNfakrfMkndf/fxNUL75",
+	"S9LB7waawwZH1Q/DO9XuW6CEyoLhS6MwZFQuqJTbrU/ZFC1blywW6u2IliM72H+UbIn4PSqcVFTxytZl",
+	"gjsiplrLhE58T8LhSTis7YYxo91UOvibtY05bhCx9cvWbgy62BhN50gqvNQ2d3/DM+Z3f7uCj0yzAkP2",
+	"vCCMZLvonCjlylo1r4eGIVA6x2xGgFPmlM3aN28XDW0l0qBL961fAbYfOqdBhrXdU0Zez23fdbss3CtP",
+	"cuymt/w7lV3HIV9rFi1tlFqIm+3YHoChNzQ9wFd7oX1suK4CSzwMP31QhoLnsRyJCliLMPlIMl2fmKse",
+	"DFUjd2d6DNjNqQnuFLy/kKe0RXTSmb3J1vqTEpZZlKR1hhwmBtwp05cQYjnfnVuP2DB4k6NZL8tiLKwl",
+	"6grFttQ6TY81OnySNfdrrteeszLYxcF8AvtBOduRRAWq/UoF+p/2m3OivkA12hQZCNZ4T+p0AMHqMhfu",
+	"RSSJQtNSlbVsvSCPCmF5+Si4NVtQRqUSWHEB12J5j7n4NfRul2vNvqI/gxkCxg3QoCHp4mDjMN8Z3ITb",
+	"MrGNr+psgPxlHHyNVa6II/OvPJ1mm8bxOxxCNIuaU7m9K2EYqdZu3hyGE0dtUz/hK4KwDpNtpMdoY1Kp",
+	"uFYuU5znS0SgWPo1IZdaBV9wpuY6DFMrO9YKVRBBeYYmZMoFAe+0SyVxgSGYzcoghdWY5nfeuJ/nBGdE",
+	"7KJjnM7daNRltkJGSgpaGHp/cdhrzHpgbLz947i+wPuqUdorRs4xBNR9SVJkK13XhzBt/FyTxvIrhx5o",
+	"5+79L/gO59cYo0D7LDHxVBmZ4jJXEtEpYpwRdE3Ezbrwf4FdXfWYSFaE07wzJYPuQw+G8m7Hp+CWd49u",
+	"hZV0bySvf+OJtn2r2D7yjgteCH4cLHYvzNtfTERdtfphlV6JkJzh3OwpIKM3oM5OMaijF7waXuKfCLwq",
+	"4mpx70wEypFiRIwP8v88EFrevhg3XQlheffUA8dAkFkG6SD0x9T45vZp3KAsTuVrCvO9T/DfNaqu1jgC",
+	"/r+/vurdx2m5Vd1+iJahz0dUE/9hGYlOY0R8O8UTb8YvpcSzwTbU9/DyI88WhEWc2RogMWdVoxqbuV5S",
+	"JZHkU4VyuqDqSe32V0qpuCAZjIlKSx8rSO+aTOacXw4JqP3VvXqbOoKd5J60BDt7bPfsIyTIjEpFxJOW",
+	"4KSewQeylDSM3NapE+Porl8BcHt0n1VjHQw3rRvzH3tUOwRu93A29NRDpKaA34Bcwaqg3sFf2t2tU84O",
+	"Ttxf5wUh6RxcM+aHH3I+QeemoABKOUtLIQhT+XIXvTZxx9V6IIXZ+2K0J+vZPpIk5SyTvj6ZqZVTCD5x",
+	"YfnRfF8TVD26xcPbzNBdJeOciCuaEu1fMsiFnuPP9/9xHxBkZCZwRrKXCDO7M9I+NbVNEBf6PVM0IqUi",
+	"LektlKrsg/giIDANTskEwelcZ7M3iNqMZIItfO54QNvnS6nIwhL3gihB05V2tbf2lV6CUeSj2ityTBvL",
+	"7q0XZGdwrspTwRdEzUkpkR5SJzBzSfW7vhxQbcHB+wsPa3u1+huoUxk7JI7IFcl5sSBM2WqWo2QEtURG",
+	"c6WKl3t7OU9xPudSvfxu/7v9UbsN26ngWZnakInWCPLlnj7udskV3jFEv5vyBRQ0tqC2UhcAcsshIDds",
+	"kSC3p7I6w+wq20Adcp3dIGFDcY7mAW3oZuwLzPCMLExFazuWax4winWayyx1IyVweqnljQYMZ3MiCEtJ",
+	"NUr1qowMZGnUblc12N8WQWZigiY559qTTaQsBUnQlCpGpPx7NU2YIdI5Dai9eDYTZGaA1zArQVgWoPAI",
+	"y/mEY5F1rjuPVLHUI/kSGX4s50Vsj3SQE6Gky52CmjL1pHJfLhZn1j5uxzRfRoasx0k6w7rZF1Ou0hzZ",
+	"fiRzuLUHegecz0VFYAmUghUUSt9qTSCMgQphqwcFrd4I8tFWrrIfH3+0lR5X1fyXiW2ma2vAf2W66sIq",
+	"aa1luB219nFkcE0xSJZg40aCzua23G1V4N0O9OPR6dno84fP/3cAkgfLb0c4AgA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
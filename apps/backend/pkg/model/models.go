@@ -1,15 +1,42 @@
 package model
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 // User represents a user in the system
 type User struct {
+	ID              string     `json:"id"`
+	Name            string     `json:"name"`
+	Email           string     `json:"email"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
+}
+
+// EmailVerified reports whether the user confirmed their current email address
+func (u *User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// EmailVerificationToken is an issued email confirmation token. The token handed to the
+// user is signed; only its ID is stored.
+type EmailVerificationToken struct {
 	ID        string     `json:"id"`
-	Name      string     `json:"name"`
+	UserID    string     `json:"user_id"`
 	Email     string     `json:"email"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
 	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// UserSettings holds a user's preferences
+type UserSettings struct {
+	UserID    string    `json:"user_id"`
+	Timezone  string    `json:"timezone"` // IANA time zone medication schedules are read in
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // SessionStatus represents the status of a check-in session
@@ -19,6 +46,10 @@ const (
 	SessionStatusActive    SessionStatus = "active"
 	SessionStatusCompleted SessionStatus = "completed"
 	SessionStatusExpired   SessionStatus = "expired"
+	SessionStatusPaused    SessionStatus = "paused"
+
+	// SessionStatusCompleting is held while one request extracts and saves the check-in
+	SessionStatusCompleting SessionStatus = "completing"
 )
 
 // Session represents a check-in session
@@ -28,8 +59,22 @@ type Session struct {
 	StartedAt   time.Time     `json:"started_at"`
 	CompletedAt *time.Time    `json:"completed_at,omitempty"`
 	ExpiredAt   *time.Time    `json:"expired_at,omitempty"`
+	PausedAt    *time.Time    `json:"paused_at,omitempty"`
+	ResumedAt   *time.Time    `json:"resumed_at,omitempty"`
 	Status      SessionStatus `json:"status"`
 	Messages    []Message     `json:"messages,omitempty"`
+
+	// QuestionSetID is the question set the session asks, nil for the built-in set
+	QuestionSetID *string `json:"question_set_id,omitempty"`
+}
+
+// ActiveSince returns when the session timeout clock started: the last resume,
+// or the session start if it was never paused
+func (s *Session) ActiveSince() time.Time {
+	if s.ResumedAt != nil {
+		return *s.ResumedAt
+	}
+	return s.StartedAt
 }
 
 // MessageRole represents the role of a message sender
@@ -47,7 +92,11 @@ type Message struct {
 	Role          MessageRole `json:"role"`
 	Content       string      `json:"content"`
 	AudioFilePath *string     `json:"audio_file_path,omitempty"`
+	IsFollowUp    bool        `json:"is_followup"`
 	CreatedAt     time.Time   `json:"created_at"`
+
+	// StorageBackend is the storage backend holding the audio file, the default when empty
+	StorageBackend string `json:"-"`
 }
 
 // AudioRecording represents an audio recording
@@ -59,29 +108,54 @@ type AudioRecording struct {
 	DurationSeconds *float64  `json:"duration_seconds,omitempty"`
 	Transcription   *string   `json:"transcription,omitempty"`
 	CreatedAt       time.Time `json:"created_at"`
+
+	// StorageBackend is the storage backend holding the file, the default when empty
+	StorageBackend string `json:"-"`
 }
 
 // HealthCheckIn represents a completed health check-in with extracted data
 type HealthCheckIn struct {
-	ID               string    `json:"id"`
-	UserID           string    `json:"user_id"`
-	SessionID        *string   `json:"session_id,omitempty"`
-	CheckInDate      time.Time `json:"check_in_date"`
-	Symptoms         []string  `json:"symptoms,omitempty"`
-	Mood             *string   `json:"mood,omitempty"`
-	PainLevel        *int      `json:"pain_level,omitempty"`
-	EnergyLevel      *string   `json:"energy_level,omitempty"`
-	SleepQuality     *string   `json:"sleep_quality,omitempty"`
-	MedicationTaken  *string   `json:"medication_taken,omitempty"`
-	PhysicalActivity []string  `json:"physical_activity,omitempty"`
-	Breakfast        *string   `json:"breakfast,omitempty"`
-	Lunch            *string   `json:"lunch,omitempty"`
-	Dinner           *string   `json:"dinner,omitempty"`
-	GeneralFeeling   *string   `json:"general_feeling,omitempty"`
-	AdditionalNotes  *string   `json:"additional_notes,omitempty"`
-	RawTranscript    *string   `json:"raw_transcript,omitempty"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	ID                      string    `json:"id"`
+	UserID                  string    `json:"user_id"`
+	SessionID               *string   `json:"session_id,omitempty"`
+	CheckInDate             time.Time `json:"check_in_date"`
+	Symptoms                []string  `json:"symptoms,omitempty"`
+	Mood                    *string   `json:"mood,omitempty"`
+	PainLevel               *int      `json:"pain_level,omitempty"`
+	EnergyLevel             *string   `json:"energy_level,omitempty"`
+	SleepQuality            *string   `json:"sleep_quality,omitempty"`
+	MedicationTaken         *string   `json:"medication_taken,omitempty"`
+	PhysicalActivity        []string  `json:"physical_activity,omitempty"`
+	Breakfast               *string   `json:"breakfast,omitempty"`
+	Lunch                   *string   `json:"lunch,omitempty"`
+	Dinner                  *string   `json:"dinner,omitempty"`
+	GeneralFeeling          *string   `json:"general_feeling,omitempty"`
+	AdditionalNotes         *string   `json:"additional_notes,omitempty"`
+	RawTranscript           *string   `json:"raw_transcript,omitempty"`
+	LowConfidence           bool      `json:"low_confidence"`
+	ExtractionIssues        []string  `json:"extraction_issues,omitempty"`
+	ExtractionPromptVersion string    `json:"extraction_prompt_version,omitempty"`
+	CreatedAt               time.Time `json:"created_at"`
+	UpdatedAt               time.Time `json:"updated_at"`
+}
+
+// Values of HealthCheckIn.MedicationTaken
+const (
+	MedicationTakenYes  = "yes"
+	MedicationTakenNo   = "no"
+	MedicationTakenSome = "some"
+
+	// MedicationTakenPartial is the deprecated spelling of MedicationTakenSome
+	MedicationTakenPartial = "partial"
+)
+
+// NormalizeMedicationTaken maps the deprecated "partial" spelling to "some" and
+// reports whether the deprecated spelling was given. Other values are returned unchanged.
+func NormalizeMedicationTaken(value string) (string, bool) {
+	if value == MedicationTakenPartial {
+		return MedicationTakenSome, true
+	}
+	return value, false
 }
 
 // Medication represents a medication record
@@ -97,6 +171,45 @@ type Medication struct {
 	Active    bool       `json:"active"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
+
+	// InteractionWarnings are the interactions with the user's other active medications
+	// found when the medication was last added or updated
+	InteractionWarnings []InteractionWarning `json:"interaction_warnings,omitempty"`
+}
+
+// MedicationSchedule represents a structured reminder schedule for a medication
+type MedicationSchedule struct {
+	ID           string         `json:"id"`
+	MedicationID string         `json:"medication_id"`
+	TimesOfDay   []string       `json:"times_of_day"`           // HH:MM in 24-hour format
+	DaysOfWeek   []time.Weekday `json:"days_of_week,omitempty"` // empty means every day
+	AsNeeded     bool           `json:"as_needed"`              // taken when needed, without times or days
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
+
+// MedicationInteraction represents a known interaction between two drugs
+type MedicationInteraction struct {
+	ID          string `json:"id"`
+	DrugA       string `json:"drug_a"`
+	DrugB       string `json:"drug_b"`
+	Severity    string `json:"severity"` // low, moderate, high
+	Description string `json:"description"`
+}
+
+// Sources of interaction warnings
+const (
+	InteractionSourceTable = "table" // the interaction table
+	InteractionSourceAI    = "ai"    // Azure OpenAI, for medications missing from the table
+)
+
+// InteractionWarning describes an interaction between a medication and an existing one
+type InteractionWarning struct {
+	Medication         string `json:"medication"`
+	ExistingMedication string `json:"existing_medication"`
+	Severity           string `json:"severity"` // low, moderate, high
+	Description        string `json:"description"`
+	Source             string `json:"source"`
 }
 
 // MedicationLog represents a medication adherence log entry
@@ -105,6 +218,7 @@ type MedicationLog struct {
 	MedicationID string    `json:"medication_id"`
 	TakenAt      time.Time `json:"taken_at"`
 	Adherence    bool      `json:"adherence"`
+	Notes        *string   `json:"notes,omitempty"`
 	CreatedAt    time.Time `json:"created_at"`
 }
 
@@ -120,6 +234,65 @@ type MenstruationCycle struct {
 	UpdatedAt     time.Time  `json:"updated_at"`
 }
 
+// CycleStats summarizes the completed menstruation cycles of a user
+type CycleStats struct {
+	CompletedCycles int `json:"completed_cycles"`
+	// SufficientData is false with fewer than two completed cycles; cycle length figures are then nil
+	SufficientData        bool          `json:"sufficient_data"`
+	AverageCycleLength    *float64      `json:"average_cycle_length_days,omitempty"`
+	CycleLengthStdDev     *float64      `json:"cycle_length_std_dev_days,omitempty"`
+	ShortestCycle         *int          `json:"shortest_cycle_days,omitempty"`
+	LongestCycle          *int          `json:"longest_cycle_days,omitempty"`
+	AveragePeriodDuration *float64      `json:"average_period_duration_days,omitempty"`
+	Cycles                []CycleLength `json:"cycles"`
+}
+
+// CycleLength holds the computed lengths of one completed cycle
+type CycleLength struct {
+	CycleID        string    `json:"cycle_id"`
+	StartDate      time.Time `json:"start_date"`
+	EndDate        time.Time `json:"end_date"`
+	PeriodDuration int       `json:"period_duration_days"`
+	// CycleLength is the number of days until the next completed cycle starts; nil for the latest cycle
+	CycleLength *int `json:"cycle_length_days,omitempty"`
+}
+
+// CyclePrediction estimates the start of a user's next menstruation cycle
+type CyclePrediction struct {
+	PredictedStartDate time.Time `json:"predicted_start_date"`
+	AverageCycleLength float64   `json:"average_cycle_length_days"`
+	// RegularityScore is the coefficient of variation of cycle lengths capped at 1; lower is more regular
+	RegularityScore float64 `json:"cycle_regularity_score"`
+	// Confidence is low, medium or high depending on how many cycles were logged
+	Confidence string `json:"confidence"`
+}
+
+// CycleSuggestionStatus is whether a cycle suggestion is still shown to the user
+type CycleSuggestionStatus string
+
+const (
+	CycleSuggestionStatusOpen      CycleSuggestionStatus = "open"
+	CycleSuggestionStatusAccepted  CycleSuggestionStatus = "accepted"
+	CycleSuggestionStatusDismissed CycleSuggestionStatus = "dismissed"
+)
+
+// CycleSuggestion proposes logging a menstruation cycle for check-ins that mention
+// menstrual symptoms outside any recorded cycle. StartDate and EndDate are the first and
+// last of those check-ins; CycleID is the cycle created when the suggestion was accepted.
+type CycleSuggestion struct {
+	ID         string                `json:"id"`
+	UserID     string                `json:"user_id"`
+	StartDate  time.Time             `json:"start_date"`
+	EndDate    time.Time             `json:"end_date"`
+	Symptoms   []string              `json:"symptoms"`
+	CheckInIDs []string              `json:"check_in_ids"`
+	Status     CycleSuggestionStatus `json:"status"`
+	CycleID    *string               `json:"cycle_id,omitempty"`
+	Message    string                `json:"message"`
+	CreatedAt  time.Time             `json:"created_at"`
+	ResolvedAt *time.Time            `json:"resolved_at,omitempty"`
+}
+
 // BloodPressureReading represents a blood pressure measurement
 type BloodPressureReading struct {
 	ID         string    `json:"id"`
@@ -128,29 +301,642 @@ type BloodPressureReading struct {
 	Diastolic  int       `json:"diastolic"`
 	Pulse      int       `json:"pulse"`
 	MeasuredAt time.Time `json:"measured_at"`
+	Notes      *string   `json:"notes,omitempty"` // context given by the patient, e.g. "after exercise"
 	CreatedAt  time.Time `json:"created_at"`
 }
 
+// Category returns the AHA blood pressure category of the reading
+func (r BloodPressureReading) Category() BPCategory {
+	return ClassifyBloodPressure(r.Systolic, r.Diastolic)
+}
+
+// BPCategory is a blood pressure category of the American Heart Association
+type BPCategory string
+
+const (
+	BPCategoryNormal   BPCategory = "normal"   // below 120 and below 80
+	BPCategoryElevated BPCategory = "elevated" // 120-129 and below 80
+	BPCategoryStage1   BPCategory = "stage_1"  // 130-139 or 80-89
+	BPCategoryStage2   BPCategory = "stage_2"  // 140 or higher or 90 or higher
+	BPCategoryCrisis   BPCategory = "crisis"   // higher than 180 and/or higher than 120
+)
+
+// Hypertensive crisis limits in mmHg: a reading above either is in BPCategoryCrisis
+const (
+	BPCrisisSystolic  = 180
+	BPCrisisDiastolic = 120
+)
+
+// BPCategories lists the blood pressure categories from lowest to highest
+var BPCategories = []BPCategory{BPCategoryNormal, BPCategoryElevated, BPCategoryStage1, BPCategoryStage2, BPCategoryCrisis}
+
+// ClassifyBloodPressure returns the AHA category of a reading in mmHg. When systolic and
+// diastolic fall into different categories, the higher one applies.
+func ClassifyBloodPressure(systolic, diastolic int) BPCategory {
+	switch {
+	case systolic > BPCrisisSystolic || diastolic > BPCrisisDiastolic:
+		return BPCategoryCrisis
+	case systolic >= 140 || diastolic >= 90:
+		return BPCategoryStage2
+	case systolic >= 130 || diastolic >= 80:
+		return BPCategoryStage1
+	case systolic >= 120:
+		return BPCategoryElevated
+	default:
+		return BPCategoryNormal
+	}
+}
+
 // FitnessDataPoint represents a fitness data point from Health Connect
 type FitnessDataPoint struct {
 	ID           string    `json:"id"`
 	UserID       string    `json:"user_id"`
 	Date         time.Time `json:"date"`
-	DataType     string    `json:"data_type"` // steps, heart_rate, sleep, calories, distance, active_minutes
+	DataType     string    `json:"data_type"` // steps, heart_rate, sleep, sleep_minutes, calories, distance, active_minutes, weight
 	Value        float64   `json:"value"`
-	Unit         string    `json:"unit"`           // count, bpm, minutes, kcal, meters
+	Unit         string    `json:"unit"`           // count, bpm, minutes, kcal, meters, kg
 	Source       string    `json:"source"`         // health_connect, google_fit
 	SourceDataID string    `json:"source_data_id"` // Original ID from Health Connect
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+// Fitness data types summarized for sleep and weight. Sleep is the older name of
+// sleep_minutes and is still accepted.
+const (
+	FitnessDataTypeSleep        = "sleep"
+	FitnessDataTypeSleepMinutes = "sleep_minutes"
+	FitnessDataTypeWeight       = "weight"
+)
+
+// SleepWeightSummary is the average nightly sleep and the latest body weight of a set of
+// fitness data points. A field is nil when no point of its data type is present.
+type SleepWeightSummary struct {
+	AverageSleepMinutes *float64   `json:"average_sleep_minutes,omitempty"`
+	SleepDays           int        `json:"sleep_days,omitempty"`
+	LatestWeightKg      *float64   `json:"latest_weight_kg,omitempty"`
+	LatestWeightDate    *time.Time `json:"latest_weight_date,omitempty"`
+}
+
+// SummarizeSleepAndWeight averages the daily total of sleep over the days with sleep data,
+// since Health Connect reports a night as one or more sessions, and takes the weight of
+// the most recent day
+func SummarizeSleepAndWeight(points []FitnessDataPoint) SleepWeightSummary {
+	var summary SleepWeightSummary
+	sleepByDay := make(map[string]float64)
+	for _, point := range points {
+		switch point.DataType {
+		case FitnessDataTypeSleep, FitnessDataTypeSleepMinutes:
+			sleepByDay[point.Date.Format(time.DateOnly)] += point.Value
+		case FitnessDataTypeWeight:
+			if summary.LatestWeightDate == nil || point.Date.After(*summary.LatestWeightDate) {
+				weight, date := point.Value, point.Date
+				summary.LatestWeightKg = &weight
+				summary.LatestWeightDate = &date
+			}
+		}
+	}
+
+	if len(sleepByDay) > 0 {
+		var total float64
+		for _, minutes := range sleepByDay {
+			total += minutes
+		}
+		average := total / float64(len(sleepByDay))
+		summary.AverageSleepMinutes = &average
+		summary.SleepDays = len(sleepByDay)
+	}
+
+	return summary
+}
+
+// ReportStatus represents the generation status of a report
+type ReportStatus string
+
+const (
+	ReportStatusPending    ReportStatus = "pending"
+	ReportStatusProcessing ReportStatus = "processing"
+	ReportStatusCompleted  ReportStatus = "completed"
+	ReportStatusFailed     ReportStatus = "failed"
+)
+
+// ReportFormat is the file format a report is generated in
+type ReportFormat string
+
+const (
+	// ReportFormatPDF is a printable PDF report
+	ReportFormatPDF ReportFormat = "pdf"
+	// ReportFormatCSV is a ZIP archive of CSV files for import into other systems
+	ReportFormatCSV ReportFormat = "csv"
+)
+
+// Valid reports whether f is a known report format
+func (f ReportFormat) Valid() bool {
+	switch f {
+	case ReportFormatPDF, ReportFormatCSV:
+		return true
+	}
+	return false
+}
+
+// ContentType returns the MIME type of a report file in format f
+func (f ReportFormat) ContentType() string {
+	if f == ReportFormatCSV {
+		return "application/zip"
+	}
+	return "application/pdf"
+}
+
+// FileExtension returns the file name extension of a report file in format f
+func (f ReportFormat) FileExtension() string {
+	if f == ReportFormatCSV {
+		return "zip"
+	}
+	return "pdf"
+}
+
+// ReportSection is a section a report can include
+type ReportSection string
+
+const (
+	ReportSectionSymptoms      ReportSection = "symptoms"
+	ReportSectionMedications   ReportSection = "medications"
+	ReportSectionAdherence     ReportSection = "adherence"
+	ReportSectionBloodPressure ReportSection = "blood_pressure"
+	ReportSectionMenstruation  ReportSection = "menstruation"
+	ReportSectionActivity      ReportSection = "activity"
+	ReportSectionMeals         ReportSection = "meals"
+	ReportSectionSummaries     ReportSection = "summaries"
+)
+
+// AllReportSections lists every report section in the order reports print them
+var AllReportSections = []ReportSection{
+	ReportSectionSymptoms,
+	ReportSectionMedications,
+	ReportSectionAdherence,
+	ReportSectionBloodPressure,
+	ReportSectionMenstruation,
+	ReportSectionActivity,
+	ReportSectionMeals,
+	ReportSectionSummaries,
+}
+
+// Valid reports whether s is a known report section
+func (s ReportSection) Valid() bool {
+	return slices.Contains(AllReportSections, s)
+}
+
 // Report represents a generated health report
 type Report struct {
-	ID             string    `json:"id"`
+	ID             string          `json:"id"`
+	UserID         string          `json:"user_id"`
+	DateRangeStart time.Time       `json:"date_range_start"`
+	DateRangeEnd   time.Time       `json:"date_range_end"`
+	FilePath       string          `json:"file_path"`
+	Status         ReportStatus    `json:"status"`
+	Format         ReportFormat    `json:"format"`
+	Sections       []ReportSection `json:"sections"`
+	Encrypted      bool            `json:"encrypted"` // the PDF opens only with the password returned on generation
+	ErrorMessage   string          `json:"error_message,omitempty"`
+	SizeBytes      int64           `json:"size_bytes"`
+	GeneratedAt    time.Time       `json:"generated_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+
+	// Fingerprint of the PDF, empty for reports generated before fingerprinting
+	SHA256           string `json:"sha256,omitempty"`
+	VerificationCode string `json:"verification_code,omitempty"`
+
+	// StorageBackend is the storage backend the file was written to, see StoredBlob
+	StorageBackend string `json:"-"`
+}
+
+// ReportJob is a queued report generation. Jobs live in the database so they survive
+// restarts and are shared by the workers of every server instance.
+type ReportJob struct {
+	ID        string          `json:"job_id"`
+	UserID    string          `json:"user_id"`
+	ReportID  *string         `json:"report_id,omitempty"` // nil once the report was deleted
+	StartDate time.Time       `json:"start_date"`
+	EndDate   time.Time       `json:"end_date"`
+	Status    ReportStatus    `json:"status"`
+	UserName  string          `json:"-"`
+	Language  string          `json:"-"`
+	Format    ReportFormat    `json:"format"`
+	Sections  []ReportSection `json:"sections"`
+	Password  string          `json:"-"` // of an encrypted report, encrypted with the server key and cleared when the job finishes
+	Primary   bool            `json:"-"` // collect the data from the primary database
+	Attempts  int             `json:"attempts"`
+	ErrorText string          `json:"error_text,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	StartedAt *time.Time      `json:"started_at,omitempty"`
+}
+
+// ReportCadence is how often a scheduled report is generated
+type ReportCadence string
+
+const (
+	ReportCadenceWeekly  ReportCadence = "weekly"
+	ReportCadenceMonthly ReportCadence = "monthly"
+)
+
+// ReportSchedule has a user's report generated automatically every week on Day, an ISO
+// weekday from 1 (Monday) to 7 (Sunday), or every month on Day, a day from 1 to 28.
+// Each report covers the period ending the day before.
+type ReportSchedule struct {
+	UserID    string        `json:"user_id"`
+	Cadence   ReportCadence `json:"cadence"`
+	Day       int           `json:"day"`
+	Enabled   bool          `json:"enabled"`
+	Language  string        `json:"language"`
+	LastRunOn *time.Time    `json:"last_run_on,omitempty"` // scheduled day of the latest report
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// AlertSeverity represents the urgency of a health alert
+type AlertSeverity string
+
+const (
+	AlertSeverityCritical AlertSeverity = "critical"
+	AlertSeverityHigh     AlertSeverity = "high"
+)
+
+// Alert represents an emergency symptom alert raised during check-in triage
+type Alert struct {
+	ID             string        `json:"id"`
+	UserID         string        `json:"user_id"`
+	CheckInID      *string       `json:"check_in_id,omitempty"`
+	SessionID      *string       `json:"session_id,omitempty"`
+	Severity       AlertSeverity `json:"severity"`
+	Reason         string        `json:"reason"`
+	Source         string        `json:"source"` // rule or ai
+	AcknowledgedAt *time.Time    `json:"acknowledged_at,omitempty"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+// AnomalySeverity ranks detected health data anomalies
+type AnomalySeverity string
+
+const (
+	AnomalySeverityCritical AnomalySeverity = "critical"
+	AnomalySeverityHigh     AnomalySeverity = "high"
+	AnomalySeverityModerate AnomalySeverity = "moderate"
+)
+
+// Anomaly is a health reading outside a threshold or far from the user's baseline
+type Anomaly struct {
+	ID         string          `json:"id"`
+	UserID     string          `json:"user_id"`
+	Metric     string          `json:"metric"` // systolic, diastolic, pulse or pain_level
+	Value      float64         `json:"value"`
+	Baseline   *float64        `json:"baseline,omitempty"` // mean of the user's recent readings
+	Threshold  float64         `json:"threshold"`          // limit the value crossed
+	Severity   AnomalySeverity `json:"severity"`
+	Reason     string          `json:"reason"`
+	SourceType string          `json:"source_type"` // blood_pressure or check_in
+	SourceID   string          `json:"source_id"`
+	ObservedAt time.Time       `json:"observed_at"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// UserUsage holds the stored data accounted to a user
+type UserUsage struct {
+	UserID          string     `json:"user_id"`
+	CheckIns        int64      `json:"check_ins"`
+	AudioBytes      int64      `json:"audio_bytes"`
+	AttachmentBytes int64      `json:"attachment_bytes"`
+	ReportBytes     int64      `json:"report_bytes"`
+	ReconciledAt    *time.Time `json:"reconciled_at,omitempty"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// TotalBytes returns the combined size of the user's stored files
+func (u *UserUsage) TotalBytes() int64 {
+	return u.AudioBytes + u.AttachmentBytes + u.ReportBytes
+}
+
+// Role is a user's role within an organization, or system-wide for system admins
+type Role string
+
+const (
+	RolePatient     Role = "patient"
+	RoleCaregiver   Role = "caregiver"
+	RoleClinician   Role = "clinician"
+	RoleOrgAdmin    Role = "org_admin"
+	RoleSystemAdmin Role = "system_admin"
+)
+
+// Valid reports whether r is a known role
+func (r Role) Valid() bool {
+	switch r {
+	case RolePatient, RoleCaregiver, RoleClinician, RoleOrgAdmin, RoleSystemAdmin:
+		return true
+	}
+	return false
+}
+
+// Organization is a clinic or care provider whose members hold roles
+type Organization struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// DataResidency names the storage backend its patients' artifacts are written to,
+	// empty for the default storage account
+	DataResidency string    `json:"data_residency,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// StoredBlob is a blob together with the name of the storage backend it was written to.
+// Reads and deletions go to that backend even when the owner's data residency changed.
+type StoredBlob struct {
+	Backend string `json:"backend"`
+	Path    string `json:"path"`
+}
+
+// RoleAssignment grants a user a role in an organization. System-wide roles have an
+// empty OrganizationID.
+type RoleAssignment struct {
+	OrganizationID string    `json:"organization_id,omitempty"`
 	UserID         string    `json:"user_id"`
-	DateRangeStart time.Time `json:"date_range_start"`
-	DateRangeEnd   time.Time `json:"date_range_end"`
-	FilePath       string    `json:"file_path"`
-	GeneratedAt    time.Time `json:"generated_at"`
+	Role           Role      `json:"role"`
+	GrantedBy      string    `json:"granted_by,omitempty"`
 	CreatedAt      time.Time `json:"created_at"`
 }
+
+// OrganizationInvitation invites the holder of an emailed token to join an organization
+// with a role. Only the SHA-256 of the token is stored.
+type OrganizationInvitation struct {
+	ID             string     `json:"id"`
+	OrganizationID string     `json:"organization_id"`
+	Email          string     `json:"email"`
+	Role           Role       `json:"role"`
+	TokenHash      string     `json:"-"`
+	InvitedBy      string     `json:"invited_by"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty"`
+	AcceptedBy     *string    `json:"accepted_by,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// OrganizationIntegration delivers event messages of an organization's patients to an
+// external system such as the care team's mailbox or chat. Settings depend on Kind.
+type OrganizationIntegration struct {
+	ID              string            `json:"id"`
+	OrganizationID  string            `json:"organization_id"`
+	Name            string            `json:"name"`
+	Kind            string            `json:"kind"`
+	Settings        map[string]string `json:"settings"`
+	SubjectTemplate string            `json:"subject_template"`
+	BodyTemplate    string            `json:"body_template"`
+	Enabled         bool              `json:"enabled"`
+	CreatedBy       string            `json:"created_by,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// PanelAssignment puts a patient on a clinician's panel within an organization
+type PanelAssignment struct {
+	OrganizationID string    `json:"organization_id"`
+	ClinicianID    string    `json:"clinician_id"`
+	PatientID      string    `json:"patient_id"`
+	AssignedBy     string    `json:"assigned_by,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// PanelDigestSubscription opts a clinician in to a daily email digest of their
+// panel's findings
+type PanelDigestSubscription struct {
+	OrganizationID string     `json:"organization_id"`
+	ClinicianID    string     `json:"clinician_id"`
+	Email          string     `json:"email"`
+	LastSentAt     *time.Time `json:"last_sent_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// FindingKind is the kind of problem a panel finding reports
+type FindingKind string
+
+const (
+	// FindingKindAlert is an unacknowledged emergency symptom alert
+	FindingKindAlert FindingKind = "alert"
+	// FindingKindExtractionFailed is a check-in saved with only its raw transcript
+	FindingKindExtractionFailed FindingKind = "extraction_failed"
+	// FindingKindExtractionIssue is a check-in whose extracted answers contradict each other
+	FindingKindExtractionIssue FindingKind = "extraction_issue"
+	// FindingKindLowConfidence is a check-in extracted with low confidence
+	FindingKindLowConfidence FindingKind = "low_confidence"
+)
+
+// FindingSeverity ranks panel findings
+type FindingSeverity string
+
+const (
+	FindingSeverityCritical FindingSeverity = "critical"
+	FindingSeverityHigh     FindingSeverity = "high"
+	FindingSeverityMedium   FindingSeverity = "medium"
+	FindingSeverityLow      FindingSeverity = "low"
+)
+
+// PanelFinding is an alert or data-quality problem of a patient on a clinician's panel.
+// SourceID is the alert or check-in the finding was raised for.
+type PanelFinding struct {
+	Kind       FindingKind     `json:"kind"`
+	Severity   FindingSeverity `json:"severity"`
+	PatientID  string          `json:"patient_id"`
+	SourceID   string          `json:"source_id"`
+	Detail     string          `json:"detail,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// DeliveryStatus is the outcome of a delivery attempt
+type DeliveryStatus string
+
+const (
+	DeliveryStatusDelivered DeliveryStatus = "delivered"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+)
+
+// IntegrationDelivery records one attempt to deliver an event through an integration
+type IntegrationDelivery struct {
+	ID            string         `json:"id"`
+	IntegrationID string         `json:"integration_id"`
+	Event         string         `json:"event"`
+	UserID        string         `json:"user_id,omitempty"`
+	CheckInID     *string        `json:"check_in_id,omitempty"`
+	Status        DeliveryStatus `json:"status"`
+	ErrorMessage  string         `json:"error_message,omitempty"`
+	AttemptedAt   time.Time      `json:"attempted_at"`
+}
+
+// ConsentType is a kind of processing a user can consent to
+type ConsentType string
+
+const (
+	ConsentTypeDataProcessing  ConsentType = "data_processing"
+	ConsentTypeVoiceRecording  ConsentType = "voice_recording"
+	ConsentTypeResearchSharing ConsentType = "research_sharing"
+)
+
+// ConsentTypes lists every consent type in the order they are presented
+var ConsentTypes = []ConsentType{
+	ConsentTypeDataProcessing,
+	ConsentTypeVoiceRecording,
+	ConsentTypeResearchSharing,
+}
+
+// UserConsent is the current state of one of a user's consents. GrantedAt and RevokedAt
+// are the times it was last granted and last revoked.
+type UserConsent struct {
+	UserID      string      `json:"user_id"`
+	ConsentType ConsentType `json:"consent_type"`
+	Granted     bool        `json:"granted"`
+	GrantedAt   *time.Time  `json:"granted_at,omitempty"`
+	RevokedAt   *time.Time  `json:"revoked_at,omitempty"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// QuestionSetQuestion is a check-in question of a question set. Type is one of
+// open_ended, numeric and yes_no. A question with ShowIf conditions is only asked when
+// all of them hold for the answers given earlier in the session.
+type QuestionSetQuestion struct {
+	ID       string              `json:"id"`
+	Text     string              `json:"text"`
+	Type     string              `json:"type"`
+	Required bool                `json:"required"`
+	ShowIf   []QuestionCondition `json:"show_if,omitempty"`
+}
+
+// QuestionCondition is a condition on the answer to an earlier question of a question
+// set. It sets exactly one of Answer, the yes or no answer to a yes_no question, Min and
+// Max, bounds of the number answered to a numeric question, and Keywords, words of which
+// the answer must contain at least one.
+type QuestionCondition struct {
+	QuestionID string   `json:"question_id"`
+	Answer     string   `json:"answer,omitempty"`
+	Min        *float64 `json:"min,omitempty"`
+	Max        *float64 `json:"max,omitempty"`
+	Keywords   []string `json:"keywords,omitempty"`
+}
+
+// QuestionSet is a clinician-defined set of check-in questions asked in place of the
+// built-in set to the users it is assigned to
+type QuestionSet struct {
+	ID        string                `json:"id"`
+	Name      string                `json:"name"`
+	Language  string                `json:"language"`
+	CreatedBy string                `json:"created_by,omitempty"`
+	Questions []QuestionSetQuestion `json:"questions"`
+	CreatedAt time.Time             `json:"created_at"`
+}
+
+// TokenScope is a permission granted to a personal access token. Every scope is
+// read-only.
+type TokenScope string
+
+const (
+	TokenScopeHealthRead  TokenScope = "health:read"
+	TokenScopeExportRead  TokenScope = "export:read"
+	TokenScopeReportsRead TokenScope = "reports:read"
+)
+
+// TokenScopes lists every scope a personal access token can be granted
+var TokenScopes = []TokenScope{
+	TokenScopeHealthRead,
+	TokenScopeExportRead,
+	TokenScopeReportsRead,
+}
+
+// PersonalAccessTokenPrefix starts every personal access token, telling them apart from JWTs
+const PersonalAccessTokenPrefix = "pat_"
+
+// PersonalAccessToken lets a user read their own data without their password. Prefix
+// is the start of the token, shown to tell tokens apart; the token itself is only
+// returned when it is created.
+type PersonalAccessToken struct {
+	ID         string       `json:"id"`
+	UserID     string       `json:"user_id"`
+	Name       string       `json:"name"`
+	Prefix     string       `json:"prefix"`
+	TokenHash  string       `json:"-"`
+	Scopes     []TokenScope `json:"scopes"`
+	ExpiresAt  time.Time    `json:"expires_at"`
+	LastUsedAt *time.Time   `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time   `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// Webhook is an endpoint a user registered to be notified of events about their data,
+// such as checkin.completed. SecretToken signs the deliveries; it is only returned when
+// the webhook is created.
+type Webhook struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	TargetURL   string    `json:"target_url"`
+	SecretToken string    `json:"secret_token,omitempty"`
+	Events      []string  `json:"events"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TimelineEventType tells apart the sources of a user's timeline
+type TimelineEventType string
+
+const (
+	TimelineEventCheckIn          TimelineEventType = "check_in"
+	TimelineEventSessionStarted   TimelineEventType = "session_started"
+	TimelineEventSessionPaused    TimelineEventType = "session_paused"
+	TimelineEventSessionResumed   TimelineEventType = "session_resumed"
+	TimelineEventSessionCompleted TimelineEventType = "session_completed"
+	TimelineEventSessionExpired   TimelineEventType = "session_expired"
+	TimelineEventBloodPressure    TimelineEventType = "blood_pressure_reading"
+	TimelineEventMenstruation     TimelineEventType = "menstruation_cycle"
+	TimelineEventFitnessData      TimelineEventType = "fitness_data"
+	TimelineEventMedication       TimelineEventType = "medication"
+	TimelineEventMedicationLog    TimelineEventType = "medication_log"
+	TimelineEventAlert            TimelineEventType = "alert"
+	TimelineEventReport           TimelineEventType = "report"
+	TimelineEventGDPR             TimelineEventType = "gdpr"
+)
+
+// TimelineEvent is one entry of a user's timeline. ResourceID is the row the event was
+// read from; a session has an event per status transition. Summary is a short
+// description, cut to a fixed length with Truncated set when it held free text that
+// was longer.
+type TimelineEvent struct {
+	Type       TimelineEventType `json:"type"`
+	ResourceID string            `json:"resource_id"`
+	OccurredAt time.Time         `json:"occurred_at"`
+	Summary    string            `json:"summary,omitempty"`
+	Truncated  bool              `json:"truncated"`
+}
+
+// AuditArchive is a month of audit logs moved to blob storage. PurgedAt is set once the
+// archived rows were deleted from the database; RestoredUntil while a restored copy of
+// the month is queryable again.
+type AuditArchive struct {
+	Month         time.Time  `json:"month"`
+	ManifestBlob  string     `json:"manifest_blob"`
+	RowCount      int        `json:"row_count"`
+	ArchivedAt    time.Time  `json:"archived_at"`
+	PurgedAt      *time.Time `json:"purged_at,omitempty"`
+	RestoredUntil *time.Time `json:"restored_until,omitempty"`
+}
+
+// GDPRConsentType is a kind of GDPR consent recorded in a user's consent history
+type GDPRConsentType string
+
+const (
+	GDPRConsentProcessing        GDPRConsentType = "processing"
+	GDPRConsentMarketing         GDPRConsentType = "marketing"
+	GDPRConsentThirdPartySharing GDPRConsentType = "third_party_sharing"
+	GDPRConsentRightToErasure    GDPRConsentType = "right_to_erasure"
+)
+
+// GDPRConsent is the answer a user gave to a version of a GDPR consent text
+type GDPRConsent struct {
+	ID          string          `json:"id"`
+	UserID      string          `json:"user_id"`
+	ConsentType GDPRConsentType `json:"consent_type"`
+	Granted     bool            `json:"granted"`
+	Version     string          `json:"version"`
+	Timestamp   time.Time       `json:"timestamp"`
+	IPAddress   *string         `json:"ip_address,omitempty"`
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyBloodPressure(t *testing.T) {
+	tests := []struct {
+		systolic  int
+		diastolic int
+		want      BPCategory
+	}{
+		{119, 79, BPCategoryNormal},
+		{90, 60, BPCategoryNormal},
+		{120, 79, BPCategoryElevated},
+		{129, 79, BPCategoryElevated},
+		{130, 79, BPCategoryStage1},
+		{119, 80, BPCategoryStage1},
+		{139, 89, BPCategoryStage1},
+		{140, 89, BPCategoryStage2},
+		{139, 90, BPCategoryStage2},
+		{180, 120, BPCategoryStage2},
+		{181, 120, BPCategoryCrisis},
+		{180, 121, BPCategoryCrisis},
+		{125, 95, BPCategoryStage2},
+		// Reaching either bound of a category is enough
+		{130, 80, BPCategoryStage1},
+		{120, 80, BPCategoryStage1},
+		{129, 80, BPCategoryStage1},
+		{130, 90, BPCategoryStage2},
+		{140, 80, BPCategoryStage2},
+		{180, 90, BPCategoryStage2},
+		{181, 80, BPCategoryCrisis},
+		{120, 121, BPCategoryCrisis},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d/%d", tt.systolic, tt.diastolic), func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyBloodPressure(tt.systolic, tt.diastolic))
+			assert.Equal(t, tt.want, BloodPressureReading{Systolic: tt.systolic, Diastolic: tt.diastolic}.Category())
+		})
+	}
+}
+
+func TestSummarizeSleepAndWeight(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	summary := SummarizeSleepAndWeight([]FitnessDataPoint{
+		{Date: day(1), DataType: FitnessDataTypeSleepMinutes, Value: 300, Unit: "minutes"},
+		{Date: day(1), DataType: FitnessDataTypeSleepMinutes, Value: 120, Unit: "minutes"},
+		{Date: day(2), DataType: FitnessDataTypeSleep, Value: 480, Unit: "minutes"},
+		{Date: day(3), DataType: FitnessDataTypeWeight, Value: 71.5, Unit: "kg"},
+		{Date: day(1), DataType: FitnessDataTypeWeight, Value: 72.4, Unit: "kg"},
+		{Date: day(3), DataType: "steps", Value: 8000, Unit: "count"},
+	})
+
+	require.NotNil(t, summary.AverageSleepMinutes)
+	assert.InDelta(t, 450, *summary.AverageSleepMinutes, 0.001, "sessions of a night add up")
+	assert.Equal(t, 2, summary.SleepDays)
+	require.NotNil(t, summary.LatestWeightKg)
+	assert.Equal(t, 71.5, *summary.LatestWeightKg)
+	assert.Equal(t, day(3), *summary.LatestWeightDate)
+
+	empty := SummarizeSleepAndWeight([]FitnessDataPoint{{Date: day(1), DataType: "steps", Value: 100}})
+	assert.Nil(t, empty.AverageSleepMinutes)
+	assert.Nil(t, empty.LatestWeightKg)
+}